@@ -1,12 +1,20 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
+	"os/signal"
 	"sort"
+	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
+	"github.com/flipkart-incubator/dkv/internal/rebalance"
 	"github.com/flipkart-incubator/dkv/pkg/ctl"
 	"github.com/flipkart-incubator/dkv/pkg/serverpb"
 )
@@ -21,13 +29,60 @@ type cmd struct {
 }
 
 var cmds = []*cmd{
-	{"set", "<key> <value>", "Set a key value pair", (*cmd).set, "", false},
+	{"set", "<key> <value> [sync|async]", "Set a key value pair, optionally overriding the WAL sync policy", (*cmd).set, "", false},
 	{"del", "<key>", "Delete the given key", (*cmd).del, "", false},
+	{"merge", "<key> <operand>", "Merge the operand into the value of the given key", (*cmd).merge, "", false},
 	{"get", "<key>", "Get value for the given key", (*cmd).get, "", false},
+	{"getAsOf", "<changeNumber> <key>", "Get value for the given key as of the given change number", (*cmd).getAsOf, "", false},
+	{"exists", "<key>", "Check if the given key exists, without getting its value", (*cmd).exists, "", false},
+	{"lock", "<key> <owner> <lease> [exclusive|shared]", "Acquires or renews the advisory lock over the given key for <owner>, expiring after <lease> (at most 1h), exclusively unless shared", (*cmd).lock, "", false},
+	{"unlock", "<key> <owner>", "Releases the advisory lock or its share over the given key held by <owner>", (*cmd).unlock, "", false},
 	{"iter", "\"*\" | <prefix> [<startKey>]", "Iterate keys matching the <prefix>, starting with <startKey> or \"*\" for all keys", (*cmd).iter, "", false},
 	{"keys", "\"*\" | <prefix> [<startKey>]", "Get keys matching the <prefix>, starting with <startKey> or \"*\" for all keys", (*cmd).keys, "", false},
+	{"diff", "<targetAddr> [\"*\" | <prefix>]", "Lists the keys matching the <prefix> or \"*\" for all keys that differ on the DKV node at <targetAddr>, are missing on it or are extra on it", (*cmd).diff, "", false},
+	{"clone", "<targetAddr> [\"*\" | <prefix>] [follow]", "Copies the keys matching the <prefix> or \"*\" for all keys onto the DKV cluster at <targetAddr>, then keeps applying their subsequent changes until interrupted for cutover if \"follow\" is given", (*cmd).clone, "", false},
+	{"scan", "\"*\" | <prefix> <pageSize> [<continuationToken>]", "Get a page of keys matching the <prefix> or \"*\" for all keys, continuing from the previous page if <continuationToken> is given", (*cmd).scan, "", false},
+	{"aggregate", "\"*\" | <prefix> [<startKey> [<endKey>]]", "Computes the count, total value size, smallest and largest keys and the sum of the 8 byte integer values of the keys matching the <prefix> or \"*\" for all keys, from <startKey> upto but excluding <endKey>", (*cmd).aggregate, "", false},
 	{"backup", "<path>", "Backs up data to the given path", (*cmd).backup, "", false},
+	{"backupCluster", "<database> <path> [quiesce]", "Backs up all the shards of the given database to the given path, pausing the writes on the shards for the duration of the backup if \"quiesce\" is given", (*cmd).backupCluster, "", false},
+	{"restoreCluster", "<manifestPath>", "Restores all the shards of a database and their slaves from the given cluster backup manifest", (*cmd).restoreCluster, "", false},
+	{"planRebalance", "<database> <node>=<capacityBytes>[,...] [<tolerance>]", "Plans the moves of the replicas of the shards of the given database, including their followers and slaves, across the given nodes in proportion to their capacities, give or take the <tolerance> fraction (default 0.1) of their share, draining the unlisted nodes", (*cmd).planRebalance, "", false},
+	{"executeRebalance", "<stateFile> <database> <node>=<capacityBytes>[,...] [<tolerance>]", "Plans the rebalancing of the given database as planRebalance does and executes its moves in order, cloning every moved replica onto the DKV node provisioned for it at its destination, saving the progress in the given state file", (*cmd).executeRebalance, "", false},
+	{"pauseRebalance", "<stateFile>", "Pauses the rebalancing whose progress is saved in the given state file once its move in progress is executed", (*cmd).pauseRebalance, "", false},
+	{"resumeRebalance", "<stateFile>", "Resumes the paused or interrupted rebalancing whose progress is saved in the given state file, executing its remaining moves", (*cmd).resumeRebalance, "", false},
 	{"restore", "<path>", "Restores data from the given path", (*cmd).restore, "", false},
+	{"restoreUpto", "<path> <changeNumber>", "Restores data from the given path upto the given change number", (*cmd).restoreUpto, "", false},
+	{"publishSnapshot", "<namespace> <name>", "Publishes a read-only snapshot of the given namespace", (*cmd).publishSnapshot, "", false},
+	{"unpublishSnapshot", "<namespace@name>", "Releases the given published snapshot", (*cmd).unpublishSnapshot, "", false},
+	{"listSnapshots", "", "Lists all the published snapshots", (*cmd).listSnapshots, "", true},
+	{"snapGet", "<namespace@name> <key>", "Get value for the given key from the given published snapshot", (*cmd).snapGet, "", false},
+	{"compact", "\"*\" | <prefix>", "Compacts keys matching the <prefix> or \"*\" for all keys", (*cmd).compact, "", false},
+	{"ingestSST", "<files> [<ttlFiles>] [move]", "Bulk loads the comma separated SST files on the DKV node holding the keys without and with TTL, moving them if \"move\" is given", (*cmd).ingestSST, "", false},
+	{"export", "<sst|binary> <destination> [\"*\" | <startKey> [<endKey>]]", "Exports a snapshot of the keys from <startKey> upto <endKey> or \"*\" for all keys into the folder or http(s) URL <destination>", (*cmd).export, "", false},
+	{"estimateSize", "\"*\" | <startKey> [<endKey>]", "Estimates the number of keys and the size of the keys from <startKey> upto <endKey> or \"*\" for all keys", (*cmd).estimateSize, "", false},
+	{"readAmpReport", "[<windowSecs>]", "Samples the reads over <windowSecs> seconds (default 10) and reports their efficiency with tuning suggestions", (*cmd).readAmpReport, "", false},
+	{"keyHeatmap", "[<minutes>]", "Reports the read and write rates across key ranges over the last <minutes> (default all retained), hottest ranges first", (*cmd).keyHeatmap, "", false},
+	{"keyPolicies", "", "Lists the key policies published by the DKV node, to which the keys of their namespaces must conform", (*cmd).keyPolicies, "", true},
+	{"promote", "", "Promotes the DKV slave to master, or the DKV master taking over from a superseded master, issuing a new fencing epoch", (*cmd).promote, "", true},
+	{"promoteAndFenceMaster", "", "Promotes the DKV slave to master, issuing a new fencing epoch that is pushed onto its master, which then rejects the writes", (*cmd).promoteAndFenceMaster, "", true},
+	{"promoteWithoutFencing", "", "Promotes the DKV slave to master without issuing a new fencing epoch, only for when its master is known to be down", (*cmd).promoteWithoutFencing, "", true},
+	{"replicationLag", "", "Gets the lag of every slave of the DKV master", (*cmd).replicationLag, "", true},
+	{"divergentRanges", "", "Gets the key ranges of the DKV slave found to diverge from its master", (*cmd).divergentRanges, "", true},
+	{"setMemtableOptions", "<option>=<value> ...", "Changes the memtable and level 0 options of the DKV node until it restarts, options being one of writeBufferSize, maxWriteBufferNumber, level0FileNumCompactionTrigger, level0SlowdownWritesTrigger or level0StopWritesTrigger", (*cmd).setMemtableOptions, "", false},
+	{"engineStats", "", "Gets the internal statistics of the storage engine of the DKV node", (*cmd).engineStats, "", true},
+	{"placeHold", "<prefix> <requester> <reason>", "Places a legal hold over keys matching the <prefix>", (*cmd).placeHold, "", false},
+	{"liftHold", "<prefix> <requester> <reason>", "Lifts the legal hold over keys matching the <prefix>", (*cmd).liftHold, "", false},
+	{"listHolds", "", "Lists all the key prefixes under legal hold", (*cmd).listHolds, "", true},
+	{"beginRename", "<oldPrefix> <newPrefix>", "Begins renaming the keys matching the <oldPrefix> to the <newPrefix>, copying them in the background", (*cmd).beginRename, "", false},
+	{"completeRename", "<oldPrefix>", "Completes the rename of the copied keys matching the <oldPrefix>, deleting them in the background", (*cmd).completeRename, "", false},
+	{"listRenames", "", "Lists all the key prefix renames in progress", (*cmd).listRenames, "", true},
+	{"listQuarantined", "", "Lists the corrupt or rejected records set aside in the quarantine namespace of the DKV node", (*cmd).listQuarantined, "", true},
+	{"deleteSubject", "<subject> <requester> <reason>", "Irrecoverably erases all the data of the given subject", (*cmd).deleteSubject, "", false},
+	{"rotateKey", "<requester> <reason>", "Re-encrypts all the values of the node using the latest master key", (*cmd).rotateKey, "", false},
+	{"rotateTenantKey", "<tenant> <requester> <reason>", "Re-encrypts all the values of the given tenant using a new data key", (*cmd).rotateTenantKey, "", false},
+	{"deleteTenant", "<tenant> <requester> <reason>", "Irrecoverably erases all the data of the given tenant", (*cmd).deleteTenant, "", false},
+	{"mintToken", "<prefix> <ttl> <requester> <reason>", "Mints a temporary token for reading keys matching the <prefix>, valid for <ttl> (at most 1h)", (*cmd).mintToken, "", false},
+	{"config", "", "Gets the effective configuration of the DKV node", (*cmd).config, "", true},
 	{"addNode", "<nexusUrl>", "Add another master node to DKV cluster", (*cmd).addNode, "", false},
 	{"removeNode", "<nexusUrl>", "Remove a master node from DKV cluster", (*cmd).removeNode, "", false},
 	{"listNodes", "", "Lists the various DKV nodes that are part of the Nexus cluster", (*cmd).listNodes, "", true},
@@ -43,11 +98,35 @@ func (c *cmd) usage() {
 }
 
 func (c *cmd) set(client *ctl.DKVClient, args ...string) {
-	if len(args) != 2 {
+	if len(args) < 2 || len(args) > 3 {
 		c.usage()
 	} else {
-		if err := client.Put([]byte(args[0]), []byte(args[1])); err != nil {
+		walSync := serverpb.WALSync_DEFAULT_WAL_SYNC
+		if len(args) == 3 {
+			switch strings.ToLower(args[2]) {
+			case "sync":
+				walSync = serverpb.WALSync_SYNC_WAL
+			case "async":
+				walSync = serverpb.WALSync_ASYNC_WAL
+			default:
+				c.usage()
+				return
+			}
+		}
+		if res, err := client.PutWithWALSync([]byte(args[0]), []byte(args[1]), walSync); err != nil {
 			fmt.Printf("Unable to perform SET. Error: %v\n", err)
+		} else {
+			fmt.Printf("OK (Durability: %s, Replicas: %d)\n", res.Durability, res.NumReplicas)
+		}
+	}
+}
+
+func (c *cmd) merge(client *ctl.DKVClient, args ...string) {
+	if len(args) != 2 {
+		c.usage()
+	} else {
+		if err := client.Merge([]byte(args[0]), []byte(args[1])); err != nil {
+			fmt.Printf("Unable to perform MERGE. Error: %v\n", err)
 		} else {
 			fmt.Println("OK")
 		}
@@ -79,6 +158,77 @@ func (c *cmd) get(client *ctl.DKVClient, args ...string) {
 	}
 }
 
+func (c *cmd) getAsOf(client *ctl.DKVClient, args ...string) {
+	if len(args) != 2 {
+		c.usage()
+	} else {
+		if chngNum, err := strconv.ParseUint(args[0], 10, 64); err != nil {
+			fmt.Printf("Invalid change number: %s. Error: %v\n", args[0], err)
+		} else if res, err := client.GetAsOf(chngNum, []byte(args[1])); err != nil {
+			fmt.Printf("Unable to perform GET as of change number. Error: %v\n", err)
+		} else {
+			fmt.Println(string(res.Value))
+		}
+	}
+}
+
+func (c *cmd) exists(client *ctl.DKVClient, args ...string) {
+	if len(args) != 1 {
+		c.usage()
+	} else {
+		rc := serverpb.ReadConsistency_LINEARIZABLE
+		if exists, err := client.Exists(rc, []byte(args[0])); err != nil {
+			fmt.Printf("Unable to perform EXISTS. Error: %v\n", err)
+		} else {
+			fmt.Println(exists)
+		}
+	}
+}
+
+func (c *cmd) lock(client *ctl.DKVClient, args ...string) {
+	if len(args) < 3 || len(args) > 4 {
+		c.usage()
+	} else {
+		lease, err := time.ParseDuration(args[2])
+		if err != nil {
+			fmt.Printf("Invalid lease. Error: %v\n", err)
+			return
+		}
+		lock := client.Lock
+		if len(args) == 4 {
+			switch strings.ToLower(args[3]) {
+			case "exclusive":
+			case "shared":
+				lock = client.LockShared
+			default:
+				c.usage()
+				return
+			}
+		}
+		if res, err := lock([]byte(args[0]), args[1], lease); err != nil {
+			fmt.Printf("Unable to acquire lock. Error: %v\n", err)
+		} else if res.Acquired {
+			fmt.Printf("OK (Mode: %s, Expires at: %s)\n", res.Mode, time.Unix(0, res.ExpiresAt*int64(time.Millisecond)).Format(time.RFC3339))
+		} else {
+			fmt.Printf("Lock held (Mode: %s) by %s until %s\n", res.Mode, res.Owner, time.Unix(0, res.ExpiresAt*int64(time.Millisecond)).Format(time.RFC3339))
+		}
+	}
+}
+
+func (c *cmd) unlock(client *ctl.DKVClient, args ...string) {
+	if len(args) != 2 {
+		c.usage()
+	} else {
+		if released, err := client.Unlock([]byte(args[0]), args[1]); err != nil {
+			fmt.Printf("Unable to release lock. Error: %v\n", err)
+		} else if released {
+			fmt.Println("OK")
+		} else {
+			fmt.Println("Lock is not held by the given owner")
+		}
+	}
+}
+
 func (c *cmd) keys(client *ctl.DKVClient, args ...string) {
 	strtKy, kyPrfx := "", ""
 	switch {
@@ -125,6 +275,151 @@ func (c *cmd) iter(client *ctl.DKVClient, args ...string) {
 	}
 }
 
+func (c *cmd) diff(client *ctl.DKVClient, args ...string) {
+	if len(args) < 1 || len(args) > 2 {
+		c.usage()
+		return
+	}
+	kyPrfx := ""
+	if len(args) == 2 && strings.TrimSpace(args[1]) != "*" {
+		kyPrfx = args[1]
+	}
+	target, err := newDKVClient(args[0])
+	if err != nil {
+		fmt.Printf("Unable to create DKV client for %s. Error: %v\n", args[0], err)
+		return
+	}
+	defer target.Close()
+	counts := make(map[ctl.DiffKind]int)
+	err = ctl.Diff(client, target, []byte(kyPrfx), func(key []byte, kind ctl.DiffKind) {
+		counts[kind]++
+		fmt.Printf("%s %s\n", kind, key)
+	})
+	if err != nil {
+		fmt.Printf("Unable to perform DIFF. Error: %v\n", err)
+		return
+	}
+	fmt.Printf("Changed: %d, Missing: %d, Extra: %d\n", counts[ctl.KeyChanged], counts[ctl.KeyMissing], counts[ctl.KeyExtra])
+}
+
+func (c *cmd) clone(client *ctl.DKVClient, args ...string) {
+	if len(args) < 1 || len(args) > 3 {
+		c.usage()
+		return
+	}
+	kyPrfx, follow := "", false
+	if len(args) == 3 {
+		if args[2] != "follow" {
+			c.usage()
+			return
+		}
+		follow = true
+	}
+	if len(args) >= 2 && strings.TrimSpace(args[1]) != "*" {
+		kyPrfx = args[1]
+	}
+	target, err := newDKVClient(args[0])
+	if err != nil {
+		fmt.Printf("Unable to create DKV client for %s. Error: %v\n", args[0], err)
+		return
+	}
+	defer target.Close()
+
+	// Interrupting stops the copy, or the following of the changes upon
+	// cutover
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sig)
+	go func() {
+		select {
+		case <-sig:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	cloner := ctl.NewCloner(client, target, ctl.CloneOptions{KeyPrefix: []byte(kyPrfx)})
+	numKeys, chngNum, err := cloner.Copy(ctx)
+	if err != nil {
+		fmt.Printf("Unable to perform CLONE after copying %d keys. Error: %v\n", numKeys, err)
+		return
+	}
+	fmt.Printf("Copied %d keys as of change number %d\n", numKeys, chngNum)
+	if !follow {
+		return
+	}
+	fmt.Println("Following the changes, interrupt for cutover once the writes to the source are stopped...")
+	lastReport := time.Now()
+	applied, err := cloner.Follow(ctx, chngNum, time.Second, func(applied, latest uint64) {
+		if time.Since(lastReport) >= 10*time.Second {
+			fmt.Printf("Applied upto change number %d of %d\n", applied, latest)
+			lastReport = time.Now()
+		}
+	})
+	if err != nil {
+		fmt.Printf("Unable to follow the changes beyond change number %d. Error: %v\n", applied, err)
+		return
+	}
+	fmt.Printf("Cutover at change number %d of the source\n", applied)
+}
+
+func (c *cmd) scan(client *ctl.DKVClient, args ...string) {
+	if len(args) < 2 || len(args) > 3 {
+		c.usage()
+		return
+	}
+	kyPrfx, token := args[0], ""
+	if strings.TrimSpace(kyPrfx) == "*" {
+		kyPrfx = ""
+	}
+	pageSize, err := strconv.ParseUint(args[1], 10, 32)
+	if err != nil {
+		fmt.Printf("Invalid page size: %s. Error: %v\n", args[1], err)
+		return
+	}
+	if len(args) == 3 {
+		token = args[2]
+	}
+	kvs, nextToken, err := client.Scan([]byte(kyPrfx), nil, uint32(pageSize), token)
+	if err != nil {
+		fmt.Printf("Unable to perform scan. Error: %v\n", err)
+		return
+	}
+	for _, kv := range kvs {
+		fmt.Printf("%s => %s\n", kv.Key, kv.Value)
+	}
+	if nextToken != "" {
+		fmt.Printf("Continuation token: %s\n", nextToken)
+	}
+}
+
+func (c *cmd) aggregate(client *ctl.DKVClient, args ...string) {
+	if len(args) < 1 || len(args) > 3 {
+		c.usage()
+		return
+	}
+	kyPrfx := args[0]
+	if strings.TrimSpace(kyPrfx) == "*" {
+		kyPrfx = ""
+	}
+	var strtKy, endKy []byte
+	if len(args) > 1 {
+		strtKy = []byte(args[1])
+	}
+	if len(args) > 2 {
+		endKy = []byte(args[2])
+	}
+	res, err := client.Aggregate(context.Background(), []byte(kyPrfx), strtKy, endKy)
+	if err != nil {
+		fmt.Printf("Unable to perform aggregate. Error: %v\n", err)
+		return
+	}
+	fmt.Printf("Count: %d\nTotal value bytes: %d\nMin key: %s\nMax key: %s\nSum: %d (of %d values)\n",
+		res.Count, res.TotalValueBytes, res.MinKey, res.MaxKey, res.Sum, res.NumSummed)
+}
+
 func (c *cmd) backup(client *ctl.DKVClient, args ...string) {
 	if len(args) != 1 {
 		c.usage()
@@ -137,6 +432,251 @@ func (c *cmd) backup(client *ctl.DKVClient, args ...string) {
 	}
 }
 
+func (c *cmd) backupCluster(client *ctl.DKVClient, args ...string) {
+	quiesce := len(args) == 3 && args[2] == "quiesce"
+	if quiesce {
+		args = args[:2]
+	}
+	if len(args) != 2 {
+		c.usage()
+	} else {
+		var res *serverpb.BackupClusterResponse
+		var err error
+		if quiesce {
+			res, err = client.QuiescedBackupCluster(args[0], args[1], 0)
+		} else {
+			res, err = client.BackupCluster(args[0], args[1])
+		}
+		if err != nil {
+			fmt.Printf("Unable to perform cluster backup. Error: %v\n", err)
+		} else {
+			for _, shard := range res.Shards {
+				fmt.Printf("Shard: %s, Node: %s, Path: %s, Change Number: %d\n", shard.VBucket, shard.NodeAddress, shard.BackupPath, shard.ChangeNumber)
+			}
+			fmt.Printf("Successfully backed up, manifest at %s\n", res.ManifestPath)
+		}
+	}
+}
+
+func (c *cmd) restoreCluster(client *ctl.DKVClient, args ...string) {
+	if len(args) != 1 {
+		c.usage()
+	} else {
+		if res, err := client.RestoreCluster(args[0]); err != nil {
+			fmt.Printf("Unable to perform cluster restore. Error: %v\n", err)
+		} else {
+			for _, shard := range res.Shards {
+				fmt.Printf("Shard: %s, Node: %s, Change Number: %d, Followers: %v, Slaves: %v\n", shard.VBucket, shard.NodeAddress, shard.ChangeNumber, shard.FollowerAddresses, shard.SlaveAddresses)
+			}
+			fmt.Println("Successfully restored")
+		}
+	}
+}
+
+func (c *cmd) planRebalance(client *ctl.DKVClient, args ...string) {
+	if len(args) < 2 || len(args) > 3 {
+		c.usage()
+		return
+	}
+	plan := c.computeRebalance(client, args...)
+	if plan == nil {
+		return
+	}
+	printRebalance(plan)
+}
+
+func (c *cmd) executeRebalance(client *ctl.DKVClient, args ...string) {
+	if len(args) < 3 || len(args) > 4 {
+		c.usage()
+		return
+	}
+	plan := c.computeRebalance(client, args[1:]...)
+	if plan == nil {
+		return
+	}
+	printRebalance(plan)
+	if err := rebalance.Start(args[0], plan); err != nil {
+		fmt.Printf("Unable to save the rebalancing. Error: %v\n", err)
+		return
+	}
+	runRebalance(args[0])
+}
+
+func (c *cmd) pauseRebalance(client *ctl.DKVClient, args ...string) {
+	if len(args) != 1 {
+		c.usage()
+		return
+	}
+	if err := rebalance.Pause(args[0]); err != nil {
+		fmt.Printf("Unable to pause the rebalancing. Error: %v\n", err)
+		return
+	}
+	fmt.Println("Rebalancing pauses once its move in progress is executed")
+}
+
+func (c *cmd) resumeRebalance(client *ctl.DKVClient, args ...string) {
+	if len(args) != 1 {
+		c.usage()
+		return
+	}
+	if err := rebalance.Resume(args[0]); err != nil {
+		fmt.Printf("Unable to resume the rebalancing. Error: %v\n", err)
+		return
+	}
+	runRebalance(args[0])
+}
+
+// computeRebalance plans the rebalancing of the database across the nodes
+// given by the arguments, reporting any error and returning nil upon one.
+func (c *cmd) computeRebalance(client *ctl.DKVClient, args ...string) *rebalance.Plan {
+	var nodes []*rebalance.Node
+	for _, nodeCap := range strings.Split(args[1], ",") {
+		addrCap := strings.SplitN(strings.TrimSpace(nodeCap), "=", 2)
+		if len(addrCap) != 2 {
+			c.usage()
+			return nil
+		}
+		capacity, err := strconv.ParseUint(addrCap[1], 10, 64)
+		if err != nil {
+			fmt.Printf("Invalid capacity of node %s. Error: %v\n", addrCap[0], err)
+			return nil
+		}
+		nodes = append(nodes, &rebalance.Node{Address: addrCap[0], CapacityBytes: capacity})
+	}
+	tolerance := 0.1
+	if len(args) == 3 {
+		var err error
+		if tolerance, err = strconv.ParseFloat(args[2], 64); err != nil {
+			fmt.Printf("Invalid tolerance. Error: %v\n", err)
+			return nil
+		}
+	}
+
+	shards, err := shardSizes(client, args[0])
+	if err != nil {
+		fmt.Printf("Unable to retrieve the shard sizes. Error: %v\n", err)
+		return nil
+	}
+	plan, err := rebalance.Compute(shards, nodes, tolerance)
+	if err != nil {
+		fmt.Printf("Unable to plan the rebalancing. Error: %v\n", err)
+		return nil
+	}
+	return plan
+}
+
+func printRebalance(plan *rebalance.Plan) {
+	for i, move := range plan.Moves {
+		fmt.Printf("%d. Move shard %s of %d bytes from %s to %s\n", i+1, move.Shard, move.SizeBytes, move.From, move.To)
+	}
+	for _, load := range plan.Loads {
+		fmt.Printf("Node: %s, Capacity: %d bytes, Load: %d bytes, Excess: %d bytes\n", load.Address, load.CapacityBytes, load.LoadBytes, load.ExcessBytes)
+	}
+	if plan.Balanced() {
+		fmt.Printf("Balanced after %d moves of %d bytes\n", len(plan.Moves), plan.MovedBytes)
+	} else {
+		fmt.Printf("Unbalanced after %d moves of %d bytes, more capacity is needed for the excess\n", len(plan.Moves), plan.MovedBytes)
+	}
+}
+
+// runRebalance executes the remaining moves of the rebalancing saved in
+// the given state file until done, paused or interrupted.
+func runRebalance(stateFile string) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sig)
+	go func() {
+		select {
+		case <-sig:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	exec, err := rebalance.Execute(ctx, stateFile, func(ctx context.Context, move *rebalance.Move) error {
+		fmt.Printf("Moving shard %s of %d bytes from %s to %s...\n", move.Shard, move.SizeBytes, move.From, move.To)
+		return migrateReplica(ctx, move)
+	})
+	switch {
+	case err == rebalance.ErrPaused:
+		fmt.Printf("Paused after %d of %d moves, resume using resumeRebalance\n", exec.Done, len(exec.Plan.Moves))
+	case err != nil && exec != nil:
+		fmt.Printf("Stopped after %d of %d moves, resume using resumeRebalance. Error: %v\n", exec.Done, len(exec.Plan.Moves), err)
+	case err != nil:
+		fmt.Printf("Unable to execute the rebalancing. Error: %v\n", err)
+	default:
+		fmt.Printf("Successfully executed all the %d moves\n", exec.Done)
+	}
+}
+
+// migrateReplica migrates the replica of a shard as given by the move by
+// cloning it onto the DKV node at the destination of the move, returning
+// once the node has caught up with the changes of the replica.
+func migrateReplica(ctx context.Context, move *rebalance.Move) error {
+	source, err := newDKVClient(move.From)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+	target, err := newDKVClient(move.To)
+	if err != nil {
+		return err
+	}
+	defer target.Close()
+
+	cloner := ctl.NewCloner(source, target, ctl.CloneOptions{})
+	_, chngNum, err := cloner.Copy(ctx)
+	if err != nil {
+		return err
+	}
+	followCtx, caughtUp := context.WithCancel(ctx)
+	defer caughtUp()
+	if _, err = cloner.Follow(followCtx, chngNum, time.Second, func(applied, latest uint64) {
+		if applied >= latest {
+			caughtUp()
+		}
+	}); err != nil {
+		return err
+	}
+	// Following returns upon interruption as well
+	return ctx.Err()
+}
+
+// shardSizes retrieves the size of every replica of every shard of the
+// given database, including the followers and the slaves, as estimated by
+// the replica itself. The replicas are found through the discovery service.
+func shardSizes(client *ctl.DKVClient, database string) ([]*rebalance.Shard, error) {
+	regions, err := client.GetClusterInfo("", database, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(regions) == 0 {
+		return nil, fmt.Errorf("no shards found for database %s", database)
+	}
+	var shards []*rebalance.Shard
+	for _, region := range regions {
+		replicaCli, err := newDKVClient(region.GetNodeAddress())
+		if err != nil {
+			return nil, err
+		}
+		res, err := replicaCli.EstimateSize(nil, nil)
+		replicaCli.Close()
+		if err != nil {
+			return nil, fmt.Errorf("unable to estimate the size of the %s replica of shard %s on %s: %v", region.GetStatus(), region.GetVBucket(), region.GetNodeAddress(), err)
+		}
+		shards = append(shards, &rebalance.Shard{Name: region.GetVBucket(), Node: region.GetNodeAddress(), SizeBytes: res.SizeBytes})
+	}
+	sort.Slice(shards, func(i, j int) bool {
+		if shards[i].Name != shards[j].Name {
+			return shards[i].Name < shards[j].Name
+		}
+		return shards[i].Node < shards[j].Node
+	})
+	return shards, nil
+}
+
 func (c *cmd) restore(client *ctl.DKVClient, args ...string) {
 	if len(args) != 1 {
 		c.usage()
@@ -149,6 +689,509 @@ func (c *cmd) restore(client *ctl.DKVClient, args ...string) {
 	}
 }
 
+func (c *cmd) restoreUpto(client *ctl.DKVClient, args ...string) {
+	if len(args) != 2 {
+		c.usage()
+	} else {
+		if chngNum, err := strconv.ParseUint(args[1], 10, 64); err != nil {
+			fmt.Printf("Invalid change number: %s. Error: %v\n", args[1], err)
+		} else if err := client.PointInTimeRestore(args[0], chngNum); err != nil {
+			fmt.Printf("Unable to perform point in time restore. Error: %v\n", err)
+		} else {
+			fmt.Println("Successfully restored")
+		}
+	}
+}
+
+func (c *cmd) publishSnapshot(client *ctl.DKVClient, args ...string) {
+	if len(args) != 2 {
+		c.usage()
+	} else {
+		if err := client.PublishSnapshot(args[0], args[1]); err != nil {
+			fmt.Printf("Unable to publish snapshot. Error: %v\n", err)
+		} else {
+			fmt.Println("OK")
+		}
+	}
+}
+
+func (c *cmd) unpublishSnapshot(client *ctl.DKVClient, args ...string) {
+	if len(args) != 1 {
+		c.usage()
+	} else {
+		if err := client.UnpublishSnapshot(args[0]); err != nil {
+			fmt.Printf("Unable to unpublish snapshot. Error: %v\n", err)
+		} else {
+			fmt.Println("OK")
+		}
+	}
+}
+
+func (c *cmd) listSnapshots(client *ctl.DKVClient, args ...string) {
+	if snaps, err := client.ListSnapshots(); err != nil {
+		fmt.Printf("Unable to list snapshots. Error: %v\n", err)
+	} else {
+		for _, snap := range snaps {
+			fmt.Println(snap)
+		}
+	}
+}
+
+func (c *cmd) snapGet(client *ctl.DKVClient, args ...string) {
+	if len(args) != 2 {
+		c.usage()
+	} else {
+		if res, err := client.GetFromSnapshot(args[0], []byte(args[1])); err != nil {
+			fmt.Printf("Unable to perform GET. Error: %v\n", err)
+		} else {
+			fmt.Println(string(res.Value))
+		}
+	}
+}
+
+func (c *cmd) compact(client *ctl.DKVClient, args ...string) {
+	if len(args) != 1 {
+		c.usage()
+	} else {
+		var keyPrefix []byte
+		if strKeyPrefix := strings.TrimSpace(args[0]); strKeyPrefix != "*" {
+			keyPrefix = []byte(strKeyPrefix)
+		}
+		if err := client.CompactRange(keyPrefix); err != nil {
+			fmt.Printf("Unable to compact. Error: %v\n", err)
+		} else {
+			fmt.Println("OK")
+		}
+	}
+}
+
+func (c *cmd) estimateSize(client *ctl.DKVClient, args ...string) {
+	if len(args) < 1 || len(args) > 2 {
+		c.usage()
+	} else {
+		var startKey, endKey []byte
+		if strStartKey := strings.TrimSpace(args[0]); strStartKey != "*" {
+			startKey = []byte(strStartKey)
+		}
+		if len(args) == 2 {
+			endKey = []byte(args[1])
+		}
+		if res, err := client.EstimateSize(startKey, endKey); err != nil {
+			fmt.Printf("Unable to estimate size. Error: %v\n", err)
+		} else {
+			fmt.Printf("Approximate number of keys: %d, size: %d bytes\n", res.NumKeys, res.SizeBytes)
+		}
+	}
+}
+
+func (c *cmd) export(client *ctl.DKVClient, args ...string) {
+	if len(args) < 2 || len(args) > 4 {
+		c.usage()
+		return
+	}
+	format, ok := serverpb.ExportFormat_value[strings.ToUpper(args[0])]
+	if !ok {
+		c.usage()
+		return
+	}
+	var startKey, endKey []byte
+	if len(args) > 2 {
+		if strStartKey := strings.TrimSpace(args[2]); strStartKey != "*" {
+			startKey = []byte(strStartKey)
+		}
+	}
+	if len(args) == 4 {
+		endKey = []byte(args[3])
+	}
+	if res, err := client.Export(startKey, endKey, serverpb.ExportFormat(format), args[1]); err != nil {
+		fmt.Printf("Unable to export. Error: %v\n", err)
+	} else {
+		fmt.Printf("Exported %d keys into %s\n", res.NumKeys, strings.Join(res.Files, ", "))
+	}
+}
+
+func (c *cmd) keyPolicies(client *ctl.DKVClient, args ...string) {
+	policies, err := client.GetKeyPolicies()
+	if err != nil {
+		fmt.Printf("Unable to get key policies. Error: %v\n", err)
+		return
+	}
+	if len(policies) == 0 {
+		fmt.Println("No key policies published")
+	}
+	for _, policy := range policies {
+		segs := make([]string, len(policy.Segments))
+		for i, seg := range policy.Segments {
+			segs[i] = fmt.Sprintf("%s:%s", seg.Name, strings.ToLower(strings.TrimSuffix(seg.Type.String(), "_SEGMENT")))
+		}
+		fmt.Printf("Namespace: %s, Version: %d, Segments: %s\n", policy.Namespace, policy.Version, strings.Join(segs, ","))
+	}
+}
+
+func (c *cmd) promote(client *ctl.DKVClient, args ...string) {
+	promote(client.PromoteToMaster(false))
+}
+
+func (c *cmd) promoteAndFenceMaster(client *ctl.DKVClient, args ...string) {
+	promote(client.PromoteAndFenceMaster())
+}
+
+func (c *cmd) promoteWithoutFencing(client *ctl.DKVClient, args ...string) {
+	promote(client.PromoteToMaster(true))
+}
+
+func promote(res *serverpb.PromoteResponse, err error) {
+	if err != nil {
+		fmt.Printf("Unable to promote. Error: %v\n", err)
+	} else {
+		fmt.Printf("Promoted with fencing epoch: %d, at change number: %d\n", res.FencingEpoch, res.ChangeNumber)
+	}
+}
+
+func (c *cmd) replicationLag(client *ctl.DKVClient, args ...string) {
+	if res, err := client.GetReplicationLag(); err != nil {
+		fmt.Printf("Unable to get replication lag. Error: %v\n", err)
+	} else {
+		fmt.Printf("Master change number: %d\n", res.MasterChangeNumber)
+		for _, replica := range res.Replicas {
+			fmt.Printf("%s: applied change number: %d, lag: %d changes, %dms\n", replica.ReplicaID,
+				replica.AppliedChangeNumber, replica.LagChanges, replica.LagMillis)
+		}
+	}
+}
+
+func (c *cmd) divergentRanges(client *ctl.DKVClient, args ...string) {
+	if res, err := client.GetDivergentRanges(); err != nil {
+		fmt.Printf("Unable to get divergent ranges. Error: %v\n", err)
+	} else {
+		if res.LastPassTimestamp == 0 {
+			fmt.Println("Verification of the entire keyspace is yet to complete")
+		} else {
+			fmt.Printf("Last verified the entire keyspace at: %s\n", time.Unix(0, res.LastPassTimestamp*int64(time.Millisecond)))
+		}
+		for _, rng := range res.Ranges {
+			fmt.Printf("[%q, %q): %d keys on master, %d keys on slave\n", rng.StartKey, rng.EndKey, rng.MasterNumKeys, rng.SlaveNumKeys)
+		}
+	}
+}
+
+func (c *cmd) ingestSST(client *ctl.DKVClient, args ...string) {
+	moveFiles := len(args) > 1 && args[len(args)-1] == "move"
+	if moveFiles {
+		args = args[:len(args)-1]
+	}
+	if len(args) < 1 || len(args) > 2 {
+		c.usage()
+	} else {
+		var files, ttlFiles []string
+		if strFiles := strings.TrimSpace(args[0]); strFiles != "" {
+			files = strings.Split(strFiles, ",")
+		}
+		if len(args) == 2 {
+			ttlFiles = strings.Split(strings.TrimSpace(args[1]), ",")
+		}
+		if err := client.IngestSST(files, ttlFiles, moveFiles); err != nil {
+			fmt.Printf("Unable to ingest SST files. Error: %v\n", err)
+		} else {
+			fmt.Println("OK")
+		}
+	}
+}
+
+func (c *cmd) setMemtableOptions(client *ctl.DKVClient, args ...string) {
+	if len(args) == 0 {
+		c.usage()
+		return
+	}
+	mtOpts := &serverpb.MemtableOptions{}
+	for _, arg := range args {
+		kv := strings.SplitN(arg, "=", 2)
+		if len(kv) != 2 {
+			fmt.Printf("Invalid memtable option: %s. Must be of the form <option>=<value>\n", arg)
+			return
+		}
+		val, err := strconv.ParseUint(kv[1], 10, 32)
+		if kv[0] == "writeBufferSize" {
+			val, err = strconv.ParseUint(kv[1], 10, 64)
+		}
+		if err != nil {
+			fmt.Printf("Invalid value of memtable option: %s. Error: %v\n", kv[0], err)
+			return
+		}
+		switch kv[0] {
+		case "writeBufferSize":
+			mtOpts.WriteBufferSize = val
+		case "maxWriteBufferNumber":
+			mtOpts.MaxWriteBufferNumber = uint32(val)
+		case "level0FileNumCompactionTrigger":
+			mtOpts.Level0FileNumCompactionTrigger = uint32(val)
+		case "level0SlowdownWritesTrigger":
+			mtOpts.Level0SlowdownWritesTrigger = uint32(val)
+		case "level0StopWritesTrigger":
+			mtOpts.Level0StopWritesTrigger = uint32(val)
+		default:
+			fmt.Printf("Unknown memtable option: %s\n", kv[0])
+			return
+		}
+	}
+	if err := client.SetMemtableOptions(mtOpts); err != nil {
+		fmt.Printf("Unable to set memtable options. Error: %v\n", err)
+	} else {
+		fmt.Println("OK")
+	}
+}
+
+func (c *cmd) engineStats(client *ctl.DKVClient, args ...string) {
+	if res, err := client.GetEngineStats(); err != nil {
+		fmt.Printf("Unable to get engine stats. Error: %v\n", err)
+	} else {
+		fmt.Printf("Memtable size: %d bytes\n", res.MemtableSizeBytes)
+		for level, numFiles := range res.NumFilesPerLevel {
+			fmt.Printf("Level %d files: %d\n", level, numFiles)
+		}
+		fmt.Printf("Block cache hit rate: %.2f%%\n", res.BlockCacheHitRate*100)
+		fmt.Printf("Pending compaction: %d bytes\n", res.PendingCompactionBytes)
+		fmt.Printf("Oldest change number: %d\n", res.OldestChangeNumber)
+		fmt.Printf("Change history: %d bytes\n", res.ChangeHistoryBytes)
+		for _, ncs := range res.NamespaceCacheStats {
+			fmt.Printf("Namespace %q block cache hit rate: %.2f%% (%d sampled reads)\n", ncs.Namespace, ncs.BlockCacheHitRate*100, ncs.NumSampledReads)
+		}
+	}
+}
+
+func (c *cmd) readAmpReport(client *ctl.DKVClient, args ...string) {
+	if len(args) > 1 {
+		c.usage()
+	} else {
+		var windowSecs uint64
+		if len(args) == 1 {
+			var err error
+			if windowSecs, err = strconv.ParseUint(args[0], 10, 32); err != nil {
+				fmt.Printf("Invalid sampling window. Error: %v\n", err)
+				return
+			}
+		}
+		if res, err := client.ReportReadAmplification(time.Duration(windowSecs) * time.Second); err != nil {
+			fmt.Printf("Unable to report read amplification. Error: %v\n", err)
+		} else {
+			fmt.Printf("Sampled reads: %d, keys: %d\n", res.NumSampledReads, res.NumSampledKeys)
+			fmt.Printf("Block reads per key: %.2f\n", res.BlockReadsPerKey)
+			fmt.Printf("Block cache hit rate: %.2f%%\n", 100*res.BlockCacheHitRate)
+			fmt.Printf("Memtable hit rate: %.2f%%\n", 100*res.MemtableHitRate)
+			fmt.Printf("Bloom filter usefulness: %.2f%%\n", 100*res.BloomUsefulness)
+			fmt.Println("Suggestions:")
+			for _, suggestion := range res.Suggestions {
+				fmt.Printf("  - %s\n", suggestion)
+			}
+		}
+	}
+}
+
+func (c *cmd) keyHeatmap(client *ctl.DKVClient, args ...string) {
+	if len(args) > 1 {
+		c.usage()
+	} else {
+		var minutes uint64
+		if len(args) == 1 {
+			var err error
+			if minutes, err = strconv.ParseUint(args[0], 10, 32); err != nil {
+				fmt.Printf("Invalid number of minutes. Error: %v\n", err)
+				return
+			}
+		}
+		res, err := client.GetKeyHeatmap(uint32(minutes))
+		if err != nil {
+			fmt.Printf("Unable to get key heatmap. Error: %v\n", err)
+			return
+		}
+		// Rates are averaged over the minutes retrieved, for ranking the
+		// ranges, whose boundaries may differ across the minutes
+		var order []string
+		readRates, writeRates := make(map[string]float64), make(map[string]float64)
+		for _, khi := range res.Intervals {
+			for i, bucket := range khi.Buckets {
+				rng := fmt.Sprintf("[%x, %x)", bucket.StartKey, bucket.EndKey)
+				if _, present := readRates[rng]; !present {
+					order = append(order, rng)
+				}
+				readRates[rng] += khi.ReadRates[i] / float64(len(res.Intervals))
+				writeRates[rng] += khi.WriteRates[i] / float64(len(res.Intervals))
+			}
+		}
+		sort.SliceStable(order, func(i, j int) bool {
+			return readRates[order[i]]+writeRates[order[i]] > readRates[order[j]]+writeRates[order[j]]
+		})
+		fmt.Printf("Minutes: %d, sampling one in %d calls\n", len(res.Intervals), res.SampleEvery)
+		for _, rng := range order {
+			if readRates[rng]+writeRates[rng] == 0 {
+				break
+			}
+			fmt.Printf("%s: %.2f reads/s, %.2f writes/s\n", rng, readRates[rng], writeRates[rng])
+		}
+	}
+}
+
+func (c *cmd) placeHold(client *ctl.DKVClient, args ...string) {
+	if len(args) < 3 {
+		c.usage()
+	} else {
+		if err := client.PlaceLegalHold([]byte(args[0]), args[1], strings.Join(args[2:], " ")); err != nil {
+			fmt.Printf("Unable to place legal hold. Error: %v\n", err)
+		} else {
+			fmt.Println("OK")
+		}
+	}
+}
+
+func (c *cmd) liftHold(client *ctl.DKVClient, args ...string) {
+	if len(args) < 3 {
+		c.usage()
+	} else {
+		if err := client.LiftLegalHold([]byte(args[0]), args[1], strings.Join(args[2:], " ")); err != nil {
+			fmt.Printf("Unable to lift legal hold. Error: %v\n", err)
+		} else {
+			fmt.Println("OK")
+		}
+	}
+}
+
+func (c *cmd) listHolds(client *ctl.DKVClient, args ...string) {
+	if prefixes, err := client.ListLegalHolds(); err != nil {
+		fmt.Printf("Unable to list legal holds. Error: %v\n", err)
+	} else {
+		for _, prefix := range prefixes {
+			fmt.Println(string(prefix))
+		}
+	}
+}
+
+func (c *cmd) beginRename(client *ctl.DKVClient, args ...string) {
+	if len(args) != 2 {
+		c.usage()
+	} else {
+		if err := client.BeginPrefixRename([]byte(args[0]), []byte(args[1])); err != nil {
+			fmt.Printf("Unable to begin prefix rename. Error: %v\n", err)
+		} else {
+			fmt.Println("OK")
+		}
+	}
+}
+
+func (c *cmd) completeRename(client *ctl.DKVClient, args ...string) {
+	if len(args) != 1 {
+		c.usage()
+	} else {
+		if err := client.CompletePrefixRename([]byte(args[0])); err != nil {
+			fmt.Printf("Unable to complete prefix rename. Error: %v\n", err)
+		} else {
+			fmt.Println("OK")
+		}
+	}
+}
+
+func (c *cmd) listRenames(client *ctl.DKVClient, args ...string) {
+	if renames, err := client.ListPrefixRenames(); err != nil {
+		fmt.Printf("Unable to list prefix renames. Error: %v\n", err)
+	} else {
+		for _, rename := range renames {
+			fmt.Printf("%s -> %s %s (%d keys)\n", rename.OldPrefix, rename.NewPrefix, rename.Phase, rename.NumKeys)
+		}
+	}
+}
+
+func (c *cmd) listQuarantined(client *ctl.DKVClient, args ...string) {
+	if recs, err := client.ListQuarantinedRecords(); err != nil {
+		fmt.Printf("Unable to list quarantined records. Error: %v\n", err)
+	} else {
+		for _, rec := range recs {
+			at := time.Unix(rec.QuarantinedAt, 0).Format(time.RFC3339)
+			if rec.Source == "replication" {
+				fmt.Printf("%s [%s] change number: %d, reason: %s\n", at, rec.Source, rec.ChangeNumber, rec.Reason)
+			} else {
+				fmt.Printf("%s [%s] key: %q, reason: %s\n", at, rec.Source, rec.Key, rec.Reason)
+			}
+		}
+	}
+}
+
+func (c *cmd) deleteSubject(client *ctl.DKVClient, args ...string) {
+	if len(args) < 3 {
+		c.usage()
+	} else {
+		if err := client.DeleteSubject([]byte(args[0]), args[1], strings.Join(args[2:], " ")); err != nil {
+			fmt.Printf("Unable to delete subject. Error: %v\n", err)
+		} else {
+			fmt.Println("OK")
+		}
+	}
+}
+
+func (c *cmd) rotateKey(client *ctl.DKVClient, args ...string) {
+	if len(args) < 2 {
+		c.usage()
+	} else {
+		if res, err := client.RotateEncryptionKey(args[0], strings.Join(args[1:], " ")); err != nil {
+			fmt.Printf("Unable to rotate encryption key. Error: %v\n", err)
+		} else {
+			fmt.Printf("Active key: %s, Values scanned: %d, Values re-encrypted: %d\n", res.ActiveKeyID, res.NumScanned, res.NumReencrypted)
+		}
+	}
+}
+
+func (c *cmd) rotateTenantKey(client *ctl.DKVClient, args ...string) {
+	if len(args) < 3 {
+		c.usage()
+	} else {
+		if res, err := client.RotateTenantKey([]byte(args[0]), args[1], strings.Join(args[2:], " ")); err != nil {
+			fmt.Printf("Unable to rotate tenant key. Error: %v\n", err)
+		} else {
+			fmt.Printf("Active key: %s, Values scanned: %d, Values re-encrypted: %d\n", res.ActiveKeyID, res.NumScanned, res.NumReencrypted)
+		}
+	}
+}
+
+func (c *cmd) deleteTenant(client *ctl.DKVClient, args ...string) {
+	if len(args) < 3 {
+		c.usage()
+	} else {
+		if err := client.DeleteTenant([]byte(args[0]), args[1], strings.Join(args[2:], " ")); err != nil {
+			fmt.Printf("Unable to delete tenant. Error: %v\n", err)
+		} else {
+			fmt.Println("OK")
+		}
+	}
+}
+
+func (c *cmd) mintToken(client *ctl.DKVClient, args ...string) {
+	if len(args) < 4 {
+		c.usage()
+		return
+	}
+	ttl, err := time.ParseDuration(args[1])
+	if err != nil {
+		fmt.Printf("Invalid TTL: %s. Error: %v\n", args[1], err)
+		return
+	}
+	if token, expireTS, err := client.MintAccessToken([]byte(args[0]), ttl, args[2], strings.Join(args[3:], " ")); err != nil {
+		fmt.Printf("Unable to mint access token. Error: %v\n", err)
+	} else {
+		fmt.Printf("Token: %s\nExpires at: %s\n", token, time.Unix(int64(expireTS), 0))
+	}
+}
+
+func (c *cmd) config(client *ctl.DKVClient, args ...string) {
+	if effCfg, err := client.GetEffectiveConfig(); err != nil {
+		fmt.Printf("Unable to get effective configuration. Error: %v\n", err)
+	} else {
+		var buf bytes.Buffer
+		if err = json.Indent(&buf, []byte(effCfg), "", "  "); err != nil {
+			fmt.Println(effCfg)
+		} else {
+			fmt.Println(buf.String())
+		}
+	}
+}
+
 func (c *cmd) addNode(client *ctl.DKVClient, args ...string) {
 	if len(args) != 1 {
 		c.usage()
@@ -220,11 +1263,12 @@ func (c *cmd) getStatus(client *ctl.DKVClient, args ...string) {
 	}
 }
 
-var dkvAddr, dkvAuthority string
+var dkvAddr, dkvAuthority, dkvAccessToken string
 
 func init() {
 	flag.StringVar(&dkvAddr, "dkvAddr", "127.0.0.1:8080", "<host>:<port> - DKV server address")
 	flag.StringVar(&dkvAuthority, "authority", "", "Override :authority pseudo header for routing purposes. Useful while accessing DKV via service mesh.")
+	flag.StringVar(&dkvAccessToken, "accessToken", "", "Temporary access token restricting this session to its scope")
 	for _, c := range cmds {
 		if c.argDesc == "" {
 			flag.BoolVar(&c.emptyValue, c.name, c.emptyValue, c.cmdDesc)
@@ -237,7 +1281,7 @@ func init() {
 
 func usage() {
 	fmt.Printf("Usage of %s:\n", os.Args[0])
-	for _, flagName := range []string{"dkvAddr", "authority"} {
+	for _, flagName := range []string{"dkvAddr", "authority", "accessToken"} {
 		dkvFlag := flag.Lookup(flagName)
 		fmt.Printf("  -%s %s (default: %s)\n", dkvFlag.Name, dkvFlag.Usage, dkvFlag.DefValue)
 	}
@@ -260,6 +1304,15 @@ func isFlagPassed(name string) bool {
 	return found
 }
 
+// newDKVClient connects to the DKV service at the given address using
+// the authority and the access token given for this session.
+func newDKVClient(addr string) (*ctl.DKVClient, error) {
+	if dkvAccessToken = strings.TrimSpace(dkvAccessToken); dkvAccessToken != "" {
+		return ctl.NewInSecureDKVClient(addr, dkvAuthority, ctl.WithAccessToken(dkvAccessToken))
+	}
+	return ctl.NewInSecureDKVClient(addr, dkvAuthority)
+}
+
 func main() {
 	if len(os.Args) < 2 {
 		usage()
@@ -272,7 +1325,7 @@ func main() {
 		fmt.Printf(" (:authority = %s)", dkvAuthority)
 	}
 	fmt.Printf("...")
-	client, err := ctl.NewInSecureDKVClient(dkvAddr, dkvAuthority)
+	client, err := newDKVClient(dkvAddr)
 	if err != nil {
 		fmt.Printf("\nUnable to create DKV client. Error: %v\n", err)
 		return
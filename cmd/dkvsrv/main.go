@@ -12,15 +12,23 @@ import (
 	"strings"
 	"syscall"
 
+	"github.com/flipkart-incubator/dkv/internal/auth"
+	"github.com/flipkart-incubator/dkv/internal/compression"
 	"github.com/flipkart-incubator/dkv/internal/discovery"
+	"github.com/flipkart-incubator/dkv/internal/heatmap"
+	"github.com/flipkart-incubator/dkv/internal/ipfilter"
+	"github.com/flipkart-incubator/dkv/internal/keypolicy"
 	"github.com/flipkart-incubator/dkv/internal/master"
 	"github.com/flipkart-incubator/dkv/internal/opts"
+	"github.com/flipkart-incubator/dkv/internal/pinning"
 	"github.com/flipkart-incubator/dkv/internal/slave"
 	"github.com/flipkart-incubator/dkv/internal/stats"
 	"github.com/flipkart-incubator/dkv/internal/storage"
 	"github.com/flipkart-incubator/dkv/internal/storage/badger"
 	"github.com/flipkart-incubator/dkv/internal/storage/rocksdb"
+	"github.com/flipkart-incubator/dkv/internal/streamlimit"
 	"github.com/flipkart-incubator/dkv/internal/sync"
+	"github.com/flipkart-incubator/dkv/internal/watermark"
 	"github.com/flipkart-incubator/dkv/pkg/health"
 	"github.com/flipkart-incubator/dkv/pkg/serverpb"
 	nexus_api "github.com/flipkart-incubator/nexus/pkg/api"
@@ -45,6 +53,9 @@ const (
 	slaveRole     dkvSrvrRole = "slave"
 	discoveryRole dkvSrvrRole = "discovery"
 
+	// repairCmd is the subcommand that repairs the store offline
+	repairCmd = "repair"
+
 	defBlockCacheSize     = 3 << 30
 	discoveryServerConfig = "serverConfig"
 	discoveryClientConfig = "clientConfig"
@@ -66,6 +77,10 @@ var (
 	dkvLogger      *zap.Logger
 	pprofEnable    bool
 
+	// Point in time restore vars
+	restoreFrom        string
+	restoreUptoChngNum uint64
+
 	// Other vars
 	statsCli stats.Client
 )
@@ -79,6 +94,8 @@ func initializeFlags() {
 	flag.StringVarP(&cfgFile, "config", "c", "", "config file (default is /etc/default/dkvsrv.yaml)")
 	flag.BoolVarP(&verboseLogging, "verbose", "v", false, fmt.Sprintf("Enable verbose logging.\nBy default, only warnings and errors are logged. (default %v)", verboseLogging))
 	flag.BoolVarP(&pprofEnable, "pprof", "p", false, "Enable pprof profiling")
+	flag.StringVar(&restoreFrom, "restore-from", "", "Backup path to restore from. When given, the server rebuilds the store and exits without serving requests")
+	flag.Uint64Var(&restoreUptoChngNum, "restore-upto", 0, "Change number upto which the store is rebuilt when restoring from a backup")
 }
 
 func main() {
@@ -90,6 +107,7 @@ func main() {
 
 	setupDKVLogger()
 	setupAccessLogger()
+	resolveDBFolder()
 	setFlagsForNexusDirs()
 	setupStats()
 
@@ -100,9 +118,32 @@ func main() {
 		}()
 	}
 
+	if flag.Arg(0) == repairCmd {
+		repairStore()
+		return
+	}
+
 	kvs, cp, ca, br := newKVStore()
-	grpcSrvr, lstnr := newGrpcServerListener()
+	if restoreFrom != "" {
+		restoreToChangeNumber(kvs, br, cp)
+		return
+	}
+	tokenIssuer, err := auth.NewTokenIssuer([]byte(config.AccessTokenSecret))
+	if err != nil {
+		log.Panicf("Failed to create access token issuer %v.", err)
+	}
+	ipFilters := newIPFilters()
+	var keyHeatmap *heatmap.Heatmap
+	if config.KeyHeatmapBuckets > 0 {
+		keyHeatmap = heatmap.New(int(config.KeyHeatmapBuckets), int(config.KeyHeatmapMinutes), int(config.KeyHeatmapSampleEvery))
+	}
+	grpcSrvr, lstnr := newGrpcServerListener(tokenIssuer, ipFilters, newWatermarkSource(cp, ca), keyHeatmap)
 	defer grpcSrvr.GracefulStop()
+	if ipFilters != nil {
+		stopIPFilterWatch := make(chan struct{})
+		defer close(stopIPFilterWatch)
+		go ipFilters.Watch(config.IPFilterReloadInterval, stopIPFilterWatch)
+	}
 	srvrRole := toDKVSrvrRole(config.DbRole)
 	//srvrRole.printFlags()
 
@@ -121,10 +162,28 @@ func main() {
 		NexusClusterUrl: nil,
 	}
 
+	effCfg, err := config.EffectiveConfig()
+	if err != nil {
+		log.Panicf("Failed to render the effective configuration %v.", err)
+	}
+	fencingEpoch, err := storage.OpenFencingEpoch(path.Join(config.DbFolder, "fencing_epoch.json"))
+	if err != nil {
+		log.Panicf("Failed to load the fencing epoch %v.", err)
+	}
 	serveropts := &opts.ServerOpts{
 		Logger:                    dkvLogger,
 		HealthCheckTickerInterval: opts.DefaultHealthCheckTickterInterval, //to be exposed later via app.conf
 		StatsCli:                  statsCli,
+		TokenIssuer:               tokenIssuer,
+		EffectiveConfig:           string(effCfg),
+		ScanCursorTTL:             config.ScanCursorTTL,
+		FencingEpoch:              fencingEpoch,
+		PinnedReads:               config.PinnedReads,
+		SemiSyncReplica:           config.SemiSyncReplica,
+		SemiSyncTimeout:           config.SemiSyncTimeout,
+		ActiveActivePeer:          config.ActiveActivePeer,
+		KeyHeatmap:                keyHeatmap,
+		KeyPolicies:               newKeyPolicies(),
 	}
 
 	var discoveryClient discovery.Client
@@ -145,6 +204,8 @@ func main() {
 		defer dkvSvc.Close()
 		serverpb.RegisterDKVServer(grpcSrvr, dkvSvc)
 		serverpb.RegisterDKVBackupRestoreServer(grpcSrvr, dkvSvc)
+		serverpb.RegisterDKVSnapshotServer(grpcSrvr, dkvSvc)
+		serverpb.RegisterDKVAdminServer(grpcSrvr, dkvSvc)
 		health.RegisterHealthServer(grpcSrvr, dkvSvc)
 	case masterRole, discoveryRole:
 		if cp == nil {
@@ -152,6 +213,9 @@ func main() {
 		}
 		var dkvSvc master.DKVService
 		if haveFlagsWithPrefix("nexus") {
+			if config.ActiveActivePeer != "" {
+				log.Panicf("active-active-peer is not available on masters of a cluster")
+			}
 			dkvSvc = master.NewDistributedService(kvs, cp, br, newDKVReplicator(kvs), regionInfo, serveropts)
 			serverpb.RegisterDKVClusterServer(grpcSrvr, dkvSvc.(master.DKVClusterService))
 		} else {
@@ -161,6 +225,8 @@ func main() {
 		defer dkvSvc.Close()
 		serverpb.RegisterDKVServer(grpcSrvr, dkvSvc)
 		serverpb.RegisterDKVReplicationServer(grpcSrvr, dkvSvc)
+		serverpb.RegisterDKVSnapshotServer(grpcSrvr, dkvSvc)
+		serverpb.RegisterDKVAdminServer(grpcSrvr, dkvSvc)
 		health.RegisterHealthServer(grpcSrvr, dkvSvc)
 
 		// Discovery servers can be only configured if node started as master.
@@ -176,6 +242,20 @@ func main() {
 	case slaveRole:
 		// TODO - construct replConfig from region level config described in LLD
 		maxNumChanges := uint32(10000)
+		if config.ReplBatchSize > 0 {
+			maxNumChanges = config.ReplBatchSize
+		}
+		// Checkpoints of the master carry all the keys, hence the slaves
+		// replicating only some of the prefixes replicate all the changes
+		checkpointStagingDir := path.Join(config.DbFolder, "bootstrap")
+		if len(config.ReplKeyPrefixes) > 0 {
+			checkpointStagingDir = ""
+		}
+		// WAN replication compresses the changes using zstd by default
+		replCompression := config.ReplCompression
+		if config.ReplWAN && replCompression == "" {
+			replCompression = compression.Zstd
+		}
 		replConfig := &slave.ReplicationConfig{
 			MaxNumChngs:           maxNumChanges,
 			ReplPollInterval:      config.ReplPollInterval,
@@ -183,10 +263,34 @@ func main() {
 			MaxActiveReplElapsed:  uint64(config.ReplPollInterval.Seconds()) * 10,
 			DisableAutoMasterDisc: config.DisableAutoMasterDisc,
 			ReplMasterAddr:        config.ReplicationMasterAddr,
+			ConsistencyTagFile:    path.Join(config.DbFolder, "consistency_tag.json"),
+			MaxMinChangeWait:      2 * config.ReplPollInterval,
+			ScrubCompareInterval:  config.ScrubReplicaCompareInterval,
+			CheckpointStagingDir:  checkpointStagingDir,
+			SemiSync:              config.SemiSync,
+			ReplicaID:             config.ReplicaID,
+			PartialReplication:    len(config.ReplKeyPrefixes) > 0,
+			Compression:           replCompression,
+			WANReplication:        config.ReplWAN,
+			WANBatchBytes:         config.ReplWANBatchBytes,
+			WANBatchDelay:         config.ReplWANBatchDelay,
+			WANMaxInFlightBytes:   config.ReplWANMaxInFlightBytes,
+			ApplyBatchSize:        config.ReplApplyBatchSize,
+			ApplyPrefetch:         config.ReplApplyPrefetch,
+			MaxApplyRate:          config.ReplMaxApplyRate,
+			ReadFallback:          config.ReplReadFallback,
+			VerifyInterval:        config.ReplVerifyInterval,
+			VerifyKeysPerRange:    config.ReplVerifyKeysPerRange,
+			RecordCompression:     config.ReplRecordCompression,
+			DeltaEncodeKeys:       config.ReplDeltaEncodeKeys,
 		}
 		dkvSvc, _ := slave.NewService(kvs, ca, regionInfo, replConfig, discoveryClient, serveropts)
 		defer dkvSvc.Close()
 		serverpb.RegisterDKVServer(grpcSrvr, dkvSvc)
+		serverpb.RegisterDKVBackupRestoreServer(grpcSrvr, dkvSvc)
+		serverpb.RegisterDKVAdminServer(grpcSrvr, dkvSvc)
+		// Slaves serve their changes to the downstream slaves of cascading replication
+		serverpb.RegisterDKVReplicationServer(grpcSrvr, dkvSvc)
 		health.RegisterHealthServer(grpcSrvr, dkvSvc)
 		discoveryClient.RegisterRegion(dkvSvc)
 	default:
@@ -268,15 +372,87 @@ func setupDKVLogger() {
 	}
 }
 
-func newGrpcServerListener() (*grpc.Server, net.Listener) {
-	grpcSrvr := grpc.NewServer(
-		grpc.StreamInterceptor(grpc_zap.StreamServerInterceptor(accessLogger)),
-		grpc.UnaryInterceptor(grpc_zap.UnaryServerInterceptor(accessLogger)),
-	)
+func newGrpcServerListener(tokenIssuer *auth.TokenIssuer, ipFilters *ipfilter.Filters, wmSrc watermark.Source, keyHeatmap *heatmap.Heatmap) (*grpc.Server, net.Listener) {
+	streamInterceptors := []grpc.StreamServerInterceptor{grpc_zap.StreamServerInterceptor(accessLogger)}
+	unaryInterceptors := []grpc.UnaryServerInterceptor{grpc_zap.UnaryServerInterceptor(accessLogger)}
+	if ipFilters != nil {
+		streamInterceptors = append(streamInterceptors, ipFilters.StreamServerInterceptor())
+		unaryInterceptors = append(unaryInterceptors, ipFilters.UnaryServerInterceptor())
+	}
+	streamInterceptors = append(streamInterceptors, auth.StreamServerInterceptor(tokenIssuer))
+	if config.MaxConcurrentStreams > 0 {
+		streamInterceptors = append(streamInterceptors, streamlimit.New(config.MaxConcurrentStreams, statsCli).StreamServerInterceptor())
+	}
+	unaryInterceptors = append(unaryInterceptors, auth.UnaryServerInterceptor(tokenIssuer))
+	if keyHeatmap != nil {
+		unaryInterceptors = append(unaryInterceptors, keyHeatmap.UnaryServerInterceptor())
+	}
+	if wmSrc != nil {
+		streamInterceptors = append(streamInterceptors, watermark.StreamServerInterceptor(wmSrc))
+		unaryInterceptors = append(unaryInterceptors, watermark.UnaryServerInterceptor(wmSrc))
+	}
+	var srvrOpts []grpc.ServerOption
+	if config.PinnedReads {
+		// Pinned values are released once the responses are marshalled,
+		// or discarded by the failed calls
+		unaryInterceptors = append([]grpc.UnaryServerInterceptor{pinning.UnaryServerInterceptor()}, unaryInterceptors...)
+		srvrOpts = append(srvrOpts, grpc.ForceServerCodec(pinning.Codec()))
+	}
+	if config.StreamWindowBytes > 0 {
+		// Fixed windows also disable their dynamic growth
+		srvrOpts = append(srvrOpts, grpc.InitialWindowSize(int32(config.StreamWindowBytes)))
+	}
+	grpcSrvr := grpc.NewServer(append(srvrOpts,
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+	)...)
 	reflection.Register(grpcSrvr)
 	return grpcSrvr, newListener()
 }
 
+// newWatermarkSource picks the change number exposed in the trailers of
+// the responses, being the last applied change on slaves and the last
+// committed change otherwise. Returns nil if the trailers are disabled
+// or the storage engine does not track change numbers.
+func newWatermarkSource(cp storage.ChangePropagator, ca storage.ChangeApplier) watermark.Source {
+	if !config.ChangeNumberTrailers {
+		return nil
+	}
+	if toDKVSrvrRole(config.DbRole) == slaveRole {
+		if ca != nil {
+			return ca.GetLatestAppliedChangeNumber
+		}
+		return nil
+	}
+	if cp != nil {
+		return cp.GetLatestCommittedChangeNumber
+	}
+	return nil
+}
+
+func newIPFilters() *ipfilter.Filters {
+	if config.IPFilterFile == "" {
+		return nil
+	}
+	ipFilters, err := ipfilter.Load(config.IPFilterFile, dkvLogger)
+	if err != nil {
+		dkvLogger.Panic("Unable to load IP filter rules", zap.Error(err))
+	}
+	return ipFilters
+}
+
+func newKeyPolicies() []*serverpb.KeyPolicy {
+	policies := make([]*serverpb.KeyPolicy, len(config.KeyPolicies))
+	for i, keyPolicy := range config.KeyPolicies {
+		policy, err := keypolicy.Parse(keyPolicy)
+		if err != nil {
+			dkvLogger.Panic("Unable to parse key policy", zap.Error(err))
+		}
+		policies[i] = policy
+	}
+	return policies
+}
+
 func newListener() (lis net.Listener) {
 	var err error
 	if lis, err = net.Listen("tcp", config.ListenAddr); err != nil {
@@ -307,6 +483,16 @@ func toDKVSrvrRole(role string) dkvSrvrRole {
 	return dkvSrvrRole(strings.TrimSpace(strings.ToLower(role)))
 }
 
+// resolveDBFolder switches the DB folder to the sub-directory of the
+// configured instance, when the DB folder hosts multiple instances.
+func resolveDBFolder() {
+	instanceFolder, err := storage.OpenInstanceFolder(config.DbFolder, config.DbInstance, config.DbEngine)
+	if err != nil {
+		log.Panicf("Unable to open the DB folder. Error: %v", err)
+	}
+	config.DbFolder = instanceFolder
+}
+
 func setFlagsForNexusDirs() {
 
 	nexusLogDirFlag, nexusSnapDirFlag = flag.Lookup("nexus-log-dir"), flag.Lookup("nexus-snap-dir")
@@ -319,10 +505,30 @@ func setFlagsForNexusDirs() {
 }
 
 func setupStats() {
-	if config.StatsdAddr != "" {
-		statsCli = stats.NewStatsDClient(config.StatsdAddr, "dkv.")
-	} else {
-		statsCli = stats.NewNoOpClient()
+	backend, err := stats.ParseBackend(config.MetricsBackend)
+	if err != nil {
+		log.Panicf("Invalid metrics backend. Error: %v", err)
+	}
+	switch backend {
+	case stats.BackendPrometheus:
+		var metricsHandler http.Handler
+		statsCli, metricsHandler = stats.NewPrometheusClient("dkv.")
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", metricsHandler)
+		go func() {
+			log.Printf("[INFO] Exposing Prometheus metrics on %s\n", config.PrometheusListenAddr)
+			log.Println(http.ListenAndServe(config.PrometheusListenAddr, mux))
+		}()
+	case stats.BackendOTLP:
+		if statsCli, err = stats.NewOTLPClient(config.OTLPMetricsEndpoint, "dkv.", config.OTLPMetricsPushInterval); err != nil {
+			log.Panicf("Unable to create OTLP metrics client. Error: %v", err)
+		}
+	default:
+		if config.StatsdAddr != "" {
+			statsCli = stats.NewStatsDClient(config.StatsdAddr, "dkv.")
+		} else {
+			statsCli = stats.NewNoOpClient()
+		}
 	}
 }
 
@@ -344,13 +550,101 @@ func newKVStore() (storage.KVStore, storage.ChangePropagator, storage.ChangeAppl
 
 	switch config.DbEngine {
 	case "rocksdb":
-		rocksDb, err := rocksdb.OpenDB(dataDir,
+		tuningOpts := rocksDBTuningOptions()
+		if err := tuningOpts.Validate(); err != nil {
+			dkvLogger.Panic("Invalid RocksDB tuning options", zap.Error(err))
+		}
+		cacheOpts := &rocksdb.CacheOptions{
+			BlockCacheSize:            config.BlockCacheSize,
+			CacheIndexAndFilterBlocks: config.RocksDBCacheIndexAndFilterBlocks,
+			PinL0FilterAndIndexBlocks: config.RocksDBPinL0FilterAndIndexBlocks,
+		}
+		if err := cacheOpts.Validate(); err != nil {
+			dkvLogger.Panic("Invalid RocksDB cache options", zap.Error(err))
+		}
+		walSyncPolicy, err := rocksdb.ParseWALSyncPolicy(config.WALSyncPolicy)
+		if err != nil {
+			dkvLogger.Panic("Invalid WAL sync policy", zap.Error(err))
+		}
+		compactionPolicy, err := rocksdb.ParseCompactionPolicy(config.CompactionPolicy)
+		if err != nil {
+			dkvLogger.Panic("Invalid compaction policy", zap.Error(err))
+		}
+		rdbOpts := []rocksdb.DBOption{
 			rocksdb.WithSSTDir(sstDir),
-			rocksdb.WithSyncWrites(),
-			rocksdb.WithCacheSize(config.BlockCacheSize),
+			rocksdb.WithWALSyncPolicy(walSyncPolicy, config.WALSyncInterval),
+			rocksdb.WithCompactionPolicy(compactionPolicy, config.CompactionPeriodicInterval),
+			rocksdb.WithCacheOptions(cacheOpts),
 			rocksdb.WithRocksDBConfig(config.DbEngineIni),
+			rocksdb.WithTuningOptions(tuningOpts),
+			rocksdb.WithSubjectEncryption(config.SubjectPrefixes, config.SubjectDelimiter),
 			rocksdb.WithLogger(dkvLogger),
-			rocksdb.WithStats(statsCli))
+			rocksdb.WithStats(statsCli),
+		}
+		for _, mergeOp := range config.MergeOperators {
+			parts := strings.SplitN(mergeOp, "=", 2)
+			rdbOpts = append(rdbOpts, rocksdb.WithMergeOperatorName(parts[0], parts[1]))
+		}
+		for _, dropPrefix := range config.CompactionDropPrefixes {
+			filter, err := rocksdb.ParseDropPrefixAfter(dropPrefix)
+			if err != nil {
+				dkvLogger.Panic("Invalid compaction drop prefix", zap.Error(err))
+			}
+			rdbOpts = append(rdbOpts, rocksdb.WithCompactionFilter(filter))
+		}
+		if config.ChangeLogEnabled {
+			changeLogDir := path.Join(config.DbFolder, "changelog")
+			rdbOpts = append(rdbOpts, rocksdb.WithChangeLog(changeLogDir, config.ChangeLogSegmentSize, config.ChangeLogMaxSegments))
+			rdbOpts = append(rdbOpts, rocksdb.WithWALLessPrefixes(config.WALLessPrefixes))
+		}
+		if config.WALRetentionTTL > 0 || config.WALRetentionSizeMB > 0 {
+			rdbOpts = append(rdbOpts, rocksdb.WithWALRetention(config.WALRetentionTTL, config.WALRetentionSizeMB))
+		}
+		if config.VersionRetention > 0 {
+			rdbOpts = append(rdbOpts, rocksdb.WithVersionRetention(config.VersionRetention))
+		}
+		if config.EncryptionKeyFile != "" {
+			rdbOpts = append(rdbOpts, rocksdb.WithEncryptionAtRest(config.EncryptionKeyFile),
+				rocksdb.WithTenantEncryption(config.TenantPrefixes))
+		}
+		if config.AutoTuneEnabled {
+			autoTuneBounds := &rocksdb.AutoTuneBounds{
+				MinWriteBufferSize:       config.AutoTuneMinWriteBufferSize,
+				MaxWriteBufferSize:       config.AutoTuneMaxWriteBufferSize,
+				MinBloomFilterBitsPerKey: config.AutoTuneMinBloomBits,
+				MaxBloomFilterBitsPerKey: config.AutoTuneMaxBloomBits,
+				TuneCompression:          config.AutoTuneCompression,
+			}
+			if err := autoTuneBounds.Validate(); err != nil {
+				dkvLogger.Panic("Invalid RocksDB auto-tune bounds", zap.Error(err))
+			}
+			rdbOpts = append(rdbOpts, rocksdb.WithAutoTuning(config.AutoTuneInterval, autoTuneBounds))
+		}
+		if config.RocksDBRateLimitBytesPerSec > 0 {
+			rdbOpts = append(rdbOpts, rocksdb.WithRateLimit(config.RocksDBRateLimitBytesPerSec))
+		}
+		if config.ScrubKeysPerSec > 0 {
+			rdbOpts = append(rdbOpts, rocksdb.WithScrubbing(config.ScrubKeysPerSec, config.ScrubQuarantine))
+		}
+		if config.MultiGetBatchSize > 0 && config.MultiGetParallelism > 0 {
+			rdbOpts = append(rdbOpts, rocksdb.WithMultiGetParallelism(config.MultiGetBatchSize, config.MultiGetParallelism))
+		}
+		if config.GroupCommitWindow > 0 {
+			rdbOpts = append(rdbOpts, rocksdb.WithGroupCommit(config.GroupCommitWindow, config.GroupCommitMaxWrites))
+		}
+		if len(config.ReplKeyPrefixes) > 0 {
+			rdbOpts = append(rdbOpts, rocksdb.WithReplicatedKeyPrefixes(config.ReplKeyPrefixes))
+		}
+		if len(config.CacheStatsNamespaces) > 0 {
+			rdbOpts = append(rdbOpts, rocksdb.WithNamespaceCacheStats(config.CacheStatsNamespaces, config.CacheStatsSampleEvery))
+		}
+		if config.ReplApplyPipelineWorkers > 1 {
+			rdbOpts = append(rdbOpts, rocksdb.WithApplyPipeline(int(config.ReplApplyPipelineWorkers)))
+		}
+		if config.ActiveActivePeer != "" {
+			rdbOpts = append(rdbOpts, rocksdb.WithOriginID(config.ActiveActiveOriginID))
+		}
+		rocksDb, err := rocksdb.OpenDB(dataDir, rdbOpts...)
 		if err != nil {
 			dkvLogger.Panic("RocksDB engine init failed", zap.Error(err))
 		}
@@ -360,17 +654,24 @@ func newKVStore() (storage.KVStore, storage.ChangePropagator, storage.ChangeAppl
 		var err error
 		bdbOpts := []badger.DBOption{
 			badger.WithSSTDir(sstDir),
-			badger.WithSyncWrites(),
 			badger.WithCacheSize(config.BlockCacheSize),
 			badger.WithBadgerConfig(config.DbEngineIni),
 			badger.WithLogger(dkvLogger),
 			badger.WithStats(statsCli),
 		}
+		if strings.ToLower(config.WALSyncPolicy) == "async" {
+			bdbOpts = append(bdbOpts, badger.WithoutSyncWrites())
+		} else {
+			bdbOpts = append(bdbOpts, badger.WithSyncWrites())
+		}
 		if config.DisklessMode {
 			bdbOpts = append(bdbOpts, badger.WithInMemory())
 		} else {
 			bdbOpts = append(bdbOpts, badger.WithDBDir(dataDir))
 		}
+		if config.MultiGetBatchSize > 0 && config.MultiGetParallelism > 0 {
+			bdbOpts = append(bdbOpts, badger.WithMultiGetParallelism(config.MultiGetBatchSize, config.MultiGetParallelism))
+		}
 		badgerDb, err = badger.OpenDB(bdbOpts...)
 		if err != nil {
 			dkvLogger.Panic("Badger engine init failed", zap.Error(err))
@@ -382,6 +683,69 @@ func newKVStore() (storage.KVStore, storage.ChangePropagator, storage.ChangeAppl
 	}
 }
 
+func rocksDBTuningOptions() *rocksdb.TuningOptions {
+	return &rocksdb.TuningOptions{
+		WriteBufferSize:            config.RocksDBWriteBufferSize,
+		MaxBackgroundJobs:          config.RocksDBMaxBackgroundJobs,
+		MaxOpenFiles:               config.RocksDBMaxOpenFiles,
+		NumLevels:                  config.RocksDBNumLevels,
+		TargetFileSizeBase:         config.RocksDBTargetFileSizeBase,
+		MaxBytesForLevelBase:       config.RocksDBMaxBytesForLevelBase,
+		MaxBytesForLevelMultiplier: config.RocksDBMaxBytesForLevelMultiplier,
+		BloomFilterBitsPerKey:      config.RocksDBBloomFilterBitsPerKey,
+		CompressionPerLevel:        config.RocksDBCompressionPerLevel,
+		BottommostCompression:      config.RocksDBBottommostCompression,
+		ZSTDMaxDictBytes:           config.RocksDBZSTDMaxDictBytes,
+		ZSTDMaxTrainBytes:          config.RocksDBZSTDMaxTrainBytes,
+	}
+}
+
+func restoreToChangeNumber(kvs storage.KVStore, br storage.Backupable, cp storage.ChangePropagator) {
+	slg := dkvLogger.Sugar()
+	defer slg.Sync()
+
+	slg.Infof("Restoring store from %s upto change number %d", restoreFrom, restoreUptoChngNum)
+	st, _, _, _, err := storage.RestoreToChangeNumber(kvs, br, cp, restoreFrom, restoreUptoChngNum)
+	if err != nil {
+		slg.Panicf("Unable to perform point in time restore. Error: %v", err)
+	}
+	st.Close()
+	log.Printf("[INFO] Successfully restored store from %s upto change number %d\n", restoreFrom, restoreUptoChngNum)
+}
+
+func repairStore() {
+	slg := dkvLogger.Sugar()
+	defer slg.Sync()
+
+	if config.DbEngine != "rocksdb" {
+		slg.Panicf("Repair is only supported for the rocksdb engine, not %s", config.DbEngine)
+	}
+	dataDir := path.Join(config.DbFolder, "data")
+	rdbOpts := []rocksdb.DBOption{
+		rocksdb.WithRocksDBConfig(config.DbEngineIni),
+		rocksdb.WithLogger(dkvLogger),
+	}
+	for _, mergeOp := range config.MergeOperators {
+		parts := strings.SplitN(mergeOp, "=", 2)
+		rdbOpts = append(rdbOpts, rocksdb.WithMergeOperatorName(parts[0], parts[1]))
+	}
+	report, err := rocksdb.Repair(dataDir, rdbOpts...)
+	if err != nil {
+		slg.Panicf("Unable to repair store at %s. Error: %v", dataDir, err)
+	}
+	log.Printf("[INFO] Repaired store at %s and verified %d SST files\n", dataDir, report.NumFilesVerified)
+	for _, lostFile := range report.LostFiles {
+		log.Printf("[WARN] Unrecoverable file set aside: %s\n", lostFile)
+	}
+	for _, cr := range report.CorruptRanges {
+		log.Printf("[ERROR] Unrecoverable range from %q upto %q of column family %s in one of %s. Error: %v\n",
+			cr.StartKey, cr.EndKey, cr.ColumnFamily, strings.Join(cr.Files, ", "), cr.Err)
+	}
+	if len(report.CorruptRanges) > 0 {
+		os.Exit(1)
+	}
+}
+
 func mkdirNexusDirs() {
 	if err := os.MkdirAll(nexusLogDirFlag.Value.String(), 0777); err != nil {
 		log.Panicf("Unable to create Nexus logDir. Error: %v", err)
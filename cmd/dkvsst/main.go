@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/flipkart-incubator/dkv/internal/storage/rocksdb"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+)
+
+// jsonPair is a key value pair of a JSON lines dump
+type jsonPair struct {
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+	ExpireTS uint64 `json:"expireTS"`
+}
+
+var (
+	inputFile       string
+	inputFormat     string
+	outputDir       string
+	maxPairsPerFile int
+)
+
+func init() {
+	flag.StringVar(&inputFile, "input", "", "Dump of key value pairs from which the SST files are built, or - for stdin")
+	flag.StringVar(&inputFormat, "format", "csv", "Format of the dump - csv (key,value[,expireTS] per row) or jsonl ({\"key\":..,\"value\":..,\"expireTS\":..} per line)")
+	flag.StringVar(&outputDir, "output", "", "Folder into which the SST files are written")
+	flag.IntVar(&maxPairsPerFile, "max-pairs-per-file", 1000000, "Maximum number of key value pairs sorted in memory and written into a single SST file")
+}
+
+func main() {
+	flag.Parse()
+	if inputFile == "" || outputDir == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	input := os.Stdin
+	if inputFile != "-" {
+		var err error
+		if input, err = os.Open(inputFile); err != nil {
+			exitf("Unable to open dump: %s. Error: %v", inputFile, err)
+		}
+		defer input.Close()
+	}
+	builder, err := rocksdb.NewSSTBuilder(outputDir, maxPairsPerFile)
+	if err != nil {
+		exitf("Unable to create SST builder. Error: %v", err)
+	}
+
+	var numPairs int
+	add := func(kv *serverpb.KVPair) {
+		if err := builder.Add(kv); err != nil {
+			exitf("Unable to add pair number %d. Error: %v", numPairs+1, err)
+		}
+		numPairs++
+	}
+	switch strings.ToLower(inputFormat) {
+	case "csv":
+		err = readCSV(input, add)
+	case "jsonl":
+		err = readJSONLines(input, add)
+	default:
+		exitf("Unknown dump format: %s, must be one of csv|jsonl", inputFormat)
+	}
+	if err != nil {
+		exitf("Unable to read dump. Error: %v", err)
+	}
+
+	files, ttlFiles, err := builder.Finish()
+	if err != nil {
+		exitf("Unable to build SST files. Error: %v", err)
+	}
+	fmt.Printf("Built SST files from %d pairs\n", numPairs)
+	fmt.Printf("Files: %s\n", strings.Join(files, ","))
+	fmt.Printf("TTL files: %s\n", strings.Join(ttlFiles, ","))
+}
+
+func readCSV(input io.Reader, add func(*serverpb.KVPair)) error {
+	rdr := csv.NewReader(bufio.NewReader(input))
+	rdr.FieldsPerRecord = -1
+	for row := 1; ; row++ {
+		record, err := rdr.Read()
+		switch {
+		case err == io.EOF:
+			return nil
+		case err != nil:
+			return err
+		case len(record) < 2 || len(record) > 3:
+			return fmt.Errorf("row %d must have 2 or 3 fields", row)
+		}
+		kv := &serverpb.KVPair{Key: []byte(record[0]), Value: []byte(record[1])}
+		if len(record) == 3 && record[2] != "" {
+			if kv.ExpireTS, err = strconv.ParseUint(record[2], 10, 64); err != nil {
+				return fmt.Errorf("invalid expireTS: %s. Error: %v", record[2], err)
+			}
+		}
+		add(kv)
+	}
+}
+
+func readJSONLines(input io.Reader, add func(*serverpb.KVPair)) error {
+	dec := json.NewDecoder(bufio.NewReader(input))
+	for {
+		var pair jsonPair
+		switch err := dec.Decode(&pair); {
+		case err == io.EOF:
+			return nil
+		case err != nil:
+			return err
+		}
+		add(&serverpb.KVPair{Key: []byte(pair.Key), Value: []byte(pair.Value), ExpireTS: pair.ExpireTS})
+	}
+}
+
+func exitf(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/internal/topology"
+)
+
+const (
+	generateCmd = "generate"
+	runCmd      = "run"
+)
+
+var (
+	name           string
+	shards         int
+	slavesPerShard int
+	regions        string
+	basePort       int
+	engine         string
+	baseConfig     string
+	workDir        string
+	mode           string
+	image          string
+	binary         string
+	numKeys        int
+	readyTimeout   time.Duration
+	convTimeout    time.Duration
+	keep           bool
+)
+
+func init() {
+	flag.StringVar(&name, "name", "dkv", "Name of the topology, which prefixes the names of its nodes")
+	flag.IntVar(&shards, "shards", 1, "Number of shards, each with a master of its own database")
+	flag.IntVar(&slavesPerShard, "slaves", 2, "Number of slaves of every master")
+	flag.StringVar(&regions, "regions", "default", "Comma separated regions across which the slaves are spread")
+	flag.IntVar(&basePort, "base-port", 9080, "Port of the first node, with the other nodes using the subsequent ports")
+	flag.StringVar(&engine, "db-engine", "rocksdb", "Storage engine of the nodes - rocksdb|badger")
+	flag.StringVar(&baseConfig, "config", "dkvsrv.yaml", "Configuration of dkvsrv from which all the nodes start")
+	flag.StringVar(&workDir, "work-dir", "", "Folder holding the data of the nodes and the generated files")
+	flag.StringVar(&mode, "mode", "local", "Whether the nodes are launched as local processes or as containers - local|containers")
+	flag.StringVar(&image, "image", topology.DefaultImage, "Image of DKV used by the containers")
+	flag.StringVar(&binary, "dkvsrv", "dkvsrv", "Binary of dkvsrv used by the local processes")
+	flag.IntVar(&numKeys, "keys", 1000, "Number of keys written onto every shard by the workload")
+	flag.DurationVar(&readyTimeout, "ready-timeout", time.Minute, "Duration for which the nodes are awaited to start")
+	flag.DurationVar(&convTimeout, "convergence-timeout", time.Minute, "Duration for which the slaves are awaited to converge")
+	flag.BoolVar(&keep, "keep", false, "Keeps the nodes running after the workload, until interrupted")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] %s|%s\n", os.Args[0], generateCmd, runCmd)
+		fmt.Fprintf(flag.CommandLine.Output(), "  %s: writes the files describing the topology into the work folder\n", generateCmd)
+		fmt.Fprintf(flag.CommandLine.Output(), "  %s: launches the topology, runs a workload and asserts the convergence of the slaves\n", runCmd)
+		flag.PrintDefaults()
+	}
+}
+
+func main() {
+	flag.Parse()
+	if flag.NArg() != 1 || workDir == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var containerized bool
+	switch mode {
+	case "local":
+	case "containers":
+		containerized = true
+	default:
+		exitf("Unknown mode: %s, must be one of local|containers", mode)
+	}
+	topo, err := topology.Generate(topology.Spec{Name: name, Shards: shards, SlavesPerShard: slavesPerShard,
+		Regions: strings.Split(regions, ","), BasePort: basePort, Engine: engine, BaseConfig: baseConfig,
+		WorkDir: workDir, Containerized: containerized})
+	if err != nil {
+		exitf("Unable to generate topology. Error: %v", err)
+	}
+
+	switch flag.Arg(0) {
+	case generateCmd:
+		generate(topo)
+	case runCmd:
+		run(topo)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
+
+func generate(topo *topology.Topology) {
+	composeFile, err := topology.WriteFiles(topo, image)
+	if err != nil {
+		exitf("Unable to write files. Error: %v", err)
+	}
+	for _, node := range topo.Nodes() {
+		fmt.Printf("%s: %s\n", node.Name, strings.Join(topo.Args(node), " "))
+	}
+	if composeFile != "" {
+		fmt.Printf("Wrote %s, launch it using: docker compose --file %s up\n", composeFile, composeFile)
+	}
+}
+
+func run(topo *topology.Topology) {
+	var cluster topology.Cluster
+	var err error
+	if topo.Spec.Containerized {
+		cluster, err = topology.LaunchContainers(topo, image)
+	} else {
+		cluster, err = topology.LaunchProcesses(topo, binary)
+	}
+	if err != nil {
+		exitf("Unable to launch topology. Error: %v", err)
+	}
+	fmt.Printf("Launched %d nodes of topology: %s\n", len(topo.Nodes()), topo.Spec.Name)
+
+	err = runWorkload(topo)
+	if keep && err == nil {
+		fmt.Println("Nodes are kept running, interrupt to stop them")
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+		<-sig
+	}
+	if stopErr := cluster.Stop(); stopErr != nil {
+		fmt.Fprintf(os.Stderr, "Unable to stop topology. Error: %v\n", stopErr)
+	}
+	if err != nil {
+		exitf("%v", err)
+	}
+}
+
+func runWorkload(topo *topology.Topology) error {
+	if err := topology.AwaitReady(topo, readyTimeout); err != nil {
+		return fmt.Errorf("topology is not ready. Error: %v", err)
+	}
+	wl, err := topology.RunWorkload(topo, numKeys)
+	if err != nil {
+		return fmt.Errorf("unable to run workload. Error: %v", err)
+	}
+	start := time.Now()
+	if err = topology.AwaitConvergence(topo, wl, convTimeout); err != nil {
+		return fmt.Errorf("topology did not converge. Error: %v", err)
+	}
+	fmt.Printf("Wrote %d keys onto each of %d shards, all slaves converged in %v\n", numKeys, len(topo.Shards), time.Since(start))
+	return nil
+}
+
+func exitf(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
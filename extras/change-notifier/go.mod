@@ -0,0 +1,13 @@
+module github.com/flipkart-incubator/dkv/extras/change-notifier
+
+go 1.15
+
+require (
+	github.com/flipkart-incubator/dkv v0.0.0-20210804080840-2aab273094b1
+	github.com/nats-io/nats.go v1.11.0
+)
+
+replace (
+	github.com/Jille/grpc-multi-resolver => github.com/mqy/grpc-multi-resolver v1.0.1-0.20211016000115-097a4c652dad
+	github.com/flipkart-incubator/dkv => ../../
+)
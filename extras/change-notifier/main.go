@@ -0,0 +1,131 @@
+package main
+
+// go build -o change-notifier main.go
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/pkg/ctl"
+	"github.com/nats-io/nats.go"
+)
+
+var (
+	dkvAddr        string
+	authority      string
+	keyPrefixes    string
+	webhookURL     string
+	webhookTimeout time.Duration
+	natsURL        string
+	natsSubject    string
+	watermarkFile  string
+	deadLetterFile string
+	batchSize      uint
+	maxRetries     uint
+	retryBackoff   time.Duration
+	pollInterval   time.Duration
+)
+
+func init() {
+	flag.StringVar(&dkvAddr, "dkvAddr", "127.0.0.1:8080", "Address (host:port) of the DKV node whose changes are notified")
+	flag.StringVar(&authority, "authority", "", "Override :authority pseudo header for routing purposes. Useful while accessing DKV via service mesh.")
+	flag.StringVar(&keyPrefixes, "keyPrefixes", "", "Comma separated prefixes of the keys whose changes are notified, all keys when empty")
+	flag.StringVar(&webhookURL, "webhookURL", "", "URL of the webhook to which the notifications are POSTed")
+	flag.DurationVar(&webhookTimeout, "webhookTimeout", 10*time.Second, "Timeout of every POST to the webhook")
+	flag.StringVar(&natsURL, "natsURL", "", "URL of the NATS server to which the notifications are published, instead of a webhook")
+	flag.StringVar(&natsSubject, "natsSubject", "dkv.changes", "NATS subject to which the notifications are published")
+	flag.StringVar(&watermarkFile, "watermarkFile", "change-notifier.watermark", "File tracking the change number upto which the changes are notified")
+	flag.StringVar(&deadLetterFile, "deadLetterFile", "change-notifier.deadletters", "File to which the notifications failing all their retries are appended, stops at such notifications when empty")
+	flag.UintVar(&batchSize, "batchSize", 1000, "Maximum number of changes retrieved at a time")
+	flag.UintVar(&maxRetries, "maxRetries", 3, "Number of times the delivery of a notification is retried")
+	flag.DurationVar(&retryBackoff, "retryBackoff", time.Second, "Duration awaited before the first retry, doubled for every subsequent retry")
+	flag.DurationVar(&pollInterval, "pollInterval", 5*time.Second, "Polling interval for changes once caught up")
+}
+
+// natsPublisher publishes the notifications to a NATS subject, flushing
+// every notification so that it is known to be received by the server.
+type natsPublisher struct {
+	conn    *nats.Conn
+	subject string
+}
+
+func (np *natsPublisher) Publish(notif *ctl.ChangeNotification) error {
+	data, err := json.Marshal(notif)
+	if err != nil {
+		return err
+	}
+	if err = np.conn.Publish(np.subject, data); err != nil {
+		return err
+	}
+	return np.conn.Flush()
+}
+
+func main() {
+	flag.Parse()
+
+	var publisher ctl.NotificationPublisher
+	switch {
+	case webhookURL != "" && natsURL != "":
+		log.Panicf("Only one of webhookURL and natsURL must be given")
+	case webhookURL != "":
+		publisher = ctl.NewWebhookPublisher(webhookURL, webhookTimeout)
+	case natsURL != "":
+		conn, err := nats.Connect(natsURL)
+		if err != nil {
+			log.Panicf("Unable to connect to NATS at %s. Error: %v", natsURL, err)
+		}
+		defer conn.Close()
+		publisher = &natsPublisher{conn, natsSubject}
+	default:
+		log.Panicf("Either webhookURL or natsURL must be given")
+	}
+
+	client, err := ctl.NewInSecureDKVClient(dkvAddr, authority)
+	if err != nil {
+		log.Panicf("Unable to connect to DKV at %s. Error: %v", dkvAddr, err)
+	}
+	defer client.Close()
+
+	var prefixes [][]byte
+	if keyPrefixes != "" {
+		for _, prefix := range strings.Split(keyPrefixes, ",") {
+			prefixes = append(prefixes, []byte(prefix))
+		}
+	}
+	notifier, err := ctl.NewNotifier(client, publisher, ctl.NotifierOptions{
+		KeyPrefixes:    prefixes,
+		WatermarkFile:  watermarkFile,
+		DeadLetterFile: deadLetterFile,
+		BatchSize:      uint32(batchSize),
+		MaxRetries:     uint32(maxRetries),
+		RetryBackoff:   retryBackoff,
+	})
+	if err != nil {
+		log.Panicf("Unable to create the notifier. Error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		sig := <-setupSignalHandler()
+		log.Printf("[WARN] Caught signal: %v. Shutting down...\n", sig)
+		cancel()
+	}()
+
+	if err = notifier.Run(ctx, pollInterval); err != nil && err != context.Canceled {
+		log.Panicf("Notifier stopped. Error: %v", err)
+	}
+}
+
+func setupSignalHandler() <-chan os.Signal {
+	signals := []os.Signal{syscall.SIGINT, syscall.SIGQUIT, syscall.SIGSTOP, syscall.SIGTERM}
+	stopChan := make(chan os.Signal, len(signals))
+	signal.Notify(stopChan, signals...)
+	return stopChan
+}
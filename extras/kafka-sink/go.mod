@@ -0,0 +1,13 @@
+module github.com/flipkart-incubator/dkv/extras/kafka-sink
+
+go 1.15
+
+require (
+	github.com/Shopify/sarama v1.29.1
+	github.com/flipkart-incubator/dkv v0.0.0-20210804080840-2aab273094b1
+)
+
+replace (
+	github.com/Jille/grpc-multi-resolver => github.com/mqy/grpc-multi-resolver v1.0.1-0.20211016000115-097a4c652dad
+	github.com/flipkart-incubator/dkv => ../../
+)
@@ -0,0 +1,104 @@
+package main
+
+// go build -o kafka-sink main.go
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/flipkart-incubator/dkv/pkg/ctl"
+)
+
+var (
+	dkvAddr       string
+	authority     string
+	brokers       string
+	topic         string
+	envelope      string
+	watermarkFile string
+	batchSize     uint
+	pollInterval  time.Duration
+)
+
+func init() {
+	flag.StringVar(&dkvAddr, "dkvAddr", "127.0.0.1:8080", "Address (host:port) of the DKV node whose changes are published")
+	flag.StringVar(&authority, "authority", "", "Override :authority pseudo header for routing purposes. Useful while accessing DKV via service mesh.")
+	flag.StringVar(&brokers, "brokers", "127.0.0.1:9092", "Comma separated addresses (host:port) of the Kafka brokers")
+	flag.StringVar(&topic, "topic", "dkv-changes", "Kafka topic to which the changes are published")
+	flag.StringVar(&envelope, "envelope", "proto", "Format of the published changes: proto|json")
+	flag.StringVar(&watermarkFile, "watermarkFile", "kafka-sink.watermark", "File tracking the change number upto which the changes are published")
+	flag.UintVar(&batchSize, "batchSize", 1000, "Maximum number of changes published at a time")
+	flag.DurationVar(&pollInterval, "pollInterval", 5*time.Second, "Polling interval for changes once caught up")
+}
+
+// saramaProducer publishes the messages through a synchronous producer,
+// which waits for all the in-sync replicas to acknowledge them.
+type saramaProducer struct {
+	producer sarama.SyncProducer
+	topic    string
+}
+
+func (sp *saramaProducer) SendMessages(msgs []*ctl.KafkaMessage) error {
+	pMsgs := make([]*sarama.ProducerMessage, len(msgs))
+	for i, msg := range msgs {
+		pMsgs[i] = &sarama.ProducerMessage{Topic: sp.topic, Key: sarama.ByteEncoder(msg.Key), Value: sarama.ByteEncoder(msg.Value)}
+	}
+	return sp.producer.SendMessages(pMsgs)
+}
+
+func main() {
+	flag.Parse()
+
+	config := sarama.NewConfig()
+	config.Version = sarama.V0_11_0_0
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Producer.Return.Successes = true
+	// Idempotence retains the order of the messages of a key across retries
+	config.Producer.Idempotent = true
+	config.Net.MaxOpenRequests = 1
+	producer, err := sarama.NewSyncProducer(strings.Split(brokers, ","), config)
+	if err != nil {
+		log.Panicf("Unable to connect to Kafka at %s. Error: %v", brokers, err)
+	}
+	defer producer.Close()
+
+	client, err := ctl.NewInSecureDKVClient(dkvAddr, authority)
+	if err != nil {
+		log.Panicf("Unable to connect to DKV at %s. Error: %v", dkvAddr, err)
+	}
+	defer client.Close()
+
+	sink, err := ctl.NewKafkaSink(client, &saramaProducer{producer, topic}, ctl.KafkaSinkOptions{
+		Envelope:      ctl.KafkaEnvelope(envelope),
+		WatermarkFile: watermarkFile,
+		BatchSize:     uint32(batchSize),
+	})
+	if err != nil {
+		log.Panicf("Unable to create the Kafka sink. Error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		sig := <-setupSignalHandler()
+		log.Printf("[WARN] Caught signal: %v. Shutting down...\n", sig)
+		cancel()
+	}()
+
+	if err = sink.Run(ctx, pollInterval); err != nil && err != context.Canceled {
+		log.Panicf("Kafka sink stopped. Error: %v", err)
+	}
+}
+
+func setupSignalHandler() <-chan os.Signal {
+	signals := []os.Signal{syscall.SIGINT, syscall.SIGQUIT, syscall.SIGSTOP, syscall.SIGTERM}
+	stopChan := make(chan os.Signal, len(signals))
+	signal.Notify(stopChan, signals...)
+	return stopChan
+}
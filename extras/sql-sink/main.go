@@ -0,0 +1,87 @@
+package main
+
+// go build -o sql-sink main.go
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/pkg/ctl"
+
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+)
+
+var (
+	dkvAddr      string
+	authority    string
+	dialect      string
+	dsn          string
+	sinkName     string
+	table        string
+	batchSize    uint
+	pollInterval time.Duration
+)
+
+func init() {
+	flag.StringVar(&dkvAddr, "dkvAddr", "127.0.0.1:8080", "Address (host:port) of the DKV node whose changes are mirrored")
+	flag.StringVar(&authority, "authority", "", "Override :authority pseudo header for routing purposes. Useful while accessing DKV via service mesh.")
+	flag.StringVar(&dialect, "dialect", "postgres", "Dialect of the SQL database: postgres|mysql")
+	flag.StringVar(&dsn, "dsn", "", "Data source name of the SQL database")
+	flag.StringVar(&sinkName, "name", "dkv-sql-sink", "Name of the sink, identifying its watermark")
+	flag.StringVar(&table, "table", "dkv_kvs", "Table into which the keys are mirrored")
+	flag.UintVar(&batchSize, "batchSize", 1000, "Maximum number of changes applied in a single SQL transaction")
+	flag.DurationVar(&pollInterval, "pollInterval", 5*time.Second, "Polling interval for changes once caught up")
+}
+
+func main() {
+	flag.Parse()
+	if dsn == "" {
+		log.Panicf("Data source name of the SQL database must be provided")
+	}
+
+	db, err := sql.Open(dialect, dsn)
+	if err != nil {
+		log.Panicf("Unable to open the SQL database. Error: %v", err)
+	}
+	defer db.Close()
+
+	client, err := ctl.NewInSecureDKVClient(dkvAddr, authority)
+	if err != nil {
+		log.Panicf("Unable to connect to DKV at %s. Error: %v", dkvAddr, err)
+	}
+	defer client.Close()
+
+	sink, err := ctl.NewSQLSink(client, db, ctl.SQLDialect(dialect), ctl.SQLSinkOptions{
+		Name:      sinkName,
+		Table:     table,
+		BatchSize: uint32(batchSize),
+	})
+	if err != nil {
+		log.Panicf("Unable to create the SQL sink. Error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		sig := <-setupSignalHandler()
+		log.Printf("[WARN] Caught signal: %v. Shutting down...\n", sig)
+		cancel()
+	}()
+
+	if err = sink.Run(ctx, pollInterval); err != nil && err != context.Canceled {
+		log.Panicf("SQL sink stopped. Error: %v", err)
+	}
+}
+
+func setupSignalHandler() <-chan os.Signal {
+	signals := []os.Signal{syscall.SIGINT, syscall.SIGQUIT, syscall.SIGSTOP, syscall.SIGTERM}
+	stopChan := make(chan os.Signal, len(signals))
+	signal.Notify(stopChan, signals...)
+	return stopChan
+}
@@ -3,7 +3,8 @@ module github.com/flipkart-incubator/dkv
 go 1.15
 
 require (
-	github.com/DataDog/zstd v1.4.5 // indirect
+	github.com/DATA-DOG/go-sqlmock v1.5.0
+	github.com/DataDog/zstd v1.4.5
 	github.com/Jille/grpc-multi-resolver v1.0.0
 	github.com/coreos/etcd v3.3.19+incompatible // indirect
 	github.com/coreos/go-systemd v0.0.0-20191104093116-d3cd4ed1dbcf // indirect
@@ -19,12 +20,13 @@ require (
 	github.com/kpango/fastime v1.0.16
 	github.com/matttproud/golang_protobuf_extensions v1.0.1
 	github.com/pkg/errors v0.9.1 // indirect
-	github.com/prometheus/client_golang v1.5.1 // indirect
+	github.com/prometheus/client_golang v1.5.1
 	github.com/prometheus/procfs v0.0.10 // indirect
 	github.com/smira/go-statsd v1.3.1
 	github.com/spf13/pflag v1.0.5
 	github.com/spf13/viper v1.10.1
 	github.com/vmihailenco/msgpack/v5 v5.3.4
+	go.opentelemetry.io/proto/otlp v0.7.0
 	go.uber.org/zap v1.17.0
 	google.golang.org/grpc v1.43.0
 	google.golang.org/protobuf v1.27.1
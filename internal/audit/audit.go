@@ -0,0 +1,25 @@
+// Package audit records the administrative actions performed over a DKV
+// node, such as erasing subjects, rotating encryption keys and minting
+// access tokens, along with who requested them and why.
+package audit
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/peer"
+)
+
+// AdminAction records an audit entry for the given administrative action
+// performed over the given target, which may be empty for actions over
+// the entire node. The given error is that of performing the action, if
+// it failed.
+func AdminAction(lgr *zap.Logger, ctx context.Context, action string, target []byte, requester, reason string, err error) {
+	peerAddr := "unknown"
+	if p, ok := peer.FromContext(ctx); ok {
+		peerAddr = p.Addr.String()
+	}
+	lgr.Info("Admin action audit", zap.String("Action", action),
+		zap.String("Target", string(target)), zap.String("Requester", requester),
+		zap.String("Reason", reason), zap.String("Peer", peerAddr), zap.Bool("Success", err == nil), zap.Error(err))
+}
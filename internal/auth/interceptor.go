@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// AuthorizationHeader is the GRPC metadata key carrying the access token.
+	AuthorizationHeader = "authorization"
+	// BearerPrefix precedes the access token in the authorization header.
+	BearerPrefix = "Bearer "
+)
+
+// UnaryServerInterceptor restricts the unary calls carrying an access
+// token to the scope of that token. Calls without a token are passed
+// through unchanged.
+func UnaryServerInterceptor(ti *TokenIssuer) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		claims, err := ti.claimsFromContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if claims != nil {
+			if err = claims.Authorize(req); err != nil {
+				return nil, status.Error(codes.PermissionDenied, err.Error())
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor restricts the streaming calls carrying an
+// access token to the scope of that token. Every message received on
+// the stream is authorized. Calls without a token are passed through
+// unchanged.
+func StreamServerInterceptor(ti *TokenIssuer) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		claims, err := ti.claimsFromContext(ss.Context())
+		if err != nil {
+			return err
+		}
+		if claims != nil {
+			ss = &authorizedStream{ss, claims}
+		}
+		return handler(srv, ss)
+	}
+}
+
+type authorizedStream struct {
+	grpc.ServerStream
+	claims *Claims
+}
+
+func (as *authorizedStream) RecvMsg(m interface{}) error {
+	if err := as.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if err := as.claims.Authorize(m); err != nil {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return nil
+}
+
+// claimsFromContext verifies the access token carried by the incoming
+// call, if any. Returns nil claims when the call carries no token.
+func (ti *TokenIssuer) claimsFromContext(ctx context.Context) (*Claims, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+	authHdrs := md.Get(AuthorizationHeader)
+	if len(authHdrs) == 0 {
+		return nil, nil
+	}
+	if !strings.HasPrefix(authHdrs[0], BearerPrefix) {
+		return nil, status.Error(codes.Unauthenticated, ErrInvalidToken.Error())
+	}
+	claims, err := ti.Verify(strings.TrimPrefix(authHdrs[0], BearerPrefix))
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	return claims, nil
+}
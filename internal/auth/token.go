@@ -0,0 +1,172 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+)
+
+// MaxTokenTTL is the longest duration for which an access token
+// can be minted. It is also the default duration of a token.
+const MaxTokenTTL = time.Hour
+
+const secretSize = 32
+
+var (
+	// ErrInvalidToken is returned when the given access token is
+	// malformed or is not signed by the current issuer.
+	ErrInvalidToken = errors.New("invalid access token")
+	// ErrTokenExpired is returned when the given access token is
+	// past its expiry.
+	ErrTokenExpired = errors.New("access token has expired")
+)
+
+// Claims captures the scope of an access token. A token only grants
+// read access to the keys having the key prefix until it expires.
+type Claims struct {
+	KeyPrefix []byte `json:"prefix"`
+	Requester string `json:"requester"`
+	ExpireTS  int64  `json:"exp"`
+}
+
+// TokenIssuer mints and verifies access tokens. Tokens are signed using
+// HMAC-SHA256 so that they can be verified without being stored. Hence
+// tokens minted by one node are valid on all the nodes sharing its secret.
+type TokenIssuer struct {
+	secret []byte
+}
+
+// NewTokenIssuer creates an issuer that signs tokens using the given
+// secret. When no secret is given, a random one is generated so that
+// the tokens are valid only on the current node until it restarts.
+func NewTokenIssuer(secret []byte) (*TokenIssuer, error) {
+	if len(secret) == 0 {
+		secret = make([]byte, secretSize)
+		if _, err := io.ReadFull(rand.Reader, secret); err != nil {
+			return nil, err
+		}
+	}
+	return &TokenIssuer{secret: append([]byte(nil), secret...)}, nil
+}
+
+// Mint creates an access token granting read-only access to the keys
+// having the given prefix for the given duration. A zero duration
+// results in a token that is valid for MaxTokenTTL.
+func (ti *TokenIssuer) Mint(keyPrefix []byte, requester string, ttl time.Duration) (string, *Claims, error) {
+	switch {
+	case len(keyPrefix) == 0:
+		return "", nil, errors.New("key prefix must be provided for minting an access token")
+	case strings.TrimSpace(requester) == "":
+		return "", nil, errors.New("requester must be provided for minting an access token")
+	case ttl < 0 || ttl > MaxTokenTTL:
+		return "", nil, fmt.Errorf("access token TTL: %v must be positive and not exceed %v", ttl, MaxTokenTTL)
+	case ttl == 0:
+		ttl = MaxTokenTTL
+	}
+
+	claims := &Claims{KeyPrefix: keyPrefix, Requester: requester, ExpireTS: time.Now().Add(ttl).Unix()}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", nil, err
+	}
+	token := encode(payload) + "." + ti.Sign(payload)
+	return token, claims, nil
+}
+
+// Verify checks the signature and the expiry of the given access token
+// and returns its claims.
+func (ti *TokenIssuer) Verify(token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return nil, ErrInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(sig, ti.sign(payload)) {
+		return nil, ErrInvalidToken
+	}
+
+	claims := &Claims{}
+	if err = json.Unmarshal(payload, claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if claims.expired() {
+		return nil, ErrTokenExpired
+	}
+	return claims, nil
+}
+
+// Authorize checks if the given request is permitted by these claims.
+// Only reads of the keys having the key prefix are permitted.
+func (c *Claims) Authorize(req interface{}) error {
+	if c.expired() {
+		return ErrTokenExpired
+	}
+	switch r := req.(type) {
+	case *serverpb.GetRequest:
+		return c.authorizeKey(r.Key)
+	case *serverpb.ExistsRequest:
+		return c.authorizeKey(r.Key)
+	case *serverpb.MultiGetRequest:
+		for _, key := range r.Keys {
+			if err := c.authorizeKey(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *serverpb.IterateRequest:
+		return c.authorizeKey(r.KeyPrefix)
+	case *serverpb.ScanRequest:
+		return c.authorizeKey(r.KeyPrefix)
+	default:
+		return errors.New("access token only permits reads")
+	}
+}
+
+func (c *Claims) authorizeKey(key []byte) error {
+	if !bytes.HasPrefix(key, c.KeyPrefix) {
+		return fmt.Errorf("access token only permits reads of keys with prefix: %s", c.KeyPrefix)
+	}
+	return nil
+}
+
+func (c *Claims) expired() bool {
+	return time.Now().Unix() >= c.ExpireTS
+}
+
+// Sign signs the given payload using the secret of this issuer and
+// returns the encoded signature. Besides access tokens, this is used
+// for signing records whose origin must be verifiable later.
+func (ti *TokenIssuer) Sign(payload []byte) string {
+	return encode(ti.sign(payload))
+}
+
+// VerifySignature checks if the given encoded signature of the given
+// payload was created using the secret of this issuer.
+func (ti *TokenIssuer) VerifySignature(payload []byte, signature string) bool {
+	sig, err := base64.RawURLEncoding.DecodeString(signature)
+	return err == nil && hmac.Equal(sig, ti.sign(payload))
+}
+
+func (ti *TokenIssuer) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, ti.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+func encode(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
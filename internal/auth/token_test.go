@@ -0,0 +1,131 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestMintAndVerify(t *testing.T) {
+	ti, err := NewTokenIssuer([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	invalidMints := []struct {
+		keyPrefix, requester string
+		ttl                  time.Duration
+	}{
+		{"", "alice", time.Minute},
+		{"orders:", " ", time.Minute},
+		{"orders:", "alice", -time.Minute},
+		{"orders:", "alice", 2 * time.Hour},
+	}
+	for _, im := range invalidMints {
+		if _, _, err := ti.Mint([]byte(im.keyPrefix), im.requester, im.ttl); err == nil {
+			t.Errorf("Expected an error while minting with %+v", im)
+		}
+	}
+
+	token, claims, err := ti.Mint([]byte("orders:"), "alice", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expTS := time.Now().Add(MaxTokenTTL).Unix(); claims.ExpireTS > expTS || claims.ExpireTS < expTS-5 {
+		t.Errorf("Expected token to expire in %v. Actual expiry: %d", MaxTokenTTL, claims.ExpireTS)
+	}
+	if verClaims, err := ti.Verify(token); err != nil {
+		t.Error(err)
+	} else if string(verClaims.KeyPrefix) != "orders:" || verClaims.Requester != "alice" {
+		t.Errorf("Claims mismatch. Actual: %+v", verClaims)
+	}
+
+	otherTi, _ := NewTokenIssuer(nil)
+	if _, err := otherTi.Verify(token); err != ErrInvalidToken {
+		t.Errorf("Expected token signed by another issuer to be invalid. Actual error: %v", err)
+	}
+	if _, err := ti.Verify(token[1:]); err != ErrInvalidToken {
+		t.Errorf("Expected tampered token to be invalid. Actual error: %v", err)
+	}
+
+	expClaims := &Claims{KeyPrefix: []byte("orders:"), ExpireTS: time.Now().Unix() - 1}
+	if err := expClaims.Authorize(&serverpb.GetRequest{Key: []byte("orders:1")}); err != ErrTokenExpired {
+		t.Errorf("Expected expired token error. Actual error: %v", err)
+	}
+}
+
+func TestSignAndVerifySignature(t *testing.T) {
+	ti, _ := NewTokenIssuer([]byte("secret"))
+	payload := []byte("node1|42")
+	sig := ti.Sign(payload)
+	if !ti.VerifySignature(payload, sig) {
+		t.Error("Expected the signature to be valid")
+	}
+	if ti.VerifySignature([]byte("node1|43"), sig) {
+		t.Error("Expected the signature of a tampered payload to be invalid")
+	}
+	if otherTi, _ := NewTokenIssuer(nil); otherTi.VerifySignature(payload, sig) {
+		t.Error("Expected the signature of another issuer to be invalid")
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	claims := &Claims{KeyPrefix: []byte("orders:"), ExpireTS: time.Now().Add(time.Minute).Unix()}
+
+	allowed := []interface{}{
+		&serverpb.GetRequest{Key: []byte("orders:1")},
+		&serverpb.MultiGetRequest{Keys: [][]byte{[]byte("orders:1"), []byte("orders:2")}},
+		&serverpb.IterateRequest{KeyPrefix: []byte("orders:2021")},
+	}
+	for _, req := range allowed {
+		if err := claims.Authorize(req); err != nil {
+			t.Errorf("Expected request: %v to be authorized. Error: %v", req, err)
+		}
+	}
+
+	denied := []interface{}{
+		&serverpb.GetRequest{Key: []byte("users:1")},
+		&serverpb.MultiGetRequest{Keys: [][]byte{[]byte("orders:1"), []byte("users:1")}},
+		&serverpb.IterateRequest{},
+		&serverpb.PutRequest{Key: []byte("orders:1"), Value: []byte("v")},
+		&serverpb.DeleteRequest{Key: []byte("orders:1")},
+	}
+	for _, req := range denied {
+		if err := claims.Authorize(req); err == nil {
+			t.Errorf("Expected request: %v to be denied", req)
+		}
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	ti, _ := NewTokenIssuer(nil)
+	token, _, _ := ti.Mint([]byte("orders:"), "alice", time.Minute)
+	intercept := UnaryServerInterceptor(ti)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return req, nil }
+
+	ctxWithToken := func(authHdr string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(AuthorizationHeader, authHdr))
+	}
+	testCases := []struct {
+		ctx     context.Context
+		req     interface{}
+		expCode codes.Code
+	}{
+		{context.Background(), &serverpb.PutRequest{Key: []byte("users:1")}, codes.OK},
+		{ctxWithToken(BearerPrefix + token), &serverpb.GetRequest{Key: []byte("orders:1")}, codes.OK},
+		{ctxWithToken(BearerPrefix + token), &serverpb.GetRequest{Key: []byte("users:1")}, codes.PermissionDenied},
+		{ctxWithToken(BearerPrefix + token), &serverpb.PutRequest{Key: []byte("orders:1")}, codes.PermissionDenied},
+		{ctxWithToken(BearerPrefix + "bogus"), &serverpb.GetRequest{Key: []byte("orders:1")}, codes.Unauthenticated},
+		{ctxWithToken(token), &serverpb.GetRequest{Key: []byte("orders:1")}, codes.Unauthenticated},
+	}
+	for _, tc := range testCases {
+		if _, err := intercept(tc.ctx, tc.req, nil, handler); status.Code(err) != tc.expCode {
+			t.Errorf("Expected code: %v for request: %v. Actual error: %v", tc.expCode, tc.req, err)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+// Package changestream serves the StreamChanges calls of the masters and
+// the slaves alike, batching the changes retrieved by long polling their
+// GetChanges into the responses of the stream as per the batch size and
+// latency targets of the subscriber.
+package changestream
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+)
+
+const (
+	// DefaultMaxChanges is the maximum number of changes per response,
+	// unless requested otherwise.
+	DefaultMaxChanges = 1000
+	// Interval at which the changes are polled for, when the store does
+	// not notify the commits.
+	pollInterval = time.Second
+	// Maximum duration of a single long poll for the changes.
+	maxPollWait = 10 * time.Second
+)
+
+// Maximum duration for which the stream stays paused awaiting the
+// acknowledgements of the subscriber, after which the stream is ended for
+// the subscriber to resume it from the changes it applied.
+var maxAckWait = time.Minute
+
+var errAckTimeout = errors.New("changes in flight are not acknowledged by the subscriber in time")
+
+// GetChanges retrieves the changes requested, waiting for upto the
+// MaxWaitMillis of the request for the changes when there are none.
+type GetChanges func(ctx context.Context, getChngsReq *serverpb.GetChangesRequest) (*serverpb.GetChangesResponse, error)
+
+// Send sends the given response on the stream.
+type Send func(res *serverpb.GetChangesResponse) error
+
+// Acked retrieves the change number upto which the subscriber acknowledged
+// applying the changes, along with a channel that is closed upon further
+// acknowledgements.
+type Acked func() (uint64, <-chan struct{})
+
+// sentBatch is a batch of changes sent to the subscriber, which is yet to
+// be acknowledged.
+type sentBatch struct {
+	lastChngNum uint64
+	numBytes    int
+}
+
+// Serve streams the changes from the change number of the given request
+// until the given context is done, retrieving them using getChanges. The
+// changes are sent once the batch reaches the MaxNumberOfChanges or the
+// MaxBatchBytes of the request, or once MaxBatchDelayMillis elapses since
+// the first change of the batch was retrieved. Without a delay, changes
+// are sent as soon as they are retrieved. Given notified is false, the
+// changes are polled for as getChanges does not wait for them.
+//
+// Given acked, no more than the MaxInFlightBytes of the request are sent
+// ahead of the acknowledgements of the subscriber, with the changes left
+// unretrieved until the subscriber catches up. Hence a slow subscriber
+// holds up only its own stream, without the changes piling up in memory.
+// A single batch is always sent when none is in flight, even if larger.
+// Heartbeats are sent while paused, while the stream fails once paused for
+// long, such as when the acknowledgements are lost.
+//
+// Changes preceding the change number of the request are accounted as
+// applied by the ReplicaID of the request, which is not passed on to the
+// subsequent retrievals as those are yet to be applied.
+func Serve(ctx context.Context, getChngsReq *serverpb.GetChangesRequest, getChanges GetChanges, notified bool, acked Acked, send Send) error {
+	maxChngs := getChngsReq.MaxNumberOfChanges
+	if maxChngs == 0 {
+		maxChngs = DefaultMaxChanges
+	}
+	maxBytes := int(getChngsReq.MaxBatchBytes)
+	delay := time.Duration(getChngsReq.MaxBatchDelayMillis) * time.Millisecond
+	heartbeat := time.Duration(getChngsReq.HeartbeatMillis) * time.Millisecond
+	maxInFlight := int(getChngsReq.MaxInFlightBytes)
+	if acked == nil {
+		maxInFlight = 0
+	}
+
+	var (
+		batch      *serverpb.GetChangesResponse
+		batchBytes int
+		flushAt    time.Time
+		last       *serverpb.GetChangesResponse
+		lastSent   = time.Now()
+		inFlight   []sentBatch
+		// Size of the batches in flight
+		inFlightBytes int
+	)
+	// sendHeartbeat sends a response without any changes, once the
+	// heartbeat interval elapses since the last response
+	sendHeartbeat := func(now time.Time) (bool, error) {
+		if last == nil || heartbeat <= 0 || now.Sub(lastSent) < heartbeat {
+			return false, nil
+		}
+		if err := send(&serverpb.GetChangesResponse{Status: last.Status,
+			MasterChangeNumber: last.MasterChangeNumber, FencingEpoch: last.FencingEpoch}); err != nil {
+			return false, err
+		}
+		lastSent = now
+		return true, nil
+	}
+	// awaitWindow waits for the batches in flight to be acknowledged, until
+	// the batch of the given size can be sent within the in flight limit
+	awaitWindow := func(numBytes int) error {
+		pausedAt := time.Now()
+		for {
+			ackedChngNum, updated := acked()
+			for len(inFlight) > 0 && inFlight[0].lastChngNum <= ackedChngNum {
+				inFlightBytes -= inFlight[0].numBytes
+				inFlight = inFlight[1:]
+			}
+			if len(inFlight) == 0 || inFlightBytes+numBytes <= maxInFlight {
+				return nil
+			}
+			now := time.Now()
+			if _, err := sendHeartbeat(now); err != nil {
+				return err
+			}
+			wait := pausedAt.Add(maxAckWait).Sub(now)
+			if wait <= 0 {
+				return errAckTimeout
+			}
+			if heartbeat > 0 && lastSent.Add(heartbeat).Sub(now) < wait {
+				wait = lastSent.Add(heartbeat).Sub(now)
+			}
+			timer := time.NewTimer(wait)
+			select {
+			case <-updated:
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			}
+			timer.Stop()
+		}
+	}
+
+	fromChngNum, replicaID := getChngsReq.FromChangeNumber, getChngsReq.ReplicaID
+	for {
+		now := time.Now()
+		if batch != nil && (batch.NumberOfChanges >= maxChngs || (maxBytes > 0 && batchBytes >= maxBytes) || !now.Before(flushAt)) {
+			if maxInFlight > 0 {
+				if err := awaitWindow(batchBytes); err != nil {
+					return err
+				}
+				inFlight = append(inFlight, sentBatch{fromChngNum - 1, batchBytes})
+				inFlightBytes += batchBytes
+			}
+			if err := send(batch); err != nil {
+				return err
+			}
+			batch, batchBytes, lastSent = nil, 0, time.Now()
+			continue
+		}
+		if batch == nil {
+			sent, err := sendHeartbeat(now)
+			if err != nil {
+				return err
+			}
+			if sent {
+				continue
+			}
+		}
+
+		wait := maxPollWait
+		switch {
+		case batch != nil:
+			wait = flushAt.Sub(now)
+		case heartbeat > 0:
+			wait = lastSent.Add(heartbeat).Sub(now)
+		}
+		if wait > maxPollWait {
+			wait = maxPollWait
+		}
+		if wait < time.Millisecond {
+			wait = time.Millisecond
+		}
+		numChngs := maxChngs
+		if batch != nil {
+			numChngs -= batch.NumberOfChanges
+		}
+		res, err := getChanges(ctx, &serverpb.GetChangesRequest{FromChangeNumber: fromChngNum, MaxNumberOfChanges: numChngs,
+			ReplicaID: replicaID, MaxWaitMillis: uint32(wait.Milliseconds()),
+			RecordCompression: getChngsReq.RecordCompression, DeltaEncodeKeys: getChngsReq.DeltaEncodeKeys})
+		if err != nil {
+			return err
+		}
+		replicaID = ""
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+		last = res
+
+		if res.NumberOfChanges > 0 {
+			if batch == nil {
+				batch, flushAt = res, time.Now().Add(delay)
+			} else {
+				batch.Changes = append(batch.Changes, res.Changes...)
+				batch.NumberOfChanges += res.NumberOfChanges
+				batch.MasterChangeNumber, batch.FencingEpoch = res.MasterChangeNumber, res.FencingEpoch
+			}
+			for _, chng := range res.Changes {
+				batchBytes += len(chng.SerialisedForm) + len(chng.CompressedForm)
+			}
+			lastChng := res.Changes[len(res.Changes)-1]
+			fromChngNum = lastChng.ChangeNumber + uint64(lastChng.NumberOfTrxns)
+			continue
+		}
+		// getChanges already waited for the changes when notified of them
+		if notified {
+			continue
+		}
+		if wait > pollInterval {
+			wait = pollInterval
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package changestream
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+)
+
+var errStop = errors.New("stop")
+
+// fakeChanges serves the given number of single operation changes, one
+// per call of getChanges, as if they were committed one after another.
+type fakeChanges struct {
+	numChngs uint64
+}
+
+func (fc *fakeChanges) getChanges(_ context.Context, req *serverpb.GetChangesRequest) (*serverpb.GetChangesResponse, error) {
+	res := &serverpb.GetChangesResponse{Status: &serverpb.Status{}, MasterChangeNumber: fc.numChngs}
+	if req.FromChangeNumber <= fc.numChngs && req.MaxNumberOfChanges > 0 {
+		res.NumberOfChanges = 1
+		res.Changes = []*serverpb.ChangeRecord{{ChangeNumber: req.FromChangeNumber, NumberOfTrxns: 1, SerialisedForm: make([]byte, 100)}}
+	}
+	return res, nil
+}
+
+func TestServeWithoutBatching(t *testing.T) {
+	fc := &fakeChanges{numChngs: 3}
+	var received []*serverpb.GetChangesResponse
+	err := Serve(context.Background(), &serverpb.GetChangesRequest{FromChangeNumber: 1}, fc.getChanges, false, nil,
+		func(res *serverpb.GetChangesResponse) error {
+			received = append(received, res)
+			if len(received) == 3 {
+				return errStop
+			}
+			return nil
+		})
+	if err != errStop {
+		t.Fatalf("Expected the stream to be stopped. Error: %v", err)
+	}
+	for i, res := range received {
+		if res.NumberOfChanges != 1 || res.Changes[0].ChangeNumber != uint64(i+1) {
+			t.Errorf("Expected change number %d to be sent by itself, but got %v", i+1, res.Changes)
+		}
+	}
+}
+
+func TestServeWithBatching(t *testing.T) {
+	fc := &fakeChanges{numChngs: 10}
+	var received []*serverpb.GetChangesResponse
+	req := &serverpb.GetChangesRequest{FromChangeNumber: 1, MaxNumberOfChanges: 4, MaxBatchBytes: 250, MaxBatchDelayMillis: 1000}
+	err := Serve(context.Background(), req, fc.getChanges, false, nil, func(res *serverpb.GetChangesResponse) error {
+		received = append(received, res)
+		if len(received) == 2 {
+			return errStop
+		}
+		return nil
+	})
+	if err != errStop {
+		t.Fatalf("Expected the stream to be stopped. Error: %v", err)
+	}
+	// Batches are bounded by their size before their number of changes
+	for i, res := range received {
+		if res.NumberOfChanges != 3 || res.Changes[0].ChangeNumber != uint64(3*i+1) {
+			t.Errorf("Expected a batch of 3 changes from change number %d, but got %v", 3*i+1, res.Changes)
+		}
+	}
+}
+
+func TestServeWithHeartbeats(t *testing.T) {
+	fc := &fakeChanges{numChngs: 1}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var received []*serverpb.GetChangesResponse
+	req := &serverpb.GetChangesRequest{FromChangeNumber: 1, MaxBatchDelayMillis: 10, HeartbeatMillis: 20}
+	err := Serve(ctx, req, fc.getChanges, false, nil, func(res *serverpb.GetChangesResponse) error {
+		received = append(received, res)
+		if len(received) == 3 {
+			return errStop
+		}
+		return nil
+	})
+	if err != errStop {
+		t.Fatalf("Expected the stream to be stopped. Error: %v", err)
+	}
+	if received[0].NumberOfChanges != 1 {
+		t.Errorf("Expected the first response to carry the change, but got %v", received[0].Changes)
+	}
+	for _, res := range received[1:] {
+		if res.NumberOfChanges != 0 || res.MasterChangeNumber != fc.numChngs {
+			t.Errorf("Expected a heartbeat with master change number %d, but got %v", fc.numChngs, res)
+		}
+	}
+}
+
+func TestServeWithFlowControl(t *testing.T) {
+	fc := &fakeChanges{numChngs: 10}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var (
+		mu           sync.Mutex
+		ackedChngNum uint64
+		updated      = make(chan struct{})
+	)
+	acked := func() (uint64, <-chan struct{}) {
+		mu.Lock()
+		defer mu.Unlock()
+		return ackedChngNum, updated
+	}
+	ack := func(chngNum uint64) {
+		mu.Lock()
+		defer mu.Unlock()
+		ackedChngNum = chngNum
+		close(updated)
+		updated = make(chan struct{})
+	}
+
+	received := make(chan *serverpb.GetChangesResponse, 10)
+	req := &serverpb.GetChangesRequest{FromChangeNumber: 1, ReplicaID: "slave", MaxInFlightBytes: 250}
+	go Serve(ctx, req, fc.getChanges, false, acked, func(res *serverpb.GetChangesResponse) error {
+		received <- res
+		return nil
+	})
+	expectChanges := func(from, to uint64) {
+		for chngNum := from; chngNum <= to; chngNum++ {
+			select {
+			case res := <-received:
+				if res.Changes[0].ChangeNumber != chngNum {
+					t.Fatalf("Expected change number %d, but got %v", chngNum, res.Changes)
+				}
+			case <-ctx.Done():
+				t.Fatalf("Expected change number %d to be sent", chngNum)
+			}
+		}
+		select {
+		case res := <-received:
+			t.Fatalf("Expected the stream to pause after change number %d, but got %v", to, res.Changes)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+	// Only 2 changes of 100 bytes each fit within the limit
+	expectChanges(1, 2)
+	ack(1)
+	expectChanges(3, 3)
+	ack(3)
+	expectChanges(4, 5)
+}
+
+func TestServeWithLostAcknowledgements(t *testing.T) {
+	defer func(wait time.Duration) { maxAckWait = wait }(maxAckWait)
+	maxAckWait = 200 * time.Millisecond
+
+	fc := &fakeChanges{numChngs: 10}
+	updated := make(chan struct{})
+	acked := func() (uint64, <-chan struct{}) { return 0, updated }
+	var numChngs, numHeartbeats int
+	req := &serverpb.GetChangesRequest{FromChangeNumber: 1, ReplicaID: "slave", MaxInFlightBytes: 250, HeartbeatMillis: 20}
+	err := Serve(context.Background(), req, fc.getChanges, false, acked, func(res *serverpb.GetChangesResponse) error {
+		if res.NumberOfChanges > 0 {
+			numChngs++
+		} else {
+			numHeartbeats++
+		}
+		return nil
+	})
+	if err != errAckTimeout {
+		t.Fatalf("Expected the paused stream to fail with %v, but got %v", errAckTimeout, err)
+	}
+	if numChngs != 2 {
+		t.Errorf("Expected 2 changes to be sent before pausing, but got %d", numChngs)
+	}
+	if numHeartbeats < 2 {
+		t.Errorf("Expected heartbeats to be sent while paused, but got %d", numHeartbeats)
+	}
+}
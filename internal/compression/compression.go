@@ -0,0 +1,108 @@
+// Package compression names the compressors of the GRPC calls and the
+// replicated change records supported by DKV, and registers with GRPC
+// the zstd compressor, which GRPC does not provide by itself.
+package compression
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/DataDog/zstd"
+	"google.golang.org/grpc/encoding"
+	// Registers the gzip compressor with GRPC
+	_ "google.golang.org/grpc/encoding/gzip"
+)
+
+const (
+	// Gzip compresses the messages using gzip.
+	Gzip = "gzip"
+	// Zstd compresses the messages using zstd, which typically
+	// compresses better than gzip at a fraction of its cost.
+	Zstd = "zstd"
+)
+
+// MaxMsgSize is the size of the largest message decompressed, same as
+// that of the largest message received by the DKV clients, so that a
+// small message cannot decompress into an unbounded one.
+const MaxMsgSize = 50 << 20
+
+// Level at which zstd compresses the messages, favouring speed.
+const zstdLevel = 3
+
+func init() {
+	encoding.RegisterCompressor(&zstdCompressor{level: zstdLevel})
+}
+
+// Validate checks if the given compressor is supported. An empty
+// compressor disables the compression.
+func Validate(compressor string) error {
+	switch compressor {
+	case "", Gzip, Zstd:
+		return nil
+	default:
+		return fmt.Errorf("unknown compressor: %s, must be one of %s|%s", compressor, Gzip, Zstd)
+	}
+}
+
+// ReadAll reads the given decompressed message, failing if it is larger
+// than MaxMsgSize.
+func ReadAll(r io.Reader) ([]byte, error) {
+	data, err := ioutil.ReadAll(io.LimitReader(r, MaxMsgSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxMsgSize {
+		return nil, fmt.Errorf("decompressed message exceeds the limit of %d bytes", MaxMsgSize)
+	}
+	return data, nil
+}
+
+// zstdCompressor compresses every message as a single zstd frame, which
+// spares the per stream state of the streaming zstd API.
+type zstdCompressor struct {
+	level int
+}
+
+func (zc *zstdCompressor) Name() string {
+	return Zstd
+}
+
+func (zc *zstdCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	return &zstdWriter{w: w, level: zc.level}, nil
+}
+
+// Decompress decompresses the message read from the given reader in
+// full, as the frame is decompressed in a streaming manner only to
+// bound its size.
+func (zc *zstdCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	zr := zstd.NewReader(r)
+	defer zr.Close()
+	msg, err := ReadAll(zr)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(msg), nil
+}
+
+// zstdWriter buffers the message written to it, which is compressed
+// and written to the underlying writer upon being closed.
+type zstdWriter struct {
+	w     io.Writer
+	level int
+	buf   bytes.Buffer
+}
+
+func (zw *zstdWriter) Write(p []byte) (int, error) {
+	return zw.buf.Write(p)
+}
+
+func (zw *zstdWriter) Close() error {
+	data, err := zstd.CompressLevel(nil, zw.buf.Bytes(), zw.level)
+	if err != nil {
+		return err
+	}
+	_, err = zw.w.Write(data)
+	return err
+}
@@ -0,0 +1,52 @@
+package compression
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"google.golang.org/grpc/encoding"
+)
+
+func TestZstdDecompress(t *testing.T) {
+	comp := encoding.GetCompressor(Zstd)
+	compress := func(msg []byte) []byte {
+		var buf bytes.Buffer
+		w, err := comp.Compress(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = w.Write(msg); err != nil {
+			t.Fatal(err)
+		}
+		if err = w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+
+	msg := bytes.Repeat([]byte("dkv"), 1000)
+	r, err := comp.Decompress(bytes.NewReader(compress(msg)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, err := ioutil.ReadAll(r); err != nil || !bytes.Equal(data, msg) {
+		t.Errorf("Expected the decompressed message to be same as the compressed one. Error: %v", err)
+	}
+
+	// Messages decompressing beyond the limit are rejected
+	if _, err = comp.Decompress(bytes.NewReader(compress(make([]byte, MaxMsgSize+1)))); err == nil {
+		t.Error("Expected an error for decompressing a message beyond the limit")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, compressor := range []string{"", Gzip, Zstd} {
+		if err := Validate(compressor); err != nil {
+			t.Errorf("Expected compressor %q to be valid. Error: %v", compressor, err)
+		}
+	}
+	if err := Validate("lz4"); err == nil {
+		t.Error("Expected an error for an unknown compressor")
+	}
+}
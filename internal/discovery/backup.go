@@ -0,0 +1,229 @@
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"go.uber.org/zap"
+)
+
+// ClusterBackupManifestFileName is the name of the file placed under the
+// backup path of a cluster backup, which records the backups of all the
+// shards along with their change numbers.
+const ClusterBackupManifestFileName = "dkv_cluster_backup.manifest"
+
+// Duration after which the leaders resume the writes paused for a
+// quiesced cluster backup, unless requested otherwise.
+const defaultQuiesceTimeout = 10 * time.Minute
+
+var errDatabaseMissing = errors.New("database to be backed up must be provided")
+
+/*
+A cluster backup is coordinated by backing up all the shards of a database
+concurrently through their leaders. The shards are backed up independently
+of one another, each upto its own change number as recorded in the manifest,
+hence the backups do not form a consistent cut of the database: writes
+spanning several shards may be captured partially.
+
+Quiesced backups pause the writes on the leaders of all the shards before
+backing them up, recording the change number of every shard as of the pause,
+and resume the writes once all the shards are backed up. The backup of every
+shard must then match its recorded change number, so that the backups form
+a consistent cut of the database, whose change number vector is recorded in
+the manifest. Leaders resume the writes by themselves should the backup not
+complete within the requested timeout, failing the backup.
+*/
+
+func (d *discoverService) BackupCluster(ctx context.Context, request *serverpb.BackupClusterRequest) (*serverpb.BackupClusterResponse, error) {
+	res, err := d.backupCluster(ctx, request)
+	if err != nil {
+		d.logger.Error("Unable to perform cluster backup", zap.String("Database", request.GetDatabase()), zap.Error(err))
+		return &serverpb.BackupClusterResponse{Status: newErrorStatus(err)}, err
+	}
+	return res, nil
+}
+
+func (d *discoverService) backupCluster(ctx context.Context, request *serverpb.BackupClusterRequest) (*serverpb.BackupClusterResponse, error) {
+	database, backupPath := request.GetDatabase(), request.GetBackupPath()
+	switch {
+	case database == "":
+		return nil, errDatabaseMissing
+	case backupPath == "":
+		return nil, errors.New("backup path must be provided")
+	}
+
+	leaders, err := d.shardLeaders(ctx, database)
+	if err != nil {
+		return nil, err
+	}
+	// Shard backups are placed under a folder of the database, which
+	// must exist for them to be taken
+	if err = os.MkdirAll(path.Join(backupPath, database), 0755); err != nil {
+		return nil, err
+	}
+
+	res := &serverpb.BackupClusterResponse{
+		Status:       &serverpb.Status{Code: 0, Message: ""},
+		ManifestPath: path.Join(backupPath, ClusterBackupManifestFileName),
+		StartedAt:    time.Now().Unix(),
+		Database:     database,
+	}
+	// Shards are backed up concurrently across nodes, but one at a time
+	// on a node, since a node takes only one backup at a time
+	res.Shards = make([]*serverpb.ShardBackup, len(leaders))
+	errs := make([]error, len(leaders))
+	nodeShards := make(map[string][]int)
+	var nodeAddrs []string
+	for i, leader := range leaders {
+		if _, present := nodeShards[leader.GetNodeAddress()]; !present {
+			nodeAddrs = append(nodeAddrs, leader.GetNodeAddress())
+		}
+		nodeShards[leader.GetNodeAddress()] = append(nodeShards[leader.GetNodeAddress()], i)
+	}
+	var quiescedChngNums map[string]uint64
+	if request.GetQuiesce() {
+		timeout := time.Duration(request.GetQuiesceTimeoutSecs()) * time.Second
+		if timeout == 0 {
+			timeout = defaultQuiesceTimeout
+		}
+		quiescedChngNums, err = d.pauseWrites(nodeAddrs, timeout)
+		defer d.resumeWrites(quiescedChngNums)
+		if err != nil {
+			return nil, err
+		}
+		res.Quiesced = true
+	}
+	var wg sync.WaitGroup
+	for _, shards := range nodeShards {
+		wg.Add(1)
+		go func(shards []int) {
+			defer wg.Done()
+			for _, i := range shards {
+				res.Shards[i], errs[i] = d.backupShard(leaders[i], path.Join(backupPath, database, leaders[i].GetVBucket()))
+			}
+		}(shards)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("unable to back up shard %s through %s: %v", leaders[i].GetVBucket(), leaders[i].GetNodeAddress(), err)
+		}
+	}
+	if res.Quiesced {
+		for _, shard := range res.Shards {
+			shard.QuiescedChangeNumber = quiescedChngNums[shard.NodeAddress]
+			if shard.ChangeNumber != shard.QuiescedChangeNumber {
+				return nil, fmt.Errorf("shard %s was backed up at change number %d through %s, instead of change number %d at which its writes were paused",
+					shard.VBucket, shard.ChangeNumber, shard.NodeAddress, shard.QuiescedChangeNumber)
+			}
+		}
+	}
+	res.CompletedAt = time.Now().Unix()
+
+	manifest, err := json.Marshal(res)
+	if err != nil {
+		return nil, err
+	}
+	if err = ioutil.WriteFile(res.ManifestPath, manifest, 0644); err != nil {
+		return nil, err
+	}
+	d.logger.Info("Completed cluster backup", zap.String("Database", database), zap.Int("NumShards", len(res.Shards)),
+		zap.String("ManifestPath", res.ManifestPath), zap.Bool("Quiesced", res.Quiesced))
+	return res, nil
+}
+
+// pauseWrites pauses the writes on the given nodes until resumed or the
+// given timeout elapses, retrieving their change numbers as of the pause.
+// Upon failure, the nodes paused so far are returned along with the one
+// that failed, which are to be resumed.
+func (d *discoverService) pauseWrites(nodeAddrs []string, timeout time.Duration) (map[string]uint64, error) {
+	chngNums := make(map[string]uint64, len(nodeAddrs))
+	for _, addr := range nodeAddrs {
+		cli, err := d.nodeClient(addr)
+		if err != nil {
+			return chngNums, fmt.Errorf("unable to pause the writes on %s: %v", addr, err)
+		}
+		chngNums[addr], err = cli.PauseWrites(timeout)
+		cli.Close()
+		if err != nil {
+			return chngNums, fmt.Errorf("unable to pause the writes on %s: %v", addr, err)
+		}
+	}
+	return chngNums, nil
+}
+
+// resumeWrites resumes the writes on the given nodes. Failures are only
+// logged, since the nodes resume the writes once their pause times out.
+func (d *discoverService) resumeWrites(chngNums map[string]uint64) {
+	for addr := range chngNums {
+		cli, err := d.nodeClient(addr)
+		if err == nil {
+			err = cli.ResumeWrites()
+			cli.Close()
+		}
+		if err != nil {
+			d.logger.Error("Unable to resume the writes", zap.String("NodeAddress", addr), zap.Error(err))
+		}
+	}
+}
+
+// shardLeaders finds the leader of every shard of the given database,
+// failing when any shard is without an active leader.
+func (d *discoverService) shardLeaders(ctx context.Context, database string) ([]*serverpb.RegionInfo, error) {
+	clusterInfo, err := d.GetClusterInfo(ctx, &serverpb.GetClusterInfoRequest{Database: &database})
+	if err != nil {
+		return nil, err
+	}
+	shards := make(map[string]*serverpb.RegionInfo)
+	for _, regionInfo := range clusterInfo.GetRegionInfos() {
+		if _, present := shards[regionInfo.GetVBucket()]; !present || regionInfo.GetStatus() == serverpb.RegionStatus_LEADER {
+			shards[regionInfo.GetVBucket()] = regionInfo
+		}
+	}
+	if len(shards) == 0 {
+		return nil, fmt.Errorf("no active shards found for database %s", database)
+	}
+	leaders := make([]*serverpb.RegionInfo, 0, len(shards))
+	for vBucket, regionInfo := range shards {
+		if regionInfo.GetStatus() != serverpb.RegionStatus_LEADER {
+			return nil, fmt.Errorf("no active leader found for shard %s of database %s", vBucket, database)
+		}
+		leaders = append(leaders, regionInfo)
+	}
+	sort.Slice(leaders, func(i, j int) bool { return leaders[i].GetVBucket() < leaders[j].GetVBucket() })
+	return leaders, nil
+}
+
+// backupShard backs up the shard led by the given node into the given
+// path and retrieves the change number upto which the backup is complete.
+func (d *discoverService) backupShard(leader *serverpb.RegionInfo, backupPath string) (*serverpb.ShardBackup, error) {
+	cli, err := d.nodeClient(leader.GetNodeAddress())
+	if err != nil {
+		return nil, err
+	}
+	defer cli.Close()
+
+	if err = cli.Backup(backupPath); err != nil {
+		return nil, err
+	}
+	chngNum, createdAt, err := cli.GetBackupManifest(backupPath)
+	if err != nil {
+		return nil, err
+	}
+	return &serverpb.ShardBackup{
+		VBucket:      leader.GetVBucket(),
+		NodeAddress:  leader.GetNodeAddress(),
+		BackupPath:   backupPath,
+		ChangeNumber: chngNum,
+		CreatedAt:    createdAt,
+	}, nil
+}
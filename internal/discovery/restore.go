@@ -0,0 +1,212 @@
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"sync"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"go.uber.org/zap"
+)
+
+/*
+A cluster restore rebuilds every shard of a database upto the change number
+recorded for it in the cluster backup manifest. The leader of every shard
+and the followers of the leader are restored upto that change number using
+point in time restores. Since the change numbers of a restored shard move
+backwards, its slaves are then restored from the same backup, so that they
+resume replicating from the restored change number. Once restored, every
+member of every shard is verified to be at the restored change number, not
+ahead of the leader of its shard. Every node stops serving requests only
+while it is being restored, hence the clients are expected to be quiesced
+for the duration of a cluster restore.
+*/
+
+func (d *discoverService) RestoreCluster(ctx context.Context, request *serverpb.RestoreClusterRequest) (*serverpb.RestoreClusterResponse, error) {
+	res, err := d.restoreCluster(ctx, request)
+	if err != nil {
+		d.logger.Error("Unable to perform cluster restore", zap.String("ManifestPath", request.GetManifestPath()), zap.Error(err))
+		return &serverpb.RestoreClusterResponse{Status: newErrorStatus(err)}, err
+	}
+	return res, nil
+}
+
+func (d *discoverService) restoreCluster(ctx context.Context, request *serverpb.RestoreClusterRequest) (*serverpb.RestoreClusterResponse, error) {
+	if request.GetManifestPath() == "" {
+		return nil, errors.New("cluster backup manifest path must be provided")
+	}
+	backup, err := readClusterBackupManifest(request.GetManifestPath())
+	if err != nil {
+		return nil, err
+	}
+	members, err := d.shardMembers(ctx, backup.GetDatabase())
+	if err != nil {
+		return nil, err
+	}
+
+	// A node hosting several shards hosts all of them in the same store,
+	// hence it is restored only once, from the latest of their backups
+	res := &serverpb.RestoreClusterResponse{Status: &serverpb.Status{Code: 0, Message: ""}}
+	masters, slaves := make(map[string]*serverpb.ShardBackup), make(map[string]*serverpb.ShardBackup)
+	for _, shard := range backup.GetShards() {
+		restored := &serverpb.ShardRestore{VBucket: shard.GetVBucket()}
+		for _, member := range members[shard.GetVBucket()] {
+			addr, nodes, others := member.GetNodeAddress(), masters, slaves
+			switch {
+			case member.MasterHost != nil || member.GetStatus() == serverpb.RegionStatus_ACTIVE_SLAVE:
+				nodes, others = slaves, masters
+				restored.SlaveAddresses = append(restored.SlaveAddresses, addr)
+			case member.GetStatus() == serverpb.RegionStatus_LEADER:
+				restored.NodeAddress = addr
+			default:
+				restored.FollowerAddresses = append(restored.FollowerAddresses, addr)
+			}
+			if _, present := others[addr]; present {
+				return nil, fmt.Errorf("node %s hosts both masters and slaves of database %s", addr, backup.GetDatabase())
+			}
+			if latest, present := nodes[addr]; !present || shard.GetChangeNumber() > latest.GetChangeNumber() {
+				nodes[addr] = shard
+			}
+		}
+		if restored.NodeAddress == "" {
+			return nil, fmt.Errorf("no active leader found for shard %s of database %s", shard.GetVBucket(), backup.GetDatabase())
+		}
+		res.Shards = append(res.Shards, restored)
+	}
+
+	// Slaves are restored only once their masters are, lest they
+	// replicate the changes being undone
+	if err = d.restoreNodes(masters, true); err != nil {
+		return nil, err
+	}
+	if err = d.restoreNodes(slaves, false); err != nil {
+		return nil, err
+	}
+	for _, restored := range res.Shards {
+		restored.ChangeNumber = masters[restored.NodeAddress].GetChangeNumber()
+	}
+	if err = d.verifyRestore(res.Shards, masters, slaves); err != nil {
+		return nil, err
+	}
+	d.logger.Info("Completed cluster restore", zap.String("Database", backup.GetDatabase()),
+		zap.Int("NumShards", len(res.Shards)), zap.String("ManifestPath", request.GetManifestPath()))
+	return res, nil
+}
+
+func readClusterBackupManifest(manifestPath string) (*serverpb.BackupClusterResponse, error) {
+	data, err := ioutil.ReadFile(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read cluster backup manifest: %v", err)
+	}
+	backup := &serverpb.BackupClusterResponse{}
+	if err = json.Unmarshal(data, backup); err != nil {
+		return nil, fmt.Errorf("unable to parse cluster backup manifest: %v", err)
+	}
+	if backup.GetDatabase() == "" || len(backup.GetShards()) == 0 {
+		return nil, fmt.Errorf("cluster backup manifest at %s does not record any shard backups", manifestPath)
+	}
+	return backup, nil
+}
+
+// shardMembers finds all the members of every shard of the given database,
+// being their leaders, followers and slaves.
+func (d *discoverService) shardMembers(ctx context.Context, database string) (map[string][]*serverpb.RegionInfo, error) {
+	clusterInfo, err := d.GetClusterInfo(ctx, &serverpb.GetClusterInfoRequest{Database: &database})
+	if err != nil {
+		return nil, err
+	}
+	members := make(map[string][]*serverpb.RegionInfo)
+	for _, regionInfo := range clusterInfo.GetRegionInfos() {
+		members[regionInfo.GetVBucket()] = append(members[regionInfo.GetVBucket()], regionInfo)
+	}
+	return members, nil
+}
+
+// restoreNodes concurrently restores the given nodes from their given shard
+// backups, upto the change numbers of the backups if pointInTime is set.
+func (d *discoverService) restoreNodes(nodes map[string]*serverpb.ShardBackup, pointInTime bool) error {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	errs := make(map[string]error)
+	for addr, shard := range nodes {
+		wg.Add(1)
+		go func(addr string, shard *serverpb.ShardBackup) {
+			defer wg.Done()
+			if err := d.restoreNode(addr, shard, pointInTime); err != nil {
+				mu.Lock()
+				errs[addr] = err
+				mu.Unlock()
+			}
+		}(addr, shard)
+	}
+	wg.Wait()
+	for addr, err := range errs {
+		return fmt.Errorf("unable to restore %s: %v", addr, err)
+	}
+	return nil
+}
+
+func (d *discoverService) restoreNode(addr string, shard *serverpb.ShardBackup, pointInTime bool) error {
+	cli, err := d.nodeClient(addr)
+	if err != nil {
+		return err
+	}
+	defer cli.Close()
+	if pointInTime {
+		return cli.PointInTimeRestore(shard.GetBackupPath(), shard.GetChangeNumber())
+	}
+	return cli.Restore(shard.GetBackupPath())
+}
+
+// verifyRestore verifies that every member of the given restored shards is
+// not behind the change number upto which it was restored, and that none
+// is ahead of the leader of its shard.
+func (d *discoverService) verifyRestore(shards []*serverpb.ShardRestore, masters, slaves map[string]*serverpb.ShardBackup) error {
+	chngNums := make(map[string]uint64, len(masters)+len(slaves))
+	for _, nodes := range []map[string]*serverpb.ShardBackup{masters, slaves} {
+		for addr, shard := range nodes {
+			chngNum, err := d.latestChangeNumber(addr)
+			if err != nil {
+				return fmt.Errorf("unable to verify %s: %v", addr, err)
+			}
+			if chngNum < shard.GetChangeNumber() {
+				return fmt.Errorf("%s is at change number %d, behind the backup change number %d", addr, chngNum, shard.GetChangeNumber())
+			}
+			chngNums[addr] = chngNum
+		}
+	}
+	for _, shard := range shards {
+		leaderChngNum := chngNums[shard.GetNodeAddress()]
+		for _, addrs := range [][]string{shard.GetFollowerAddresses(), shard.GetSlaveAddresses()} {
+			for _, addr := range addrs {
+				if chngNums[addr] > leaderChngNum {
+					return fmt.Errorf("%s is at change number %d, ahead of the leader %s of shard %s at change number %d",
+						addr, chngNums[addr], shard.GetNodeAddress(), shard.GetVBucket(), leaderChngNum)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (d *discoverService) latestChangeNumber(addr string) (uint64, error) {
+	cli, err := d.nodeClient(addr)
+	if err != nil {
+		return 0, err
+	}
+	defer cli.Close()
+	// Asking for the changes beyond any committed change retrieves only
+	// the latest change number
+	chngsRes, err := cli.GetChanges(math.MaxUint64, 1)
+	if err == nil && chngsRes.GetStatus().GetCode() != 0 {
+		err = errors.New(chngsRes.GetStatus().GetMessage())
+	}
+	if err != nil {
+		return 0, err
+	}
+	return chngsRes.GetMasterChangeNumber(), nil
+}
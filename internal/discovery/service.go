@@ -41,6 +41,8 @@ type discoverService struct {
 	dkvCli serverpb.DKVClient
 	logger *zap.Logger
 	config *DiscoveryConfig
+	// creates clients to individual nodes of the cluster, used for cluster backups
+	nodeClient func(nodeAddr string) (*ctl.DKVClient, error)
 }
 
 // Create the dkv lookup key from region info
@@ -133,7 +135,10 @@ func NewDiscoveryService(dkvService serverpb.DKVServer, logger *zap.Logger, conf
 	if err != nil {
 		return nil, err
 	}
-	return &discoverService{dkvCli: dkvClient, logger: logger, config: config}, nil
+	nodeClient := func(nodeAddr string) (*ctl.DKVClient, error) {
+		return ctl.NewInSecureDKVClient(nodeAddr, "")
+	}
+	return &discoverService{dkvCli: dkvClient, logger: logger, config: config, nodeClient: nodeClient}, nil
 }
 
 func newErrorStatus(err error) *serverpb.Status {
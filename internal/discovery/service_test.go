@@ -1,6 +1,7 @@
 package discovery
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/flipkart-incubator/dkv/internal/master"
 	"github.com/flipkart-incubator/dkv/internal/storage"
@@ -10,6 +11,7 @@ import (
 	"github.com/flipkart-incubator/dkv/pkg/serverpb"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"io/ioutil"
 	"net"
 	"os/exec"
 	"testing"
@@ -154,6 +156,172 @@ func TestDKVDiscoveryService(t *testing.T) {
 	}
 }
 
+func TestClusterBackup(t *testing.T) {
+	bkpSvcPort, bkpPath := dkvSvcPort+1, "/tmp/dkv_discovery_test_cluster_backup"
+	if err := exec.Command("rm", "-rf", bkpPath, bkpPath+"_quiesced").Run(); err != nil {
+		t.Fatal(err)
+	}
+	dkvSvc, grpcSvc := serveStandaloneDKVWithDiscovery(bkpSvcPort, &serverpb.RegionInfo{}, dbFolder+"_CB")
+	defer dkvSvc.Close()
+	defer grpcSvc.GracefulStop()
+
+	svcAddr := fmt.Sprintf("%s:%d", dkvSvcHost, bkpSvcPort)
+	dkvCli, err := ctl.NewInSecureDKVClient(svcAddr, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dkvCli.Close()
+	for i := 1; i <= 10; i++ {
+		if err = dkvCli.Put([]byte(fmt.Sprintf("BackupKey%d", i)), []byte("BackupValue")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The same node leads both the shards, with a slave for one of them
+	masterHost := svcAddr
+	for _, vBucket := range []string{"vbucket2", "vbucket1"} {
+		dkvCli.UpdateStatus(serverpb.RegionInfo{DcID: "dc1", NodeAddress: svcAddr, Database: "bkpdb",
+			VBucket: vBucket, Status: serverpb.RegionStatus_LEADER})
+	}
+	dkvCli.UpdateStatus(serverpb.RegionInfo{DcID: "dc1", NodeAddress: "host2:port", Database: "bkpdb",
+		VBucket: "vbucket1", Status: serverpb.RegionStatus_ACTIVE_SLAVE, MasterHost: &masterHost})
+
+	res, err := dkvCli.BackupCluster("bkpdb", bkpPath)
+	if err != nil {
+		t.Fatalf("Unable to perform cluster backup. Error: %v", err)
+	}
+	if len(res.Shards) != 2 || res.Shards[0].VBucket != "vbucket1" || res.Shards[1].VBucket != "vbucket2" {
+		t.Fatalf("Expected backups of both the shards in order. Actual: %v", res.Shards)
+	}
+	for _, shard := range res.Shards {
+		if shard.NodeAddress != svcAddr || shard.ChangeNumber == 0 || shard.BackupPath != bkpPath+"/bkpdb/"+shard.VBucket {
+			t.Errorf("Unexpected backup of shard %s: %v", shard.VBucket, shard)
+		}
+	}
+
+	manifest, err := ioutil.ReadFile(res.ManifestPath)
+	if err != nil {
+		t.Fatalf("Unable to read cluster backup manifest. Error: %v", err)
+	}
+	stored := &serverpb.BackupClusterResponse{}
+	if err = json.Unmarshal(manifest, stored); err != nil {
+		t.Fatalf("Unable to parse cluster backup manifest. Error: %v", err)
+	}
+	if len(stored.Shards) != 2 || stored.Shards[0].ChangeNumber != res.Shards[0].ChangeNumber || stored.CompletedAt < stored.StartedAt {
+		t.Errorf("Cluster backup manifest mismatch. Expected: %v, Actual: %v", res, stored)
+	}
+
+	// Shard backups must be restorable upto their change numbers
+	if err = dkvCli.PointInTimeRestore(res.Shards[0].BackupPath, res.Shards[0].ChangeNumber); err != nil {
+		t.Errorf("Unable to restore shard backup. Error: %v", err)
+	}
+
+	// Quiesced backups record the change numbers of the shards as of the
+	// pause of their writes, which are resumed after the backup
+	if err = dkvCli.Put([]byte("BackupKey11"), []byte("BackupValue")); err != nil {
+		t.Fatal(err)
+	}
+	res, err = dkvCli.QuiescedBackupCluster("bkpdb", bkpPath+"_quiesced", time.Minute)
+	if err != nil {
+		t.Fatalf("Unable to perform quiesced cluster backup. Error: %v", err)
+	}
+	if !res.Quiesced || len(res.Shards) != 2 {
+		t.Fatalf("Expected quiesced backups of both the shards. Actual: %v", res)
+	}
+	for _, shard := range res.Shards {
+		if shard.QuiescedChangeNumber == 0 || shard.QuiescedChangeNumber != shard.ChangeNumber {
+			t.Errorf("Unexpected quiesced backup of shard %s: %v", shard.VBucket, shard)
+		}
+	}
+	if manifest, err = ioutil.ReadFile(res.ManifestPath); err != nil {
+		t.Fatalf("Unable to read cluster backup manifest. Error: %v", err)
+	}
+	stored = &serverpb.BackupClusterResponse{}
+	if err = json.Unmarshal(manifest, stored); err != nil {
+		t.Fatalf("Unable to parse cluster backup manifest. Error: %v", err)
+	}
+	if !stored.Quiesced || len(stored.Shards) != 2 || stored.Shards[0].QuiescedChangeNumber != res.Shards[0].QuiescedChangeNumber ||
+		stored.Shards[1].QuiescedChangeNumber != res.Shards[1].QuiescedChangeNumber {
+		t.Errorf("Expected the change number vector in the cluster backup manifest. Expected: %v, Actual: %v", res, stored)
+	}
+	if err = dkvCli.Put([]byte("BackupKey12"), []byte("BackupValue")); err != nil {
+		t.Errorf("Expected the writes to be resumed after the quiesced backup, but got error: %v", err)
+	}
+
+	// Shards without an active leader fail the cluster backup
+	dkvCli.UpdateStatus(serverpb.RegionInfo{DcID: "dc1", NodeAddress: "host3:port", Database: "bkpdb",
+		VBucket: "vbucket3", Status: serverpb.RegionStatus_ACTIVE_SLAVE, MasterHost: &masterHost})
+	if _, err = dkvCli.BackupCluster("bkpdb", bkpPath); err == nil {
+		t.Error("Expected cluster backup to fail for a shard without leader")
+	}
+	if _, err = dkvCli.BackupCluster("", bkpPath); err == nil {
+		t.Error("Expected cluster backup to fail without database")
+	}
+}
+
+func TestClusterRestore(t *testing.T) {
+	rstSvcPort, bkpPath := dkvSvcPort+2, "/tmp/dkv_discovery_test_cluster_restore"
+	if err := exec.Command("rm", "-rf", bkpPath).Run(); err != nil {
+		t.Fatal(err)
+	}
+	dkvSvc, grpcSvc := serveStandaloneDKVWithDiscovery(rstSvcPort, &serverpb.RegionInfo{}, dbFolder+"_CR")
+	defer dkvSvc.Close()
+	defer grpcSvc.GracefulStop()
+
+	svcAddr := fmt.Sprintf("%s:%d", dkvSvcHost, rstSvcPort)
+	dkvCli, err := ctl.NewInSecureDKVClient(svcAddr, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dkvCli.Close()
+	for _, vBucket := range []string{"vbucket1", "vbucket2"} {
+		dkvCli.UpdateStatus(serverpb.RegionInfo{DcID: "dc1", NodeAddress: svcAddr, Database: "rstdb",
+			VBucket: vBucket, Status: serverpb.RegionStatus_LEADER})
+	}
+	for i := 1; i <= 10; i++ {
+		if err = dkvCli.Put([]byte(fmt.Sprintf("RestoreKey%d", i)), []byte("BackupValue")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	bkpRes, err := dkvCli.BackupCluster("rstdb", bkpPath)
+	if err != nil {
+		t.Fatalf("Unable to perform cluster backup. Error: %v", err)
+	}
+
+	// Changes made after the backup are undone by the restore
+	for i := 1; i <= 10; i++ {
+		if err = dkvCli.Put([]byte(fmt.Sprintf("RestoreKey%d", i)), []byte("UpdatedValue")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	res, err := dkvCli.RestoreCluster(bkpRes.ManifestPath)
+	if err != nil {
+		t.Fatalf("Unable to perform cluster restore. Error: %v", err)
+	}
+	if len(res.Shards) != 2 {
+		t.Fatalf("Expected restores of both the shards. Actual: %v", res.Shards)
+	}
+	// Both the shards are led by the same node, which is restored once
+	// from the latest of their backups
+	for _, shard := range res.Shards {
+		if shard.NodeAddress != svcAddr || shard.ChangeNumber != bkpRes.Shards[1].ChangeNumber || len(shard.SlaveAddresses) != 0 {
+			t.Errorf("Unexpected restore of shard %s: %v", shard.VBucket, shard)
+		}
+	}
+	for i := 1; i <= 10; i++ {
+		key := fmt.Sprintf("RestoreKey%d", i)
+		if res, err := dkvCli.Get(serverpb.ReadConsistency_SEQUENTIAL, []byte(key)); err != nil {
+			t.Errorf("Unable to get key %s. Error: %v", key, err)
+		} else if string(res.Value) != "BackupValue" {
+			t.Errorf("Value mismatch for key %s after restore. Expected: BackupValue, Actual: %s", key, res.Value)
+		}
+	}
+
+	if _, err = dkvCli.RestoreCluster(bkpPath + "/missing.manifest"); err == nil {
+		t.Error("Expected cluster restore to fail without a manifest")
+	}
+}
+
 func serveStandaloneDKVWithDiscovery(port int, info *serverpb.RegionInfo, dbFolder string) (master.DKVService, *grpc.Server) {
 	kvs, cp, ba := newKVStore(dbFolder)
 	dkvSvc := master.NewStandaloneService(kvs, cp, ba, info, serveropts)
@@ -0,0 +1,305 @@
+// Package heatmap maintains a coarse heatmap of the keys read and written
+// through a DKV node, across a fixed number of key range buckets for every
+// minute, so that the dashboards can reveal the hot ranges before they
+// turn into incidents. Only one in every given number of calls is sampled,
+// keeping its overhead on the calls negligible.
+//
+// The boundaries of the buckets are derived from the keys sampled during
+// the previous minute, such that every bucket holds about as many of them,
+// hence the buckets follow the distribution of the keys in use, including
+// the keys sharing a common prefix. Until enough keys are sampled, the
+// keyspace is split evenly by the leading two bytes of the keys.
+package heatmap
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/rand"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"google.golang.org/grpc"
+)
+
+// Duration of every interval of the heatmap
+const interval = time.Minute
+
+// Until enough keys are sampled, keys are bucketed by their leading bytes,
+// upto which the buckets are partitioned
+const (
+	prefixBytes = 2
+	prefixSpace = 1 << (8 * prefixBytes)
+)
+
+// Number of keys retained per bucket for deriving the bucket boundaries of
+// the next minute, and the least number of them to have been sampled
+const (
+	samplesPerBucket    = 32
+	minSamplesPerBucket = 8
+)
+
+// MaxBuckets is the largest number of key range buckets of a heatmap.
+const MaxBuckets = 1024
+
+// ErrDisabled is returned by GetKeyHeatmap when the node has no heatmap.
+var ErrDisabled = errors.New("Key heatmap is disabled, set key-heatmap-buckets to enable it")
+
+// Heatmap accounts the keys of the sampled calls to their key range
+// buckets, retaining the given number of most recent minutes. It is safe
+// for concurrent use.
+type Heatmap struct {
+	numBuckets  int
+	sampleEvery uint64
+	calls       uint64
+	now         func() time.Time
+
+	mu        sync.Mutex
+	intervals []*heatmapInterval
+	// Start of the minute in progress and the start keys of its buckets
+	latest int64
+	starts [][]byte
+	// Keys retained out of those sampled during the minute in progress,
+	// from which the bucket boundaries of the next minute are derived
+	samples [][]byte
+	sampled int64
+	rnd     *rand.Rand
+}
+
+// heatmapInterval holds the sampled keys of a minute, per bucket.
+type heatmapInterval struct {
+	start         int64
+	starts        [][]byte
+	reads, writes []uint64
+}
+
+// New creates a Heatmap partitioning the keyspace into the given number of
+// buckets, retaining the given number of minutes and sampling one in every
+// given number of calls.
+func New(numBuckets, minutes, sampleEvery int) *Heatmap {
+	hm := &Heatmap{numBuckets: numBuckets, sampleEvery: uint64(sampleEvery), now: time.Now}
+	hm.intervals = make([]*heatmapInterval, minutes)
+	for i := range hm.intervals {
+		hm.intervals[i] = &heatmapInterval{start: -1, reads: make([]uint64, numBuckets), writes: make([]uint64, numBuckets)}
+	}
+	hm.starts = evenStarts(numBuckets)
+	hm.latest = -1
+	hm.samples = make([][]byte, 0, numBuckets*samplesPerBucket)
+	hm.rnd = rand.New(rand.NewSource(time.Now().UnixNano()))
+	return hm
+}
+
+// evenStarts determines the start keys of the given number of buckets
+// splitting the keyspace evenly by the leading bytes of the keys, the
+// first of which is empty. Every bucket starts at the smallest prefix
+// accounted to it.
+func evenStarts(numBuckets int) [][]byte {
+	starts := make([][]byte, numBuckets)
+	for bucket := 1; bucket < numBuckets; bucket++ {
+		prefix := (bucket*prefixSpace + numBuckets - 1) / numBuckets
+		start := make([]byte, prefixBytes)
+		for i := prefixBytes - 1; i >= 0; i-- {
+			start[i] = byte(prefix)
+			prefix >>= 8
+		}
+		starts[bucket] = start
+	}
+	return starts
+}
+
+// sampledStarts determines the start keys of the given number of buckets
+// each holding about as many of the given sampled keys, the first of which
+// is empty. The buckets of the keys sampled most often may be empty.
+func sampledStarts(numBuckets int, samples [][]byte) [][]byte {
+	sorted := append([][]byte{}, samples...)
+	sort.Slice(sorted, func(i, j int) bool { return bytes.Compare(sorted[i], sorted[j]) < 0 })
+	starts := make([][]byte, numBuckets)
+	for bucket := 1; bucket < numBuckets; bucket++ {
+		starts[bucket] = sorted[bucket*len(sorted)/numBuckets]
+	}
+	return starts
+}
+
+// bucket determines the bucket of the given key among the buckets with
+// the given start keys.
+func bucket(starts [][]byte, key []byte) int {
+	return sort.Search(len(starts), func(i int) bool { return bytes.Compare(starts[i], key) > 0 }) - 1
+}
+
+// intervalStart determines the start of the minute of the given time, in
+// seconds since the epoch.
+func intervalStart(t time.Time) int64 {
+	return t.Truncate(interval).Unix()
+}
+
+// sample determines if the call being served is to be sampled.
+func (hm *Heatmap) sample() bool {
+	return atomic.AddUint64(&hm.calls, 1)%hm.sampleEvery == 0
+}
+
+// record accounts the given keys read or written by a sampled call.
+func (hm *Heatmap) record(write bool, keys [][]byte) {
+	start := intervalStart(hm.now())
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+	hm.advance(start)
+	hi := hm.intervals[int(start/int64(interval/time.Second))%len(hm.intervals)]
+	if hi.start != start {
+		hi.start, hi.starts = start, hm.starts
+		for i := range hi.reads {
+			hi.reads[i], hi.writes[i] = 0, 0
+		}
+	}
+	counts := hi.reads
+	if write {
+		counts = hi.writes
+	}
+	for _, key := range keys {
+		counts[bucket(hi.starts, key)]++
+		hm.retain(key)
+	}
+}
+
+// advance moves onto the minute starting at the given time, deriving its
+// bucket boundaries from the keys sampled during the previous minute, if
+// enough of them were sampled. The boundaries are retained otherwise.
+func (hm *Heatmap) advance(start int64) {
+	if start <= hm.latest {
+		return
+	}
+	if start == hm.latest+int64(interval/time.Second) && len(hm.samples) >= hm.numBuckets*minSamplesPerBucket {
+		hm.starts = sampledStarts(hm.numBuckets, hm.samples)
+	}
+	hm.latest, hm.samples, hm.sampled = start, hm.samples[:0], 0
+}
+
+// retain retains a copy of the given sampled key, replacing a random one
+// once the keys retained are at capacity, such that every key sampled
+// during the minute is equally likely to be retained.
+func (hm *Heatmap) retain(key []byte) {
+	hm.sampled++
+	if len(hm.samples) < cap(hm.samples) {
+		hm.samples = append(hm.samples, append([]byte{}, key...))
+	} else if i := hm.rnd.Int63n(hm.sampled); i < int64(len(hm.samples)) {
+		hm.samples[i] = append([]byte{}, key...)
+	}
+}
+
+// Snapshot retrieves the heatmap of the given number of most recent
+// minutes, limited to the minutes retained. Rates of the minute in
+// progress are estimated over its elapsed duration.
+func (hm *Heatmap) Snapshot(minutes int) *serverpb.GetKeyHeatmapResponse {
+	if minutes <= 0 || minutes > len(hm.intervals) {
+		minutes = len(hm.intervals)
+	}
+	res := &serverpb.GetKeyHeatmapResponse{SampleEvery: uint32(hm.sampleEvery)}
+
+	now := hm.now()
+	latest := intervalStart(now)
+	intervalSecs := int64(interval / time.Second)
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+	hm.advance(latest)
+	for start := latest - int64(minutes-1)*intervalSecs; start <= latest; start += intervalSecs {
+		secs := float64(intervalSecs)
+		if start == latest {
+			secs = now.Sub(time.Unix(start, 0)).Seconds()
+			if secs < 1 {
+				secs = 1
+			}
+		}
+		khi := &serverpb.KeyHeatmapInterval{StartTime: start, ReadRates: make([]float64, hm.numBuckets), WriteRates: make([]float64, hm.numBuckets)}
+		// Minutes without any sampled call are reported with zero rates,
+		// across the buckets of the minute in progress
+		starts := hm.starts
+		if hi := hm.intervals[int(start/intervalSecs)%len(hm.intervals)]; hi.start == start {
+			starts = hi.starts
+			for i := range hi.reads {
+				khi.ReadRates[i] = float64(hi.reads[i]*hm.sampleEvery) / secs
+				khi.WriteRates[i] = float64(hi.writes[i]*hm.sampleEvery) / secs
+			}
+		}
+		khi.Buckets = keyRangeBuckets(starts)
+		res.Intervals = append(res.Intervals, khi)
+	}
+	res.Buckets = res.Intervals[len(res.Intervals)-1].Buckets
+	return res
+}
+
+// keyRangeBuckets determines the contiguous key ranges of the buckets with
+// the given start keys.
+func keyRangeBuckets(starts [][]byte) []*serverpb.KeyRangeBucket {
+	buckets := make([]*serverpb.KeyRangeBucket, len(starts))
+	for i := range buckets {
+		buckets[i] = &serverpb.KeyRangeBucket{StartKey: starts[i]}
+		if i > 0 {
+			buckets[i-1].EndKey = starts[i]
+		}
+	}
+	return buckets
+}
+
+// GetKeyHeatmap serves the GetKeyHeatmap calls of the DKV services using
+// the given heatmap, which is nil when the heatmap is disabled.
+func GetKeyHeatmap(hm *Heatmap, hmReq *serverpb.GetKeyHeatmapRequest) (*serverpb.GetKeyHeatmapResponse, error) {
+	if hm == nil {
+		return &serverpb.GetKeyHeatmapResponse{Status: &serverpb.Status{Code: -1, Message: ErrDisabled.Error()}}, ErrDisabled
+	}
+	res := hm.Snapshot(int(hmReq.GetMinutes()))
+	res.Status = &serverpb.Status{Code: 0, Message: ""}
+	return res, nil
+}
+
+// Key value calls accounted by the heatmap, mapped to whether they write
+var accountedCalls = map[string]bool{
+	"/dkv.serverpb.DKV/Get":           false,
+	"/dkv.serverpb.DKV/MultiGet":      false,
+	"/dkv.serverpb.DKV/Exists":        false,
+	"/dkv.serverpb.DKV/Put":           true,
+	"/dkv.serverpb.DKV/MultiPut":      true,
+	"/dkv.serverpb.DKV/Delete":        true,
+	"/dkv.serverpb.DKV/CompareAndSet": true,
+	"/dkv.serverpb.DKV/Merge":         true,
+}
+
+// keysOf retrieves the keys of the given key value call.
+func keysOf(req interface{}) [][]byte {
+	switch r := req.(type) {
+	case *serverpb.GetRequest:
+		return [][]byte{r.Key}
+	case *serverpb.MultiGetRequest:
+		return r.Keys
+	case *serverpb.ExistsRequest:
+		return [][]byte{r.Key}
+	case *serverpb.PutRequest:
+		return [][]byte{r.Key}
+	case *serverpb.MultiPutRequest:
+		keys := make([][]byte, len(r.PutRequest))
+		for i, put := range r.PutRequest {
+			keys[i] = put.Key
+		}
+		return keys
+	case *serverpb.DeleteRequest:
+		return [][]byte{r.Key}
+	case *serverpb.CompareAndSetRequest:
+		return [][]byte{r.Key}
+	case *serverpb.MergeRequest:
+		return [][]byte{r.Key}
+	default:
+		return nil
+	}
+}
+
+// UnaryServerInterceptor accounts the keys of the sampled key value calls
+// served by the node, namely the reads, the writes and the deletes.
+func (hm *Heatmap) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if write, ok := accountedCalls[info.FullMethod]; ok && hm.sample() {
+			hm.record(write, keysOf(req))
+		}
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,129 @@
+package heatmap
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"google.golang.org/grpc"
+)
+
+func TestBuckets(t *testing.T) {
+	starts := evenStarts(3)
+	for key, expBucket := range map[string]int{"": 0, "\x00": 0, "\x55\x55": 0, "\x55\x56": 1, "\xaa\xaa": 1, "\xaa\xab": 2, "\xff\xff\xff": 2} {
+		if bucket := bucket(starts, []byte(key)); bucket != expBucket {
+			t.Errorf("Expected key %q in bucket %d, but got %d", key, expBucket, bucket)
+		}
+	}
+	// Every bucket starts at the smallest key accounted to it
+	for b := 1; b < 3; b++ {
+		prev := append([]byte{}, starts[b]...)
+		if prev[1]--; prev[1] == 0xff {
+			prev[0]--
+		}
+		if bucket(starts, starts[b]) != b || bucket(starts, prev) != b-1 {
+			t.Errorf("Expected bucket %d to start at %x", b, starts[b])
+		}
+	}
+}
+
+func TestSampledBuckets(t *testing.T) {
+	now := time.Unix(6000, 0)
+	hm := New(4, 2, 1)
+	hm.now = func() time.Time { return now }
+
+	// Keys sharing a prefix fall in a single bucket until enough of them
+	// are sampled, upon which the next minute splits them evenly
+	for i := 0; i < 100; i++ {
+		hm.record(false, [][]byte{[]byte(fmt.Sprintf("user:%02d", i))})
+	}
+	res := hm.Snapshot(1)
+	if !equalRates(res.Intervals[0].ReadRates, []float64{0, 100, 0, 0}) {
+		t.Errorf("Expected the keys in the second bucket, but got %v", res.Intervals[0].ReadRates)
+	}
+	now = now.Add(time.Minute)
+	for i := 0; i < 100; i++ {
+		hm.record(true, [][]byte{[]byte(fmt.Sprintf("user:%02d", i))})
+	}
+	res = hm.Snapshot(2)
+	expStarts := [][]byte{nil, []byte("user:25"), []byte("user:50"), []byte("user:75")}
+	for i, bucket := range res.Buckets {
+		if !bytes.Equal(bucket.StartKey, expStarts[i]) {
+			t.Errorf("Expected bucket %d to start at %q, but got %q", i, expStarts[i], bucket.StartKey)
+		}
+	}
+	if !equalRates(res.Intervals[1].WriteRates, []float64{25, 25, 25, 25}) {
+		t.Errorf("Expected the keys split evenly, but got %v", res.Intervals[1].WriteRates)
+	}
+	// Earlier minutes retain their buckets
+	if len(res.Intervals[0].Buckets) != 4 || !bytes.Equal(res.Intervals[0].Buckets[1].StartKey, []byte{0x40, 0}) {
+		t.Errorf("Expected the earlier minute to retain its buckets, but got %v", res.Intervals[0].Buckets)
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	now := time.Unix(5950, 0)
+	hm := New(4, 3, 2)
+	hm.now = func() time.Time { return now }
+	intercept := hm.UnaryServerInterceptor()
+	call := func(method string, req interface{}) {
+		info := &grpc.UnaryServerInfo{FullMethod: "/dkv.serverpb.DKV/" + method}
+		if _, err := intercept(context.Background(), req, info, func(context.Context, interface{}) (interface{}, error) { return nil, nil }); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Every other call is sampled, the calls of other methods being ignored
+	for i := 0; i < 4; i++ {
+		call("Get", &serverpb.GetRequest{Key: []byte("\x10key")})
+		call("Iterate", &serverpb.IterateRequest{StartKey: []byte("\xf0key")})
+	}
+	call("Delete", &serverpb.DeleteRequest{Key: []byte("\xf0c")})
+	call("MultiPut", &serverpb.MultiPutRequest{PutRequest: []*serverpb.PutRequest{{Key: []byte("\xf0a")}, {Key: []byte("\xf0b")}}})
+	now = now.Add(80 * time.Second)
+	call("Put", &serverpb.PutRequest{Key: []byte("\x80c")})
+	call("MultiGet", &serverpb.MultiGetRequest{Keys: [][]byte{[]byte("\x80a"), []byte("\x80b"), []byte("\x10a")}})
+
+	res := hm.Snapshot(0)
+	if len(res.Buckets) != 4 || res.SampleEvery != 2 {
+		t.Fatalf("Expected 4 buckets sampling every other call, but got %v", res)
+	}
+	if start := res.Buckets[2].StartKey; !bytes.Equal(start, []byte{0x80, 0}) || !bytes.Equal(res.Buckets[1].EndKey, start) || res.Buckets[3].EndKey != nil {
+		t.Errorf("Expected contiguous key ranges, but got %v", res.Buckets)
+	}
+	expIntervals := []*serverpb.KeyHeatmapInterval{
+		{StartTime: 5880, ReadRates: []float64{0, 0, 0, 0}, WriteRates: []float64{0, 0, 0, 0}},
+		{StartTime: 5940, ReadRates: []float64{4.0 / 60, 0, 0, 0}, WriteRates: []float64{0, 0, 0, 4.0 / 60}},
+		{StartTime: 6000, ReadRates: []float64{2.0 / 30, 0, 4.0 / 30, 0}, WriteRates: []float64{0, 0, 0, 0}},
+	}
+	if len(res.Intervals) != len(expIntervals) {
+		t.Fatalf("Expected %d intervals, but got %v", len(expIntervals), res.Intervals)
+	}
+	for i, exp := range expIntervals {
+		act := res.Intervals[i]
+		if act.StartTime != exp.StartTime || !equalRates(act.ReadRates, exp.ReadRates) || !equalRates(act.WriteRates, exp.WriteRates) {
+			t.Errorf("Expected interval %v, but got %v", exp, act)
+		}
+	}
+
+	// Minutes beyond those retained are recycled
+	now = now.Add(3 * time.Minute)
+	if res = hm.Snapshot(1); len(res.Intervals) != 1 || !equalRates(res.Intervals[0].ReadRates, []float64{0, 0, 0, 0}) {
+		t.Errorf("Expected a single interval without any calls, but got %v", res.Intervals)
+	}
+}
+
+func equalRates(act, exp []float64) bool {
+	if len(act) != len(exp) {
+		return false
+	}
+	for i := range act {
+		if diff := act[i] - exp[i]; diff > 1e-9 || diff < -1e-9 {
+			return false
+		}
+	}
+	return true
+}
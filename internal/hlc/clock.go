@@ -0,0 +1,43 @@
+package hlc
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// A Clock is a hybrid logical clock, whose timestamps are the unix times
+// in nanoseconds of the wall clock, unless the clock has observed later
+// timestamps, such as those of the writes replicated from other nodes.
+// Timestamps of a clock are unique and ever increasing, hence any event
+// timestamped after observing another event is ordered after it, despite
+// the skew between the wall clocks of their nodes. The zero value is
+// ready to use and is safe for concurrent use.
+type Clock struct {
+	// Shall be manipulated using atomics
+	last uint64
+}
+
+// Now retrieves the next timestamp of the clock.
+func (c *Clock) Now() uint64 {
+	for {
+		last := atomic.LoadUint64(&c.last)
+		ts := uint64(time.Now().UnixNano())
+		if ts <= last {
+			ts = last + 1
+		}
+		if atomic.CompareAndSwapUint64(&c.last, last, ts) {
+			return ts
+		}
+	}
+}
+
+// Observe advances the clock to the given timestamp, if it is later, so
+// that the subsequent timestamps of the clock follow it.
+func (c *Clock) Observe(ts uint64) {
+	for {
+		last := atomic.LoadUint64(&c.last)
+		if ts <= last || atomic.CompareAndSwapUint64(&c.last, last, ts) {
+			return
+		}
+	}
+}
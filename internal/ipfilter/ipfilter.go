@@ -0,0 +1,262 @@
+package ipfilter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Listener identifies the class of GRPC services that share the same
+// IP filtering rules.
+type Listener string
+
+const (
+	// Data covers the services reading and mutating the keyspace.
+	Data Listener = "data"
+	// Admin covers the services administering the node and its storage.
+	Admin Listener = "admin"
+	// Replication covers the services used by replicas and cluster members.
+	Replication Listener = "replication"
+)
+
+var serviceListeners = map[string]Listener{
+	"dkv.serverpb.DKV":              Data,
+	"dkv.serverpb.DKVAdmin":         Admin,
+	"dkv.serverpb.DKVBackupRestore": Admin,
+	"dkv.serverpb.DKVSnapshot":      Admin,
+	"dkv.serverpb.DKVCluster":       Admin,
+	"dkv.serverpb.DKVReplication":   Replication,
+	"dkv.serverpb.DKVDiscovery":     Replication,
+	"dkv.serverpb.DKVDiscoveryNode": Replication,
+}
+
+// Rules captures the allowed and denied IP addresses or CIDR blocks of
+// a listener. Denied entries take precedence over allowed ones, while
+// an empty allow list allows all the addresses that are not denied.
+type Rules struct {
+	Allow []string `json:"allow"`
+	Deny  []string `json:"deny"`
+}
+
+type compiledRules struct {
+	allow, deny []*net.IPNet
+}
+
+// Filters enforces the IP filtering rules of every listener, loaded
+// from a JSON file mapping listener names to their rules. The rules
+// can be reloaded at runtime without restarting the server.
+type Filters struct {
+	file    string
+	lgr     *zap.Logger
+	mu      sync.RWMutex
+	modTime time.Time
+	rules   map[Listener]*compiledRules
+}
+
+// Load reads the IP filtering rules from the given file.
+func Load(file string, lgr *zap.Logger) (*Filters, error) {
+	f := &Filters{file: file, lgr: lgr}
+	if _, err := f.Reload(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Reload re-reads the rules from the file if it has been modified since
+// the last load. On failure, the previously loaded rules stay in effect.
+// Returns true if new rules have been loaded.
+func (f *Filters) Reload() (bool, error) {
+	fi, err := os.Stat(f.file)
+	if err != nil {
+		return false, err
+	}
+	f.mu.RLock()
+	unchanged := fi.ModTime().Equal(f.modTime)
+	f.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	data, err := ioutil.ReadFile(f.file)
+	if err != nil {
+		return false, err
+	}
+	var lstnrRules map[Listener]*Rules
+	if err = json.Unmarshal(data, &lstnrRules); err != nil {
+		return false, fmt.Errorf("unable to parse IP filter rules from %s, error: %v", f.file, err)
+	}
+	rules := make(map[Listener]*compiledRules, len(lstnrRules))
+	for lstnr, lr := range lstnrRules {
+		switch lstnr {
+		case Data, Admin, Replication:
+		default:
+			return false, fmt.Errorf("unknown listener: %s, must be one of data|admin|replication", lstnr)
+		}
+		if rules[lstnr], err = lr.compile(); err != nil {
+			return false, fmt.Errorf("invalid IP filter rules of listener: %s, error: %v", lstnr, err)
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rules, f.modTime = rules, fi.ModTime()
+	return true, nil
+}
+
+// Watch periodically reloads the rules until the given channel is closed.
+func (f *Filters) Watch(interval time.Duration, stop <-chan struct{}) {
+	tckr := time.NewTicker(interval)
+	defer tckr.Stop()
+	for {
+		select {
+		case <-tckr.C:
+			if reloaded, err := f.Reload(); err != nil {
+				f.lgr.Error("Unable to reload IP filter rules", zap.String("File", f.file), zap.Error(err))
+			} else if reloaded {
+				f.lgr.Info("Reloaded IP filter rules", zap.String("File", f.file))
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+// Allowed checks if the given IP is permitted to access the given listener.
+func (f *Filters) Allowed(lstnr Listener, ip net.IP) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	cr, present := f.rules[lstnr]
+	if !present {
+		return true
+	}
+	if containsIP(cr.deny, ip) {
+		return false
+	}
+	return len(cr.allow) == 0 || containsIP(cr.allow, ip)
+}
+
+// UnaryServerInterceptor rejects the unary calls made from IPs that
+// are not permitted to access the listener of the invoked service.
+func (f *Filters) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := f.authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor rejects the streaming calls made from IPs
+// that are not permitted to access the listener of the invoked service.
+func (f *Filters) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := f.authorize(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func (f *Filters) authorize(ctx context.Context, fullMethod string) error {
+	lstnr, present := listenerOf(fullMethod)
+	if !present {
+		return nil
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return status.Error(codes.PermissionDenied, "unable to determine the peer address")
+	}
+	ip := peerIP(p.Addr)
+	if ip == nil || !f.Allowed(lstnr, ip) {
+		f.lgr.Warn("Rejected call by IP filter", zap.String("Listener", string(lstnr)),
+			zap.String("Method", fullMethod), zap.String("Peer", p.Addr.String()))
+		return status.Errorf(codes.PermissionDenied, "peer address: %s is not permitted to access %s services", p.Addr, lstnr)
+	}
+	return nil
+}
+
+// listenerOf resolves the listener of the given GRPC method, which is
+// in the /package.Service/Method format.
+func listenerOf(fullMethod string) (Listener, bool) {
+	svc := strings.TrimPrefix(fullMethod, "/")
+	if idx := strings.LastIndex(svc, "/"); idx >= 0 {
+		svc = svc[:idx]
+	}
+	lstnr, present := serviceListeners[svc]
+	return lstnr, present
+}
+
+func peerIP(addr net.Addr) net.IP {
+	switch a := addr.(type) {
+	case *net.TCPAddr:
+		return a.IP
+	default:
+		host, _, err := net.SplitHostPort(addr.String())
+		if err != nil {
+			return nil
+		}
+		return net.ParseIP(host)
+	}
+}
+
+func (lr *Rules) compile() (*compiledRules, error) {
+	if lr == nil {
+		return &compiledRules{}, nil
+	}
+	allow, err := parseNets(lr.Allow)
+	if err != nil {
+		return nil, err
+	}
+	deny, err := parseNets(lr.Deny)
+	if err != nil {
+		return nil, err
+	}
+	return &compiledRules{allow: allow, deny: deny}, nil
+}
+
+// parseNets parses the given IP addresses or CIDR blocks. Plain IP
+// addresses are treated as blocks of a single address.
+func parseNets(entries []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid IP address: %s", entry)
+			}
+			bits := 8 * net.IPv4len
+			if ip.To4() == nil {
+				bits = 8 * net.IPv6len
+			}
+			entry = fmt.Sprintf("%s/%d", entry, bits)
+		}
+		_, ipNet, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, err
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets, nil
+}
+
+func containsIP(nets []*net.IPNet, ip net.IP) bool {
+	for _, ipNet := range nets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,144 @@
+package ipfilter
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const rulesV1 = `{
+  "data": {"allow": ["10.0.0.0/8", "127.0.0.1"], "deny": ["10.1.0.0/16"]},
+  "admin": {"allow": ["127.0.0.1", "::1"]}
+}`
+
+const rulesV2 = `{
+  "data": {"deny": ["127.0.0.1"]}
+}`
+
+func TestAllowed(t *testing.T) {
+	f := loadFilters(t, rulesV1)
+
+	testCases := []struct {
+		lstnr   Listener
+		ip      string
+		allowed bool
+	}{
+		{Data, "10.2.3.4", true},
+		{Data, "127.0.0.1", true},
+		{Data, "10.1.2.3", false},
+		{Data, "192.168.1.1", false},
+		{Admin, "127.0.0.1", true},
+		{Admin, "::1", true},
+		{Admin, "10.2.3.4", false},
+		{Replication, "192.168.1.1", true},
+	}
+	for _, tc := range testCases {
+		if allowed := f.Allowed(tc.lstnr, net.ParseIP(tc.ip)); allowed != tc.allowed {
+			t.Errorf("Listener: %s, IP: %s. Expected allowed: %t, Actual: %t", tc.lstnr, tc.ip, tc.allowed, allowed)
+		}
+	}
+}
+
+func TestReload(t *testing.T) {
+	f := loadFilters(t, rulesV1)
+	if !f.Allowed(Data, net.ParseIP("127.0.0.1")) {
+		t.Fatal("Expected 127.0.0.1 to be allowed before reload")
+	}
+
+	if reloaded, err := f.Reload(); err != nil || reloaded {
+		t.Errorf("Expected no reload of an unmodified file. Reloaded: %t, Error: %v", reloaded, err)
+	}
+
+	writeRules(t, f.file, `{"data": {"allow": ["not-an-ip"]}}`)
+	if _, err := f.Reload(); err == nil {
+		t.Error("Expected an error while reloading invalid rules")
+	}
+	if !f.Allowed(Data, net.ParseIP("127.0.0.1")) {
+		t.Error("Expected the previous rules to stay in effect after a failed reload")
+	}
+
+	writeRules(t, f.file, rulesV2)
+	if reloaded, err := f.Reload(); err != nil || !reloaded {
+		t.Fatalf("Expected rules to be reloaded. Reloaded: %t, Error: %v", reloaded, err)
+	}
+	if f.Allowed(Data, net.ParseIP("127.0.0.1")) {
+		t.Error("Expected 127.0.0.1 to be denied after reload")
+	}
+	if !f.Allowed(Admin, net.ParseIP("10.2.3.4")) {
+		t.Error("Expected admin listener to be unrestricted after reload")
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	f := loadFilters(t, rulesV1)
+	intercept := f.UnaryServerInterceptor()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return req, nil }
+
+	testCases := []struct {
+		method, ip string
+		expCode    codes.Code
+	}{
+		{"/dkv.serverpb.DKV/Get", "10.2.3.4", codes.OK},
+		{"/dkv.serverpb.DKV/Get", "192.168.1.1", codes.PermissionDenied},
+		{"/dkv.serverpb.DKVAdmin/CompactRange", "10.2.3.4", codes.PermissionDenied},
+		{"/dkv.serverpb.DKVBackupRestore/Backup", "127.0.0.1", codes.OK},
+		{"/grpc.health.v1.Health/Check", "192.168.1.1", codes.OK},
+	}
+	for _, tc := range testCases {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(tc.ip), Port: 1234}})
+		info := &grpc.UnaryServerInfo{FullMethod: tc.method}
+		if _, err := intercept(ctx, nil, info, handler); status.Code(err) != tc.expCode {
+			t.Errorf("Method: %s, IP: %s. Expected code: %v, Actual error: %v", tc.method, tc.ip, tc.expCode, err)
+		}
+	}
+}
+
+func TestInvalidRules(t *testing.T) {
+	invalidRules := []string{
+		`{"data": {"allow": ["10.0.0.0/33"]}}`,
+		`{"metrics": {"allow": ["10.0.0.0/8"]}}`,
+		`["10.0.0.0/8"]`,
+	}
+	for _, rules := range invalidRules {
+		file := path.Join(t.TempDir(), "ipfilter.json")
+		writeRules(t, file, rules)
+		if _, err := Load(file, zap.NewNop()); err == nil {
+			t.Errorf("Expected an error while loading rules: %s", rules)
+		}
+	}
+}
+
+func loadFilters(t *testing.T, rules string) *Filters {
+	file := path.Join(t.TempDir(), "ipfilter.json")
+	writeRules(t, file, rules)
+	f, err := Load(file, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+var rulesVersion int
+
+// writeRules writes the given rules with a distinct modification time so
+// that the change is detected even on filesystems with coarse timestamps.
+func writeRules(t *testing.T, file, rules string) {
+	if err := ioutil.WriteFile(file, []byte(rules), 0644); err != nil {
+		t.Fatal(err)
+	}
+	rulesVersion++
+	modTime := time.Now().Add(time.Duration(rulesVersion) * time.Second)
+	if err := os.Chtimes(file, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,100 @@
+// Package keypolicy parses and validates the key policies published by the
+// DKV nodes, to which the keys built by the clients for the namespaces must
+// conform. A namespace may have several versions of its policy, so that its
+// keys are migrated to a new layout gradually.
+package keypolicy
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+)
+
+// MaxVersion is the largest version of the layout of the keys, which is
+// encoded in a byte.
+const MaxVersion = 255
+
+var segmentTypes = map[string]serverpb.KeySegmentType{
+	"string": serverpb.KeySegmentType_STRING_SEGMENT,
+	"uint64": serverpb.KeySegmentType_UINT64_SEGMENT,
+	"int64":  serverpb.KeySegmentType_INT64_SEGMENT,
+}
+
+// Parse parses the given definition of a key policy, which is in
+// <namespace>@<version>=<segment>:<type>,... format, the types being one
+// of string|uint64|int64. For instance, orders@1=tenant:string,id:uint64.
+func Parse(def string) (*serverpb.KeyPolicy, error) {
+	parts := strings.SplitN(def, "=", 2)
+	nsVer := strings.LastIndex(parts[0], "@")
+	if len(parts) != 2 || nsVer < 0 {
+		return nil, fmt.Errorf("key policy %s must be in <namespace>@<version>=<segment>:<type>,... format", def)
+	}
+	version, err := strconv.ParseUint(parts[0][nsVer+1:], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("key policy %s has an invalid version: %v", def, err)
+	}
+	policy := &serverpb.KeyPolicy{Namespace: parts[0][:nsVer], Version: uint32(version)}
+	for _, seg := range strings.Split(parts[1], ",") {
+		nameType := strings.SplitN(seg, ":", 2)
+		if len(nameType) != 2 {
+			return nil, fmt.Errorf("key policy %s has an invalid segment %s, must be in <segment>:<type> format", def, seg)
+		}
+		segType, ok := segmentTypes[nameType[1]]
+		if !ok {
+			return nil, fmt.Errorf("key policy %s has segment %s of an invalid type, must be one of string|uint64|int64", def, nameType[0])
+		}
+		policy.Segments = append(policy.Segments, &serverpb.KeySegment{Name: nameType[0], Type: segType})
+	}
+	if err = Validate(policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// Validate checks if the given key policy is well formed.
+func Validate(policy *serverpb.KeyPolicy) error {
+	switch {
+	case policy.Namespace == "":
+		return errors.New("namespace of the key policy must not be empty")
+	case policy.Version < 1 || policy.Version > MaxVersion:
+		return fmt.Errorf("version of the key policy of namespace %s must be between 1 and %d", policy.Namespace, MaxVersion)
+	case len(policy.Segments) == 0:
+		return fmt.Errorf("key policy of namespace %s must have segments", policy.Namespace)
+	}
+	names := make(map[string]bool, len(policy.Segments))
+	for _, seg := range policy.Segments {
+		if seg.Name == "" || names[seg.Name] {
+			return fmt.Errorf("key policy of namespace %s must have uniquely named segments", policy.Namespace)
+		}
+		if _, ok := serverpb.KeySegmentType_name[int32(seg.Type)]; !ok {
+			return fmt.Errorf("key policy of namespace %s has segment %s of an unknown type %d", policy.Namespace, seg.Name, seg.Type)
+		}
+		names[seg.Name] = true
+	}
+	return nil
+}
+
+// ValidateVersions checks if the given key policies of a namespace are
+// well formed and of distinct versions.
+func ValidateVersions(policies []*serverpb.KeyPolicy) error {
+	if len(policies) == 0 {
+		return errors.New("at least one key policy must be given")
+	}
+	versions := make(map[uint32]bool, len(policies))
+	for _, policy := range policies {
+		if err := Validate(policy); err != nil {
+			return err
+		}
+		if policy.Namespace != policies[0].Namespace {
+			return fmt.Errorf("key policies must be of a single namespace, but got %s and %s", policies[0].Namespace, policy.Namespace)
+		}
+		if versions[policy.Version] {
+			return fmt.Errorf("key policies of namespace %s must be of distinct versions, but got more of version %d", policy.Namespace, policy.Version)
+		}
+		versions[policy.Version] = true
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package keypolicy
+
+import (
+	"testing"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+)
+
+func TestParse(t *testing.T) {
+	policy, err := Parse("orders:@2=tenant:string,id:uint64,delta:int64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expTypes := []serverpb.KeySegmentType{serverpb.KeySegmentType_STRING_SEGMENT, serverpb.KeySegmentType_UINT64_SEGMENT, serverpb.KeySegmentType_INT64_SEGMENT}
+	if policy.Namespace != "orders:" || policy.Version != 2 || len(policy.Segments) != len(expTypes) {
+		t.Fatalf("Expected version 2 of namespace orders: with %d segments, but got %v", len(expTypes), policy)
+	}
+	for i, seg := range policy.Segments {
+		if seg.Type != expTypes[i] {
+			t.Errorf("Expected segment %s of type %s, but got %s", seg.Name, expTypes[i], seg.Type)
+		}
+	}
+
+	for _, def := range []string{"orders:", "orders:=id:uint64", "orders:@0=id:uint64", "orders:@256=id:uint64",
+		"orders:@1=id", "orders:@1=id:float", "orders:@1=id:uint64,id:string", "@1=id:uint64"} {
+		if _, err = Parse(def); err == nil {
+			t.Errorf("Expected an error for the key policy %s", def)
+		}
+	}
+}
+
+func TestValidateVersions(t *testing.T) {
+	parse := func(def string) *serverpb.KeyPolicy {
+		policy, err := Parse(def)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return policy
+	}
+	v1, v2, other := parse("users:@1=name:string"), parse("users:@2=id:uint64"), parse("carts:@1=id:uint64")
+	if err := ValidateVersions([]*serverpb.KeyPolicy{v1, v2}); err != nil {
+		t.Errorf("Expected several versions of a namespace to be valid, but got error: %v", err)
+	}
+	for _, policies := range [][]*serverpb.KeyPolicy{nil, {v1, v1}, {v1, other}} {
+		if err := ValidateVersions(policies); err == nil {
+			t.Errorf("Expected an error for the key policies %v", policies)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package master
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/internal/stats"
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/dkv/pkg/ctl"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"go.uber.org/zap"
+)
+
+var (
+	errPeerReplicationNotSupported = errors.New("storage engine does not support active-active replication")
+	errNoStoreForPeerChanges       = errors.New("no store to apply the changes of the peer onto")
+)
+
+const (
+	// Maximum number of changes retrieved from the peer at a time
+	maxPeerChanges = 1000
+	// Duration for which the peer holds the retrievals of its changes
+	// when there are none yet
+	peerChangesWait = 5 * time.Second
+	// Interval at which the changes of the peer are retrieved upon
+	// failures or when the peer does not hold the retrievals
+	peerRetryInterval = time.Second
+)
+
+// peerReplication pulls the changes of the peer master in an active-active
+// setup, just as a slave would, and applies them over the local writes
+// with their conflicts resolved by the configured resolver. The peer does
+// the same with the changes of this master, so that the writes accepted
+// by either master are eventually held by both. Changes are applied while
+// holding the lock of the service, so that the store is not swapped out
+// underneath, such as when it is restored.
+type peerReplication struct {
+	peerAddr string
+	applier  storage.PeerChangeApplier
+	rwl      *sync.RWMutex
+	resolver storage.ConflictResolver
+	lgr      *zap.Logger
+	statsCli stats.Client
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// newPeerReplication starts replicating the changes of the peer master at
+// the given address onto the given store, guarded by the given lock of
+// the service, returning nil when no peer is given.
+func newPeerReplication(peerAddr string, store storage.KVStore, rwl *sync.RWMutex, resolver storage.ConflictResolver,
+	lgr *zap.Logger, statsCli stats.Client) (*peerReplication, error) {
+	if peerAddr == "" {
+		return nil, nil
+	}
+	applier, ok := store.(storage.PeerChangeApplier)
+	if !ok {
+		return nil, errPeerReplicationNotSupported
+	}
+	if resolver == nil {
+		resolver = storage.LastWriterWins
+	}
+	pr := &peerReplication{peerAddr: peerAddr, applier: applier, rwl: rwl, resolver: resolver, lgr: lgr, statsCli: statsCli,
+		stop: make(chan struct{}), done: make(chan struct{})}
+	go pr.run()
+	return pr, nil
+}
+
+func (pr *peerReplication) run() {
+	defer close(pr.done)
+	pr.lgr.Info("Replicating changes from peer master", zap.String("PeerAddress", pr.peerAddr))
+	var peerCli *ctl.DKVClient
+	defer func() {
+		if peerCli != nil {
+			peerCli.Close()
+		}
+	}()
+	for {
+		var (
+			numChngs uint32
+			err      error
+		)
+		if peerCli == nil {
+			peerCli, err = ctl.NewInSecureDKVClient(pr.peerAddr, "")
+		}
+		if err == nil {
+			numChngs, err = pr.replicateChanges(peerCli)
+		}
+		if err != nil {
+			pr.lgr.Warn("Unable to replicate changes from peer master", zap.String("PeerAddress", pr.peerAddr), zap.Error(err))
+			pr.statsCli.Incr("peer.replication.errors", 1)
+		}
+
+		wait := time.Duration(0)
+		if err != nil || numChngs == 0 {
+			wait = peerRetryInterval
+		}
+		select {
+		case <-pr.stop:
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// replicateChanges applies a batch of changes retrieved from the peer,
+// following the latest change applied from it. Returns the number of
+// changes retrieved.
+func (pr *peerReplication) replicateChanges(peerCli *ctl.DKVClient) (uint32, error) {
+	defer pr.statsCli.Timing("peer.replicateChanges.latency.ms", time.Now())
+	appliedChngNum, err := pr.latestPeerChangeNumber()
+	if err != nil {
+		return 0, err
+	}
+	res, err := peerCli.AwaitChanges("", appliedChngNum+1, maxPeerChanges, peerChangesWait)
+	if err != nil {
+		return 0, err
+	}
+	if res.Status.Code != 0 {
+		return 0, errors.New(res.Status.Message)
+	}
+	if res.NumberOfChanges > 0 {
+		if appliedChngNum, err = pr.applyChanges(appliedChngNum, res.Changes); err != nil {
+			return 0, err
+		}
+	}
+	if res.MasterChangeNumber > appliedChngNum {
+		pr.statsCli.Gauge("peer.replication.lag", int64(res.MasterChangeNumber-appliedChngNum))
+	} else {
+		pr.statsCli.Gauge("peer.replication.lag", 0)
+	}
+	return res.NumberOfChanges, nil
+}
+
+func (pr *peerReplication) latestPeerChangeNumber() (uint64, error) {
+	pr.rwl.RLock()
+	defer pr.rwl.RUnlock()
+
+	if pr.applier == nil {
+		return 0, errNoStoreForPeerChanges
+	}
+	return pr.applier.GetLatestPeerChangeNumber(pr.peerAddr)
+}
+
+// applyChanges applies the given changes of the peer, which follow the
+// given change number. The changes are left out when the store has moved
+// on from that change number in the meantime, such as when it is restored,
+// and are retrieved afresh instead.
+func (pr *peerReplication) applyChanges(fromChngNum uint64, chngs []*serverpb.ChangeRecord) (uint64, error) {
+	pr.rwl.RLock()
+	defer pr.rwl.RUnlock()
+
+	if pr.applier == nil {
+		return 0, errNoStoreForPeerChanges
+	}
+	appliedChngNum, err := pr.applier.GetLatestPeerChangeNumber(pr.peerAddr)
+	if err != nil || appliedChngNum != fromChngNum {
+		return appliedChngNum, err
+	}
+	return pr.applier.ApplyPeerChanges(pr.peerAddr, chngs, pr.resolver)
+}
+
+// reopen switches the replication over to the given store, such as after
+// it is restored, or detaches it from any store when none is given, such
+// as before the store is closed. It must be invoked while holding the lock
+// of the service exclusively.
+func (pr *peerReplication) reopen(store storage.KVStore) {
+	if pr == nil {
+		return
+	}
+	applier, _ := store.(storage.PeerChangeApplier)
+	pr.applier = applier
+}
+
+// Close stops replicating the changes of the peer, if at all.
+func (pr *peerReplication) Close() {
+	if pr == nil {
+		return
+	}
+	close(pr.stop)
+	<-pr.done
+}
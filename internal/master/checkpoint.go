@@ -0,0 +1,217 @@
+package master
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// Size of the chunks in which the checkpoint files are streamed.
+	checkpointChunkSize = 1 << 20
+	// Checkpoints that are not transferred for this long are discarded,
+	// after which their interrupted transfers can no longer be resumed.
+	maxCheckpointIdleTime = 10 * time.Minute
+)
+
+var errCheckpointsNotSupported = errors.New("storage engine does not support checkpoints")
+
+// checkpointTransfer is a checkpoint retained on the master for being
+// transferred to slaves, until it remains idle for too long.
+type checkpointTransfer struct {
+	folder     string
+	chngNum    uint64
+	files      []storage.CheckpointFile
+	numActive  int
+	lastActive time.Time
+}
+
+// checkpointTransfers tracks the checkpoints being transferred, so that
+// the interrupted transfers can be resumed from the same checkpoint.
+type checkpointTransfers struct {
+	mu          sync.Mutex
+	checkpoints map[string]*checkpointTransfer
+	lgr         *zap.Logger
+}
+
+func newCheckpointTransfers(lgr *zap.Logger) *checkpointTransfers {
+	return &checkpointTransfers{checkpoints: make(map[string]*checkpointTransfer), lgr: lgr}
+}
+
+// add tracks the checkpoint in the given folder for being transferred.
+func (ct *checkpointTransfers) add(folder string, chngNum uint64) (string, *checkpointTransfer, error) {
+	files, err := storage.ListCheckpointFiles(folder)
+	if err != nil {
+		return "", nil, err
+	}
+	id := make([]byte, 8)
+	if _, err = rand.Read(id); err != nil {
+		return "", nil, err
+	}
+	checkpointID := hex.EncodeToString(id)
+	cpt := &checkpointTransfer{folder: folder, chngNum: chngNum, files: files, numActive: 1, lastActive: time.Now()}
+
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+	ct.checkpoints[checkpointID] = cpt
+	return checkpointID, cpt, nil
+}
+
+// acquire retrieves the given checkpoint for resuming its transfer,
+// which must be released once the transfer ends. Returns a NotFound
+// error if the checkpoint is discarded, whose transfer must hence be
+// restarted with a new checkpoint.
+func (ct *checkpointTransfers) acquire(checkpointID string) (*checkpointTransfer, error) {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+	cpt, present := ct.checkpoints[checkpointID]
+	if !present {
+		return nil, status.Errorf(codes.NotFound, "checkpoint: %s is not available for transfer", checkpointID)
+	}
+	cpt.numActive++
+	cpt.lastActive = time.Now()
+	return cpt, nil
+}
+
+// release ends a transfer of the given checkpoint and discards all the
+// checkpoints that are idle for too long.
+func (ct *checkpointTransfers) release(cpt *checkpointTransfer) {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+	cpt.numActive--
+	cpt.lastActive = time.Now()
+	for checkpointID, idle := range ct.checkpoints {
+		if idle.numActive == 0 && time.Since(idle.lastActive) > maxCheckpointIdleTime {
+			ct.discard(checkpointID, idle)
+		}
+	}
+}
+
+// Close discards all the checkpoints.
+func (ct *checkpointTransfers) Close() {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+	for checkpointID, cpt := range ct.checkpoints {
+		ct.discard(checkpointID, cpt)
+	}
+}
+
+func (ct *checkpointTransfers) discard(checkpointID string, cpt *checkpointTransfer) {
+	delete(ct.checkpoints, checkpointID)
+	if err := os.RemoveAll(cpt.folder); err != nil {
+		ct.lgr.Warn("Unable to remove checkpoint", zap.String("CheckpointID", checkpointID), zap.Error(err))
+	}
+}
+
+// TransferCheckpoint streams the files of a new or a previously created
+// checkpoint from the requested file and offset onwards.
+func (ss *standaloneService) TransferCheckpoint(transferReq *serverpb.TransferCheckpointRequest, stream serverpb.DKVReplication_TransferCheckpointServer) error {
+	checkpointID, cpt, err := ss.acquireCheckpoint(transferReq.CheckpointID)
+	if err != nil {
+		ss.opts.Logger.Error("Unable to acquire checkpoint for transfer", zap.Error(err))
+		return err
+	}
+	defer ss.checkpoints.release(cpt)
+
+	ss.opts.Logger.Info("Transferring checkpoint", zap.String("CheckpointID", checkpointID),
+		zap.String("FileName", transferReq.FileName), zap.Uint64("Offset", transferReq.Offset))
+	offset, found := int64(transferReq.Offset), transferReq.FileName == ""
+	for _, file := range cpt.files {
+		if !found {
+			if file.Name != transferReq.FileName {
+				continue
+			}
+			found = true
+		} else {
+			offset = 0
+		}
+		if err = ss.transferCheckpointFile(checkpointID, cpt, file, offset, stream); err != nil {
+			ss.opts.Logger.Error("Unable to transfer checkpoint file", zap.String("CheckpointID", checkpointID),
+				zap.String("FileName", file.Name), zap.Error(err))
+			return err
+		}
+	}
+	if !found {
+		return fmt.Errorf("given file: %s is not part of checkpoint: %s", transferReq.FileName, checkpointID)
+	}
+	return nil
+}
+
+// acquireCheckpoint retrieves the given checkpoint, creating a new one
+// if none is given.
+func (ss *standaloneService) acquireCheckpoint(checkpointID string) (string, *checkpointTransfer, error) {
+	if checkpointID != "" {
+		cpt, err := ss.checkpoints.acquire(checkpointID)
+		return checkpointID, cpt, err
+	}
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+	ckpt, ok := ss.store.(storage.Checkpointer)
+	if !ok {
+		return "", nil, errCheckpointsNotSupported
+	}
+	folder, chngNum, err := ckpt.CreateCheckpoint()
+	if err != nil {
+		return "", nil, err
+	}
+	checkpointID, cpt, err := ss.checkpoints.add(folder, chngNum)
+	if err != nil {
+		_ = os.RemoveAll(folder)
+		return "", nil, err
+	}
+	ss.opts.Logger.Info("Created checkpoint for transfer", zap.String("CheckpointID", checkpointID),
+		zap.Uint64("ChangeNumber", chngNum), zap.Int("NumFiles", len(cpt.files)))
+	return checkpointID, cpt, nil
+}
+
+// transferCheckpointFile streams the given checkpoint file from the given
+// offset onwards. Empty files are streamed as a single empty chunk.
+func (ss *standaloneService) transferCheckpointFile(checkpointID string, cpt *checkpointTransfer,
+	file storage.CheckpointFile, offset int64, stream serverpb.DKVReplication_TransferCheckpointServer) error {
+	if offset > file.Size {
+		return fmt.Errorf("given offset: %d is beyond the size: %d of file: %s", offset, file.Size, file.Name)
+	}
+	f, err := os.Open(filepath.Join(cpt.folder, file.Name))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err = f.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+	buf := make([]byte, checkpointChunkSize)
+	for {
+		n, err := io.ReadFull(f, buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return err
+		}
+		if n > 0 || offset == 0 {
+			chunk := &serverpb.CheckpointChunk{Status: newEmptyStatus(), CheckpointID: checkpointID,
+				ChangeNumber: cpt.chngNum, FileName: file.Name, FileSize: uint64(file.Size),
+				Offset: uint64(offset), Data: buf[:n]}
+			if err := stream.Send(chunk); err != nil {
+				return err
+			}
+			ss.opts.StatsCli.Incr("checkpoint.transfer.bytes", int64(n))
+		}
+		offset += int64(n)
+		switch {
+		case offset >= file.Size:
+			return nil
+		case n == 0:
+			return io.ErrUnexpectedEOF
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package master
+
+import (
+	"context"
+
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// GetRangeDigests computes the digests of the ranges of the keyspace that
+// the slaves compare with their own for verifying their consistency.
+func (ss *standaloneService) GetRangeDigests(ctx context.Context, digestReq *serverpb.GetRangeDigestsRequest) (*serverpb.GetRangeDigestsResponse, error) {
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	iterReq := &serverpb.IterateRequest{StartKey: digestReq.StartKey}
+	digests, err := storage.DigestRanges(ctx, storage.NewIteration(ss.store, iterReq), digestReq)
+	if err != nil {
+		ss.opts.Logger.Error("Unable to compute range digests", zap.Error(err))
+		return &serverpb.GetRangeDigestsResponse{Status: newErrorStatus(err)}, err
+	}
+	return &serverpb.GetRangeDigestsResponse{Status: newEmptyStatus(), Digests: digests}, nil
+}
+
+func (ss *standaloneService) GetDivergentRanges(_ context.Context, _ *emptypb.Empty) (*serverpb.GetDivergentRangesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "DKV master service does not verify its consistency, which is done by its slaves")
+}
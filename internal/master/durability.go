@@ -0,0 +1,177 @@
+package master
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"go.uber.org/zap"
+)
+
+const (
+	// Slaves that have not retrieved any changes for this long are no
+	// longer accounted for in the durability of the writes.
+	maxReplicaIdleTime = time.Minute
+	// Maximum duration for which AwaitDurability waits for the writes to
+	// reach the desired durability.
+	maxDurabilityWait = time.Minute
+)
+
+var (
+	errInvalidDurability   = errors.New("desired durability must be one of LOCAL_WAL_SYNCED or SLAVE_REPLICATED")
+	errDurabilityWaitLimit = fmt.Errorf("timeout for awaiting durability must not exceed %v", maxDurabilityWait)
+)
+
+type replicaState struct {
+	appliedChngNum uint64
+	lastSeen       time.Time
+	// Time at which the slave last applied any change, as reported by it
+	appliedAt time.Time
+}
+
+// replicaProgress tracks the changes applied by every slave, as inferred
+// from the change numbers from which they retrieve the changes. Waiters
+// are notified of the progress by closing the updated channel, which is
+// replaced thereafter.
+type replicaProgress struct {
+	mu       sync.Mutex
+	replicas map[string]*replicaState
+	updated  chan struct{}
+}
+
+func newReplicaProgress() *replicaProgress {
+	return &replicaProgress{replicas: make(map[string]*replicaState), updated: make(chan struct{})}
+}
+
+// applied records that the given slave applied all the changes upto the
+// given change number.
+func (rp *replicaProgress) applied(replicaID string, chngNum uint64) {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	rp.update(replicaID, chngNum)
+}
+
+// reported records the progress reported by the given slave, which applied
+// all the changes upto the given change number and last applied any change
+// at the given time.
+func (rp *replicaProgress) reported(replicaID string, chngNum uint64, appliedAt time.Time) {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	rp.update(replicaID, chngNum).appliedAt = appliedAt
+}
+
+func (rp *replicaProgress) update(replicaID string, chngNum uint64) *replicaState {
+	state, present := rp.replicas[replicaID]
+	if !present {
+		state = &replicaState{}
+		rp.replicas[replicaID] = state
+	}
+	state.lastSeen = time.Now()
+	// Slaves restored from an older backup move backwards
+	if state.appliedChngNum != chngNum {
+		state.appliedChngNum = chngNum
+		close(rp.updated)
+		rp.updated = make(chan struct{})
+	}
+	return state
+}
+
+// numReplicas counts the active slaves that applied the given change
+// number, along with a channel that is closed upon further progress.
+func (rp *replicaProgress) numReplicas(chngNum uint64) (uint32, <-chan struct{}) {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	numRepls, now := uint32(0), time.Now()
+	for replicaID, state := range rp.replicas {
+		switch {
+		case now.Sub(state.lastSeen) > maxReplicaIdleTime:
+			delete(rp.replicas, replicaID)
+		case state.appliedChngNum >= chngNum:
+			numRepls++
+		}
+	}
+	return numRepls, rp.updated
+}
+
+// appliedBy retrieves the change number upto which the given slave applied
+// the changes, along with a channel that is closed upon further progress.
+// Slaves that are idle for too long are considered to have applied none.
+func (rp *replicaProgress) appliedBy(replicaID string) (uint64, <-chan struct{}) {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	if state, present := rp.replicas[replicaID]; present && time.Since(state.lastSeen) <= maxReplicaIdleTime {
+		return state.appliedChngNum, rp.updated
+	}
+	return 0, rp.updated
+}
+
+func (ss *standaloneService) AwaitDurability(ctx context.Context, awaitReq *serverpb.AwaitDurabilityRequest) (*serverpb.AwaitDurabilityResponse, error) {
+	res, err := ss.awaitDurability(ctx, awaitReq)
+	if err != nil {
+		ss.opts.Logger.Error("Unable to await durability", zap.Error(err))
+		return &serverpb.AwaitDurabilityResponse{Status: newErrorStatus(err)}, err
+	}
+	return res, nil
+}
+
+// awaitDurability reports the durability of the writes upto the requested
+// change number, after waiting for them to reach the desired durability
+// for upto the requested timeout. Writes are fsynced right away when
+// waiting, instead of waiting for a subsequent write to fsync the WAL.
+func (ss *standaloneService) awaitDurability(ctx context.Context, awaitReq *serverpb.AwaitDurabilityRequest) (*serverpb.AwaitDurabilityResponse, error) {
+	timeout := time.Duration(awaitReq.TimeoutMillis) * time.Millisecond
+	switch {
+	case awaitReq.Durability != serverpb.Durability_LOCAL_WAL_SYNCED && awaitReq.Durability != serverpb.Durability_SLAVE_REPLICATED:
+		return nil, errInvalidDurability
+	case timeout > maxDurabilityWait:
+		return nil, errDurabilityWaitLimit
+	}
+	if latestChngNum := latestChangeNumber(ss.cp, ss.opts.Logger); awaitReq.ChangeNumber > latestChngNum {
+		return nil, fmt.Errorf("given change number: %d is beyond the latest change number: %d", awaitReq.ChangeNumber, latestChngNum)
+	}
+	minReplicas := awaitReq.MinReplicas
+	if minReplicas == 0 {
+		minReplicas = 1
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	res := &serverpb.AwaitDurabilityResponse{Status: newEmptyStatus(), Durability: serverpb.Durability_LOCAL_WAL_WRITTEN}
+	if dt, ok := ss.store.(storage.DurabilityTracker); ok {
+		res.SyncedChangeNumber = dt.GetSyncedChangeNumber()
+		if res.SyncedChangeNumber < awaitReq.ChangeNumber && timeout > 0 {
+			ss.rwl.RLock()
+			syncedChngNum, err := dt.SyncWAL()
+			ss.rwl.RUnlock()
+			if err != nil {
+				return nil, err
+			}
+			res.SyncedChangeNumber = syncedChngNum
+		}
+		if res.SyncedChangeNumber >= awaitReq.ChangeNumber {
+			res.Durability = serverpb.Durability_LOCAL_WAL_SYNCED
+		}
+	} else {
+		res.Durability = serverpb.Durability_UNKNOWN_DURABILITY
+	}
+
+	for {
+		numRepls, updated := ss.replicas.numReplicas(awaitReq.ChangeNumber)
+		res.NumReplicas = numRepls
+		if numRepls >= minReplicas {
+			res.Durability = serverpb.Durability_SLAVE_REPLICATED
+		}
+		if awaitReq.Durability != serverpb.Durability_SLAVE_REPLICATED || numRepls >= minReplicas {
+			return res, nil
+		}
+		select {
+		case <-updated:
+		case <-ctx.Done():
+			return res, nil
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package master
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"go.uber.org/zap"
+)
+
+const supersededMessage = "DKV master has been superseded by a node promoted to master, write to it instead"
+
+// supersession records the node that superseded the master by being
+// promoted with a higher fencing epoch.
+type supersession struct {
+	// epoch is the fencing epoch of the master when superseded, which
+	// the changes served by it thereafter continue to carry
+	epoch uint64
+	// masterAddr is the address of the node that superseded the master
+	masterAddr string
+}
+
+// Fence records the master as superseded by the node promoted with the
+// given fencing epoch, upon which the writes are rejected with the
+// REPLICA_READ_ONLY status carrying the address of that node. Writes in
+// progress are completed beforehand. The master accepts the writes again
+// only once it is promoted, issuing an epoch higher than the given one.
+func (ss *standaloneService) Fence(_ context.Context, fenceReq *serverpb.FenceRequest) (*serverpb.Status, error) {
+	ss.rwl.Lock()
+	defer ss.rwl.Unlock()
+
+	epoch := ss.fencingEpoch()
+	if fenceReq.FencingEpoch <= epoch {
+		err := fmt.Errorf("%w: %d, epoch of the master: %d", storage.ErrStaleFencingEpoch, fenceReq.FencingEpoch, epoch)
+		return newErrorStatus(err), err
+	}
+	if err := ss.fencing.Observe(fenceReq.FencingEpoch); err != nil {
+		ss.opts.Logger.Error("Unable to observe the fencing epoch", zap.Uint64("FencingEpoch", fenceReq.FencingEpoch), zap.Error(err))
+		return newErrorStatus(err), err
+	}
+	ss.superseded = &supersession{epoch: epoch, masterAddr: fenceReq.MasterAddress}
+	ss.opts.Logger.Warn("Superseded by a node promoted to master, rejecting the writes", zap.Uint64("FencingEpoch", epoch),
+		zap.Uint64("NewFencingEpoch", fenceReq.FencingEpoch), zap.String("NewMasterAddress", fenceReq.MasterAddress))
+	ss.opts.StatsCli.Incr("fencing.superseded", 1)
+	return newEmptyStatus(), nil
+}
+
+// fencingEpoch retrieves the fencing epoch of the master, which remains
+// the epoch it held when superseded, even though it observes the higher
+// epoch of the node superseding it. Must be called with the rwl held.
+func (ss *standaloneService) fencingEpoch() uint64 {
+	if ss.superseded != nil {
+		return ss.superseded.epoch
+	}
+	return ss.fencing.Current()
+}
+
+// checkSuperseded rejects the writes once the master is superseded,
+// redirecting them to the node that superseded it. Must be called with
+// the rwl held.
+func (ss *standaloneService) checkSuperseded() error {
+	if ss.superseded == nil {
+		return nil
+	}
+	ss.opts.StatsCli.Incr("fencing.superseded.writes", 1)
+	return ReadOnlyError(supersededMessage, ss.superseded.masterAddr)
+}
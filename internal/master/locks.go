@@ -0,0 +1,289 @@
+package master
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"go.uber.org/zap"
+)
+
+// Advisory locks are held as regular keys having this prefix, so that
+// they are replicated like any other key and retained across failovers.
+const dkvMetaLockPrefix = "_dkv_meta::Lock_"
+
+const (
+	maxLockLease = time.Hour
+	// Number of attempts for acquiring or releasing a lock, when its
+	// state is concurrently changed by another client
+	maxLockAttempts = 3
+)
+
+var (
+	errLockKeyMissing   = errors.New("key of the lock must be provided")
+	errLockOwnerMissing = errors.New("owner of the lock must be provided")
+	errInvalidLockLease = errors.New("lease of the lock must be positive and not exceed an hour")
+	errInvalidLockMode  = errors.New("mode of the lock must be one of EXCLUSIVE or SHARED")
+)
+
+// lockState is the value of the key holding a lock, which is either held
+// exclusively by its owner or shared by the owners of its shares. The
+// expiry of every lease is absolute, so that the lock expires as expected
+// even after a failover, subject to the clock skew between the nodes.
+type lockState struct {
+	Owner     string `json:"o"`
+	ExpiresAt int64  `json:"e"`
+	// Expiry of the lease of every owner of a shared lock
+	Shares map[string]int64 `json:"s,omitempty"`
+}
+
+// live drops the leases that expired as of the given epoch in millis,
+// returning nil if the lock is no longer held.
+func (held *lockState) live(nowMillis int64) *lockState {
+	if held == nil {
+		return nil
+	}
+	if held.Owner != "" {
+		if held.ExpiresAt > nowMillis {
+			return held
+		}
+		return nil
+	}
+	shares := make(map[string]int64, len(held.Shares))
+	for owner, expiresAt := range held.Shares {
+		if expiresAt > nowMillis {
+			shares[owner] = expiresAt
+		}
+	}
+	if len(shares) == 0 {
+		return nil
+	}
+	return &lockState{Shares: shares}
+}
+
+// sharedByOthers reports an owner other than the given one among the
+// owners of the shared lock, along with the latest expiry of their leases.
+func (held *lockState) sharedByOthers(owner string) (string, int64) {
+	var other string
+	var expiresAt int64
+	for sharer, shareExpiresAt := range held.Shares {
+		if sharer == owner {
+			continue
+		}
+		if other == "" || sharer < other {
+			other = sharer
+		}
+		if shareExpiresAt > expiresAt {
+			expiresAt = shareExpiresAt
+		}
+	}
+	return other, expiresAt
+}
+
+// lockStore offers the operations over the keys holding the locks. Such
+// keys are changed only through CAS, so that the concurrent clients
+// attempting the same lock are serialized.
+type lockStore struct {
+	get func(key []byte) ([]byte, error)
+	cas func(key, expect, update []byte) (bool, error)
+}
+
+func lockKey(key []byte) []byte {
+	return append([]byte(dkvMetaLockPrefix), key...)
+}
+
+// lock acquires the requested lock, unless it is held exclusively by
+// another owner, or shared by other owners when acquiring exclusively,
+// with their leases yet to expire. Acquiring a lock already held by the
+// same owner renews its lease, changing its mode if requested.
+func (ls lockStore) lock(lockReq *serverpb.LockRequest) (*serverpb.LockResponse, error) {
+	lease := time.Duration(lockReq.LeaseMillis) * time.Millisecond
+	switch {
+	case len(lockReq.Key) == 0:
+		return nil, errLockKeyMissing
+	case lockReq.Owner == "":
+		return nil, errLockOwnerMissing
+	case lease <= 0 || lease > maxLockLease:
+		return nil, errInvalidLockLease
+	case lockReq.Mode != serverpb.LockMode_EXCLUSIVE && lockReq.Mode != serverpb.LockMode_SHARED:
+		return nil, errInvalidLockMode
+	}
+
+	lk := lockKey(lockReq.Key)
+	for attempt := 0; attempt < maxLockAttempts; attempt++ {
+		curr, held, err := ls.load(lk)
+		if err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		held = held.live(now.UnixNano() / int64(time.Millisecond))
+		expiresAt := now.Add(lease).UnixNano() / int64(time.Millisecond)
+		var acquired *lockState
+		switch {
+		case held != nil && held.Owner != "" && held.Owner != lockReq.Owner:
+			return &serverpb.LockResponse{Status: newEmptyStatus(), Owner: held.Owner, ExpiresAt: held.ExpiresAt,
+				Mode: serverpb.LockMode_EXCLUSIVE}, nil
+		case lockReq.Mode == serverpb.LockMode_EXCLUSIVE:
+			if held != nil {
+				if other, otherExpiresAt := held.sharedByOthers(lockReq.Owner); other != "" {
+					return &serverpb.LockResponse{Status: newEmptyStatus(), Owner: other, ExpiresAt: otherExpiresAt,
+						Mode: serverpb.LockMode_SHARED}, nil
+				}
+			}
+			acquired = &lockState{Owner: lockReq.Owner, ExpiresAt: expiresAt}
+		default:
+			acquired = &lockState{Shares: map[string]int64{lockReq.Owner: expiresAt}}
+			if held != nil {
+				for sharer, shareExpiresAt := range held.Shares {
+					if sharer != lockReq.Owner {
+						acquired.Shares[sharer] = shareExpiresAt
+					}
+				}
+			}
+		}
+		update, _ := json.Marshal(acquired)
+		if updated, err := ls.cas(lk, curr, update); err != nil {
+			return nil, err
+		} else if updated {
+			return &serverpb.LockResponse{Status: newEmptyStatus(), Acquired: true, Owner: lockReq.Owner, ExpiresAt: expiresAt,
+				Mode: lockReq.Mode}, nil
+		}
+	}
+	return nil, errors.New("unable to acquire the lock due to concurrent attempts, retry later")
+}
+
+// unlock releases the requested lock or the share of it, provided it is
+// held by the given owner. Released locks are held as empty values, which
+// CAS considers the same as absent values.
+func (ls lockStore) unlock(unlockReq *serverpb.UnlockRequest) (*serverpb.UnlockResponse, error) {
+	switch {
+	case len(unlockReq.Key) == 0:
+		return nil, errLockKeyMissing
+	case unlockReq.Owner == "":
+		return nil, errLockOwnerMissing
+	}
+
+	lk := lockKey(unlockReq.Key)
+	for attempt := 0; attempt < maxLockAttempts; attempt++ {
+		curr, held, err := ls.load(lk)
+		if err != nil {
+			return nil, err
+		}
+		held = held.live(time.Now().UnixNano() / int64(time.Millisecond))
+		update := []byte{}
+		switch {
+		case held == nil:
+			return &serverpb.UnlockResponse{Status: newEmptyStatus()}, nil
+		case held.Owner != "":
+			if held.Owner != unlockReq.Owner {
+				return &serverpb.UnlockResponse{Status: newEmptyStatus()}, nil
+			}
+		default:
+			if _, present := held.Shares[unlockReq.Owner]; !present {
+				return &serverpb.UnlockResponse{Status: newEmptyStatus()}, nil
+			}
+			delete(held.Shares, unlockReq.Owner)
+			if len(held.Shares) > 0 {
+				update, _ = json.Marshal(held)
+			}
+		}
+		if updated, err := ls.cas(lk, curr, update); err != nil {
+			return nil, err
+		} else if updated {
+			return &serverpb.UnlockResponse{Status: newEmptyStatus(), Released: true}, nil
+		}
+	}
+	return nil, errors.New("unable to release the lock due to concurrent attempts, retry later")
+}
+
+// load retrieves the current value of the given lock key along with the
+// lock state held by it, which is nil when the lock is not held.
+func (ls lockStore) load(lk []byte) ([]byte, *lockState, error) {
+	curr, err := ls.get(lk)
+	if err != nil || len(curr) == 0 {
+		return curr, nil, err
+	}
+	held := &lockState{}
+	if err = json.Unmarshal(curr, held); err != nil {
+		return nil, nil, err
+	}
+	return curr, held, nil
+}
+
+func (ss *standaloneService) lockStore() lockStore {
+	return lockStore{
+		get: func(key []byte) ([]byte, error) {
+			kvs, err := ss.store.Get(key)
+			if err != nil || len(kvs) == 0 {
+				return nil, err
+			}
+			return kvs[0].Value, nil
+		},
+		cas: ss.store.CompareAndSet,
+	}
+}
+
+func (ss *standaloneService) Lock(ctx context.Context, lockReq *serverpb.LockRequest) (*serverpb.LockResponse, error) {
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	res, err := ss.lockStore().lock(lockReq)
+	if err != nil {
+		ss.opts.Logger.Error("Unable to acquire lock", zap.Error(err))
+		return &serverpb.LockResponse{Status: newErrorStatus(err)}, err
+	}
+	return res, nil
+}
+
+func (ss *standaloneService) Unlock(ctx context.Context, unlockReq *serverpb.UnlockRequest) (*serverpb.UnlockResponse, error) {
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	res, err := ss.lockStore().unlock(unlockReq)
+	if err != nil {
+		ss.opts.Logger.Error("Unable to release lock", zap.Error(err))
+		return &serverpb.UnlockResponse{Status: newErrorStatus(err)}, err
+	}
+	return res, nil
+}
+
+// lockStore of the distributed service reads and changes the locks
+// through consensus, so that all the nodes agree on the lock states.
+func (ds *distributedService) lockStore(ctx context.Context) lockStore {
+	return lockStore{
+		get: func(key []byte) ([]byte, error) {
+			res, err := ds.Get(ctx, &serverpb.GetRequest{Key: key, ReadConsistency: serverpb.ReadConsistency_LINEARIZABLE})
+			if err != nil {
+				return nil, err
+			}
+			return res.Value, nil
+		},
+		cas: func(key, expect, update []byte) (bool, error) {
+			res, err := ds.CompareAndSet(ctx, &serverpb.CompareAndSetRequest{Key: key, OldValue: expect, NewValue: update})
+			if err != nil {
+				return false, err
+			}
+			return res.Updated, nil
+		},
+	}
+}
+
+func (ds *distributedService) Lock(ctx context.Context, lockReq *serverpb.LockRequest) (*serverpb.LockResponse, error) {
+	res, err := ds.lockStore(ctx).lock(lockReq)
+	if err != nil {
+		ds.opts.Logger.Error("Unable to acquire lock", zap.Error(err))
+		return &serverpb.LockResponse{Status: newErrorStatus(err)}, err
+	}
+	return res, nil
+}
+
+func (ds *distributedService) Unlock(ctx context.Context, unlockReq *serverpb.UnlockRequest) (*serverpb.UnlockResponse, error) {
+	res, err := ds.lockStore(ctx).unlock(unlockReq)
+	if err != nil {
+		ds.opts.Logger.Error("Unable to release lock", zap.Error(err))
+		return &serverpb.UnlockResponse{Status: newErrorStatus(err)}, err
+	}
+	return res, nil
+}
@@ -0,0 +1,81 @@
+package master
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+var errWritesPaused = errors.New("writes are paused, retry after they are resumed")
+
+// writePause is set while the writes are paused, until resumed or its
+// timer expires.
+type writePause struct {
+	timer *time.Timer
+}
+
+// PauseWrites pauses the writes until resumed or the requested timeout
+// elapses. Taking the rwl exclusively completes the writes in progress,
+// hence the change number retrieved is that of the last write before the
+// pause. Pausing the writes again restarts the timeout.
+func (ss *standaloneService) PauseWrites(_ context.Context, pauseReq *serverpb.PauseWritesRequest) (*serverpb.PauseWritesResponse, error) {
+	if pauseReq.TimeoutSecs == 0 {
+		err := errors.New("timeout must be provided for pausing the writes")
+		return &serverpb.PauseWritesResponse{Status: newErrorStatus(err)}, err
+	}
+	ss.rwl.Lock()
+	defer ss.rwl.Unlock()
+
+	if ss.paused != nil {
+		ss.paused.timer.Stop()
+	}
+	timeout := time.Duration(pauseReq.TimeoutSecs) * time.Second
+	pause := &writePause{}
+	pause.timer = time.AfterFunc(timeout, func() { ss.expireWritePause(pause) })
+	ss.paused = pause
+	chngNum := latestChangeNumber(ss.cp, ss.opts.Logger)
+	ss.opts.Logger.Info("Paused writes", zap.Uint64("ChangeNumber", chngNum), zap.Duration("Timeout", timeout))
+	ss.opts.StatsCli.Incr("writes.paused", 1)
+	return &serverpb.PauseWritesResponse{Status: newEmptyStatus(), ChangeNumber: chngNum}, nil
+}
+
+func (ss *standaloneService) ResumeWrites(_ context.Context, _ *emptypb.Empty) (*serverpb.Status, error) {
+	ss.rwl.Lock()
+	defer ss.rwl.Unlock()
+
+	if ss.paused != nil {
+		ss.paused.timer.Stop()
+		ss.paused = nil
+		ss.opts.Logger.Info("Resumed writes")
+	}
+	return newEmptyStatus(), nil
+}
+
+// expireWritePause resumes the writes once the given pause times out,
+// unless they were resumed or paused again since.
+func (ss *standaloneService) expireWritePause(pause *writePause) {
+	ss.rwl.Lock()
+	defer ss.rwl.Unlock()
+
+	if ss.paused == pause {
+		ss.paused = nil
+		ss.opts.Logger.Warn("Resumed writes upon the timeout of their pause")
+		ss.opts.StatsCli.Incr("writes.pause.expired", 1)
+	}
+}
+
+// checkWritable rejects the writes while they are paused, which can be
+// retried, as well as once the master is superseded. Must be called with
+// the rwl held.
+func (ss *standaloneService) checkWritable() error {
+	if ss.paused != nil {
+		return status.Error(codes.Unavailable, errWritesPaused.Error())
+	}
+	return ss.checkSuperseded()
+}
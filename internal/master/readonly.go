@@ -0,0 +1,24 @@
+package master
+
+import (
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ReadOnlyError rejects the keyspace mutations sent to a node that does
+// not accept writes, with the FailedPrecondition code detailed by the
+// REPLICA_READ_ONLY status, which carries the address of the given master
+// so that the clients can redirect their writes to it.
+func ReadOnlyError(message, masterAddr string) error {
+	st := &serverpb.Status{
+		Code:          int32(serverpb.StatusCode_REPLICA_READ_ONLY),
+		Message:       message,
+		MasterAddress: masterAddr,
+	}
+	grpcStatus, err := status.New(codes.FailedPrecondition, message).WithDetails(st)
+	if err != nil {
+		return status.Error(codes.FailedPrecondition, message)
+	}
+	return grpcStatus.Err()
+}
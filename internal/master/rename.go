@@ -0,0 +1,300 @@
+package master
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/internal/stats"
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Maximum number of keys copied or deleted at a time by a prefix rename,
+// between which the writes blocked by the service are let through
+const prefixRenamePageSize = 1000
+
+var (
+	errPrefixRenameNotCopied = errors.New("keys of the old prefix are still being copied to the new prefix")
+	errPrefixRenameStopped   = errors.New("prefix rename stopped since the service is closing")
+	errUnmirroredWrite       = errors.New("conditional writes and merges of the keys of a prefix being renamed must use its new prefix")
+)
+
+// prefixRenamer carries out the key prefix renames registered with it in
+// the background. Renames begin by copying the keys of the old prefix to
+// the new prefix, without overwriting the keys written by the clients
+// under the new prefix in the meanwhile, and complete by deleting the keys
+// of the old prefix. Until then, the writes of the keys of the old prefix
+// are mirrored onto the new prefix by the service. Renames interrupted by
+// restarts are resumed.
+type prefixRenamer struct {
+	renames  *storage.PrefixRenames
+	store    storage.KVStore
+	rwl      *sync.RWMutex
+	lgr      *zap.Logger
+	statsCli stats.Client
+
+	mu      sync.Mutex
+	running map[string]bool
+	stop    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// newPrefixRenamer creates a prefixRenamer over the registry held by the
+// given store, or only in memory if the store cannot hold it, resuming its
+// renames in progress.
+func newPrefixRenamer(store storage.KVStore, rwl *sync.RWMutex, lgr *zap.Logger, statsCli stats.Client) *prefixRenamer {
+	rs, _ := store.(storage.RecordStore)
+	renames, err := storage.OpenPrefixRenames(rs)
+	if err != nil {
+		lgr.Error("Unable to load the prefix renames", zap.Error(err))
+		renames, _ = storage.OpenPrefixRenames(nil)
+	}
+	pr := &prefixRenamer{renames: renames, store: store, rwl: rwl, lgr: lgr, statsCli: statsCli,
+		running: make(map[string]bool), stop: make(chan struct{})}
+	for _, rename := range renames.List() {
+		if rename.Phase != serverpb.PrefixRenamePhase_COPIED {
+			lgr.Info("Resuming prefix rename", zap.ByteString("OldPrefix", rename.OldPrefix),
+				zap.ByteString("NewPrefix", rename.NewPrefix), zap.Stringer("Phase", rename.Phase))
+			pr.start(rename)
+		}
+	}
+	return pr
+}
+
+// begin registers the rename of the given old prefix to the given new
+// prefix and starts copying its keys, unless they are being copied or
+// already copied.
+func (pr *prefixRenamer) begin(oldPrefix, newPrefix []byte) error {
+	if err := pr.renames.Begin(oldPrefix, newPrefix); err != nil {
+		return err
+	}
+	if rename, ok := pr.renames.Lookup(oldPrefix); ok && rename.Phase == serverpb.PrefixRenamePhase_COPYING {
+		pr.start(rename)
+	}
+	return nil
+}
+
+// complete stops the reads from falling back to the given old prefix and
+// starts deleting its keys, once they are copied to the new prefix.
+func (pr *prefixRenamer) complete(oldPrefix []byte) error {
+	rename, ok := pr.renames.Lookup(oldPrefix)
+	if !ok {
+		return storage.ErrPrefixRenameNotFound
+	}
+	switch rename.Phase {
+	case serverpb.PrefixRenamePhase_COPYING:
+		return errPrefixRenameNotCopied
+	case serverpb.PrefixRenamePhase_COPIED:
+		if err := pr.renames.SetPhase(oldPrefix, serverpb.PrefixRenamePhase_SWEEPING); err != nil {
+			return err
+		}
+		rename.Phase = serverpb.PrefixRenamePhase_SWEEPING
+	}
+	pr.start(rename)
+	return nil
+}
+
+// start runs the current phase of the given rename in the background,
+// unless it is already running.
+func (pr *prefixRenamer) start(rename *serverpb.PrefixRename) {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	if pr.running[string(rename.OldPrefix)] {
+		return
+	}
+	pr.running[string(rename.OldPrefix)] = true
+	pr.wg.Add(1)
+	go pr.run(rename)
+}
+
+func (pr *prefixRenamer) run(rename *serverpb.PrefixRename) {
+	defer pr.wg.Done()
+	defer func() {
+		pr.mu.Lock()
+		delete(pr.running, string(rename.OldPrefix))
+		pr.mu.Unlock()
+	}()
+
+	var err error
+	switch rename.Phase {
+	case serverpb.PrefixRenamePhase_COPYING:
+		if err = pr.forEachPage(rename.OldPrefix, func(kvs []*serverpb.KVPair) error { return pr.copyKeys(rename, kvs) }); err == nil {
+			err = pr.renames.SetPhase(rename.OldPrefix, serverpb.PrefixRenamePhase_COPIED)
+		}
+	case serverpb.PrefixRenamePhase_SWEEPING:
+		if err = pr.forEachPage(rename.OldPrefix, func(kvs []*serverpb.KVPair) error { return pr.deleteKeys(rename, kvs) }); err == nil {
+			err = pr.renames.End(rename.OldPrefix)
+		}
+	}
+	switch {
+	case err == errPrefixRenameStopped:
+		pr.lgr.Info("Stopped prefix rename", zap.ByteString("OldPrefix", rename.OldPrefix), zap.Stringer("Phase", rename.Phase))
+	case err != nil:
+		pr.lgr.Error("Unable to rename prefix", zap.ByteString("OldPrefix", rename.OldPrefix),
+			zap.ByteString("NewPrefix", rename.NewPrefix), zap.Stringer("Phase", rename.Phase), zap.Error(err))
+		pr.statsCli.Incr("prefixRename.errors", 1)
+	default:
+		pr.lgr.Info("Finished prefix rename phase", zap.ByteString("OldPrefix", rename.OldPrefix),
+			zap.ByteString("NewPrefix", rename.NewPrefix), zap.Stringer("Phase", rename.Phase))
+	}
+}
+
+// forEachPage invokes the given handler with the keys of the given prefix,
+// a page at a time.
+func (pr *prefixRenamer) forEachPage(prefix []byte, hndlr func([]*serverpb.KVPair) error) error {
+	var startKey []byte
+	for {
+		select {
+		case <-pr.stop:
+			return errPrefixRenameStopped
+		default:
+		}
+		kvs, err := pr.nextPage(prefix, startKey)
+		if err == nil && len(kvs) > 0 {
+			err = hndlr(kvs)
+		}
+		if err != nil || len(kvs) < prefixRenamePageSize {
+			return err
+		}
+		startKey = append(append([]byte(nil), kvs[len(kvs)-1].Key...), 0)
+	}
+}
+
+func (pr *prefixRenamer) nextPage(prefix, startKey []byte) ([]*serverpb.KVPair, error) {
+	pr.rwl.RLock()
+	defer pr.rwl.RUnlock()
+
+	var kvs []*serverpb.KVPair
+	iteration := storage.NewIteration(pr.store, &serverpb.IterateRequest{KeyPrefix: prefix, StartKey: startKey})
+	itrtr, err := iteration.Open()
+	if err != nil {
+		return nil, err
+	}
+	for itrtr.HasNext() && len(kvs) < prefixRenamePageSize {
+		kvs = append(kvs, itrtr.Next())
+	}
+	err = itrtr.Err()
+	itrtr.Close()
+	return kvs, err
+}
+
+// copyKeys copies the given keys of the old prefix to the new prefix of
+// the given rename, leaving the keys already present under the new prefix
+// untouched. The writes of the service are blocked while the page is
+// copied, so that checking for the absence of the keys and writing them
+// is a single conditional put, which unlike CompareAndSet carries the
+// expiries of the keys. The keys of the old prefix are read once again
+// in the meanwhile, since the writes mirrored onto the new prefix may
+// have changed them after the page was read.
+func (pr *prefixRenamer) copyKeys(rename *serverpb.PrefixRename, kvs []*serverpb.KVPair) error {
+	defer pr.statsCli.Timing("prefixRename.copy.latency.ms", time.Now())
+	pr.rwl.Lock()
+	defer pr.rwl.Unlock()
+
+	oldKeys, newKeys := make([][]byte, len(kvs)), make([][]byte, len(kvs))
+	for i, kv := range kvs {
+		oldKeys[i] = kv.Key
+		newKeys[i] = append(append([]byte(nil), rename.NewPrefix...), kv.Key[len(rename.OldPrefix):]...)
+	}
+	present := make(map[string]bool, len(kvs))
+	newKVs, err := pr.store.Get(newKeys...)
+	if err != nil {
+		return err
+	}
+	for _, kv := range newKVs {
+		present[string(kv.Key)] = true
+	}
+	oldKVs, err := pr.store.Get(oldKeys...)
+	if err != nil {
+		return err
+	}
+	var puts []*serverpb.KVPair
+	for _, kv := range oldKVs {
+		newKey := append(append([]byte(nil), rename.NewPrefix...), kv.Key[len(rename.OldPrefix):]...)
+		if !present[string(newKey)] {
+			puts = append(puts, &serverpb.KVPair{Key: newKey, Value: kv.Value, ExpireTS: kv.ExpireTS})
+		}
+	}
+	if len(puts) > 0 {
+		if err = pr.store.Put(puts...); err != nil {
+			return err
+		}
+	}
+	pr.renames.Progress(rename.OldPrefix, uint64(len(kvs)))
+	pr.statsCli.Incr("prefixRename.copied", int64(len(kvs)))
+	return nil
+}
+
+// deleteKeys deletes the given keys of the old prefix of the given rename.
+func (pr *prefixRenamer) deleteKeys(rename *serverpb.PrefixRename, kvs []*serverpb.KVPair) error {
+	defer pr.statsCli.Timing("prefixRename.sweep.latency.ms", time.Now())
+	pr.rwl.RLock()
+	defer pr.rwl.RUnlock()
+	for _, kv := range kvs {
+		if err := pr.store.Delete(kv.Key); err != nil {
+			return err
+		}
+	}
+	pr.renames.Progress(rename.OldPrefix, uint64(len(kvs)))
+	pr.statsCli.Incr("prefixRename.swept", int64(len(kvs)))
+	return nil
+}
+
+// reopen switches the renamer over to the given store, such as after it
+// is restored, resuming the renames in progress of the store. It must be
+// invoked while holding the lock of the service exclusively.
+func (pr *prefixRenamer) reopen(store storage.KVStore) {
+	pr.store = store
+	rs, _ := store.(storage.RecordStore)
+	if err := pr.renames.Reopen(rs); err != nil {
+		pr.lgr.Error("Unable to load the prefix renames", zap.Error(err))
+		return
+	}
+	for _, rename := range pr.renames.List() {
+		if rename.Phase != serverpb.PrefixRenamePhase_COPIED {
+			pr.start(rename)
+		}
+	}
+}
+
+// Close stops the renames running in the background, which are resumed
+// once a service is created afresh.
+func (pr *prefixRenamer) Close() {
+	close(pr.stop)
+	pr.wg.Wait()
+}
+
+// BeginPrefixRename registers the rename of a key prefix and starts copying
+// its keys to the new prefix in the background. Until the rename completes,
+// the reads of the keys absent under the new prefix fall back to the old
+// prefix, so that the clients can switch to the new prefix right away.
+func (ss *standaloneService) BeginPrefixRename(_ context.Context, renameReq *serverpb.PrefixRenameRequest) (*serverpb.Status, error) {
+	err := ss.renamer.begin(renameReq.OldPrefix, renameReq.NewPrefix)
+	if err != nil {
+		ss.opts.Logger.Error("Unable to begin prefix rename", zap.ByteString("OldPrefix", renameReq.OldPrefix),
+			zap.ByteString("NewPrefix", renameReq.NewPrefix), zap.Error(err))
+		return newErrorStatus(err), err
+	}
+	return newEmptyStatus(), nil
+}
+
+// CompletePrefixRename ends the fallback of the reads to the old prefix of
+// a rename whose keys are copied, and deletes the keys of the old prefix
+// in the background.
+func (ss *standaloneService) CompletePrefixRename(_ context.Context, renameReq *serverpb.PrefixRenameRequest) (*serverpb.Status, error) {
+	err := ss.renamer.complete(renameReq.OldPrefix)
+	if err != nil {
+		ss.opts.Logger.Error("Unable to complete prefix rename", zap.ByteString("OldPrefix", renameReq.OldPrefix),
+			zap.ByteString("NewPrefix", renameReq.NewPrefix), zap.Error(err))
+		return newErrorStatus(err), err
+	}
+	return newEmptyStatus(), nil
+}
+
+func (ss *standaloneService) ListPrefixRenames(_ context.Context, _ *emptypb.Empty) (*serverpb.ListPrefixRenamesResponse, error) {
+	return &serverpb.ListPrefixRenamesResponse{Status: newEmptyStatus(), Renames: ss.renamer.renames.List()}, nil
+}
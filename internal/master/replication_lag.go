@@ -0,0 +1,73 @@
+package master
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+var errMissingReplicaID = errors.New("replica ID of the reporting slave must be given")
+
+// lags computes the lag from the given change number of the master of
+// every slave that is not idle for too long, in the order of their IDs.
+func (rp *replicaProgress) lags(masterChngNum uint64) []*serverpb.ReplicaLag {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	now := time.Now()
+	var lags []*serverpb.ReplicaLag
+	for replicaID, state := range rp.replicas {
+		if now.Sub(state.lastSeen) > maxReplicaIdleTime {
+			continue
+		}
+		lags = append(lags, state.lag(replicaID, masterChngNum, now))
+	}
+	sort.Slice(lags, func(i, j int) bool { return lags[i].ReplicaID < lags[j].ReplicaID })
+	return lags
+}
+
+func (state *replicaState) lag(replicaID string, masterChngNum uint64, now time.Time) *serverpb.ReplicaLag {
+	lag := &serverpb.ReplicaLag{ReplicaID: replicaID, AppliedChangeNumber: state.appliedChngNum,
+		LastSeenTimestamp: state.lastSeen.UnixNano() / int64(time.Millisecond)}
+	if !state.appliedAt.IsZero() {
+		lag.AppliedTimestamp = state.appliedAt.UnixNano() / int64(time.Millisecond)
+	}
+	if masterChngNum > state.appliedChngNum {
+		lag.LagChanges = masterChngNum - state.appliedChngNum
+		if !state.appliedAt.IsZero() && now.After(state.appliedAt) {
+			lag.LagMillis = now.Sub(state.appliedAt).Milliseconds()
+		}
+	}
+	return lag
+}
+
+func (ss *standaloneService) ReportReplicationLag(_ context.Context, lagReport *serverpb.ReplicationLagReport) (*serverpb.Status, error) {
+	if lagReport.ReplicaID == "" {
+		ss.opts.Logger.Error("Unable to record replication lag", zap.Error(errMissingReplicaID))
+		return newErrorStatus(errMissingReplicaID), errMissingReplicaID
+	}
+	var appliedAt time.Time
+	if lagReport.AppliedTimestamp > 0 {
+		appliedAt = time.Unix(0, lagReport.AppliedTimestamp*int64(time.Millisecond))
+	}
+	ss.replicas.reported(lagReport.ReplicaID, lagReport.AppliedChangeNumber, appliedAt)
+
+	// Slaves report periodically, hence their lag is emitted upon every report
+	state := &replicaState{appliedChngNum: lagReport.AppliedChangeNumber, appliedAt: appliedAt, lastSeen: time.Now()}
+	lag := state.lag(lagReport.ReplicaID, latestChangeNumber(ss.cp, ss.opts.Logger), state.lastSeen)
+	metricPrefix := "replication.replica." + storage.MetricSafe(lagReport.ReplicaID)
+	ss.opts.StatsCli.Gauge(metricPrefix+".lag", int64(lag.LagChanges))
+	ss.opts.StatsCli.Gauge(metricPrefix+".lag.ms", lag.LagMillis)
+	return newEmptyStatus(), nil
+}
+
+func (ss *standaloneService) GetReplicationLag(_ context.Context, _ *emptypb.Empty) (*serverpb.GetReplicationLagResponse, error) {
+	masterChngNum := latestChangeNumber(ss.cp, ss.opts.Logger)
+	return &serverpb.GetReplicationLagResponse{Status: newEmptyStatus(), MasterChangeNumber: masterChngNum,
+		Replicas: ss.replicas.lags(masterChngNum)}, nil
+}
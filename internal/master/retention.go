@@ -0,0 +1,85 @@
+package master
+
+import (
+	"sort"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"go.uber.org/zap"
+)
+
+// Slaves positioned within this fraction of the retained changes from
+// the oldest retained change are warned about, since the changes they
+// are yet to retrieve are the next ones to be truncated.
+const retentionWarnFraction = 0.1
+
+// replicaHeadroom is the number of retained changes that a slave is yet to
+// retrieve before the oldest retained change, below which it has fallen
+// behind the retention and must bootstrap afresh.
+type replicaHeadroom struct {
+	replicaID string
+	headroom  uint64
+	atRisk    bool
+	lost      bool
+}
+
+// headrooms computes the headroom of every slave that is not idle for too
+// long, given the oldest and the latest retained change numbers, in the
+// order of their IDs.
+func (rp *replicaProgress) headrooms(oldestChngNum, latestChngNum uint64) []*replicaHeadroom {
+	if oldestChngNum == 0 || latestChngNum < oldestChngNum {
+		return nil
+	}
+	retained := latestChngNum - oldestChngNum + 1
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	now := time.Now()
+	var hrs []*replicaHeadroom
+	for replicaID, state := range rp.replicas {
+		if now.Sub(state.lastSeen) > maxReplicaIdleTime {
+			continue
+		}
+		hr := &replicaHeadroom{replicaID: replicaID}
+		// Slaves retrieve the changes following the ones they applied
+		if nextChngNum := state.appliedChngNum + 1; nextChngNum < oldestChngNum {
+			hr.lost = true
+		} else {
+			hr.headroom = nextChngNum - oldestChngNum
+			hr.atRisk = float64(hr.headroom) < retentionWarnFraction*float64(retained)
+		}
+		hrs = append(hrs, hr)
+	}
+	sort.Slice(hrs, func(i, j int) bool { return hrs[i].replicaID < hrs[j].replicaID })
+	return hrs
+}
+
+// reportEngineStats loads the statistics of the storage engine for the
+// periodic reports, checking the retention of the changes against the
+// positions of the slaves along the way.
+func (ss *standaloneService) reportEngineStats() (*storage.EngineStats, error) {
+	es, err := ss.engineStats()
+	if err == nil {
+		ss.checkRetention(es.OldestChangeNumber, latestChangeNumber(ss.cp, ss.opts.Logger))
+	}
+	return es, err
+}
+
+// checkRetention emits the headroom of every slave, warning about the
+// slaves whose changes are about to be truncated and the ones that have
+// already fallen behind the retained changes.
+func (ss *standaloneService) checkRetention(oldestChngNum, latestChngNum uint64) {
+	for _, hr := range ss.replicas.headrooms(oldestChngNum, latestChngNum) {
+		ss.opts.StatsCli.Gauge("replication.replica."+storage.MetricSafe(hr.replicaID)+".retention.headroom", int64(hr.headroom))
+		switch {
+		case hr.lost:
+			ss.opts.Logger.Error("Changes to be retrieved by slave are no longer retained, slave must bootstrap afresh",
+				zap.String("ReplicaID", hr.replicaID), zap.Uint64("OldestChangeNumber", oldestChngNum))
+			ss.opts.StatsCli.Incr("replication.retention.exceeded", 1)
+		case hr.atRisk:
+			ss.opts.Logger.Warn("Changes to be retrieved by slave are about to be truncated",
+				zap.String("ReplicaID", hr.replicaID), zap.Uint64("Headroom", hr.headroom),
+				zap.Uint64("OldestChangeNumber", oldestChngNum), zap.Uint64("LatestChangeNumber", latestChngNum))
+			ss.opts.StatsCli.Incr("replication.retention.warnings", 1)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package master
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/internal/stats"
+	"go.uber.org/zap"
+)
+
+// Duration for which the writes await the confirmation of the semi-sync
+// replica, unless configured otherwise.
+const defaultSemiSyncTimeout = time.Second
+
+// semiSyncReplication holds off acknowledging the writes until a designated
+// slave confirms their receipt, which it does by retrieving the changes
+// following them. Once the slave fails to confirm a write in time, the
+// writes are acknowledged without awaiting it until it catches up with the
+// change number of that write, narrowing the window of data loss without
+// letting an unavailable slave block the writes.
+type semiSyncReplication struct {
+	replicaID string
+	timeout   time.Duration
+	replicas  *replicaProgress
+	lgr       *zap.Logger
+	statsCli  stats.Client
+
+	mu       sync.Mutex
+	degraded bool
+	// Change number the slave must catch up with for restoring the
+	// semi-sync replication once degraded
+	catchUpChngNum uint64
+}
+
+// newSemiSyncReplication creates the semi-sync replication to the given
+// slave, returning nil when no slave is given.
+func newSemiSyncReplication(replicaID string, timeout time.Duration, replicas *replicaProgress,
+	lgr *zap.Logger, statsCli stats.Client) *semiSyncReplication {
+	if replicaID == "" {
+		return nil
+	}
+	if timeout <= 0 {
+		timeout = defaultSemiSyncTimeout
+	}
+	return &semiSyncReplication{replicaID: replicaID, timeout: timeout, replicas: replicas, lgr: lgr, statsCli: statsCli}
+}
+
+// await waits for the designated slave to confirm the receipt of all the
+// changes upto the given change number. Returns true if confirmed, else
+// false when the replication is degraded to async or the given context
+// is done.
+func (ssr *semiSyncReplication) await(ctx context.Context, chngNum uint64) bool {
+	if ssr == nil || !ssr.isActive() {
+		return false
+	}
+
+	timer := time.NewTimer(ssr.timeout)
+	defer timer.Stop()
+	for {
+		appliedChngNum, updated := ssr.replicas.appliedBy(ssr.replicaID)
+		if appliedChngNum >= chngNum {
+			return true
+		}
+		select {
+		case <-updated:
+		case <-ctx.Done():
+			return false
+		case <-timer.C:
+			ssr.degrade(chngNum)
+			return false
+		}
+	}
+}
+
+// isActive checks if the writes are to await the designated slave,
+// restoring the semi-sync replication if the degraded slave caught up.
+func (ssr *semiSyncReplication) isActive() bool {
+	ssr.mu.Lock()
+	defer ssr.mu.Unlock()
+	if !ssr.degraded {
+		return true
+	}
+	if appliedChngNum, _ := ssr.replicas.appliedBy(ssr.replicaID); appliedChngNum < ssr.catchUpChngNum {
+		return false
+	}
+	ssr.degraded = false
+	ssr.lgr.Info("Semi-sync replica caught up, restoring semi-sync replication",
+		zap.String("ReplicaID", ssr.replicaID), zap.Uint64("CatchUpChangeNumber", ssr.catchUpChngNum))
+	ssr.statsCli.Gauge("replication.semisync.degraded", 0)
+	return true
+}
+
+func (ssr *semiSyncReplication) degrade(chngNum uint64) {
+	ssr.mu.Lock()
+	defer ssr.mu.Unlock()
+	if ssr.degraded {
+		return
+	}
+	ssr.degraded, ssr.catchUpChngNum = true, chngNum
+	ssr.lgr.Error("Semi-sync replica did not confirm the receipt of changes in time, degrading to async replication",
+		zap.String("ReplicaID", ssr.replicaID), zap.Uint64("ChangeNumber", chngNum), zap.Duration("Timeout", ssr.timeout))
+	ssr.statsCli.Incr("replication.semisync.degradations", 1)
+	ssr.statsCli.Gauge("replication.semisync.degraded", 1)
+}
@@ -18,14 +18,22 @@ import (
 
 	"google.golang.org/protobuf/types/known/emptypb"
 
+	"github.com/flipkart-incubator/dkv/internal/audit"
+	"github.com/flipkart-incubator/dkv/internal/auth"
+	"github.com/flipkart-incubator/dkv/internal/changestream"
+	"github.com/flipkart-incubator/dkv/internal/heatmap"
 	"github.com/flipkart-incubator/dkv/internal/opts"
+	"github.com/flipkart-incubator/dkv/internal/pinning"
 	"github.com/flipkart-incubator/dkv/internal/storage"
 	"github.com/flipkart-incubator/dkv/internal/sync/raftpb"
+	"github.com/flipkart-incubator/dkv/pkg/ctl"
 	"github.com/flipkart-incubator/dkv/pkg/serverpb"
 	nexus_api "github.com/flipkart-incubator/nexus/pkg/api"
 	"github.com/gogo/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/empty"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // A DKVService represents a service for serving key value data
@@ -36,18 +44,33 @@ type DKVService interface {
 	serverpb.DKVDiscoveryNodeServer
 	serverpb.DKVReplicationServer
 	serverpb.DKVBackupRestoreServer
+	serverpb.DKVSnapshotServer
+	serverpb.DKVAdminServer
 	health.HealthServer
 }
 
 type standaloneService struct {
-	store      storage.KVStore
-	cp         storage.ChangePropagator
-	br         storage.Backupable
-	rwl        *sync.RWMutex
-	regionInfo *serverpb.RegionInfo
-	isClosed   bool
-	shutdown   chan struct{}
-	opts       *opts.ServerOpts
+	store       storage.KVStore
+	cp          storage.ChangePropagator
+	br          storage.Backupable
+	rwl         *sync.RWMutex
+	regionInfo  *serverpb.RegionInfo
+	isClosed    bool
+	shutdown    chan struct{}
+	opts        *opts.ServerOpts
+	scans       *storage.ScanCursors
+	engStats    *storage.EngineStatsReporter
+	fencing     *storage.FencingEpoch
+	replicas    *replicaProgress
+	checkpoints *checkpointTransfers
+	semiSync    *semiSyncReplication
+	peerRepl    *peerReplication
+	renamer     *prefixRenamer
+	// superseded is set once the master is fenced by a node promoted to
+	// master, guarded by the rwl
+	superseded *supersession
+	// paused is set while the writes are paused, guarded by the rwl
+	paused *writePause
 }
 
 func (ss *standaloneService) GetStatus(ctx context.Context, request *emptypb.Empty) (*serverpb.RegionInfo, error) {
@@ -87,50 +110,158 @@ func (ss *standaloneService) Watch(req *health.HealthCheckRequest, watcher healt
 func NewStandaloneService(store storage.KVStore, cp storage.ChangePropagator, br storage.Backupable, regionInfo *serverpb.RegionInfo, opts *opts.ServerOpts) DKVService {
 	rwl := &sync.RWMutex{}
 	regionInfo.Status = serverpb.RegionStatus_LEADER
-	return &standaloneService{store, cp, br, rwl, regionInfo, false, make(chan struct{}, 1), opts}
+	scans := storage.NewScanCursors(opts.ScanCursorTTL)
+	fencing := opts.FencingEpoch
+	if fencing == nil {
+		fencing, _ = storage.OpenFencingEpoch("")
+	}
+	replicas := newReplicaProgress()
+	semiSync := newSemiSyncReplication(opts.SemiSyncReplica, opts.SemiSyncTimeout, replicas, opts.Logger, opts.StatsCli)
+	peerRepl, err := newPeerReplication(opts.ActiveActivePeer, store, rwl, opts.ConflictResolver, opts.Logger, opts.StatsCli)
+	if err != nil {
+		opts.Logger.Panic("Unable to start active-active replication", zap.Error(err))
+	}
+	ss := &standaloneService{store, cp, br, rwl, regionInfo, false, make(chan struct{}, 1), opts, scans, nil, fencing, replicas, newCheckpointTransfers(opts.Logger), semiSync, peerRepl,
+		newPrefixRenamer(store, rwl, opts.Logger, opts.StatsCli), nil, nil}
+	ss.engStats = storage.NewEngineStatsReporter(opts.StatsCli, storage.DefaultEngineStatsInterval, ss.reportEngineStats)
+	ss.handleCorruptEntries(store)
+	return ss
 }
 
-func (ss *standaloneService) Put(ctx context.Context, putReq *serverpb.PutRequest) (*serverpb.PutResponse, error) {
-	ss.rwl.RLock()
-	defer ss.rwl.RUnlock()
-	if err := ss.store.Put(&serverpb.KVPair{Key: putReq.Key, Value: putReq.Value, ExpireTS: putReq.ExpireTS}); err != nil {
-		ss.opts.Logger.Error("Unable to PUT", zap.Error(err))
-		return &serverpb.PutResponse{Status: newErrorStatus(err)}, err
+// handleCorruptEntries quarantines the corrupt entries found by the
+// scrubber of the given store through the write path of this node, being
+// the store itself, so that the quarantine reaches the slaves through the
+// change log.
+func (ss *standaloneService) handleCorruptEntries(store storage.KVStore) {
+	if q, ok := store.(storage.Quarantiner); ok {
+		q.OnCorruptEntry(func(rec *serverpb.QuarantinedRecord) error {
+			_, err := q.Quarantine(rec)
+			return err
+		})
 	}
-	return &serverpb.PutResponse{Status: newEmptyStatus()}, nil
+}
+
+func (ss *standaloneService) Put(ctx context.Context, putReq *serverpb.PutRequest) (*serverpb.PutResponse, error) {
+	kv := &serverpb.KVPair{Key: putReq.Key, Value: putReq.Value, ExpireTS: putReq.ExpireTS}
+	return ss.put(ctx, putReq.WalSync, kv)
 }
 
 func (ss *standaloneService) MultiPut(ctx context.Context, putReq *serverpb.MultiPutRequest) (*serverpb.PutResponse, error) {
-	ss.rwl.RLock()
-	defer ss.rwl.RUnlock()
 	puts := make([]*serverpb.KVPair, len(putReq.PutRequest))
 	for i, request := range putReq.PutRequest {
 		puts[i] = &serverpb.KVPair{Key: request.Key, Value: request.Value, ExpireTS: request.ExpireTS}
 	}
+	return ss.put(ctx, storage.MultiPutWALSync(putReq.PutRequest), puts...)
+}
 
-	if err := ss.store.Put(puts...); err != nil {
+// put writes the given pairs, reporting the semi-sync replica as holding
+// them once it confirms their receipt.
+func (ss *standaloneService) put(ctx context.Context, walSync serverpb.WALSync, kvs ...*serverpb.KVPair) (*serverpb.PutResponse, error) {
+	ss.rwl.RLock()
+	if err := ss.checkWritable(); err != nil {
+		ss.rwl.RUnlock()
+		return nil, err
+	}
+	kvs = ss.mirrorPuts(kvs)
+	durability, err := storage.PutWithWALSync(ss.store, walSync, kvs...)
+	chngNum := latestChangeNumber(ss.cp, ss.opts.Logger)
+	ss.rwl.RUnlock()
+	if err != nil {
 		ss.opts.Logger.Error("Unable to PUT", zap.Error(err))
-		return &serverpb.PutResponse{Status: newErrorStatus(err)}, err
+		return &serverpb.PutResponse{Status: newErrorStatus(err)}, toGRPCError(err)
+	}
+	if ss.semiSync.await(ctx, chngNum) {
+		return newPutResponse(serverpb.Durability_SLAVE_REPLICATED, 2, chngNum), nil
+	}
+	return newPutResponse(durability, 1, chngNum), nil
+}
+
+// mirrorPuts appends the writes of the given pairs onto the new prefix of
+// the renames in progress of their keys, so that the pairs are written
+// under both the prefixes atomically.
+func (ss *standaloneService) mirrorPuts(kvs []*serverpb.KVPair) []*serverpb.KVPair {
+	var mirrored []*serverpb.KVPair
+	for _, kv := range kvs {
+		if mirrorKey, ok := ss.renamer.renames.MirrorKey(kv.Key); ok {
+			if mirrored == nil {
+				mirrored = append(mirrored, kvs...)
+			}
+			mirrored = append(mirrored, &serverpb.KVPair{Key: mirrorKey, Value: kv.Value, ExpireTS: kv.ExpireTS})
+		}
+	}
+	if mirrored == nil {
+		return kvs
+	}
+	return mirrored
+}
+
+// checkUnmirrored fails the writes of the given keys that cannot be
+// mirrored onto the new prefix of a rename, which are the conditional
+// writes and merges whose outcome depends on the existing values.
+func (ss *standaloneService) checkUnmirrored(keys ...[]byte) error {
+	for _, key := range keys {
+		if _, ok := ss.renamer.renames.MirrorKey(key); ok {
+			return errUnmirroredWrite
+		}
+	}
+	return nil
+}
+
+// newPutResponse reports the durability achieved by a successful write,
+// along with the number of nodes known to hold it and its change number.
+func newPutResponse(durability serverpb.Durability, numReplicas uint32, chngNum uint64) *serverpb.PutResponse {
+	return &serverpb.PutResponse{Status: newEmptyStatus(), Durability: durability, NumReplicas: numReplicas, ChangeNumber: chngNum}
+}
+
+// latestChangeNumber retrieves the latest committed change number, which
+// is reported to the writers for subsequently reading their own writes.
+// Concurrent writes may have committed after a write by then, hence the
+// change number may be more recent than that of the write, which does not
+// affect the session guarantees.
+func latestChangeNumber(cp storage.ChangePropagator, lg *zap.Logger) uint64 {
+	if cp == nil {
+		return 0
+	}
+	chngNum, err := cp.GetLatestCommittedChangeNumber()
+	if err != nil {
+		lg.Warn("Unable to load the latest committed change number", zap.Error(err))
 	}
-	return &serverpb.PutResponse{Status: newEmptyStatus()}, nil
+	return chngNum
 }
 
 func (ss *standaloneService) Delete(ctx context.Context, delReq *serverpb.DeleteRequest) (*serverpb.DeleteResponse, error) {
 	ss.rwl.RLock()
-	defer ss.rwl.RUnlock()
+	if err := ss.checkWritable(); err != nil {
+		ss.rwl.RUnlock()
+		return nil, err
+	}
+	err := ss.store.Delete(delReq.Key)
+	// Deleted keys must not be read from the old prefix of a rename
+	if fallbackKey, ok := ss.renamer.renames.FallbackKey(delReq.Key); err == nil && ok {
+		err = ss.store.Delete(fallbackKey)
+	}
+	if mirrorKey, ok := ss.renamer.renames.MirrorKey(delReq.Key); err == nil && ok {
+		err = ss.store.Delete(mirrorKey)
+	}
+	chngNum := latestChangeNumber(ss.cp, ss.opts.Logger)
+	ss.rwl.RUnlock()
 
-	if err := ss.store.Delete(delReq.Key); err != nil {
+	if err != nil {
 		ss.opts.Logger.Error("Unable to DELETE", zap.Error(err))
-		return &serverpb.DeleteResponse{Status: newErrorStatus(err)}, err
+		return &serverpb.DeleteResponse{Status: newErrorStatus(err)}, toGRPCError(err)
 	}
-	return &serverpb.DeleteResponse{Status: newEmptyStatus()}, nil
+	ss.semiSync.await(ctx, chngNum)
+	return &serverpb.DeleteResponse{Status: newEmptyStatus(), ChangeNumber: chngNum}, nil
 }
 
 func (ss *standaloneService) Get(ctx context.Context, getReq *serverpb.GetRequest) (*serverpb.GetResponse, error) {
 	ss.rwl.RLock()
 	defer ss.rwl.RUnlock()
 
-	readResults, err := ss.store.Get(getReq.Key)
+	if pr, ok := ss.store.(storage.PinnedReader); ok && ss.opts.PinnedReads && getReq.Snapshot == "" && getReq.AsOfChangeNumber == 0 && getReq.ReadOptions == nil && !ss.hasFallbackKey(getReq.Key) {
+		return ss.getPinned(pr, getReq.Key)
+	}
+	readResults, err := ss.read(getReq.Snapshot, getReq.AsOfChangeNumber, getReq.ReadOptions, getReq.Key)
 	res := &serverpb.GetResponse{Status: newEmptyStatus()}
 	if err != nil {
 		ss.opts.Logger.Error("Unable to GET", zap.Error(err))
@@ -139,17 +270,41 @@ func (ss *standaloneService) Get(ctx context.Context, getReq *serverpb.GetReques
 		// Needed to take care of the (valid) case when the
 		// given key is not found with DKV
 		if len(readResults) == 1 {
-			res.Value = readResults[0].Value
+			res.Value, res.ExpireTS = readResults[0].Value, readResults[0].ExpireTS
+		} else {
+			res.NotFound = true
 		}
 	}
 	return res, err
 }
 
+// hasFallbackKey checks if the reads of the given key fall back to
+// another key, due to a prefix rename in progress.
+func (ss *standaloneService) hasFallbackKey(key []byte) bool {
+	_, ok := ss.renamer.renames.FallbackKey(key)
+	return ok
+}
+
+// getPinned serves the value of the given key without copying it out
+// of the store. The value is released once the response is marshalled.
+func (ss *standaloneService) getPinned(pr storage.PinnedReader, key []byte) (*serverpb.GetResponse, error) {
+	val, expireTS, release, err := pr.GetPinned(key)
+	if err != nil {
+		ss.opts.Logger.Error("Unable to GET", zap.Error(err))
+		return &serverpb.GetResponse{Status: newErrorStatus(err)}, err
+	}
+	res := &serverpb.GetResponse{Status: newEmptyStatus(), Value: val, ExpireTS: expireTS, NotFound: len(val) == 0}
+	pinning.Pin(res, release)
+	return res, nil
+}
+
 func (ss *standaloneService) MultiGet(ctx context.Context, multiGetReq *serverpb.MultiGetRequest) (*serverpb.MultiGetResponse, error) {
 	ss.rwl.RLock()
 	defer ss.rwl.RUnlock()
 
-	readResults, err := ss.store.Get(multiGetReq.Keys...)
+	readResults, err := storage.MultiGet(func(keys ...[]byte) ([]*serverpb.KVPair, error) {
+		return ss.read(multiGetReq.Snapshot, multiGetReq.AsOfChangeNumber, multiGetReq.ReadOptions, keys...)
+	}, multiGetReq.Keys...)
 	res := &serverpb.MultiGetResponse{Status: newEmptyStatus()}
 	if err != nil {
 		ss.opts.Logger.Error("Unable to MultiGET", zap.Error(err))
@@ -160,26 +315,181 @@ func (ss *standaloneService) MultiGet(ctx context.Context, multiGetReq *serverpb
 	return res, err
 }
 
-func (ss *standaloneService) CompareAndSet(ctx context.Context, casReq *serverpb.CompareAndSetRequest) (*serverpb.CompareAndSetResponse, error) {
+func (ss *standaloneService) Exists(ctx context.Context, existsReq *serverpb.ExistsRequest) (*serverpb.ExistsResponse, error) {
 	ss.rwl.RLock()
 	defer ss.rwl.RUnlock()
 
+	var exists bool
+	var err error
+	if existsReq.Snapshot == "" {
+		exists, err = storage.Exists(ss.store, existsReq.Key)
+		if fallbackKey, ok := ss.renamer.renames.FallbackKey(existsReq.Key); err == nil && !exists && ok {
+			exists, err = storage.Exists(ss.store, fallbackKey)
+		}
+	} else {
+		var readResults []*serverpb.KVPair
+		readResults, err = ss.read(existsReq.Snapshot, 0, nil, existsReq.Key)
+		exists = len(readResults) == 1
+	}
+	res := &serverpb.ExistsResponse{Status: newEmptyStatus()}
+	if err != nil {
+		ss.opts.Logger.Error("Unable to check existence", zap.Error(err))
+		res.Status = newErrorStatus(err)
+	} else {
+		res.Exists = exists
+	}
+	return res, err
+}
+
+func (ss *standaloneService) CompareAndSet(ctx context.Context, casReq *serverpb.CompareAndSetRequest) (*serverpb.CompareAndSetResponse, error) {
+	ss.rwl.RLock()
+	if err := ss.checkWritable(); err != nil {
+		ss.rwl.RUnlock()
+		return nil, err
+	}
 	res := &serverpb.CompareAndSetResponse{Status: newEmptyStatus()}
-	casRes, err := ss.store.CompareAndSet(casReq.Key, casReq.OldValue, casReq.NewValue)
+	var casRes bool
+	err := ss.checkUnmirrored(casReq.Key)
+	if err == nil {
+		casRes, err = ss.store.CompareAndSet(casReq.Key, casReq.OldValue, casReq.NewValue)
+	}
 	if err != nil {
 		ss.opts.Logger.Error("Unable to perform CAS", zap.Error(err))
 		res.Status = newErrorStatus(err)
 	}
 	res.Updated = casRes
+	if casRes {
+		res.ChangeNumber = latestChangeNumber(ss.cp, ss.opts.Logger)
+	}
+	ss.rwl.RUnlock()
+
+	if casRes {
+		ss.semiSync.await(ctx, res.ChangeNumber)
+	}
+	return res, err
+}
+
+var errTransactionsNotSupported = errors.New("Current storage engine does not support transactions")
+
+func (ss *standaloneService) Transact(ctx context.Context, txnReq *serverpb.TransactRequest) (*serverpb.TransactResponse, error) {
+	res, err := ss.transact(txnReq)
+	if err == nil {
+		ss.semiSync.await(ctx, res.ChangeNumber)
+	}
+	return res, err
+}
+
+func (ss *standaloneService) transact(txnReq *serverpb.TransactRequest) (*serverpb.TransactResponse, error) {
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	if err := ss.checkWritable(); err != nil {
+		return nil, err
+	}
+	res := &serverpb.TransactResponse{Status: newEmptyStatus()}
+	txr, ok := ss.store.(storage.Transactor)
+	if !ok {
+		res.Status = newErrorStatus(errTransactionsNotSupported)
+		return res, errTransactionsNotSupported
+	}
+	for _, write := range txnReq.Writes {
+		if err := ss.checkUnmirrored(write.Key); err != nil {
+			res.Status = newErrorStatus(err)
+			return res, err
+		}
+	}
+	if err := txr.Transact(txnReq.Reads, txnReq.Writes); err != nil {
+		if err != storage.ErrTxnConflict {
+			ss.opts.Logger.Error("Unable to perform transaction", zap.Error(err))
+		}
+		res.Status = newErrorStatus(err)
+		return res, toGRPCError(err)
+	}
+	res.ChangeNumber = latestChangeNumber(ss.cp, ss.opts.Logger)
+	return res, nil
+}
+
+var errMergeNotSupported = errors.New("Current storage engine does not support merges")
+
+func (ss *standaloneService) Merge(ctx context.Context, mergeReq *serverpb.MergeRequest) (*serverpb.MergeResponse, error) {
+	res, err := ss.merge(mergeReq)
+	if err == nil {
+		ss.semiSync.await(ctx, res.ChangeNumber)
+	}
 	return res, err
 }
 
+func (ss *standaloneService) merge(mergeReq *serverpb.MergeRequest) (*serverpb.MergeResponse, error) {
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	if err := ss.checkWritable(); err != nil {
+		return nil, err
+	}
+	res := &serverpb.MergeResponse{Status: newEmptyStatus()}
+	mrgr, ok := ss.store.(storage.Merger)
+	if !ok {
+		res.Status = newErrorStatus(errMergeNotSupported)
+		return res, errMergeNotSupported
+	}
+	if err := ss.checkUnmirrored(mergeReq.Key); err != nil {
+		res.Status = newErrorStatus(err)
+		return res, err
+	}
+	if err := mrgr.Merge(mergeReq.Key, mergeReq.Operand); err != nil {
+		ss.opts.Logger.Error("Unable to MERGE", zap.Error(err))
+		res.Status = newErrorStatus(err)
+		return res, toGRPCError(err)
+	}
+	res.ChangeNumber = latestChangeNumber(ss.cp, ss.opts.Logger)
+	return res, nil
+}
+
+// Maximum duration for which GetChanges waits for the changes to be committed.
+const maxChangesWait = 10 * time.Second
+
+// GetChanges retrieves the changes from the requested change number. When
+// there are none yet, the request is held for upto the requested wait time
+// for the changes to be committed.
 func (ss *standaloneService) GetChanges(ctx context.Context, getChngsReq *serverpb.GetChangesRequest) (*serverpb.GetChangesResponse, error) {
+	maxWait := time.Duration(getChngsReq.MaxWaitMillis) * time.Millisecond
+	cn, ok := ss.store.(storage.ChangeNotifier)
+	if maxWait <= 0 || !ok {
+		return ss.getChanges(getChngsReq)
+	}
+	if maxWait > maxChangesWait {
+		maxWait = maxChangesWait
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, maxWait)
+	defer cancel()
+	for {
+		// Subscribing before loading the changes ensures that no commit
+		// following the load goes unnoticed
+		committed := cn.ChangesCommitted()
+		res, err := ss.getChanges(getChngsReq)
+		if err != nil || res.NumberOfChanges > 0 {
+			return res, err
+		}
+		select {
+		case <-committed:
+		case <-ctx.Done():
+			return res, nil
+		}
+	}
+}
+
+func (ss *standaloneService) getChanges(getChngsReq *serverpb.GetChangesRequest) (*serverpb.GetChangesResponse, error) {
 	ss.rwl.RLock()
 	defer ss.rwl.RUnlock()
 
+	// Slaves retrieve the changes following the ones they applied
+	if getChngsReq.ReplicaID != "" && getChngsReq.FromChangeNumber > 0 {
+		ss.replicas.applied(getChngsReq.ReplicaID, getChngsReq.FromChangeNumber-1)
+	}
 	latestChngNum, _ := ss.cp.GetLatestCommittedChangeNumber()
-	res := &serverpb.GetChangesResponse{Status: newEmptyStatus(), MasterChangeNumber: latestChngNum}
+	epoch := ss.fencingEpoch()
+	res := &serverpb.GetChangesResponse{Status: newEmptyStatus(), MasterChangeNumber: latestChngNum, FencingEpoch: epoch}
 	if getChngsReq.FromChangeNumber > latestChngNum {
 		if getChngsReq.FromChangeNumber > (latestChngNum + 1) {
 			ss.opts.Logger.Warn("GetChanges: From change number more than the latest change number",
@@ -189,16 +499,63 @@ func (ss *standaloneService) GetChanges(ctx context.Context, getChngsReq *server
 	}
 
 	chngs, err := ss.cp.LoadChanges(getChngsReq.FromChangeNumber, int(getChngsReq.MaxNumberOfChanges))
+	if err == nil {
+		err = ctl.EncodeChangeRecords(chngs, getChngsReq.RecordCompression, getChngsReq.DeltaEncodeKeys)
+	}
 	if err != nil {
 		ss.opts.Logger.Error("Unable to load changes", zap.Error(err))
 		res.Status = newErrorStatus(err)
+		if errors.Is(err, storage.ErrChangesNotAvailable) {
+			// Slaves recognise this code to bootstrap afresh
+			err = status.Error(codes.OutOfRange, err.Error())
+		}
 	} else {
+		for _, chng := range chngs {
+			chng.FencingEpoch = epoch
+		}
 		res.NumberOfChanges = uint32(len(chngs))
 		res.Changes = chngs
 	}
 	return res, err
 }
 
+// StreamChanges streams the changes from the requested change number as
+// they are committed, batching them as per the targets of the request.
+func (ss *standaloneService) StreamChanges(getChngsReq *serverpb.GetChangesRequest, stream serverpb.DKVReplication_StreamChangesServer) error {
+	if ss.cp == nil {
+		return errors.New("storage engine does not support change propagation")
+	}
+	_, notified := ss.store.(storage.ChangeNotifier)
+	// Slaves acknowledge the changes by reporting their replication lag
+	var acked changestream.Acked
+	if getChngsReq.ReplicaID != "" {
+		acked = func() (uint64, <-chan struct{}) {
+			return ss.replicas.appliedBy(getChngsReq.ReplicaID)
+		}
+	}
+	return changestream.Serve(stream.Context(), getChngsReq, ss.GetChanges, notified, acked, stream.Send)
+}
+
+// Promote issues a new fencing epoch on the master, unless skipped. A
+// superseded master accepts the writes again once promoted, since the
+// issued epoch is higher than that of the node that superseded it.
+func (ss *standaloneService) Promote(ctx context.Context, promoteReq *serverpb.PromoteRequest) (*serverpb.PromoteResponse, error) {
+	ss.rwl.Lock()
+	defer ss.rwl.Unlock()
+
+	epoch := ss.fencingEpoch()
+	if !promoteReq.SkipFencing {
+		var err error
+		if epoch, err = ss.fencing.Advance(); err != nil {
+			ss.opts.Logger.Error("Unable to issue a new fencing epoch", zap.Error(err))
+			return &serverpb.PromoteResponse{Status: newErrorStatus(err)}, err
+		}
+		ss.superseded = nil
+	}
+	ss.opts.Logger.Info("Promoted with a fencing epoch", zap.Uint64("FencingEpoch", epoch), zap.Bool("SkipFencing", promoteReq.SkipFencing))
+	return &serverpb.PromoteResponse{Status: newEmptyStatus(), FencingEpoch: epoch, ChangeNumber: latestChangeNumber(ss.cp, ss.opts.Logger)}, nil
+}
+
 const (
 	dkvMetaReplicaPrefix   = "_dkv_meta::Replica_"
 	zoneReplicaValueFormat = "%s@%s:%d"
@@ -302,6 +659,8 @@ func (ss *standaloneService) Restore(ctx context.Context, restoreReq *serverpb.R
 	defer ss.rwl.Unlock()
 
 	ss.opts.Logger.Info("Closing the current DB connection")
+	ss.scans.ReleaseAll()
+	ss.peerRepl.reopen(nil)
 	ss.store.Close()
 
 	rstrPath := restoreReq.RestorePath
@@ -312,16 +671,73 @@ func (ss *standaloneService) Restore(ctx context.Context, restoreReq *serverpb.R
 		return newErrorStatus(err), err
 	}
 	ss.store, ss.br, ss.cp = st, ba, cp
+	ss.renamer.reopen(st)
+	ss.peerRepl.reopen(st)
+	ss.handleCorruptEntries(st)
 	ss.opts.Logger.Info("Restoration completed")
 	return newEmptyStatus(), nil
 }
 
+func (ss *standaloneService) PointInTimeRestore(ctx context.Context, pitrReq *serverpb.PointInTimeRestoreRequest) (*serverpb.Status, error) {
+	ss.opts.Logger.Info("Waiting for all other requests to complete")
+	ss.rwl.Lock()
+	defer ss.rwl.Unlock()
+
+	rstrPath, chngNum := pitrReq.RestorePath, pitrReq.ChangeNumber
+	ss.opts.Logger.Info("Beginning the point in time restoration.", zap.String("RestorePath", rstrPath), zap.Uint64("ChangeNumber", chngNum))
+	pitr, err := storage.PreparePointInTimeRestore(ss.br, ss.cp, rstrPath, chngNum)
+	if err != nil {
+		ss.opts.Logger.Error("Unable to perform point in time restore", zap.Error(err))
+		return newErrorStatus(err), err
+	}
+	defer pitr.Close()
+
+	ss.scans.ReleaseAll()
+	ss.peerRepl.reopen(nil)
+	st, ba, cp, _, err := pitr.Restore(ss.store)
+	if st != nil {
+		ss.store, ss.br, ss.cp = st, ba, cp
+		ss.renamer.reopen(st)
+		ss.peerRepl.reopen(st)
+		ss.handleCorruptEntries(st)
+	}
+	if err != nil {
+		ss.opts.Logger.Error("Unable to perform point in time restore", zap.Error(err))
+		return newErrorStatus(err), err
+	}
+	ss.opts.Logger.Info("Point in time restoration completed")
+	return newEmptyStatus(), nil
+}
+
+func (ss *standaloneService) GetBackupManifest(ctx context.Context, manifestReq *serverpb.GetBackupManifestRequest) (*serverpb.GetBackupManifestResponse, error) {
+	manifest, err := storage.ReadBackupManifest(manifestReq.BackupPath)
+	if err != nil {
+		ss.opts.Logger.Error("Unable to read backup manifest", zap.Error(err))
+		return &serverpb.GetBackupManifestResponse{Status: newErrorStatus(err)}, err
+	}
+	return &serverpb.GetBackupManifestResponse{Status: newEmptyStatus(), ChangeNumber: manifest.ChangeNumber, CreatedAt: manifest.CreatedAt}, nil
+}
+
 func (ss *standaloneService) Iterate(iterReq *serverpb.IterateRequest, dkvIterSrvr serverpb.DKV_IterateServer) error {
 	ss.rwl.RLock()
 	defer ss.rwl.RUnlock()
 
 	iteration := storage.NewIteration(ss.store, iterReq)
+	if iterReq.Snapshot != "" {
+		view, err := ss.snapshotView(iterReq.Snapshot)
+		if err != nil {
+			ss.opts.Logger.Error("Unable to iterate", zap.Error(err))
+			return dkvIterSrvr.Send(&serverpb.IterateResponse{Status: newErrorStatus(err)})
+		}
+		defer view.Close()
+		iteration = storage.NewSnapshotIteration(view, iterReq)
+	}
 	err := iteration.ForEach(func(e *serverpb.KVPair) error {
+		// Stops as soon as the stream is cancelled or exceeds its deadline,
+		// releasing any snapshot pinned by the iteration
+		if err := dkvIterSrvr.Context().Err(); err != nil {
+			return err
+		}
 		itRes := &serverpb.IterateResponse{Status: newEmptyStatus(), Key: e.Key, Value: e.Value}
 		return dkvIterSrvr.Send(itRes)
 	})
@@ -333,11 +749,607 @@ func (ss *standaloneService) Iterate(iterReq *serverpb.IterateRequest, dkvIterSr
 	return nil
 }
 
+func (ss *standaloneService) ChecksumScan(scanReq *serverpb.ChecksumScanRequest, dkvScanSrvr serverpb.DKV_ChecksumScanServer) error {
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	iterReq := &serverpb.IterateRequest{KeyPrefix: scanReq.KeyPrefix, StartKey: scanReq.StartKey, PinSnapshot: scanReq.PinSnapshot}
+	iteration := storage.NewIteration(ss.store, iterReq)
+	err := iteration.ForEach(func(e *serverpb.KVPair) error {
+		if err := dkvScanSrvr.Context().Err(); err != nil {
+			return err
+		}
+		scanRes := &serverpb.ChecksumScanResponse{Status: newEmptyStatus(), Key: e.Key, Checksum: storage.Checksum(e)}
+		return dkvScanSrvr.Send(scanRes)
+	})
+	if err != nil {
+		ss.opts.Logger.Error("Unable to perform checksum scan", zap.Error(err))
+		scanRes := &serverpb.ChecksumScanResponse{Status: newErrorStatus(err)}
+		return dkvScanSrvr.Send(scanRes)
+	}
+	return nil
+}
+
+func (ss *standaloneService) Aggregate(ctx context.Context, aggReq *serverpb.AggregateRequest) (*serverpb.AggregateResponse, error) {
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	iterReq := &serverpb.IterateRequest{KeyPrefix: aggReq.KeyPrefix, StartKey: aggReq.StartKey, PinSnapshot: aggReq.PinSnapshot}
+	res, err := storage.Aggregate(ctx, storage.NewIteration(ss.store, iterReq), aggReq)
+	if err != nil {
+		ss.opts.Logger.Error("Unable to aggregate", zap.Error(err))
+		return &serverpb.AggregateResponse{Status: newErrorStatus(err)}, err
+	}
+	res.Status = newEmptyStatus()
+	return res, nil
+}
+
+func (ss *standaloneService) Scan(ctx context.Context, scanReq *serverpb.ScanRequest) (*serverpb.ScanResponse, error) {
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	iterReq := &serverpb.IterateRequest{KeyPrefix: scanReq.KeyPrefix, StartKey: scanReq.StartKey, ReadOptions: scanReq.ReadOptions, PinSnapshot: scanReq.PinSnapshot}
+	iteration := storage.NewIteration(ss.store, iterReq)
+	if scanReq.Snapshot != "" && scanReq.ContinuationToken == "" {
+		view, err := ss.snapshotView(scanReq.Snapshot)
+		if err != nil {
+			ss.opts.Logger.Error("Unable to scan", zap.Error(err))
+			return &serverpb.ScanResponse{Status: newErrorStatus(err)}, err
+		}
+		// Cursors retained for the following pages hold the snapshot
+		// through their own iterators
+		defer view.Close()
+		iteration = storage.NewSnapshotIteration(view, iterReq)
+	}
+	items, token, err := ss.scans.Scan(iteration, scanReq)
+	if err != nil {
+		ss.opts.Logger.Error("Unable to scan", zap.Error(err))
+		return &serverpb.ScanResponse{Status: newErrorStatus(err)}, err
+	}
+	return &serverpb.ScanResponse{Status: newEmptyStatus(), Items: items, ContinuationToken: token}, nil
+}
+
+var errSnapshotsNotSupported = errors.New("Current storage engine does not support snapshots")
+
+func (ss *standaloneService) snapshotPublisher() (storage.SnapshotPublisher, error) {
+	if sp, ok := ss.store.(storage.SnapshotPublisher); ok {
+		return sp, nil
+	}
+	return nil, errSnapshotsNotSupported
+}
+
+func (ss *standaloneService) snapshotView(ref string) (storage.SnapshotView, error) {
+	sp, err := ss.snapshotPublisher()
+	if err != nil {
+		return nil, err
+	}
+	return sp.SnapshotView(ref)
+}
+
+var (
+	errVersionedReadsNotSupported = errors.New("Current storage engine does not support reads as of a change number")
+	errSnapshotVersionedRead      = errors.New("reads as of a change number cannot be served from a snapshot")
+)
+
+// read loads the values of the given keys either from the published
+// snapshot with the given reference, or as of the given change number
+// when non-zero, or from the live keyspace using the given read options.
+func (ss *standaloneService) read(snapshotRef string, asOfChngNum uint64, readOpts *serverpb.ReadOptions, keys ...[]byte) ([]*serverpb.KVPair, error) {
+	if asOfChngNum > 0 {
+		if snapshotRef != "" {
+			return nil, errSnapshotVersionedRead
+		}
+		vr, ok := ss.store.(storage.VersionedReader)
+		if !ok {
+			return nil, errVersionedReadsNotSupported
+		}
+		return vr.GetAsOf(asOfChngNum, keys...)
+	}
+	if snapshotRef == "" {
+		return ss.renamer.renames.Read(func(keys ...[]byte) ([]*serverpb.KVPair, error) {
+			return storage.GetWithOptions(ss.store, readOpts, keys...)
+		}, keys...)
+	}
+	view, err := ss.snapshotView(snapshotRef)
+	if err != nil {
+		return nil, err
+	}
+	defer view.Close()
+	return view.Get(keys...)
+}
+
+func (ss *standaloneService) PublishSnapshot(ctx context.Context, pubReq *serverpb.PublishSnapshotRequest) (*serverpb.Status, error) {
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	sp, err := ss.snapshotPublisher()
+	if err == nil {
+		err = sp.PublishSnapshot(pubReq.Namespace, pubReq.Name)
+	}
+	if err != nil {
+		ss.opts.Logger.Error("Unable to publish snapshot", zap.Error(err))
+		return newErrorStatus(err), err
+	}
+	return newEmptyStatus(), nil
+}
+
+func (ss *standaloneService) UnpublishSnapshot(ctx context.Context, unpubReq *serverpb.UnpublishSnapshotRequest) (*serverpb.Status, error) {
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	sp, err := ss.snapshotPublisher()
+	if err == nil {
+		ss.scans.ReleaseSnapshot(unpubReq.Snapshot)
+		err = sp.UnpublishSnapshot(unpubReq.Snapshot)
+	}
+	if err != nil {
+		ss.opts.Logger.Error("Unable to unpublish snapshot", zap.Error(err))
+		return newErrorStatus(err), err
+	}
+	return newEmptyStatus(), nil
+}
+
+func (ss *standaloneService) ListSnapshots(ctx context.Context, _ *emptypb.Empty) (*serverpb.ListSnapshotsResponse, error) {
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	sp, err := ss.snapshotPublisher()
+	if err != nil {
+		return &serverpb.ListSnapshotsResponse{Status: newErrorStatus(err)}, err
+	}
+	return &serverpb.ListSnapshotsResponse{Status: newEmptyStatus(), Snapshots: sp.ListSnapshots()}, nil
+}
+
+var errCompactionNotSupported = errors.New("Current storage engine does not support manual compaction")
+
+func (ss *standaloneService) CompactRange(ctx context.Context, compReq *serverpb.CompactRangeRequest) (*serverpb.Status, error) {
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	compactable, ok := ss.store.(storage.Compactable)
+	if !ok {
+		return newErrorStatus(errCompactionNotSupported), errCompactionNotSupported
+	}
+	if err := compactable.CompactRange(compReq.KeyPrefix); err != nil {
+		ss.opts.Logger.Error("Unable to compact range", zap.Error(err))
+		return newErrorStatus(err), err
+	}
+	return newEmptyStatus(), nil
+}
+
+var errSizeEstimationNotSupported = errors.New("Current storage engine does not support size estimation")
+
+func (ss *standaloneService) EstimateSize(ctx context.Context, estReq *serverpb.EstimateSizeRequest) (*serverpb.EstimateSizeResponse, error) {
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	estimator, ok := ss.store.(storage.SizeEstimator)
+	if !ok {
+		return &serverpb.EstimateSizeResponse{Status: newErrorStatus(errSizeEstimationNotSupported)}, errSizeEstimationNotSupported
+	}
+	est, err := estimator.EstimateSize(estReq.StartKey, estReq.EndKey)
+	if err != nil {
+		ss.opts.Logger.Error("Unable to estimate size", zap.Error(err))
+		return &serverpb.EstimateSizeResponse{Status: newErrorStatus(err)}, err
+	}
+	return &serverpb.EstimateSizeResponse{Status: newEmptyStatus(), NumKeys: est.NumKeys, SizeBytes: est.SizeBytes}, nil
+}
+
+var errReadAmplificationNotSupported = errors.New("Current storage engine does not support read amplification reports")
+
+const (
+	defaultReadAmpWindow = 10 * time.Second
+	maxReadAmpWindow     = time.Minute
+)
+
+func (ss *standaloneService) ReportReadAmplification(ctx context.Context, raReq *serverpb.ReadAmplificationRequest) (*serverpb.ReadAmplificationResponse, error) {
+	// The read lock is not held while sampling, so as to not hold up
+	// backups and restores for the entire window
+	ss.rwl.RLock()
+	sampler, ok := ss.store.(storage.ReadAmplificationSampler)
+	ss.rwl.RUnlock()
+	if !ok {
+		return &serverpb.ReadAmplificationResponse{Status: newErrorStatus(errReadAmplificationNotSupported)}, errReadAmplificationNotSupported
+	}
+	return reportReadAmplification(sampler, raReq, ss.opts.Logger)
+}
+
+// reportReadAmplification samples the reads of the given sampler over
+// the window requested, which is bounded to avoid long running requests.
+func reportReadAmplification(sampler storage.ReadAmplificationSampler, raReq *serverpb.ReadAmplificationRequest, lg *zap.Logger) (*serverpb.ReadAmplificationResponse, error) {
+	window := time.Duration(raReq.SamplingWindowSecs) * time.Second
+	switch {
+	case window == 0:
+		window = defaultReadAmpWindow
+	case window > maxReadAmpWindow:
+		err := fmt.Errorf("sampling window must not exceed %v", maxReadAmpWindow)
+		return &serverpb.ReadAmplificationResponse{Status: newErrorStatus(err)}, err
+	}
+	readAmp, err := sampler.SampleReadAmplification(window)
+	if err != nil {
+		lg.Error("Unable to sample read amplification", zap.Error(err))
+		return &serverpb.ReadAmplificationResponse{Status: newErrorStatus(err)}, err
+	}
+	return &serverpb.ReadAmplificationResponse{
+		Status:            newEmptyStatus(),
+		NumSampledReads:   readAmp.NumReads,
+		NumSampledKeys:    readAmp.NumKeys,
+		BlockReadsPerKey:  readAmp.BlockReadsPerKey,
+		BlockCacheHitRate: readAmp.BlockCacheHitRate,
+		MemtableHitRate:   readAmp.MemtableHitRate,
+		BloomUsefulness:   readAmp.BloomUsefulness,
+		Suggestions:       readAmp.Suggestions,
+	}, nil
+}
+
+func (ss *standaloneService) GetKeyHeatmap(_ context.Context, hmReq *serverpb.GetKeyHeatmapRequest) (*serverpb.GetKeyHeatmapResponse, error) {
+	return heatmap.GetKeyHeatmap(ss.opts.KeyHeatmap, hmReq)
+}
+
+func (ss *standaloneService) GetKeyPolicies(_ context.Context, _ *emptypb.Empty) (*serverpb.GetKeyPoliciesResponse, error) {
+	return &serverpb.GetKeyPoliciesResponse{Status: newEmptyStatus(), KeyPolicies: ss.opts.KeyPolicies}, nil
+}
+
+var errLegalHoldsNotSupported = errors.New("Current storage engine does not support legal holds")
+
+func (ss *standaloneService) PlaceLegalHold(ctx context.Context, lhReq *serverpb.LegalHoldRequest) (*serverpb.Status, error) {
+	return ss.updateLegalHold(ctx, "PlaceLegalHold", lhReq, storage.LegalHolder.PlaceLegalHold)
+}
+
+func (ss *standaloneService) LiftLegalHold(ctx context.Context, lhReq *serverpb.LegalHoldRequest) (*serverpb.Status, error) {
+	return ss.updateLegalHold(ctx, "LiftLegalHold", lhReq, storage.LegalHolder.LiftLegalHold)
+}
+
+func (ss *standaloneService) ListLegalHolds(ctx context.Context, _ *emptypb.Empty) (*serverpb.ListLegalHoldsResponse, error) {
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	lh, ok := ss.store.(storage.LegalHolder)
+	if !ok {
+		return &serverpb.ListLegalHoldsResponse{Status: newErrorStatus(errLegalHoldsNotSupported)}, errLegalHoldsNotSupported
+	}
+	return &serverpb.ListLegalHoldsResponse{Status: newEmptyStatus(), KeyPrefixes: lh.ListLegalHolds()}, nil
+}
+
+// updateLegalHold applies the given legal hold operation and records
+// an audit entry regardless of whether the operation succeeded.
+func (ss *standaloneService) updateLegalHold(ctx context.Context, action string, lhReq *serverpb.LegalHoldRequest,
+	update func(storage.LegalHolder, []byte) error) (*serverpb.Status, error) {
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	err := validateLegalHoldRequest(lhReq)
+	if err == nil {
+		if lh, ok := ss.store.(storage.LegalHolder); ok {
+			err = update(lh, lhReq.KeyPrefix)
+		} else {
+			err = errLegalHoldsNotSupported
+		}
+	}
+	audit.AdminAction(ss.opts.Logger, ctx, action, lhReq.KeyPrefix, lhReq.Requester, lhReq.Reason, err)
+	if err != nil {
+		return newErrorStatus(err), err
+	}
+	return newEmptyStatus(), nil
+}
+
+func validateLegalHoldRequest(lhReq *serverpb.LegalHoldRequest) error {
+	if len(lhReq.KeyPrefix) == 0 {
+		return errors.New("key prefix must be provided for legal holds")
+	}
+	return validateAuditedRequest(lhReq.Requester, lhReq.Reason, "legal holds")
+}
+
+// validateAuditedRequest checks that the requester and the reason of the
+// given audited action are provided.
+func validateAuditedRequest(requester, reason, action string) error {
+	switch {
+	case strings.TrimSpace(requester) == "":
+		return fmt.Errorf("requester must be provided for auditing %s", action)
+	case strings.TrimSpace(reason) == "":
+		return fmt.Errorf("reason must be provided for auditing %s", action)
+	}
+	return nil
+}
+
+var errSubjectErasureNotSupported = errors.New("Current storage engine does not support subject erasure")
+
+func (ss *standaloneService) DeleteSubject(ctx context.Context, delSubjReq *serverpb.DeleteSubjectRequest) (*serverpb.Status, error) {
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	if err := ss.checkWritable(); err != nil {
+		return newErrorStatus(err), err
+	}
+
+	err := validateAuditedRequest(delSubjReq.Requester, delSubjReq.Reason, "subject erasures")
+	switch se, ok := ss.store.(storage.SubjectEraser); {
+	case len(delSubjReq.Subject) == 0:
+		err = errors.New("subject must be provided for erasure")
+	case err != nil:
+	case !ok:
+		err = errSubjectErasureNotSupported
+	case ss.subjectUnderLegalHold(delSubjReq.Subject):
+		err = storage.ErrUnderLegalHold
+	default:
+		err = se.DeleteSubject(delSubjReq.Subject)
+	}
+	audit.AdminAction(ss.opts.Logger, ctx, "DeleteSubject", delSubjReq.Subject, delSubjReq.Requester, delSubjReq.Reason, err)
+	if err != nil {
+		return newErrorStatus(err), toGRPCError(err)
+	}
+	return newEmptyStatus(), nil
+}
+
+var errKeyRotationNotSupported = errors.New("Current storage engine does not support encryption key rotation")
+
+func (ss *standaloneService) RotateEncryptionKey(ctx context.Context, rotateReq *serverpb.RotateEncryptionKeyRequest) (*serverpb.RotateEncryptionKeyResponse, error) {
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	rotator, _ := ss.store.(storage.EncryptionKeyRotator)
+	return rotateEncryptionKey(ctx, rotator, rotateReq, ss.opts.Logger)
+}
+
+// rotateEncryptionKey re-encrypts the values of the given store using
+// the latest master key, auditing the rotation.
+func rotateEncryptionKey(ctx context.Context, rotator storage.EncryptionKeyRotator, rotateReq *serverpb.RotateEncryptionKeyRequest, lg *zap.Logger) (*serverpb.RotateEncryptionKeyResponse, error) {
+	var rotation *storage.KeyRotation
+	err := validateAuditedRequest(rotateReq.Requester, rotateReq.Reason, "key rotations")
+	switch {
+	case err != nil:
+	case rotator == nil:
+		err = errKeyRotationNotSupported
+	default:
+		rotation, err = rotator.RotateEncryptionKey()
+	}
+	audit.AdminAction(lg, ctx, "RotateEncryptionKey", nil, rotateReq.Requester, rotateReq.Reason, err)
+	if err != nil {
+		return &serverpb.RotateEncryptionKeyResponse{Status: newErrorStatus(err)}, err
+	}
+	return &serverpb.RotateEncryptionKeyResponse{
+		Status:         newEmptyStatus(),
+		ActiveKeyID:    rotation.ActiveKeyID,
+		NumScanned:     rotation.NumScanned,
+		NumReencrypted: rotation.NumReencrypted,
+	}, nil
+}
+
+var errTenantEncryptionNotSupported = errors.New("Current storage engine does not support tenant encryption")
+
+func (ss *standaloneService) RotateTenantKey(ctx context.Context, rotateReq *serverpb.RotateTenantKeyRequest) (*serverpb.RotateEncryptionKeyResponse, error) {
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	if err := ss.checkWritable(); err != nil {
+		return &serverpb.RotateEncryptionKeyResponse{Status: newErrorStatus(err)}, err
+	}
+
+	var rotation *storage.KeyRotation
+	err := validateAuditedRequest(rotateReq.Requester, rotateReq.Reason, "key rotations")
+	switch tkm, ok := ss.store.(storage.TenantKeyManager); {
+	case len(rotateReq.Tenant) == 0:
+		err = errors.New("tenant must be provided for key rotation")
+	case err != nil:
+	case !ok:
+		err = errTenantEncryptionNotSupported
+	default:
+		rotation, err = tkm.RotateTenantKey(rotateReq.Tenant)
+	}
+	audit.AdminAction(ss.opts.Logger, ctx, "RotateTenantKey", rotateReq.Tenant, rotateReq.Requester, rotateReq.Reason, err)
+	if err != nil {
+		return &serverpb.RotateEncryptionKeyResponse{Status: newErrorStatus(err)}, err
+	}
+	return &serverpb.RotateEncryptionKeyResponse{
+		Status:         newEmptyStatus(),
+		ActiveKeyID:    rotation.ActiveKeyID,
+		NumScanned:     rotation.NumScanned,
+		NumReencrypted: rotation.NumReencrypted,
+	}, nil
+}
+
+func (ss *standaloneService) DeleteTenant(ctx context.Context, delTenantReq *serverpb.DeleteTenantRequest) (*serverpb.Status, error) {
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	if err := ss.checkWritable(); err != nil {
+		return newErrorStatus(err), err
+	}
+
+	err := validateAuditedRequest(delTenantReq.Requester, delTenantReq.Reason, "tenant erasures")
+	switch tkm, ok := ss.store.(storage.TenantKeyManager); {
+	case len(delTenantReq.Tenant) == 0:
+		err = errors.New("tenant must be provided for erasure")
+	case err != nil:
+	case !ok:
+		err = errTenantEncryptionNotSupported
+	case ss.subjectUnderLegalHold(delTenantReq.Tenant):
+		err = storage.ErrUnderLegalHold
+	default:
+		err = tkm.DeleteTenant(delTenantReq.Tenant)
+	}
+	audit.AdminAction(ss.opts.Logger, ctx, "DeleteTenant", delTenantReq.Tenant, delTenantReq.Requester, delTenantReq.Reason, err)
+	if err != nil {
+		return newErrorStatus(err), toGRPCError(err)
+	}
+	return newEmptyStatus(), nil
+}
+
+// subjectUnderLegalHold checks if any of the keys of the given subject
+// are under legal hold, either fully or partially.
+func (ss *standaloneService) subjectUnderLegalHold(subject []byte) bool {
+	lh, ok := ss.store.(storage.LegalHolder)
+	if !ok {
+		return false
+	}
+	if lh.UnderLegalHold(subject) {
+		return true
+	}
+	for _, prefix := range lh.ListLegalHolds() {
+		if bytes.HasPrefix(prefix, subject) {
+			return true
+		}
+	}
+	return false
+}
+
+var errAccessTokensNotEnabled = errors.New("Current DKV instance does not support access tokens")
+
+func (ss *standaloneService) MintAccessToken(ctx context.Context, mintReq *serverpb.MintAccessTokenRequest) (*serverpb.MintAccessTokenResponse, error) {
+	var token string
+	var claims *auth.Claims
+	err := validateAuditedRequest(mintReq.Requester, mintReq.Reason, "access tokens")
+	switch {
+	case err != nil:
+	case ss.opts.TokenIssuer == nil:
+		err = errAccessTokensNotEnabled
+	default:
+		ttl := time.Duration(mintReq.TtlSecs) * time.Second
+		token, claims, err = ss.opts.TokenIssuer.Mint(mintReq.KeyPrefix, mintReq.Requester, ttl)
+	}
+	audit.AdminAction(ss.opts.Logger, ctx, "MintAccessToken", mintReq.KeyPrefix, mintReq.Requester, mintReq.Reason, err)
+	if err != nil {
+		return &serverpb.MintAccessTokenResponse{Status: newErrorStatus(err)}, err
+	}
+	return &serverpb.MintAccessTokenResponse{Status: newEmptyStatus(), Token: token, ExpireTS: uint64(claims.ExpireTS)}, nil
+}
+
+var errEffectiveConfigNotAvailable = errors.New("Effective configuration is not available on current DKV instance")
+
+func (ss *standaloneService) GetEngineStats(_ context.Context, _ *emptypb.Empty) (*serverpb.GetEngineStatsResponse, error) {
+	es, err := ss.engineStats()
+	if err != nil {
+		ss.opts.Logger.Error("Unable to retrieve engine stats", zap.Error(err))
+		return &serverpb.GetEngineStatsResponse{Status: newErrorStatus(err)}, err
+	}
+	return &serverpb.GetEngineStatsResponse{
+		Status:                 newEmptyStatus(),
+		MemtableSizeBytes:      es.MemtableSizeBytes,
+		NumFilesPerLevel:       es.NumFilesPerLevel,
+		BlockCacheHitRate:      es.BlockCacheHitRate,
+		PendingCompactionBytes: es.PendingCompactionBytes,
+		OldestChangeNumber:     es.OldestChangeNumber,
+		ChangeHistoryBytes:     es.ChangeHistoryBytes,
+		NamespaceCacheStats:    es.NamespaceCacheStats,
+	}, nil
+}
+
+var errSSTIngestionNotSupported = errors.New("Current storage engine does not support SST ingestion")
+
+func (ss *standaloneService) IngestSST(_ context.Context, ingestReq *serverpb.IngestSSTRequest) (*serverpb.Status, error) {
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	if err := ss.checkWritable(); err != nil {
+		return newErrorStatus(err), err
+	}
+
+	ingester, ok := ss.store.(storage.SSTIngester)
+	if !ok {
+		return newErrorStatus(errSSTIngestionNotSupported), errSSTIngestionNotSupported
+	}
+	if len(ingestReq.Files) == 0 && len(ingestReq.TtlFiles) == 0 {
+		err := errors.New("SST files to ingest must be provided")
+		return newErrorStatus(err), err
+	}
+	if err := ingester.IngestSST(ingestReq.Files, ingestReq.TtlFiles, ingestReq.MoveFiles); err != nil {
+		ss.opts.Logger.Error("Unable to ingest SST files", zap.Error(err))
+		return newErrorStatus(err), err
+	}
+	return newEmptyStatus(), nil
+}
+
+var errExportNotSupported = errors.New("Current storage engine does not support export")
+
+func (ss *standaloneService) Export(_ context.Context, exportReq *serverpb.ExportRequest) (*serverpb.ExportResponse, error) {
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	exporter, ok := ss.store.(storage.Exporter)
+	if !ok {
+		return &serverpb.ExportResponse{Status: newErrorStatus(errExportNotSupported)}, errExportNotSupported
+	}
+	files, numKeys, err := storage.ExportTo(exporter, exportReq.StartKey, exportReq.EndKey, exportReq.Format, exportReq.Destination)
+	if err != nil {
+		ss.opts.Logger.Error("Unable to export key range", zap.Error(err))
+		return &serverpb.ExportResponse{Status: newErrorStatus(err)}, err
+	}
+	return &serverpb.ExportResponse{Status: newEmptyStatus(), Files: files, NumKeys: numKeys}, nil
+}
+
+var errQuarantineNotSupported = errors.New("Current storage engine does not support quarantining records")
+
+func (ss *standaloneService) ListQuarantinedRecords(_ context.Context, _ *emptypb.Empty) (*serverpb.ListQuarantinedRecordsResponse, error) {
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	quarantiner, ok := ss.store.(storage.Quarantiner)
+	if !ok {
+		return &serverpb.ListQuarantinedRecordsResponse{Status: newErrorStatus(errQuarantineNotSupported)}, errQuarantineNotSupported
+	}
+	recs, err := quarantiner.ListQuarantinedRecords()
+	if err != nil {
+		return &serverpb.ListQuarantinedRecordsResponse{Status: newErrorStatus(err)}, err
+	}
+	return &serverpb.ListQuarantinedRecordsResponse{Status: newEmptyStatus(), Records: recs}, nil
+}
+
+var errMemtableTuningNotSupported = errors.New("Current storage engine does not support tuning memtables")
+
+func (ss *standaloneService) SetMemtableOptions(_ context.Context, mtOpts *serverpb.MemtableOptions) (*serverpb.Status, error) {
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	tuner, ok := ss.store.(storage.MemtableTuner)
+	if !ok {
+		return newErrorStatus(errMemtableTuningNotSupported), errMemtableTuningNotSupported
+	}
+	if err := tuner.SetMemtableOptions(mtOpts); err != nil {
+		ss.opts.Logger.Error("Unable to set memtable options", zap.Error(err))
+		return newErrorStatus(err), err
+	}
+	return newEmptyStatus(), nil
+}
+
+func (ss *standaloneService) engineStats() (*storage.EngineStats, error) {
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+	return ss.store.Stats()
+}
+
+func (ss *standaloneService) GetEffectiveConfig(_ context.Context, _ *emptypb.Empty) (*serverpb.GetEffectiveConfigResponse, error) {
+	if ss.opts.EffectiveConfig == "" {
+		return &serverpb.GetEffectiveConfigResponse{Status: newErrorStatus(errEffectiveConfigNotAvailable)}, errEffectiveConfigNotAvailable
+	}
+	return &serverpb.GetEffectiveConfigResponse{Status: newEmptyStatus(), Config: ss.opts.EffectiveConfig}, nil
+}
+
+// toGRPCError converts storage errors that have a well defined
+// GRPC status into the corresponding status errors.
+func toGRPCError(err error) error {
+	if errors.Is(err, storage.ErrUnderLegalHold) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if errors.Is(err, storage.ErrUnmergeableValue) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	// Conflicting transactions can be retried
+	if errors.Is(err, storage.ErrTxnConflict) {
+		return status.Error(codes.Aborted, err.Error())
+	}
+	return err
+}
+
 func (ss *standaloneService) Close() error {
 	defer ss.opts.Logger.Sync()
 	ss.opts.Logger.Info("Closing DKV service")
 	ss.shutdown <- struct{}{}
 	ss.isClosed = true
+	ss.engStats.Close()
+	ss.scans.Close()
+	ss.checkpoints.Close()
+	ss.peerRepl.Close()
+	ss.renamer.Close()
 	ss.store.Close()
 	return nil
 }
@@ -353,6 +1365,7 @@ type DKVClusterService interface {
 
 type distributedService struct {
 	DKVService
+	cp       storage.ChangePropagator
 	raftRepl nexus_api.RaftReplicator
 	isClosed bool
 	// shutdown should be a buffer channel to avoid blocking close in case the health check client
@@ -365,12 +1378,35 @@ type distributedService struct {
 // that attempts to replicate data across multiple replicas over Nexus.
 func NewDistributedService(kvs storage.KVStore, cp storage.ChangePropagator, br storage.Backupable,
 	raftRepl nexus_api.RaftReplicator, regionInfo *serverpb.RegionInfo, opts *opts.ServerOpts) DKVClusterService {
-	return &distributedService{
+	ds := &distributedService{
 		DKVService: NewStandaloneService(kvs, cp, br, regionInfo, opts),
+		cp:         cp,
 		raftRepl:   raftRepl,
 		shutdown:   make(chan struct{}, 1),
 		opts:       opts,
 	}
+	// Corrupt entries are quarantined on all the replicas alike
+	if q, ok := kvs.(storage.Quarantiner); ok {
+		q.OnCorruptEntry(ds.quarantine)
+	}
+	return ds
+}
+
+// quarantineTimeout bounds the time taken to quarantine a corrupt entry
+// through the RAFT log, for which the scrubber waits.
+const quarantineTimeout = 10 * time.Second
+
+// quarantine quarantines the given corrupt entry found by the scrubber of
+// this member on every member of the cluster through the RAFT log.
+func (ds *distributedService) quarantine(rec *serverpb.QuarantinedRecord) error {
+	reqBts, err := proto.Marshal(&raftpb.InternalRaftRequest{Quarantine: rec})
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), quarantineTimeout)
+	defer cancel()
+	_, err = ds.raftRepl.Save(ctx, reqBts)
+	return err
 }
 
 func (ds *distributedService) Put(ctx context.Context, putReq *serverpb.PutRequest) (*serverpb.PutResponse, error) {
@@ -383,6 +1419,8 @@ func (ds *distributedService) Put(ctx context.Context, putReq *serverpb.PutReque
 		if _, err = ds.raftRepl.Save(ctx, reqBts); err != nil {
 			ds.opts.Logger.Error("Unable to save in replicated storage", zap.Error(err))
 			res.Status = newErrorStatus(err)
+		} else {
+			res = newPutResponse(serverpb.Durability_CONSENSUS_COMMITTED, ds.quorumSize(), latestChangeNumber(ds.cp, ds.opts.Logger))
 		}
 	}
 	return res, err
@@ -398,6 +1436,8 @@ func (ds *distributedService) MultiPut(ctx context.Context, multiPutReq *serverp
 		if _, err = ds.raftRepl.Save(ctx, reqBts); err != nil {
 			ds.opts.Logger.Error("Unable to save in replicated storage", zap.Error(err))
 			res.Status = newErrorStatus(err)
+		} else {
+			res = newPutResponse(serverpb.Durability_CONSENSUS_COMMITTED, ds.quorumSize(), latestChangeNumber(ds.cp, ds.opts.Logger))
 		}
 	}
 	return res, err
@@ -414,10 +1454,50 @@ func (ds *distributedService) CompareAndSet(ctx context.Context, casReq *serverp
 	}
 	// '0' indicates CAS update was successful
 	res.Updated = casRes[0] == 0
+	if res.Updated {
+		res.ChangeNumber = latestChangeNumber(ds.cp, ds.opts.Logger)
+	}
+	return res, err
+}
+
+func (ds *distributedService) Transact(ctx context.Context, txnReq *serverpb.TransactRequest) (*serverpb.TransactResponse, error) {
+	reqBts, _ := proto.Marshal(&raftpb.InternalRaftRequest{Transact: txnReq})
+	res := &serverpb.TransactResponse{Status: newEmptyStatus()}
+	txnRes, err := ds.raftRepl.Save(ctx, reqBts)
+	if err != nil {
+		ds.opts.Logger.Error("Unable to transact in replicated storage", zap.Error(err))
+		res.Status = newErrorStatus(err)
+		return res, toGRPCError(err)
+	}
+	// '0' indicates the transaction was committed
+	if txnRes[0] != 0 {
+		res.Status = newErrorStatus(storage.ErrTxnConflict)
+		return res, toGRPCError(storage.ErrTxnConflict)
+	}
+	res.ChangeNumber = latestChangeNumber(ds.cp, ds.opts.Logger)
+	return res, nil
+}
+
+func (ds *distributedService) Merge(ctx context.Context, mergeReq *serverpb.MergeRequest) (*serverpb.MergeResponse, error) {
+	reqBts, err := proto.Marshal(&raftpb.InternalRaftRequest{Merge: mergeReq})
+	res := &serverpb.MergeResponse{Status: newEmptyStatus()}
+	if err != nil {
+		ds.opts.Logger.Error("Unable to MERGE over Nexus", zap.Error(err))
+		res.Status = newErrorStatus(err)
+	} else {
+		if _, err = ds.raftRepl.Save(ctx, reqBts); err != nil {
+			ds.opts.Logger.Error("Unable to merge in replicated storage", zap.Error(err))
+			res.Status = newErrorStatus(err)
+		} else {
+			res.ChangeNumber = latestChangeNumber(ds.cp, ds.opts.Logger)
+		}
+	}
 	return res, err
 }
 
 func (ds *distributedService) Delete(ctx context.Context, delReq *serverpb.DeleteRequest) (*serverpb.DeleteResponse, error) {
+	// Deletes under legal hold are rejected upon being applied, as per
+	// the legal holds applied through consensus
 	reqBts, err := proto.Marshal(&raftpb.InternalRaftRequest{Delete: delReq})
 	res := &serverpb.DeleteResponse{Status: newEmptyStatus()}
 	if err != nil {
@@ -427,13 +1507,57 @@ func (ds *distributedService) Delete(ctx context.Context, delReq *serverpb.Delet
 		if _, err = ds.raftRepl.Save(ctx, reqBts); err != nil {
 			ds.opts.Logger.Error("Unable to delete in replicated storage", zap.Error(err))
 			res.Status = newErrorStatus(err)
+		} else {
+			res.ChangeNumber = latestChangeNumber(ds.cp, ds.opts.Logger)
 		}
 	}
-	return res, err
+	return res, toGRPCError(err)
+}
+
+// Legal holds are placed and lifted through consensus, as they are part
+// of the replicated state consulted by the deletes upon being applied.
+func (ds *distributedService) PlaceLegalHold(ctx context.Context, lhReq *serverpb.LegalHoldRequest) (*serverpb.Status, error) {
+	return ds.updateLegalHold(ctx, "PlaceLegalHold", lhReq, &raftpb.InternalRaftRequest{PlaceLegalHold: lhReq})
+}
+
+func (ds *distributedService) LiftLegalHold(ctx context.Context, lhReq *serverpb.LegalHoldRequest) (*serverpb.Status, error) {
+	return ds.updateLegalHold(ctx, "LiftLegalHold", lhReq, &raftpb.InternalRaftRequest{LiftLegalHold: lhReq})
+}
+
+func (ds *distributedService) updateLegalHold(ctx context.Context, action string, lhReq *serverpb.LegalHoldRequest,
+	intReq *raftpb.InternalRaftRequest) (*serverpb.Status, error) {
+	err := validateLegalHoldRequest(lhReq)
+	if err == nil {
+		var reqBts []byte
+		if reqBts, err = proto.Marshal(intReq); err == nil {
+			_, err = ds.raftRepl.Save(ctx, reqBts)
+		}
+	}
+	audit.AdminAction(ds.opts.Logger, ctx, action, lhReq.KeyPrefix, lhReq.Requester, lhReq.Reason, err)
+	if err != nil {
+		return newErrorStatus(err), err
+	}
+	return newEmptyStatus(), nil
+}
+
+// readConsistency upgrades a sequential read to a linearizable one, when
+// this node is yet to apply the minimum change number required by the read.
+// Linearizable reads are served only after this node applies all the
+// changes committed by the cluster, which includes the required change.
+func (ds *distributedService) readConsistency(consistency serverpb.ReadConsistency, minChngNum uint64) serverpb.ReadConsistency {
+	if consistency == serverpb.ReadConsistency_SEQUENTIAL && minChngNum > latestChangeNumber(ds.cp, ds.opts.Logger) {
+		return serverpb.ReadConsistency_LINEARIZABLE
+	}
+	return consistency
 }
 
 func (ds *distributedService) Get(ctx context.Context, getReq *serverpb.GetRequest) (*serverpb.GetResponse, error) {
-	switch getReq.ReadConsistency {
+	// Published snapshots are immutable and local to this node,
+	// as are the change numbers of the historical reads
+	if getReq.Snapshot != "" || getReq.AsOfChangeNumber > 0 {
+		return ds.DKVService.Get(ctx, getReq)
+	}
+	switch ds.readConsistency(getReq.ReadConsistency, getReq.MinChangeNumber) {
 	case serverpb.ReadConsistency_SEQUENTIAL:
 		return ds.DKVService.Get(ctx, getReq)
 	case serverpb.ReadConsistency_LINEARIZABLE:
@@ -449,7 +1573,9 @@ func (ds *distributedService) Get(ctx context.Context, getReq *serverpb.GetReque
 				loadError = err
 			} else {
 				if kvs != nil && len(kvs) == 1 {
-					res.Value = kvs[0].Value
+					res.Value, res.ExpireTS = kvs[0].Value, kvs[0].ExpireTS
+				} else {
+					res.NotFound = true
 				}
 			}
 		}
@@ -460,7 +1586,12 @@ func (ds *distributedService) Get(ctx context.Context, getReq *serverpb.GetReque
 }
 
 func (ds *distributedService) MultiGet(ctx context.Context, multiGetReq *serverpb.MultiGetRequest) (*serverpb.MultiGetResponse, error) {
-	switch multiGetReq.ReadConsistency {
+	// Published snapshots are immutable and local to this node,
+	// as are the change numbers of the historical reads
+	if multiGetReq.Snapshot != "" || multiGetReq.AsOfChangeNumber > 0 {
+		return ds.DKVService.MultiGet(ctx, multiGetReq)
+	}
+	switch ds.readConsistency(multiGetReq.ReadConsistency, multiGetReq.MinChangeNumber) {
 	case serverpb.ReadConsistency_SEQUENTIAL:
 		return ds.DKVService.MultiGet(ctx, multiGetReq)
 	case serverpb.ReadConsistency_LINEARIZABLE:
@@ -480,6 +1611,38 @@ func (ds *distributedService) MultiGet(ctx context.Context, multiGetReq *serverp
 	}
 }
 
+func (ds *distributedService) Exists(ctx context.Context, existsReq *serverpb.ExistsRequest) (*serverpb.ExistsResponse, error) {
+	// Published snapshots are immutable and local to this node
+	if existsReq.Snapshot != "" {
+		return ds.DKVService.Exists(ctx, existsReq)
+	}
+	switch existsReq.ReadConsistency {
+	case serverpb.ReadConsistency_SEQUENTIAL:
+		return ds.DKVService.Exists(ctx, existsReq)
+	case serverpb.ReadConsistency_LINEARIZABLE:
+		// Linearizable reads are served through the replicated storage,
+		// which only supports loading values
+		getReq := &serverpb.GetRequest{Key: existsReq.Key, ReadConsistency: existsReq.ReadConsistency}
+		getRes, err := ds.Get(ctx, getReq)
+		res := &serverpb.ExistsResponse{Status: newEmptyStatus()}
+		if err != nil {
+			res.Status = newErrorStatus(err)
+		} else {
+			res.Exists = len(getRes.Value) > 0
+		}
+		return res, err
+	default:
+		return nil, fmt.Errorf("Unknown read consistency level: %d", existsReq.ReadConsistency)
+	}
+}
+
+// quorumSize is the number of the members of the Nexus cluster that
+// must hold a change for it to be committed through consensus.
+func (ds *distributedService) quorumSize() uint32 {
+	_, members := ds.raftRepl.ListMembers()
+	return uint32(len(members)/2 + 1)
+}
+
 func gobDecodeAsKVPairs(val []byte) ([]*serverpb.KVPair, error) {
 	buf := bytes.NewBuffer(val)
 	res := new([]*serverpb.KVPair)
@@ -493,11 +1656,72 @@ func (ds *distributedService) Iterate(iterReq *serverpb.IterateRequest, dkvIterS
 	return ds.DKVService.Iterate(iterReq, dkvIterSrvr)
 }
 
+func (ds *distributedService) Promote(ctx context.Context, _ *serverpb.PromoteRequest) (*serverpb.PromoteResponse, error) {
+	err := errors.New("Current DKV instance does not support promotion, since its leadership is fenced by the raft terms")
+	return &serverpb.PromoteResponse{Status: newErrorStatus(err)}, err
+}
+
+func (ds *distributedService) PauseWrites(ctx context.Context, _ *serverpb.PauseWritesRequest) (*serverpb.PauseWritesResponse, error) {
+	err := errors.New("Current DKV instance does not support pausing the writes")
+	return &serverpb.PauseWritesResponse{Status: newErrorStatus(err)}, err
+}
+
+func (ds *distributedService) ResumeWrites(ctx context.Context, _ *emptypb.Empty) (*serverpb.Status, error) {
+	err := errors.New("Current DKV instance does not support pausing the writes")
+	return newErrorStatus(err), err
+}
+
+func (ds *distributedService) Fence(ctx context.Context, _ *serverpb.FenceRequest) (*serverpb.Status, error) {
+	err := errors.New("Current DKV instance does not support fencing, since its leadership is fenced by the raft terms")
+	return newErrorStatus(err), err
+}
+
 func (ds *distributedService) Restore(ctx context.Context, restoreReq *serverpb.RestoreRequest) (*serverpb.Status, error) {
 	err := errors.New("Current DKV instance does not support restores")
 	return newErrorStatus(err), err
 }
 
+func (ds *distributedService) PointInTimeRestore(ctx context.Context, pitrReq *serverpb.PointInTimeRestoreRequest) (*serverpb.Status, error) {
+	err := errors.New("Current DKV instance does not support restores")
+	return newErrorStatus(err), err
+}
+
+func (ds *distributedService) DeleteSubject(ctx context.Context, delSubjReq *serverpb.DeleteSubjectRequest) (*serverpb.Status, error) {
+	err := errors.New("Current DKV instance does not support subject erasure")
+	return newErrorStatus(err), err
+}
+
+// RotateTenantKey is not supported in the distributed mode, since every
+// member would generate a data key of its own outside the RAFT log.
+func (ds *distributedService) RotateTenantKey(_ context.Context, _ *serverpb.RotateTenantKeyRequest) (*serverpb.RotateEncryptionKeyResponse, error) {
+	err := errors.New("Current DKV instance does not support tenant key rotation")
+	return &serverpb.RotateEncryptionKeyResponse{Status: newErrorStatus(err)}, err
+}
+
+func (ds *distributedService) DeleteTenant(_ context.Context, _ *serverpb.DeleteTenantRequest) (*serverpb.Status, error) {
+	err := errors.New("Current DKV instance does not support tenant erasure")
+	return newErrorStatus(err), err
+}
+
+// IngestSST is not supported in the distributed mode, since the ingested
+// keys bypass the RAFT log and would diverge across the cluster.
+func (ds *distributedService) IngestSST(_ context.Context, _ *serverpb.IngestSSTRequest) (*serverpb.Status, error) {
+	err := errors.New("Current DKV instance does not support SST ingestion")
+	return newErrorStatus(err), err
+}
+
+// Prefix renames are not supported in the distributed mode, since the keys
+// are copied and deleted in the background bypassing the RAFT log.
+func (ds *distributedService) BeginPrefixRename(_ context.Context, _ *serverpb.PrefixRenameRequest) (*serverpb.Status, error) {
+	err := errors.New("Current DKV instance does not support prefix renames")
+	return newErrorStatus(err), err
+}
+
+func (ds *distributedService) CompletePrefixRename(_ context.Context, _ *serverpb.PrefixRenameRequest) (*serverpb.Status, error) {
+	err := errors.New("Current DKV instance does not support prefix renames")
+	return newErrorStatus(err), err
+}
+
 func (ds *distributedService) AddNode(ctx context.Context, req *serverpb.AddNodeRequest) (*serverpb.Status, error) {
 	// TODO: We can include any relevant checks on the joining node - like reachability, storage engine compatibility, etc.
 	if err := ds.raftRepl.AddMember(ctx, req.NodeUrl); err != nil {
@@ -525,6 +1749,7 @@ func (ds *distributedService) Close() error {
 	// Do not invoke DKVService::Close here since `raftRepl` already
 	// closes the underlying storage connection.
 	// TODO - fix the above as ideally it should call DKVService::Close if there are more aspects to DKVService to close other than storage
+	ds.DKVService.(*standaloneService).engStats.Close()
 	ds.raftRepl.Stop()
 	ds.shutdown <- struct{}{}
 	ds.isClosed = true
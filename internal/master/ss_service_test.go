@@ -3,22 +3,31 @@ package master
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"os/exec"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/flipkart-incubator/dkv/internal/keypolicy"
 	"github.com/flipkart-incubator/dkv/internal/opts"
 	"github.com/flipkart-incubator/dkv/internal/stats"
 	"github.com/flipkart-incubator/dkv/internal/storage"
 	"github.com/flipkart-incubator/dkv/internal/storage/badger"
 	"github.com/flipkart-incubator/dkv/internal/storage/rocksdb"
 	"github.com/flipkart-incubator/dkv/pkg/health"
+	"github.com/golang/protobuf/proto"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/flipkart-incubator/dkv/pkg/ctl"
 	"github.com/flipkart-incubator/dkv/pkg/serverpb"
@@ -63,14 +72,43 @@ func TestStandaloneService(t *testing.T) {
 		defer grpcSrvr.Stop()
 		t.Run("testPutAndGet", testPutAndGet)
 		t.Run("testPutTTLAndGet", testPutTTLAndGet)
+		t.Run("testPutDurability", testPutDurability)
+		t.Run("testAwaitDurability", testAwaitDurability)
+		t.Run("testSemiSyncReplication", testSemiSyncReplication)
+		t.Run("testReplicationLag", testReplicationLag)
+		t.Run("testRetentionHeadroom", testRetentionHeadroom)
 		t.Run("testAtomicKeyCreation", testAtomicKeyCreation)
 		t.Run("testAtomicIncrDecr", testAtomicIncrDecr)
 		t.Run("testDelete", testDelete)
 		t.Run("testMultiGet", testMultiGet)
+		t.Run("testRequestBatching", testRequestBatching)
+		t.Run("testCompression", testCompression)
+		t.Run("testClientHooks", testClientHooks)
+		t.Run("testObjectMapper", testObjectMapper)
+		t.Run("testKeyBuilder", testKeyBuilder)
+		t.Run("testVersionedKeyBuilder", testVersionedKeyBuilder)
 		t.Run("testIteration", testIteration)
+		t.Run("testPaginatedScan", testPaginatedScan)
+		t.Run("testChecksumScan", testChecksumScan)
+		t.Run("testAggregate", testAggregate)
 		t.Run("testMissingGet", testMissingGet)
+		t.Run("testExists", testExists)
+		t.Run("testTaskScheduler", testTaskScheduler)
+		t.Run("testTransaction", testTransaction)
+		t.Run("testKeyLocks", testKeyLocks)
+		t.Run("testSharedKeyLocks", testSharedKeyLocks)
 		t.Run("testGetChanges", testGetChanges)
+		t.Run("testClone", testClone)
+		t.Run("testStreamChanges", testStreamChanges)
+		t.Run("testStreamChangesWithBatching", testStreamChangesWithBatching)
+		t.Run("testEncodedChanges", testEncodedChanges)
+		t.Run("testKafkaSink", testKafkaSink)
+		t.Run("testChangeNotifier", testChangeNotifier)
+		t.Run("testPrefixRename", testPrefixRename)
+		t.Run("testPromote", testPromote)
+		t.Run("testPauseWrites", testPauseWrites)
 		t.Run("testBackupRestore", testBackupRestore)
+		t.Run("testActiveActiveRestore", testActiveActiveRestore)
 		t.Run("testStandaloneHealthCheckUnary", testStandaloneHealthCheckUnary)
 	}
 }
@@ -114,6 +152,175 @@ func testPutAndGet(t *testing.T) {
 	}
 }
 
+func testPutDurability(t *testing.T) {
+	key, value := "DurableKey", "SomeValue"
+	res, err := dkvCli.PutWithWALSync([]byte(key), []byte(value), serverpb.WALSync_SYNC_WAL)
+	if err != nil {
+		t.Fatalf("Unable to PUT. Key: %s, Value: %s, Error: %v", key, value, err)
+	}
+	expDurability := serverpb.Durability_LOCAL_WAL_SYNCED
+	if engine != "rocksdb" {
+		expDurability = serverpb.Durability_UNKNOWN_DURABILITY
+	}
+	if res.Durability != expDurability || res.NumReplicas != 1 {
+		t.Errorf("Expected durability %s with 1 replica, but got %s with %d replicas", expDurability, res.Durability, res.NumReplicas)
+	}
+}
+
+func testAwaitDurability(t *testing.T) {
+	key, value := "AsyncKey", "SomeValue"
+	res, err := dkvCli.PutWithWALSync([]byte(key), []byte(value), serverpb.WALSync_ASYNC_WAL)
+	if err != nil {
+		t.Fatalf("Unable to PUT. Key: %s, Value: %s, Error: %v", key, value, err)
+	}
+	awaitRes, err := dkvCli.AwaitDurability(res.ChangeNumber, serverpb.Durability_LOCAL_WAL_SYNCED, 0, time.Second)
+	if err != nil {
+		t.Fatalf("Unable to await durability. Error: %v", err)
+	}
+	if engine == "rocksdb" && (awaitRes.Durability != serverpb.Durability_LOCAL_WAL_SYNCED || awaitRes.SyncedChangeNumber < res.ChangeNumber) {
+		t.Errorf("Expected the write to be synced, but got %s upto %d", awaitRes.Durability, awaitRes.SyncedChangeNumber)
+	}
+
+	if awaitRes, err = dkvCli.AwaitDurability(res.ChangeNumber, serverpb.Durability_SLAVE_REPLICATED, 1, 100*time.Millisecond); err != nil {
+		t.Fatalf("Unable to await durability. Error: %v", err)
+	} else if awaitRes.Durability == serverpb.Durability_SLAVE_REPLICATED || awaitRes.NumReplicas != 0 {
+		t.Errorf("Did not expect the write to be replicated, but got %s with %d replicas", awaitRes.Durability, awaitRes.NumReplicas)
+	}
+	if _, err = dkvCli.ReplicateChanges("SomeSlave", res.ChangeNumber+1, 1); err != nil {
+		t.Fatalf("Unable to replicate changes. Error: %v", err)
+	}
+	if awaitRes, err = dkvCli.AwaitDurability(res.ChangeNumber, serverpb.Durability_SLAVE_REPLICATED, 1, 0); err != nil {
+		t.Fatalf("Unable to await durability. Error: %v", err)
+	} else if awaitRes.Durability != serverpb.Durability_SLAVE_REPLICATED || awaitRes.NumReplicas != 1 {
+		t.Errorf("Expected the write to be replicated, but got %s with %d replicas", awaitRes.Durability, awaitRes.NumReplicas)
+	}
+	if _, err = dkvCli.AwaitDurability(res.ChangeNumber, serverpb.Durability_LOCAL_WAL_WRITTEN, 0, 0); err == nil {
+		t.Error("Expected an error when awaiting an invalid durability")
+	}
+}
+
+func testSemiSyncReplication(t *testing.T) {
+	ss := dkvSvc.(*standaloneService)
+	replicaID, timeout := "SemiSyncSlave", 200*time.Millisecond
+	ss.semiSync = newSemiSyncReplication(replicaID, timeout, ss.replicas, ss.opts.Logger, ss.opts.StatsCli)
+	defer func() { ss.semiSync = nil }()
+
+	// Writes are acknowledged after the timeout when the replica is unavailable
+	start := time.Now()
+	res, err := dkvCli.PutWithWALSync([]byte("SemiSyncKey1"), []byte("SemiSyncVal1"), serverpb.WALSync_DEFAULT_WAL_SYNC)
+	if err != nil {
+		t.Fatalf("Unable to PUT. Error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < timeout {
+		t.Errorf("Expected the write to await the semi-sync replica for %v, but took %v", timeout, elapsed)
+	}
+	if res.Durability == serverpb.Durability_SLAVE_REPLICATED {
+		t.Errorf("Did not expect the write to be replicated, but got %s", res.Durability)
+	}
+
+	// Subsequent writes no longer await the replica once degraded
+	start = time.Now()
+	if res, err = dkvCli.PutWithWALSync([]byte("SemiSyncKey2"), []byte("SemiSyncVal2"), serverpb.WALSync_DEFAULT_WAL_SYNC); err != nil {
+		t.Fatalf("Unable to PUT. Error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed >= timeout {
+		t.Errorf("Expected the write to not await the degraded semi-sync replica, but took %v", elapsed)
+	}
+
+	// Replica catches up and confirms the receipt of the next write
+	if _, err = dkvCli.ReplicateChanges(replicaID, res.ChangeNumber+1, 1); err != nil {
+		t.Fatalf("Unable to replicate changes. Error: %v", err)
+	}
+	confirmed := make(chan error, 1)
+	go func() {
+		chngsRes, err := dkvCli.AwaitChanges(replicaID, res.ChangeNumber+1, 100, 5*time.Second)
+		if err == nil && chngsRes.NumberOfChanges == 0 {
+			err = errors.New("expected the changes committed while waiting")
+		}
+		if err == nil {
+			lastChng := chngsRes.Changes[chngsRes.NumberOfChanges-1]
+			_, err = dkvCli.ReplicateChanges(replicaID, lastChng.ChangeNumber+uint64(lastChng.NumberOfTrxns), 1)
+		}
+		confirmed <- err
+	}()
+	sleepInSecs(1)
+	if res, err = dkvCli.PutWithWALSync([]byte("SemiSyncKey3"), []byte("SemiSyncVal3"), serverpb.WALSync_DEFAULT_WAL_SYNC); err != nil {
+		t.Fatalf("Unable to PUT. Error: %v", err)
+	}
+	if err = <-confirmed; err != nil {
+		t.Fatalf("Unable to confirm the receipt of changes. Error: %v", err)
+	}
+	if res.Durability != serverpb.Durability_SLAVE_REPLICATED || res.NumReplicas != 2 {
+		t.Errorf("Expected the write to be replicated, but got %s with %d replicas", res.Durability, res.NumReplicas)
+	}
+}
+
+func testReplicationLag(t *testing.T) {
+	res, err := dkvCli.PutWithWALSync([]byte("LagKey1"), []byte("LagVal1"), serverpb.WALSync_DEFAULT_WAL_SYNC)
+	if err != nil {
+		t.Fatalf("Unable to PUT. Error: %v", err)
+	}
+	appliedAt := time.Now().Add(-time.Second)
+	if err = dkvCli.ReportReplicationLag("LaggingSlave", res.ChangeNumber-1, appliedAt); err != nil {
+		t.Fatalf("Unable to report replication lag. Error: %v", err)
+	}
+	if err = dkvCli.ReportReplicationLag("CaughtUpSlave", res.ChangeNumber, time.Now()); err != nil {
+		t.Fatalf("Unable to report replication lag. Error: %v", err)
+	}
+	if err = dkvCli.ReportReplicationLag("", res.ChangeNumber, time.Now()); err == nil {
+		t.Error("Expected an error when reporting replication lag without a replica ID")
+	}
+
+	lagRes, err := dkvCli.GetReplicationLag()
+	if err != nil {
+		t.Fatalf("Unable to get replication lag. Error: %v", err)
+	}
+	if lagRes.MasterChangeNumber < res.ChangeNumber {
+		t.Errorf("Expected master change number of at least %d, but got %d", res.ChangeNumber, lagRes.MasterChangeNumber)
+	}
+	lags := make(map[string]*serverpb.ReplicaLag)
+	for _, lag := range lagRes.Replicas {
+		lags[lag.ReplicaID] = lag
+	}
+	if lag := lags["CaughtUpSlave"]; lag == nil || lag.LagChanges != lagRes.MasterChangeNumber-res.ChangeNumber {
+		t.Errorf("Unexpected lag of caught up slave: %v", lag)
+	}
+	lag := lags["LaggingSlave"]
+	switch {
+	case lag == nil:
+		t.Fatal("Expected the lag of the lagging slave")
+	case lag.AppliedChangeNumber != res.ChangeNumber-1 || lag.LagChanges < 1:
+		t.Errorf("Unexpected lag of lagging slave: %v", lag)
+	case lag.LagMillis < time.Second.Milliseconds():
+		t.Errorf("Expected the lagging slave to be behind for at least a second, but got %dms", lag.LagMillis)
+	}
+}
+
+func testRetentionHeadroom(t *testing.T) {
+	rp := newReplicaProgress()
+	rp.applied("CaughtUpSlave", 1000)
+	rp.applied("LaggingSlave", 105)
+	rp.applied("LostSlave", 50)
+
+	// Changes 101 through 1000 are retained
+	hrs := rp.headrooms(101, 1000)
+	if len(hrs) != 3 {
+		t.Fatalf("Expected the headrooms of 3 slaves, but got %d", len(hrs))
+	}
+	if hr := hrs[0]; hr.replicaID != "CaughtUpSlave" || hr.headroom != 900 || hr.atRisk || hr.lost {
+		t.Errorf("Unexpected headroom of caught up slave: %+v", hr)
+	}
+	if hr := hrs[1]; hr.replicaID != "LaggingSlave" || hr.headroom != 5 || !hr.atRisk || hr.lost {
+		t.Errorf("Unexpected headroom of lagging slave: %+v", hr)
+	}
+	if hr := hrs[2]; hr.replicaID != "LostSlave" || hr.headroom != 0 || !hr.lost {
+		t.Errorf("Unexpected headroom of lost slave: %+v", hr)
+	}
+	if hrs = rp.headrooms(0, 1000); len(hrs) != 0 {
+		t.Errorf("Expected no headrooms without any retained changes, but got %d", len(hrs))
+	}
+}
+
 func testPutTTLAndGet(t *testing.T) {
 	key1, key2, value := "ValidKey", "ExpiredKey", "SomeValue"
 
@@ -242,6 +449,306 @@ func testMultiGet(t *testing.T) {
 			}
 		}
 	}
+
+	positionalKeys := [][]byte{keys[1], []byte("MissingMK"), keys[0], keys[1]}
+	expVals := []string{vals[1], "", vals[0], vals[1]}
+	if results, err := dkvCli.MultiGet(rc, positionalKeys...); err != nil {
+		t.Fatalf("Unable to MultiGet. Error: %v", err)
+	} else if len(results) != len(positionalKeys) {
+		t.Errorf("Expected %d positional results, but got %d", len(positionalKeys), len(results))
+	} else {
+		for i, result := range results {
+			if !bytes.Equal(result.Key, positionalKeys[i]) || string(result.Value) != expVals[i] {
+				t.Errorf("Positional Multi Get mismatch at %d. Expected Key: %s, Value: %s, Actual Key: %s, Value: %s",
+					i, positionalKeys[i], expVals[i], result.Key, result.Value)
+			}
+		}
+	}
+}
+
+func testRequestBatching(t *testing.T) {
+	rb, err := ctl.NewRequestBatcher(ctl.BatchingConfig{Window: 5 * time.Millisecond, MaxBatchSize: 8, BatchPuts: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	batchCli, err := ctl.NewInSecureDKVClient(fmt.Sprintf("%s:%d", dkvSvcHost, dkvSvcPort), "", ctl.WithBatching(rb))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer batchCli.Close()
+
+	numKeys, keyPrefix, valPrefix := 20, "BatchKey", "BatchVal"
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*numKeys)
+	for i := 0; i < numKeys; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- batchCli.Put([]byte(fmt.Sprintf("%s%d", keyPrefix, i)), []byte(fmt.Sprintf("%s%d", valPrefix, i)))
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < numKeys; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			key, expVal := fmt.Sprintf("%s%d", keyPrefix, i), fmt.Sprintf("%s%d", valPrefix, i)
+			res, err := batchCli.Get(serverpb.ReadConsistency_SEQUENTIAL, []byte(key))
+			if err == nil && string(res.Value) != expVal {
+				err = fmt.Errorf("expected value: %s for key: %s, but got: %s", expVal, key, res.Value)
+			}
+			errs <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+	if _, err = ctl.NewRequestBatcher(ctl.BatchingConfig{Window: time.Millisecond, MaxBatchSize: 1}); err == nil {
+		t.Error("Expected an error for a batch size of one")
+	}
+}
+
+func testCompression(t *testing.T) {
+	for _, compressor := range []string{ctl.GzipCompressor, ctl.ZstdCompressor} {
+		compCli, err := ctl.NewInSecureDKVClient(fmt.Sprintf("%s:%d", dkvSvcHost, dkvSvcPort), "", ctl.WithCompression(compressor))
+		if err != nil {
+			t.Fatal(err)
+		}
+		key, val := []byte("CompKey_"+compressor), bytes.Repeat([]byte("CompVal"), 1000)
+		if err = compCli.Put(key, val); err != nil {
+			t.Errorf("Unable to PUT using %s compression. Error: %v", compressor, err)
+		} else if res, err := compCli.Get(serverpb.ReadConsistency_SEQUENTIAL, key); err != nil {
+			t.Errorf("Unable to GET using %s compression. Error: %v", compressor, err)
+		} else if !bytes.Equal(res.Value, val) {
+			t.Errorf("GET mismatch using %s compression. Key: %s", compressor, key)
+		}
+
+		rawKey, rawVal := []byte("RawCompKey_"+compressor), []byte("AlreadyCompressedVal")
+		if err = compCli.PutWithoutCompression(rawKey, rawVal); err != nil {
+			t.Errorf("Unable to PUT without compression. Error: %v", err)
+		} else if res, err := compCli.Get(serverpb.ReadConsistency_SEQUENTIAL, rawKey); err != nil || !bytes.Equal(res.Value, rawVal) {
+			t.Errorf("GET mismatch for the value put without compression. Key: %s, Error: %v", rawKey, err)
+		}
+		compCli.Close()
+	}
+	if _, err := ctl.NewInSecureDKVClient(fmt.Sprintf("%s:%d", dkvSvcHost, dkvSvcPort), "", ctl.WithCompression("lz4")); err == nil {
+		t.Error("Expected an error for an unknown compressor")
+	}
+}
+
+type hooksCtxKey struct{}
+
+func testClientHooks(t *testing.T) {
+	var mu sync.Mutex
+	var requests, responses []string
+	hooks := ctl.ClientHooks{
+		OnRequest: func(ctx context.Context, method string, _ interface{}) context.Context {
+			mu.Lock()
+			defer mu.Unlock()
+			requests = append(requests, method)
+			return context.WithValue(ctx, hooksCtxKey{}, method)
+		},
+		OnResponse: func(ctx context.Context, method string, _, reply interface{}, err error, elapsed time.Duration) {
+			mu.Lock()
+			defer mu.Unlock()
+			// Context returned by OnRequest is passed along
+			if ctx.Value(hooksCtxKey{}) != method || err != nil || reply == nil || elapsed <= 0 {
+				t.Errorf("Unexpected response of method: %s. Reply: %v, Error: %v, Elapsed: %v", method, reply, err, elapsed)
+			}
+			responses = append(responses, method)
+		},
+		OnRetry: func(_ context.Context, method string, _ int, cause error) {
+			t.Errorf("Expected no retries of method: %s. Cause: %v", method, cause)
+		},
+		MaxRetries: 2,
+	}
+	hooksCli, err := ctl.NewInSecureDKVClient(fmt.Sprintf("%s:%d", dkvSvcHost, dkvSvcPort), "", ctl.WithHooks(hooks))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer hooksCli.Close()
+
+	key, val := []byte("HooksKey"), []byte("HooksVal")
+	if err = hooksCli.Put(key, val); err != nil {
+		t.Fatal(err)
+	}
+	if res, err := hooksCli.Get(serverpb.ReadConsistency_SEQUENTIAL, key); err != nil || !bytes.Equal(res.Value, val) {
+		t.Errorf("GET mismatch using hooks. Key: %s, Error: %v", key, err)
+	}
+	expected := []string{"/dkv.serverpb.DKV/Put", "/dkv.serverpb.DKV/Get"}
+	mu.Lock()
+	defer mu.Unlock()
+	if fmt.Sprint(requests) != fmt.Sprint(expected) || fmt.Sprint(responses) != fmt.Sprint(expected) {
+		t.Errorf("Expected hooks of methods: %v. Requests: %v, Responses: %v", expected, requests, responses)
+	}
+
+	if _, err = ctl.NewInSecureDKVClient(fmt.Sprintf("%s:%d", dkvSvcHost, dkvSvcPort), "", ctl.WithHooks(ctl.ClientHooks{MaxRetries: -1})); err == nil {
+		t.Error("Expected an error for negative max retries")
+	}
+}
+
+type mappedUser struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func testKeyBuilder(t *testing.T) {
+	policy, err := keypolicy.Parse("Orders:@1=tenant:string,id:uint64,delta:int64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	serverOpts.KeyPolicies = []*serverpb.KeyPolicy{policy}
+	defer func() { serverOpts.KeyPolicies = nil }()
+
+	if _, err = dkvCli.NewKeyBuilder("Missing:"); err == nil {
+		t.Error("Expected an error for a namespace without a key policy")
+	}
+	kb, err := dkvCli.NewKeyBuilder("Orders:")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Keys sort by the values of their segments
+	orders := [][]interface{}{
+		{"acme", uint64(2), int64(-5)},
+		{"acme", uint64(2), int64(3)},
+		{"acme", uint64(10), int64(-1)},
+		{"acme\x00x", uint64(1), int64(0)},
+		{"acmf", uint64(0), int64(0)},
+	}
+	var prevKey []byte
+	for _, vals := range orders {
+		key, err := kb.Build(vals...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Compare(prevKey, key) >= 0 {
+			t.Errorf("Expected key %q of %v to sort after %q", key, vals, prevKey)
+		}
+		if parsed, err := kb.Parse(key); err != nil || fmt.Sprintf("%#v", parsed) != fmt.Sprintf("%#v", vals) {
+			t.Errorf("Expected key %q to be parsed into %v, but got %v. Error: %v", key, vals, parsed, err)
+		}
+		if err = dkvCli.Put(key, []byte("order")); err != nil {
+			t.Fatal(err)
+		}
+		prevKey = key
+	}
+	prefix, err := kb.Prefix("acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch, err := dkvCli.Iterate(prefix, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	count := 0
+	for range ch {
+		count++
+	}
+	if count != 3 {
+		t.Errorf("Expected 3 keys of tenant acme, but got %d", count)
+	}
+
+	for _, vals := range [][]interface{}{{"acme", 2, int64(1)}, {"acme", uint64(2)}, {1, uint64(2), int64(1)}} {
+		if _, err = kb.Build(vals...); !errors.Is(err, ctl.ErrInvalidKey) {
+			t.Errorf("Expected values %v to be rejected, but got error: %v", vals, err)
+		}
+	}
+	for _, key := range [][]byte{prevKey[:len(prevKey)-1], append(prevKey, 0), []byte("Orders:\x02acme\x00\x01"), []byte("Orders:\x01acme\x00")} {
+		if err = kb.Validate(key); !errors.Is(err, ctl.ErrInvalidKey) {
+			t.Errorf("Expected key %q to be rejected, but got error: %v", key, err)
+		}
+	}
+}
+
+func testVersionedKeyBuilder(t *testing.T) {
+	var policies []*serverpb.KeyPolicy
+	for _, def := range []string{"Users:@1=name:string", "Users:@2=tenant:string,id:uint64", "Carts:@1=id:uint64"} {
+		policy, err := keypolicy.Parse(def)
+		if err != nil {
+			t.Fatal(err)
+		}
+		policies = append(policies, policy)
+	}
+	serverOpts.KeyPolicies = policies
+	defer func() { serverOpts.KeyPolicies = nil }()
+
+	kb, err := dkvCli.NewKeyBuilder("Users:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kb.Policy().Version != 2 {
+		t.Errorf("Expected the keys to be built as per version 2, but got %d", kb.Policy().Version)
+	}
+	newKey, err := kb.Build("acme", uint64(7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldKey := []byte("Users:\x01alice\x00\x01")
+	for key, expVals := range map[string][]interface{}{string(newKey): {"acme", uint64(7)}, string(oldKey): {"alice"}} {
+		if policy, vals, err := kb.ParseVersion([]byte(key)); err != nil || fmt.Sprintf("%#v", vals) != fmt.Sprintf("%#v", expVals) {
+			t.Errorf("Expected key %q to be parsed into %v, but got %v. Error: %v", key, expVals, vals, err)
+		} else if policy.Version != uint32(key[len("Users:")]) {
+			t.Errorf("Expected key %q to be parsed as per version %d, but got %d", key, key[len("Users:")], policy.Version)
+		}
+	}
+	for _, key := range [][]byte{[]byte("Users:\x03alice\x00\x01"), []byte("Users:\x01acme\x00\x01\x00\x00\x00\x00\x00\x00\x00\x07")} {
+		if err = kb.Validate(key); !errors.Is(err, ctl.ErrInvalidKey) {
+			t.Errorf("Expected key %q to be rejected, but got error: %v", key, err)
+		}
+	}
+	if _, err = ctl.NewKeyBuilder(policies[0], policies[0]); err == nil {
+		t.Error("Expected an error for the key policies of the same version")
+	}
+	if _, err = ctl.NewKeyBuilder(policies[0], policies[2]); err == nil {
+		t.Error("Expected an error for the key policies of different namespaces")
+	}
+}
+
+func testObjectMapper(t *testing.T) {
+	om := ctl.NewObjectMapper(dkvCli)
+	userKey := func(obj interface{}) (string, error) { return obj.(*mappedUser).ID, nil }
+	if err := om.Register(&mappedUser{}, "MappedUser:", userKey, ctl.JSONCodec); err != nil {
+		t.Fatal(err)
+	}
+	if err := om.Register(&serverpb.KVPair{}, "MappedUser:Pair:", userKey, ctl.ProtoCodec); err == nil {
+		t.Error("Expected an error for an overlapping key prefix")
+	}
+
+	users := []*mappedUser{{"team1:alice", "Alice"}, {"team1:bob", "Bob"}, {"team2:carol", "Carol"}}
+	for _, user := range users {
+		if err := om.Save(user); err != nil {
+			t.Fatalf("Unable to save user: %s. Error: %v", user.ID, err)
+		}
+	}
+	var loaded mappedUser
+	if err := om.Load("team1:bob", &loaded); err != nil || loaded != *users[1] {
+		t.Errorf("Load mismatch. Expected: %v, Actual: %v, Error: %v", users[1], loaded, err)
+	}
+	if err := om.Load("team3:dave", &loaded); err != ctl.ErrObjectNotFound {
+		t.Errorf("Expected object not found error. Actual: %v", err)
+	}
+
+	var team1 []mappedUser
+	if err := om.Query("team1:", &team1); err != nil {
+		t.Fatal(err)
+	}
+	if len(team1) != 2 || team1[0] != *users[0] || team1[1] != *users[1] {
+		t.Errorf("Query mismatch. Expected: %v, Actual: %v", users[:2], team1)
+	}
+
+	if err := om.Delete("team1:alice", &mappedUser{}); err != nil {
+		t.Fatal(err)
+	}
+	var remaining []*mappedUser
+	if err := om.Query("", &remaining); err != nil || len(remaining) != 2 {
+		t.Errorf("Expected 2 users after the delete. Actual: %v, Error: %v", remaining, err)
+	}
 }
 
 func testIteration(t *testing.T) {
@@ -276,6 +783,108 @@ func testIteration(t *testing.T) {
 	}
 }
 
+func testPaginatedScan(t *testing.T) {
+	numKeys, keyPrefix, valPrefix := 25, "ScanK", "ScanV"
+	putKeys(t, numKeys, keyPrefix, valPrefix)
+
+	kvs, token, err := dkvCli.Scan([]byte(keyPrefix), nil, 10, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// insert after the first page, not expected in the subsequent pages
+	putKeys(t, 5, keyPrefix+"New", valPrefix)
+	count := len(kvs)
+	for token != "" {
+		if kvs, token, err = dkvCli.Scan([]byte(keyPrefix), nil, 10, token); err != nil {
+			t.Fatal(err)
+		}
+		for _, kv := range kvs {
+			if strings.HasPrefix(string(kv.Key), keyPrefix+"New") {
+				t.Errorf("Did not expect the key %s in this scan.", kv.Key)
+			}
+		}
+		count += len(kvs)
+	}
+	if count != numKeys {
+		t.Errorf("Expected %d keys to be scanned. Actual: %d", numKeys, count)
+	}
+	if _, _, err = dkvCli.Scan([]byte(keyPrefix), nil, 10, "expired"); err == nil {
+		t.Error("Expected an error for an unknown continuation token")
+	}
+}
+
+func testAggregate(t *testing.T) {
+	keyPrefix := "AggregateKey"
+	for i := 1; i <= 5; i++ {
+		val := make([]byte, 8)
+		binary.BigEndian.PutUint64(val, uint64(i*10))
+		if err := dkvCli.Put([]byte(fmt.Sprintf("%s_%d", keyPrefix, i)), val); err != nil {
+			t.Fatalf("Unable to PUT. Error: %v", err)
+		}
+	}
+	if err := dkvCli.Put([]byte(keyPrefix+"_6"), []byte("not-a-number")); err != nil {
+		t.Fatalf("Unable to PUT. Error: %v", err)
+	}
+
+	res, err := dkvCli.Aggregate(context.Background(), []byte(keyPrefix), nil, nil)
+	if err != nil {
+		t.Fatalf("Unable to aggregate. Error: %v", err)
+	}
+	if res.Count != 6 || res.TotalValueBytes != 5*8+12 || string(res.MinKey) != keyPrefix+"_1" || string(res.MaxKey) != keyPrefix+"_6" {
+		t.Errorf("Unexpected aggregates: %v", res)
+	}
+	if res.Sum != 150 || res.NumSummed != 5 {
+		t.Errorf("Expected the sum 150 of 5 values. Actual: %d of %d values", res.Sum, res.NumSummed)
+	}
+
+	// Ranges exclude their end keys
+	if res, err = dkvCli.Aggregate(context.Background(), []byte(keyPrefix), []byte(keyPrefix+"_2"), []byte(keyPrefix+"_4")); err != nil {
+		t.Fatalf("Unable to aggregate. Error: %v", err)
+	}
+	if res.Count != 2 || res.Sum != 50 || string(res.MinKey) != keyPrefix+"_2" || string(res.MaxKey) != keyPrefix+"_3" {
+		t.Errorf("Unexpected aggregates of range: %v", res)
+	}
+	if _, err = dkvCli.Aggregate(context.Background(), nil, []byte("b"), []byte("a")); err == nil {
+		t.Error("Expected an error when the end key precedes the start key")
+	}
+}
+
+func testChecksumScan(t *testing.T) {
+	numKeys, keyPrefix, valPrefix := 10, "ChecksumK", "ChecksumV"
+	putKeys(t, numKeys, keyPrefix, valPrefix)
+
+	ch, err := dkvCli.ChecksumScan([]byte(keyPrefix), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var prevKey []byte
+	count := 0
+	for kc := range ch {
+		if kc.ErrMsg != "" {
+			t.Fatalf("Unable to perform checksum scan. Error: %s", kc.ErrMsg)
+		}
+		if prevKey != nil && bytes.Compare(prevKey, kc.Key) >= 0 {
+			t.Errorf("Expected the key %s to be scanned after %s", kc.Key, prevKey)
+		}
+		expVal := valPrefix + string(kc.Key[len(keyPrefix):])
+		if expChecksum := storage.Checksum(&serverpb.KVPair{Value: []byte(expVal)}); kc.Checksum != expChecksum {
+			t.Errorf("Checksum mismatch for key %s. Expected: %d, Actual: %d", kc.Key, expChecksum, kc.Checksum)
+		}
+		prevKey = kc.Key
+		count++
+	}
+	if count != numKeys {
+		t.Errorf("Expected %d keys to be scanned. Actual: %d", numKeys, count)
+	}
+
+	err = ctl.Diff(dkvCli, dkvCli, []byte(keyPrefix), func(key []byte, kind ctl.DiffKind) {
+		t.Errorf("Did not expect the key %s to differ from itself. Kind: %s", key, kind)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
 func testMissingGet(t *testing.T) {
 	key := "MissingKey"
 	if val, _ := dkvCli.Get(rc, []byte(key)); val != nil && string(val.Value) != "" {
@@ -283,33 +892,340 @@ func testMissingGet(t *testing.T) {
 	}
 }
 
-func testDelete(t *testing.T) {
-	key, value := "DeletedKey", "SomeValue"
-
+func testExists(t *testing.T) {
+	key, value := "ExistingKey", "SomeValue"
 	if err := dkvCli.Put([]byte(key), []byte(value)); err != nil {
 		t.Fatalf("Unable to PUT. Key: %s, Value: %s, Error: %v", key, value, err)
 	}
+	if exists, err := dkvCli.Exists(rc, []byte(key)); err != nil {
+		t.Fatalf("Unable to check existence. Key: %s, Error: %v", key, err)
+	} else if !exists {
+		t.Errorf("Expected key %s to exist", key)
+	}
 
 	if err := dkvCli.Delete([]byte(key)); err != nil {
 		t.Fatalf("Unable to DELETE. Key: %s, Error: %v", key, err)
 	}
-
-	if val, _ := dkvCli.Get(rc, []byte(key)); val != nil && string(val.Value) != "" {
-		t.Errorf("Expected no value for key %s. But got %s", key, val)
+	for _, key := range []string{key, "MissingKey"} {
+		if exists, err := dkvCli.Exists(rc, []byte(key)); err != nil {
+			t.Fatalf("Unable to check existence. Key: %s, Error: %v", key, err)
+		} else if exists {
+			t.Errorf("Expected key %s to not exist", key)
+		}
 	}
 }
 
-func testGetChanges(t *testing.T) {
-	numKeys, keyPrefix, valPrefix := 10, "GCK", "GCV"
-	putKeys(t, numKeys, keyPrefix, valPrefix)
+func testTaskScheduler(t *testing.T) {
+	worker1, err := ctl.NewTaskScheduler(dkvCli, "tasks", "worker1", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	worker2, _ := ctl.NewTaskScheduler(dkvCli, "tasks", "worker2", time.Second)
 
-	if chngsRes, err := dkvCli.GetChanges(0, 1000); err != nil {
-		t.Fatalf("Unable to get changes. Error: %v", err)
-	} else {
-		if chngsRes.MasterChangeNumber == 0 {
-			t.Errorf("Expected master change number to be greater than 0")
+	now := time.Now()
+	for i, due := range []time.Time{now.Add(-2 * time.Second), now.Add(-time.Second), now.Add(time.Hour)} {
+		id := fmt.Sprintf("task%d", i+1)
+		if scheduled, err := worker1.Schedule(id, due, []byte(id)); err != nil || !scheduled {
+			t.Fatalf("Unable to schedule task %s. Scheduled: %v, Error: %v", id, scheduled, err)
 		}
-		chngs := chngsRes.Changes
+	}
+	if scheduled, err := worker1.Schedule("task1", now.Add(-2*time.Second), nil); err != nil || scheduled {
+		t.Errorf("Expected scheduling to be idempotent. Scheduled: %v, Error: %v", scheduled, err)
+	}
+
+	tasks, err := worker1.Claim(1)
+	if err != nil || len(tasks) != 1 || tasks[0].ID != "task1" || string(tasks[0].Payload) != "task1" {
+		t.Fatalf("Expected task1 to be claimed first. Tasks: %v, Error: %v", tasks, err)
+	}
+	if tasks, err = worker2.Claim(10); err != nil || len(tasks) != 1 || tasks[0].ID != "task2" {
+		t.Fatalf("Expected only task2 to be claimed by another worker. Tasks: %v, Error: %v", tasks, err)
+	}
+
+	// Lease of task2 lapses without completion
+	time.Sleep(time.Second + 100*time.Millisecond)
+	reclaimed, err := worker1.Claim(10)
+	if err != nil || len(reclaimed) != 2 || reclaimed[1].ID != "task2" || reclaimed[1].Attempts != 2 {
+		t.Fatalf("Expected lapsed tasks to be claimed again. Tasks: %v, Error: %v", reclaimed, err)
+	}
+	if completed, err := worker2.Complete(tasks[0]); err != nil || completed {
+		t.Errorf("Expected completion to fail after losing the claim. Completed: %v, Error: %v", completed, err)
+	}
+	for _, task := range reclaimed {
+		if completed, err := worker1.Complete(task); err != nil || !completed {
+			t.Errorf("Unable to complete task %s. Completed: %v, Error: %v", task.ID, completed, err)
+		}
+	}
+	if tasks, err = worker2.Claim(10); err != nil || len(tasks) != 0 {
+		t.Errorf("Expected no more due tasks. Tasks: %v, Error: %v", tasks, err)
+	}
+}
+
+func testTransaction(t *testing.T) {
+	key := []byte("TxnCounter")
+	txn1, txn2 := dkvCli.NewTxn(), dkvCli.NewTxn()
+	for _, txn := range []*ctl.Txn{txn1, txn2} {
+		if val, err := txn.Get(key); err != nil {
+			t.Fatalf("Unable to GET within transaction. Key: %s, Error: %v", key, err)
+		} else if len(val) != 0 {
+			t.Fatalf("Expected key %s to be absent, but got %s", key, val)
+		}
+	}
+	txn1.Put(key, []byte("1"))
+	if val, _ := txn1.Get(key); string(val) != "1" {
+		t.Errorf("Expected the transaction to read its own writes, but got %s", val)
+	}
+	txn2.Put(key, []byte("2"))
+	if err := txn1.Commit(); err != nil {
+		t.Fatalf("Unable to commit transaction. Error: %v", err)
+	}
+	if err := txn2.Commit(); !ctl.IsTxnConflict(err) {
+		t.Errorf("Expected a conflict for the concurrent transaction, but got: %v", err)
+	}
+	if val, err := dkvCli.Get(rc, key); err != nil || string(val.Value) != "1" {
+		t.Errorf("Expected value 1 for key %s, but got %v, %v", key, val, err)
+	}
+}
+
+func testKeyLocks(t *testing.T) {
+	key, lease := []byte("LockedKey"), 200*time.Millisecond
+	if res, err := dkvCli.Lock(key, "owner1", lease); err != nil {
+		t.Fatalf("Unable to acquire lock. Error: %v", err)
+	} else if !res.Acquired || res.Owner != "owner1" {
+		t.Fatalf("Expected the lock to be acquired by owner1. Actual: %v", res)
+	}
+	if res, err := dkvCli.Lock(key, "owner2", lease); err != nil {
+		t.Fatalf("Unable to attempt lock. Error: %v", err)
+	} else if res.Acquired || res.Owner != "owner1" {
+		t.Errorf("Expected the lock to be held by owner1. Actual: %v", res)
+	}
+	// Owner renews its lease by locking again
+	if res, err := dkvCli.Lock(key, "owner1", lease); err != nil || !res.Acquired {
+		t.Errorf("Expected the lease to be renewed. Actual: %v, Error: %v", res, err)
+	}
+	if released, err := dkvCli.Unlock(key, "owner2"); err != nil || released {
+		t.Errorf("Expected the lock to not be released by another owner. Released: %t, Error: %v", released, err)
+	}
+	if released, err := dkvCli.Unlock(key, "owner1"); err != nil || !released {
+		t.Errorf("Expected the lock to be released by its owner. Released: %t, Error: %v", released, err)
+	}
+	if res, err := dkvCli.Lock(key, "owner2", lease); err != nil || !res.Acquired {
+		t.Errorf("Expected the released lock to be acquired. Actual: %v, Error: %v", res, err)
+	}
+
+	// Expired locks can be acquired by other owners
+	time.Sleep(2 * lease)
+	if res, err := dkvCli.Lock(key, "owner3", lease); err != nil || !res.Acquired {
+		t.Errorf("Expected the expired lock to be acquired. Actual: %v, Error: %v", res, err)
+	}
+	if _, err := dkvCli.Lock(key, "owner3", 2*time.Hour); err == nil {
+		t.Error("Expected an error for a lease longer than the maximum")
+	}
+}
+
+func testSharedKeyLocks(t *testing.T) {
+	key, lease := []byte("SharedLockedKey"), 200*time.Millisecond
+	for _, reader := range []string{"reader1", "reader2"} {
+		if res, err := dkvCli.LockShared(key, reader, lease); err != nil || !res.Acquired || res.Mode != serverpb.LockMode_SHARED {
+			t.Fatalf("Expected the lock to be shared by %s. Actual: %v, Error: %v", reader, res, err)
+		}
+	}
+	// Shared locks are not acquired exclusively, not even by their owners
+	if res, err := dkvCli.Lock(key, "writer", lease); err != nil || res.Acquired || res.Mode != serverpb.LockMode_SHARED {
+		t.Errorf("Expected the lock to be shared by the readers. Actual: %v, Error: %v", res, err)
+	}
+	if res, err := dkvCli.Lock(key, "reader1", lease); err != nil || res.Acquired || res.Owner != "reader2" {
+		t.Errorf("Expected the lock to be shared by reader2. Actual: %v, Error: %v", res, err)
+	}
+	if released, err := dkvCli.Unlock(key, "reader2"); err != nil || !released {
+		t.Errorf("Expected the share of reader2 to be released. Released: %t, Error: %v", released, err)
+	}
+	// Sole owner of a shared lock upgrades it
+	if res, err := dkvCli.Lock(key, "reader1", lease); err != nil || !res.Acquired || res.Mode != serverpb.LockMode_EXCLUSIVE {
+		t.Errorf("Expected the lock to be upgraded by reader1. Actual: %v, Error: %v", res, err)
+	}
+	if res, err := dkvCli.LockShared(key, "reader2", lease); err != nil || res.Acquired || res.Owner != "reader1" {
+		t.Errorf("Expected the lock to be held exclusively by reader1. Actual: %v, Error: %v", res, err)
+	}
+
+	// Expired exclusive locks can be shared
+	time.Sleep(2 * lease)
+	if res, err := dkvCli.LockShared(key, "reader2", lease); err != nil || !res.Acquired {
+		t.Errorf("Expected the expired lock to be shared. Actual: %v, Error: %v", res, err)
+	}
+}
+
+func testDelete(t *testing.T) {
+	key, value := "DeletedKey", "SomeValue"
+
+	if err := dkvCli.Put([]byte(key), []byte(value)); err != nil {
+		t.Fatalf("Unable to PUT. Key: %s, Value: %s, Error: %v", key, value, err)
+	}
+
+	if err := dkvCli.Delete([]byte(key)); err != nil {
+		t.Fatalf("Unable to DELETE. Key: %s, Error: %v", key, err)
+	}
+
+	if val, _ := dkvCli.Get(rc, []byte(key)); val != nil && string(val.Value) != "" {
+		t.Errorf("Expected no value for key %s. But got %s", key, val)
+	}
+}
+
+// syncUntilCaughtUp syncs a sink until no more changes are processed.
+func syncUntilCaughtUp(t *testing.T, sync func(context.Context) (int, error)) {
+	for {
+		numProcessed, err := sync(context.Background())
+		if err != nil {
+			t.Fatalf("Unable to sync. Error: %v", err)
+		}
+		if numProcessed == 0 {
+			return
+		}
+	}
+}
+
+// memNotificationPublisher fails the given number of deliveries before
+// accepting the notifications.
+type memNotificationPublisher struct {
+	numFailures int
+	notified    map[string]string
+}
+
+func (mnp *memNotificationPublisher) Publish(notif *ctl.ChangeNotification) error {
+	if mnp.numFailures > 0 {
+		mnp.numFailures--
+		return errors.New("consumer unavailable")
+	}
+	mnp.notified[string(notif.Key)] = string(notif.Value)
+	return nil
+}
+
+func testChangeNotifier(t *testing.T) {
+	numKeys, keyPrefix, valPrefix := 3, "NotifierKey", "NotifierVal"
+	putKeys(t, numKeys, keyPrefix, valPrefix)
+
+	// First delivery fails and is retried
+	publisher := &memNotificationPublisher{numFailures: 1, notified: make(map[string]string)}
+	deadLetterFile := fmt.Sprintf("%s/%s", dbFolder, "notifier.deadletters")
+	notifier, err := ctl.NewNotifier(dkvCli, publisher, ctl.NotifierOptions{
+		KeyPrefixes:    [][]byte{[]byte(keyPrefix)},
+		WatermarkFile:  fmt.Sprintf("%s/%s", dbFolder, "notifier.watermark"),
+		DeadLetterFile: deadLetterFile,
+		MaxRetries:     1,
+		RetryBackoff:   time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Unable to create notifier. Error: %v", err)
+	}
+	syncUntilCaughtUp(t, notifier.Sync)
+	if len(publisher.notified) != numKeys {
+		t.Errorf("Expected only the %d keys matching the prefix to be notified, but got %v", numKeys, publisher.notified)
+	}
+	for i := 1; i <= numKeys; i++ {
+		key, expVal := fmt.Sprintf("%s_%d", keyPrefix, i), fmt.Sprintf("%s_%d", valPrefix, i)
+		if val := publisher.notified[key]; val != expVal {
+			t.Errorf("Expected the value %s to be notified for key %s, but got %s", expVal, key, val)
+		}
+	}
+
+	// Notifications failing all their retries are dead lettered
+	publisher.numFailures = 2
+	putKeys(t, 1, keyPrefix+"DeadLetter", valPrefix)
+	syncUntilCaughtUp(t, notifier.Sync)
+	if data, err := ioutil.ReadFile(deadLetterFile); err != nil || !strings.Contains(string(data), "consumer unavailable") {
+		t.Errorf("Expected the failed notification to be dead lettered, but got %s. Error: %v", data, err)
+	}
+
+	// Webhooks receive the change number for deduplication, and fail
+	// the deliveries upon non 2xx responses
+	var chngNum string
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if chngNum = r.Header.Get("X-DKV-Change-Number"); chngNum != "7" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer webhook.Close()
+	webhookPublisher := ctl.NewWebhookPublisher(webhook.URL, time.Second)
+	if err = webhookPublisher.Publish(&ctl.ChangeNotification{ChangeNumber: 7, Key: []byte(keyPrefix)}); err != nil || chngNum != "7" {
+		t.Errorf("Expected the notification of change number 7 to be delivered, but got %s. Error: %v", chngNum, err)
+	}
+	if err = webhookPublisher.Publish(&ctl.ChangeNotification{ChangeNumber: 8, Key: []byte(keyPrefix)}); err == nil {
+		t.Error("Expected an error for the webhook failing the delivery")
+	}
+}
+
+type memKafkaProducer struct {
+	msgs []*ctl.KafkaMessage
+	err  error
+}
+
+func (mkp *memKafkaProducer) SendMessages(msgs []*ctl.KafkaMessage) error {
+	if mkp.err != nil {
+		return mkp.err
+	}
+	mkp.msgs = append(mkp.msgs, msgs...)
+	return nil
+}
+
+func testKafkaSink(t *testing.T) {
+	numKeys, keyPrefix, valPrefix := 5, "KafkaSinkKey", "KafkaSinkVal"
+	putKeys(t, numKeys, keyPrefix, valPrefix)
+
+	producer := &memKafkaProducer{}
+	opts := ctl.KafkaSinkOptions{WatermarkFile: fmt.Sprintf("%s/%s", dbFolder, "kafka-sink.watermark"), BatchSize: 100}
+	sink, err := ctl.NewKafkaSink(dkvCli, producer, opts)
+	if err != nil {
+		t.Fatalf("Unable to create Kafka sink. Error: %v", err)
+	}
+	syncUntilCaughtUp(t, sink.Sync)
+	chngsRes, err := dkvCli.GetChanges(0, 1)
+	if err != nil {
+		t.Fatalf("Unable to get changes. Error: %v", err)
+	}
+	if watermark, err := sink.Watermark(); err != nil || watermark != chngsRes.MasterChangeNumber {
+		t.Errorf("Expected the watermark %d, but got %d. Error: %v", chngsRes.MasterChangeNumber, watermark, err)
+	}
+
+	published := make(map[string]string)
+	for _, msg := range producer.msgs {
+		rec := &serverpb.ChangeRecord{}
+		if err = proto.Unmarshal(msg.Value, rec); err != nil {
+			t.Fatalf("Unable to decode the published change. Error: %v", err)
+		}
+		if len(rec.Trxns) != 1 || !bytes.Equal(rec.Trxns[0].Key, msg.Key) || storage.IsReservedKey(msg.Key) {
+			t.Fatalf("Unexpected change published for key %q: %v", msg.Key, rec)
+		}
+		published[string(msg.Key)] = string(rec.Trxns[0].Value)
+	}
+	for i := 1; i <= numKeys; i++ {
+		key, expVal := fmt.Sprintf("%s_%d", keyPrefix, i), fmt.Sprintf("%s_%d", valPrefix, i)
+		if val := published[key]; val != expVal {
+			t.Errorf("Expected the value %s to be published for key %s, but got %s", expVal, key, val)
+		}
+	}
+
+	// Watermark is not advanced unless the changes are published
+	watermark, _ := sink.Watermark()
+	putKeys(t, 1, "KafkaSinkFailedKey", valPrefix)
+	producer.err = errors.New("broker unavailable")
+	if _, err = sink.Sync(context.Background()); err == nil {
+		t.Error("Expected an error when the changes cannot be published")
+	}
+	if failedWatermark, _ := sink.Watermark(); failedWatermark != watermark {
+		t.Errorf("Expected the watermark to remain at %d, but got %d", watermark, failedWatermark)
+	}
+}
+
+func testGetChanges(t *testing.T) {
+	numKeys, keyPrefix, valPrefix := 10, "GCK", "GCV"
+	putKeys(t, numKeys, keyPrefix, valPrefix)
+
+	if chngsRes, err := dkvCli.GetChanges(0, 1000); err != nil {
+		t.Fatalf("Unable to get changes. Error: %v", err)
+	} else {
+		if chngsRes.MasterChangeNumber == 0 {
+			t.Errorf("Expected master change number to be greater than 0")
+		}
+		chngs := chngsRes.Changes
 		numChngs := len(chngs)
 		if numChngs < numKeys {
 			t.Errorf("Expected at least %d changes. But got only %d changes.", numKeys, numChngs)
@@ -336,6 +1252,211 @@ func testGetChanges(t *testing.T) {
 	}
 }
 
+func testEncodedChanges(t *testing.T) {
+	value := bytes.Repeat([]byte("EncodedChangesValue"), 100)
+	res, err := dkvCli.PutWithWALSync([]byte("EncodedChangesKey_0"), value, serverpb.WALSync_DEFAULT_WAL_SYNC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i < 5; i++ {
+		if err = dkvCli.Put([]byte(fmt.Sprintf("EncodedChangesKey_%d", i)), value); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	getChngsReq := &serverpb.GetChangesRequest{FromChangeNumber: res.ChangeNumber, MaxNumberOfChanges: 5}
+	plain, err := dkvSvc.GetChanges(context.Background(), getChngsReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	getChngsReq.RecordCompression, getChngsReq.DeltaEncodeKeys = ctl.ZstdCompressor, true
+	encoded, err := dkvSvc.GetChanges(context.Background(), getChngsReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(encoded.Changes) != len(plain.Changes) {
+		t.Fatalf("Expected %d changes, but got %d", len(plain.Changes), len(encoded.Changes))
+	}
+	for i, chng := range encoded.Changes {
+		if chng.Compression != ctl.ZstdCompressor || len(chng.CompressedForm) >= len(plain.Changes[i].SerialisedForm) {
+			t.Errorf("Expected change number %d to be compressed. Compression: %q, Size: %d", chng.ChangeNumber, chng.Compression, len(chng.CompressedForm))
+		}
+	}
+	if err = ctl.DecodeChangeRecords(encoded.Changes); err != nil {
+		t.Fatal(err)
+	}
+	for i, chng := range encoded.Changes {
+		if !proto.Equal(chng, plain.Changes[i]) {
+			t.Errorf("Expected the decoded change to match the plain one. Expected: %v, Actual: %v", plain.Changes[i], chng)
+		}
+	}
+
+	// Keys sharing a prefix with the preceding ones are delta encoded
+	chngs := []*serverpb.ChangeRecord{{ChangeNumber: 1, Trxns: []*serverpb.TrxnRecord{
+		{Key: []byte("prefix/a")}, {Key: []byte("prefix/b")}, {Key: []byte("other")}}}}
+	if err = ctl.EncodeChangeRecords(chngs, "", true); err != nil {
+		t.Fatal(err)
+	}
+	if trxn := chngs[0].Trxns[1]; trxn.SharedKeyPrefix != 7 || string(trxn.Key) != "b" {
+		t.Errorf("Expected the key to be delta encoded. Shared prefix: %d, Key: %q", trxn.SharedKeyPrefix, trxn.Key)
+	}
+	if err = ctl.DecodeChangeRecords(chngs); err != nil {
+		t.Fatal(err)
+	}
+	for i, expKey := range []string{"prefix/a", "prefix/b", "other"} {
+		if trxn := chngs[0].Trxns[i]; string(trxn.Key) != expKey || trxn.SharedKeyPrefix != 0 {
+			t.Errorf("Expected the key %q to be decoded. Actual: %q", expKey, trxn.Key)
+		}
+	}
+
+	// Clients decode the records retrieved by them
+	encCli, err := ctl.NewInSecureDKVClient(fmt.Sprintf("%s:%d", dkvSvcHost, dkvSvcPort), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer encCli.Close()
+	if err = encCli.SetChangeRecordEncoding(ctl.GzipCompressor, true); err != nil {
+		t.Fatal(err)
+	}
+	if chngsRes, err := encCli.GetChanges(res.ChangeNumber, 5); err != nil {
+		t.Fatal(err)
+	} else if len(chngsRes.Changes) != len(plain.Changes) || !proto.Equal(chngsRes.Changes[0], plain.Changes[0]) {
+		t.Errorf("Expected the changes to be decoded by the client. Actual: %v", chngsRes.Changes)
+	}
+}
+
+func testStreamChanges(t *testing.T) {
+	res, err := dkvCli.PutWithWALSync([]byte("StreamKey1"), []byte("StreamValue1"), serverpb.WALSync_DEFAULT_WAL_SYNC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	stream, err := dkvCli.StreamChanges(ctx, res.ChangeNumber, 10)
+	if err != nil {
+		t.Fatalf("Unable to stream changes. Error: %v", err)
+	}
+	expectChange := func(key string) {
+		chngsRes, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Unable to receive changes. Error: %v", err)
+		}
+		trxns := chngsRes.Changes[chngsRes.NumberOfChanges-1].Trxns
+		if lastKey := string(trxns[len(trxns)-1].Key); lastKey != key {
+			t.Errorf("Expected the change of key: %s to be streamed, but got %s", key, lastKey)
+		}
+	}
+	expectChange("StreamKey1")
+
+	// Changes committed after subscribing are pushed to the stream
+	if err = dkvCli.Put([]byte("StreamKey2"), []byte("StreamValue2")); err != nil {
+		t.Fatal(err)
+	}
+	expectChange("StreamKey2")
+}
+
+func testStreamChangesWithBatching(t *testing.T) {
+	latestChngs, err := dkvCli.GetChanges(0, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	stream, err := dkvCli.StreamChangesWithOptions(ctx, latestChngs.MasterChangeNumber+1, ctl.ChangeStreamOptions{
+		MaxNumChanges: 100, MaxBatchDelay: 500 * time.Millisecond, Heartbeat: 100 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("Unable to stream changes. Error: %v", err)
+	}
+	// Changes committed within the batch delay are received together
+	numKeys, keyPrefix, valPrefix := 5, "BatchStreamKey", "BatchStreamVal"
+	putKeys(t, numKeys, keyPrefix, valPrefix)
+	numChngs := 0
+	for numChngs < numKeys {
+		chngsRes, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Unable to receive changes. Error: %v", err)
+		}
+		if chngsRes.NumberOfChanges == 0 {
+			// Heartbeats are received only before the changes are committed
+			if numChngs > 0 {
+				t.Errorf("Expected the changes to be batched, but received %d of %d changes", numChngs, numKeys)
+			}
+			continue
+		}
+		numChngs += int(chngsRes.NumberOfChanges)
+	}
+	if numChngs != numKeys {
+		t.Errorf("Expected %d changes to be streamed, but got %d", numKeys, numChngs)
+	}
+}
+
+func testPromote(t *testing.T) {
+	chngsRes, err := dkvCli.GetChanges(0, 1)
+	if err != nil {
+		t.Fatalf("Unable to get changes. Error: %v", err)
+	}
+	epoch, err := dkvCli.Promote()
+	if err != nil {
+		t.Fatalf("Unable to promote. Error: %v", err)
+	}
+	if epoch != chngsRes.FencingEpoch+1 {
+		t.Errorf("Expected fencing epoch %d to be issued, but got %d", chngsRes.FencingEpoch+1, epoch)
+	}
+
+	putKeys(t, 1, "PromoteKey", "PromoteVal")
+	if chngsRes, err = dkvCli.GetChanges(0, 1000); err != nil {
+		t.Fatalf("Unable to get changes. Error: %v", err)
+	}
+	if chngsRes.FencingEpoch != epoch {
+		t.Errorf("Expected fencing epoch %d of the master, but got %d", epoch, chngsRes.FencingEpoch)
+	}
+	for _, chng := range chngsRes.Changes {
+		if chng.FencingEpoch != epoch {
+			t.Errorf("Expected change number %d to carry fencing epoch %d, but got %d", chng.ChangeNumber, epoch, chng.FencingEpoch)
+		}
+	}
+}
+
+func testPauseWrites(t *testing.T) {
+	putKeys(t, 1, "PauseKey", "PauseVal")
+	chngsRes, err := dkvCli.GetChanges(0, 1)
+	if err != nil {
+		t.Fatalf("Unable to get changes. Error: %v", err)
+	}
+	if _, err = dkvCli.PauseWrites(0); err == nil {
+		t.Error("Expected the writes to not be paused without a timeout")
+	}
+	chngNum, err := dkvCli.PauseWrites(time.Minute)
+	if err != nil {
+		t.Fatalf("Unable to pause the writes. Error: %v", err)
+	}
+	if chngNum != chngsRes.MasterChangeNumber {
+		t.Errorf("Expected the writes to be paused at change number %d, but got %d", chngsRes.MasterChangeNumber, chngNum)
+	}
+
+	// Writes are rejected as retriable while paused, unlike the reads
+	if err = dkvCli.Put([]byte("PausedKey_1"), []byte("PausedVal_1")); status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected the write to be rejected as unavailable while paused, but got error: %v", err)
+	}
+	if err = dkvCli.Delete([]byte("PauseKey_1")); status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected the delete to be rejected as unavailable while paused, but got error: %v", err)
+	}
+	getKeys(t, 1, "PauseKey", "PauseVal")
+	if err = dkvCli.ResumeWrites(); err != nil {
+		t.Fatalf("Unable to resume the writes. Error: %v", err)
+	}
+	putKeys(t, 1, "PausedKey", "PausedVal")
+
+	// Writes are resumed once the pause times out
+	if _, err = dkvCli.PauseWrites(time.Second); err != nil {
+		t.Fatalf("Unable to pause the writes. Error: %v", err)
+	}
+	sleepInSecs(2)
+	if err = dkvCli.Put([]byte("PausedKey_2"), []byte("PausedVal_2")); err != nil {
+		t.Errorf("Expected the writes to be resumed once the pause timed out, but got error: %v", err)
+	}
+}
+
 func testBackupRestore(t *testing.T) {
 	numKeys, keyPrefix, valPrefix := 5, "brKey", "brVal"
 	putKeys(t, numKeys, keyPrefix, valPrefix)
@@ -355,6 +1476,50 @@ func testBackupRestore(t *testing.T) {
 	}
 }
 
+func testActiveActiveRestore(t *testing.T) {
+	peerOpts := *serverOpts
+	peerOpts.ActiveActivePeer = fmt.Sprintf("%s:%d", dkvSvcHost, dkvSvcPort)
+	kvs, cp, ba := newKVStore(dbFolder + "_active_peer")
+	peerSvc := NewStandaloneService(kvs, cp, ba, &serverpb.RegionInfo{}, &peerOpts)
+	defer peerSvc.Close()
+	peerSrvr := grpc.NewServer()
+	serverpb.RegisterDKVServer(peerSrvr, peerSvc)
+	serverpb.RegisterDKVBackupRestoreServer(peerSrvr, peerSvc)
+	defer peerSrvr.Stop()
+	go listenAndServe(peerSrvr, dkvSvcPort+2)
+	sleepInSecs(1)
+	peer, err := ctl.NewInSecureDKVClient(fmt.Sprintf("%s:%d", dkvSvcHost, dkvSvcPort+2), "")
+	if err != nil {
+		t.Fatalf("Unable to connect to the peer DKV service. Error: %v", err)
+	}
+	defer peer.Close()
+
+	awaitPeer := func(key, expVal string) {
+		t.Helper()
+		for i := 0; i < 100; i++ {
+			if res, err := peer.Get(rc, []byte(key)); err == nil && string(res.Value) == expVal {
+				return
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+		t.Fatalf("Expected key %s to be replicated onto the peer with value %s", key, expVal)
+	}
+
+	putKeys(t, 1, "ActivePeerKey", "ActivePeerVal")
+	awaitPeer("ActivePeerKey_1", "ActivePeerVal_1")
+	backupPath := fmt.Sprintf("%s_active_peer_backup", dbFolder)
+	if err = peer.Backup(backupPath); err != nil {
+		t.Fatalf("Unable to backup the peer. Error: %v", err)
+	}
+	if err = peer.Restore(backupPath); err != nil {
+		t.Fatalf("Unable to restore the peer. Error: %v", err)
+	}
+
+	// Changes keep being replicated onto the restored store of the peer
+	putKeys(t, 1, "ActivePeerRestoredKey", "ActivePeerRestoredVal")
+	awaitPeer("ActivePeerRestoredKey_1", "ActivePeerRestoredVal_1")
+}
+
 func newKVStore(dir string) (storage.KVStore, storage.ChangePropagator, storage.Backupable) {
 	if err := exec.Command("rm", "-rf", dir).Run(); err != nil {
 		panic(err)
@@ -362,7 +1527,7 @@ func newKVStore(dir string) (storage.KVStore, storage.ChangePropagator, storage.
 	switch engine {
 	case "rocksdb":
 		rocksDb, err := rocksdb.OpenDB(dir,
-			rocksdb.WithSyncWrites(), rocksdb.WithCacheSize(cacheSize))
+			rocksdb.WithSyncWrites(), rocksdb.WithCacheSize(cacheSize), rocksdb.WithMergeOperatorName("CloneKeyCtr", "counter"))
 		if err != nil {
 			panic(err)
 		}
@@ -397,6 +1562,203 @@ func listenAndServe(grpcSrvr *grpc.Server, port int) {
 	}
 }
 
+func testClone(t *testing.T) {
+	numKeys, keyPrefix, valPrefix := 5, "CloneKey", "CloneVal"
+	putKeys(t, numKeys, keyPrefix, valPrefix)
+	putKeys(t, numKeys, "UnclonedKey", valPrefix)
+
+	kvs, cp, ba := newKVStore(dbFolder + "_clone")
+	targetSvc := NewStandaloneService(kvs, cp, ba, &serverpb.RegionInfo{}, serverOpts)
+	defer targetSvc.Close()
+	targetSrvr := grpc.NewServer()
+	serverpb.RegisterDKVServer(targetSrvr, targetSvc)
+	defer targetSrvr.Stop()
+	go listenAndServe(targetSrvr, dkvSvcPort+1)
+	sleepInSecs(1)
+	target, err := ctl.NewInSecureDKVClient(fmt.Sprintf("%s:%d", dkvSvcHost, dkvSvcPort+1), "")
+	if err != nil {
+		t.Fatalf("Unable to connect to the target DKV service. Error: %v", err)
+	}
+	defer target.Close()
+
+	expectTarget := func(key, expVal string) {
+		t.Helper()
+		if res, err := target.Get(rc, []byte(key)); err != nil {
+			t.Fatalf("Unable to GET %s from the target. Error: %v", key, err)
+		} else if string(res.Value) != expVal {
+			t.Errorf("Expected key %s to have value %q on the target, but got %q", key, expVal, res.Value)
+		}
+	}
+	cloner := ctl.NewCloner(dkvCli, target, ctl.CloneOptions{KeyPrefix: []byte(keyPrefix), PageSize: 2})
+	copied, chngNum, err := cloner.Copy(context.Background())
+	if err != nil {
+		t.Fatalf("Unable to copy the keys. Error: %v", err)
+	}
+	if copied != numKeys {
+		t.Errorf("Expected %d keys to be copied, but got %d", numKeys, copied)
+	}
+	for i := 1; i <= numKeys; i++ {
+		expectTarget(fmt.Sprintf("%s_%d", keyPrefix, i), fmt.Sprintf("%s_%d", valPrefix, i))
+		expectTarget(fmt.Sprintf("UnclonedKey_%d", i), "")
+	}
+
+	// Changes following the copy are applied until caught up
+	updKey, delKey := keyPrefix+"_1", keyPrefix+"_2"
+	if err = dkvCli.Put([]byte(updKey), []byte("Updated")); err != nil {
+		t.Fatal(err)
+	}
+	if err = dkvCli.Delete([]byte(delKey)); err != nil {
+		t.Fatal(err)
+	}
+	if err = dkvCli.Put([]byte("UnclonedKey_1"), []byte("Updated")); err != nil {
+		t.Fatal(err)
+	}
+	for {
+		applied, latest, err := cloner.Sync(chngNum)
+		if err != nil {
+			t.Fatalf("Unable to sync the clone. Error: %v", err)
+		}
+		if chngNum = applied; applied == latest {
+			break
+		}
+	}
+	expectTarget(updKey, "Updated")
+	expectTarget(delKey, "")
+	expectTarget("UnclonedKey_1", "")
+
+	// Following catches up with the changes committed before the cutover,
+	// copying the merged values and the expiry of the keys
+	ctx, cancel := context.WithCancel(context.Background())
+	followed := make(chan error, 1)
+	go func() {
+		_, err := cloner.Follow(ctx, chngNum, 100*time.Millisecond, nil)
+		followed <- err
+	}()
+	ttlKey, ctrKey := keyPrefix+"_3", keyPrefix+"Ctr_1"
+	expireTS := uint64(time.Now().Add(time.Hour).Unix())
+	if err = dkvCli.PutTTL([]byte(ttlKey), []byte("UpdatedTTL"), expireTS); err != nil {
+		t.Fatal(err)
+	}
+	if engine == "rocksdb" {
+		for _, operand := range []string{"5", "3"} {
+			if err = dkvCli.Merge([]byte(ctrKey), []byte(operand)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err = dkvCli.Delete([]byte(keyPrefix + "_4")); err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+	if err = <-followed; err != nil {
+		t.Fatalf("Unable to follow the source. Error: %v", err)
+	}
+	expectTarget(ttlKey, "UpdatedTTL")
+	if res, err := target.Get(rc, []byte(ttlKey)); err != nil {
+		t.Fatal(err)
+	} else if res.ExpireTS != expireTS {
+		t.Errorf("Expected key %s to expire at %d on the target, but got %d", ttlKey, expireTS, res.ExpireTS)
+	}
+	if engine == "rocksdb" {
+		expectTarget(ctrKey, "8")
+	}
+	if res, err := target.Get(rc, []byte(keyPrefix+"_4")); err != nil {
+		t.Fatal(err)
+	} else if !res.NotFound {
+		t.Errorf("Expected key %s_4 to be absent on the target, but got %q", keyPrefix, res.Value)
+	}
+}
+
+func testPrefixRename(t *testing.T) {
+	oldPrefix, newPrefix, numKeys := "RenameOldKey", "RenameNewKey", 10
+	putKeys(t, numKeys, oldPrefix, "RenameVal")
+
+	// Reads fall back to the old prefix, even before the keys are copied
+	renames := dkvSvc.(*standaloneService).renamer.renames
+	if err := renames.Begin([]byte(oldPrefix), []byte(newPrefix)); err != nil {
+		t.Fatal(err)
+	}
+	getKeys(t, numKeys, newPrefix, "RenameVal")
+	if err := renames.End([]byte(oldPrefix)); err != nil {
+		t.Fatal(err)
+	}
+	noKeys(t, numKeys, newPrefix)
+
+	// Keys written under the new prefix are not overwritten by the copy,
+	// while keys are copied along with their expiry
+	if err := dkvCli.Put([]byte(newPrefix+"_1"), []byte("RenameNewVal_1")); err != nil {
+		t.Fatalf("Unable to PUT. Error: %v", err)
+	}
+	ttlKey, expireTS := "_TTL", uint64(time.Now().Add(time.Hour).Unix())
+	if err := dkvCli.PutTTL([]byte(oldPrefix+ttlKey), []byte("RenameTTLVal"), expireTS); err != nil {
+		t.Fatalf("Unable to PUT. Error: %v", err)
+	}
+	if err := dkvCli.BeginPrefixRename([]byte(oldPrefix), []byte(newPrefix)); err != nil {
+		t.Fatalf("Unable to begin prefix rename. Error: %v", err)
+	}
+	awaitPrefixRename(t, oldPrefix, func(rename *serverpb.PrefixRename) bool {
+		return rename != nil && rename.Phase == serverpb.PrefixRenamePhase_COPIED
+	})
+
+	// Writes of the old prefix made after the copy are not lost
+	if err := dkvCli.Put([]byte(oldPrefix+"_2"), []byte("RenameMirroredVal")); err != nil {
+		t.Fatalf("Unable to PUT. Error: %v", err)
+	}
+	if _, err := dkvCli.CompareAndSet([]byte(oldPrefix+"_3"), []byte("RenameVal_3"), []byte("RenameCASVal")); err == nil {
+		t.Error("Expected an error for a conditional write of a key of the prefix being renamed")
+	}
+	if err := dkvCli.CompletePrefixRename([]byte(oldPrefix)); err != nil {
+		t.Fatalf("Unable to complete prefix rename. Error: %v", err)
+	}
+	awaitPrefixRename(t, oldPrefix, func(rename *serverpb.PrefixRename) bool { return rename == nil })
+
+	noKeys(t, numKeys, oldPrefix)
+	getKeys(t, 1, newPrefix, "RenameNewVal")
+	if res, err := dkvCli.Get(rc, []byte(newPrefix+"_2")); err != nil {
+		t.Fatalf("Unable to GET. Error: %v", err)
+	} else if string(res.Value) != "RenameMirroredVal" {
+		t.Errorf("Expected the write of the old prefix to be mirrored, but got %q", res.Value)
+	}
+	if res, err := dkvCli.Get(rc, []byte(newPrefix+ttlKey)); err != nil {
+		t.Fatalf("Unable to GET. Error: %v", err)
+	} else if string(res.Value) != "RenameTTLVal" || res.ExpireTS != expireTS {
+		t.Errorf("Expected the key to be copied along with its expiry, but got %q expiring at %d", res.Value, res.ExpireTS)
+	}
+	for i := 3; i <= numKeys; i++ {
+		key, expectedValue := fmt.Sprintf("%s_%d", newPrefix, i), fmt.Sprintf("RenameVal_%d", i)
+		if res, err := dkvCli.Get(rc, []byte(key)); err != nil {
+			t.Fatalf("Unable to GET. Key: %s, Error: %v", key, err)
+		} else if string(res.Value) != expectedValue {
+			t.Errorf("GET mismatch. Key: %s, Expected Value: %s, Actual Value: %s", key, expectedValue, res.Value)
+		}
+	}
+	if err := dkvCli.CompletePrefixRename([]byte(oldPrefix)); err == nil {
+		t.Error("Expected an error for completing a prefix rename that is not in progress")
+	}
+}
+
+// awaitPrefixRename waits for the rename of the given old prefix, nil once
+// it is completed, to satisfy the given condition.
+func awaitPrefixRename(t *testing.T, oldPrefix string, cond func(*serverpb.PrefixRename) bool) {
+	for i := 0; i < 100; i++ {
+		renames, err := dkvCli.ListPrefixRenames()
+		if err != nil {
+			t.Fatalf("Unable to list prefix renames. Error: %v", err)
+		}
+		var rename *serverpb.PrefixRename
+		for _, r := range renames {
+			if string(r.OldPrefix) == oldPrefix {
+				rename = r
+			}
+		}
+		if cond(rename) {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Fatalf("Prefix rename of %s did not progress as expected", oldPrefix)
+}
+
 func putKeys(t *testing.T, numKeys int, keyPrefix, valPrefix string) {
 	for i := 1; i <= numKeys; i++ {
 		key, value := fmt.Sprintf("%s_%d", keyPrefix, i), fmt.Sprintf("%s_%d", valPrefix, i)
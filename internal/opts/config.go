@@ -1,8 +1,10 @@
 package opts
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
+	"math"
 	"os"
 	"path"
 	"path/filepath"
@@ -10,6 +12,12 @@ import (
 	"strings"
 	"time"
 
+	"github.com/flipkart-incubator/dkv/internal/compression"
+	"github.com/flipkart-incubator/dkv/internal/heatmap"
+	"github.com/flipkart-incubator/dkv/internal/keypolicy"
+	"github.com/flipkart-incubator/dkv/internal/streamlimit"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"github.com/flipkart-incubator/dkv/version"
 	flag "github.com/spf13/pflag"
 
 	"github.com/spf13/viper"
@@ -32,24 +40,185 @@ type Config struct {
 	// Storage Configuration
 	RootFolder string `mapstructure:"root-folder" desc:"Root Dir (optional)"` // used to derive other folders if not defined
 	DbFolder   string `mapstructure:"db-folder" desc:"DB folder path for storing data files"`
+	DbInstance string `mapstructure:"db-instance" desc:"Name of the instance sub-directory of db-folder holding the data files, described by the layout.json manifest of db-folder. Empty uses db-folder as is"`
+
+	// Server-side merges, must be identical on all the nodes of a cluster
+	MergeOperators []string `mapstructure:"merge-operators" desc:"Merge operators of key prefixes in <prefix>=<operator> format. Built-in operators are counter|set"`
+
+	// Change propagation from log segments instead of the RocksDB WAL
+	ChangeLogEnabled     bool  `mapstructure:"change-log-enabled" desc:"Propagate changes from log segments retained independently of the RocksDB WAL"`
+	ChangeLogSegmentSize int64 `mapstructure:"change-log-segment-size" desc:"Size (in bytes) beyond which a change log segment is rolled over"`
+	ChangeLogMaxSegments int   `mapstructure:"change-log-max-segments" desc:"Number of change log segments to retain, 0 retains all the segments"`
+
+	// Reads as of a historical change number
+	VersionRetention uint64 `mapstructure:"version-retention" desc:"Number of latest changes as of which keys can be read, 0 disables such reads"`
+
+	// Reclaiming the space of expired and deleted keys
+	CompactionPolicy                 string   `mapstructure:"compaction-policy" desc:"Keys dropped while compacting - none|expired|expired-and-empty. Only supported by RocksDB"`
+	CompactionPeriodicIntervalString string   `mapstructure:"compaction-periodic-interval" desc:"Interval within which every RocksDB SST file is compacted, 0 disables periodic compactions. Eg., 24h, 168h, etc."`
+	CompactionDropPrefixes           []string `mapstructure:"compaction-drop-prefixes" desc:"Key prefixes dropped while compacting from the given date onwards, in <prefix>=<YYYY-MM-DD> format. Only supported by RocksDB"`
+
+	// WAL durability, overridable per Put request
+	WALSyncPolicy         string   `mapstructure:"wal-sync-policy" desc:"When the WAL is fsynced - sync|periodic|async. Badger supports only sync|async"`
+	WALSyncIntervalString string   `mapstructure:"wal-sync-interval" desc:"Interval between WAL fsyncs of the periodic policy. Eg., 100ms, 1s, etc."`
+	WALRetentionTTLString string   `mapstructure:"wal-retention-ttl" desc:"Duration for which the RocksDB WAL files are retained for replication, 0 disables the limit. Eg., 1h, 24h, etc."`
+	WALRetentionSizeMB    uint64   `mapstructure:"wal-retention-size-mb" desc:"Size (in MB) upto which the RocksDB WAL files are retained for replication, 0 disables the limit"`
+	WALLessPrefixes       []string `mapstructure:"wal-less-prefixes" desc:"Key prefixes of reconstructible data written without the WAL, which are lost upon a crash and never replicated. Requires change-log-enabled"`
+
+	// Group commit of the writes fsyncing the WAL, only supported by RocksDB
+	GroupCommitWindowString string `mapstructure:"group-commit-window" desc:"Window within which the concurrent writes fsyncing the WAL are coalesced into a single fsync, 0 disables group commit. Eg., 500us, 2ms, etc."`
+	GroupCommitMaxWrites    int    `mapstructure:"group-commit-max-writes" desc:"Maximum number of writes coalesced into a single fsync"`
+
+	// RocksDB tuning, zero values retain the engine defaults or the values from db-engine-ini
+	RocksDBWriteBufferSize            uint64   `mapstructure:"rocksdb-write-buffer-size" desc:"Size (in bytes) of a single RocksDB memtable"`
+	RocksDBMaxBackgroundJobs          int      `mapstructure:"rocksdb-max-background-jobs" desc:"Maximum number of concurrent RocksDB background flushes and compactions"`
+	RocksDBMaxOpenFiles               int      `mapstructure:"rocksdb-max-open-files" desc:"Number of files RocksDB can keep open, -1 keeps all files open"`
+	RocksDBNumLevels                  int      `mapstructure:"rocksdb-num-levels" desc:"Number of levels of the RocksDB LSM tree"`
+	RocksDBTargetFileSizeBase         uint64   `mapstructure:"rocksdb-target-file-size-base" desc:"Target size (in bytes) of RocksDB SST files in level 1"`
+	RocksDBMaxBytesForLevelBase       uint64   `mapstructure:"rocksdb-max-bytes-for-level-base" desc:"Maximum total size (in bytes) of RocksDB SST files in level 1"`
+	RocksDBMaxBytesForLevelMultiplier float64  `mapstructure:"rocksdb-max-bytes-for-level-multiplier" desc:"Growth factor of the maximum total size of every subsequent RocksDB level"`
+	RocksDBBloomFilterBitsPerKey      int      `mapstructure:"rocksdb-bloom-filter-bits-per-key" desc:"Bits per key of RocksDB bloom filters, 0 disables bloom filters"`
+	RocksDBCompressionPerLevel        []string `mapstructure:"rocksdb-compression-per-level" desc:"Compression of every RocksDB level starting with level 0 - none|snappy|zlib|bzip2|lz4|lz4hc|xpress|zstd"`
+	RocksDBBottommostCompression      string   `mapstructure:"rocksdb-bottommost-compression" desc:"Compression of the bottommost RocksDB level, overriding rocksdb-compression-per-level"`
+	RocksDBZSTDMaxDictBytes           uint32   `mapstructure:"rocksdb-zstd-max-dict-bytes" desc:"Size (in bytes) of the dictionaries of zstd compressed RocksDB levels, 0 disables dictionary compression"`
+	RocksDBZSTDMaxTrainBytes          uint32   `mapstructure:"rocksdb-zstd-max-train-bytes" desc:"Size (in bytes) of the samples used for training zstd dictionaries, 0 disables training"`
+	RocksDBRateLimitBytesPerSec       int64    `mapstructure:"rocksdb-rate-limit-bytes-per-sec" desc:"Disk bandwidth (in bytes per second) of RocksDB background flushes and compactions, 0 disables rate limiting"`
+	RocksDBCacheIndexAndFilterBlocks  bool     `mapstructure:"rocksdb-cache-index-and-filter-blocks" desc:"Holds the RocksDB index and filter blocks in the block cache, bounding their memory by block-cache-size"`
+	RocksDBPinL0FilterAndIndexBlocks  bool     `mapstructure:"rocksdb-pin-l0-filter-and-index-blocks" desc:"Keeps the index and filter blocks of RocksDB level 0 files in the block cache without evicting them"`
+	CacheStatsNamespaces              []string `mapstructure:"cache-stats-namespaces" desc:"Key prefixes identifying the namespaces whose block cache hit rates are reported separately, empty disables the accounting"`
+	CacheStatsSampleEvery             int      `mapstructure:"cache-stats-sample-every" desc:"Sampling of one in every given number of reads of a namespace for its block cache hit rate"`
+	PinnedReads                       bool     `mapstructure:"pinned-reads" desc:"Serves the values of Gets from RocksDB memory without copying them, until they are marshalled into the responses"`
+
+	// RocksDB auto-tuning within operator set bounds, zero bounds leave the corresponding setting untuned
+	AutoTuneEnabled            bool   `mapstructure:"auto-tune-enabled" desc:"Adjusts the RocksDB settings to the observed workload within the auto-tune bounds"`
+	AutoTuneIntervalString     string `mapstructure:"auto-tune-interval" desc:"Interval over which the workload is observed before adjusting the settings. Eg., 5m, 1h, etc."`
+	AutoTuneMinWriteBufferSize uint64 `mapstructure:"auto-tune-min-write-buffer-size" desc:"Minimum size (in bytes) of a single RocksDB memtable"`
+	AutoTuneMaxWriteBufferSize uint64 `mapstructure:"auto-tune-max-write-buffer-size" desc:"Maximum size (in bytes) of a single RocksDB memtable"`
+	AutoTuneMinBloomBits       int    `mapstructure:"auto-tune-min-bloom-bits" desc:"Minimum bits per key of RocksDB bloom filters"`
+	AutoTuneMaxBloomBits       int    `mapstructure:"auto-tune-max-bloom-bits" desc:"Maximum bits per key of RocksDB bloom filters"`
+	AutoTuneCompression        bool   `mapstructure:"auto-tune-compression" desc:"Switches the compression of the RocksDB levels between lighter and denser profiles"`
+
+	// Background integrity scrubbing
+	ScrubKeysPerSec                   int    `mapstructure:"scrub-keys-per-sec" desc:"Number of RocksDB entries whose checksums and encoding are verified per second in the background, 0 disables scrubbing"`
+	ScrubQuarantine                   bool   `mapstructure:"scrub-quarantine" desc:"Moves the corrupt entries found by the scrubber aside instead of only reporting them, on masters and standalone nodes"`
+	ScrubReplicaCompareIntervalString string `mapstructure:"scrub-replica-compare-interval" desc:"Interval at which slaves compare a sampled range of keys with their master, 0 disables the comparison. Eg., 10s, 1m, etc."`
+
+	// Crypto-shredding of data subjects
+	SubjectPrefixes  []string `mapstructure:"subject-prefixes" desc:"Key prefixes whose values are encrypted using a key per data subject, enabling their erasure"`
+	SubjectDelimiter string   `mapstructure:"subject-delimiter" desc:"Delimiter ending the subject identifier that follows a subject prefix. When empty, the prefix itself identifies the subject"`
+
+	// Encryption at rest
+	EncryptionKeyFile string   `mapstructure:"encryption-key-file" desc:"JSON file listing the master keys used for encrypting the values at rest, the last of which is active. Encryption is disabled when empty"`
+	TenantPrefixes    []string `mapstructure:"tenant-prefixes" desc:"Key prefixes identifying the tenants whose values are encrypted using a data key per tenant, enabling their key rotation and erasure. Requires encryption-key-file"`
 
 	// Server Configuration
 	ListenAddr string `mapstructure:"listen-addr" desc:"Address on which the DKV service binds"`
 	StatsdAddr string `mapstructure:"statsd-addr" desc:"StatsD service address in host:port format"`
 
+	// Metrics emission
+	MetricsBackend                string `mapstructure:"metrics-backend" desc:"Backend to which the metrics are emitted - statsd|prometheus|otlp"`
+	PrometheusListenAddr          string `mapstructure:"prometheus-listen-addr" desc:"Address in host:port format on which the metrics are exposed at /metrics for scraping by Prometheus"`
+	OTLPMetricsEndpoint           string `mapstructure:"otlp-metrics-endpoint" desc:"OpenTelemetry collector address in host:port format to which the metrics are pushed over OTLP/gRPC"`
+	OTLPMetricsPushIntervalString string `mapstructure:"otlp-metrics-push-interval" desc:"Interval for pushing the metrics to the OpenTelemetry collector. Eg., 10s, 1m, etc."`
+
+	// Paginated scans
+	ScanCursorTTLString string `mapstructure:"scan-cursor-ttl" desc:"Duration for which the cursor of a paginated scan is retained between pages. Eg., 30s, 1m, etc."`
+
+	// Batched reads
+	MultiGetBatchSize   int `mapstructure:"multi-get-batch-size" desc:"Number of keys of a MultiGet looked up together, with larger MultiGets split into batches read in parallel. 0 disables the split"`
+	MultiGetParallelism int `mapstructure:"multi-get-parallelism" desc:"Maximum number of batches of a MultiGet read in parallel"`
+
+	// Freshness tracking
+	ChangeNumberTrailers bool `mapstructure:"change-number-trailers" desc:"Attaches the latest committed (or applied on slaves) change number to the trailers of all the responses"`
+
+	// Secret for signing access tokens, must be shared by all the nodes accepting the same tokens
+	AccessTokenSecret string `mapstructure:"access-token-secret" redact:"true" desc:"Secret for signing temporary access tokens. When empty, tokens are valid only on the minting node until it restarts"`
+
+	// IP filtering of the data, admin and replication services
+	IPFilterFile                 string `mapstructure:"ip-filter-file" desc:"A .json file with the allowed and denied IPs or CIDRs of the data, admin and replication services"`
+	IPFilterReloadIntervalString string `mapstructure:"ip-filter-reload-interval" desc:"Interval for reloading the IP filter file when modified. Eg., 10s, 1m, etc."`
+
+	// Fan-out limits of the streaming calls
+	MaxConcurrentStreams uint32 `mapstructure:"max-concurrent-streams" desc:"Maximum number of streaming calls, such as iterations and health watches, served concurrently with the rest rejected. Replication streams are not counted. 0 leaves them unbounded"`
+	StreamWindowBytes    uint32 `mapstructure:"stream-window-bytes" desc:"Initial flow control window of every stream, bounding the bytes a client sends on a stream ahead of the server reading them. Responses are not bounded by it. At least 65535, while 0 lets the windows grow dynamically"`
+
+	// Heatmap of the keys read and written across key ranges
+	KeyHeatmapBuckets     uint32 `mapstructure:"key-heatmap-buckets" desc:"Number of key range buckets of the heatmap of the reads and writes, bounded by the keys sampled in the previous minute. 0 disables the heatmap"`
+	KeyHeatmapMinutes     uint32 `mapstructure:"key-heatmap-minutes" desc:"Number of most recent minutes retained by the heatmap of the reads and writes"`
+	KeyHeatmapSampleEvery uint32 `mapstructure:"key-heatmap-sample-every" desc:"Sampling of one in every given number of reads and writes for the heatmap"`
+
+	// Layouts of the keys of the namespaces, published to the clients
+	KeyPolicies []string `mapstructure:"key-policies" desc:"Key policies published to the clients for building and validating the keys of the namespaces, in <namespace>@<version>=<segment>:<type>,... format with types being string|uint64|int64. A namespace may have policies of several versions, the latest of which is used for building its keys"`
+
 	//Service discovery related params
 	DiscoveryServiceConfig string `mapstructure:"discovery-service-config" desc:"A .ini file for configuring discovery service parameters"`
 
 	// Temporary variables to be removed once https://github.com/flipkart-incubator/dkv/issues/82 is fixed
 	// The above issue causes replication issues during master switch due to inconsistent change numbers
 	// Thus enabling hardcoded masters to not degrade current behaviour
-	ReplicationMasterAddr string `mapstructure:"repl-master-addr" desc:"Service address of DKV master node for replication"`
+	ReplicationMasterAddr string `mapstructure:"repl-master-addr" desc:"Service address of DKV master node for replication, or of another slave for cascading replication"`
 	DisableAutoMasterDisc bool   `mapstructure:"disable-auto-master-disc"`
 
+	// Semi-sync replication
+	SemiSyncReplica       string `mapstructure:"semi-sync-replica" desc:"Replica ID of the slave whose receipt of the changes is awaited before acknowledging the writes on the master, empty disables semi-sync replication"`
+	SemiSyncTimeoutString string `mapstructure:"semi-sync-timeout" desc:"Duration for which the writes await the semi-sync replica before degrading to async replication. Eg., 500ms, 1s, etc."`
+	SemiSync              bool   `mapstructure:"semi-sync" desc:"Retrieves the changes as soon as they are committed on the master, for serving as its semi-sync replica"`
+	ReplicaID             string `mapstructure:"replica-id" desc:"ID with which the slave identifies itself to its master, such as its address as seen by the master when behind NAT. Defaults to the detected address of the node"`
+
+	// Compression of the replication stream
+	ReplCompression       string `mapstructure:"repl-compression" desc:"Compressor (gzip|zstd) with which slaves retrieve the changes from their master, empty disables the compression"`
+	ReplRecordCompression string `mapstructure:"repl-record-compression" desc:"Compressor (gzip|zstd) with which masters compress every change record sent to a slave individually, leaving the records that do not shrink as is. Empty disables it"`
+	ReplDeltaEncodeKeys   bool   `mapstructure:"repl-delta-encode-keys" desc:"Omits from the keys of the changes sent to a slave the prefixes they share with the preceding keys of the same change record"`
+
+	// Filtered replication, only supported by RocksDB
+	ReplKeyPrefixes []string `mapstructure:"repl-key-prefixes" desc:"Key prefixes replicated by a slave, with the changes on other keys skipped. Empty replicates all the keys"`
+
+	// Cross-datacenter replication
+	ReplWAN                 bool   `mapstructure:"repl-wan" desc:"Retrieves the changes over a single long lived stream from the master, batched and compressed using zstd unless repl-compression is given. Suits the slaves across datacenters"`
+	ReplWANBatchBytes       uint32 `mapstructure:"repl-wan-batch-bytes" desc:"Size of the changes upon which the master sends a batch to a WAN slave, 0 bounds the batches only by their number of changes"`
+	ReplWANBatchDelayString string `mapstructure:"repl-wan-batch-delay" desc:"Duration for which the master holds on to the changes for batching them to a WAN slave. Eg., 20ms, 100ms, etc."`
+	ReplWANMaxInFlightBytes uint32 `mapstructure:"repl-wan-max-inflight-bytes" desc:"Size of the changes that the master sends to a WAN slave ahead of its acknowledgements, pausing once reached. 0 leaves it unbounded"`
+
+	// Apply-side throttling of slaves
+	ReplBatchSize            uint32 `mapstructure:"repl-batch-size" desc:"Maximum number of changes retrieved by a slave from its master at a time, 10000 when 0"`
+	ReplApplyBatchSize       uint32 `mapstructure:"repl-apply-batch-size" desc:"Maximum number of changes applied by a slave at a time, 0 applies all the retrieved changes at once"`
+	ReplApplyPrefetch        uint32 `mapstructure:"repl-apply-prefetch" desc:"Number of batches of changes that a slave retrieves ahead of their apply during catch-up, each while applying the preceding one, 0 alternates between retrieving and applying"`
+	ReplApplyPipelineWorkers uint32 `mapstructure:"repl-apply-pipeline-workers" desc:"Number of workers of a slave decoding and validating the changes while the preceding ones are committed in order, 0 or 1 applies them serially. Only supported by RocksDB"`
+	ReplMaxApplyRate         uint32 `mapstructure:"repl-max-apply-rate" desc:"Maximum number of changes applied by a slave per second, 0 leaves it unbounded"`
+
+	// Read-path fallback of slaves
+	ReplReadFallback bool `mapstructure:"repl-read-fallback" desc:"Retries the Gets of the keys missing on a slave, or of the changes it is yet to apply, on its master"`
+
+	// Consistency verification of slaves
+	ReplVerifyIntervalString string `mapstructure:"repl-verify-interval" desc:"Interval at which slaves verify the digests of successive key ranges against their master, 0 disables the verification. Eg., 10s, 1m, etc."`
+	ReplVerifyKeysPerRange   uint32 `mapstructure:"repl-verify-keys-per-range" desc:"Number of keys of every range verified by slaves against their master, defaults to 1000 when 0"`
+
+	// Active-active replication between two masters, only supported by RocksDB
+	ActiveActivePeer     string `mapstructure:"active-active-peer" desc:"Address of the peer master with which this master replicates actively, with their conflicting writes resolved by last-writer-wins. Empty disables active-active replication"`
+	ActiveActiveOriginID string `mapstructure:"active-active-origin-id" desc:"ID with which the writes accepted by this master are stamped under active-active replication, which must differ from that of the peer"`
+
 	// Logging vars
 	AccessLog string `mapstructure:"access-log" desc:"File for logging DKV accesses eg., stdout, stderr, /tmp/access.log"`
 
-	ReplPollInterval time.Duration
+	ReplPollInterval  time.Duration
+	WALSyncInterval   time.Duration
+	WALRetentionTTL   time.Duration
+	GroupCommitWindow time.Duration
+	AutoTuneInterval  time.Duration
+
+	CompactionPeriodicInterval time.Duration
+
+	IPFilterReloadInterval time.Duration
+	ScanCursorTTL          time.Duration
+
+	OTLPMetricsPushInterval time.Duration
+
+	ScrubReplicaCompareInterval time.Duration
+
+	SemiSyncTimeout   time.Duration
+	ReplWANBatchDelay time.Duration
+
+	ReplVerifyInterval time.Duration
 
 	//Nexus vars
 	NexusClusterName            string `mapstructure:"nexus-cluster-name" desc:"Nexus Cluster Name"`
@@ -75,6 +244,99 @@ func (c *Config) parseConfig() {
 		}
 		c.ReplPollInterval = replicationPollInterval
 	}
+	if c.WALSyncIntervalString != "" {
+		walSyncInterval, err := time.ParseDuration(c.WALSyncIntervalString)
+		if err != nil {
+			log.Panicf("Failed to read WAL sync interval value from config %v", err)
+		}
+		c.WALSyncInterval = walSyncInterval
+	}
+	if c.WALRetentionTTLString != "" {
+		walRetentionTTL, err := time.ParseDuration(c.WALRetentionTTLString)
+		if err != nil {
+			log.Panicf("Failed to read WAL retention TTL value from config %v", err)
+		}
+		c.WALRetentionTTL = walRetentionTTL
+	}
+	if c.GroupCommitWindowString != "" {
+		groupCommitWindow, err := time.ParseDuration(c.GroupCommitWindowString)
+		if err != nil {
+			log.Panicf("Failed to read group commit window value from config %v", err)
+		}
+		c.GroupCommitWindow = groupCommitWindow
+	}
+	if c.CompactionPeriodicIntervalString != "" {
+		compactionPeriodicInterval, err := time.ParseDuration(c.CompactionPeriodicIntervalString)
+		if err != nil {
+			log.Panicf("Failed to read periodic compaction interval value from config %v", err)
+		}
+		c.CompactionPeriodicInterval = compactionPeriodicInterval
+	}
+	if c.AutoTuneIntervalString != "" {
+		autoTuneInterval, err := time.ParseDuration(c.AutoTuneIntervalString)
+		if err != nil {
+			log.Panicf("Failed to read auto-tune interval value from config %v", err)
+		}
+		c.AutoTuneInterval = autoTuneInterval
+	}
+	if c.IPFilterReloadIntervalString != "" {
+		ipFilterReloadInterval, err := time.ParseDuration(c.IPFilterReloadIntervalString)
+		if err != nil {
+			log.Panicf("Failed to read IP filter reload interval value from config %v", err)
+		}
+		c.IPFilterReloadInterval = ipFilterReloadInterval
+	}
+	if c.OTLPMetricsPushIntervalString != "" {
+		otlpMetricsPushInterval, err := time.ParseDuration(c.OTLPMetricsPushIntervalString)
+		if err != nil {
+			log.Panicf("Failed to read OTLP metrics push interval value from config %v", err)
+		}
+		c.OTLPMetricsPushInterval = otlpMetricsPushInterval
+	}
+	if c.ScrubReplicaCompareIntervalString != "" {
+		scrubReplicaCompareInterval, err := time.ParseDuration(c.ScrubReplicaCompareIntervalString)
+		if err != nil {
+			log.Panicf("Failed to read scrub replica compare interval value from config %v", err)
+		}
+		c.ScrubReplicaCompareInterval = scrubReplicaCompareInterval
+	}
+	if c.ReplVerifyIntervalString != "" {
+		replVerifyInterval, err := time.ParseDuration(c.ReplVerifyIntervalString)
+		if err != nil {
+			log.Panicf("Failed to read replication verify interval value from config %v", err)
+		}
+		c.ReplVerifyInterval = replVerifyInterval
+	}
+	if c.SemiSyncTimeoutString != "" {
+		semiSyncTimeout, err := time.ParseDuration(c.SemiSyncTimeoutString)
+		if err != nil {
+			log.Panicf("Failed to read semi-sync timeout value from config %v", err)
+		}
+		c.SemiSyncTimeout = semiSyncTimeout
+	}
+	if c.ReplWANBatchDelayString != "" {
+		replWANBatchDelay, err := time.ParseDuration(c.ReplWANBatchDelayString)
+		if err != nil {
+			log.Panicf("Failed to read WAN replication batch delay value from config %v", err)
+		}
+		c.ReplWANBatchDelay = replWANBatchDelay
+	}
+	if c.ScanCursorTTLString != "" {
+		scanCursorTTL, err := time.ParseDuration(c.ScanCursorTTLString)
+		if err != nil {
+			log.Panicf("Failed to read scan cursor TTL value from config %v", err)
+		}
+		c.ScanCursorTTL = scanCursorTTL
+	}
+	if c.WALSyncPolicy == "" {
+		c.WALSyncPolicy = "sync"
+	}
+	if c.CompactionPolicy == "" {
+		c.CompactionPolicy = "expired"
+	}
+	if c.ChangeLogSegmentSize == 0 {
+		c.ChangeLogSegmentSize = 64 << 20
+	}
 	//Append node name to default db folder location
 	if c.DbFolder == "" {
 		c.DbFolder = path.Join(c.RootFolder, c.NodeName, "data")
@@ -99,16 +361,278 @@ func (c *Config) validateFlags() {
 		log.Panicf("given StatsD address: %s is invalid, must be in host:port format", c.StatsdAddr)
 	}
 
+	switch strings.ToLower(c.MetricsBackend) {
+	case "", "statsd":
+	case "prometheus":
+		if strings.IndexRune(c.PrometheusListenAddr, ':') < 0 {
+			log.Panicf("given Prometheus listen address: %s is invalid, must be in host:port format", c.PrometheusListenAddr)
+		}
+	case "otlp":
+		if strings.IndexRune(c.OTLPMetricsEndpoint, ':') < 0 {
+			log.Panicf("given OTLP metrics endpoint: %s is invalid, must be in host:port format", c.OTLPMetricsEndpoint)
+		}
+		if c.OTLPMetricsPushInterval <= 0 {
+			log.Panicf("otlp-metrics-push-interval must be positive for the otlp metrics-backend")
+		}
+	default:
+		log.Panicf("given metrics backend: %s is invalid, must be one of statsd|prometheus|otlp", c.MetricsBackend)
+	}
+
 	if c.DisklessMode && strings.ToLower(c.DbEngine) == "rocksdb" {
 		log.Panicf("diskless is available only on Badger storage")
 	}
 
+	if len(c.SubjectPrefixes) > 0 && strings.ToLower(c.DbEngine) != "rocksdb" {
+		log.Panicf("subject-prefixes is available only on RocksDB storage")
+	}
+
+	if c.EncryptionKeyFile != "" && strings.ToLower(c.DbEngine) != "rocksdb" {
+		log.Panicf("encryption-key-file is available only on RocksDB storage")
+	}
+
+	if len(c.TenantPrefixes) > 0 && c.EncryptionKeyFile == "" {
+		log.Panicf("tenant-prefixes requires encryption-key-file to be configured")
+	}
+	for _, prefix := range c.TenantPrefixes {
+		if prefix == "" {
+			log.Panicf("tenant-prefixes must not contain an empty prefix")
+		}
+	}
+
+	if len(c.MergeOperators) > 0 && strings.ToLower(c.DbEngine) != "rocksdb" {
+		log.Panicf("merge-operators is available only on RocksDB storage")
+	}
+	for _, mergeOp := range c.MergeOperators {
+		if parts := strings.SplitN(mergeOp, "=", 2); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			log.Panicf("given merge operator: %s is invalid, must be in <prefix>=<operator> format", mergeOp)
+		}
+	}
+
+	if c.ScanCursorTTL < 0 {
+		log.Panicf("scan-cursor-ttl must not be negative")
+	}
+
+	if c.MultiGetBatchSize < 0 || c.MultiGetParallelism < 0 {
+		log.Panicf("multi-get-batch-size and multi-get-parallelism must not be negative")
+	}
+
+	if c.ChangeLogEnabled {
+		if strings.ToLower(c.DbEngine) != "rocksdb" {
+			log.Panicf("change-log-enabled is available only on RocksDB storage")
+		}
+		if c.ChangeLogSegmentSize < 0 || c.ChangeLogMaxSegments < 0 {
+			log.Panicf("change-log-segment-size and change-log-max-segments must not be negative")
+		}
+	}
+
+	switch strings.ToLower(c.WALSyncPolicy) {
+	case "sync", "async":
+	case "periodic":
+		if strings.ToLower(c.DbEngine) != "rocksdb" {
+			log.Panicf("periodic wal-sync-policy is available only on RocksDB storage")
+		}
+		if c.WALSyncInterval <= 0 {
+			log.Panicf("wal-sync-interval must be positive for the periodic wal-sync-policy")
+		}
+	default:
+		log.Panicf("given WAL sync policy: %s is invalid, must be one of sync|periodic|async", c.WALSyncPolicy)
+	}
+	if c.WALRetentionTTL < 0 {
+		log.Panicf("wal-retention-ttl must not be negative")
+	}
+	if (c.WALRetentionTTL > 0 || c.WALRetentionSizeMB > 0) && strings.ToLower(c.DbEngine) != "rocksdb" {
+		log.Panicf("wal-retention-ttl and wal-retention-size-mb are available only on RocksDB storage")
+	}
+	if len(c.WALLessPrefixes) > 0 && !c.ChangeLogEnabled {
+		log.Panicf("wal-less-prefixes requires change-log-enabled")
+	}
+	for _, prefix := range c.WALLessPrefixes {
+		if prefix == "" {
+			log.Panicf("wal-less-prefixes must not be empty")
+		}
+	}
+
+	if c.GroupCommitWindow > 0 {
+		if strings.ToLower(c.DbEngine) != "rocksdb" {
+			log.Panicf("group-commit-window is available only on RocksDB storage")
+		}
+		if c.GroupCommitMaxWrites <= 0 {
+			log.Panicf("group-commit-max-writes must be positive when group commit is enabled")
+		}
+	}
+
+	switch strings.ToLower(c.CompactionPolicy) {
+	case "expired":
+	case "none", "expired-and-empty":
+		if strings.ToLower(c.DbEngine) != "rocksdb" {
+			log.Panicf("%s compaction-policy is available only on RocksDB storage", c.CompactionPolicy)
+		}
+	default:
+		log.Panicf("given compaction policy: %s is invalid, must be one of none|expired|expired-and-empty", c.CompactionPolicy)
+	}
+	if c.CompactionPeriodicInterval < 0 {
+		log.Panicf("compaction-periodic-interval must not be negative")
+	}
+	if c.CompactionPeriodicInterval > 0 && strings.ToLower(c.DbEngine) != "rocksdb" {
+		log.Panicf("compaction-periodic-interval is available only on RocksDB storage")
+	}
+	if len(c.CompactionDropPrefixes) > 0 && strings.ToLower(c.DbEngine) != "rocksdb" {
+		log.Panicf("compaction-drop-prefixes is available only on RocksDB storage")
+	}
+	for _, dropPrefix := range c.CompactionDropPrefixes {
+		parts := strings.SplitN(dropPrefix, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			log.Panicf("given compaction drop prefix: %s is invalid, must be in <prefix>=<YYYY-MM-DD> format", dropPrefix)
+		}
+		if _, err := time.Parse("2006-01-02", parts[1]); err != nil {
+			log.Panicf("given compaction drop prefix: %s has an invalid date. Error: %v", dropPrefix, err)
+		}
+	}
+
+	if c.AutoTuneEnabled {
+		if strings.ToLower(c.DbEngine) != "rocksdb" {
+			log.Panicf("auto-tune-enabled is available only on RocksDB storage")
+		}
+		if c.AutoTuneInterval <= 0 {
+			log.Panicf("auto-tune-interval must be positive when auto-tune-enabled is set")
+		}
+	}
+
+	if c.RocksDBRateLimitBytesPerSec < 0 {
+		log.Panicf("rocksdb-rate-limit-bytes-per-sec must not be negative")
+	}
+	if c.RocksDBRateLimitBytesPerSec > 0 && strings.ToLower(c.DbEngine) != "rocksdb" {
+		log.Panicf("rocksdb-rate-limit-bytes-per-sec is available only on RocksDB storage")
+	}
+
+	if c.PinnedReads && strings.ToLower(c.DbEngine) != "rocksdb" {
+		log.Panicf("pinned-reads is available only on RocksDB storage")
+	}
+	if len(c.CacheStatsNamespaces) > 0 {
+		if strings.ToLower(c.DbEngine) != "rocksdb" {
+			log.Panicf("cache-stats-namespaces is available only on RocksDB storage")
+		}
+		if c.CacheStatsSampleEvery <= 0 {
+			log.Panicf("cache-stats-sample-every must be positive when cache-stats-namespaces is given")
+		}
+		for _, ns := range c.CacheStatsNamespaces {
+			if ns == "" {
+				log.Panicf("cache-stats-namespaces must not be empty")
+			}
+		}
+	}
+	if c.RocksDBCacheIndexAndFilterBlocks && strings.ToLower(c.DbEngine) != "rocksdb" {
+		log.Panicf("rocksdb-cache-index-and-filter-blocks is available only on RocksDB storage")
+	}
+
+	if c.ScrubKeysPerSec < 0 {
+		log.Panicf("scrub-keys-per-sec must not be negative")
+	}
+	if c.ScrubKeysPerSec > 0 && strings.ToLower(c.DbEngine) != "rocksdb" {
+		log.Panicf("scrub-keys-per-sec is available only on RocksDB storage")
+	}
+	if c.ScrubQuarantine && c.DbRole == "slave" {
+		log.Panicf("scrub-quarantine is not available on slaves, whose corrupt entries must be repaired from their master")
+	}
+	if c.ScrubReplicaCompareInterval < 0 {
+		log.Panicf("scrub-replica-compare-interval must not be negative")
+	}
+	if c.ReplVerifyInterval < 0 {
+		log.Panicf("repl-verify-interval must not be negative")
+	}
+
+	if c.IPFilterFile != "" {
+		if _, err := os.Stat(c.IPFilterFile); err != nil && os.IsNotExist(err) {
+			log.Panicf("given IP filter file: %s does not exist", c.IPFilterFile)
+		}
+		if c.IPFilterReloadInterval <= 0 {
+			log.Panicf("ip-filter-reload-interval must be positive when ip-filter-file is given")
+		}
+	}
+
 	if c.DbEngineIni != "" {
 		if _, err := os.Stat(c.DbEngineIni); err != nil && os.IsNotExist(err) {
 			log.Panicf("given storage configuration file: %s does not exist", c.DbEngineIni)
 		}
 	}
 
+	if c.SemiSyncTimeout < 0 {
+		log.Panicf("semi-sync-timeout must not be negative")
+	}
+	if err := compression.Validate(c.ReplCompression); err != nil {
+		log.Panicf("given repl-compression is invalid: %v", err)
+	}
+	if err := compression.Validate(c.ReplRecordCompression); err != nil {
+		log.Panicf("given repl-record-compression is invalid: %v", err)
+	}
+	if c.ReplWANBatchDelay < 0 {
+		log.Panicf("repl-wan-batch-delay must not be negative")
+	}
+	if c.StreamWindowBytes > 0 && (c.StreamWindowBytes < streamlimit.MinStreamWindowBytes || c.StreamWindowBytes > math.MaxInt32) {
+		log.Panicf("stream-window-bytes must be between %d and %d", streamlimit.MinStreamWindowBytes, math.MaxInt32)
+	}
+	if c.KeyHeatmapBuckets > 0 {
+		if c.KeyHeatmapBuckets > heatmap.MaxBuckets {
+			log.Panicf("key-heatmap-buckets must not exceed %d", heatmap.MaxBuckets)
+		}
+		if c.KeyHeatmapMinutes == 0 || c.KeyHeatmapSampleEvery == 0 {
+			log.Panicf("key-heatmap-minutes and key-heatmap-sample-every must be positive when key-heatmap-buckets is given")
+		}
+	}
+	namespaces := make(map[string][]*serverpb.KeyPolicy, len(c.KeyPolicies))
+	for _, keyPolicy := range c.KeyPolicies {
+		policy, err := keypolicy.Parse(keyPolicy)
+		if err != nil {
+			log.Panicf("given key policy is invalid. Error: %v", err)
+		}
+		namespaces[policy.Namespace] = append(namespaces[policy.Namespace], policy)
+	}
+	for _, policies := range namespaces {
+		if err := keypolicy.ValidateVersions(policies); err != nil {
+			log.Panicf("given key policies are invalid. Error: %v", err)
+		}
+	}
+	if c.ReplWAN && c.ReplApplyPrefetch > 0 {
+		log.Panicf("repl-apply-prefetch can not be used with repl-wan, since the changes are streamed by the master")
+	}
+	if c.ReplApplyPipelineWorkers > 1 && (c.DbRole != "slave" || strings.ToLower(c.DbEngine) != "rocksdb") {
+		log.Panicf("repl-apply-pipeline-workers is available only on slaves using RocksDB storage")
+	}
+	if c.ReplWAN && c.SemiSync {
+		log.Panicf("repl-wan can not be used with semi-sync, since the changes are batched by the master")
+	}
+	if c.ActiveActivePeer != "" {
+		if c.DbRole != "master" || strings.ToLower(c.DbEngine) != "rocksdb" {
+			log.Panicf("active-active-peer is available only on masters using RocksDB storage")
+		}
+		if strings.IndexRune(c.ActiveActivePeer, ':') < 0 {
+			log.Panicf("given active-active peer: %s is invalid, must be in host:port format", c.ActiveActivePeer)
+		}
+		if c.ActiveActiveOriginID == "" {
+			log.Panicf("active-active-origin-id must be given along with active-active-peer")
+		}
+		// Values of the peer are applied as they are stored by the peer
+		if c.EncryptionKeyFile != "" {
+			log.Panicf("active-active-peer is not available along with encryption-key-file")
+		}
+	}
+	if len(c.ReplKeyPrefixes) > 0 {
+		if c.DbRole != "slave" || strings.ToLower(c.DbEngine) != "rocksdb" {
+			log.Panicf("repl-key-prefixes is available only on slaves using RocksDB storage")
+		}
+		if c.ScrubReplicaCompareInterval > 0 {
+			log.Panicf("repl-key-prefixes can not be used with scrub-replica-compare-interval, since the skipped keys diverge from the master")
+		}
+		if c.ReplVerifyInterval > 0 {
+			log.Panicf("repl-key-prefixes can not be used with repl-verify-interval, since the skipped keys diverge from the master")
+		}
+		for _, prefix := range c.ReplKeyPrefixes {
+			if prefix == "" {
+				log.Panicf("repl-key-prefixes must not be empty")
+			}
+		}
+	}
+
 	if c.DbRole == "slave" && c.DisableAutoMasterDisc {
 		if c.ReplicationMasterAddr == "" || strings.IndexRune(c.ReplicationMasterAddr, ':') < 0 {
 			log.Panicf("given master address: %s for replication is invalid, must be in host:port format", c.ReplicationMasterAddr)
@@ -116,18 +640,58 @@ func (c *Config) validateFlags() {
 	}
 }
 
+// Print emits the startup banner along with the effective configuration.
 func (c *Config) Print() {
+	effCfg, err := c.EffectiveConfig()
+	if err != nil {
+		log.Panicf("Failed to render the effective configuration %v", err)
+	}
+	log.Printf("Starting DKV server version: %s with effective configuration: %s\n", version.Version, effCfg)
+}
+
+const redactedValue = "<redacted>"
+
+// EffectiveSetting captures the resolved value of a configuration
+// setting along with its source, which is one of flag, env, file or
+// default in the order of precedence.
+type EffectiveSetting struct {
+	Value  interface{} `json:"value"`
+	Source string      `json:"source"`
+}
+
+// EffectiveConfig renders the fully resolved configuration as JSON keyed
+// by the setting names. Values of the settings tagged as `redact` are
+// never revealed.
+func (c *Config) EffectiveConfig() ([]byte, error) {
 	f := reflect.TypeOf(*c)
 	v := reflect.ValueOf(*c)
+	settings := make(map[string]EffectiveSetting)
 	for i := 0; i < v.NumField(); i++ {
-		value := v.Field(i).Interface()
 		tag := f.Field(i).Tag
 		name := tag.Get("mapstructure")
 		if name == "" {
 			continue
 		}
-		log.Printf("%s (%s) : %v\n", name, tag.Get("desc"), value)
+		value := v.Field(i).Interface()
+		if tag.Get("redact") == "true" && !v.Field(i).IsZero() {
+			value = redactedValue
+		}
+		settings[name] = EffectiveSetting{Value: value, Source: settingSource(name)}
 	}
+	return json.Marshal(settings)
+}
+
+func settingSource(name string) string {
+	if _, present := flagOverrides[name]; present || isFlagPassed(name) {
+		return "flag"
+	}
+	if _, present := os.LookupEnv(strings.ToUpper(name)); present {
+		return "env"
+	}
+	if viper.InConfig(name) {
+		return "file"
+	}
+	return "default"
 }
 
 func (c *Config) Init(cfgFile string) {
@@ -159,11 +723,15 @@ func loadConfigFile(cfgFile string) {
 	}
 }
 
+// flagOverrides tracks the settings overridden through unknown flags
+var flagOverrides = make(map[string]struct{})
+
 func applyConfigOverrides() {
 	flag.CommandLine.VisitUnknowns(func(f *flag.Flag) {
 		// Apply the flag override value to viper config
 		val := fmt.Sprintf("%v", f.Value.String())
 		viper.Set(f.Name, val)
+		flagOverrides[f.Name] = struct{}{}
 	})
 
 	flag.VisitAll(func(f *flag.Flag) {
@@ -0,0 +1,36 @@
+package opts
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEffectiveConfig(t *testing.T) {
+	c := &Config{NodeName: "node1", DbEngine: "rocksdb", AccessTokenSecret: "s3cr3t"}
+	effCfg, err := c.EffectiveConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var settings map[string]EffectiveSetting
+	if err = json.Unmarshal(effCfg, &settings); err != nil {
+		t.Fatal(err)
+	}
+	if setting := settings["node-name"]; setting.Value != "node1" || setting.Source != "default" {
+		t.Errorf("Setting mismatch for node-name. Actual: %+v", setting)
+	}
+	if setting := settings["access-token-secret"]; setting.Value != redactedValue {
+		t.Errorf("Expected access-token-secret to be redacted. Actual: %+v", setting)
+	}
+	if _, present := settings["ReplPollInterval"]; present {
+		t.Error("Expected only the named settings to be rendered")
+	}
+
+	c.AccessTokenSecret = ""
+	effCfg, _ = c.EffectiveConfig()
+	settings = nil
+	json.Unmarshal(effCfg, &settings)
+	if setting := settings["access-token-secret"]; setting.Value != "" {
+		t.Errorf("Expected empty access-token-secret to be rendered as is. Actual: %+v", setting)
+	}
+}
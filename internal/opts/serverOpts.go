@@ -1,7 +1,13 @@
 package opts
 
 import (
+	"time"
+
+	"github.com/flipkart-incubator/dkv/internal/auth"
+	"github.com/flipkart-incubator/dkv/internal/heatmap"
 	"github.com/flipkart-incubator/dkv/internal/stats"
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
 	"go.uber.org/zap"
 )
 
@@ -12,6 +18,28 @@ type ServerOpts struct {
 	HealthCheckTickerInterval uint
 	StatsCli                  stats.Client
 	Logger                    *zap.Logger
+	TokenIssuer               *auth.TokenIssuer
+	EffectiveConfig           string
+	ScanCursorTTL             time.Duration
+	FencingEpoch              *storage.FencingEpoch
+	PinnedReads               bool
+	// Replica ID of the slave whose receipt of the changes is awaited by
+	// the master before acknowledging the writes, empty disables it
+	SemiSyncReplica string
+	// Duration for which the writes await the semi-sync replica before
+	// degrading to async replication
+	SemiSyncTimeout time.Duration
+	// Address of the peer master with which this master replicates
+	// actively, empty disables active-active replication
+	ActiveActivePeer string
+	// Resolves the conflicting writes of the active-active masters,
+	// last-writer-wins unless given otherwise
+	ConflictResolver storage.ConflictResolver
+	// Heatmap of the keys read and written through the node, nil when
+	// disabled
+	KeyHeatmap *heatmap.Heatmap
+	// Key policies published to the clients for the namespaces
+	KeyPolicies []*serverpb.KeyPolicy
 }
 
 const (
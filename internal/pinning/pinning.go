@@ -0,0 +1,76 @@
+// Package pinning lets the GRPC responses refer to the values pinned in
+// the memory of the storage engine, instead of copies of those values.
+// The pins are released as soon as the responses are marshalled, hence
+// the values are copied exactly once, into the wire format.
+package pinning
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+// Pinned responses along with the functions releasing their pins
+var pins sync.Map
+
+// Pin registers the given function releasing the values referred to by
+// the given response, which is invoked once the response is marshalled.
+// Responses must be pinned only by the servers using the Codec along
+// with the UnaryServerInterceptor.
+func Pin(res interface{}, release func()) {
+	pins.Store(res, release)
+}
+
+// Release releases the values referred to by the given response, if it
+// is pinned.
+func Release(res interface{}) {
+	if release, present := pins.LoadAndDelete(res); present {
+		release.(func())()
+	}
+}
+
+type codec struct{}
+
+// Codec creates the GRPC codec marshalling the messages using protobuf,
+// same as the default codec, and releasing the pinned responses once
+// they are marshalled.
+func Codec() encoding.Codec {
+	return codec{}
+}
+
+func (codec) Marshal(v interface{}) ([]byte, error) {
+	defer Release(v)
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("failed to marshal, message is %T, want proto.Message", v)
+	}
+	return proto.Marshal(msg)
+}
+
+func (codec) Unmarshal(data []byte, v interface{}) error {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("failed to unmarshal, message is %T, want proto.Message", v)
+	}
+	return proto.Unmarshal(data, msg)
+}
+
+func (codec) Name() string {
+	return "proto"
+}
+
+// UnaryServerInterceptor releases the pinned responses of the failed
+// calls, since such responses are discarded without being marshalled.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		res, err := handler(ctx, req)
+		if err != nil && res != nil {
+			Release(res)
+		}
+		return res, err
+	}
+}
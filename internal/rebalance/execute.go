@@ -0,0 +1,142 @@
+package rebalance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"syscall"
+)
+
+// ErrPaused is returned by Execute when the execution is paused.
+var ErrPaused = errors.New("rebalancing is paused")
+
+// Execution is the progress of executing a plan, saved in a state file so
+// that the execution can be paused and later resumed, possibly by another
+// process.
+type Execution struct {
+	Plan *Plan
+	// Number of moves of the plan executed so far.
+	Done int
+	// Set to stop the execution once the move in progress is executed.
+	Paused bool
+}
+
+// Migrate migrates the replica of a shard as given by the move, returning
+// only once the replica is hosted by the destination node.
+type Migrate func(ctx context.Context, move *Move) error
+
+// Start saves the execution of the given plan into the given state file,
+// which must not exist yet, so that its moves are executed by Execute.
+func Start(stateFile string, plan *Plan) error {
+	f, err := os.OpenFile(stateFile, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return saveExecution(f, &Execution{Plan: plan})
+}
+
+// Pause pauses the execution saved in the given state file. The process
+// executing it stops once the move in progress is executed.
+func Pause(stateFile string) error {
+	_, err := updateExecution(stateFile, func(exec *Execution) error {
+		exec.Paused = true
+		return nil
+	})
+	return err
+}
+
+// Resume resumes the execution saved in the given state file, whose
+// remaining moves are then executed by Execute.
+func Resume(stateFile string) error {
+	_, err := updateExecution(stateFile, func(exec *Execution) error {
+		exec.Paused = false
+		return nil
+	})
+	return err
+}
+
+// Execute executes the remaining moves of the execution saved in the given
+// state file in order, using the given function to migrate the replicas.
+// The progress is saved after every move, until all of them are executed,
+// the execution is paused or the given context is done, upon which the
+// move in progress is executed again once resumed. It returns the saved
+// execution along with ErrPaused if paused.
+func Execute(ctx context.Context, stateFile string, migrate Migrate) (*Execution, error) {
+	for {
+		var move *Move
+		exec, err := updateExecution(stateFile, func(exec *Execution) error {
+			switch {
+			case exec.Paused:
+				return ErrPaused
+			case exec.Done < len(exec.Plan.Moves):
+				move = exec.Plan.Moves[exec.Done]
+			}
+			return nil
+		})
+		if err != nil || move == nil {
+			return exec, err
+		}
+		if err = ctx.Err(); err != nil {
+			return exec, err
+		}
+		if err = migrate(ctx, move); err != nil {
+			return exec, fmt.Errorf("unable to move shard %s from %s to %s: %v", move.Shard, move.From, move.To, err)
+		}
+		// The execution may have been paused meanwhile, which is retained
+		if _, err = updateExecution(stateFile, func(exec *Execution) error {
+			exec.Done++
+			return nil
+		}); err != nil {
+			return exec, err
+		}
+	}
+}
+
+// updateExecution updates the execution saved in the given state file
+// using the given function while holding an exclusive lock over the file,
+// saving it back unless the function fails.
+func updateExecution(stateFile string, update func(*Execution) error) (*Execution, error) {
+	f, err := os.OpenFile(stateFile, os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if err = syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		return nil, fmt.Errorf("unable to lock state file %s: %v", stateFile, err)
+	}
+	// Closing the file releases the lock as well
+	defer syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+
+	exec := &Execution{}
+	if err = json.NewDecoder(f).Decode(exec); err != nil {
+		return nil, fmt.Errorf("unable to load state file %s: %v", stateFile, err)
+	}
+	if exec.Plan == nil {
+		return nil, fmt.Errorf("state file %s holds no plan", stateFile)
+	}
+	if err = update(exec); err != nil {
+		return exec, err
+	}
+	return exec, saveExecution(f, exec)
+}
+
+func saveExecution(f *os.File, exec *Execution) error {
+	data, err := json.MarshalIndent(exec, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = f.Truncate(0); err != nil {
+		return err
+	}
+	if _, err = f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if _, err = f.Write(data); err != nil {
+		return err
+	}
+	return f.Sync()
+}
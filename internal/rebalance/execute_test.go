@@ -0,0 +1,62 @@
+package rebalance
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestExecute(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rebalance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	stateFile := filepath.Join(dir, "state.json")
+
+	plan := &Plan{Moves: []*Move{{"s1", "a", "b", 10}, {"s2", "a", "c", 20}, {"s3", "b", "c", 30}}, MovedBytes: 60}
+	if err = Start(stateFile, plan); err != nil {
+		t.Fatal(err)
+	}
+	if err = Start(stateFile, plan); err == nil {
+		t.Error("Expected an error for starting an execution already saved")
+	}
+
+	// Pausing during the first move stops the execution after it
+	var migrated []string
+	exec, err := Execute(context.Background(), stateFile, func(ctx context.Context, move *Move) error {
+		migrated = append(migrated, move.Shard)
+		return Pause(stateFile)
+	})
+	if err != ErrPaused || exec.Done != 1 || !exec.Paused {
+		t.Fatalf("Expected the execution to be paused after a move, but got %v with error %v", exec, err)
+	}
+
+	// Resuming executes the remaining moves, the failed one again
+	if err = Resume(stateFile); err != nil {
+		t.Fatal(err)
+	}
+	failMove := errors.New("move failed")
+	if _, err = Execute(context.Background(), stateFile, func(ctx context.Context, move *Move) error {
+		return failMove
+	}); err == nil {
+		t.Error("Expected an error for the failed move")
+	}
+	exec, err = Execute(context.Background(), stateFile, func(ctx context.Context, move *Move) error {
+		migrated = append(migrated, move.Shard)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exec.Done != len(plan.Moves) || exec.Paused || !reflect.DeepEqual(exec.Plan, plan) {
+		t.Errorf("Expected all the moves of the plan to be executed, but got %v", exec)
+	}
+	if exp := []string{"s1", "s2", "s3"}; !reflect.DeepEqual(migrated, exp) {
+		t.Errorf("Expected the moves %v to be executed in order, but got %v", exp, migrated)
+	}
+}
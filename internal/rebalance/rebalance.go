@@ -0,0 +1,312 @@
+// Package rebalance plans the rebalancing of the shards of a database
+// across the nodes hosting them, in proportion to the capacities of the
+// nodes. Plans move as few bytes as possible, leaving the shards of the
+// nodes within the tolerated imbalance in place, so that rebalancing a
+// mostly balanced cluster only moves the shards of its outliers.
+//
+// Every replica of a shard, be it a member of its raft quorum or a slave,
+// is hosted by a node of its own, hence plans never move a replica onto a
+// node hosting another replica of the same shard. The vBuckets into which
+// the keys of a database are partitioned are left as they are, only the
+// nodes hosting their replicas change. Among the nodes equally suited for
+// a replica, the one it hashes the highest onto is chosen, as rendezvous
+// hashing does, so that the replicas of a grown cluster spread across its
+// new nodes as they would on a consistent hashing ring, rather than piling
+// onto the node with the lowest address.
+package rebalance
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sort"
+)
+
+// Shard is a replica of a shard of a database, hosted by a node. All the
+// replicas of a shard share its name.
+type Shard struct {
+	// Name of the shard, typically its vBucket.
+	Name string
+	// Address of the node currently hosting the replica.
+	Node string
+	// Size of the shard in bytes.
+	SizeBytes uint64
+}
+
+// Node is a node available for hosting the shards.
+type Node struct {
+	// Address of the node.
+	Address string
+	// Capacity of the node in bytes. Nodes without any capacity are
+	// drained of their shards.
+	CapacityBytes uint64
+}
+
+// Move is a replica of a shard to be moved from its node onto another.
+type Move struct {
+	Shard     string
+	From, To  string
+	SizeBytes uint64
+}
+
+// NodeLoad is the load of a node once the moves of a plan are executed.
+type NodeLoad struct {
+	Address       string
+	CapacityBytes uint64
+	// Bytes of the shards hosted by the node.
+	LoadBytes uint64
+	// Bytes beyond those the node is meant to host, which could not be
+	// moved elsewhere.
+	ExcessBytes uint64
+}
+
+// Plan is a rebalancing plan, moving the shards in the given order.
+type Plan struct {
+	Moves      []*Move
+	MovedBytes uint64
+	// Loads of all the nodes, ordered by their addresses.
+	Loads []*NodeLoad
+}
+
+// Balanced determines if all the nodes host at most the bytes they are
+// meant to, once the moves of the plan are executed.
+func (p *Plan) Balanced() bool {
+	for _, load := range p.Loads {
+		if load.ExcessBytes > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+type nodeState struct {
+	*NodeLoad
+	// Most bytes the node is meant to host
+	limit  uint64
+	shards []*Shard
+	// Set once none of the shards of the node can be moved
+	stuck bool
+}
+
+func (ns *nodeState) excessWith(loadBytes uint64) uint64 {
+	if loadBytes > ns.limit {
+		return loadBytes - ns.limit
+	}
+	return 0
+}
+
+// hosts determines if the node hosts a replica of the given shard.
+func (ns *nodeState) hosts(shard *Shard) bool {
+	for _, s := range ns.shards {
+		if s.Name == shard.Name {
+			return true
+		}
+	}
+	return false
+}
+
+func (ns *nodeState) utilisation() float64 {
+	return float64(ns.LoadBytes) / float64(ns.CapacityBytes)
+}
+
+type planner struct {
+	states map[string]*nodeState
+	// Addresses of the nodes in order, for deterministic plans
+	addrs []string
+	moved map[*Shard]bool
+	plan  *Plan
+}
+
+// Compute plans the moves of the given shards across the given nodes, such
+// that every node hosts its share of the total size in proportion to its
+// capacity, give or take the given fraction of its share. Nodes hosting
+// the shards but missing from the given nodes are drained, as are the
+// nodes without any capacity. Replicas of the same shard are never placed
+// on the same node. Shards are moved at most once, each move
+// relieving the most loaded node through the smallest shard that relieves
+// it the most. When no shard can be moved without overloading the other
+// nodes, the remaining excess of the nodes is reported by the plan.
+func Compute(shards []*Shard, nodes []*Node, tolerance float64) (*Plan, error) {
+	if tolerance < 0 {
+		return nil, errors.New("tolerance must not be negative")
+	}
+	p := &planner{states: make(map[string]*nodeState, len(nodes)), moved: make(map[*Shard]bool), plan: &Plan{}}
+	var totalCapacity, totalSize uint64
+	for _, node := range nodes {
+		if _, present := p.states[node.Address]; present {
+			return nil, fmt.Errorf("node %s is given more than once", node.Address)
+		}
+		p.states[node.Address] = &nodeState{NodeLoad: &NodeLoad{Address: node.Address, CapacityBytes: node.CapacityBytes}}
+		totalCapacity += node.CapacityBytes
+	}
+	for _, shard := range shards {
+		ns, present := p.states[shard.Node]
+		if !present {
+			ns = &nodeState{NodeLoad: &NodeLoad{Address: shard.Node}}
+			p.states[shard.Node] = ns
+		}
+		if ns.hosts(shard) {
+			return nil, fmt.Errorf("node %s hosts more than one replica of shard %s", shard.Node, shard.Name)
+		}
+		ns.shards = append(ns.shards, shard)
+		ns.LoadBytes += shard.SizeBytes
+		totalSize += shard.SizeBytes
+	}
+	if totalSize > totalCapacity {
+		return nil, fmt.Errorf("shards of %d bytes exceed the total capacity of %d bytes", totalSize, totalCapacity)
+	}
+
+	for addr, ns := range p.states {
+		p.addrs = append(p.addrs, addr)
+		if ns.CapacityBytes > 0 {
+			share := float64(totalSize) * float64(ns.CapacityBytes) / float64(totalCapacity) * (1 + tolerance)
+			if ns.limit = uint64(share); ns.limit > ns.CapacityBytes {
+				ns.limit = ns.CapacityBytes
+			}
+		}
+		// Smaller shards are preferred for the moves
+		sort.SliceStable(ns.shards, func(i, j int) bool { return ns.shards[i].SizeBytes < ns.shards[j].SizeBytes })
+	}
+	sort.Strings(p.addrs)
+
+	if err := p.drain(); err != nil {
+		return nil, err
+	}
+	for from := p.mostExcessive(); from != nil; from = p.mostExcessive() {
+		if !p.relieve(from) {
+			from.stuck = true
+		}
+	}
+
+	p.plan.Loads = make([]*NodeLoad, len(p.addrs))
+	for i, addr := range p.addrs {
+		ns := p.states[addr]
+		ns.ExcessBytes = ns.excessWith(ns.LoadBytes)
+		p.plan.Loads[i] = ns.NodeLoad
+	}
+	return p.plan, nil
+}
+
+// drain moves all the shards of the nodes without any capacity, the larger
+// shards first so that they find room more easily.
+func (p *planner) drain() error {
+	for _, addr := range p.addrs {
+		from := p.states[addr]
+		if from.CapacityBytes > 0 {
+			continue
+		}
+		for len(from.shards) > 0 {
+			shard := from.shards[len(from.shards)-1]
+			to := p.destination(from, shard)
+			if to == nil {
+				return fmt.Errorf("no node has room for shard %s of %d bytes drained from %s", shard.Name, shard.SizeBytes, addr)
+			}
+			p.move(from, to, shard)
+		}
+	}
+	return nil
+}
+
+// relieve moves the shard of the given node that reduces the total excess
+// of the nodes the most, preferring the smaller shards. It reports if any
+// shard could be moved.
+func (p *planner) relieve(from *nodeState) bool {
+	var best *Shard
+	var bestTo *nodeState
+	var bestRelief uint64
+	fromExcess := from.excessWith(from.LoadBytes)
+	for _, shard := range from.shards {
+		if p.moved[shard] {
+			continue
+		}
+		to := p.destination(from, shard)
+		if to == nil {
+			continue
+		}
+		relieved := fromExcess - from.excessWith(from.LoadBytes-shard.SizeBytes)
+		added := to.excessWith(to.LoadBytes+shard.SizeBytes) - to.excessWith(to.LoadBytes)
+		// Shards are ordered by size, hence the smallest one among those
+		// with the same relief is retained
+		if relieved > added && relieved-added > bestRelief {
+			best, bestTo, bestRelief = shard, to, relieved-added
+		}
+	}
+	if best == nil {
+		return false
+	}
+	p.move(from, bestTo, best)
+	return true
+}
+
+// destination finds the node onto which the given shard is to be moved,
+// being the one left with the least excess, then the least utilised and
+// then the one the shard hashes the highest onto, nil if no other node
+// without a replica of the shard has room for it.
+func (p *planner) destination(from *nodeState, shard *Shard) *nodeState {
+	var to *nodeState
+	for _, addr := range p.addrs {
+		ns := p.states[addr]
+		if ns == from || ns.CapacityBytes == 0 || ns.LoadBytes+shard.SizeBytes > ns.CapacityBytes || ns.hosts(shard) {
+			continue
+		}
+		if to == nil {
+			to = ns
+			continue
+		}
+		excess, toExcess := ns.excessWith(ns.LoadBytes+shard.SizeBytes), to.excessWith(to.LoadBytes+shard.SizeBytes)
+		if excess != toExcess {
+			if excess < toExcess {
+				to = ns
+			}
+			continue
+		}
+		if util, toUtil := ns.utilisation(), to.utilisation(); util != toUtil {
+			if util < toUtil {
+				to = ns
+			}
+			continue
+		}
+		if weight(shard, ns) > weight(shard, to) {
+			to = ns
+		}
+	}
+	return to
+}
+
+// weight is the rendezvous hash of the given shard onto the given node.
+func weight(shard *Shard, ns *nodeState) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(shard.Name))
+	h.Write([]byte{0})
+	h.Write([]byte(ns.Address))
+	return h.Sum64()
+}
+
+func (p *planner) move(from, to *nodeState, shard *Shard) {
+	for i, s := range from.shards {
+		if s == shard {
+			from.shards = append(from.shards[:i], from.shards[i+1:]...)
+			break
+		}
+	}
+	to.shards = append(to.shards, shard)
+	from.LoadBytes -= shard.SizeBytes
+	to.LoadBytes += shard.SizeBytes
+	p.moved[shard] = true
+	p.plan.Moves = append(p.plan.Moves, &Move{Shard: shard.Name, From: from.Address, To: to.Address, SizeBytes: shard.SizeBytes})
+	p.plan.MovedBytes += shard.SizeBytes
+}
+
+// mostExcessive finds the node hosting the most bytes beyond its limit,
+// among those whose shards can still be moved, nil if there is none.
+func (p *planner) mostExcessive() *nodeState {
+	var most *nodeState
+	var mostExcess uint64
+	for _, addr := range p.addrs {
+		ns := p.states[addr]
+		if excess := ns.excessWith(ns.LoadBytes); !ns.stuck && excess > mostExcess {
+			most, mostExcess = ns, excess
+		}
+	}
+	return most
+}
@@ -0,0 +1,99 @@
+package rebalance
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompute(t *testing.T) {
+	nodes := []*Node{{Address: "a", CapacityBytes: 100}, {Address: "b", CapacityBytes: 100}}
+	testCases := []struct {
+		name      string
+		shards    []*Shard
+		expMoves  []Move
+		expLoads  map[string]uint64
+		expExcess map[string]uint64
+	}{
+		{
+			name:     "Balanced within tolerance",
+			shards:   []*Shard{{"s1", "a", 30}, {"s2", "a", 20}, {"s3", "b", 25}, {"s4", "b", 25}},
+			expLoads: map[string]uint64{"a": 50, "b": 50},
+		},
+		{
+			name:     "Single move relieving the most",
+			shards:   []*Shard{{"s1", "a", 10}, {"s2", "a", 40}, {"s3", "a", 30}, {"s4", "b", 20}},
+			expMoves: []Move{{"s3", "a", "b", 30}},
+			expLoads: map[string]uint64{"a": 50, "b": 50},
+		},
+		{
+			name:     "Drained node",
+			shards:   []*Shard{{"s1", "a", 45}, {"s2", "b", 45}, {"s3", "c", 10}},
+			expMoves: []Move{{"s3", "c", "b", 10}},
+			expLoads: map[string]uint64{"a": 45, "b": 55, "c": 0},
+		},
+		{
+			name:      "Replica not moved onto another of its shard",
+			shards:    []*Shard{{"s1", "a", 10}, {"s1", "b", 10}, {"s2", "a", 45}, {"s3", "b", 5}},
+			expLoads:  map[string]uint64{"a": 55, "b": 15},
+			expExcess: map[string]uint64{"a": 17},
+		},
+		{
+			name:      "Drained replica kept apart from another of its shard",
+			shards:    []*Shard{{"s1", "a", 10}, {"s1", "c", 10}, {"s2", "b", 30}},
+			expMoves:  []Move{{"s1", "c", "b", 10}},
+			expLoads:  map[string]uint64{"a": 10, "b": 40, "c": 0},
+			expExcess: map[string]uint64{"b": 13},
+		},
+		{
+			name:      "Shard too large to be moved",
+			shards:    []*Shard{{"s1", "a", 90}},
+			expLoads:  map[string]uint64{"a": 90, "b": 0},
+			expExcess: map[string]uint64{"a": 41},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			plan, err := Compute(tc.shards, nodes, 0.1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var moves []Move
+			var movedBytes uint64
+			for _, move := range plan.Moves {
+				moves = append(moves, *move)
+				movedBytes += move.SizeBytes
+			}
+			if !reflect.DeepEqual(moves, tc.expMoves) || plan.MovedBytes != movedBytes {
+				t.Errorf("Expected moves %v, but got %v moving %d bytes", tc.expMoves, moves, plan.MovedBytes)
+			}
+			if len(plan.Loads) != len(tc.expLoads) {
+				t.Fatalf("Expected the loads of %d nodes, but got %d", len(tc.expLoads), len(plan.Loads))
+			}
+			for _, load := range plan.Loads {
+				if load.LoadBytes != tc.expLoads[load.Address] || load.ExcessBytes != tc.expExcess[load.Address] {
+					t.Errorf("Expected node %s to host %d bytes with %d in excess, but got %v", load.Address, tc.expLoads[load.Address], tc.expExcess[load.Address], load)
+				}
+			}
+			if plan.Balanced() != (len(tc.expExcess) == 0) {
+				t.Errorf("Expected the plan to be balanced only without any excess, but got %v", plan.Loads)
+			}
+		})
+	}
+}
+
+func TestComputeInvalid(t *testing.T) {
+	shards := []*Shard{{"s1", "a", 150}}
+	if _, err := Compute(shards, []*Node{{Address: "a", CapacityBytes: 100}}, 0.1); err == nil {
+		t.Error("Expected an error for the shards exceeding the total capacity")
+	}
+	if _, err := Compute(shards, []*Node{{Address: "a", CapacityBytes: 100}, {Address: "a", CapacityBytes: 100}}, 0.1); err == nil {
+		t.Error("Expected an error for the nodes given more than once")
+	}
+	if _, err := Compute([]*Shard{{"s1", "a", 10}, {"s1", "a", 10}}, []*Node{{Address: "a", CapacityBytes: 100}}, 0.1); err == nil {
+		t.Error("Expected an error for the replicas of a shard hosted by the same node")
+	}
+	if _, err := Compute(shards, []*Node{{Address: "a", CapacityBytes: 200}}, -1); err == nil {
+		t.Error("Expected an error for the negative tolerance")
+	}
+}
@@ -0,0 +1,208 @@
+package slave
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// File within the staging folder recording the checkpoint being
+	// transferred, so that its transfer is resumed after a restart.
+	stagedCheckpointFile = "checkpoint.json"
+	// Folder within the staging folder holding the checkpoint files.
+	stagedFilesFolder = "files"
+)
+
+// stagedCheckpoint identifies the checkpoint being transferred.
+type stagedCheckpoint struct {
+	CheckpointID string `json:"checkpointID"`
+	ChangeNumber uint64 `json:"changeNumber"`
+}
+
+// needsBootstrap checks if the slave is yet to apply any change and can
+// hence be bootstrapped from a checkpoint of the master, instead of
+// retrieving all the changes from the beginning.
+func (ss *slaveService) needsBootstrap() bool {
+	return ss.replInfo.replConfig.CheckpointStagingDir != "" && !ss.replInfo.bootstrapped && ss.replInfo.fromChngNum <= 1
+}
+
+// bootstrapFromCheckpoint transfers a checkpoint of the master onto the
+// staging folder and replaces the keyspace of the slave with it, after
+// which the changes following the checkpoint are replicated as usual.
+// Transfers interrupted by a failure or a restart are resumed upon the
+// next invocation, as long as the master retains the checkpoint.
+func (ss *slaveService) bootstrapFromCheckpoint() error {
+	if ss.replInfo.replCli == nil || !ss.replInfo.replActive {
+		return errors.New("can not bootstrap as unable to connect to an active master")
+	}
+	ckpt, ok := ss.store.(storage.Checkpointer)
+	if !ok {
+		ss.serveropts.Logger.Warn("Storage engine does not support checkpoints, replicating all changes instead")
+		ss.replInfo.bootstrapped = true
+		return nil
+	}
+
+	stagingDir := ss.replInfo.replConfig.CheckpointStagingDir
+	staged, err := ss.transferCheckpoint(stagingDir)
+	if status.Code(err) == codes.Unimplemented {
+		// Replicating all the changes from the beginning is left to the
+		// slaves without a staging folder
+		ss.serveropts.StatsCli.Incr("slave.bootstrap.errors", 1)
+		return fmt.Errorf("%s does not transfer checkpoints, bootstrap from the address of its master instead: %v",
+			ss.replInfo.replConfig.ReplMasterAddr, err)
+	}
+	if status.Code(err) == codes.NotFound {
+		// Master discarded the checkpoint, hence restart the transfer
+		ss.serveropts.Logger.Warn("Checkpoint is no longer available on master, discarding the staged files", zap.Error(err))
+		if err = os.RemoveAll(stagingDir); err != nil {
+			return err
+		}
+		staged, err = ss.transferCheckpoint(stagingDir)
+	}
+	if err != nil {
+		ss.serveropts.StatsCli.Incr("slave.bootstrap.errors", 1)
+		return err
+	}
+
+	ss.rwl.Lock()
+	defer ss.rwl.Unlock()
+	ss.scans.ReleaseAll()
+	appliedChngNum, err := ckpt.ApplyCheckpoint(filepath.Join(stagingDir, stagedFilesFolder))
+	if err != nil {
+		ss.serveropts.StatsCli.Incr("slave.bootstrap.errors", 1)
+		return err
+	}
+	// Checkpoint may contain the changes committed while it was created
+	ss.replInfo.fromChngNum = 1 + appliedChngNum
+	ss.replInfo.replLag, ss.replInfo.caughtUp, ss.replInfo.bootstrapped = 0, false, true
+	ss.replInfo.appliedAt = time.Now()
+	if err = os.RemoveAll(stagingDir); err != nil {
+		ss.serveropts.Logger.Warn("Unable to remove the staging folder", zap.Error(err))
+	}
+	ss.serveropts.Logger.Info("Bootstrapped from checkpoint of master", zap.String("CheckpointID", staged.CheckpointID),
+		zap.Uint64("CheckpointChangeNumber", staged.ChangeNumber), zap.Uint64("FromChangeNumber", ss.replInfo.fromChngNum))
+	return nil
+}
+
+// transferCheckpoint receives all the files of a checkpoint of the master
+// onto the given staging folder, resuming any previous transfer recorded
+// in that folder from its last received file and offset.
+func (ss *slaveService) transferCheckpoint(stagingDir string) (*stagedCheckpoint, error) {
+	filesDir := filepath.Join(stagingDir, stagedFilesFolder)
+	if err := os.MkdirAll(filesDir, 0755); err != nil {
+		return nil, err
+	}
+	staged, err := readStagedCheckpoint(stagingDir)
+	if err != nil {
+		return nil, err
+	}
+	// Files are received in the order of their names, hence only the
+	// last of them can be partially received
+	var fileName string
+	var offset uint64
+	if staged.CheckpointID != "" {
+		files, err := storage.ListCheckpointFiles(filesDir)
+		if err != nil {
+			return nil, err
+		}
+		if len(files) > 0 {
+			last := files[len(files)-1]
+			fileName, offset = last.Name, uint64(last.Size)
+		}
+	}
+
+	ss.serveropts.Logger.Info("Transferring checkpoint from master", zap.String("CheckpointID", staged.CheckpointID),
+		zap.String("FileName", fileName), zap.Uint64("Offset", offset))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := ss.replInfo.replCli.TransferCheckpoint(ctx, staged.CheckpointID, fileName, offset)
+	if err != nil {
+		return nil, err
+	}
+	var f *os.File
+	defer func() {
+		if f != nil {
+			f.Close()
+		}
+	}()
+	for {
+		chunk, err := stream.Recv()
+		switch {
+		case err == io.EOF:
+			if f != nil {
+				if err = f.Close(); err != nil {
+					return nil, err
+				}
+				f = nil
+			}
+			return staged, nil
+		case err != nil:
+			return nil, err
+		case chunk.Status != nil && chunk.Status.Code != 0:
+			return nil, errors.New(chunk.Status.Message)
+		}
+		if staged.CheckpointID == "" {
+			staged.CheckpointID, staged.ChangeNumber = chunk.CheckpointID, chunk.ChangeNumber
+			if err = writeStagedCheckpoint(stagingDir, staged); err != nil {
+				return nil, err
+			}
+		} else if chunk.CheckpointID != staged.CheckpointID {
+			return nil, fmt.Errorf("received chunk of checkpoint: %s while transferring checkpoint: %s", chunk.CheckpointID, staged.CheckpointID)
+		}
+		if f == nil || filepath.Base(f.Name()) != chunk.FileName {
+			if f != nil {
+				if err = f.Close(); err != nil {
+					return nil, err
+				}
+				f = nil
+			}
+			if chunk.FileName == "" || filepath.Base(chunk.FileName) != chunk.FileName {
+				return nil, fmt.Errorf("received invalid checkpoint file name: %q", chunk.FileName)
+			}
+			if f, err = os.OpenFile(filepath.Join(filesDir, chunk.FileName), os.O_CREATE|os.O_WRONLY, 0644); err != nil {
+				return nil, err
+			}
+		}
+		if err = f.Truncate(int64(chunk.Offset)); err != nil {
+			return nil, err
+		}
+		if _, err = f.WriteAt(chunk.Data, int64(chunk.Offset)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func readStagedCheckpoint(stagingDir string) (*stagedCheckpoint, error) {
+	staged := &stagedCheckpoint{}
+	data, err := ioutil.ReadFile(filepath.Join(stagingDir, stagedCheckpointFile))
+	switch {
+	case os.IsNotExist(err):
+		return staged, nil
+	case err != nil:
+		return nil, err
+	}
+	if err = json.Unmarshal(data, staged); err != nil {
+		return nil, fmt.Errorf("unable to parse staged checkpoint: %v", err)
+	}
+	return staged, nil
+}
+
+func writeStagedCheckpoint(stagingDir string, staged *stagedCheckpoint) error {
+	data, err := json.Marshal(staged)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(stagingDir, stagedCheckpointFile), data, 0644)
+}
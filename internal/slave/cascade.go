@@ -0,0 +1,183 @@
+package slave
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/internal/changestream"
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/dkv/pkg/ctl"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Maximum duration for which GetChanges waits for the changes to be applied.
+const maxChangesWait = 10 * time.Second
+
+var errChangePropagationNotSupported = errors.New("storage engine does not support change propagation")
+
+// Slaves serve the changes they applied to downstream slaves, thereby
+// relieving the master from replicating to every slave directly when
+// fanning out to many slaves or to remote datacenters. Since a slave
+// applies the changes of its master with the same change numbers, the
+// downstream slaves can switch between the master and any slave
+// replicating from it, as long as the latter retains the changes.
+
+// GetChanges retrieves the changes applied by this slave from the requested
+// change number. When there are none yet, the request is held for upto the
+// requested wait time for the changes to be applied.
+func (ss *slaveService) GetChanges(ctx context.Context, getChngsReq *serverpb.GetChangesRequest) (*serverpb.GetChangesResponse, error) {
+	if ms := ss.promotedMaster(); ms != nil {
+		return ms.GetChanges(ctx, getChngsReq)
+	}
+	maxWait := time.Duration(getChngsReq.MaxWaitMillis) * time.Millisecond
+	cn, ok := ss.store.(storage.ChangeNotifier)
+	if maxWait <= 0 || !ok {
+		return ss.getChanges(getChngsReq)
+	}
+	if maxWait > maxChangesWait {
+		maxWait = maxChangesWait
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, maxWait)
+	defer cancel()
+	for {
+		// Subscribing before loading the changes ensures that no change
+		// applied following the load goes unnoticed
+		applied := cn.ChangesCommitted()
+		res, err := ss.getChanges(getChngsReq)
+		if err != nil || res.NumberOfChanges > 0 {
+			return res, err
+		}
+		select {
+		case <-applied:
+		case <-ctx.Done():
+			return res, nil
+		}
+	}
+}
+
+func (ss *slaveService) getChanges(getChngsReq *serverpb.GetChangesRequest) (*serverpb.GetChangesResponse, error) {
+	cp, ok := ss.store.(storage.ChangePropagator)
+	if !ok {
+		return &serverpb.GetChangesResponse{Status: newErrorStatus(errChangePropagationNotSupported)}, errChangePropagationNotSupported
+	}
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	latestChngNum, _ := cp.GetLatestCommittedChangeNumber()
+	// Changes are served with the highest fencing epoch observed by this
+	// slave, which is that of the master from which they are applied
+	epoch := ss.fencing.Current()
+	res := &serverpb.GetChangesResponse{Status: newEmptyStatus(), MasterChangeNumber: latestChngNum, FencingEpoch: epoch}
+	if getChngsReq.FromChangeNumber > latestChngNum {
+		return res, nil
+	}
+
+	chngs, err := cp.LoadChanges(getChngsReq.FromChangeNumber, int(getChngsReq.MaxNumberOfChanges))
+	if err == nil {
+		err = ctl.EncodeChangeRecords(chngs, getChngsReq.RecordCompression, getChngsReq.DeltaEncodeKeys)
+	}
+	if err != nil {
+		ss.serveropts.Logger.Error("Unable to load changes", zap.Error(err))
+		res.Status = newErrorStatus(err)
+		if errors.Is(err, storage.ErrChangesNotAvailable) {
+			// Slaves recognise this code to bootstrap afresh
+			err = status.Error(codes.OutOfRange, err.Error())
+		}
+	} else {
+		for _, chng := range chngs {
+			chng.FencingEpoch = epoch
+		}
+		res.NumberOfChanges = uint32(len(chngs))
+		res.Changes = chngs
+	}
+	return res, err
+}
+
+// StreamChanges streams the changes applied by this slave from the requested
+// change number as they are applied, batching them as per the targets of
+// the request.
+func (ss *slaveService) StreamChanges(getChngsReq *serverpb.GetChangesRequest, stream serverpb.DKVReplication_StreamChangesServer) error {
+	if ms := ss.promotedMaster(); ms != nil {
+		return ms.StreamChanges(getChngsReq, stream)
+	}
+	if _, ok := ss.store.(storage.ChangePropagator); !ok {
+		return errChangePropagationNotSupported
+	}
+	_, notified := ss.store.(storage.ChangeNotifier)
+	// Downstream slaves are not tracked, hence their streams are not
+	// flow controlled
+	return changestream.Serve(stream.Context(), getChngsReq, ss.GetChanges, notified, nil, stream.Send)
+}
+
+// TransferCheckpoint relays the transfer of a checkpoint of the master of
+// this slave, so that the downstream slaves bootstrap from a checkpoint of
+// the master, with whose change numbers they replicate, rather than from
+// the beginning. Downstream slaves then apply the changes beyond the
+// checkpoint once this slave applies them.
+func (ss *slaveService) TransferCheckpoint(transferReq *serverpb.TransferCheckpointRequest, stream serverpb.DKVReplication_TransferCheckpointServer) error {
+	if ms := ss.promotedMaster(); ms != nil {
+		return ms.TransferCheckpoint(transferReq, stream)
+	}
+	replCli := ss.replInfo.replCli
+	if replCli == nil || !ss.replInfo.replActive {
+		return status.Error(codes.Unavailable, "DKV slave is unable to connect to an active master to transfer the checkpoint from")
+	}
+	upstream, err := replCli.TransferCheckpoint(stream.Context(), transferReq.CheckpointID, transferReq.FileName, transferReq.Offset)
+	if err != nil {
+		return err
+	}
+	for {
+		chunk, err := upstream.Recv()
+		switch {
+		case err == io.EOF:
+			return nil
+		case err != nil:
+			return err
+		}
+		if err = stream.Send(chunk); err != nil {
+			return err
+		}
+	}
+}
+
+func (ss *slaveService) AddReplica(ctx context.Context, req *serverpb.Replica) (*serverpb.Status, error) {
+	if ms := ss.promotedMaster(); ms != nil {
+		return ms.AddReplica(ctx, req)
+	}
+	return nil, errors.New("DKV slave service does not support registering replicas")
+}
+
+func (ss *slaveService) RemoveReplica(ctx context.Context, req *serverpb.Replica) (*serverpb.Status, error) {
+	if ms := ss.promotedMaster(); ms != nil {
+		return ms.RemoveReplica(ctx, req)
+	}
+	return nil, errors.New("DKV slave service does not support deregistering replicas")
+}
+
+func (ss *slaveService) GetReplicas(ctx context.Context, req *serverpb.GetReplicasRequest) (*serverpb.GetReplicasResponse, error) {
+	if ms := ss.promotedMaster(); ms != nil {
+		return ms.GetReplicas(ctx, req)
+	}
+	return nil, errors.New("DKV slave service does not support retrieving replicas")
+}
+
+func (ss *slaveService) ReportReplicationLag(ctx context.Context, req *serverpb.ReplicationLagReport) (*serverpb.Status, error) {
+	if ms := ss.promotedMaster(); ms != nil {
+		return ms.ReportReplicationLag(ctx, req)
+	}
+	return nil, status.Error(codes.Unimplemented, "DKV slave service does not track the lag of downstream slaves")
+}
+
+func (ss *slaveService) GetReplicationLag(ctx context.Context, req *emptypb.Empty) (*serverpb.GetReplicationLagResponse, error) {
+	if ms := ss.promotedMaster(); ms != nil {
+		return ms.GetReplicationLag(ctx, req)
+	}
+	return nil, status.Error(codes.Unimplemented, "DKV slave service does not track the lag of downstream slaves")
+}
@@ -0,0 +1,119 @@
+package slave
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// Number of key ranges, each starting with a distinct leading byte,
+	// that are sampled for computing the checksum of a consistency tag
+	numSampledRanges = 16
+	// Number of keys read from each of the sampled key ranges
+	numKeysPerSampledRange = 64
+)
+
+// recordConsistencyTag records the consistency tag of this replica
+// after it caught up with its master upto the given change number.
+// The tag is persisted, if configured, and exposed via GetStatus.
+func (ss *slaveService) recordConsistencyTag(chngNum uint64) error {
+	checksum, err := sampledRangesChecksum(ss.store)
+	if err != nil {
+		return err
+	}
+	tag := &serverpb.ConsistencyTag{
+		SourceNode:   ss.replInfo.replConfig.ReplMasterAddr,
+		ChangeNumber: chngNum,
+		Checksum:     checksum,
+		Timestamp:    uint64(time.Now().Unix()),
+	}
+	if ti := ss.serveropts.TokenIssuer; ti != nil {
+		tag.Signature = ti.Sign(consistencyTagPayload(tag))
+	}
+	if tagFile := ss.replInfo.replConfig.ConsistencyTagFile; tagFile != "" {
+		if err = writeConsistencyTag(tagFile, tag); err != nil {
+			return err
+		}
+	}
+	ss.regionInfo.ConsistencyTag = tag
+	ss.serveropts.Logger.Info("Recorded consistency tag", zap.String("SourceNode", tag.SourceNode),
+		zap.Uint64("ChangeNumber", tag.ChangeNumber), zap.String("Checksum", tag.Checksum))
+	return nil
+}
+
+// consistencyTagPayload is the payload of the given consistency tag
+// that is signed, made up of all the fields except the signature.
+func consistencyTagPayload(tag *serverpb.ConsistencyTag) []byte {
+	return []byte(fmt.Sprintf("%s|%d|%s|%d", tag.SourceNode, tag.ChangeNumber, tag.Checksum, tag.Timestamp))
+}
+
+// sampledRangesChecksum computes the checksum of the leading keys of
+// every sampled key range, along with their values.
+func sampledRangesChecksum(store storage.KVStore) (string, error) {
+	hash, lenBuf := sha256.New(), make([]byte, 4)
+	rangeWidth := 256 / numSampledRanges
+	for i := 0; i < numSampledRanges; i++ {
+		startKey := []byte{byte(i * rangeWidth)}
+		iterOpts, err := storage.NewIteratorOptions(storage.IterationStartKey(startKey))
+		if err != nil {
+			return "", err
+		}
+		iter := store.Iterate(iterOpts)
+		for n := 0; n < numKeysPerSampledRange && iter.HasNext(); n++ {
+			kv := iter.Next()
+			if i < numSampledRanges-1 && len(kv.Key) > 0 && int(kv.Key[0]) >= (i+1)*rangeWidth {
+				break
+			}
+			for _, data := range [][]byte{kv.Key, kv.Value} {
+				binary.BigEndian.PutUint32(lenBuf, uint32(len(data)))
+				hash.Write(lenBuf)
+				hash.Write(data)
+			}
+		}
+		err = iter.Err()
+		iter.Close()
+		if err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func writeConsistencyTag(tagFile string, tag *serverpb.ConsistencyTag) error {
+	data, err := protojson.Marshal(tag)
+	if err != nil {
+		return err
+	}
+	tmpFile := tagFile + ".tmp"
+	if err = ioutil.WriteFile(tmpFile, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile, tagFile)
+}
+
+// readConsistencyTag loads the consistency tag persisted in the given
+// file. Returns nil if no consistency tag has been persisted yet.
+func readConsistencyTag(tagFile string) (*serverpb.ConsistencyTag, error) {
+	data, err := ioutil.ReadFile(tagFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	tag := &serverpb.ConsistencyTag{}
+	if err = protojson.Unmarshal(data, tag); err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
@@ -0,0 +1,126 @@
+package slave
+
+import (
+	"context"
+	"errors"
+
+	"github.com/flipkart-incubator/dkv/internal/master"
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"go.uber.org/zap"
+)
+
+var (
+	errPromotionNotSupported = errors.New("Current storage engine does not support change propagation, hence the slave can not be promoted")
+	errRestoreAfterPromotion = errors.New("Promoted slave must be restarted as a master before being restored")
+	errPartialReplica        = errors.New("Slave replicating only some of the key prefixes can not be promoted")
+	errMasterNotConnected    = errors.New("Slave is not connected to a master to be fenced")
+)
+
+// promotedMaster retrieves the master service serving the promoted slave,
+// nil until the slave is promoted.
+func (ss *slaveService) promotedMaster() master.DKVService {
+	ms, _ := ss.promoted.Load().(master.DKVService)
+	return ms
+}
+
+// Promote promotes the slave to a master for manual failovers, without
+// restarting it. Replication from the current master is stopped, after
+// which the writes, the reads and the changes are served by a master
+// service over the local storage, until the slave is restarted as a
+// master. Unless skipped, a new fencing epoch is issued beforehand, so that
+// the changes of the superseded master are rejected by the slaves that
+// replicate from the promoted slave. Promoting a slave again issues a new
+// fencing epoch as on any master. When requested, the epoch is pushed onto
+// the master before the replication stops, after which it rejects the
+// writes, redirecting them to the promoted slave. Otherwise the superseded
+// master keeps accepting writes, so it must be stopped or isolated
+// separately, while the other slaves are fenced once they observe the
+// epoch upon being repointed to the promoted slave. Slaves replicating
+// only some of the key prefixes can not be promoted.
+func (ss *slaveService) Promote(ctx context.Context, promoteReq *serverpb.PromoteRequest) (*serverpb.PromoteResponse, error) {
+	ss.promoteMu.Lock()
+	defer ss.promoteMu.Unlock()
+	if ms := ss.promotedMaster(); ms != nil {
+		return ms.Promote(ctx, promoteReq)
+	}
+
+	if ss.replInfo.replConfig.PartialReplication {
+		return &serverpb.PromoteResponse{Status: newErrorStatus(errPartialReplica)}, errPartialReplica
+	}
+	ss.rwl.RLock()
+	_, ok := ss.store.(storage.ChangePropagator)
+	ss.rwl.RUnlock()
+	if !ok {
+		return &serverpb.PromoteResponse{Status: newErrorStatus(errPromotionNotSupported)}, errPromotionNotSupported
+	}
+	epoch := ss.fencing.Current()
+	if !promoteReq.SkipFencing {
+		var err error
+		if epoch, err = ss.fencing.Advance(); err != nil {
+			ss.serveropts.Logger.Error("Unable to issue a new fencing epoch", zap.Error(err))
+			return &serverpb.PromoteResponse{Status: newErrorStatus(err)}, err
+		}
+		if promoteReq.FenceMaster {
+			if err := ss.fenceMaster(epoch); err != nil {
+				ss.serveropts.Logger.Error("Unable to fence the master", zap.Uint64("FencingEpoch", epoch),
+					zap.String("MasterAddress", ss.replInfo.replConfig.ReplMasterAddr), zap.Error(err))
+				return &serverpb.PromoteResponse{Status: newErrorStatus(err)}, err
+			}
+		}
+	}
+
+	// Changes being applied are completed before the replication stops
+	ss.stopReplication()
+	ss.rwl.Lock()
+	defer ss.rwl.Unlock()
+	cp := ss.store.(storage.ChangePropagator)
+	chngNum, err := ss.ca.GetLatestAppliedChangeNumber()
+	if err != nil {
+		ss.serveropts.Logger.Error("Unable to load the latest applied change number", zap.Error(err))
+	}
+	mopts := *ss.serveropts
+	mopts.FencingEpoch = ss.fencing
+	br, _ := ss.store.(storage.Backupable)
+	ss.promoted.Store(master.NewStandaloneService(ss.store, cp, br, ss.regionInfo, &mopts))
+
+	ss.serveropts.Logger.Info("Promoted slave to master", zap.Uint64("FencingEpoch", epoch), zap.Uint64("ChangeNumber", chngNum),
+		zap.String("PreviousMasterAddress", ss.replInfo.replConfig.ReplMasterAddr), zap.Bool("SkipFencing", promoteReq.SkipFencing), zap.Bool("FenceMaster", promoteReq.FenceMaster))
+	ss.serveropts.StatsCli.Incr("replication.promoted", 1)
+	return &serverpb.PromoteResponse{Status: newEmptyStatus(), FencingEpoch: epoch, ChangeNumber: chngNum}, nil
+}
+
+// fenceMaster pushes the given fencing epoch onto the master from which
+// the slave replicates, so that the master rejects the writes thereafter.
+func (ss *slaveService) fenceMaster(epoch uint64) error {
+	if ss.replInfo.replCli == nil {
+		return errMasterNotConnected
+	}
+	return ss.replInfo.replCli.Fence(epoch, ss.regionInfo.GetNodeAddress())
+}
+
+// Fence is served by the master service once the slave is promoted.
+// Otherwise the slave only observes the given fencing epoch, rejecting
+// the changes of its master if they carry a lower epoch.
+func (ss *slaveService) Fence(ctx context.Context, fenceReq *serverpb.FenceRequest) (*serverpb.Status, error) {
+	if ms := ss.promotedMaster(); ms != nil {
+		return ms.Fence(ctx, fenceReq)
+	}
+	if err := ss.fencing.Observe(fenceReq.FencingEpoch); err != nil {
+		return newErrorStatus(err), err
+	}
+	return newEmptyStatus(), nil
+}
+
+// stopReplication stops retrieving the changes from the master, awaiting
+// the changes being applied, along with the comparisons with the master.
+func (ss *slaveService) stopReplication() {
+	ss.markReplicationStopping()
+	ss.closeChangeStream()
+	ss.replInfo.replStop <- struct{}{}
+	ss.replInfo.replTckr.Stop()
+	ss.stopReplicaScrubber()
+	ss.scrubber = nil
+	ss.stopConsistencyVerifier()
+	ss.verifier = nil
+}
@@ -0,0 +1,15 @@
+package slave
+
+import (
+	"github.com/flipkart-incubator/dkv/internal/master"
+)
+
+const readOnlyMessage = "DKV slave service does not support keyspace mutations, write to its master instead"
+
+// readOnlyError rejects the keyspace mutations sent to the slave, with the
+// FailedPrecondition code detailed by the REPLICA_READ_ONLY status, which
+// carries the address of the current master so that the clients can
+// redirect their writes to it.
+func (ss *slaveService) readOnlyError() error {
+	return master.ReadOnlyError(readOnlyMessage, ss.replInfo.replConfig.ReplMasterAddr)
+}
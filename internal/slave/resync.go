@@ -0,0 +1,113 @@
+package slave
+
+import (
+	"errors"
+
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// resyncState is the state of the recovery of a slave that has fallen
+// behind the changes retained by its master. Such a slave can no longer
+// replicate the changes and is hence bootstrapped afresh from a checkpoint
+// of its master, after which it replicates the changes following it.
+type resyncState int32
+
+const (
+	// resyncNone is the state of a slave replicating the changes as usual.
+	resyncNone resyncState = iota
+	// resyncPending is the state of a slave whose changes to be retrieved
+	// are no longer retained, awaiting its bootstrap upon the next poll.
+	resyncPending
+	// resyncBootstrapping is the state of a slave being bootstrapped from
+	// a checkpoint of its master. Failed bootstraps are pending again.
+	resyncBootstrapping
+	// resyncUnavailable is the state of a slave that can not be bootstrapped
+	// afresh, either since checkpoint staging is not configured or since its
+	// storage engine or its master does not support checkpoints. Such slaves
+	// must be rebuilt manually.
+	resyncUnavailable
+)
+
+func (rs resyncState) String() string {
+	switch rs {
+	case resyncNone:
+		return "none"
+	case resyncPending:
+		return "pending"
+	case resyncBootstrapping:
+		return "bootstrapping"
+	case resyncUnavailable:
+		return "unavailable"
+	default:
+		return "unknown"
+	}
+}
+
+var errResyncUnavailable = errors.New("slave can not be bootstrapped afresh from a checkpoint of master, " +
+	"either checkpoint staging is not configured or checkpoints are not supported")
+
+// isChangesNotAvailable checks if the given error indicates that the changes
+// to be retrieved from the master are no longer retained by it.
+func isChangesNotAvailable(err error) bool {
+	return errors.Is(err, storage.ErrChangesNotAvailable) || status.Code(err) == codes.OutOfRange
+}
+
+func (ss *slaveService) setResyncState(state resyncState) {
+	if prev := ss.replInfo.resync; prev != state {
+		ss.serveropts.Logger.Info("Resync state changed", zap.Stringer("From", prev), zap.Stringer("To", state))
+	}
+	ss.replInfo.resync = state
+	ss.serveropts.StatsCli.Gauge("replication.resync.state", int64(state))
+}
+
+// needsResync checks if the slave has fallen behind the changes retained
+// by its master and is due to be bootstrapped afresh.
+func (ss *slaveService) needsResync() bool {
+	return ss.replInfo.resync == resyncPending
+}
+
+// beginResync marks the slave to be bootstrapped afresh upon the next poll,
+// given the error of retrieving the changes no longer retained by the master.
+func (ss *slaveService) beginResync(err error) {
+	if ss.replInfo.resync == resyncUnavailable {
+		ss.serveropts.Logger.Error("Changes are no longer retained by master, slave must be rebuilt", zap.Error(err),
+			zap.Uint64("FromChangeNumber", ss.replInfo.fromChngNum), zap.String("MasterAddress", ss.replInfo.replConfig.ReplMasterAddr))
+		return
+	}
+	ss.serveropts.Logger.Error("Changes are no longer retained by master, bootstrapping afresh", zap.Error(err),
+		zap.Uint64("FromChangeNumber", ss.replInfo.fromChngNum), zap.String("MasterAddress", ss.replInfo.replConfig.ReplMasterAddr))
+	ss.serveropts.StatsCli.Incr("replication.resync.triggered", 1)
+	ss.setResyncState(resyncPending)
+}
+
+// resyncFromCheckpoint bootstraps the slave afresh from a checkpoint of
+// its master, replacing its keyspace. The checkpoint must succeed the
+// changes no longer retained by the master, failing which the slave can
+// not be recovered automatically.
+func (ss *slaveService) resyncFromCheckpoint() error {
+	if _, ok := ss.store.(storage.Checkpointer); !ok || ss.replInfo.replConfig.CheckpointStagingDir == "" {
+		ss.setResyncState(resyncUnavailable)
+		return errResyncUnavailable
+	}
+	ss.setResyncState(resyncBootstrapping)
+	fromChngNum := ss.replInfo.fromChngNum
+	ss.replInfo.bootstrapped = false
+	if err := ss.bootstrapFromCheckpoint(); err != nil {
+		ss.serveropts.StatsCli.Incr("replication.resync.errors", 1)
+		ss.setResyncState(resyncPending)
+		return err
+	}
+	// Masters that do not transfer checkpoints leave the slave as is
+	if ss.replInfo.fromChngNum <= fromChngNum {
+		ss.setResyncState(resyncUnavailable)
+		return errResyncUnavailable
+	}
+	ss.serveropts.Logger.Info("Resynced from checkpoint of master", zap.Uint64("PreviousFromChangeNumber", fromChngNum),
+		zap.Uint64("FromChangeNumber", ss.replInfo.fromChngNum))
+	ss.serveropts.StatsCli.Incr("replication.resync.completed", 1)
+	ss.setResyncState(resyncNone)
+	return nil
+}
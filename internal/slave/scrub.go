@@ -0,0 +1,165 @@
+package slave
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"go.uber.org/zap"
+)
+
+// scrubSampleSize is the number of keys compared with the master
+// every scrub interval.
+const scrubSampleSize = 100
+
+type replicaScrubber struct {
+	ss     *slaveService
+	cursor []byte
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+// startReplicaScrubber periodically compares successive ranges of the
+// keyspace of this slave with those of its master, so that divergence
+// that went unnoticed during replication is eventually reported.
+func (ss *slaveService) startReplicaScrubber() {
+	interval := ss.replInfo.replConfig.ScrubCompareInterval
+	if interval <= 0 {
+		return
+	}
+	rs := &replicaScrubber{ss: ss, stop: make(chan struct{}), done: make(chan struct{})}
+	ss.scrubber = rs
+	go func() {
+		defer close(rs.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				rs.compare()
+			case <-rs.stop:
+				return
+			}
+		}
+	}()
+}
+
+func (ss *slaveService) stopReplicaScrubber() {
+	if rs := ss.scrubber; rs != nil {
+		close(rs.stop)
+		<-rs.done
+	}
+}
+
+// compare compares the next sampled range of keys with the master.
+// Ranges are only compared when the slave has caught up, while the
+// differing keys are read again from both sides before being reported,
+// so that the changes made in the meantime are not mistaken for
+// divergence.
+func (rs *replicaScrubber) compare() {
+	ss := rs.ss
+	replCli := ss.replInfo.replCli
+	if replCli == nil || !ss.replInfo.replActive || ss.replInfo.replLag > 0 {
+		return
+	}
+	masterKVs, token, err := replCli.Scan(nil, rs.cursor, scrubSampleSize, "")
+	if err != nil {
+		ss.serveropts.Logger.Warn("Unable to sample keys from master for scrubbing", zap.Error(err))
+		return
+	}
+	// Only the first page of the scan is sampled
+	if token != "" {
+		defer replCli.CloseScan(token)
+	}
+	var endKey []byte
+	if len(masterKVs) == scrubSampleSize {
+		endKey = masterKVs[len(masterKVs)-1].Key
+	}
+
+	ss.rwl.RLock()
+	localKVs, err := rs.localRange(endKey)
+	ss.rwl.RUnlock()
+	if err != nil {
+		ss.serveropts.Logger.Warn("Unable to sample local keys for scrubbing", zap.Error(err))
+		return
+	}
+
+	masterVals := make(map[string][]byte, len(masterKVs))
+	for _, kv := range masterKVs {
+		masterVals[string(kv.Key)] = kv.Value
+	}
+	var suspects [][]byte
+	for _, kv := range localKVs {
+		if masterVal, present := masterVals[string(kv.Key)]; !present || !bytes.Equal(masterVal, kv.Value) {
+			suspects = append(suspects, kv.Key)
+		}
+		delete(masterVals, string(kv.Key))
+	}
+	for key := range masterVals {
+		suspects = append(suspects, []byte(key))
+	}
+	if len(suspects) > 0 {
+		rs.recheck(suspects)
+	}
+
+	if endKey == nil {
+		rs.cursor = nil
+	} else {
+		rs.cursor = append(append([]byte(nil), endKey...), 0)
+	}
+}
+
+// localRange retrieves the local keys from the cursor upto the given
+// end key, or upto the end of the keyspace when not given.
+func (rs *replicaScrubber) localRange(endKey []byte) ([]*serverpb.KVPair, error) {
+	itOpts, err := storage.NewIteratorOptions(storage.IterationStartKey(rs.cursor))
+	if err != nil {
+		return nil, err
+	}
+	it := rs.ss.store.Iterate(itOpts)
+	defer it.Close()
+	var kvs []*serverpb.KVPair
+	for it.HasNext() {
+		kv := it.Next()
+		if endKey != nil && bytes.Compare(kv.Key, endKey) > 0 {
+			break
+		}
+		if !storage.IsReservedKey(kv.Key) {
+			kvs = append(kvs, kv)
+		}
+	}
+	return kvs, it.Err()
+}
+
+func (rs *replicaScrubber) recheck(keys [][]byte) {
+	ss := rs.ss
+	masterKVs, err := ss.replInfo.replCli.MultiGet(serverpb.ReadConsistency_SEQUENTIAL, keys...)
+	if err != nil {
+		ss.serveropts.Logger.Warn("Unable to recheck the sampled keys with master", zap.Error(err))
+		return
+	}
+	ss.rwl.RLock()
+	localKVs, err := ss.store.Get(keys...)
+	ss.rwl.RUnlock()
+	if err != nil {
+		ss.serveropts.Logger.Warn("Unable to recheck the sampled local keys", zap.Error(err))
+		return
+	}
+
+	masterVals := make(map[string][]byte, len(masterKVs))
+	for _, kv := range masterKVs {
+		masterVals[string(kv.Key)] = kv.Value
+	}
+	localVals := make(map[string][]byte, len(localKVs))
+	for _, kv := range localKVs {
+		localVals[string(kv.Key)] = kv.Value
+	}
+	for _, key := range keys {
+		if !bytes.Equal(masterVals[string(key)], localVals[string(key)]) {
+			ss.serveropts.Logger.Error("Scrubber found key diverging from master", zap.ByteString("Key", key),
+				zap.String("MasterAddress", ss.replInfo.replConfig.ReplMasterAddr))
+			ss.serveropts.StatsCli.Incr("replication.scrub.mismatches", 1)
+		}
+	}
+}
@@ -7,17 +7,25 @@ import (
 	"io"
 	"math/rand"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/flipkart-incubator/dkv/pkg/health"
 
+	"github.com/flipkart-incubator/dkv/internal/audit"
+	"github.com/flipkart-incubator/dkv/internal/auth"
 	"github.com/flipkart-incubator/dkv/internal/discovery"
+	"github.com/flipkart-incubator/dkv/internal/heatmap"
 	"github.com/flipkart-incubator/dkv/internal/hlc"
 	opts "github.com/flipkart-incubator/dkv/internal/opts"
+	"github.com/flipkart-incubator/dkv/internal/pinning"
 	"github.com/flipkart-incubator/dkv/internal/storage"
 	"github.com/flipkart-incubator/dkv/pkg/ctl"
 	"github.com/flipkart-incubator/dkv/pkg/serverpb"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -26,6 +34,9 @@ type DKVService interface {
 	io.Closer
 	serverpb.DKVServer
 	serverpb.DKVDiscoveryNodeServer
+	serverpb.DKVAdminServer
+	serverpb.DKVBackupRestoreServer
+	serverpb.DKVReplicationServer
 	health.HealthServer
 }
 
@@ -44,6 +55,78 @@ type ReplicationConfig struct {
 	// Temporary flag to disable automatic master discovery until https://github.com/flipkart-incubator/dkv/issues/82 is fixed
 	// The above issue causes replication issues during master switch due to inconsistent change numbers
 	DisableAutoMasterDisc bool
+	// File in which the consistency tag of the slave is persisted once it
+	// catches up with its master. The tag is only held in memory when empty
+	ConsistencyTagFile string
+	// Maximum duration for which reads wait for their minimum change number
+	// to be replicated, before they are redirected to the master
+	MaxMinChangeWait time.Duration
+	// Interval at which a sampled range of keys is compared with the
+	// master for detecting divergence, zero disables the comparison
+	ScrubCompareInterval time.Duration
+	// Folder in which a checkpoint of the master is staged for
+	// bootstrapping the slave when it is yet to apply any change. It
+	// must reside on the same filesystem as the DB folder, but outside of
+	// it since the latter is replaced by the checkpoint. Slaves
+	// replicate all the changes from the beginning when empty
+	CheckpointStagingDir string
+	// Retrieves the changes as soon as they are committed on the master,
+	// instead of polling for them. Such slaves can be designated as the
+	// semi-sync replicas of their master, which awaits their receipt of
+	// the changes before acknowledging the writes
+	SemiSync bool
+	// ID with which the slave identifies itself to its master, such as
+	// when reporting its progress. Defaults to the address of the node
+	ReplicaID string
+	// Indicates if only some of the key prefixes are replicated, in which
+	// case the slave can not be promoted since it lacks the other keys
+	PartialReplication bool
+	// Compressor with which the changes are retrieved from the master,
+	// which is worthwhile across datacenters. Empty disables the compression
+	Compression string
+	// Retrieves the changes over a single long lived stream from the master,
+	// batched as per WANBatchBytes and WANBatchDelay, instead of polling for
+	// them. Suits the slaves replicating across datacenters
+	WANReplication bool
+	// Size of the changes upon which the master sends a batch to the slave,
+	// zero leaves it bounded only by MaxNumChngs
+	WANBatchBytes uint32
+	// Duration for which the master holds on to the changes for batching
+	// them, trading replication latency for fewer and larger batches
+	WANBatchDelay time.Duration
+	// Size of the changes that the master sends ahead of the
+	// acknowledgements of the slave, zero leaves it unbounded
+	WANMaxInFlightBytes uint32
+	// Maximum number of changes applied at a time, so that the reads are
+	// interleaved with the apply of large batches. Zero applies all the
+	// retrieved changes at once
+	ApplyBatchSize uint32
+	// Number of batches of changes retrieved from the master ahead of
+	// their apply, each while the preceding one is applied, when catching
+	// up. Zero alternates between retrieving and applying the changes
+	ApplyPrefetch uint32
+	// Maximum number of changes applied per second, so that catching up
+	// after a downtime does not saturate the disk of the slave at the
+	// expense of its reads. Zero leaves it unbounded
+	MaxApplyRate uint32
+	// Retries the Gets of the keys missing on the slave, or awaiting the
+	// changes yet to be applied, on the master. Pinned reads are not served
+	// when enabled
+	ReadFallback bool
+	// Interval at which the digests of successive ranges of the keyspace
+	// are verified against those of the master, zero disables the
+	// verification
+	VerifyInterval time.Duration
+	// Number of keys of every range verified against the master, defaults
+	// to 1000 when zero
+	VerifyKeysPerRange uint32
+	// Compressor with which every change record is compressed individually
+	// by the master, leaving the records that do not shrink as is. Empty
+	// disables the compression of the records
+	RecordCompression string
+	// Omits from the keys of the changes the prefixes they share with the
+	// preceding keys of the same change record
+	DeltaEncodeKeys bool
 }
 
 type replInfo struct {
@@ -51,13 +134,32 @@ type replInfo struct {
 	replCli *ctl.DKVClient
 	// replActive can be used to avoid setting replCli to nil during master reelection
 	// which would otherwise require additional locks to prevent crashes due to intermediate null switches
-	replActive   bool
-	replTckr     *time.Ticker
-	replStop     chan struct{}
+	replActive bool
+	replTckr   *time.Ticker
+	replStop   chan struct{}
+	// closed once the replication is being stopped, interrupting the
+	// pacing of the apply
+	applyStop    chan struct{}
 	replLag      uint64
 	lastReplTime uint64
 	replConfig   *ReplicationConfig
 	fromChngNum  uint64
+	// indicates if the slave caught up with its master since it started
+	caughtUp bool
+	// indicates if the slave is bootstrapped from a checkpoint of its master
+	bootstrapped bool
+	// state of the recovery from falling behind the changes retained by the master
+	resync resyncState
+	// time at which any change was last applied
+	appliedAt time.Time
+	// time at which the progress was last reported to the master
+	lastLagReport time.Time
+	// stream of changes from the master, used only for WAN replication
+	changeStream *changeStream
+	// guards the stream of changes against being replaced while closing
+	streamMu sync.Mutex
+	// paces the apply of the changes to the configured rate
+	applyThrottle applyThrottle
 }
 
 type slaveService struct {
@@ -68,6 +170,19 @@ type slaveService struct {
 	isClosed    bool
 	replInfo    *replInfo
 	serveropts  *opts.ServerOpts
+	scans       *storage.ScanCursors
+	engStats    *storage.EngineStatsReporter
+	fencing     *storage.FencingEpoch
+	scrubber    *replicaScrubber
+	verifier    *consistencyVerifier
+	renames     *storage.PrefixRenames
+	// guards the store against being replaced during a restore
+	rwl sync.RWMutex
+	// master service serving the slave once promoted, see Promote
+	promoted  atomic.Value
+	promoteMu sync.Mutex
+	// set once the replication is being stopped for the promotion
+	replStopping uint32
 }
 
 // NewService creates a slave DKVService that periodically polls
@@ -86,37 +201,240 @@ func NewService(store storage.KVStore, ca storage.ChangeApplier, regionInfo *ser
 func newSlaveService(store storage.KVStore, ca storage.ChangeApplier, info *serverpb.RegionInfo,
 	replConf *ReplicationConfig, clusterInfo discovery.ClusterInfoGetter, serveropts *opts.ServerOpts) *slaveService {
 	ri := &replInfo{replConfig: replConf}
-	ss := &slaveService{store: store, ca: ca, regionInfo: info, replInfo: ri, clusterInfo: clusterInfo, serveropts: serveropts}
+	ss := &slaveService{store: store, ca: ca, regionInfo: info, replInfo: ri, clusterInfo: clusterInfo,
+		serveropts: serveropts, scans: storage.NewScanCursors(serveropts.ScanCursorTTL), fencing: serveropts.FencingEpoch}
+	if ss.fencing == nil {
+		ss.fencing, _ = storage.OpenFencingEpoch("")
+	}
+	rs, _ := store.(storage.RecordStore)
+	var err error
+	if ss.renames, err = storage.OpenPrefixRenames(rs); err != nil {
+		serveropts.Logger.Error("Unable to load the prefix renames", zap.Error(err))
+		ss.renames, _ = storage.OpenPrefixRenames(nil)
+	}
+	if tagFile := replConf.ConsistencyTagFile; tagFile != "" {
+		if tag, err := readConsistencyTag(tagFile); err != nil {
+			serveropts.Logger.Warn("Unable to load the persisted consistency tag", zap.Error(err))
+		} else {
+			info.ConsistencyTag = tag
+		}
+	}
+	ss.engStats = storage.NewEngineStatsReporter(serveropts.StatsCli, storage.DefaultEngineStatsInterval, ss.engineStats)
 	ss.findAndConnectToMaster()
 	ss.startReplication()
+	ss.startReplicaScrubber()
+	ss.startConsistencyVerifier()
 	return ss
 }
 
-func (ss *slaveService) Put(_ context.Context, _ *serverpb.PutRequest) (*serverpb.PutResponse, error) {
-	return nil, errors.New("DKV slave service does not support keyspace mutations")
+func (ss *slaveService) Put(ctx context.Context, req *serverpb.PutRequest) (*serverpb.PutResponse, error) {
+	if ms := ss.promotedMaster(); ms != nil {
+		return ms.Put(ctx, req)
+	}
+	return nil, ss.readOnlyError()
 }
 
-func (ss *slaveService) MultiPut(_ context.Context, _ *serverpb.MultiPutRequest) (*serverpb.PutResponse, error) {
-	return nil, errors.New("DKV slave service does not support keyspace mutations")
+func (ss *slaveService) MultiPut(ctx context.Context, req *serverpb.MultiPutRequest) (*serverpb.PutResponse, error) {
+	if ms := ss.promotedMaster(); ms != nil {
+		return ms.MultiPut(ctx, req)
+	}
+	return nil, ss.readOnlyError()
 }
 
-func (ss *slaveService) Delete(_ context.Context, _ *serverpb.DeleteRequest) (*serverpb.DeleteResponse, error) {
-	return nil, errors.New("DKV slave service does not support keyspace mutations")
+func (ss *slaveService) AwaitDurability(ctx context.Context, req *serverpb.AwaitDurabilityRequest) (*serverpb.AwaitDurabilityResponse, error) {
+	if ms := ss.promotedMaster(); ms != nil {
+		return ms.AwaitDurability(ctx, req)
+	}
+	return nil, errors.New("DKV slave service does not support awaiting durability of writes")
 }
 
-func (ss *slaveService) CompareAndSet(_ context.Context, _ *serverpb.CompareAndSetRequest) (*serverpb.CompareAndSetResponse, error) {
-	return nil, errors.New("DKV slave service does not support keyspace mutations")
+func (ss *slaveService) Delete(ctx context.Context, req *serverpb.DeleteRequest) (*serverpb.DeleteResponse, error) {
+	if ms := ss.promotedMaster(); ms != nil {
+		return ms.Delete(ctx, req)
+	}
+	return nil, ss.readOnlyError()
 }
 
+func (ss *slaveService) CompareAndSet(ctx context.Context, req *serverpb.CompareAndSetRequest) (*serverpb.CompareAndSetResponse, error) {
+	if ms := ss.promotedMaster(); ms != nil {
+		return ms.CompareAndSet(ctx, req)
+	}
+	return nil, ss.readOnlyError()
+}
+
+func (ss *slaveService) Transact(ctx context.Context, req *serverpb.TransactRequest) (*serverpb.TransactResponse, error) {
+	if ms := ss.promotedMaster(); ms != nil {
+		return ms.Transact(ctx, req)
+	}
+	return nil, ss.readOnlyError()
+}
+
+func (ss *slaveService) Merge(ctx context.Context, req *serverpb.MergeRequest) (*serverpb.MergeResponse, error) {
+	if ms := ss.promotedMaster(); ms != nil {
+		return ms.Merge(ctx, req)
+	}
+	return nil, ss.readOnlyError()
+}
+
+func (ss *slaveService) Lock(ctx context.Context, req *serverpb.LockRequest) (*serverpb.LockResponse, error) {
+	if ms := ss.promotedMaster(); ms != nil {
+		return ms.Lock(ctx, req)
+	}
+	return nil, errors.New("DKV slave service does not support locks")
+}
+
+func (ss *slaveService) Unlock(ctx context.Context, req *serverpb.UnlockRequest) (*serverpb.UnlockResponse, error) {
+	if ms := ss.promotedMaster(); ms != nil {
+		return ms.Unlock(ctx, req)
+	}
+	return nil, errors.New("DKV slave service does not support locks")
+}
+
+var errSnapshotReadsNotSupported = errors.New("DKV slave service does not support reads from published snapshots")
+
 func (ss *slaveService) Get(ctx context.Context, getReq *serverpb.GetRequest) (*serverpb.GetResponse, error) {
-	readResults, err := ss.store.Get(getReq.Key)
+	if ms := ss.promotedMaster(); ms != nil {
+		return ms.Get(ctx, getReq)
+	}
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	if getReq.Snapshot != "" {
+		return &serverpb.GetResponse{Status: newErrorStatus(errSnapshotReadsNotSupported)}, errSnapshotReadsNotSupported
+	}
+	if err := ss.awaitChange(ctx, getReq.MinChangeNumber); err != nil {
+		if ss.replInfo.replConfig.ReadFallback && status.Code(err) == codes.FailedPrecondition {
+			if res, mErr := ss.getFromMaster(getReq); mErr == nil {
+				return res, nil
+			}
+		}
+		return &serverpb.GetResponse{Status: newErrorStatus(err)}, err
+	}
+	if pr, ok := ss.store.(storage.PinnedReader); ok && ss.serveropts.PinnedReads && getReq.AsOfChangeNumber == 0 && getReq.ReadOptions == nil &&
+		!ss.hasFallbackKey(getReq.Key) && !ss.replInfo.replConfig.ReadFallback {
+		return ss.getPinned(pr, getReq.Key)
+	}
+	readResults, err := ss.read(getReq.AsOfChangeNumber, getReq.ReadOptions, getReq.Key)
 	res := &serverpb.GetResponse{Status: newEmptyStatus()}
 	if err != nil {
 		res.Status = newErrorStatus(err)
 	} else {
 		if len(readResults) == 1 {
-			res.Value = readResults[0].Value
+			res.Value, res.ExpireTS = readResults[0].Value, readResults[0].ExpireTS
+		} else {
+			res.NotFound = true
 		}
+		// Versioned reads refer to the change numbers of the master,
+		// hence only the live reads can miss due to the replication lag
+		if res.NotFound && getReq.AsOfChangeNumber == 0 && ss.replInfo.replConfig.ReadFallback {
+			if masterRes, mErr := ss.getFromMaster(getReq); mErr == nil {
+				return masterRes, nil
+			}
+		}
+	}
+	return res, err
+}
+
+// getFromMaster serves the given read from the master of this slave, or
+// the upstream slave under cascading replication.
+func (ss *slaveService) getFromMaster(getReq *serverpb.GetRequest) (*serverpb.GetResponse, error) {
+	replCli := ss.replInfo.replCli
+	if replCli == nil || !ss.replInfo.replActive {
+		return nil, errors.New("can not read from master as unable to connect to an active master")
+	}
+	ss.serveropts.StatsCli.Incr("slave.read.fallbacks", 1)
+	var res *serverpb.GetResponse
+	var err error
+	if getReq.ReadOptions != nil {
+		res, err = replCli.GetWithReadOptions(getReq.ReadOptions, getReq.Key)
+	} else {
+		res, err = replCli.Get(getReq.ReadConsistency, getReq.Key)
+	}
+	if err == nil && res.Status.Code != 0 {
+		err = errors.New(res.Status.Message)
+	}
+	if err != nil {
+		ss.serveropts.Logger.Warn("Unable to read from master", zap.Error(err))
+		ss.serveropts.StatsCli.Incr("slave.read.fallback.errors", 1)
+	}
+	return res, err
+}
+
+// Interval at which the applied change number is checked by the reads
+// awaiting their minimum change number
+const minChangePollInterval = 5 * time.Millisecond
+
+// awaitChange waits until the change with the given number is applied
+// onto this slave, for at most the configured duration or until the given
+// context is done. Reads that cannot wait any longer are redirected to the
+// master, by failing them with the address of the master.
+func (ss *slaveService) awaitChange(ctx context.Context, minChngNum uint64) error {
+	if minChngNum == 0 {
+		return nil
+	}
+	timeout := time.NewTimer(ss.replInfo.replConfig.MaxMinChangeWait)
+	defer timeout.Stop()
+	ticker := time.NewTicker(minChangePollInterval)
+	defer ticker.Stop()
+	for {
+		appliedChngNum, err := ss.ca.GetLatestAppliedChangeNumber()
+		if err != nil {
+			return err
+		}
+		if appliedChngNum >= minChngNum {
+			return nil
+		}
+		select {
+		case <-ticker.C:
+		case <-timeout.C:
+			ss.serveropts.StatsCli.Incr("slave.min.change.redirects", 1)
+			return status.Errorf(codes.FailedPrecondition, "change number %d is not yet applied (applied: %d), read from the master at %s",
+				minChngNum, appliedChngNum, ss.replInfo.replConfig.ReplMasterAddr)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+var errVersionedReadsNotSupported = errors.New("Current storage engine does not support reads as of a change number")
+
+// read loads the values of the given keys as of the given change
+// number when non-zero, otherwise from the live keyspace using the given
+// read options. Versions are
+// replicated along with the changes, hence such reads refer to the
+// change numbers of the master.
+func (ss *slaveService) read(asOfChngNum uint64, readOpts *serverpb.ReadOptions, keys ...[]byte) ([]*serverpb.KVPair, error) {
+	if asOfChngNum == 0 {
+		return ss.renames.Read(func(keys ...[]byte) ([]*serverpb.KVPair, error) {
+			return storage.GetWithOptions(ss.store, readOpts, keys...)
+		}, keys...)
+	}
+	vr, ok := ss.store.(storage.VersionedReader)
+	if !ok {
+		return nil, errVersionedReadsNotSupported
+	}
+	return vr.GetAsOf(asOfChngNum, keys...)
+}
+
+func (ss *slaveService) Exists(ctx context.Context, existsReq *serverpb.ExistsRequest) (*serverpb.ExistsResponse, error) {
+	if ms := ss.promotedMaster(); ms != nil {
+		return ms.Exists(ctx, existsReq)
+	}
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	if existsReq.Snapshot != "" {
+		return &serverpb.ExistsResponse{Status: newErrorStatus(errSnapshotReadsNotSupported)}, errSnapshotReadsNotSupported
+	}
+	exists, err := storage.Exists(ss.store, existsReq.Key)
+	if fallbackKey, ok := ss.renames.FallbackKey(existsReq.Key); err == nil && !exists && ok {
+		exists, err = storage.Exists(ss.store, fallbackKey)
+	}
+	res := &serverpb.ExistsResponse{Status: newEmptyStatus()}
+	if err != nil {
+		res.Status = newErrorStatus(err)
+	} else {
+		res.Exists = exists
 	}
 	return res, err
 }
@@ -158,8 +476,34 @@ func (ss *slaveService) Watch(req *health.HealthCheckRequest, watcher health.Hea
 	}
 }
 
+// getPinned serves the value of the given key without copying it out
+// of the store. The value is released once the response is marshalled.
+func (ss *slaveService) getPinned(pr storage.PinnedReader, key []byte) (*serverpb.GetResponse, error) {
+	val, expireTS, release, err := pr.GetPinned(key)
+	if err != nil {
+		return &serverpb.GetResponse{Status: newErrorStatus(err)}, err
+	}
+	res := &serverpb.GetResponse{Status: newEmptyStatus(), Value: val, ExpireTS: expireTS, NotFound: len(val) == 0}
+	pinning.Pin(res, release)
+	return res, nil
+}
+
 func (ss *slaveService) MultiGet(ctx context.Context, multiGetReq *serverpb.MultiGetRequest) (*serverpb.MultiGetResponse, error) {
-	readResults, err := ss.store.Get(multiGetReq.Keys...)
+	if ms := ss.promotedMaster(); ms != nil {
+		return ms.MultiGet(ctx, multiGetReq)
+	}
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	if multiGetReq.Snapshot != "" {
+		return &serverpb.MultiGetResponse{Status: newErrorStatus(errSnapshotReadsNotSupported)}, errSnapshotReadsNotSupported
+	}
+	if err := ss.awaitChange(ctx, multiGetReq.MinChangeNumber); err != nil {
+		return &serverpb.MultiGetResponse{Status: newErrorStatus(err)}, err
+	}
+	readResults, err := storage.MultiGet(func(keys ...[]byte) ([]*serverpb.KVPair, error) {
+		return ss.read(multiGetReq.AsOfChangeNumber, multiGetReq.ReadOptions, keys...)
+	}, multiGetReq.Keys...)
 	res := &serverpb.MultiGetResponse{Status: newEmptyStatus()}
 	if err != nil {
 		res.Status = newErrorStatus(err)
@@ -170,8 +514,19 @@ func (ss *slaveService) MultiGet(ctx context.Context, multiGetReq *serverpb.Mult
 }
 
 func (ss *slaveService) Iterate(iterReq *serverpb.IterateRequest, dkvIterSrvr serverpb.DKV_IterateServer) error {
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	if iterReq.Snapshot != "" {
+		return dkvIterSrvr.Send(&serverpb.IterateResponse{Status: newErrorStatus(errSnapshotReadsNotSupported)})
+	}
 	iteration := storage.NewIteration(ss.store, iterReq)
 	err := iteration.ForEach(func(e *serverpb.KVPair) error {
+		// Stops as soon as the stream is cancelled or exceeds its deadline,
+		// releasing any snapshot pinned by the iteration
+		if err := dkvIterSrvr.Context().Err(); err != nil {
+			return err
+		}
 		itRes := &serverpb.IterateResponse{Status: newEmptyStatus(), Key: e.Key, Value: e.Value}
 		return dkvIterSrvr.Send(itRes)
 	})
@@ -182,14 +537,487 @@ func (ss *slaveService) Iterate(iterReq *serverpb.IterateRequest, dkvIterSrvr se
 	return nil
 }
 
+func (ss *slaveService) ChecksumScan(scanReq *serverpb.ChecksumScanRequest, dkvScanSrvr serverpb.DKV_ChecksumScanServer) error {
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	iterReq := &serverpb.IterateRequest{KeyPrefix: scanReq.KeyPrefix, StartKey: scanReq.StartKey, PinSnapshot: scanReq.PinSnapshot}
+	iteration := storage.NewIteration(ss.store, iterReq)
+	err := iteration.ForEach(func(e *serverpb.KVPair) error {
+		if err := dkvScanSrvr.Context().Err(); err != nil {
+			return err
+		}
+		scanRes := &serverpb.ChecksumScanResponse{Status: newEmptyStatus(), Key: e.Key, Checksum: storage.Checksum(e)}
+		return dkvScanSrvr.Send(scanRes)
+	})
+	if err != nil {
+		ss.serveropts.Logger.Error("Unable to perform checksum scan", zap.Error(err))
+		scanRes := &serverpb.ChecksumScanResponse{Status: newErrorStatus(err)}
+		return dkvScanSrvr.Send(scanRes)
+	}
+	return nil
+}
+
+func (ss *slaveService) Aggregate(ctx context.Context, aggReq *serverpb.AggregateRequest) (*serverpb.AggregateResponse, error) {
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	iterReq := &serverpb.IterateRequest{KeyPrefix: aggReq.KeyPrefix, StartKey: aggReq.StartKey, PinSnapshot: aggReq.PinSnapshot}
+	res, err := storage.Aggregate(ctx, storage.NewIteration(ss.store, iterReq), aggReq)
+	if err != nil {
+		ss.serveropts.Logger.Error("Unable to aggregate", zap.Error(err))
+		return &serverpb.AggregateResponse{Status: newErrorStatus(err)}, err
+	}
+	res.Status = newEmptyStatus()
+	return res, nil
+}
+
+func (ss *slaveService) Scan(_ context.Context, scanReq *serverpb.ScanRequest) (*serverpb.ScanResponse, error) {
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	if scanReq.Snapshot != "" {
+		return &serverpb.ScanResponse{Status: newErrorStatus(errSnapshotReadsNotSupported)}, errSnapshotReadsNotSupported
+	}
+	iterReq := &serverpb.IterateRequest{KeyPrefix: scanReq.KeyPrefix, StartKey: scanReq.StartKey, ReadOptions: scanReq.ReadOptions, PinSnapshot: scanReq.PinSnapshot}
+	items, token, err := ss.scans.Scan(storage.NewIteration(ss.store, iterReq), scanReq)
+	if err != nil {
+		return &serverpb.ScanResponse{Status: newErrorStatus(err)}, err
+	}
+	return &serverpb.ScanResponse{Status: newEmptyStatus(), Items: items, ContinuationToken: token}, nil
+}
+
+var errCompactionNotSupported = errors.New("Current storage engine does not support manual compaction")
+
+func (ss *slaveService) CompactRange(ctx context.Context, compReq *serverpb.CompactRangeRequest) (*serverpb.Status, error) {
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	compactable, ok := ss.store.(storage.Compactable)
+	if !ok {
+		return newErrorStatus(errCompactionNotSupported), errCompactionNotSupported
+	}
+	if err := compactable.CompactRange(compReq.KeyPrefix); err != nil {
+		ss.serveropts.Logger.Error("Unable to compact range", zap.Error(err))
+		return newErrorStatus(err), err
+	}
+	return newEmptyStatus(), nil
+}
+
+func (ss *slaveService) DeleteSubject(ctx context.Context, delSubjReq *serverpb.DeleteSubjectRequest) (*serverpb.Status, error) {
+	if ms := ss.promotedMaster(); ms != nil {
+		return ms.DeleteSubject(ctx, delSubjReq)
+	}
+	return nil, ss.readOnlyError()
+}
+
+// RotateTenantKey is not supported on slaves, which receive the data keys
+// of the tenants along with the re-encrypted values from their master.
+func (ss *slaveService) RotateTenantKey(ctx context.Context, rotateReq *serverpb.RotateTenantKeyRequest) (*serverpb.RotateEncryptionKeyResponse, error) {
+	if ms := ss.promotedMaster(); ms != nil {
+		return ms.RotateTenantKey(ctx, rotateReq)
+	}
+	return nil, ss.readOnlyError()
+}
+
+func (ss *slaveService) DeleteTenant(ctx context.Context, delTenantReq *serverpb.DeleteTenantRequest) (*serverpb.Status, error) {
+	if ms := ss.promotedMaster(); ms != nil {
+		return ms.DeleteTenant(ctx, delTenantReq)
+	}
+	return nil, ss.readOnlyError()
+}
+
+var errSizeEstimationNotSupported = errors.New("Current storage engine does not support size estimation")
+
+func (ss *slaveService) EstimateSize(ctx context.Context, estReq *serverpb.EstimateSizeRequest) (*serverpb.EstimateSizeResponse, error) {
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	estimator, ok := ss.store.(storage.SizeEstimator)
+	if !ok {
+		return &serverpb.EstimateSizeResponse{Status: newErrorStatus(errSizeEstimationNotSupported)}, errSizeEstimationNotSupported
+	}
+	est, err := estimator.EstimateSize(estReq.StartKey, estReq.EndKey)
+	if err != nil {
+		ss.serveropts.Logger.Error("Unable to estimate size", zap.Error(err))
+		return &serverpb.EstimateSizeResponse{Status: newErrorStatus(err)}, err
+	}
+	return &serverpb.EstimateSizeResponse{Status: newEmptyStatus(), NumKeys: est.NumKeys, SizeBytes: est.SizeBytes}, nil
+}
+
+var errReadAmplificationNotSupported = errors.New("Current storage engine does not support read amplification reports")
+
+const (
+	defaultReadAmpWindow = 10 * time.Second
+	maxReadAmpWindow     = time.Minute
+)
+
+func (ss *slaveService) ReportReadAmplification(ctx context.Context, raReq *serverpb.ReadAmplificationRequest) (*serverpb.ReadAmplificationResponse, error) {
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	sampler, ok := ss.store.(storage.ReadAmplificationSampler)
+	if !ok {
+		return &serverpb.ReadAmplificationResponse{Status: newErrorStatus(errReadAmplificationNotSupported)}, errReadAmplificationNotSupported
+	}
+	window := time.Duration(raReq.SamplingWindowSecs) * time.Second
+	switch {
+	case window == 0:
+		window = defaultReadAmpWindow
+	case window > maxReadAmpWindow:
+		err := fmt.Errorf("sampling window must not exceed %v", maxReadAmpWindow)
+		return &serverpb.ReadAmplificationResponse{Status: newErrorStatus(err)}, err
+	}
+	readAmp, err := sampler.SampleReadAmplification(window)
+	if err != nil {
+		ss.serveropts.Logger.Error("Unable to sample read amplification", zap.Error(err))
+		return &serverpb.ReadAmplificationResponse{Status: newErrorStatus(err)}, err
+	}
+	return &serverpb.ReadAmplificationResponse{
+		Status:            newEmptyStatus(),
+		NumSampledReads:   readAmp.NumReads,
+		NumSampledKeys:    readAmp.NumKeys,
+		BlockReadsPerKey:  readAmp.BlockReadsPerKey,
+		BlockCacheHitRate: readAmp.BlockCacheHitRate,
+		MemtableHitRate:   readAmp.MemtableHitRate,
+		BloomUsefulness:   readAmp.BloomUsefulness,
+		Suggestions:       readAmp.Suggestions,
+	}, nil
+}
+
+var errLegalHoldsNotSupported = errors.New("Current storage engine does not support legal holds")
+
+// Legal holds are replicated from the master along with the keys they
+// cover, hence are placed and lifted only on the master.
+func (ss *slaveService) PlaceLegalHold(ctx context.Context, lhReq *serverpb.LegalHoldRequest) (*serverpb.Status, error) {
+	if ms := ss.promotedMaster(); ms != nil {
+		return ms.PlaceLegalHold(ctx, lhReq)
+	}
+	return nil, ss.readOnlyError()
+}
+
+func (ss *slaveService) LiftLegalHold(ctx context.Context, lhReq *serverpb.LegalHoldRequest) (*serverpb.Status, error) {
+	if ms := ss.promotedMaster(); ms != nil {
+		return ms.LiftLegalHold(ctx, lhReq)
+	}
+	return nil, ss.readOnlyError()
+}
+
+func (ss *slaveService) ListLegalHolds(ctx context.Context, _ *emptypb.Empty) (*serverpb.ListLegalHoldsResponse, error) {
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	lh, ok := ss.store.(storage.LegalHolder)
+	if !ok {
+		return &serverpb.ListLegalHoldsResponse{Status: newErrorStatus(errLegalHoldsNotSupported)}, errLegalHoldsNotSupported
+	}
+	return &serverpb.ListLegalHoldsResponse{Status: newEmptyStatus(), KeyPrefixes: lh.ListLegalHolds()}, nil
+}
+
+// BeginPrefixRename is served only by the master. The renames registered
+// with the master are replicated onto the slave, so that its reads fall
+// back to the old prefix just as those of the master.
+func (ss *slaveService) BeginPrefixRename(ctx context.Context, renameReq *serverpb.PrefixRenameRequest) (*serverpb.Status, error) {
+	if ms := ss.promotedMaster(); ms != nil {
+		return ms.BeginPrefixRename(ctx, renameReq)
+	}
+	return nil, ss.readOnlyError()
+}
+
+// CompletePrefixRename is served only by the master. Completing a rename
+// is replicated onto the slave, along with the deletes of the keys of the
+// old prefix.
+func (ss *slaveService) CompletePrefixRename(ctx context.Context, renameReq *serverpb.PrefixRenameRequest) (*serverpb.Status, error) {
+	if ms := ss.promotedMaster(); ms != nil {
+		return ms.CompletePrefixRename(ctx, renameReq)
+	}
+	return nil, ss.readOnlyError()
+}
+
+func (ss *slaveService) ListPrefixRenames(ctx context.Context, req *emptypb.Empty) (*serverpb.ListPrefixRenamesResponse, error) {
+	if ms := ss.promotedMaster(); ms != nil {
+		return ms.ListPrefixRenames(ctx, req)
+	}
+	return &serverpb.ListPrefixRenamesResponse{Status: newEmptyStatus(), Renames: ss.renames.List()}, nil
+}
+
+func (ss *slaveService) GetKeyHeatmap(_ context.Context, hmReq *serverpb.GetKeyHeatmapRequest) (*serverpb.GetKeyHeatmapResponse, error) {
+	return heatmap.GetKeyHeatmap(ss.serveropts.KeyHeatmap, hmReq)
+}
+
+func (ss *slaveService) GetKeyPolicies(_ context.Context, _ *emptypb.Empty) (*serverpb.GetKeyPoliciesResponse, error) {
+	return &serverpb.GetKeyPoliciesResponse{Status: newEmptyStatus(), KeyPolicies: ss.serveropts.KeyPolicies}, nil
+}
+
+// hasFallbackKey checks if the reads of the given key fall back to
+// another key, due to a prefix rename in progress.
+func (ss *slaveService) hasFallbackKey(key []byte) bool {
+	_, ok := ss.renames.FallbackKey(key)
+	return ok
+}
+
+var errKeyRotationNotSupported = errors.New("Current storage engine does not support encryption key rotation")
+
+// RotateEncryptionKey re-encrypts the values of the slave using the latest
+// master key. Changes replicated from the master continue to be applied
+// during the rotation.
+func (ss *slaveService) RotateEncryptionKey(ctx context.Context, rotateReq *serverpb.RotateEncryptionKeyRequest) (*serverpb.RotateEncryptionKeyResponse, error) {
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	var err error
+	var rotation *storage.KeyRotation
+	switch rotator, ok := ss.store.(storage.EncryptionKeyRotator); {
+	case strings.TrimSpace(rotateReq.Requester) == "":
+		err = errors.New("requester must be provided for auditing key rotations")
+	case strings.TrimSpace(rotateReq.Reason) == "":
+		err = errors.New("reason must be provided for auditing key rotations")
+	case !ok:
+		err = errKeyRotationNotSupported
+	default:
+		rotation, err = rotator.RotateEncryptionKey()
+	}
+
+	audit.AdminAction(ss.serveropts.Logger, ctx, "RotateEncryptionKey", nil, rotateReq.Requester, rotateReq.Reason, err)
+	if err != nil {
+		return &serverpb.RotateEncryptionKeyResponse{Status: newErrorStatus(err)}, err
+	}
+	return &serverpb.RotateEncryptionKeyResponse{
+		Status:         newEmptyStatus(),
+		ActiveKeyID:    rotation.ActiveKeyID,
+		NumScanned:     rotation.NumScanned,
+		NumReencrypted: rotation.NumReencrypted,
+	}, nil
+}
+
+var errBackupsNotSupported = errors.New("Current storage engine does not support backups")
+
+// Backup backs up the keyspace of the slave, which is complete upto the
+// latest change applied from the master.
+func (ss *slaveService) Backup(_ context.Context, backupReq *serverpb.BackupRequest) (*serverpb.Status, error) {
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	br, ok := ss.store.(storage.Backupable)
+	if !ok {
+		return newErrorStatus(errBackupsNotSupported), errBackupsNotSupported
+	}
+	if err := br.BackupTo(backupReq.BackupPath); err != nil {
+		ss.serveropts.Logger.Error("Unable to perform backup", zap.Error(err))
+		return newErrorStatus(err), err
+	}
+	return newEmptyStatus(), nil
+}
+
+// Restore replaces the keyspace of the slave with the given backup and
+// resumes the replication from the change number of that backup. This
+// is how the slaves are brought in line with a master restored from the
+// same backup, since the change numbers of such a master move backwards.
+func (ss *slaveService) Restore(_ context.Context, restoreReq *serverpb.RestoreRequest) (*serverpb.Status, error) {
+	if ss.promotedMaster() != nil {
+		return newErrorStatus(errRestoreAfterPromotion), errRestoreAfterPromotion
+	}
+	ss.serveropts.Logger.Info("Waiting for all other requests to complete")
+	ss.rwl.Lock()
+	defer ss.rwl.Unlock()
+
+	br, ok := ss.store.(storage.Backupable)
+	if !ok {
+		return newErrorStatus(errBackupsNotSupported), errBackupsNotSupported
+	}
+	ss.serveropts.Logger.Info("Closing the current DB connection")
+	ss.scans.ReleaseAll()
+	ss.store.Close()
+
+	rstrPath := restoreReq.RestorePath
+	ss.serveropts.Logger.Info("Beginning the restoration.", zap.String("RestorePath", rstrPath))
+	st, _, _, ca, err := br.RestoreFrom(rstrPath)
+	if err != nil {
+		ss.serveropts.Logger.Error("Unable to perform restore, DKV must be restarted.", zap.Error(err))
+		return newErrorStatus(err), err
+	}
+	ss.store, ss.ca = st, ca
+	latestChngNum, _ := ss.ca.GetLatestAppliedChangeNumber()
+	ss.replInfo.fromChngNum = 1 + latestChngNum
+	ss.replInfo.replLag, ss.replInfo.caughtUp = 0, false
+	ss.serveropts.Logger.Info("Restoration completed", zap.Uint64("FromChangeNumber", ss.replInfo.fromChngNum))
+	return newEmptyStatus(), nil
+}
+
+var errPointInTimeRestoreNotSupported = errors.New("DKV slave service can only be restored from a backup in its entirety")
+
+func (ss *slaveService) PointInTimeRestore(_ context.Context, _ *serverpb.PointInTimeRestoreRequest) (*serverpb.Status, error) {
+	return newErrorStatus(errPointInTimeRestoreNotSupported), errPointInTimeRestoreNotSupported
+}
+
+func (ss *slaveService) GetBackupManifest(_ context.Context, manifestReq *serverpb.GetBackupManifestRequest) (*serverpb.GetBackupManifestResponse, error) {
+	manifest, err := storage.ReadBackupManifest(manifestReq.BackupPath)
+	if err != nil {
+		ss.serveropts.Logger.Error("Unable to read backup manifest", zap.Error(err))
+		return &serverpb.GetBackupManifestResponse{Status: newErrorStatus(err)}, err
+	}
+	return &serverpb.GetBackupManifestResponse{Status: newEmptyStatus(), ChangeNumber: manifest.ChangeNumber, CreatedAt: manifest.CreatedAt}, nil
+}
+
+// PauseWrites is served by the master service once the slave is promoted,
+// since the slave otherwise rejects all the writes.
+func (ss *slaveService) PauseWrites(ctx context.Context, pauseReq *serverpb.PauseWritesRequest) (*serverpb.PauseWritesResponse, error) {
+	if ms := ss.promotedMaster(); ms != nil {
+		return ms.PauseWrites(ctx, pauseReq)
+	}
+	return nil, ss.readOnlyError()
+}
+
+func (ss *slaveService) ResumeWrites(ctx context.Context, req *emptypb.Empty) (*serverpb.Status, error) {
+	if ms := ss.promotedMaster(); ms != nil {
+		return ms.ResumeWrites(ctx, req)
+	}
+	return nil, ss.readOnlyError()
+}
+
+var errAccessTokensNotEnabled = errors.New("Current DKV instance does not support access tokens")
+
+// MintAccessToken mints an access token for reading from the slave, which
+// is the preferred way of granting temporary access to production data.
+func (ss *slaveService) MintAccessToken(ctx context.Context, mintReq *serverpb.MintAccessTokenRequest) (*serverpb.MintAccessTokenResponse, error) {
+	var token string
+	var claims *auth.Claims
+	var err error
+	switch {
+	case strings.TrimSpace(mintReq.Reason) == "":
+		err = errors.New("reason must be provided for auditing access tokens")
+	case ss.serveropts.TokenIssuer == nil:
+		err = errAccessTokensNotEnabled
+	default:
+		ttl := time.Duration(mintReq.TtlSecs) * time.Second
+		token, claims, err = ss.serveropts.TokenIssuer.Mint(mintReq.KeyPrefix, mintReq.Requester, ttl)
+	}
+
+	audit.AdminAction(ss.serveropts.Logger, ctx, "MintAccessToken", mintReq.KeyPrefix, mintReq.Requester, mintReq.Reason, err)
+	if err != nil {
+		return &serverpb.MintAccessTokenResponse{Status: newErrorStatus(err)}, err
+	}
+	return &serverpb.MintAccessTokenResponse{Status: newEmptyStatus(), Token: token, ExpireTS: uint64(claims.ExpireTS)}, nil
+}
+
+var errEffectiveConfigNotAvailable = errors.New("Effective configuration is not available on current DKV instance")
+
+func (ss *slaveService) GetEngineStats(_ context.Context, _ *emptypb.Empty) (*serverpb.GetEngineStatsResponse, error) {
+	es, err := ss.engineStats()
+	if err != nil {
+		ss.serveropts.Logger.Error("Unable to retrieve engine stats", zap.Error(err))
+		return &serverpb.GetEngineStatsResponse{Status: newErrorStatus(err)}, err
+	}
+	return &serverpb.GetEngineStatsResponse{
+		Status:                 newEmptyStatus(),
+		MemtableSizeBytes:      es.MemtableSizeBytes,
+		NumFilesPerLevel:       es.NumFilesPerLevel,
+		BlockCacheHitRate:      es.BlockCacheHitRate,
+		PendingCompactionBytes: es.PendingCompactionBytes,
+		OldestChangeNumber:     es.OldestChangeNumber,
+		ChangeHistoryBytes:     es.ChangeHistoryBytes,
+		NamespaceCacheStats:    es.NamespaceCacheStats,
+	}, nil
+}
+
+// IngestSST is not supported on slaves, since their keyspace is only
+// mutated by the changes replicated from the master.
+func (ss *slaveService) IngestSST(ctx context.Context, ingestReq *serverpb.IngestSSTRequest) (*serverpb.Status, error) {
+	if ms := ss.promotedMaster(); ms != nil {
+		return ms.IngestSST(ctx, ingestReq)
+	}
+	err := ss.readOnlyError()
+	return newErrorStatus(err), err
+}
+
+var errExportNotSupported = errors.New("Current storage engine does not support export")
+
+// Export exports the given key range from the local storage, thereby
+// offloading the offline processing from the master.
+func (ss *slaveService) Export(_ context.Context, exportReq *serverpb.ExportRequest) (*serverpb.ExportResponse, error) {
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	exporter, ok := ss.store.(storage.Exporter)
+	if !ok {
+		return &serverpb.ExportResponse{Status: newErrorStatus(errExportNotSupported)}, errExportNotSupported
+	}
+	files, numKeys, err := storage.ExportTo(exporter, exportReq.StartKey, exportReq.EndKey, exportReq.Format, exportReq.Destination)
+	if err != nil {
+		ss.serveropts.Logger.Error("Unable to export key range", zap.Error(err))
+		return &serverpb.ExportResponse{Status: newErrorStatus(err)}, err
+	}
+	return &serverpb.ExportResponse{Status: newEmptyStatus(), Files: files, NumKeys: numKeys}, nil
+}
+
+var errQuarantineNotSupported = errors.New("Current storage engine does not support quarantining records")
+
+func (ss *slaveService) ListQuarantinedRecords(_ context.Context, _ *emptypb.Empty) (*serverpb.ListQuarantinedRecordsResponse, error) {
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	quarantiner, ok := ss.store.(storage.Quarantiner)
+	if !ok {
+		return &serverpb.ListQuarantinedRecordsResponse{Status: newErrorStatus(errQuarantineNotSupported)}, errQuarantineNotSupported
+	}
+	recs, err := quarantiner.ListQuarantinedRecords()
+	if err != nil {
+		return &serverpb.ListQuarantinedRecordsResponse{Status: newErrorStatus(err)}, err
+	}
+	return &serverpb.ListQuarantinedRecordsResponse{Status: newEmptyStatus(), Records: recs}, nil
+}
+
+var errMemtableTuningNotSupported = errors.New("Current storage engine does not support tuning memtables")
+
+func (ss *slaveService) SetMemtableOptions(_ context.Context, mtOpts *serverpb.MemtableOptions) (*serverpb.Status, error) {
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	tuner, ok := ss.store.(storage.MemtableTuner)
+	if !ok {
+		return newErrorStatus(errMemtableTuningNotSupported), errMemtableTuningNotSupported
+	}
+	if err := tuner.SetMemtableOptions(mtOpts); err != nil {
+		ss.serveropts.Logger.Error("Unable to set memtable options", zap.Error(err))
+		return newErrorStatus(err), err
+	}
+	return newEmptyStatus(), nil
+}
+
+func (ss *slaveService) engineStats() (*storage.EngineStats, error) {
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+	return ss.store.Stats()
+}
+
+func (ss *slaveService) GetEffectiveConfig(_ context.Context, _ *emptypb.Empty) (*serverpb.GetEffectiveConfigResponse, error) {
+	if ss.serveropts.EffectiveConfig == "" {
+		return &serverpb.GetEffectiveConfigResponse{Status: newErrorStatus(errEffectiveConfigNotAvailable)}, errEffectiveConfigNotAvailable
+	}
+	return &serverpb.GetEffectiveConfigResponse{Status: newEmptyStatus(), Config: ss.serveropts.EffectiveConfig}, nil
+}
+
 func (ss *slaveService) Close() error {
 	ss.serveropts.Logger.Info("Closing the slave service")
+	// Changes are no longer retrieved back to back once stopping, hence
+	// the poller gets back to receive the signal
+	ss.markReplicationStopping()
+	ss.closeChangeStream()
 	ss.replInfo.replStop <- struct{}{}
 	ss.replInfo.replTckr.Stop()
+	ss.stopReplicaScrubber()
+	ss.stopConsistencyVerifier()
 	if ss.replInfo.replCli != nil {
 		ss.replInfo.replCli.Close()
 	}
-	ss.store.Close()
+	ss.engStats.Close()
+	ss.scans.Close()
+	// Promoted slaves close their storage along with the master service
+	if ms := ss.promotedMaster(); ms != nil {
+		ms.Close()
+	} else {
+		ss.store.Close()
+	}
 	ss.isClosed = true
 	return nil
 }
@@ -199,6 +1027,7 @@ func (ss *slaveService) startReplication() {
 	latestChngNum, _ := ss.ca.GetLatestAppliedChangeNumber()
 	ss.replInfo.fromChngNum = 1 + latestChngNum
 	ss.replInfo.replStop = make(chan struct{})
+	ss.replInfo.applyStop = make(chan struct{})
 	slg := ss.serveropts.Logger.Sugar()
 	slg.Infof("Replicating changes from change number: %d and polling interval: %s", ss.replInfo.fromChngNum, ss.replInfo.replConfig.ReplPollInterval.String())
 	slg.Sync()
@@ -211,11 +1040,15 @@ func (ss *slaveService) pollAndApplyChanges() {
 		case <-ss.replInfo.replTckr.C:
 			ss.serveropts.Logger.Info("Current replication lag", zap.Uint64("ReplicationLag", ss.replInfo.replLag))
 			ss.serveropts.StatsCli.Gauge("replication.lag", int64(ss.replInfo.replLag))
-			if err := ss.applyChangesFromMaster(ss.replInfo.replConfig.MaxNumChngs); err != nil {
-				ss.serveropts.Logger.Error("Unable to retrieve changes from master", zap.Error(err))
-				if err := ss.replaceMasterIfInactive(); err != nil {
-					ss.serveropts.Logger.Error("Unable to replace master", zap.Error(err))
-				}
+			// Semi-sync replicas retrieve the changes back to back, so as
+			// to confirm their receipt as soon as they are committed, as
+			// do the WAN replicas receiving the changes over a stream
+			err := ss.replicateChanges()
+			ss.reportReplicationLag()
+			for err == nil && (ss.replInfo.replConfig.SemiSync || ss.replInfo.replConfig.WANReplication) && !ss.isClosed &&
+				atomic.LoadUint32(&ss.replStopping) == 0 {
+				err = ss.replicateChanges()
+				ss.reportReplicationLag()
 			}
 		case <-ss.replInfo.replStop:
 			ss.serveropts.Logger.Info("Stopping the change poller")
@@ -224,6 +1057,57 @@ func (ss *slaveService) pollAndApplyChanges() {
 	}
 }
 
+// replicateChanges applies a batch of changes retrieved from the master,
+// after bootstrapping from a checkpoint of the master if needed. Failures
+// to retrieve the changes are handled by replacing the master if needed,
+// while the changes no longer retained by the master are recovered from
+// by bootstrapping afresh.
+func (ss *slaveService) replicateChanges() error {
+	var err error
+	switch {
+	case ss.needsResync():
+		err = ss.resyncFromCheckpoint()
+	case ss.needsBootstrap():
+		err = ss.bootstrapFromCheckpoint()
+	}
+	if err == nil {
+		if ss.replInfo.replConfig.WANReplication {
+			err = ss.applyChangesFromStream()
+		} else {
+			ss.rwl.RLock()
+			err = ss.applyChangesFromMaster(ss.replInfo.replConfig.MaxNumChngs)
+			ss.rwl.RUnlock()
+		}
+	}
+	switch {
+	case err == nil:
+		// Replacing an unrecoverable master with one retaining the changes
+		// also recovers the slave
+		if ss.replInfo.resync == resyncUnavailable {
+			ss.setResyncState(resyncNone)
+		}
+	case errors.Is(err, errReplicationStopping):
+	case isChangesNotAvailable(err):
+		ss.beginResync(err)
+	case errors.Is(err, storage.ErrStaleFencingEpoch):
+		// Master has been superseded by a promoted one
+		ss.serveropts.Logger.Error("Rejected changes from a fenced master", zap.Error(err),
+			zap.String("MasterAddress", ss.replInfo.replConfig.ReplMasterAddr))
+		ss.serveropts.StatsCli.Incr("replication.fenced.master", 1)
+		if !ss.replInfo.replConfig.DisableAutoMasterDisc {
+			if err := ss.reconnectMaster(); err != nil {
+				ss.serveropts.Logger.Error("Unable to replace master", zap.Error(err))
+			}
+		}
+	default:
+		ss.serveropts.Logger.Error("Unable to retrieve changes from master", zap.Error(err))
+		if err := ss.replaceMasterIfInactive(); err != nil {
+			ss.serveropts.Logger.Error("Unable to replace master", zap.Error(err))
+		}
+	}
+	return err
+}
+
 func (ss *slaveService) applyChangesFromMaster(chngsPerBatch uint32) error {
 	defer ss.serveropts.StatsCli.Timing("slave.applyChangesFromMaster.latency.ms", time.Now())
 
@@ -232,20 +1116,17 @@ func (ss *slaveService) applyChangesFromMaster(chngsPerBatch uint32) error {
 	}
 	ss.serveropts.Logger.Info("Retrieving changes from master", zap.Uint64("FromChangeNumber", ss.replInfo.fromChngNum), zap.Uint32("ChangesPerBatch", chngsPerBatch))
 
-	res, err := ss.replInfo.replCli.GetChanges(ss.replInfo.fromChngNum, chngsPerBatch)
+	var maxWait time.Duration
+	if ss.replInfo.replConfig.SemiSync {
+		maxWait = ss.replInfo.replConfig.ReplPollInterval
+	}
+	res, err := ss.replInfo.replCli.AwaitChanges(ss.replicaID(), ss.replInfo.fromChngNum, chngsPerBatch, maxWait)
 	if err == nil {
 		if res.Status.Code != 0 {
 			// this is an error from DKV master's end
 			err = errors.New(res.Status.Message)
-		} else {
-			if res.MasterChangeNumber < (ss.replInfo.fromChngNum - 1) {
-				ss.serveropts.Logger.Error("change number of the master node can not be lesser than the change number of the slave node", zap.Uint64("MasterChangeNum", res.MasterChangeNumber), zap.Uint64("FromChangeNum", ss.replInfo.fromChngNum))
-				err = errors.New("change number of the master node can not be lesser than the change number of the slave node")
-			} else {
-				if err = ss.applyChanges(res); err == nil {
-					ss.replInfo.lastReplTime = hlc.UnixNow()
-				}
-			}
+		} else if err = ss.checkMasterChangeNumber(res, ss.replInfo.fromChngNum); err == nil {
+			err = ss.applyRetrievedChanges(res, chngsPerBatch)
 		}
 	} else {
 		if strings.Contains(err.Error(), "ResourceExhausted") {
@@ -267,19 +1148,93 @@ func (ss *slaveService) applyChangesFromMaster(chngsPerBatch uint32) error {
 	return err
 }
 
+// checkMasterChangeNumber checks that the change number of the master, as
+// given by its response to retrieving the changes from the given change
+// number, is not behind the changes already applied by the slave.
+func (ss *slaveService) checkMasterChangeNumber(res *serverpb.GetChangesResponse, fromChngNum uint64) error {
+	if res.MasterChangeNumber < (fromChngNum - 1) {
+		ss.serveropts.Logger.Error("change number of the master node can not be lesser than the change number of the slave node", zap.Uint64("MasterChangeNum", res.MasterChangeNumber), zap.Uint64("FromChangeNum", fromChngNum))
+		return errors.New("change number of the master node can not be lesser than the change number of the slave node")
+	}
+	return nil
+}
+
+// replicaID is the ID with which the slave identifies itself to its master.
+func (ss *slaveService) replicaID() string {
+	if id := ss.replInfo.replConfig.ReplicaID; id != "" {
+		return id
+	}
+	return ss.regionInfo.NodeAddress
+}
+
+// reportReplicationLag reports the progress of this slave to its master
+// once every polling interval, for the master to track its lag.
+func (ss *slaveService) reportReplicationLag() {
+	if ss.replInfo.replCli == nil || !ss.replInfo.replActive ||
+		time.Since(ss.replInfo.lastLagReport) < ss.replInfo.replConfig.ReplPollInterval {
+		return
+	}
+	ss.replInfo.lastLagReport = time.Now()
+	appliedChngNum := ss.replInfo.fromChngNum - 1
+	err := ss.replInfo.replCli.ReportReplicationLag(ss.replicaID(), appliedChngNum, ss.replInfo.appliedAt)
+	switch {
+	case status.Code(err) == codes.Unimplemented:
+		// Upstream slaves of cascading replication do not track the lag
+		ss.serveropts.Logger.Debug("Replication lag is not tracked by master", zap.Error(err))
+	case err != nil:
+		ss.serveropts.Logger.Warn("Unable to report replication lag to master", zap.Error(err))
+	}
+}
+
 func (ss *slaveService) applyChanges(chngsRes *serverpb.GetChangesResponse) error {
+	if err := ss.checkFencingEpochs(chngsRes); err != nil {
+		return err
+	}
+	// Changes retrieved by the client are already decoded, unlike the
+	// ones streamed
+	if err := ctl.DecodeChangeRecords(chngsRes.Changes); err != nil {
+		return err
+	}
 	if chngsRes.NumberOfChanges > 0 {
 		ss.serveropts.Logger.Info("Applying the changes received from master", zap.Uint32("NumberOfChanges", chngsRes.NumberOfChanges))
-		actChngNum, err := ss.ca.SaveChanges(chngsRes.Changes)
+		actChngNum, err := ss.saveChanges(chngsRes.Changes)
 		if err != nil {
+			// Changes preceding the failed one are applied, hence the
+			// replication resumes from the applied change number persisted
+			// by the store rather than the one last tracked in memory
+			if appliedChngNum, lerr := ss.ca.GetLatestAppliedChangeNumber(); lerr == nil && appliedChngNum >= ss.replInfo.fromChngNum {
+				ss.replInfo.fromChngNum, ss.replInfo.appliedAt = appliedChngNum+1, time.Now()
+			}
 			return err
 		}
-		ss.replInfo.fromChngNum = actChngNum + 1
+		ss.replInfo.fromChngNum, ss.replInfo.appliedAt = actChngNum+1, time.Now()
 		ss.serveropts.Logger.Info("Changes applied to local storage", zap.Uint64("FromChangeNumber", ss.replInfo.fromChngNum))
 		ss.replInfo.replLag = chngsRes.MasterChangeNumber - actChngNum
 	} else {
 		ss.serveropts.Logger.Info("Not received any changes from master")
 	}
+	if appliedChngNum := ss.replInfo.fromChngNum - 1; !ss.replInfo.caughtUp && chngsRes.MasterChangeNumber <= appliedChngNum {
+		ss.replInfo.caughtUp = true
+		if err := ss.recordConsistencyTag(appliedChngNum); err != nil {
+			ss.serveropts.Logger.Error("Unable to record consistency tag", zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// checkFencingEpochs rejects the given changes if the master or any of
+// the change records carry a fencing epoch lower than the highest epoch
+// observed by this slave. Higher epochs are observed, so that the changes
+// of any master superseded by them are rejected thereafter.
+func (ss *slaveService) checkFencingEpochs(chngsRes *serverpb.GetChangesResponse) error {
+	if err := ss.fencing.Observe(chngsRes.FencingEpoch); err != nil {
+		return err
+	}
+	for _, chng := range chngsRes.Changes {
+		if err := ss.fencing.Observe(chng.FencingEpoch); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -346,7 +1301,11 @@ func (ss *slaveService) reconnectMaster() error {
 func (ss *slaveService) findAndConnectToMaster() error {
 	if master, err := ss.findNewMaster(); err == nil {
 		// TODO: Check if authority override option is needed for slaves while they connect with masters
-		if replCli, err := ctl.NewInSecureDKVClient(*master, ""); err == nil {
+		if replCli, err := ctl.NewInSecureDKVClient(*master, "", ctl.WithCompression(ss.replInfo.replConfig.Compression)); err == nil {
+			if err = replCli.SetChangeRecordEncoding(ss.replInfo.replConfig.RecordCompression, ss.replInfo.replConfig.DeltaEncodeKeys); err != nil {
+				replCli.Close()
+				return err
+			}
 			if ss.replInfo.replCli != nil {
 				ss.replInfo.replCli.Close()
 			}
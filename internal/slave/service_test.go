@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"context"
 	"crypto/rand"
+	"errors"
 	"fmt"
 	"net"
+	"os"
 	"os/exec"
 	"strings"
 	"sync"
@@ -29,6 +31,8 @@ import (
 	"github.com/flipkart-incubator/dkv/pkg/serverpb"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -97,6 +101,505 @@ func TestMasterRocksDBSlaveBadger(t *testing.T) {
 	testMasterSlaveRepl(t, masterRDB, slaveRDB, masterRDB, slaveRDB, masterRDB)
 }
 
+func TestSlaveBootstrapFromCheckpoint(t *testing.T) {
+	masterRDB := newRocksDBStore(masterDBFolder)
+	slaveRDB := newRocksDBStore(slaveDBFolder)
+	initMasterAndSlaves(masterRDB, slaveRDB, masterRDB, slaveRDB, masterRDB)
+	defer closeMasterAndSlave()
+
+	numKeys, keyPrefix, valPrefix := 10, "CK", "CV"
+	putKeys(t, masterCli, numKeys, keyPrefix, valPrefix, 0)
+	masterChngNum, _ := masterRDB.GetLatestCommittedChangeNumber()
+
+	slave := slaveSvc.(*slaveService)
+	// Staging folder must not be within the DB folder replaced by the checkpoint
+	stagingDir := fmt.Sprintf("%s_bootstrap", slaveDBFolder)
+	if err := os.RemoveAll(stagingDir); err != nil {
+		t.Fatal(err)
+	}
+	slave.replInfo.replConfig.CheckpointStagingDir = stagingDir
+	if !slave.needsBootstrap() {
+		t.Fatal("Expected an empty slave to be bootstrapped from a checkpoint")
+	}
+
+	// Simulate an interrupted transfer by discarding the tail of the
+	// last received file, which must be resumed by the bootstrap
+	staged, err := slave.transferCheckpoint(stagingDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	filesDir := fmt.Sprintf("%s/%s", stagingDir, stagedFilesFolder)
+	files, err := storage.ListCheckpointFiles(filesDir)
+	if err != nil || len(files) == 0 {
+		t.Fatalf("Expected the checkpoint files to be staged. Files: %v, Error: %v", files, err)
+	}
+	last := files[len(files)-1]
+	if err = os.Truncate(fmt.Sprintf("%s/%s", filesDir, last.Name), last.Size/2); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = slave.bootstrapFromCheckpoint(); err != nil {
+		t.Fatal(err)
+	}
+	if slave.needsBootstrap() {
+		t.Error("Expected the slave to not be bootstrapped again")
+	}
+	if staged.ChangeNumber != masterChngNum {
+		t.Errorf("Expected checkpoint at change number: %d, but got: %d", masterChngNum, staged.ChangeNumber)
+	}
+	if slave.replInfo.fromChngNum <= masterChngNum {
+		t.Errorf("Expected replication to resume after change number: %d, but resumes from: %d", masterChngNum, slave.replInfo.fromChngNum)
+	}
+	if _, err = os.Stat(stagingDir); !os.IsNotExist(err) {
+		t.Errorf("Expected the staging folder to be removed. Error: %v", err)
+	}
+	getKeys(t, slaveCli, numKeys, keyPrefix, valPrefix)
+
+	// Subsequent changes are replicated incrementally
+	putKeys(t, masterCli, numKeys, "CK-Incr", "CV-Incr", 0)
+	if err = slave.applyChangesFromMaster(100); err != nil {
+		t.Fatal(err)
+	}
+	getKeys(t, slaveCli, numKeys, "CK-Incr", "CV-Incr")
+}
+
+func TestSlaveResyncFromCheckpoint(t *testing.T) {
+	masterRDB := newRocksDBStore(masterDBFolder)
+	slaveRDB := newRocksDBStore(slaveDBFolder)
+	initMasterAndSlaves(masterRDB, slaveRDB, masterRDB, slaveRDB, masterRDB)
+	defer closeMasterAndSlave()
+
+	numKeys, keyPrefix, valPrefix := 10, "RK", "RV"
+	putKeys(t, masterCli, numKeys, keyPrefix, valPrefix, 0)
+	masterChngNum, _ := masterRDB.GetLatestCommittedChangeNumber()
+
+	slave := slaveSvc.(*slaveService)
+	slave.replInfo.replActive = true
+	// Simulate the master no longer retaining the changes to be retrieved
+	notAvailable := status.Error(codes.OutOfRange, storage.ErrChangesNotAvailable.Error())
+	if !isChangesNotAvailable(notAvailable) {
+		t.Fatalf("Expected the error to indicate changes no longer available. Error: %v", notAvailable)
+	}
+	slave.beginResync(notAvailable)
+	if !slave.needsResync() {
+		t.Fatalf("Expected the slave to be resynced. State: %s", slave.replInfo.resync)
+	}
+
+	// Slaves without checkpoint staging can not be resynced
+	if err := slave.resyncFromCheckpoint(); err != errResyncUnavailable {
+		t.Errorf("Expected the resync to be unavailable. Error: %v", err)
+	}
+	if slave.replInfo.resync != resyncUnavailable {
+		t.Errorf("Expected the resync state: %s, Actual: %s", resyncUnavailable, slave.replInfo.resync)
+	}
+	slave.beginResync(notAvailable)
+	if slave.needsResync() {
+		t.Error("Expected an unrecoverable slave to not be resynced again")
+	}
+
+	stagingDir := fmt.Sprintf("%s_resync", slaveDBFolder)
+	if err := os.RemoveAll(stagingDir); err != nil {
+		t.Fatal(err)
+	}
+	slave.replInfo.replConfig.CheckpointStagingDir = stagingDir
+	slave.setResyncState(resyncPending)
+	if err := slave.replicateChanges(); err != nil {
+		t.Fatal(err)
+	}
+	if slave.replInfo.resync != resyncNone {
+		t.Errorf("Expected the resync to complete. State: %s", slave.replInfo.resync)
+	}
+	if slave.replInfo.fromChngNum <= masterChngNum {
+		t.Errorf("Expected replication to resume after change number: %d, but resumes from: %d", masterChngNum, slave.replInfo.fromChngNum)
+	}
+	getKeys(t, slaveCli, numKeys, keyPrefix, valPrefix)
+
+	// Subsequent changes are replicated incrementally
+	putKeys(t, masterCli, numKeys, "RK-Incr", "RV-Incr", 0)
+	if err := slave.applyChangesFromMaster(100); err != nil {
+		t.Fatal(err)
+	}
+	getKeys(t, slaveCli, numKeys, "RK-Incr", "RV-Incr")
+}
+
+func TestSlaveRejectsWritesAsReadOnly(t *testing.T) {
+	masterRDB := newRocksDBStore(masterDBFolder)
+	slaveRDB := newRocksDBStore(slaveDBFolder)
+	initMasterAndSlaves(masterRDB, slaveRDB, masterRDB, slaveRDB, masterRDB)
+	defer closeMasterAndSlave()
+
+	masterAddr := fmt.Sprintf("%s:%d", dkvSvcHost, masterSvcPort)
+	slaveSvc.(*slaveService).replInfo.replConfig.ReplMasterAddr = masterAddr
+	writes := map[string]func() error{
+		"Put":    func() error { return slaveCli.Put([]byte("ReadOnlyKey"), []byte("ReadOnlyVal")) },
+		"Delete": func() error { return slaveCli.Delete([]byte("ReadOnlyKey")) },
+	}
+	for name, write := range writes {
+		err := write()
+		if !errors.Is(err, ctl.ErrReplicaReadOnly) {
+			t.Errorf("Expected %s on the slave to be rejected as read-only, but got error: %v", name, err)
+			continue
+		}
+		var roErr *ctl.ReplicaReadOnlyError
+		if !errors.As(err, &roErr) || roErr.MasterAddress != masterAddr {
+			t.Errorf("Expected %s on the slave to be redirected to master %s, but got error: %v", name, masterAddr, err)
+		}
+	}
+}
+
+func TestSlavePromotion(t *testing.T) {
+	masterRDB := newRocksDBStore(masterDBFolder)
+	slaveRDB := newRocksDBStore(slaveDBFolder)
+	initMasterAndSlaves(masterRDB, slaveRDB, masterRDB, slaveRDB, masterRDB)
+	defer closeMasterAndSlave()
+
+	numKeys, keyPrefix, valPrefix := 10, "PK", "PV"
+	putKeys(t, masterCli, numKeys, keyPrefix, valPrefix, 0)
+	slave := slaveSvc.(*slaveService)
+	if err := slave.applyChangesFromMaster(100); err != nil {
+		t.Fatal(err)
+	}
+	masterChngNum, _ := masterRDB.GetLatestCommittedChangeNumber()
+	if err := slaveCli.Put([]byte("PromotedKey1"), []byte("PromotedVal1")); err == nil {
+		t.Fatal("Expected the slave to reject writes before being promoted")
+	}
+
+	// Slaves lacking some of the keys are not promoted
+	slave.replInfo.replConfig.PartialReplication = true
+	if _, err := slaveCli.PromoteToMaster(false); err == nil {
+		t.Error("Expected the slave replicating only some of the key prefixes to not be promoted")
+	}
+	slave.replInfo.replConfig.PartialReplication = false
+	if err := slaveCli.DeleteSubject([]byte("user:1"), "tester", "erasure"); !errors.Is(err, ctl.ErrReplicaReadOnly) {
+		t.Errorf("Expected the slave to reject subject erasure as read-only, but got error: %v", err)
+	}
+
+	epoch := slave.fencing.Current()
+	res, err := slaveCli.PromoteToMaster(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.FencingEpoch != epoch+1 || res.ChangeNumber != masterChngNum {
+		t.Errorf("Expected promotion with fencing epoch %d at change number %d, but got %v", epoch+1, masterChngNum, res)
+	}
+
+	// Promoted slave serves the writes and their changes as a master
+	if err = slaveCli.Put([]byte("PromotedKey1"), []byte("PromotedVal1")); err != nil {
+		t.Fatal(err)
+	}
+	getKeys(t, slaveCli, numKeys, keyPrefix, valPrefix)
+	getKeys(t, slaveCli, 1, "PromotedKey", "PromotedVal")
+	// Every other write is served by the promoted slave as well
+	if err = slaveCli.DeleteSubject([]byte("user:1"), "tester", "erasure"); errors.Is(err, ctl.ErrReplicaReadOnly) {
+		t.Errorf("Expected the promoted slave to serve subject erasure, but got error: %v", err)
+	}
+	chngsRes, err := slaveCli.GetChanges(masterChngNum+1, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if numChngs := len(chngsRes.Changes); numChngs == 0 || chngsRes.Changes[numChngs-1].FencingEpoch != res.FencingEpoch {
+		t.Errorf("Expected the write to be propagated with fencing epoch %d, but got %v", res.FencingEpoch, chngsRes.Changes)
+	}
+	if err = slaveCli.Restore(fmt.Sprintf("%s_promoted_backup", slaveDBFolder)); err == nil {
+		t.Error("Expected the promoted slave to not be restored")
+	}
+
+	// Promoting again only issues a new fencing epoch
+	if epoch, err = slaveCli.Promote(); err != nil || epoch != res.FencingEpoch+1 {
+		t.Errorf("Expected fencing epoch %d to be issued, but got %d, error: %v", res.FencingEpoch+1, epoch, err)
+	}
+}
+
+func TestSlavePromotionFencesMaster(t *testing.T) {
+	masterRDB := newRocksDBStore(masterDBFolder)
+	slaveRDB := newRocksDBStore(slaveDBFolder)
+	initMasterAndSlaves(masterRDB, slaveRDB, masterRDB, slaveRDB, masterRDB)
+	defer closeMasterAndSlave()
+
+	numKeys, keyPrefix, valPrefix := 10, "FK", "FV"
+	putKeys(t, masterCli, numKeys, keyPrefix, valPrefix, 0)
+	slave := slaveSvc.(*slaveService)
+	if err := slave.applyChangesFromMaster(100); err != nil {
+		t.Fatal(err)
+	}
+	slaveAddr := fmt.Sprintf("%s:%d", dkvSvcHost, slaveSvcPort)
+	slave.regionInfo.NodeAddress = slaveAddr
+	res, err := slaveCli.PromoteAndFenceMaster()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Superseded master rejects the writes, redirecting them to the
+	// promoted slave, while continuing to serve the reads
+	err = masterCli.Put([]byte("FencedKey1"), []byte("FencedVal1"))
+	var roErr *ctl.ReplicaReadOnlyError
+	if !errors.As(err, &roErr) || roErr.MasterAddress != slaveAddr {
+		t.Errorf("Expected the superseded master to redirect the write to %s, but got error: %v", slaveAddr, err)
+	}
+	getKeys(t, masterCli, numKeys, keyPrefix, valPrefix)
+	if err = slaveCli.Put([]byte("FencedKey1"), []byte("FencedVal1")); err != nil {
+		t.Fatal(err)
+	}
+	getKeys(t, slaveCli, 1, "FencedKey", "FencedVal")
+
+	// Changes of the superseded master carry its own epoch, which is
+	// lower than that of the promoted slave
+	chngsRes, err := masterCli.GetChanges(1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chngsRes.FencingEpoch >= res.FencingEpoch {
+		t.Errorf("Expected the changes of the superseded master to carry an epoch lower than %d, but got %d", res.FencingEpoch, chngsRes.FencingEpoch)
+	}
+	if err = masterCli.Fence(chngsRes.FencingEpoch, slaveAddr); err == nil {
+		t.Error("Expected fencing with an epoch that is not higher than that of the master to be rejected")
+	}
+
+	// Superseded master accepts the writes again once promoted
+	epoch, err := masterCli.Promote()
+	if err != nil || epoch <= res.FencingEpoch {
+		t.Errorf("Expected fencing epoch higher than %d to be issued, but got %d, error: %v", res.FencingEpoch, epoch, err)
+	}
+	if err = masterCli.Put([]byte("FencedKey2"), []byte("FencedVal2")); err != nil {
+		t.Errorf("Expected the promoted master to accept the writes, but got error: %v", err)
+	}
+}
+
+func TestThrottledApply(t *testing.T) {
+	masterRDB := newRocksDBStore(masterDBFolder)
+	slaveRDB := newRocksDBStore(slaveDBFolder)
+	initMasterAndSlaves(masterRDB, slaveRDB, masterRDB, slaveRDB, masterRDB)
+	defer closeMasterAndSlave()
+
+	numKeys, keyPrefix, valPrefix := 20, "TK", "TV"
+	putKeys(t, masterCli, numKeys, keyPrefix, valPrefix, 0)
+	masterChngNum, _ := masterRDB.GetLatestCommittedChangeNumber()
+
+	// Batches following the first are retrieved while applying the preceding
+	// ones, in smaller batches paced to the apply rate
+	slave := slaveSvc.(*slaveService)
+	slave.replInfo.replConfig.ApplyBatchSize = 3
+	slave.replInfo.replConfig.ApplyPrefetch = 3
+	slave.replInfo.replConfig.MaxApplyRate = 100
+	startTime := time.Now()
+	if err := slave.applyChangesFromMaster(5); err != nil {
+		t.Fatal(err)
+	}
+	if slave.replInfo.fromChngNum <= masterChngNum {
+		t.Errorf("Expected all the changes upto %d to be applied, but replication resumes from: %d", masterChngNum, slave.replInfo.fromChngNum)
+	}
+	if elapsed := time.Since(startTime); elapsed < 150*time.Millisecond {
+		t.Errorf("Expected the apply of %d changes to be paced to 100 changes per second, but took %s", numKeys, elapsed)
+	}
+	getKeys(t, slaveCli, numKeys, keyPrefix, valPrefix)
+}
+
+func TestReadFallback(t *testing.T) {
+	masterRDB := newRocksDBStore(masterDBFolder)
+	slaveRDB := newRocksDBStore(slaveDBFolder)
+	initMasterAndSlaves(masterRDB, slaveRDB, masterRDB, slaveRDB, masterRDB)
+	defer closeMasterAndSlave()
+
+	sess := ctl.NewSession()
+	key, val := []byte("FallbackKey"), []byte("FallbackVal")
+	if err := sess.Put(masterCli, key, val); err != nil {
+		t.Fatal(err)
+	}
+
+	// Client falls back to the master for the write yet to be replicated
+	fr := ctl.NewFallbackReader(slaveCli, masterCli)
+	for _, s := range []*ctl.Session{nil, sess} {
+		if res, err := fr.Get(s, serverpb.ReadConsistency_SEQUENTIAL, key); err != nil {
+			t.Error(err)
+		} else if !bytes.Equal(res.Value, val) {
+			t.Errorf("Expected the read to fall back to the master. Expected: %q, Actual: %q", val, res.Value)
+		}
+	}
+	if kvs, err := fr.MultiGet(nil, serverpb.ReadConsistency_SEQUENTIAL, key, []byte("AbsentKey")); err != nil {
+		t.Error(err)
+	} else if len(kvs) != 1 || !bytes.Equal(kvs[0].Value, val) {
+		t.Errorf("Expected only the missing keys to be read from the master. Actual: %v", kvs)
+	}
+
+	// Slave falls back to the master once enabled
+	if res, _ := slaveCli.Get(serverpb.ReadConsistency_SEQUENTIAL, key); len(res.GetValue()) != 0 {
+		t.Errorf("Expected the slave to not have the key yet. Value: %q", res.GetValue())
+	}
+	slave := slaveSvc.(*slaveService)
+	slave.replInfo.replConfig.ReadFallback = true
+	slave.replInfo.replActive = true
+	if res, err := slaveCli.Get(serverpb.ReadConsistency_SEQUENTIAL, key); err != nil {
+		t.Error(err)
+	} else if !bytes.Equal(res.Value, val) {
+		t.Errorf("Expected the slave to read from the master. Expected: %q, Actual: %q", val, res.Value)
+	}
+	if res, err := sess.Get(slaveCli, serverpb.ReadConsistency_SEQUENTIAL, key); err != nil {
+		t.Errorf("Expected the read awaiting the write to fall back to the master. Error: %v", err)
+	} else if !bytes.Equal(res.Value, val) {
+		t.Errorf("Expected the slave to read from the master. Expected: %q, Actual: %q", val, res.Value)
+	}
+	if res, err := slaveCli.Get(serverpb.ReadConsistency_SEQUENTIAL, []byte("AbsentKey")); err != nil || len(res.Value) != 0 {
+		t.Errorf("Expected the key to be absent. Value: %q, Error: %v", res.GetValue(), err)
+	}
+}
+
+func TestConsistencyVerifier(t *testing.T) {
+	masterRDB := newRocksDBStore(masterDBFolder)
+	slaveRDB := newRocksDBStore(slaveDBFolder)
+	initMasterAndSlaves(masterRDB, slaveRDB, masterRDB, slaveRDB, masterRDB)
+	defer closeMasterAndSlave()
+
+	numKeys, keyPrefix, valPrefix := 20, "VK", "VV"
+	putKeys(t, masterCli, numKeys, keyPrefix, valPrefix, 0)
+	slave := slaveSvc.(*slaveService)
+	if err := slave.applyChangesFromMaster(100); err != nil {
+		t.Fatal(err)
+	}
+	slave.replInfo.replActive = true
+	slave.replInfo.replConfig.VerifyKeysPerRange = 4
+	slave.replInfo.replConfig.VerifyInterval = time.Hour
+	slave.startConsistencyVerifier()
+
+	verifyPass := func() *serverpb.GetDivergentRangesResponse {
+		if err := slave.verifier.verify(); err != nil {
+			t.Fatal(err)
+		}
+		res, err := slaveCli.GetDivergentRanges()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.LastPassTimestamp == 0 {
+			t.Error("Expected the verifier to complete a pass over the keyspace")
+		}
+		return res
+	}
+	if res := verifyPass(); len(res.Ranges) != 0 {
+		t.Errorf("Expected no divergent ranges. Actual: %v", res.Ranges)
+	}
+
+	// Slave silently diverges from its master
+	divergentKey := []byte(fmt.Sprintf("%s%d", keyPrefix, 7))
+	if err := slaveRDB.Put(&serverpb.KVPair{Key: divergentKey, Value: []byte("diverged")}); err != nil {
+		t.Fatal(err)
+	}
+	res := verifyPass()
+	if len(res.Ranges) != 1 {
+		t.Fatalf("Expected a single divergent range. Actual: %v", res.Ranges)
+	}
+	if rng := res.Ranges[0]; bytes.Compare(rng.StartKey, divergentKey) > 0 || (len(rng.EndKey) > 0 && bytes.Compare(divergentKey, rng.EndKey) >= 0) {
+		t.Errorf("Expected the divergent range to hold the key %q. Actual: %v", divergentKey, rng)
+	}
+
+	// Ranges verified to be consistent again are no longer reported
+	if err := slaveRDB.Put(&serverpb.KVPair{Key: divergentKey, Value: []byte(fmt.Sprintf("%s%d", valPrefix, 7))}); err != nil {
+		t.Fatal(err)
+	}
+	if res = verifyPass(); len(res.Ranges) != 0 {
+		t.Errorf("Expected no divergent ranges. Actual: %v", res.Ranges)
+	}
+}
+
+func TestApplyThrottleDelay(t *testing.T) {
+	var at applyThrottle
+	now := time.Now()
+	if delay := at.delay(50, 0, now); delay != 0 {
+		t.Errorf("Expected no delay without a maximum apply rate, but got %s", delay)
+	}
+	if delay := at.delay(50, 100, now); delay != 500*time.Millisecond {
+		t.Errorf("Expected a delay of 500ms, but got %s", delay)
+	}
+	if delay := at.delay(50, 100, now.Add(500*time.Millisecond)); delay != 500*time.Millisecond {
+		t.Errorf("Expected a delay of 500ms, but got %s", delay)
+	}
+	// Idle periods are not made up for by bursts
+	if delay := at.delay(100, 100, now.Add(10*time.Second)); delay != time.Second {
+		t.Errorf("Expected a delay of 1s, but got %s", delay)
+	}
+}
+
+func TestCascadingReplication(t *testing.T) {
+	masterRDB := newRocksDBStore(masterDBFolder)
+	slaveRDB := newRocksDBStore(slaveDBFolder)
+	initMasterAndSlaves(masterRDB, slaveRDB, masterRDB, slaveRDB, masterRDB)
+	defer closeMasterAndSlave()
+
+	// Downstream slave replicates from the slave instead of the master
+	downstreamRDB := newRocksDBStore(fmt.Sprintf("%s_downstream", slaveDBFolder))
+	replConf := &ReplicationConfig{
+		MaxNumChngs:           100,
+		ReplPollInterval:      time.Hour,
+		MaxActiveReplLag:      10,
+		MaxActiveReplElapsed:  5,
+		DisableAutoMasterDisc: true,
+		ReplMasterAddr:        fmt.Sprintf("%s:%d", dkvSvcHost, slaveSvcPort),
+	}
+	downstreamSvc, err := NewService(downstreamRDB, downstreamRDB, &serverpb.RegionInfo{Database: dbName, VBucket: vbucket},
+		replConf, testingClusterInfo{}, serverOpts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer downstreamSvc.Close()
+	downstream := downstreamSvc.(*slaveService)
+
+	numKeys, keyPrefix, valPrefix := 10, "CascadeKey", "CascadeVal"
+	putKeys(t, masterCli, numKeys, keyPrefix, valPrefix, 0)
+	slave := slaveSvc.(*slaveService)
+	if err = slave.applyChangesFromMaster(100); err != nil {
+		t.Fatal(err)
+	}
+	if err = downstream.applyChangesFromMaster(100); err != nil {
+		t.Fatal(err)
+	}
+
+	masterChngNum, _ := masterRDB.GetLatestCommittedChangeNumber()
+	if downstreamChngNum, _ := downstreamRDB.GetLatestAppliedChangeNumber(); downstreamChngNum != masterChngNum {
+		t.Errorf("Expected the downstream slave to apply upto change number: %d, but applied upto: %d", masterChngNum, downstreamChngNum)
+	}
+	for i := 1; i <= numKeys; i++ {
+		key, expVal := fmt.Sprintf("%s%d", keyPrefix, i), fmt.Sprintf("%s%d", valPrefix, i)
+		if kvs, err := downstreamRDB.Get([]byte(key)); err != nil || len(kvs) != 1 || string(kvs[0].Value) != expVal {
+			t.Errorf("Expected value: %s for key: %s on downstream slave, but got: %v, error: %v", expVal, key, kvs, err)
+		}
+	}
+
+	// Changes are served by the slave only upto what it applied
+	putKeys(t, masterCli, numKeys, "CascadeIncrKey", "CascadeIncrVal", 0)
+	res, err := slaveCli.GetChanges(masterChngNum+1, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.NumberOfChanges != 0 || res.MasterChangeNumber != masterChngNum {
+		t.Errorf("Expected no changes beyond change number: %d, but got %d changes upto: %d", masterChngNum, res.NumberOfChanges, res.MasterChangeNumber)
+	}
+
+	// Empty downstream slaves bootstrap from a checkpoint of the master
+	// relayed by the slave
+	relayedRDB := newRocksDBStore(fmt.Sprintf("%s_relayed", slaveDBFolder))
+	relayedConf := *replConf
+	relayedConf.CheckpointStagingDir = fmt.Sprintf("%s_relayed_bootstrap", slaveDBFolder)
+	if err = os.RemoveAll(relayedConf.CheckpointStagingDir); err != nil {
+		t.Fatal(err)
+	}
+	relayedSvc, err := NewService(relayedRDB, relayedRDB, &serverpb.RegionInfo{Database: dbName, VBucket: vbucket},
+		&relayedConf, testingClusterInfo{}, serverOpts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer relayedSvc.Close()
+	relayed := relayedSvc.(*slaveService)
+	slave.replInfo.replActive, relayed.replInfo.replActive = true, true
+	if err = relayed.bootstrapFromCheckpoint(); err != nil {
+		t.Fatal(err)
+	}
+	latestChngNum, _ := masterRDB.GetLatestCommittedChangeNumber()
+	if relayed.replInfo.fromChngNum != latestChngNum+1 {
+		t.Errorf("Expected the relayed checkpoint upto change number: %d, but replicating from: %d", latestChngNum, relayed.replInfo.fromChngNum)
+	}
+	if kvs, err := relayedRDB.Get([]byte("CascadeIncrKey1")); err != nil || len(kvs) != 1 || string(kvs[0].Value) != "CascadeIncrVal1" {
+		t.Errorf("Expected the key written after the slave applied its changes to be bootstrapped, but got: %v, error: %v", kvs, err)
+	}
+}
+
 func TestSlaveDiscoveryFunctionality(t *testing.T) {
 	masterRDB := newRocksDBStore(masterDBFolder)
 	slaveRDB := newRocksDBStore(slaveDBFolder)
@@ -547,6 +1050,7 @@ func testMasterSlaveRepl(t *testing.T, masterStore, slaveStore storage.KVStore,
 	if err := slaveSvc.(*slaveService).applyChangesFromMaster(maxNumChangesRepl); err != nil {
 		t.Error(err)
 	}
+	testConsistencyTag(t, ca)
 
 	getKeys(t, masterCli, numKeys, keyPrefix, valPrefix)
 	getKeys(t, slaveCli, numKeys, keyPrefix, valPrefix)
@@ -560,6 +1064,8 @@ func testMasterSlaveRepl(t *testing.T, masterStore, slaveStore storage.KVStore,
 	getInvalidKeys(t, slaveCli, numKeys, ttlExpiredKeyPrefix)
 
 	getNonExistentKey(t, slaveCli, keyPrefix)
+	testSessionReads(t)
+	testFencing(t)
 
 	backupFolder := fmt.Sprintf("%s/backup", masterDBFolder)
 	if err := masterCli.Backup(backupFolder); err != nil {
@@ -589,6 +1095,64 @@ func testMasterSlaveRepl(t *testing.T, masterStore, slaveStore storage.KVStore,
 	}
 }
 
+func testFencing(t *testing.T) {
+	slave := slaveSvc.(*slaveService)
+	// Slave observes the epoch of another master promoted in the meantime
+	epoch := slave.fencing.Current() + 1
+	if err := slave.fencing.Observe(epoch); err != nil {
+		t.Fatal(err)
+	}
+	putKeys(t, masterCli, 1, "FencedK", "FencedV", 0)
+	if err := slave.applyChangesFromMaster(100); !errors.Is(err, storage.ErrStaleFencingEpoch) {
+		t.Errorf("Expected error: %v, but got: %v", storage.ErrStaleFencingEpoch, err)
+	}
+	getInvalidKeys(t, slaveCli, 1, "FencedK")
+
+	if promotedEpoch, err := masterCli.Promote(); err != nil || promotedEpoch != epoch {
+		t.Fatalf("Expected fencing epoch %d to be issued, but got %d, error: %v", epoch, promotedEpoch, err)
+	}
+	if err := slave.applyChangesFromMaster(100); err != nil {
+		t.Fatal(err)
+	}
+	getKeys(t, slaveCli, 1, "FencedK", "FencedV")
+}
+
+func testSessionReads(t *testing.T) {
+	sess := ctl.NewSession()
+	key, val := []byte("SessionKey"), []byte("SessionVal")
+	if err := sess.Put(masterCli, key, val); err != nil {
+		t.Fatal(err)
+	}
+	if sess.ChangeNumber() == 0 {
+		t.Fatal("Expected the change number of the write to be observed by the session")
+	}
+
+	// Slave is yet to replicate the write, hence the read is redirected
+	if _, err := sess.Get(slaveCli, serverpb.ReadConsistency_SEQUENTIAL, key); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected the read to be redirected to the master. Actual error: %v", err)
+	} else if !strings.Contains(err.Error(), "read from the master") {
+		t.Errorf("Expected the error to refer to the master. Actual error: %v", err)
+	}
+	// Reads without a session are served as before
+	if res, err := slaveCli.Get(serverpb.ReadConsistency_SEQUENTIAL, key); err != nil || len(res.Value) != 0 {
+		t.Errorf("Expected the slave to not have the key yet. Value: %q, Error: %v", res.GetValue(), err)
+	}
+
+	if err := slaveSvc.(*slaveService).applyChangesFromMaster(100); err != nil {
+		t.Fatal(err)
+	}
+	if res, err := sess.Get(slaveCli, serverpb.ReadConsistency_SEQUENTIAL, key); err != nil {
+		t.Error(err)
+	} else if !bytes.Equal(res.Value, val) {
+		t.Errorf("Expected the session to read its own write. Expected: %q, Actual: %q", val, res.Value)
+	}
+	if kvs, err := sess.MultiGet(slaveCli, serverpb.ReadConsistency_SEQUENTIAL, key); err != nil {
+		t.Error(err)
+	} else if len(kvs) != 1 || !bytes.Equal(kvs[0].Value, val) {
+		t.Errorf("Expected the session to read its own write. Expected: %q, Actual: %v", val, kvs)
+	}
+}
+
 func testGetStatus(t *testing.T, masterStore, slaveStore storage.KVStore, cp storage.ChangePropagator, ca storage.ChangeApplier, masterBU storage.Backupable) {
 	initMasterAndSlaves(masterStore, slaveStore, cp, ca, masterBU)
 	defer closeMaster()
@@ -804,6 +1368,16 @@ func getInvalidKeys(t *testing.T, dkvCli *ctl.DKVClient, numKeys int, keyPrefix
 	}
 }
 
+func testConsistencyTag(t *testing.T, ca storage.ChangeApplier) {
+	appliedChngNum, _ := ca.GetLatestAppliedChangeNumber()
+	regionInfo, _ := slaveSvc.GetStatus(nil, nil)
+	if tag := regionInfo.ConsistencyTag; tag == nil {
+		t.Error("Expected a consistency tag to be recorded once the slave caught up")
+	} else if tag.ChangeNumber != appliedChngNum || len(tag.Checksum) != 64 {
+		t.Errorf("Consistency tag mismatch. Expected change number: %d, Actual tag: %v", appliedChngNum, tag)
+	}
+}
+
 func getNonExistentKey(t *testing.T, dkvCli *ctl.DKVClient, keyPrefix string) {
 	rc := serverpb.ReadConsistency_SEQUENTIAL
 	key := keyPrefix + "DeletedKey"
@@ -911,6 +1485,7 @@ func serveStandaloneDKVSlave(wg *sync.WaitGroup, store storage.KVStore, ca stora
 		}
 		slaveGrpcSrvr = grpc.NewServer()
 		serverpb.RegisterDKVServer(slaveGrpcSrvr, slaveSvc)
+		serverpb.RegisterDKVReplicationServer(slaveGrpcSrvr, slaveSvc)
 		health.RegisterHealthServer(slaveGrpcSrvr, slaveSvc)
 		lis := listen(slaveSvcPort)
 		wg.Done()
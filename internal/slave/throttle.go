@@ -0,0 +1,170 @@
+package slave
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/internal/hlc"
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"go.uber.org/zap"
+)
+
+// applyThrottle paces the apply of the changes to the configured rate,
+// over windows of a second so that idle periods are not made up for by
+// bursts thereafter.
+type applyThrottle struct {
+	windowStart time.Time
+	numApplied  uint64
+}
+
+// delay computes the duration for which the apply must pause after
+// applying the given number of changes, so as not to exceed the given
+// number of changes per second.
+func (at *applyThrottle) delay(numChngs int, maxRate uint32, now time.Time) time.Duration {
+	if maxRate == 0 {
+		return 0
+	}
+	elapsed := now.Sub(at.windowStart)
+	if elapsed > time.Second {
+		at.windowStart, at.numApplied, elapsed = now, 0, 0
+	}
+	at.numApplied += uint64(numChngs)
+	if due := time.Duration(float64(at.numApplied) / float64(maxRate) * float64(time.Second)); due > elapsed {
+		return due - elapsed
+	}
+	return 0
+}
+
+var errApplyStopped = errors.New("apply of the changes is stopped as the replication is being stopped")
+
+// saveChanges applies the given changes in batches of the configured size,
+// pacing them to the configured rate. Returns the latest change number
+// applied, along with an error if the replication is stopped meanwhile.
+func (ss *slaveService) saveChanges(chngs []*serverpb.ChangeRecord) (uint64, error) {
+	batchSize := int(ss.replInfo.replConfig.ApplyBatchSize)
+	if batchSize == 0 {
+		batchSize = len(chngs)
+	}
+	var appliedChngNum uint64
+	for len(chngs) > 0 {
+		batch := chngs
+		if len(batch) > batchSize {
+			batch = batch[:batchSize]
+		}
+		chngs = chngs[len(batch):]
+		chngNum, err := ss.ca.SaveChanges(batch)
+		if chngNum > appliedChngNum {
+			appliedChngNum = chngNum
+		}
+		ss.reloadPrefixRenames(batch)
+		if err != nil {
+			return appliedChngNum, err
+		}
+		if delay := ss.replInfo.applyThrottle.delay(len(batch), ss.replInfo.replConfig.MaxApplyRate, time.Now()); delay > 0 {
+			ss.serveropts.StatsCli.Incr("slave.apply.throttled.ms", delay.Milliseconds())
+			if !ss.pauseApply(delay) {
+				return appliedChngNum, errApplyStopped
+			}
+		}
+	}
+	return appliedChngNum, nil
+}
+
+// pauseApply pauses the apply for the given duration, unless the
+// replication is stopped meanwhile. Reports whether the apply can go on.
+func (ss *slaveService) pauseApply(delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ss.replInfo.applyStop:
+		return false
+	}
+}
+
+// markReplicationStopping marks the replication as being stopped, which
+// interrupts the apply paused for pacing it.
+func (ss *slaveService) markReplicationStopping() {
+	if atomic.CompareAndSwapUint32(&ss.replStopping, 0, 1) && ss.replInfo.applyStop != nil {
+		close(ss.replInfo.applyStop)
+	}
+}
+
+// reloadPrefixRenames reloads the registry of the prefix renames after
+// applying the given changes, if they may alter the registry.
+func (ss *slaveService) reloadPrefixRenames(chngs []*serverpb.ChangeRecord) {
+	for _, chng := range chngs {
+		if storage.ChangesPrefixRenames(chng) {
+			if err := ss.renames.Reload(); err != nil {
+				ss.serveropts.Logger.Error("Unable to reload the prefix renames", zap.Uint64("ChangeNumber", chng.ChangeNumber), zap.Error(err))
+			}
+			return
+		}
+	}
+}
+
+type prefetchedChanges struct {
+	res *serverpb.GetChangesResponse
+	err error
+}
+
+// applyRetrievedChanges applies the given changes retrieved from the master.
+// When the slave is catching up, the following batches of changes, upto
+// the configured number, are retrieved while the preceding ones are
+// applied.
+func (ss *slaveService) applyRetrievedChanges(res *serverpb.GetChangesResponse, chngsPerBatch uint32) error {
+	for numBatches := uint32(1); ; numBatches++ {
+		var next chan *prefetchedChanges
+		if numBatches <= ss.replInfo.replConfig.ApplyPrefetch && res.NumberOfChanges == chngsPerBatch {
+			next = make(chan *prefetchedChanges, 1)
+			go ss.prefetchChanges(nextChangeNumber(res), chngsPerBatch, next)
+		}
+		err := ss.applyChanges(res)
+		if err == nil {
+			ss.replInfo.lastReplTime = hlc.UnixNow()
+		}
+		if next == nil {
+			return err
+		}
+		prefetched := <-next
+		switch {
+		case err != nil:
+			return err
+		case prefetched.err != nil:
+			return prefetched.err
+		case prefetched.res.Status.Code != 0:
+			// this is an error from DKV master's end
+			return errors.New(prefetched.res.Status.Message)
+		}
+		// Changes skipped by the apply are retrieved again later
+		fromChngNum := nextChangeNumber(res)
+		if fromChngNum != ss.replInfo.fromChngNum {
+			ss.serveropts.Logger.Warn("Discarding the changes retrieved ahead of their apply",
+				zap.Uint64("RetrievedFromChangeNumber", fromChngNum), zap.Uint64("FromChangeNumber", ss.replInfo.fromChngNum))
+			return nil
+		}
+		if err = ss.checkMasterChangeNumber(prefetched.res, fromChngNum); err != nil {
+			return err
+		}
+		ss.serveropts.StatsCli.Incr("slave.apply.prefetched", 1)
+		res = prefetched.res
+	}
+}
+
+func (ss *slaveService) prefetchChanges(fromChngNum uint64, chngsPerBatch uint32, next chan<- *prefetchedChanges) {
+	res, err := ss.replInfo.replCli.AwaitChanges(ss.replicaID(), fromChngNum, chngsPerBatch, 0)
+	next <- &prefetchedChanges{res, err}
+}
+
+// nextChangeNumber computes the change number following the last one of
+// the given changes, given that there is at least one of them.
+func nextChangeNumber(res *serverpb.GetChangesResponse) uint64 {
+	last := res.Changes[len(res.Changes)-1]
+	if last.NumberOfTrxns > 1 {
+		return last.ChangeNumber + uint64(last.NumberOfTrxns)
+	}
+	return last.ChangeNumber + 1
+}
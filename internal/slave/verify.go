@@ -0,0 +1,224 @@
+package slave
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	// verifyRangesPerInterval is the number of ranges verified with the
+	// master every verification interval.
+	verifyRangesPerInterval = 10
+	// defaultVerifyKeysPerRange is the number of keys of every verified
+	// range, unless configured otherwise.
+	defaultVerifyKeysPerRange = 1000
+	// maxDivergentRanges caps the number of divergent ranges tracked, so
+	// that a slave diverging entirely does not exhaust its memory.
+	maxDivergentRanges = 1000
+)
+
+var errVerifierDisabled = errors.New("consistency verification is not enabled on this slave")
+
+// consistencyVerifier periodically verifies successive ranges of the
+// keyspace of this slave against those of its master by comparing their
+// digests, so that silent divergence is detected without transferring
+// the keys. Unlike the scrubber, which samples a few keys at a time, it
+// eventually covers the entire keyspace every pass.
+type consistencyVerifier struct {
+	ss     *slaveService
+	cursor []byte
+	stop   chan struct{}
+	done   chan struct{}
+
+	mu         sync.Mutex
+	divergent  map[string]*serverpb.DivergentRange
+	lastPassAt time.Time
+}
+
+// startConsistencyVerifier begins verifying the consistency of this slave
+// with its master at the configured interval, unless disabled.
+func (ss *slaveService) startConsistencyVerifier() {
+	interval := ss.replInfo.replConfig.VerifyInterval
+	if interval <= 0 {
+		return
+	}
+	cv := &consistencyVerifier{ss: ss, stop: make(chan struct{}), done: make(chan struct{}),
+		divergent: make(map[string]*serverpb.DivergentRange)}
+	ss.verifier = cv
+	go func() {
+		defer close(cv.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := cv.verify(); err != nil {
+					ss.serveropts.Logger.Warn("Unable to verify consistency with master", zap.Error(err))
+				}
+			case <-cv.stop:
+				return
+			}
+		}
+	}()
+}
+
+func (ss *slaveService) stopConsistencyVerifier() {
+	if cv := ss.verifier; cv != nil {
+		close(cv.stop)
+		<-cv.done
+	}
+}
+
+// verify compares the digests of the next ranges with the master. Ranges
+// are only verified when the slave has caught up, while the divergent
+// ranges are digested again on both sides before being reported, so that
+// the changes made in the meantime are not mistaken for divergence.
+func (cv *consistencyVerifier) verify() error {
+	ss := cv.ss
+	replCli := ss.replInfo.replCli
+	if replCli == nil || !ss.replInfo.replActive || ss.replInfo.replLag > 0 {
+		return nil
+	}
+	keysPerRange := ss.replInfo.replConfig.VerifyKeysPerRange
+	if keysPerRange == 0 {
+		keysPerRange = defaultVerifyKeysPerRange
+	}
+	masterDigests, err := replCli.GetRangeDigests(&serverpb.GetRangeDigestsRequest{StartKey: cv.cursor,
+		KeysPerRange: keysPerRange, MaxRanges: verifyRangesPerInterval})
+	if err != nil || len(masterDigests) == 0 {
+		return err
+	}
+	for _, masterDigest := range masterDigests {
+		if err = cv.verifyRange(masterDigest); err != nil {
+			return err
+		}
+	}
+	ss.serveropts.StatsCli.Incr("replication.verify.ranges", int64(len(masterDigests)))
+
+	if last := masterDigests[len(masterDigests)-1]; len(last.EndKey) > 0 {
+		cv.cursor = last.EndKey
+		return nil
+	}
+	cv.cursor = nil
+	cv.mu.Lock()
+	cv.lastPassAt = time.Now()
+	cv.mu.Unlock()
+	ss.serveropts.StatsCli.Incr("replication.verify.passes", 1)
+	return nil
+}
+
+// verifyRange compares the given digest of the master with the local one
+// of the same range, rechecking both upon a mismatch.
+func (cv *consistencyVerifier) verifyRange(masterDigest *serverpb.RangeDigest) error {
+	ss := cv.ss
+	localDigest, err := cv.localDigest(masterDigest)
+	if err != nil {
+		return err
+	}
+	if localDigest.Digest != masterDigest.Digest || localDigest.NumKeys != masterDigest.NumKeys {
+		masterDigests, err := ss.replInfo.replCli.GetRangeDigests(&serverpb.GetRangeDigestsRequest{
+			StartKey: masterDigest.StartKey, EndKey: masterDigest.EndKey})
+		if err != nil || len(masterDigests) == 0 {
+			return err
+		}
+		masterDigest = masterDigests[0]
+		if localDigest, err = cv.localDigest(masterDigest); err != nil {
+			return err
+		}
+	}
+
+	cv.mu.Lock()
+	defer cv.mu.Unlock()
+	// Ranges verified afresh supersede the overlapping ones tracked earlier,
+	// whose bounds may have shifted since
+	for key, rng := range cv.divergent {
+		if rangesOverlap(rng.StartKey, rng.EndKey, masterDigest.StartKey, masterDigest.EndKey) {
+			delete(cv.divergent, key)
+		}
+	}
+	if localDigest.Digest == masterDigest.Digest && localDigest.NumKeys == masterDigest.NumKeys {
+		return nil
+	}
+	ss.serveropts.Logger.Error("Verifier found range diverging from master", zap.ByteString("StartKey", masterDigest.StartKey),
+		zap.ByteString("EndKey", masterDigest.EndKey), zap.Uint64("MasterNumKeys", masterDigest.NumKeys),
+		zap.Uint64("SlaveNumKeys", localDigest.NumKeys), zap.String("MasterAddress", ss.replInfo.replConfig.ReplMasterAddr))
+	ss.serveropts.StatsCli.Incr("replication.verify.mismatches", 1)
+	if len(cv.divergent) < maxDivergentRanges {
+		cv.divergent[string(masterDigest.StartKey)] = &serverpb.DivergentRange{StartKey: masterDigest.StartKey,
+			EndKey: masterDigest.EndKey, MasterNumKeys: masterDigest.NumKeys, SlaveNumKeys: localDigest.NumKeys,
+			DetectedTimestamp: time.Now().UnixNano() / int64(time.Millisecond)}
+	}
+	return nil
+}
+
+// localDigest computes the digest of the same range as the given one.
+func (cv *consistencyVerifier) localDigest(rng *serverpb.RangeDigest) (*serverpb.RangeDigest, error) {
+	ss := cv.ss
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+	iterReq := &serverpb.IterateRequest{StartKey: rng.StartKey}
+	digests, err := storage.DigestRanges(context.Background(), storage.NewIteration(ss.store, iterReq),
+		&serverpb.GetRangeDigestsRequest{StartKey: rng.StartKey, EndKey: rng.EndKey})
+	if err != nil {
+		return nil, err
+	}
+	return digests[0], nil
+}
+
+// divergentRanges retrieves the divergent ranges in the order of their
+// start keys, along with the time at which the last pass completed.
+func (cv *consistencyVerifier) divergentRanges() ([]*serverpb.DivergentRange, time.Time) {
+	cv.mu.Lock()
+	defer cv.mu.Unlock()
+	ranges := make([]*serverpb.DivergentRange, 0, len(cv.divergent))
+	for _, rng := range cv.divergent {
+		ranges = append(ranges, rng)
+	}
+	sort.Slice(ranges, func(i, j int) bool { return bytes.Compare(ranges[i].StartKey, ranges[j].StartKey) < 0 })
+	return ranges, cv.lastPassAt
+}
+
+// rangesOverlap checks if the given ranges overlap, with empty end keys
+// extending upto the end of the keyspace.
+func rangesOverlap(startA, endA, startB, endB []byte) bool {
+	return (len(endB) == 0 || bytes.Compare(startA, endB) < 0) && (len(endA) == 0 || bytes.Compare(startB, endA) < 0)
+}
+
+func (ss *slaveService) GetRangeDigests(ctx context.Context, digestReq *serverpb.GetRangeDigestsRequest) (*serverpb.GetRangeDigestsResponse, error) {
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+
+	iterReq := &serverpb.IterateRequest{StartKey: digestReq.StartKey}
+	digests, err := storage.DigestRanges(ctx, storage.NewIteration(ss.store, iterReq), digestReq)
+	if err != nil {
+		ss.serveropts.Logger.Error("Unable to compute range digests", zap.Error(err))
+		return &serverpb.GetRangeDigestsResponse{Status: newErrorStatus(err)}, err
+	}
+	return &serverpb.GetRangeDigestsResponse{Status: newEmptyStatus(), Digests: digests}, nil
+}
+
+// GetDivergentRanges retrieves the ranges found to diverge from the master
+// by the consistency verifier of this slave.
+func (ss *slaveService) GetDivergentRanges(_ context.Context, _ *emptypb.Empty) (*serverpb.GetDivergentRangesResponse, error) {
+	if ss.verifier == nil {
+		return &serverpb.GetDivergentRangesResponse{Status: newErrorStatus(errVerifierDisabled)},
+			status.Error(codes.FailedPrecondition, errVerifierDisabled.Error())
+	}
+	ranges, lastPassAt := ss.verifier.divergentRanges()
+	res := &serverpb.GetDivergentRangesResponse{Status: newEmptyStatus(), Ranges: ranges}
+	if !lastPassAt.IsZero() {
+		res.LastPassTimestamp = lastPassAt.UnixNano() / int64(time.Millisecond)
+	}
+	return res, nil
+}
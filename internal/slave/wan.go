@@ -0,0 +1,187 @@
+package slave
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/internal/hlc"
+	"github.com/flipkart-incubator/dkv/pkg/ctl"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"go.uber.org/zap"
+)
+
+// Slaves replicating across datacenters retrieve the changes over a single
+// long lived stream from their master, instead of polling for them. The
+// master batches the changes into the responses of the stream as per the
+// batch size and latency targets of the slave, sparing the round trips of
+// many small batches over high latency links. Such streams are typically
+// compressed using zstd, which suits the highly repetitive changes.
+//
+// Given a limit on the changes in flight, the master pauses the stream
+// once the slave falls behind by that many bytes. Slaves acknowledge the
+// applied changes by reporting their replication lag after every batch,
+// which is done in the background so that applying the changes is not
+// held up by the round trips of the acknowledgements. The acknowledgements
+// are repeated periodically in case any is lost, while the master ends a
+// stream paused for long, for the slave to resume it afresh.
+
+var errReplicationStopping = errors.New("replication from the master is being stopped")
+
+// changeStream is the stream of changes from the master of a slave.
+type changeStream struct {
+	replCli *ctl.DKVClient
+	stream  serverpb.DKVReplication_StreamChangesClient
+	cancel  context.CancelFunc
+	// change numbers upto which the changes are applied, yet to be
+	// acknowledged, nil unless the stream is flow controlled
+	acks chan uint64
+}
+
+// openChangeStream subscribes to the changes of the current master from
+// the change number following the last applied change, unless already
+// subscribed to that master. No stream is opened once the replication is
+// being stopped.
+func (ss *slaveService) openChangeStream() (*changeStream, error) {
+	ss.replInfo.streamMu.Lock()
+	defer ss.replInfo.streamMu.Unlock()
+	if cs := ss.replInfo.changeStream; cs != nil && cs.replCli == ss.replInfo.replCli {
+		return cs, nil
+	}
+	ss.closeChangeStreamLocked()
+	if atomic.LoadUint32(&ss.replStopping) != 0 {
+		return nil, errReplicationStopping
+	}
+
+	replConfig := ss.replInfo.replConfig
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := ss.replInfo.replCli.StreamChangesWithOptions(ctx, ss.replInfo.fromChngNum, ctl.ChangeStreamOptions{
+		ReplicaID:     ss.replicaID(),
+		MaxNumChanges: replConfig.MaxNumChngs,
+		MaxBatchBytes: replConfig.WANBatchBytes,
+		MaxBatchDelay: replConfig.WANBatchDelay,
+		// Heartbeats keep the slave active while the master is idle
+		Heartbeat:        replConfig.ReplPollInterval,
+		MaxInFlightBytes: replConfig.WANMaxInFlightBytes,
+	})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	ss.serveropts.Logger.Info("Streaming changes from master", zap.Uint64("FromChangeNumber", ss.replInfo.fromChngNum),
+		zap.String("MasterAddress", replConfig.ReplMasterAddr))
+	cs := &changeStream{replCli: ss.replInfo.replCli, stream: stream, cancel: cancel}
+	if replConfig.WANMaxInFlightBytes > 0 {
+		cs.acks = make(chan uint64, 1)
+		go ss.acknowledgeChanges(ctx, cs.replCli, cs.acks, replConfig.ReplPollInterval)
+	}
+	ss.replInfo.changeStream = cs
+	return cs, nil
+}
+
+// acknowledge hands over the given change number, upto which the changes
+// are applied, for it to be acknowledged to the master. Replaces the change
+// number yet to be acknowledged, if any.
+func (cs *changeStream) acknowledge(chngNum uint64) {
+	if cs.acks == nil {
+		return
+	}
+	select {
+	case <-cs.acks:
+	default:
+	}
+	cs.acks <- chngNum
+}
+
+// acknowledgeChanges acknowledges the applied changes handed over on the
+// given channel to the master, until the given context is done. The last
+// acknowledgement is sent again once every given interval, so that a lost
+// one does not leave the stream paused.
+func (ss *slaveService) acknowledgeChanges(ctx context.Context, replCli *ctl.DKVClient, acks <-chan uint64, interval time.Duration) {
+	tckr := time.NewTicker(interval)
+	defer tckr.Stop()
+	var ackedChngNum uint64
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ackedChngNum = <-acks:
+		case <-tckr.C:
+			if ackedChngNum == 0 {
+				continue
+			}
+		}
+		if err := replCli.ReportReplicationLag(ss.replicaID(), ackedChngNum, time.Now()); err != nil {
+			ss.serveropts.Logger.Warn("Unable to acknowledge the applied changes to master", zap.Error(err))
+			ss.serveropts.StatsCli.Incr("slave.acknowledge.changes.errors", 1)
+		}
+	}
+}
+
+// closeChangeStream unsubscribes from the changes of the master, if at
+// all, which also aborts any change being received over the stream.
+func (ss *slaveService) closeChangeStream() {
+	ss.replInfo.streamMu.Lock()
+	defer ss.replInfo.streamMu.Unlock()
+	ss.closeChangeStreamLocked()
+}
+
+func (ss *slaveService) closeChangeStreamLocked() {
+	if cs := ss.replInfo.changeStream; cs != nil {
+		cs.cancel()
+		ss.replInfo.changeStream = nil
+	}
+}
+
+// applyChangesFromStream applies the next batch of changes received over
+// the stream from the master, subscribing to it if needed. The stream is
+// closed upon any failure, for it to be resumed from the last applied
+// change by the next attempt.
+func (ss *slaveService) applyChangesFromStream() error {
+	defer ss.serveropts.StatsCli.Timing("slave.applyChangesFromStream.latency.ms", time.Now())
+
+	if ss.replInfo.replCli == nil || !ss.replInfo.replActive {
+		return errors.New("can not replicate as unable to connect to an active master")
+	}
+	cs, err := ss.openChangeStream()
+	if err != nil {
+		return err
+	}
+	res, err := cs.stream.Recv()
+	if err == nil {
+		err = ss.applyStreamedChanges(res)
+	}
+	if err != nil {
+		ss.closeChangeStream()
+		return err
+	}
+	if res.NumberOfChanges > 0 {
+		cs.acknowledge(ss.replInfo.fromChngNum - 1)
+	}
+	return nil
+}
+
+func (ss *slaveService) applyStreamedChanges(res *serverpb.GetChangesResponse) error {
+	if res.Status.Code != 0 {
+		// this is an error from DKV master's end
+		return errors.New(res.Status.Message)
+	}
+	if res.MasterChangeNumber < (ss.replInfo.fromChngNum - 1) {
+		return errors.New("change number of the master node can not be lesser than the change number of the slave node")
+	}
+	if res.NumberOfChanges > 0 && res.Changes[0].ChangeNumber < ss.replInfo.fromChngNum {
+		// Changes were applied otherwise since subscribing, such as
+		// upon bootstrapping from a checkpoint
+		return fmt.Errorf("streamed change number %d precedes the change number %d to apply", res.Changes[0].ChangeNumber, ss.replInfo.fromChngNum)
+	}
+
+	ss.rwl.RLock()
+	defer ss.rwl.RUnlock()
+	if err := ss.applyChanges(res); err != nil {
+		return err
+	}
+	ss.replInfo.lastReplTime = hlc.UnixNow()
+	return nil
+}
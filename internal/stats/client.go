@@ -1,12 +1,40 @@
 package stats
 
 import (
+	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	"github.com/smira/go-statsd"
 )
 
+// Backend identifies the metrics sink to which the measurements are sent.
+type Backend string
+
+const (
+	// BackendStatsD sends the measurements to a StatsD agent, which
+	// includes the DogStatsD and CloudWatch agents.
+	BackendStatsD Backend = "statsd"
+	// BackendPrometheus exposes the measurements for scraping by Prometheus.
+	BackendPrometheus Backend = "prometheus"
+	// BackendOTLP pushes the measurements to an OpenTelemetry collector.
+	BackendOTLP Backend = "otlp"
+)
+
+// ParseBackend converts the given string into a Backend, defaulting
+// to StatsD when empty.
+func ParseBackend(backend string) (Backend, error) {
+	switch metricsBackend := Backend(strings.ToLower(strings.TrimSpace(backend))); metricsBackend {
+	case "":
+		return BackendStatsD, nil
+	case BackendStatsD, BackendPrometheus, BackendOTLP:
+		return metricsBackend, nil
+	default:
+		return "", fmt.Errorf("unknown metrics backend: %s, must be one of statsd|prometheus|otlp", backend)
+	}
+}
+
 // Tag represents the key value pair that is sent
 // along with every measurement to a metrics sink.
 type Tag struct {
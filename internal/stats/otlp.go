@@ -0,0 +1,184 @@
+package stats
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/grpc"
+)
+
+type otlpHistogram struct {
+	count   uint64
+	sum     float64
+	buckets []uint64
+}
+
+type otlpClient struct {
+	mu         sync.Mutex
+	conn       *grpc.ClientConn
+	cli        colmetricspb.MetricsServiceClient
+	prfx       string
+	resource   *resourcepb.Resource
+	startTime  time.Time
+	interval   time.Duration
+	counters   map[string]int64
+	gauges     map[string]int64
+	histograms map[string]*otlpHistogram
+	stop       chan struct{}
+	done       chan struct{}
+}
+
+// NewOTLPClient creates a metrics client that pushes various measurements
+// to an OpenTelemetry collector over OTLP/gRPC once every given interval.
+// Counters and timings are pushed as cumulative sums and histograms in
+// milliseconds respectively, while the default tags are pushed as the
+// attributes of the resource emitting the measurements.
+func NewOTLPClient(endpoint, metricPrfx string, pushInterval time.Duration, defTags ...Tag) (Client, error) {
+	conn, err := grpc.Dial(endpoint, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	attrs := make([]*commonpb.KeyValue, len(defTags))
+	for i, defTag := range defTags {
+		attrs[i] = &commonpb.KeyValue{
+			Key:   defTag.key,
+			Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: defTag.val}},
+		}
+	}
+	oc := &otlpClient{
+		conn:       conn,
+		cli:        colmetricspb.NewMetricsServiceClient(conn),
+		prfx:       metricPrfx,
+		resource:   &resourcepb.Resource{Attributes: attrs},
+		startTime:  time.Now(),
+		interval:   pushInterval,
+		counters:   make(map[string]int64),
+		gauges:     make(map[string]int64),
+		histograms: make(map[string]*otlpHistogram),
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	go oc.pushPeriodically()
+	return oc, nil
+}
+
+func (oc *otlpClient) Incr(name string, value int64) {
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+	oc.counters[name] += value
+}
+
+func (oc *otlpClient) Gauge(name string, value int64) {
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+	oc.gauges[name] = value
+}
+
+func (oc *otlpClient) GaugeDelta(name string, value int64) {
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+	oc.gauges[name] += value
+}
+
+func (oc *otlpClient) Timing(name string, startTime time.Time) {
+	elapsed := float64(time.Since(startTime)) / float64(time.Millisecond)
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+	h, ok := oc.histograms[name]
+	if !ok {
+		h = &otlpHistogram{buckets: make([]uint64, len(timingBuckets)+1)}
+		oc.histograms[name] = h
+	}
+	h.count++
+	h.sum += elapsed
+	h.buckets[sort.SearchFloat64s(timingBuckets, elapsed)]++
+}
+
+// Close pushes the measurements recorded since the last push before
+// closing the connection to the collector.
+func (oc *otlpClient) Close() error {
+	close(oc.stop)
+	<-oc.done
+	oc.push()
+	return oc.conn.Close()
+}
+
+func (oc *otlpClient) pushPeriodically() {
+	defer close(oc.done)
+	ticker := time.NewTicker(oc.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-oc.stop:
+			return
+		case <-ticker.C:
+			oc.push()
+		}
+	}
+}
+
+// push exports the current measurements. Since all the measurements
+// are cumulative, a failed push is made up for by the next one.
+func (oc *otlpClient) push() {
+	req := &colmetricspb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*metricspb.ResourceMetrics{{
+			Resource: oc.resource,
+			InstrumentationLibraryMetrics: []*metricspb.InstrumentationLibraryMetrics{{
+				InstrumentationLibrary: &commonpb.InstrumentationLibrary{Name: "dkv"},
+				Metrics:                oc.metrics(),
+			}},
+		}},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), oc.interval)
+	defer cancel()
+	oc.cli.Export(ctx, req)
+}
+
+func (oc *otlpClient) metrics() []*metricspb.Metric {
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+	startTS, nowTS := uint64(oc.startTime.UnixNano()), uint64(time.Now().UnixNano())
+	metrics := make([]*metricspb.Metric, 0, len(oc.counters)+len(oc.gauges)+len(oc.histograms))
+	for name, value := range oc.counters {
+		metrics = append(metrics, &metricspb.Metric{
+			Name: oc.prfx + name,
+			Data: &metricspb.Metric_IntSum{IntSum: &metricspb.IntSum{
+				DataPoints:             []*metricspb.IntDataPoint{{StartTimeUnixNano: startTS, TimeUnixNano: nowTS, Value: value}},
+				AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+				IsMonotonic:            true,
+			}},
+		})
+	}
+	for name, value := range oc.gauges {
+		metrics = append(metrics, &metricspb.Metric{
+			Name: oc.prfx + name,
+			Data: &metricspb.Metric_IntGauge{IntGauge: &metricspb.IntGauge{
+				DataPoints: []*metricspb.IntDataPoint{{StartTimeUnixNano: startTS, TimeUnixNano: nowTS, Value: value}},
+			}},
+		})
+	}
+	for name, h := range oc.histograms {
+		metrics = append(metrics, &metricspb.Metric{
+			Name: oc.prfx + name,
+			Unit: "ms",
+			Data: &metricspb.Metric_DoubleHistogram{DoubleHistogram: &metricspb.DoubleHistogram{
+				DataPoints: []*metricspb.DoubleHistogramDataPoint{{
+					StartTimeUnixNano: startTS,
+					TimeUnixNano:      nowTS,
+					Count:             h.count,
+					Sum:               h.sum,
+					BucketCounts:      append([]uint64(nil), h.buckets...),
+					ExplicitBounds:    timingBuckets,
+				}},
+				AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+			}},
+		})
+	}
+	return metrics
+}
@@ -0,0 +1,71 @@
+package stats
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	"google.golang.org/grpc"
+)
+
+type otlpCollector struct {
+	colmetricspb.UnimplementedMetricsServiceServer
+	reqs chan *colmetricspb.ExportMetricsServiceRequest
+}
+
+func (oc *otlpCollector) Export(_ context.Context, req *colmetricspb.ExportMetricsServiceRequest) (*colmetricspb.ExportMetricsServiceResponse, error) {
+	oc.reqs <- req
+	return &colmetricspb.ExportMetricsServiceResponse{}, nil
+}
+
+func TestOTLPClient(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	collector := &otlpCollector{reqs: make(chan *colmetricspb.ExportMetricsServiceRequest, 1)}
+	grpcSrvr := grpc.NewServer()
+	colmetricspb.RegisterMetricsServiceServer(grpcSrvr, collector)
+	go grpcSrvr.Serve(lis)
+	defer grpcSrvr.Stop()
+
+	statsCli, err := NewOTLPClient(lis.Addr().String(), "dkv.", time.Hour, NewTag("node-name", "node1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	statsCli.Incr("sample.counter", 5)
+	statsCli.Incr("sample.counter", 2)
+	statsCli.GaugeDelta("sample.gauge", 42)
+	statsCli.Timing("sample.latency.ms", time.Now())
+	// Closing pushes the measurements recorded since the last push
+	if err = statsCli.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var req *colmetricspb.ExportMetricsServiceRequest
+	select {
+	case req = <-collector.reqs:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the measurements to be pushed to the collector")
+	}
+	rm := req.ResourceMetrics[0]
+	if attr := rm.Resource.Attributes[0]; attr.Key != "node-name" || attr.Value.GetStringValue() != "node1" {
+		t.Errorf("Expected the default tag as a resource attribute, but got %v", attr)
+	}
+	metrics := make(map[string]*metricspb.Metric)
+	for _, metric := range rm.InstrumentationLibraryMetrics[0].Metrics {
+		metrics[metric.Name] = metric
+	}
+	if value := metrics["dkv.sample.counter"].GetIntSum().DataPoints[0].Value; value != 7 {
+		t.Errorf("Expected counter value 7, but got %d", value)
+	}
+	if value := metrics["dkv.sample.gauge"].GetIntGauge().DataPoints[0].Value; value != 42 {
+		t.Errorf("Expected gauge value 42, but got %d", value)
+	}
+	if count := metrics["dkv.sample.latency.ms"].GetDoubleHistogram().DataPoints[0].Count; count != 1 {
+		t.Errorf("Expected timing count 1, but got %d", count)
+	}
+}
@@ -0,0 +1,127 @@
+package stats
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// timingBuckets are the upper bounds in milliseconds of the
+// histogram buckets into which the timings are recorded.
+var timingBuckets = prometheus.ExponentialBuckets(0.25, 2, 18)
+
+type prometheusClient struct {
+	mu         sync.Mutex
+	reg        *prometheus.Registry
+	prfx       string
+	labels     prometheus.Labels
+	counters   map[string]prometheus.Counter
+	gauges     map[string]prometheus.Gauge
+	histograms map[string]prometheus.Histogram
+}
+
+// NewPrometheusClient creates a metrics client that records various
+// measurements for scraping by Prometheus through the returned handler.
+// Metric names are sanitized by replacing the characters not permitted
+// by Prometheus with underscores, while timings are recorded as
+// histograms in milliseconds.
+func NewPrometheusClient(metricPrfx string, defTags ...Tag) (Client, http.Handler) {
+	labels := make(prometheus.Labels, len(defTags))
+	for _, defTag := range defTags {
+		labels[prometheusName(defTag.key)] = defTag.val
+	}
+	reg := prometheus.NewRegistry()
+	pc := &prometheusClient{
+		reg:        reg,
+		prfx:       metricPrfx,
+		labels:     labels,
+		counters:   make(map[string]prometheus.Counter),
+		gauges:     make(map[string]prometheus.Gauge),
+		histograms: make(map[string]prometheus.Histogram),
+	}
+	return pc, promhttp.HandlerFor(reg, promhttp.HandlerOpts{})
+}
+
+func (pc *prometheusClient) Incr(name string, value int64) {
+	// Prometheus counters can only increase
+	if value < 0 {
+		return
+	}
+	pc.counter(name).Add(float64(value))
+}
+
+func (pc *prometheusClient) Gauge(name string, value int64) {
+	pc.gauge(name).Set(float64(value))
+}
+
+func (pc *prometheusClient) GaugeDelta(name string, value int64) {
+	pc.gauge(name).Add(float64(value))
+}
+
+func (pc *prometheusClient) Timing(name string, startTime time.Time) {
+	pc.histogram(name).Observe(float64(time.Since(startTime)) / float64(time.Millisecond))
+}
+
+func (pc *prometheusClient) Close() error {
+	return nil
+}
+
+func (pc *prometheusClient) counter(name string) prometheus.Counter {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	c, ok := pc.counters[name]
+	if !ok {
+		c = prometheus.NewCounter(prometheus.CounterOpts{Name: prometheusName(pc.prfx + name), ConstLabels: pc.labels})
+		pc.register(c)
+		pc.counters[name] = c
+	}
+	return c
+}
+
+func (pc *prometheusClient) gauge(name string) prometheus.Gauge {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	g, ok := pc.gauges[name]
+	if !ok {
+		g = prometheus.NewGauge(prometheus.GaugeOpts{Name: prometheusName(pc.prfx + name), ConstLabels: pc.labels})
+		pc.register(g)
+		pc.gauges[name] = g
+	}
+	return g
+}
+
+func (pc *prometheusClient) histogram(name string) prometheus.Histogram {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	h, ok := pc.histograms[name]
+	if !ok {
+		h = prometheus.NewHistogram(prometheus.HistogramOpts{Name: prometheusName(pc.prfx + name), ConstLabels: pc.labels, Buckets: timingBuckets})
+		pc.register(h)
+		pc.histograms[name] = h
+	}
+	return h
+}
+
+// register adds the given collector to the registry. A name already
+// registered as a different kind of metric is left unexported rather
+// than failing the measurement.
+func (pc *prometheusClient) register(c prometheus.Collector) {
+	_ = pc.reg.Register(c)
+}
+
+// prometheusName converts the given name into a valid Prometheus
+// metric or label name.
+func prometheusName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
@@ -0,0 +1,37 @@
+package stats
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrometheusClient(t *testing.T) {
+	statsCli, handler := NewPrometheusClient("dkv.", NewTag("node-name", "node1"))
+	defer statsCli.Close()
+
+	statsCli.Incr("sample.counter", 5)
+	statsCli.Incr("sample.counter", -1)
+	statsCli.Gauge("sample.gauge", 42)
+	statsCli.GaugeDelta("sample.gauge", -2)
+	statsCli.Timing("sample.latency.ms", time.Now().Add(-3*time.Millisecond))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, err := ioutil.ReadAll(rec.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	metrics := string(body)
+	for _, expected := range []string{
+		`dkv_sample_counter{node_name="node1"} 5`,
+		`dkv_sample_gauge{node_name="node1"} 40`,
+		`dkv_sample_latency_ms_count{node_name="node1"} 1`,
+	} {
+		if !strings.Contains(metrics, expected) {
+			t.Errorf("Expected metric: %s in scraped metrics: %s", expected, metrics)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+)
+
+// In an active-active setup, two masters accept writes and replicate them
+// to each other. Every write of a key records the origin of that write,
+// which is the ID of the master accepting it along with its timestamp as
+// per the hybrid logical clock of that master. When the replicated write
+// of a key conflicts with the local write of that key, the conflict is
+// resolved by comparing their origins, so that both masters converge to
+// the same value irrespective of the order in which they apply the writes.
+
+// RecordOrigin identifies the master at which a record was last written,
+// along with the timestamp of that write.
+type RecordOrigin struct {
+	OriginID  string
+	Timestamp uint64
+}
+
+var (
+	originPrefix     = append(append([]byte(nil), reservedKeyPrefix...), "origin:"...)
+	peerChangePrefix = append(append([]byte(nil), reservedKeyPrefix...), "peer-change:"...)
+
+	errMalformedOrigin = errors.New("malformed record origin")
+)
+
+// OriginKey is the internal key under which the origin of the given key
+// is recorded. Origins outlive the deletes of their keys, so that deletes
+// are also resolved against the conflicting writes.
+func OriginKey(key []byte) []byte {
+	return append(append([]byte(nil), originPrefix...), key...)
+}
+
+// UserKeyOfOrigin extracts the key whose origin is recorded under the
+// given internal key, if it is the key of an origin at all.
+func UserKeyOfOrigin(originKey []byte) ([]byte, bool) {
+	if !bytes.HasPrefix(originKey, originPrefix) {
+		return nil, false
+	}
+	return originKey[len(originPrefix):], true
+}
+
+// PeerChangeKey is the internal key under which the change number of the
+// latest change applied from the given peer master is recorded.
+func PeerChangeKey(peerID string) []byte {
+	return append(append([]byte(nil), peerChangePrefix...), peerID...)
+}
+
+// Marshal encodes the origin as its timestamp followed by its ID.
+func (ro *RecordOrigin) Marshal() []byte {
+	data := make([]byte, 8, 8+len(ro.OriginID))
+	binary.BigEndian.PutUint64(data, ro.Timestamp)
+	return append(data, ro.OriginID...)
+}
+
+// UnmarshalRecordOrigin decodes the origin encoded by Marshal.
+func UnmarshalRecordOrigin(data []byte) (*RecordOrigin, error) {
+	if len(data) < 8 {
+		return nil, errMalformedOrigin
+	}
+	return &RecordOrigin{Timestamp: binary.BigEndian.Uint64(data), OriginID: string(data[8:])}, nil
+}
+
+// A ConflictResolver decides between the conflicting writes of a key made
+// at different masters. Resolvers must be deterministic, so that either
+// master reaches the same decision.
+type ConflictResolver interface {
+	// Resolve checks if the write of the given key replicated from the
+	// peer master, made at the given remote origin, supersedes the local
+	// write of that key made at the given local origin.
+	Resolve(key []byte, local, remote *RecordOrigin) bool
+}
+
+// LastWriterWins resolves the conflicts in favour of the later write, with
+// the writes made at the same time ordered by the IDs of their origins.
+var LastWriterWins ConflictResolver = lastWriterWins{}
+
+type lastWriterWins struct{}
+
+func (lastWriterWins) Resolve(_ []byte, local, remote *RecordOrigin) bool {
+	if remote.Timestamp != local.Timestamp {
+		return remote.Timestamp > local.Timestamp
+	}
+	return remote.OriginID > local.OriginID
+}
+
+// A PeerChangeApplier represents the capability of the underlying store to
+// apply the changes replicated from its peer master in an active-active
+// setup. Unlike a ChangeApplier, the changes are applied as new changes
+// of the store, after resolving their conflicts with the local writes.
+type PeerChangeApplier interface {
+	// GetLatestPeerChangeNumber retrieves the change number of the latest
+	// change applied from the given peer.
+	GetLatestPeerChangeNumber(peerID string) (uint64, error)
+	// ApplyPeerChanges applies the given changes of the given peer, with
+	// the writes that conflict with the local writes applied only if the
+	// given resolver decides so. Returns the change number of the latest
+	// change applied from the peer along with an error if any, in which
+	// case the remaining changes are not applied.
+	ApplyPeerChanges(peerID string, changes []*serverpb.ChangeRecord, resolver ConflictResolver) (uint64, error)
+}
@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRecordOrigin(t *testing.T) {
+	origin := &RecordOrigin{OriginID: "dc1", Timestamp: 1234}
+	decoded, err := UnmarshalRecordOrigin(origin.Marshal())
+	if err != nil || *decoded != *origin {
+		t.Errorf("Origin mismatch. Expected: %v, Actual: %v, Error: %v", origin, decoded, err)
+	}
+	if _, err = UnmarshalRecordOrigin([]byte("dc1")); err == nil {
+		t.Error("Expected an error for a malformed origin")
+	}
+
+	key := []byte("some-key")
+	if userKey, ok := UserKeyOfOrigin(OriginKey(key)); !ok || !bytes.Equal(userKey, key) {
+		t.Errorf("Expected the key %q to be extracted, but got %q", key, userKey)
+	}
+	if _, ok := UserKeyOfOrigin(key); ok {
+		t.Errorf("Expected %q not to be the key of an origin", key)
+	}
+	if !IsReservedKey(OriginKey(key)) || !IsReservedKey(PeerChangeKey("dc2")) {
+		t.Error("Expected the keys of origins and peer changes to be reserved")
+	}
+}
+
+func TestLastWriterWins(t *testing.T) {
+	key := []byte("some-key")
+	earlier, later := &RecordOrigin{"dc2", 100}, &RecordOrigin{"dc1", 200}
+	if !LastWriterWins.Resolve(key, earlier, later) || LastWriterWins.Resolve(key, later, earlier) {
+		t.Error("Expected the later write to win")
+	}
+	// Writes made at the same time are ordered by their origins on either side
+	dc1, dc2 := &RecordOrigin{"dc1", 100}, &RecordOrigin{"dc2", 100}
+	if !LastWriterWins.Resolve(key, dc1, dc2) || LastWriterWins.Resolve(key, dc2, dc1) {
+		t.Error("Expected the write of the greater origin to win")
+	}
+}
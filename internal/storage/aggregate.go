@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+)
+
+// Number of keys aggregated between the checks for the cancellation of
+// an aggregation
+const aggregateCancelCheckInterval = 1024
+
+var errInvalidAggregateRange = errors.New("EndKey must follow StartKey")
+
+// Aggregate computes the aggregates of the key value pairs requested by
+// the given request, iterated using the given iteration, which iterates
+// the keys in their ascending order. Stops as soon as the given context
+// is done.
+func Aggregate(ctx context.Context, iteration Iteration, aggReq *serverpb.AggregateRequest) (*serverpb.AggregateResponse, error) {
+	if len(aggReq.EndKey) > 0 && bytes.Compare(aggReq.EndKey, aggReq.StartKey) <= 0 {
+		return nil, errInvalidAggregateRange
+	}
+	itrtr, err := iteration.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer itrtr.Close()
+
+	res := &serverpb.AggregateResponse{}
+	for itrtr.HasNext() {
+		kv := itrtr.Next()
+		if len(aggReq.EndKey) > 0 && bytes.Compare(kv.Key, aggReq.EndKey) >= 0 {
+			break
+		}
+		if res.Count%aggregateCancelCheckInterval == 0 {
+			if err = ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		res.Count++
+		res.TotalValueBytes += uint64(len(kv.Value))
+		if res.MinKey == nil || bytes.Compare(kv.Key, res.MinKey) < 0 {
+			res.MinKey = kv.Key
+		}
+		if res.MaxKey == nil || bytes.Compare(kv.Key, res.MaxKey) > 0 {
+			res.MaxKey = kv.Key
+		}
+		if len(kv.Value) == 8 {
+			res.Sum += int64(binary.BigEndian.Uint64(kv.Value))
+			res.NumSummed++
+		}
+	}
+	if err = itrtr.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+)
+
+func TestAggregate(t *testing.T) {
+	iteration, si := newSliceIteration(10)
+	for i, kv := range si.kvs {
+		if i%2 == 0 {
+			kv.Value = make([]byte, 8)
+			binary.BigEndian.PutUint64(kv.Value, uint64(i))
+		} else {
+			kv.Value = []byte("abc")
+		}
+	}
+
+	res, err := Aggregate(context.Background(), iteration, &serverpb.AggregateRequest{EndKey: []byte("key009")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Keys key000 through key008 are aggregated, of which the even ones are summed
+	if res.Count != 9 || res.TotalValueBytes != 5*8+4*3 || string(res.MinKey) != "key000" || string(res.MaxKey) != "key008" {
+		t.Errorf("Unexpected aggregates: %v", res)
+	}
+	if res.Sum != 0+2+4+6+8 || res.NumSummed != 5 {
+		t.Errorf("Expected the sum 20 of 5 values. Actual: %d of %d values", res.Sum, res.NumSummed)
+	}
+	if !si.closed {
+		t.Error("Expected the iterator to be closed at the end of the aggregation")
+	}
+
+	iteration, si = newSliceIteration(2)
+	si.kvs[1].Value = make([]byte, 8)
+	binary.BigEndian.PutUint64(si.kvs[1].Value, ^uint64(99))
+	if res, err = Aggregate(context.Background(), iteration, &serverpb.AggregateRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	if res.Count != 2 || res.Sum != -100 || res.NumSummed != 1 {
+		t.Errorf("Expected the sum -100 of a single value. Actual: %v", res)
+	}
+
+	if _, err = Aggregate(context.Background(), iteration, &serverpb.AggregateRequest{StartKey: []byte("b"), EndKey: []byte("a")}); err == nil {
+		t.Error("Expected an error when the end key precedes the start key")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	iteration, _ = newSliceIteration(1)
+	if _, err = Aggregate(ctx, iteration, &serverpb.AggregateRequest{}); err != context.Canceled {
+		t.Errorf("Expected the aggregation to be cancelled. Actual error: %v", err)
+	}
+}
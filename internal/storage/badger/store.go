@@ -46,10 +46,12 @@ type badgerDB struct {
 }
 
 type bdgrOpts struct {
-	opts         badger.Options
-	lgr          *zap.Logger
-	statsCli     stats.Client
-	sstDirectory string
+	opts                badger.Options
+	lgr                 *zap.Logger
+	statsCli            stats.Client
+	sstDirectory        string
+	multiGetBatchSize   int
+	multiGetParallelism int
 }
 
 // DBOption is used to configure the Badger
@@ -120,6 +122,18 @@ func WithCacheSize(size uint64) DBOption {
 	}
 }
 
+// WithMultiGetParallelism splits the reads of more than the given batch
+// size of keys into batches, with upto the given number of batches read
+// concurrently. All the batches of a read observe the same snapshot.
+func WithMultiGetParallelism(batchSize, parallelism int) DBOption {
+	return func(opts *bdgrOpts) {
+		if batchSize <= 0 || parallelism <= 0 {
+			panic(fmt.Errorf("multi get batch size: %d and parallelism: %d must be positive", batchSize, parallelism))
+		}
+		opts.multiGetBatchSize, opts.multiGetParallelism = batchSize, parallelism
+	}
+}
+
 // WithBadgerConfig can be used to override internal Badger
 // storage settings through the given .ini file.
 func WithBadgerConfig(iniFile string) DBOption {
@@ -233,19 +247,14 @@ func (bdb *badgerDB) Get(keys ...[]byte) ([]*serverpb.KVPair, error) {
 	defer bdb.opts.statsCli.Timing("badger.get.latency.ms", time.Now())
 	var results []*serverpb.KVPair
 	err := bdb.db.View(func(txn *badger.Txn) error {
-		for _, key := range keys {
-			item, err := txn.Get(key)
-			switch err {
-			case nil:
-				value, _ := item.ValueCopy(nil)
-				results = append(results, &serverpb.KVPair{Key: key, Value: value})
-			case badger.ErrKeyNotFound:
-				continue
-			default:
-				return err
-			}
+		// Lookups do not mutate a read-only transaction, hence the
+		// batches can share it and thereby its snapshot
+		read := func(batch ...[]byte) ([]*serverpb.KVPair, error) {
+			return readKeys(txn, batch)
 		}
-		return nil
+		var err error
+		results, err = storage.ParallelGet(read, bdb.opts.multiGetBatchSize, bdb.opts.multiGetParallelism, keys...)
+		return err
 	})
 	if err != nil {
 		bdb.opts.statsCli.Incr("badger.get.errors", 1)
@@ -253,6 +262,23 @@ func (bdb *badgerDB) Get(keys ...[]byte) ([]*serverpb.KVPair, error) {
 	return results, err
 }
 
+func readKeys(txn *badger.Txn, keys [][]byte) ([]*serverpb.KVPair, error) {
+	var results []*serverpb.KVPair
+	for _, key := range keys {
+		item, err := txn.Get(key)
+		switch err {
+		case nil:
+			value, _ := item.ValueCopy(nil)
+			results = append(results, &serverpb.KVPair{Key: key, Value: value})
+		case badger.ErrKeyNotFound:
+			continue
+		default:
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
 func (bdb *badgerDB) CompareAndSet(key, expect, update []byte) (bool, error) {
 	defer bdb.opts.statsCli.Timing("badger.cas.latency.ms", time.Now())
 	casTrxn := bdb.db.NewTransaction(true)
@@ -289,6 +315,22 @@ const (
 	badgerSSTPrefix = "badger-snapshot-"
 )
 
+// Stats retrieves the statistics of Badger. Since Badger does not expose
+// the size of its memtables and the pending compactions, only the number
+// of tables per level and the block cache hit rate are reported.
+func (bdb *badgerDB) Stats() (*storage.EngineStats, error) {
+	es := &storage.EngineStats{NumFilesPerLevel: make([]uint64, bdb.opts.opts.MaxLevels)}
+	for _, table := range bdb.db.Tables(false) {
+		if table.Level >= 0 && table.Level < len(es.NumFilesPerLevel) {
+			es.NumFilesPerLevel[table.Level]++
+		}
+	}
+	if metrics := bdb.db.BlockCacheMetrics(); metrics != nil {
+		es.BlockCacheHitRate = metrics.Ratio()
+	}
+	return es, nil
+}
+
 func (bdb *badgerDB) GetSnapshot() (io.ReadCloser, error) {
 	defer bdb.opts.statsCli.Timing("badger.snapshot.get.latency.ms", time.Now())
 
@@ -510,6 +552,22 @@ func (bdb *badgerDB) SaveChanges(changes []*serverpb.ChangeRecord) (uint64, erro
 			break
 		}
 
+		// Changes applied before a crash or a restart are skipped, as
+		// the applied change number is committed along with them
+		lastChngNum := chng.ChangeNumber
+		if chng.NumberOfTrxns > 1 {
+			lastChngNum += uint64(chng.NumberOfTrxns) - 1
+		}
+		if lastChngNum <= currChngNum {
+			appldChngNum = currChngNum
+			continue
+		}
+		// Changes corrupted in transit are not applied, for them to be
+		// fetched again from the master
+		if lastErr = storage.VerifyChangeChecksum(chng); lastErr != nil {
+			break
+		}
+
 		// Loop through every transaction record of the current change and
 		// apply the operation to the current badger transaction
 		for _, trxnRec := range chng.Trxns {
@@ -532,10 +590,10 @@ func (bdb *badgerDB) SaveChanges(changes []*serverpb.ChangeRecord) (uint64, erro
 			break
 		}
 
-		// Increment and set the change number in the same badger transaction
-		currChngNum = currChngNum + 1
+		// Record the change number of the master in the same badger
+		// transaction, for the replication to resume from it
 		var buf [8]byte
-		binary.BigEndian.PutUint64(buf[:], currChngNum)
+		binary.BigEndian.PutUint64(buf[:], lastChngNum)
 		if lastErr = chngTrxn.Set([]byte(changeNumberKey), buf[:]); lastErr != nil {
 			break
 		}
@@ -544,7 +602,7 @@ func (bdb *badgerDB) SaveChanges(changes []*serverpb.ChangeRecord) (uint64, erro
 		if lastErr = chngTrxn.Commit(); lastErr != nil {
 			break
 		} else {
-			appldChngNum = chng.ChangeNumber
+			appldChngNum = lastChngNum
 		}
 	}
 	return appldChngNum, lastErr
@@ -616,6 +674,14 @@ func (bdb *badgerDB) Iterate(iterOpts storage.IterationOptions) storage.Iterator
 	return bdb.newIter(iterOpts)
 }
 
+// IterateSnapshot is same as Iterate, since every iterator of Badger
+// reads from a read-only transaction, which already observes a single
+// consistent view that is released once the iterator is closed. Read
+// options are not supported by Badger.
+func (bdb *badgerDB) IterateSnapshot(_ *serverpb.ReadOptions, iterOpts storage.IterationOptions) storage.Iterator {
+	return bdb.newIter(iterOpts)
+}
+
 var errGlobalMutation = errors.New("Another global keyspace mutation is in progress")
 
 func (bdb *badgerDB) hasGlobalMutation() bool {
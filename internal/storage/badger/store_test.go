@@ -3,6 +3,7 @@ package badger
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -164,6 +165,47 @@ func TestMultiGet(t *testing.T) {
 	}
 
 	checkGetResults(t, store, keys, vals)
+
+	positionalKeys := [][]byte{keys[1], []byte("MissingMK"), keys[0], keys[1]}
+	if results, err := storage.MultiGet(store.Get, positionalKeys...); err != nil {
+		t.Fatal(err)
+	} else if len(results) != len(positionalKeys) {
+		t.Errorf("Expected %d positional results, but got %d", len(positionalKeys), len(results))
+	} else {
+		expVals := []string{string(vals[1]), "", string(vals[0]), string(vals[1])}
+		for i, result := range results {
+			if !bytes.Equal(result.Key, positionalKeys[i]) || string(result.Value) != expVals[i] {
+				t.Errorf("Positional Multi Get mismatch at %d. Expected Key: %s, Value: %s, Actual Key: %s, Value: %s",
+					i, positionalKeys[i], expVals[i], result.Key, result.Value)
+			}
+		}
+	}
+}
+
+func TestParallelMultiGet(t *testing.T) {
+	store.opts.multiGetBatchSize, store.opts.multiGetParallelism = 3, 2
+	defer func() { store.opts.multiGetBatchSize, store.opts.multiGetParallelism = 0, 0 }()
+
+	numKeys := 10
+	keys, vals := make([][]byte, numKeys), make([][]byte, numKeys)
+	for i := 0; i < numKeys; i++ {
+		key, value := fmt.Sprintf("PMK%d", i), fmt.Sprintf("PMV%d", i)
+		expectNoError(t, store.Put(kvEntry(key, value)))
+		keys[i], vals[i] = []byte(key), []byte(value)
+	}
+	results, err := store.Get(append(keys, []byte("MissingPMK"))...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != numKeys {
+		t.Fatalf("Expected %d results, but got %d", numKeys, len(results))
+	}
+	for i, result := range results {
+		if !bytes.Equal(result.Key, keys[i]) || !bytes.Equal(result.Value, vals[i]) {
+			t.Errorf("Parallel Multi Get mismatch at %d. Expected Key: %s, Value: %s, Actual Key: %s, Value: %s",
+				i, keys[i], vals[i], result.Key, result.Value)
+		}
+	}
 }
 
 func TestMissingGet(t *testing.T) {
@@ -342,6 +384,53 @@ func testSaveChangesForInterleavedPutAndDelete(t *testing.T, bdb DB) {
 	}
 }
 
+func TestSaveChangesResumesFromAppliedChange(t *testing.T) {
+	inMemStore, _ := OpenDB(WithInMemory())
+	defer inMemStore.Close()
+	// Change numbers of the master need not be contiguous on the slave
+	chngRecs := []*serverpb.ChangeRecord{
+		newPutChange(5, []byte("KRSC_1"), []byte("VRSC_1")),
+		newPutChange(9, []byte("KRSC_2"), []byte("VRSC_2")),
+	}
+	chngRecs[1].NumberOfTrxns = 3
+	if appldChng, err := inMemStore.SaveChanges(chngRecs[:1]); err != nil || appldChng != 5 {
+		t.Fatalf("Expected the change to be applied upto 5. Actual: %d, Error: %v", appldChng, err)
+	}
+	if appldChng, err := inMemStore.SaveChanges(chngRecs); err != nil || appldChng != 11 {
+		t.Fatalf("Expected the changes to be applied upto 11. Actual: %d, Error: %v", appldChng, err)
+	}
+	if chngNum, err := inMemStore.GetLatestAppliedChangeNumber(); err != nil || chngNum != 11 {
+		t.Errorf("Expected the applied change number to be persisted as 11. Actual: %d, Error: %v", chngNum, err)
+	}
+	checkGetResults(t, inMemStore, [][]byte{[]byte("KRSC_1"), []byte("KRSC_2")}, [][]byte{[]byte("VRSC_1"), []byte("VRSC_2")})
+}
+
+func TestSaveChangesRejectsCorruptChanges(t *testing.T) {
+	inMemStore, _ := OpenDB(WithInMemory())
+	defer inMemStore.Close()
+	chngRecs := []*serverpb.ChangeRecord{
+		newPutChange(1, []byte("KCRC_1"), []byte("VCRC_1")),
+		newPutChange(2, []byte("KCRC_2"), []byte("VCRC_2")),
+	}
+	for _, chngRec := range chngRecs {
+		storage.SetChangeChecksum(chngRec)
+	}
+	// Corrupting the value of the second change in transit
+	chngRecs[1].Trxns[0].Value[0] ^= 1
+	if appldChng, err := inMemStore.SaveChanges(chngRecs); !errors.Is(err, storage.ErrChangeChecksumMismatch) || appldChng != 1 {
+		t.Fatalf("Expected the corrupt change to be rejected after applying upto 1. Actual: %d, Error: %v", appldChng, err)
+	}
+	checkGetResults(t, inMemStore, [][]byte{[]byte("KCRC_1")}, [][]byte{[]byte("VCRC_1")})
+	checkMissingGetResults(t, inMemStore, [][]byte{[]byte("KCRC_2")})
+
+	// Fetching the change again applies it
+	chngRecs[1].Trxns[0].Value[0] ^= 1
+	if appldChng, err := inMemStore.SaveChanges(chngRecs[1:]); err != nil || appldChng != 2 {
+		t.Fatalf("Expected the change to be applied upto 2. Actual: %d, Error: %v", appldChng, err)
+	}
+	checkGetResults(t, inMemStore, [][]byte{[]byte("KCRC_2")}, [][]byte{[]byte("VCRC_2")})
+}
+
 func TestBackupFileValidity(t *testing.T) {
 	expectError(t, checksForBackup(""))
 	expectError(t, checksForBackup(dbFolder))
@@ -829,6 +918,23 @@ func noKeys(t *testing.T, bdb storage.KVStore, numKeys int, keyPrefix string) {
 	}
 }
 
+func TestStats(t *testing.T) {
+	numKeys, keyPrefix, valPrefix := 100, "StatsKey", "StatsVal"
+	putKeys(t, store, numKeys, keyPrefix, valPrefix)
+	getKeys(t, store, numKeys, keyPrefix, valPrefix)
+
+	es, err := store.Stats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(es.NumFilesPerLevel) != store.opts.opts.MaxLevels {
+		t.Errorf("Expected the number of tables of all the %d levels. Actual: %v", store.opts.opts.MaxLevels, es.NumFilesPerLevel)
+	}
+	if es.BlockCacheHitRate < 0 || es.BlockCacheHitRate > 1 {
+		t.Errorf("Expected block cache hit rate within [0, 1]. Actual: %f", es.BlockCacheHitRate)
+	}
+}
+
 func getKeys(t *testing.T, bdb storage.KVStore, numKeys int, keyPrefix, valPrefix string) {
 	for i := 1; i <= numKeys; i++ {
 		key, expectedValue := fmt.Sprintf("%s_%d", keyPrefix, i), fmt.Sprintf("%s_%d", valPrefix, i)
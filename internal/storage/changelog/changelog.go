@@ -0,0 +1,604 @@
+package changelog
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// An Entry is a single commit recorded in the change log. It covers
+// the change numbers from ChangeNumber up to ChangeNumber+NumberOfTrxns-1.
+type Entry struct {
+	ChangeNumber  uint64
+	NumberOfTrxns uint32
+	Data          []byte
+}
+
+const (
+	segmentExt = ".seg"
+	// Every record is made up of the change number (8 bytes), number of
+	// transactions (4 bytes), data length (4 bytes), data and the CRC32
+	// (4 bytes) of all the preceding fields.
+	recordHeaderSize  = 16
+	recordTrailerSize = 4
+)
+
+var (
+	// ErrOutOfOrder is returned when appending an entry whose change
+	// number does not follow the latest change number of the log.
+	ErrOutOfOrder = errors.New("change number of the entry must follow the latest change number of the log")
+	errCorrupted  = errors.New("corrupted change log record")
+)
+
+// Log is a change log made up of append-only segment files, each named
+// after the first change number it holds. Every segment is accompanied
+// by a sparse index of its records by change number, so that changes
+// can be loaded from any change number by scanning only a few records.
+// Older segments are removed as newer ones are added, retaining up to
+// the configured number of segments.
+type Log struct {
+	mu            sync.RWMutex
+	dir           string
+	segmentSize   int64
+	maxSegments   int
+	indexInterval int64
+	segments      []*segment
+	active        *os.File
+	activeIndex   *os.File
+}
+
+type segment struct {
+	path             string
+	size             int64
+	lastChangeNumber uint64
+	index            []indexEntry
+}
+
+func (seg *segment) empty() bool {
+	return len(seg.index) == 0
+}
+
+func (seg *segment) firstChangeNumber() uint64 {
+	return seg.index[0].changeNumber
+}
+
+// Open opens the change log in the given directory, creating it if
+// needed. Segments are rolled over once they exceed the given size and
+// at most the given number of segments are retained, where zero retains
+// all the segments. Any partially written record at the end of the log,
+// say due to a crash, is discarded.
+func Open(dir string, segmentSize int64, maxSegments int) (*Log, error) {
+	if segmentSize <= 0 || maxSegments < 0 {
+		return nil, fmt.Errorf("invalid change log segment size: %d or maximum segments: %d", segmentSize, maxSegments)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	cl := &Log{dir: dir, segmentSize: segmentSize, maxSegments: maxSegments, indexInterval: defaultIndexInterval}
+	var names []string
+	for _, f := range files {
+		if !f.IsDir() && strings.HasSuffix(f.Name(), segmentExt) {
+			names = append(names, f.Name())
+		}
+	}
+	// Segment names are zero padded change numbers, hence sort as numbers
+	sort.Strings(names)
+	for i, name := range names {
+		seg, err := loadSegment(filepath.Join(dir, name), i == len(names)-1, cl.indexInterval)
+		if err != nil {
+			return nil, err
+		}
+		if seg.empty() {
+			if err = seg.remove(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		cl.segments = append(cl.segments, seg)
+	}
+	if err = cl.openActive(); err != nil {
+		return nil, err
+	}
+	return cl, nil
+}
+
+// loadSegment loads the index of the given segment and scans only the
+// records beyond the last indexed one. The index is rebuilt if it is
+// missing or inconsistent with the segment. For the last segment, a
+// corrupted or partially written tail is truncated.
+func loadSegment(path string, last bool, indexInterval int64) (*segment, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	index, err := readIndex(indexPath(path), fi.Size())
+	if err != nil {
+		return nil, err
+	}
+
+	// Resume from the last indexed record, provided it is intact
+	seg, from := &segment{path: path}, int64(0)
+	if n := len(index); n > 0 {
+		rr := newRecordReader(f, index[n-1].offset, fi.Size())
+		if entry, _, err := rr.next(); err == nil && entry.ChangeNumber == index[n-1].changeNumber {
+			seg.index, from = index[:n-1], index[n-1].offset
+		}
+	}
+	seg.size = from
+	rr := newRecordReader(f, from, fi.Size())
+	for {
+		entry, offset, err := rr.next()
+		if err == io.EOF {
+			break
+		}
+		if err == errCorrupted || err == io.ErrUnexpectedEOF {
+			if !last {
+				return nil, fmt.Errorf("change log segment: %s is corrupted at offset: %d", path, offset)
+			}
+			if err = os.Truncate(path, offset); err != nil {
+				return nil, err
+			}
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if offset == from || seg.indexable(offset, indexInterval) {
+			seg.index = append(seg.index, indexEntry{entry.ChangeNumber, offset})
+		}
+		seg.size = rr.offset
+		seg.lastChangeNumber = entry.ChangeNumber + uint64(entry.NumberOfTrxns) - 1
+	}
+	if !seg.empty() && !sameIndex(index, seg.index) {
+		if err = writeIndex(indexPath(path), seg.index); err != nil {
+			return nil, err
+		}
+	}
+	return seg, nil
+}
+
+func sameIndex(a, b []indexEntry) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// FirstChangeNumber retrieves the oldest change number held by the log.
+// Returns false if the log is empty.
+func (cl *Log) FirstChangeNumber() (uint64, bool) {
+	cl.mu.RLock()
+	defer cl.mu.RUnlock()
+	if len(cl.segments) == 0 {
+		return 0, false
+	}
+	return cl.segments[0].firstChangeNumber(), true
+}
+
+// LastChangeNumber retrieves the latest change number held by the log.
+// Returns false if the log is empty.
+func (cl *Log) LastChangeNumber() (uint64, bool) {
+	cl.mu.RLock()
+	defer cl.mu.RUnlock()
+	if len(cl.segments) == 0 {
+		return 0, false
+	}
+	return cl.segments[len(cl.segments)-1].lastChangeNumber, true
+}
+
+// Size retrieves the total size in bytes of the segments of the log.
+func (cl *Log) Size() int64 {
+	cl.mu.RLock()
+	defer cl.mu.RUnlock()
+	var size int64
+	for _, seg := range cl.segments {
+		size += seg.size
+	}
+	return size
+}
+
+// Append records the given entry at the end of the log. The change
+// number of the entry must follow the latest change number of the log.
+func (cl *Log) Append(entry *Entry) error {
+	if entry.NumberOfTrxns == 0 {
+		return errors.New("change log entry must have at least one transaction")
+	}
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+
+	n := len(cl.segments)
+	if n > 0 && entry.ChangeNumber <= cl.segments[n-1].lastChangeNumber {
+		return ErrOutOfOrder
+	}
+	if n == 0 || cl.segments[n-1].size >= cl.segmentSize {
+		if err := cl.rollOver(entry.ChangeNumber); err != nil {
+			return err
+		}
+	}
+
+	seg := cl.segments[len(cl.segments)-1]
+	offset := seg.size
+	if _, err := cl.active.Write(encodeRecord(entry)); err != nil {
+		// Discard any partial write so that the segment stays readable
+		if truncErr := os.Truncate(seg.path, offset); truncErr != nil {
+			return fmt.Errorf("unable to append to change log: %v, followed by: %v", err, truncErr)
+		}
+		if seg.empty() {
+			cl.closeActive()
+			seg.remove()
+			cl.segments = cl.segments[:len(cl.segments)-1]
+			cl.openActive()
+		}
+		return err
+	}
+	if seg.indexable(offset, cl.indexInterval) {
+		ie := indexEntry{entry.ChangeNumber, offset}
+		seg.index = append(seg.index, ie)
+		// A failure only leaves the persisted index sparser than the
+		// in-memory one, which is rebuilt when the segment is loaded
+		if cl.activeIndex != nil {
+			if _, err := cl.activeIndex.Write(encodeIndexEntry(ie)); err != nil {
+				cl.activeIndex.Close()
+				cl.activeIndex = nil
+			}
+		}
+	}
+	seg.size += recordSize(entry.Data)
+	seg.lastChangeNumber = entry.ChangeNumber + uint64(entry.NumberOfTrxns) - 1
+	return nil
+}
+
+// rollOver starts a new segment beginning with the given change number
+// and removes the oldest segments beyond the retention limit.
+func (cl *Log) rollOver(changeNumber uint64) error {
+	if cl.active != nil {
+		if err := cl.active.Sync(); err != nil {
+			return err
+		}
+	}
+	if err := cl.closeActive(); err != nil {
+		return err
+	}
+	path := filepath.Join(cl.dir, fmt.Sprintf("%020d%s", changeNumber, segmentExt))
+	for _, p := range []string{path, indexPath(path)} {
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	// The index is populated by the subsequent append
+	cl.segments = append(cl.segments, &segment{path: path})
+	if err := cl.openActive(); err != nil {
+		cl.segments = cl.segments[:len(cl.segments)-1]
+		return err
+	}
+
+	for cl.maxSegments > 0 && len(cl.segments) > cl.maxSegments {
+		if err := cl.segments[0].remove(); err != nil {
+			return err
+		}
+		cl.segments = cl.segments[1:]
+	}
+	return nil
+}
+
+// Read loads up to the given number of entries, beginning with the entry
+// that holds the given change number or the first entry after it.
+func (cl *Log) Read(fromChangeNumber uint64, maxEntries int) ([]*Entry, error) {
+	cl.mu.RLock()
+	defer cl.mu.RUnlock()
+
+	// Locate the first segment that holds changes at or after the given one
+	segIdx := sort.Search(len(cl.segments), func(i int) bool {
+		return cl.segments[i].lastChangeNumber >= fromChangeNumber
+	})
+	var entries []*Entry
+	for ; segIdx < len(cl.segments) && len(entries) < maxEntries; segIdx++ {
+		segEntries, err := cl.segments[segIdx].read(fromChangeNumber, maxEntries-len(entries))
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, segEntries...)
+	}
+	return entries, nil
+}
+
+func (seg *segment) read(fromChangeNumber uint64, maxEntries int) ([]*Entry, error) {
+	if seg.empty() || maxEntries <= 0 {
+		return nil, nil
+	}
+	f, err := os.Open(seg.path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []*Entry
+	rr := newRecordReader(f, seg.seek(fromChangeNumber), seg.size)
+	for len(entries) < maxEntries {
+		entry, offset, err := rr.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to read segment: %s at offset: %d, error: %v", seg.path, offset, err)
+		}
+		if entry.ChangeNumber+uint64(entry.NumberOfTrxns)-1 >= fromChangeNumber {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// Rewrite passes every entry of the log to the given function, which
+// may alter the data of the entry in place without changing its length.
+// The entries for which the function returns true are overwritten in
+// their segments, which are synced before returning. This allows for
+// redacting the entries, say those holding erased encryption keys.
+func (cl *Log) Rewrite(rewrite func(entry *Entry) bool) error {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	for _, seg := range cl.segments {
+		if err := seg.rewrite(rewrite); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (seg *segment) rewrite(rewrite func(entry *Entry) bool) error {
+	if seg.empty() {
+		return nil
+	}
+	f, err := os.OpenFile(seg.path, os.O_RDWR, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	rewritten := false
+	rr := newRecordReader(f, 0, seg.size)
+	for {
+		entry, offset, err := rr.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("unable to read segment: %s at offset: %d, error: %v", seg.path, offset, err)
+		}
+		dataLen := len(entry.Data)
+		if !rewrite(entry) {
+			continue
+		}
+		if len(entry.Data) != dataLen {
+			return fmt.Errorf("rewritten change log entry of change number: %d must retain its length", entry.ChangeNumber)
+		}
+		// Only the records already read are overwritten
+		if _, err = f.WriteAt(encodeRecord(entry), offset); err != nil {
+			return err
+		}
+		rewritten = true
+	}
+	if !rewritten {
+		return nil
+	}
+	return f.Sync()
+}
+
+// TruncateAfter discards all the entries beginning after the given
+// change number. This is required when the store is rolled back, say
+// when restored from a backup.
+func (cl *Log) TruncateAfter(changeNumber uint64) error {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+
+	for n := len(cl.segments); n > 0; n = len(cl.segments) {
+		seg := cl.segments[n-1]
+		if seg.lastChangeNumber <= changeNumber {
+			break
+		}
+		if err := cl.closeActive(); err != nil {
+			return err
+		}
+		if seg.firstChangeNumber() > changeNumber {
+			if err := seg.remove(); err != nil {
+				return err
+			}
+			cl.segments = cl.segments[:n-1]
+			continue
+		}
+		if err := seg.truncateAfter(changeNumber); err != nil {
+			return err
+		}
+		break
+	}
+	return cl.openActive()
+}
+
+// truncateAfter discards the records of the segment beginning after the
+// given change number, which must not precede the first record.
+func (seg *segment) truncateAfter(changeNumber uint64) error {
+	f, err := os.Open(seg.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	offset, lastChngNum := seg.seek(changeNumber), uint64(0)
+	rr := newRecordReader(f, offset, seg.size)
+	for {
+		entry, entryOffset, err := rr.next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if entry.ChangeNumber > changeNumber {
+			offset = entryOffset
+			break
+		}
+		lastChngNum = entry.ChangeNumber + uint64(entry.NumberOfTrxns) - 1
+	}
+
+	if err = os.Truncate(seg.path, offset); err != nil {
+		return err
+	}
+	idx := sort.Search(len(seg.index), func(i int) bool {
+		return seg.index[i].offset >= offset
+	})
+	seg.size, seg.lastChangeNumber, seg.index = offset, lastChngNum, seg.index[:idx]
+	return writeIndex(indexPath(seg.path), seg.index)
+}
+
+func (seg *segment) remove() error {
+	for _, p := range []string{seg.path, indexPath(seg.path)} {
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reset discards all the entries of the log.
+func (cl *Log) Reset() error {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	if err := cl.closeActive(); err != nil {
+		return err
+	}
+	for _, seg := range cl.segments {
+		if err := seg.remove(); err != nil {
+			return err
+		}
+	}
+	cl.segments = nil
+	return nil
+}
+
+// Close flushes and closes the log.
+func (cl *Log) Close() error {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	if cl.active == nil {
+		return nil
+	}
+	if err := cl.active.Sync(); err != nil {
+		return err
+	}
+	return cl.closeActive()
+}
+
+func (cl *Log) closeActive() error {
+	var err error
+	if cl.activeIndex != nil {
+		err = cl.activeIndex.Close()
+		cl.activeIndex = nil
+	}
+	if cl.active != nil {
+		if closeErr := cl.active.Close(); closeErr != nil {
+			err = closeErr
+		}
+		cl.active = nil
+	}
+	return err
+}
+
+func (cl *Log) openActive() (err error) {
+	n := len(cl.segments)
+	if n == 0 || cl.active != nil {
+		return nil
+	}
+	path := cl.segments[n-1].path
+	if cl.active, err = os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644); err != nil {
+		return err
+	}
+	if cl.activeIndex, err = os.OpenFile(indexPath(path), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644); err != nil {
+		cl.closeActive()
+	}
+	return err
+}
+
+func recordSize(data []byte) int64 {
+	return int64(recordHeaderSize + len(data) + recordTrailerSize)
+}
+
+func encodeRecord(entry *Entry) []byte {
+	rec := make([]byte, recordSize(entry.Data))
+	binary.BigEndian.PutUint64(rec[0:8], entry.ChangeNumber)
+	binary.BigEndian.PutUint32(rec[8:12], entry.NumberOfTrxns)
+	binary.BigEndian.PutUint32(rec[12:16], uint32(len(entry.Data)))
+	copy(rec[recordHeaderSize:], entry.Data)
+	crcOffset := recordHeaderSize + len(entry.Data)
+	binary.BigEndian.PutUint32(rec[crcOffset:], crc32.ChecksumIEEE(rec[:crcOffset]))
+	return rec
+}
+
+// recordReader sequentially decodes the records of a segment, starting
+// at the given offset and ending at the given size.
+type recordReader struct {
+	r      *bufio.Reader
+	offset int64
+	limit  int64
+}
+
+func newRecordReader(f io.ReaderAt, offset, limit int64) *recordReader {
+	return &recordReader{bufio.NewReader(io.NewSectionReader(f, offset, limit-offset)), offset, limit}
+}
+
+// next decodes the next record along with its offset. Returns io.EOF if
+// there are no more records and io.ErrUnexpectedEOF or errCorrupted if
+// the record is partially written or corrupted.
+func (rr *recordReader) next() (*Entry, int64, error) {
+	offset := rr.offset
+	hdr := make([]byte, recordHeaderSize)
+	if _, err := io.ReadFull(rr.r, hdr); err != nil {
+		return nil, offset, err
+	}
+	dataLen := binary.BigEndian.Uint32(hdr[12:16])
+	recSize := int64(recordHeaderSize) + int64(dataLen) + recordTrailerSize
+	if offset+recSize > rr.limit {
+		return nil, offset, io.ErrUnexpectedEOF
+	}
+	rest := make([]byte, int64(dataLen)+recordTrailerSize)
+	if _, err := io.ReadFull(rr.r, rest); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, offset, err
+	}
+	crc := crc32.ChecksumIEEE(hdr)
+	crc = crc32.Update(crc, crc32.IEEETable, rest[:dataLen])
+	if crc != binary.BigEndian.Uint32(rest[dataLen:]) {
+		return nil, offset, errCorrupted
+	}
+	rr.offset += recSize
+	return &Entry{
+		ChangeNumber:  binary.BigEndian.Uint64(hdr[0:8]),
+		NumberOfTrxns: binary.BigEndian.Uint32(hdr[8:12]),
+		Data:          rest[:dataLen:dataLen],
+	}, offset, nil
+}
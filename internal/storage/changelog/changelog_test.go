@@ -0,0 +1,240 @@
+package changelog
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAppendAndRead(t *testing.T) {
+	cl := openLog(t, t.TempDir(), 64, 0)
+	defer cl.Close()
+
+	if _, ok := cl.LastChangeNumber(); ok {
+		t.Fatal("Expected an empty change log")
+	}
+	// Change numbers 1, 2-4, 5, ... 20
+	appendEntries(t, cl, 1, 1)
+	appendEntries(t, cl, 2, 3)
+	for cn := uint64(5); cn <= 20; cn++ {
+		appendEntries(t, cl, cn, 1)
+	}
+	if err := cl.Append(&Entry{ChangeNumber: 20, NumberOfTrxns: 1}); err != ErrOutOfOrder {
+		t.Errorf("Expected out of order error. Actual: %v", err)
+	}
+	if len(cl.segments) < 2 {
+		t.Errorf("Expected segments to be rolled over. Segments: %d", len(cl.segments))
+	}
+	assertRange(t, cl, 1, 20)
+
+	entries, err := cl.Read(3, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEntries(t, entries, 2, 5, 6)
+
+	entries, _ = cl.Read(19, 10)
+	assertEntries(t, entries, 19, 20)
+
+	if entries, _ = cl.Read(21, 10); len(entries) != 0 {
+		t.Errorf("Expected no entries beyond the last change number. Actual: %d", len(entries))
+	}
+}
+
+func TestRetention(t *testing.T) {
+	cl := openLog(t, t.TempDir(), 1, 3)
+	defer cl.Close()
+
+	for cn := uint64(1); cn <= 10; cn++ {
+		appendEntries(t, cl, cn, 1)
+	}
+	assertRange(t, cl, 8, 10)
+	entries, _ := cl.Read(1, 10)
+	assertEntries(t, entries, 8, 9, 10)
+	expSize := recordSize([]byte("data_8")) + recordSize([]byte("data_9")) + recordSize([]byte("data_10"))
+	if cl.Size() != expSize {
+		t.Errorf("Size mismatch of the retained segments. Expected: %d, Actual: %d", expSize, cl.Size())
+	}
+}
+
+func TestReopen(t *testing.T) {
+	dir := t.TempDir()
+	cl := openLog(t, dir, 64, 0)
+	for cn := uint64(1); cn <= 10; cn++ {
+		appendEntries(t, cl, cn, 1)
+	}
+	cl.Close()
+
+	// Simulate a torn write at the end of the last segment
+	segs, _ := filepath.Glob(filepath.Join(dir, "*"+segmentExt))
+	last := segs[len(segs)-1]
+	f, _ := os.OpenFile(last, os.O_WRONLY|os.O_APPEND, 0644)
+	f.Write(encodeRecord(&Entry{ChangeNumber: 11, NumberOfTrxns: 1, Data: []byte("torn")})[:10])
+	f.Close()
+
+	cl = openLog(t, dir, 64, 0)
+	defer cl.Close()
+	assertRange(t, cl, 1, 10)
+	appendEntries(t, cl, 11, 1)
+	entries, _ := cl.Read(10, 10)
+	assertEntries(t, entries, 10, 11)
+}
+
+func TestTruncateAfterAndReset(t *testing.T) {
+	dir := t.TempDir()
+	cl := openLog(t, dir, 64, 0)
+	defer cl.Close()
+	for cn := uint64(1); cn <= 20; cn++ {
+		appendEntries(t, cl, cn, 1)
+	}
+
+	if err := cl.TruncateAfter(7); err != nil {
+		t.Fatal(err)
+	}
+	assertRange(t, cl, 1, 7)
+	appendEntries(t, cl, 8, 1)
+	entries, _ := cl.Read(6, 10)
+	assertEntries(t, entries, 6, 7, 8)
+
+	if err := cl.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cl.FirstChangeNumber(); ok {
+		t.Error("Expected an empty change log after reset")
+	}
+	if files, _ := ioutil.ReadDir(dir); len(files) != 0 {
+		t.Errorf("Expected no segments after reset. Actual: %d", len(files))
+	}
+	appendEntries(t, cl, 100, 1)
+	assertRange(t, cl, 100, 100)
+}
+
+func TestRewrite(t *testing.T) {
+	dir := t.TempDir()
+	cl := openLog(t, dir, 64, 0)
+	for cn := uint64(1); cn <= 20; cn++ {
+		appendEntries(t, cl, cn, 1)
+	}
+	redact := func(entry *Entry) bool {
+		if entry.ChangeNumber%7 != 0 {
+			return false
+		}
+		copy(entry.Data, "xxxx")
+		return true
+	}
+	if err := cl.Rewrite(redact); err != nil {
+		t.Fatal(err)
+	}
+	err := cl.Rewrite(func(entry *Entry) bool {
+		entry.Data = append(entry.Data, '!')
+		return entry.ChangeNumber == 20
+	})
+	if err == nil {
+		t.Error("Expected an error for rewriting an entry with data of a different length")
+	}
+	cl.Close()
+
+	// Rewritten records remain intact across restarts
+	cl = openLog(t, dir, 64, 0)
+	defer cl.Close()
+	assertRange(t, cl, 1, 20)
+	entries, _ := cl.Read(1, 20)
+	if len(entries) != 20 {
+		t.Fatalf("Expected 20 entries. Actual: %d", len(entries))
+	}
+	for _, entry := range entries {
+		expData := fmt.Sprintf("data_%d", entry.ChangeNumber)
+		if entry.ChangeNumber%7 == 0 {
+			expData = "xxxx" + expData[4:]
+		}
+		if string(entry.Data) != expData {
+			t.Errorf("Data mismatch of change number %d. Expected: %s, Actual: %s", entry.ChangeNumber, expData, entry.Data)
+		}
+	}
+}
+
+func TestSparseIndex(t *testing.T) {
+	dir := t.TempDir()
+	cl := openLog(t, dir, 1<<20, 0)
+	// Index every fourth record, each of which is 26 bytes long
+	cl.indexInterval = 100
+	for cn := uint64(1); cn <= 100; cn += 2 {
+		appendEntries(t, cl, cn, 2)
+	}
+	seg := cl.segments[0]
+	if len(seg.index) != 13 {
+		t.Errorf("Expected 13 index entries. Actual: %d", len(seg.index))
+	}
+	if offset := seg.seek(50); offset != seg.index[6].offset {
+		t.Errorf("Expected seek to the index entry of change number 49. Actual offset: %d", offset)
+	}
+	entries, _ := cl.Read(50, 2)
+	assertEntries(t, entries, 49, 51)
+	cl.Close()
+
+	// Index is loaded instead of being rebuilt
+	cl = openLog(t, dir, 1<<20, 0)
+	if len(cl.segments[0].index) != 13 {
+		t.Errorf("Expected the persisted index to be loaded. Index entries: %d", len(cl.segments[0].index))
+	}
+	entries, _ = cl.Read(100, 2)
+	assertEntries(t, entries, 99)
+	cl.Close()
+
+	// Corrupted index is rebuilt
+	idxFile := indexPath(seg.path)
+	if err := ioutil.WriteFile(idxFile, encodeIndexEntry(indexEntry{changeNumber: 7, offset: 0}), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cl = openLog(t, dir, 1<<20, 0)
+	defer cl.Close()
+	assertRange(t, cl, 1, 100)
+	entries, _ = cl.Read(1, 100)
+	if len(entries) != 50 {
+		t.Errorf("Expected 50 entries after rebuilding the index. Actual: %d", len(entries))
+	}
+	if index, _ := readIndex(idxFile, cl.segments[0].size); len(index) == 0 || index[0].changeNumber != 1 {
+		t.Errorf("Expected the rebuilt index to be persisted. Actual: %v", index)
+	}
+}
+
+func openLog(t *testing.T, dir string, segmentSize int64, maxSegments int) *Log {
+	cl, err := Open(dir, segmentSize, maxSegments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cl
+}
+
+func appendEntries(t *testing.T, cl *Log, changeNum uint64, numTrxns uint32) {
+	data := []byte(fmt.Sprintf("data_%d", changeNum))
+	if err := cl.Append(&Entry{ChangeNumber: changeNum, NumberOfTrxns: numTrxns, Data: data}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func assertRange(t *testing.T, cl *Log, expFirst, expLast uint64) {
+	t.Helper()
+	first, _ := cl.FirstChangeNumber()
+	last, _ := cl.LastChangeNumber()
+	if first != expFirst || last != expLast {
+		t.Errorf("Change number range mismatch. Expected: [%d, %d], Actual: [%d, %d]", expFirst, expLast, first, last)
+	}
+}
+
+func assertEntries(t *testing.T, entries []*Entry, expChangeNums ...uint64) {
+	t.Helper()
+	if len(entries) != len(expChangeNums) {
+		t.Fatalf("Expected %d entries. Actual: %d", len(expChangeNums), len(entries))
+	}
+	for i, entry := range entries {
+		if entry.ChangeNumber != expChangeNums[i] {
+			t.Errorf("Expected change number: %d. Actual: %d", expChangeNums[i], entry.ChangeNumber)
+		}
+		if expData := fmt.Sprintf("data_%d", entry.ChangeNumber); string(entry.Data) != expData {
+			t.Errorf("Data mismatch for change number: %d. Actual: %s", entry.ChangeNumber, entry.Data)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package changelog
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+)
+
+const (
+	indexExt = ".idx"
+	// Every index entry is made up of the change number (8 bytes) and
+	// the offset (8 bytes) of the indexed record.
+	indexEntrySize = 16
+	// A record is indexed once the preceding indexed record is at least
+	// these many bytes behind, bounding the bytes scanned per seek.
+	defaultIndexInterval = 4 << 10
+)
+
+// An indexEntry locates a record of a segment by its change number.
+// Segment indexes are sparse, hence every index entry covers all the
+// records up to the next one. The first record is always indexed.
+type indexEntry struct {
+	changeNumber uint64
+	offset       int64
+}
+
+func indexPath(segmentPath string) string {
+	return strings.TrimSuffix(segmentPath, segmentExt) + indexExt
+}
+
+// seek locates the offset from which the records of the given change
+// number onwards can be scanned.
+func (seg *segment) seek(changeNumber uint64) int64 {
+	idx := sort.Search(len(seg.index), func(i int) bool {
+		return seg.index[i].changeNumber > changeNumber
+	})
+	if idx == 0 {
+		return 0
+	}
+	return seg.index[idx-1].offset
+}
+
+// indexable checks if the record at the given offset is to be indexed.
+func (seg *segment) indexable(offset, indexInterval int64) bool {
+	n := len(seg.index)
+	return n == 0 || offset-seg.index[n-1].offset >= indexInterval
+}
+
+// readIndex loads the index of a segment having the given size. Only the
+// leading entries that are consistent with each other are retained, so
+// that an index partially written during a crash is discarded.
+func readIndex(path string, segmentSize int64) ([]indexEntry, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var index []indexEntry
+	for i := 0; i+indexEntrySize <= len(data); i += indexEntrySize {
+		ie := decodeIndexEntry(data[i : i+indexEntrySize])
+		n := len(index)
+		if ie.offset >= segmentSize || (n == 0 && ie.offset != 0) ||
+			(n > 0 && (ie.changeNumber <= index[n-1].changeNumber || ie.offset <= index[n-1].offset)) {
+			break
+		}
+		index = append(index, ie)
+	}
+	return index, nil
+}
+
+// writeIndex replaces the index of a segment with the given entries.
+func writeIndex(path string, index []indexEntry) error {
+	data := make([]byte, 0, len(index)*indexEntrySize)
+	for _, ie := range index {
+		data = append(data, encodeIndexEntry(ie)...)
+	}
+	tmpPath := path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+func encodeIndexEntry(ie indexEntry) []byte {
+	buf := make([]byte, indexEntrySize)
+	binary.BigEndian.PutUint64(buf[0:8], ie.changeNumber)
+	binary.BigEndian.PutUint64(buf[8:16], uint64(ie.offset))
+	return buf
+}
+
+func decodeIndexEntry(buf []byte) indexEntry {
+	return indexEntry{
+		changeNumber: binary.BigEndian.Uint64(buf[0:8]),
+		offset:       int64(binary.BigEndian.Uint64(buf[8:16])),
+	}
+}
@@ -0,0 +1,46 @@
+package storage
+
+import "os"
+
+// A Checkpointer represents the capability of the underlying store
+// to bootstrap a replica from a checkpoint of another store, sparing
+// the replica from replaying all the changes since the beginning,
+// which may no longer be retained.
+type Checkpointer interface {
+	// CreateCheckpoint creates a checkpoint of the entire keyspace in
+	// a new folder, which is owned by the caller thereafter. Returns
+	// that folder along with the change number upto which the
+	// checkpoint is complete.
+	CreateCheckpoint() (string, uint64, error)
+	// ApplyCheckpoint replaces the entire keyspace with the checkpoint
+	// in the given folder, which is consumed in the process. Returns
+	// the latest change number of the replaced keyspace.
+	ApplyCheckpoint(folder string) (uint64, error)
+}
+
+// CheckpointFile is a file of a checkpoint.
+type CheckpointFile struct {
+	Name string
+	Size int64
+}
+
+// ListCheckpointFiles retrieves the files of the checkpoint in the
+// given folder, in the order of their names as sorted by ReadDir.
+func ListCheckpointFiles(folder string) ([]CheckpointFile, error) {
+	entries, err := os.ReadDir(folder)
+	if err != nil {
+		return nil, err
+	}
+	var files []CheckpointFile
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, CheckpointFile{Name: entry.Name(), Size: info.Size()})
+	}
+	return files, nil
+}
@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+)
+
+// ErrChangeChecksumMismatch is returned for the changes whose contents do
+// not match their checksum, which indicates that they were corrupted in
+// transit. Such changes are not applied, so that they are fetched again
+// from the master.
+var ErrChangeChecksumMismatch = errors.New("change does not match its checksum")
+
+// SetChangeChecksum computes the checksum of the given change and sets it
+// onto the change.
+func SetChangeChecksum(chng *serverpb.ChangeRecord) {
+	checksum := changeChecksum(chng)
+	chng.Checksum = &checksum
+}
+
+// VerifyChangeChecksum checks if the given change matches its checksum,
+// if any.
+func VerifyChangeChecksum(chng *serverpb.ChangeRecord) error {
+	if chng.Checksum == nil {
+		return nil
+	}
+	if checksum := changeChecksum(chng); checksum != *chng.Checksum {
+		return fmt.Errorf("%w: change number %d has checksum %08x instead of %08x",
+			ErrChangeChecksumMismatch, chng.ChangeNumber, checksum, *chng.Checksum)
+	}
+	return nil
+}
+
+// changeChecksum computes the checksum of the given change over its
+// change number, its serialised form and its transaction records, which
+// are what the replicas apply. The fencing epoch, which is verified
+// separately, is left out along with the encoding of the change in
+// transit, which is undone before verifying the change.
+func changeChecksum(chng *serverpb.ChangeRecord) uint32 {
+	crc := crc32.NewIEEE()
+	var buf [binary.MaxVarintLen64]byte
+	writeUint := func(n uint64) {
+		crc.Write(buf[:binary.PutUvarint(buf[:], n)])
+	}
+	writeBytes := func(b []byte) {
+		writeUint(uint64(len(b)))
+		crc.Write(b)
+	}
+	writeUint(chng.ChangeNumber)
+	writeUint(uint64(chng.NumberOfTrxns))
+	writeBytes(chng.SerialisedForm)
+	writeUint(uint64(len(chng.Trxns)))
+	for _, trxn := range chng.Trxns {
+		writeUint(uint64(trxn.Type))
+		writeBytes(trxn.Key)
+		writeBytes(trxn.Value)
+		writeUint(trxn.ExpireTS)
+	}
+	return crc.Sum32()
+}
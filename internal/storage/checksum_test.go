@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+)
+
+func TestChangeChecksum(t *testing.T) {
+	chng := &serverpb.ChangeRecord{ChangeNumber: 7, NumberOfTrxns: 1, SerialisedForm: []byte("serialised write batch"),
+		Trxns: []*serverpb.TrxnRecord{{Type: serverpb.TrxnRecord_Put, Key: []byte("key"), Value: []byte("value")}}}
+	if err := VerifyChangeChecksum(chng); err != nil {
+		t.Errorf("Expected the change without a checksum to be accepted, but got %v", err)
+	}
+	SetChangeChecksum(chng)
+	if err := VerifyChangeChecksum(chng); err != nil {
+		t.Errorf("Expected the change to match its checksum, but got %v", err)
+	}
+	chng.SerialisedForm[0] ^= 1
+	if err := VerifyChangeChecksum(chng); !errors.Is(err, ErrChangeChecksumMismatch) {
+		t.Errorf("Expected the change with a corrupt serialised form to be rejected, but got %v", err)
+	}
+	chng.SerialisedForm[0] ^= 1
+	chng.Trxns[0].Value[0] ^= 1
+	if err := VerifyChangeChecksum(chng); !errors.Is(err, ErrChangeChecksumMismatch) {
+		t.Errorf("Expected the change with a corrupt transaction record to be rejected, but got %v", err)
+	}
+	chng.Trxns[0].Value[0] ^= 1
+	chng.ChangeNumber++
+	if err := VerifyChangeChecksum(chng); !errors.Is(err, ErrChangeChecksumMismatch) {
+		t.Errorf("Expected the change with a corrupt change number to be rejected, but got %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"hash"
+	"hash/fnv"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+)
+
+// Number of keys digested between the checks for the cancellation of
+// a digest computation
+const digestCancelCheckInterval = 1024
+
+var errInvalidDigestRange = errors.New("EndKey must follow StartKey")
+
+// DigestRanges computes the digests of the consecutive ranges requested
+// by the given request, iterated using the given iteration, which begins
+// at the requested start key. Ranges are contiguous, so that every key
+// between the start key and the end of the last range falls within a
+// range, irrespective of the node digesting them. Reserved keys internal
+// to DKV are not digested. Stops as soon as the given context is done.
+func DigestRanges(ctx context.Context, iteration Iteration, digestReq *serverpb.GetRangeDigestsRequest) ([]*serverpb.RangeDigest, error) {
+	if len(digestReq.EndKey) > 0 && bytes.Compare(digestReq.EndKey, digestReq.StartKey) <= 0 {
+		return nil, errInvalidDigestRange
+	}
+	maxRanges := int(digestReq.MaxRanges)
+	if maxRanges == 0 {
+		maxRanges = 1
+	}
+	itrtr, err := iteration.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer itrtr.Close()
+
+	var digests []*serverpb.RangeDigest
+	rd := newRangeDigester(digestReq.StartKey)
+	for numIterated := 0; itrtr.HasNext(); numIterated++ {
+		kv := itrtr.Next()
+		if len(digestReq.EndKey) > 0 && bytes.Compare(kv.Key, digestReq.EndKey) >= 0 {
+			break
+		}
+		if numIterated%digestCancelCheckInterval == 0 {
+			if err = ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		if IsReservedKey(kv.Key) {
+			continue
+		}
+		rd.add(kv)
+		if digestReq.KeysPerRange > 0 && rd.numKeys == uint64(digestReq.KeysPerRange) {
+			endKey := append(append([]byte(nil), kv.Key...), 0)
+			digests = append(digests, rd.digest(endKey))
+			if len(digests) == maxRanges {
+				return digests, itrtr.Err()
+			}
+			rd = newRangeDigester(endKey)
+		}
+	}
+	if err = itrtr.Err(); err != nil {
+		return nil, err
+	}
+	// The last range extends upto the end key, unless the preceding range
+	// ended exactly at the last key
+	if rd.numKeys > 0 || len(digests) == 0 {
+		digests = append(digests, rd.digest(digestReq.EndKey))
+	}
+	return digests, nil
+}
+
+type rangeDigester struct {
+	startKey []byte
+	numKeys  uint64
+	hash     hash.Hash64
+	buf      []byte
+}
+
+func newRangeDigester(startKey []byte) *rangeDigester {
+	return &rangeDigester{startKey: startKey, hash: fnv.New64a(), buf: make([]byte, binary.MaxVarintLen64)}
+}
+
+func (rd *rangeDigester) add(kv *serverpb.KVPair) {
+	// Keys are prefixed by their lengths so that the boundaries between
+	// the keys and the checksums are unambiguous
+	rd.hash.Write(rd.buf[:binary.PutUvarint(rd.buf, uint64(len(kv.Key)))])
+	rd.hash.Write(kv.Key)
+	binary.BigEndian.PutUint64(rd.buf, Checksum(kv))
+	rd.hash.Write(rd.buf[:8])
+	rd.numKeys++
+}
+
+func (rd *rangeDigester) digest(endKey []byte) *serverpb.RangeDigest {
+	return &serverpb.RangeDigest{StartKey: rd.startKey, EndKey: endKey, NumKeys: rd.numKeys, Digest: rd.hash.Sum64()}
+}
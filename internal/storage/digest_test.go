@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+)
+
+func TestDigestRanges(t *testing.T) {
+	_, si := newSliceIteration(10)
+	kvs := si.kvs
+	digests, err := digestSlice(kvs, &serverpb.GetRangeDigestsRequest{KeysPerRange: 4, MaxRanges: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(digests) != 3 {
+		t.Fatalf("Expected 3 ranges of upto 4 keys. Actual: %v", digests)
+	}
+	// Ranges are contiguous, with the last one extending upto the end
+	if string(digests[0].EndKey) != "key003\x00" || string(digests[1].StartKey) != "key003\x00" || digests[2].EndKey != nil {
+		t.Errorf("Expected contiguous ranges. Actual: %v", digests)
+	}
+	if digests[0].NumKeys != 4 || digests[1].NumKeys != 4 || digests[2].NumKeys != 2 {
+		t.Errorf("Unexpected number of keys within the ranges: %v", digests)
+	}
+
+	// Changing a value changes only the digest of its range
+	kvs[5].Value = []byte("changed")
+	changed, err := digestSlice(kvs, &serverpb.GetRangeDigestsRequest{KeysPerRange: 4, MaxRanges: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed[0].Digest != digests[0].Digest || changed[1].Digest == digests[1].Digest || changed[2].Digest != digests[2].Digest {
+		t.Errorf("Expected only the digest of the second range to change. Before: %v, After: %v", digests, changed)
+	}
+
+	if digests, err = digestSlice(kvs, &serverpb.GetRangeDigestsRequest{KeysPerRange: 4, MaxRanges: 1}); err != nil {
+		t.Fatal(err)
+	} else if len(digests) != 1 {
+		t.Errorf("Expected a single range. Actual: %v", digests)
+	}
+	if digests, err = digestSlice(kvs, &serverpb.GetRangeDigestsRequest{EndKey: []byte("key005")}); err != nil {
+		t.Fatal(err)
+	} else if len(digests) != 1 || digests[0].NumKeys != 5 || string(digests[0].EndKey) != "key005" {
+		t.Errorf("Expected a single range of 5 keys upto the end key. Actual: %v", digests)
+	}
+	// Empty keyspace is a single empty range
+	if digests, err = digestSlice(nil, &serverpb.GetRangeDigestsRequest{KeysPerRange: 4}); err != nil {
+		t.Fatal(err)
+	} else if len(digests) != 1 || digests[0].NumKeys != 0 {
+		t.Errorf("Expected a single empty range. Actual: %v", digests)
+	}
+
+	if _, err = digestSlice(kvs, &serverpb.GetRangeDigestsRequest{StartKey: []byte("b"), EndKey: []byte("a")}); err == nil {
+		t.Error("Expected an error when the end key precedes the start key")
+	}
+}
+
+func digestSlice(kvs []*serverpb.KVPair, digestReq *serverpb.GetRangeDigestsRequest) ([]*serverpb.RangeDigest, error) {
+	si := &sliceIterator{kvs: kvs}
+	iterate := func(IterationOptions) (Iterator, error) { return si, nil }
+	return DigestRanges(context.Background(), &iteration{iterate, &iterOpts{}}, digestReq)
+}
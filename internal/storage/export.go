@@ -0,0 +1,187 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+)
+
+// exportMagic begins every file exported in the BINARY format,
+// followed by the version of the format.
+var exportMagic = []byte("DKVX")
+
+const exportVersion = 1
+
+// ExportWriter writes key value pairs in the BINARY export format,
+// as documented by serverpb.ExportFormat_BINARY.
+type ExportWriter struct {
+	w *bufio.Writer
+}
+
+// NewExportWriter creates an ExportWriter writing into the given writer,
+// beginning with the header of the format.
+func NewExportWriter(w io.Writer) (*ExportWriter, error) {
+	bw := bufio.NewWriter(w)
+	if _, err := bw.Write(append(append([]byte(nil), exportMagic...), exportVersion)); err != nil {
+		return nil, err
+	}
+	return &ExportWriter{bw}, nil
+}
+
+// Write writes the given key value pair as the next record.
+func (ew *ExportWriter) Write(kv *serverpb.KVPair) error {
+	var buf [8]byte
+	binary.BigEndian.PutUint32(buf[:4], uint32(len(kv.Key)))
+	ew.w.Write(buf[:4])
+	ew.w.Write(kv.Key)
+	binary.BigEndian.PutUint32(buf[:4], uint32(len(kv.Value)))
+	ew.w.Write(buf[:4])
+	ew.w.Write(kv.Value)
+	binary.BigEndian.PutUint64(buf[:], kv.ExpireTS)
+	_, err := ew.w.Write(buf[:])
+	return err
+}
+
+// Flush writes out the buffered records.
+func (ew *ExportWriter) Flush() error {
+	return ew.w.Flush()
+}
+
+// ExportReader reads the key value pairs of a file exported in
+// the BINARY format.
+type ExportReader struct {
+	r *bufio.Reader
+}
+
+// NewExportReader creates an ExportReader reading from the given
+// reader, after verifying the header of the format.
+func NewExportReader(r io.Reader) (*ExportReader, error) {
+	br := bufio.NewReader(r)
+	header := make([]byte, len(exportMagic)+1)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return nil, fmt.Errorf("unable to read the export header: %v", err)
+	}
+	if !bytes.Equal(header[:len(exportMagic)], exportMagic) {
+		return nil, errors.New("not a DKV export")
+	}
+	if version := header[len(exportMagic)]; version != exportVersion {
+		return nil, fmt.Errorf("unsupported export version: %d", version)
+	}
+	return &ExportReader{br}, nil
+}
+
+// Read reads the next key value pair, returning io.EOF once
+// all the records are read.
+func (er *ExportReader) Read() (*serverpb.KVPair, error) {
+	var buf [8]byte
+	if _, err := io.ReadFull(er.r, buf[:4]); err != nil {
+		return nil, err
+	}
+	key, err := er.readBytes(binary.BigEndian.Uint32(buf[:4]))
+	if err != nil {
+		return nil, err
+	}
+	if _, err = io.ReadFull(er.r, buf[:4]); err != nil {
+		return nil, truncated(err)
+	}
+	value, err := er.readBytes(binary.BigEndian.Uint32(buf[:4]))
+	if err != nil {
+		return nil, err
+	}
+	if _, err = io.ReadFull(er.r, buf[:]); err != nil {
+		return nil, truncated(err)
+	}
+	return &serverpb.KVPair{Key: key, Value: value, ExpireTS: binary.BigEndian.Uint64(buf[:])}, nil
+}
+
+func (er *ExportReader) readBytes(n uint32) ([]byte, error) {
+	res := make([]byte, n)
+	if _, err := io.ReadFull(er.r, res); err != nil {
+		return nil, truncated(err)
+	}
+	return res, nil
+}
+
+// truncated converts the end of the input in the middle of
+// a record into an error.
+func truncated(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// ExportTo exports the given key range from the given store into the
+// given destination, which is either a folder or an http(s) URL to which
+// the exported files are uploaded. Retrieves the paths or URLs of the
+// exported files along with the number of keys exported.
+func ExportTo(exp Exporter, startKey, endKey []byte, format serverpb.ExportFormat, destination string) ([]string, uint64, error) {
+	if destination == "" {
+		return nil, 0, errors.New("export destination must be provided")
+	}
+	if !isURL(destination) {
+		return exp.Export(startKey, endKey, format, destination)
+	}
+
+	tempDir, err := ioutil.TempDir("", "dkv_export_")
+	if err != nil {
+		return nil, 0, err
+	}
+	defer os.RemoveAll(tempDir)
+	files, numKeys, err := exp.Export(startKey, endKey, format, tempDir)
+	if err != nil {
+		return nil, 0, err
+	}
+	urls := make([]string, len(files))
+	for i, file := range files {
+		urls[i] = strings.TrimSuffix(destination, "/") + "/" + path.Base(file)
+		if err = upload(file, urls[i]); err != nil {
+			return nil, 0, err
+		}
+	}
+	return urls, numKeys, nil
+}
+
+func isURL(destination string) bool {
+	return strings.HasPrefix(destination, "http://") || strings.HasPrefix(destination, "https://")
+}
+
+// upload uploads the given file to the given URL using a PUT request,
+// with its length set as required by object storages.
+func upload(file, url string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPut, url, f)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = fi.Size()
+	req.Header.Set("Content-Type", "application/octet-stream")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode/100 != 2 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(res.Body, 512))
+		return fmt.Errorf("unable to upload %s to %s, status: %s, response: %s", path.Base(file), url, res.Status, msg)
+	}
+	return nil
+}
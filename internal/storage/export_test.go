@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"sync"
+	"testing"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+)
+
+func TestExportWriterAndReader(t *testing.T) {
+	kvs := []*serverpb.KVPair{
+		{Key: []byte("key1"), Value: []byte("val1")},
+		{Key: []byte("key2"), Value: []byte("val2"), ExpireTS: 1700000000},
+	}
+	var buf bytes.Buffer
+	wrtr, err := NewExportWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, kv := range kvs {
+		if err = wrtr.Write(kv); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = wrtr.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	rdr, err := NewExportReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expKV := range kvs {
+		kv, err := rdr.Read()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(kv.Key, expKV.Key) || !bytes.Equal(kv.Value, expKV.Value) || kv.ExpireTS != expKV.ExpireTS {
+			t.Errorf("Expected %v, but got %v", expKV, kv)
+		}
+	}
+	if _, err = rdr.Read(); err != io.EOF {
+		t.Errorf("Expected EOF after the last record, but got %v", err)
+	}
+
+	rdr, _ = NewExportReader(bytes.NewReader(data[:len(data)-3]))
+	rdr.Read()
+	if _, err = rdr.Read(); err != io.ErrUnexpectedEOF {
+		t.Errorf("Expected an unexpected EOF for a truncated record, but got %v", err)
+	}
+	if _, err = NewExportReader(bytes.NewReader([]byte("NOTDKV"))); err == nil {
+		t.Error("Expected an error for an invalid header")
+	}
+}
+
+type fileExporter struct{}
+
+func (fileExporter) Export(_, _ []byte, _ serverpb.ExportFormat, dir string) ([]string, uint64, error) {
+	file := path.Join(dir, "export.bin")
+	return []string{file}, 1, ioutil.WriteFile(file, []byte("exported"), 0644)
+}
+
+func TestExportToURL(t *testing.T) {
+	var mu sync.Mutex
+	uploads := make(map[string]string)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if r.Method != http.MethodPut || r.ContentLength != int64(len(body)) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		uploads[r.URL.Path] = string(body)
+	}))
+	defer srv.Close()
+
+	files, numKeys, err := ExportTo(fileExporter{}, nil, nil, serverpb.ExportFormat_BINARY, srv.URL+"/bucket/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0] != srv.URL+"/bucket/export.bin" || numKeys != 1 {
+		t.Errorf("Unexpected exported files: %v, number of keys: %d", files, numKeys)
+	}
+	if uploads["/bucket/export.bin"] != "exported" {
+		t.Errorf("Expected the exported file to be uploaded, but got %v", uploads)
+	}
+
+	dir, _ := ioutil.TempDir("", "export_test")
+	defer os.RemoveAll(dir)
+	if files, _, err = ExportTo(fileExporter{}, nil, nil, serverpb.ExportFormat_BINARY, dir); err != nil || files[0] != path.Join(dir, "export.bin") {
+		t.Errorf("Expected the export into the given folder. Files: %v, Error: %v", files, err)
+	}
+}
@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+)
+
+// ErrStaleFencingEpoch is returned for the changes carrying a fencing
+// epoch lower than the highest epoch observed so far, which indicates
+// that they are served by a master that has since been superseded.
+var ErrStaleFencingEpoch = errors.New("changes carry a stale fencing epoch")
+
+// FencingEpoch tracks the highest fencing epoch issued or observed by
+// a node and is safe for concurrent use. A new epoch is issued when a
+// node is promoted to master, and is carried by all the changes served
+// by it thereafter. When backed by a file, every change to the epoch is
+// persisted so that a replica promoted using the same data folder issues
+// an epoch higher than that of the master it replicated from.
+type FencingEpoch struct {
+	mu    sync.Mutex
+	file  string
+	epoch uint64
+}
+
+// OpenFencingEpoch loads the fencing epoch from the given file. A
+// missing file results in a zero epoch, while an empty file name
+// results in an epoch that is only held in memory.
+func OpenFencingEpoch(file string) (*FencingEpoch, error) {
+	fe := &FencingEpoch{file: file}
+	if file == "" {
+		return fe, nil
+	}
+	data, err := ioutil.ReadFile(file)
+	switch {
+	case os.IsNotExist(err):
+		return fe, nil
+	case err != nil:
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &fe.epoch); err != nil {
+		return nil, err
+	}
+	return fe, nil
+}
+
+// Current retrieves the highest fencing epoch issued or observed.
+func (fe *FencingEpoch) Current() uint64 {
+	fe.mu.Lock()
+	defer fe.mu.Unlock()
+	return fe.epoch
+}
+
+// Advance issues a new fencing epoch, higher than all the epochs
+// issued or observed so far.
+func (fe *FencingEpoch) Advance() (uint64, error) {
+	fe.mu.Lock()
+	defer fe.mu.Unlock()
+	if err := fe.update(fe.epoch + 1); err != nil {
+		return 0, err
+	}
+	return fe.epoch, nil
+}
+
+// Observe records the given fencing epoch if it is higher than the
+// current epoch. Returns ErrStaleFencingEpoch if it is lower.
+func (fe *FencingEpoch) Observe(epoch uint64) error {
+	fe.mu.Lock()
+	defer fe.mu.Unlock()
+	switch {
+	case epoch < fe.epoch:
+		return fmt.Errorf("%w: %d, highest observed epoch: %d", ErrStaleFencingEpoch, epoch, fe.epoch)
+	case epoch > fe.epoch:
+		return fe.update(epoch)
+	}
+	return nil
+}
+
+// update persists the given epoch, if backed by a file, before making
+// it visible so that a failed write leaves the epoch unchanged.
+func (fe *FencingEpoch) update(epoch uint64) error {
+	if fe.file != "" {
+		data, err := json.Marshal(epoch)
+		if err != nil {
+			return err
+		}
+		tmpFile := fe.file + ".tmp"
+		if err = ioutil.WriteFile(tmpFile, data, 0644); err != nil {
+			return err
+		}
+		if err = os.Rename(tmpFile, fe.file); err != nil {
+			return err
+		}
+	}
+	fe.epoch = epoch
+	return nil
+}
@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestFencingEpoch(t *testing.T) {
+	epochDir, err := CreateTempFolder("", "dkv-fencing-epoch-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(epochDir)
+
+	epochFile := path.Join(epochDir, "fencing_epoch.json")
+	fe, err := OpenFencingEpoch(epochFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if epoch := fe.Current(); epoch != 0 {
+		t.Errorf("Expected a zero fencing epoch initially, but got %d", epoch)
+	}
+	if err = fe.Observe(3); err != nil {
+		t.Fatal(err)
+	}
+	if err = fe.Observe(3); err != nil {
+		t.Errorf("Expected the current fencing epoch to be accepted, but got %v", err)
+	}
+	if err = fe.Observe(2); !errors.Is(err, ErrStaleFencingEpoch) {
+		t.Errorf("Expected error: %v, but got: %v", ErrStaleFencingEpoch, err)
+	}
+
+	// Observed epochs must survive reopening, so that a promotion
+	// issues an epoch higher than any epoch observed before
+	if fe, err = OpenFencingEpoch(epochFile); err != nil {
+		t.Fatal(err)
+	}
+	if epoch, err := fe.Advance(); err != nil || epoch != 4 {
+		t.Errorf("Expected fencing epoch 4 to be issued, but got %d, error: %v", epoch, err)
+	}
+	if fe, err = OpenFencingEpoch(epochFile); err != nil {
+		t.Fatal(err)
+	}
+	if epoch := fe.Current(); epoch != 4 {
+		t.Errorf("Expected fencing epoch 4 after reopening, but got %d", epoch)
+	}
+}
@@ -0,0 +1,13 @@
+package storage
+
+// filteredChangeKey is the internal key written in place of every
+// operation of a replicated change that is filtered out by a slave.
+var filteredChangeKey = append(append([]byte(nil), reservedKeyPrefix...), "filtered-change"...)
+
+// FilteredChangeKey is the internal key that stands in for the operations
+// of the replicated changes filtered out by a slave replicating only some
+// of the key prefixes, so that the slave retains the change numbers of
+// its master.
+func FilteredChangeKey() []byte {
+	return append([]byte(nil), filteredChangeKey...)
+}
@@ -2,7 +2,9 @@ package storage
 
 import (
 	"bytes"
+	"encoding/binary"
 	"errors"
+	"hash/fnv"
 	"io"
 
 	"github.com/flipkart-incubator/dkv/pkg/serverpb"
@@ -85,19 +87,28 @@ type Iterator interface {
 // once for every key value pair iterated.
 type Iteration interface {
 	ForEach(func(*serverpb.KVPair) error) error
+	// Open creates the underlying `Iterator`, which must be
+	// closed by the caller.
+	Open() (Iterator, error)
 }
 
 type iteration struct {
-	kvs  KVStore
-	opts *iterOpts
+	iterate func(IterationOptions) (Iterator, error)
+	opts    *iterOpts
 }
 
-func (iter *iteration) ForEach(hndlr func(*serverpb.KVPair) error) error {
+func (iter *iteration) Open() (Iterator, error) {
 	if err := iter.opts.validate(); err != nil {
-		return err
+		return nil, err
 	}
+	return iter.iterate(iter.opts)
+}
 
-	itrtr := iter.kvs.Iterate(iter.opts)
+func (iter *iteration) ForEach(hndlr func(*serverpb.KVPair) error) error {
+	itrtr, err := iter.Open()
+	if err != nil {
+		return err
+	}
 	defer itrtr.Close()
 	for itrtr.HasNext() {
 		if err := hndlr(itrtr.Next()); err != nil {
@@ -107,10 +118,56 @@ func (iter *iteration) ForEach(hndlr func(*serverpb.KVPair) error) error {
 	return itrtr.Err()
 }
 
+// ErrPinnedSnapshotsNotSupported is returned when an iteration over a
+// pinned snapshot is requested from a store lacking such a capability.
+var ErrPinnedSnapshotsNotSupported = errors.New("current storage engine does not support iterating through pinned snapshots")
+
+// A SnapshotIterable represents the capability of the underlying store
+// to iterate through a snapshot pinned for the lifetime of the iterator,
+// so that long iterations observe a single consistent view.
+type SnapshotIterable interface {
+	// IterateSnapshot is similar to Iterate except that the iterator reads
+	// from a snapshot taken at its creation, honouring the given read
+	// options if any. The snapshot is released once the iterator is closed.
+	IterateSnapshot(readOpts *serverpb.ReadOptions, iterOpts IterationOptions) Iterator
+}
+
 // NewIteration allows for the creation of an `Iteration` instance
 // that uses the underlying store's Iterator to callback for every
-// key value pair iterated.
+// key value pair iterated. The read options of the given request
+// are honoured if the store supports them, while a pinned snapshot
+// must be supported by the store if requested.
 func NewIteration(kvs KVStore, iterReq *serverpb.IterateRequest) Iteration {
 	itOpts := &iterOpts{iterReq.KeyPrefix, iterReq.StartKey}
-	return &iteration{kvs, itOpts}
+	iterate := func(opts IterationOptions) (Iterator, error) {
+		if iterReq.PinSnapshot {
+			if si, ok := kvs.(SnapshotIterable); ok {
+				return si.IterateSnapshot(iterReq.ReadOptions, opts), nil
+			}
+			return nil, ErrPinnedSnapshotsNotSupported
+		}
+		if or, ok := kvs.(OptionedReader); ok && iterReq.ReadOptions != nil {
+			return or.IterateWithOptions(iterReq.ReadOptions, opts), nil
+		}
+		return kvs.Iterate(opts), nil
+	}
+	return &iteration{iterate, itOpts}
+}
+
+// NewSnapshotIteration allows for the creation of an `Iteration` instance
+// that iterates through the given view of a published snapshot.
+func NewSnapshotIteration(view SnapshotView, iterReq *serverpb.IterateRequest) Iteration {
+	itOpts := &iterOpts{iterReq.KeyPrefix, iterReq.StartKey}
+	return &iteration{view.Iterate, itOpts}
+}
+
+// Checksum computes the checksum of the value and the expiry of the given
+// pair, so that the pairs of a key held by two nodes can be compared
+// without transferring their values.
+func Checksum(kv *serverpb.KVPair) uint64 {
+	hash, expBuf := fnv.New64a(), make([]byte, 8)
+	binary.BigEndian.PutUint64(expBuf, kv.ExpireTS)
+	hash.Write(expBuf)
+	hash.Write(kv.Value)
+	return hash.Sum64()
 }
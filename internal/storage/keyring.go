@@ -0,0 +1,168 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sync"
+)
+
+// An EncryptionKeyRotator represents the capability of the underlying
+// store to re-encrypt all its values at rest using the latest master
+// key, so that the older master keys can be retired.
+type EncryptionKeyRotator interface {
+	// RotateEncryptionKey reloads the master keys and re-encrypts all
+	// the values that are encrypted using any key other than the
+	// active one.
+	RotateEncryptionKey() (*KeyRotation, error)
+}
+
+// KeyRotation captures the outcome of re-encrypting the stored values.
+type KeyRotation struct {
+	// ActiveKeyID identifies the master key used for re-encryption.
+	ActiveKeyID string
+	// NumScanned is the number of values scanned.
+	NumScanned uint64
+	// NumReencrypted is the number of values that were re-encrypted.
+	NumReencrypted uint64
+}
+
+// sealedValuePrefix marks the values encrypted using a master key,
+// which is followed by the length and the identifier of that key.
+var sealedValuePrefix = []byte("\x00dkv-sealed\x01")
+
+// masterKey is the form in which a master key appears in the key file.
+type masterKey struct {
+	ID  string `json:"id"`
+	Key string `json:"key"`
+}
+
+// Keyring holds the master keys used for encrypting the values at rest.
+// Keys are loaded from a JSON file listing the identifier and the hex
+// encoded 256 bit key of each master key, the last of which is active
+// and used for all encryptions. The other keys are retained only for
+// decrypting the values encrypted before the latest rotation. Key
+// material kept in a KMS is expected to be materialised into this file,
+// by the deployment. It is safe for concurrent use.
+type Keyring struct {
+	file string
+
+	mu       sync.RWMutex
+	keys     map[string][]byte
+	activeID string
+}
+
+// OpenKeyring loads the master keys from the given file, which must hold
+// at least one key.
+func OpenKeyring(file string) (*Keyring, error) {
+	kr := &Keyring{file: file}
+	if err := kr.Reload(); err != nil {
+		return nil, err
+	}
+	return kr, nil
+}
+
+// Reload reloads the master keys from the key file, so that a key newly
+// appended to it becomes the active key.
+func (kr *Keyring) Reload() error {
+	data, err := ioutil.ReadFile(kr.file)
+	if err != nil {
+		return fmt.Errorf("unable to read master keys: %v", err)
+	}
+	var mks []masterKey
+	if err = json.Unmarshal(data, &mks); err != nil {
+		return fmt.Errorf("unable to parse master keys: %v", err)
+	}
+	if len(mks) == 0 {
+		return errors.New("at least one master key must be provided")
+	}
+	keys := make(map[string][]byte, len(mks))
+	for _, mk := range mks {
+		key, err := hex.DecodeString(mk.Key)
+		switch {
+		case mk.ID == "" || len(mk.ID) > 255:
+			return errors.New("identifier of every master key must be between 1 and 255 characters")
+		case err != nil || len(key) != subjectKeySize:
+			return fmt.Errorf("master key: %s must be %d hex encoded bytes", mk.ID, subjectKeySize)
+		}
+		if _, present := keys[mk.ID]; present {
+			return fmt.Errorf("master key: %s is listed more than once", mk.ID)
+		}
+		keys[mk.ID] = key
+	}
+
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	kr.keys, kr.activeID = keys, mks[len(mks)-1].ID
+	return nil
+}
+
+// ActiveKeyID retrieves the identifier of the master key used for all
+// encryptions.
+func (kr *Keyring) ActiveKeyID() string {
+	kr.mu.RLock()
+	defer kr.mu.RUnlock()
+	return kr.activeID
+}
+
+// Seal encrypts the given value using the active master key.
+func (kr *Keyring) Seal(value []byte) ([]byte, error) {
+	kr.mu.RLock()
+	keyID, key := kr.activeID, kr.keys[kr.activeID]
+	kr.mu.RUnlock()
+
+	encValue, err := EncryptValue(key, value)
+	if err != nil {
+		return nil, err
+	}
+	return envelope(sealedValuePrefix, keyID, encValue), nil
+}
+
+// Open decrypts the given value previously encrypted using Seal. Values
+// that were never sealed, such as the ones stored before enabling the
+// encryption, are returned as is.
+func (kr *Keyring) Open(value []byte) ([]byte, error) {
+	keyID, encValue, sealed := parseEnvelope(sealedValuePrefix, value)
+	if !sealed {
+		return value, nil
+	}
+	kr.mu.RLock()
+	key, present := kr.keys[keyID]
+	kr.mu.RUnlock()
+	if !present {
+		return nil, fmt.Errorf("master key: %s used for encrypting the value is not available", keyID)
+	}
+	return DecryptValue(key, encValue)
+}
+
+// NeedsRotation checks if the given value is not sealed using the
+// active master key.
+func (kr *Keyring) NeedsRotation(value []byte) bool {
+	keyID, _, sealed := parseEnvelope(sealedValuePrefix, value)
+	return !sealed || keyID != kr.ActiveKeyID()
+}
+
+// envelope prefixes the given encrypted value with the given marker
+// followed by the length and the identifier of the key used.
+func envelope(prefix []byte, keyID string, encValue []byte) []byte {
+	res := make([]byte, 0, len(prefix)+1+len(keyID)+len(encValue))
+	res = append(append(res, prefix...), byte(len(keyID)))
+	return append(append(res, keyID...), encValue...)
+}
+
+// parseEnvelope extracts the key identifier and the encrypted value from
+// the given value, if it is enveloped with the given marker.
+func parseEnvelope(prefix, value []byte) (string, []byte, bool) {
+	if !bytes.HasPrefix(value, prefix) || len(value) <= len(prefix) {
+		return "", nil, false
+	}
+	rest := value[len(prefix):]
+	keyIDLen := int(rest[0])
+	if len(rest) < 1+keyIDLen {
+		return "", nil, false
+	}
+	return string(rest[1 : 1+keyIDLen]), rest[1+keyIDLen:], true
+}
@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"strings"
+	"testing"
+)
+
+func TestKeyringRotation(t *testing.T) {
+	keyFile := path.Join(t.TempDir(), "master_keys.json")
+	writeKeyFile(t, keyFile, "k1")
+	kr, err := OpenKeyring(keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kr.ActiveKeyID() != "k1" {
+		t.Errorf("Expected k1 to be the active key. Actual: %s", kr.ActiveKeyID())
+	}
+
+	value := []byte("sensitive value")
+	sealedK1, err := kr.Seal(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(sealedK1, value) || kr.NeedsRotation(sealedK1) {
+		t.Error("Expected the value to be encrypted using the active key")
+	}
+	if opened, err := kr.Open(sealedK1); err != nil || !bytes.Equal(opened, value) {
+		t.Errorf("Expected the sealed value to be opened. Actual: %s, Error: %v", opened, err)
+	}
+	if opened, err := kr.Open(value); err != nil || !bytes.Equal(opened, value) || !kr.NeedsRotation(value) {
+		t.Errorf("Expected unsealed values to be opened as is and rotated. Actual: %s, Error: %v", opened, err)
+	}
+
+	writeKeyFile(t, keyFile, "k1", "k2")
+	if err = kr.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if kr.ActiveKeyID() != "k2" || !kr.NeedsRotation(sealedK1) {
+		t.Errorf("Expected k2 to be the active key after reload. Actual: %s", kr.ActiveKeyID())
+	}
+	if opened, err := kr.Open(sealedK1); err != nil || !bytes.Equal(opened, value) {
+		t.Errorf("Expected values sealed using the previous key to be opened. Actual: %s, Error: %v", opened, err)
+	}
+
+	writeKeyFile(t, keyFile, "k2")
+	if err = kr.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = kr.Open(sealedK1); err == nil {
+		t.Error("Expected values sealed using a retired key to be unreadable")
+	}
+}
+
+func TestInvalidKeyFiles(t *testing.T) {
+	keyFile := path.Join(t.TempDir(), "master_keys.json")
+	invalidKeys := []string{
+		`[]`,
+		`[{"id": "k1", "key": "abcd"}]`,
+		`[{"id": "", "key": "` + strings.Repeat("ab", 32) + `"}]`,
+		`[{"id": "k1", "key": "` + strings.Repeat("ab", 32) + `"}, {"id": "k1", "key": "` + strings.Repeat("cd", 32) + `"}]`,
+		`not json`,
+	}
+	for _, keys := range invalidKeys {
+		if err := ioutil.WriteFile(keyFile, []byte(keys), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := OpenKeyring(keyFile); err == nil {
+			t.Errorf("Expected an error for master keys: %s", keys)
+		}
+	}
+	if _, err := OpenKeyring(keyFile + ".missing"); err == nil {
+		t.Error("Expected an error for missing key file")
+	}
+}
+
+func writeKeyFile(t *testing.T, keyFile string, keyIDs ...string) {
+	var keys []string
+	for _, keyID := range keyIDs {
+		keys = append(keys, fmt.Sprintf(`{"id": "%s", "key": "%x"}`, keyID, sha256.Sum256([]byte(keyID))))
+	}
+	if err := ioutil.WriteFile(keyFile, []byte("["+strings.Join(keys, ",")+"]"), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,213 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// LayoutManifestFile is the name of the manifest describing the instances
+// hosted by a data root.
+const LayoutManifestFile = "layout.json"
+
+// Version of the layout manifests written by this release
+const layoutVersion = 1
+
+// Folder holding the storage engine files of the single instance layout
+const legacyDataDir = "data"
+
+// Layout describes the instances hosted by a data root, every one of which
+// is held in the sub-directory of its name. Instances are added to the
+// layout as they are opened for the first time.
+type Layout struct {
+	Version   int               `json:"version"`
+	Instances []*LayoutInstance `json:"instances"`
+}
+
+// LayoutInstance describes an instance hosted by a data root, such as a
+// shard of a namespace or the keyspace of a storage engine.
+type LayoutInstance struct {
+	Name   string `json:"name"`
+	Engine string `json:"engine"`
+}
+
+func (l *Layout) instance(name string) *LayoutInstance {
+	for _, inst := range l.Instances {
+		if inst.Name == name {
+			return inst
+		}
+	}
+	return nil
+}
+
+func (l *Layout) names() []string {
+	names := make([]string, len(l.Instances))
+	for i, inst := range l.Instances {
+		names[i] = inst.Name
+	}
+	return names
+}
+
+// OpenInstanceFolder resolves the folder holding the files of the given
+// instance within the given data root, using the given storage engine.
+// Without an instance, the data root itself is the folder of the single
+// instance layout, as long as it does not host any instances. Otherwise,
+// the instance is held in its sub-directory and is added to the layout
+// manifest of the data root when opened for the first time. Data roots
+// whose contents are not fully described by their manifest are refused,
+// as are the data roots of the single instance layout, since their files
+// can not be told apart from those of the instances. The data root is
+// locked while its manifest is read and updated, since it may be shared
+// by the processes hosting its instances.
+func OpenInstanceFolder(root, instance, engine string) (string, error) {
+	if err := os.MkdirAll(root, 0777); err != nil {
+		return "", err
+	}
+	unlock, err := lockDataRoot(root)
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
+	manifestFile := filepath.Join(root, LayoutManifestFile)
+	layout, err := loadLayout(manifestFile)
+	if err != nil {
+		return "", err
+	}
+	if instance == "" {
+		if layout != nil {
+			return "", fmt.Errorf("data root %s hosts the instances %s as described by %s, "+
+				"configure db-instance as one of them", root, strings.Join(layout.names(), ", "), manifestFile)
+		}
+		return root, nil
+	}
+	if err = validateInstanceName(instance); err != nil {
+		return "", err
+	}
+
+	entries, err := ioutil.ReadDir(root)
+	if err != nil {
+		return "", err
+	}
+	if layout == nil {
+		for _, entry := range entries {
+			if entry.Name() == legacyDataDir {
+				return "", fmt.Errorf("data root %s holds a single instance layout, move its contents into %s to host it as the instance %q, "+
+					"or leave db-instance unset", root, filepath.Join(root, instance), instance)
+			}
+		}
+		if len(entries) > 0 {
+			return "", fmt.Errorf("data root %s is not empty and has no %s describing its layout, "+
+				"configure an empty folder as db-folder for hosting instances", root, LayoutManifestFile)
+		}
+		layout = &Layout{Version: layoutVersion}
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if name != LayoutManifestFile && name != LayoutManifestFile+".tmp" && layout.instance(name) == nil {
+			return "", fmt.Errorf("data root %s holds %s which is not an instance described by %s, "+
+				"remove it or move it out of the data root", root, name, manifestFile)
+		}
+	}
+
+	switch inst := layout.instance(instance); {
+	case inst == nil:
+		layout.Instances = append(layout.Instances, &LayoutInstance{Name: instance, Engine: engine})
+		if err = saveLayout(manifestFile, layout); err != nil {
+			return "", err
+		}
+	case inst.Engine != engine:
+		return "", fmt.Errorf("instance %q of data root %s uses the %s storage engine, not %s, "+
+			"configure it as db-engine or use another instance", instance, root, inst.Engine, engine)
+	}
+	instanceFolder := filepath.Join(root, instance)
+	if err = os.MkdirAll(instanceFolder, 0777); err != nil {
+		return "", err
+	}
+	return instanceFolder, nil
+}
+
+// lockDataRoot locks the given data root exclusively, awaiting the other
+// processes holding its lock. The returned function releases the lock.
+func lockDataRoot(root string) (func(), error) {
+	dir, err := os.Open(root)
+	if err != nil {
+		return nil, err
+	}
+	if err = syscall.Flock(int(dir.Fd()), syscall.LOCK_EX); err != nil {
+		dir.Close()
+		return nil, fmt.Errorf("unable to lock data root %s: %v", root, err)
+	}
+	return func() {
+		// Closing the folder releases the lock as well
+		_ = syscall.Flock(int(dir.Fd()), syscall.LOCK_UN)
+		dir.Close()
+	}, nil
+}
+
+func validateInstanceName(instance string) error {
+	if instance == "." || instance == ".." || instance == LayoutManifestFile || strings.ContainsAny(instance, `/\`) {
+		return fmt.Errorf("invalid instance name %q, which must be a plain folder name", instance)
+	}
+	return nil
+}
+
+// loadLayout loads the layout manifest from the given file, returning nil
+// if it is missing.
+func loadLayout(file string) (*Layout, error) {
+	data, err := ioutil.ReadFile(file)
+	switch {
+	case os.IsNotExist(err):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	layout := &Layout{}
+	if err = json.Unmarshal(data, layout); err != nil {
+		return nil, fmt.Errorf("unable to parse layout manifest %s: %v", file, err)
+	}
+	if layout.Version > layoutVersion {
+		return nil, fmt.Errorf("layout manifest %s has version %d, which is newer than the supported version %d", file, layout.Version, layoutVersion)
+	}
+	return layout, nil
+}
+
+// saveLayout persists the given layout manifest into the given file,
+// replacing it atomically. The manifest is written into a temporary file
+// that is synced before being renamed over the given file, followed by
+// syncing the folder holding the file, so that a crash leaves either the
+// previous or the given manifest in place.
+func saveLayout(file string, layout *Layout) error {
+	data, err := json.MarshalIndent(layout, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmpFile := file + ".tmp"
+	f, err := os.OpenFile(tmpFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(data); err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmpFile)
+		return err
+	}
+	if err = os.Rename(tmpFile, file); err != nil {
+		return err
+	}
+	dir, err := os.Open(filepath.Dir(file))
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+	return dir.Sync()
+}
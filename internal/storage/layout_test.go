@@ -0,0 +1,114 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestOpenInstanceFolder(t *testing.T) {
+	tmpDir, err := CreateTempFolder("", "dkv-layout-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// Data roots without any instance are used as is
+	root := path.Join(tmpDir, "root")
+	if folder, err := OpenInstanceFolder(root, "", "rocksdb"); err != nil || folder != root {
+		t.Errorf("Expected the data root to be used as is, but got %s. Error: %v", folder, err)
+	}
+
+	for _, inst := range []string{"shard-1", "shard-2"} {
+		folder, err := OpenInstanceFolder(root, inst, "rocksdb")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fi, err := os.Stat(folder); err != nil || !fi.IsDir() || folder != path.Join(root, inst) {
+			t.Errorf("Expected the sub-directory of instance %s to be created, but got %s. Error: %v", inst, folder, err)
+		}
+	}
+	layout, err := loadLayout(path.Join(root, LayoutManifestFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := layout.names(); len(names) != 2 || names[0] != "shard-1" || names[1] != "shard-2" || layout.Version != layoutVersion {
+		t.Errorf("Expected the instances to be described by the manifest, but got %v", layout)
+	}
+	// Instances are reopened, even when their engine files are present
+	if err = os.MkdirAll(path.Join(root, "shard-1", "data"), 0777); err != nil {
+		t.Fatal(err)
+	}
+	if folder, err := OpenInstanceFolder(root, "shard-1", "rocksdb"); err != nil || folder != path.Join(root, "shard-1") {
+		t.Errorf("Expected instance shard-1 to be reopened, but got %s. Error: %v", folder, err)
+	}
+
+	expectRefusal := func(root, instance, engine, reason string) {
+		t.Helper()
+		if _, err := OpenInstanceFolder(root, instance, engine); err == nil || !strings.Contains(err.Error(), reason) {
+			t.Errorf("Expected instance %q of %s to be refused as %q, but got error: %v", instance, root, reason, err)
+		}
+	}
+	expectRefusal(root, "", "rocksdb", "configure db-instance as one of them")
+	expectRefusal(root, "shard-1", "badger", "uses the rocksdb storage engine")
+	expectRefusal(root, "../shard-3", "rocksdb", "invalid instance name")
+	if err = os.MkdirAll(path.Join(root, "stray"), 0777); err != nil {
+		t.Fatal(err)
+	}
+	expectRefusal(root, "shard-3", "rocksdb", "stray which is not an instance")
+
+	// Data roots of the single instance layout must be migrated explicitly
+	legacyRoot := path.Join(tmpDir, "legacy")
+	if err = os.MkdirAll(path.Join(legacyRoot, "data"), 0777); err != nil {
+		t.Fatal(err)
+	}
+	expectRefusal(legacyRoot, "shard-1", "rocksdb", "holds a single instance layout")
+	if folder, err := OpenInstanceFolder(legacyRoot, "", "rocksdb"); err != nil || folder != legacyRoot {
+		t.Errorf("Expected the single instance layout to be used as is, but got %s. Error: %v", folder, err)
+	}
+	if err = os.RemoveAll(path.Join(legacyRoot, "data")); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.MkdirAll(path.Join(legacyRoot, "unknown"), 0777); err != nil {
+		t.Fatal(err)
+	}
+	expectRefusal(legacyRoot, "shard-1", "rocksdb", "has no layout.json describing its layout")
+}
+
+func TestOpenInstanceFolderConcurrently(t *testing.T) {
+	tmpDir, err := CreateTempFolder("", "dkv-layout-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// Instances opened concurrently must all be added to the manifest
+	var wg sync.WaitGroup
+	numInsts := 10
+	errs := make(chan error, numInsts)
+	for i := 0; i < numInsts; i++ {
+		wg.Add(1)
+		go func(inst string) {
+			defer wg.Done()
+			_, err := OpenInstanceFolder(tmpDir, inst, "rocksdb")
+			errs <- err
+		}(fmt.Sprintf("shard-%d", i))
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+	layout, err := loadLayout(path.Join(tmpDir, LayoutManifestFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := layout.names(); len(names) != numInsts {
+		t.Errorf("Expected %d instances to be described by the manifest, but got %v", numInsts, names)
+	}
+}
@@ -0,0 +1,149 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"sync"
+)
+
+// ErrUnderLegalHold is returned when attempting to delete a key
+// that belongs to a namespace or key prefix under legal hold.
+var ErrUnderLegalHold = errors.New("key is under legal hold")
+
+// A LegalHolder represents the capability of the underlying store
+// to place legal holds over namespaces or key prefixes. Keys under
+// a legal hold never expire and cannot be deleted until the hold
+// is lifted.
+type LegalHolder interface {
+	// PlaceLegalHold places a legal hold over all the keys having
+	// the given prefix.
+	PlaceLegalHold(keyPrefix []byte) error
+	// LiftLegalHold lifts the legal hold previously placed over
+	// the given prefix.
+	LiftLegalHold(keyPrefix []byte) error
+	// ListLegalHolds retrieves all the prefixes under legal hold.
+	ListLegalHolds() [][]byte
+	// UnderLegalHold checks if the given key belongs to any of the
+	// prefixes under legal hold.
+	UnderLegalHold(key []byte) bool
+}
+
+var legalHoldRecordPrefix = append(append([]byte(nil), reservedKeyPrefix...), "legal-hold:"...)
+
+// LegalHoldRecord computes the reserved key under which the legal hold
+// over the given prefix is stored, so that the holds are replicated
+// along with the keys they cover.
+func LegalHoldRecord(keyPrefix []byte) []byte {
+	return append(append([]byte(nil), legalHoldRecordPrefix...), keyPrefix...)
+}
+
+// LegalHoldRecordPrefix is the prefix of the reserved keys under which
+// the legal holds are stored.
+func LegalHoldRecordPrefix() []byte {
+	return append([]byte(nil), legalHoldRecordPrefix...)
+}
+
+// LegalHolds is an in memory registry of key prefixes under legal hold
+// that is safe for concurrent use. Stores persist the holds themselves,
+// loading them into the registry upon opening.
+type LegalHolds struct {
+	mu       sync.RWMutex
+	prefixes [][]byte
+	// Holds every key until the prefixes are loaded
+	pending bool
+}
+
+// NewLegalHolds creates a registry of legal holds over the given
+// prefixes.
+func NewLegalHolds(prefixes [][]byte) *LegalHolds {
+	lh := &LegalHolds{}
+	lh.Reset(prefixes)
+	return lh
+}
+
+// NewPendingLegalHolds creates a registry of legal holds whose prefixes
+// are yet to be loaded, which holds every key until then. Hence the keys
+// are not dropped by the compactions running while the store is opened.
+func NewPendingLegalHolds() *LegalHolds {
+	return &LegalHolds{pending: true}
+}
+
+// Reset replaces the prefixes under legal hold with the given ones.
+func (lh *LegalHolds) Reset(prefixes [][]byte) {
+	prefixes = append([][]byte(nil), prefixes...)
+	sort.Slice(prefixes, func(i, j int) bool { return bytes.Compare(prefixes[i], prefixes[j]) < 0 })
+	lh.mu.Lock()
+	defer lh.mu.Unlock()
+	lh.prefixes, lh.pending = prefixes, false
+}
+
+// PlaceLegalHold places a legal hold over the given prefix. Placing
+// a hold over an already held prefix has no effect.
+func (lh *LegalHolds) PlaceLegalHold(keyPrefix []byte) error {
+	if len(keyPrefix) == 0 {
+		return errors.New("key prefix must be provided for placing a legal hold")
+	}
+	lh.mu.Lock()
+	defer lh.mu.Unlock()
+	if lh.indexOf(keyPrefix) >= 0 {
+		return nil
+	}
+	prefixes := append(lh.copyPrefixes(), append([]byte(nil), keyPrefix...))
+	sort.Slice(prefixes, func(i, j int) bool { return bytes.Compare(prefixes[i], prefixes[j]) < 0 })
+	lh.prefixes = prefixes
+	return nil
+}
+
+// LiftLegalHold lifts the legal hold over the given prefix. Returns
+// an error if the given prefix is not under legal hold.
+func (lh *LegalHolds) LiftLegalHold(keyPrefix []byte) error {
+	lh.mu.Lock()
+	defer lh.mu.Unlock()
+	idx := lh.indexOf(keyPrefix)
+	if idx < 0 {
+		return errors.New("given key prefix is not under legal hold")
+	}
+	prefixes := lh.copyPrefixes()
+	lh.prefixes = append(prefixes[:idx], prefixes[idx+1:]...)
+	return nil
+}
+
+// ListLegalHolds retrieves all the prefixes under legal hold in
+// their lexicographical order.
+func (lh *LegalHolds) ListLegalHolds() [][]byte {
+	lh.mu.RLock()
+	defer lh.mu.RUnlock()
+	return lh.copyPrefixes()
+}
+
+// UnderLegalHold checks if the given key belongs to any of the prefixes
+// under legal hold.
+func (lh *LegalHolds) UnderLegalHold(key []byte) bool {
+	lh.mu.RLock()
+	defer lh.mu.RUnlock()
+	if lh.pending {
+		return true
+	}
+	for _, prefix := range lh.prefixes {
+		if bytes.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (lh *LegalHolds) indexOf(keyPrefix []byte) int {
+	for i, prefix := range lh.prefixes {
+		if bytes.Equal(prefix, keyPrefix) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (lh *LegalHolds) copyPrefixes() [][]byte {
+	prefixes := make([][]byte, len(lh.prefixes))
+	copy(prefixes, lh.prefixes)
+	return prefixes
+}
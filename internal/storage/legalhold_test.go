@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"testing"
+)
+
+func TestLegalHolds(t *testing.T) {
+	lh := NewPendingLegalHolds()
+	if !lh.UnderLegalHold([]byte("order:1")) {
+		t.Error("Expected every key to be under legal hold until the holds are loaded")
+	}
+	lh.Reset(nil)
+	if lh.UnderLegalHold([]byte("order:1")) {
+		t.Error("Did not expect any key to be under legal hold once loaded")
+	}
+
+	if err := lh.PlaceLegalHold(nil); err == nil {
+		t.Error("Expected an error for placing a legal hold without a key prefix")
+	}
+	if err := lh.PlaceLegalHold([]byte("user:")); err != nil {
+		t.Fatal(err)
+	}
+	if !lh.UnderLegalHold([]byte("user:1")) || lh.UnderLegalHold([]byte("order:1")) {
+		t.Error("Legal hold must only cover the keys having the held prefix")
+	}
+	if holds := lh.ListLegalHolds(); len(holds) != 1 || string(holds[0]) != "user:" {
+		t.Errorf("Expected only user: to be under legal hold, but got %q", holds)
+	}
+
+	if err := lh.LiftLegalHold([]byte("user:")); err != nil {
+		t.Fatal(err)
+	}
+	if err := lh.LiftLegalHold([]byte("user:")); err == nil {
+		t.Error("Expected an error for lifting a legal hold that is not placed")
+	}
+	if lh.UnderLegalHold([]byte("user:1")) {
+		t.Error("Did not expect key to be under legal hold once lifted")
+	}
+}
@@ -0,0 +1,45 @@
+package storage
+
+import "sync"
+
+// A ChangeNotifier represents the capability of the underlying store
+// to notify the commits of changes, sparing the consumers of the
+// changes from polling for them.
+type ChangeNotifier interface {
+	// ChangesCommitted returns a channel that is closed once any
+	// change is committed after this call.
+	ChangesCommitted() <-chan struct{}
+}
+
+// CommitSignal notifies the commits to any number of waiters, by
+// closing the channel handed out to them. A new channel is handed
+// out only after the previous one is closed, hence the commits
+// without any waiters are not charged for the notification.
+type CommitSignal struct {
+	mu     sync.Mutex
+	ch     chan struct{}
+	waited bool
+}
+
+// NewCommitSignal creates a signal for notifying the commits.
+func NewCommitSignal() *CommitSignal {
+	return &CommitSignal{ch: make(chan struct{})}
+}
+
+// Wait returns a channel that is closed by the next Signal.
+func (cs *CommitSignal) Wait() <-chan struct{} {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	cs.waited = true
+	return cs.ch
+}
+
+// Signal notifies a commit to all the current waiters.
+func (cs *CommitSignal) Signal() {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	if cs.waited {
+		close(cs.ch)
+		cs.ch, cs.waited = make(chan struct{}), false
+	}
+}
@@ -0,0 +1,28 @@
+package storage
+
+import "testing"
+
+func TestCommitSignal(t *testing.T) {
+	cs := NewCommitSignal()
+	// Commits without any waiters are not notified to the later waiters
+	cs.Signal()
+	first, second := cs.Wait(), cs.Wait()
+	select {
+	case <-first:
+		t.Fatal("Did not expect a commit to be notified before it happens")
+	default:
+	}
+	cs.Signal()
+	for _, committed := range []<-chan struct{}{first, second} {
+		select {
+		case <-committed:
+		default:
+			t.Error("Expected the commit to be notified to every waiter")
+		}
+	}
+	select {
+	case <-cs.Wait():
+		t.Error("Did not expect an earlier commit to be notified to a new waiter")
+	default:
+	}
+}
@@ -0,0 +1,231 @@
+package storage
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"github.com/matttproud/golang_protobuf_extensions/pbutil"
+)
+
+// BackupManifestFileName is the name of the file that holds the
+// manifest of a backup when the backup is a folder. For file based
+// backups, the manifest is stored alongside with this name as suffix.
+const BackupManifestFileName = "dkv_backup.manifest"
+
+// A BackupManifest captures the metadata associated with a backup
+// that is necessary to rebuild a store upto a specific point in time.
+type BackupManifest struct {
+	// ChangeNumber is the latest committed change number at the time
+	// of taking the backup. All changes upto and including this change
+	// number are guaranteed to be present in the backup.
+	ChangeNumber uint64 `json:"changeNumber"`
+	// CreatedAt is the epoch seconds at which the backup was taken.
+	CreatedAt int64 `json:"createdAt"`
+}
+
+func backupManifestPath(backupPath string) string {
+	if fi, err := os.Stat(backupPath); err == nil && fi.IsDir() {
+		return path.Join(backupPath, BackupManifestFileName)
+	}
+	return fmt.Sprintf("%s.%s", path.Clean(backupPath), BackupManifestFileName)
+}
+
+// WriteBackupManifest stores the given manifest against the backup
+// present at the given `backupPath`.
+func WriteBackupManifest(backupPath string, manifest *BackupManifest) error {
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(backupManifestPath(backupPath), data, 0644)
+}
+
+// ReadBackupManifest loads the manifest associated with the backup
+// present at the given `backupPath`.
+func ReadBackupManifest(backupPath string) (*BackupManifest, error) {
+	data, err := ioutil.ReadFile(backupManifestPath(backupPath))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read backup manifest: %v", err)
+	}
+	manifest := new(BackupManifest)
+	if err = json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("unable to parse backup manifest: %v", err)
+	}
+	return manifest, nil
+}
+
+const maxChangesPerLoad = 1000
+
+var (
+	errChangesNotRetained   = errors.New("changes required for point in time restore are no longer retained")
+	errPITRNotSupported     = errors.New("point in time restore is not supported by the current storage engine")
+	errChangesNotApplicable = errors.New("restored store does not support applying changes")
+)
+
+// ForEachChangeUpto invokes the given handler with batches of the changes
+// retrieved from the given ChangePropagator, that are committed after
+// `fromChangeNumber` upto and including the given `toChangeNumber`.
+// Change records are never split, so an error is returned when the given
+// `toChangeNumber` falls within a change record, before it is handled.
+func ForEachChangeUpto(cp ChangePropagator, fromChangeNumber, toChangeNumber uint64, hndlr func([]*serverpb.ChangeRecord) error) error {
+	nextChngNum := fromChangeNumber + 1
+	for nextChngNum <= toChangeNumber {
+		chngs, err := cp.LoadChanges(nextChngNum, maxChangesPerLoad)
+		if err != nil {
+			return err
+		}
+		if len(chngs) == 0 || chngs[0].ChangeNumber > nextChngNum {
+			return errChangesNotRetained
+		}
+		numChngs := 0
+		for _, chng := range chngs {
+			if chng.ChangeNumber > toChangeNumber {
+				break
+			}
+			lastChngNum := chng.ChangeNumber + uint64(chng.NumberOfTrxns) - 1
+			if lastChngNum > toChangeNumber {
+				return fmt.Errorf("change number %d falls within the change record of change numbers %d to %d, which cannot be restored partially",
+					toChangeNumber, chng.ChangeNumber, lastChngNum)
+			}
+			numChngs++
+			nextChngNum = lastChngNum + 1
+		}
+		if err = hndlr(chngs[:numChngs]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// A PointInTimeRestore rebuilds a store from a backup and replays on top
+// of it the changes committed after the backup, upto a change number.
+// Since the changes of a store are discarded once it is restored, they
+// are spilled into a temporary file beforehand, from which they are then
+// replayed in batches, so that they are never held in memory altogether.
+type PointInTimeRestore struct {
+	br           Backupable
+	backupPath   string
+	changeNumber uint64
+	changes      *os.File
+	numChanges   int
+}
+
+// PreparePointInTimeRestore validates the restore of a store from the
+// backup present at `backupPath` upto and including `changeNumber`, and
+// spills the changes retained by the given ChangePropagator that are
+// required to be replayed. The store itself is left untouched, so that
+// it continues to be served when the restore cannot be performed.
+// The returned restore must be closed once done.
+func PreparePointInTimeRestore(br Backupable, cp ChangePropagator, backupPath string, changeNumber uint64) (*PointInTimeRestore, error) {
+	if br == nil || cp == nil {
+		return nil, errPITRNotSupported
+	}
+
+	manifest, err := ReadBackupManifest(backupPath)
+	if err != nil {
+		return nil, err
+	}
+	if changeNumber < manifest.ChangeNumber {
+		return nil, fmt.Errorf("given change number %d is older than the backup change number %d", changeNumber, manifest.ChangeNumber)
+	}
+	latestChngNum, err := cp.GetLatestCommittedChangeNumber()
+	if err != nil {
+		return nil, err
+	}
+	if changeNumber > latestChngNum {
+		return nil, fmt.Errorf("given change number %d is beyond the latest committed change number %d", changeNumber, latestChngNum)
+	}
+
+	changes, err := ioutil.TempFile("", "dkv-pitr-changes-")
+	if err != nil {
+		return nil, err
+	}
+	pitr := &PointInTimeRestore{br: br, backupPath: backupPath, changeNumber: changeNumber, changes: changes}
+	w := bufio.NewWriter(changes)
+	err = ForEachChangeUpto(cp, manifest.ChangeNumber, changeNumber, func(chngs []*serverpb.ChangeRecord) error {
+		for _, chng := range chngs {
+			if _, err := pbutil.WriteDelimited(w, chng); err != nil {
+				return err
+			}
+		}
+		pitr.numChanges += len(chngs)
+		return nil
+	})
+	if err == nil {
+		err = w.Flush()
+	}
+	if err != nil {
+		pitr.Close()
+		return nil, err
+	}
+	return pitr, nil
+}
+
+// Restore closes the given store, rebuilds it from the backup and then
+// replays the spilled changes on top of it. Returns the various traits of
+// the newly restored store upon successful restoration, else an error.
+func (pitr *PointInTimeRestore) Restore(st KVStore) (KVStore, Backupable, ChangePropagator, ChangeApplier, error) {
+	st.Close()
+	newSt, newBr, newCp, newCa, err := pitr.br.RestoreFrom(pitr.backupPath)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	if pitr.numChanges > 0 {
+		if newCa == nil {
+			return newSt, newBr, newCp, newCa, errChangesNotApplicable
+		}
+		if err = pitr.replayChanges(newCa); err != nil {
+			return newSt, newBr, newCp, newCa, err
+		}
+	}
+	return newSt, newBr, newCp, newCa, nil
+}
+
+func (pitr *PointInTimeRestore) replayChanges(ca ChangeApplier) error {
+	if _, err := pitr.changes.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	r := bufio.NewReader(pitr.changes)
+	chngs := make([]*serverpb.ChangeRecord, 0, maxChangesPerLoad)
+	for i := 0; i < pitr.numChanges; i++ {
+		chng := new(serverpb.ChangeRecord)
+		if _, err := pbutil.ReadDelimited(r, chng); err != nil {
+			return fmt.Errorf("unable to read the changes to replay: %v", err)
+		}
+		if chngs = append(chngs, chng); len(chngs) == maxChangesPerLoad || i == pitr.numChanges-1 {
+			if _, err := ca.SaveChanges(chngs); err != nil {
+				return err
+			}
+			chngs = chngs[:0]
+		}
+	}
+	return nil
+}
+
+// Close discards the spilled changes.
+func (pitr *PointInTimeRestore) Close() error {
+	pitr.changes.Close()
+	return os.Remove(pitr.changes.Name())
+}
+
+// RestoreToChangeNumber rebuilds the given store from the backup present
+// at `backupPath` and then replays the changes retained by the given
+// ChangePropagator on top of it, upto and including `changeNumber`.
+// The given store is closed only once the restore is found to be possible.
+// Returns the various traits of the newly restored store upon successful
+// restoration, else an error.
+func RestoreToChangeNumber(st KVStore, br Backupable, cp ChangePropagator, backupPath string, changeNumber uint64) (KVStore, Backupable, ChangePropagator, ChangeApplier, error) {
+	pitr, err := PreparePointInTimeRestore(br, cp, backupPath, changeNumber)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	defer pitr.Close()
+	return pitr.Restore(st)
+}
@@ -0,0 +1,170 @@
+package storage
+
+import (
+	"os"
+	"testing"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+)
+
+type memChangePropagator struct {
+	chngs []*serverpb.ChangeRecord
+}
+
+func (mcp *memChangePropagator) GetLatestCommittedChangeNumber() (uint64, error) {
+	last := mcp.chngs[len(mcp.chngs)-1]
+	return last.ChangeNumber + uint64(last.NumberOfTrxns) - 1, nil
+}
+
+func (mcp *memChangePropagator) LoadChanges(fromChangeNumber uint64, maxChanges int) ([]*serverpb.ChangeRecord, error) {
+	var res []*serverpb.ChangeRecord
+	for _, chng := range mcp.chngs {
+		if len(res) == maxChanges {
+			break
+		}
+		if chng.ChangeNumber+uint64(chng.NumberOfTrxns)-1 >= fromChangeNumber {
+			res = append(res, chng)
+		}
+	}
+	return res, nil
+}
+
+func TestBackupManifest(t *testing.T) {
+	backupDir, err := CreateTempFolder("", "dkv-manifest-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(backupDir)
+
+	if _, err = ReadBackupManifest(backupDir); err == nil {
+		t.Error("Expected an error for missing backup manifest")
+	}
+
+	expManifest := &BackupManifest{ChangeNumber: 42, CreatedAt: 1000}
+	if err = WriteBackupManifest(backupDir, expManifest); err != nil {
+		t.Fatal(err)
+	}
+	if manifest, err := ReadBackupManifest(backupDir); err != nil {
+		t.Fatal(err)
+	} else if *manifest != *expManifest {
+		t.Errorf("Backup manifest mismatch. Expected: %v, Actual: %v", expManifest, manifest)
+	}
+}
+
+func TestForEachChangeUpto(t *testing.T) {
+	mcp := &memChangePropagator{chngs: []*serverpb.ChangeRecord{
+		{ChangeNumber: 5, NumberOfTrxns: 1},
+		{ChangeNumber: 6, NumberOfTrxns: 2},
+		{ChangeNumber: 8, NumberOfTrxns: 1},
+		{ChangeNumber: 9, NumberOfTrxns: 3},
+	}}
+	collectUpto := func(from, to uint64) ([]*serverpb.ChangeRecord, error) {
+		var res []*serverpb.ChangeRecord
+		err := ForEachChangeUpto(mcp, from, to, func(chngs []*serverpb.ChangeRecord) error {
+			res = append(res, chngs...)
+			return nil
+		})
+		return res, err
+	}
+
+	if chngs, err := collectUpto(5, 8); err != nil {
+		t.Fatal(err)
+	} else if len(chngs) != 2 || chngs[0].ChangeNumber != 6 || chngs[1].ChangeNumber != 8 {
+		t.Errorf("Expected change records 6 and 8, but got %v", chngs)
+	}
+
+	if chngs, err := collectUpto(5, 11); err != nil {
+		t.Fatal(err)
+	} else if len(chngs) != 3 {
+		t.Errorf("Expected change records 6, 8 and 9, but got %v", chngs)
+	}
+
+	if _, err := collectUpto(5, 10); err == nil {
+		t.Error("Expected an error since the change number falls within a change record")
+	}
+
+	if _, err := collectUpto(2, 8); err == nil {
+		t.Error("Expected an error since the required changes are not retained")
+	}
+}
+
+type memBackupable struct {
+	ca *memChangeApplier
+}
+
+func (mb *memBackupable) BackupTo(string) error { return nil }
+
+func (mb *memBackupable) RestoreFrom(string) (KVStore, Backupable, ChangePropagator, ChangeApplier, error) {
+	return nil, mb, nil, mb.ca, nil
+}
+
+type memChangeApplier struct {
+	batches [][]*serverpb.ChangeRecord
+}
+
+func (mca *memChangeApplier) GetLatestAppliedChangeNumber() (uint64, error) {
+	return 0, nil
+}
+
+func (mca *memChangeApplier) SaveChanges(chngs []*serverpb.ChangeRecord) (uint64, error) {
+	mca.batches = append(mca.batches, append([]*serverpb.ChangeRecord{}, chngs...))
+	return chngs[len(chngs)-1].ChangeNumber, nil
+}
+
+type memKVStore struct {
+	KVStore
+	closed bool
+}
+
+func (mkv *memKVStore) Close() error {
+	mkv.closed = true
+	return nil
+}
+
+func TestPointInTimeRestoreReplay(t *testing.T) {
+	numChngs := maxChangesPerLoad + 10
+	mcp := &memChangePropagator{}
+	for i := 1; i <= numChngs; i++ {
+		mcp.chngs = append(mcp.chngs, &serverpb.ChangeRecord{ChangeNumber: uint64(i), NumberOfTrxns: 1, SerialisedForm: []byte{byte(i)}})
+	}
+	backupDir, err := CreateTempFolder("", "dkv-pitr-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(backupDir)
+	if err = WriteBackupManifest(backupDir, &BackupManifest{ChangeNumber: 5}); err != nil {
+		t.Fatal(err)
+	}
+
+	mb := &memBackupable{ca: &memChangeApplier{}}
+	if _, err = PreparePointInTimeRestore(mb, mcp, backupDir, 2); err == nil {
+		t.Error("Expected an error for change number older than the backup")
+	}
+	pitr, err := PreparePointInTimeRestore(mb, mcp, backupDir, uint64(numChngs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pitr.Close()
+	mkv := &memKVStore{}
+	if _, _, _, _, err = pitr.Restore(mkv); err != nil {
+		t.Fatal(err)
+	}
+	if !mkv.closed {
+		t.Error("Expected the store to be closed upon restore")
+	}
+	if len(mb.ca.batches) != 2 || len(mb.ca.batches[0]) != maxChangesPerLoad {
+		t.Fatalf("Expected the changes to be replayed in batches of %d", maxChangesPerLoad)
+	}
+	chngNum := uint64(6)
+	for _, batch := range mb.ca.batches {
+		for _, chng := range batch {
+			if chng.ChangeNumber != chngNum || chng.SerialisedForm[0] != byte(chngNum) {
+				t.Fatalf("Expected change number %d to be replayed, but got %v", chngNum, chng)
+			}
+			chngNum++
+		}
+	}
+	if chngNum != uint64(numChngs)+1 {
+		t.Errorf("Expected changes upto %d to be replayed, but got upto %d", numChngs, chngNum-1)
+	}
+}
@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"fmt"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+)
+
+const (
+	// QuarantineSourceScrubber identifies the records quarantined by
+	// the background scrubber.
+	QuarantineSourceScrubber = "scrubber"
+	// QuarantineSourceReplication identifies the changes quarantined
+	// while being applied on a slave.
+	QuarantineSourceReplication = "replication"
+)
+
+var quarantinePrefix = append(append([]byte(nil), reservedKeyPrefix...), "quarantine:"...)
+
+// QuarantinePrefix is the prefix of the internal keys making up the
+// quarantine namespace, into which the corrupt or rejected records are
+// set aside so that they are no longer served or applied, while their
+// evidence is preserved for forensics.
+func QuarantinePrefix() []byte {
+	return append([]byte(nil), quarantinePrefix...)
+}
+
+// QuarantineKey is the internal key under which the given record is
+// kept in the quarantine namespace. Records are identified by their key
+// or by their change number, depending on their source.
+func QuarantineKey(rec *serverpb.QuarantinedRecord) []byte {
+	id := rec.Key
+	if rec.Source == QuarantineSourceReplication {
+		id = []byte(fmt.Sprintf("%020d", rec.ChangeNumber))
+	}
+	qKey := append(QuarantinePrefix(), rec.Source...)
+	qKey = append(qKey, ':')
+	return append(qKey, id...)
+}
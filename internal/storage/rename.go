@@ -0,0 +1,309 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+)
+
+// ErrPrefixRenameNotFound is returned when the given key prefix is not
+// being renamed.
+var ErrPrefixRenameNotFound = errors.New("given key prefix is not being renamed")
+
+// Renaming a key prefix is a two phase cutover. While the keys of the
+// old prefix are being copied to the new prefix, and until the rename
+// is completed thereafter, the reads of the keys absent under the new
+// prefix fall back to the same keys under the old prefix. Hence the
+// clients can switch over to the new prefix right away. Once completed,
+// the reads no longer fall back and the keys of the old prefix are
+// deleted.
+
+// prefixRenamesRecord is the internal key under which the registry of
+// prefix renames is held by the store.
+var prefixRenamesRecord = append(append([]byte(nil), reservedKeyPrefix...), "prefix-renames"...)
+
+// prefixRename is a rename in progress. The number of keys is tracked
+// only in memory.
+type prefixRename struct {
+	OldPrefix []byte                     `json:"oldPrefix"`
+	NewPrefix []byte                     `json:"newPrefix"`
+	Phase     serverpb.PrefixRenamePhase `json:"phase"`
+	numKeys   uint64
+}
+
+// PrefixRenames is a registry of the key prefix renames in progress that
+// is safe for concurrent use. When backed by a store, every change to the
+// phase of a rename is saved as an internal record of the store, so that
+// renames survive restarts and are replicated along with the changes of
+// the store.
+type PrefixRenames struct {
+	mu      sync.RWMutex
+	rs      RecordStore
+	renames []*prefixRename
+}
+
+// OpenPrefixRenames loads the registry of prefix renames from the given
+// store. A missing record results in an empty registry, while a nil store
+// results in a registry that is only held in memory.
+func OpenPrefixRenames(rs RecordStore) (*PrefixRenames, error) {
+	pr := &PrefixRenames{rs: rs}
+	if err := pr.Reload(); err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+// Reload loads the registry afresh from its store, such as after the
+// record of the registry is replicated from another node.
+func (pr *PrefixRenames) Reload() error {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	return pr.reload()
+}
+
+// Reopen switches the registry over to the given store, such as after
+// the store is restored, and loads the registry afresh from it.
+func (pr *PrefixRenames) Reopen(rs RecordStore) error {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	pr.rs = rs
+	return pr.reload()
+}
+
+func (pr *PrefixRenames) reload() error {
+	if pr.rs == nil {
+		return nil
+	}
+	data, err := pr.rs.LoadRecord(prefixRenamesRecord)
+	if err != nil {
+		return err
+	}
+	var renames []*prefixRename
+	if len(data) > 0 {
+		if err = json.Unmarshal(data, &renames); err != nil {
+			return err
+		}
+	}
+	// Keys counted so far are retained for the renames still in progress
+	for _, rename := range renames {
+		if idx := pr.indexOf(rename.OldPrefix); idx >= 0 && pr.renames[idx].Phase == rename.Phase {
+			rename.numKeys = pr.renames[idx].numKeys
+		}
+	}
+	pr.renames = renames
+	return nil
+}
+
+// ChangesPrefixRenames checks if the given change replicated from another
+// node may alter the registry of prefix renames.
+func ChangesPrefixRenames(chng *serverpb.ChangeRecord) bool {
+	return bytes.Contains(chng.SerialisedForm, prefixRenamesRecord)
+}
+
+// Begin registers the rename of the given old prefix to the given new
+// prefix. Beginning a rename that is already being copied has no effect,
+// so that interrupted renames can be resumed.
+func (pr *PrefixRenames) Begin(oldPrefix, newPrefix []byte) error {
+	if len(oldPrefix) == 0 || len(newPrefix) == 0 {
+		return errors.New("old and new key prefixes must be provided for renaming a key prefix")
+	}
+	if prefixesOverlap(oldPrefix, newPrefix) {
+		return errors.New("old and new key prefixes must not overlap")
+	}
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	for _, rename := range pr.renames {
+		if bytes.Equal(rename.OldPrefix, oldPrefix) && bytes.Equal(rename.NewPrefix, newPrefix) && rename.Phase != serverpb.PrefixRenamePhase_SWEEPING {
+			return nil
+		}
+		for _, prefix := range [][]byte{oldPrefix, newPrefix} {
+			if prefixesOverlap(prefix, rename.OldPrefix) || prefixesOverlap(prefix, rename.NewPrefix) {
+				return fmt.Errorf("key prefix %q overlaps with the rename in progress of %q to %q", prefix, rename.OldPrefix, rename.NewPrefix)
+			}
+		}
+	}
+	rename := &prefixRename{OldPrefix: append([]byte(nil), oldPrefix...), NewPrefix: append([]byte(nil), newPrefix...)}
+	return pr.update(append(pr.copyRenames(), rename))
+}
+
+// SetPhase moves the rename of the given old prefix to the given phase,
+// resetting the number of keys tracked for it.
+func (pr *PrefixRenames) SetPhase(oldPrefix []byte, phase serverpb.PrefixRenamePhase) error {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	idx := pr.indexOf(oldPrefix)
+	if idx < 0 {
+		return ErrPrefixRenameNotFound
+	}
+	renames := pr.copyRenames()
+	renames[idx] = &prefixRename{OldPrefix: renames[idx].OldPrefix, NewPrefix: renames[idx].NewPrefix, Phase: phase}
+	return pr.update(renames)
+}
+
+// End removes the rename of the given old prefix from the registry.
+func (pr *PrefixRenames) End(oldPrefix []byte) error {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	idx := pr.indexOf(oldPrefix)
+	if idx < 0 {
+		return ErrPrefixRenameNotFound
+	}
+	renames := pr.copyRenames()
+	return pr.update(append(renames[:idx], renames[idx+1:]...))
+}
+
+// Progress adds the given number of keys copied or deleted to the rename
+// of the given old prefix.
+func (pr *PrefixRenames) Progress(oldPrefix []byte, numKeys uint64) {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	if idx := pr.indexOf(oldPrefix); idx >= 0 {
+		pr.renames[idx].numKeys += numKeys
+	}
+}
+
+// Lookup retrieves the rename of the given old prefix, if any.
+func (pr *PrefixRenames) Lookup(oldPrefix []byte) (*serverpb.PrefixRename, bool) {
+	pr.mu.RLock()
+	defer pr.mu.RUnlock()
+	idx := pr.indexOf(oldPrefix)
+	if idx < 0 {
+		return nil, false
+	}
+	return pr.renames[idx].toProto(), true
+}
+
+// List retrieves all the renames in progress in the lexicographical order
+// of their old prefixes.
+func (pr *PrefixRenames) List() []*serverpb.PrefixRename {
+	pr.mu.RLock()
+	defer pr.mu.RUnlock()
+	renames := make([]*serverpb.PrefixRename, len(pr.renames))
+	for i, rename := range pr.renames {
+		renames[i] = rename.toProto()
+	}
+	sort.Slice(renames, func(i, j int) bool { return bytes.Compare(renames[i].OldPrefix, renames[j].OldPrefix) < 0 })
+	return renames
+}
+
+// FallbackKey retrieves the key under the old prefix to which the reads of
+// the given key fall back, if the key belongs to the new prefix of a rename
+// that is not yet completed.
+func (pr *PrefixRenames) FallbackKey(key []byte) ([]byte, bool) {
+	pr.mu.RLock()
+	defer pr.mu.RUnlock()
+	for _, rename := range pr.renames {
+		if rename.Phase != serverpb.PrefixRenamePhase_SWEEPING && bytes.HasPrefix(key, rename.NewPrefix) {
+			return append(append([]byte(nil), rename.OldPrefix...), key[len(rename.NewPrefix):]...), true
+		}
+	}
+	return nil, false
+}
+
+// MirrorKey retrieves the key under the new prefix onto which the writes
+// of the given key are mirrored, if the key belongs to the old prefix of
+// a rename that is not yet completed. Mirroring the writes prevents those
+// made after the key is copied from being lost.
+func (pr *PrefixRenames) MirrorKey(key []byte) ([]byte, bool) {
+	pr.mu.RLock()
+	defer pr.mu.RUnlock()
+	for _, rename := range pr.renames {
+		if rename.Phase != serverpb.PrefixRenamePhase_SWEEPING && bytes.HasPrefix(key, rename.OldPrefix) {
+			return append(append([]byte(nil), rename.NewPrefix...), key[len(rename.OldPrefix):]...), true
+		}
+	}
+	return nil, false
+}
+
+// Read loads the given keys using the given read function, typically the
+// Get of a store, loading the keys that are absent from their fallback
+// keys. Pairs loaded from the fallback keys are returned under the given
+// keys. Like the reads of a store, only the pairs of the present keys are
+// returned.
+func (pr *PrefixRenames) Read(read func(keys ...[]byte) ([]*serverpb.KVPair, error), keys ...[]byte) ([]*serverpb.KVPair, error) {
+	kvs, err := read(keys...)
+	if err != nil || pr == nil {
+		return kvs, err
+	}
+	present := make(map[string]bool, len(kvs))
+	for _, kv := range kvs {
+		// Empty values are read as absent
+		present[string(kv.Key)] = len(kv.Value) > 0
+	}
+	var fallbackKeys [][]byte
+	fallbackOf := make(map[string][]byte)
+	for _, key := range keys {
+		if present[string(key)] {
+			continue
+		}
+		if fallbackKey, ok := pr.FallbackKey(key); ok {
+			if _, seen := fallbackOf[string(fallbackKey)]; !seen {
+				fallbackKeys = append(fallbackKeys, fallbackKey)
+			}
+			fallbackOf[string(fallbackKey)] = key
+		}
+	}
+	if len(fallbackKeys) == 0 {
+		return kvs, nil
+	}
+	fallbackKVs, err := read(fallbackKeys...)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*serverpb.KVPair, 0, len(kvs)+len(fallbackKVs))
+	for _, kv := range kvs {
+		if present[string(kv.Key)] {
+			results = append(results, kv)
+		}
+	}
+	for _, kv := range fallbackKVs {
+		if key, ok := fallbackOf[string(kv.Key)]; ok && len(kv.Value) > 0 {
+			results = append(results, &serverpb.KVPair{Key: key, Value: kv.Value, ExpireTS: kv.ExpireTS})
+		}
+	}
+	return results, nil
+}
+
+func (rename *prefixRename) toProto() *serverpb.PrefixRename {
+	return &serverpb.PrefixRename{OldPrefix: rename.OldPrefix, NewPrefix: rename.NewPrefix, Phase: rename.Phase, NumKeys: rename.numKeys}
+}
+
+func (pr *PrefixRenames) indexOf(oldPrefix []byte) int {
+	for i, rename := range pr.renames {
+		if bytes.Equal(rename.OldPrefix, oldPrefix) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (pr *PrefixRenames) copyRenames() []*prefixRename {
+	renames := make([]*prefixRename, len(pr.renames))
+	copy(renames, pr.renames)
+	return renames
+}
+
+// update saves the given renames, if backed by a store, before making
+// them visible so that a failed write leaves the renames unchanged.
+func (pr *PrefixRenames) update(renames []*prefixRename) error {
+	if pr.rs != nil {
+		data, err := json.Marshal(renames)
+		if err != nil {
+			return err
+		}
+		if err = pr.rs.SaveRecord(prefixRenamesRecord, data); err != nil {
+			return err
+		}
+	}
+	pr.renames = renames
+	return nil
+}
+
+func prefixesOverlap(a, b []byte) bool {
+	return bytes.HasPrefix(a, b) || bytes.HasPrefix(b, a)
+}
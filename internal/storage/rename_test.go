@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+)
+
+// memRecords is a RecordStore held in memory.
+type memRecords map[string][]byte
+
+func (mr memRecords) LoadRecord(key []byte) ([]byte, error) {
+	return mr[string(key)], nil
+}
+
+func (mr memRecords) SaveRecord(key, value []byte) error {
+	mr[string(key)] = value
+	return nil
+}
+
+func TestPrefixRenames(t *testing.T) {
+	records := make(memRecords)
+	pr, err := OpenPrefixRenames(records)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = pr.Begin([]byte("user:"), []byte("user:v2:")); err == nil {
+		t.Error("Expected an error for renaming a key prefix to an overlapping prefix")
+	}
+	if err = pr.Begin([]byte("user:"), []byte("member:")); err != nil {
+		t.Fatal(err)
+	}
+	if err = pr.Begin([]byte("user:"), []byte("member:")); err != nil {
+		t.Errorf("Expected beginning the same rename again to succeed. Error: %v", err)
+	}
+	if err = pr.Begin([]byte("member:vip:"), []byte("vip:")); err == nil {
+		t.Error("Expected an error for renaming a key prefix overlapping with a rename in progress")
+	}
+	if fallbackKey, ok := pr.FallbackKey([]byte("member:1")); !ok || string(fallbackKey) != "user:1" {
+		t.Errorf("Expected member:1 to fall back to user:1. Actual: %q", fallbackKey)
+	}
+	if _, ok := pr.FallbackKey([]byte("user:1")); ok {
+		t.Error("Did not expect keys of the old prefix to fall back")
+	}
+	if mirrorKey, ok := pr.MirrorKey([]byte("user:1")); !ok || string(mirrorKey) != "member:1" {
+		t.Errorf("Expected writes of user:1 to be mirrored onto member:1. Actual: %q", mirrorKey)
+	}
+
+	// Renames must survive reopening
+	if err = pr.SetPhase([]byte("user:"), serverpb.PrefixRenamePhase_COPIED); err != nil {
+		t.Fatal(err)
+	}
+	if pr, err = OpenPrefixRenames(records); err != nil {
+		t.Fatal(err)
+	}
+	if renames := pr.List(); len(renames) != 1 || string(renames[0].NewPrefix) != "member:" || renames[0].Phase != serverpb.PrefixRenamePhase_COPIED {
+		t.Errorf("Expected only the copied rename of user: to member:, but got %v", renames)
+	}
+
+	if err = pr.SetPhase([]byte("user:"), serverpb.PrefixRenamePhase_SWEEPING); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := pr.FallbackKey([]byte("member:1")); ok {
+		t.Error("Did not expect reads to fall back once the rename is completed")
+	}
+	if _, ok := pr.MirrorKey([]byte("user:1")); ok {
+		t.Error("Did not expect writes to be mirrored once the rename is completed")
+	}
+	if err = pr.End([]byte("user:")); err != nil {
+		t.Fatal(err)
+	}
+	if err = pr.End([]byte("user:")); err != ErrPrefixRenameNotFound {
+		t.Errorf("Expected an error for ending a rename that is not in progress. Actual: %v", err)
+	}
+}
+
+func TestPrefixRenamesRead(t *testing.T) {
+	pr, _ := OpenPrefixRenames(nil)
+	if err := pr.Begin([]byte("old:"), []byte("new:")); err != nil {
+		t.Fatal(err)
+	}
+	data := map[string]string{"old:1": "a", "old:2": "b", "new:2": "c", "other:1": "d"}
+	read := func(keys ...[]byte) ([]*serverpb.KVPair, error) {
+		var kvs []*serverpb.KVPair
+		for _, key := range keys {
+			if val, ok := data[string(key)]; ok {
+				kvs = append(kvs, &serverpb.KVPair{Key: key, Value: []byte(val)})
+			}
+		}
+		return kvs, nil
+	}
+
+	kvs, err := pr.Read(read, []byte("new:1"), []byte("new:2"), []byte("new:3"), []byte("other:1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	vals := make(map[string]string)
+	for _, kv := range kvs {
+		vals[string(kv.Key)] = string(kv.Value)
+	}
+	// new:1 falls back to old:1, while new:2 is read as is
+	if len(vals) != 3 || vals["new:1"] != "a" || vals["new:2"] != "c" || vals["other:1"] != "d" {
+		t.Errorf("Unexpected pairs read: %v", vals)
+	}
+}
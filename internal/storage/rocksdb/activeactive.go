@@ -0,0 +1,215 @@
+package rocksdb
+
+import (
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"github.com/flipkart-incubator/gorocksdb"
+)
+
+// Masters replicating actively to each other record the origin of every
+// put and delete in the same batch as the write itself, so that the
+// origins reach the peer along with the writes. Writes replicated from
+// the peer are applied as new batches of the store, which also carry
+// the origins of their writes, while the peer ignores the writes that
+// originated at itself. Compare and sets, merges and transactions are
+// rejected, since their outcomes depend on the values they overwrite,
+// which may well be superseded by the conflicting writes of the peer.
+//
+// Local writes are stamped concurrently, while the writes of the peer
+// are applied exclusively, so that no local write is stamped between
+// resolving and applying a conflicting write of the peer. Since the
+// clock observes the timestamps of the applied writes, local writes
+// made afterwards always supersede them.
+
+var (
+	errActiveActiveDisabled = errors.New("active-active replication is not enabled on current DKV instance")
+	errUnstampedWrite       = errors.New("compare and sets, merges and transactions are not supported under active-active replication")
+)
+
+// WithOriginID enables the active-active replication with a peer master,
+// stamping every put and delete with the given ID of this master. IDs
+// of the peers must differ.
+func WithOriginID(originID string) DBOption {
+	return func(opts *rocksDBOpts) {
+		opts.originID = originID
+	}
+}
+
+// stampOrigins begins stamping the origins of the writes, returning the
+// timestamp of the writes, unless active-active replication is disabled
+// in which case zero is returned. The returned function must be called
+// once the stamped batch is written.
+func (rdb *rocksDB) stampOrigins() (uint64, func()) {
+	if rdb.opts.originID == "" {
+		return 0, func() {}
+	}
+	rdb.originMu.RLock()
+	return rdb.clock.Now(), rdb.originMu.RUnlock
+}
+
+// stampOrigin records the origin of the write of the given key made at
+// the given timestamp into the given batch.
+func (rdb *rocksDB) stampOrigin(wb *gorocksdb.WriteBatch, key []byte, ts uint64) {
+	origin := &storage.RecordOrigin{OriginID: rdb.opts.originID, Timestamp: ts}
+	wb.PutCF(rdb.normalCF, storage.OriginKey(key), origin.Marshal())
+}
+
+// recordOrigin loads the origin of the latest write of the given key,
+// which is nil if the key was never written.
+func (rdb *rocksDB) recordOrigin(key []byte) (*storage.RecordOrigin, error) {
+	val, err := rdb.db.GetCF(rdb.opts.readOpts, rdb.normalCF, storage.OriginKey(key))
+	if err != nil {
+		return nil, err
+	}
+	defer val.Free()
+	if val.Size() == 0 {
+		return nil, nil
+	}
+	return storage.UnmarshalRecordOrigin(val.Data())
+}
+
+func (rdb *rocksDB) GetLatestPeerChangeNumber(peerID string) (uint64, error) {
+	val, err := rdb.db.GetCF(rdb.opts.readOpts, rdb.normalCF, storage.PeerChangeKey(peerID))
+	if err != nil {
+		return 0, err
+	}
+	defer val.Free()
+	if val.Size() < 8 {
+		return 0, nil
+	}
+	return binary.BigEndian.Uint64(val.Data()), nil
+}
+
+// ApplyPeerChanges applies every change of the peer as a single batch,
+// which also records the change number of that change, so that changes
+// are applied exactly once despite the failures.
+func (rdb *rocksDB) ApplyPeerChanges(peerID string, changes []*serverpb.ChangeRecord, resolver storage.ConflictResolver) (uint64, error) {
+	defer rdb.opts.statsCli.Timing("rocksdb.apply.peer.changes.latency.ms", time.Now())
+	if rdb.opts.originID == "" {
+		return 0, errActiveActiveDisabled
+	}
+	if resolver == nil {
+		resolver = storage.LastWriterWins
+	}
+
+	rdb.originMu.Lock()
+	defer rdb.originMu.Unlock()
+	appliedChngNum, err := rdb.GetLatestPeerChangeNumber(peerID)
+	if err != nil {
+		return 0, err
+	}
+	wo := rdb.writeOptions(serverpb.WALSync_DEFAULT_WAL_SYNC)
+	for _, chng := range changes {
+		lastChngNum := chng.ChangeNumber + uint64(chng.NumberOfTrxns) - 1
+		if lastChngNum <= appliedChngNum {
+			rdb.opts.statsCli.Incr("rocksdb.apply.peer.changes.skipped", 1)
+			continue
+		}
+		wb, err := rdb.resolvePeerChange(chng, resolver)
+		if err != nil {
+			rdb.opts.statsCli.Incr("rocksdb.apply.peer.changes.errors", 1)
+			return appliedChngNum, err
+		}
+		chngNum := make([]byte, 8)
+		binary.BigEndian.PutUint64(chngNum, lastChngNum)
+		wb.PutCF(rdb.normalCF, storage.PeerChangeKey(peerID), chngNum)
+		err = rdb.write(wo, wb)
+		wb.Destroy()
+		if err != nil {
+			rdb.opts.statsCli.Incr("rocksdb.apply.peer.changes.errors", 1)
+			return appliedChngNum, err
+		}
+		appliedChngNum = lastChngNum
+	}
+	return appliedChngNum, nil
+}
+
+// peerWrite holds the records of a change of the peer on a key, along
+// with the origin of that change.
+type peerWrite struct {
+	origin  *storage.RecordOrigin
+	records []gorocksdb.WriteBatchRecord
+}
+
+// resolvePeerChange builds the batch of the given change of the peer,
+// holding the writes of the keys whose local writes are superseded as
+// per the given resolver, along with their origins. Versions of the
+// peer are left out, since the versions of the keys are recorded anew
+// by this store.
+func (rdb *rocksDB) resolvePeerChange(chng *serverpb.ChangeRecord, resolver storage.ConflictResolver) (*gorocksdb.WriteBatch, error) {
+	if err := storage.VerifyChangeChecksum(chng); err != nil {
+		return nil, err
+	}
+	writes := make(map[string]*peerWrite)
+	var keys []string
+	writeOf := func(key []byte) *peerWrite {
+		pw, present := writes[string(key)]
+		if !present {
+			pw = &peerWrite{}
+			writes[string(key)] = pw
+			keys = append(keys, string(key))
+		}
+		return pw
+	}
+
+	wbIter := NewWriteBatchIterator(chng.SerialisedForm)
+	for wbIter.Next() {
+		wbr := wbIter.Record()
+		switch wbr.Type {
+		case gorocksdb.WriteBatchValueRecord, gorocksdb.WriteBatchCFValueRecord,
+			gorocksdb.WriteBatchDeletionRecord, gorocksdb.WriteBatchCFDeletionRecord:
+		default:
+			continue
+		}
+		if wbr.CF >= versionsCFID {
+			continue
+		}
+		if key, ok := storage.UserKeyOfOrigin(wbr.Key); ok {
+			origin, err := storage.UnmarshalRecordOrigin(wbr.Value)
+			if err != nil {
+				return nil, err
+			}
+			writeOf(key).origin = origin
+		} else if !storage.IsReservedKey(wbr.Key) {
+			pw := writeOf(wbr.Key)
+			pw.records = append(pw.records, *wbr)
+		}
+	}
+	if err := wbIter.Error(); err != nil {
+		return nil, err
+	}
+
+	wb := gorocksdb.NewWriteBatch()
+	cfs := []*gorocksdb.ColumnFamilyHandle{rdb.normalCF, rdb.ttlCF}
+	for _, key := range keys {
+		pw := writes[key]
+		// Writes without origins are not meant for the peers, while
+		// the writes originating here return from the peer
+		if pw.origin == nil || pw.origin.OriginID == rdb.opts.originID {
+			continue
+		}
+		rdb.clock.Observe(pw.origin.Timestamp)
+		local, err := rdb.recordOrigin([]byte(key))
+		if err != nil {
+			wb.Destroy()
+			return nil, err
+		}
+		if local != nil && !resolver.Resolve([]byte(key), local, pw.origin) {
+			rdb.opts.statsCli.Incr("rocksdb.apply.peer.changes.conflicts.discarded", 1)
+			continue
+		}
+		for _, wbr := range pw.records {
+			if wbr.Type == gorocksdb.WriteBatchValueRecord || wbr.Type == gorocksdb.WriteBatchCFValueRecord {
+				wb.PutCF(cfs[wbr.CF], wbr.Key, wbr.Value)
+			} else {
+				wb.DeleteCF(cfs[wbr.CF], wbr.Key)
+			}
+		}
+		wb.PutCF(rdb.normalCF, storage.OriginKey([]byte(key)), pw.origin.Marshal())
+	}
+	return wb, nil
+}
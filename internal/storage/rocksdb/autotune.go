@@ -0,0 +1,273 @@
+package rocksdb
+
+// #include <stdlib.h>
+// #include "rocksdb/c.h"
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+	"unsafe"
+
+	"github.com/flipkart-incubator/gorocksdb"
+	"go.uber.org/zap"
+)
+
+// AutoTuneBounds captures the bounds within which the auto-tuner adjusts
+// the RocksDB settings, based on the workload observed over every tuning
+// interval. Zero bounds leave the corresponding setting untuned.
+type AutoTuneBounds struct {
+	// MinWriteBufferSize and MaxWriteBufferSize bound the size (in bytes)
+	// of a single memtable. Larger memtables absorb write heavy workloads
+	// with fewer flushes, while smaller ones free up memory for reads.
+	MinWriteBufferSize, MaxWriteBufferSize uint64
+	// MinBloomFilterBitsPerKey and MaxBloomFilterBitsPerKey bound the bits
+	// per key of the bloom filters. More bits rule out more files for reads
+	// of absent keys, at the cost of memory.
+	MinBloomFilterBitsPerKey, MaxBloomFilterBitsPerKey int
+	// TuneCompression allows switching the compression of the levels
+	// between a lighter profile for write heavy workloads and a denser
+	// profile for read heavy workloads or large values.
+	TuneCompression bool
+}
+
+// Validate checks the given bounds for consistency.
+func (atb *AutoTuneBounds) Validate() error {
+	switch {
+	case atb.MaxWriteBufferSize > 0 && atb.MinWriteBufferSize < minWriteBufferSize:
+		return fmt.Errorf("min write buffer size: %d must be at least %d bytes", atb.MinWriteBufferSize, minWriteBufferSize)
+	case atb.MinWriteBufferSize > atb.MaxWriteBufferSize:
+		return fmt.Errorf("min write buffer size: %d must not exceed max write buffer size: %d", atb.MinWriteBufferSize, atb.MaxWriteBufferSize)
+	case atb.MaxBloomFilterBitsPerKey > 0 && atb.MinBloomFilterBitsPerKey < 1:
+		return fmt.Errorf("min bloom filter bits per key: %d must be at least 1", atb.MinBloomFilterBitsPerKey)
+	case atb.MinBloomFilterBitsPerKey > atb.MaxBloomFilterBitsPerKey:
+		return fmt.Errorf("min bloom filter bits per key: %d must not exceed max bloom filter bits per key: %d", atb.MinBloomFilterBitsPerKey, atb.MaxBloomFilterBitsPerKey)
+	case atb.MaxBloomFilterBitsPerKey > maxBloomFilterBitsPerKey:
+		return fmt.Errorf("max bloom filter bits per key: %d must not exceed %d", atb.MaxBloomFilterBitsPerKey, maxBloomFilterBitsPerKey)
+	}
+	return nil
+}
+
+// WithAutoTuning enables the auto-tuner, which adjusts the RocksDB
+// settings within the given bounds once every given interval.
+func WithAutoTuning(interval time.Duration, bounds *AutoTuneBounds) DBOption {
+	return func(opts *rocksDBOpts) {
+		if interval <= 0 {
+			panic(fmt.Errorf("auto-tuning interval: %v must be positive", interval))
+		}
+		if err := bounds.Validate(); err != nil {
+			panic(fmt.Errorf("invalid auto-tuning bounds, error: %v", err))
+		}
+		opts.autoTuneInterval, opts.autoTuneBounds = interval, bounds
+	}
+}
+
+const (
+	// Minimum number of operations over an interval for the workload
+	// to be considered representative
+	minOpsForAutoTuning = 1000
+	writeHeavyRatio     = 0.7
+	readHeavyRatio      = 0.3
+	highReadMissRate    = 0.2
+	lowReadMissRate     = 0.02
+	largeValueSize      = 4 << 10
+
+	// Compression profiles of the levels, the last of which applies
+	// to all the remaining levels
+	lightCompression = "kNoCompression:kNoCompression:kLZ4Compression"
+	denseCompression = "kLZ4Compression:kLZ4Compression:kZSTD"
+)
+
+// workloadStats counts the operations served by the store. Shall be
+// manipulated using atomics.
+type workloadStats struct {
+	reads, readMisses, writes, writtenBytes uint64
+}
+
+func (ws *workloadStats) recordReads(numKeys, numFound int) {
+	atomic.AddUint64(&ws.reads, uint64(numKeys))
+	atomic.AddUint64(&ws.readMisses, uint64(numKeys-numFound))
+}
+
+func (ws *workloadStats) recordWrite(valueSize int) {
+	atomic.AddUint64(&ws.writes, 1)
+	atomic.AddUint64(&ws.writtenBytes, uint64(valueSize))
+}
+
+func (ws *workloadStats) load() workloadStats {
+	return workloadStats{
+		reads:        atomic.LoadUint64(&ws.reads),
+		readMisses:   atomic.LoadUint64(&ws.readMisses),
+		writes:       atomic.LoadUint64(&ws.writes),
+		writtenBytes: atomic.LoadUint64(&ws.writtenBytes),
+	}
+}
+
+func (ws workloadStats) since(prev workloadStats) workloadStats {
+	return workloadStats{
+		reads:        ws.reads - prev.reads,
+		readMisses:   ws.readMisses - prev.readMisses,
+		writes:       ws.writes - prev.writes,
+		writtenBytes: ws.writtenBytes - prev.writtenBytes,
+	}
+}
+
+// optionChange is a change of a RocksDB option made by the auto-tuner.
+type optionChange struct {
+	name, value, reason string
+}
+
+// autoTuner periodically adjusts the RocksDB settings to the workload
+// observed over the last interval. Only the settings that RocksDB can
+// change without reopening the store are adjusted.
+type autoTuner struct {
+	rdb      *rocksDB
+	interval time.Duration
+	bounds   *AutoTuneBounds
+	stop     chan struct{}
+	done     chan struct{}
+
+	// Values of the options last applied, which are empty until
+	// the auto-tuner first changes them
+	applied map[string]string
+}
+
+func (rdb *rocksDB) startAutoTuner() {
+	if rdb.opts.autoTuneBounds == nil {
+		return
+	}
+	at := &autoTuner{
+		rdb:      rdb,
+		interval: rdb.opts.autoTuneInterval,
+		bounds:   rdb.opts.autoTuneBounds,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+		applied:  make(map[string]string),
+	}
+	rdb.autoTuner = at
+	go at.run()
+}
+
+// stopAutoTuner stops the auto-tuner, if any, and waits for it to
+// return, so that the store can be closed safely.
+func (rdb *rocksDB) stopAutoTuner() {
+	if at := rdb.autoTuner; at != nil {
+		rdb.autoTuner = nil
+		close(at.stop)
+		<-at.done
+	}
+}
+
+func (at *autoTuner) run() {
+	defer close(at.done)
+	ticker := time.NewTicker(at.interval)
+	defer ticker.Stop()
+	prev := at.rdb.workload.load()
+	for {
+		select {
+		case <-ticker.C:
+			curr := at.rdb.workload.load()
+			for _, chng := range at.decide(curr.since(prev)) {
+				at.apply(chng)
+			}
+			prev = curr
+		case <-at.stop:
+			return
+		}
+	}
+}
+
+// decide determines the option changes suited to the given workload,
+// leaving out the options already holding the suited values.
+func (at *autoTuner) decide(wl workloadStats) []optionChange {
+	numOps := wl.reads + wl.writes
+	if numOps < minOpsForAutoTuning {
+		return nil
+	}
+	writeRatio := float64(wl.writes) / float64(numOps)
+	writeHeavy, readHeavy := writeRatio >= writeHeavyRatio, writeRatio <= readHeavyRatio
+	var chngs []optionChange
+	propose := func(name, value, reason string) {
+		if at.applied[name] != value {
+			chngs = append(chngs, optionChange{name, value, reason})
+		}
+	}
+
+	if bnds := at.bounds; bnds.MaxWriteBufferSize > 0 {
+		switch {
+		case writeHeavy:
+			propose("write_buffer_size", fmt.Sprint(bnds.MaxWriteBufferSize), fmt.Sprintf("%.0f%% of the operations are writes", 100*writeRatio))
+		case readHeavy:
+			propose("write_buffer_size", fmt.Sprint(bnds.MinWriteBufferSize), fmt.Sprintf("%.0f%% of the operations are reads", 100*(1-writeRatio)))
+		}
+	}
+	if bnds := at.bounds; bnds.MaxBloomFilterBitsPerKey > 0 && wl.reads >= minOpsForAutoTuning {
+		missRate := float64(wl.readMisses) / float64(wl.reads)
+		bloomFilter := "{filter_policy=bloomfilter:%d:false}"
+		switch {
+		case missRate >= highReadMissRate:
+			propose("block_based_table_factory", fmt.Sprintf(bloomFilter, bnds.MaxBloomFilterBitsPerKey), fmt.Sprintf("%.0f%% of the reads are for absent keys", 100*missRate))
+		case missRate <= lowReadMissRate:
+			propose("block_based_table_factory", fmt.Sprintf(bloomFilter, bnds.MinBloomFilterBitsPerKey), fmt.Sprintf("only %.1f%% of the reads are for absent keys", 100*missRate))
+		}
+	}
+	if at.bounds.TuneCompression {
+		var avgValueSize uint64
+		if wl.writes > 0 {
+			avgValueSize = wl.writtenBytes / wl.writes
+		}
+		switch {
+		case writeHeavy && avgValueSize < largeValueSize:
+			propose("compression_per_level", lightCompression, fmt.Sprintf("%.0f%% of the operations are writes of small values", 100*writeRatio))
+		case readHeavy || avgValueSize >= largeValueSize:
+			propose("compression_per_level", denseCompression, fmt.Sprintf("%.0f%% of the operations are reads with values of %d bytes on average", 100*(1-writeRatio), avgValueSize))
+		}
+	}
+	return chngs
+}
+
+// apply changes the given option on the column families holding the
+// keys, logging the change.
+func (at *autoTuner) apply(chng optionChange) {
+	rdb := at.rdb
+	for _, cf := range []*gorocksdb.ColumnFamilyHandle{rdb.normalCF, rdb.ttlCF} {
+		if err := rdb.setCFOption(cf, chng.name, chng.value); err != nil {
+			rdb.opts.lgr.Error("Unable to auto-tune RocksDB option", zap.String("Option", chng.name),
+				zap.String("Value", chng.value), zap.Error(err))
+			rdb.opts.statsCli.Incr("rocksdb.autotune.errors", 1)
+			return
+		}
+	}
+	rdb.opts.lgr.Info("Auto-tuned RocksDB option", zap.String("Option", chng.name),
+		zap.String("From", at.applied[chng.name]), zap.String("To", chng.value), zap.String("Reason", chng.reason))
+	rdb.opts.statsCli.Incr("rocksdb.autotune.changes", 1)
+	at.applied[chng.name] = chng.value
+}
+
+// setCFOption changes the given mutable option of the given column
+// family, without reopening the store.
+func (rdb *rocksDB) setCFOption(cf *gorocksdb.ColumnFamilyHandle, name, value string) error {
+	return rdb.setCFOptions(cf, []string{name}, []string{value})
+}
+
+// setCFOptions changes the given mutable options of the given column
+// family together, without reopening the store.
+func (rdb *rocksDB) setCFOptions(cf *gorocksdb.ColumnFamilyHandle, names, values []string) error {
+	cNames, cValues := make([]*C.char, len(names)), make([]*C.char, len(values))
+	for i := range names {
+		cNames[i], cValues[i] = C.CString(names[i]), C.CString(values[i])
+		defer C.free(unsafe.Pointer(cNames[i]))
+		defer C.free(unsafe.Pointer(cValues[i]))
+	}
+	cDB := (*C.rocksdb_t)(rdb.db.UnsafeGetDB())
+	cCF := (*C.rocksdb_column_family_handle_t)(cf.UnsafeGetCFHandler())
+	var cErr *C.char
+	C.rocksdb_set_options_cf(cDB, cCF, C.int(len(names)), &cNames[0], &cValues[0], &cErr)
+	if cErr != nil {
+		defer C.rocksdb_free(unsafe.Pointer(cErr))
+		return errors.New(C.GoString(cErr))
+	}
+	return nil
+}
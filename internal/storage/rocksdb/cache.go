@@ -0,0 +1,58 @@
+package rocksdb
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/flipkart-incubator/gorocksdb"
+)
+
+// CacheOptions captures the sizing and the behaviour of the caches used
+// by the store. The block cache is shared by all the column families of
+// the store, so that the memory set aside for caching is bounded per store.
+type CacheOptions struct {
+	// BlockCacheSize is the size (in bytes) of the cache holding the
+	// uncompressed data blocks. A value of 0 disables block caching.
+	BlockCacheSize uint64
+	// CacheIndexAndFilterBlocks holds the index and filter blocks in the
+	// block cache, bounding their memory by its size, instead of holding
+	// them outside of it for every open SST file.
+	CacheIndexAndFilterBlocks bool
+	// PinL0FilterAndIndexBlocks keeps the index and filter blocks of the
+	// level 0 files in the block cache, so that they are never evicted.
+	PinL0FilterAndIndexBlocks bool
+}
+
+// Validate checks the given cache options for consistency.
+func (co *CacheOptions) Validate() error {
+	switch {
+	case co.PinL0FilterAndIndexBlocks && !co.CacheIndexAndFilterBlocks:
+		return errors.New("pinning of level 0 filter and index blocks requires caching of index and filter blocks")
+	case co.CacheIndexAndFilterBlocks && co.BlockCacheSize == 0:
+		return errors.New("caching of index and filter blocks requires a block cache")
+	}
+	return nil
+}
+
+// WithCacheOptions configures the caches of the store using the given
+// options. Since it changes the block based table options, it must be
+// given before WithRocksDBConfig and WithTuningOptions, if those are used.
+func WithCacheOptions(co *CacheOptions) DBOption {
+	return func(opts *rocksDBOpts) {
+		if co == nil {
+			return
+		}
+		if err := co.Validate(); err != nil {
+			panic(fmt.Errorf("invalid RocksDB cache options, error: %v", err))
+		}
+		if co.BlockCacheSize > 0 {
+			opts.blockCache = gorocksdb.NewLRUCache(co.BlockCacheSize)
+			opts.blockTableOpts.SetBlockCache(opts.blockCache)
+		} else {
+			opts.blockTableOpts.SetNoBlockCache(true)
+		}
+		opts.blockTableOpts.SetCacheIndexAndFilterBlocks(co.CacheIndexAndFilterBlocks)
+		opts.blockTableOpts.SetPinL0FilterAndIndexBlocksInCache(co.PinL0FilterAndIndexBlocks)
+		opts.rocksDBOpts.SetBlockBasedTableFactory(opts.blockTableOpts)
+	}
+}
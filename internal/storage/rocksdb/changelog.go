@@ -0,0 +1,173 @@
+package rocksdb
+
+import (
+	"github.com/flipkart-incubator/dkv/internal/storage/changelog"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"github.com/flipkart-incubator/gorocksdb"
+	"go.uber.org/zap"
+)
+
+// WithChangeLog records every commit into a change log made up of
+// segment files in the given directory, from which the changes are
+// propagated to the replicas instead of the RocksDB WAL. This makes the
+// change propagation immune to WAL rollovers and allows for retaining
+// a longer history of changes. Segments are rolled over once they exceed
+// the given size in bytes and at most the given number of segments are
+// retained, where zero retains all the segments.
+func WithChangeLog(dir string, segmentSize int64, maxSegments int) DBOption {
+	return func(opts *rocksDBOpts) {
+		opts.changeLogDir = dir
+		opts.changeLogSegmentSize = segmentSize
+		opts.changeLogMaxSegments = maxSegments
+	}
+}
+
+// openChangeLog opens the configured change log and reconciles it with
+// the latest change number of the store. Changes beyond the store are
+// discarded, as is the case after a restore, while the changes missing
+// from the log are backfilled from the WAL whenever possible.
+func (rdb *rocksDB) openChangeLog() error {
+	cl, err := changelog.Open(rdb.opts.changeLogDir, rdb.opts.changeLogSegmentSize, rdb.opts.changeLogMaxSegments)
+	if err != nil {
+		return err
+	}
+	latestChngNum := rdb.db.GetLatestSequenceNumber()
+	lastChngNum, nonEmpty := cl.LastChangeNumber()
+	switch {
+	case nonEmpty && lastChngNum > latestChngNum:
+		err = cl.TruncateAfter(latestChngNum)
+	case nonEmpty && lastChngNum < latestChngNum:
+		if err = backfillChangeLog(rdb.db, cl, lastChngNum+1); err != nil {
+			rdb.opts.lgr.Warn("Unable to backfill change log from WAL, discarding the change log",
+				zap.Uint64("FromChangeNumber", lastChngNum+1), zap.Error(err))
+			err = cl.Reset()
+		}
+	}
+	if err != nil {
+		cl.Close()
+		return err
+	}
+	rdb.changeLog = cl
+	return nil
+}
+
+func backfillChangeLog(db *gorocksdb.DB, cl *changelog.Log, fromChangeNumber uint64) error {
+	chngIter, err := db.GetUpdatesSince(fromChangeNumber)
+	if err != nil {
+		return err
+	}
+	defer chngIter.Destroy()
+	for ; chngIter.Valid(); chngIter.Next() {
+		wb, chngNum := chngIter.GetBatch()
+		data := wb.Data()
+		err = cl.Append(&changelog.Entry{ChangeNumber: chngNum, NumberOfTrxns: uint32(wb.Count()), Data: byteArrayCopy(data, len(data))})
+		wb.Destroy()
+		if err != nil {
+			return err
+		}
+	}
+	return chngIter.Err()
+}
+
+// write commits the given write batch along with the versions of the
+// keys changed by it, if versions are retained. Writes that fsync the
+// WAL are coalesced with the concurrent ones, if group commit is enabled.
+func (rdb *rocksDB) write(wo *gorocksdb.WriteOptions, wb *gorocksdb.WriteBatch) error {
+	if wo == rdb.opts.syncWriteOpts {
+		if gc := rdb.loadGroupCommitter(); gc != nil {
+			return gc.commit(wb)
+		}
+	}
+	return rdb.commit(wo, wb, true)
+}
+
+// commit commits the given write batch and records it in the change
+// log, if configured. Commits are serialized in the presence of the
+// change log, so that the change number of every commit is known, as
+// is the case when versions of the changed keys are to be recorded.
+// Changes replicated from elsewhere must not record versions, since
+// they carry the versions recorded at their source.
+func (rdb *rocksDB) commit(wo *gorocksdb.WriteOptions, wb *gorocksdb.WriteBatch, versioned bool) error {
+	rdb.rotateMu.RLock()
+	defer rdb.rotateMu.RUnlock()
+	versioned = versioned && rdb.needsVersioning()
+	if rdb.changeLog == nil && !versioned {
+		if err := rdb.writeTracked(wo, wb, false); err != nil {
+			return err
+		}
+		rdb.commitSignal.Signal()
+		return nil
+	}
+	rdb.commitMu.Lock()
+	defer rdb.commitMu.Unlock()
+	return rdb.commitLocked(wo, wb, versioned)
+}
+
+// commitLocked is same as commit, while commits are already serialized.
+func (rdb *rocksDB) commitLocked(wo *gorocksdb.WriteOptions, wb *gorocksdb.WriteBatch, versioned bool) error {
+	if versioned {
+		if err := rdb.recordVersions(wb); err != nil {
+			return err
+		}
+	}
+	if err := rdb.writeTracked(wo, wb, true); err != nil {
+		return err
+	}
+	if versioned {
+		rdb.versionsCommitted()
+	}
+	switch {
+	case rdb.changeLog == nil:
+	case rdb.isPropagated(wo):
+		rdb.appendChange(wb)
+	default:
+		rdb.appendFillerChange(wb)
+	}
+	rdb.commitSignal.Signal()
+	return nil
+}
+
+// ChangesCommitted returns a channel that is closed upon the next commit.
+func (rdb *rocksDB) ChangesCommitted() <-chan struct{} {
+	return rdb.commitSignal.Wait()
+}
+
+// appendChange records the given write batch, which must have been the
+// latest commit, in the change log. Since the commit cannot be undone,
+// failures are only logged after which the change log is discarded so
+// that the changes are loaded from the WAL instead.
+func (rdb *rocksDB) appendChange(wb *gorocksdb.WriteBatch) {
+	numTrxns, data := wb.Count(), wb.Data()
+	chngNum := rdb.db.GetLatestSequenceNumber() - uint64(numTrxns) + 1
+	entry := &changelog.Entry{ChangeNumber: chngNum, NumberOfTrxns: uint32(numTrxns), Data: byteArrayCopy(data, len(data))}
+	if err := rdb.changeLog.Append(entry); err != nil {
+		rdb.opts.statsCli.Incr("rocksdb.changelog.append.errors", 1)
+		rdb.opts.lgr.Error("Unable to append to change log, discarding the change log",
+			zap.Uint64("ChangeNumber", chngNum), zap.Error(err))
+		if err = rdb.changeLog.Reset(); err != nil {
+			rdb.opts.lgr.Error("Unable to discard change log", zap.Error(err))
+		}
+	}
+}
+
+// loadChangesFromLog loads the changes from the change log. Returns
+// false if the change log does not hold the given change number.
+func (rdb *rocksDB) loadChangesFromLog(fromChangeNumber uint64, maxChanges int) ([]*serverpb.ChangeRecord, bool, error) {
+	if rdb.changeLog == nil {
+		return nil, false, nil
+	}
+	if firstChngNum, nonEmpty := rdb.changeLog.FirstChangeNumber(); !nonEmpty || fromChangeNumber < firstChngNum {
+		return nil, false, nil
+	}
+	entries, err := rdb.changeLog.Read(fromChangeNumber, maxChanges)
+	if err != nil {
+		return nil, true, err
+	}
+	chngs := make([]*serverpb.ChangeRecord, len(entries))
+	for i, entry := range entries {
+		wb := gorocksdb.WriteBatchFrom(entry.Data)
+		chngs[i] = rdb.toChangeRecord(wb, entry.ChangeNumber)
+		wb.Destroy()
+	}
+	return chngs, true, nil
+}
@@ -0,0 +1,204 @@
+package rocksdb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/internal/hlc"
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/gorocksdb"
+	"go.uber.org/zap"
+)
+
+// CompactionPolicy determines the entries that are dropped while
+// compacting, thereby reclaiming the space held by them.
+type CompactionPolicy string
+
+const (
+	// CompactionRetainAll never drops any entry while compacting,
+	// leaving the expired keys on disk until they are overwritten.
+	CompactionRetainAll CompactionPolicy = "none"
+	// CompactionDropExpired drops the keys whose TTL has expired.
+	CompactionDropExpired CompactionPolicy = "expired"
+	// CompactionDropExpiredAndEmpty additionally drops the keys whose
+	// values are empty, since such keys are read as absent anyway.
+	CompactionDropExpiredAndEmpty CompactionPolicy = "expired-and-empty"
+)
+
+// ParseCompactionPolicy converts the given string into a CompactionPolicy.
+func ParseCompactionPolicy(policy string) (CompactionPolicy, error) {
+	switch compactionPolicy := CompactionPolicy(strings.ToLower(strings.TrimSpace(policy))); compactionPolicy {
+	case CompactionRetainAll, CompactionDropExpired, CompactionDropExpiredAndEmpty:
+		return compactionPolicy, nil
+	default:
+		return "", fmt.Errorf("unknown compaction policy: %s, must be one of none|expired|expired-and-empty", policy)
+	}
+}
+
+// WithCompactionPolicy configures the entries dropped while compacting.
+// Keys under legal hold are never dropped. A positive periodic interval
+// compacts every SST file that has not been compacted within the interval,
+// so that the space held by the expired and deleted keys of rarely written
+// key ranges is reclaimed as well. Zero disables periodic compactions.
+func WithCompactionPolicy(policy CompactionPolicy, periodicInterval time.Duration) DBOption {
+	return func(opts *rocksDBOpts) {
+		if _, err := ParseCompactionPolicy(string(policy)); err != nil {
+			panic(err)
+		}
+		if periodicInterval < 0 {
+			panic(fmt.Errorf("periodic compaction interval: %v must not be negative", periodicInterval))
+		}
+		opts.compactionPolicy = policy
+		if secs := int64(periodicInterval / time.Second); secs > 0 {
+			rdbOpts, err := gorocksdb.GetOptionsFromString(opts.rocksDBOpts, fmt.Sprintf("periodic_compaction_seconds=%d", secs))
+			if err != nil {
+				panic(fmt.Errorf("unable to set periodic compaction interval: %v, error: %v", periodicInterval, err))
+			}
+			opts.rocksDBOpts = rdbOpts
+		}
+	}
+}
+
+// A CompactionFilter chooses the keys to be dropped while compacting, so
+// that the stale data of an application is purged without sweeping through
+// it with deletes. Reserved keys and the keys under legal hold are never
+// dropped. Since the keys dropped while compacting are not replicated, the
+// same filters must be configured on all the nodes.
+type CompactionFilter interface {
+	// Name identifies the filter.
+	Name() string
+	// Drop checks if the given key is to be dropped. The given value is
+	// as stored, which is encrypted if encryption at rest is enabled. It
+	// is invoked concurrently from the compaction threads of RocksDB.
+	Drop(key, value []byte) bool
+}
+
+// WithCompactionFilter drops the keys chosen by the given filter while
+// compacting, in addition to the keys dropped as per the compaction policy.
+func WithCompactionFilter(filter CompactionFilter) DBOption {
+	return func(opts *rocksDBOpts) {
+		if filter == nil {
+			panic(errors.New("compaction filter must be provided"))
+		}
+		opts.compactionFilters = append(opts.compactionFilters, filter)
+	}
+}
+
+type emptyValuesFilter struct{}
+
+// DropEmptyValues creates a compaction filter that drops the keys whose
+// values are empty, since such keys are read as absent anyway.
+func DropEmptyValues() CompactionFilter {
+	return emptyValuesFilter{}
+}
+
+func (emptyValuesFilter) Name() string {
+	return "drop-empty-values"
+}
+
+func (emptyValuesFilter) Drop(_, value []byte) bool {
+	return len(value) == 0
+}
+
+type prefixExpiryFilter struct {
+	prefix []byte
+	after  time.Time
+}
+
+// DropPrefixAfter creates a compaction filter that drops the keys having
+// the given prefix once the given time has passed. Such keys remain
+// readable until they are compacted.
+func DropPrefixAfter(prefix []byte, after time.Time) CompactionFilter {
+	return &prefixExpiryFilter{append([]byte(nil), prefix...), after}
+}
+
+// ParseDropPrefixAfter creates the compaction filter of DropPrefixAfter
+// from the given spec in <prefix>=<YYYY-MM-DD> format, where the keys
+// are dropped from the beginning of the given date in UTC.
+func ParseDropPrefixAfter(spec string) (CompactionFilter, error) {
+	parts := strings.SplitN(spec, "=", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return nil, fmt.Errorf("given compaction filter: %s is invalid, must be in <prefix>=<YYYY-MM-DD> format", spec)
+	}
+	after, err := time.Parse("2006-01-02", parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("given compaction filter: %s has an invalid date, error: %v", spec, err)
+	}
+	return DropPrefixAfter([]byte(parts[0]), after), nil
+}
+
+func (pf *prefixExpiryFilter) Name() string {
+	return fmt.Sprintf("drop-prefix-after:%s", pf.after.Format(time.RFC3339))
+}
+
+func (pf *prefixExpiryFilter) Drop(key, _ []byte) bool {
+	return bytes.HasPrefix(key, pf.prefix) && time.Now().After(pf.after)
+}
+
+// dropsAny checks if any of the given filters drops the given key.
+func dropsAny(filters []CompactionFilter, key, value []byte) bool {
+	for _, filter := range filters {
+		if filter.Drop(key, value) {
+			return true
+		}
+	}
+	return false
+}
+
+type ttlCompactionFilter struct {
+	lgr     *zap.Logger
+	holds   *storage.LegalHolds
+	policy  CompactionPolicy
+	filters []CompactionFilter
+}
+
+// Name returns the CompactionFilter name
+func (m *ttlCompactionFilter) Name() string {
+	return "dkv.ttlFilter"
+}
+
+// Filter applies the logic for the Compaction process.
+// this returns remove as true if the TTL of the key has expired,
+// or its value is empty as per the policy, or any of the configured
+// filters drops it, and the key is not under legal hold.
+func (m *ttlCompactionFilter) Filter(level int, key, val []byte) (remove bool, newVal []byte) {
+	if m.policy == CompactionRetainAll && len(m.filters) == 0 {
+		return false, nil
+	}
+	ttlRow, err := parseTTLMsgPackData(val)
+	if err != nil {
+		m.lgr.Warn("ttlCompactionFilter::Filter Failed to parse msgpack data", zap.String("Key", string(key)))
+		return false, nil
+	}
+	droppable := (m.policy != CompactionRetainAll && hlc.InThePast(ttlRow.ExpiryTS)) ||
+		(m.policy == CompactionDropExpiredAndEmpty && len(ttlRow.Data) == 0) ||
+		dropsAny(m.filters, key, ttlRow.Data)
+	if droppable && !m.holds.UnderLegalHold(key) {
+		return true, val
+	}
+	return false, nil
+}
+
+// keyspaceCompactionFilter drops the keys of the default column
+// family chosen by any of the configured filters.
+type keyspaceCompactionFilter struct {
+	holds   *storage.LegalHolds
+	filters []CompactionFilter
+}
+
+// Name returns the CompactionFilter name
+func (kf *keyspaceCompactionFilter) Name() string {
+	return "dkv.keyspaceFilter"
+}
+
+// Filter returns remove as true if any of the filters drops the
+// key and the key is neither reserved nor under legal hold.
+func (kf *keyspaceCompactionFilter) Filter(level int, key, val []byte) (remove bool, newVal []byte) {
+	if !storage.IsReservedKey(key) && dropsAny(kf.filters, key, val) && !kf.holds.UnderLegalHold(key) {
+		return true, val
+	}
+	return false, nil
+}
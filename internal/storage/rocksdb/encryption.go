@@ -0,0 +1,313 @@
+package rocksdb
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"github.com/flipkart-incubator/gorocksdb"
+	"github.com/vmihailenco/msgpack/v5"
+	"go.uber.org/zap"
+)
+
+var errEncryptionNotEnabled = errors.New("encryption at rest is not enabled")
+
+const (
+	// Number of values re-encrypted together, while holding off the
+	// other writes
+	rotationBatchSize = 1000
+	// Maximum number of scans over the keyspace during a rotation. Scans
+	// are repeated for the values written using the previous master key
+	// concurrently with an earlier scan.
+	maxRotationScans = 3
+)
+
+// WithEncryptionAtRest encrypts all the stored values using the master
+// keys loaded from the given key file. Since the values are encrypted
+// before they are written, the encryption extends to the WAL, the SST
+// files, the backups and the changes propagated to the replicas, all of
+// which must hence have access to the same master keys. Values stored
+// before enabling the encryption remain readable and are encrypted by
+// the next rotation.
+func WithEncryptionAtRest(keyFile string) DBOption {
+	return func(opts *rocksDBOpts) {
+		keyring, err := storage.OpenKeyring(keyFile)
+		if err != nil {
+			panic(fmt.Errorf("unable to load master keys from %s, error: %v", keyFile, err))
+		}
+		opts.keyring = keyring
+	}
+}
+
+// sealValue encrypts the given value of the given key using the active
+// master key, or the active data key of the tenant owning that key, if
+// encryption at rest is enabled. Empty values are left as is, since they
+// are considered the same as absent values.
+func (rdb *rocksDB) sealValue(key, value []byte) ([]byte, error) {
+	if rdb.opts.keyring == nil || len(value) == 0 {
+		return value, nil
+	}
+	if tenant, present := rdb.opts.tenants.Of(key); present {
+		return rdb.sealTenantValue(tenant, value)
+	}
+	return rdb.opts.keyring.Seal(value)
+}
+
+// unsealValue decrypts the given value of the given key previously
+// encrypted using sealValue.
+func (rdb *rocksDB) unsealValue(key, value []byte) ([]byte, error) {
+	if tenant, present := rdb.opts.tenants.Of(key); present {
+		return rdb.openTenantValue(tenant, value)
+	}
+	return rdb.opts.keyring.Open(value)
+}
+
+// openValue decrypts the given value of the given key, if encryption
+// at rest is enabled. Returns false once the tenant owning the key is
+// erased, in which case the value is considered absent. Any other failure
+// to decrypt the value, such as its master key being unavailable, is
+// returned as an error.
+func (rdb *rocksDB) openValue(key, value []byte) ([]byte, bool, error) {
+	if rdb.opts.keyring == nil {
+		return value, true, nil
+	}
+	decValue, err := rdb.unsealValue(key, value)
+	if err == errTenantErased {
+		return nil, false, nil
+	}
+	if err != nil {
+		rdb.opts.lgr.Warn("RocksDB::openValue Failed to decrypt value",
+			zap.String("Key", string(key)), zap.Error(err))
+		rdb.opts.statsCli.Incr("rocksdb.decrypt.errors", 1)
+		return nil, false, err
+	}
+	return decValue, true, nil
+}
+
+// mergeOperator computes the merge operator to be registered with
+// RocksDB, which decrypts the existing value and the operands before
+// merging them, when encryption at rest is enabled.
+func (rdbOpts *rocksDBOpts) mergeOperator() gorocksdb.MergeOperator {
+	if rdbOpts.keyring == nil {
+		return rdbOpts.mergeOps
+	}
+	return sealedMergeOperator{rdbOpts.mergeOps, rdbOpts.keyring}
+}
+
+type sealedMergeOperator struct {
+	prefixMergeOperators
+	keyring *storage.Keyring
+}
+
+func (smo sealedMergeOperator) FullMerge(key, existingValue []byte, operands [][]byte) ([]byte, bool) {
+	var err error
+	if existingValue != nil {
+		if existingValue, err = smo.keyring.Open(existingValue); err != nil {
+			return nil, false
+		}
+	}
+	decOperands := make([][]byte, len(operands))
+	for i, operand := range operands {
+		if decOperands[i], err = smo.keyring.Open(operand); err != nil {
+			return nil, false
+		}
+	}
+	merged, ok := smo.prefixMergeOperators.FullMerge(key, existingValue, decOperands)
+	if !ok || len(merged) == 0 {
+		return merged, ok
+	}
+	if merged, err = smo.keyring.Seal(merged); err != nil {
+		return nil, false
+	}
+	return merged, true
+}
+
+// RotateEncryptionKey reloads the master keys and re-encrypts all the
+// values using the active master key, including the values stored before
+// enabling the encryption. Values are re-encrypted in batches, holding
+// off the other writes only while a batch is written. Versions of keys
+// retained for reads as of a change number are not re-encrypted, hence
+// an older master key must be retained till such versions age out, as
+// is the case for the backups taken before the rotation. Values of the
+// tenants encrypted using their data keys are left as is, while the data
+// keys themselves are re-encrypted.
+func (rdb *rocksDB) RotateEncryptionKey() (*storage.KeyRotation, error) {
+	keyring := rdb.opts.keyring
+	if keyring == nil {
+		return nil, errEncryptionNotEnabled
+	}
+	// Prevent any backups or restores while the values are re-encrypted
+	if err := rdb.beginGlobalMutation(); err != nil {
+		return nil, err
+	}
+	defer rdb.endGlobalMutation()
+
+	if err := keyring.Reload(); err != nil {
+		rdb.opts.statsCli.Incr("rocksdb.rotate.key.errors", 1)
+		return nil, err
+	}
+	// Data keys of the tenants are decrypted again using the reloaded
+	// master keys
+	rdb.tenantKeys.invalidate()
+	rotation := &storage.KeyRotation{ActiveKeyID: keyring.ActiveKeyID()}
+	rdb.opts.lgr.Info("Beginning rotation of master key", zap.String("ActiveKeyID", rotation.ActiveKeyID))
+	for scan := 0; scan < maxRotationScans; scan++ {
+		numReencrypted := rotation.NumReencrypted
+		for _, cf := range []*gorocksdb.ColumnFamilyHandle{rdb.normalCF, rdb.ttlCF} {
+			if err := rdb.rotateCF(cf, nil, rotation, scan == 0); err != nil {
+				rdb.opts.statsCli.Incr("rocksdb.rotate.key.errors", 1)
+				return nil, err
+			}
+		}
+		if scan > 0 && rotation.NumReencrypted == numReencrypted {
+			break
+		}
+	}
+	// Compaction discards the values superseded by the re-encrypted ones
+	// and merges the pending merge operands using the active key
+	if err := rdb.CompactRange(nil); err != nil {
+		return nil, err
+	}
+	rdb.opts.lgr.Info("Completed rotation of master key", zap.String("ActiveKeyID", rotation.ActiveKeyID),
+		zap.Uint64("NumScanned", rotation.NumScanned), zap.Uint64("NumReencrypted", rotation.NumReencrypted))
+	return rotation, nil
+}
+
+// rotateCF re-encrypts the values of the given column family that are
+// not encrypted using the active data key of the given tenant, or the
+// active master key when no tenant is given.
+func (rdb *rocksDB) rotateCF(cf *gorocksdb.ColumnFamilyHandle, tenant []byte, rotation *storage.KeyRotation, countScanned bool) error {
+	it := rdb.db.NewIteratorCF(rdb.opts.readOpts, cf)
+	defer it.Close()
+
+	var keys [][]byte
+	for it.Seek(tenant); it.ValidForPrefix(tenant); it.Next() {
+		if countScanned {
+			rotation.NumScanned++
+		}
+		key, value := toByteArray(it.Key()), toByteArray(it.Value())
+		if rdb.needsRotation(cf, tenant, value) {
+			keys = append(keys, key)
+		}
+		if len(keys) == rotationBatchSize {
+			if err := rdb.reencrypt(cf, tenant, keys, rotation); err != nil {
+				return err
+			}
+			keys = keys[:0]
+		}
+	}
+	if err := it.Err(); err != nil {
+		return err
+	}
+	if len(keys) > 0 {
+		return rdb.reencrypt(cf, tenant, keys, rotation)
+	}
+	return nil
+}
+
+func (rdb *rocksDB) needsRotation(cf *gorocksdb.ColumnFamilyHandle, tenant, value []byte) bool {
+	if cf == rdb.ttlCF {
+		ttlRow, err := parseTTLMsgPackData(value)
+		if err != nil {
+			return false
+		}
+		value = ttlRow.Data
+	}
+	if len(value) == 0 {
+		return false
+	}
+	if tenant == nil {
+		// Values encrypted using the data keys of tenants are unaffected
+		// by the master key, unlike the data keys themselves
+		return !storage.IsTenantSealed(value) && rdb.opts.keyring.NeedsRotation(value)
+	}
+	keys, err := rdb.loadTenantKeys(tenant)
+	return err == nil && !keys.IsErased() && keys.NeedsRotation(value)
+}
+
+// reencrypt re-encrypts the current values of the given keys, holding
+// off all the other writes so that none of them is overwritten.
+func (rdb *rocksDB) reencrypt(cf *gorocksdb.ColumnFamilyHandle, tenant []byte, keys [][]byte, rotation *storage.KeyRotation) error {
+	rdb.rotateMu.Lock()
+	defer rdb.rotateMu.Unlock()
+
+	cfs := make([]*gorocksdb.ColumnFamilyHandle, len(keys))
+	for i := range cfs {
+		cfs[i] = cf
+	}
+	slices, err := rdb.db.MultiGetCFMultiCF(rdb.opts.readOpts, cfs, keys)
+	if err != nil {
+		return err
+	}
+	values := make([][]byte, len(slices))
+	for i, slice := range slices {
+		values[i] = toByteArray(slice)
+		slice.Free()
+	}
+
+	wb := gorocksdb.NewWriteBatch()
+	defer wb.Destroy()
+	for i, key := range keys {
+		value := values[i]
+		if !rdb.needsRotation(cf, tenant, value) {
+			continue
+		}
+		if value, err = rdb.reseal(cf, tenant, value); err != nil {
+			return fmt.Errorf("unable to re-encrypt value of key: %s, error: %v", key, err)
+		}
+		wb.PutCF(cf, key, value)
+	}
+	if wb.Count() == 0 {
+		return nil
+	}
+
+	// Re-encryption leaves the values unchanged, hence does not record
+	// any versions
+	wo := rdb.writeOptions(serverpb.WALSync_DEFAULT_WAL_SYNC)
+	if rdb.changeLog == nil {
+		err = rdb.db.Write(wo, wb)
+	} else {
+		rdb.commitMu.Lock()
+		err = rdb.commitLocked(wo, wb, false)
+		rdb.commitMu.Unlock()
+	}
+	if err == nil {
+		rotation.NumReencrypted += uint64(wb.Count())
+	}
+	return err
+}
+
+func (rdb *rocksDB) reseal(cf *gorocksdb.ColumnFamilyHandle, tenant, value []byte) ([]byte, error) {
+	if cf != rdb.ttlCF {
+		return rdb.resealValue(tenant, value)
+	}
+	ttlRow, err := parseTTLMsgPackData(value)
+	if err != nil {
+		return nil, err
+	}
+	if ttlRow.Data, err = rdb.resealValue(tenant, ttlRow.Data); err != nil {
+		return nil, err
+	}
+	return msgpack.Marshal(ttlRow)
+}
+
+// resealValue re-encrypts the given value using the active data key of
+// the given tenant, or the active master key when no tenant is given.
+// Since the data keys of the tenant already exist, sealing the value
+// does not write to the store.
+func (rdb *rocksDB) resealValue(tenant, value []byte) ([]byte, error) {
+	keyring := rdb.opts.keyring
+	if tenant == nil {
+		decValue, err := keyring.Open(value)
+		if err != nil {
+			return nil, err
+		}
+		return keyring.Seal(decValue)
+	}
+	decValue, err := rdb.openTenantValue(tenant, value)
+	if err != nil {
+		return nil, err
+	}
+	return rdb.sealTenantValue(tenant, decValue)
+}
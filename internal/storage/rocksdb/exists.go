@@ -0,0 +1,51 @@
+package rocksdb
+
+// #include <stdlib.h>
+// #include "rocksdb/c.h"
+//
+// static unsigned char dkv_key_may_exist_cf(rocksdb_t* db, rocksdb_column_family_handle_t* cf,
+//                                           const char* key, size_t key_len) {
+//   rocksdb_readoptions_t* ro = rocksdb_readoptions_create();
+//   unsigned char res = rocksdb_key_may_exist_cf(db, ro, cf, key, key_len, NULL, NULL, NULL, 0, NULL);
+//   rocksdb_readoptions_destroy(ro);
+//   return res;
+// }
+import "C"
+
+import (
+	"time"
+	"unsafe"
+
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/gorocksdb"
+)
+
+// Exists checks if the given key is present in either of the column
+// families. RocksDB's KeyMayExist is consulted first, which mostly rules
+// out absent keys using the bloom filters and the memtables without any
+// disk reads. Keys that may exist are confirmed by reading them, since
+// their values could be expired or belong to an erased subject.
+func (rdb *rocksDB) Exists(key []byte) (bool, error) {
+	defer rdb.opts.statsCli.Timing("rocksdb.exists.latency.ms", time.Now())
+	if storage.IsReservedKey(key) {
+		return false, nil
+	}
+	if !rdb.keyMayExist(rdb.normalCF, key) && !rdb.keyMayExist(rdb.ttlCF, key) {
+		rdb.opts.statsCli.Incr("rocksdb.exists.filtered", 1)
+		return false, nil
+	}
+	kvs, err := rdb.getSingleKey(rdb.opts.readOpts, key)
+	return len(kvs) == 1, err
+}
+
+// keyMayExist returns false only if the given key is certainly absent
+// in the given column family. It may return true for absent keys.
+func (rdb *rocksDB) keyMayExist(cf *gorocksdb.ColumnFamilyHandle, key []byte) bool {
+	var cKey *C.char
+	if len(key) > 0 {
+		cKey = (*C.char)(unsafe.Pointer(&key[0]))
+	}
+	cDB := (*C.rocksdb_t)(rdb.db.UnsafeGetDB())
+	cCF := (*C.rocksdb_column_family_handle_t)(cf.UnsafeGetCFHandler())
+	return C.dkv_key_may_exist_cf(cDB, cCF, cKey, C.size_t(len(key))) != 0
+}
@@ -0,0 +1,183 @@
+package rocksdb
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/internal/hlc"
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"github.com/flipkart-incubator/gorocksdb"
+	"github.com/vmihailenco/msgpack/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	exportSSTFile    = "export.sst"
+	exportTTLSSTFile = "export.ttl.sst"
+	exportBinaryFile = "export.bin"
+)
+
+// Export exports the given key range from a RocksDB snapshot. Values are
+// exported decrypted, while the expired keys, the keys of erased subjects
+// and the internal keys are left out. The SST format yields a file for the
+// keys without TTL and another for the keys with TTL, either of which is
+// omitted when it would be empty, such that they can be ingested as is.
+func (rdb *rocksDB) Export(startKey, endKey []byte, format serverpb.ExportFormat, dir string) ([]string, uint64, error) {
+	defer rdb.opts.statsCli.Timing("rocksdb.export.latency.ms", time.Now())
+
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return nil, 0, err
+	}
+	snap := rdb.db.NewSnapshot()
+	defer rdb.db.ReleaseSnapshot(snap)
+	readOpts := gorocksdb.NewDefaultReadOptions()
+	defer readOpts.Destroy()
+	readOpts.SetSnapshot(snap)
+	readOpts.SetFillCache(false)
+
+	var (
+		files   []string
+		numKeys uint64
+		err     error
+	)
+	switch format {
+	case serverpb.ExportFormat_SST:
+		for _, cfFile := range []struct {
+			cf   *gorocksdb.ColumnFamilyHandle
+			file string
+		}{{rdb.normalCF, exportSSTFile}, {rdb.ttlCF, exportTTLSSTFile}} {
+			file := path.Join(dir, cfFile.file)
+			n, err := rdb.exportSST(readOpts, cfFile.cf, startKey, endKey, file)
+			if err != nil {
+				return nil, 0, err
+			}
+			if n > 0 {
+				files, numKeys = append(files, file), numKeys+n
+			}
+		}
+	case serverpb.ExportFormat_BINARY:
+		file := path.Join(dir, exportBinaryFile)
+		if numKeys, err = rdb.exportBinary(readOpts, startKey, endKey, file); err != nil {
+			return nil, 0, err
+		}
+		files = append(files, file)
+	default:
+		return nil, 0, fmt.Errorf("unsupported export format: %v", format)
+	}
+	rdb.opts.lgr.Info("Exported key range", zap.ByteString("StartKey", startKey), zap.ByteString("EndKey", endKey),
+		zap.Strings("Files", files), zap.Uint64("NumKeys", numKeys))
+	return files, numKeys, nil
+}
+
+func (rdb *rocksDB) exportSST(readOpts *gorocksdb.ReadOptions, cf *gorocksdb.ColumnFamilyHandle, startKey, endKey []byte, file string) (uint64, error) {
+	envOpts := gorocksdb.NewDefaultEnvOptions()
+	defer envOpts.Destroy()
+	opts := gorocksdb.NewDefaultOptions()
+	defer opts.Destroy()
+	sstWrtr := gorocksdb.NewSSTFileWriter(envOpts, opts)
+	defer sstWrtr.Destroy()
+
+	// RocksDB cannot finish an empty SST file, hence the
+	// file is only opened for the first key exported
+	var numKeys uint64
+	err := rdb.exportRange(readOpts, cf, startKey, endKey, func(kv *serverpb.KVPair) error {
+		if numKeys == 0 {
+			if err := sstWrtr.Open(file); err != nil {
+				return err
+			}
+		}
+		numKeys++
+		value := kv.Value
+		if cf == rdb.ttlCF {
+			msgPack, err := msgpack.Marshal(ttlDataFormat{ExpiryTS: kv.ExpireTS, Data: kv.Value})
+			if err != nil {
+				return err
+			}
+			value = msgPack
+		}
+		return sstWrtr.Add(kv.Key, value)
+	})
+	if err != nil || numKeys == 0 {
+		return 0, err
+	}
+	return numKeys, sstWrtr.Finish()
+}
+
+func (rdb *rocksDB) exportBinary(readOpts *gorocksdb.ReadOptions, startKey, endKey []byte, file string) (uint64, error) {
+	f, err := os.Create(file)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	wrtr, err := storage.NewExportWriter(f)
+	if err != nil {
+		return 0, err
+	}
+
+	var numKeys uint64
+	for _, cf := range []*gorocksdb.ColumnFamilyHandle{rdb.normalCF, rdb.ttlCF} {
+		err = rdb.exportRange(readOpts, cf, startKey, endKey, func(kv *serverpb.KVPair) error {
+			numKeys++
+			return wrtr.Write(kv)
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+	if err = wrtr.Flush(); err != nil {
+		return 0, err
+	}
+	return numKeys, f.Sync()
+}
+
+// exportRange invokes the given function with the live keys of the given
+// column family that lie within the given range, in their sorted order.
+func (rdb *rocksDB) exportRange(readOpts *gorocksdb.ReadOptions, cf *gorocksdb.ColumnFamilyHandle, startKey, endKey []byte, fn func(*serverpb.KVPair) error) error {
+	it := rdb.db.NewIteratorCF(readOpts, cf)
+	defer it.Close()
+	if len(startKey) > 0 {
+		it.Seek(startKey)
+	} else {
+		it.SeekToFirst()
+	}
+	for ; it.Valid(); it.Next() {
+		key := toByteArray(it.Key())
+		if len(endKey) > 0 && bytes.Compare(key, endKey) >= 0 {
+			break
+		}
+		if storage.IsReservedKey(key) {
+			continue
+		}
+		kv := &serverpb.KVPair{Key: key, Value: toByteArray(it.Value())}
+		if cf == rdb.ttlCF {
+			ttlRow, err := parseTTLMsgPackData(kv.Value)
+			if err != nil {
+				return fmt.Errorf("unable to parse the value of key: %q, error: %v", key, err)
+			}
+			if hlc.InThePast(ttlRow.ExpiryTS) && !rdb.UnderLegalHold(key) {
+				continue
+			}
+			kv.Value, kv.ExpireTS = ttlRow.Data, ttlRow.ExpiryTS
+		}
+		// Empty values are the same as absent values
+		if len(kv.Value) == 0 {
+			continue
+		}
+		value, ok, err := rdb.decryptValue(key, kv.Value)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		kv.Value = value
+		if err := fn(kv); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
@@ -0,0 +1,137 @@
+package rocksdb
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"github.com/flipkart-incubator/gorocksdb"
+)
+
+// WithReplicatedKeyPrefixes restricts the changes applied by a slave to
+// the keys having any of the given prefixes, which suits the replicas
+// that only serve a subset of the keyspace, such as those at the edge.
+//
+// Every operation of a replicated change on any other key is applied as
+// a write of an internal key instead, so that the change consumes the
+// same change numbers as on the master. Hence such slaves remain usable
+// for cascading replication and for switching masters, albeit serving
+// only the changes of the replicated prefixes. Internal keys, such as
+// the data keys of the subjects and tenants, are always replicated.
+func WithReplicatedKeyPrefixes(prefixes []string) DBOption {
+	return func(opts *rocksDBOpts) {
+		opts.replKeyPrefixes = nil
+		for _, prefix := range prefixes {
+			opts.replKeyPrefixes = append(opts.replKeyPrefixes, []byte(prefix))
+		}
+	}
+}
+
+// isReplicated checks if the given key of a replicated change belongs to
+// any of the replicated prefixes.
+func (rdb *rocksDB) isReplicated(key []byte) bool {
+	if len(rdb.opts.replKeyPrefixes) == 0 || storage.IsReservedKey(key) {
+		return true
+	}
+	for _, prefix := range rdb.opts.replKeyPrefixes {
+		if bytes.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isReplicatedRecord checks if the given record of a write batch is on a
+// key of the replicated prefixes. Versions are retained along with their
+// keys, while records that can not be rewritten are always retained.
+func (rdb *rocksDB) isReplicatedRecord(wbr *gorocksdb.WriteBatchRecord) bool {
+	switch wbr.Type {
+	case gorocksdb.WriteBatchValueRecord, gorocksdb.WriteBatchCFValueRecord,
+		gorocksdb.WriteBatchDeletionRecord, gorocksdb.WriteBatchCFDeletionRecord,
+		gorocksdb.WriteBatchMergeRecord, gorocksdb.WriteBatchCFMergeRecord:
+	default:
+		return true
+	}
+	if wbr.CF == versionsCFID {
+		key, ok := userKeyOfVersion(wbr.Key)
+		return !ok || rdb.isReplicated(key)
+	}
+	return rdb.isReplicated(wbr.Key)
+}
+
+// userKeyOfVersion extracts the user key from the given key of the
+// versions column family, unless it is the versions marker.
+func userKeyOfVersion(verKey []byte) ([]byte, bool) {
+	if len(verKey) < 4+8 || bytes.Equal(verKey, versionsMarkerKey) {
+		return nil, false
+	}
+	keyLen := int(binary.BigEndian.Uint32(verKey))
+	if len(verKey) != 4+keyLen+8 {
+		return nil, false
+	}
+	return verKey[4 : 4+keyLen], true
+}
+
+// filterChange rewrites the given replicated change for it to only carry
+// the operations on the replicated prefixes, with every other operation
+// replaced by a write of the internal filtered change key. The change is
+// returned as is when all of its operations are replicated.
+func (rdb *rocksDB) filterChange(chng *serverpb.ChangeRecord) *serverpb.ChangeRecord {
+	if len(rdb.opts.replKeyPrefixes) == 0 {
+		return chng
+	}
+	var retained, filtered []gorocksdb.WriteBatchRecord
+	wbIter := NewWriteBatchIterator(chng.SerialisedForm)
+	for wbIter.Next() {
+		wbr := wbIter.Record()
+		switch wbr.Type {
+		case gorocksdb.WriteBatchLogDataRecord, gorocksdb.WriteBatchNoopRecord,
+			gorocksdb.WriteBatchBeginPrepareXIDRecord, gorocksdb.WriteBatchBeginPersistedPrepareXIDRecord,
+			gorocksdb.WriteBatchEndPrepareXIDRecord, gorocksdb.WriteBatchCommitXIDRecord, gorocksdb.WriteBatchRollbackXIDRecord:
+			// Consume no sequence numbers, hence are dropped
+			continue
+		}
+		if rdb.isReplicatedRecord(wbr) {
+			retained = append(retained, *wbr)
+		} else {
+			filtered = append(filtered, *wbr)
+		}
+	}
+	if len(filtered) == 0 {
+		return chng
+	}
+	rdb.opts.statsCli.Incr("rocksdb.replication.filtered.trxns", int64(len(filtered)))
+
+	wb := gorocksdb.NewWriteBatch()
+	defer wb.Destroy()
+	cfs := []*gorocksdb.ColumnFamilyHandle{rdb.normalCF, rdb.ttlCF, rdb.versionsCF}
+	for _, wbr := range retained {
+		switch wbr.Type {
+		case gorocksdb.WriteBatchValueRecord, gorocksdb.WriteBatchCFValueRecord:
+			wb.PutCF(cfs[wbr.CF], wbr.Key, wbr.Value)
+		case gorocksdb.WriteBatchDeletionRecord, gorocksdb.WriteBatchCFDeletionRecord:
+			wb.DeleteCF(cfs[wbr.CF], wbr.Key)
+		case gorocksdb.WriteBatchMergeRecord, gorocksdb.WriteBatchCFMergeRecord:
+			wb.MergeCF(cfs[wbr.CF], wbr.Key, wbr.Value)
+		default:
+			// Such records can not be rewritten, hence the change is
+			// applied in its entirety
+			return chng
+		}
+	}
+	filteredKey := storage.FilteredChangeKey()
+	for range filtered {
+		wb.PutCF(rdb.normalCF, filteredKey, nil)
+	}
+
+	data := wb.Data()
+	filteredChng := &serverpb.ChangeRecord{
+		ChangeNumber:   chng.ChangeNumber,
+		NumberOfTrxns:  chng.NumberOfTrxns,
+		SerialisedForm: byteArrayCopy(data, len(data)),
+		FencingEpoch:   chng.FencingEpoch,
+	}
+	storage.SetChangeChecksum(filteredChng)
+	return filteredChng
+}
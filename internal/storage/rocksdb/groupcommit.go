@@ -0,0 +1,156 @@
+package rocksdb
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/flipkart-incubator/gorocksdb"
+)
+
+// WithGroupCommit coalesces the writes that fsync the WAL, arriving within
+// the given window of one another, into a single write batch committed with
+// a single fsync, upto the given number of writes per batch. Each of these
+// writes is acknowledged only once the batch is committed. Hence the writes
+// are delayed by upto the window, in exchange for a much higher throughput
+// of synchronous writes.
+func WithGroupCommit(window time.Duration, maxWrites int) DBOption {
+	return func(opts *rocksDBOpts) {
+		if window <= 0 || maxWrites <= 0 {
+			panic(fmt.Errorf("group commit window: %v and max writes: %d must be positive", window, maxWrites))
+		}
+		opts.groupCommitWindow, opts.groupCommitMaxWrites = window, maxWrites
+	}
+}
+
+var errGroupCommitStopped = errors.New("store is closed")
+
+// Size of the header of a serialised write batch, made up of the
+// sequence number followed by the number of operations
+const wbHeaderSize = 8 + 4
+
+type commitReq struct {
+	wb   *gorocksdb.WriteBatch
+	done chan error
+}
+
+type groupCommitter struct {
+	rdb       *rocksDB
+	window    time.Duration
+	maxWrites int
+	reqs      chan *commitReq
+	stop      chan struct{}
+	stopOnce  sync.Once
+	done      chan struct{}
+}
+
+func (rdb *rocksDB) startGroupCommitter() {
+	if rdb.opts.groupCommitWindow <= 0 {
+		return
+	}
+	gc := &groupCommitter{
+		rdb:       rdb,
+		window:    rdb.opts.groupCommitWindow,
+		maxWrites: rdb.opts.groupCommitMaxWrites,
+		reqs:      make(chan *commitReq),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	rdb.groupCommitter.Store(gc)
+	go gc.run()
+}
+
+func (rdb *rocksDB) loadGroupCommitter() *groupCommitter {
+	gc, _ := rdb.groupCommitter.Load().(*groupCommitter)
+	return gc
+}
+
+// stopGroupCommitter stops accepting writes, after committing the batch
+// being coalesced, if any. Writes arriving thereafter are rejected until
+// the group committer is started afresh.
+func (rdb *rocksDB) stopGroupCommitter() {
+	if gc := rdb.loadGroupCommitter(); gc != nil {
+		gc.stopOnce.Do(func() { close(gc.stop) })
+		<-gc.done
+	}
+}
+
+// commit blocks until the given write batch is committed along with
+// the other writes coalesced with it.
+func (gc *groupCommitter) commit(wb *gorocksdb.WriteBatch) error {
+	req := &commitReq{wb, make(chan error, 1)}
+	select {
+	case gc.reqs <- req:
+		return <-req.done
+	case <-gc.stop:
+		return errGroupCommitStopped
+	}
+}
+
+func (gc *groupCommitter) run() {
+	defer close(gc.done)
+	for {
+		var group []*commitReq
+		select {
+		case req := <-gc.reqs:
+			group = append(group, req)
+		case <-gc.stop:
+			return
+		}
+		timer := time.NewTimer(gc.window)
+	collect:
+		for len(group) < gc.maxWrites {
+			select {
+			case req := <-gc.reqs:
+				group = append(group, req)
+			case <-timer.C:
+				break collect
+			}
+		}
+		timer.Stop()
+		gc.commitGroup(group)
+	}
+}
+
+func (gc *groupCommitter) commitGroup(group []*commitReq) {
+	rdb, wo := gc.rdb, gc.rdb.opts.syncWriteOpts
+	rdb.opts.statsCli.Gauge("rocksdb.group.commit.writes", int64(len(group)))
+	if len(group) == 1 {
+		group[0].done <- rdb.commit(wo, group[0].wb, true)
+		return
+	}
+	wb := gorocksdb.WriteBatchFrom(mergeWriteBatches(group))
+	err := rdb.commit(wo, wb, true)
+	wb.Destroy()
+	if err == nil {
+		for _, req := range group {
+			req.done <- nil
+		}
+		return
+	}
+	// Since nothing is committed by a failed batch, the writes are retried
+	// individually so that a rejected write does not fail the others
+	rdb.opts.statsCli.Incr("rocksdb.group.commit.retries", 1)
+	for _, req := range group {
+		req.done <- rdb.commit(wo, req.wb, true)
+	}
+}
+
+// mergeWriteBatches serialises a write batch holding the operations of
+// all the given write batches, in their order.
+func mergeWriteBatches(group []*commitReq) []byte {
+	datas, size := make([][]byte, len(group)), wbHeaderSize
+	for i, req := range group {
+		datas[i] = req.wb.Data()
+		size += len(datas[i]) - wbHeaderSize
+	}
+	merged, count := make([]byte, wbHeaderSize, size), uint32(0)
+	for i, data := range datas {
+		merged = append(merged, data[wbHeaderSize:]...)
+		count += uint32(group[i].wb.Count())
+	}
+	binary.LittleEndian.PutUint32(merged[8:], count)
+	return merged
+}
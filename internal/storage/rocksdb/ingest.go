@@ -0,0 +1,173 @@
+package rocksdb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"github.com/flipkart-incubator/gorocksdb"
+	"github.com/vmihailenco/msgpack/v5"
+	"go.uber.org/zap"
+)
+
+var errIngestionWithEncryption = errors.New("SST files cannot be ingested when values are encrypted at rest or per subject")
+
+// IngestSST ingests the given SST files, typically built using SSTBuilder,
+// into the default and TTL column families respectively. Every file is
+// ingested separately so that files with overlapping key ranges are
+// permitted, the keys of the later files taking precedence. Since the
+// ingested keys bypass the WAL, they are neither recorded as versions nor
+// propagated to slaves, hence ingestion is meant for the initial loads of
+// keys that do not exist yet.
+func (rdb *rocksDB) IngestSST(files, ttlFiles []string, moveFiles bool) error {
+	defer rdb.opts.statsCli.Timing("rocksdb.ingest.sst.latency.ms", time.Now())
+
+	if rdb.opts.keyring != nil || rdb.opts.subjects != nil {
+		return errIngestionWithEncryption
+	}
+	ingestOpts := gorocksdb.NewDefaultIngestExternalFileOptions()
+	defer ingestOpts.Destroy()
+	ingestOpts.SetMoveFiles(moveFiles)
+
+	for _, cfFiles := range []struct {
+		cf    *gorocksdb.ColumnFamilyHandle
+		files []string
+	}{{rdb.normalCF, files}, {rdb.ttlCF, ttlFiles}} {
+		for _, file := range cfFiles.files {
+			if err := rdb.db.IngestExternalFileCF(cfFiles.cf, []string{file}, ingestOpts); err != nil {
+				rdb.opts.statsCli.Incr("rocksdb.ingest.sst.errors", 1)
+				return fmt.Errorf("unable to ingest SST file: %s, error: %v", file, err)
+			}
+			rdb.opts.lgr.Info("Ingested SST file", zap.String("File", file))
+		}
+	}
+	return nil
+}
+
+// SSTBuilder builds SST files for ingestion into a RocksDB store out of
+// key value pairs given in any order. The pairs are buffered in memory
+// upto the given limit, before being sorted and written out into a new
+// file for the keys without TTL and another for the keys with TTL. A key
+// added more than once retains the value it was last added with, as long
+// as the files are ingested in the order they are built.
+type SSTBuilder struct {
+	dir             string
+	maxPairs        int
+	pairs           []*serverpb.KVPair
+	files, ttlFiles []string
+}
+
+// NewSSTBuilder creates an SSTBuilder writing the SST files into the
+// given folder, each holding upto the given number of key value pairs.
+func NewSSTBuilder(dir string, maxPairsPerFile int) (*SSTBuilder, error) {
+	if maxPairsPerFile <= 0 {
+		return nil, fmt.Errorf("maximum number of pairs per SST file: %d must be positive", maxPairsPerFile)
+	}
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return nil, err
+	}
+	return &SSTBuilder{dir: dir, maxPairs: maxPairsPerFile}, nil
+}
+
+// Add adds the given key value pair, whose expiry is considered
+// only when positive.
+func (sb *SSTBuilder) Add(kv *serverpb.KVPair) error {
+	switch {
+	case len(kv.Key) == 0:
+		return errors.New("key must not be empty")
+	case storage.IsReservedKey(kv.Key):
+		return fmt.Errorf("key: %q is reserved for internal use", kv.Key)
+	}
+	sb.pairs = append(sb.pairs, kv)
+	if len(sb.pairs) >= sb.maxPairs {
+		return sb.flush()
+	}
+	return nil
+}
+
+// Finish writes out the buffered key value pairs and retrieves the SST
+// files built for the keys without and with TTL, in the order in which
+// they must be ingested.
+func (sb *SSTBuilder) Finish() (files, ttlFiles []string, err error) {
+	if err = sb.flush(); err != nil {
+		return nil, nil, err
+	}
+	return sb.files, sb.ttlFiles, nil
+}
+
+func (sb *SSTBuilder) flush() error {
+	if len(sb.pairs) == 0 {
+		return nil
+	}
+	pairs := sb.pairs
+	sb.pairs = nil
+
+	// Retain only the last value added for every key, since the
+	// keys of an SST file must be strictly increasing
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return bytes.Compare(pairs[i].Key, pairs[j].Key) < 0
+	})
+	var normalPairs, ttlPairs []*serverpb.KVPair
+	for i, kv := range pairs {
+		if i+1 < len(pairs) && bytes.Equal(kv.Key, pairs[i+1].Key) {
+			continue
+		}
+		if kv.ExpireTS > 0 {
+			ttlPairs = append(ttlPairs, kv)
+		} else {
+			normalPairs = append(normalPairs, kv)
+		}
+	}
+
+	seq := len(sb.files) + len(sb.ttlFiles)
+	if len(normalPairs) > 0 {
+		file := path.Join(sb.dir, fmt.Sprintf("%06d.default.sst", seq))
+		if err := writeSST(file, normalPairs, false); err != nil {
+			return err
+		}
+		sb.files = append(sb.files, file)
+	}
+	if len(ttlPairs) > 0 {
+		file := path.Join(sb.dir, fmt.Sprintf("%06d.ttl.sst", seq))
+		if err := writeSST(file, ttlPairs, true); err != nil {
+			return err
+		}
+		sb.ttlFiles = append(sb.ttlFiles, file)
+	}
+	return nil
+}
+
+// writeSST writes the given sorted key value pairs into the given file,
+// encoding their values in the format of the TTL column family if needed.
+func writeSST(file string, pairs []*serverpb.KVPair, withTTL bool) error {
+	envOpts := gorocksdb.NewDefaultEnvOptions()
+	defer envOpts.Destroy()
+	opts := gorocksdb.NewDefaultOptions()
+	defer opts.Destroy()
+	sstWrtr := gorocksdb.NewSSTFileWriter(envOpts, opts)
+	defer sstWrtr.Destroy()
+
+	if err := sstWrtr.Open(file); err != nil {
+		return err
+	}
+	for _, kv := range pairs {
+		value := kv.Value
+		if withTTL {
+			msgPack, err := msgpack.Marshal(ttlDataFormat{ExpiryTS: kv.ExpireTS, Data: kv.Value})
+			if err != nil {
+				return err
+			}
+			value = msgPack
+		}
+		if err := sstWrtr.Add(kv.Key, value); err != nil {
+			return err
+		}
+	}
+	return sstWrtr.Finish()
+}
@@ -0,0 +1,91 @@
+package rocksdb
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"github.com/flipkart-incubator/gorocksdb"
+	"go.uber.org/zap"
+)
+
+// Legal holds are stored under reserved keys of the default column family,
+// hence are replicated to the slaves through the change log, and to the
+// members of a cluster by applying the holds through consensus. The holds
+// are loaded into memory upon opening the store, as they are consulted
+// upon every expiry and deletion.
+
+// PlaceLegalHold places a legal hold over all the keys having the given
+// prefix. Placing a hold over an already held prefix has no effect.
+func (rdb *rocksDB) PlaceLegalHold(keyPrefix []byte) error {
+	if len(keyPrefix) == 0 {
+		return errors.New("key prefix must be provided for placing a legal hold")
+	}
+	rdb.legalHoldsMu.Lock()
+	defer rdb.legalHoldsMu.Unlock()
+	if rdb.isLegalHold(keyPrefix) {
+		return nil
+	}
+	wb := gorocksdb.NewWriteBatch()
+	defer wb.Destroy()
+	wb.PutCF(rdb.normalCF, storage.LegalHoldRecord(keyPrefix), nil)
+	if err := rdb.write(rdb.writeOptions(serverpb.WALSync_DEFAULT_WAL_SYNC), wb); err != nil {
+		return err
+	}
+	return rdb.LegalHolds.PlaceLegalHold(keyPrefix)
+}
+
+// LiftLegalHold lifts the legal hold previously placed over the given
+// prefix. Returns an error if the given prefix is not under legal hold.
+func (rdb *rocksDB) LiftLegalHold(keyPrefix []byte) error {
+	rdb.legalHoldsMu.Lock()
+	defer rdb.legalHoldsMu.Unlock()
+	if !rdb.isLegalHold(keyPrefix) {
+		return errors.New("given key prefix is not under legal hold")
+	}
+	wb := gorocksdb.NewWriteBatch()
+	defer wb.Destroy()
+	wb.DeleteCF(rdb.normalCF, storage.LegalHoldRecord(keyPrefix))
+	if err := rdb.write(rdb.writeOptions(serverpb.WALSync_DEFAULT_WAL_SYNC), wb); err != nil {
+		return err
+	}
+	return rdb.LegalHolds.LiftLegalHold(keyPrefix)
+}
+
+func (rdb *rocksDB) isLegalHold(keyPrefix []byte) bool {
+	for _, prefix := range rdb.ListLegalHolds() {
+		if bytes.Equal(prefix, keyPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// loadLegalHolds reads the prefixes under legal hold from the store.
+func (rdb *rocksDB) loadLegalHolds() ([][]byte, error) {
+	recPrefix := storage.LegalHoldRecordPrefix()
+	it := rdb.db.NewIteratorCF(rdb.opts.readOpts, rdb.normalCF)
+	defer it.Close()
+	var prefixes [][]byte
+	for it.Seek(recPrefix); it.ValidForPrefix(recPrefix); it.Next() {
+		prefixes = append(prefixes, toByteArray(it.Key())[len(recPrefix):])
+	}
+	return prefixes, it.Err()
+}
+
+// reloadLegalHolds reloads the legal holds after applying the given
+// change replicated from the master, if the change may alter them.
+func (rdb *rocksDB) reloadLegalHolds(chng *serverpb.ChangeRecord) {
+	if !bytes.Contains(chng.SerialisedForm, storage.LegalHoldRecordPrefix()) {
+		return
+	}
+	rdb.legalHoldsMu.Lock()
+	defer rdb.legalHoldsMu.Unlock()
+	prefixes, err := rdb.loadLegalHolds()
+	if err != nil {
+		rdb.opts.lgr.Error("Unable to reload the legal holds", zap.Uint64("ChangeNumber", chng.ChangeNumber), zap.Error(err))
+		return
+	}
+	rdb.LegalHolds.Reset(prefixes)
+}
@@ -0,0 +1,55 @@
+package rocksdb
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"github.com/flipkart-incubator/gorocksdb"
+	"go.uber.org/zap"
+)
+
+var errNoMemtableOptions = errors.New("at least one memtable option must be given")
+
+// SetMemtableOptions changes the given non zero memtable and level 0
+// options on the column families holding the keys. The change lasts until
+// the store is closed. Note that the auto-tuner, if enabled, may later
+// override the write buffer size.
+func (rdb *rocksDB) SetMemtableOptions(mtOpts *serverpb.MemtableOptions) error {
+	var names, values []string
+	set := func(name string, value uint64) {
+		if value > 0 {
+			names, values = append(names, name), append(values, fmt.Sprint(value))
+		}
+	}
+	set("write_buffer_size", mtOpts.WriteBufferSize)
+	set("max_write_buffer_number", uint64(mtOpts.MaxWriteBufferNumber))
+	set("level0_file_num_compaction_trigger", uint64(mtOpts.Level0FileNumCompactionTrigger))
+	set("level0_slowdown_writes_trigger", uint64(mtOpts.Level0SlowdownWritesTrigger))
+	set("level0_stop_writes_trigger", uint64(mtOpts.Level0StopWritesTrigger))
+
+	switch {
+	case len(names) == 0:
+		return errNoMemtableOptions
+	case mtOpts.WriteBufferSize > 0 && mtOpts.WriteBufferSize < minWriteBufferSize:
+		return fmt.Errorf("write buffer size: %d must be at least %d bytes", mtOpts.WriteBufferSize, minWriteBufferSize)
+	case mtOpts.MaxWriteBufferNumber == 1:
+		return errors.New("max write buffer number: 1 must be at least 2")
+	case mtOpts.Level0SlowdownWritesTrigger > 0 && mtOpts.Level0StopWritesTrigger > 0 &&
+		mtOpts.Level0SlowdownWritesTrigger > mtOpts.Level0StopWritesTrigger:
+		return fmt.Errorf("level0 slowdown writes trigger: %d must not exceed level0 stop writes trigger: %d",
+			mtOpts.Level0SlowdownWritesTrigger, mtOpts.Level0StopWritesTrigger)
+	}
+
+	for _, cf := range []*gorocksdb.ColumnFamilyHandle{rdb.normalCF, rdb.ttlCF} {
+		if err := rdb.setCFOptions(cf, names, values); err != nil {
+			return err
+		}
+	}
+	fields := make([]zap.Field, len(names))
+	for i := range names {
+		fields[i] = zap.String(names[i], values[i])
+	}
+	rdb.opts.lgr.Info("Changed memtable options", fields...)
+	return nil
+}
@@ -0,0 +1,207 @@
+package rocksdb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"github.com/flipkart-incubator/gorocksdb"
+)
+
+// A MergeOperator implements an application defined read-modify-write
+// over the value of a key, that is performed by RocksDB on the server.
+// Since the merge operands are replicated as is, the same operators
+// must be registered for the same key prefixes on all the nodes.
+type MergeOperator interface {
+	// FullMerge merges the given operands, in their order of arrival,
+	// into the existing value which is nil if the key does not exist.
+	// Returns false if the operands cannot be merged.
+	FullMerge(key, existingValue []byte, operands [][]byte) ([]byte, bool)
+}
+
+var (
+	errNoMergeOperator         = errors.New("no merge operator is registered for the given key")
+	errMergeOnEncryptedSubject = errors.New("merge is not supported on keys of encrypted subjects")
+)
+
+var (
+	mergeOperatorsMu sync.RWMutex
+	mergeOperators   = map[string]MergeOperator{"counter": counterMergeOperator{}, "set": setMergeOperator{}}
+)
+
+var setMembersSeparator = []byte(",")
+
+// RegisterMergeOperator makes the given merge operator available under
+// the given name, so that it can be configured using WithMergeOperatorName.
+// It is intended to be called from the init function of the packages
+// implementing merge operators. The built-in operators are `counter`,
+// which adds decimal integers, and `set`, which unions comma separated
+// members.
+func RegisterMergeOperator(name string, op MergeOperator) error {
+	mergeOperatorsMu.Lock()
+	defer mergeOperatorsMu.Unlock()
+	if _, present := mergeOperators[name]; present {
+		return fmt.Errorf("merge operator: %s is already registered", name)
+	}
+	mergeOperators[name] = op
+	return nil
+}
+
+// WithMergeOperator registers the given merge operator for the keys
+// having the given prefix. When prefixes overlap, the operator of the
+// longest matching prefix is used.
+func WithMergeOperator(keyPrefix string, op MergeOperator) DBOption {
+	return func(opts *rocksDBOpts) {
+		if keyPrefix == "" || op == nil {
+			panic(errors.New("key prefix and merge operator must be provided"))
+		}
+		opts.mergeOps = append(opts.mergeOps, prefixMergeOperator{[]byte(keyPrefix), op})
+		sort.SliceStable(opts.mergeOps, func(i, j int) bool {
+			return len(opts.mergeOps[i].keyPrefix) > len(opts.mergeOps[j].keyPrefix)
+		})
+	}
+}
+
+// WithMergeOperatorName registers the merge operator previously made
+// available under the given name for the keys having the given prefix.
+func WithMergeOperatorName(keyPrefix, name string) DBOption {
+	mergeOperatorsMu.RLock()
+	op, present := mergeOperators[name]
+	mergeOperatorsMu.RUnlock()
+	if !present {
+		panic(fmt.Errorf("unknown merge operator: %s", name))
+	}
+	return WithMergeOperator(keyPrefix, op)
+}
+
+type prefixMergeOperator struct {
+	keyPrefix []byte
+	op        MergeOperator
+}
+
+// prefixMergeOperators dispatches the merges of every key to the merge
+// operator registered for the longest matching prefix of that key.
+type prefixMergeOperators []prefixMergeOperator
+
+func (pmos prefixMergeOperators) Name() string {
+	return "dkv.prefixMergeOperator"
+}
+
+func (pmos prefixMergeOperators) FullMerge(key, existingValue []byte, operands [][]byte) ([]byte, bool) {
+	if op := pmos.lookup(key); op != nil {
+		return op.FullMerge(key, existingValue, operands)
+	}
+	return nil, false
+}
+
+func (pmos prefixMergeOperators) lookup(key []byte) MergeOperator {
+	for _, pmo := range pmos {
+		if bytes.HasPrefix(key, pmo.keyPrefix) {
+			return pmo.op
+		}
+	}
+	return nil
+}
+
+// Merge merges the given operand into the value of the given key using
+// the merge operator registered for that key. Merges apply to the keys
+// without expiry, hence any expiring version of the key is discarded.
+func (rdb *rocksDB) Merge(key, operand []byte) error {
+	defer rdb.opts.statsCli.Timing("rocksdb.merge.latency.ms", time.Now())
+	var err error
+	_, isSubj := rdb.opts.subjects.Of(key)
+	_, isTenant := rdb.opts.tenants.Of(key)
+	switch {
+	case storage.IsReservedKey(key):
+		err = errReservedKey
+	case rdb.opts.originID != "":
+		err = errUnstampedWrite
+	case isSubj:
+		err = errMergeOnEncryptedSubject
+	case isTenant:
+		err = errMergeOnEncryptedTenant
+	case rdb.isWALLess(key):
+		err = errWALLessKey
+	case rdb.opts.mergeOps.lookup(key) == nil:
+		err = errNoMergeOperator
+	case rdb.UnderLegalHold(key):
+		// Any expiring version of the key must be retained
+		rdb.opts.statsCli.Incr("rocksdb.merge.legal.hold.rejects", 1)
+		err = storage.ErrUnderLegalHold
+	default:
+		if err = rdb.checkMergeable(key, operand); err != nil {
+			break
+		}
+		if operand, err = rdb.sealValue(key, operand); err != nil {
+			break
+		}
+		wb := gorocksdb.NewWriteBatch()
+		defer wb.Destroy()
+		wb.DeleteCF(rdb.ttlCF, key)
+		wb.MergeCF(rdb.normalCF, key, operand)
+		err = rdb.write(rdb.writeOptions(serverpb.WALSync_DEFAULT_WAL_SYNC), wb)
+	}
+	if err != nil {
+		rdb.opts.statsCli.Incr("rocksdb.merge.errors", 1)
+	}
+	return err
+}
+
+// checkMergeable verifies that the merge operator registered for the
+// given key, if any, is able to merge the given value. A value that
+// cannot be merged fails every subsequent read of the key and also its
+// compactions, which in turn stop the writes onto the entire store.
+func (rdb *rocksDB) checkMergeable(key, value []byte) error {
+	if op := rdb.opts.mergeOps.lookup(key); op != nil {
+		if _, ok := op.FullMerge(key, nil, [][]byte{value}); !ok {
+			return storage.ErrUnmergeableValue
+		}
+	}
+	return nil
+}
+
+// counterMergeOperator adds the operands to the existing value, all of
+// which are decimal integers.
+type counterMergeOperator struct{}
+
+func (counterMergeOperator) FullMerge(_, existingValue []byte, operands [][]byte) ([]byte, bool) {
+	var sum int64
+	for _, val := range append([][]byte{existingValue}, operands...) {
+		if len(val) == 0 {
+			continue
+		}
+		num, err := strconv.ParseInt(string(val), 10, 64)
+		if err != nil {
+			return nil, false
+		}
+		sum += num
+	}
+	return []byte(strconv.FormatInt(sum, 10)), true
+}
+
+// setMergeOperator unions the operands with the existing value, all of
+// which are comma separated members. Members are kept sorted.
+type setMergeOperator struct{}
+
+func (setMergeOperator) FullMerge(_, existingValue []byte, operands [][]byte) ([]byte, bool) {
+	members := make(map[string]struct{})
+	for _, val := range append([][]byte{existingValue}, operands...) {
+		for _, member := range bytes.Split(val, setMembersSeparator) {
+			if len(member) > 0 {
+				members[string(member)] = struct{}{}
+			}
+		}
+	}
+	sorted := make([][]byte, 0, len(members))
+	for member := range members {
+		sorted = append(sorted, []byte(member))
+	}
+	sort.Slice(sorted, func(i, j int) bool { return bytes.Compare(sorted[i], sorted[j]) < 0 })
+	return bytes.Join(sorted, setMembersSeparator), true
+}
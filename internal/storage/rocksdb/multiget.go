@@ -0,0 +1,57 @@
+package rocksdb
+
+import (
+	"fmt"
+
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"github.com/flipkart-incubator/gorocksdb"
+)
+
+// WithMultiGetParallelism splits the reads of more than the given batch
+// size of keys into batches, each looked up using the native MultiGet
+// of RocksDB, with upto the given number of batches in flight. Hence the
+// latency of a large read is not bound by looking up all of its keys in
+// sequence. All the batches of a read observe the same snapshot.
+func WithMultiGetParallelism(batchSize, parallelism int) DBOption {
+	return func(opts *rocksDBOpts) {
+		if batchSize <= 0 || parallelism <= 0 {
+			panic(fmt.Errorf("multi get batch size: %d and parallelism: %d must be positive", batchSize, parallelism))
+		}
+		opts.multiGetBatchSize, opts.multiGetParallelism = batchSize, parallelism
+	}
+}
+
+func (rdb *rocksDB) parallelMultiGet(numKeys int) bool {
+	return rdb.opts.multiGetBatchSize > 0 && numKeys > rdb.opts.multiGetBatchSize && rdb.opts.multiGetParallelism > 1
+}
+
+// multiGet loads the values of the given keys using the given read
+// options, or the default ones if nil. Keys read in parallel batches
+// are read from a snapshot taken for this read.
+func (rdb *rocksDB) multiGet(readOpts *serverpb.ReadOptions, keys [][]byte) ([]*serverpb.KVPair, error) {
+	if !rdb.parallelMultiGet(len(keys)) {
+		if readOpts == nil {
+			return rdb.getMultipleKeys(rdb.opts.readOpts, keys)
+		}
+		ro := toReadOptions(readOpts)
+		defer putReadOptions(ro)
+		return rdb.getMultipleKeys(ro, keys)
+	}
+	ro := toReadOptions(readOpts)
+	defer putReadOptions(ro)
+	snap := rdb.db.NewSnapshot()
+	defer rdb.db.ReleaseSnapshot(snap)
+	ro.SetSnapshot(snap)
+	return rdb.getSnapshotKeys(ro, keys)
+}
+
+// getSnapshotKeys loads the values of the given keys using the given
+// read options, which must refer to a snapshot if the keys are to be
+// read in parallel batches.
+func (rdb *rocksDB) getSnapshotKeys(ro *gorocksdb.ReadOptions, keys [][]byte) ([]*serverpb.KVPair, error) {
+	read := func(batch ...[]byte) ([]*serverpb.KVPair, error) {
+		return rdb.getMultipleKeys(ro, batch)
+	}
+	return storage.ParallelGet(read, rdb.opts.multiGetBatchSize, rdb.opts.multiGetParallelism, keys...)
+}
@@ -0,0 +1,89 @@
+package rocksdb
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"sync/atomic"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+)
+
+// WithNamespaceCacheStats accounts the block cache lookups of the reads
+// per namespace, identified by the given key prefixes, so that the ones
+// thrashing the block cache shared by all the namespaces can be told apart.
+// Since sampling the perf context of a read costs more than reading a
+// cached block, only one in every given number of reads of a namespace is
+// sampled. Reads of several keys are accounted to the namespace of their
+// first key.
+func WithNamespaceCacheStats(namespaces []string, sampleEvery int) DBOption {
+	return func(opts *rocksDBOpts) {
+		if len(namespaces) == 0 {
+			return
+		}
+		if sampleEvery <= 0 {
+			panic(fmt.Errorf("namespace cache stats sampling: %d must be positive", sampleEvery))
+		}
+		nca := &namespaceCacheAccounting{sampleEvery: uint64(sampleEvery)}
+		for _, ns := range namespaces {
+			nca.namespaces = append(nca.namespaces, &namespaceCacheStats{prefix: []byte(ns)})
+		}
+		// Longer prefixes are matched first, so that nested namespaces are
+		// accounted separately
+		sort.Slice(nca.namespaces, func(i, j int) bool { return len(nca.namespaces[i].prefix) > len(nca.namespaces[j].prefix) })
+		opts.nsCacheStats = nca
+	}
+}
+
+type namespaceCacheAccounting struct {
+	sampleEvery uint64
+	namespaces  []*namespaceCacheStats
+}
+
+// namespaceCacheStats accumulates the perf context counters of the sampled
+// reads of a namespace. Its counters are updated atomically.
+type namespaceCacheStats struct {
+	prefix                     []byte
+	reads, sampledReads        uint64
+	blockCacheHits, blockReads uint64
+}
+
+// sample determines the namespace of the given key whose read is to be
+// sampled, returning nil if the read is not to be sampled. It is a no-op
+// on a nil namespaceCacheAccounting.
+func (nca *namespaceCacheAccounting) sample(key []byte) *namespaceCacheStats {
+	if nca == nil {
+		return nil
+	}
+	for _, ns := range nca.namespaces {
+		if bytes.HasPrefix(key, ns.prefix) {
+			if atomic.AddUint64(&ns.reads, 1)%nca.sampleEvery != 0 {
+				return nil
+			}
+			return ns
+		}
+	}
+	return nil
+}
+
+func (ns *namespaceCacheStats) record(blockCacheHits, blockReads uint64) {
+	atomic.AddUint64(&ns.sampledReads, 1)
+	atomic.AddUint64(&ns.blockCacheHits, blockCacheHits)
+	atomic.AddUint64(&ns.blockReads, blockReads)
+}
+
+// stats retrieves the block cache usage of the sampled reads of every
+// namespace since the store was opened, in the order of the namespaces.
+func (nca *namespaceCacheAccounting) stats() []*serverpb.NamespaceCacheStats {
+	if nca == nil {
+		return nil
+	}
+	res := make([]*serverpb.NamespaceCacheStats, len(nca.namespaces))
+	for i, ns := range nca.namespaces {
+		hits, reads := atomic.LoadUint64(&ns.blockCacheHits), atomic.LoadUint64(&ns.blockReads)
+		res[i] = &serverpb.NamespaceCacheStats{Namespace: string(ns.prefix), NumSampledReads: atomic.LoadUint64(&ns.sampledReads),
+			BlockCacheHits: hits, BlockReads: reads, BlockCacheHitRate: ratio(hits, hits+reads)}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Namespace < res[j].Namespace })
+	return res
+}
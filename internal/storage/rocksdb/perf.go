@@ -0,0 +1,157 @@
+package rocksdb
+
+// #include "rocksdb/c.h"
+import "C"
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/internal/storage"
+)
+
+// Minimum number of sampled keys for the tuning suggestions to be relevant
+const minSampledKeysForSuggestions = 100
+
+var errReadAmpSamplingInProgress = errors.New("read amplification is already being sampled")
+
+// readAmpStats accumulates the RocksDB perf context counters of the
+// sampled reads.
+type readAmpStats struct {
+	mu                         sync.Mutex
+	reads, keys, memtableReads uint64
+	blockReads, blockCacheHits uint64
+	bloomHits, bloomMisses     uint64
+}
+
+// perfSample captures the perf context of a single read. Perf contexts
+// are thread local, hence the goroutine is pinned to its OS thread for
+// the duration of the read.
+type perfSample struct {
+	pc      *C.rocksdb_perfcontext_t
+	readAmp bool
+	ns      *namespaceCacheStats
+}
+
+// beginPerfSample begins sampling the read of the given keys that follows,
+// if the reads are being sampled for the read amplification or for the
+// cache usage of the namespace of the first key. Returns nil otherwise.
+func (rdb *rocksDB) beginPerfSample(keys ...[]byte) *perfSample {
+	readAmp := atomic.LoadUint32(&rdb.readAmpSampling) != 0
+	var ns *namespaceCacheStats
+	if len(keys) > 0 {
+		ns = rdb.opts.nsCacheStats.sample(keys[0])
+	}
+	if !readAmp && ns == nil {
+		return nil
+	}
+	runtime.LockOSThread()
+	C.rocksdb_set_perf_level(C.rocksdb_enable_count)
+	pc := C.rocksdb_perfcontext_create()
+	C.rocksdb_perfcontext_reset(pc)
+	return &perfSample{pc, readAmp, ns}
+}
+
+// end records the perf context of the sampled read of the given number
+// of keys. It is a no-op on a nil perfSample.
+func (ps *perfSample) end(rdb *rocksDB, numKeys int) {
+	if ps == nil {
+		return
+	}
+	metric := func(m C.int) uint64 {
+		return uint64(C.rocksdb_perfcontext_metric(ps.pc, m))
+	}
+	blockReads, blockCacheHits := metric(C.rocksdb_block_read_count), metric(C.rocksdb_block_cache_hit_count)
+	bloomHits, bloomMisses := metric(C.rocksdb_bloom_sst_hit_count), metric(C.rocksdb_bloom_sst_miss_count)
+	C.rocksdb_perfcontext_destroy(ps.pc)
+	C.rocksdb_set_perf_level(C.rocksdb_disable)
+	runtime.UnlockOSThread()
+
+	if ps.ns != nil {
+		ps.ns.record(blockCacheHits, blockReads)
+	}
+	if !ps.readAmp {
+		return
+	}
+	stats := &rdb.readAmpStats
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+	stats.reads++
+	stats.keys += uint64(numKeys)
+	// Reads that did not touch any SST file are served by the memtables
+	if blockReads+blockCacheHits+bloomHits+bloomMisses == 0 {
+		stats.memtableReads++
+	}
+	stats.blockReads += blockReads
+	stats.blockCacheHits += blockCacheHits
+	stats.bloomHits += bloomHits
+	stats.bloomMisses += bloomMisses
+}
+
+// SampleReadAmplification samples the RocksDB perf context of all the
+// reads served during the given window and summarizes them.
+func (rdb *rocksDB) SampleReadAmplification(window time.Duration) (*storage.ReadAmplification, error) {
+	if !atomic.CompareAndSwapUint32(&rdb.readAmpSampling, 0, 1) {
+		return nil, errReadAmpSamplingInProgress
+	}
+	stats := &rdb.readAmpStats
+	stats.mu.Lock()
+	stats.reads, stats.keys, stats.memtableReads = 0, 0, 0
+	stats.blockReads, stats.blockCacheHits, stats.bloomHits, stats.bloomMisses = 0, 0, 0, 0
+	stats.mu.Unlock()
+
+	time.Sleep(window)
+	atomic.StoreUint32(&rdb.readAmpSampling, 0)
+
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+	readAmp := &storage.ReadAmplification{
+		NumReads:          stats.reads,
+		NumKeys:           stats.keys,
+		BlockReadsPerKey:  ratio(stats.blockReads, stats.keys),
+		BlockCacheHitRate: ratio(stats.blockCacheHits, stats.blockCacheHits+stats.blockReads),
+		MemtableHitRate:   ratio(stats.memtableReads, stats.reads),
+		BloomUsefulness:   ratio(stats.bloomMisses, stats.bloomHits+stats.bloomMisses),
+	}
+	readAmp.Suggestions = readAmpSuggestions(readAmp, stats.bloomHits+stats.bloomMisses > 0)
+	return readAmp, nil
+}
+
+func ratio(num, den uint64) float64 {
+	if den == 0 {
+		return 0
+	}
+	return float64(num) / float64(den)
+}
+
+// readAmpSuggestions suggests the configuration changes, if any, that
+// could make the reads summarized by the given report more efficient.
+func readAmpSuggestions(readAmp *storage.ReadAmplification, bloomsChecked bool) []string {
+	if readAmp.NumKeys < minSampledKeysForSuggestions {
+		return []string{"Too few reads were sampled for any suggestions, try a longer sampling window during peak traffic."}
+	}
+	var suggestions []string
+	if readAmp.BlockReadsPerKey > 0.5 && readAmp.BlockCacheHitRate < 0.8 {
+		suggestions = append(suggestions, "Most block lookups miss the block cache and go to disk. "+
+			"Consider increasing block-cache-size, so that frequently read blocks remain cached.")
+	}
+	if readAmp.BlockReadsPerKey > 2 {
+		suggestions = append(suggestions, "Every key lookup reads several blocks from disk, which is typical of too many files in level 0. "+
+			"Consider compacting the keyspace or increasing rocksdb-max-background-jobs, so that compactions keep up with the writes.")
+	}
+	switch {
+	case !bloomsChecked && readAmp.MemtableHitRate < 1:
+		suggestions = append(suggestions, "Bloom filters are not used while reading files. "+
+			"Consider setting rocksdb-bloom-filter-bits-per-key (10 is typical), so that files without the key are skipped.")
+	case bloomsChecked && readAmp.BloomUsefulness < 0.1:
+		suggestions = append(suggestions, "Bloom filters rarely rule out any file, since most reads are for existing keys. "+
+			"Memory held by the bloom filters could be reclaimed by reducing rocksdb-bloom-filter-bits-per-key.")
+	}
+	if len(suggestions) == 0 {
+		suggestions = append(suggestions, "Reads are served efficiently, no tuning is needed.")
+	}
+	return suggestions
+}
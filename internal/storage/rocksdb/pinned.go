@@ -0,0 +1,112 @@
+package rocksdb
+
+// #include <stdlib.h>
+// #include "rocksdb/c.h"
+import "C"
+
+import (
+	"errors"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"github.com/flipkart-incubator/gorocksdb"
+)
+
+func noRelease() {}
+
+// GetPinned loads the value of the given key without copying it out of
+// RocksDB, when the value is held as is in the normal column family. Such
+// a value remains pinned in the memtable or the block cache until the
+// returned release function is invoked. Values that must be decoded, ie.,
+// those with TTL or encrypted ones, are copied as in Get instead.
+func (rdb *rocksDB) GetPinned(key []byte) ([]byte, uint64, func(), error) {
+	if _, present := rdb.opts.subjects.Of(key); present || rdb.opts.keyring != nil || storage.IsReservedKey(key) {
+		kvs, err := rdb.Get(key)
+		if err != nil || len(kvs) == 0 {
+			return nil, 0, noRelease, err
+		}
+		return kvs[0].Value, kvs[0].ExpireTS, noRelease, nil
+	}
+
+	defer rdb.opts.statsCli.Timing("rocksdb.pinned.get.latency.ms", time.Now())
+	sample := rdb.beginPerfSample(key)
+	defer sample.end(rdb, 1)
+	rdb.pinMu.RLock()
+	ps, err := rdb.getPinnedCF(rdb.normalCF, key)
+	if err != nil {
+		rdb.pinMu.RUnlock()
+		rdb.opts.statsCli.Incr("rocksdb.pinned.get.errors", 1)
+		return nil, 0, noRelease, err
+	}
+	if val := pinnedValue(ps); len(val) > 0 {
+		rdb.workload.recordReads(1, 1)
+		var once sync.Once
+		return val, 0, func() {
+			once.Do(func() {
+				C.rocksdb_pinnableslice_destroy(ps)
+				rdb.pinMu.RUnlock()
+			})
+		}, nil
+	}
+	C.rocksdb_pinnableslice_destroy(ps)
+
+	// Values with TTL are decoded, hence copied out right away
+	ps, err = rdb.getPinnedCF(rdb.ttlCF, key)
+	if err != nil {
+		rdb.pinMu.RUnlock()
+		rdb.opts.statsCli.Incr("rocksdb.pinned.get.errors", 1)
+		return nil, 0, noRelease, err
+	}
+	var kv *serverpb.KVPair
+	if ttlVal := pinnedValue(ps); len(ttlVal) > 0 {
+		kv, err = rdb.toKVPair(key, nil, append([]byte(nil), ttlVal...))
+	}
+	C.rocksdb_pinnableslice_destroy(ps)
+	rdb.pinMu.RUnlock()
+	if err != nil {
+		rdb.opts.statsCli.Incr("rocksdb.pinned.get.errors", 1)
+		return nil, 0, noRelease, err
+	}
+	if kv == nil {
+		rdb.workload.recordReads(1, 0)
+		return nil, 0, noRelease, nil
+	}
+	rdb.workload.recordReads(1, 1)
+	return kv.Value, kv.ExpireTS, noRelease, nil
+}
+
+// getPinnedCF looks up the given key in the given column family, pinning
+// its value if present. The returned handle must be destroyed once done.
+func (rdb *rocksDB) getPinnedCF(cf *gorocksdb.ColumnFamilyHandle, key []byte) (*C.rocksdb_pinnableslice_t, error) {
+	var cKey *C.char
+	if len(key) > 0 {
+		cKey = (*C.char)(unsafe.Pointer(&key[0]))
+	}
+	cDB := (*C.rocksdb_t)(rdb.db.UnsafeGetDB())
+	cCF := (*C.rocksdb_column_family_handle_t)(cf.UnsafeGetCFHandler())
+	cRO := (*C.rocksdb_readoptions_t)(rdb.opts.readOpts.UnsafeGetReadOptions())
+	var cErr *C.char
+	ps := C.rocksdb_get_pinned_cf(cDB, cRO, cCF, cKey, C.size_t(len(key)), &cErr)
+	if cErr != nil {
+		defer C.rocksdb_free(unsafe.Pointer(cErr))
+		return nil, errors.New(C.GoString(cErr))
+	}
+	return ps, nil
+}
+
+// pinnedValue refers to the value held by the given pinnable slice
+// without copying it. A nil slice is returned for absent keys.
+func pinnedValue(ps *C.rocksdb_pinnableslice_t) []byte {
+	if ps == nil {
+		return nil
+	}
+	var cLen C.size_t
+	cVal := C.rocksdb_pinnableslice_value(ps, &cLen)
+	if cVal == nil || cLen == 0 {
+		return nil
+	}
+	return (*[1 << 30]byte)(unsafe.Pointer(cVal))[:cLen:cLen]
+}
@@ -0,0 +1,115 @@
+package rocksdb
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"github.com/flipkart-incubator/gorocksdb"
+)
+
+// Number of changes prepared ahead of their commit per worker of the
+// apply pipeline, which bounds the write batches held in memory.
+const applyPipelineDepth = 4
+
+// WithApplyPipeline applies the replicated changes saved together through
+// a pipeline, wherein the given number of workers decode and validate the
+// changes into write batches while the preceding ones are committed. Since
+// the changes consume the same sequence numbers as on the master, they are
+// still committed one at a time in the order of their change numbers.
+func WithApplyPipeline(workers int) DBOption {
+	return func(opts *rocksDBOpts) {
+		if workers <= 0 {
+			panic(fmt.Errorf("apply pipeline workers: %d must be positive", workers))
+		}
+		opts.applyPipelineWorkers = workers
+	}
+}
+
+func (rdb *rocksDB) pipelinedApply(numChanges int) bool {
+	return rdb.opts.applyPipelineWorkers > 1 && numChanges > 1
+}
+
+// preparedChange is a change prepared by a worker of the apply pipeline,
+// ready once either its write batch or its validation error is set.
+type preparedChange struct {
+	wb    *gorocksdb.WriteBatch
+	err   error
+	ready chan struct{}
+}
+
+// savePipelinedChanges is same as SaveChanges, with the changes prepared
+// by the workers of the apply pipeline ahead of their commit.
+func (rdb *rocksDB) savePipelinedChanges(changes []*serverpb.ChangeRecord) (uint64, error) {
+	workers := rdb.opts.applyPipelineWorkers
+	window := workers * applyPipelineDepth
+	prepared := make([]preparedChange, len(changes))
+	for i := range prepared {
+		prepared[i].ready = make(chan struct{})
+	}
+
+	// Changes are handed to the workers in order, once a slot within the
+	// window is released by the commit of a preceding change
+	indices, slots, stop := make(chan int, window), make(chan struct{}, window), make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1 + workers)
+	go func() {
+		defer wg.Done()
+		defer close(indices)
+		for i := range changes {
+			select {
+			case slots <- struct{}{}:
+				indices <- i
+			case <-stop:
+				return
+			}
+		}
+	}()
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				select {
+				case <-stop:
+				default:
+					prepared[i].wb, prepared[i].err = rdb.prepareChange(changes[i])
+				}
+				close(prepared[i].ready)
+			}
+		}()
+	}
+
+	next := 0
+	defer func() {
+		close(stop)
+		wg.Wait()
+		// Changes prepared beyond a failed one are discarded
+		for _, pc := range prepared[next:] {
+			if pc.wb != nil {
+				pc.wb.Destroy()
+			}
+		}
+	}()
+	appldChngNum := uint64(0)
+	for ; next < len(changes); next++ {
+		chng, pc := changes[next], &prepared[next]
+		<-pc.ready
+		wb := pc.wb
+		pc.wb = nil
+		<-slots
+		if latestChngNum, applied := rdb.isChangeApplied(chng); applied {
+			if wb != nil {
+				wb.Destroy()
+			}
+			rdb.opts.statsCli.Incr("rocksdb.save.changes.skipped", 1)
+			appldChngNum = latestChngNum
+			continue
+		}
+		if err := rdb.commitChange(chng, wb, pc.err); err != nil {
+			return appldChngNum, err
+		}
+		appldChngNum = chng.ChangeNumber + uint64(chng.NumberOfTrxns) - 1
+	}
+	rdb.opts.statsCli.Incr("rocksdb.save.changes.pipelined", int64(len(changes)))
+	return appldChngNum, nil
+}
@@ -0,0 +1,78 @@
+package rocksdb
+
+// #include "rocksdb/c.h"
+import "C"
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"github.com/flipkart-incubator/gorocksdb"
+)
+
+// Write batches and read options are natively allocated, hence creating
+// and destroying them for every request adds up to a considerable cgo
+// overhead under high QPS. They are instead reused through the pools
+// below. Since a pool silently drops its idle objects during GC, their
+// native memory is released by finalizers. Iterators are not pooled as
+// a RocksDB iterator observes the keyspace as of its creation.
+
+// maxPooledBatchOps bounds the number of operations in a write batch that
+// is returned to the pool, so that the memory grown by a large batch is
+// not retained for the smaller ones that follow.
+const maxPooledBatchOps = 1024
+
+var writeBatchPool = sync.Pool{
+	New: func() interface{} {
+		wb := gorocksdb.NewWriteBatch()
+		runtime.SetFinalizer(wb, (*gorocksdb.WriteBatch).Destroy)
+		return wb
+	},
+}
+
+// getWriteBatch retrieves an empty write batch, which must be returned
+// using putWriteBatch instead of being destroyed.
+func getWriteBatch() *gorocksdb.WriteBatch {
+	return writeBatchPool.Get().(*gorocksdb.WriteBatch)
+}
+
+func putWriteBatch(wb *gorocksdb.WriteBatch) {
+	if wb.Count() > maxPooledBatchOps {
+		runtime.SetFinalizer(wb, nil)
+		wb.Destroy()
+		return
+	}
+	wb.Clear()
+	writeBatchPool.Put(wb)
+}
+
+var readOptionsPool = sync.Pool{
+	New: func() interface{} {
+		ro := gorocksdb.NewDefaultReadOptions()
+		runtime.SetFinalizer(ro, (*gorocksdb.ReadOptions).Destroy)
+		return ro
+	},
+}
+
+// toReadOptions maps the read options of a request onto RocksDB read
+// options, which must be returned using putReadOptions once done. Nil
+// options map onto the defaults of RocksDB.
+func toReadOptions(readOpts *serverpb.ReadOptions) *gorocksdb.ReadOptions {
+	ro := readOptionsPool.Get().(*gorocksdb.ReadOptions)
+	ro.SetFillCache(!readOpts.GetSkipBlockCacheFill())
+	ro.SetVerifyChecksums(!readOpts.GetSkipChecksums())
+	if readOpts.GetReadTier() == serverpb.ReadTier_BLOCK_CACHE_TIER {
+		ro.SetReadTier(gorocksdb.BlockCacheTier)
+	} else {
+		ro.SetReadTier(gorocksdb.ReadAllTier)
+	}
+	return ro
+}
+
+// putReadOptions returns the given read options to the pool after
+// dropping any snapshot set on them, which gorocksdb cannot unset.
+func putReadOptions(ro *gorocksdb.ReadOptions) {
+	C.rocksdb_readoptions_set_snapshot((*C.rocksdb_readoptions_t)(ro.UnsafeGetReadOptions()), nil)
+	readOptionsPool.Put(ro)
+}
@@ -0,0 +1,158 @@
+package rocksdb
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"github.com/flipkart-incubator/gorocksdb"
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/zap"
+)
+
+// ListQuarantinedRecords retrieves all the records of the quarantine
+// namespace, in the order of their sources and identifiers.
+func (rdb *rocksDB) ListQuarantinedRecords() ([]*serverpb.QuarantinedRecord, error) {
+	prefix := storage.QuarantinePrefix()
+	it := rdb.db.NewIteratorCF(rdb.opts.readOpts, rdb.normalCF)
+	defer it.Close()
+	var recs []*serverpb.QuarantinedRecord
+	for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+		rec := new(serverpb.QuarantinedRecord)
+		if err := proto.Unmarshal(toByteArray(it.Value()), rec); err != nil {
+			return nil, fmt.Errorf("unable to read quarantined record: %q, error: %v", toByteArray(it.Key()), err)
+		}
+		recs = append(recs, rec)
+	}
+	return recs, it.Err()
+}
+
+// putQuarantined adds the given record to the quarantine namespace
+// as part of the given write batch, the given number of times.
+func putQuarantined(wb *gorocksdb.WriteBatch, cf *gorocksdb.ColumnFamilyHandle, rec *serverpb.QuarantinedRecord, times int) error {
+	rec.QuarantinedAt = time.Now().Unix()
+	value, err := proto.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	qKey := storage.QuarantineKey(rec)
+	for i := 0; i < times; i++ {
+		wb.PutCF(cf, qKey, value)
+	}
+	return nil
+}
+
+// saveChange applies the given change replicated from the master. A
+// change that is malformed or is rejected by RocksDB as corrupt or
+// invalid is quarantined instead, so that a single bad change does not
+// halt the replication, while other failures such as those of the disk
+// or the checksum of the change are returned for the change to be
+// retried.
+func (rdb *rocksDB) saveChange(chng *serverpb.ChangeRecord) error {
+	wb, err := rdb.prepareChange(chng)
+	return rdb.commitChange(chng, wb, err)
+}
+
+// prepareChange validates the given replicated change and builds its write
+// batch, returning the validation error instead for malformed changes.
+func (rdb *rocksDB) prepareChange(chng *serverpb.ChangeRecord) (*gorocksdb.WriteBatch, error) {
+	if err := storage.VerifyChangeChecksum(chng); err != nil {
+		return nil, err
+	}
+	if err := validateChange(chng); err != nil {
+		return nil, err
+	}
+	// Not pooled, as the RocksDB C API builds a write batch
+	// from its serialised form only upon creation
+	return gorocksdb.WriteBatchFrom(rdb.filterChange(chng).SerialisedForm), nil
+}
+
+// commitChange commits the write batch prepared for the given change,
+// destroying it thereafter. Changes found malformed while preparing, as
+// indicated by the given error, or rejected by RocksDB are quarantined.
+// Changes not matching their checksum are corrupted in transit rather than
+// malformed at the source, hence are rejected for them to be fetched again.
+func (rdb *rocksDB) commitChange(chng *serverpb.ChangeRecord, wb *gorocksdb.WriteBatch, err error) error {
+	if errors.Is(err, storage.ErrChangeChecksumMismatch) {
+		rdb.opts.lgr.Error("Rejecting change replicated from master", zap.Uint64("ChangeNumber", chng.ChangeNumber), zap.Error(err))
+		rdb.opts.statsCli.Incr("rocksdb.replication.corrupt.changes", 1)
+		return err
+	}
+	if err == nil {
+		defer wb.Destroy()
+		if err = rdb.commit(rdb.writeOptions(serverpb.WALSync_DEFAULT_WAL_SYNC), wb, false); err == nil {
+			rdb.reloadLegalHolds(chng)
+			rdb.invalidateTenantKeys(chng)
+			rdb.redactDeletedSubjectKeys(chng)
+			return nil
+		}
+		if !isRejection(err) {
+			return err
+		}
+	}
+	return rdb.quarantineChange(chng, err)
+}
+
+// validateChange checks if the serialised form of the given change is
+// a well formed write batch holding the declared number of operations.
+func validateChange(chng *serverpb.ChangeRecord) error {
+	if len(chng.SerialisedForm) < 8+4 {
+		return errors.New("malformed change, too small for a write batch")
+	}
+	wbIter := NewWriteBatchIterator(chng.SerialisedForm)
+	numOps := 0
+	for wbIter.Next() {
+		// Only the data operations consume sequence numbers
+		switch wbIter.Record().Type {
+		case gorocksdb.WriteBatchLogDataRecord, gorocksdb.WriteBatchNoopRecord,
+			gorocksdb.WriteBatchBeginPrepareXIDRecord, gorocksdb.WriteBatchBeginPersistedPrepareXIDRecord,
+			gorocksdb.WriteBatchEndPrepareXIDRecord, gorocksdb.WriteBatchCommitXIDRecord, gorocksdb.WriteBatchRollbackXIDRecord:
+		default:
+			numOps++
+		}
+	}
+	if err := wbIter.Error(); err != nil {
+		return fmt.Errorf("malformed change: %v", err)
+	}
+	if numOps != int(chng.NumberOfTrxns) {
+		return fmt.Errorf("malformed change, holding %d operations instead of %d", numOps, chng.NumberOfTrxns)
+	}
+	return nil
+}
+
+// isRejection checks if the given error reported by RocksDB for a write
+// is due to the contents of the write rather than the state of the store.
+func isRejection(err error) bool {
+	msg := err.Error()
+	return strings.HasPrefix(msg, "Corruption") || strings.HasPrefix(msg, "Invalid argument")
+}
+
+// quarantineChange sets aside the given change in the quarantine
+// namespace. Since the change numbers of a slave are the sequence
+// numbers of its store, the quarantine record is written as many times
+// as the operations of the change, thereby consuming the same sequence
+// numbers as the change would have.
+func (rdb *rocksDB) quarantineChange(chng *serverpb.ChangeRecord, reason error) error {
+	rdb.opts.lgr.Error("Quarantining change replicated from master", zap.Uint64("ChangeNumber", chng.ChangeNumber), zap.Error(reason))
+	rdb.opts.statsCli.Incr("rocksdb.replication.quarantined.changes", 1)
+
+	numOps := int(chng.NumberOfTrxns)
+	if numOps < 1 {
+		numOps = 1
+	}
+	rec := &serverpb.QuarantinedRecord{
+		Source:       storage.QuarantineSourceReplication,
+		Reason:       reason.Error(),
+		Value:        chng.SerialisedForm,
+		ChangeNumber: chng.ChangeNumber,
+	}
+	wb := gorocksdb.NewWriteBatch()
+	defer wb.Destroy()
+	if err := putQuarantined(wb, rdb.normalCF, rec, numOps); err != nil {
+		return err
+	}
+	return rdb.commit(rdb.writeOptions(serverpb.WALSync_DEFAULT_WAL_SYNC), wb, false)
+}
@@ -0,0 +1,21 @@
+package rocksdb
+
+import (
+	"fmt"
+
+	"github.com/flipkart-incubator/gorocksdb"
+)
+
+// WithRateLimit caps the bandwidth at which the background flushes and
+// compactions write to disk at the given number of bytes per second, so
+// that compaction storms do not starve the foreground reads and writes
+// on a shared disk. The limiter is refilled every 100ms.
+func WithRateLimit(bytesPerSec int64) DBOption {
+	return func(opts *rocksDBOpts) {
+		if bytesPerSec <= 0 {
+			panic(fmt.Errorf("rate limit: %d bytes per second must be positive", bytesPerSec))
+		}
+		opts.rateLimiter = gorocksdb.NewRateLimiter(bytesPerSec, 100*1000, 10)
+		opts.rocksDBOpts.SetRateLimiter(opts.rateLimiter)
+	}
+}
@@ -0,0 +1,59 @@
+package rocksdb
+
+import (
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"github.com/flipkart-incubator/gorocksdb"
+)
+
+// GetWithOptions loads the values of the given keys using the given
+// read options. Reads restricted to the block cache fail when any of
+// the keys cannot be looked up without touching disk.
+func (rdb *rocksDB) GetWithOptions(readOpts *serverpb.ReadOptions, keys ...[]byte) ([]*serverpb.KVPair, error) {
+	if len(keys) == 1 {
+		ro := toReadOptions(readOpts)
+		defer putReadOptions(ro)
+		return rdb.getSingleKey(ro, keys[0])
+	}
+	return rdb.multiGet(readOpts, keys)
+}
+
+// IterateWithOptions iterates through the keyspace using the given
+// read options, which are returned to the pool once the iterator is
+// closed.
+func (rdb *rocksDB) IterateWithOptions(readOpts *serverpb.ReadOptions, iterOpts storage.IterationOptions) storage.Iterator {
+	ro := toReadOptions(readOpts)
+	return &optionedIter{rdb.iterate(ro, iterOpts), ro}
+}
+
+type optionedIter struct {
+	storage.Iterator
+	readOpts *gorocksdb.ReadOptions
+}
+
+func (oi *optionedIter) Close() error {
+	defer putReadOptions(oi.readOpts)
+	return oi.Iterator.Close()
+}
+
+// IterateSnapshot iterates through the keyspace as of a snapshot taken
+// now, using the given read options if any. Both the column families
+// holding the keys are read from the same snapshot, which is released
+// once the iterator is closed.
+func (rdb *rocksDB) IterateSnapshot(readOpts *serverpb.ReadOptions, iterOpts storage.IterationOptions) storage.Iterator {
+	snap := rdb.db.NewSnapshot()
+	ro := toReadOptions(readOpts)
+	ro.SetSnapshot(snap)
+	return &pinnedIter{optionedIter{rdb.iterate(ro, iterOpts), ro}, rdb.db, snap}
+}
+
+type pinnedIter struct {
+	optionedIter
+	db   *gorocksdb.DB
+	snap *gorocksdb.Snapshot
+}
+
+func (pi *pinnedIter) Close() error {
+	defer pi.db.ReleaseSnapshot(pi.snap)
+	return pi.optionedIter.Close()
+}
@@ -0,0 +1,38 @@
+package rocksdb
+
+import (
+	"errors"
+
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"github.com/flipkart-incubator/gorocksdb"
+)
+
+var errUnreservedRecordKey = errors.New("internal records must be held under the keyspace reserved by DKV")
+
+// LoadRecord loads the internal record held under the given reserved key
+// in the default column family, which is empty when absent.
+func (rdb *rocksDB) LoadRecord(key []byte) ([]byte, error) {
+	if !storage.IsReservedKey(key) {
+		return nil, errUnreservedRecordKey
+	}
+	val, err := rdb.db.GetCF(rdb.opts.readOpts, rdb.normalCF, key)
+	if err != nil {
+		return nil, err
+	}
+	defer val.Free()
+	return toByteArray(val), nil
+}
+
+// SaveRecord saves the given internal record under the given reserved key
+// in the default column family, hence it is replicated to the slaves
+// through the change log.
+func (rdb *rocksDB) SaveRecord(key, value []byte) error {
+	if !storage.IsReservedKey(key) {
+		return errUnreservedRecordKey
+	}
+	wb := gorocksdb.NewWriteBatch()
+	defer wb.Destroy()
+	wb.PutCF(rdb.normalCF, key, value)
+	return rdb.write(rdb.writeOptions(serverpb.WALSync_DEFAULT_WAL_SYNC), wb)
+}
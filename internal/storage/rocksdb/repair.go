@@ -0,0 +1,158 @@
+package rocksdb
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path"
+
+	"github.com/flipkart-incubator/gorocksdb"
+	"go.uber.org/zap"
+)
+
+// RepairReport captures the outcome of repairing a RocksDB store.
+type RepairReport struct {
+	// NumFilesVerified is the number of live SST files whose
+	// checksums have been verified after the repair.
+	NumFilesVerified int
+	// LostFiles are the files set aside by the repair into the `lost`
+	// folder, since they could not be made part of the repaired store.
+	LostFiles []string
+	// CorruptRanges are the key ranges of the live SST files which
+	// still fail checksum verification after the repair.
+	CorruptRanges []CorruptRange
+}
+
+// CorruptRange is a key range of a column family whose data cannot be
+// recovered. StartKey is the last key read before the failed one, or the
+// smallest key of the SST file if the failed key is the first of it.
+type CorruptRange struct {
+	ColumnFamily string
+	// Files are the live SST files spanning the failed key, one of which
+	// holds it. Since RocksDB does not report the column families of the
+	// live files, those of other column families with overlapping key
+	// ranges may be among them.
+	Files    []string
+	StartKey []byte
+	EndKey   []byte
+	Err      error
+}
+
+// Repair recovers as much data as possible from the RocksDB store in
+// the given folder, which must not be open by any other process, and
+// then verifies the checksums of all the data blocks of the repaired
+// store. The given options must include the merge operators with which
+// the store is opened, so that the merge operands can be read back.
+func Repair(dbFolder string, dbOpts ...DBOption) (*RepairReport, error) {
+	opts := newOptions(dbFolder)
+	defer opts.destroy()
+	for _, dbOpt := range dbOpts {
+		dbOpt(opts)
+	}
+	rdbOpts := opts.rocksDBOpts
+	rdbOpts.SetCreateIfMissing(false)
+	if len(opts.mergeOps) > 0 {
+		rdbOpts.SetMergeOperator(opts.mergeOperator())
+	}
+
+	opts.lgr.Info("Repairing RocksDB store", zap.String("DBFolder", dbFolder))
+	if err := gorocksdb.RepairDb(dbFolder, rdbOpts); err != nil {
+		return nil, err
+	}
+	report := &RepairReport{}
+	lostFiles, err := listLostFiles(dbFolder)
+	if err != nil {
+		return nil, err
+	}
+	report.LostFiles = lostFiles
+
+	cfOpts := make([]*gorocksdb.Options, len(opts.cfNames))
+	for i := range cfOpts {
+		cfOpts[i] = rdbOpts
+	}
+	db, cfhs, err := gorocksdb.OpenDbForReadOnlyColumnFamilies(rdbOpts, dbFolder, opts.cfNames, cfOpts, false)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	verifyOpts := gorocksdb.NewDefaultReadOptions()
+	defer verifyOpts.Destroy()
+	verifyOpts.SetVerifyChecksums(true)
+	verifyOpts.SetFillCache(false)
+
+	liveFiles := db.GetLiveFilesMetaData()
+	report.NumFilesVerified = len(liveFiles)
+	for i, cfh := range cfhs {
+		// A full scan is cheap enough for a healthy column family,
+		// while the corrupt ranges are located one SST file at a time,
+		// by the key at which reading through the file range fails
+		if _, err := verifyRange(db, verifyOpts, cfh, nil, nil); err == nil {
+			continue
+		}
+		corruptRanges := make(map[string]*CorruptRange)
+		for _, lf := range liveFiles {
+			lastKey, err := verifyRange(db, verifyOpts, cfh, lf.SmallestKey, lf.LargestKey)
+			if err == nil {
+				continue
+			}
+			if lastKey == nil {
+				lastKey = lf.SmallestKey
+			}
+			cr, present := corruptRanges[string(lastKey)]
+			if !present {
+				cr = &CorruptRange{ColumnFamily: opts.cfNames[i], StartKey: lastKey, EndKey: lf.LargestKey, Err: err}
+				corruptRanges[string(lastKey)] = cr
+			} else if bytes.Compare(lf.LargestKey, cr.EndKey) < 0 {
+				cr.EndKey = lf.LargestKey
+			}
+			cr.Files = append(cr.Files, lf.Name)
+		}
+		for _, cr := range corruptRanges {
+			report.CorruptRanges = append(report.CorruptRanges, *cr)
+		}
+	}
+	opts.lgr.Info("Completed repair of RocksDB store", zap.Int("NumFilesVerified", report.NumFilesVerified),
+		zap.Int("NumLostFiles", len(report.LostFiles)), zap.Int("NumCorruptRanges", len(report.CorruptRanges)))
+	return report, nil
+}
+
+// verifyRange reads through the given inclusive key range of the given
+// column family, thereby verifying the checksums of its data blocks.
+// Nil bounds denote the start or the end of the keyspace. Returns the
+// last key read, which precedes the failed one in case of an error.
+func verifyRange(db *gorocksdb.DB, verifyOpts *gorocksdb.ReadOptions, cfh *gorocksdb.ColumnFamilyHandle, startKey, endKey []byte) ([]byte, error) {
+	it := db.NewIteratorCF(verifyOpts, cfh)
+	defer it.Close()
+
+	if startKey == nil {
+		it.SeekToFirst()
+	} else {
+		it.Seek(startKey)
+	}
+	var lastKey []byte
+	for ; it.Valid(); it.Next() {
+		key := toByteArray(it.Key())
+		if endKey != nil && bytes.Compare(key, endKey) > 0 {
+			break
+		}
+		lastKey = key
+	}
+	return lastKey, it.Err()
+}
+
+func listLostFiles(dbFolder string) ([]string, error) {
+	lostDir := path.Join(dbFolder, "lost")
+	files, err := ioutil.ReadDir(lostDir)
+	switch {
+	case os.IsNotExist(err):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	lostFiles := make([]string, len(files))
+	for i, file := range files {
+		lostFiles[i] = path.Join(lostDir, file.Name())
+	}
+	return lostFiles, nil
+}
@@ -0,0 +1,252 @@
+package rocksdb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"github.com/flipkart-incubator/gorocksdb"
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/zap"
+)
+
+// WithScrubbing enables the background scrubber, which verifies the
+// checksums and the decodability of upto the given number of entries
+// per second, thereby passing over the keyspace of a large store once
+// every few days without competing with the foreground workload. Corrupt
+// entries are reported to the handler set using OnCorruptEntry for them
+// to be moved aside as quarantine records if quarantine is set, which
+// must only be done on the nodes whose writes are replicated. Otherwise
+// they are only reported.
+func WithScrubbing(keysPerSec int, quarantine bool) DBOption {
+	return func(opts *rocksDBOpts) {
+		if keysPerSec <= 0 {
+			panic(fmt.Errorf("scrubbing rate: %d keys per second must be positive", keysPerSec))
+		}
+		opts.scrubKeysPerSec, opts.scrubQuarantine = keysPerSec, quarantine
+	}
+}
+
+const scrubStepInterval = time.Second
+
+type scrubber struct {
+	rdb        *rocksDB
+	keysPerSec int
+	quarantine bool
+	stop       chan struct{}
+	done       chan struct{}
+
+	// Position of the scrubber within the current pass
+	cfIdx  int
+	cursor []byte
+
+	// Summary of the current pass
+	passStart                              time.Time
+	numScanned, numCorrupt, numQuarantined uint64
+}
+
+func (rdb *rocksDB) startScrubber() {
+	if rdb.opts.scrubKeysPerSec <= 0 {
+		return
+	}
+	sc := &scrubber{
+		rdb:        rdb,
+		keysPerSec: rdb.opts.scrubKeysPerSec,
+		quarantine: rdb.opts.scrubQuarantine,
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+		passStart:  time.Now(),
+	}
+	rdb.scrubber = sc
+	go sc.run()
+}
+
+// stopScrubber stops the scrubber, if any, and waits for it to
+// return, so that the store can be closed safely.
+func (rdb *rocksDB) stopScrubber() {
+	if sc := rdb.scrubber; sc != nil {
+		rdb.scrubber = nil
+		close(sc.stop)
+		<-sc.done
+	}
+}
+
+func (sc *scrubber) run() {
+	defer close(sc.done)
+	ticker := time.NewTicker(scrubStepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			sc.step()
+		case <-sc.stop:
+			return
+		}
+	}
+}
+
+// step scrubs the next batch of entries from the current position.
+func (sc *scrubber) step() {
+	rdb := sc.rdb
+	cfs := []*gorocksdb.ColumnFamilyHandle{rdb.normalCF, rdb.ttlCF}
+	cf := cfs[sc.cfIdx]
+
+	// Blocks read for scrubbing must not evict the hot blocks
+	readOpts := gorocksdb.NewDefaultReadOptions()
+	defer readOpts.Destroy()
+	readOpts.SetVerifyChecksums(true)
+	readOpts.SetFillCache(false)
+
+	it := rdb.db.NewIteratorCF(readOpts, cf)
+	defer it.Close()
+	if sc.cursor == nil {
+		it.SeekToFirst()
+	} else {
+		it.Seek(sc.cursor)
+	}
+	for n := 0; it.Valid() && n < sc.keysPerSec; it.Next() {
+		key, value := toByteArray(it.Key()), toByteArray(it.Value())
+		n++
+		sc.numScanned++
+		if err := rdb.checkDecodable(cf == rdb.ttlCF, key, value); err != nil {
+			sc.handleCorrupt(cf, key, value, err)
+		}
+	}
+	if err := it.Err(); err != nil {
+		// Corrupt blocks cannot be iterated past, hence the rest of
+		// the column family is left for the offline repair
+		rdb.opts.lgr.Error("Scrubber found corrupt data blocks", zap.Int("ColumnFamily", sc.cfIdx),
+			zap.ByteString("AfterKey", sc.cursor), zap.Error(err))
+		rdb.opts.statsCli.Incr("rocksdb.scrub.corrupt.blocks", 1)
+		sc.numCorrupt++
+		sc.nextColumnFamily(len(cfs))
+		return
+	}
+	if it.Valid() {
+		sc.cursor = toByteArray(it.Key())
+	} else {
+		sc.nextColumnFamily(len(cfs))
+	}
+}
+
+func (sc *scrubber) nextColumnFamily(numCFs int) {
+	sc.cfIdx, sc.cursor = sc.cfIdx+1, nil
+	if sc.cfIdx < numCFs {
+		return
+	}
+	sc.rdb.opts.lgr.Info("Scrubber completed a pass over the keyspace", zap.Duration("Duration", time.Since(sc.passStart)),
+		zap.Uint64("NumScanned", sc.numScanned), zap.Uint64("NumCorrupt", sc.numCorrupt), zap.Uint64("NumQuarantined", sc.numQuarantined))
+	sc.rdb.opts.statsCli.Gauge("rocksdb.scrub.pass.corrupt", int64(sc.numCorrupt))
+	sc.cfIdx, sc.passStart = 0, time.Now()
+	sc.numScanned, sc.numCorrupt, sc.numQuarantined = 0, 0, 0
+}
+
+func (sc *scrubber) handleCorrupt(cf *gorocksdb.ColumnFamilyHandle, key, value []byte, reason error) {
+	rdb := sc.rdb
+	rdb.opts.lgr.Warn("Scrubber found corrupt entry", zap.ByteString("Key", key), zap.Error(reason))
+	rdb.opts.statsCli.Incr("rocksdb.scrub.corrupt.entries", 1)
+	sc.numCorrupt++
+	if !sc.quarantine || rdb.UnderLegalHold(key) {
+		return
+	}
+	rdb.corruptHndlrMu.Lock()
+	hndlr := rdb.corruptHndlr
+	rdb.corruptHndlrMu.Unlock()
+	if hndlr == nil {
+		return
+	}
+	// Keys overwritten since being scrubbed are no longer corrupt
+	switch unchanged, err := rdb.entryUnchanged(cf, key, value); {
+	case err != nil:
+		rdb.opts.lgr.Error("Unable to quarantine corrupt entry", zap.ByteString("Key", key), zap.Error(err))
+		return
+	case !unchanged:
+		return
+	}
+	rec := &serverpb.QuarantinedRecord{Source: storage.QuarantineSourceScrubber, Reason: reason.Error(), Key: key, Value: value}
+	if err := hndlr(rec); err != nil {
+		rdb.opts.lgr.Error("Unable to quarantine corrupt entry", zap.ByteString("Key", key), zap.Error(err))
+		return
+	}
+	rdb.opts.statsCli.Incr("rocksdb.scrub.quarantined.entries", 1)
+	sc.numQuarantined++
+}
+
+// checkDecodable checks if the given raw value of the given key can be
+// decoded, which is not the case for a corrupt TTL record or for a value
+// encrypted at rest that cannot be opened. Values of erased subjects are
+// not corrupt, hence are not decrypted.
+func (rdb *rocksDB) checkDecodable(ttl bool, key, value []byte) error {
+	if storage.IsReservedKey(key) {
+		return nil
+	}
+	if ttl {
+		ttlRow, err := parseTTLMsgPackData(value)
+		if err != nil {
+			return err
+		}
+		value = ttlRow.Data
+	}
+	if len(value) == 0 {
+		return nil
+	}
+	_, _, err := rdb.openValue(key, value)
+	return err
+}
+
+// entryUnchanged determines if the given key still holds the given value
+// in the given column family.
+func (rdb *rocksDB) entryUnchanged(cf *gorocksdb.ColumnFamilyHandle, key, value []byte) (bool, error) {
+	current, err := rdb.db.GetCF(rdb.opts.readOpts, cf, key)
+	if err != nil {
+		return false, err
+	}
+	defer current.Free()
+	return bytes.Equal(current.Data(), value), nil
+}
+
+// OnCorruptEntry sets the handler to which the scrubber reports the
+// corrupt entries for quarantining.
+func (rdb *rocksDB) OnCorruptEntry(hndlr func(*serverpb.QuarantinedRecord) error) {
+	rdb.corruptHndlrMu.Lock()
+	defer rdb.corruptHndlrMu.Unlock()
+	rdb.corruptHndlr = hndlr
+}
+
+// Quarantine moves the entry of the key of the given record aside into the
+// quarantine namespace along with the reason of the record. The value of
+// the entry held by this store is preserved, rather than that of the
+// record, since the replicas applying the same quarantine may hold values
+// of their own for a key corrupted on only one of them.
+func (rdb *rocksDB) Quarantine(rec *serverpb.QuarantinedRecord) (bool, error) {
+	if storage.IsReservedKey(rec.Key) {
+		return false, errors.New("internal records cannot be quarantined")
+	}
+	for _, cf := range []*gorocksdb.ColumnFamilyHandle{rdb.normalCF, rdb.ttlCF} {
+		value, err := rdb.db.GetCF(rdb.opts.readOpts, cf, rec.Key)
+		if err != nil {
+			return false, err
+		}
+		present := value.Size() > 0
+		qRec := proto.Clone(rec).(*serverpb.QuarantinedRecord)
+		qRec.Value = toByteArray(value)
+		value.Free()
+		if !present {
+			continue
+		}
+		wb := gorocksdb.NewWriteBatch()
+		defer wb.Destroy()
+		if err = putQuarantined(wb, rdb.normalCF, qRec, 1); err != nil {
+			return false, err
+		}
+		wb.DeleteCF(cf, rec.Key)
+		if err = rdb.write(rdb.writeOptions(serverpb.WALSync_DEFAULT_WAL_SYNC), wb); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	return false, nil
+}
@@ -0,0 +1,333 @@
+package rocksdb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/dkv/internal/storage/changelog"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"github.com/flipkart-incubator/gorocksdb"
+	"go.uber.org/zap"
+)
+
+var (
+	errReservedKey           = errors.New("given key belongs to the keyspace reserved by DKV")
+	errCASOnEncryptedSubject = errors.New("compare and set is not supported on keys of encrypted subjects")
+	errUnreadableValue       = errors.New("existing value of the given key cannot be decrypted")
+)
+
+// WithSubjectEncryption enables crypto-shredding for the keys having any
+// of the given prefixes. Values of such keys are encrypted using a key
+// per subject, where the subject is identified by the prefix followed by
+// the remainder of the key up to the given delimiter.
+func WithSubjectEncryption(prefixes []string, delimiter string) DBOption {
+	return func(opts *rocksDBOpts) {
+		opts.subjects = storage.NewSubjects(prefixes, delimiter)
+	}
+}
+
+// loadSubjectKey retrieves the encryption key of the given subject. Always
+// reads the live keyspace so that erased subjects are not readable even
+// from published snapshots. Returns nil if the subject has no key.
+func (rdb *rocksDB) loadSubjectKey(subject []byte) ([]byte, error) {
+	val, err := rdb.db.GetCF(rdb.opts.readOpts, rdb.normalCF, storage.SubjectKeyRecord(subject))
+	if err != nil {
+		return nil, err
+	}
+	defer val.Free()
+	if val.Size() == 0 {
+		return nil, nil
+	}
+	key, ok, err := rdb.openValue(storage.SubjectKeyRecord(subject), toByteArray(val))
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errUnreadableValue
+	}
+	return key, nil
+}
+
+// subjectKeyForWrite retrieves the encryption key of the given subject,
+// creating one if it does not exist yet.
+func (rdb *rocksDB) subjectKeyForWrite(subject []byte) ([]byte, error) {
+	if key, err := rdb.loadSubjectKey(subject); err != nil || key != nil {
+		return key, err
+	}
+
+	rdb.subjKeyMu.Lock()
+	defer rdb.subjKeyMu.Unlock()
+	if key, err := rdb.loadSubjectKey(subject); err != nil || key != nil {
+		return key, err
+	}
+	key, err := storage.NewSubjectKey()
+	if err != nil {
+		return nil, err
+	}
+	// Subject keys are themselves encrypted at rest, if enabled
+	sealedKey, err := rdb.sealValue(storage.SubjectKeyRecord(subject), key)
+	if err != nil {
+		return nil, err
+	}
+	wb := gorocksdb.NewWriteBatch()
+	defer wb.Destroy()
+	wb.PutCF(rdb.normalCF, storage.SubjectKeyRecord(subject), sealedKey)
+	if err = rdb.write(rdb.opts.syncWriteOpts, wb); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// encryptValue encrypts the value of the given key if it belongs to a
+// subject, followed by the encryption at rest if enabled.
+func (rdb *rocksDB) encryptValue(key, value []byte) ([]byte, error) {
+	subject, present := rdb.opts.subjects.Of(key)
+	if !present {
+		return rdb.sealValue(key, value)
+	}
+	subjKey, err := rdb.subjectKeyForWrite(subject)
+	if err != nil {
+		return nil, err
+	}
+	encValue, err := storage.EncryptValue(subjKey, value)
+	if err != nil {
+		return nil, err
+	}
+	return rdb.sealValue(key, encValue)
+}
+
+// decryptValue decrypts the value of the given key if it belongs to a
+// subject. Returns false if the value is absent, which is the case once
+// the subject or the tenant owning the key is erased, while any other
+// failure to decrypt the value is returned as an error.
+func (rdb *rocksDB) decryptValue(key, value []byte) ([]byte, bool, error) {
+	value, ok, err := rdb.openValue(key, value)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	subject, present := rdb.opts.subjects.Of(key)
+	if !present {
+		return value, true, nil
+	}
+	subjKey, err := rdb.loadSubjectKey(subject)
+	if err != nil || subjKey == nil {
+		return nil, false, err
+	}
+	decValue, err := storage.DecryptValue(subjKey, value)
+	if err != nil {
+		rdb.opts.lgr.Warn("RocksDB::decryptValue Failed to decrypt value",
+			zap.String("Key", string(key)), zap.Error(err))
+		rdb.opts.statsCli.Incr("rocksdb.decrypt.errors", 1)
+		return nil, false, err
+	}
+	return decValue, true, nil
+}
+
+// isErased checks if the given key belongs to a subject or a tenant
+// whose encryption keys no longer exist.
+func (rdb *rocksDB) isErased(key []byte) bool {
+	return rdb.isSubjectErased(key) || rdb.isTenantErased(key)
+}
+
+// isSubjectErased checks if the given key belongs to a subject whose
+// encryption key no longer exists.
+func (rdb *rocksDB) isSubjectErased(key []byte) bool {
+	subject, present := rdb.opts.subjects.Of(key)
+	if !present {
+		return false
+	}
+	subjKey, err := rdb.loadSubjectKey(subject)
+	return err != nil || subjKey == nil
+}
+
+// DeleteSubject destroys the encryption key of the given subject. Since
+// the key is deleted like any other key, its deletion is propagated to
+// the replicas through the regular change propagation. The key is also
+// redacted from the change log segments, as well as from the changes
+// loaded thereafter, including those loaded from the WAL. However the
+// key continues to remain in:
+//   - the WAL files of the master and the replicas, until they are purged
+//     as per the WAL retention
+//   - the SST files pinned by the published snapshots and checkpoints,
+//     until they are released
+//   - the backups taken before the deletion
+//   - the changes already loaded by the replicas and the consumers of the
+//     changes, such as the change sinks, before the deletion
+func (rdb *rocksDB) DeleteSubject(subject []byte) error {
+	if !rdb.opts.subjects.IsSubject(subject) {
+		return fmt.Errorf("given subject: %s is not configured for encryption", subject)
+	}
+
+	rdb.subjKeyMu.Lock()
+	defer rdb.subjKeyMu.Unlock()
+	subjKeyRec := storage.SubjectKeyRecord(subject)
+	wb := gorocksdb.NewWriteBatch()
+	defer wb.Destroy()
+	wb.DeleteCF(rdb.normalCF, subjKeyRec)
+	if err := rdb.write(rdb.opts.syncWriteOpts, wb); err != nil {
+		rdb.opts.statsCli.Incr("rocksdb.delete.subject.errors", 1)
+		return err
+	}
+	// Compact away any older versions of the encryption key
+	if err := rdb.CompactRange(subjKeyRec); err != nil {
+		return err
+	}
+	// No new key of the subject can be committed meanwhile
+	if err := rdb.redactSubjectKey(subject, rdb.db.GetLatestSequenceNumber()); err != nil {
+		return err
+	}
+	rdb.opts.lgr.Info("Deleted encryption key of subject", zap.String("Subject", string(subject)))
+	return nil
+}
+
+// redactSubjectKey overwrites with zeroes the encryption keys of the given
+// subject that are recorded in the change log by the changes preceding
+// the given change number.
+func (rdb *rocksDB) redactSubjectKey(subject []byte, changeNumber uint64) error {
+	if rdb.changeLog == nil {
+		return nil
+	}
+	subjKeyRec := storage.SubjectKeyRecord(subject)
+	err := rdb.changeLog.Rewrite(func(entry *changelog.Entry) bool {
+		if entry.ChangeNumber >= changeNumber || !bytes.Contains(entry.Data, subjKeyRec) {
+			return false
+		}
+		redacted := false
+		// Values of the records are slices of the data of the entry
+		wbIter := NewWriteBatchIterator(entry.Data)
+		for wbIter.Next() {
+			if wbr := wbIter.Record(); isPutRecord(wbr) && bytes.Equal(wbr.Key, subjKeyRec) {
+				for i := range wbr.Value {
+					wbr.Value[i] = 0
+				}
+				redacted = true
+			}
+		}
+		return redacted
+	})
+	if err != nil {
+		rdb.opts.statsCli.Incr("rocksdb.changelog.redact.errors", 1)
+		rdb.opts.lgr.Error("Unable to redact encryption key of subject from change log",
+			zap.String("Subject", string(subject)), zap.Error(err))
+	}
+	return err
+}
+
+// redactDeletedSubjectKeys redacts from the change log the encryption keys
+// of the subjects deleted by the given change replicated from the master.
+// Since the change is already applied, failures are only logged.
+func (rdb *rocksDB) redactDeletedSubjectKeys(chng *serverpb.ChangeRecord) {
+	if rdb.changeLog == nil || !bytes.Contains(chng.SerialisedForm, storage.SubjectKeyRecordPrefix()) {
+		return
+	}
+	wbIter := NewWriteBatchIterator(chng.SerialisedForm)
+	for wbIter.Next() {
+		wbr := wbIter.Record()
+		switch wbr.Type {
+		case gorocksdb.WriteBatchDeletionRecord, gorocksdb.WriteBatchCFDeletionRecord:
+			if subject, ok := storage.SubjectOfKeyRecord(wbr.Key); ok {
+				if err := rdb.redactSubjectKey(subject, chng.ChangeNumber); err != nil {
+					return
+				}
+			}
+		}
+	}
+}
+
+// redactErasedSubjectKeys rewrites the given serialised change, which is
+// about to be propagated, for it to carry empty keys in place of the
+// encryption keys of the subjects erased since. Replicas treat subjects
+// having empty keys as erased, until they apply the deletions of those
+// keys. The change is returned as is when it carries no such keys.
+func (rdb *rocksDB) redactErasedSubjectKeys(data []byte) []byte {
+	if rdb.opts.subjects == nil || !bytes.Contains(data, storage.SubjectKeyRecordPrefix()) {
+		return data
+	}
+	var erased []bool
+	numErased := 0
+	wbIter := NewWriteBatchIterator(data)
+	for wbIter.Next() {
+		wbr := wbIter.Record()
+		isErased := isPutRecord(wbr) && rdb.isErasedSubjectKey(wbr.Key, wbr.Value)
+		if isErased {
+			numErased++
+		}
+		erased = append(erased, isErased)
+	}
+	if numErased == 0 {
+		return data
+	}
+	rdb.opts.statsCli.Incr("rocksdb.changes.redacted.subject.keys", int64(numErased))
+
+	wb := gorocksdb.NewWriteBatch()
+	defer wb.Destroy()
+	cfs := []*gorocksdb.ColumnFamilyHandle{rdb.normalCF, rdb.ttlCF, rdb.versionsCF}
+	wbIter = NewWriteBatchIterator(data)
+	for i := 0; wbIter.Next(); i++ {
+		wbr := wbIter.Record()
+		switch wbr.Type {
+		case gorocksdb.WriteBatchLogDataRecord, gorocksdb.WriteBatchNoopRecord,
+			gorocksdb.WriteBatchBeginPrepareXIDRecord, gorocksdb.WriteBatchBeginPersistedPrepareXIDRecord,
+			gorocksdb.WriteBatchEndPrepareXIDRecord, gorocksdb.WriteBatchCommitXIDRecord, gorocksdb.WriteBatchRollbackXIDRecord:
+			// Consume no sequence numbers, hence are dropped
+		case gorocksdb.WriteBatchValueRecord, gorocksdb.WriteBatchCFValueRecord:
+			if erased[i] {
+				wb.PutCF(cfs[wbr.CF], wbr.Key, nil)
+			} else {
+				wb.PutCF(cfs[wbr.CF], wbr.Key, wbr.Value)
+			}
+		case gorocksdb.WriteBatchDeletionRecord, gorocksdb.WriteBatchCFDeletionRecord:
+			wb.DeleteCF(cfs[wbr.CF], wbr.Key)
+		case gorocksdb.WriteBatchMergeRecord, gorocksdb.WriteBatchCFMergeRecord:
+			wb.MergeCF(cfs[wbr.CF], wbr.Key, wbr.Value)
+		default:
+			// Such records can not be rewritten, hence the erased
+			// keys are overwritten with zeroes instead
+			return zeroErasedSubjectKeys(data, erased)
+		}
+	}
+	redacted := wb.Data()
+	return byteArrayCopy(redacted, len(redacted))
+}
+
+// zeroErasedSubjectKeys overwrites with zeroes the values of the records
+// of the given serialised change, that are marked as erased, in a copy.
+func zeroErasedSubjectKeys(data []byte, erased []bool) []byte {
+	data = byteArrayCopy(data, len(data))
+	wbIter := NewWriteBatchIterator(data)
+	for i := 0; wbIter.Next(); i++ {
+		if erased[i] {
+			val := wbIter.Record().Value
+			for j := range val {
+				val[j] = 0
+			}
+		}
+	}
+	return data
+}
+
+// isErasedSubjectKey checks if the given value put for the given key is
+// the encryption key of a subject, that has since been erased. That is
+// the case when the subject has no key or has another key created after
+// the erasure.
+func (rdb *rocksDB) isErasedSubjectKey(key, value []byte) bool {
+	subject, ok := storage.SubjectOfKeyRecord(key)
+	if !ok || len(value) == 0 {
+		return false
+	}
+	curKey, err := rdb.loadSubjectKey(subject)
+	if err != nil {
+		return false
+	}
+	if curKey == nil {
+		return true
+	}
+	subjKey, ok, err := rdb.openValue(key, value)
+	return err == nil && ok && !bytes.Equal(subjKey, curKey)
+}
+
+func isPutRecord(wbr *gorocksdb.WriteBatchRecord) bool {
+	return wbr.Type == gorocksdb.WriteBatchValueRecord || wbr.Type == gorocksdb.WriteBatchCFValueRecord
+}
@@ -0,0 +1,153 @@
+package rocksdb
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"github.com/flipkart-incubator/gorocksdb"
+	"go.uber.org/zap"
+)
+
+// publishedSnapshot is released once it is unpublished and all the views
+// and the iterators reading it are closed, tracked by its reference count.
+// Publishing holds a reference until the snapshot is unpublished.
+type publishedSnapshot struct {
+	rdb       *rocksDB
+	namespace []byte
+	snap      *gorocksdb.Snapshot
+	readOpts  *gorocksdb.ReadOptions
+	refs      int32
+}
+
+func (ps *publishedSnapshot) ref() {
+	atomic.AddInt32(&ps.refs, 1)
+}
+
+func (ps *publishedSnapshot) unref() {
+	if atomic.AddInt32(&ps.refs, -1) == 0 {
+		ps.release()
+	}
+}
+
+func (ps *publishedSnapshot) Get(keys ...[]byte) ([]*serverpb.KVPair, error) {
+	if err := storage.CheckNamespaceKeys(ps.namespace, keys...); err != nil {
+		return nil, err
+	}
+	switch numKeys := len(keys); {
+	case numKeys == 1:
+		return ps.rdb.getSingleKey(ps.readOpts, keys[0])
+	default:
+		return ps.rdb.getSnapshotKeys(ps.readOpts, keys)
+	}
+}
+
+// Iterate iterates through the snapshot, which is held until the returned
+// iterator is closed, even beyond the view the iterator is created from.
+func (ps *publishedSnapshot) Iterate(iterOpts storage.IterationOptions) (storage.Iterator, error) {
+	nsIterOpts, err := storage.NamespaceIterationOptions(ps.namespace, iterOpts)
+	if err != nil {
+		return nil, err
+	}
+	ps.ref()
+	return &snapshotIterator{Iterator: ps.rdb.iterate(ps.readOpts, nsIterOpts), ps: ps}, nil
+}
+
+type snapshotIterator struct {
+	storage.Iterator
+	ps   *publishedSnapshot
+	once sync.Once
+}
+
+func (si *snapshotIterator) Close() error {
+	err := si.Iterator.Close()
+	si.once.Do(si.ps.unref)
+	return err
+}
+
+// snapshotView is a view of a published snapshot, holding it until closed.
+type snapshotView struct {
+	*publishedSnapshot
+	once sync.Once
+}
+
+func (sv *snapshotView) Close() error {
+	sv.once.Do(sv.unref)
+	return nil
+}
+
+func (ps *publishedSnapshot) release() {
+	ps.readOpts.Destroy()
+	ps.rdb.db.ReleaseSnapshot(ps.snap)
+}
+
+func (rdb *rocksDB) PublishSnapshot(namespace, name string) error {
+	if err := storage.ValidateSnapshotName(name); err != nil {
+		return err
+	}
+	ref := storage.SnapshotRef(namespace, name)
+
+	rdb.snapsMu.Lock()
+	defer rdb.snapsMu.Unlock()
+	if _, present := rdb.snaps[ref]; present {
+		return fmt.Errorf("snapshot: %s is already published", ref)
+	}
+
+	readOpts := gorocksdb.NewDefaultReadOptions()
+	snap := rdb.db.NewSnapshot()
+	readOpts.SetSnapshot(snap)
+	rdb.snaps[ref] = &publishedSnapshot{rdb, []byte(namespace), snap, readOpts, 1}
+	rdb.opts.lgr.Info("Published snapshot", zap.String("Snapshot", ref))
+	return nil
+}
+
+func (rdb *rocksDB) UnpublishSnapshot(ref string) error {
+	rdb.snapsMu.Lock()
+	defer rdb.snapsMu.Unlock()
+	ps, present := rdb.snaps[ref]
+	if !present {
+		return fmt.Errorf("snapshot: %s is not published", ref)
+	}
+	delete(rdb.snaps, ref)
+	ps.unref()
+	rdb.opts.lgr.Info("Unpublished snapshot", zap.String("Snapshot", ref))
+	return nil
+}
+
+func (rdb *rocksDB) ListSnapshots() []string {
+	rdb.snapsMu.RLock()
+	defer rdb.snapsMu.RUnlock()
+	refs := make([]string, 0, len(rdb.snaps))
+	for ref := range rdb.snaps {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	return refs
+}
+
+func (rdb *rocksDB) SnapshotView(ref string) (storage.SnapshotView, error) {
+	if _, _, err := storage.ParseSnapshotRef(ref); err != nil {
+		return nil, err
+	}
+	rdb.snapsMu.RLock()
+	defer rdb.snapsMu.RUnlock()
+	// Views are only created while the snapshot is published, hence it
+	// is never referenced again once released
+	if ps, present := rdb.snaps[ref]; present {
+		ps.ref()
+		return &snapshotView{publishedSnapshot: ps}, nil
+	}
+	return nil, fmt.Errorf("snapshot: %s is not published", ref)
+}
+
+func (rdb *rocksDB) releaseSnapshots() {
+	rdb.snapsMu.Lock()
+	defer rdb.snapsMu.Unlock()
+	for ref, ps := range rdb.snaps {
+		ps.unref()
+		delete(rdb.snaps, ref)
+	}
+}
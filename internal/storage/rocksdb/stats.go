@@ -0,0 +1,122 @@
+package rocksdb
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/gorocksdb"
+)
+
+// Stats retrieves the statistics of RocksDB, summed across all the column
+// families. The block cache hit rate is computed from the RocksDB
+// statistics accumulated since the store was opened.
+func (rdb *rocksDB) Stats() (*storage.EngineStats, error) {
+	es := &storage.EngineStats{}
+	for _, cf := range []*gorocksdb.ColumnFamilyHandle{rdb.normalCF, rdb.ttlCF, rdb.versionsCF} {
+		memtableSize, err := rdb.uint64Property("rocksdb.cur-size-all-mem-tables", cf)
+		if err != nil {
+			return nil, err
+		}
+		pendingBytes, err := rdb.uint64Property("rocksdb.estimate-pending-compaction-bytes", cf)
+		if err != nil {
+			return nil, err
+		}
+		es.MemtableSizeBytes += memtableSize
+		es.PendingCompactionBytes += pendingBytes
+
+		// The property is absent for the levels beyond the configured
+		// number of levels
+		for level := 0; ; level++ {
+			propName := fmt.Sprintf("rocksdb.num-files-at-level%d", level)
+			if rdb.db.GetPropertyCF(propName, cf) == "" {
+				break
+			}
+			numFiles, err := rdb.uint64Property(propName, cf)
+			if err != nil {
+				return nil, err
+			}
+			if level == len(es.NumFilesPerLevel) {
+				es.NumFilesPerLevel = append(es.NumFilesPerLevel, 0)
+			}
+			es.NumFilesPerLevel[level] += numFiles
+		}
+	}
+
+	tickers := parseTickers(rdb.opts.rocksDBOpts.GetStatisticsString())
+	hits, misses := tickers["rocksdb.block.cache.hit"], tickers["rocksdb.block.cache.miss"]
+	if hits+misses > 0 {
+		es.BlockCacheHitRate = float64(hits) / float64(hits+misses)
+	}
+	rdb.changeHistoryStats(es)
+	es.NamespaceCacheStats = rdb.opts.nsCacheStats.stats()
+	return es, nil
+}
+
+// changeHistoryStats computes the oldest change number and the size of
+// the history of changes retained across the WAL and the change log.
+// Since the replication only depends on them, failures to compute them
+// are only counted.
+func (rdb *rocksDB) changeHistoryStats(es *storage.EngineStats) {
+	if oldestChngNum, err := rdb.oldestWALChangeNumber(); err != nil {
+		rdb.opts.statsCli.Incr("rocksdb.changes.wal.iterator.errors", 1)
+	} else {
+		es.OldestChangeNumber = oldestChngNum
+	}
+	// WAL files are retained in the archive when a WAL TTL is configured
+	for _, dir := range []string{rdb.opts.folderName, filepath.Join(rdb.opts.folderName, "archive")} {
+		files, err := ioutil.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, f := range files {
+			if !f.IsDir() && strings.HasSuffix(f.Name(), ".log") {
+				es.ChangeHistoryBytes += uint64(f.Size())
+			}
+		}
+	}
+	if rdb.changeLog != nil {
+		if firstChngNum, nonEmpty := rdb.changeLog.FirstChangeNumber(); nonEmpty &&
+			(es.OldestChangeNumber == 0 || firstChngNum < es.OldestChangeNumber) {
+			es.OldestChangeNumber = firstChngNum
+		}
+		es.ChangeHistoryBytes += uint64(rdb.changeLog.Size())
+	}
+}
+
+// oldestWALChangeNumber retrieves the change number of the oldest change
+// retained in the WAL, which is zero if the WAL is empty.
+func (rdb *rocksDB) oldestWALChangeNumber() (uint64, error) {
+	chngIter, err := rdb.db.GetUpdatesSince(0)
+	if err != nil {
+		return 0, err
+	}
+	defer chngIter.Destroy()
+	if !chngIter.Valid() {
+		return 0, nil
+	}
+	wb, chngNum := chngIter.GetBatch()
+	wb.Destroy()
+	return chngNum, nil
+}
+
+// parseTickers parses the counters out of the RocksDB statistics, which
+// are reported one per line in the form `<name> COUNT : <value>`.
+func parseTickers(statistics string) map[string]uint64 {
+	tickers := make(map[string]uint64)
+	scanner := bufio.NewScanner(strings.NewReader(statistics))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 4 || fields[1] != "COUNT" || fields[2] != ":" {
+			continue
+		}
+		if val, err := strconv.ParseUint(fields[3], 10, 64); err == nil {
+			tickers[fields[0]] = val
+		}
+	}
+	return tickers
+}
@@ -9,11 +9,14 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/flipkart-incubator/dkv/internal/hlc"
+	"github.com/flipkart-incubator/dkv/internal/storage/changelog"
 	"github.com/flipkart-incubator/dkv/internal/storage/iterators"
 	"github.com/flipkart-incubator/dkv/internal/storage/utils"
 	"github.com/vmihailenco/msgpack/v5"
@@ -32,7 +35,22 @@ type DB interface {
 	storage.KVStore
 	storage.Backupable
 	storage.ChangePropagator
+	storage.ChangeNotifier
 	storage.ChangeApplier
+	storage.SnapshotPublisher
+	storage.Compactable
+	storage.SizeEstimator
+	storage.LegalHolder
+	storage.SubjectEraser
+	storage.TenantKeyManager
+	storage.WALSyncWriter
+	storage.DurabilityTracker
+	storage.Checkpointer
+	storage.Merger
+	storage.ExistenceChecker
+	storage.VersionedReader
+	storage.Transactor
+	storage.ReadAmplificationSampler
 }
 
 type rocksDB struct {
@@ -45,19 +63,119 @@ type rocksDB struct {
 	// Indicates a global mutation like backup and restore that
 	// require exclusivity. Shall be manipulated using atomics.
 	globalMutation uint32
+
+	// Published snapshots keyed by their references.
+	snapsMu sync.RWMutex
+	snaps   map[string]*publishedSnapshot
+
+	// Key prefixes under legal hold.
+	*storage.LegalHolds
+	// Serializes the changes to the legal holds.
+	legalHoldsMu sync.Mutex
+
+	// Serializes the creation and deletion of subject encryption keys.
+	subjKeyMu sync.Mutex
+
+	// Serializes the changes to the data keys of tenants.
+	tenantKeyMu sync.Mutex
+	// Decrypted data keys of tenants.
+	tenantKeys tenantKeyCache
+
+	// Handler to which the scrubber reports the corrupt entries for
+	// quarantining.
+	corruptHndlrMu sync.Mutex
+	corruptHndlr   func(*serverpb.QuarantinedRecord) error
+
+	// Change number upto which the writes are known to be fsynced.
+	// Shall be manipulated using atomics.
+	syncedChngNum uint64
+
+	// Change log from which the changes are propagated, if configured.
+	// Commits are serialized using the mutex in its presence.
+	changeLog *changelog.Log
+	commitMu  sync.Mutex
+
+	// Notifies the commits to the consumers streaming the changes.
+	commitSignal *storage.CommitSignal
+
+	// Column family holding the versions of keys, for reads as of a
+	// change number. Presence of the versions marker is tracked to
+	// discard it once versions are no longer recorded, and shall be
+	// manipulated using atomics.
+	versionsCF     *gorocksdb.ColumnFamilyHandle
+	versionsMarked uint32
+
+	// Indicates if the reads are being sampled for reporting the read
+	// amplification. Shall be manipulated using atomics.
+	readAmpSampling uint32
+	readAmpStats    readAmpStats
+
+	workload  workloadStats
+	autoTuner *autoTuner
+	scrubber  *scrubber
+	walSyncer *walSyncer
+
+	// Group committer of the synchronous writes, if enabled, which is
+	// retained once stopped so that the writes following the closing of
+	// the store are rejected. Holds a *groupCommitter and shall be
+	// manipulated using atomics.
+	groupCommitter atomic.Value
+
+	// Held exclusively while re-encrypting the values during a master
+	// key rotation, so that no concurrent write is overwritten.
+	rotateMu sync.RWMutex
+
+	// Held shared by every value pinned by GetPinned until its release,
+	// so that the DB is closed only after all the pins are released.
+	pinMu sync.RWMutex
+
+	// Timestamps the writes under active-active replication, which are
+	// stamped holding originMu shared, while the changes of the peer are
+	// applied holding it exclusively.
+	clock    hlc.Clock
+	originMu sync.RWMutex
 }
 
 type rocksDBOpts struct {
-	readOpts       *gorocksdb.ReadOptions
-	writeOpts      *gorocksdb.WriteOptions
-	blockTableOpts *gorocksdb.BlockBasedTableOptions
-	rocksDBOpts    *gorocksdb.Options
-	restoreOpts    *gorocksdb.RestoreOptions
-	folderName     string
-	sstDirectory   string
-	lgr            *zap.Logger
-	statsCli       stats.Client
-	cfNames        []string
+	readOpts             *gorocksdb.ReadOptions
+	writeOpts            *gorocksdb.WriteOptions
+	syncWriteOpts        *gorocksdb.WriteOptions
+	walLessWriteOpts     *gorocksdb.WriteOptions
+	walLessPrefixes      [][]byte
+	replKeyPrefixes      [][]byte
+	blockTableOpts       *gorocksdb.BlockBasedTableOptions
+	rocksDBOpts          *gorocksdb.Options
+	restoreOpts          *gorocksdb.RestoreOptions
+	folderName           string
+	sstDirectory         string
+	subjects             *storage.Subjects
+	tenants              *storage.Tenants
+	keyring              *storage.Keyring
+	walSyncPolicy        WALSyncPolicy
+	walSyncInterval      time.Duration
+	mergeOps             prefixMergeOperators
+	changeLogDir         string
+	changeLogSegmentSize int64
+	changeLogMaxSegments int
+	versionRetention     uint64
+	originID             string
+	autoTuneInterval     time.Duration
+	autoTuneBounds       *AutoTuneBounds
+	compactionPolicy     CompactionPolicy
+	compactionFilters    []CompactionFilter
+	scrubKeysPerSec      int
+	scrubQuarantine      bool
+	rateLimiter          *gorocksdb.RateLimiter
+	blockCache           *gorocksdb.Cache
+	multiGetBatchSize    int
+	multiGetParallelism  int
+	groupCommitWindow    time.Duration
+	groupCommitMaxWrites int
+	applyPipelineWorkers int
+	nsCacheStats         *namespaceCacheAccounting
+	lgr                  *zap.Logger
+	statsCli             stats.Client
+	cfNames              []string
 }
 
 // DBOption is used to configure the RocksDB
@@ -87,11 +205,10 @@ func WithStats(statsCli stats.Client) DBOption {
 }
 
 // WithSyncWrites ensures all writes to RocksDB are
-// immediatey flushed to disk from OS buffers.
+// immediatey flushed to disk from OS buffers. This is
+// same as using the WALSyncPerWrite policy.
 func WithSyncWrites() DBOption {
-	return func(opts *rocksDBOpts) {
-		opts.writeOpts.SetSync(true)
-	}
+	return WithWALSyncPolicy(WALSyncPerWrite, 0)
 }
 
 // WithSSTDir configures the directory to be used
@@ -104,13 +221,7 @@ func WithSSTDir(sstDir string) DBOption {
 
 // WithCacheSize is used to set the block cache size.
 func WithCacheSize(size uint64) DBOption {
-	return func(opts *rocksDBOpts) {
-		if size > 0 {
-			opts.blockTableOpts.SetBlockCache(gorocksdb.NewLRUCache(size))
-		} else {
-			opts.blockTableOpts.SetNoBlockCache(true)
-		}
-	}
+	return WithCacheOptions(&CacheOptions{BlockCacheSize: size})
 }
 
 // WithRocksDBConfig can be used to override internal RocksDB
@@ -144,30 +255,21 @@ func OpenDB(dbFolder string, dbOpts ...DBOption) (DB, error) {
 	for _, dbOpt := range dbOpts {
 		dbOpt(opts)
 	}
-	return openStore(opts)
-}
-
-type ttlCompactionFilter struct {
-	lgr *zap.Logger
-}
-
-// Name returns the CompactionFilter name
-func (m *ttlCompactionFilter) Name() string {
-	return "dkv.ttlFilter"
-}
-
-// Filter applies the logic for the Compaction process.
-// this returns remove as true if the TTL of the key has expired.
-func (m *ttlCompactionFilter) Filter(level int, key, val []byte) (remove bool, newVal []byte) {
-	ttlRow, err := parseTTLMsgPackData(val)
-	if err != nil {
-		m.lgr.Warn("ttlCompactionFilter::Filter Failed to parse msgpack data", zap.String("Key", string(key)))
-		return false, nil
+	if opts.tenants != nil && opts.keyring == nil {
+		return nil, errTenantsWithoutEncryption
+	}
+	if len(opts.walLessPrefixes) > 0 && opts.changeLogDir == "" {
+		return nil, errWALLessWithoutChangeLog
 	}
-	if hlc.InThePast(ttlRow.ExpiryTS) {
-		return true, val
+	rdb, err := openStore(opts)
+	if err != nil {
+		return nil, err
 	}
-	return false, nil
+	rdb.startAutoTuner()
+	rdb.startScrubber()
+	rdb.startGroupCommitter()
+	rdb.startWALSyncer()
+	return rdb, nil
 }
 
 func newOptions(dbFolder string) *rocksDBOpts {
@@ -176,20 +278,27 @@ func newOptions(dbFolder string) *rocksDBOpts {
 	opts.SetCreateIfMissing(true)
 	opts.SetCreateIfMissingColumnFamilies(true)
 	opts.SetBlockBasedTableFactory(bbto)
+	// Statistics are needed for reporting the block cache hit rate
+	opts.EnableStatistics()
 	rstOpts := gorocksdb.NewRestoreOptions()
 	wrOpts := gorocksdb.NewDefaultWriteOptions()
+	syncWrOpts := gorocksdb.NewDefaultWriteOptions()
+	syncWrOpts.SetSync(true)
 	rdOpts := gorocksdb.NewDefaultReadOptions()
-	cfNames := []string{"default", "ttl"}
+	cfNames := []string{"default", "ttl", versionsCFName}
 	return &rocksDBOpts{
-		folderName:     dbFolder,
-		blockTableOpts: bbto,
-		rocksDBOpts:    opts,
-		restoreOpts:    rstOpts,
-		lgr:            zap.NewNop(),
-		readOpts:       rdOpts,
-		writeOpts:      wrOpts,
-		statsCli:       stats.NewNoOpClient(),
-		cfNames:        cfNames,
+		folderName:       dbFolder,
+		blockTableOpts:   bbto,
+		rocksDBOpts:      opts,
+		restoreOpts:      rstOpts,
+		lgr:              zap.NewNop(),
+		readOpts:         rdOpts,
+		writeOpts:        wrOpts,
+		syncWriteOpts:    syncWrOpts,
+		walSyncPolicy:    WALSyncAsync,
+		compactionPolicy: CompactionDropExpired,
+		statsCli:         stats.NewNoOpClient(),
+		cfNames:          cfNames,
 	}
 }
 
@@ -199,20 +308,50 @@ func (rdbOpts *rocksDBOpts) destroy() {
 	rdbOpts.restoreOpts.Destroy()
 	rdbOpts.readOpts.Destroy()
 	rdbOpts.writeOpts.Destroy()
+	rdbOpts.syncWriteOpts.Destroy()
+	if rdbOpts.walLessWriteOpts != nil {
+		rdbOpts.walLessWriteOpts.Destroy()
+	}
+	if rdbOpts.rateLimiter != nil {
+		rdbOpts.rateLimiter.Destroy()
+	}
+	if rdbOpts.blockCache != nil {
+		rdbOpts.blockCache.Destroy()
+	}
 }
 
 func openStore(opts *rocksDBOpts) (*rocksDB, error) {
+	// Legal holds are loaded once opened, yet are consulted by the
+	// compaction filters from the beginning
+	holds := storage.NewPendingLegalHolds()
 	normalOpts := opts.rocksDBOpts
 	ttlOpts, err := gorocksdb.GetOptionsFromString(normalOpts, "")
 	if err != nil {
 		return nil, err
 	}
-	ttlOpts.SetCompactionFilter(&ttlCompactionFilter{opts.lgr})
+	ttlOpts.SetCompactionFilter(&ttlCompactionFilter{opts.lgr, holds, opts.compactionPolicy, opts.compactionFilters})
+	versionsOpts, err := gorocksdb.GetOptionsFromString(normalOpts, "")
+	if err != nil {
+		return nil, err
+	}
+	versionsFilter := &versionsCompactionFilter{retention: opts.versionRetention}
+	versionsOpts.SetCompactionFilter(versionsFilter)
+	keyspaceFilters := opts.compactionFilters
+	if opts.compactionPolicy == CompactionDropExpiredAndEmpty {
+		keyspaceFilters = append([]CompactionFilter{DropEmptyValues()}, keyspaceFilters...)
+	}
+	if len(keyspaceFilters) > 0 {
+		normalOpts.SetCompactionFilter(&keyspaceCompactionFilter{holds, keyspaceFilters})
+	}
+	if len(opts.mergeOps) > 0 {
+		normalOpts.SetMergeOperator(opts.mergeOperator())
+	}
 	optimTrxnDB, cfh, err := gorocksdb.OpenOptimisticTransactionDbColumnFamilies(opts.rocksDBOpts,
-		opts.folderName, opts.cfNames, []*gorocksdb.Options{normalOpts, ttlOpts})
+		opts.folderName, opts.cfNames, []*gorocksdb.Options{normalOpts, ttlOpts, versionsOpts})
 	if err != nil {
 		return nil, err
 	}
+	versionsFilter.db.Store(optimTrxnDB.GetBaseDb())
 
 	rocksdb := rocksDB{
 		db:             optimTrxnDB.GetBaseDb(),
@@ -221,6 +360,33 @@ func openStore(opts *rocksDBOpts) (*rocksDB, error) {
 		optimTrxnDB:    optimTrxnDB,
 		opts:           opts,
 		globalMutation: 0,
+		snaps:          make(map[string]*publishedSnapshot),
+		LegalHolds:     holds,
+		versionsCF:     cfh[2],
+		commitSignal:   storage.NewCommitSignal(),
+	}
+	// Writes recovered from the WAL upon opening are durable
+	rocksdb.syncedChngNum = rocksdb.db.GetLatestSequenceNumber()
+	marker, err := rocksdb.db.GetCF(opts.readOpts, rocksdb.versionsCF, versionsMarkerKey)
+	if err != nil {
+		optimTrxnDB.Close()
+		return nil, err
+	}
+	if marker.Size() > 0 {
+		rocksdb.versionsMarked = 1
+	}
+	marker.Free()
+	heldPrefixes, err := rocksdb.loadLegalHolds()
+	if err != nil {
+		optimTrxnDB.Close()
+		return nil, err
+	}
+	holds.Reset(heldPrefixes)
+	if opts.changeLogDir != "" {
+		if err = rocksdb.openChangeLog(); err != nil {
+			optimTrxnDB.Close()
+			return nil, err
+		}
 	}
 	//TODO: revisit this later after understanding what is the impact of manually triggered compaction
 	//go rocksdb.Compaction()
@@ -246,8 +412,119 @@ func (rdb *rocksDB) Compaction() error {
 	return nil
 }
 
+func (rdb *rocksDB) CompactRange(keyPrefix []byte) error {
+	defer rdb.opts.statsCli.Timing("rocksdb.compact.range.latency.ms", time.Now())
+
+	rng := gorocksdb.Range{}
+	if len(keyPrefix) > 0 {
+		rng.Start, rng.Limit = keyPrefix, prefixUpperBound(keyPrefix)
+	}
+	rdb.opts.lgr.Info("Triggering manual RocksDB Compaction", zap.String("KeyPrefix", string(keyPrefix)))
+	rdb.db.CompactRangeCF(rdb.normalCF, rng)
+	rdb.db.CompactRangeCF(rdb.ttlCF, rng)
+	return nil
+}
+
+var errInvalidSizeEstimateRange = errors.New("start key must be less than the end key for estimating size")
+
+// EstimateSize estimates the size of the given key range using the
+// approximate sizes of the SST files overlapping the range, across
+// both the column families. Since RocksDB tracks the approximate
+// number of keys only for the entire keyspace, the number of keys in
+// the given range is estimated in proportion to its size. Keys that
+// are yet to be flushed out of the memtables are not accounted for
+// in the size of a range.
+func (rdb *rocksDB) EstimateSize(startKey, endKey []byte) (*storage.SizeEstimate, error) {
+	defer rdb.opts.statsCli.Timing("rocksdb.estimate.size.latency.ms", time.Now())
+
+	if len(endKey) > 0 && bytes.Compare(startKey, endKey) >= 0 {
+		return nil, errInvalidSizeEstimateRange
+	}
+	var totalKeys, totalSize uint64
+	for _, cf := range []*gorocksdb.ColumnFamilyHandle{rdb.normalCF, rdb.ttlCF} {
+		numKeys, err := rdb.uint64Property("rocksdb.estimate-num-keys", cf)
+		if err != nil {
+			return nil, err
+		}
+		size, err := rdb.uint64Property("rocksdb.total-sst-files-size", cf)
+		if err != nil {
+			return nil, err
+		}
+		totalKeys, totalSize = totalKeys+numKeys, totalSize+size
+	}
+	if len(startKey) == 0 && len(endKey) == 0 {
+		return &storage.SizeEstimate{NumKeys: totalKeys, SizeBytes: totalSize}, nil
+	}
+
+	// An unbounded range is estimated by excluding the keys before the start key
+	rng, excludeRange := gorocksdb.Range{Start: []byte{}, Limit: endKey}, len(endKey) == 0
+	if excludeRange {
+		rng.Limit = startKey
+	} else if len(startKey) > 0 {
+		rng.Start = startKey
+	}
+	var size uint64
+	for _, cf := range []*gorocksdb.ColumnFamilyHandle{rdb.normalCF, rdb.ttlCF} {
+		sizes, err := rdb.db.GetApproximateSizesCF(cf, []gorocksdb.Range{rng})
+		if err != nil {
+			return nil, err
+		}
+		size += sizes[0]
+	}
+	if size > totalSize {
+		size = totalSize
+	}
+	if excludeRange {
+		size = totalSize - size
+	}
+	est := &storage.SizeEstimate{SizeBytes: size}
+	if totalSize > 0 {
+		est.NumKeys = uint64(float64(totalKeys) * float64(size) / float64(totalSize))
+	}
+	return est, nil
+}
+
+func (rdb *rocksDB) uint64Property(propName string, cf *gorocksdb.ColumnFamilyHandle) (uint64, error) {
+	propVal := rdb.db.GetPropertyCF(propName, cf)
+	if propVal == "" {
+		return 0, nil
+	}
+	val, err := strconv.ParseUint(propVal, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse RocksDB property %s with value %q: %w", propName, propVal, err)
+	}
+	return val, nil
+}
+
+// prefixUpperBound computes the smallest key that is greater than all
+// the keys having the given prefix. Returns nil if no such key exists,
+// which is the case when the prefix is made up of only 0xFF bytes.
+func prefixUpperBound(prefix []byte) []byte {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] < 0xFF {
+			limit := make([]byte, i+1)
+			copy(limit, prefix)
+			limit[i]++
+			return limit
+		}
+	}
+	return nil
+}
+
 func (rdb *rocksDB) Close() error {
+	rdb.stopAutoTuner()
+	rdb.stopScrubber()
+	rdb.stopGroupCommitter()
+	rdb.stopWALSyncer()
+	rdb.releaseSnapshots()
+	rdb.pinMu.Lock()
 	rdb.optimTrxnDB.Close()
+	rdb.pinMu.Unlock()
+	if rdb.changeLog != nil {
+		if err := rdb.changeLog.Close(); err != nil {
+			rdb.opts.lgr.Error("Unable to close change log", zap.Error(err))
+		}
+	}
 	//rdb.opts.destroy()
 	return nil
 }
@@ -277,30 +554,74 @@ func (rdb *rocksDB) replaceDB(checkpointDir string) error {
 		rdb.optimTrxnDB = finalDB.optimTrxnDB
 		rdb.normalCF = finalDB.normalCF
 		rdb.ttlCF = finalDB.ttlCF
+		rdb.versionsCF = finalDB.versionsCF
+		atomic.StoreUint32(&rdb.versionsMarked, finalDB.versionsMarked)
+		atomic.StoreUint64(&rdb.syncedChngNum, finalDB.syncedChngNum)
+		rdb.LegalHolds = finalDB.LegalHolds
+		rdb.tenantKeys.invalidate()
+		rdb.changeLog = finalDB.changeLog
+		// Changes preceding the replaced DB are no longer applicable
+		if rdb.changeLog != nil {
+			if err = rdb.changeLog.Reset(); err != nil {
+				rdb.opts.lgr.Error("Failed to discard change log", zap.Error(err))
+				return err
+			}
+		}
 
 		_ = os.RemoveAll(backupDir) //remove old db.
+		rdb.startAutoTuner()
+		rdb.startScrubber()
+		rdb.startGroupCommitter()
+		rdb.startWALSyncer()
+		// Streams of changes resume from the restored changes
+		rdb.commitSignal.Signal()
 	}
 
 	return nil
 }
 
 func (rdb *rocksDB) Put(pairs ...*serverpb.KVPair) error {
+	return rdb.put(rdb.writeOptions(serverpb.WALSync_DEFAULT_WAL_SYNC), pairs...)
+}
+
+func (rdb *rocksDB) put(wo *gorocksdb.WriteOptions, pairs ...*serverpb.KVPair) error {
 	metricsPrefix := "rocksdb.put.multi"
 	if len(pairs) == 1 {
 		metricsPrefix = "rocksdb.put.single"
 	}
 
 	defer rdb.opts.statsCli.Timing(metricsPrefix+".latency.ms", time.Now())
-	wb := gorocksdb.NewWriteBatch()
-	defer wb.Destroy()
+	if walLess, err := rdb.isWALLessWrite(pairs); err != nil {
+		rdb.opts.statsCli.Incr(metricsPrefix+".errors", 1)
+		return err
+	} else if walLess {
+		wo = rdb.opts.walLessWriteOpts
+	}
+	wb := getWriteBatch()
+	defer putWriteBatch(wb)
+	originTS, stamped := rdb.stampOrigins()
+	defer stamped()
 	for _, kv := range pairs {
 		if kv == nil {
 			continue //skip nil entries
 		}
+		if storage.IsReservedKey(kv.Key) {
+			rdb.opts.statsCli.Incr(metricsPrefix+".errors", 1)
+			return errReservedKey
+		}
+		if err := rdb.checkMergeable(kv.Key, kv.Value); err != nil {
+			rdb.opts.statsCli.Incr(metricsPrefix+".errors", 1)
+			return err
+		}
+		value, err := rdb.encryptValue(kv.Key, kv.Value)
+		if err != nil {
+			rdb.opts.statsCli.Incr(metricsPrefix+".errors", 1)
+			return err
+		}
 		if kv.ExpireTS > 0 {
 			dF := ttlDataFormat{
 				ExpiryTS: kv.ExpireTS,
-				Data:     kv.Value,
+				Data:     value,
 			}
 			msgPack, err := msgpack.Marshal(dF)
 			if err != nil {
@@ -311,43 +632,81 @@ func (rdb *rocksDB) Put(pairs ...*serverpb.KVPair) error {
 			wb.PutCF(rdb.ttlCF, kv.Key, msgPack)
 		} else {
 			wb.DeleteCF(rdb.ttlCF, kv.Key)
-			wb.PutCF(rdb.normalCF, kv.Key, kv.Value)
+			wb.PutCF(rdb.normalCF, kv.Key, value)
+		}
+		if originTS > 0 {
+			rdb.stampOrigin(wb, kv.Key, originTS)
 		}
 	}
-	err := rdb.db.Write(rdb.opts.writeOpts, wb)
+	err := rdb.write(wo, wb)
 	if err != nil {
 		rdb.opts.statsCli.Incr(metricsPrefix+".errors", 1)
+		return err
 	}
-	return err
+	for _, kv := range pairs {
+		if kv != nil {
+			rdb.workload.recordWrite(len(kv.Value))
+		}
+	}
+	return nil
 }
 
 func (rdb *rocksDB) Delete(key []byte) error {
 	defer rdb.opts.statsCli.Timing("rocksdb.delete.latency.ms", time.Now())
-	wb := gorocksdb.NewWriteBatch()
-	defer wb.Destroy()
+	if storage.IsReservedKey(key) {
+		rdb.opts.statsCli.Incr("rocksdb.delete.errors", 1)
+		return errReservedKey
+	}
+	if rdb.UnderLegalHold(key) {
+		rdb.opts.statsCli.Incr("rocksdb.delete.legal.hold.rejects", 1)
+		return storage.ErrUnderLegalHold
+	}
+	wb := getWriteBatch()
+	defer putWriteBatch(wb)
 	wb.DeleteCF(rdb.ttlCF, key)
 	wb.Delete(key)
-	err := rdb.db.Write(rdb.opts.writeOpts, wb)
+	if originTS, stamped := rdb.stampOrigins(); originTS > 0 {
+		defer stamped()
+		rdb.stampOrigin(wb, key, originTS)
+	}
+	wo := rdb.writeOptions(serverpb.WALSync_DEFAULT_WAL_SYNC)
+	if rdb.isWALLess(key) {
+		wo = rdb.opts.walLessWriteOpts
+	}
+	err := rdb.write(wo, wb)
 	if err != nil {
 		rdb.opts.statsCli.Incr("rocksdb.delete.errors", 1)
+	} else {
+		rdb.workload.recordWrite(0)
 	}
 	return err
 }
 
 func (rdb *rocksDB) Get(keys ...[]byte) ([]*serverpb.KVPair, error) {
-	ro := rdb.opts.readOpts
 	switch numKeys := len(keys); {
 	case numKeys == 1:
-		return rdb.getSingleKey(ro, keys[0])
+		return rdb.getSingleKey(rdb.opts.readOpts, keys[0])
 	default:
-		return rdb.getMultipleKeys(ro, keys)
+		return rdb.multiGet(nil, keys)
 	}
 }
 
 func (rdb *rocksDB) CompareAndSet(key, expect, update []byte) (bool, error) {
 	defer rdb.opts.statsCli.Timing("rocksdb.cas.latency.ms", time.Now())
+	if storage.IsReservedKey(key) {
+		return false, errReservedKey
+	}
+	if rdb.opts.originID != "" {
+		return false, errUnstampedWrite
+	}
+	if _, present := rdb.opts.subjects.Of(key); present {
+		return false, errCASOnEncryptedSubject
+	}
+	if rdb.isWALLess(key) {
+		return false, errWALLessKey
+	}
 	ro := rdb.opts.readOpts
-	wo := rdb.opts.writeOpts
+	wo := rdb.writeOptions(serverpb.WALSync_DEFAULT_WAL_SYNC)
 	to := gorocksdb.NewDefaultOptimisticTransactionOptions()
 	txn := rdb.optimTrxnDB.TransactionBegin(wo, to, nil)
 	defer txn.Destroy()
@@ -358,7 +717,10 @@ func (rdb *rocksDB) CompareAndSet(key, expect, update []byte) (bool, error) {
 	}
 	defer exist.Free()
 
-	existVal := exist.Data()
+	existVal, _, err := rdb.openValue(key, exist.Data())
+	if err != nil {
+		return false, err
+	}
 	if expect == nil || len(expect) == 0 {
 		if len(existVal) > 0 {
 			return false, nil
@@ -368,20 +730,61 @@ func (rdb *rocksDB) CompareAndSet(key, expect, update []byte) (bool, error) {
 			return false, nil
 		}
 	}
+	if update, err = rdb.sealValue(key, update); err != nil {
+		rdb.opts.statsCli.Incr("rocksdb.cas.set.errors", 1)
+		return false, err
+	}
 	err = txn.Put(key, update)
 	if err != nil {
 		rdb.opts.statsCli.Incr("rocksdb.cas.set.errors", 1)
 		return false, err
 	}
-	err = txn.Commit()
-	if err != nil && strings.HasSuffix(err.Error(), "Resource busy: ") {
+	wb := gorocksdb.NewWriteBatch()
+	defer wb.Destroy()
+	wb.Put(key, update)
+	err = rdb.commitTxn(wo, txn, wb)
+	if isTxnConflict(err) {
 		return false, nil
 	}
 	return err == nil, err
 }
 
+// commitTxn commits the given transaction, whose changes are held by
+// the given equivalent write batch. In the presence of the change log,
+// the commit is recorded in it as the equivalent write batch. Versions
+// of the changed keys are committed ahead of the transaction, if
+// versions are retained.
+func (rdb *rocksDB) commitTxn(wo *gorocksdb.WriteOptions, txn *gorocksdb.Transaction, wb *gorocksdb.WriteBatch) error {
+	rdb.rotateMu.RLock()
+	defer rdb.rotateMu.RUnlock()
+	versioned := rdb.needsVersioning()
+	if rdb.changeLog == nil && !versioned {
+		if err := txn.Commit(); err != nil {
+			return err
+		}
+		rdb.commitSignal.Signal()
+		return nil
+	}
+	rdb.commitMu.Lock()
+	defer rdb.commitMu.Unlock()
+	if versioned {
+		if err := rdb.commitTxnVersions(wo, wb); err != nil {
+			return err
+		}
+	}
+	if err := txn.Commit(); err != nil {
+		return err
+	}
+	if rdb.changeLog != nil {
+		rdb.appendChange(wb)
+	}
+	rdb.commitSignal.Signal()
+	return nil
+}
+
 const (
 	sstPrefix               = "rocksdb-sstfile-"
+	checkpointPrefix        = "rocksdb-checkpoint-"
 	sstDefaultCF            = "/default.cf"
 	sstTtlCF                = "/ttl.cf"
 	snapshotLogSizeForFlush = 0
@@ -426,6 +829,31 @@ func (rdb *rocksDB) generateSST(snap *gorocksdb.Snapshot, cf *gorocksdb.ColumnFa
 	return os.Open(fileName)
 }
 
+// createCheckpoint creates a checkpoint of the entire keyspace in the
+// given folder, which must not exist.
+func (rdb *rocksDB) createCheckpoint(checkpointDir string) error {
+	checkpoint, err := rdb.db.NewCheckpoint()
+	if err != nil {
+		rdb.opts.lgr.Error("GetSnapshot: Failed to create new checkpoint", zap.Error(err))
+		return err
+	}
+	defer checkpoint.Destroy()
+
+	err = checkpoint.CreateCheckpoint(checkpointDir, snapshotLogSizeForFlush)
+	if err != nil {
+		rdb.opts.lgr.Error("GetSnapshot: Failed to make checkpoint", zap.Error(err))
+		return err
+	}
+
+	//check that checkpoint was successfully created
+	if created, _ := exists(checkpointDir); !created {
+		err = fmt.Errorf("checkpoint.CreateCheckpoint failed")
+		rdb.opts.lgr.Error("GetSnapshot: Checkpoint dir was not created", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
 type checkPointSnapshot struct {
 	tar *utils.StreamingTar
 	dir string
@@ -458,23 +886,8 @@ func (rdb *rocksDB) GetSnapshot() (io.ReadCloser, error) {
 		return nil, err
 	}
 
-	checkpoint, err := rdb.db.NewCheckpoint()
-	if err != nil {
-		rdb.opts.lgr.Error("GetSnapshot: Failed to create new checkpoint", zap.Error(err))
-		return nil, err
-	}
-
 	checkpointDir := fmt.Sprintf("%s/checkpoint", sstDir)
-	err = checkpoint.CreateCheckpoint(checkpointDir, snapshotLogSizeForFlush)
-	if err != nil {
-		rdb.opts.lgr.Error("GetSnapshot: Failed to make checkpoint", zap.Error(err))
-		return nil, err
-	}
-
-	//check that checkpoint was successfully created
-	if created, _ := exists(checkpointDir); !created {
-		err = fmt.Errorf("checkpoint.CreateCheckpoint failed")
-		rdb.opts.lgr.Error("GetSnapshot: Checkpoint dir was not created", zap.Error(err))
+	if err = rdb.createCheckpoint(checkpointDir); err != nil {
 		return nil, err
 	}
 
@@ -537,6 +950,58 @@ func (rdb *rocksDB) PutSnapshot(snap io.ReadCloser) error {
 	return nil
 }
 
+// CreateCheckpoint creates a checkpoint of the entire keyspace in a new
+// folder under the SST directory. Like the backups, changes committed
+// while the checkpoint is created may also be present in it, but
+// replaying them later is harmless.
+func (rdb *rocksDB) CreateCheckpoint() (string, uint64, error) {
+	defer rdb.opts.statsCli.Timing("rocksdb.checkpoint.create.latency.ms", time.Now())
+
+	//Prevent any other backups or restores
+	if err := rdb.beginGlobalMutation(); err != nil {
+		return "", 0, err
+	}
+	defer rdb.endGlobalMutation()
+
+	chngNum, err := rdb.GetLatestCommittedChangeNumber()
+	if err != nil {
+		return "", 0, err
+	}
+	checkpointDir, err := storage.CreateTempFolder(rdb.opts.sstDirectory, checkpointPrefix)
+	if err != nil {
+		rdb.opts.lgr.Error("CreateCheckpoint: Failed to create temporary dir", zap.Error(err))
+		return "", 0, err
+	}
+	// Checkpoints can only be created in a folder that does not exist
+	if err = os.Remove(checkpointDir); err != nil {
+		return "", 0, err
+	}
+	if err = rdb.createCheckpoint(checkpointDir); err != nil {
+		_ = os.RemoveAll(checkpointDir)
+		return "", 0, err
+	}
+	return checkpointDir, chngNum, nil
+}
+
+// ApplyCheckpoint replaces the entire keyspace with the checkpoint in the
+// given folder, which must reside on the same filesystem as the DB folder
+// since it is moved in place of the latter.
+func (rdb *rocksDB) ApplyCheckpoint(folder string) (uint64, error) {
+	defer rdb.opts.statsCli.Timing("rocksdb.checkpoint.apply.latency.ms", time.Now())
+
+	//Prevent any other backups or restores
+	if err := rdb.beginGlobalMutation(); err != nil {
+		return 0, err
+	}
+	defer rdb.endGlobalMutation()
+
+	if err := rdb.replaceDB(folder); err != nil {
+		rdb.opts.lgr.Error("ApplyCheckpoint: Failed to restore from checkpoint", zap.Error(err))
+		return 0, err
+	}
+	return rdb.GetLatestAppliedChangeNumber()
+}
+
 func (rdb *rocksDB) BackupTo(folder string) error {
 	if err := checksForBackup(folder); err != nil {
 		return err
@@ -555,7 +1020,17 @@ func (rdb *rocksDB) BackupTo(folder string) error {
 
 	// Retain only the latest backup in the given folder
 	defer be.PurgeOldBackups(1)
-	return be.CreateNewBackupFlush(rdb.db, true)
+
+	// Changes committed while the backup is in progress may also be
+	// present in the backup, but replaying them later is harmless.
+	manifest := &storage.BackupManifest{
+		ChangeNumber: rdb.db.GetLatestSequenceNumber(),
+		CreatedAt:    time.Now().Unix(),
+	}
+	if err = be.CreateNewBackupFlush(rdb.db, true); err != nil {
+		return err
+	}
+	return storage.WriteBackupManifest(folder, manifest)
 }
 
 const tempDirPrefix = "rocksdb-restore-"
@@ -610,25 +1085,49 @@ func (rdb *rocksDB) RestoreFrom(folder string) (st storage.KVStore, ba storage.B
 	return
 }
 
+// GetLatestCommittedChangeNumber retrieves the change number of the latest
+// commit.
 func (rdb *rocksDB) GetLatestCommittedChangeNumber() (uint64, error) {
 	return rdb.db.GetLatestSequenceNumber(), nil
 }
 
 func (rdb *rocksDB) LoadChanges(fromChangeNumber uint64, maxChanges int) ([]*serverpb.ChangeRecord, error) {
 	defer rdb.opts.statsCli.Timing("rocksdb.load.changes.latency.ms", time.Now())
+	if chngs, fromLog, err := rdb.loadChangesFromLog(fromChangeNumber, maxChanges); fromLog {
+		return chngs, err
+	}
 	chngIter, err := rdb.db.GetUpdatesSince(fromChangeNumber)
 	if err != nil {
+		rdb.opts.statsCli.Incr("rocksdb.changes.wal.iterator.errors", 1)
+		rdb.opts.lgr.Error("Unable to open WAL iterator", zap.Uint64("FromChangeNumber", fromChangeNumber), zap.Error(err))
 		return nil, err
 	}
 	defer chngIter.Destroy()
+	var serTime time.Duration
 	i, chngs := 0, make([]*serverpb.ChangeRecord, maxChanges)
 	for i < maxChanges && chngIter.Valid() {
 		wb, chngNum := chngIter.GetBatch()
 		defer wb.Destroy()
+		// Changes missing from the WAL, such as those of WAL-less
+		// writes, are skipped, unless they precede the oldest change
+		// retained in the WAL, in which case their WAL files are purged
+		if i == 0 && chngNum > fromChangeNumber {
+			if oldestChngNum, err := rdb.oldestWALChangeNumber(); err == nil && chngNum <= oldestChngNum {
+				rdb.opts.statsCli.Incr("rocksdb.changes.unavailable", 1)
+				rdb.opts.lgr.Warn("Changes are no longer retained in the WAL", zap.Uint64("FromChangeNumber", fromChangeNumber),
+					zap.Uint64("OldestChangeNumber", oldestChngNum))
+				return nil, storage.ErrChangesNotAvailable
+			}
+			rdb.opts.statsCli.Incr("rocksdb.changes.skipped", int64(chngNum-fromChangeNumber))
+		}
+		start := time.Now()
 		chngs[i] = rdb.toChangeRecord(wb, chngNum)
+		serTime += time.Since(start)
 		i++
 		chngIter.Next()
 	}
+	rdb.opts.statsCli.Timing("rocksdb.load.changes.serialisation.latency.ms", time.Now().Add(-serTime))
+	rdb.opts.statsCli.Incr("rocksdb.load.changes.count", int64(i))
 	return chngs[0:i:i], nil
 }
 
@@ -636,14 +1135,26 @@ func (rdb *rocksDB) GetLatestAppliedChangeNumber() (uint64, error) {
 	return rdb.db.GetLatestSequenceNumber(), nil
 }
 
+// SaveChanges applies the given changes replicated from the master, one
+// write batch per change. Since every change consumes the same sequence
+// numbers as on the master, the latest sequence number of the store is
+// the change number of the last applied change, which RocksDB persists
+// atomically with the change. Hence changes that were already applied,
+// say by an attempt that failed midway or preceded a crash, are skipped
+// so that the replication resumes exactly from the last applied change.
 func (rdb *rocksDB) SaveChanges(changes []*serverpb.ChangeRecord) (uint64, error) {
 	defer rdb.opts.statsCli.Timing("rocksdb.save.changes.latency.ms", time.Now())
+	if rdb.pipelinedApply(len(changes)) {
+		return rdb.savePipelinedChanges(changes)
+	}
 	appldChngNum := uint64(0)
 	for _, chng := range changes {
-		wb := gorocksdb.WriteBatchFrom(chng.SerialisedForm)
-		defer wb.Destroy()
-		err := rdb.db.Write(rdb.opts.writeOpts, wb)
-		if err != nil {
+		if latestChngNum, applied := rdb.isChangeApplied(chng); applied {
+			rdb.opts.statsCli.Incr("rocksdb.save.changes.skipped", 1)
+			appldChngNum = latestChngNum
+			continue
+		}
+		if err := rdb.saveChange(chng); err != nil {
 			return appldChngNum, err
 		}
 		// at changeNum 3 with NumTxn = 2. Applied should be 4. Ie. 3 + 2 -1
@@ -652,10 +1163,24 @@ func (rdb *rocksDB) SaveChanges(changes []*serverpb.ChangeRecord) (uint64, error
 	return appldChngNum, nil
 }
 
+// isChangeApplied checks if the given change is already applied, along
+// with the change number of the latest applied change.
+func (rdb *rocksDB) isChangeApplied(chng *serverpb.ChangeRecord) (uint64, bool) {
+	lastChngNum := chng.ChangeNumber
+	if chng.NumberOfTrxns > 1 {
+		lastChngNum += uint64(chng.NumberOfTrxns) - 1
+	}
+	latestChngNum := rdb.db.GetLatestSequenceNumber()
+	return latestChngNum, lastChngNum <= latestChngNum
+}
+
 type iter struct {
 	iterOpts storage.IterationOptions
 	rdbIter  *gorocksdb.Iterator
 	ttlCF    bool
+	rdb      *rocksDB
+	// Error in decrypting a value, which ends the iteration
+	err error
 }
 
 func (rdb *rocksDB) newIterCF(readOpts *gorocksdb.ReadOptions, iterOpts storage.IterationOptions, cf *gorocksdb.ColumnFamilyHandle) *iter {
@@ -665,15 +1190,19 @@ func (rdb *rocksDB) newIterCF(readOpts *gorocksdb.ReadOptions, iterOpts storage.
 	} else {
 		it.SeekToFirst()
 	}
-	return &iter{iterOpts, it, cf == rdb.ttlCF}
+	return &iter{iterOpts: iterOpts, rdbIter: it, ttlCF: cf == rdb.ttlCF, rdb: rdb}
 }
 
 func (rdbIter *iter) verifyTTLValidity() bool {
 	if rdbIter.rdbIter.Valid() {
+		key := toByteArray(rdbIter.rdbIter.Key())
+		if storage.IsReservedKey(key) || rdbIter.rdb.isErased(key) {
+			return false
+		}
 		val := toByteArray(rdbIter.rdbIter.Value())
 		if rdbIter.ttlCF {
 			ttlRow, _ := parseTTLMsgPackData(val)
-			if hlc.InThePast(ttlRow.ExpiryTS) {
+			if hlc.InThePast(ttlRow.ExpiryTS) && !rdbIter.rdb.UnderLegalHold(key) {
 				return false
 			}
 		}
@@ -712,13 +1241,22 @@ func (rdbIter *iter) Next() *serverpb.KVPair {
 	if rdbIter.ttlCF { //base iterator doesn't have ttl
 		ttlRow, _ = parseTTLMsgPackData(val)
 	}
+	kv := &serverpb.KVPair{Key: key, Value: val}
 	if ttlRow != nil && ttlRow.ExpiryTS > 0 {
-		return &serverpb.KVPair{Key: key, Value: ttlRow.Data, ExpireTS: ttlRow.ExpiryTS}
+		kv.Value, kv.ExpireTS = ttlRow.Data, ttlRow.ExpiryTS
 	}
-	return &serverpb.KVPair{Key: key, Value: val}
+	decVal, _, err := rdbIter.rdb.decryptValue(key, kv.Value)
+	if err != nil && rdbIter.err == nil {
+		rdbIter.err = err
+	}
+	kv.Value = decVal
+	return kv
 }
 
 func (rdbIter *iter) Err() error {
+	if rdbIter.err != nil {
+		return rdbIter.err
+	}
 	return rdbIter.rdbIter.Err()
 }
 
@@ -728,7 +1266,10 @@ func (rdbIter *iter) Close() error {
 }
 
 func (rdb *rocksDB) Iterate(iterOpts storage.IterationOptions) storage.Iterator {
-	readOpts := rdb.opts.readOpts
+	return rdb.iterate(rdb.opts.readOpts, iterOpts)
+}
+
+func (rdb *rocksDB) iterate(readOpts *gorocksdb.ReadOptions, iterOpts storage.IterationOptions) storage.Iterator {
 	baseIter := rdb.newIterCF(readOpts, iterOpts, rdb.normalCF)
 	ttlIter := rdb.newIterCF(readOpts, iterOpts, rdb.ttlCF)
 	return iterators.Concat(baseIter, ttlIter)
@@ -738,16 +1279,21 @@ func (rdb *rocksDB) toChangeRecord(writeBatch *gorocksdb.WriteBatch, changeNum u
 	chngRec := &serverpb.ChangeRecord{}
 	chngRec.ChangeNumber = changeNum
 	dataBts := writeBatch.Data()
-	dataBtsCopy := byteArrayCopy(dataBts, len(dataBts))
+	dataBtsCopy := rdb.redactErasedSubjectKeys(byteArrayCopy(dataBts, len(dataBts)))
 	chngRec.SerialisedForm = dataBtsCopy
 	chngRec.NumberOfTrxns = uint32(writeBatch.Count())
 	wbIter := NewWriteBatchIterator(dataBtsCopy)
 	var trxns []*serverpb.TrxnRecord
 	for wbIter.Next() {
 		wbr := wbIter.Record()
+		// Versions are only carried along in the serialised form
+		if wbr.CF >= versionsCFID {
+			continue
+		}
 		trxns = append(trxns, rdb.toTrxnRecord(wbr))
 	}
 	chngRec.Trxns = trxns
+	storage.SetChangeChecksum(chngRec)
 	return chngRec
 }
 
@@ -767,6 +1313,8 @@ func (rdb *rocksDB) toTrxnRecord(wbr *gorocksdb.WriteBatchRecord) *serverpb.Trxn
 		trxnRec.Type = serverpb.TrxnRecord_Put
 	case gorocksdb.WriteBatchCFValueRecord:
 		trxnRec.Type = serverpb.TrxnRecord_Put
+	case gorocksdb.WriteBatchMergeRecord, gorocksdb.WriteBatchCFMergeRecord:
+		trxnRec.Type = serverpb.TrxnRecord_Merge
 	default:
 		trxnRec.Type = serverpb.TrxnRecord_Unknown
 	}
@@ -806,47 +1354,78 @@ func parseTTLMsgPackData(valueWithTTL []byte) (*ttlDataFormat, error) {
 
 func (rdb *rocksDB) getSingleKey(ro *gorocksdb.ReadOptions, key []byte) ([]*serverpb.KVPair, error) {
 	defer rdb.opts.statsCli.Timing("rocksdb.single.get.latency.ms", time.Now())
+	sample := rdb.beginPerfSample(key)
 	values, err := rdb.db.MultiGetCFMultiCF(ro, []*gorocksdb.ColumnFamilyHandle{rdb.normalCF, rdb.ttlCF}, [][]byte{key, key})
+	sample.end(rdb, 1)
 	if err != nil {
 		rdb.opts.statsCli.Incr("rocksdb.single.get.errors", 1)
 		return nil, err
 	}
 	value1, value2 := values[0], values[1]
-	kv := rdb.extractResult(value1, value2, key)
+	kv, err := rdb.extractResult(value1, value2, key)
 	value1.Free()
 	value2.Free()
+	if err != nil {
+		rdb.opts.statsCli.Incr("rocksdb.single.get.errors", 1)
+		return nil, err
+	}
 	if kv != nil {
+		rdb.workload.recordReads(1, 1)
 		return []*serverpb.KVPair{kv}, nil
 	}
+	rdb.workload.recordReads(1, 0)
 	return nil, nil
 }
 
-func (rdb *rocksDB) extractResult(value1 *gorocksdb.Slice, value2 *gorocksdb.Slice, key []byte) *serverpb.KVPair {
+func (rdb *rocksDB) extractResult(value1 *gorocksdb.Slice, value2 *gorocksdb.Slice, key []byte) (*serverpb.KVPair, error) {
 	if value1.Size() > 0 {
+		return rdb.toKVPair(key, toByteArray(value1), nil)
+	}
+	if value2.Size() > 0 {
+		return rdb.toKVPair(key, nil, toByteArray(value2))
+	}
+	return nil, nil
+}
+
+// toKVPair builds the pair for the given key from its value
+// in either the normal or the TTL column family.
+func (rdb *rocksDB) toKVPair(key, normalVal, ttlVal []byte) (*serverpb.KVPair, error) {
+	if storage.IsReservedKey(key) {
+		return nil, nil
+	}
+
+	if len(normalVal) > 0 {
 		//non ttl use-case
-		val := toByteArray(value1)
-		return &serverpb.KVPair{Key: key, Value: val}
+		val, ok, err := rdb.decryptValue(key, normalVal)
+		if err != nil || !ok {
+			return nil, err
+		}
+		return &serverpb.KVPair{Key: key, Value: val}, nil
 	}
 
-	if value2.Size() > 0 {
+	if len(ttlVal) > 0 {
 		//ttl use-case, check ttl
-		val := toByteArray(value2)
+		val := ttlVal
 		ttlRow, err := parseTTLMsgPackData(val)
 		if err != nil {
 			rdb.opts.lgr.Warn("RocksDB::extractResult Failed to parse msgpack data",
 				zap.String("Key", string(key)), zap.Error(err))
 			rdb.opts.statsCli.Incr("rocksdb.get.parse.errors", 1)
-			return nil
+			return nil, nil
 		}
-		if hlc.InThePast(ttlRow.ExpiryTS) {
-			return nil
+		if hlc.InThePast(ttlRow.ExpiryTS) && !rdb.UnderLegalHold(key) {
+			return nil, nil
 		} else if ttlRow.ExpiryTS > 0 {
 			val = ttlRow.Data
 		}
-		return &serverpb.KVPair{Key: key, Value: val, ExpireTS: ttlRow.ExpiryTS}
+		val, ok, err := rdb.decryptValue(key, val)
+		if err != nil || !ok {
+			return nil, err
+		}
+		return &serverpb.KVPair{Key: key, Value: val, ExpireTS: ttlRow.ExpiryTS}, nil
 	}
 
-	return nil
+	return nil, nil
 }
 
 func (rdb *rocksDB) getMultipleKeys(ro *gorocksdb.ReadOptions, keys [][]byte) ([]*serverpb.KVPair, error) {
@@ -859,7 +1438,9 @@ func (rdb *rocksDB) getMultipleKeys(ro *gorocksdb.ReadOptions, keys [][]byte) ([
 		reqCFs[i+kl] = rdb.ttlCF
 	}
 
-	values, err := rdb.db.MultiGetCFMultiCF(ro, reqCFs, append(keys, keys...))
+	sample := rdb.beginPerfSample(keys...)
+	values, err := rdb.db.MultiGetCFMultiCF(ro, reqCFs, append(keys[:kl:kl], keys...))
+	sample.end(rdb, kl)
 	if err != nil {
 		rdb.opts.statsCli.Incr("rocksdb.multi.get.errors", 1)
 		return nil, err
@@ -868,13 +1449,18 @@ func (rdb *rocksDB) getMultipleKeys(ro *gorocksdb.ReadOptions, keys [][]byte) ([
 	var results []*serverpb.KVPair
 	for i := 0; i < kl; i++ {
 		value1, value2 := values[i], values[i+kl]
-		kv := rdb.extractResult(value1, value2, keys[i])
+		kv, err := rdb.extractResult(value1, value2, keys[i])
 		value1.Free()
 		value2.Free()
+		if err != nil {
+			rdb.opts.statsCli.Incr("rocksdb.multi.get.errors", 1)
+			return nil, err
+		}
 		if kv != nil {
 			results = append(results, kv)
 		}
 	}
+	rdb.workload.recordReads(kl, len(results))
 	return results, nil
 }
 
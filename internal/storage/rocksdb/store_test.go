@@ -2,10 +2,15 @@ package rocksdb
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"github.com/flipkart-incubator/dkv/internal/hlc"
+	"io"
+	"io/ioutil"
 	"math"
+	"math/rand"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -55,6 +60,137 @@ func TestINIFileOption(t *testing.T) {
 	}
 }
 
+func TestTuningOptions(t *testing.T) {
+	dbFolder := "/tmp/rdb_tuning"
+	tuningOpts := &TuningOptions{
+		WriteBufferSize:            8 << 20,
+		MaxBackgroundJobs:          4,
+		MaxOpenFiles:               1000,
+		NumLevels:                  4,
+		TargetFileSizeBase:         8 << 20,
+		MaxBytesForLevelBase:       64 << 20,
+		MaxBytesForLevelMultiplier: 8,
+		BloomFilterBitsPerKey:      10,
+		CompressionPerLevel:        []string{"none", "none", "snappy", "lz4"},
+		BottommostCompression:      "zstd",
+		ZSTDMaxDictBytes:           16 << 10,
+		ZSTDMaxTrainBytes:          1 << 20,
+	}
+	db, err := OpenDB(dbFolder, WithRocksDBConfig(iniFilePath), WithTuningOptions(tuningOpts))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+}
+
+func TestTuningOptionsValidation(t *testing.T) {
+	invalidOpts := []*TuningOptions{
+		{WriteBufferSize: 1024},
+		{MaxBackgroundJobs: -1},
+		{MaxOpenFiles: 5},
+		{NumLevels: 1},
+		{TargetFileSizeBase: 2 << 20, MaxBytesForLevelBase: 1 << 20},
+		{MaxBytesForLevelMultiplier: 0.5},
+		{BloomFilterBitsPerKey: 100},
+		{NumLevels: 2, CompressionPerLevel: []string{"none", "none", "snappy"}},
+		{CompressionPerLevel: []string{"gzip"}},
+		{BottommostCompression: "gzip"},
+		{ZSTDMaxTrainBytes: 1 << 20},
+		{BottommostCompression: "zstd", ZSTDMaxDictBytes: 16 << 10, ZSTDMaxTrainBytes: 1 << 10},
+		{CompressionPerLevel: []string{"none", "lz4"}, ZSTDMaxDictBytes: 16 << 10},
+	}
+	for _, tuningOpts := range invalidOpts {
+		if err := tuningOpts.Validate(); err == nil {
+			t.Errorf("Expected an error for invalid tuning options: %+v", tuningOpts)
+		}
+	}
+
+	validOpts := &TuningOptions{MaxOpenFiles: -1, CompressionPerLevel: []string{"none", "LZ4"}}
+	if err := validOpts.Validate(); err != nil {
+		t.Error(err)
+	}
+	expOptsStr := "max_open_files=-1;compression_per_level=kNoCompression:kLZ4Compression;"
+	if optsStr := validOpts.optionsString(); optsStr != expOptsStr {
+		t.Errorf("Options string mismatch. Expected: %s, Actual: %s", expOptsStr, optsStr)
+	}
+
+	zstdOpts := &TuningOptions{CompressionPerLevel: []string{"none", "lz4"}, BottommostCompression: "ZSTD", ZSTDMaxDictBytes: 16384, ZSTDMaxTrainBytes: 1638400}
+	if err := zstdOpts.Validate(); err != nil {
+		t.Error(err)
+	}
+	expOptsStr = "compression_per_level=kNoCompression:kLZ4Compression;bottommost_compression=kZSTD;compression_opts=-14:32767:0:16384:1638400;"
+	if optsStr := zstdOpts.optionsString(); optsStr != expOptsStr {
+		t.Errorf("Options string mismatch. Expected: %s, Actual: %s", expOptsStr, optsStr)
+	}
+}
+
+func TestWALSyncPolicy(t *testing.T) {
+	if _, err := ParseWALSyncPolicy("fsync"); err == nil {
+		t.Error("Expected an error for an unknown WAL sync policy")
+	}
+	if policy, err := ParseWALSyncPolicy(" Periodic "); err != nil || policy != WALSyncPeriodic {
+		t.Errorf("Expected periodic WAL sync policy. Actual: %s, Error: %v", policy, err)
+	}
+
+	dbFolder := fmt.Sprintf("%s_%d", dbFolder, time.Now().UnixNano())
+	kvs, err := OpenDB(dbFolder, WithWALSyncPolicy(WALSyncPeriodic, time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer kvs.Close()
+	defer os.RemoveAll(dbFolder)
+	rdb := kvs.(*rocksDB)
+
+	if wo := rdb.writeOptions(serverpb.WALSync_DEFAULT_WAL_SYNC); wo != rdb.opts.writeOpts {
+		t.Error("Expected the writes of the periodic policy to not sync the WAL")
+	}
+	if wo := rdb.writeOptions(serverpb.WALSync_SYNC_WAL); wo != rdb.opts.syncWriteOpts {
+		t.Error("Expected the per write override to sync the WAL")
+	}
+
+	expDurabilities := map[serverpb.WALSync]serverpb.Durability{
+		serverpb.WALSync_SYNC_WAL:  serverpb.Durability_LOCAL_WAL_SYNCED,
+		serverpb.WALSync_ASYNC_WAL: serverpb.Durability_LOCAL_WAL_WRITTEN,
+	}
+	for walSync, expDurability := range expDurabilities {
+		key, value := "WALSyncKey_"+walSync.String(), "WALSyncValue"
+		if durability, err := rdb.PutWithWALSync(walSync, kvEntry(key, value)); err != nil {
+			t.Fatalf("Unable to PUT. Key: %s, Error: %v", key, err)
+		} else if durability != expDurability {
+			t.Errorf("Durability mismatch. Key: %s, Expected: %s, Actual: %s", key, expDurability, durability)
+		}
+		if readResults, err := rdb.Get([]byte(key)); err != nil {
+			t.Fatalf("Unable to GET. Key: %s, Error: %v", key, err)
+		} else if string(readResults[0].Value) != value {
+			t.Errorf("GET mismatch. Key: %s, Expected Value: %s, Actual Value: %s", key, value, readResults[0].Value)
+		}
+	}
+
+	if _, err := rdb.PutWithWALSync(serverpb.WALSync_ASYNC_WAL, kvEntry("WALSyncKey_Unsynced", "WALSyncValue")); err != nil {
+		t.Fatal(err)
+	}
+	latestChngNum, _ := rdb.GetLatestCommittedChangeNumber()
+	if syncedChngNum := rdb.GetSyncedChangeNumber(); syncedChngNum >= latestChngNum {
+		t.Errorf("Did not expect the async write to be synced. Synced: %d, Latest: %d", syncedChngNum, latestChngNum)
+	}
+	if syncedChngNum, err := rdb.SyncWAL(); err != nil || syncedChngNum != latestChngNum {
+		t.Errorf("Expected all the writes to be synced. Synced: %d, Latest: %d, Error: %v", syncedChngNum, latestChngNum, err)
+	}
+
+	// WAL is fsynced in the background once every interval
+	rdb.stopWALSyncer()
+	rdb.opts.walSyncInterval = 10 * time.Millisecond
+	rdb.startWALSyncer()
+	if _, err := rdb.PutWithWALSync(serverpb.WALSync_DEFAULT_WAL_SYNC, kvEntry("WALSyncKey_Periodic", "WALSyncValue")); err != nil {
+		t.Fatal(err)
+	}
+	latestChngNum, _ = rdb.GetLatestCommittedChangeNumber()
+	time.Sleep(100 * time.Millisecond)
+	if syncedChngNum := rdb.GetSyncedChangeNumber(); syncedChngNum != latestChngNum {
+		t.Errorf("Expected the write to be synced periodically. Synced: %d, Latest: %d", syncedChngNum, latestChngNum)
+	}
+}
+
 func TestPutAndGet(t *testing.T) {
 	numKeys := 10
 	for i := 1; i <= numKeys; i++ {
@@ -186,371 +322,1189 @@ func TestCompactionFilterOnExpiredKeys(t *testing.T) {
 	}
 }
 
-func TestPutTTLAndGet(t *testing.T) {
-	numIteration := 10
-	for i := 1; i <= numIteration; i++ {
-		key, value := fmt.Sprintf("KTTL%d", i), fmt.Sprintf("V%d", i)
-		if err := store.Put(
-			&serverpb.KVPair{Key: []byte(key), Value: []byte(value),
-				ExpireTS: uint64(time.Now().Add(2 * time.Second).Unix())}); err != nil {
-			t.Fatalf("Unable to PUT. Key: %s, Value: %s, Error: %v", key, value, err)
-		}
+func TestCompactionPolicy(t *testing.T) {
+	if _, err := ParseCompactionPolicy("all"); err == nil {
+		t.Error("Expected an error for an unknown compaction policy")
+	}
+	if policy, err := ParseCompactionPolicy(" Expired-And-Empty "); err != nil || policy != CompactionDropExpiredAndEmpty {
+		t.Errorf("Expected expired-and-empty compaction policy. Actual: %s, Error: %v", policy, err)
 	}
 
-	for i := 11; i <= 10+numIteration; i++ {
-		key, value := fmt.Sprintf("KTTL%d", i), fmt.Sprintf("V%d", i)
-		if err := store.Put(
-			&serverpb.KVPair{Key: []byte(key), Value: []byte(value),
-				ExpireTS: uint64(time.Now().Add(-2 * time.Second).Unix())}); err != nil {
-			t.Fatalf("Unable to PUT. Key: %s, Value: %s, Error: %v", key, value, err)
+	expireAt := uint64(time.Now().Add(-2 * time.Second).Unix())
+	for _, policy := range []CompactionPolicy{CompactionRetainAll, CompactionDropExpired, CompactionDropExpiredAndEmpty} {
+		dbFolder := fmt.Sprintf("%s_compaction_%s_%d", dbFolder, policy, time.Now().UnixNano())
+		kvs, err := OpenDB(dbFolder, WithCompactionPolicy(policy, 24*time.Hour))
+		if err != nil {
+			t.Fatal(err)
+		}
+		rdb := kvs.(*rocksDB)
+		expectNoError(t, rdb.Put(
+			&serverpb.KVPair{Key: []byte("expiredKey"), Value: []byte("val"), ExpireTS: expireAt},
+			&serverpb.KVPair{Key: []byte("emptyKey"), Value: []byte{}},
+			&serverpb.KVPair{Key: []byte("heldEmptyKey"), Value: []byte{}},
+			&serverpb.KVPair{Key: []byte("liveKey"), Value: []byte("val")},
+		))
+		expectNoError(t, rdb.PlaceLegalHold([]byte("held")))
+		expectNoError(t, rdb.CompactRange(nil))
+
+		expected := map[string]bool{
+			"expiredKey":   policy == CompactionRetainAll,
+			"emptyKey":     policy != CompactionDropExpiredAndEmpty,
+			"heldEmptyKey": true,
+			"liveKey":      true,
+		}
+		for key, retained := range expected {
+			cf := rdb.normalCF
+			if key == "expiredKey" {
+				cf = rdb.ttlCF
+			}
+			value, err := rdb.db.GetCF(rdb.opts.readOpts, cf, []byte(key))
+			if err != nil {
+				t.Fatalf("Unable to GET. Key: %s, Error: %v", key, err)
+			}
+			if value.Exists() != retained {
+				t.Errorf("Compaction policy: %s. Expected key: %s to be retained: %t", policy, key, retained)
+			}
+			value.Free()
 		}
+		kvs.Close()
+		os.RemoveAll(dbFolder)
 	}
+}
 
-	for i := 1; i <= numIteration; i++ {
-		key, expectedValue := fmt.Sprintf("KTTL%d", i), fmt.Sprintf("V%d", i)
-		if readResults, err := store.Get([]byte(key)); err != nil {
-			t.Fatalf("Unable to GET. Key: %s, Error: %v", key, err)
-		} else {
-			if string(readResults[0].Value) != expectedValue {
-				t.Errorf("GET mismatch. Key: %s, Expected Value: %s, Actual Value: %s", key, expectedValue, readResults[0].Value)
-			}
+type suffixCompactionFilter string
+
+func (sf suffixCompactionFilter) Name() string {
+	return "drop-suffix"
+}
+
+func (sf suffixCompactionFilter) Drop(key, _ []byte) bool {
+	return strings.HasSuffix(string(key), string(sf))
+}
+
+func TestCompactionFilters(t *testing.T) {
+	for _, spec := range []string{"events:", "=2024-01-01", "events:=01/01/2024"} {
+		if _, err := ParseDropPrefixAfter(spec); err == nil {
+			t.Errorf("Expected an error for the invalid compaction filter: %s", spec)
 		}
 	}
+	stale, err := ParseDropPrefixAfter("stale:=2020-01-01")
+	if err != nil {
+		t.Fatal(err)
+	}
 
-	for i := 11; i <= 10+numIteration; i++ {
-		key := fmt.Sprintf("KTTL%d", i)
-		if readResults, err := store.Get([]byte(key)); err != nil {
+	dbFolder := fmt.Sprintf("%s_compaction_filters_%d", dbFolder, time.Now().UnixNano())
+	kvs, err := OpenDB(dbFolder, WithCompactionFilter(stale),
+		WithCompactionFilter(DropPrefixAfter([]byte("fresh:"), time.Now().Add(time.Hour))),
+		WithCompactionFilter(suffixCompactionFilter(":tmp")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dbFolder)
+	defer kvs.Close()
+	rdb := kvs.(*rocksDB)
+	expireAt := uint64(time.Now().Add(time.Hour).Unix())
+	expectNoError(t, rdb.Put(
+		&serverpb.KVPair{Key: []byte("stale:1"), Value: []byte("val")},
+		&serverpb.KVPair{Key: []byte("stale:2"), Value: []byte("val"), ExpireTS: expireAt},
+		&serverpb.KVPair{Key: []byte("stale:held"), Value: []byte("val")},
+		&serverpb.KVPair{Key: []byte("fresh:1"), Value: []byte("val")},
+		&serverpb.KVPair{Key: []byte("live:tmp"), Value: []byte("val")},
+		&serverpb.KVPair{Key: []byte("live:1"), Value: []byte{}},
+	))
+	expectNoError(t, rdb.PlaceLegalHold([]byte("stale:held")))
+	expectNoError(t, rdb.CompactRange(nil))
+
+	expected := map[string]bool{
+		"stale:1":    false,
+		"stale:2":    false,
+		"stale:held": true,
+		"fresh:1":    true,
+		"live:tmp":   false,
+		"live:1":     true,
+	}
+	for key, retained := range expected {
+		cf := rdb.normalCF
+		if key == "stale:2" {
+			cf = rdb.ttlCF
+		}
+		value, err := rdb.db.GetCF(rdb.opts.readOpts, cf, []byte(key))
+		if err != nil {
 			t.Fatalf("Unable to GET. Key: %s, Error: %v", key, err)
-		} else {
-			if len(readResults) > 0 {
-				t.Errorf("GET mismatch post TTL Expiry. Key: %s, Expected Value: %s, Actual Value: %s", key, "nil", readResults[0].Value)
-			}
 		}
+		if value.Exists() != retained {
+			t.Errorf("Expected key: %s to be retained: %t", key, retained)
+		}
+		value.Free()
 	}
 }
 
-func TestPutEmptyValue(t *testing.T) {
-	key, val := "EmptyKey", ""
-	if err := store.Put(kvEntry(key, val)); err != nil {
-		t.Fatalf("Unable to PUT empty value. Key: %s", key)
+func TestIngestSST(t *testing.T) {
+	sstDir := fmt.Sprintf("%s_ingest_sst_%d", dbFolder, time.Now().UnixNano())
+	defer os.RemoveAll(sstDir)
+	builder, err := NewSSTBuilder(sstDir, 2)
+	if err != nil {
+		t.Fatal(err)
 	}
-
-	if res, err := store.Get([]byte(key)); err != nil {
-		t.Fatalf("Unable to GET empty value. Key: %s", key)
-	} else {
-		t.Logf("Got value: '%v'", res)
+	expireTS := uint64(time.Now().Add(time.Hour).Unix())
+	for _, kv := range []*serverpb.KVPair{
+		{Key: []byte("ingestKey2"), Value: []byte("stale")},
+		{Key: []byte("ingestKey1"), Value: []byte("val1")},
+		{Key: []byte("ingestTTLKey"), Value: []byte("ttlVal"), ExpireTS: expireTS},
+		{Key: []byte("ingestKey2"), Value: []byte("val2")},
+	} {
+		expectNoError(t, builder.Add(kv))
 	}
-
-	// update nil value for same key
-	if err := store.Put(&serverpb.KVPair{Key: []byte(key), Value: nil}); err != nil {
-		t.Fatalf("Unable to PUT empty value. Key: %s", key)
+	if err = builder.Add(&serverpb.KVPair{Value: []byte("val")}); err == nil {
+		t.Error("Expected an error for an empty key")
+	}
+	files, ttlFiles, err := builder.Finish()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 || len(ttlFiles) != 1 {
+		t.Fatalf("Expected 2 SST files and 1 TTL SST file, but got %v and %v", files, ttlFiles)
 	}
 
-	if res, err := store.Get([]byte(key)); err != nil {
-		t.Fatalf("Unable to GET empty value. Key: %s", key)
-	} else {
-		t.Logf("Got value: '%v'", res)
+	expectNoError(t, store.IngestSST(files, ttlFiles, false))
+	for key, value := range map[string]string{"ingestKey1": "val1", "ingestKey2": "val2", "ingestTTLKey": "ttlVal"} {
+		if vals, err := store.Get([]byte(key)); err != nil || len(vals) != 1 || string(vals[0].Value) != value {
+			t.Errorf("Expected value: %s for key: %s after ingestion. Values: %v, Error: %v", value, key, vals, err)
+		}
+	}
+	if vals, _ := store.Get([]byte("ingestTTLKey")); len(vals) == 1 && vals[0].ExpireTS != expireTS {
+		t.Errorf("Expected expiry: %d for the ingested TTL key, but got %d", expireTS, vals[0].ExpireTS)
 	}
 }
 
-func TestDelete(t *testing.T) {
-	key, val := "SomeKey", "SomeValue"
-	if err := store.Put(kvEntry(key, val)); err != nil {
-		t.Fatalf("Unable to PUT. Key: %s", key)
+func TestExport(t *testing.T) {
+	expireTS := uint64(time.Now().Add(time.Hour).Unix())
+	expectNoError(t, store.Put(
+		&serverpb.KVPair{Key: []byte("exportKey1"), Value: []byte("val1")},
+		&serverpb.KVPair{Key: []byte("exportKey2"), Value: []byte("val2"), ExpireTS: expireTS},
+		&serverpb.KVPair{Key: []byte("exportKey3"), Value: []byte("val3")},
+	))
+
+	exportDir := fmt.Sprintf("%s_export_%d", dbFolder, time.Now().UnixNano())
+	defer os.RemoveAll(exportDir)
+	files, numKeys, err := store.Export([]byte("exportKey1"), []byte("exportKey3"), serverpb.ExportFormat_BINARY, exportDir)
+	if err != nil {
+		t.Fatal(err)
 	}
-
-	if res, err := store.Get([]byte(key)); err != nil {
-		t.Fatalf("Unable to GET. Key: %s", key)
-	} else {
-		t.Logf("Got value: '%s'", string(res[0].Value))
+	if len(files) != 1 || numKeys != 2 {
+		t.Fatalf("Expected 2 keys exported into a single file, but got %d keys into %v", numKeys, files)
+	}
+	f, err := os.Open(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	rdr, err := storage.NewExportReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expKV := range []*serverpb.KVPair{
+		{Key: []byte("exportKey1"), Value: []byte("val1")},
+		{Key: []byte("exportKey2"), Value: []byte("val2"), ExpireTS: expireTS},
+	} {
+		if kv, err := rdr.Read(); err != nil || string(kv.Key) != string(expKV.Key) || string(kv.Value) != string(expKV.Value) || kv.ExpireTS != expKV.ExpireTS {
+			t.Errorf("Expected exported pair: %v, but got %v. Error: %v", expKV, kv, err)
+		}
+	}
+	if _, err = rdr.Read(); err != io.EOF {
+		t.Errorf("Expected only the keys within the range to be exported. Error: %v", err)
 	}
 
-	// delete key
-	if err := store.Delete([]byte(key)); err != nil {
-		t.Fatalf("Unable to delete Key: %s", key)
+	sstDir := fmt.Sprintf("%s_export_sst_%d", dbFolder, time.Now().UnixNano())
+	defer os.RemoveAll(sstDir)
+	if files, numKeys, err = store.Export([]byte("exportKey"), []byte("exportKey4"), serverpb.ExportFormat_SST, sstDir); err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 || numKeys != 3 {
+		t.Fatalf("Expected 3 keys exported into 2 SST files, but got %d keys into %v", numKeys, files)
 	}
 
-	if res, err := store.Get([]byte(key)); err != nil {
-		t.Fatalf("Got Exception while trying to GET deleted key value. Key: %s", key)
-	} else if len(res) != 0 {
-		t.Fatalf("Got Result while trying to GET deleted key value. Key: %s", key)
+	dbFolder := fmt.Sprintf("%s_export_ingest_%d", dbFolder, time.Now().UnixNano())
+	defer os.RemoveAll(dbFolder)
+	kvs, err := OpenDB(dbFolder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer kvs.Close()
+	expectNoError(t, kvs.(*rocksDB).IngestSST(files[:1], files[1:], false))
+	for key, value := range map[string]string{"exportKey1": "val1", "exportKey2": "val2", "exportKey3": "val3"} {
+		if vals, err := kvs.Get([]byte(key)); err != nil || len(vals) != 1 || string(vals[0].Value) != value {
+			t.Errorf("Expected value: %s for key: %s after ingesting the export. Values: %v, Error: %v", value, key, vals, err)
+		}
 	}
 }
 
-func TestGetLatestChangeNumber(t *testing.T) {
-	numInsert := 5
-	beforeChngNum, _ := store.GetLatestCommittedChangeNumber()
-	putKeys(t, numInsert, "aaKey", "aaVal", 0)
-	afterChngNum, _ := store.GetLatestCommittedChangeNumber()
-	actNumTrxns := afterChngNum - beforeChngNum
-	expNumTrxns := uint64(10)
-	if expNumTrxns != actNumTrxns {
-		t.Errorf("Mismatch in number of transactions. Expected: %d, Actual: %d", expNumTrxns, actNumTrxns)
+func TestRateLimit(t *testing.T) {
+	dbFolder := fmt.Sprintf("%s_rate_limit_%d", dbFolder, time.Now().UnixNano())
+	defer os.RemoveAll(dbFolder)
+	kvs, err := OpenDB(dbFolder, WithRateLimit(1<<20))
+	if err != nil {
+		t.Fatal(err)
 	}
-	beforeChngNum = afterChngNum
-	getKeys(t, numInsert, "aaKey", "aaVal")
-	afterChngNum, _ = store.GetLatestCommittedChangeNumber()
-	actNumTrxns = afterChngNum - beforeChngNum
-	if actNumTrxns != 0 {
-		t.Errorf("Expected no transactions to have occurred but found %d transactions", actNumTrxns)
+	defer kvs.Close()
+	rdb := kvs.(*rocksDB)
+	expectNoError(t, rdb.Put(&serverpb.KVPair{Key: []byte("rateLimitKey"), Value: []byte("val")}))
+	expectNoError(t, rdb.CompactRange(nil))
+	if vals, err := rdb.Get([]byte("rateLimitKey")); err != nil || len(vals) != 1 || string(vals[0].Value) != "val" {
+		t.Errorf("Expected the value of the key after compacting under the rate limit. Values: %v, Error: %v", vals, err)
 	}
 }
 
-func TestLoadChanges(t *testing.T) {
-	expNumTrxns, maxChngs := 3, 8
-	keyPrefix, valPrefix := "bbKey", "bbVal"
-	chngNum, _ := store.GetLatestCommittedChangeNumber()
-	chngNum++ // due to possible previous transaction
-	putKeys(t, expNumTrxns, keyPrefix, valPrefix, 0)
-	if chngs, err := store.LoadChanges(chngNum, maxChngs); err != nil {
-		t.Fatal(err)
-	} else {
-		expNumChngs, actNumChngs := 3, len(chngs)
-		if expNumChngs != actNumChngs {
-			t.Errorf("Incorrect number of changes retrieved. Expected: %d, Actual: %d", expNumChngs, actNumChngs)
-		}
-		firstChngNum := chngs[0].ChangeNumber
-		if firstChngNum != chngNum {
-			t.Errorf("Expected first change number to be %d but it is %d", chngNum, firstChngNum)
-		}
-		for i := 0; i < actNumChngs; i += 2 {
-			chng := chngs[i]
-			// t.Log(string(chng.SerialisedForm))
-			if chng.NumberOfTrxns != 2 {
-				t.Errorf("Expected only two transaction in this change but found %d transactions", chng.NumberOfTrxns)
-			}
-			trxnRec := chng.Trxns[1]
-			if trxnRec.Type != serverpb.TrxnRecord_Put {
-				t.Errorf("Expected transaction type to be Put but found %s", trxnRec.Type.String())
-			}
-			expKey, expVal := fmt.Sprintf("%s_%d", keyPrefix, i+1), fmt.Sprintf("%s_%d", valPrefix, i+1)
-			actKey, actVal := string(trxnRec.Key), string(trxnRec.Value)
-			if expKey != actKey {
-				t.Errorf("Key mismatch. Expected: %s, Actual: %s", expKey, actKey)
-			}
-			if expVal != actVal {
-				t.Errorf("Value mismatch. Expected: %s, Actual: %s", expVal, actVal)
-			}
+func TestSetMemtableOptions(t *testing.T) {
+	if err := store.SetMemtableOptions(&serverpb.MemtableOptions{}); err != errNoMemtableOptions {
+		t.Errorf("Expected an error when no memtable options are given, but got %v", err)
+	}
+	invalidOpts := []*serverpb.MemtableOptions{
+		{WriteBufferSize: 1 << 10},
+		{MaxWriteBufferNumber: 1},
+		{Level0SlowdownWritesTrigger: 40, Level0StopWritesTrigger: 20},
+	}
+	for _, mtOpts := range invalidOpts {
+		if err := store.SetMemtableOptions(mtOpts); err == nil {
+			t.Errorf("Expected an error for the invalid memtable options: %v", mtOpts)
 		}
 	}
+	expectNoError(t, store.SetMemtableOptions(&serverpb.MemtableOptions{
+		WriteBufferSize:                8 << 20,
+		MaxWriteBufferNumber:           4,
+		Level0FileNumCompactionTrigger: 8,
+		Level0SlowdownWritesTrigger:    30,
+		Level0StopWritesTrigger:        40,
+	}))
+	expectNoError(t, store.SetMemtableOptions(&serverpb.MemtableOptions{WriteBufferSize: 64 << 20}))
+	expectNoError(t, store.Put(&serverpb.KVPair{Key: []byte("memtableKey"), Value: []byte("val")}))
 }
 
-func TestSaveChanges(t *testing.T) {
-	numTrxns := 3
-	putKeyPrefix, putValPrefix := "ccKey", "ccVal"
-	putKeys(t, numTrxns, putKeyPrefix, putValPrefix, 0)
-	chngNum, _ := store.GetLatestCommittedChangeNumber()
-	chngNum++ // due to possible previous transaction
-	wbPutKeyPrefix, wbPutValPrefix := "ddKey", "ddVal"
-	chngs := make([]*serverpb.ChangeRecord, numTrxns)
-	for i := 0; i < numTrxns; i++ {
-		wb := gorocksdb.NewWriteBatch()
-		defer wb.Destroy()
-		ks, vs := fmt.Sprintf("%s_%d", wbPutKeyPrefix, i+1), fmt.Sprintf("%s_%d", wbPutValPrefix, i+1)
-		wb.Put([]byte(ks), []byte(vs))
-		delKs := fmt.Sprintf("%s_%d", putKeyPrefix, i+1)
-		wb.Delete([]byte(delKs))
-		chngs[i] = store.toChangeRecord(wb, chngNum)
-		chngNum++
+func TestCacheOptions(t *testing.T) {
+	invalidOpts := []*CacheOptions{
+		{BlockCacheSize: 8 << 20, PinL0FilterAndIndexBlocks: true},
+		{CacheIndexAndFilterBlocks: true},
+	}
+	for _, co := range invalidOpts {
+		if err := co.Validate(); err == nil {
+			t.Errorf("Expected an error for the invalid cache options: %+v", co)
+		}
 	}
-	expChngNum := chngNum
 
-	if actChngNum, err := store.SaveChanges(chngs); err != nil {
+	cacheOpts := &CacheOptions{BlockCacheSize: 8 << 20, CacheIndexAndFilterBlocks: true, PinL0FilterAndIndexBlocks: true}
+	key, value := []byte("cacheKey"), []byte("cacheVal")
+	dbFolder := fmt.Sprintf("%s_cache_%d", dbFolder, time.Now().UnixNano())
+	defer os.RemoveAll(dbFolder)
+	kvs, err := OpenDB(dbFolder, WithCacheOptions(cacheOpts))
+	if err != nil {
 		t.Fatal(err)
-	} else {
-		if expChngNum != actChngNum {
-			t.Errorf("Change numbers mismatch. Expected: %d, Actual: %d", expChngNum, actChngNum)
-		}
-		getKeys(t, numTrxns, wbPutKeyPrefix, wbPutValPrefix)
-		noKeys(t, numTrxns, putKeyPrefix)
+	}
+	defer kvs.Close()
+	rdb := kvs.(*rocksDB)
+	expectNoError(t, rdb.Put(&serverpb.KVPair{Key: key, Value: value}))
+	expectNoError(t, rdb.CompactRange(nil))
+	if res, err := rdb.Get(key); err != nil || len(res) != 1 || !bytes.Equal(res[0].Value, value) {
+		t.Errorf("Expected value: %q for key: %q, but got %v. Error: %v", value, key, res, err)
 	}
 }
 
-func TestIteratorPrefixScan(t *testing.T) {
-	numTrxns := 3
-	keyPrefix1, valPrefix1 := "aaPrefixKey", "aaPrefixVal"
-	putKeys(t, numTrxns, keyPrefix1, valPrefix1, 0)
-	keyPrefix2, valPrefix2 := "bbPrefixKey", "bbPrefixVal"
-	putKeys(t, numTrxns, keyPrefix2, valPrefix2, 0)
-	keyPrefix3, valPrefix3 := "ccPrefixKey", "ccPrefixVal"
-	putKeys(t, numTrxns, keyPrefix3, valPrefix3, 0)
-
-	prefix := []byte("bbPrefix")
-	itOpts, err := storage.NewIteratorOptions(
-		storage.IterationPrefixKey(prefix),
-	)
+func TestNamespaceCacheStats(t *testing.T) {
+	dbFolder := fmt.Sprintf("%s_ns_cache_%d", dbFolder, time.Now().UnixNano())
+	defer os.RemoveAll(dbFolder)
+	kvs, err := OpenDB(dbFolder, WithNamespaceCacheStats([]string{"user:", "user:session:", "order:"}, 2))
 	if err != nil {
 		t.Fatal(err)
 	}
-	it := store.Iterate(itOpts)
-	defer it.Close()
+	defer kvs.Close()
+	rdb := kvs.(*rocksDB)
 
-	actCount := 0
-	for it.HasNext() {
-		entry := it.Next()
-		actCount++
-		if strings.HasPrefix(string(entry.Key), string(prefix)) {
-			t.Logf("Key: %s Value: %s\n", entry.Key, entry.Value)
-		} else {
-			t.Errorf("Expected key %s to have prefix %s", entry.Key, prefix)
+	for _, key := range []string{"user:1", "user:session:1", "order:1", "other:1"} {
+		expectNoError(t, rdb.Put(&serverpb.KVPair{Key: []byte(key), Value: []byte("nsVal")}))
+	}
+	expectNoError(t, rdb.CompactRange(nil))
+	for i := 0; i < 10; i++ {
+		if _, err = rdb.Get([]byte("user:1")); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = rdb.Get([]byte("user:session:1"), []byte("other:1")); err != nil {
+			t.Fatal(err)
 		}
 	}
 
-	if err := it.Err(); err != nil {
+	stats, err := rdb.Stats()
+	if err != nil {
 		t.Fatal(err)
 	}
+	nsStats := stats.NamespaceCacheStats
+	if len(nsStats) != 3 {
+		t.Fatalf("Expected stats of 3 namespaces, but got %v", nsStats)
+	}
+	for i, exp := range []struct {
+		namespace string
+		reads     uint64
+	}{{"order:", 0}, {"user:", 5}, {"user:session:", 5}} {
+		ncs := nsStats[i]
+		switch {
+		case ncs.Namespace != exp.namespace || ncs.NumSampledReads != exp.reads:
+			t.Errorf("Expected %d sampled reads of namespace %q, but got %v", exp.reads, exp.namespace, ncs)
+		case exp.reads > 0 && ncs.BlockCacheHits+ncs.BlockReads == 0:
+			t.Errorf("Expected the block lookups of namespace %q to be accounted, but got %v", exp.namespace, ncs)
+		case ncs.BlockCacheHitRate < 0 || ncs.BlockCacheHitRate > 1:
+			t.Errorf("Expected the block cache hit rate of namespace %q to be a ratio, but got %v", exp.namespace, ncs)
+		}
+	}
+}
 
-	if numTrxns != actCount {
-		t.Errorf("Expected %d records with prefix: %s. But got %d records.", numTrxns, prefix, actCount)
+func TestGroupCommit(t *testing.T) {
+	dbFolder := fmt.Sprintf("%s_group_commit_%d", dbFolder, time.Now().UnixNano())
+	defer os.RemoveAll(dbFolder)
+	kvs, err := OpenDB(dbFolder, WithSyncWrites(), WithGroupCommit(5*time.Millisecond, 16))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer kvs.Close()
+	rdb := kvs.(*rocksDB)
+
+	numWriters := 50
+	var wg sync.WaitGroup
+	for i := 0; i < numWriters; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			kv := &serverpb.KVPair{Key: []byte(fmt.Sprintf("GCKey%d", i)), Value: []byte(fmt.Sprintf("GCVal%d", i))}
+			if i&1 == 1 {
+				kv.ExpireTS = uint64(time.Now().Add(time.Hour).Unix())
+			}
+			if err := rdb.Put(kv); err != nil {
+				t.Errorf("Unable to PUT. Key: %s, Error: %v", kv.Key, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	for i := 0; i < numWriters; i++ {
+		key, expVal := fmt.Sprintf("GCKey%d", i), fmt.Sprintf("GCVal%d", i)
+		if res, err := rdb.Get([]byte(key)); err != nil || len(res) != 1 || string(res[0].Value) != expVal {
+			t.Errorf("Expected value: %s for key: %s, but got %v. Error: %v", expVal, key, res, err)
+		}
+	}
+
+	expectNoError(t, rdb.Delete([]byte("GCKey0")))
+	if res, err := rdb.Get([]byte("GCKey0")); err != nil || len(res) != 0 {
+		t.Errorf("Expected the key GCKey0 to be deleted, but got %v. Error: %v", res, err)
+	}
+
+	wb1, wb2 := gorocksdb.NewWriteBatch(), gorocksdb.NewWriteBatch()
+	defer wb1.Destroy()
+	defer wb2.Destroy()
+	wb1.Put([]byte("k1"), []byte("v1"))
+	wb2.Put([]byte("k2"), []byte("v2"))
+	wb2.Delete([]byte("k3"))
+	merged := gorocksdb.WriteBatchFrom(mergeWriteBatches([]*commitReq{{wb: wb1}, {wb: wb2}}))
+	defer merged.Destroy()
+	if merged.Count() != 3 {
+		t.Errorf("Expected the merged batch to hold 3 operations. Actual: %d", merged.Count())
 	}
 }
 
-func TestIteratorFromStartKeyWithTTL(t *testing.T) {
-	numTrxns := 3
-	keyPrefix1, valPrefix1 := "TTLStartKeyAA", "aaStartVal"
-	putKeys(t, numTrxns, keyPrefix1, valPrefix1, 0)
-	keyPrefix2, valPrefix2 := "TTLStartKeyBB", "bbStartVal"
-	putKeys(t, numTrxns, keyPrefix2, valPrefix2, 0)
-	keyPrefix3, valPrefix3 := "TTLStartKeyCC", "ccStartVal"
-	putKeys(t, numTrxns, keyPrefix3, valPrefix3, time.Now().Add(2*time.Second).Unix())
-	keyPrefix4, valPrefix4 := "TTLStartKeyDD", "ccStartVal"
-	putKeys(t, numTrxns, keyPrefix4, valPrefix4, time.Now().Add(-2*time.Second).Unix())
+func TestGroupCommitClose(t *testing.T) {
+	dbFolder := fmt.Sprintf("%s_group_commit_close_%d", dbFolder, time.Now().UnixNano())
+	defer os.RemoveAll(dbFolder)
+	kvs, err := OpenDB(dbFolder, WithSyncWrites(), WithGroupCommit(5*time.Millisecond, 16))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rdb := kvs.(*rocksDB)
 
-	prefix, startKey := []byte("TTLStartKey"), []byte("TTLStartKeyBB_2")
-	itOpts, err := storage.NewIteratorOptions(
-		storage.IterationPrefixKey(prefix),
-		storage.IterationStartKey(startKey),
-	)
+	// Writers keep writing until the store is closed underneath them
+	var wg sync.WaitGroup
+	var numWrites int64
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; ; j++ {
+				kv := &serverpb.KVPair{Key: []byte(fmt.Sprintf("GCCloseKey%d_%d", i, j)), Value: []byte("GCCloseVal")}
+				if err := rdb.Put(kv); err != nil {
+					if err != errGroupCommitStopped {
+						t.Errorf("Expected the writes to be rejected once the store is closed, but got %v", err)
+					}
+					return
+				}
+				atomic.AddInt64(&numWrites, 1)
+			}
+		}(i)
+	}
+	time.Sleep(100 * time.Millisecond)
+	expectNoError(t, kvs.Close())
+
+	writersDone := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(writersDone)
+	}()
+	select {
+	case <-writersDone:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the writers to return once the store is closed")
+	}
+	if atomic.LoadInt64(&numWrites) == 0 {
+		t.Error("Expected some writes to be committed before the store is closed")
+	}
+	if err = rdb.Put(&serverpb.KVPair{Key: []byte("GCCloseKey"), Value: []byte("GCCloseVal")}); err != errGroupCommitStopped {
+		t.Errorf("Expected the write following the closing of the store to be rejected, but got %v", err)
+	}
+}
+
+func TestRepair(t *testing.T) {
+	dbFolder := fmt.Sprintf("%s_repair_%d", dbFolder, time.Now().UnixNano())
+	defer os.RemoveAll(dbFolder)
+	kvs, err := OpenDB(dbFolder)
 	if err != nil {
 		t.Fatal(err)
 	}
-	it := store.Iterate(itOpts)
-	defer it.Close()
+	expectNoError(t, kvs.Put(
+		&serverpb.KVPair{Key: []byte("repairKey"), Value: []byte("val")},
+		&serverpb.KVPair{Key: []byte("repairTTLKey"), Value: []byte("val"), ExpireTS: uint64(time.Now().Add(time.Hour).Unix())},
+	))
+	expectNoError(t, kvs.(*rocksDB).CompactRange(nil))
+	kvs.Close()
 
-	actCount := 0
-	for it.HasNext() {
-		entry := it.Next()
-		actCount++
-		if strings.HasPrefix(string(entry.Key), string(prefix)) {
-			t.Logf("Key: %s Value: %s\n", entry.Key, entry.Value)
-		} else {
-			t.Errorf("Expected key %s to have prefix %s", entry.Key, prefix)
+	report, err := Repair(dbFolder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.NumFilesVerified == 0 {
+		t.Error("Expected the live SST files to be verified")
+	}
+	if len(report.LostFiles) > 0 || len(report.CorruptRanges) > 0 {
+		t.Errorf("Expected a healthy store. Lost files: %v, Corrupt ranges: %v", report.LostFiles, report.CorruptRanges)
+	}
+
+	if kvs, err = OpenDB(dbFolder); err != nil {
+		t.Fatal(err)
+	}
+	defer kvs.Close()
+	for _, key := range []string{"repairKey", "repairTTLKey"} {
+		if vals, err := kvs.Get([]byte(key)); err != nil || len(vals) != 1 || string(vals[0].Value) != "val" {
+			t.Errorf("Expected key: %s to survive the repair. Values: %v, Error: %v", key, vals, err)
 		}
 	}
+}
 
-	expCount := 5
-	if expCount != actCount {
-		t.Errorf("Expected %d records with prefix: %s, start key: %s. But got %d records.", expCount, prefix, startKey, actCount)
+func TestScrubbing(t *testing.T) {
+	dbFolder := fmt.Sprintf("%s_scrub_%d", dbFolder, time.Now().UnixNano())
+	defer os.RemoveAll(dbFolder)
+	kvs, err := OpenDB(dbFolder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer kvs.Close()
+	rdb := kvs.(*rocksDB)
+	expectNoError(t, rdb.Put(
+		&serverpb.KVPair{Key: []byte("scrubKey"), Value: []byte("val")},
+		&serverpb.KVPair{Key: []byte("scrubTTLKey"), Value: []byte("val"), ExpireTS: uint64(time.Now().Add(time.Hour).Unix())},
+	))
+	corruptKey, corruptVal := []byte("scrubCorruptKey"), []byte("not msgpack")
+	wo := gorocksdb.NewDefaultWriteOptions()
+	defer wo.Destroy()
+	expectNoError(t, rdb.db.PutCF(wo, rdb.ttlCF, corruptKey, corruptVal))
+
+	// Corrupt entries are only reported without any handler
+	sc := &scrubber{rdb: rdb, keysPerSec: 1000, quarantine: true, passStart: time.Now()}
+	sc.step()
+	sc.step()
+	if sc.cfIdx != 0 || sc.cursor != nil {
+		t.Errorf("Expected the scrubber to complete its pass. Column family: %d, Cursor: %q", sc.cfIdx, sc.cursor)
+	}
+	if unchanged, err := rdb.entryUnchanged(rdb.ttlCF, corruptKey, corruptVal); err != nil || !unchanged {
+		t.Errorf("Expected the corrupt key to be left as is without any handler. Error: %v", err)
+	}
+
+	var reported []*serverpb.QuarantinedRecord
+	rdb.OnCorruptEntry(func(rec *serverpb.QuarantinedRecord) error {
+		reported = append(reported, rec)
+		_, err := rdb.Quarantine(rec)
+		return err
+	})
+	sc.step()
+	sc.step()
+	if len(reported) != 1 || string(reported[0].Key) != string(corruptKey) {
+		t.Errorf("Expected the corrupt key to be reported to the handler, but got %v", reported)
 	}
 
+	for _, key := range []string{"scrubKey", "scrubTTLKey"} {
+		if vals, err := rdb.Get([]byte(key)); err != nil || len(vals) != 1 || string(vals[0].Value) != "val" {
+			t.Errorf("Expected key: %s to survive scrubbing. Values: %v, Error: %v", key, vals, err)
+		}
+	}
+	if vals, err := rdb.Get(corruptKey); err != nil || len(vals) != 0 {
+		t.Errorf("Expected the corrupt key to be quarantined. Values: %v, Error: %v", vals, err)
+	}
+	recs, err := rdb.ListQuarantinedRecords()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recs) != 1 || recs[0].Source != storage.QuarantineSourceScrubber || string(recs[0].Key) != string(corruptKey) ||
+		string(recs[0].Value) != string(corruptVal) || recs[0].Reason == "" {
+		t.Errorf("Expected the corrupt value to be retained as a quarantined record, but got %v", recs)
+	}
 }
 
-func TestTTLIteratorWithoutPrefix(t *testing.T) {
-	numTrxns := 3
-	keyPrefix4, valPrefix4 := "TTLStartKeyDD", "ccStartVal"
-	putKeys(t, numTrxns, keyPrefix4, valPrefix4, time.Now().Add(-2*time.Second).Unix())
-	itOpts, err := storage.NewIteratorOptions()
+func TestSaveChangesWithQuarantine(t *testing.T) {
+	dbFolder := fmt.Sprintf("%s_quarantine_%d", dbFolder, time.Now().UnixNano())
+	defer os.RemoveAll(dbFolder)
+	kvs, err := OpenDB(dbFolder)
 	if err != nil {
 		t.Fatal(err)
 	}
-	it := store.Iterate(itOpts)
-	defer it.Close()
-	for it.HasNext() {
-		entry := it.Next()
-		if hlc.InThePast(entry.ExpireTS) {
-			t.Errorf("Got Expired Key: %s Value: %s\n", entry.Key, entry.Value)
-		} else {
-			t.Logf("Key: %s Value: %s Expiry %v\n", entry.Key, entry.Value, entry.ExpireTS)
+	defer kvs.Close()
+	rdb := kvs.(*rocksDB)
+
+	chngNum, _ := rdb.GetLatestAppliedChangeNumber()
+	chngs := make([]*serverpb.ChangeRecord, 3)
+	for i := range chngs {
+		chngNum++
+		wb := gorocksdb.NewWriteBatch()
+		defer wb.Destroy()
+		wb.Put([]byte(fmt.Sprintf("qKey%d", i)), []byte("qVal"))
+		chngs[i] = rdb.toChangeRecord(wb, chngNum)
+	}
+	// Truncates the value of the second change, as if malformed by the
+	// master, hence along with its checksum
+	malformed := chngs[1].SerialisedForm
+	chngs[1].SerialisedForm = malformed[:len(malformed)-1]
+	storage.SetChangeChecksum(chngs[1])
+
+	actChngNum, err := rdb.SaveChanges(chngs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actChngNum != chngNum {
+		t.Errorf("Expected the changes to be applied upto %d, but got %d", chngNum, actChngNum)
+	}
+	if latestChngNum, _ := rdb.GetLatestAppliedChangeNumber(); latestChngNum != chngNum {
+		t.Errorf("Expected the quarantined change to consume its change number. Expected: %d, Actual: %d", chngNum, latestChngNum)
+	}
+	for i, present := range []bool{true, false, true} {
+		if vals, err := rdb.Get([]byte(fmt.Sprintf("qKey%d", i))); err != nil || (len(vals) == 1) != present {
+			t.Errorf("Expected presence of key: qKey%d to be %t. Values: %v, Error: %v", i, present, vals, err)
 		}
 	}
+	recs, err := rdb.ListQuarantinedRecords()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recs) != 1 || recs[0].Source != storage.QuarantineSourceReplication || recs[0].ChangeNumber != chngs[1].ChangeNumber {
+		t.Errorf("Expected the malformed change to be quarantined, but got %v", recs)
+	}
 }
 
-func TestIteratorFromStartKey(t *testing.T) {
-	numTrxns := 3
-	keyPrefix1, valPrefix1 := "StartKeyAA", "aaStartVal"
-	putKeys(t, numTrxns, keyPrefix1, valPrefix1, 0)
-	keyPrefix2, valPrefix2 := "StartKeyBB", "bbStartVal"
-	putKeys(t, numTrxns, keyPrefix2, valPrefix2, 0)
-	keyPrefix3, valPrefix3 := "StartKeyCC", "ccStartVal"
-	putKeys(t, numTrxns, keyPrefix3, valPrefix3, 0)
+func TestSaveChangesWithChecksumMismatch(t *testing.T) {
+	dbFolder := fmt.Sprintf("%s_checksum_%d", dbFolder, time.Now().UnixNano())
+	defer os.RemoveAll(dbFolder)
+	kvs, err := OpenDB(dbFolder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer kvs.Close()
+	rdb := kvs.(*rocksDB)
 
-	prefix, startKey := []byte("StartKey"), []byte("StartKeyBB_2")
-	itOpts, err := storage.NewIteratorOptions(
-		storage.IterationPrefixKey(prefix),
-		storage.IterationStartKey(startKey),
-	)
+	chngNum, _ := rdb.GetLatestAppliedChangeNumber()
+	chngs := make([]*serverpb.ChangeRecord, 2)
+	for i := range chngs {
+		chngNum++
+		wb := gorocksdb.NewWriteBatch()
+		defer wb.Destroy()
+		wb.Put([]byte(fmt.Sprintf("crcKey%d", i)), []byte("crcVal"))
+		chngs[i] = rdb.toChangeRecord(wb, chngNum)
+	}
+	// Flips a bit of the value of the second change, as if in transit
+	corrupt := chngs[1].SerialisedForm
+	corrupt[len(corrupt)-1] ^= 1
+
+	actChngNum, err := rdb.SaveChanges(chngs)
+	if !errors.Is(err, storage.ErrChangeChecksumMismatch) || actChngNum != chngs[0].ChangeNumber {
+		t.Errorf("Expected the corrupt change to be rejected after applying upto %d, but got %d. Error: %v", chngs[0].ChangeNumber, actChngNum, err)
+	}
+	if recs, err := rdb.ListQuarantinedRecords(); err != nil || len(recs) != 0 {
+		t.Errorf("Expected the corrupt change not to be quarantined, but got %v. Error: %v", recs, err)
+	}
+	if vals, err := rdb.Get([]byte("crcKey1")); err != nil || len(vals) != 0 {
+		t.Errorf("Expected the corrupt change not to be applied, but got %v. Error: %v", vals, err)
+	}
+
+	// Change fetched again is applied
+	corrupt[len(corrupt)-1] ^= 1
+	if actChngNum, err = rdb.SaveChanges(chngs); err != nil || actChngNum != chngNum {
+		t.Errorf("Expected the changes to be applied upto %d, but got %d. Error: %v", chngNum, actChngNum, err)
+	}
+}
+
+func TestSaveChangesWithApplyPipeline(t *testing.T) {
+	dbFolder := fmt.Sprintf("%s_pipeline_%d", dbFolder, time.Now().UnixNano())
+	defer os.RemoveAll(dbFolder)
+	kvs, err := OpenDB(dbFolder, WithApplyPipeline(3))
 	if err != nil {
 		t.Fatal(err)
 	}
-	it := store.Iterate(itOpts)
-	defer it.Close()
+	defer kvs.Close()
+	rdb := kvs.(*rocksDB)
 
-	actCount := 0
-	for it.HasNext() {
-		entry := it.Next()
-		actCount++
-		if strings.HasPrefix(string(entry.Key), string(prefix)) {
-			t.Logf("Key: %s Value: %s\n", entry.Key, entry.Value)
-		} else {
-			t.Errorf("Expected key %s to have prefix %s", entry.Key, prefix)
-		}
+	chngNum, _ := rdb.GetLatestAppliedChangeNumber()
+	chngs := make([]*serverpb.ChangeRecord, 40)
+	for i := range chngs {
+		wb := gorocksdb.NewWriteBatch()
+		defer wb.Destroy()
+		wb.Put([]byte(fmt.Sprintf("pipeKey%d", i)), []byte(fmt.Sprintf("pipeVal%d", i)))
+		wb.Put([]byte(fmt.Sprintf("pipeCtr%d", i)), []byte("1"))
+		chngs[i] = rdb.toChangeRecord(wb, chngNum+1)
+		chngNum += 2
 	}
+	// Truncates the value of a change, which is quarantined in its order
+	malformed := chngs[25].SerialisedForm
+	chngs[25].SerialisedForm = malformed[:len(malformed)-1]
+	storage.SetChangeChecksum(chngs[25])
 
-	expCount := 5
-	if expCount != actCount {
-		t.Errorf("Expected %d records with prefix: %s, start key: %s. But got %d records.", expCount, prefix, startKey, actCount)
+	if _, err = rdb.SaveChanges(chngs[:10]); err != nil {
+		t.Fatal(err)
+	}
+	// Changes already applied are skipped when retried along with the rest
+	actChngNum, err := rdb.SaveChanges(chngs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latestChngNum, _ := rdb.GetLatestAppliedChangeNumber(); actChngNum != chngNum || latestChngNum != chngNum {
+		t.Errorf("Expected the changes to be applied upto %d. Returned: %d, Latest: %d", chngNum, actChngNum, latestChngNum)
+	}
+	for i := range chngs {
+		vals, err := rdb.Get([]byte(fmt.Sprintf("pipeKey%d", i)))
+		switch {
+		case err != nil:
+			t.Error(err)
+		case i == 25 && len(vals) != 0:
+			t.Errorf("Expected the malformed change to not be applied. Values: %v", vals)
+		case i != 25 && (len(vals) != 1 || string(vals[0].Value) != fmt.Sprintf("pipeVal%d", i)):
+			t.Errorf("Expected key: pipeKey%d to be applied. Values: %v", i, vals)
+		}
+	}
+	if recs, err := rdb.ListQuarantinedRecords(); err != nil || len(recs) != 1 || recs[0].ChangeNumber != chngs[25].ChangeNumber {
+		t.Errorf("Expected the malformed change to be quarantined. Records: %v, Error: %v", recs, err)
 	}
 }
 
-// Following test can be removed once DKV supports bulk writes
-func TestGetUpdatesFromSeqNumForBatches(t *testing.T) {
-	beforeSeq := store.db.GetLatestSequenceNumber()
+func TestSaveChangesWithReplicatedKeyPrefixes(t *testing.T) {
+	dbFolder := fmt.Sprintf("%s_filtered_%d", dbFolder, time.Now().UnixNano())
+	defer os.RemoveAll(dbFolder)
+	kvs, err := OpenDB(dbFolder, WithReplicatedKeyPrefixes([]string{"edge:"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer kvs.Close()
+	rdb := kvs.(*rocksDB)
 
-	expNumBatchTrxns := 3
-	numTrxnsPerBatch := 2
-	expNumTrxns := expNumBatchTrxns * numTrxnsPerBatch
-	for i := 1; i <= expNumBatchTrxns; i++ {
-		k, v := fmt.Sprintf("bKey_%d", i), fmt.Sprintf("bVal_%d", i)
+	chngNum, _ := rdb.GetLatestAppliedChangeNumber()
+	keySets := [][]string{{"edge:1", "core:1"}, {"core:2"}, {"edge:2", "edge:3"}}
+	var chngs []*serverpb.ChangeRecord
+	for _, keys := range keySets {
 		wb := gorocksdb.NewWriteBatch()
-		wb.Put([]byte(k), []byte(v))
-		wb.Delete([]byte(k))
-		wo := gorocksdb.NewDefaultWriteOptions()
-		wo.SetSync(true)
-		if err := store.db.Write(wo, wb); err != nil {
-			t.Fatal(err)
+		defer wb.Destroy()
+		for _, key := range keys {
+			wb.Put([]byte(key), []byte("val_"+key))
 		}
-		wb.Destroy()
-		wo.Destroy()
+		chngs = append(chngs, rdb.toChangeRecord(wb, chngNum+1))
+		chngNum += uint64(len(keys))
 	}
 
-	afterSeq := store.db.GetLatestSequenceNumber()
-	numTrxns := int(afterSeq - beforeSeq)
-	if numTrxns != expNumTrxns {
-		t.Errorf("Incorrect number of transactions reported. Expected: %d, Actual: %d", expNumTrxns, numTrxns)
+	actChngNum, err := rdb.SaveChanges(chngs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actChngNum != chngNum {
+		t.Errorf("Expected the changes to be applied upto %d, but got %d", chngNum, actChngNum)
+	}
+	if latestChngNum, _ := rdb.GetLatestAppliedChangeNumber(); latestChngNum != chngNum {
+		t.Errorf("Expected the filtered changes to consume their change numbers. Expected: %d, Actual: %d", chngNum, latestChngNum)
+	}
+	for key, present := range map[string]bool{"edge:1": true, "edge:2": true, "edge:3": true, "core:1": false, "core:2": false} {
+		if vals, err := rdb.Get([]byte(key)); err != nil || (len(vals) == 1) != present {
+			t.Errorf("Expected presence of key: %s to be %t. Values: %v, Error: %v", key, present, vals, err)
+		}
 	}
+}
 
-	startSeq := 1 + beforeSeq // This is done to remove previous transaction if any
-	if trxnIter, err := store.db.GetUpdatesSince(startSeq); err != nil {
+func TestActiveActiveReplication(t *testing.T) {
+	var peers []*rocksDB
+	for _, originID := range []string{"dc1", "dc2"} {
+		peerFolder := fmt.Sprintf("%s_%s_%d", dbFolder, originID, time.Now().UnixNano())
+		defer os.RemoveAll(peerFolder)
+		kvs, err := OpenDB(peerFolder, WithOriginID(originID))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer kvs.Close()
+		peers = append(peers, kvs.(*rocksDB))
+	}
+	dc1, dc2 := peers[0], peers[1]
+
+	// Conflicting writes on either side, with the writes of dc2 made later
+	if err := dc1.Put(kvEntry("AAKey1", "dc1"), kvEntry("AAKey2", "dc1"), kvEntry("AAKey3", "dc1")); err != nil {
 		t.Fatal(err)
-	} else {
-		defer trxnIter.Destroy()
-		for trxnIter.Valid() {
-			wb, _ := trxnIter.GetBatch()
-			numTrxnsPerWb := wb.Count()
-			if numTrxnsPerWb != numTrxnsPerBatch {
-				t.Errorf("Incorrect number of transactions per batch. Expected: %d, Actual: %d", numTrxnsPerBatch, numTrxnsPerWb)
+	}
+	if err := dc2.Put(kvEntry("AAKey2", "dc2")); err != nil {
+		t.Fatal(err)
+	}
+	if err := dc2.Delete([]byte("AAKey3")); err != nil {
+		t.Fatal(err)
+	}
+	if err := dc1.Put(kvEntry("AAKey4", "dc1")); err != nil {
+		t.Fatal(err)
+	}
+
+	replicate := func(from, to *rocksDB, peerID string) {
+		fromChngNum, _ := to.GetLatestPeerChangeNumber(peerID)
+		chngs, err := from.LoadChanges(fromChngNum+1, 100)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = to.ApplyPeerChanges(peerID, chngs, nil); err != nil {
+			t.Fatal(err)
+		}
+		// Applying the changes again is a no-op
+		if _, err = to.ApplyPeerChanges(peerID, chngs, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	replicate(dc1, dc2, "dc1")
+	replicate(dc2, dc1, "dc2")
+	// Writes of dc1 applied by dc2 return to dc1, which ignores them
+	replicate(dc1, dc2, "dc1")
+
+	expected := map[string]string{"AAKey1": "dc1", "AAKey2": "dc2", "AAKey3": "", "AAKey4": "dc1"}
+	for _, peer := range peers {
+		for key, val := range expected {
+			vals, err := peer.Get([]byte(key))
+			switch {
+			case err != nil:
+				t.Fatal(err)
+			case val == "" && len(vals) != 0:
+				t.Errorf("Expected key: %s to be deleted on %s, but got %v", key, peer.opts.originID, vals)
+			case val != "" && (len(vals) != 1 || string(vals[0].Value) != val):
+				t.Errorf("Value mismatch for key: %s on %s. Expected: %s, Actual: %v", key, peer.opts.originID, val, vals)
 			}
-			wbIter := wb.NewIterator()
-			for wbIter.Next() {
-				wbr := wbIter.Record()
-				// t.Logf("Type: %v, Key: %s, Val: %s", wbr.Type, wbr.Key, wbr.Value)
-				switch wbr.Type {
-				case 1: // Put
-					if !strings.HasPrefix(string(wbr.Key), "bKey_") {
-						t.Errorf("Invalid key for PUT record. Value: %s", wbr.Key)
-					}
-					if !strings.HasPrefix(string(wbr.Value), "bVal_") {
-						t.Errorf("Invalid value inside write batch record for key: %s. Value: %s", wbr.Key, wbr.Value)
+		}
+	}
+
+	// Local writes made after applying the writes of the peer supersede them
+	if err := dc1.Put(kvEntry("AAKey2", "dc1-again")); err != nil {
+		t.Fatal(err)
+	}
+	replicate(dc1, dc2, "dc1")
+	if vals, _ := dc2.Get([]byte("AAKey2")); len(vals) != 1 || string(vals[0].Value) != "dc1-again" {
+		t.Errorf("Expected the later write of dc1 to be applied, but got %v", vals)
+	}
+}
+
+func TestPutTTLAndGet(t *testing.T) {
+	numIteration := 10
+	for i := 1; i <= numIteration; i++ {
+		key, value := fmt.Sprintf("KTTL%d", i), fmt.Sprintf("V%d", i)
+		if err := store.Put(
+			&serverpb.KVPair{Key: []byte(key), Value: []byte(value),
+				ExpireTS: uint64(time.Now().Add(2 * time.Second).Unix())}); err != nil {
+			t.Fatalf("Unable to PUT. Key: %s, Value: %s, Error: %v", key, value, err)
+		}
+	}
+
+	for i := 11; i <= 10+numIteration; i++ {
+		key, value := fmt.Sprintf("KTTL%d", i), fmt.Sprintf("V%d", i)
+		if err := store.Put(
+			&serverpb.KVPair{Key: []byte(key), Value: []byte(value),
+				ExpireTS: uint64(time.Now().Add(-2 * time.Second).Unix())}); err != nil {
+			t.Fatalf("Unable to PUT. Key: %s, Value: %s, Error: %v", key, value, err)
+		}
+	}
+
+	for i := 1; i <= numIteration; i++ {
+		key, expectedValue := fmt.Sprintf("KTTL%d", i), fmt.Sprintf("V%d", i)
+		if readResults, err := store.Get([]byte(key)); err != nil {
+			t.Fatalf("Unable to GET. Key: %s, Error: %v", key, err)
+		} else {
+			if string(readResults[0].Value) != expectedValue {
+				t.Errorf("GET mismatch. Key: %s, Expected Value: %s, Actual Value: %s", key, expectedValue, readResults[0].Value)
+			}
+		}
+	}
+
+	for i := 11; i <= 10+numIteration; i++ {
+		key := fmt.Sprintf("KTTL%d", i)
+		if readResults, err := store.Get([]byte(key)); err != nil {
+			t.Fatalf("Unable to GET. Key: %s, Error: %v", key, err)
+		} else {
+			if len(readResults) > 0 {
+				t.Errorf("GET mismatch post TTL Expiry. Key: %s, Expected Value: %s, Actual Value: %s", key, "nil", readResults[0].Value)
+			}
+		}
+	}
+}
+
+func TestPutEmptyValue(t *testing.T) {
+	key, val := "EmptyKey", ""
+	if err := store.Put(kvEntry(key, val)); err != nil {
+		t.Fatalf("Unable to PUT empty value. Key: %s", key)
+	}
+
+	if res, err := store.Get([]byte(key)); err != nil {
+		t.Fatalf("Unable to GET empty value. Key: %s", key)
+	} else {
+		t.Logf("Got value: '%v'", res)
+	}
+
+	// update nil value for same key
+	if err := store.Put(&serverpb.KVPair{Key: []byte(key), Value: nil}); err != nil {
+		t.Fatalf("Unable to PUT empty value. Key: %s", key)
+	}
+
+	if res, err := store.Get([]byte(key)); err != nil {
+		t.Fatalf("Unable to GET empty value. Key: %s", key)
+	} else {
+		t.Logf("Got value: '%v'", res)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	key, val := "SomeKey", "SomeValue"
+	if err := store.Put(kvEntry(key, val)); err != nil {
+		t.Fatalf("Unable to PUT. Key: %s", key)
+	}
+
+	if res, err := store.Get([]byte(key)); err != nil {
+		t.Fatalf("Unable to GET. Key: %s", key)
+	} else {
+		t.Logf("Got value: '%s'", string(res[0].Value))
+	}
+
+	// delete key
+	if err := store.Delete([]byte(key)); err != nil {
+		t.Fatalf("Unable to delete Key: %s", key)
+	}
+
+	if res, err := store.Get([]byte(key)); err != nil {
+		t.Fatalf("Got Exception while trying to GET deleted key value. Key: %s", key)
+	} else if len(res) != 0 {
+		t.Fatalf("Got Result while trying to GET deleted key value. Key: %s", key)
+	}
+}
+
+func TestGetLatestChangeNumber(t *testing.T) {
+	numInsert := 5
+	beforeChngNum, _ := store.GetLatestCommittedChangeNumber()
+	putKeys(t, numInsert, "aaKey", "aaVal", 0)
+	afterChngNum, _ := store.GetLatestCommittedChangeNumber()
+	actNumTrxns := afterChngNum - beforeChngNum
+	expNumTrxns := uint64(10)
+	if expNumTrxns != actNumTrxns {
+		t.Errorf("Mismatch in number of transactions. Expected: %d, Actual: %d", expNumTrxns, actNumTrxns)
+	}
+	beforeChngNum = afterChngNum
+	getKeys(t, numInsert, "aaKey", "aaVal")
+	afterChngNum, _ = store.GetLatestCommittedChangeNumber()
+	actNumTrxns = afterChngNum - beforeChngNum
+	if actNumTrxns != 0 {
+		t.Errorf("Expected no transactions to have occurred but found %d transactions", actNumTrxns)
+	}
+}
+
+func TestLoadChanges(t *testing.T) {
+	expNumTrxns, maxChngs := 3, 8
+	keyPrefix, valPrefix := "bbKey", "bbVal"
+	chngNum, _ := store.GetLatestCommittedChangeNumber()
+	chngNum++ // due to possible previous transaction
+	putKeys(t, expNumTrxns, keyPrefix, valPrefix, 0)
+	if chngs, err := store.LoadChanges(chngNum, maxChngs); err != nil {
+		t.Fatal(err)
+	} else {
+		expNumChngs, actNumChngs := 3, len(chngs)
+		if expNumChngs != actNumChngs {
+			t.Errorf("Incorrect number of changes retrieved. Expected: %d, Actual: %d", expNumChngs, actNumChngs)
+		}
+		firstChngNum := chngs[0].ChangeNumber
+		if firstChngNum != chngNum {
+			t.Errorf("Expected first change number to be %d but it is %d", chngNum, firstChngNum)
+		}
+		for i := 0; i < actNumChngs; i += 2 {
+			chng := chngs[i]
+			// t.Log(string(chng.SerialisedForm))
+			if chng.NumberOfTrxns != 2 {
+				t.Errorf("Expected only two transaction in this change but found %d transactions", chng.NumberOfTrxns)
+			}
+			trxnRec := chng.Trxns[1]
+			if trxnRec.Type != serverpb.TrxnRecord_Put {
+				t.Errorf("Expected transaction type to be Put but found %s", trxnRec.Type.String())
+			}
+			expKey, expVal := fmt.Sprintf("%s_%d", keyPrefix, i+1), fmt.Sprintf("%s_%d", valPrefix, i+1)
+			actKey, actVal := string(trxnRec.Key), string(trxnRec.Value)
+			if expKey != actKey {
+				t.Errorf("Key mismatch. Expected: %s, Actual: %s", expKey, actKey)
+			}
+			if expVal != actVal {
+				t.Errorf("Value mismatch. Expected: %s, Actual: %s", expVal, actVal)
+			}
+		}
+	}
+}
+
+func TestSaveChanges(t *testing.T) {
+	numTrxns := 3
+	putKeyPrefix, putValPrefix := "ccKey", "ccVal"
+	putKeys(t, numTrxns, putKeyPrefix, putValPrefix, 0)
+	chngNum, _ := store.GetLatestCommittedChangeNumber()
+	chngNum++ // due to possible previous transaction
+	wbPutKeyPrefix, wbPutValPrefix := "ddKey", "ddVal"
+	chngs := make([]*serverpb.ChangeRecord, numTrxns)
+	for i := 0; i < numTrxns; i++ {
+		wb := gorocksdb.NewWriteBatch()
+		defer wb.Destroy()
+		ks, vs := fmt.Sprintf("%s_%d", wbPutKeyPrefix, i+1), fmt.Sprintf("%s_%d", wbPutValPrefix, i+1)
+		wb.Put([]byte(ks), []byte(vs))
+		delKs := fmt.Sprintf("%s_%d", putKeyPrefix, i+1)
+		wb.Delete([]byte(delKs))
+		chngs[i] = store.toChangeRecord(wb, chngNum)
+		chngNum++
+	}
+	expChngNum := chngNum
+
+	if actChngNum, err := store.SaveChanges(chngs); err != nil {
+		t.Fatal(err)
+	} else {
+		if expChngNum != actChngNum {
+			t.Errorf("Change numbers mismatch. Expected: %d, Actual: %d", expChngNum, actChngNum)
+		}
+		getKeys(t, numTrxns, wbPutKeyPrefix, wbPutValPrefix)
+		noKeys(t, numTrxns, putKeyPrefix)
+	}
+}
+
+func TestSaveChangesResumesFromAppliedChange(t *testing.T) {
+	dbFolder := fmt.Sprintf("%s_resume_%d", dbFolder, time.Now().UnixNano())
+	defer os.RemoveAll(dbFolder)
+	kvs, err := OpenDB(dbFolder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer kvs.Close()
+	rdb := kvs.(*rocksDB)
+
+	chngNum, _ := rdb.GetLatestAppliedChangeNumber()
+	chngs := make([]*serverpb.ChangeRecord, 4)
+	for i := range chngs {
+		wb := gorocksdb.NewWriteBatch()
+		defer wb.Destroy()
+		wb.Put([]byte(fmt.Sprintf("resumeKey%d", i)), []byte(fmt.Sprintf("resumeVal%d", i)))
+		wb.Put([]byte(fmt.Sprintf("resumeCtr%d", i)), []byte("1"))
+		chngs[i] = rdb.toChangeRecord(wb, chngNum+1)
+		chngNum += 2
+	}
+	if _, err = rdb.SaveChanges(chngs[:2]); err != nil {
+		t.Fatal(err)
+	}
+	// Changes already applied are skipped when retried along with the rest
+	actChngNum, err := rdb.SaveChanges(chngs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latestChngNum, _ := rdb.GetLatestAppliedChangeNumber(); actChngNum != chngNum || latestChngNum != chngNum {
+		t.Errorf("Expected the changes to be applied upto %d. Returned: %d, Latest: %d", chngNum, actChngNum, latestChngNum)
+	}
+	if actChngNum, err = rdb.SaveChanges(chngs[3:]); err != nil || actChngNum != chngNum {
+		t.Errorf("Expected an applied change to be skipped. Returned: %d, Error: %v", actChngNum, err)
+	}
+}
+
+func TestIteratorPrefixScan(t *testing.T) {
+	numTrxns := 3
+	keyPrefix1, valPrefix1 := "aaPrefixKey", "aaPrefixVal"
+	putKeys(t, numTrxns, keyPrefix1, valPrefix1, 0)
+	keyPrefix2, valPrefix2 := "bbPrefixKey", "bbPrefixVal"
+	putKeys(t, numTrxns, keyPrefix2, valPrefix2, 0)
+	keyPrefix3, valPrefix3 := "ccPrefixKey", "ccPrefixVal"
+	putKeys(t, numTrxns, keyPrefix3, valPrefix3, 0)
+
+	prefix := []byte("bbPrefix")
+	itOpts, err := storage.NewIteratorOptions(
+		storage.IterationPrefixKey(prefix),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	it := store.Iterate(itOpts)
+	defer it.Close()
+
+	actCount := 0
+	for it.HasNext() {
+		entry := it.Next()
+		actCount++
+		if strings.HasPrefix(string(entry.Key), string(prefix)) {
+			t.Logf("Key: %s Value: %s\n", entry.Key, entry.Value)
+		} else {
+			t.Errorf("Expected key %s to have prefix %s", entry.Key, prefix)
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if numTrxns != actCount {
+		t.Errorf("Expected %d records with prefix: %s. But got %d records.", numTrxns, prefix, actCount)
+	}
+}
+
+func TestIteratorFromStartKeyWithTTL(t *testing.T) {
+	numTrxns := 3
+	keyPrefix1, valPrefix1 := "TTLStartKeyAA", "aaStartVal"
+	putKeys(t, numTrxns, keyPrefix1, valPrefix1, 0)
+	keyPrefix2, valPrefix2 := "TTLStartKeyBB", "bbStartVal"
+	putKeys(t, numTrxns, keyPrefix2, valPrefix2, 0)
+	keyPrefix3, valPrefix3 := "TTLStartKeyCC", "ccStartVal"
+	putKeys(t, numTrxns, keyPrefix3, valPrefix3, time.Now().Add(2*time.Second).Unix())
+	keyPrefix4, valPrefix4 := "TTLStartKeyDD", "ccStartVal"
+	putKeys(t, numTrxns, keyPrefix4, valPrefix4, time.Now().Add(-2*time.Second).Unix())
+
+	prefix, startKey := []byte("TTLStartKey"), []byte("TTLStartKeyBB_2")
+	itOpts, err := storage.NewIteratorOptions(
+		storage.IterationPrefixKey(prefix),
+		storage.IterationStartKey(startKey),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	it := store.Iterate(itOpts)
+	defer it.Close()
+
+	actCount := 0
+	for it.HasNext() {
+		entry := it.Next()
+		actCount++
+		if strings.HasPrefix(string(entry.Key), string(prefix)) {
+			t.Logf("Key: %s Value: %s\n", entry.Key, entry.Value)
+		} else {
+			t.Errorf("Expected key %s to have prefix %s", entry.Key, prefix)
+		}
+	}
+
+	expCount := 5
+	if expCount != actCount {
+		t.Errorf("Expected %d records with prefix: %s, start key: %s. But got %d records.", expCount, prefix, startKey, actCount)
+	}
+
+}
+
+func TestTTLIteratorWithoutPrefix(t *testing.T) {
+	numTrxns := 3
+	keyPrefix4, valPrefix4 := "TTLStartKeyDD", "ccStartVal"
+	putKeys(t, numTrxns, keyPrefix4, valPrefix4, time.Now().Add(-2*time.Second).Unix())
+	itOpts, err := storage.NewIteratorOptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	it := store.Iterate(itOpts)
+	defer it.Close()
+	for it.HasNext() {
+		entry := it.Next()
+		if hlc.InThePast(entry.ExpireTS) {
+			t.Errorf("Got Expired Key: %s Value: %s\n", entry.Key, entry.Value)
+		} else {
+			t.Logf("Key: %s Value: %s Expiry %v\n", entry.Key, entry.Value, entry.ExpireTS)
+		}
+	}
+}
+
+func TestIteratorFromStartKey(t *testing.T) {
+	numTrxns := 3
+	keyPrefix1, valPrefix1 := "StartKeyAA", "aaStartVal"
+	putKeys(t, numTrxns, keyPrefix1, valPrefix1, 0)
+	keyPrefix2, valPrefix2 := "StartKeyBB", "bbStartVal"
+	putKeys(t, numTrxns, keyPrefix2, valPrefix2, 0)
+	keyPrefix3, valPrefix3 := "StartKeyCC", "ccStartVal"
+	putKeys(t, numTrxns, keyPrefix3, valPrefix3, 0)
+
+	prefix, startKey := []byte("StartKey"), []byte("StartKeyBB_2")
+	itOpts, err := storage.NewIteratorOptions(
+		storage.IterationPrefixKey(prefix),
+		storage.IterationStartKey(startKey),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	it := store.Iterate(itOpts)
+	defer it.Close()
+
+	actCount := 0
+	for it.HasNext() {
+		entry := it.Next()
+		actCount++
+		if strings.HasPrefix(string(entry.Key), string(prefix)) {
+			t.Logf("Key: %s Value: %s\n", entry.Key, entry.Value)
+		} else {
+			t.Errorf("Expected key %s to have prefix %s", entry.Key, prefix)
+		}
+	}
+
+	expCount := 5
+	if expCount != actCount {
+		t.Errorf("Expected %d records with prefix: %s, start key: %s. But got %d records.", expCount, prefix, startKey, actCount)
+	}
+}
+
+// Following test can be removed once DKV supports bulk writes
+func TestGetUpdatesFromSeqNumForBatches(t *testing.T) {
+	beforeSeq := store.db.GetLatestSequenceNumber()
+
+	expNumBatchTrxns := 3
+	numTrxnsPerBatch := 2
+	expNumTrxns := expNumBatchTrxns * numTrxnsPerBatch
+	for i := 1; i <= expNumBatchTrxns; i++ {
+		k, v := fmt.Sprintf("bKey_%d", i), fmt.Sprintf("bVal_%d", i)
+		wb := gorocksdb.NewWriteBatch()
+		wb.Put([]byte(k), []byte(v))
+		wb.Delete([]byte(k))
+		wo := gorocksdb.NewDefaultWriteOptions()
+		wo.SetSync(true)
+		if err := store.db.Write(wo, wb); err != nil {
+			t.Fatal(err)
+		}
+		wb.Destroy()
+		wo.Destroy()
+	}
+
+	afterSeq := store.db.GetLatestSequenceNumber()
+	numTrxns := int(afterSeq - beforeSeq)
+	if numTrxns != expNumTrxns {
+		t.Errorf("Incorrect number of transactions reported. Expected: %d, Actual: %d", expNumTrxns, numTrxns)
+	}
+
+	startSeq := 1 + beforeSeq // This is done to remove previous transaction if any
+	if trxnIter, err := store.db.GetUpdatesSince(startSeq); err != nil {
+		t.Fatal(err)
+	} else {
+		defer trxnIter.Destroy()
+		for trxnIter.Valid() {
+			wb, _ := trxnIter.GetBatch()
+			numTrxnsPerWb := wb.Count()
+			if numTrxnsPerWb != numTrxnsPerBatch {
+				t.Errorf("Incorrect number of transactions per batch. Expected: %d, Actual: %d", numTrxnsPerBatch, numTrxnsPerWb)
+			}
+			wbIter := wb.NewIterator()
+			for wbIter.Next() {
+				wbr := wbIter.Record()
+				// t.Logf("Type: %v, Key: %s, Val: %s", wbr.Type, wbr.Key, wbr.Value)
+				switch wbr.Type {
+				case 1: // Put
+					if !strings.HasPrefix(string(wbr.Key), "bKey_") {
+						t.Errorf("Invalid key for PUT record. Value: %s", wbr.Key)
+					}
+					if !strings.HasPrefix(string(wbr.Value), "bVal_") {
+						t.Errorf("Invalid value inside write batch record for key: %s. Value: %s", wbr.Key, wbr.Value)
 					}
 				case 0: // Delete
 					if !strings.HasPrefix(string(wbr.Key), "bKey_") {
@@ -560,176 +1514,1445 @@ func TestGetUpdatesFromSeqNumForBatches(t *testing.T) {
 					t.Errorf("Invalid type: %v", wbr.Type)
 				}
 			}
-			wb.Destroy()
-			trxnIter.Next()
+			wb.Destroy()
+			trxnIter.Next()
+		}
+	}
+}
+
+func TestMultiGet(t *testing.T) {
+	numKeys := 10
+	keys, vals := make([][]byte, numKeys), make([]string, numKeys)
+	for i := 1; i <= numKeys; i++ {
+		key, value := fmt.Sprintf("MK%d", i), fmt.Sprintf("MV%d", i)
+		ttl := int64(0)
+		if i&1 == 1 {
+			ttl = time.Now().Add(2 * time.Second).Unix()
+		}
+		err := store.Put(&serverpb.KVPair{Key: []byte(key), Value: []byte(value), ExpireTS: uint64(ttl)})
+		if err != nil {
+			t.Fatalf("Unable to PUT. Key: %s, Value: %s, Error: %v", key, value, err)
+		} else {
+			keys[i-1] = []byte(key)
+			vals[i-1] = value
+		}
+	}
+
+	if results, err := store.Get(keys...); err != nil {
+		t.Fatal(err)
+	} else {
+		for i, result := range results {
+			if string(result.Value) != vals[i] {
+				t.Errorf("Multi Get value mismatch. Key: %s, Expected Value: %s, Actual Value: %s", keys[i], vals[i], result.Value)
+			}
+		}
+	}
+
+	positionalKeys := [][]byte{keys[1], []byte("MissingMK"), keys[0], keys[1]}
+	if results, err := storage.MultiGet(store.Get, positionalKeys...); err != nil {
+		t.Fatal(err)
+	} else if len(results) != len(positionalKeys) {
+		t.Errorf("Expected %d positional results, but got %d", len(positionalKeys), len(results))
+	} else {
+		expVals := []string{vals[1], "", vals[0], vals[1]}
+		for i, result := range results {
+			if !bytes.Equal(result.Key, positionalKeys[i]) || string(result.Value) != expVals[i] {
+				t.Errorf("Positional Multi Get mismatch at %d. Expected Key: %s, Value: %s, Actual Key: %s, Value: %s",
+					i, positionalKeys[i], expVals[i], result.Key, result.Value)
+			}
+		}
+	}
+}
+
+func TestParallelMultiGet(t *testing.T) {
+	store.opts.multiGetBatchSize, store.opts.multiGetParallelism = 3, 2
+	defer func() { store.opts.multiGetBatchSize, store.opts.multiGetParallelism = 0, 0 }()
+
+	numKeys := 10
+	keys, vals := make([][]byte, numKeys), make([][]byte, numKeys)
+	for i := 0; i < numKeys; i++ {
+		kv := &serverpb.KVPair{Key: []byte(fmt.Sprintf("PMK%d", i)), Value: []byte(fmt.Sprintf("PMV%d", i))}
+		if i&1 == 1 {
+			kv.ExpireTS = uint64(time.Now().Add(time.Hour).Unix())
+		}
+		expectNoError(t, store.Put(kv))
+		keys[i], vals[i] = kv.Key, kv.Value
+	}
+	allKeys := append(keys[:numKeys:numKeys], []byte("MissingPMK"))
+	for _, get := range []func(keys ...[]byte) ([]*serverpb.KVPair, error){
+		store.Get,
+		func(keys ...[]byte) ([]*serverpb.KVPair, error) {
+			return store.GetWithOptions(&serverpb.ReadOptions{SkipBlockCacheFill: true}, keys...)
+		},
+	} {
+		results, err := get(allKeys...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(results) != numKeys {
+			t.Fatalf("Expected %d results, but got %d", numKeys, len(results))
+		}
+		for i, result := range results {
+			if !bytes.Equal(result.Key, keys[i]) || !bytes.Equal(result.Value, vals[i]) {
+				t.Errorf("Parallel Multi Get mismatch at %d. Expected Key: %s, Value: %s, Actual Key: %s, Value: %s",
+					i, keys[i], vals[i], result.Key, result.Value)
+			}
+		}
+	}
+}
+
+func TestGetPinned(t *testing.T) {
+	ttl := uint64(time.Now().Add(time.Hour).Unix())
+	pairs := []*serverpb.KVPair{
+		{Key: []byte("PinnedKey"), Value: []byte("PinnedValue")},
+		{Key: []byte("PinnedTTLKey"), Value: []byte("PinnedTTLValue"), ExpireTS: ttl},
+	}
+	expectNoError(t, store.Put(pairs...))
+	for _, kv := range pairs {
+		val, expireTS, release, err := store.GetPinned(kv.Key)
+		expectNoError(t, err)
+		if string(val) != string(kv.Value) {
+			t.Errorf("Pinned value mismatch. Key: %s, Expected: %s, Actual: %s", kv.Key, kv.Value, val)
+		}
+		if expireTS != kv.ExpireTS {
+			t.Errorf("Pinned expiry mismatch. Key: %s, Expected: %d, Actual: %d", kv.Key, kv.ExpireTS, expireTS)
+		}
+		release()
+		// Releasing more than once must be harmless
+		release()
+	}
+
+	val, _, release, err := store.GetPinned([]byte("MissingPinnedKey"))
+	expectNoError(t, err)
+	defer release()
+	if len(val) != 0 {
+		t.Errorf("Expected no value for a missing key. Actual: %s", val)
+	}
+}
+
+func TestObjectPools(t *testing.T) {
+	wb := getWriteBatch()
+	wb.Put([]byte("PooledKey"), []byte("PooledValue"))
+	putWriteBatch(wb)
+	if wb = getWriteBatch(); wb.Count() != 0 {
+		t.Errorf("Expected a pooled write batch to be empty. Actual count: %d", wb.Count())
+	}
+	putWriteBatch(wb)
+
+	key := []byte("PooledSnapshotKey")
+	expectNoError(t, store.Put(&serverpb.KVPair{Key: key, Value: []byte("v1")}))
+	iterOpts, _ := storage.NewIteratorOptions(storage.IterationPrefixKey(key))
+	it := store.IterateSnapshot(nil, iterOpts)
+	expectNoError(t, it.Close())
+	expectNoError(t, store.Put(&serverpb.KVPair{Key: key, Value: []byte("v2")}))
+	// Read options reused from a pinned iteration must not carry its snapshot
+	for i := 0; i < 10; i++ {
+		if kvs, err := store.GetWithOptions(&serverpb.ReadOptions{}, key); err != nil {
+			t.Fatal(err)
+		} else if len(kvs) != 1 || string(kvs[0].Value) != "v2" {
+			t.Errorf("Expected the latest value v2. Actual: %v", kvs)
+		}
+	}
+}
+
+func TestMissingGet(t *testing.T) {
+	key := "MissingKey"
+	if readResults, err := store.Get([]byte(key)); err != nil {
+		t.Errorf("Expected no error since given key is only missing. But got error: %v", err)
+	} else if len(readResults) > 0 {
+		t.Errorf("Expected no values for missing key. Key: %s, Actual Value: %v", key, readResults)
+	}
+}
+
+func TestBackupFolderValidity(t *testing.T) {
+	expectError(t, checksForBackup(""))
+	expectError(t, checksForBackup("/missing/backup"))
+	expectNoError(t, checksForBackup("/tmp/backup.bak"))
+	expectNoError(t, checksForBackup("/missing"))
+	expectNoError(t, checksForBackup(dbFolder))
+}
+
+func TestRestoreFolderValidity(t *testing.T) {
+	expectError(t, checksForRestore(""))
+	expectError(t, checksForRestore("/missing/backup"))
+	expectError(t, checksForRestore("/missing"))
+	expectNoError(t, checksForRestore(dbFolder))
+}
+
+func TestBackupAndRestore(t *testing.T) {
+	numTrxns, keyPrefix, valPrefix := 5, "brKey", "brVal"
+	putKeys(t, numTrxns, keyPrefix, valPrefix, 0)
+
+	backupPath := fmt.Sprintf("%s/%s", dbFolder, "backup")
+	if err := store.BackupTo(backupPath); err != nil {
+		t.Fatal(err)
+	} else {
+		missKeyPrefix, missValPrefix := "mbrKey", "mbrVal"
+		putKeys(t, numTrxns, missKeyPrefix, missValPrefix, 0)
+		store.Close()
+		if st, _, _, _, err := store.RestoreFrom(backupPath); err != nil {
+			t.Fatal(err)
+		} else {
+			store = st.(*rocksDB)
+			getKeys(t, numTrxns, keyPrefix, valPrefix)
+			noKeys(t, numTrxns, missKeyPrefix)
+		}
+	}
+}
+
+func TestPointInTimeRestore(t *testing.T) {
+	numTrxns, keyPrefix, valPrefix := 5, "pitrKey", "pitrVal"
+	putKeys(t, numTrxns, keyPrefix, valPrefix, 0)
+
+	backupPath := "/tmp/rocksdb_pitr_backup"
+	if err := exec.Command("rm", "-rf", backupPath).Run(); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.BackupTo(backupPath); err != nil {
+		t.Fatal(err)
+	}
+
+	inclKeyPrefix, inclValPrefix := "inclPitrKey", "inclPitrVal"
+	putKeys(t, numTrxns, inclKeyPrefix, inclValPrefix, 0)
+	chngNum, _ := store.GetLatestCommittedChangeNumber()
+	exclKeyPrefix, exclValPrefix := "exclPitrKey", "exclPitrVal"
+	expectNoError(t, store.Put(&serverpb.KVPair{Key: []byte("exclPitrBatchKey1"), Value: []byte("exclPitrBatchVal1")},
+		&serverpb.KVPair{Key: []byte("exclPitrBatchKey2"), Value: []byte("exclPitrBatchVal2")}))
+	putKeys(t, numTrxns, exclKeyPrefix, exclValPrefix, 0)
+
+	if _, _, _, _, err := storage.RestoreToChangeNumber(store, store, store, backupPath, chngNum+uint64(10*numTrxns)); err == nil {
+		t.Error("Expected an error for change number beyond the latest committed change number")
+	}
+	// Store is left untouched when the change number falls within a change record
+	if _, _, _, _, err := storage.RestoreToChangeNumber(store, store, store, backupPath, chngNum+1); err == nil {
+		t.Error("Expected an error for change number within a change record")
+	}
+	getKeys(t, numTrxns, exclKeyPrefix, exclValPrefix)
+
+	if st, _, _, _, err := storage.RestoreToChangeNumber(store, store, store, backupPath, chngNum); err != nil {
+		t.Fatal(err)
+	} else {
+		store = st.(*rocksDB)
+		getKeys(t, numTrxns, keyPrefix, valPrefix)
+		getKeys(t, numTrxns, inclKeyPrefix, inclValPrefix)
+		noKeys(t, numTrxns, exclKeyPrefix)
+		if latestChngNum, _ := store.GetLatestCommittedChangeNumber(); latestChngNum != chngNum {
+			t.Errorf("Expected change number %d after restore, but got %d", chngNum, latestChngNum)
+		}
+	}
+}
+
+func TestGetPutSnapshot(t *testing.T) {
+	numTrxns := 100
+	ttl := time.Now().Add(5 * time.Second)
+	keyPrefix1, valPrefix1, newValPrefix1 := "firSnapKey", "firSnapVal", "newFirSnapVal"
+	keyPrefix1T, valPrefix1T, newValPrefix1T := "firSnapTTLKey", "firSnapTTLVal", "newFirSnapTTLVal"
+
+	putKeys(t, numTrxns, keyPrefix1, valPrefix1, 0)
+	putKeys(t, numTrxns, keyPrefix1T, valPrefix1T, ttl.Unix())
+
+	if snap, err := store.GetSnapshot(); err != nil {
+		t.Fatal(err)
+	} else {
+		putKeys(t, numTrxns, keyPrefix1, newValPrefix1, 0)
+		putKeys(t, numTrxns, keyPrefix1T, newValPrefix1T, ttl.Unix())
+
+		keyPrefix2, valPrefix2 := "secSnapKey", "secSnapVal"
+		putKeys(t, numTrxns, keyPrefix2, valPrefix2, 0)
+
+		if err := store.PutSnapshot(snap); err != nil {
+			t.Fatal(err)
+		} else {
+			getKeys(t, numTrxns, keyPrefix1, valPrefix1)
+			getKeys(t, numTrxns, keyPrefix1T, valPrefix1T)
+			//getKeys(t, numTrxns, keyPrefix2, valPrefix2)
+			noKeys(t, numTrxns, keyPrefix2)
+		}
+	}
+}
+
+func TestGetPutSnapshotTTLOnly(t *testing.T) {
+	numTrxns := 100
+	ttl := time.Now().Add(5 * time.Second)
+	keyPrefix1T, valPrefix1T, newValPrefix1T := "firSnapTTLKey", "firSnapTTLVal", "newFirSnapTTLVal"
+
+	putKeys(t, numTrxns, keyPrefix1T, valPrefix1T, ttl.Unix())
+
+	if snap, err := store.GetSnapshot(); err != nil {
+		t.Fatal(err)
+	} else {
+		putKeys(t, numTrxns, keyPrefix1T, newValPrefix1T, ttl.Unix())
+
+		keyPrefix2, valPrefix2 := "secSnapKey", "secSnapVal"
+		putKeys(t, numTrxns, keyPrefix2, valPrefix2, 0)
+
+		if err := store.PutSnapshot(snap); err != nil {
+			t.Fatal(err)
+		} else {
+			getKeys(t, numTrxns, keyPrefix1T, valPrefix1T)
+			//getKeys(t, numTrxns, keyPrefix2, valPrefix2)
+			noKeys(t, numTrxns, keyPrefix2)
+		}
+	}
+}
+
+func TestIterationOnExplicitSnapshot(t *testing.T) {
+	numTrxns := 100
+	keyPrefix1, valPrefix1 := "firKey", "firVal"
+	putKeys(t, numTrxns, keyPrefix1, valPrefix1, 0)
+
+	snap := store.db.NewSnapshot()
+	defer store.db.ReleaseSnapshot(snap)
+
+	keyPrefix2, valPrefix2 := "secKey", "secVal"
+	putKeys(t, numTrxns, keyPrefix2, valPrefix2, 0)
+
+	readOpts := gorocksdb.NewDefaultReadOptions()
+	defer readOpts.Destroy()
+
+	readOpts.SetSnapshot(snap)
+	it := store.db.NewIterator(readOpts)
+	defer it.Close()
+
+	actCnt := 0
+	for it.SeekToFirst(); it.Valid(); it.Next() {
+		k, v := string(it.Key().Data()), string(it.Value().Data())
+		if strings.HasPrefix(k, keyPrefix1) && strings.HasPrefix(v, valPrefix1) {
+			actCnt++
+		}
+
+		if strings.HasPrefix(k, keyPrefix2) || strings.HasPrefix(v, valPrefix2) {
+			t.Errorf("Did not expect snapshot iterator to give key: %s with value: %s", k, v)
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if actCnt != numTrxns {
+		t.Errorf("Expected snapshot iterator to give %d keys, but only got %d keys", numTrxns, actCnt)
+	}
+}
+
+func TestIterateSnapshot(t *testing.T) {
+	numTrxns, keyPrefix, valPrefix := 10, "pinnedSnapKey", "pinnedSnapVal"
+	putKeys(t, numTrxns, keyPrefix, valPrefix, 0)
+
+	baseNumSnaps := store.db.GetProperty("rocksdb.num-snapshots")
+	iterOpts, _ := storage.NewIteratorOptions(storage.IterationPrefixKey([]byte(keyPrefix)))
+	it := store.IterateSnapshot(nil, iterOpts)
+	if numSnaps := store.db.GetProperty("rocksdb.num-snapshots"); numSnaps == baseNumSnaps {
+		t.Errorf("Expected a pinned snapshot in addition to the %s snapshots", baseNumSnaps)
+	}
+
+	// Both the normal and the TTL column families are read as of the snapshot
+	putKeys(t, numTrxns, keyPrefix, "newPinnedSnapVal", 0)
+	putKeys(t, numTrxns, keyPrefix+"TTL", valPrefix, time.Now().Add(time.Hour).Unix())
+
+	actCnt := 0
+	for it.HasNext() {
+		kv := it.Next()
+		if !strings.HasPrefix(string(kv.Value), valPrefix) || strings.HasPrefix(string(kv.Key), keyPrefix+"TTL") {
+			t.Errorf("Did not expect pinned snapshot iterator to give key: %s with value: %s", kv.Key, kv.Value)
+		}
+		actCnt++
+	}
+	expectNoError(t, it.Err())
+	expectNoError(t, it.Close())
+	if actCnt != numTrxns {
+		t.Errorf("Expected pinned snapshot iterator to give %d keys, but got %d keys", numTrxns, actCnt)
+	}
+	if numSnaps := store.db.GetProperty("rocksdb.num-snapshots"); numSnaps != baseNumSnaps {
+		t.Errorf("Expected the pinned snapshot to be released, but found %s snapshots instead of %s", numSnaps, baseNumSnaps)
+	}
+}
+
+func TestPublishedSnapshot(t *testing.T) {
+	numTrxns, keyPrefix, valPrefix := 10, "pubSnapKey", "pubSnapVal"
+	putKeys(t, numTrxns, keyPrefix, valPrefix, 0)
+
+	expectError(t, store.PublishSnapshot(keyPrefix, ""))
+	expectNoError(t, store.PublishSnapshot(keyPrefix, "batch"))
+	expectError(t, store.PublishSnapshot(keyPrefix, "batch"))
+
+	ref := storage.SnapshotRef(keyPrefix, "batch")
+	if snaps := store.ListSnapshots(); len(snaps) != 1 || snaps[0] != ref {
+		t.Errorf("Expected only snapshot: %s to be published, but got %v", ref, snaps)
+	}
+
+	newValPrefix := "newPubSnapVal"
+	putKeys(t, numTrxns, keyPrefix, newValPrefix, 0)
+	getKeys(t, numTrxns, keyPrefix, newValPrefix)
+
+	view, err := store.SnapshotView(ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i <= numTrxns; i++ {
+		key, expVal := fmt.Sprintf("%s_%d", keyPrefix, i), fmt.Sprintf("%s_%d", valPrefix, i)
+		if res, err := view.Get([]byte(key)); err != nil {
+			t.Fatal(err)
+		} else if len(res) != 1 || string(res[0].Value) != expVal {
+			t.Errorf("Snapshot GET mismatch. Key: %s, Expected Value: %s, Actual Value: %v", key, expVal, res)
+		}
+	}
+	if _, err = view.Get([]byte("otherKey")); err == nil {
+		t.Error("Expected an error for reading a key outside the snapshot namespace")
+	}
+
+	itOpts, _ := storage.NewIteratorOptions()
+	iter, err := view.Iterate(itOpts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actCnt := 0
+	for ; iter.HasNext(); actCnt++ {
+		kv := iter.Next()
+		if !strings.HasPrefix(string(kv.Key), keyPrefix) || !strings.HasPrefix(string(kv.Value), valPrefix) {
+			t.Errorf("Did not expect snapshot iterator to give key: %s with value: %s", kv.Key, kv.Value)
+		}
+	}
+	iter.Close()
+	if actCnt != numTrxns {
+		t.Errorf("Expected snapshot iterator to give %d keys, but got %d keys", numTrxns, actCnt)
+	}
+
+	// Views and iterators in use keep reading the unpublished snapshot
+	if iter, err = view.Iterate(itOpts); err != nil {
+		t.Fatal(err)
+	}
+	expectNoError(t, store.UnpublishSnapshot(ref))
+	expectError(t, store.UnpublishSnapshot(ref))
+	key, expVal := fmt.Sprintf("%s_1", keyPrefix), fmt.Sprintf("%s_1", valPrefix)
+	if res, err := view.Get([]byte(key)); err != nil || len(res) != 1 || string(res[0].Value) != expVal {
+		t.Errorf("Expected the unpublished snapshot to give %s for key %s, but got %v. Error: %v", expVal, key, res, err)
+	}
+	expectNoError(t, view.Close())
+	for actCnt = 0; iter.HasNext(); actCnt++ {
+		if kv := iter.Next(); !strings.HasPrefix(string(kv.Value), valPrefix) {
+			t.Errorf("Did not expect the iterator of the unpublished snapshot to give key: %s with value: %s", kv.Key, kv.Value)
+		}
+	}
+	iter.Close()
+	if actCnt != numTrxns {
+		t.Errorf("Expected the iterator of the unpublished snapshot to give %d keys, but got %d keys", numTrxns, actCnt)
+	}
+	if _, err = store.SnapshotView(ref); err == nil {
+		t.Error("Expected an error for reading an unpublished snapshot")
+	}
+}
+
+func TestExists(t *testing.T) {
+	numTrxns, keyPrefix, valPrefix := 10, "existsKey", "existsVal"
+	putKeys(t, numTrxns, keyPrefix, valPrefix, 0)
+	expiredKey := []byte("existsExpiredKey")
+	expectNoError(t, store.Put(&serverpb.KVPair{Key: expiredKey, Value: []byte("val"), ExpireTS: uint64(time.Now().Add(-time.Second).Unix())}))
+
+	for i := 1; i <= numTrxns; i++ {
+		key := fmt.Sprintf("%s_%d", keyPrefix, i)
+		if exists, err := store.Exists([]byte(key)); err != nil {
+			t.Fatal(err)
+		} else if !exists {
+			t.Errorf("Expected key %s to exist", key)
+		}
+	}
+	for _, key := range [][]byte{[]byte("existsMissingKey"), expiredKey} {
+		if exists, err := store.Exists(key); err != nil {
+			t.Fatal(err)
+		} else if exists {
+			t.Errorf("Expected key %s to not exist", key)
+		}
+	}
+}
+
+func TestTransact(t *testing.T) {
+	key1, key2, key3 := []byte("txnKey1"), []byte("txnKey2"), []byte("txnKey3")
+	expectNoError(t, store.Put(kvEntry("txnKey1", "val1"), kvEntry("txnKey3", "val3")))
+
+	reads := []*serverpb.KVPair{{Key: key1, Value: []byte("val1")}, {Key: key2}}
+	writes := []*serverpb.TxnWrite{{Key: key1, Value: []byte("val2")}, {Key: key2, Value: []byte("val2")}, {Key: key3, Delete: true}}
+	expectNoError(t, store.Transact(reads, writes))
+	if res, err := store.Get(key1, key2, key3); err != nil {
+		t.Fatal(err)
+	} else if len(res) != 2 || string(res[0].Value) != "val2" || string(res[1].Value) != "val2" {
+		t.Errorf("Expected the writes of the transaction to be committed, but got %v", res)
+	}
+
+	// Reads are no longer current
+	if err := store.Transact(reads, []*serverpb.TxnWrite{{Key: key3, Value: []byte("val4")}}); err != storage.ErrTxnConflict {
+		t.Errorf("Expected error: %v, but got: %v", storage.ErrTxnConflict, err)
+	}
+	if res, err := store.Get(key3); err != nil || len(res) != 0 {
+		t.Errorf("Expected no writes of a conflicting transaction, but got %v, %v", res, err)
+	}
+
+	expiringKey := "txnExpiringKey"
+	expectNoError(t, store.Put(&serverpb.KVPair{Key: []byte(expiringKey), Value: []byte("val"), ExpireTS: uint64(time.Now().Add(time.Hour).Unix())}))
+	if err := store.Transact(nil, []*serverpb.TxnWrite{{Key: []byte(expiringKey), Delete: true}}); err != errTxnOnExpiringKey {
+		t.Errorf("Expected error: %v, but got: %v", errTxnOnExpiringKey, err)
+	}
+}
+
+func TestVersionedReads(t *testing.T) {
+	dbFolder := fmt.Sprintf("%s_versions_%d", dbFolder, time.Now().UnixNano())
+	defer os.RemoveAll(dbFolder)
+	kvs, err := OpenDB(dbFolder, WithVersionRetention(10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer kvs.Close()
+	rdb := kvs.(*rocksDB)
+
+	latestChangeNumber := func() uint64 {
+		chngNum, err := rdb.GetLatestCommittedChangeNumber()
+		expectNoError(t, err)
+		return chngNum
+	}
+	expectAsOf := func(chngNum uint64, expected ...string) {
+		res, err := rdb.GetAsOf(chngNum, []byte("verKey1"), []byte("verKey2"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var actual []string
+		for _, kv := range res {
+			actual = append(actual, fmt.Sprintf("%s=%s", kv.Key, kv.Value))
+		}
+		if fmt.Sprint(actual) != fmt.Sprint(expected) {
+			t.Errorf("GET mismatch as of change number: %d. Expected: %v, Actual: %v", chngNum, expected, actual)
+		}
+	}
+
+	chngNum0 := latestChangeNumber()
+	expectNoError(t, rdb.Put(kvEntry("verKey1", "val1")))
+	chngNum1 := latestChangeNumber()
+	expectNoError(t, rdb.Put(kvEntry("verKey1", "val2"), kvEntry("verKey2", "val3")))
+	chngNum2 := latestChangeNumber()
+	expectNoError(t, rdb.Delete([]byte("verKey1")))
+	chngNum3 := latestChangeNumber()
+	if casRes, err := rdb.CompareAndSet([]byte("verKey2"), []byte("val3"), []byte("val4")); err != nil || !casRes {
+		t.Fatalf("Expected compare and set to succeed, but got %v, %v", casRes, err)
+	}
+	chngNum4 := latestChangeNumber()
+
+	expectAsOf(chngNum0)
+	expectAsOf(chngNum1, "verKey1=val1")
+	expectAsOf(chngNum2, "verKey1=val2", "verKey2=val3")
+	expectAsOf(chngNum3, "verKey2=val3")
+	expectAsOf(chngNum4, "verKey2=val4")
+
+	if _, err = rdb.GetAsOf(chngNum4 + 1); err != errFutureChangeNumber {
+		t.Errorf("Expected error: %v, but got: %v", errFutureChangeNumber, err)
+	}
+	for i := 0; i < 10; i++ {
+		expectNoError(t, rdb.Put(kvEntry("verKey2", fmt.Sprintf("val%d", i+5))))
+	}
+	if _, err = rdb.GetAsOf(chngNum0, []byte("verKey1")); err != errVersionNotRetained {
+		t.Errorf("Expected error: %v, but got: %v", errVersionNotRetained, err)
+	}
+	if _, err = store.GetAsOf(1); err != errVersionedReadsDisabled {
+		t.Errorf("Expected error: %v, but got: %v", errVersionedReadsDisabled, err)
+	}
+}
+
+func TestReadOptions(t *testing.T) {
+	dbFolder := fmt.Sprintf("%s_read_opts_%d", dbFolder, time.Now().UnixNano())
+	defer os.RemoveAll(dbFolder)
+	kvs, err := OpenDB(dbFolder, WithCacheSize(8<<20))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer kvs.Close()
+	rdb := kvs.(*rocksDB)
+
+	key, value := []byte("readOptsKey"), []byte("readOptsVal")
+	expectNoError(t, rdb.Put(&serverpb.KVPair{Key: key, Value: value}))
+	// Compaction flushes the memtables, so that the reads go to SST files
+	// whose blocks are yet to be cached
+	expectNoError(t, rdb.CompactRange(nil))
+
+	cacheOnly := &serverpb.ReadOptions{ReadTier: serverpb.ReadTier_BLOCK_CACHE_TIER}
+	if _, err := rdb.GetWithOptions(cacheOnly, key); err == nil {
+		t.Error("Expected the block cache only read of an uncached key to fail")
+	}
+	skipFill := &serverpb.ReadOptions{SkipBlockCacheFill: true, SkipChecksums: true}
+	if kvs, err := rdb.GetWithOptions(skipFill, key); err != nil || len(kvs) != 1 || !bytes.Equal(kvs[0].Value, value) {
+		t.Errorf("GET mismatch. Key: %s, Expected Value: %s, Actual Values: %v, Error: %v", key, value, kvs, err)
+	}
+	if _, err := rdb.GetWithOptions(cacheOnly, key); err == nil {
+		t.Error("Expected reads skipping the block cache fill to not cache the blocks")
+	}
+	if kvs, err := rdb.GetWithOptions(&serverpb.ReadOptions{}, key); err != nil || len(kvs) != 1 {
+		t.Errorf("GET failed. Key: %s, Actual Values: %v, Error: %v", key, kvs, err)
+	}
+	if kvs, err := rdb.GetWithOptions(cacheOnly, key, key); err != nil || len(kvs) != 2 || !bytes.Equal(kvs[0].Value, value) {
+		t.Errorf("Expected the block cache only read of a cached key to succeed. Actual Values: %v, Error: %v", kvs, err)
+	}
+
+	iterOpts, _ := storage.NewIteratorOptions(storage.IterationPrefixKey([]byte("readOpts")))
+	it := rdb.IterateWithOptions(skipFill, iterOpts)
+	var numKeys int
+	for it.HasNext() {
+		if kv := it.Next(); !bytes.Equal(kv.Value, value) {
+			t.Errorf("Iteration mismatch. Key: %s, Expected Value: %s, Actual Value: %s", kv.Key, value, kv.Value)
+		}
+		numKeys++
+	}
+	expectNoError(t, it.Err())
+	expectNoError(t, it.Close())
+	if numKeys != 1 {
+		t.Errorf("Expected 1 key to be iterated, but got %d", numKeys)
+	}
+}
+
+func TestCompactRange(t *testing.T) {
+	numTrxns, keyPrefix, valPrefix := 50, "compactKey", "compactVal"
+	putKeys(t, numTrxns, keyPrefix, valPrefix, 0)
+	for i := 1; i <= numTrxns/2; i++ {
+		expectNoError(t, store.Delete([]byte(fmt.Sprintf("%s_%d", keyPrefix, i))))
+	}
+
+	expectNoError(t, store.CompactRange([]byte(keyPrefix)))
+	expectNoError(t, store.CompactRange(nil))
+
+	noKeys(t, numTrxns/2, keyPrefix)
+	for i := numTrxns/2 + 1; i <= numTrxns; i++ {
+		key, expVal := fmt.Sprintf("%s_%d", keyPrefix, i), fmt.Sprintf("%s_%d", valPrefix, i)
+		if res, err := store.Get([]byte(key)); err != nil {
+			t.Fatal(err)
+		} else if len(res) != 1 || string(res[0].Value) != expVal {
+			t.Errorf("GET mismatch after compaction. Key: %s, Expected Value: %s, Actual Value: %v", key, expVal, res)
+		}
+	}
+}
+
+func TestStats(t *testing.T) {
+	numTrxns, keyPrefix, valPrefix := 1000, "statsKey", "statsVal"
+	putKeys(t, numTrxns, keyPrefix, valPrefix, 0)
+	// Compaction flushes the memtables into the SST files, whose blocks
+	// are then cached by the reads
+	expectNoError(t, store.CompactRange(nil))
+	getKeys(t, numTrxns, keyPrefix, valPrefix)
+	getKeys(t, numTrxns, keyPrefix, valPrefix)
+	putKeys(t, 10, keyPrefix+"Unflushed", valPrefix, 0)
+
+	es, err := store.Stats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var numFiles uint64
+	for _, levelFiles := range es.NumFilesPerLevel {
+		numFiles += levelFiles
+	}
+	if len(es.NumFilesPerLevel) == 0 || numFiles == 0 {
+		t.Errorf("Expected SST files to be reported. Actual: %v", es.NumFilesPerLevel)
+	}
+	if es.MemtableSizeBytes == 0 {
+		t.Error("Expected non zero memtable size")
+	}
+	if es.BlockCacheHitRate <= 0 || es.BlockCacheHitRate > 1 {
+		t.Errorf("Expected block cache hit rate within (0, 1]. Actual: %f", es.BlockCacheHitRate)
+	}
+	if latestChngNum, _ := store.GetLatestCommittedChangeNumber(); es.OldestChangeNumber == 0 || es.OldestChangeNumber > latestChngNum {
+		t.Errorf("Expected oldest change number within [1, %d]. Actual: %d", latestChngNum, es.OldestChangeNumber)
+	}
+	if es.ChangeHistoryBytes == 0 {
+		t.Error("Expected non zero change history size")
+	}
+}
+
+func TestParseTickers(t *testing.T) {
+	statistics := "rocksdb.block.cache.miss COUNT : 25\nrocksdb.block.cache.hit COUNT : 75\n" +
+		"rocksdb.db.get.micros P50 : 1.000000 P95 : 2.000000 P99 : 3.000000 P100 : 4.000000 COUNT : 10 SUM : 20\n"
+	tickers := parseTickers(statistics)
+	if len(tickers) != 2 || tickers["rocksdb.block.cache.hit"] != 75 || tickers["rocksdb.block.cache.miss"] != 25 {
+		t.Errorf("Unexpected tickers parsed: %v", tickers)
+	}
+}
+
+func TestEstimateSize(t *testing.T) {
+	numTrxns, keyPrefix, valPrefix := 1000, "estimateKey", "estimateVal"
+	putKeys(t, numTrxns, keyPrefix, valPrefix, 0)
+	// Compaction flushes the memtables so that SST file sizes are accounted
+	expectNoError(t, store.CompactRange(nil))
+
+	total, err := store.EstimateSize(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total.NumKeys < uint64(numTrxns) || total.SizeBytes == 0 {
+		t.Errorf("Expected the entire keyspace to account for at least %d keys. Actual: %+v", numTrxns, total)
+	}
+
+	est, err := store.EstimateSize([]byte(keyPrefix), prefixUpperBound([]byte(keyPrefix)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if est.SizeBytes == 0 || est.SizeBytes > total.SizeBytes || est.NumKeys > total.NumKeys {
+		t.Errorf("Expected a non empty estimate within the total of %+v. Actual: %+v", total, est)
+	}
+	if est, err = store.EstimateSize([]byte(keyPrefix), nil); err != nil {
+		t.Fatal(err)
+	} else if est.SizeBytes > total.SizeBytes {
+		t.Errorf("Expected the estimate to be within the total of %+v. Actual: %+v", total, est)
+	}
+
+	if _, err = store.EstimateSize([]byte("b"), []byte("a")); err == nil {
+		t.Error("Expected an error for an invalid key range")
+	}
+}
+
+func TestAutoTunerDecisions(t *testing.T) {
+	bounds := &AutoTuneBounds{
+		MinWriteBufferSize:       1 << 20,
+		MaxWriteBufferSize:       64 << 20,
+		MinBloomFilterBitsPerKey: 5,
+		MaxBloomFilterBitsPerKey: 20,
+		TuneCompression:          true,
+	}
+	expectNoError(t, bounds.Validate())
+	at := &autoTuner{rdb: store, bounds: bounds, applied: make(map[string]string)}
+
+	if chngs := at.decide(workloadStats{reads: 10, writes: 10}); len(chngs) != 0 {
+		t.Errorf("Expected no changes for too few operations. Actual: %v", chngs)
+	}
+
+	writeHeavy := workloadStats{reads: 1000, readMisses: 10, writes: 9000, writtenBytes: 9000 * 100}
+	expected := map[string]string{
+		"write_buffer_size":         fmt.Sprint(bounds.MaxWriteBufferSize),
+		"block_based_table_factory": "{filter_policy=bloomfilter:5:false}",
+		"compression_per_level":     lightCompression,
+	}
+	checkOptionChanges(t, at.decide(writeHeavy), expected)
+	for name, value := range expected {
+		at.applied[name] = value
+	}
+	if chngs := at.decide(writeHeavy); len(chngs) != 0 {
+		t.Errorf("Expected no changes for options already applied. Actual: %v", chngs)
+	}
+
+	readHeavy := workloadStats{reads: 9000, readMisses: 4000, writes: 1000, writtenBytes: 1000 * 100}
+	checkOptionChanges(t, at.decide(readHeavy), map[string]string{
+		"write_buffer_size":         fmt.Sprint(bounds.MinWriteBufferSize),
+		"block_based_table_factory": "{filter_policy=bloomfilter:20:false}",
+		"compression_per_level":     denseCompression,
+	})
+
+	invalidBounds := []*AutoTuneBounds{
+		{MinWriteBufferSize: 1, MaxWriteBufferSize: 64 << 20},
+		{MinWriteBufferSize: 64 << 20, MaxWriteBufferSize: 1 << 20},
+		{MinBloomFilterBitsPerKey: 20, MaxBloomFilterBitsPerKey: 5},
+		{MinBloomFilterBitsPerKey: 5, MaxBloomFilterBitsPerKey: 100},
+	}
+	for _, bnds := range invalidBounds {
+		expectError(t, bnds.Validate())
+	}
+}
+
+func TestAutoTunerSetOptions(t *testing.T) {
+	expectNoError(t, store.setCFOption(store.normalCF, "write_buffer_size", fmt.Sprint(4<<20)))
+	expectNoError(t, store.setCFOption(store.normalCF, "compression_per_level", denseCompression))
+	expectError(t, store.setCFOption(store.normalCF, "no_such_option", "1"))
+}
+
+func checkOptionChanges(t *testing.T, chngs []optionChange, expected map[string]string) {
+	if len(chngs) != len(expected) {
+		t.Errorf("Expected %d option changes. Actual: %v", len(expected), chngs)
+	}
+	for _, chng := range chngs {
+		if value, present := expected[chng.name]; !present || value != chng.value {
+			t.Errorf("Unexpected change of option %s to %s. Expected: %s", chng.name, chng.value, value)
+		}
+		if chng.reason == "" {
+			t.Errorf("Expected a reason for the change of option %s", chng.name)
+		}
+	}
+}
+
+func TestReadAmplificationReport(t *testing.T) {
+	numTrxns, keyPrefix, valPrefix := 200, "readAmpKey", "readAmpVal"
+	putKeys(t, numTrxns, keyPrefix, valPrefix, 0)
+	// Compaction flushes the memtables so that the reads go to SST files
+	expectNoError(t, store.CompactRange(nil))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		// Wait for the sampling to begin
+		for atomic.LoadUint32(&store.readAmpSampling) == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		if _, err := store.SampleReadAmplification(time.Second); err != errReadAmpSamplingInProgress {
+			t.Errorf("Expected concurrent sampling to be rejected. Actual error: %v", err)
+		}
+		for i := 1; i <= numTrxns; i++ {
+			key := fmt.Sprintf("%s_%d", keyPrefix, i)
+			if _, err := store.Get([]byte(key)); err != nil {
+				t.Error(err)
+			}
+		}
+	}()
+
+	readAmp, err := store.SampleReadAmplification(2 * time.Second)
+	<-done
+	if err != nil {
+		t.Fatal(err)
+	}
+	if readAmp.NumReads < uint64(numTrxns) || readAmp.NumKeys < uint64(numTrxns) {
+		t.Errorf("Expected at least %d reads to be sampled. Actual: %+v", numTrxns, readAmp)
+	}
+	if readAmp.MemtableHitRate == 1 || readAmp.BlockReadsPerKey+readAmp.BlockCacheHitRate == 0 {
+		t.Errorf("Expected the reads to be served from SST files. Actual: %+v", readAmp)
+	}
+	if len(readAmp.Suggestions) == 0 {
+		t.Error("Expected at least one suggestion")
+	}
+
+	// Reads outside the window are not sampled
+	if _, err = store.Get([]byte(keyPrefix + "_1")); err != nil {
+		t.Fatal(err)
+	}
+	if readAmp, err = store.SampleReadAmplification(10 * time.Millisecond); err != nil {
+		t.Fatal(err)
+	} else if readAmp.NumReads != 0 || len(readAmp.Suggestions) != 1 {
+		t.Errorf("Expected no reads to be sampled. Actual: %+v", readAmp)
+	}
+}
+
+func TestPrefixUpperBound(t *testing.T) {
+	testCases := []struct {
+		prefix, limit []byte
+	}{
+		{[]byte("abc"), []byte("abd")},
+		{[]byte{'a', 0xFF}, []byte("b")},
+		{[]byte{0xFF, 0xFF}, nil},
+	}
+	for _, tc := range testCases {
+		if limit := prefixUpperBound(tc.prefix); !bytes.Equal(limit, tc.limit) {
+			t.Errorf("Upper bound mismatch for prefix: %v. Expected: %v, Actual: %v", tc.prefix, tc.limit, limit)
+		}
+	}
+}
+
+func TestLegalHold(t *testing.T) {
+	numTrxns, keyPrefix, valPrefix := 5, "heldKey", "heldVal"
+	putKeys(t, numTrxns, keyPrefix, valPrefix, time.Now().Add(2*time.Second).Unix())
+	expectNoError(t, store.PlaceLegalHold([]byte(keyPrefix)))
+
+	key := []byte(fmt.Sprintf("%s_%d", keyPrefix, 1))
+	if err := store.Delete(key); err != storage.ErrUnderLegalHold {
+		t.Errorf("Expected delete to be rejected due to legal hold, but got %v", err)
+	}
+
+	// Keys under legal hold must not expire
+	time.Sleep(3 * time.Second)
+	expectNoError(t, store.CompactRange([]byte(keyPrefix)))
+	getKeys(t, numTrxns, keyPrefix, valPrefix)
+
+	expectNoError(t, store.LiftLegalHold([]byte(keyPrefix)))
+	noKeys(t, numTrxns, keyPrefix)
+	expectNoError(t, store.Delete(key))
+}
+
+func TestLegalHoldsReplicated(t *testing.T) {
+	openHoldsDB := func(name string) (string, *rocksDB) {
+		dbFolder := fmt.Sprintf("%s_holds_%s_%d", dbFolder, name, time.Now().UnixNano())
+		kvs, err := OpenDB(dbFolder, WithSyncWrites())
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { os.RemoveAll(dbFolder) })
+		return dbFolder, kvs.(*rocksDB)
+	}
+	masterFolder, master := openHoldsDB("master")
+	_, slave := openHoldsDB("slave")
+	defer slave.Close()
+
+	fromChngNum, _ := master.GetLatestCommittedChangeNumber()
+	expectNoError(t, master.PlaceLegalHold([]byte("held:")))
+	expectNoError(t, master.PlaceLegalHold([]byte("lifted:")))
+	expectNoError(t, master.LiftLegalHold([]byte("lifted:")))
+	chngs, err := master.LoadChanges(fromChngNum+1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = slave.SaveChanges(chngs); err != nil {
+		t.Fatal(err)
+	}
+
+	// Legal holds are replicated and survive reopening
+	master.Close()
+	kvs, err := OpenDB(masterFolder, WithSyncWrites())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer kvs.Close()
+	for _, rdb := range []*rocksDB{kvs.(*rocksDB), slave} {
+		if holds := rdb.ListLegalHolds(); len(holds) != 1 || string(holds[0]) != "held:" {
+			t.Errorf("Expected only held: to be under legal hold, but got %q", holds)
+		}
+		if err := rdb.Delete([]byte("held:1")); err != storage.ErrUnderLegalHold {
+			t.Errorf("Expected delete to be rejected due to legal hold, but got %v", err)
+		}
+	}
+}
+
+func TestRecordsReplicated(t *testing.T) {
+	openRecordsDB := func(name string) *rocksDB {
+		dbFolder := fmt.Sprintf("%s_records_%s_%d", dbFolder, name, time.Now().UnixNano())
+		kvs, err := OpenDB(dbFolder, WithSyncWrites())
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { os.RemoveAll(dbFolder) })
+		return kvs.(*rocksDB)
+	}
+	master, slave := openRecordsDB("master"), openRecordsDB("slave")
+	defer master.Close()
+	defer slave.Close()
+
+	if err := master.SaveRecord([]byte("unreserved"), []byte("record")); err == nil {
+		t.Error("Expected an error for saving a record under an unreserved key")
+	}
+	fromChngNum, _ := master.GetLatestCommittedChangeNumber()
+	renames, err := storage.OpenPrefixRenames(master)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectNoError(t, renames.Begin([]byte("old:"), []byte("new:")))
+	chngs, err := master.LoadChanges(fromChngNum+1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chngs) != 1 || !storage.ChangesPrefixRenames(chngs[0]) {
+		t.Fatalf("Expected a single change of the prefix renames, but got %d changes", len(chngs))
+	}
+	if _, err = slave.SaveChanges(chngs); err != nil {
+		t.Fatal(err)
+	}
+	if renames, err = storage.OpenPrefixRenames(slave); err != nil {
+		t.Fatal(err)
+	}
+	if list := renames.List(); len(list) != 1 || string(list[0].OldPrefix) != "old:" || string(list[0].NewPrefix) != "new:" {
+		t.Errorf("Expected the rename of old: to new: to be replicated, but got %v", list)
+	}
+}
+
+func TestSubjectErasure(t *testing.T) {
+	dbFolder := "/tmp/rdb_subject_erasure"
+	if err := exec.Command("rm", "-rf", dbFolder).Run(); err != nil {
+		t.Fatal(err)
+	}
+	db, err := OpenDB(dbFolder, WithSubjectEncryption([]string{"user:"}, ":"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	expectNoError(t, db.Put(kvEntry("user:1:name", "alice"), kvEntry("user:2:name", "bob"), kvEntry("item:1", "book")))
+	if res, err := db.Get([]byte("user:1:name")); err != nil {
+		t.Fatal(err)
+	} else if len(res) != 1 || string(res[0].Value) != "alice" {
+		t.Errorf("Expected value alice for user:1:name, but got %v", res)
+	}
+	if _, err = db.CompareAndSet([]byte("user:1:name"), []byte("alice"), []byte("carol")); err == nil {
+		t.Error("Expected an error for compare and set on keys of encrypted subjects")
+	}
+	expectError(t, db.Put(kvEntry(string(storage.SubjectKeyRecord([]byte("user:1"))), "key")))
+
+	expectError(t, db.DeleteSubject([]byte("user:1:name")))
+	expectNoError(t, db.DeleteSubject([]byte("user:1")))
+
+	if res, err := db.Get([]byte("user:1:name"), []byte("user:2:name"), []byte("item:1")); err != nil {
+		t.Fatal(err)
+	} else if len(res) != 2 || string(res[0].Value) != "bob" || string(res[1].Value) != "book" {
+		t.Errorf("Expected only the erased subject to be unreadable, but got %v", res)
+	}
+
+	itOpts, _ := storage.NewIteratorOptions()
+	iter := db.Iterate(itOpts)
+	defer iter.Close()
+	actCnt := 0
+	for ; iter.HasNext(); actCnt++ {
+		if kv := iter.Next(); string(kv.Key) == "user:1:name" || storage.IsReservedKey(kv.Key) {
+			t.Errorf("Did not expect iterator to give key: %q", kv.Key)
+		}
+	}
+	if actCnt != 2 {
+		t.Errorf("Expected iterator to give 2 keys, but got %d keys", actCnt)
+	}
+}
+
+func TestSubjectKeyRedaction(t *testing.T) {
+	openShredDB := func(name string) (*rocksDB, string) {
+		dbFolder := fmt.Sprintf("%s_%s_%d", dbFolder, name, time.Now().UnixNano())
+		changeLogDir := dbFolder + "_log"
+		kvs, err := OpenDB(dbFolder, WithSyncWrites(), WithSubjectEncryption([]string{"user:"}, ":"),
+			WithChangeLog(changeLogDir, 1<<20, 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			kvs.Close()
+			os.RemoveAll(dbFolder)
+			os.RemoveAll(changeLogDir)
+		})
+		return kvs.(*rocksDB), changeLogDir
+	}
+	subjKeyOf := func(chngs []*serverpb.ChangeRecord, subject string) []byte {
+		for _, chng := range chngs {
+			for _, trxn := range chng.Trxns {
+				if bytes.Equal(trxn.Key, storage.SubjectKeyRecord([]byte(subject))) && trxn.Type == serverpb.TrxnRecord_Put {
+					return trxn.Value
+				}
+			}
+		}
+		t.Fatalf("Expected the changes to carry the encryption key of subject %s", subject)
+		return nil
+	}
+	assertNotInChangeLog := func(changeLogDir string, subjKey []byte) {
+		segs, _ := filepath.Glob(filepath.Join(changeLogDir, "*.seg"))
+		for _, seg := range segs {
+			if data, err := ioutil.ReadFile(seg); err != nil {
+				t.Fatal(err)
+			} else if bytes.Contains(data, subjKey) {
+				t.Errorf("Expected the change log segment %s to not hold the erased encryption key", seg)
+			}
+		}
+	}
+	master, masterLogDir := openShredDB("shred_master")
+	slave, slaveLogDir := openShredDB("shred_slave")
+
+	fromChngNum, _ := master.GetLatestCommittedChangeNumber()
+	expectNoError(t, master.Put(kvEntry("user:1:name", "alice"), kvEntry("user:2:name", "bob")))
+	chngs, err := master.LoadChanges(fromChngNum+1, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	erasedKey, retainedKey := subjKeyOf(chngs, "user:1"), subjKeyOf(chngs, "user:2")
+	if len(erasedKey) == 0 || len(retainedKey) == 0 {
+		t.Fatal("Expected the changes to carry non empty encryption keys")
+	}
+	if _, err = slave.SaveChanges(chngs); err != nil {
+		t.Fatal(err)
+	}
+
+	expectNoError(t, master.DeleteSubject([]byte("user:1")))
+	assertNotInChangeLog(masterLogDir, erasedKey)
+	redactedChngs, err := master.LoadChanges(fromChngNum+1, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subjKey := subjKeyOf(redactedChngs, "user:1"); len(subjKey) != 0 {
+		t.Errorf("Expected the erased encryption key to be redacted from the changes. Actual: %x", subjKey)
+	}
+	if subjKey := subjKeyOf(redactedChngs, "user:2"); !bytes.Equal(subjKey, retainedKey) {
+		t.Errorf("Expected the encryption key of user:2 to be retained. Actual: %x", subjKey)
+	}
+
+	// Replicas redact the erased key once they apply its deletion
+	slaveChngNum, _ := slave.GetLatestCommittedChangeNumber()
+	if chngs, err = master.LoadChanges(slaveChngNum+1, 100); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = slave.SaveChanges(chngs); err != nil {
+		t.Fatal(err)
+	}
+	assertNotInChangeLog(slaveLogDir, erasedKey)
+
+	// Replicas applying the redacted changes find the subject erased
+	freshSlave, _ := openShredDB("shred_fresh_slave")
+	if _, err = freshSlave.SaveChanges(redactedChngs); err != nil {
+		t.Fatal(err)
+	}
+	for _, rdb := range []*rocksDB{slave, freshSlave} {
+		if res, err := rdb.Get([]byte("user:1:name"), []byte("user:2:name")); err != nil {
+			t.Fatal(err)
+		} else if len(res) != 1 || string(res[0].Value) != "bob" {
+			t.Errorf("Expected only the erased subject to be unreadable, but got %v", res)
 		}
 	}
 }
 
-func TestMultiGet(t *testing.T) {
-	numKeys := 10
-	keys, vals := make([][]byte, numKeys), make([]string, numKeys)
-	for i := 1; i <= numKeys; i++ {
-		key, value := fmt.Sprintf("MK%d", i), fmt.Sprintf("MV%d", i)
-		ttl := int64(0)
-		if i&1 == 1 {
-			ttl = time.Now().Add(2 * time.Second).Unix()
+func TestEncryptionAtRest(t *testing.T) {
+	dbFolder := "/tmp/rdb_encryption_at_rest"
+	if err := exec.Command("rm", "-rf", dbFolder).Run(); err != nil {
+		t.Fatal(err)
+	}
+	keyFile := filepath.Join(t.TempDir(), "master_keys.json")
+	writeMasterKeys := func(keyIDs ...string) {
+		var keys []string
+		for _, keyID := range keyIDs {
+			keys = append(keys, fmt.Sprintf(`{"id": "%s", "key": "%x"}`, keyID, sha256.Sum256([]byte(keyID))))
 		}
-		err := store.Put(&serverpb.KVPair{Key: []byte(key), Value: []byte(value), ExpireTS: uint64(ttl)})
-		if err != nil {
-			t.Fatalf("Unable to PUT. Key: %s, Value: %s, Error: %v", key, value, err)
-		} else {
-			keys[i-1] = []byte(key)
-			vals[i-1] = value
+		if err := ioutil.WriteFile(keyFile, []byte("["+strings.Join(keys, ",")+"]"), 0600); err != nil {
+			t.Fatal(err)
 		}
 	}
+	writeMasterKeys("k1")
+	kvs, err := OpenDB(dbFolder, WithSyncWrites(), WithEncryptionAtRest(keyFile),
+		WithSubjectEncryption([]string{"user:"}, ":"), WithMergeOperatorName("ctr:", "counter"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := kvs.(*rocksDB)
+	defer db.Close()
 
-	if results, err := store.Get(keys...); err != nil {
+	expectNoError(t, db.Put(kvEntry("enc:1", "secret"), kvEntry("user:1:name", "alice")))
+	expectNoError(t, db.Put(&serverpb.KVPair{Key: []byte("enc:ttl"), Value: []byte("expiring secret"), ExpireTS: hlc.GetUnixTimeFromNow(3600)}))
+	expectNoError(t, db.Merge([]byte("ctr:1"), []byte("5")))
+	expectNoError(t, db.Merge([]byte("ctr:1"), []byte("3")))
+	if updated, err := db.CompareAndSet([]byte("enc:1"), []byte("secret"), []byte("new secret")); !updated || err != nil {
+		t.Errorf("Expected compare and set over the decrypted value to succeed. Error: %v", err)
+	}
+
+	raw, err := db.db.GetCF(db.opts.readOpts, db.normalCF, []byte("enc:1"))
+	if err != nil {
 		t.Fatal(err)
-	} else {
-		for i, result := range results {
-			if string(result.Value) != vals[i] {
-				t.Errorf("Multi Get value mismatch. Key: %s, Expected Value: %s, Actual Value: %s", keys[i], vals[i], result.Value)
+	}
+	if rawVal := toByteArray(raw); bytes.Contains(rawVal, []byte("secret")) || db.opts.keyring.NeedsRotation(rawVal) {
+		t.Errorf("Expected the stored value to be encrypted using the active key. Actual: %q", rawVal)
+	}
+	raw.Free()
+
+	expected := map[string]string{"enc:1": "new secret", "enc:ttl": "expiring secret", "user:1:name": "alice", "ctr:1": "8"}
+	checkDecrypted := func() {
+		for key, val := range expected {
+			if res, err := db.Get([]byte(key)); err != nil || len(res) != 1 || string(res[0].Value) != val {
+				t.Errorf("Expected value %s for key %s. Actual: %v, Error: %v", val, key, res, err)
 			}
 		}
 	}
-}
+	checkDecrypted()
 
-func TestMissingGet(t *testing.T) {
-	key := "MissingKey"
-	if readResults, err := store.Get([]byte(key)); err != nil {
-		t.Errorf("Expected no error since given key is only missing. But got error: %v", err)
-	} else if len(readResults) > 0 {
-		t.Errorf("Expected no values for missing key. Key: %s, Actual Value: %v", key, readResults)
+	writeMasterKeys("k1", "k2")
+	rotation, err := db.RotateEncryptionKey()
+	if err != nil {
+		t.Fatalf("Unable to rotate encryption key. Error: %v", err)
 	}
-}
+	// Values of the keys along with the subject key are re-encrypted
+	if rotation.ActiveKeyID != "k2" || rotation.NumReencrypted < 4 || rotation.NumScanned < rotation.NumReencrypted {
+		t.Errorf("Unexpected key rotation: %+v", rotation)
+	}
+	checkDecrypted()
 
-func TestBackupFolderValidity(t *testing.T) {
-	expectError(t, checksForBackup(""))
-	expectError(t, checksForBackup("/missing/backup"))
-	expectNoError(t, checksForBackup("/tmp/backup.bak"))
-	expectNoError(t, checksForBackup("/missing"))
-	expectNoError(t, checksForBackup(dbFolder))
-}
+	// Once re-encrypted, the values no longer need the retired key
+	writeMasterKeys("k2")
+	if rotation, err = db.RotateEncryptionKey(); err != nil || rotation.NumReencrypted != 0 {
+		t.Errorf("Expected no values to be re-encrypted again. Rotation: %+v, Error: %v", rotation, err)
+	}
+	checkDecrypted()
 
-func TestRestoreFolderValidity(t *testing.T) {
-	expectError(t, checksForRestore(""))
-	expectError(t, checksForRestore("/missing/backup"))
-	expectError(t, checksForRestore("/missing"))
-	expectNoError(t, checksForRestore(dbFolder))
+	// Values whose master key is unavailable fail to be read, rather
+	// than being reported as absent
+	writeMasterKeys("k3")
+	expectNoError(t, db.opts.keyring.Reload())
+	if res, err := db.Get([]byte("enc:1")); err == nil {
+		t.Errorf("Expected an error for reading a value of an unavailable master key. Actual: %v", res)
+	}
+	if _, err := db.CompareAndSet([]byte("enc:1"), nil, []byte("secret")); err == nil {
+		t.Error("Expected an error for compare and set over a value of an unavailable master key")
+	}
+	itOpts, err := storage.NewIteratorOptions(storage.IterationPrefixKey([]byte("enc:")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	iter := db.Iterate(itOpts)
+	for iter.HasNext() {
+		iter.Next()
+	}
+	if err = iter.Err(); err == nil {
+		t.Error("Expected an error for iterating over values of an unavailable master key")
+	}
+	iter.Close()
+	writeMasterKeys("k2")
+	expectNoError(t, db.opts.keyring.Reload())
+
+	if _, err = store.RotateEncryptionKey(); err != errEncryptionNotEnabled {
+		t.Errorf("Expected rotation to fail without encryption. Actual: %v", err)
+	}
 }
 
-func TestBackupAndRestore(t *testing.T) {
-	numTrxns, keyPrefix, valPrefix := 5, "brKey", "brVal"
-	putKeys(t, numTrxns, keyPrefix, valPrefix, 0)
+func TestTenantEncryption(t *testing.T) {
+	dbFolder := "/tmp/rdb_tenant_encryption"
+	if err := exec.Command("rm", "-rf", dbFolder).Run(); err != nil {
+		t.Fatal(err)
+	}
+	keyFile := filepath.Join(t.TempDir(), "master_keys.json")
+	if err := ioutil.WriteFile(keyFile, []byte(fmt.Sprintf(`[{"id": "k1", "key": "%x"}]`, sha256.Sum256([]byte("k1")))), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenDB(dbFolder, WithTenantEncryption([]string{"acme:"})); err != errTenantsWithoutEncryption {
+		t.Errorf("Expected tenant encryption to require encryption at rest. Actual: %v", err)
+	}
+	// Values stored before enabling tenant encryption remain readable
+	kvs, err := OpenDB(dbFolder, WithSyncWrites(), WithEncryptionAtRest(keyFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectNoError(t, kvs.Put(kvEntry("acme:legacy", "old")))
+	kvs.Close()
 
-	backupPath := fmt.Sprintf("%s/%s", dbFolder, "backup")
-	if err := store.BackupTo(backupPath); err != nil {
+	kvs, err = OpenDB(dbFolder, WithSyncWrites(), WithEncryptionAtRest(keyFile),
+		WithTenantEncryption([]string{"acme:", "globex:"}), WithMergeOperatorName("acme:ctr:", "counter"))
+	if err != nil {
 		t.Fatal(err)
-	} else {
-		missKeyPrefix, missValPrefix := "mbrKey", "mbrVal"
-		putKeys(t, numTrxns, missKeyPrefix, missValPrefix, 0)
-		store.Close()
-		if st, _, _, _, err := store.RestoreFrom(backupPath); err != nil {
-			t.Fatal(err)
-		} else {
-			store = st.(*rocksDB)
-			getKeys(t, numTrxns, keyPrefix, valPrefix)
-			noKeys(t, numTrxns, missKeyPrefix)
-		}
 	}
-}
+	db := kvs.(*rocksDB)
+	defer db.Close()
 
-func TestGetPutSnapshot(t *testing.T) {
-	numTrxns := 100
-	ttl := time.Now().Add(5 * time.Second)
-	keyPrefix1, valPrefix1, newValPrefix1 := "firSnapKey", "firSnapVal", "newFirSnapVal"
-	keyPrefix1T, valPrefix1T, newValPrefix1T := "firSnapTTLKey", "firSnapTTLVal", "newFirSnapTTLVal"
+	expectNoError(t, db.Put(kvEntry("acme:1", "anvil"), kvEntry("globex:1", "globe"), kvEntry("other:1", "plain")))
+	expectNoError(t, db.Put(&serverpb.KVPair{Key: []byte("acme:ttl"), Value: []byte("rocket"), ExpireTS: hlc.GetUnixTimeFromNow(3600)}))
+	expectError(t, db.Merge([]byte("acme:ctr:1"), []byte("1")))
+	if updated, err := db.CompareAndSet([]byte("acme:1"), []byte("anvil"), []byte("new anvil")); !updated || err != nil {
+		t.Errorf("Expected compare and set over the decrypted value to succeed. Error: %v", err)
+	}
+	raw, err := db.db.GetCF(db.opts.readOpts, db.normalCF, []byte("acme:1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rawVal := toByteArray(raw); !storage.IsTenantSealed(rawVal) {
+		t.Errorf("Expected the stored value to be encrypted using the data key of the tenant. Actual: %q", rawVal)
+	}
+	raw.Free()
 
-	putKeys(t, numTrxns, keyPrefix1, valPrefix1, 0)
-	putKeys(t, numTrxns, keyPrefix1T, valPrefix1T, ttl.Unix())
+	checkValues := func(expected map[string]string) {
+		for key, val := range expected {
+			res, err := db.Get([]byte(key))
+			if val == "" && (err != nil || len(res) != 0) {
+				t.Errorf("Expected key %s to be unreadable. Actual: %v, Error: %v", key, res, err)
+			} else if val != "" && (err != nil || len(res) != 1 || string(res[0].Value) != val) {
+				t.Errorf("Expected value %s for key %s. Actual: %v, Error: %v", val, key, res, err)
+			}
+		}
+	}
+	checkValues(map[string]string{"acme:legacy": "old", "acme:1": "new anvil", "acme:ttl": "rocket", "globex:1": "globe", "other:1": "plain"})
 
-	if snap, err := store.GetSnapshot(); err != nil {
-		t.Fatal(err)
-	} else {
-		putKeys(t, numTrxns, keyPrefix1, newValPrefix1, 0)
-		putKeys(t, numTrxns, keyPrefix1T, newValPrefix1T, ttl.Unix())
+	// Master key rotation leaves the values encrypted using data keys as is
+	rotation, err := db.RotateEncryptionKey()
+	if err != nil || rotation.NumReencrypted != 0 {
+		t.Errorf("Did not expect any values to be re-encrypted. Rotation: %+v, Error: %v", rotation, err)
+	}
 
-		keyPrefix2, valPrefix2 := "secSnapKey", "secSnapVal"
-		putKeys(t, numTrxns, keyPrefix2, valPrefix2, 0)
+	// Tenant key rotation re-encrypts all the values of the tenant, including the legacy one
+	if _, err = db.RotateTenantKey([]byte("acme:1")); err == nil {
+		t.Error("Expected an error for rotating the key of an unknown tenant")
+	}
+	if rotation, err = db.RotateTenantKey([]byte("acme:")); err != nil {
+		t.Fatalf("Unable to rotate tenant key. Error: %v", err)
+	}
+	if rotation.ActiveKeyID != "2" || rotation.NumReencrypted != 3 || rotation.NumScanned < rotation.NumReencrypted {
+		t.Errorf("Unexpected tenant key rotation: %+v", rotation)
+	}
+	checkValues(map[string]string{"acme:legacy": "old", "acme:1": "new anvil", "acme:ttl": "rocket", "globex:1": "globe"})
+	// Data keys read since the rotation are cached afresh
+	if keys, _ := db.tenantKeys.get([]byte("acme:")); keys == nil || keys.ActiveKeyID() != "2" {
+		t.Errorf("Expected the rotated data keys of the tenant to be cached. Actual: %+v", keys)
+	}
 
-		if err := store.PutSnapshot(snap); err != nil {
-			t.Fatal(err)
-		} else {
-			getKeys(t, numTrxns, keyPrefix1, valPrefix1)
-			getKeys(t, numTrxns, keyPrefix1T, valPrefix1T)
-			//getKeys(t, numTrxns, keyPrefix2, valPrefix2)
-			noKeys(t, numTrxns, keyPrefix2)
+	// Erasure of a tenant leaves the other tenants readable
+	expectError(t, db.DeleteTenant([]byte("initech:")))
+	expectNoError(t, db.DeleteTenant([]byte("acme:")))
+	expectNoError(t, db.DeleteTenant([]byte("acme:")))
+	checkValues(map[string]string{"acme:legacy": "", "acme:1": "", "acme:ttl": "", "globex:1": "globe", "other:1": "plain"})
+	expectError(t, db.Put(kvEntry("acme:2", "dynamite")))
+	if _, err = db.RotateTenantKey([]byte("acme:")); err != errTenantErased {
+		t.Errorf("Expected rotation of an erased tenant to fail. Actual: %v", err)
+	}
+
+	itOpts, _ := storage.NewIteratorOptions()
+	iter := db.Iterate(itOpts)
+	defer iter.Close()
+	for iter.HasNext() {
+		if kv := iter.Next(); bytes.HasPrefix(kv.Key, []byte("acme:")) || storage.IsReservedKey(kv.Key) {
+			t.Errorf("Did not expect iterator to give key: %q", kv.Key)
 		}
 	}
 }
 
-func TestGetPutSnapshotTTLOnly(t *testing.T) {
-	numTrxns := 100
-	ttl := time.Now().Add(5 * time.Second)
-	keyPrefix1T, valPrefix1T, newValPrefix1T := "firSnapTTLKey", "firSnapTTLVal", "newFirSnapTTLVal"
-
-	putKeys(t, numTrxns, keyPrefix1T, valPrefix1T, ttl.Unix())
+func TestMergeOperators(t *testing.T) {
+	openMergeDB := func(name string) *rocksDB {
+		dbFolder := fmt.Sprintf("%s_%s_%d", dbFolder, name, time.Now().UnixNano())
+		kvs, err := OpenDB(dbFolder, WithSyncWrites(),
+			WithMergeOperatorName("ctr:", "counter"), WithMergeOperatorName("tags:", "set"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			kvs.Close()
+			os.RemoveAll(dbFolder)
+		})
+		return kvs.(*rocksDB)
+	}
+	master, slave := openMergeDB("master"), openMergeDB("slave")
+
+	fromChngNum, _ := master.GetLatestCommittedChangeNumber()
+	merges := []struct{ key, operand string }{
+		{"ctr:1", "5"}, {"ctr:1", "-2"}, {"ctr:1", "10"},
+		{"tags:1", "b,a"}, {"tags:1", "c,a"},
+	}
+	for _, m := range merges {
+		if err := master.Merge([]byte(m.key), []byte(m.operand)); err != nil {
+			t.Fatalf("Unable to MERGE. Key: %s, Operand: %s, Error: %v", m.key, m.operand, err)
+		}
+	}
+	if err := master.Merge([]byte("other:1"), []byte("1")); err != errNoMergeOperator {
+		t.Errorf("Expected no merge operator error. Actual: %v", err)
+	}
+	// Values that the merge operators cannot merge are rejected upfront
+	if err := master.Merge([]byte("ctr:1"), []byte("one")); err != storage.ErrUnmergeableValue {
+		t.Errorf("Expected unmergeable operand error. Actual: %v", err)
+	}
+	if err := master.Put(kvEntry("ctr:2", "two")); err != storage.ErrUnmergeableValue {
+		t.Errorf("Expected unmergeable value error. Actual: %v", err)
+	}
+	expectNoError(t, master.Put(kvEntry("ctr:2", "2"), kvEntry("other:2", "two")))
+	// Merges would discard the expiring versions of the keys under legal hold
+	expectNoError(t, master.PlaceLegalHold([]byte("ctr:held")))
+	if err := master.Merge([]byte("ctr:held"), []byte("1")); err != storage.ErrUnderLegalHold {
+		t.Errorf("Expected merge to be rejected due to legal hold. Actual: %v", err)
+	}
 
-	if snap, err := store.GetSnapshot(); err != nil {
+	expVals := map[string]string{"ctr:1": "13", "tags:1": "a,b,c"}
+	chngs, err := master.LoadChanges(fromChngNum+1, len(merges))
+	if err != nil {
 		t.Fatal(err)
-	} else {
-		putKeys(t, numTrxns, keyPrefix1T, newValPrefix1T, ttl.Unix())
-
-		keyPrefix2, valPrefix2 := "secSnapKey", "secSnapVal"
-		putKeys(t, numTrxns, keyPrefix2, valPrefix2, 0)
+	}
+	for _, chng := range chngs {
+		if trxn := chng.Trxns[len(chng.Trxns)-1]; trxn.Type != serverpb.TrxnRecord_Merge {
+			t.Errorf("Expected a merge transaction. Actual: %v", trxn.Type)
+		}
+	}
+	if _, err = slave.SaveChanges(chngs); err != nil {
+		t.Fatal(err)
+	}
+	for _, rdb := range []*rocksDB{master, slave} {
+		for key, expVal := range expVals {
+			if vals, err := rdb.Get([]byte(key)); err != nil {
+				t.Errorf("Unable to GET. Key: %s, Error: %v", key, err)
+			} else if string(vals[0].Value) != expVal {
+				t.Errorf("GET mismatch. Key: %s, Expected Value: %s, Actual Value: %s", key, expVal, vals[0].Value)
+			}
+		}
+	}
+}
 
-		if err := store.PutSnapshot(snap); err != nil {
+func TestChangeLog(t *testing.T) {
+	dbFolder := fmt.Sprintf("%s_changelog_%d", dbFolder, time.Now().UnixNano())
+	changeLogDir := dbFolder + "_log"
+	defer os.RemoveAll(dbFolder)
+	defer os.RemoveAll(changeLogDir)
+	openChangeLogDB := func() *rocksDB {
+		kvs, err := OpenDB(dbFolder, WithSyncWrites(), WithChangeLog(changeLogDir, 1024, 0))
+		if err != nil {
 			t.Fatal(err)
-		} else {
-			getKeys(t, numTrxns, keyPrefix1T, valPrefix1T)
-			//getKeys(t, numTrxns, keyPrefix2, valPrefix2)
-			noKeys(t, numTrxns, keyPrefix2)
 		}
+		return kvs.(*rocksDB)
 	}
-}
+	rdb := openChangeLogDB()
 
-func TestIterationOnExplicitSnapshot(t *testing.T) {
+	fromChngNum, _ := rdb.GetLatestCommittedChangeNumber()
+	fromChngNum++
 	numTrxns := 100
-	keyPrefix1, valPrefix1 := "firKey", "firVal"
-	putKeys(t, numTrxns, keyPrefix1, valPrefix1, 0)
-
-	snap := store.db.NewSnapshot()
-	defer store.db.ReleaseSnapshot(snap)
-
-	keyPrefix2, valPrefix2 := "secKey", "secVal"
-	putKeys(t, numTrxns, keyPrefix2, valPrefix2, 0)
-
-	readOpts := gorocksdb.NewDefaultReadOptions()
-	defer readOpts.Destroy()
-
-	readOpts.SetSnapshot(snap)
-	it := store.db.NewIterator(readOpts)
-	defer it.Close()
+	for i := 1; i <= numTrxns; i++ {
+		expectNoError(t, rdb.Put(&serverpb.KVPair{Key: []byte(fmt.Sprintf("clKey%d", i)), Value: []byte("newVal")}))
+	}
+	if ok, err := rdb.CompareAndSet([]byte("clCasKey"), nil, []byte("casVal")); !ok || err != nil {
+		t.Fatalf("Unable to CAS. Error: %v", err)
+	}
+	expectNoError(t, rdb.Delete([]byte("clKey1")))
+	if segs, _ := filepath.Glob(filepath.Join(changeLogDir, "*.seg")); len(segs) < 2 {
+		t.Errorf("Expected change log segments to be rolled over. Segments: %d", len(segs))
+	}
 
-	actCnt := 0
-	for it.SeekToFirst(); it.Valid(); it.Next() {
-		k, v := string(it.Key().Data()), string(it.Value().Data())
-		if strings.HasPrefix(k, keyPrefix1) && strings.HasPrefix(v, valPrefix1) {
-			actCnt++
+	assertChanges := func(rdb *rocksDB) {
+		chngs, err := rdb.LoadChanges(fromChngNum, 2*numTrxns+10)
+		if err != nil {
+			t.Fatal(err)
 		}
-
-		if strings.HasPrefix(k, keyPrefix2) || strings.HasPrefix(v, valPrefix2) {
-			t.Errorf("Did not expect snapshot iterator to give key: %s with value: %s", k, v)
+		if chngs[0].ChangeNumber != fromChngNum {
+			t.Errorf("Expected first change number: %d. Actual: %d", fromChngNum, chngs[0].ChangeNumber)
+		}
+		if len(chngs) != numTrxns+2 {
+			t.Fatalf("Expected %d changes. Actual: %d", numTrxns+2, len(chngs))
+		}
+		casTrxn := chngs[numTrxns].Trxns[0]
+		if casTrxn.Type != serverpb.TrxnRecord_Put || string(casTrxn.Key) != "clCasKey" || string(casTrxn.Value) != "casVal" {
+			t.Errorf("Expected CAS to be recorded as a put. Actual: %v", casTrxn)
+		}
+		if lastTrxn := chngs[numTrxns+1].Trxns; lastTrxn[len(lastTrxn)-1].Type != serverpb.TrxnRecord_Delete {
+			t.Errorf("Expected the last change to be a delete. Actual: %v", lastTrxn)
 		}
 	}
+	assertChanges(rdb)
 
-	if err := it.Err(); err != nil {
+	// Changes are retained across restarts
+	rdb.Close()
+	rdb = openChangeLogDB()
+	defer rdb.Close()
+	assertChanges(rdb)
+}
+
+func TestWALLessPrefixes(t *testing.T) {
+	dbFolder := fmt.Sprintf("%s_walless_%d", dbFolder, time.Now().UnixNano())
+	changeLogDir := dbFolder + "_log"
+	defer os.RemoveAll(dbFolder)
+	defer os.RemoveAll(changeLogDir)
+	if _, err := OpenDB(dbFolder, WithWALLessPrefixes([]string{"cache:"})); err != errWALLessWithoutChangeLog {
+		t.Errorf("Expected WAL-less prefixes to require the change log. Error: %v", err)
+	}
+	kvs, err := OpenDB(dbFolder, WithChangeLog(changeLogDir, 1024, 0), WithWALLessPrefixes([]string{"cache:"}))
+	if err != nil {
 		t.Fatal(err)
 	}
+	defer kvs.Close()
+	rdb := kvs.(*rocksDB)
 
-	if actCnt != numTrxns {
-		t.Errorf("Expected snapshot iterator to give %d keys, but only got %d keys", numTrxns, actCnt)
+	fromChngNum := rdb.db.GetLatestSequenceNumber() + 1
+	expectNoError(t, rdb.Put(kvEntry("wlKey", "val")))
+	latestChngNum, _ := rdb.GetLatestCommittedChangeNumber()
+	if durability, err := rdb.PutWithWALSync(serverpb.WALSync_SYNC_WAL, kvEntry("cache:1", "val"), kvEntry("cache:2", "val")); err != nil {
+		t.Fatal(err)
+	} else if durability != serverpb.Durability_UNKNOWN_DURABILITY {
+		t.Errorf("Expected unknown durability for WAL-less writes. Actual: %s", durability)
+	}
+	if vals, err := rdb.Get([]byte("cache:1")); err != nil || string(vals[0].Value) != "val" {
+		t.Errorf("Expected the WAL-less key to be readable. Values: %v, Error: %v", vals, err)
+	}
+	if err = rdb.Put(kvEntry("cache:3", "val"), kvEntry("wlKey2", "val")); err != errMixedWALLessWrite {
+		t.Errorf("Expected mixed writes to be rejected. Error: %v", err)
+	}
+	if _, err = rdb.CompareAndSet([]byte("cache:1"), []byte("val"), []byte("newVal")); err != errWALLessKey {
+		t.Errorf("Expected CAS on a WAL-less key to be rejected. Error: %v", err)
+	}
+
+	// WAL-less writes are propagated as filler changes retaining their
+	// change numbers
+	if chngNum, _ := rdb.GetLatestCommittedChangeNumber(); chngNum != rdb.db.GetLatestSequenceNumber() || chngNum <= latestChngNum {
+		t.Errorf("Expected latest change number: %d to account for WAL-less writes beyond %d", chngNum, latestChngNum)
+	}
+	expectNoError(t, rdb.Delete([]byte("wlKey")))
+	chngs, err := rdb.LoadChanges(fromChngNum, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chngs) != 3 {
+		t.Fatalf("Expected 3 changes to be propagated. Actual: %d", len(chngs))
+	}
+	for i, chng := range chngs {
+		if i > 0 && chng.ChangeNumber != chngs[i-1].ChangeNumber+uint64(chngs[i-1].NumberOfTrxns) {
+			t.Errorf("Expected change number %d to follow change number %d of %d trxns", chng.ChangeNumber, chngs[i-1].ChangeNumber, chngs[i-1].NumberOfTrxns)
+		}
+		for _, trxn := range chng.Trxns {
+			if strings.HasPrefix(string(trxn.Key), "cache:") {
+				t.Errorf("Did not expect WAL-less key: %s to be propagated", trxn.Key)
+			}
+		}
+	}
+	for _, trxn := range chngs[1].Trxns {
+		if !bytes.Equal(trxn.Key, storage.FilteredChangeKey()) {
+			t.Errorf("Expected the WAL-less write to be propagated as a filler, but got key: %s", trxn.Key)
+		}
 	}
 }
 
@@ -1042,6 +3265,66 @@ func BenchmarkPutNewKeys(b *testing.B) {
 	}
 }
 
+// BenchmarkReplicationCatchUp measures the rate at which a slave catches up
+// with the changes of its master, by loading the changes from the master
+// and saving them onto the slave in batches, just as the replication does
+// sans the network. Every operation is a change putting a single value.
+func BenchmarkReplicationCatchUp(b *testing.B) {
+	for _, valueSize := range []int{64, 1024, 16 * 1024} {
+		for _, batchSize := range []int{10, 100, 1000} {
+			b.Run(fmt.Sprintf("ValueSize=%d/BatchSize=%d", valueSize, batchSize), func(b *testing.B) {
+				benchmarkReplicationCatchUp(b, valueSize, batchSize)
+			})
+		}
+	}
+}
+
+func benchmarkReplicationCatchUp(b *testing.B, valueSize, batchSize int) {
+	var stores []*rocksDB
+	for _, role := range []string{"master", "slave"} {
+		folder := fmt.Sprintf("%s_catchup_%s_%d", dbFolder, role, time.Now().UnixNano())
+		defer os.RemoveAll(folder)
+		kvs, err := OpenDB(folder, WithCacheSize(cacheSize))
+		if err != nil {
+			b.Fatal(err)
+		}
+		defer kvs.Close()
+		stores = append(stores, kvs.(*rocksDB))
+	}
+	master, slave := stores[0], stores[1]
+
+	// Values are random, yet the same across runs, so that compression
+	// neither flatters nor varies the results
+	rnd := rand.New(rand.NewSource(int64(valueSize)))
+	value := make([]byte, valueSize)
+	for i := 0; i < b.N; i++ {
+		rnd.Read(value)
+		if err := master.Put(kvEntry(fmt.Sprintf("CatchUpKey%d", i), string(value))); err != nil {
+			b.Fatal(err)
+		}
+	}
+	masterChngNum, _ := master.GetLatestCommittedChangeNumber()
+
+	b.SetBytes(int64(valueSize))
+	b.ResetTimer()
+	start := time.Now()
+	for chngNum := uint64(0); chngNum < masterChngNum; {
+		chngs, err := master.LoadChanges(chngNum+1, batchSize)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(chngs) == 0 {
+			b.Fatalf("No changes loaded from change number %d upto %d", chngNum+1, masterChngNum)
+		}
+		if chngNum, err = slave.SaveChanges(chngs); err != nil {
+			b.Fatal(err)
+		}
+	}
+	elapsed := time.Since(start)
+	b.StopTimer()
+	b.ReportMetric(float64(b.N)/elapsed.Seconds(), "changes/s")
+}
+
 type IncOp struct{}
 
 func (io *IncOp) FullMerge(key, existingValue []byte, operands [][]byte) ([]byte, bool) {
@@ -0,0 +1,330 @@
+package rocksdb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"github.com/flipkart-incubator/gorocksdb"
+	"go.uber.org/zap"
+)
+
+var (
+	errTenantsWithoutEncryption = errors.New("tenant encryption requires encryption at rest to be enabled")
+	errTenantErased             = errors.New("data keys of the tenant owning the given key are destroyed")
+	errMergeOnEncryptedTenant   = errors.New("merge is not supported on keys of encrypted tenants")
+)
+
+// Number of the latest data keys of a tenant retained after its rotation.
+// The previous key is retained for the values written concurrently with
+// the rotation, which are re-encrypted by the next rotation.
+const numRetainedTenantKeys = 2
+
+// WithTenantEncryption encrypts the values of the keys having any of the
+// given prefixes using a data key per prefix, in place of the master key.
+// Data keys are themselves stored encrypted using the master key, hence
+// the encryption at rest must also be enabled. Values of a tenant stored
+// before enabling its encryption remain readable using the master key and
+// are encrypted using its data key by the next rotation of that key.
+func WithTenantEncryption(prefixes []string) DBOption {
+	return func(opts *rocksDBOpts) {
+		opts.tenants = storage.NewTenants(prefixes)
+	}
+}
+
+// tenantKeyCache holds the decrypted data keys of the tenants, sparing
+// every read and write of a tenant from decrypting its data keys using
+// the master key. The cached keys are shared, hence must not be changed.
+type tenantKeyCache struct {
+	mu   sync.RWMutex
+	keys map[string]*storage.TenantKeys
+	// Incremented upon every invalidation, so that the keys loaded
+	// concurrently with an invalidation are not cached.
+	generation uint64
+}
+
+func (tkc *tenantKeyCache) get(tenant []byte) (*storage.TenantKeys, uint64) {
+	tkc.mu.RLock()
+	defer tkc.mu.RUnlock()
+	return tkc.keys[string(tenant)], tkc.generation
+}
+
+// put caches the given keys of the given tenant, unless the cache is
+// invalidated since the given generation.
+func (tkc *tenantKeyCache) put(tenant []byte, keys *storage.TenantKeys, generation uint64) {
+	tkc.mu.Lock()
+	defer tkc.mu.Unlock()
+	if tkc.generation != generation {
+		return
+	}
+	if tkc.keys == nil {
+		tkc.keys = make(map[string]*storage.TenantKeys)
+	}
+	tkc.keys[string(tenant)] = keys
+}
+
+func (tkc *tenantKeyCache) invalidate() {
+	tkc.mu.Lock()
+	defer tkc.mu.Unlock()
+	tkc.keys = nil
+	tkc.generation++
+}
+
+// invalidateTenantKeys invalidates the cached data keys after applying
+// the given change replicated from the master, if the change may alter
+// the data keys of any tenant.
+func (rdb *rocksDB) invalidateTenantKeys(chng *serverpb.ChangeRecord) {
+	if bytes.Contains(chng.SerialisedForm, storage.TenantKeyRecordPrefix()) {
+		rdb.tenantKeys.invalidate()
+	}
+}
+
+// loadTenantKeys retrieves the data keys of the given tenant, which are
+// cached once decrypted. Returns nil if the tenant has no keys yet.
+func (rdb *rocksDB) loadTenantKeys(tenant []byte) (*storage.TenantKeys, error) {
+	keys, generation := rdb.tenantKeys.get(tenant)
+	if keys != nil {
+		return keys, nil
+	}
+	keys, err := rdb.readTenantKeys(tenant)
+	if err != nil || keys == nil {
+		return nil, err
+	}
+	rdb.tenantKeys.put(tenant, keys, generation)
+	return keys, nil
+}
+
+// readTenantKeys reads the data keys of the given tenant bypassing the
+// cache. Like the subject keys, the live keyspace is always read so that
+// erased tenants are not readable even from published snapshots. Returns
+// nil if the tenant has no keys yet.
+func (rdb *rocksDB) readTenantKeys(tenant []byte) (*storage.TenantKeys, error) {
+	val, err := rdb.db.GetCF(rdb.opts.readOpts, rdb.normalCF, storage.TenantKeyRecord(tenant))
+	if err != nil {
+		return nil, err
+	}
+	defer val.Free()
+	if val.Size() == 0 {
+		return nil, nil
+	}
+	data, err := rdb.opts.keyring.Open(toByteArray(val))
+	if err != nil {
+		return nil, err
+	}
+	return storage.ParseTenantKeys(data)
+}
+
+// storeTenantKeys stores the given data keys of the given tenant after
+// encrypting them using the active master key. Since they are written
+// like any other key, the data keys are propagated to the replicas
+// through the regular change propagation.
+func (rdb *rocksDB) storeTenantKeys(tenant []byte, keys *storage.TenantKeys) error {
+	data, err := keys.Marshal()
+	if err != nil {
+		return err
+	}
+	sealed, err := rdb.opts.keyring.Seal(data)
+	if err != nil {
+		return err
+	}
+	wb := gorocksdb.NewWriteBatch()
+	defer wb.Destroy()
+	wb.PutCF(rdb.normalCF, storage.TenantKeyRecord(tenant), sealed)
+	err = rdb.write(rdb.opts.syncWriteOpts, wb)
+	rdb.tenantKeys.invalidate()
+	return err
+}
+
+// tenantKeysForWrite retrieves the data keys of the given tenant,
+// creating the first one if the tenant has no keys yet.
+func (rdb *rocksDB) tenantKeysForWrite(tenant []byte) (*storage.TenantKeys, error) {
+	if keys, err := rdb.loadTenantKeys(tenant); err != nil || keys != nil {
+		return keys, err
+	}
+
+	rdb.tenantKeyMu.Lock()
+	defer rdb.tenantKeyMu.Unlock()
+	if keys, err := rdb.loadTenantKeys(tenant); err != nil || keys != nil {
+		return keys, err
+	}
+	keys := &storage.TenantKeys{}
+	if err := keys.AddKey(); err != nil {
+		return nil, err
+	}
+	if err := rdb.storeTenantKeys(tenant, keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// sealTenantValue encrypts the given value using the active data key of
+// the given tenant. Returns an error if the tenant is erased.
+func (rdb *rocksDB) sealTenantValue(tenant, value []byte) ([]byte, error) {
+	keys, err := rdb.tenantKeysForWrite(tenant)
+	switch {
+	case err != nil:
+		return nil, err
+	case keys.IsErased():
+		return nil, errTenantErased
+	}
+	return keys.Seal(value)
+}
+
+// openTenantValue decrypts the given value of the given tenant. Values
+// stored before enabling the encryption of the tenant are decrypted
+// using the master keys, unless the tenant is erased.
+func (rdb *rocksDB) openTenantValue(tenant, value []byte) ([]byte, error) {
+	if len(value) == 0 {
+		return value, nil
+	}
+	keys, err := rdb.loadTenantKeys(tenant)
+	switch {
+	case err != nil:
+		return nil, err
+	case keys.IsErased():
+		return nil, errTenantErased
+	case !storage.IsTenantSealed(value):
+		return rdb.opts.keyring.Open(value)
+	}
+	return keys.Open(value)
+}
+
+// isTenantErased checks if the given key belongs to a tenant whose data
+// keys are destroyed.
+func (rdb *rocksDB) isTenantErased(key []byte) bool {
+	if rdb.opts.keyring == nil {
+		return false
+	}
+	tenant, present := rdb.opts.tenants.Of(key)
+	if !present {
+		return false
+	}
+	keys, err := rdb.loadTenantKeys(tenant)
+	return err != nil || keys.IsErased()
+}
+
+// RotateTenantKey generates a new data key for the given tenant and
+// re-encrypts all the values of that tenant using it, in the same way
+// as the rotation of the master key. Only the previous data key is
+// retained afterwards, hence versions of keys retained for reads as of
+// a change number that are encrypted using older data keys become
+// unreadable. Backups taken before the rotation hold the data keys of
+// their time and remain readable.
+func (rdb *rocksDB) RotateTenantKey(tenant []byte) (*storage.KeyRotation, error) {
+	if rdb.opts.keyring == nil {
+		return nil, errEncryptionNotEnabled
+	}
+	if !rdb.opts.tenants.IsTenant(tenant) {
+		return nil, fmt.Errorf("given tenant: %s is not configured for encryption", tenant)
+	}
+	// Prevent any backups or restores while the values are re-encrypted
+	if err := rdb.beginGlobalMutation(); err != nil {
+		return nil, err
+	}
+	defer rdb.endGlobalMutation()
+
+	keys, err := rdb.rollTenantKeys(tenant, func(keys *storage.TenantKeys) error { return keys.AddKey() })
+	if err != nil {
+		rdb.opts.statsCli.Incr("rocksdb.rotate.tenant.key.errors", 1)
+		return nil, err
+	}
+	rotation := &storage.KeyRotation{ActiveKeyID: keys.ActiveKeyID()}
+	rdb.opts.lgr.Info("Beginning rotation of tenant key",
+		zap.String("Tenant", string(tenant)), zap.String("ActiveKeyID", rotation.ActiveKeyID))
+	for scan := 0; scan < maxRotationScans; scan++ {
+		numReencrypted := rotation.NumReencrypted
+		for _, cf := range []*gorocksdb.ColumnFamilyHandle{rdb.normalCF, rdb.ttlCF} {
+			if err = rdb.rotateCF(cf, tenant, rotation, scan == 0); err != nil {
+				rdb.opts.statsCli.Incr("rocksdb.rotate.tenant.key.errors", 1)
+				return nil, err
+			}
+		}
+		if scan > 0 && rotation.NumReencrypted == numReencrypted {
+			break
+		}
+	}
+	if _, err = rdb.rollTenantKeys(tenant, func(keys *storage.TenantKeys) error {
+		keys.RetainKeys(numRetainedTenantKeys)
+		return nil
+	}); err != nil {
+		rdb.opts.statsCli.Incr("rocksdb.rotate.tenant.key.errors", 1)
+		return nil, err
+	}
+	// Compaction discards the values superseded by the re-encrypted ones
+	// along with the older versions of the data keys
+	if err = rdb.CompactRange(tenant); err != nil {
+		return nil, err
+	}
+	if err = rdb.CompactRange(storage.TenantKeyRecord(tenant)); err != nil {
+		return nil, err
+	}
+	rdb.opts.lgr.Info("Completed rotation of tenant key", zap.String("Tenant", string(tenant)),
+		zap.String("ActiveKeyID", rotation.ActiveKeyID), zap.Uint64("NumScanned", rotation.NumScanned),
+		zap.Uint64("NumReencrypted", rotation.NumReencrypted))
+	return rotation, nil
+}
+
+// rollTenantKeys applies the given change to the data keys of the given
+// tenant, while holding off the creation of its keys by the writes. The
+// keys are read afresh, as the cached ones must not be changed.
+func (rdb *rocksDB) rollTenantKeys(tenant []byte, change func(*storage.TenantKeys) error) (*storage.TenantKeys, error) {
+	rdb.tenantKeyMu.Lock()
+	defer rdb.tenantKeyMu.Unlock()
+	keys, err := rdb.readTenantKeys(tenant)
+	switch {
+	case err != nil:
+		return nil, err
+	case keys.IsErased():
+		return nil, errTenantErased
+	case keys == nil:
+		keys = &storage.TenantKeys{}
+	}
+	if err = change(keys); err != nil {
+		return nil, err
+	}
+	if err = rdb.storeTenantKeys(tenant, keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// DeleteTenant destroys the data keys of the given tenant, retaining
+// only their generation so that its values remain unreadable and its
+// subsequent writes are rejected. Deleting an erased tenant is a no-op.
+// Values of the tenant stored before enabling its encryption that were
+// never re-encrypted by a rotation remain encrypted using the master
+// key, hence the tenant key must be rotated before the deletion for its
+// erasure to extend to such values. Note that backups taken before the
+// deletion continue to hold the data keys.
+func (rdb *rocksDB) DeleteTenant(tenant []byte) error {
+	if rdb.opts.keyring == nil {
+		return errEncryptionNotEnabled
+	}
+	if !rdb.opts.tenants.IsTenant(tenant) {
+		return fmt.Errorf("given tenant: %s is not configured for encryption", tenant)
+	}
+	_, err := rdb.rollTenantKeys(tenant, func(keys *storage.TenantKeys) error {
+		// Tenants without any keys are erased as of the first generation
+		if keys.Generation == 0 {
+			keys.Generation = 1
+		}
+		keys.Erase()
+		return nil
+	})
+	switch {
+	case err == errTenantErased:
+		return nil
+	case err != nil:
+		rdb.opts.statsCli.Incr("rocksdb.delete.tenant.errors", 1)
+		return err
+	}
+	// Compact away any older versions of the data keys
+	if err = rdb.CompactRange(storage.TenantKeyRecord(tenant)); err != nil {
+		return err
+	}
+	rdb.opts.lgr.Info("Deleted data keys of tenant", zap.String("Tenant", string(tenant)))
+	return nil
+}
@@ -0,0 +1,191 @@
+package rocksdb
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/flipkart-incubator/gorocksdb"
+)
+
+// TuningOptions captures the commonly tuned RocksDB settings. Zero
+// values leave the corresponding setting to the engine defaults or
+// to the values loaded from the RocksDB .ini file, if any.
+type TuningOptions struct {
+	// WriteBufferSize is the size (in bytes) of a single memtable.
+	WriteBufferSize uint64
+	// MaxBackgroundJobs is the maximum number of concurrent background
+	// flushes and compactions.
+	MaxBackgroundJobs int
+	// MaxOpenFiles is the number of files that can be kept open by
+	// RocksDB. A value of -1 keeps all the files open.
+	MaxOpenFiles int
+	// NumLevels is the number of levels of the LSM tree.
+	NumLevels int
+	// TargetFileSizeBase is the target size (in bytes) of the SST
+	// files in level 1.
+	TargetFileSizeBase uint64
+	// MaxBytesForLevelBase is the maximum total size (in bytes) of
+	// the SST files in level 1.
+	MaxBytesForLevelBase uint64
+	// MaxBytesForLevelMultiplier is the factor by which the maximum
+	// total size of every subsequent level grows.
+	MaxBytesForLevelMultiplier float64
+	// BloomFilterBitsPerKey enables bloom filters on SST files using
+	// the given number of bits per key.
+	BloomFilterBitsPerKey int
+	// CompressionPerLevel is the compression used for every level
+	// starting with level 0. Must be one of none, snappy, zlib, bzip2,
+	// lz4, lz4hc, xpress or zstd.
+	CompressionPerLevel []string
+	// BottommostCompression is the compression used for the bottommost
+	// level, overriding CompressionPerLevel for that level. Takes the
+	// same values as CompressionPerLevel.
+	BottommostCompression string
+	// ZSTDMaxDictBytes enables dictionary compression on the levels using
+	// zstd with dictionaries of up to the given size (in bytes).
+	ZSTDMaxDictBytes uint32
+	// ZSTDMaxTrainBytes is the size (in bytes) of the samples used for
+	// training the zstd dictionaries. When zero, the dictionaries are
+	// built from raw samples without training.
+	ZSTDMaxTrainBytes uint32
+}
+
+const (
+	minWriteBufferSize       = 64 << 10
+	minMaxOpenFiles          = 20
+	maxBloomFilterBitsPerKey = 64
+
+	// RocksDB defaults of the compression options that are not tuned
+	defaultCompressionWindowBits = -14
+	defaultCompressionLevel      = 32767
+	defaultCompressionStrategy   = 0
+)
+
+var compressionTypes = map[string]string{
+	"none":   "kNoCompression",
+	"snappy": "kSnappyCompression",
+	"zlib":   "kZlibCompression",
+	"bzip2":  "kBZip2Compression",
+	"lz4":    "kLZ4Compression",
+	"lz4hc":  "kLZ4HCCompression",
+	"xpress": "kXpressCompression",
+	"zstd":   "kZSTD",
+}
+
+// Validate checks the given tuning options for values that RocksDB
+// either rejects or silently sanitizes.
+func (to *TuningOptions) Validate() error {
+	switch {
+	case to.WriteBufferSize > 0 && to.WriteBufferSize < minWriteBufferSize:
+		return fmt.Errorf("write buffer size: %d must be at least %d bytes", to.WriteBufferSize, minWriteBufferSize)
+	case to.MaxBackgroundJobs < 0:
+		return fmt.Errorf("max background jobs: %d must not be negative", to.MaxBackgroundJobs)
+	case to.MaxOpenFiles != 0 && to.MaxOpenFiles != -1 && to.MaxOpenFiles < minMaxOpenFiles:
+		return fmt.Errorf("max open files: %d must either be -1 or at least %d", to.MaxOpenFiles, minMaxOpenFiles)
+	case to.NumLevels < 0 || to.NumLevels == 1:
+		return fmt.Errorf("number of levels: %d must be at least 2", to.NumLevels)
+	case to.MaxBytesForLevelBase > 0 && to.TargetFileSizeBase > to.MaxBytesForLevelBase:
+		return fmt.Errorf("target file size base: %d must not exceed max bytes for level base: %d", to.TargetFileSizeBase, to.MaxBytesForLevelBase)
+	case to.MaxBytesForLevelMultiplier != 0 && to.MaxBytesForLevelMultiplier < 1:
+		return fmt.Errorf("max bytes for level multiplier: %v must be at least 1", to.MaxBytesForLevelMultiplier)
+	case to.BloomFilterBitsPerKey < 0 || to.BloomFilterBitsPerKey > maxBloomFilterBitsPerKey:
+		return fmt.Errorf("bloom filter bits per key: %d must be between 0 and %d", to.BloomFilterBitsPerKey, maxBloomFilterBitsPerKey)
+	case to.NumLevels > 0 && len(to.CompressionPerLevel) > to.NumLevels:
+		return fmt.Errorf("compression is given for %d levels, but only %d levels are configured", len(to.CompressionPerLevel), to.NumLevels)
+	}
+	usesZSTD := false
+	for _, comp := range to.CompressionPerLevel {
+		if _, present := compressionTypes[normalizeCompression(comp)]; !present {
+			return fmt.Errorf("unknown compression: %s", comp)
+		}
+		usesZSTD = usesZSTD || normalizeCompression(comp) == "zstd"
+	}
+	if to.BottommostCompression != "" {
+		if _, present := compressionTypes[normalizeCompression(to.BottommostCompression)]; !present {
+			return fmt.Errorf("unknown bottommost compression: %s", to.BottommostCompression)
+		}
+		usesZSTD = usesZSTD || normalizeCompression(to.BottommostCompression) == "zstd"
+	}
+	switch {
+	case to.ZSTDMaxTrainBytes > 0 && to.ZSTDMaxDictBytes == 0:
+		return fmt.Errorf("zstd max train bytes: %d requires zstd max dict bytes to be set", to.ZSTDMaxTrainBytes)
+	case to.ZSTDMaxTrainBytes > 0 && to.ZSTDMaxTrainBytes < to.ZSTDMaxDictBytes:
+		return fmt.Errorf("zstd max train bytes: %d must be at least zstd max dict bytes: %d", to.ZSTDMaxTrainBytes, to.ZSTDMaxDictBytes)
+	case to.ZSTDMaxDictBytes > 0 && !usesZSTD:
+		return fmt.Errorf("zstd dictionary compression requires zstd on at least one level")
+	}
+	return nil
+}
+
+func normalizeCompression(comp string) string {
+	return strings.ToLower(strings.TrimSpace(comp))
+}
+
+// optionsString renders the tuning options in the format accepted by
+// the RocksDB options string parser.
+func (to *TuningOptions) optionsString() string {
+	var buff strings.Builder
+	if to.WriteBufferSize > 0 {
+		fmt.Fprintf(&buff, "write_buffer_size=%d;", to.WriteBufferSize)
+	}
+	if to.MaxBackgroundJobs > 0 {
+		fmt.Fprintf(&buff, "max_background_jobs=%d;", to.MaxBackgroundJobs)
+	}
+	if to.MaxOpenFiles != 0 {
+		fmt.Fprintf(&buff, "max_open_files=%d;", to.MaxOpenFiles)
+	}
+	if to.NumLevels > 0 {
+		fmt.Fprintf(&buff, "num_levels=%d;", to.NumLevels)
+	}
+	if to.TargetFileSizeBase > 0 {
+		fmt.Fprintf(&buff, "target_file_size_base=%d;", to.TargetFileSizeBase)
+	}
+	if to.MaxBytesForLevelBase > 0 {
+		fmt.Fprintf(&buff, "max_bytes_for_level_base=%d;", to.MaxBytesForLevelBase)
+	}
+	if to.MaxBytesForLevelMultiplier > 0 {
+		fmt.Fprintf(&buff, "max_bytes_for_level_multiplier=%v;", to.MaxBytesForLevelMultiplier)
+	}
+	if len(to.CompressionPerLevel) > 0 {
+		comps := make([]string, len(to.CompressionPerLevel))
+		for i, comp := range to.CompressionPerLevel {
+			comps[i] = compressionTypes[normalizeCompression(comp)]
+		}
+		fmt.Fprintf(&buff, "compression_per_level=%s;", strings.Join(comps, ":"))
+	}
+	if to.BottommostCompression != "" {
+		fmt.Fprintf(&buff, "bottommost_compression=%s;", compressionTypes[normalizeCompression(to.BottommostCompression)])
+	}
+	// The bottommost level shares these options, since its own options
+	// are left disabled
+	if to.ZSTDMaxDictBytes > 0 {
+		fmt.Fprintf(&buff, "compression_opts=%d:%d:%d:%d:%d;", defaultCompressionWindowBits,
+			defaultCompressionLevel, defaultCompressionStrategy, to.ZSTDMaxDictBytes, to.ZSTDMaxTrainBytes)
+	}
+	return buff.String()
+}
+
+// WithTuningOptions applies the given tuning options on top of the
+// RocksDB settings loaded so far, including those from the .ini file.
+// Hence it must be given after WithRocksDBConfig, if both are used.
+func WithTuningOptions(to *TuningOptions) DBOption {
+	return func(opts *rocksDBOpts) {
+		if to == nil {
+			return
+		}
+		if err := to.Validate(); err != nil {
+			panic(fmt.Errorf("invalid RocksDB tuning options, error: %v", err))
+		}
+		if optsStr := to.optionsString(); optsStr != "" {
+			if rdbOpts, err := gorocksdb.GetOptionsFromString(opts.rocksDBOpts, optsStr); err != nil {
+				panic(fmt.Errorf("unable to apply RocksDB tuning options: %s, error: %v", optsStr, err))
+			} else {
+				opts.rocksDBOpts = rdbOpts
+			}
+		}
+		if to.BloomFilterBitsPerKey > 0 {
+			opts.blockTableOpts.SetFilterPolicy(gorocksdb.NewBloomFilter(to.BloomFilterBitsPerKey))
+			opts.rocksDBOpts.SetBlockBasedTableFactory(opts.blockTableOpts)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package rocksdb
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"github.com/flipkart-incubator/gorocksdb"
+)
+
+var (
+	errTxnOnEncryptedSubject = errors.New("transactions are not supported on keys of encrypted subjects")
+	errTxnOnExpiringKey      = errors.New("transactions are not supported on keys with expiry")
+)
+
+// isTxnConflict checks if the given error is due to a conflicting
+// change detected while committing an optimistic transaction.
+func isTxnConflict(err error) bool {
+	return err != nil && strings.HasSuffix(err.Error(), "Resource busy: ")
+}
+
+// Transact commits the given writes as an optimistic transaction, which
+// conflicts if any of the read keys is changed by a concurrent commit.
+// Like CompareAndSet, only the keys without expiry are transacted upon.
+func (rdb *rocksDB) Transact(reads []*serverpb.KVPair, writes []*serverpb.TxnWrite) error {
+	defer rdb.opts.statsCli.Timing("rocksdb.transact.latency.ms", time.Now())
+	if rdb.opts.originID != "" {
+		return errUnstampedWrite
+	}
+	ro := rdb.opts.readOpts
+	for _, read := range reads {
+		if err := rdb.checkTxnKey(ro, read.Key); err != nil {
+			return err
+		}
+	}
+	for _, write := range writes {
+		if err := rdb.checkTxnKey(ro, write.Key); err != nil {
+			return err
+		}
+		if write.Delete && rdb.UnderLegalHold(write.Key) {
+			rdb.opts.statsCli.Incr("rocksdb.transact.legal.hold.rejects", 1)
+			return storage.ErrUnderLegalHold
+		}
+	}
+
+	wo := rdb.writeOptions(serverpb.WALSync_DEFAULT_WAL_SYNC)
+	to := gorocksdb.NewDefaultOptimisticTransactionOptions()
+	txn := rdb.optimTrxnDB.TransactionBegin(wo, to, nil)
+	defer txn.Destroy()
+
+	// Every read key is tracked for conflicts from here on, hence
+	// it is enough to validate the observed value once
+	for _, read := range reads {
+		val, err := txn.GetForUpdate(ro, read.Key)
+		if err != nil {
+			return err
+		}
+		readVal, _, err := rdb.openValue(read.Key, val.Data())
+		val.Free()
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(readVal, read.Value) {
+			rdb.opts.statsCli.Incr("rocksdb.transact.conflicts", 1)
+			return storage.ErrTxnConflict
+		}
+	}
+
+	wb := gorocksdb.NewWriteBatch()
+	defer wb.Destroy()
+	for _, write := range writes {
+		var err error
+		if write.Delete {
+			err = txn.Delete(write.Key)
+			wb.Delete(write.Key)
+		} else {
+			var value []byte
+			if value, err = rdb.sealValue(write.Key, write.Value); err == nil {
+				err = txn.Put(write.Key, value)
+				wb.Put(write.Key, value)
+			}
+		}
+		if err != nil {
+			rdb.opts.statsCli.Incr("rocksdb.transact.errors", 1)
+			return err
+		}
+	}
+	if err := rdb.commitTxn(wo, txn, wb); isTxnConflict(err) {
+		rdb.opts.statsCli.Incr("rocksdb.transact.conflicts", 1)
+		return storage.ErrTxnConflict
+	} else if err != nil {
+		rdb.opts.statsCli.Incr("rocksdb.transact.errors", 1)
+		return err
+	}
+	return nil
+}
+
+// checkTxnKey checks if the given key can be transacted upon. Values of
+// the keys with expiry are held separately, hence they cannot be tracked
+// for conflicts by the transactions.
+func (rdb *rocksDB) checkTxnKey(ro *gorocksdb.ReadOptions, key []byte) error {
+	if storage.IsReservedKey(key) {
+		return errReservedKey
+	}
+	if _, present := rdb.opts.subjects.Of(key); present {
+		return errTxnOnEncryptedSubject
+	}
+	if rdb.isWALLess(key) {
+		return errWALLessKey
+	}
+	ttlVal, err := rdb.db.GetCF(ro, rdb.ttlCF, key)
+	if err != nil {
+		return err
+	}
+	defer ttlVal.Free()
+	if ttlVal.Size() > 0 {
+		return errTxnOnExpiringKey
+	}
+	return nil
+}
@@ -0,0 +1,282 @@
+package rocksdb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"github.com/flipkart-incubator/gorocksdb"
+)
+
+// Versions of keys are recorded as undo records in a dedicated column
+// family. Every change to a key records the value of the key prior to
+// that change, keyed by the key and the change number of the change.
+// Hence the value of a key as of a change number is the value recorded
+// by the earliest subsequent change to that key, or its current value
+// when no such change exists. Versions are written in the same batch
+// as the changes, so that they are replicated along with the changes.
+
+const (
+	versionsCFName = "versions"
+	// ID of the versions column family, as per the order of column families
+	versionsCFID = 2
+)
+
+// Types of the recorded versions, based on the column family of the value
+const (
+	absentVersion byte = iota
+	normalVersion
+	ttlVersion
+)
+
+var (
+	// versionsMarkerKey holds the change number since which versions
+	// are recorded. It can never clash with the version of a key, since
+	// no key is 4GB long.
+	versionsMarkerKey = []byte{0xFF, 0xFF, 0xFF, 0xFF}
+
+	errVersionedReadsDisabled = errors.New("reads as of a change number are not enabled on current DKV instance")
+	errVersionNotRetained     = errors.New("values as of the given change number are not retained")
+	errFutureChangeNumber     = errors.New("given change number is yet to be committed")
+)
+
+// WithVersionRetention retains the older values of keys for the given
+// number of latest changes, so that keys can be read as of any of these
+// change numbers. Zero disables reads as of a change number.
+func WithVersionRetention(numChanges uint64) DBOption {
+	return func(opts *rocksDBOpts) {
+		opts.versionRetention = numChanges
+	}
+}
+
+// versionsCompactionFilter removes the versions that are no longer
+// needed for reads as of the retained change numbers.
+type versionsCompactionFilter struct {
+	retention uint64
+	// Shall be manipulated using atomics, since compactions
+	// can begin even before the DB is opened.
+	db atomic.Value
+}
+
+// Name returns the CompactionFilter name
+func (vf *versionsCompactionFilter) Name() string {
+	return "dkv.versionsFilter"
+}
+
+// Filter removes the versions recorded by changes that are at least
+// as old as the oldest retained change number.
+func (vf *versionsCompactionFilter) Filter(level int, key, val []byte) (remove bool, newVal []byte) {
+	db, _ := vf.db.Load().(*gorocksdb.DB)
+	if db == nil || len(key) < 4+8 || bytes.Equal(key, versionsMarkerKey) {
+		return false, nil
+	}
+	chngNum := binary.BigEndian.Uint64(key[len(key)-8:])
+	return chngNum+vf.retention <= db.GetLatestSequenceNumber(), nil
+}
+
+func versionKeyPrefix(key []byte) []byte {
+	prefix := make([]byte, 4+len(key), 4+len(key)+8)
+	binary.BigEndian.PutUint32(prefix, uint32(len(key)))
+	copy(prefix[4:], key)
+	return prefix
+}
+
+func versionKey(key []byte, chngNum uint64) []byte {
+	var chngNumBts [8]byte
+	binary.BigEndian.PutUint64(chngNumBts[:], chngNum)
+	return append(versionKeyPrefix(key), chngNumBts[:]...)
+}
+
+// needsVersioning checks if commits must be serialized for recording
+// versions, which is also the case for discarding the versions marker.
+func (rdb *rocksDB) needsVersioning() bool {
+	return rdb.opts.versionRetention > 0 || atomic.LoadUint32(&rdb.versionsMarked) == 1
+}
+
+// recordVersions adds to the given write batch the versions of all
+// the keys changed by it, which is to be committed next. Must be
+// invoked while commits are serialized.
+func (rdb *rocksDB) recordVersions(wb *gorocksdb.WriteBatch) error {
+	chngNum := rdb.db.GetLatestSequenceNumber() + 1
+	if rdb.opts.versionRetention == 0 {
+		// History recorded so far is incomplete once
+		// versions are no longer recorded
+		wb.DeleteCF(rdb.versionsCF, versionsMarkerKey)
+		return nil
+	}
+	if err := rdb.putVersions(wb, chngNum, changedKeys(wb)); err != nil {
+		return err
+	}
+	rdb.putVersionsMarker(wb, chngNum)
+	return nil
+}
+
+// commitTxnVersions commits the versions of the keys changed by the
+// given write batch, which is equivalent to the transaction that is
+// committed right next, as recorded by the change number of the
+// transaction. Such versions are harmless even if the transaction fails
+// to commit, since the keys then remain unchanged. Must be invoked while
+// commits are serialized.
+func (rdb *rocksDB) commitTxnVersions(wo *gorocksdb.WriteOptions, txnWb *gorocksdb.WriteBatch) error {
+	wb := gorocksdb.NewWriteBatch()
+	defer wb.Destroy()
+	if rdb.opts.versionRetention == 0 {
+		wb.DeleteCF(rdb.versionsCF, versionsMarkerKey)
+	} else {
+		// Every record of this batch consumes a change number
+		keys := changedKeys(txnWb)
+		numRecords := uint64(len(keys))
+		if atomic.LoadUint32(&rdb.versionsMarked) == 0 {
+			numRecords++
+		}
+		chngNum := rdb.db.GetLatestSequenceNumber() + numRecords + 1
+		if err := rdb.putVersions(wb, chngNum, keys); err != nil {
+			return err
+		}
+		rdb.putVersionsMarker(wb, chngNum)
+	}
+	return rdb.commitLocked(wo, wb, false)
+}
+
+// putVersionsMarker adds the versions marker to the given write
+// batch, unless present, as versions are recorded since the given
+// change number.
+func (rdb *rocksDB) putVersionsMarker(wb *gorocksdb.WriteBatch, chngNum uint64) {
+	if atomic.LoadUint32(&rdb.versionsMarked) == 0 {
+		marker := make([]byte, 8)
+		binary.BigEndian.PutUint64(marker, chngNum)
+		wb.PutCF(rdb.versionsCF, versionsMarkerKey, marker)
+	}
+}
+
+// versionsCommitted tracks the presence of the versions marker once a
+// write batch carrying the versions, or the deletion of the marker,
+// is committed.
+func (rdb *rocksDB) versionsCommitted() {
+	var marked uint32
+	if rdb.opts.versionRetention > 0 {
+		marked = 1
+	}
+	atomic.StoreUint32(&rdb.versionsMarked, marked)
+}
+
+// changedKeys lists the distinct user keys changed by the given batch.
+func changedKeys(wb *gorocksdb.WriteBatch) [][]byte {
+	var keys [][]byte
+	seen := make(map[string]struct{})
+	wbIter := NewWriteBatchIterator(wb.Data())
+	for wbIter.Next() {
+		wbr := wbIter.Record()
+		if wbr.CF >= versionsCFID || storage.IsReservedKey(wbr.Key) {
+			continue
+		}
+		if _, present := seen[string(wbr.Key)]; !present {
+			seen[string(wbr.Key)] = struct{}{}
+			keys = append(keys, wbr.Key)
+		}
+	}
+	return keys
+}
+
+// putVersions adds to the given write batch the current values of the
+// given keys, as the versions recorded by the given change number.
+func (rdb *rocksDB) putVersions(wb *gorocksdb.WriteBatch, chngNum uint64, keys [][]byte) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	kl := len(keys)
+	reqCFs := make([]*gorocksdb.ColumnFamilyHandle, kl<<1)
+	for i := 0; i < kl; i++ {
+		reqCFs[i] = rdb.normalCF
+		reqCFs[i+kl] = rdb.ttlCF
+	}
+	values, err := rdb.db.MultiGetCFMultiCF(rdb.opts.readOpts, reqCFs, append(keys, keys...))
+	if err != nil {
+		return err
+	}
+	for i, key := range keys {
+		value1, value2 := values[i], values[i+kl]
+		version := []byte{absentVersion}
+		if value1.Size() > 0 {
+			version = append([]byte{normalVersion}, value1.Data()...)
+		} else if value2.Size() > 0 {
+			version = append([]byte{ttlVersion}, value2.Data()...)
+		}
+		value1.Free()
+		value2.Free()
+		wb.PutCF(rdb.versionsCF, versionKey(key, chngNum), version)
+	}
+	return nil
+}
+
+// GetAsOf bulk fetches the values of the given keys as of the given
+// change number, which must be within the configured version retention.
+func (rdb *rocksDB) GetAsOf(chngNum uint64, keys ...[]byte) ([]*serverpb.KVPair, error) {
+	defer rdb.opts.statsCli.Timing("rocksdb.get.as.of.latency.ms", time.Now())
+	if rdb.opts.versionRetention == 0 {
+		return nil, errVersionedReadsDisabled
+	}
+	latestChngNum := rdb.db.GetLatestSequenceNumber()
+	if chngNum > latestChngNum {
+		return nil, errFutureChangeNumber
+	}
+	// Every change beyond the latest change number is part of the
+	// snapshot along with its versions
+	snap := rdb.db.NewSnapshot()
+	defer rdb.db.ReleaseSnapshot(snap)
+	ro := gorocksdb.NewDefaultReadOptions()
+	defer ro.Destroy()
+	ro.SetSnapshot(snap)
+
+	marker, err := rdb.db.GetCF(ro, rdb.versionsCF, versionsMarkerKey)
+	if err != nil {
+		return nil, err
+	}
+	defer marker.Free()
+	if marker.Size() != 8 || chngNum+1 < binary.BigEndian.Uint64(marker.Data()) ||
+		chngNum+rdb.opts.versionRetention < latestChngNum {
+		return nil, errVersionNotRetained
+	}
+
+	it := rdb.db.NewIteratorCF(ro, rdb.versionsCF)
+	defer it.Close()
+	var results []*serverpb.KVPair
+	for _, key := range keys {
+		it.Seek(versionKey(key, chngNum+1))
+		if it.ValidForPrefix(versionKeyPrefix(key)) {
+			kv, err := rdb.fromVersion(key, toByteArray(it.Value()))
+			if err != nil {
+				return nil, err
+			}
+			if kv != nil {
+				results = append(results, kv)
+			}
+			continue
+		}
+		if err = it.Err(); err != nil {
+			return nil, err
+		}
+		// Key is unchanged since the given change number
+		kvs, err := rdb.getSingleKey(ro, key)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, kvs...)
+	}
+	return results, nil
+}
+
+func (rdb *rocksDB) fromVersion(key, version []byte) (*serverpb.KVPair, error) {
+	switch version[0] {
+	case normalVersion:
+		return rdb.toKVPair(key, version[1:], nil)
+	case ttlVersion:
+		return rdb.toKVPair(key, nil, version[1:])
+	default:
+		return nil, nil
+	}
+}
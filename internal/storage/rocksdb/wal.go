@@ -0,0 +1,195 @@
+package rocksdb
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"github.com/flipkart-incubator/gorocksdb"
+	"go.uber.org/zap"
+)
+
+// WALSyncPolicy determines when the RocksDB WAL is fsynced.
+type WALSyncPolicy string
+
+const (
+	// WALSyncPerWrite fsyncs the WAL before acknowledging every write.
+	WALSyncPerWrite WALSyncPolicy = "sync"
+	// WALSyncPeriodic fsyncs the WAL once every configured interval in
+	// the background, which makes all the preceding writes durable, so
+	// that no write waits for the fsync.
+	WALSyncPeriodic WALSyncPolicy = "periodic"
+	// WALSyncAsync never waits for the WAL to be fsynced, leaving it to
+	// the OS to flush its buffers.
+	WALSyncAsync WALSyncPolicy = "async"
+)
+
+// ParseWALSyncPolicy converts the given string into a WALSyncPolicy.
+func ParseWALSyncPolicy(policy string) (WALSyncPolicy, error) {
+	switch walSyncPolicy := WALSyncPolicy(strings.ToLower(strings.TrimSpace(policy))); walSyncPolicy {
+	case WALSyncPerWrite, WALSyncPeriodic, WALSyncAsync:
+		return walSyncPolicy, nil
+	default:
+		return "", fmt.Errorf("unknown WAL sync policy: %s, must be one of sync|periodic|async", policy)
+	}
+}
+
+// WithWALSyncPolicy configures when the WAL is fsynced. The given
+// interval is only used by the WALSyncPeriodic policy.
+func WithWALSyncPolicy(policy WALSyncPolicy, interval time.Duration) DBOption {
+	return func(opts *rocksDBOpts) {
+		if policy == WALSyncPeriodic && interval <= 0 {
+			panic(fmt.Errorf("WAL sync interval: %v must be positive for the periodic WAL sync policy", interval))
+		}
+		opts.walSyncPolicy = policy
+		opts.walSyncInterval = interval
+	}
+}
+
+// WithWALRetention retains the archived WAL files, from which the changes
+// are propagated to the replicas, for the given duration or until they
+// exceed the given size in MB, whichever limit is given. Zero for either
+// disables that limit, while zero for both discards the WAL files as soon
+// as their writes are flushed. Overrides the limits of the RocksDB ini.
+func WithWALRetention(ttl time.Duration, sizeLimitMB uint64) DBOption {
+	return func(opts *rocksDBOpts) {
+		if ttl < 0 {
+			panic(fmt.Errorf("WAL retention: %v must not be negative", ttl))
+		}
+		opts.rocksDBOpts.SetWALTtlSeconds(uint64(ttl.Seconds()))
+		opts.rocksDBOpts.SetWalSizeLimitMb(sizeLimitMB)
+	}
+}
+
+// writeOptions chooses the write options honouring the given WAL sync,
+// falling back to the configured WAL sync policy by default.
+func (rdb *rocksDB) writeOptions(walSync serverpb.WALSync) *gorocksdb.WriteOptions {
+	switch walSync {
+	case serverpb.WALSync_SYNC_WAL:
+		return rdb.opts.syncWriteOpts
+	case serverpb.WALSync_ASYNC_WAL:
+		return rdb.opts.writeOpts
+	}
+
+	if rdb.opts.walSyncPolicy == WALSyncPerWrite {
+		return rdb.opts.syncWriteOpts
+	}
+	return rdb.opts.writeOpts
+}
+
+type walSyncer struct {
+	rdb      *rocksDB
+	interval time.Duration
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// startWALSyncer starts fsyncing the WAL periodically under the periodic
+// WAL sync policy.
+func (rdb *rocksDB) startWALSyncer() {
+	if rdb.opts.walSyncPolicy != WALSyncPeriodic {
+		return
+	}
+	ws := &walSyncer{
+		rdb:      rdb,
+		interval: rdb.opts.walSyncInterval,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	rdb.walSyncer = ws
+	go ws.run()
+}
+
+// stopWALSyncer stops the WAL syncer, if any, and waits for it to
+// return, so that the store can be closed safely.
+func (rdb *rocksDB) stopWALSyncer() {
+	if ws := rdb.walSyncer; ws != nil {
+		rdb.walSyncer = nil
+		close(ws.stop)
+		<-ws.done
+	}
+}
+
+func (ws *walSyncer) run() {
+	defer close(ws.done)
+	ticker := time.NewTicker(ws.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := ws.rdb.SyncWAL(); err != nil {
+				ws.rdb.opts.lgr.Error("Unable to sync the WAL periodically", zap.Error(err))
+			}
+		case <-ws.stop:
+			return
+		}
+	}
+}
+
+func (rdb *rocksDB) PutWithWALSync(walSync serverpb.WALSync, pairs ...*serverpb.KVPair) (serverpb.Durability, error) {
+	wo := rdb.writeOptions(walSync)
+	if err := rdb.put(wo, pairs...); err != nil {
+		return serverpb.Durability_UNKNOWN_DURABILITY, err
+	}
+	// Writes of the WAL-less prefixes are held only in the memtable
+	if walLess, _ := rdb.isWALLessWrite(pairs); walLess {
+		return serverpb.Durability_UNKNOWN_DURABILITY, nil
+	}
+	if wo == rdb.opts.syncWriteOpts {
+		return serverpb.Durability_LOCAL_WAL_SYNCED, nil
+	}
+	return serverpb.Durability_LOCAL_WAL_WRITTEN, nil
+}
+
+// writeTracked writes the given write batch, advancing the synced change
+// number if the write fsyncs the WAL. Unless the commits are serialized,
+// the change number of the write itself is not known, in which case only
+// the changes committed before the write are known to be fsynced.
+func (rdb *rocksDB) writeTracked(wo *gorocksdb.WriteOptions, wb *gorocksdb.WriteBatch, serialized bool) error {
+	if wo != rdb.opts.syncWriteOpts {
+		return rdb.db.Write(wo, wb)
+	}
+	chngNum := rdb.db.GetLatestSequenceNumber()
+	if err := rdb.db.Write(wo, wb); err != nil {
+		return err
+	}
+	if serialized {
+		chngNum = rdb.db.GetLatestSequenceNumber()
+	}
+	rdb.advanceSyncedChangeNumber(chngNum)
+	return nil
+}
+
+func (rdb *rocksDB) advanceSyncedChangeNumber(chngNum uint64) {
+	for {
+		syncedChngNum := atomic.LoadUint64(&rdb.syncedChngNum)
+		if chngNum <= syncedChngNum || atomic.CompareAndSwapUint64(&rdb.syncedChngNum, syncedChngNum, chngNum) {
+			return
+		}
+	}
+}
+
+// GetSyncedChangeNumber retrieves the change number upto which the writes
+// are known to be fsynced, either by the writes that fsync the WAL or by
+// SyncWAL. Writes preceding the opening of the store are all considered
+// to be fsynced, since they are recovered from the WAL.
+func (rdb *rocksDB) GetSyncedChangeNumber() uint64 {
+	return atomic.LoadUint64(&rdb.syncedChngNum)
+}
+
+// SyncWAL fsyncs the WAL by committing an empty write batch that fsyncs
+// the WAL, which makes all the preceding writes durable along with it.
+func (rdb *rocksDB) SyncWAL() (uint64, error) {
+	defer rdb.opts.statsCli.Timing("rocksdb.sync.wal.latency.ms", time.Now())
+	chngNum := rdb.db.GetLatestSequenceNumber()
+	wb := gorocksdb.NewWriteBatch()
+	defer wb.Destroy()
+	if err := rdb.db.Write(rdb.opts.syncWriteOpts, wb); err != nil {
+		rdb.opts.statsCli.Incr("rocksdb.sync.wal.errors", 1)
+		return rdb.GetSyncedChangeNumber(), err
+	}
+	rdb.advanceSyncedChangeNumber(chngNum)
+	return rdb.GetSyncedChangeNumber(), nil
+}
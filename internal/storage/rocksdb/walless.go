@@ -0,0 +1,101 @@
+package rocksdb
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"github.com/flipkart-incubator/gorocksdb"
+)
+
+var (
+	errWALLessWithoutChangeLog = errors.New("WAL-less prefixes require the change log to be enabled")
+	errMixedWALLessWrite       = errors.New("keys of WAL-less prefixes must not be written along with other keys")
+	errWALLessKey              = errors.New("operation is not supported on keys of WAL-less prefixes")
+)
+
+// WithWALLessPrefixes writes the keys having any of the given prefixes
+// without the WAL, trading their durability for a much higher write
+// throughput. It is meant for the data that can be reconstructed, such
+// as caches, since the writes yet to be flushed are lost upon a crash.
+//
+// Such keys are local to every node and are excluded from the change
+// propagation, hence they are never replicated. Since their writes
+// consume change numbers missing from the WAL, the change log must be
+// enabled, into which a filler change is recorded in place of every
+// WAL-less write so that the replicas retain the change numbers of the
+// master. Writes must not mix such keys with other keys, and such keys
+// cannot be used with CompareAndSet, Merge and Transact.
+func WithWALLessPrefixes(prefixes []string) DBOption {
+	return func(opts *rocksDBOpts) {
+		if len(prefixes) == 0 {
+			return
+		}
+		opts.walLessPrefixes = nil
+		for _, prefix := range prefixes {
+			opts.walLessPrefixes = append(opts.walLessPrefixes, []byte(prefix))
+		}
+		if opts.walLessWriteOpts == nil {
+			opts.walLessWriteOpts = gorocksdb.NewDefaultWriteOptions()
+			opts.walLessWriteOpts.DisableWAL(true)
+		}
+	}
+}
+
+// isWALLess checks if the given key belongs to any WAL-less prefix.
+func (rdb *rocksDB) isWALLess(key []byte) bool {
+	for _, prefix := range rdb.opts.walLessPrefixes {
+		if bytes.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isWALLessWrite checks if all the given pairs belong to the WAL-less
+// prefixes. Returns an error if only some of them do.
+func (rdb *rocksDB) isWALLessWrite(pairs []*serverpb.KVPair) (bool, error) {
+	if len(rdb.opts.walLessPrefixes) == 0 {
+		return false, nil
+	}
+	numWALLess, numPairs := 0, 0
+	for _, kv := range pairs {
+		if kv == nil {
+			continue
+		}
+		numPairs++
+		if rdb.isWALLess(kv.Key) {
+			numWALLess++
+		}
+	}
+	switch numWALLess {
+	case 0:
+		return false, nil
+	case numPairs:
+		return true, nil
+	default:
+		return false, errMixedWALLessWrite
+	}
+}
+
+// isPropagated checks if the writes using the given write options are
+// recorded as is for the change propagation.
+func (rdb *rocksDB) isPropagated(wo *gorocksdb.WriteOptions) bool {
+	return rdb.opts.walLessWriteOpts == nil || wo != rdb.opts.walLessWriteOpts
+}
+
+// appendFillerChange records a change in the change log in place of the
+// given WAL-less write batch, which must have been the latest commit. The
+// filler writes the internal key of the filtered changes as many times as
+// the write batch has operations, so that it consumes the same change
+// numbers on the replicas applying it.
+func (rdb *rocksDB) appendFillerChange(wb *gorocksdb.WriteBatch) {
+	filler := gorocksdb.NewWriteBatch()
+	defer filler.Destroy()
+	filteredKey := storage.FilteredChangeKey()
+	for i := 0; i < wb.Count(); i++ {
+		filler.PutCF(rdb.normalCF, filteredKey, nil)
+	}
+	rdb.appendChange(filler)
+}
@@ -0,0 +1,230 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+)
+
+const (
+	// DefaultScanCursorTTL is the default duration for which the cursor
+	// of a paginated scan is retained between the calls.
+	DefaultScanCursorTTL = time.Minute
+	// DefaultScanPageSize is the number of key value pairs returned in
+	// a page when no page size is requested.
+	DefaultScanPageSize = 100
+	// MaxScanPageSize is the largest number of key value pairs that can
+	// be returned in a page.
+	MaxScanPageSize = 10000
+	// MaxScanCursors is the largest number of cursors that are retained
+	// at any point in time.
+	MaxScanCursors = 1024
+
+	scanTokenSize = 16
+)
+
+var (
+	// ErrScanCursorNotFound is returned when the given continuation token
+	// does not refer to a retained cursor, either because the token is
+	// invalid or because the cursor has expired.
+	ErrScanCursorNotFound = errors.New("scan cursor is either expired or invalid, scan must be restarted")
+	// ErrScanCursorMismatch is returned when the key prefix or the snapshot
+	// of a continued scan differs from those of its first page.
+	ErrScanCursorMismatch = errors.New("key prefix and snapshot must be the same across the pages of a scan")
+	errTooManyScanCursors = fmt.Errorf("number of open scan cursors exceeds the maximum of %d", MaxScanCursors)
+)
+
+// ScanCursors retains the iterators of paginated scans across calls,
+// each identified by an opaque continuation token. Since an iterator
+// reads from the point in time view of the keyspace as of its creation,
+// all the pages of a scan are consistent with each other. Cursors not
+// continued within the TTL are released.
+type ScanCursors struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	cursors map[string]*scanCursor
+	stop    chan struct{}
+}
+
+type scanCursor struct {
+	iter      Iterator
+	keyPrefix []byte
+	snapshot  string
+	expiresAt time.Time
+}
+
+// NewScanCursors creates the registry of cursors that are retained for
+// the given TTL between the calls. A zero TTL uses DefaultScanCursorTTL.
+func NewScanCursors(ttl time.Duration) *ScanCursors {
+	if ttl <= 0 {
+		ttl = DefaultScanCursorTTL
+	}
+	sc := &ScanCursors{ttl: ttl, cursors: make(map[string]*scanCursor), stop: make(chan struct{})}
+	go sc.expireCursors()
+	return sc
+}
+
+// Scan returns the next page of the given scan request, along with the
+// continuation token of the subsequent page. The token is empty once
+// the scan is complete. The given iteration is opened only for the
+// first page of a scan. Requests closing the cursor of their token only
+// release it, returning no page.
+func (sc *ScanCursors) Scan(iteration Iteration, scanReq *serverpb.ScanRequest) ([]*serverpb.KVPair, string, error) {
+	if scanReq.CloseCursor {
+		if scanReq.ContinuationToken != "" {
+			cursor, err := sc.checkout(scanReq.ContinuationToken)
+			if err != nil {
+				return nil, "", err
+			}
+			cursor.iter.Close()
+		}
+		return nil, "", nil
+	}
+
+	pageSize := int(scanReq.PageSize)
+	switch {
+	case pageSize == 0:
+		pageSize = DefaultScanPageSize
+	case pageSize > MaxScanPageSize:
+		return nil, "", fmt.Errorf("page size: %d must not exceed %d", pageSize, MaxScanPageSize)
+	}
+
+	var cursor *scanCursor
+	if token := scanReq.ContinuationToken; token != "" {
+		var err error
+		if cursor, err = sc.checkout(token); err != nil {
+			return nil, "", err
+		}
+		if !bytes.Equal(cursor.keyPrefix, scanReq.KeyPrefix) || cursor.snapshot != scanReq.Snapshot {
+			cursor.iter.Close()
+			return nil, "", ErrScanCursorMismatch
+		}
+	} else {
+		iter, err := iteration.Open()
+		if err != nil {
+			return nil, "", err
+		}
+		cursor = &scanCursor{iter: iter, keyPrefix: scanReq.KeyPrefix, snapshot: scanReq.Snapshot}
+	}
+
+	items := make([]*serverpb.KVPair, 0, pageSize)
+	for len(items) < pageSize && cursor.iter.HasNext() {
+		items = append(items, cursor.iter.Next())
+	}
+	if err := cursor.iter.Err(); err != nil {
+		cursor.iter.Close()
+		return nil, "", err
+	}
+	if !cursor.iter.HasNext() {
+		cursor.iter.Close()
+		return items, "", nil
+	}
+	token, err := sc.checkin(cursor)
+	if err != nil {
+		cursor.iter.Close()
+		return nil, "", err
+	}
+	return items, token, nil
+}
+
+// checkout removes the cursor of the given token, so that iterators
+// are never used concurrently. The cursor is checked back in under a
+// new token, hence every token can be used only once.
+func (sc *ScanCursors) checkout(token string) (*scanCursor, error) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	cursor, present := sc.cursors[token]
+	if !present {
+		return nil, ErrScanCursorNotFound
+	}
+	delete(sc.cursors, token)
+	if time.Now().After(cursor.expiresAt) {
+		cursor.iter.Close()
+		return nil, ErrScanCursorNotFound
+	}
+	return cursor, nil
+}
+
+func (sc *ScanCursors) checkin(cursor *scanCursor) (string, error) {
+	tokenBts := make([]byte, scanTokenSize)
+	if _, err := io.ReadFull(rand.Reader, tokenBts); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(tokenBts)
+
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.cursors == nil {
+		return "", ErrScanCursorNotFound
+	}
+	if len(sc.cursors) >= MaxScanCursors {
+		return "", errTooManyScanCursors
+	}
+	cursor.expiresAt = time.Now().Add(sc.ttl)
+	sc.cursors[token] = cursor
+	return token, nil
+}
+
+func (sc *ScanCursors) expireCursors() {
+	tckr := time.NewTicker(sc.ttl / 2)
+	defer tckr.Stop()
+	for {
+		select {
+		case now := <-tckr.C:
+			sc.mu.Lock()
+			for token, cursor := range sc.cursors {
+				if now.After(cursor.expiresAt) {
+					cursor.iter.Close()
+					delete(sc.cursors, token)
+				}
+			}
+			sc.mu.Unlock()
+		case <-sc.stop:
+			return
+		}
+	}
+}
+
+// ReleaseAll releases all the retained cursors. It must be invoked
+// before the underlying store is closed or replaced.
+func (sc *ScanCursors) ReleaseAll() {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	for token, cursor := range sc.cursors {
+		cursor.iter.Close()
+		delete(sc.cursors, token)
+	}
+}
+
+// ReleaseSnapshot releases the retained cursors of the scans over the
+// given published snapshot. It must be invoked before the snapshot is
+// unpublished.
+func (sc *ScanCursors) ReleaseSnapshot(snapshotRef string) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	for token, cursor := range sc.cursors {
+		if cursor.snapshot == snapshotRef {
+			cursor.iter.Close()
+			delete(sc.cursors, token)
+		}
+	}
+}
+
+// Close releases all the retained cursors and stops expiring them.
+// No cursors are retained after closing.
+func (sc *ScanCursors) Close() {
+	sc.ReleaseAll()
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.cursors != nil {
+		sc.cursors = nil
+		close(sc.stop)
+	}
+}
@@ -0,0 +1,153 @@
+package storage
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+)
+
+type sliceIterator struct {
+	kvs    []*serverpb.KVPair
+	closed bool
+}
+
+func (si *sliceIterator) HasNext() bool { return len(si.kvs) > 0 }
+func (si *sliceIterator) Err() error    { return nil }
+func (si *sliceIterator) Close() error  { si.closed = true; return nil }
+
+func (si *sliceIterator) Next() *serverpb.KVPair {
+	kv := si.kvs[0]
+	si.kvs = si.kvs[1:]
+	return kv
+}
+
+func newSliceIteration(numKeys int) (Iteration, *sliceIterator) {
+	si := &sliceIterator{}
+	for i := 0; i < numKeys; i++ {
+		si.kvs = append(si.kvs, &serverpb.KVPair{Key: []byte(fmt.Sprintf("key%03d", i))})
+	}
+	iterate := func(IterationOptions) (Iterator, error) { return si, nil }
+	return &iteration{iterate, &iterOpts{}}, si
+}
+
+func TestScanPagination(t *testing.T) {
+	sc := NewScanCursors(time.Minute)
+	defer sc.Close()
+	iteration, si := newSliceIteration(25)
+
+	scanReq := &serverpb.ScanRequest{PageSize: 10}
+	var keys []string
+	for numPages := 1; ; numPages++ {
+		items, token, err := sc.Scan(iteration, scanReq)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, item := range items {
+			keys = append(keys, string(item.Key))
+		}
+		if token == "" {
+			if numPages != 3 {
+				t.Errorf("Expected 3 pages. Actual: %d", numPages)
+			}
+			break
+		}
+		if _, _, err = sc.Scan(iteration, &serverpb.ScanRequest{ContinuationToken: "bogus"}); err != ErrScanCursorNotFound {
+			t.Errorf("Expected cursor not found error. Actual: %v", err)
+		}
+		scanReq.ContinuationToken = token
+	}
+	if len(keys) != 25 || keys[0] != "key000" || keys[24] != "key024" {
+		t.Errorf("Unexpected keys scanned: %v", keys)
+	}
+	if !si.closed {
+		t.Error("Expected the iterator to be closed at the end of the scan")
+	}
+	if _, _, err := sc.Scan(iteration, scanReq); err != ErrScanCursorNotFound {
+		t.Errorf("Expected the continuation token to be usable only once. Actual error: %v", err)
+	}
+}
+
+func TestScanCursorMismatch(t *testing.T) {
+	sc := NewScanCursors(time.Minute)
+	defer sc.Close()
+	iteration, si := newSliceIteration(5)
+
+	_, token, err := sc.Scan(iteration, &serverpb.ScanRequest{KeyPrefix: []byte("key"), PageSize: 2})
+	if err != nil || token == "" {
+		t.Fatalf("Expected a continuation token. Error: %v", err)
+	}
+	scanReq := &serverpb.ScanRequest{KeyPrefix: []byte("other"), PageSize: 2, ContinuationToken: token}
+	if _, _, err = sc.Scan(iteration, scanReq); err != ErrScanCursorMismatch {
+		t.Errorf("Expected cursor mismatch error. Actual: %v", err)
+	}
+	if !si.closed {
+		t.Error("Expected the iterator to be closed on mismatch")
+	}
+	if _, _, err = sc.Scan(iteration, &serverpb.ScanRequest{PageSize: MaxScanPageSize + 1}); err == nil {
+		t.Error("Expected an error for page size beyond the maximum")
+	}
+}
+
+func TestScanCursorClose(t *testing.T) {
+	sc := NewScanCursors(time.Minute)
+	defer sc.Close()
+	iteration, si := newSliceIteration(5)
+
+	_, token, err := sc.Scan(iteration, &serverpb.ScanRequest{PageSize: 2})
+	if err != nil || token == "" {
+		t.Fatalf("Expected a continuation token. Error: %v", err)
+	}
+	if items, nextToken, err := sc.Scan(iteration, &serverpb.ScanRequest{ContinuationToken: token, CloseCursor: true}); err != nil || len(items) != 0 || nextToken != "" {
+		t.Errorf("Expected the cursor to be closed without any page. Items: %v, Token: %s, Error: %v", items, nextToken, err)
+	}
+	if !si.closed {
+		t.Error("Expected the iterator to be closed along with its cursor")
+	}
+	if _, _, err = sc.Scan(iteration, &serverpb.ScanRequest{ContinuationToken: token}); err != ErrScanCursorNotFound {
+		t.Errorf("Expected cursor not found error. Actual: %v", err)
+	}
+}
+
+func TestScanCursorExpiry(t *testing.T) {
+	sc := NewScanCursors(50 * time.Millisecond)
+	defer sc.Close()
+	iteration, si := newSliceIteration(5)
+
+	_, token, err := sc.Scan(iteration, &serverpb.ScanRequest{PageSize: 2})
+	if err != nil || token == "" {
+		t.Fatalf("Expected a continuation token. Error: %v", err)
+	}
+	time.Sleep(200 * time.Millisecond)
+	if _, _, err = sc.Scan(iteration, &serverpb.ScanRequest{PageSize: 2, ContinuationToken: token}); err != ErrScanCursorNotFound {
+		t.Errorf("Expected cursor not found error after expiry. Actual: %v", err)
+	}
+	if !si.closed {
+		t.Error("Expected the expired iterator to be closed")
+	}
+}
+
+func TestScanCursorsRelease(t *testing.T) {
+	sc := NewScanCursors(time.Minute)
+	liveIteration, liveIter := newSliceIteration(5)
+	snapIteration, snapIter := newSliceIteration(5)
+
+	_, liveToken, _ := sc.Scan(liveIteration, &serverpb.ScanRequest{PageSize: 2})
+	_, snapToken, _ := sc.Scan(snapIteration, &serverpb.ScanRequest{Snapshot: "ns@snap1", PageSize: 2})
+	sc.ReleaseSnapshot("ns@snap1")
+	if !snapIter.closed || liveIter.closed {
+		t.Errorf("Expected only the snapshot cursor to be released. Snapshot: %t, Live: %t", snapIter.closed, liveIter.closed)
+	}
+	if _, _, err := sc.Scan(snapIteration, &serverpb.ScanRequest{Snapshot: "ns@snap1", ContinuationToken: snapToken}); err != ErrScanCursorNotFound {
+		t.Errorf("Expected cursor not found error after release. Actual: %v", err)
+	}
+
+	sc.Close()
+	if !liveIter.closed {
+		t.Error("Expected all the cursors to be released on close")
+	}
+	if _, _, err := sc.Scan(liveIteration, &serverpb.ScanRequest{ContinuationToken: liveToken}); err != ErrScanCursorNotFound {
+		t.Errorf("Expected cursor not found error after close. Actual: %v", err)
+	}
+}
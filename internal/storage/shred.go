@@ -0,0 +1,149 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"io"
+)
+
+// A SubjectEraser represents the capability of the underlying store
+// to irrecoverably erase all the data of a subject by destroying the
+// encryption key of that subject, also known as crypto-shredding.
+type SubjectEraser interface {
+	// DeleteSubject destroys the encryption key of the given subject
+	// rendering all its values unreadable. Returns an error if the
+	// given subject is not one of the configured subjects.
+	DeleteSubject(subject []byte) error
+}
+
+const subjectKeySize = 32
+
+// reservedKeyPrefix marks the internal keys that are never exposed
+// through reads, iterations or mutations of the keyspace.
+var reservedKeyPrefix = []byte("\x00dkv:")
+
+var subjectKeyRecordPrefix = append(append([]byte(nil), reservedKeyPrefix...), "subject-key:"...)
+
+// IsReservedKey checks if the given key belongs to the internal
+// keyspace reserved by DKV.
+func IsReservedKey(key []byte) bool {
+	return bytes.HasPrefix(key, reservedKeyPrefix)
+}
+
+// SubjectKeyRecord computes the reserved key under which the
+// encryption key of the given subject is stored.
+func SubjectKeyRecord(subject []byte) []byte {
+	return append(append([]byte(nil), subjectKeyRecordPrefix...), subject...)
+}
+
+// SubjectKeyRecordPrefix retrieves the common prefix of the reserved
+// keys under which the encryption keys of the subjects are stored.
+func SubjectKeyRecordPrefix() []byte {
+	return append([]byte(nil), subjectKeyRecordPrefix...)
+}
+
+// SubjectOfKeyRecord retrieves the subject whose encryption key is
+// stored under the given reserved key, if it is one such key.
+func SubjectOfKeyRecord(key []byte) ([]byte, bool) {
+	if !bytes.HasPrefix(key, subjectKeyRecordPrefix) {
+		return nil, false
+	}
+	return key[len(subjectKeyRecordPrefix):], true
+}
+
+// Subjects resolves the subject owning a given key. Keys having one
+// of the configured prefixes belong to the subject identified by the
+// prefix followed by the remainder of the key up to the delimiter.
+// With an empty delimiter, the prefix itself identifies the subject.
+type Subjects struct {
+	prefixes  [][]byte
+	delimiter []byte
+}
+
+// NewSubjects creates the subject resolver for the given prefixes and
+// delimiter. Returns nil when no prefixes are given.
+func NewSubjects(prefixes []string, delimiter string) *Subjects {
+	if len(prefixes) == 0 {
+		return nil
+	}
+	subjs := &Subjects{delimiter: []byte(delimiter)}
+	for _, prefix := range prefixes {
+		subjs.prefixes = append(subjs.prefixes, []byte(prefix))
+	}
+	return subjs
+}
+
+// Of retrieves the subject owning the given key, if any.
+func (subjs *Subjects) Of(key []byte) ([]byte, bool) {
+	if subjs == nil {
+		return nil, false
+	}
+	for _, prefix := range subjs.prefixes {
+		if !bytes.HasPrefix(key, prefix) {
+			continue
+		}
+		if len(subjs.delimiter) == 0 {
+			return prefix, true
+		}
+		rest := key[len(prefix):]
+		if idx := bytes.Index(rest, subjs.delimiter); idx >= 0 {
+			return key[:len(prefix)+idx], true
+		}
+		return key, true
+	}
+	return nil, false
+}
+
+// IsSubject checks if the given value identifies a subject by itself.
+func (subjs *Subjects) IsSubject(subject []byte) bool {
+	owner, present := subjs.Of(subject)
+	return present && bytes.Equal(owner, subject)
+}
+
+// NewSubjectKey generates a random encryption key for a subject.
+func NewSubjectKey() ([]byte, error) {
+	key := make([]byte, subjectKeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// EncryptValue encrypts the given value using the given subject key.
+// The result is prefixed with the random nonce used for encryption.
+func EncryptValue(subjectKey, value []byte) ([]byte, error) {
+	aead, err := newAEAD(subjectKey)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(value)+aead.Overhead())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, value, nil), nil
+}
+
+// DecryptValue decrypts the given value that was previously encrypted
+// using EncryptValue with the given subject key.
+func DecryptValue(subjectKey, encValue []byte) ([]byte, error) {
+	aead, err := newAEAD(subjectKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(encValue) < aead.NonceSize() {
+		return nil, errors.New("encrypted value is too short")
+	}
+	nonce, cipherText := encValue[:aead.NonceSize()], encValue[aead.NonceSize():]
+	return aead.Open(nil, nonce, cipherText, nil)
+}
+
+func newAEAD(subjectKey []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(subjectKey)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
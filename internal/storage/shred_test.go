@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSubjects(t *testing.T) {
+	subjs := NewSubjects([]string{"user:", "order:"}, ":")
+	testCases := []struct {
+		key, subject string
+		present      bool
+	}{
+		{"user:1:profile", "user:1", true},
+		{"user:1", "user:1", true},
+		{"order:42:items:1", "order:42", true},
+		{"product:1", "", false},
+	}
+	for _, tc := range testCases {
+		subject, present := subjs.Of([]byte(tc.key))
+		if present != tc.present || string(subject) != tc.subject {
+			t.Errorf("Subject mismatch for key: %s. Expected: %s, Actual: %s", tc.key, tc.subject, subject)
+		}
+	}
+	if !subjs.IsSubject([]byte("user:1")) || subjs.IsSubject([]byte("user:1:profile")) {
+		t.Error("Expected only user:1 to identify a subject")
+	}
+
+	if subject, _ := NewSubjects([]string{"tenant:"}, "").Of([]byte("tenant:1:name")); string(subject) != "tenant:" {
+		t.Errorf("Expected the prefix to identify the subject without a delimiter, but got %s", subject)
+	}
+	if _, present := NewSubjects(nil, ":").Of([]byte("user:1")); present {
+		t.Error("Did not expect any subjects without prefixes")
+	}
+}
+
+func TestSubjectKeyRecord(t *testing.T) {
+	rec := SubjectKeyRecord([]byte("user:1"))
+	if !IsReservedKey(rec) || !bytes.HasPrefix(rec, SubjectKeyRecordPrefix()) {
+		t.Errorf("Expected a reserved key having the subject key record prefix, but got %q", rec)
+	}
+	if subject, ok := SubjectOfKeyRecord(rec); !ok || string(subject) != "user:1" {
+		t.Errorf("Expected subject user:1 for %q, but got %q", rec, subject)
+	}
+	if _, ok := SubjectOfKeyRecord([]byte("user:1")); ok {
+		t.Error("Did not expect a subject for a key that is not a subject key record")
+	}
+}
+
+func TestEncryptDecryptValue(t *testing.T) {
+	subjKey, err := NewSubjectKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	value := []byte("some personal data")
+	encValue, err := EncryptValue(subjKey, value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(encValue, value) {
+		t.Error("Did not expect the encrypted value to contain the plain text")
+	}
+	if decValue, err := DecryptValue(subjKey, encValue); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(decValue, value) {
+		t.Errorf("Decrypted value mismatch. Expected: %s, Actual: %s", value, decValue)
+	}
+
+	otherKey, _ := NewSubjectKey()
+	if _, err = DecryptValue(otherKey, encValue); err == nil {
+		t.Error("Expected an error for decrypting with a different subject key")
+	}
+}
@@ -0,0 +1,110 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+)
+
+// A SnapshotPublisher represents the capability of the underlying
+// store to publish named, immutable point in time views of all the
+// keys belonging to a namespace. A namespace is identified by the
+// key prefix shared by all its keys. Published snapshots are only
+// held in memory and hence do not survive restarts.
+type SnapshotPublisher interface {
+	// PublishSnapshot captures the current state of the given namespace
+	// and registers it under the `namespace@name` reference. Returns an
+	// error if a snapshot with the same reference is already published.
+	PublishSnapshot(namespace, name string) error
+	// UnpublishSnapshot releases the snapshot registered under the
+	// given reference.
+	UnpublishSnapshot(ref string) error
+	// ListSnapshots retrieves the references of all the published
+	// snapshots.
+	ListSnapshots() []string
+	// SnapshotView retrieves the read-only view of the snapshot that
+	// is registered under the given reference. The view holds the
+	// snapshot, even once unpublished, until it is closed.
+	SnapshotView(ref string) (SnapshotView, error)
+}
+
+// A SnapshotView represents a read-only view of a published snapshot.
+// Only the keys belonging to the namespace of the snapshot can be
+// read through this view, which must be closed once done.
+type SnapshotView interface {
+	io.Closer
+	// Get bulk fetches the associated values for the given keys as of
+	// the time the snapshot was published.
+	Get(keys ...[]byte) ([]*serverpb.KVPair, error)
+	// Iterate iterates through the keys of the snapshot namespace.
+	// Any key prefix set in the IterationOptions must itself belong
+	// to the snapshot namespace. The iterator holds the snapshot until
+	// it is closed, even once this view is closed.
+	Iterate(IterationOptions) (Iterator, error)
+}
+
+const snapshotRefSeparator = "@"
+
+// SnapshotRef builds the reference of the snapshot with the given
+// name published over the given namespace.
+func SnapshotRef(namespace, name string) string {
+	return namespace + snapshotRefSeparator + name
+}
+
+// ParseSnapshotRef extracts the namespace and name from the given
+// snapshot reference of the form `namespace@name`.
+func ParseSnapshotRef(ref string) (namespace, name string, err error) {
+	idx := strings.LastIndex(ref, snapshotRefSeparator)
+	if idx < 0 {
+		return "", "", fmt.Errorf("invalid snapshot reference: %s, must be in namespace@name format", ref)
+	}
+	namespace, name = ref[:idx], ref[idx+1:]
+	if err = ValidateSnapshotName(name); err != nil {
+		return "", "", err
+	}
+	return namespace, name, nil
+}
+
+// ValidateSnapshotName checks if the given name can be used
+// for publishing a snapshot.
+func ValidateSnapshotName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New("snapshot name must be provided")
+	}
+	if strings.Contains(name, snapshotRefSeparator) {
+		return fmt.Errorf("snapshot name: %s must not contain %s", name, snapshotRefSeparator)
+	}
+	return nil
+}
+
+// CheckNamespaceKeys ensures all the given keys belong to the given
+// namespace, returning an error otherwise.
+func CheckNamespaceKeys(namespace []byte, keys ...[]byte) error {
+	for _, key := range keys {
+		if !bytes.HasPrefix(key, namespace) {
+			return fmt.Errorf("key: %s does not belong to the snapshot namespace: %s", key, namespace)
+		}
+	}
+	return nil
+}
+
+// NamespaceIterationOptions restricts the given IterationOptions to the
+// given namespace. Returns an error if the key prefix or the start key
+// present in the given options do not belong to the namespace.
+func NamespaceIterationOptions(namespace []byte, itOpts IterationOptions) (IterationOptions, error) {
+	opts := &iterOpts{keyPrefix: namespace}
+	if kp, present := itOpts.KeyPrefix(); present {
+		if err := CheckNamespaceKeys(namespace, kp); err != nil {
+			return nil, err
+		}
+		opts.keyPrefix = kp
+	}
+	if sk, present := itOpts.StartKey(); present {
+		opts.startKey = sk
+	}
+	return opts, opts.validate()
+}
@@ -0,0 +1,37 @@
+package storage
+
+import "testing"
+
+func TestParseSnapshotRef(t *testing.T) {
+	if ns, name, err := ParseSnapshotRef(SnapshotRef("user:", "nightly")); err != nil {
+		t.Fatal(err)
+	} else if ns != "user:" || name != "nightly" {
+		t.Errorf("Snapshot reference mismatch. Namespace: %s, Name: %s", ns, name)
+	}
+
+	for _, ref := range []string{"user:", "user:@", "user:@ "} {
+		if _, _, err := ParseSnapshotRef(ref); err == nil {
+			t.Errorf("Expected an error for invalid snapshot reference: %s", ref)
+		}
+	}
+}
+
+func TestNamespaceIterationOptions(t *testing.T) {
+	ns := []byte("user:")
+	itOpts, _ := NewIteratorOptions(IterationPrefixKey([]byte("user:1")))
+	if nsItOpts, err := NamespaceIterationOptions(ns, itOpts); err != nil {
+		t.Fatal(err)
+	} else if kp, _ := nsItOpts.KeyPrefix(); string(kp) != "user:1" {
+		t.Errorf("Expected key prefix: user:1, but got %s", kp)
+	}
+
+	itOpts, _ = NewIteratorOptions(IterationPrefixKey([]byte("order:")))
+	if _, err := NamespaceIterationOptions(ns, itOpts); err == nil {
+		t.Error("Expected an error for key prefix outside the namespace")
+	}
+
+	itOpts, _ = NewIteratorOptions(IterationStartKey([]byte("order:1")))
+	if _, err := NamespaceIterationOptions(ns, itOpts); err == nil {
+		t.Error("Expected an error for start key outside the namespace")
+	}
+}
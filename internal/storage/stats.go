@@ -0,0 +1,112 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/internal/stats"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+)
+
+// DefaultEngineStatsInterval is the default interval at which the
+// statistics of the storage engine are published as metrics.
+const DefaultEngineStatsInterval = 30 * time.Second
+
+// EngineStats captures the internals of a storage engine. Statistics
+// that are not tracked by an engine are left as zero.
+type EngineStats struct {
+	// MemtableSizeBytes is the size of all the in-memory write buffers.
+	MemtableSizeBytes uint64
+	// NumFilesPerLevel is the number of files at every level of the
+	// LSM tree, starting with level 0.
+	NumFilesPerLevel []uint64
+	// BlockCacheHitRate is the fraction of the block lookups that were
+	// served from the block cache.
+	BlockCacheHitRate float64
+	// PendingCompactionBytes is the estimated number of bytes that are
+	// yet to be rewritten by the compactions.
+	PendingCompactionBytes uint64
+	// OldestChangeNumber is the oldest change number that can be loaded
+	// for replication, below which slaves must bootstrap afresh.
+	OldestChangeNumber uint64
+	// ChangeHistoryBytes is the size of the retained history of the
+	// changes, such as the WAL and the change log.
+	ChangeHistoryBytes uint64
+	// NamespaceCacheStats is the block cache usage of the reads of every
+	// namespace accounted for, in the order of the namespaces.
+	NamespaceCacheStats []*serverpb.NamespaceCacheStats
+}
+
+// EngineStatsReporter periodically publishes the statistics of a storage
+// engine as gauges. Statistics are loaded through a function rather than
+// from a store, since the store of a service is replaced upon restores.
+type EngineStatsReporter struct {
+	statsCli stats.Client
+	load     func() (*EngineStats, error)
+	stop     chan struct{}
+	once     sync.Once
+}
+
+// NewEngineStatsReporter begins publishing the statistics loaded using
+// the given function once every given interval. A zero interval uses
+// DefaultEngineStatsInterval.
+func NewEngineStatsReporter(statsCli stats.Client, interval time.Duration, load func() (*EngineStats, error)) *EngineStatsReporter {
+	if interval <= 0 {
+		interval = DefaultEngineStatsInterval
+	}
+	esr := &EngineStatsReporter{statsCli: statsCli, load: load, stop: make(chan struct{})}
+	go esr.report(interval)
+	return esr
+}
+
+func (esr *EngineStatsReporter) report(interval time.Duration) {
+	tckr := time.NewTicker(interval)
+	defer tckr.Stop()
+	for {
+		select {
+		case <-tckr.C:
+			if es, err := esr.load(); err != nil {
+				esr.statsCli.Incr("storage.stats.errors", 1)
+			} else {
+				esr.publish(es)
+			}
+		case <-esr.stop:
+			return
+		}
+	}
+}
+
+func (esr *EngineStatsReporter) publish(es *EngineStats) {
+	esr.statsCli.Gauge("storage.memtable.size.bytes", int64(es.MemtableSizeBytes))
+	for level, numFiles := range es.NumFilesPerLevel {
+		esr.statsCli.Gauge(fmt.Sprintf("storage.level%d.num.files", level), int64(numFiles))
+	}
+	esr.statsCli.Gauge("storage.block.cache.hit.pct", int64(es.BlockCacheHitRate*100))
+	esr.statsCli.Gauge("storage.pending.compaction.bytes", int64(es.PendingCompactionBytes))
+	esr.statsCli.Gauge("storage.changes.oldest", int64(es.OldestChangeNumber))
+	esr.statsCli.Gauge("storage.changes.history.bytes", int64(es.ChangeHistoryBytes))
+	for _, ncs := range es.NamespaceCacheStats {
+		esr.statsCli.Gauge("storage.namespace."+MetricSafe(ncs.Namespace)+".block.cache.hit.pct", int64(ncs.BlockCacheHitRate*100))
+	}
+}
+
+// MetricSafe replaces the characters of the given name that are not
+// allowed within the metric names, such as the separators of the host
+// and port of an address or those of a key prefix.
+func MetricSafe(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
+
+// Close stops publishing the statistics.
+func (esr *EngineStatsReporter) Close() {
+	esr.once.Do(func() { close(esr.stop) })
+}
@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/internal/stats"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+)
+
+type gaugeRecorder struct {
+	stats.Client
+	mu     sync.Mutex
+	gauges map[string]int64
+	incrs  map[string]int64
+}
+
+func newGaugeRecorder() *gaugeRecorder {
+	return &gaugeRecorder{Client: stats.NewNoOpClient(), gauges: make(map[string]int64), incrs: make(map[string]int64)}
+}
+
+func (gr *gaugeRecorder) Gauge(name string, value int64) {
+	gr.mu.Lock()
+	defer gr.mu.Unlock()
+	gr.gauges[name] = value
+}
+
+func (gr *gaugeRecorder) Incr(name string, value int64) {
+	gr.mu.Lock()
+	defer gr.mu.Unlock()
+	gr.incrs[name] += value
+}
+
+func (gr *gaugeRecorder) gauge(name string) (int64, bool) {
+	gr.mu.Lock()
+	defer gr.mu.Unlock()
+	val, present := gr.gauges[name]
+	return val, present
+}
+
+func TestEngineStatsReporter(t *testing.T) {
+	gr := newGaugeRecorder()
+	es := &EngineStats{MemtableSizeBytes: 1024, NumFilesPerLevel: []uint64{4, 2}, BlockCacheHitRate: 0.75, PendingCompactionBytes: 2048,
+		OldestChangeNumber: 42, ChangeHistoryBytes: 4096,
+		NamespaceCacheStats: []*serverpb.NamespaceCacheStats{{Namespace: "user:session:", BlockCacheHitRate: 0.5}}}
+	esr := NewEngineStatsReporter(gr, 10*time.Millisecond, func() (*EngineStats, error) { return es, nil })
+	time.Sleep(50 * time.Millisecond)
+	esr.Close()
+	esr.Close()
+
+	expected := map[string]int64{
+		"storage.memtable.size.bytes":                         1024,
+		"storage.level0.num.files":                            4,
+		"storage.level1.num.files":                            2,
+		"storage.block.cache.hit.pct":                         75,
+		"storage.pending.compaction.bytes":                    2048,
+		"storage.changes.oldest":                              42,
+		"storage.changes.history.bytes":                       4096,
+		"storage.namespace.user_session_.block.cache.hit.pct": 50,
+	}
+	for name, expVal := range expected {
+		if val, present := gr.gauge(name); !present || val != expVal {
+			t.Errorf("Gauge mismatch for %s. Expected: %d, Actual: %d", name, expVal, val)
+		}
+	}
+}
+
+func TestEngineStatsReporterErrors(t *testing.T) {
+	gr := newGaugeRecorder()
+	esr := NewEngineStatsReporter(gr, 10*time.Millisecond, func() (*EngineStats, error) { return nil, errors.New("closed") })
+	time.Sleep(50 * time.Millisecond)
+	esr.Close()
+
+	gr.mu.Lock()
+	defer gr.mu.Unlock()
+	if len(gr.gauges) != 0 || gr.incrs["storage.stats.errors"] == 0 {
+		t.Errorf("Expected only errors to be reported. Gauges: %v, Counters: %v", gr.gauges, gr.incrs)
+	}
+}
@@ -1,9 +1,11 @@
 package storage
 
 import (
+	"errors"
 	"io"
 	"io/ioutil"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/flipkart-incubator/dkv/pkg/serverpb"
@@ -18,6 +20,8 @@ type KVStore interface {
 	// optionally sets the expireTS of the key to the provided epoch in seconds
 	Put(pairs ...*serverpb.KVPair) error
 	// Get bulk fetches the associated values for the given keys.
+	// Only the keys that are present are returned, in the order in
+	// which they are given. Use MultiGet for a positional response.
 	// Note that during partial failures, any successful results
 	// are discarded and an error is returned instead.
 	Get(keys ...[]byte) ([]*serverpb.KVPair, error)
@@ -42,6 +46,8 @@ type KVStore interface {
 	// If the expected value is `nil`, then the key is created and
 	// initialized with the given value, atomically.
 	CompareAndSet(key, expect, update []byte) (bool, error)
+	// Stats retrieves the current statistics of the storage engine.
+	Stats() (*EngineStats, error)
 }
 
 // A Backupable represents the capability of the underlying store
@@ -75,10 +81,17 @@ type ChangePropagator interface {
 	GetLatestCommittedChangeNumber() (uint64, error)
 	// LoadChanges retrieves all the changes committed since the given
 	// `fromChangeNumber`. Also, `maxChanges` can be used to limit the
-	// number of changes returned in the response.
+	// number of changes returned in the response. Returns
+	// ErrChangesNotAvailable if the change with the given number is no
+	// longer retained.
 	LoadChanges(fromChangeNumber uint64, maxChanges int) ([]*serverpb.ChangeRecord, error)
 }
 
+// ErrChangesNotAvailable is returned when the changes to be loaded are no
+// longer retained, such as those of the purged WAL files. Replicas that
+// are yet to apply such changes must bootstrap afresh.
+var ErrChangesNotAvailable = errors.New("change number no longer available")
+
 // A ChangeApplier represents the capability of the underlying store
 // to apply changes directly onto its key space. This is typically
 // used for replication purposes to indicate that the implementor
@@ -98,6 +111,326 @@ type ChangeApplier interface {
 	SaveChanges(changes []*serverpb.ChangeRecord) (uint64, error)
 }
 
+// A Merger represents the capability of the underlying store to
+// merge operands into the values of keys on the server, using the
+// merge operators configured on the store.
+type Merger interface {
+	// Merge merges the given operand into the value of the given key.
+	Merge(key, operand []byte) error
+}
+
+// An ExistenceChecker represents the capability of the underlying
+// store to check the presence of keys without loading their values.
+type ExistenceChecker interface {
+	// Exists checks if the given key is present in the store.
+	Exists(key []byte) (bool, error)
+}
+
+// Exists checks if the given key is present in the given store. The
+// value of the key is loaded for checking its presence, only if the
+// store is incapable of checking presence otherwise.
+func Exists(kvs KVStore, key []byte) (bool, error) {
+	if ec, ok := kvs.(ExistenceChecker); ok {
+		return ec.Exists(key)
+	}
+	res, err := kvs.Get(key)
+	return len(res) == 1, err
+}
+
+// A VersionedReader represents the capability of the underlying store
+// to read the values of keys as of a historical change number.
+type VersionedReader interface {
+	// GetAsOf bulk fetches the values of the given keys as they were
+	// right after the change with the given change number committed.
+	GetAsOf(changeNumber uint64, keys ...[]byte) ([]*serverpb.KVPair, error)
+}
+
+// ErrTxnConflict is returned when an optimistic transaction is not
+// committed, since some of the keys read by it changed in the meantime.
+// Such transactions can be retried.
+var ErrTxnConflict = errors.New("transaction conflicts with concurrent changes")
+
+// ErrUnmergeableValue is returned when the operand of a merge, or the
+// value put for a key whose merges are managed by a merge operator,
+// cannot be merged by that merge operator.
+var ErrUnmergeableValue = errors.New("value cannot be merged by the merge operator of the key")
+
+// A Transactor represents the capability of the underlying store to
+// commit optimistic transactions over multiple keys.
+type Transactor interface {
+	// Transact atomically commits the given writes, provided every read
+	// key still holds the value observed by the read, where an empty value
+	// indicates that the key was observed to be absent. Returns
+	// ErrTxnConflict otherwise.
+	Transact(reads []*serverpb.KVPair, writes []*serverpb.TxnWrite) error
+}
+
+// A WALSyncWriter represents the capability of the underlying store
+// to override its configured WAL sync policy for individual writes.
+type WALSyncWriter interface {
+	// PutWithWALSync is similar to Put except that the given WAL sync
+	// overrides the configured WAL sync policy for this write. Returns
+	// the durability of the write at the time it is acknowledged.
+	PutWithWALSync(walSync serverpb.WALSync, pairs ...*serverpb.KVPair) (serverpb.Durability, error)
+}
+
+// PutWithWALSync puts the given pairs into the given store honouring
+// the given WAL sync, if the store supports it. Otherwise the pairs
+// are put using the WAL sync policy configured on the store, in which
+// case the durability of the write is unknown.
+func PutWithWALSync(kvs KVStore, walSync serverpb.WALSync, pairs ...*serverpb.KVPair) (serverpb.Durability, error) {
+	if wsw, ok := kvs.(WALSyncWriter); ok {
+		return wsw.PutWithWALSync(walSync, pairs...)
+	}
+	return serverpb.Durability_UNKNOWN_DURABILITY, kvs.Put(pairs...)
+}
+
+// A DurabilityTracker represents the capability of the underlying store
+// to report the change number upto which its writes are fsynced.
+type DurabilityTracker interface {
+	// GetSyncedChangeNumber retrieves the change number upto which all
+	// the writes are known to be fsynced to the WAL.
+	GetSyncedChangeNumber() uint64
+	// SyncWAL fsyncs the WAL, making all the writes committed so far
+	// durable. Returns the change number upto which the writes are
+	// fsynced thereafter.
+	SyncWAL() (uint64, error)
+}
+
+// MultiPutWALSync determines the WAL sync for a bulk write of the
+// given put requests. The write is fsynced if any of the requests
+// asks for it, and is not if all of them ask for async writes.
+func MultiPutWALSync(putReqs []*serverpb.PutRequest) serverpb.WALSync {
+	walSync := serverpb.WALSync_ASYNC_WAL
+	for _, putReq := range putReqs {
+		switch putReq.WalSync {
+		case serverpb.WALSync_SYNC_WAL:
+			return serverpb.WALSync_SYNC_WAL
+		case serverpb.WALSync_DEFAULT_WAL_SYNC:
+			walSync = serverpb.WALSync_DEFAULT_WAL_SYNC
+		}
+	}
+	return walSync
+}
+
+// An OptionedReader represents the capability of the underlying store
+// to honour the read options of individual read requests.
+type OptionedReader interface {
+	// GetWithOptions is similar to Get except that the given read
+	// options are used for this read.
+	GetWithOptions(readOpts *serverpb.ReadOptions, keys ...[]byte) ([]*serverpb.KVPair, error)
+	// IterateWithOptions is similar to Iterate except that the given
+	// read options are used for this iteration.
+	IterateWithOptions(readOpts *serverpb.ReadOptions, iterOpts IterationOptions) Iterator
+}
+
+// GetWithOptions loads the values of the given keys from the given store
+// honouring the given read options, if any and if the store supports them.
+// Otherwise the values are loaded using the default read options.
+func GetWithOptions(kvs KVStore, readOpts *serverpb.ReadOptions, keys ...[]byte) ([]*serverpb.KVPair, error) {
+	if or, ok := kvs.(OptionedReader); ok && readOpts != nil {
+		return or.GetWithOptions(readOpts, keys...)
+	}
+	return kvs.Get(keys...)
+}
+
+// MultiGet loads the values of the given keys using the given read
+// function, typically the Get of a store, such that the i-th result
+// always belongs to the i-th key. Keys that are absent are answered with
+// an empty value, just as the reads of single keys. Duplicate keys are
+// read once and answered at each of their positions.
+func MultiGet(read func(keys ...[]byte) ([]*serverpb.KVPair, error), keys ...[]byte) ([]*serverpb.KVPair, error) {
+	uniqKeys, seen := make([][]byte, 0, len(keys)), make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if !seen[string(key)] {
+			seen[string(key)] = true
+			uniqKeys = append(uniqKeys, key)
+		}
+	}
+	kvs, err := read(uniqKeys...)
+	if err != nil {
+		return nil, err
+	}
+	found := make(map[string]*serverpb.KVPair, len(kvs))
+	for _, kv := range kvs {
+		found[string(kv.Key)] = kv
+	}
+	results := make([]*serverpb.KVPair, len(keys))
+	for i, key := range keys {
+		res := &serverpb.KVPair{Key: key}
+		if kv, present := found[string(key)]; present {
+			res.Value, res.ExpireTS = kv.Value, kv.ExpireTS
+		}
+		results[i] = res
+	}
+	return results, nil
+}
+
+// ParallelGet loads the values of the given keys using the given read
+// function, typically the Get of a store, in batches of upto the given
+// size with upto the given number of batches read concurrently. Results
+// are returned in the order of the keys, as long as the read function
+// does so for every batch. All the keys are read at once if they fit in
+// a single batch or the parallelism is less than 2. The read function
+// is responsible for reading all the batches from the same point in
+// time view of the keyspace, if necessary.
+func ParallelGet(read func(keys ...[]byte) ([]*serverpb.KVPair, error), batchSize, parallelism int, keys ...[]byte) ([]*serverpb.KVPair, error) {
+	if batchSize <= 0 || len(keys) <= batchSize || parallelism < 2 {
+		return read(keys...)
+	}
+	numBatches := (len(keys) + batchSize - 1) / batchSize
+	results, errs := make([][]*serverpb.KVPair, numBatches), make([]error, numBatches)
+	slots := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	for i := 0; i < numBatches; i++ {
+		start, end := i*batchSize, (i+1)*batchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		slots <- struct{}{}
+		wg.Add(1)
+		// Batches are capped so that appending to one cannot overwrite the next
+		go func(i int, batch [][]byte) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			results[i], errs[i] = read(batch...)
+		}(i, keys[start:end:end])
+	}
+	wg.Wait()
+	var kvs []*serverpb.KVPair
+	for i, res := range results {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		kvs = append(kvs, res...)
+	}
+	return kvs, nil
+}
+
+// A Compactable represents the capability of the underlying store
+// to compact its keyspace on demand, typically used for reclaiming
+// space after a large number of deletes.
+type Compactable interface {
+	// CompactRange compacts all the keys having the given prefix. The
+	// entire keyspace is compacted when the given prefix is empty.
+	CompactRange(keyPrefix []byte) error
+}
+
+// SizeEstimate is the approximate number of keys and the approximate
+// size in bytes occupied by a portion of the keyspace.
+type SizeEstimate struct {
+	NumKeys   uint64
+	SizeBytes uint64
+}
+
+// A SizeEstimator represents the capability of the underlying store
+// to cheaply estimate the size of its keyspace, without scanning it.
+// Such estimates are typically used for capacity planning and for
+// deciding the split points of shards.
+type SizeEstimator interface {
+	// EstimateSize estimates the size of the keys lying within the given
+	// start key (inclusive) and end key (exclusive). An empty start key
+	// or end key leaves the range unbounded on that side.
+	EstimateSize(startKey, endKey []byte) (*SizeEstimate, error)
+}
+
+// ReadAmplification summarizes the work done by the reads sampled over
+// a window of time, along with plain-language tuning suggestions.
+type ReadAmplification struct {
+	// Number of sampled reads and the keys looked up by them
+	NumReads, NumKeys uint64
+	// Average number of data blocks read from disk per key
+	BlockReadsPerKey float64
+	// Fraction of block lookups served by the block cache
+	BlockCacheHitRate float64
+	// Fraction of reads served entirely by the memtables
+	MemtableHitRate float64
+	// Fraction of bloom filter checks that ruled out a file
+	BloomUsefulness float64
+	Suggestions     []string
+}
+
+// A ReadAmplificationSampler represents the capability of the underlying
+// store to report on the efficiency of its reads, for guiding operators
+// in tuning the store.
+type ReadAmplificationSampler interface {
+	// SampleReadAmplification samples all the reads served during the
+	// given window, blocking until the window elapses.
+	SampleReadAmplification(window time.Duration) (*ReadAmplification, error)
+}
+
+// An SSTIngester represents the capability of the underlying store to
+// ingest externally built SST files, bypassing its WAL and memtables.
+// Such ingestion is typically used for bulk loading the initial data.
+type SSTIngester interface {
+	// IngestSST ingests the given SST files holding the keys without and
+	// with TTL respectively, such that the keys of the later files take
+	// precedence. The files are moved rather than copied if moveFiles is
+	// set, which requires them to be on the same filesystem as the store.
+	IngestSST(files, ttlFiles []string, moveFiles bool) error
+}
+
+// An Exporter represents the capability of the underlying store to
+// export a consistent snapshot of a key range for offline processing.
+type Exporter interface {
+	// Export writes the keys lying within the given start key (inclusive)
+	// and end key (exclusive), as of a single point in time, into the given
+	// folder in the given format. Empty keys leave the range unbounded on
+	// the respective side. Retrieves the paths of the files written along
+	// with the number of keys exported.
+	Export(startKey, endKey []byte, format serverpb.ExportFormat, dir string) ([]string, uint64, error)
+}
+
+// A Quarantiner represents the capability of the underlying store to
+// set aside the records found to be corrupt or rejected into its
+// quarantine namespace, instead of serving or applying them.
+type Quarantiner interface {
+	// ListQuarantinedRecords retrieves all the quarantined records.
+	ListQuarantinedRecords() ([]*serverpb.QuarantinedRecord, error)
+	// OnCorruptEntry sets the handler to which the background scrubber
+	// reports the corrupt entries it finds, for them to be quarantined
+	// through the write path of the node, hence replicated like any other
+	// write. Without a handler, corrupt entries are only reported.
+	OnCorruptEntry(hndlr func(*serverpb.QuarantinedRecord) error)
+	// Quarantine moves the entry of the key of the given record aside into
+	// the quarantine namespace, preserving its current value as evidence.
+	// It reports if the key was present.
+	Quarantine(rec *serverpb.QuarantinedRecord) (bool, error)
+}
+
+// A MemtableTuner represents the capability of the underlying store to
+// change the sizing of its memtables and the thresholds of its level 0
+// at runtime.
+type MemtableTuner interface {
+	// SetMemtableOptions changes the given non zero options.
+	SetMemtableOptions(mtOpts *serverpb.MemtableOptions) error
+}
+
+// A PinnedReader represents the capability of the underlying store to
+// load a value without copying it out of the memory of the store, which
+// avoids an allocation per read of large values.
+type PinnedReader interface {
+	// GetPinned loads the value of the given key along with its expiry
+	// timestamp, if any. The value is empty when the key is absent. The
+	// value is only valid until the returned release function is invoked,
+	// which must be done exactly once.
+	GetPinned(key []byte) ([]byte, uint64, func(), error)
+}
+
+// A RecordStore represents the capability of the underlying store to hold
+// the internal records of DKV under reserved keys. Such records are never
+// exposed to the clients, yet are replicated along with the changes of
+// the store.
+type RecordStore interface {
+	// LoadRecord loads the record held under the given reserved key,
+	// which is empty when absent.
+	LoadRecord(key []byte) ([]byte, error)
+	// SaveRecord saves the given record under the given reserved key.
+	SaveRecord(key, value []byte) error
+}
+
 // TODO: Following functions should be moved to a util layer ?
 
 const timeFormatTempPath = "20060102150405"
@@ -0,0 +1,121 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+)
+
+func TestMultiGet(t *testing.T) {
+	data := map[string]string{"k1": "v1", "k2": "v2"}
+	var reads [][]byte
+	read := func(keys ...[]byte) ([]*serverpb.KVPair, error) {
+		reads = append(reads, keys...)
+		var kvs []*serverpb.KVPair
+		for _, key := range keys {
+			if val, present := data[string(key)]; present {
+				kvs = append(kvs, &serverpb.KVPair{Key: key, Value: []byte(val)})
+			}
+		}
+		return kvs, nil
+	}
+
+	keys := [][]byte{[]byte("k2"), []byte("absent"), []byte("k1"), []byte("k2")}
+	results, err := MultiGet(read, keys...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reads) != 3 {
+		t.Errorf("Expected the duplicate keys to be read once, but read %q", reads)
+	}
+	expVals := []string{"v2", "", "v1", "v2"}
+	if len(results) != len(keys) {
+		t.Fatalf("Expected %d results, but got %d", len(keys), len(results))
+	}
+	for i, res := range results {
+		if !bytes.Equal(res.Key, keys[i]) || string(res.Value) != expVals[i] {
+			t.Errorf("Expected key: %s with value: %q at position: %d, but got key: %s with value: %q",
+				keys[i], expVals[i], i, res.Key, res.Value)
+		}
+	}
+
+	readErr := errors.New("read failure")
+	if _, err = MultiGet(func(keys ...[]byte) ([]*serverpb.KVPair, error) { return nil, readErr }, keys...); err != readErr {
+		t.Errorf("Expected error: %v, but got: %v", readErr, err)
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	kv := &serverpb.KVPair{Key: []byte("k1"), Value: []byte("v1"), ExpireTS: 100}
+	if Checksum(kv) != Checksum(&serverpb.KVPair{Key: []byte("k2"), Value: []byte("v1"), ExpireTS: 100}) {
+		t.Error("Expected the checksum to be independent of the key")
+	}
+	for _, other := range []*serverpb.KVPair{
+		{Key: []byte("k1"), Value: []byte("v2"), ExpireTS: 100},
+		{Key: []byte("k1"), Value: []byte("v1"), ExpireTS: 200},
+		{Key: []byte("k1"), Value: []byte("v1")},
+	} {
+		if Checksum(kv) == Checksum(other) {
+			t.Errorf("Expected the checksums of %v and %v to differ", kv, other)
+		}
+	}
+}
+
+func TestParallelGet(t *testing.T) {
+	var keys [][]byte
+	for i := 0; i < 10; i++ {
+		keys = append(keys, []byte(fmt.Sprintf("k%d", i)))
+	}
+	var mu sync.Mutex
+	var batchSizes []int
+	read := func(batch ...[]byte) ([]*serverpb.KVPair, error) {
+		mu.Lock()
+		batchSizes = append(batchSizes, len(batch))
+		mu.Unlock()
+		var kvs []*serverpb.KVPair
+		for _, key := range batch {
+			// Odd keys are absent
+			if key[len(key)-1]&1 == 0 {
+				kvs = append(kvs, &serverpb.KVPair{Key: key, Value: key})
+			}
+		}
+		// Appending must not clobber the keys of the other batches
+		_ = append(batch, []byte("clobber"))
+		return kvs, nil
+	}
+
+	kvs, err := ParallelGet(read, 3, 2, keys...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(batchSizes) != 4 {
+		t.Errorf("Expected 4 batches to be read. Actual: %v", batchSizes)
+	}
+	if len(kvs) != 5 {
+		t.Fatalf("Expected 5 results. Actual: %d", len(kvs))
+	}
+	for i, kv := range kvs {
+		if expKey := fmt.Sprintf("k%d", 2*i); string(kv.Key) != expKey {
+			t.Errorf("Expected the result at %d to be of key %s. Actual: %s", i, expKey, kv.Key)
+		}
+	}
+
+	batchSizes = nil
+	if _, err = ParallelGet(read, 20, 2, keys...); err != nil || len(batchSizes) != 1 {
+		t.Errorf("Expected a single batch. Actual: %v, Error: %v", batchSizes, err)
+	}
+
+	failingRead := func(batch ...[]byte) ([]*serverpb.KVPair, error) {
+		if string(batch[0]) == "k6" {
+			return nil, errors.New("read failed")
+		}
+		return nil, nil
+	}
+	if _, err = ParallelGet(failingRead, 3, 2, keys...); err == nil {
+		t.Error("Expected the error of the failed batch")
+	}
+}
@@ -0,0 +1,197 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// A TenantKeyManager represents the capability of the underlying store
+// to encrypt the values of every tenant using a data key of its own,
+// which is in turn encrypted using the master key. The data key of a
+// tenant can hence be rotated or destroyed independently of the other
+// tenants.
+type TenantKeyManager interface {
+	// RotateTenantKey generates a new data key for the given tenant and
+	// re-encrypts all the values of that tenant using it.
+	RotateTenantKey(tenant []byte) (*KeyRotation, error)
+	// DeleteTenant destroys the data keys of the given tenant rendering
+	// all its values unreadable. Returns an error if the given tenant is
+	// not one of the configured tenants.
+	DeleteTenant(tenant []byte) error
+}
+
+// tenantValuePrefix marks the values encrypted using a data key of a
+// tenant, which is followed by the length and the identifier of that
+// key.
+var tenantValuePrefix = []byte("\x00dkv-tenant\x01")
+
+var tenantKeyRecordPrefix = append(append([]byte(nil), reservedKeyPrefix...), "tenant-key:"...)
+
+// TenantKeyRecord computes the reserved key under which the data keys
+// of the given tenant are stored.
+func TenantKeyRecord(tenant []byte) []byte {
+	return append(append([]byte(nil), tenantKeyRecordPrefix...), tenant...)
+}
+
+// TenantKeyRecordPrefix is the prefix of the reserved keys under which
+// the data keys of the tenants are stored.
+func TenantKeyRecordPrefix() []byte {
+	return append([]byte(nil), tenantKeyRecordPrefix...)
+}
+
+// IsTenantSealed checks if the given value is encrypted using a data
+// key of a tenant.
+func IsTenantSealed(value []byte) bool {
+	_, _, sealed := parseEnvelope(tenantValuePrefix, value)
+	return sealed
+}
+
+// Tenants resolves the tenant owning a given key. Every configured
+// key prefix identifies a tenant, which owns all the keys having
+// that prefix.
+type Tenants struct {
+	prefixes [][]byte
+}
+
+// NewTenants creates the tenant resolver for the given prefixes.
+// Returns nil when no prefixes are given.
+func NewTenants(prefixes []string) *Tenants {
+	if len(prefixes) == 0 {
+		return nil
+	}
+	tnts := &Tenants{}
+	for _, prefix := range prefixes {
+		tnts.prefixes = append(tnts.prefixes, []byte(prefix))
+	}
+	return tnts
+}
+
+// Of retrieves the tenant owning the given key, if any.
+func (tnts *Tenants) Of(key []byte) ([]byte, bool) {
+	if tnts == nil {
+		return nil, false
+	}
+	for _, prefix := range tnts.prefixes {
+		if bytes.HasPrefix(key, prefix) {
+			return prefix, true
+		}
+	}
+	return nil, false
+}
+
+// IsTenant checks if the given value identifies a configured tenant.
+func (tnts *Tenants) IsTenant(tenant []byte) bool {
+	owner, present := tnts.Of(tenant)
+	return present && bytes.Equal(owner, tenant)
+}
+
+// TenantKey is a data key of a tenant.
+type TenantKey struct {
+	ID  string `json:"id"`
+	Key []byte `json:"key"`
+}
+
+// TenantKeys holds the data keys of a tenant, the last of which is
+// active and used for all encryptions. The other keys are retained
+// only for decrypting the values encrypted before the latest rotation.
+// Once the tenant is erased, its keys are discarded while retaining
+// the generation, so that erasures are distinguishable from tenants
+// that never had any keys.
+type TenantKeys struct {
+	// Generation is the number of data keys ever generated, from
+	// which the identifier of the next key is derived.
+	Generation uint32 `json:"generation"`
+	// Keys are the current data keys, the last of which is active.
+	Keys []TenantKey `json:"keys"`
+}
+
+// ParseTenantKeys parses the data keys of a tenant, previously
+// serialized using Marshal.
+func ParseTenantKeys(data []byte) (*TenantKeys, error) {
+	tk := &TenantKeys{}
+	if err := json.Unmarshal(data, tk); err != nil {
+		return nil, fmt.Errorf("unable to parse tenant keys: %v", err)
+	}
+	return tk, nil
+}
+
+// Marshal serializes the data keys for storage.
+func (tk *TenantKeys) Marshal() ([]byte, error) {
+	return json.Marshal(tk)
+}
+
+// AddKey generates a new data key, which becomes the active key.
+func (tk *TenantKeys) AddKey() error {
+	key, err := NewSubjectKey()
+	if err != nil {
+		return err
+	}
+	tk.Generation++
+	tk.Keys = append(tk.Keys, TenantKey{ID: strconv.FormatUint(uint64(tk.Generation), 10), Key: key})
+	return nil
+}
+
+// RetainKeys discards all but the latest given number of data keys.
+func (tk *TenantKeys) RetainKeys(num int) {
+	if len(tk.Keys) > num {
+		tk.Keys = append([]TenantKey(nil), tk.Keys[len(tk.Keys)-num:]...)
+	}
+}
+
+// Erase discards all the data keys.
+func (tk *TenantKeys) Erase() {
+	tk.Keys = nil
+}
+
+// IsErased checks if the data keys were discarded using Erase.
+func (tk *TenantKeys) IsErased() bool {
+	return tk != nil && tk.Generation > 0 && len(tk.Keys) == 0
+}
+
+// ActiveKeyID retrieves the identifier of the data key used for all
+// encryptions.
+func (tk *TenantKeys) ActiveKeyID() string {
+	if tk == nil || len(tk.Keys) == 0 {
+		return ""
+	}
+	return tk.Keys[len(tk.Keys)-1].ID
+}
+
+// Seal encrypts the given value using the active data key.
+func (tk *TenantKeys) Seal(value []byte) ([]byte, error) {
+	if tk == nil || len(tk.Keys) == 0 {
+		return nil, errors.New("tenant has no data key")
+	}
+	active := tk.Keys[len(tk.Keys)-1]
+	encValue, err := EncryptValue(active.Key, value)
+	if err != nil {
+		return nil, err
+	}
+	return envelope(tenantValuePrefix, active.ID, encValue), nil
+}
+
+// Open decrypts the given value previously encrypted using Seal.
+func (tk *TenantKeys) Open(value []byte) ([]byte, error) {
+	keyID, encValue, sealed := parseEnvelope(tenantValuePrefix, value)
+	if !sealed {
+		return nil, errors.New("value is not encrypted using a tenant key")
+	}
+	if tk != nil {
+		for _, dk := range tk.Keys {
+			if dk.ID == keyID {
+				return DecryptValue(dk.Key, encValue)
+			}
+		}
+	}
+	return nil, fmt.Errorf("tenant key: %s used for encrypting the value is not available", keyID)
+}
+
+// NeedsRotation checks if the given value is not sealed using the
+// active data key.
+func (tk *TenantKeys) NeedsRotation(value []byte) bool {
+	keyID, _, sealed := parseEnvelope(tenantValuePrefix, value)
+	return !sealed || keyID != tk.ActiveKeyID()
+}
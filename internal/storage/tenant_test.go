@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTenants(t *testing.T) {
+	tnts := NewTenants([]string{"acme:", "globex:"})
+	if tenant, present := tnts.Of([]byte("acme:user:1")); !present || string(tenant) != "acme:" {
+		t.Errorf("Expected acme: to own the key, but got %s", tenant)
+	}
+	if _, present := tnts.Of([]byte("initech:user:1")); present {
+		t.Error("Did not expect a tenant for a key outside the tenant prefixes")
+	}
+	if !tnts.IsTenant([]byte("globex:")) || tnts.IsTenant([]byte("globex:1")) {
+		t.Error("Expected only the prefix to identify the tenant")
+	}
+	if _, present := NewTenants(nil).Of([]byte("acme:user:1")); present {
+		t.Error("Did not expect any tenants without prefixes")
+	}
+}
+
+func TestTenantKeys(t *testing.T) {
+	tk := &TenantKeys{}
+	if _, err := tk.Seal([]byte("value")); err == nil {
+		t.Error("Expected sealing to fail without any data key")
+	}
+	if err := tk.AddKey(); err != nil {
+		t.Fatal(err)
+	}
+	value := []byte("tenant value")
+	sealed1, err := tk.Seal(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsTenantSealed(sealed1) || bytes.Contains(sealed1, value) || tk.NeedsRotation(sealed1) {
+		t.Errorf("Expected the value to be sealed using the active data key. Actual: %q", sealed1)
+	}
+	if !tk.NeedsRotation(value) {
+		t.Error("Expected values not sealed using a data key to need rotation")
+	}
+
+	// Keys survive serialization and older keys remain usable till retired
+	data, err := tk.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tk, err = ParseTenantKeys(data); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if err = tk.AddKey(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if tk.ActiveKeyID() != "3" || !tk.NeedsRotation(sealed1) {
+		t.Errorf("Expected 3 to be the active data key. Actual: %s", tk.ActiveKeyID())
+	}
+	if opened, err := tk.Open(sealed1); err != nil || !bytes.Equal(opened, value) {
+		t.Errorf("Expected the value sealed using an older key to be opened. Actual: %s, Error: %v", opened, err)
+	}
+	tk.RetainKeys(2)
+	if _, err = tk.Open(sealed1); err == nil {
+		t.Error("Expected values sealed using a retired key to be unreadable")
+	}
+
+	tk.Erase()
+	if !tk.IsErased() || tk.Generation != 3 {
+		t.Errorf("Expected the keys to be erased retaining their generation. Actual: %+v", tk)
+	}
+	if (*TenantKeys)(nil).IsErased() {
+		t.Error("Did not expect tenants without any keys to be erased")
+	}
+}
@@ -0,0 +1,84 @@
+// Package streamlimit caps the number of streaming calls, such as
+// iterations, checksum scans and health watches, concurrently served by
+// a DKV node, so that a misconfigured fleet of consumers opening
+// thousands of streams cannot destabilize the node. Calls beyond the cap
+// are rejected with ResourceExhausted, for the clients to retry later.
+package streamlimit
+
+import (
+	"strings"
+	"sync/atomic"
+
+	"github.com/flipkart-incubator/dkv/internal/stats"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MinStreamWindowBytes is the smallest flow control window of a stream
+// accepted by GRPC.
+const MinStreamWindowBytes = 65535
+
+// Services whose streams are never rejected, since the slaves retrieving
+// the changes and the checkpoints of their master are few and must not be
+// starved by the consumers
+var exemptServices = []string{"/dkv.serverpb.DKVReplication/"}
+
+// Limiter tracks the streaming calls being served, rejecting the ones
+// beyond its cap. It is safe for concurrent use.
+type Limiter struct {
+	maxStreams int64
+	active     int64
+	statsCli   stats.Client
+}
+
+// New creates a Limiter capping the concurrent streaming calls to the
+// given number, zero leaving them unbounded.
+func New(maxStreams uint32, statsCli stats.Client) *Limiter {
+	return &Limiter{maxStreams: int64(maxStreams), statsCli: statsCli}
+}
+
+// Active retrieves the number of streaming calls being served.
+func (l *Limiter) Active() int64 {
+	return atomic.LoadInt64(&l.active)
+}
+
+// acquire admits a streaming call unless the cap is reached.
+func (l *Limiter) acquire() bool {
+	active := atomic.AddInt64(&l.active, 1)
+	if l.maxStreams > 0 && active > l.maxStreams {
+		atomic.AddInt64(&l.active, -1)
+		l.statsCli.Incr("grpc.streams.rejected", 1)
+		return false
+	}
+	l.statsCli.Gauge("grpc.streams.active", active)
+	return true
+}
+
+func (l *Limiter) release() {
+	l.statsCli.Gauge("grpc.streams.active", atomic.AddInt64(&l.active, -1))
+}
+
+// StreamServerInterceptor rejects the streaming calls made once the cap of
+// the given limiter is reached, except for the replication streams.
+func (l *Limiter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if exempt(info.FullMethod) {
+			return handler(srv, ss)
+		}
+		if !l.acquire() {
+			return status.Errorf(codes.ResourceExhausted, "node is serving the maximum of %d concurrent streams, retry later", l.maxStreams)
+		}
+		defer l.release()
+		return handler(srv, ss)
+	}
+}
+
+func exempt(fullMethod string) bool {
+	for _, svc := range exemptServices {
+		if strings.HasPrefix(fullMethod, svc) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package streamlimit
+
+import (
+	"testing"
+
+	"github.com/flipkart-incubator/dkv/internal/stats"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStreamServerInterceptor(t *testing.T) {
+	l := New(1, stats.NewNoOpClient())
+	intercept := l.StreamServerInterceptor()
+	iterate := &grpc.StreamServerInfo{FullMethod: "/dkv.serverpb.DKV/Iterate"}
+	streamChanges := &grpc.StreamServerInfo{FullMethod: "/dkv.serverpb.DKVReplication/StreamChanges"}
+
+	// Streams beyond the cap are rejected while the first one is served
+	err := intercept(nil, nil, iterate, func(interface{}, grpc.ServerStream) error {
+		if err := intercept(nil, nil, iterate, func(interface{}, grpc.ServerStream) error { return nil }); status.Code(err) != codes.ResourceExhausted {
+			t.Errorf("Expected the stream beyond the cap to be rejected. Error: %v", err)
+		}
+		if err := intercept(nil, nil, streamChanges, func(interface{}, grpc.ServerStream) error { return nil }); err != nil {
+			t.Errorf("Expected the replication stream to be admitted. Error: %v", err)
+		}
+		if active := l.Active(); active != 1 {
+			t.Errorf("Expected a single active stream, but got %d", active)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if active := l.Active(); active != 0 {
+		t.Errorf("Expected no active streams once served, but got %d", active)
+	}
+	if err = intercept(nil, nil, iterate, func(interface{}, grpc.ServerStream) error { return nil }); err != nil {
+		t.Errorf("Expected the stream to be admitted once the others are served. Error: %v", err)
+	}
+}
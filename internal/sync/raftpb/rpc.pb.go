@@ -26,12 +26,17 @@ type InternalRaftRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Put      *serverpb.PutRequest           `protobuf:"bytes,10,opt,name=put,proto3" json:"put,omitempty"`
-	Get      *serverpb.GetRequest           `protobuf:"bytes,11,opt,name=get,proto3" json:"get,omitempty"`
-	MultiGet *serverpb.MultiGetRequest      `protobuf:"bytes,12,opt,name=multi_get,json=multiGet,proto3" json:"multi_get,omitempty"`
-	Delete   *serverpb.DeleteRequest        `protobuf:"bytes,13,opt,name=delete,proto3" json:"delete,omitempty"`
-	Cas      *serverpb.CompareAndSetRequest `protobuf:"bytes,14,opt,name=cas,proto3" json:"cas,omitempty"`
-	MultiPut *serverpb.MultiPutRequest      `protobuf:"bytes,15,opt,name=multi_put,json=multiPut,proto3" json:"multi_put,omitempty"`
+	Put            *serverpb.PutRequest           `protobuf:"bytes,10,opt,name=put,proto3" json:"put,omitempty"`
+	Get            *serverpb.GetRequest           `protobuf:"bytes,11,opt,name=get,proto3" json:"get,omitempty"`
+	MultiGet       *serverpb.MultiGetRequest      `protobuf:"bytes,12,opt,name=multi_get,json=multiGet,proto3" json:"multi_get,omitempty"`
+	Delete         *serverpb.DeleteRequest        `protobuf:"bytes,13,opt,name=delete,proto3" json:"delete,omitempty"`
+	Cas            *serverpb.CompareAndSetRequest `protobuf:"bytes,14,opt,name=cas,proto3" json:"cas,omitempty"`
+	MultiPut       *serverpb.MultiPutRequest      `protobuf:"bytes,15,opt,name=multi_put,json=multiPut,proto3" json:"multi_put,omitempty"`
+	Merge          *serverpb.MergeRequest         `protobuf:"bytes,16,opt,name=merge,proto3" json:"merge,omitempty"`
+	Transact       *serverpb.TransactRequest      `protobuf:"bytes,17,opt,name=transact,proto3" json:"transact,omitempty"`
+	PlaceLegalHold *serverpb.LegalHoldRequest     `protobuf:"bytes,18,opt,name=place_legal_hold,json=placeLegalHold,proto3" json:"place_legal_hold,omitempty"`
+	LiftLegalHold  *serverpb.LegalHoldRequest     `protobuf:"bytes,19,opt,name=lift_legal_hold,json=liftLegalHold,proto3" json:"lift_legal_hold,omitempty"`
+	Quarantine     *serverpb.QuarantinedRecord    `protobuf:"bytes,20,opt,name=quarantine,proto3" json:"quarantine,omitempty"`
 }
 
 func (x *InternalRaftRequest) Reset() {
@@ -108,6 +113,41 @@ func (x *InternalRaftRequest) GetMultiPut() *serverpb.MultiPutRequest {
 	return nil
 }
 
+func (x *InternalRaftRequest) GetMerge() *serverpb.MergeRequest {
+	if x != nil {
+		return x.Merge
+	}
+	return nil
+}
+
+func (x *InternalRaftRequest) GetTransact() *serverpb.TransactRequest {
+	if x != nil {
+		return x.Transact
+	}
+	return nil
+}
+
+func (x *InternalRaftRequest) GetPlaceLegalHold() *serverpb.LegalHoldRequest {
+	if x != nil {
+		return x.PlaceLegalHold
+	}
+	return nil
+}
+
+func (x *InternalRaftRequest) GetLiftLegalHold() *serverpb.LegalHoldRequest {
+	if x != nil {
+		return x.LiftLegalHold
+	}
+	return nil
+}
+
+func (x *InternalRaftRequest) GetQuarantine() *serverpb.QuarantinedRecord {
+	if x != nil {
+		return x.Quarantine
+	}
+	return nil
+}
+
 var File_internal_sync_raftpb_rpc_proto protoreflect.FileDescriptor
 
 var file_internal_sync_raftpb_rpc_proto_rawDesc = []byte{
@@ -115,32 +155,54 @@ var file_internal_sync_raftpb_rpc_proto_rawDesc = []byte{
 	0x72, 0x61, 0x66, 0x74, 0x70, 0x62, 0x2f, 0x72, 0x70, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 	0x12, 0x0a, 0x64, 0x6b, 0x76, 0x2e, 0x72, 0x61, 0x66, 0x74, 0x70, 0x62, 0x1a, 0x16, 0x70, 0x6b,
 	0x67, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2f, 0x61, 0x70, 0x69, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x22, 0xd0, 0x02, 0x0a, 0x13, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61,
-	0x6c, 0x52, 0x61, 0x66, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x03,
-	0x70, 0x75, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x64, 0x6b, 0x76, 0x2e,
-	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x50, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x52, 0x03, 0x70, 0x75, 0x74, 0x12, 0x2a, 0x0a, 0x03, 0x67, 0x65, 0x74, 0x18,
-	0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76,
-	0x65, 0x72, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52,
-	0x03, 0x67, 0x65, 0x74, 0x12, 0x3a, 0x0a, 0x09, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x5f, 0x67, 0x65,
-	0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x47, 0x65, 0x74, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x08, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x47, 0x65, 0x74,
-	0x12, 0x33, 0x0a, 0x06, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x1b, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e,
-	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x06, 0x64,
-	0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x34, 0x0a, 0x03, 0x63, 0x61, 0x73, 0x18, 0x0e, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x22, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70,
-	0x62, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x41, 0x6e, 0x64, 0x53, 0x65, 0x74, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x03, 0x63, 0x61, 0x73, 0x12, 0x3a, 0x0a, 0x09, 0x6d,
-	0x75, 0x6c, 0x74, 0x69, 0x5f, 0x70, 0x75, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d,
-	0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x4d, 0x75,
-	0x6c, 0x74, 0x69, 0x50, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x08, 0x6d,
-	0x75, 0x6c, 0x74, 0x69, 0x50, 0x75, 0x74, 0x42, 0x3f, 0x5a, 0x3d, 0x67, 0x69, 0x74, 0x68, 0x75,
-	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x66, 0x6c, 0x69, 0x70, 0x6b, 0x61, 0x72, 0x74, 0x2d, 0x69,
-	0x6e, 0x63, 0x75, 0x62, 0x61, 0x74, 0x6f, 0x72, 0x2f, 0x64, 0x6b, 0x76, 0x2f, 0x69, 0x6e, 0x74,
-	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x73, 0x79, 0x6e,
-	0x63, 0x2f, 0x72, 0x61, 0x66, 0x74, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x18, 0x70, 0x6b, 0x67, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x70, 0x62, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x90,
+	0x05, 0x0a, 0x13, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x52, 0x61, 0x66, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x03, 0x70, 0x75, 0x74, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x70, 0x62, 0x2e, 0x50, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x03, 0x70,
+	0x75, 0x74, 0x12, 0x2a, 0x0a, 0x03, 0x67, 0x65, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x18, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x47,
+	0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x03, 0x67, 0x65, 0x74, 0x12, 0x3a,
+	0x0a, 0x09, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x5f, 0x67, 0x65, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1d, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62,
+	0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x52, 0x08, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x47, 0x65, 0x74, 0x12, 0x33, 0x0a, 0x06, 0x64, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x64, 0x6b, 0x76,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x06, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12,
+	0x34, 0x0a, 0x03, 0x63, 0x61, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x64,
+	0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6d, 0x70,
+	0x61, 0x72, 0x65, 0x41, 0x6e, 0x64, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x52, 0x03, 0x63, 0x61, 0x73, 0x12, 0x3a, 0x0a, 0x09, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x5f, 0x70,
+	0x75, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x50, 0x75, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x08, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x50, 0x75,
+	0x74, 0x12, 0x30, 0x0a, 0x05, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e,
+	0x4d, 0x65, 0x72, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x05, 0x6d, 0x65,
+	0x72, 0x67, 0x65, 0x12, 0x39, 0x0a, 0x08, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x18,
+	0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x70, 0x62, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x52, 0x08, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x12, 0x48,
+	0x0a, 0x10, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x5f, 0x6c, 0x65, 0x67, 0x61, 0x6c, 0x5f, 0x68, 0x6f,
+	0x6c, 0x64, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x4c, 0x65, 0x67, 0x61, 0x6c, 0x48, 0x6f, 0x6c,
+	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x0e, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x4c,
+	0x65, 0x67, 0x61, 0x6c, 0x48, 0x6f, 0x6c, 0x64, 0x12, 0x46, 0x0a, 0x0f, 0x6c, 0x69, 0x66, 0x74,
+	0x5f, 0x6c, 0x65, 0x67, 0x61, 0x6c, 0x5f, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x13, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1e, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62,
+	0x2e, 0x4c, 0x65, 0x67, 0x61, 0x6c, 0x48, 0x6f, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x52, 0x0d, 0x6c, 0x69, 0x66, 0x74, 0x4c, 0x65, 0x67, 0x61, 0x6c, 0x48, 0x6f, 0x6c, 0x64,
+	0x12, 0x3f, 0x0a, 0x0a, 0x71, 0x75, 0x61, 0x72, 0x61, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x18, 0x14,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x70, 0x62, 0x2e, 0x51, 0x75, 0x61, 0x72, 0x61, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x64, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x0a, 0x71, 0x75, 0x61, 0x72, 0x61, 0x6e, 0x74, 0x69, 0x6e,
+	0x65, 0x42, 0x3f, 0x5a, 0x3d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x66, 0x6c, 0x69, 0x70, 0x6b, 0x61, 0x72, 0x74, 0x2d, 0x69, 0x6e, 0x63, 0x75, 0x62, 0x61, 0x74,
+	0x6f, 0x72, 0x2f, 0x64, 0x6b, 0x76, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x73, 0x79, 0x6e, 0x63, 0x2f, 0x72, 0x61, 0x66, 0x74,
+	0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -164,19 +226,28 @@ var file_internal_sync_raftpb_rpc_proto_goTypes = []interface{}{
 	(*serverpb.DeleteRequest)(nil),        // 4: dkv.serverpb.DeleteRequest
 	(*serverpb.CompareAndSetRequest)(nil), // 5: dkv.serverpb.CompareAndSetRequest
 	(*serverpb.MultiPutRequest)(nil),      // 6: dkv.serverpb.MultiPutRequest
+	(*serverpb.MergeRequest)(nil),         // 7: dkv.serverpb.MergeRequest
+	(*serverpb.TransactRequest)(nil),      // 8: dkv.serverpb.TransactRequest
+	(*serverpb.LegalHoldRequest)(nil),     // 9: dkv.serverpb.LegalHoldRequest
+	(*serverpb.QuarantinedRecord)(nil),    // 10: dkv.serverpb.QuarantinedRecord
 }
 var file_internal_sync_raftpb_rpc_proto_depIdxs = []int32{
-	1, // 0: dkv.raftpb.InternalRaftRequest.put:type_name -> dkv.serverpb.PutRequest
-	2, // 1: dkv.raftpb.InternalRaftRequest.get:type_name -> dkv.serverpb.GetRequest
-	3, // 2: dkv.raftpb.InternalRaftRequest.multi_get:type_name -> dkv.serverpb.MultiGetRequest
-	4, // 3: dkv.raftpb.InternalRaftRequest.delete:type_name -> dkv.serverpb.DeleteRequest
-	5, // 4: dkv.raftpb.InternalRaftRequest.cas:type_name -> dkv.serverpb.CompareAndSetRequest
-	6, // 5: dkv.raftpb.InternalRaftRequest.multi_put:type_name -> dkv.serverpb.MultiPutRequest
-	6, // [6:6] is the sub-list for method output_type
-	6, // [6:6] is the sub-list for method input_type
-	6, // [6:6] is the sub-list for extension type_name
-	6, // [6:6] is the sub-list for extension extendee
-	0, // [0:6] is the sub-list for field type_name
+	1,  // 0: dkv.raftpb.InternalRaftRequest.put:type_name -> dkv.serverpb.PutRequest
+	2,  // 1: dkv.raftpb.InternalRaftRequest.get:type_name -> dkv.serverpb.GetRequest
+	3,  // 2: dkv.raftpb.InternalRaftRequest.multi_get:type_name -> dkv.serverpb.MultiGetRequest
+	4,  // 3: dkv.raftpb.InternalRaftRequest.delete:type_name -> dkv.serverpb.DeleteRequest
+	5,  // 4: dkv.raftpb.InternalRaftRequest.cas:type_name -> dkv.serverpb.CompareAndSetRequest
+	6,  // 5: dkv.raftpb.InternalRaftRequest.multi_put:type_name -> dkv.serverpb.MultiPutRequest
+	7,  // 6: dkv.raftpb.InternalRaftRequest.merge:type_name -> dkv.serverpb.MergeRequest
+	8,  // 7: dkv.raftpb.InternalRaftRequest.transact:type_name -> dkv.serverpb.TransactRequest
+	9,  // 8: dkv.raftpb.InternalRaftRequest.place_legal_hold:type_name -> dkv.serverpb.LegalHoldRequest
+	9,  // 9: dkv.raftpb.InternalRaftRequest.lift_legal_hold:type_name -> dkv.serverpb.LegalHoldRequest
+	10, // 10: dkv.raftpb.InternalRaftRequest.quarantine:type_name -> dkv.serverpb.QuarantinedRecord
+	11, // [11:11] is the sub-list for method output_type
+	11, // [11:11] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_internal_sync_raftpb_rpc_proto_init() }
@@ -38,6 +38,16 @@ func (dr *dkvReplStore) Save(_ db.RaftEntry, req []byte) ([]byte, error) {
 		return dr.delete(intReq.Delete)
 	case intReq.Cas != nil:
 		return dr.cas(intReq.Cas)
+	case intReq.Merge != nil:
+		return dr.merge(intReq.Merge)
+	case intReq.Transact != nil:
+		return dr.transact(intReq.Transact)
+	case intReq.PlaceLegalHold != nil:
+		return dr.updateLegalHold(intReq.PlaceLegalHold, storage.LegalHolder.PlaceLegalHold)
+	case intReq.LiftLegalHold != nil:
+		return dr.updateLegalHold(intReq.LiftLegalHold, storage.LegalHolder.LiftLegalHold)
+	case intReq.Quarantine != nil:
+		return dr.quarantine(intReq.Quarantine)
 	default:
 		return nil, errors.New("Unknown Save request in dkv")
 	}
@@ -59,7 +69,8 @@ func (dr *dkvReplStore) Load(req []byte) ([]byte, error) {
 }
 
 func (dr *dkvReplStore) put(putReq *serverpb.PutRequest) ([]byte, error) {
-	err := dr.kvs.Put(&serverpb.KVPair{Key: putReq.Key, Value: putReq.Value, ExpireTS: putReq.ExpireTS})
+	kv := &serverpb.KVPair{Key: putReq.Key, Value: putReq.Value, ExpireTS: putReq.ExpireTS}
+	_, err := storage.PutWithWALSync(dr.kvs, putReq.WalSync, kv)
 	return nil, err
 }
 
@@ -68,10 +79,26 @@ func (dr *dkvReplStore) multiPut(multiPutReq *serverpb.MultiPutRequest) ([]byte,
 	for i, request := range multiPutReq.PutRequest {
 		puts[i] = &serverpb.KVPair{Key: request.Key, Value: request.Value, ExpireTS: request.ExpireTS}
 	}
-	err := dr.kvs.Put(puts...)
+	_, err := storage.PutWithWALSync(dr.kvs, storage.MultiPutWALSync(multiPutReq.PutRequest), puts...)
 	return nil, err
 }
 
+func (dr *dkvReplStore) transact(txnReq *serverpb.TransactRequest) ([]byte, error) {
+	txr, ok := dr.kvs.(storage.Transactor)
+	if !ok {
+		return nil, errors.New("Current storage engine does not support transactions")
+	}
+	// Conflicts are reported as a result, being identical on all the replicas
+	switch err := txr.Transact(txnReq.Reads, txnReq.Writes); err {
+	case nil:
+		return []byte{0}, nil
+	case storage.ErrTxnConflict:
+		return []byte{1}, nil
+	default:
+		return nil, err
+	}
+}
+
 func (dr *dkvReplStore) cas(casReq *serverpb.CompareAndSetRequest) ([]byte, error) {
 	res, err := dr.kvs.CompareAndSet(casReq.Key, casReq.OldValue, casReq.NewValue)
 	succ, fail := []byte{0}, []byte{1}
@@ -81,13 +108,43 @@ func (dr *dkvReplStore) cas(casReq *serverpb.CompareAndSetRequest) ([]byte, erro
 	return fail, err
 }
 
+func (dr *dkvReplStore) merge(mergeReq *serverpb.MergeRequest) ([]byte, error) {
+	mrgr, ok := dr.kvs.(storage.Merger)
+	if !ok {
+		return nil, errors.New("Current storage engine does not support merges")
+	}
+	err := mrgr.Merge(mergeReq.Key, mergeReq.Operand)
+	return nil, err
+}
+
+// updateLegalHold places or lifts a legal hold, which is part of the
+// replicated state so that every replica rejects the same deletes.
+func (dr *dkvReplStore) updateLegalHold(lhReq *serverpb.LegalHoldRequest, update func(storage.LegalHolder, []byte) error) ([]byte, error) {
+	lh, ok := dr.kvs.(storage.LegalHolder)
+	if !ok {
+		return nil, errors.New("Current storage engine does not support legal holds")
+	}
+	return nil, update(lh, lhReq.KeyPrefix)
+}
+
+// quarantine sets the corrupt entry found by the scrubber of a replica
+// aside on every replica, so that they keep serving the same keys.
+func (dr *dkvReplStore) quarantine(rec *serverpb.QuarantinedRecord) ([]byte, error) {
+	q, ok := dr.kvs.(storage.Quarantiner)
+	if !ok {
+		return nil, errors.New("Current storage engine does not support quarantining records")
+	}
+	_, err := q.Quarantine(rec)
+	return nil, err
+}
+
 func (dr *dkvReplStore) delete(delReq *serverpb.DeleteRequest) ([]byte, error) {
 	err := dr.kvs.Delete(delReq.Key)
 	return nil, err
 }
 
 func (dr *dkvReplStore) get(getReq *serverpb.GetRequest) ([]byte, error) {
-	vals, err := dr.kvs.Get(getReq.Key)
+	vals, err := storage.GetWithOptions(dr.kvs, getReq.ReadOptions, getReq.Key)
 	if err != nil {
 		return nil, err
 	}
@@ -95,7 +152,9 @@ func (dr *dkvReplStore) get(getReq *serverpb.GetRequest) ([]byte, error) {
 }
 
 func (dr *dkvReplStore) multiGet(multiGetReq *serverpb.MultiGetRequest) ([]byte, error) {
-	vals, err := dr.kvs.Get(multiGetReq.Keys...)
+	vals, err := storage.MultiGet(func(keys ...[]byte) ([]*serverpb.KVPair, error) {
+		return storage.GetWithOptions(dr.kvs, multiGetReq.ReadOptions, keys...)
+	}, multiGetReq.Keys...)
 	if err != nil {
 		return nil, err
 	}
@@ -228,3 +228,7 @@ func (ms *memStore) PutSnapshot(snap io.ReadCloser) error {
 func (ms *memStore) Iterate(iterOpts storage.IterationOptions) storage.Iterator {
 	return nil
 }
+
+func (ms *memStore) Stats() (*storage.EngineStats, error) {
+	return &storage.EngineStats{}, nil
+}
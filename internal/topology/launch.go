@@ -0,0 +1,222 @@
+package topology
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"text/template"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/pkg/ctl"
+)
+
+const (
+	// Image of DKV built from the Dockerfile at the root of this repo.
+	DefaultImage = "dkv/dkv-deb9-amd64:latest"
+	// File within the work folder describing the containerized topology.
+	composeFile = "docker-compose.yaml"
+	// Duration for which the processes are allowed to exit gracefully.
+	stopTimeout = 10 * time.Second
+)
+
+var composeTemplate = template.Must(template.New("compose").Parse(`# Generated by dkvtopo for the topology: {{.Topology.Spec.Name}}
+version: "3"
+services:
+{{- range .Nodes}}
+  {{.Node.Name}}:
+    image: {{$.Image}}
+    container_name: {{.Node.Name}}
+    hostname: {{.Node.Name}}
+    working_dir: {{$.Topology.ConfigDir}}
+    command:
+      - dkvsrv
+{{- range .Args}}
+      - "{{.}}"
+{{- end}}
+    ports:
+      - "{{.Node.Port}}:{{.Node.Port}}"
+    volumes:
+      - "{{$.ConfigDir}}:{{$.Topology.ConfigDir}}:ro"
+      - "{{$.WorkDir}}:` + containerWorkDir + `"
+{{- if .Node.Master}}
+    depends_on:
+      - {{.Node.Master.Name}}
+{{- else if ne .Node.Role "discovery"}}
+    depends_on:
+      - {{$.Topology.Discovery.Name}}
+{{- end}}
+{{- end}}
+`))
+
+// WriteFiles writes the files generated for the topology into its work
+// folder, which are the configuration of the discovery service and for
+// containerized topologies, the docker compose file using the given image.
+// Returns the path of the compose file, if written.
+func WriteFiles(t *Topology, image string) (string, error) {
+	if err := os.MkdirAll(t.Spec.WorkDir, 0755); err != nil {
+		return "", err
+	}
+	iniFile := filepath.Join(t.Spec.WorkDir, discoveryConfigFile)
+	if err := ioutil.WriteFile(iniFile, []byte(t.discoveryINI()), 0644); err != nil {
+		return "", err
+	}
+	if !t.Spec.Containerized {
+		return "", nil
+	}
+
+	type service struct {
+		Node *Node
+		Args []string
+	}
+	data := struct {
+		Topology           *Topology
+		Image              string
+		ConfigDir, WorkDir string
+		Nodes              []service
+	}{Topology: t, Image: image, ConfigDir: filepath.Dir(t.Spec.BaseConfig), WorkDir: t.Spec.WorkDir}
+	if data.Image == "" {
+		data.Image = DefaultImage
+	}
+	for _, node := range t.Nodes() {
+		data.Nodes = append(data.Nodes, service{Node: node, Args: t.Args(node)})
+	}
+	var sb strings.Builder
+	if err := composeTemplate.Execute(&sb, data); err != nil {
+		return "", err
+	}
+	file := filepath.Join(t.Spec.WorkDir, composeFile)
+	return file, ioutil.WriteFile(file, []byte(sb.String()), 0644)
+}
+
+// A Cluster is a launched topology.
+type Cluster interface {
+	// Stop stops all the nodes of the topology.
+	Stop() error
+}
+
+// processes is a topology launched as local processes.
+type processes struct {
+	cmds []*exec.Cmd
+}
+
+// LaunchProcesses launches every node of the given topology as a local
+// process of the given dkvsrv binary, whose output is written into a log
+// file within the work folder of the topology.
+func LaunchProcesses(t *Topology, binary string) (Cluster, error) {
+	if t.Spec.Containerized {
+		return nil, errors.New("containerized topology can not be launched as local processes")
+	}
+	if _, err := WriteFiles(t, ""); err != nil {
+		return nil, err
+	}
+	procs := &processes{}
+	for _, node := range t.Nodes() {
+		logFile, err := os.Create(filepath.Join(t.Spec.WorkDir, node.Name+".log"))
+		if err != nil {
+			procs.Stop()
+			return nil, err
+		}
+		cmd := exec.Command(binary, t.Args(node)...)
+		cmd.Dir, cmd.Stdout, cmd.Stderr = t.ConfigDir(), logFile, logFile
+		err = cmd.Start()
+		logFile.Close()
+		if err != nil {
+			procs.Stop()
+			return nil, fmt.Errorf("unable to launch node: %s, error: %v", node.Name, err)
+		}
+		procs.cmds = append(procs.cmds, cmd)
+	}
+	return procs, nil
+}
+
+// Stop terminates the processes in the reverse order of their launch,
+// killing the ones that do not exit in time.
+func (p *processes) Stop() error {
+	var errs []string
+	for i := len(p.cmds) - 1; i >= 0; i-- {
+		cmd := p.cmds[i]
+		_ = cmd.Process.Signal(syscall.SIGTERM)
+		exited := make(chan struct{})
+		go func() {
+			_ = cmd.Wait()
+			close(exited)
+		}()
+		select {
+		case <-exited:
+		case <-time.After(stopTimeout):
+			if err := cmd.Process.Kill(); err != nil {
+				errs = append(errs, err.Error())
+			}
+			<-exited
+		}
+	}
+	p.cmds = nil
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// containers is a topology launched as containers using docker compose.
+type containers struct {
+	project, composeFile string
+}
+
+// LaunchContainers launches every node of the given topology as a
+// container of the given image using docker compose.
+func LaunchContainers(t *Topology, image string) (Cluster, error) {
+	if !t.Spec.Containerized {
+		return nil, errors.New("topology is not containerized")
+	}
+	file, err := WriteFiles(t, image)
+	if err != nil {
+		return nil, err
+	}
+	ctrs := &containers{project: t.Spec.Name, composeFile: file}
+	if err = ctrs.compose("up", "--detach"); err != nil {
+		return nil, err
+	}
+	return ctrs, nil
+}
+
+// Stop stops and removes the containers.
+func (c *containers) Stop() error {
+	return c.compose("down", "--timeout", fmt.Sprint(int(stopTimeout.Seconds())))
+}
+
+func (c *containers) compose(args ...string) error {
+	args = append([]string{"compose", "--project-name", c.project, "--file", c.composeFile}, args...)
+	out, err := exec.Command("docker", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("docker %s failed: %v, output: %s", strings.Join(args, " "), err, out)
+	}
+	return nil
+}
+
+// AwaitReady waits until every node of the given topology accepts the
+// connections of clients, or the given timeout elapses.
+func AwaitReady(t *Topology, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for _, node := range t.Nodes() {
+		for {
+			cli, err := ctl.NewInSecureDKVClient(node.ClientAddr(), "")
+			if err == nil {
+				_, err = cli.GetEffectiveConfig()
+				cli.Close()
+			}
+			if err == nil {
+				break
+			}
+			if time.Now().After(deadline) {
+				return fmt.Errorf("node: %s is not ready, error: %v", node.Name, err)
+			}
+			time.Sleep(time.Second)
+		}
+	}
+	return nil
+}
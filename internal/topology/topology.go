@@ -0,0 +1,247 @@
+// Package topology generates and launches multi-node DKV topologies for
+// testing the distributed features end to end. A topology consists of a
+// discovery node along with one or more shards, each of which comprises
+// a master and its slaves, optionally spread across multiple regions.
+// Topologies are launched either as local processes or as containers
+// using docker compose, after which a workload can be run against them
+// and the convergence of the slaves with their masters asserted.
+package topology
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Role of a DKV node, as given to dkvsrv.
+type Role string
+
+const (
+	// Discovery nodes serve the discovery service along with being a
+	// master of their own database.
+	Discovery Role = "discovery"
+	Master    Role = "master"
+	Slave     Role = "slave"
+)
+
+const (
+	discoveryDatabase = "discovery"
+	defaultRegion     = "default"
+	defaultBasePort   = 9080
+	defaultEngine     = "rocksdb"
+	// Interval at which the slaves poll their masters for changes, kept
+	// short so that the topologies converge quickly
+	replPollInterval = "100ms"
+	// Size of the block cache of every node, kept small since all the
+	// nodes of a topology typically share the same machine
+	blockCacheSize = 64 << 20
+
+	// Folders onto which the base configuration and the work folder are
+	// mounted within the containers
+	containerConfigDir = "/etc/dkv"
+	containerWorkDir   = "/dkv"
+	// File within the work folder configuring the discovery service
+	discoveryConfigFile = "discovery.ini"
+)
+
+// Spec describes a topology to be generated.
+type Spec struct {
+	// Name of the topology, which prefixes the names of its nodes.
+	Name string
+	// Number of shards, each with a master of its own database.
+	Shards int
+	// Number of slaves of every master.
+	SlavesPerShard int
+	// Regions across which the slaves of every master are spread in a
+	// round robin manner. Masters and the discovery node are placed in
+	// the first region.
+	Regions []string
+	// Port of the first node, with the other nodes using the subsequent
+	// ports.
+	BasePort int
+	// Storage engine of all the nodes - rocksdb|badger.
+	Engine string
+	// Configuration file of dkvsrv from which all the nodes start, with
+	// the node specific settings overridden through flags. Relative
+	// paths within it are resolved against its folder.
+	BaseConfig string
+	// Folder under which the data folders of the nodes along with the
+	// generated files are placed.
+	WorkDir string
+	// Containerized topologies address the nodes by their names instead
+	// of the loopback address.
+	Containerized bool
+}
+
+// Node is a DKV node of a topology.
+type Node struct {
+	Name     string
+	Role     Role
+	Region   string
+	Database string
+	VBucket  string
+	Port     int
+	// Host through which the other nodes reach this node.
+	Host string
+	// Master of a slave.
+	Master *Node
+	// Folder holding the data of this node, as seen by the node.
+	DBFolder string
+}
+
+// Addr is the address through which the other nodes reach this node.
+func (n *Node) Addr() string {
+	return fmt.Sprintf("%s:%d", n.Host, n.Port)
+}
+
+// ClientAddr is the address through which the clients running on the
+// launching machine reach this node.
+func (n *Node) ClientAddr() string {
+	return fmt.Sprintf("127.0.0.1:%d", n.Port)
+}
+
+// Shard is a master along with its slaves.
+type Shard struct {
+	Master *Node
+	Slaves []*Node
+}
+
+// Topology is a generated topology.
+type Topology struct {
+	Spec      Spec
+	Discovery *Node
+	Shards    []*Shard
+}
+
+// Nodes retrieves all the nodes of the topology, in the order in which
+// they must be launched.
+func (t *Topology) Nodes() []*Node {
+	nodes := []*Node{t.Discovery}
+	for _, shard := range t.Shards {
+		nodes = append(nodes, shard.Master)
+	}
+	for _, shard := range t.Shards {
+		nodes = append(nodes, shard.Slaves...)
+	}
+	return nodes
+}
+
+// Generate generates the topology described by the given spec.
+func Generate(spec Spec) (*Topology, error) {
+	switch {
+	case spec.Name == "":
+		return nil, errors.New("name of the topology must be given")
+	case spec.Shards <= 0:
+		return nil, errors.New("topology must have at least one shard")
+	case spec.SlavesPerShard < 0:
+		return nil, errors.New("number of slaves per shard must not be negative")
+	case spec.BaseConfig == "":
+		return nil, errors.New("base configuration of the nodes must be given")
+	case spec.WorkDir == "":
+		return nil, errors.New("work folder of the topology must be given")
+	}
+	if len(spec.Regions) == 0 {
+		spec.Regions = []string{defaultRegion}
+	}
+	if spec.BasePort == 0 {
+		spec.BasePort = defaultBasePort
+	}
+	if spec.Engine == "" {
+		spec.Engine = defaultEngine
+	}
+	var err error
+	if spec.BaseConfig, err = filepath.Abs(spec.BaseConfig); err != nil {
+		return nil, err
+	}
+	if spec.WorkDir, err = filepath.Abs(spec.WorkDir); err != nil {
+		return nil, err
+	}
+
+	t := &Topology{Spec: spec}
+	port := spec.BasePort
+	newNode := func(name string, role Role, region, database string) *Node {
+		node := &Node{Name: fmt.Sprintf("%s-%s", spec.Name, name), Role: role, Region: region,
+			Database: database, VBucket: database, Port: port}
+		node.Host, node.DBFolder = "127.0.0.1", filepath.Join(spec.WorkDir, node.Name)
+		if spec.Containerized {
+			node.Host, node.DBFolder = node.Name, filepath.Join(containerWorkDir, node.Name)
+		}
+		port++
+		return node
+	}
+
+	t.Discovery = newNode("discovery", Discovery, spec.Regions[0], discoveryDatabase)
+	for i := 0; i < spec.Shards; i++ {
+		database := "shard" + strconv.Itoa(i)
+		t.Shards = append(t.Shards, &Shard{Master: newNode(database+"-master", Master, spec.Regions[0], database)})
+	}
+	for i, shard := range t.Shards {
+		for j := 0; j < spec.SlavesPerShard; j++ {
+			region := spec.Regions[j%len(spec.Regions)]
+			slave := newNode(fmt.Sprintf("shard%d-slave%d", i, j), Slave, region, shard.Master.Database)
+			slave.Master = shard.Master
+			shard.Slaves = append(shard.Slaves, slave)
+		}
+	}
+	return t, nil
+}
+
+// ConfigDir is the folder of the base configuration as seen by the nodes,
+// which is also their working folder.
+func (t *Topology) ConfigDir() string {
+	if t.Spec.Containerized {
+		return containerConfigDir
+	}
+	return filepath.Dir(t.Spec.BaseConfig)
+}
+
+// DiscoveryConfig is the generated configuration of the discovery service
+// as seen by the nodes.
+func (t *Topology) DiscoveryConfig() string {
+	if t.Spec.Containerized {
+		return filepath.Join(containerWorkDir, discoveryConfigFile)
+	}
+	return filepath.Join(t.Spec.WorkDir, discoveryConfigFile)
+}
+
+// Args retrieves the arguments of dkvsrv for launching the given node.
+func (t *Topology) Args(node *Node) []string {
+	args := []string{
+		"--config=" + filepath.Join(t.ConfigDir(), filepath.Base(t.Spec.BaseConfig)),
+		"--node-name=" + node.Name,
+		"--listen-addr=" + fmt.Sprintf("0.0.0.0:%d", node.Port),
+		"--role=" + string(node.Role),
+		"--db-engine=" + t.Spec.Engine,
+		"--db-folder=" + node.DBFolder,
+		"--block-cache-size=" + strconv.Itoa(blockCacheSize),
+		"--dc-id=" + node.Region,
+		"--database=" + node.Database,
+		"--vbucket=" + node.VBucket,
+		"--discovery-service-config=" + t.DiscoveryConfig(),
+	}
+	if node.Role == Slave {
+		args = append(args,
+			"--disable-auto-master-disc=true",
+			"--repl-master-addr="+node.Master.Addr(),
+			"--repl-poll-interval="+replPollInterval,
+		)
+	}
+	return args
+}
+
+// discoveryINI renders the configuration of the discovery service, whose
+// server section is used by the discovery node and the client section
+// by all the other nodes.
+func (t *Topology) discoveryINI() string {
+	var sb strings.Builder
+	sb.WriteString("[serverConfig]\n")
+	sb.WriteString("statusTTL           =   30\n")
+	sb.WriteString("heartbeatTimeout    =   10\n")
+	sb.WriteString("\n[clientConfig]\n")
+	fmt.Fprintf(&sb, "discoveryServiceAddr    =   %s\n", t.Discovery.Addr())
+	sb.WriteString("pushStatusInterval      =   1\n")
+	sb.WriteString("pollClusterInfoInterval =   1\n")
+	return sb.String()
+}
@@ -0,0 +1,103 @@
+package topology
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	workDir := t.TempDir()
+	topo, err := Generate(Spec{Name: "test", Shards: 2, SlavesPerShard: 3, Regions: []string{"in-south", "in-west"},
+		BasePort: 9000, BaseConfig: "dkvsrv.yaml", WorkDir: workDir})
+	if err != nil {
+		t.Fatalf("Unable to generate topology. Error: %v", err)
+	}
+
+	nodes := topo.Nodes()
+	if len(nodes) != 1+2+2*3 {
+		t.Fatalf("Expected %d nodes but got %d", 1+2+2*3, len(nodes))
+	}
+	ports := make(map[int]struct{})
+	for _, node := range nodes {
+		if _, present := ports[node.Port]; present {
+			t.Errorf("Port %d is assigned to more than one node", node.Port)
+		}
+		ports[node.Port] = struct{}{}
+	}
+	if topo.Discovery.Role != Discovery || topo.Discovery.Port != 9000 {
+		t.Errorf("Unexpected discovery node: %+v", topo.Discovery)
+	}
+	for i, shard := range topo.Shards {
+		if shard.Master.Role != Master || shard.Master.Region != "in-south" {
+			t.Errorf("Unexpected master of shard %d: %+v", i, shard.Master)
+		}
+		for j, slave := range shard.Slaves {
+			if slave.Master != shard.Master || slave.Database != shard.Master.Database {
+				t.Errorf("Slave %s is not replicating from its master", slave.Name)
+			}
+			if expRegion := topo.Spec.Regions[j%2]; slave.Region != expRegion {
+				t.Errorf("Expected slave %s in region %s but got %s", slave.Name, expRegion, slave.Region)
+			}
+		}
+	}
+
+	args := strings.Join(topo.Args(topo.Shards[1].Slaves[0]), " ")
+	for _, expArg := range []string{"--role=slave", "--database=shard1", "--dc-id=in-south",
+		"--repl-master-addr=" + topo.Shards[1].Master.Addr(), "--db-folder=" + filepath.Join(workDir, "test-shard1-slave0")} {
+		if !strings.Contains(args, expArg) {
+			t.Errorf("Expected argument %s in %s", expArg, args)
+		}
+	}
+}
+
+func TestGenerateInvalidSpec(t *testing.T) {
+	for _, spec := range []Spec{
+		{Shards: 1, BaseConfig: "dkvsrv.yaml", WorkDir: "work"},
+		{Name: "test", BaseConfig: "dkvsrv.yaml", WorkDir: "work"},
+		{Name: "test", Shards: 1, SlavesPerShard: -1, BaseConfig: "dkvsrv.yaml", WorkDir: "work"},
+		{Name: "test", Shards: 1, WorkDir: "work"},
+		{Name: "test", Shards: 1, BaseConfig: "dkvsrv.yaml"},
+	} {
+		if _, err := Generate(spec); err == nil {
+			t.Errorf("Expected error for spec: %+v", spec)
+		}
+	}
+}
+
+func TestWriteComposeFile(t *testing.T) {
+	topo, err := Generate(Spec{Name: "test", Shards: 1, SlavesPerShard: 2, BaseConfig: "dkvsrv.yaml",
+		WorkDir: t.TempDir(), Containerized: true})
+	if err != nil {
+		t.Fatalf("Unable to generate topology. Error: %v", err)
+	}
+	file, err := WriteFiles(topo, "")
+	if err != nil {
+		t.Fatalf("Unable to write files. Error: %v", err)
+	}
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatalf("Unable to read compose file. Error: %v", err)
+	}
+	compose := string(data)
+	for _, node := range topo.Nodes() {
+		if !strings.Contains(compose, "  "+node.Name+":\n") {
+			t.Errorf("Expected service for node %s", node.Name)
+		}
+	}
+	for _, expStr := range []string{"image: " + DefaultImage, "--repl-master-addr=test-shard0-master:9081",
+		"--discovery-service-config=/dkv/discovery.ini", "- test-discovery\n"} {
+		if !strings.Contains(compose, expStr) {
+			t.Errorf("Expected %q in compose file:\n%s", expStr, compose)
+		}
+	}
+
+	ini, err := ioutil.ReadFile(filepath.Join(topo.Spec.WorkDir, discoveryConfigFile))
+	if err != nil {
+		t.Fatalf("Unable to read discovery config. Error: %v", err)
+	}
+	if !strings.Contains(string(ini), "discoveryServiceAddr    =   test-discovery:9080") {
+		t.Errorf("Unexpected discovery config:\n%s", ini)
+	}
+}
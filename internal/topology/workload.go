@@ -0,0 +1,88 @@
+package topology
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/pkg/ctl"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+)
+
+// Workload is the set of keys and values written onto every shard of a
+// topology, whose slaves must eventually converge onto them.
+type Workload struct {
+	// Values of the keys written onto each shard, keyed by its database.
+	Values map[string]map[string][]byte
+}
+
+// RunWorkload writes the given number of keys onto the master of every
+// shard of the given topology.
+func RunWorkload(t *Topology, numKeys int) (*Workload, error) {
+	wl := &Workload{Values: make(map[string]map[string][]byte)}
+	runID := time.Now().UnixNano()
+	for _, shard := range t.Shards {
+		cli, err := ctl.NewInSecureDKVClient(shard.Master.ClientAddr(), "")
+		if err != nil {
+			return nil, fmt.Errorf("unable to connect to master: %s, error: %v", shard.Master.Name, err)
+		}
+		values := make(map[string][]byte, numKeys)
+		for i := 0; i < numKeys; i++ {
+			key, value := fmt.Sprintf("topology-key-%d", i), []byte(fmt.Sprintf("topology-value-%d-%d", runID, i))
+			if err = cli.Put([]byte(key), value); err != nil {
+				cli.Close()
+				return nil, fmt.Errorf("unable to put key: %s on master: %s, error: %v", key, shard.Master.Name, err)
+			}
+			values[key] = value
+		}
+		cli.Close()
+		wl.Values[shard.Master.Database] = values
+	}
+	return wl, nil
+}
+
+// AwaitConvergence waits until every slave of the given topology serves
+// the values written by the given workload onto its master, or the
+// given timeout elapses.
+func AwaitConvergence(t *Topology, wl *Workload, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for _, shard := range t.Shards {
+		values := wl.Values[shard.Master.Database]
+		for _, slave := range shard.Slaves {
+			for {
+				key, err := firstDivergentKey(slave, values)
+				if err == nil && key == "" {
+					break
+				}
+				if time.Now().After(deadline) {
+					if err != nil {
+						return fmt.Errorf("slave: %s did not converge, error: %v", slave.Name, err)
+					}
+					return fmt.Errorf("slave: %s did not converge, key: %s differs from its master", slave.Name, key)
+				}
+				time.Sleep(time.Second)
+			}
+		}
+	}
+	return nil
+}
+
+// firstDivergentKey retrieves the first of the given keys whose value on
+// the given node differs from the expected one, or an empty key if none.
+func firstDivergentKey(node *Node, values map[string][]byte) (string, error) {
+	cli, err := ctl.NewInSecureDKVClient(node.ClientAddr(), "")
+	if err != nil {
+		return "", err
+	}
+	defer cli.Close()
+	for key, value := range values {
+		res, err := cli.Get(serverpb.ReadConsistency_SEQUENTIAL, []byte(key))
+		if err != nil {
+			return "", err
+		}
+		if !bytes.Equal(res.Value, value) {
+			return key, nil
+		}
+	}
+	return "", nil
+}
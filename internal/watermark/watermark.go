@@ -0,0 +1,68 @@
+// Package watermark exposes the latest change number of a DKV node in
+// the trailers of its GRPC responses, so that the clients and proxies
+// can track the freshness of the node and route their reads such that
+// they never observe an older state than one already observed.
+package watermark
+
+import (
+	"context"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// TrailerKey is the key of the response trailer carrying the latest
+// change number of the node serving the call.
+const TrailerKey = "dkv-change-number"
+
+// Source retrieves the latest change number of the node, ie., the last
+// committed change on a master or the last applied change on a slave.
+type Source func() (uint64, error)
+
+// UnaryServerInterceptor attaches the latest change number, as of the
+// completion of every unary call, to its trailer. Hence the change
+// number of a successful write is never ahead of its trailer.
+func UnaryServerInterceptor(src Source) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		res, err := handler(ctx, req)
+		if md, ok := trailer(src); ok {
+			_ = grpc.SetTrailer(ctx, md)
+		}
+		return res, err
+	}
+}
+
+// StreamServerInterceptor attaches the latest change number, as of the
+// completion of every streaming call, to its trailer.
+func StreamServerInterceptor(src Source) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := handler(srv, ss)
+		if md, ok := trailer(src); ok {
+			ss.SetTrailer(md)
+		}
+		return err
+	}
+}
+
+func trailer(src Source) (metadata.MD, bool) {
+	chngNum, err := src()
+	if err != nil {
+		return nil, false
+	}
+	return metadata.Pairs(TrailerKey, strconv.FormatUint(chngNum, 10)), true
+}
+
+// FromTrailer extracts the change number from the given trailer of a
+// response. Returns false if the trailer does not carry one.
+func FromTrailer(md metadata.MD) (uint64, bool) {
+	vals := md.Get(TrailerKey)
+	if len(vals) == 0 {
+		return 0, false
+	}
+	chngNum, err := strconv.ParseUint(vals[len(vals)-1], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return chngNum, true
+}
@@ -0,0 +1,70 @@
+package watermark
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type trailerStream struct {
+	grpc.ServerTransportStream
+	trailer metadata.MD
+}
+
+func (ts *trailerStream) Method() string { return "/dkv.serverpb.DKV/Put" }
+
+func (ts *trailerStream) SetTrailer(md metadata.MD) error {
+	ts.trailer = metadata.Join(ts.trailer, md)
+	return nil
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	chngNum := uint64(10)
+	src := func() (uint64, error) { return chngNum, nil }
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		// The trailer must reflect the changes made by the call itself
+		chngNum++
+		return "OK", nil
+	}
+
+	ts := &trailerStream{}
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), ts)
+	if _, err := UnaryServerInterceptor(src)(ctx, nil, &grpc.UnaryServerInfo{}, handler); err != nil {
+		t.Fatal(err)
+	}
+	if actual, ok := FromTrailer(ts.trailer); !ok || actual != 11 {
+		t.Errorf("Expected the change number 11 in the trailer. Actual: %d, Present: %t", actual, ok)
+	}
+
+	ts = &trailerStream{}
+	ctx = grpc.NewContextWithServerTransportStream(context.Background(), ts)
+	failingSrc := func() (uint64, error) { return 0, errors.New("unavailable") }
+	if _, err := UnaryServerInterceptor(failingSrc)(ctx, nil, &grpc.UnaryServerInfo{}, handler); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := FromTrailer(ts.trailer); ok {
+		t.Error("Expected no change number in the trailer when the source fails")
+	}
+}
+
+func TestFromTrailer(t *testing.T) {
+	testCases := []struct {
+		md      metadata.MD
+		chngNum uint64
+		present bool
+	}{
+		{metadata.Pairs(TrailerKey, "42"), 42, true},
+		{metadata.Pairs(TrailerKey, "1", TrailerKey, "2"), 2, true},
+		{metadata.Pairs(TrailerKey, "invalid"), 0, false},
+		{metadata.Pairs("other", "42"), 0, false},
+		{nil, 0, false},
+	}
+	for _, tc := range testCases {
+		if chngNum, present := FromTrailer(tc.md); chngNum != tc.chngNum || present != tc.present {
+			t.Errorf("Expected (%d, %t) from %v. Actual: (%d, %t)", tc.chngNum, tc.present, tc.md, chngNum, present)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package ctl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	getMethod      = "/dkv.serverpb.DKV/Get"
+	multiGetMethod = "/dkv.serverpb.DKV/MultiGet"
+	putMethod      = "/dkv.serverpb.DKV/Put"
+	multiPutMethod = "/dkv.serverpb.DKV/MultiPut"
+)
+
+// BatchingConfig holds the parameters of a request batcher.
+type BatchingConfig struct {
+	// Window is the time for which the first call of a batch waits for
+	// the other calls to join it, typically a few hundred microseconds.
+	Window time.Duration
+	// MaxBatchSize is the number of calls upon which a batch is sent
+	// right away, without waiting for the rest of its window.
+	MaxBatchSize int
+	// BatchPuts also coalesces the Put calls into MultiPut calls, which
+	// are applied atomically in the order of their arrival. Failure of
+	// such a MultiPut fails all the coalesced Put calls.
+	BatchPuts bool
+}
+
+// A RequestBatcher coalesces the Get calls made concurrently within a
+// short window into a single MultiGet call, and optionally the Put calls
+// into a single MultiPut call, improving the throughput of the callers
+// that fan out many small calls at the cost of the window in latency.
+// Only the calls without per call options, such as snapshots, change
+// numbers or outgoing metadata, are coalesced, with the rest made as is.
+// Calls made one after another by the same caller are never coalesced,
+// hence their ordering is retained. It is safe for concurrent use.
+type RequestBatcher struct {
+	cfg BatchingConfig
+
+	mu      sync.Mutex
+	batches map[batchKey]*requestBatch
+}
+
+// batchKey identifies the calls that can be coalesced together.
+type batchKey struct {
+	method  string
+	rc      serverpb.ReadConsistency
+	walSync serverpb.WALSync
+}
+
+type requestBatch struct {
+	key     batchKey
+	calls   []*batchedCall
+	timer   *time.Timer
+	cc      *grpc.ClientConn
+	invoker grpc.UnaryInvoker
+}
+
+type batchedCall struct {
+	req  interface{}
+	done chan batchedResult
+}
+
+type batchedResult struct {
+	reply proto.Message
+	err   error
+}
+
+// NewRequestBatcher creates a request batcher from the given configuration.
+func NewRequestBatcher(cfg BatchingConfig) (*RequestBatcher, error) {
+	switch {
+	case cfg.Window <= 0:
+		return nil, errors.New("batching window must be positive")
+	case cfg.MaxBatchSize <= 1:
+		return nil, errors.New("max batch size must be more than one")
+	}
+	return &RequestBatcher{cfg: cfg, batches: make(map[batchKey]*requestBatch)}, nil
+}
+
+// WithBatching makes the Get and optionally Put calls of the client be
+// coalesced by the given request batcher. Since the batches are sent over
+// a single connection, the batcher must not be shared across clients.
+func WithBatching(rb *RequestBatcher) ClientOption {
+	return withDialOptions(grpc.WithChainUnaryInterceptor(rb.intercept))
+}
+
+func (rb *RequestBatcher) intercept(ctx context.Context, method string, req, reply interface{},
+	cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	key, ok := rb.batchKeyOf(ctx, method, req, opts)
+	if !ok {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	call := &batchedCall{req: req, done: make(chan batchedResult, 1)}
+	rb.enqueue(key, call, cc, invoker)
+	select {
+	case res := <-call.done:
+		if res.reply != nil {
+			proto.Merge(reply.(proto.Message), res.reply)
+		}
+		return res.err
+	case <-ctx.Done():
+		if ctx.Err() == context.DeadlineExceeded {
+			return status.Error(codes.DeadlineExceeded, ctx.Err().Error())
+		}
+		return status.Error(codes.Canceled, ctx.Err().Error())
+	}
+}
+
+// batchKeyOf identifies the batch into which the given call can be
+// coalesced, if at all.
+func (rb *RequestBatcher) batchKeyOf(ctx context.Context, method string, req interface{}, opts []grpc.CallOption) (batchKey, bool) {
+	if len(opts) > 0 {
+		return batchKey{}, false
+	}
+	if md, present := metadata.FromOutgoingContext(ctx); present && md.Len() > 0 {
+		return batchKey{}, false
+	}
+	switch method {
+	case getMethod:
+		getReq := req.(*serverpb.GetRequest)
+		if getReq.Snapshot != "" || getReq.AsOfChangeNumber > 0 || getReq.MinChangeNumber > 0 || getReq.ReadOptions != nil {
+			return batchKey{}, false
+		}
+		return batchKey{method: method, rc: getReq.ReadConsistency}, true
+	case putMethod:
+		if !rb.cfg.BatchPuts {
+			return batchKey{}, false
+		}
+		return batchKey{method: method, walSync: req.(*serverpb.PutRequest).WalSync}, true
+	default:
+		return batchKey{}, false
+	}
+}
+
+// enqueue adds the given call to its batch, sending the batch right away
+// if full, else once its window elapses.
+func (rb *RequestBatcher) enqueue(key batchKey, call *batchedCall, cc *grpc.ClientConn, invoker grpc.UnaryInvoker) {
+	rb.mu.Lock()
+	batch := rb.batches[key]
+	if batch == nil {
+		batch = &requestBatch{key: key, cc: cc, invoker: invoker}
+		batch.timer = time.AfterFunc(rb.cfg.Window, func() { rb.flush(batch) })
+		rb.batches[key] = batch
+	}
+	batch.calls = append(batch.calls, call)
+	full := len(batch.calls) >= rb.cfg.MaxBatchSize
+	rb.mu.Unlock()
+	if full {
+		rb.flush(batch)
+	}
+}
+
+// flush sends the given batch, unless already sent.
+func (rb *RequestBatcher) flush(batch *requestBatch) {
+	rb.mu.Lock()
+	if rb.batches[batch.key] != batch {
+		rb.mu.Unlock()
+		return
+	}
+	delete(rb.batches, batch.key)
+	batch.timer.Stop()
+	rb.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	if len(batch.calls) == 1 {
+		call := batch.calls[0]
+		var reply proto.Message = &serverpb.GetResponse{}
+		if batch.key.method == putMethod {
+			reply = &serverpb.PutResponse{}
+		}
+		err := batch.invoker(ctx, batch.key.method, call.req, reply, batch.cc)
+		call.done <- batchedResult{reply, err}
+		return
+	}
+	switch batch.key.method {
+	case getMethod:
+		rb.sendGets(ctx, batch)
+	case putMethod:
+		rb.sendPuts(ctx, batch)
+	}
+}
+
+func (rb *RequestBatcher) sendGets(ctx context.Context, batch *requestBatch) {
+	multiGetReq := &serverpb.MultiGetRequest{ReadConsistency: batch.key.rc}
+	for _, call := range batch.calls {
+		multiGetReq.Keys = append(multiGetReq.Keys, call.req.(*serverpb.GetRequest).Key)
+	}
+	multiGetRes := &serverpb.MultiGetResponse{}
+	err := batch.invoker(ctx, multiGetMethod, multiGetReq, multiGetRes, batch.cc)
+	if err == nil && len(multiGetRes.KeyValues) != len(batch.calls) {
+		err = fmt.Errorf("expected %d results of MultiGet, but got %d", len(batch.calls), len(multiGetRes.KeyValues))
+	}
+	for i, call := range batch.calls {
+		if err != nil {
+			call.done <- batchedResult{err: err}
+			continue
+		}
+		// MultiGet does not tell an absent key apart from an empty value,
+		// hence both are reported as not found
+		kv := multiGetRes.KeyValues[i]
+		call.done <- batchedResult{reply: &serverpb.GetResponse{Status: multiGetRes.Status, Value: kv.Value, ExpireTS: kv.ExpireTS, NotFound: len(kv.Value) == 0}}
+	}
+}
+
+func (rb *RequestBatcher) sendPuts(ctx context.Context, batch *requestBatch) {
+	multiPutReq := &serverpb.MultiPutRequest{}
+	for _, call := range batch.calls {
+		multiPutReq.PutRequest = append(multiPutReq.PutRequest, call.req.(*serverpb.PutRequest))
+	}
+	putRes := &serverpb.PutResponse{}
+	err := batch.invoker(ctx, multiPutMethod, multiPutReq, putRes, batch.cc)
+	for _, call := range batch.calls {
+		if err != nil {
+			call.done <- batchedResult{err: err}
+			continue
+		}
+		call.done <- batchedResult{reply: putRes}
+	}
+}
@@ -0,0 +1,256 @@
+package ctl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrCircuitOpen is returned for the calls rejected by an open circuit
+// breaker, when no fallback serves them.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitState is the state of a circuit breaker.
+type CircuitState int32
+
+const (
+	// CircuitClosed lets all the calls through.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects all the calls without reaching the endpoint.
+	CircuitOpen
+	// CircuitHalfOpen lets a single trial call through, whose outcome
+	// decides whether the circuit closes or opens again.
+	CircuitHalfOpen
+)
+
+func (cs CircuitState) String() string {
+	switch cs {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("CircuitState(%d)", int32(cs))
+	}
+}
+
+// Fallback is invoked with the fully qualified GRPC method name along
+// with its request and reply, for the calls that either failed or were
+// rejected by an open circuit, as indicated by the given cause. It can
+// serve such calls by populating the reply and returning nil, say from
+// a local cache or with a default value. Otherwise it must return an
+// error, typically the given cause.
+type Fallback func(method string, req, reply interface{}, cause error) error
+
+// CircuitBreakerConfig holds the parameters of a circuit breaker.
+type CircuitBreakerConfig struct {
+	// WindowSize is the number of most recent calls whose outcomes are
+	// considered for computing the error rate.
+	WindowSize int
+	// MinCalls is the least number of calls in the window before the
+	// circuit can open.
+	MinCalls int
+	// ErrorRateThreshold is the fraction of failed calls in the window,
+	// in the range (0, 1], at which the circuit opens.
+	ErrorRateThreshold float64
+	// OpenDuration is the time for which the circuit stays open before
+	// a trial call is let through.
+	OpenDuration time.Duration
+	// Fallback optionally serves the failed and rejected calls.
+	Fallback Fallback
+}
+
+// A CircuitBreaker tracks the error rate of the calls made to a single
+// DKV endpoint and stops making them once it crosses the configured
+// threshold, so that the callers are not held up by a degraded node.
+// Only the transport level failures, such as unavailability and timeouts,
+// are counted. It is safe for concurrent use.
+type CircuitBreaker struct {
+	cfg CircuitBreakerConfig
+
+	mu       sync.Mutex
+	state    CircuitState
+	openedAt time.Time
+	trialOn  bool
+	outcomes []bool
+	next     int
+	count    int
+	failures int
+}
+
+// NewCircuitBreaker creates a circuit breaker, in the closed state, from
+// the given configuration.
+func NewCircuitBreaker(cfg CircuitBreakerConfig) (*CircuitBreaker, error) {
+	switch {
+	case cfg.WindowSize <= 0:
+		return nil, errors.New("window size must be positive")
+	case cfg.MinCalls <= 0 || cfg.MinCalls > cfg.WindowSize:
+		return nil, errors.New("min calls must be positive and at most the window size")
+	case cfg.ErrorRateThreshold <= 0 || cfg.ErrorRateThreshold > 1:
+		return nil, errors.New("error rate threshold must be in the range (0, 1]")
+	case cfg.OpenDuration <= 0:
+		return nil, errors.New("open duration must be positive")
+	}
+	return &CircuitBreaker{cfg: cfg, outcomes: make([]bool, cfg.WindowSize)}, nil
+}
+
+// WithCircuitBreaker makes the calls of the client be guarded by the
+// given circuit breaker. Since the breaker tracks the health of a single
+// endpoint, it must not be shared across clients. Streaming calls are
+// not guarded.
+func WithCircuitBreaker(cb *CircuitBreaker) ClientOption {
+	return withDialOptions(grpc.WithChainUnaryInterceptor(cb.intercept))
+}
+
+// State retrieves the current state of this circuit breaker.
+func (cb *CircuitBreaker) State() CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if cb.state == CircuitOpen && time.Since(cb.openedAt) >= cb.cfg.OpenDuration {
+		return CircuitHalfOpen
+	}
+	return cb.state
+}
+
+func (cb *CircuitBreaker) intercept(ctx context.Context, method string, req, reply interface{},
+	cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	trial, ok := cb.allow()
+	if !ok {
+		return cb.fallback(method, req, reply, ErrCircuitOpen)
+	}
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	failed := isFailure(err)
+	cb.record(trial, failed)
+	if failed {
+		return cb.fallback(method, req, reply, err)
+	}
+	return err
+}
+
+func (cb *CircuitBreaker) fallback(method string, req, reply interface{}, cause error) error {
+	if cb.cfg.Fallback == nil {
+		return cause
+	}
+	return cb.cfg.Fallback(method, req, reply, cause)
+}
+
+// allow reports whether a call can be made, and if so whether it is
+// the trial call of a half open circuit.
+func (cb *CircuitBreaker) allow() (trial bool, ok bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	switch cb.state {
+	case CircuitClosed:
+		return false, true
+	case CircuitOpen:
+		if time.Since(cb.openedAt) < cb.cfg.OpenDuration {
+			return false, false
+		}
+		cb.state, cb.trialOn = CircuitHalfOpen, true
+		return true, true
+	default:
+		if cb.trialOn {
+			return false, false
+		}
+		cb.trialOn = true
+		return true, true
+	}
+}
+
+func (cb *CircuitBreaker) record(trial, failed bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if trial {
+		cb.trialOn = false
+		if failed {
+			cb.open()
+		} else {
+			cb.reset()
+		}
+		return
+	}
+	if cb.state != CircuitClosed {
+		return
+	}
+	if cb.count == len(cb.outcomes) {
+		if cb.outcomes[cb.next] {
+			cb.failures--
+		}
+	} else {
+		cb.count++
+	}
+	cb.outcomes[cb.next] = failed
+	if failed {
+		cb.failures++
+	}
+	cb.next = (cb.next + 1) % len(cb.outcomes)
+	if cb.count >= cb.cfg.MinCalls && float64(cb.failures) >= cb.cfg.ErrorRateThreshold*float64(cb.count) {
+		cb.open()
+	}
+}
+
+func (cb *CircuitBreaker) open() {
+	cb.state, cb.openedAt = CircuitOpen, time.Now()
+}
+
+func (cb *CircuitBreaker) reset() {
+	cb.state = CircuitClosed
+	cb.next, cb.count, cb.failures = 0, 0, 0
+}
+
+// isFailure reports whether the given error indicates that the endpoint
+// is degraded. Errors that are specific to the request, such as invalid
+// arguments, transaction conflicts or internal errors, are not counted.
+func isFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
+
+// ReadFallback creates a Fallback that serves the Get and MultiGet calls
+// using the given lookup, typically backed by a local cache. A MultiGet
+// call is served only when all of its keys are found. All other calls
+// fail with the original cause.
+func ReadFallback(lookup func(key []byte) ([]byte, bool)) Fallback {
+	return func(method string, req, reply interface{}, cause error) error {
+		switch method {
+		case "/dkv.serverpb.DKV/Get":
+			getReq, getRes := req.(*serverpb.GetRequest), reply.(*serverpb.GetResponse)
+			val, ok := lookup(getReq.Key)
+			if !ok {
+				return cause
+			}
+			getRes.Status, getRes.Value = &serverpb.Status{}, val
+			return nil
+		case "/dkv.serverpb.DKV/MultiGet":
+			multiGetReq, multiGetRes := req.(*serverpb.MultiGetRequest), reply.(*serverpb.MultiGetResponse)
+			kvs := make([]*serverpb.KVPair, len(multiGetReq.Keys))
+			for i, key := range multiGetReq.Keys {
+				val, ok := lookup(key)
+				if !ok {
+					return cause
+				}
+				kvs[i] = &serverpb.KVPair{Key: key, Value: val}
+			}
+			multiGetRes.Status, multiGetRes.KeyValues = &serverpb.Status{}, kvs
+			return nil
+		default:
+			return cause
+		}
+	}
+}
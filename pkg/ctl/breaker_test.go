@@ -0,0 +1,266 @@
+package ctl
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	errUnavailable = status.Error(codes.Unavailable, "node unavailable")
+	errNotFound    = status.Error(codes.NotFound, "key not found")
+	errInvalidArg  = status.Error(codes.InvalidArgument, "invalid key")
+)
+
+func newTestCircuitBreaker(t *testing.T, fallback Fallback) *CircuitBreaker {
+	cb, err := NewCircuitBreaker(CircuitBreakerConfig{WindowSize: 4, MinCalls: 3, ErrorRateThreshold: 0.5,
+		OpenDuration: time.Hour, Fallback: fallback})
+	if err != nil {
+		t.Fatalf("Unable to create circuit breaker. Error: %v", err)
+	}
+	return cb
+}
+
+// coolDown elapses the open duration of the given circuit breaker.
+func coolDown(cb *CircuitBreaker) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.openedAt = cb.openedAt.Add(-cb.cfg.OpenDuration)
+}
+
+// invokeThrough makes a call guarded by the given circuit breaker to an
+// endpoint that fails it with the given error, or serves the given value
+// otherwise. It reports whether the call reached the endpoint.
+func invokeThrough(cb *CircuitBreaker, method string, req, reply interface{}, err error, val []byte) (bool, error) {
+	reached := false
+	invoker := func(_ context.Context, _ string, _, reply interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		reached = true
+		if err != nil {
+			return err
+		}
+		if getRes, ok := reply.(*serverpb.GetResponse); ok {
+			getRes.Status, getRes.Value = &serverpb.Status{}, val
+		}
+		return nil
+	}
+	err = cb.intercept(context.Background(), method, req, reply, nil, invoker)
+	return reached, err
+}
+
+func TestCircuitBreakerStates(t *testing.T) {
+	type call struct {
+		// cooledDown elapses the open duration before the call, upon which
+		// the circuit must be half open
+		cooledDown bool
+		// err is the error the endpoint fails the call with
+		err error
+		// rejected reports whether the call must be rejected without
+		// reaching the endpoint
+		rejected bool
+		// state is the state of the circuit after the call
+		state CircuitState
+	}
+	failures := []call{
+		{err: errUnavailable, state: CircuitClosed},
+		{err: errUnavailable, state: CircuitClosed},
+		{err: errUnavailable, state: CircuitOpen},
+	}
+
+	tests := []struct {
+		name  string
+		calls []call
+	}{
+		{
+			name: "closed circuit opens once the error rate reaches the threshold",
+			calls: []call{
+				{state: CircuitClosed},
+				{err: errUnavailable, state: CircuitClosed},
+				{state: CircuitClosed},
+				{err: status.Error(codes.DeadlineExceeded, "timed out"), state: CircuitOpen},
+				{rejected: true, state: CircuitOpen},
+			},
+		},
+		{
+			name:  "closed circuit stays closed until the min calls are made",
+			calls: append(failures, call{rejected: true, state: CircuitOpen}),
+		},
+		{
+			name: "application errors are not counted towards the threshold",
+			calls: []call{
+				{err: errNotFound, state: CircuitClosed},
+				{err: errInvalidArg, state: CircuitClosed},
+				{err: errNotFound, state: CircuitClosed},
+				{err: errInvalidArg, state: CircuitClosed},
+				{err: errUnavailable, state: CircuitClosed},
+			},
+		},
+		{
+			name: "open circuit half opens after the cool down and closes upon a successful trial",
+			calls: append(failures,
+				call{rejected: true, state: CircuitOpen},
+				call{cooledDown: true, state: CircuitClosed},
+				// Outcomes of the calls before the circuit opened are discarded
+				call{err: errUnavailable, state: CircuitClosed},
+				call{err: errUnavailable, state: CircuitClosed},
+			),
+		},
+		{
+			name: "open circuit half opens after the cool down and opens again upon a failed trial",
+			calls: append(failures,
+				call{cooledDown: true, err: errUnavailable, state: CircuitOpen},
+				call{rejected: true, state: CircuitOpen},
+				call{cooledDown: true, state: CircuitClosed},
+			),
+		},
+		{
+			name: "application errors of the trial call close the circuit",
+			calls: append(failures,
+				call{cooledDown: true, err: errNotFound, state: CircuitClosed},
+			),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cb := newTestCircuitBreaker(t, nil)
+			for i, c := range tc.calls {
+				if c.cooledDown {
+					coolDown(cb)
+					if state := cb.State(); state != CircuitHalfOpen {
+						t.Fatalf("Call %d: expected the circuit to be half open after the cool down, but it is %s", i, state)
+					}
+				}
+				reached, err := invokeThrough(cb, getMethod, &serverpb.GetRequest{Key: []byte("key")}, &serverpb.GetResponse{}, c.err, nil)
+				switch {
+				case c.rejected && (reached || err != ErrCircuitOpen):
+					t.Errorf("Call %d: expected to be rejected by the open circuit, but reached: %t, error: %v", i, reached, err)
+				case !c.rejected && (!reached || err != c.err):
+					t.Errorf("Call %d: expected to reach the endpoint and fail with %v, but reached: %t, error: %v", i, c.err, reached, err)
+				}
+				if state := cb.State(); state != c.state {
+					t.Fatalf("Call %d: expected the circuit to be %s, but it is %s", i, c.state, state)
+				}
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerReadFallback(t *testing.T) {
+	cache := map[string][]byte{"key1": []byte("cached1"), "key2": []byte("cached2")}
+
+	tests := []struct {
+		name string
+		// open opens the circuit before the call
+		open   bool
+		method string
+		keys   []string
+		// err is the error the endpoint fails the call with
+		err error
+		// vals are the values expected to be read
+		vals    []string
+		wantErr error
+		lookups int
+	}{
+		{
+			name:   "closed circuit serves the reads from the endpoint",
+			method: getMethod, keys: []string{"key1"},
+			vals: []string{"value1"},
+		},
+		{
+			name:   "closed circuit fails the reads with the application errors",
+			method: getMethod, keys: []string{"key1"},
+			err: errNotFound, wantErr: errNotFound,
+		},
+		{
+			name:   "open circuit serves the reads of the cached keys",
+			open:   true,
+			method: getMethod, keys: []string{"key1"},
+			vals: []string{"cached1"}, lookups: 1,
+		},
+		{
+			name:   "open circuit rejects the reads of the keys missing from the cache",
+			open:   true,
+			method: getMethod, keys: []string{"key3"},
+			wantErr: ErrCircuitOpen, lookups: 1,
+		},
+		{
+			name:   "open circuit serves the multi reads of the cached keys",
+			open:   true,
+			method: multiGetMethod, keys: []string{"key1", "key2"},
+			vals: []string{"cached1", "cached2"}, lookups: 2,
+		},
+		{
+			name:   "open circuit rejects the multi reads of any key missing from the cache",
+			open:   true,
+			method: multiGetMethod, keys: []string{"key3", "key1"},
+			wantErr: ErrCircuitOpen, lookups: 1,
+		},
+		{
+			name:   "open circuit rejects the writes",
+			open:   true,
+			method: putMethod, keys: []string{"key1"},
+			wantErr: ErrCircuitOpen,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lookups := 0
+			cb := newTestCircuitBreaker(t, ReadFallback(func(key []byte) ([]byte, bool) {
+				lookups++
+				val, ok := cache[string(key)]
+				return val, ok
+			}))
+			if tc.open {
+				cb.mu.Lock()
+				cb.open()
+				cb.mu.Unlock()
+			}
+
+			var req, reply interface{}
+			var keys [][]byte
+			for _, key := range tc.keys {
+				keys = append(keys, []byte(key))
+			}
+			switch tc.method {
+			case getMethod:
+				req, reply = &serverpb.GetRequest{Key: keys[0]}, &serverpb.GetResponse{}
+			case multiGetMethod:
+				req, reply = &serverpb.MultiGetRequest{Keys: keys}, &serverpb.MultiGetResponse{}
+			default:
+				req, reply = &serverpb.PutRequest{Key: keys[0], Value: []byte("value")}, &serverpb.PutResponse{}
+			}
+			_, err := invokeThrough(cb, tc.method, req, reply, tc.err, []byte("value1"))
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("Expected error: %v, actual: %v", tc.wantErr, err)
+			}
+			if lookups != tc.lookups {
+				t.Errorf("Expected %d cache lookups, actual: %d", tc.lookups, lookups)
+			}
+			if err != nil {
+				return
+			}
+			var vals []string
+			switch res := reply.(type) {
+			case *serverpb.GetResponse:
+				vals = append(vals, string(res.Value))
+			case *serverpb.MultiGetResponse:
+				for _, kv := range res.KeyValues {
+					vals = append(vals, string(kv.Value))
+				}
+			}
+			if len(vals) != len(tc.vals) {
+				t.Fatalf("Expected values: %v, actual: %v", tc.vals, vals)
+			}
+			for i := range vals {
+				if vals[i] != tc.vals[i] {
+					t.Errorf("Expected values: %v, actual: %v", tc.vals, vals)
+				}
+			}
+		})
+	}
+}
@@ -3,7 +3,10 @@ package ctl
 import (
 	"context"
 	"errors"
+	"github.com/flipkart-incubator/dkv/internal/auth"
+	"github.com/flipkart-incubator/dkv/internal/compression"
 	"github.com/flipkart-incubator/dkv/internal/hlc"
+	"github.com/flipkart-incubator/dkv/internal/storage"
 	"github.com/flipkart-incubator/nexus/models"
 	"io"
 	"time"
@@ -24,39 +27,84 @@ type DKVClient struct {
 	dkvBRCli   serverpb.DKVBackupRestoreClient
 	dkvClusCli serverpb.DKVClusterClient
 	dkvDisCli  serverpb.DKVDiscoveryClient
+	dkvSnapCli serverpb.DKVSnapshotClient
+	dkvAdmCli  serverpb.DKVAdminClient
+	// encoding of the change records requested from the master
+	chngEncoding changeRecordEncoding
+}
+
+type changeRecordEncoding struct {
+	compressor      string
+	deltaEncodeKeys bool
 }
 
 // TODO: Should these be paramterised ?
 const (
 	ReadBufSize    = 10 << 20
 	WriteBufSize   = 10 << 20
-	MaxMsgSize     = 50 << 20
+	MaxMsgSize     = compression.MaxMsgSize
 	Timeout        = 10 * time.Second
 	ConnectTimeout = 10 * time.Second
+	// RestoreTimeout bounds the restores, which replace the entire keyspace.
+	RestoreTimeout = 30 * time.Minute
 )
 
+// A ClientOption configures a client created by NewInSecureDKVClient.
+type ClientOption func(*clientOptions) error
+
+type clientOptions struct {
+	dialOpts []grpc.DialOption
+}
+
+func withDialOptions(dialOpts ...grpc.DialOption) ClientOption {
+	return func(opts *clientOptions) error {
+		opts.dialOpts = append(opts.dialOpts, dialOpts...)
+		return nil
+	}
+}
+
+// WithAccessToken makes the client send the given access token with
+// every request. Such a client is restricted to the scope of the token,
+// which is typically minted using MintAccessToken.
+func WithAccessToken(accessToken string) ClientOption {
+	return withDialOptions(grpc.WithPerRPCCredentials(accessTokenCreds(accessToken)))
+}
+
 // NewInSecureDKVClient creates an insecure GRPC client against the
 // given DKV service address. Optionally the authority param can be
-// used to send a :authority psuedo-header for routing purposes.
-func NewInSecureDKVClient(svcAddr, authority string) (*DKVClient, error) {
+// used to send a :authority psuedo-header for routing purposes. The
+// unary calls of the client pass through the interceptors installed
+// by the given options in their order, such as WithHooks followed by
+// WithCircuitBreaker to observe the calls rejected by the breaker.
+func NewInSecureDKVClient(svcAddr, authority string, options ...ClientOption) (*DKVClient, error) {
+	cliOpts := &clientOptions{}
+	for _, option := range options {
+		if err := option(cliOpts); err != nil {
+			return nil, err
+		}
+	}
 	var dkvClnt *DKVClient
 	ctx, cancel := context.WithTimeout(context.Background(), ConnectTimeout)
 	defer cancel()
-	conn, err := grpc.DialContext(ctx, svcAddr,
+	dialOpts := append([]grpc.DialOption{
 		grpc.WithInsecure(),
 		grpc.WithBlock(),
 		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(MaxMsgSize)),
 		grpc.WithReadBufferSize(ReadBufSize),
 		grpc.WithWriteBufferSize(WriteBufSize),
 		grpc.WithAuthority(authority),
-		grpc.WithDefaultServiceConfig(`{"loadBalancingPolicy":"round_robin"}`))
+		grpc.WithDefaultServiceConfig(`{"loadBalancingPolicy":"round_robin"}`),
+	}, cliOpts.dialOpts...)
+	conn, err := grpc.DialContext(ctx, svcAddr, dialOpts...)
 	if err == nil {
 		dkvCli := serverpb.NewDKVClient(conn)
 		dkvReplCli := serverpb.NewDKVReplicationClient(conn)
 		dkvBRCli := serverpb.NewDKVBackupRestoreClient(conn)
 		dkvClusCli := serverpb.NewDKVClusterClient(conn)
 		dkvDisCli := serverpb.NewDKVDiscoveryClient(conn)
-		dkvClnt = &DKVClient{conn, dkvCli, dkvReplCli, dkvBRCli, dkvClusCli, dkvDisCli}
+		dkvSnapCli := serverpb.NewDKVSnapshotClient(conn)
+		dkvAdmCli := serverpb.NewDKVAdminClient(conn)
+		dkvClnt = &DKVClient{conn, dkvCli, dkvReplCli, dkvBRCli, dkvClusCli, dkvDisCli, dkvSnapCli, dkvAdmCli, changeRecordEncoding{}}
 	}
 	return dkvClnt, err
 }
@@ -75,6 +123,44 @@ func (dkvClnt *DKVClient) Put(key []byte, value []byte) error {
 	return errorFromStatus(status, err)
 }
 
+// PutWithWALSync takes the key and value as byte arrays and invokes the
+// GRPC Put method, overriding the WAL sync policy of the server for this
+// write. The response reports the durability achieved by the write. This
+// is a convenience wrapper.
+func (dkvClnt *DKVClient) PutWithWALSync(key []byte, value []byte, walSync serverpb.WALSync) (*serverpb.PutResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	putReq := &serverpb.PutRequest{Key: key, Value: value, WalSync: walSync}
+	res, err := dkvClnt.dkvCli.Put(ctx, putReq)
+	var status *serverpb.Status
+	if res != nil {
+		status = res.Status
+	}
+	if err = errorFromStatus(status, err); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// AwaitDurability waits for upto the given timeout for the writes upto
+// the given change number to reach the given durability, using the
+// underlying GRPC AwaitDurability method. A zero timeout reports the
+// current durability of the writes without waiting. The durability of
+// the writes in the response falls short of the given durability if
+// the wait times out.
+func (dkvClnt *DKVClient) AwaitDurability(chngNum uint64, durability serverpb.Durability, minReplicas uint32, timeout time.Duration) (*serverpb.AwaitDurabilityResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout+timeout)
+	defer cancel()
+	awaitReq := &serverpb.AwaitDurabilityRequest{ChangeNumber: chngNum, Durability: durability,
+		MinReplicas: minReplicas, TimeoutMillis: uint64(timeout / time.Millisecond)}
+	res, err := dkvClnt.dkvCli.AwaitDurability(ctx, awaitReq)
+	var status *serverpb.Status
+	if res != nil {
+		status = res.Status
+	}
+	return res, errorFromStatus(status, err)
+}
+
 // PutTTL takes the key and value as byte arrays, expireTS as epoch seconds and invokes the
 // GRPC Put method. This is a convenience wrapper.
 func (dkvClnt *DKVClient) PutTTL(key []byte, value []byte, expireTS uint64) error {
@@ -89,6 +175,37 @@ func (dkvClnt *DKVClient) PutTTL(key []byte, value []byte, expireTS uint64) erro
 	return errorFromStatus(status, err)
 }
 
+// MultiPut takes the given key value pairs along with their expiry and
+// invokes the GRPC MultiPut method, which writes all of them atomically.
+func (dkvClnt *DKVClient) MultiPut(kvs ...*serverpb.KVPair) error {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	multiPutReq := &serverpb.MultiPutRequest{PutRequest: make([]*serverpb.PutRequest, len(kvs))}
+	for i, kv := range kvs {
+		multiPutReq.PutRequest[i] = &serverpb.PutRequest{Key: kv.Key, Value: kv.Value, ExpireTS: kv.ExpireTS}
+	}
+	res, err := dkvClnt.dkvCli.MultiPut(ctx, multiPutReq)
+	var status *serverpb.Status
+	if res != nil {
+		status = res.Status
+	}
+	return errorFromStatus(status, err)
+}
+
+// Merge takes the key and the merge operand as byte arrays and invokes
+// the GRPC Merge method. This is a convenience wrapper.
+func (dkvClnt *DKVClient) Merge(key []byte, operand []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	mergeReq := &serverpb.MergeRequest{Key: key, Operand: operand}
+	res, err := dkvClnt.dkvCli.Merge(ctx, mergeReq)
+	var status *serverpb.Status
+	if res != nil {
+		status = res.Status
+	}
+	return errorFromStatus(status, err)
+}
+
 // CompareAndSet provides the wrapper for the standard CAS primitive.
 // It invokes the underlying GRPC CompareAndSet method. This is a
 // convenience wrapper.
@@ -117,6 +234,52 @@ func (dkvClnt *DKVClient) Delete(key []byte) error {
 	return errorFromStatus(status, err)
 }
 
+// Lock acquires the advisory lock over the given key exclusively on behalf
+// of the given owner for the given lease, using the underlying GRPC Lock
+// method. The response indicates if the lock is acquired, else the current
+// owner of the lock. The owner renews its lease by locking the key again.
+func (dkvClnt *DKVClient) Lock(key []byte, owner string, lease time.Duration) (*serverpb.LockResponse, error) {
+	return dkvClnt.lock(key, owner, lease, serverpb.LockMode_EXCLUSIVE)
+}
+
+// LockShared acquires a share of the advisory lock over the given key on
+// behalf of the given owner for the given lease, which is acquired unless
+// another owner holds the lock exclusively. Releasing the share is done
+// through Unlock.
+func (dkvClnt *DKVClient) LockShared(key []byte, owner string, lease time.Duration) (*serverpb.LockResponse, error) {
+	return dkvClnt.lock(key, owner, lease, serverpb.LockMode_SHARED)
+}
+
+func (dkvClnt *DKVClient) lock(key []byte, owner string, lease time.Duration, mode serverpb.LockMode) (*serverpb.LockResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	lockReq := &serverpb.LockRequest{Key: key, Owner: owner, LeaseMillis: uint64(lease / time.Millisecond), Mode: mode}
+	res, err := dkvClnt.dkvCli.Lock(ctx, lockReq)
+	var status *serverpb.Status
+	if res != nil {
+		status = res.Status
+	}
+	return res, errorFromStatus(status, err)
+}
+
+// Unlock releases the advisory lock over the given key held by the given
+// owner, using the underlying GRPC Unlock method. Returns false if the
+// lock is not held by the given owner.
+func (dkvClnt *DKVClient) Unlock(key []byte, owner string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	unlockReq := &serverpb.UnlockRequest{Key: key, Owner: owner}
+	res, err := dkvClnt.dkvCli.Unlock(ctx, unlockReq)
+	var status *serverpb.Status
+	if res != nil {
+		status = res.Status
+	}
+	if err = errorFromStatus(status, err); err != nil {
+		return false, err
+	}
+	return res.Released, nil
+}
+
 // Get takes the key as byte array along with the consistency
 // level and invokes the GRPC Get method. This is a convenience wrapper.
 func (dkvClnt *DKVClient) Get(rc serverpb.ReadConsistency, key []byte) (*serverpb.GetResponse, error) {
@@ -127,7 +290,9 @@ func (dkvClnt *DKVClient) Get(rc serverpb.ReadConsistency, key []byte) (*serverp
 }
 
 // MultiGet takes the keys as byte arrays along with the consistency
-// level and invokes the GRPC MultiGet method. This is a convenience wrapper.
+// level and invokes the GRPC MultiGet method. The i-th result belongs to
+// the i-th key, with an empty value when the key is absent. This is a
+// convenience wrapper.
 func (dkvClnt *DKVClient) MultiGet(rc serverpb.ReadConsistency, keys ...[]byte) ([]*serverpb.KVPair, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
 	defer cancel()
@@ -139,15 +304,286 @@ func (dkvClnt *DKVClient) MultiGet(rc serverpb.ReadConsistency, keys ...[]byte)
 	return res.KeyValues, nil
 }
 
+// Exists takes the key as byte array along with the consistency level
+// and invokes the GRPC Exists method, which checks the presence of the
+// key without transferring its value. This is a convenience wrapper.
+func (dkvClnt *DKVClient) Exists(rc serverpb.ReadConsistency, key []byte) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	existsReq := &serverpb.ExistsRequest{Key: key, ReadConsistency: rc}
+	res, err := dkvClnt.dkvCli.Exists(ctx, existsReq)
+	var status *serverpb.Status
+	if res != nil {
+		status = res.Status
+	}
+	if err = errorFromStatus(status, err); err != nil {
+		return false, err
+	}
+	return res.Exists, nil
+}
+
+// GetWithReadOptions takes the key as byte array along with the options
+// tuning how the read is served by the storage engine and invokes the
+// GRPC Get method. This is a convenience wrapper.
+func (dkvClnt *DKVClient) GetWithReadOptions(readOpts *serverpb.ReadOptions, key []byte) (*serverpb.GetResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	getReq := &serverpb.GetRequest{Key: key, ReadOptions: readOpts}
+	return dkvClnt.dkvCli.Get(ctx, getReq)
+}
+
+// GetFromSnapshot takes the key as byte array and invokes the GRPC Get
+// method against the published snapshot with the given `namespace@name`
+// reference. This is a convenience wrapper.
+func (dkvClnt *DKVClient) GetFromSnapshot(snapshot string, key []byte) (*serverpb.GetResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	getReq := &serverpb.GetRequest{Key: key, Snapshot: snapshot}
+	return dkvClnt.dkvCli.Get(ctx, getReq)
+}
+
+// GetAsOf takes the key as byte array and invokes the GRPC Get method
+// for loading its value as of the given change number. This is a
+// convenience wrapper.
+func (dkvClnt *DKVClient) GetAsOf(changeNumber uint64, key []byte) (*serverpb.GetResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	getReq := &serverpb.GetRequest{Key: key, AsOfChangeNumber: changeNumber}
+	return dkvClnt.dkvCli.Get(ctx, getReq)
+}
+
+// MultiGetAsOf takes the keys as byte arrays and invokes the GRPC
+// MultiGet method for loading their values as of the given change
+// number. This is a convenience wrapper.
+func (dkvClnt *DKVClient) MultiGetAsOf(changeNumber uint64, keys ...[]byte) ([]*serverpb.KVPair, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	multiGetReq := &serverpb.MultiGetRequest{Keys: keys, AsOfChangeNumber: changeNumber}
+	res, err := dkvClnt.dkvCli.MultiGet(ctx, multiGetReq)
+	var status *serverpb.Status
+	if res != nil {
+		status = res.Status
+	}
+	if err = errorFromStatus(status, err); err != nil {
+		return nil, err
+	}
+	return res.KeyValues, nil
+}
+
 // GetChanges retrieves changes since the given change number
 // using the underlying GRPC GetChanges method. One can limit the
 // number of changes retrieved using the maxNumChanges parameter.
 // This is a convenience wrapper.
 func (dkvClnt *DKVClient) GetChanges(fromChangeNum uint64, maxNumChanges uint32) (*serverpb.GetChangesResponse, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	return dkvClnt.ReplicateChanges("", fromChangeNum, maxNumChanges)
+}
+
+// ReplicateChanges is same as GetChanges, except that the changes are
+// retrieved on behalf of the slave identified by the given replica ID.
+// The master then accounts for the changes preceding the given change
+// number as applied by that slave.
+func (dkvClnt *DKVClient) ReplicateChanges(replicaID string, fromChangeNum uint64, maxNumChanges uint32) (*serverpb.GetChangesResponse, error) {
+	return dkvClnt.AwaitChanges(replicaID, fromChangeNum, maxNumChanges, 0)
+}
+
+// AwaitChanges is same as ReplicateChanges, except that the master waits
+// for upto the given duration for the changes to be committed when there
+// are none yet, responding as soon as any change is committed.
+func (dkvClnt *DKVClient) AwaitChanges(replicaID string, fromChangeNum uint64, maxNumChanges uint32, maxWait time.Duration) (*serverpb.GetChangesResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout+maxWait)
 	defer cancel()
+	getChngsReq := &serverpb.GetChangesRequest{FromChangeNumber: fromChangeNum, MaxNumberOfChanges: maxNumChanges,
+		ReplicaID: replicaID, MaxWaitMillis: uint32(maxWait.Milliseconds()),
+		RecordCompression: dkvClnt.chngEncoding.compressor, DeltaEncodeKeys: dkvClnt.chngEncoding.deltaEncodeKeys}
+	res, err := dkvClnt.dkvReplCli.GetChanges(ctx, getChngsReq)
+	if err == nil {
+		err = DecodeChangeRecords(res.Changes)
+	}
+	return res, err
+}
+
+// SetChangeRecordEncoding sets the compression and the delta encoding of
+// the keys requested for the change records retrieved using this client,
+// which trades the CPU of both the ends for less bandwidth. Records are
+// decoded by the GetChanges and the AwaitChanges of this client, while
+// those received over the streams of StreamChangesWithOptions must be
+// decoded using DecodeChangeRecords. Masters not supporting the encoding
+// send the records as is. Must be set before retrieving any changes.
+func (dkvClnt *DKVClient) SetChangeRecordEncoding(compressor string, deltaEncodeKeys bool) error {
+	if err := compression.Validate(compressor); err != nil {
+		return err
+	}
+	dkvClnt.chngEncoding = changeRecordEncoding{compressor, deltaEncodeKeys}
+	return nil
+}
+
+// StreamChanges subscribes to the changes since the given change number
+// using the underlying GRPC StreamChanges method. Changes are received from
+// the returned stream as they are committed, until the given context is
+// cancelled. One can limit the number of changes per received response
+// using the maxNumChanges parameter.
+func (dkvClnt *DKVClient) StreamChanges(ctx context.Context, fromChangeNum uint64, maxNumChanges uint32) (serverpb.DKVReplication_StreamChangesClient, error) {
 	getChngsReq := &serverpb.GetChangesRequest{FromChangeNumber: fromChangeNum, MaxNumberOfChanges: maxNumChanges}
-	return dkvClnt.dkvReplCli.GetChanges(ctx, getChngsReq)
+	return dkvClnt.dkvReplCli.StreamChanges(ctx, getChngsReq)
+}
+
+// ChangeStreamOptions hold the batching targets of a stream of changes.
+type ChangeStreamOptions struct {
+	// ReplicaID identifies the slave subscribing to the changes, if any.
+	ReplicaID string
+	// MaxNumChanges is the maximum number of changes per response.
+	MaxNumChanges uint32
+	// MaxBatchBytes is the size of the changes upon which a response is
+	// sent, zero leaves it unbounded.
+	MaxBatchBytes uint32
+	// MaxBatchDelay is the duration for which the changes are held for
+	// batching them with the following ones, zero sends them right away.
+	MaxBatchDelay time.Duration
+	// Heartbeat is the interval at which a response without any changes
+	// is received when there are none, zero disables such responses.
+	Heartbeat time.Duration
+	// MaxInFlightBytes is the size of the changes sent ahead of the
+	// acknowledgements of the slave, which acknowledges the changes by
+	// reporting its replication lag. Zero leaves it unbounded.
+	MaxInFlightBytes uint32
+}
+
+// StreamChangesWithOptions is same as StreamChanges, except that the
+// changes are batched into the received responses as per the given
+// options, which suits the subscribers across high latency links.
+func (dkvClnt *DKVClient) StreamChangesWithOptions(ctx context.Context, fromChangeNum uint64, opts ChangeStreamOptions) (serverpb.DKVReplication_StreamChangesClient, error) {
+	getChngsReq := &serverpb.GetChangesRequest{FromChangeNumber: fromChangeNum, MaxNumberOfChanges: opts.MaxNumChanges,
+		ReplicaID: opts.ReplicaID, MaxBatchBytes: opts.MaxBatchBytes,
+		MaxBatchDelayMillis: uint32(opts.MaxBatchDelay.Milliseconds()), HeartbeatMillis: uint32(opts.Heartbeat.Milliseconds()),
+		MaxInFlightBytes: opts.MaxInFlightBytes, RecordCompression: dkvClnt.chngEncoding.compressor,
+		DeltaEncodeKeys: dkvClnt.chngEncoding.deltaEncodeKeys}
+	return dkvClnt.dkvReplCli.StreamChanges(ctx, getChngsReq)
+}
+
+// TransferCheckpoint streams a checkpoint of the entire keyspace using
+// the underlying GRPC TransferCheckpoint method. A new checkpoint is
+// created when the given checkpoint ID is empty, else the transfer of
+// that checkpoint is resumed from the given file and offset.
+func (dkvClnt *DKVClient) TransferCheckpoint(ctx context.Context, checkpointID, fileName string, offset uint64) (serverpb.DKVReplication_TransferCheckpointClient, error) {
+	transferReq := &serverpb.TransferCheckpointRequest{CheckpointID: checkpointID, FileName: fileName, Offset: offset}
+	return dkvClnt.dkvReplCli.TransferCheckpoint(ctx, transferReq)
+}
+
+// Promote issues a new fencing epoch on the DKV master taking over
+// from a superseded master, using the underlying GRPC Promote method.
+// Returns the newly issued fencing epoch.
+func (dkvClnt *DKVClient) Promote() (uint64, error) {
+	res, err := dkvClnt.PromoteToMaster(false)
+	if err != nil {
+		return 0, err
+	}
+	return res.FencingEpoch, nil
+}
+
+// PromoteToMaster promotes the DKV node to a master, using the underlying
+// GRPC Promote method. Slaves stop replicating from their master and serve
+// the writes, reporting the last change applied from their master. Unless
+// fencing is skipped, a new fencing epoch is issued, so that the changes of
+// the superseded master are rejected by the slaves replicating from the
+// promoted node.
+func (dkvClnt *DKVClient) PromoteToMaster(skipFencing bool) (*serverpb.PromoteResponse, error) {
+	return dkvClnt.promote(&serverpb.PromoteRequest{SkipFencing: skipFencing})
+}
+
+// PromoteAndFenceMaster promotes the DKV slave to a master as done by
+// PromoteToMaster, pushing the newly issued fencing epoch onto its master
+// beforehand, which rejects the writes thereafter. The slave is not
+// promoted if its master can not be fenced.
+func (dkvClnt *DKVClient) PromoteAndFenceMaster() (*serverpb.PromoteResponse, error) {
+	return dkvClnt.promote(&serverpb.PromoteRequest{FenceMaster: true})
+}
+
+func (dkvClnt *DKVClient) promote(promoteReq *serverpb.PromoteRequest) (*serverpb.PromoteResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	res, err := dkvClnt.dkvReplCli.Promote(ctx, promoteReq)
+	var status *serverpb.Status
+	if res != nil {
+		status = res.Status
+	}
+	return res, errorFromStatus(status, err)
+}
+
+// Fence pushes the given fencing epoch, issued by the DKV node promoted
+// to master at the given address, onto the DKV master it supersedes,
+// using the underlying GRPC Fence method. The superseded master rejects
+// the writes thereafter with ReplicaReadOnlyError, redirecting them to
+// the given address.
+func (dkvClnt *DKVClient) Fence(epoch uint64, masterAddr string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	res, err := dkvClnt.dkvReplCli.Fence(ctx, &serverpb.FenceRequest{FencingEpoch: epoch, MasterAddress: masterAddr})
+	return errorFromStatus(res, err)
+}
+
+// ReportReplicationLag reports to the DKV master that the given slave
+// applied all its changes upto the given change number and last applied
+// any change at the given time, using the underlying GRPC
+// ReportReplicationLag method.
+func (dkvClnt *DKVClient) ReportReplicationLag(replicaID string, appliedChangeNum uint64, appliedAt time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	lagReport := &serverpb.ReplicationLagReport{ReplicaID: replicaID, AppliedChangeNumber: appliedChangeNum}
+	if !appliedAt.IsZero() {
+		lagReport.AppliedTimestamp = appliedAt.UnixNano() / int64(time.Millisecond)
+	}
+	res, err := dkvClnt.dkvReplCli.ReportReplicationLag(ctx, lagReport)
+	return errorFromStatus(res, err)
+}
+
+// GetReplicationLag retrieves the lag of every slave of the DKV master
+// using the underlying GRPC GetReplicationLag method.
+func (dkvClnt *DKVClient) GetReplicationLag() (*serverpb.GetReplicationLagResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	res, err := dkvClnt.dkvReplCli.GetReplicationLag(ctx, &empty.Empty{})
+	var status *serverpb.Status
+	if res != nil {
+		status = res.Status
+	}
+	if err = errorFromStatus(status, err); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetRangeDigests retrieves the digests of the ranges of the keyspace
+// requested by the given request, using the underlying GRPC
+// GetRangeDigests method.
+func (dkvClnt *DKVClient) GetRangeDigests(digestReq *serverpb.GetRangeDigestsRequest) ([]*serverpb.RangeDigest, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	res, err := dkvClnt.dkvReplCli.GetRangeDigests(ctx, digestReq)
+	var status *serverpb.Status
+	if res != nil {
+		status = res.Status
+	}
+	if err = errorFromStatus(status, err); err != nil {
+		return nil, err
+	}
+	return res.Digests, nil
+}
+
+// GetDivergentRanges retrieves the ranges of the keyspace found to diverge
+// from the master by the consistency verifier of the DKV slave, using the
+// underlying GRPC GetDivergentRanges method.
+func (dkvClnt *DKVClient) GetDivergentRanges() (*serverpb.GetDivergentRangesResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	res, err := dkvClnt.dkvReplCli.GetDivergentRanges(ctx, &empty.Empty{})
+	var status *serverpb.Status
+	if res != nil {
+		status = res.Status
+	}
+	if err = errorFromStatus(status, err); err != nil {
+		return nil, err
+	}
+	return res, nil
 }
 
 // Backup backs up the entire keyspace into the given filesystem
@@ -161,17 +597,74 @@ func (dkvClnt *DKVClient) Backup(path string) error {
 	return errorFromStatus(res, err)
 }
 
+// GetBackupManifest retrieves the change number upto which the backup
+// at the given filesystem location is complete, along with the epoch
+// seconds at which it was taken, using the underlying GRPC
+// GetBackupManifest method. This is a convenience wrapper.
+func (dkvClnt *DKVClient) GetBackupManifest(path string) (uint64, int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	manifestReq := &serverpb.GetBackupManifestRequest{BackupPath: path}
+	res, err := dkvClnt.dkvBRCli.GetBackupManifest(ctx, manifestReq)
+	var status *serverpb.Status
+	if res != nil {
+		status = res.Status
+	}
+	if err = errorFromStatus(status, err); err != nil {
+		return 0, 0, err
+	}
+	return res.ChangeNumber, res.CreatedAt, nil
+}
+
+// PauseWrites pauses the writes on the DKV master until resumed or the
+// given timeout elapses, using the underlying GRPC PauseWrites method.
+// Returns the latest change number as of the pause.
+func (dkvClnt *DKVClient) PauseWrites(timeout time.Duration) (uint64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	res, err := dkvClnt.dkvBRCli.PauseWrites(ctx, &serverpb.PauseWritesRequest{TimeoutSecs: uint32(timeout.Seconds())})
+	var status *serverpb.Status
+	if res != nil {
+		status = res.Status
+	}
+	if err = errorFromStatus(status, err); err != nil {
+		return 0, err
+	}
+	return res.ChangeNumber, nil
+}
+
+// ResumeWrites resumes the writes paused on the DKV master, using the
+// underlying GRPC ResumeWrites method.
+func (dkvClnt *DKVClient) ResumeWrites() error {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	res, err := dkvClnt.dkvBRCli.ResumeWrites(ctx, &empty.Empty{})
+	return errorFromStatus(res, err)
+}
+
 // Restore restores the entire keyspace from the given filesystem
 // location using the underlying GRPC Restore method. This is a
 // convenience wrapper.
 func (dkvClnt *DKVClient) Restore(path string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), RestoreTimeout)
 	defer cancel()
 	restoreReq := &serverpb.RestoreRequest{RestorePath: path}
 	res, err := dkvClnt.dkvBRCli.Restore(ctx, restoreReq)
 	return errorFromStatus(res, err)
 }
 
+// PointInTimeRestore restores the entire keyspace from the given
+// filesystem location and replays the retained changes upto the given
+// change number using the underlying GRPC PointInTimeRestore method.
+// This is a convenience wrapper.
+func (dkvClnt *DKVClient) PointInTimeRestore(path string, changeNumber uint64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), RestoreTimeout)
+	defer cancel()
+	pitrReq := &serverpb.PointInTimeRestoreRequest{RestorePath: path, ChangeNumber: changeNumber}
+	res, err := dkvClnt.dkvBRCli.PointInTimeRestore(ctx, pitrReq)
+	return errorFromStatus(res, err)
+}
+
 // AddNode adds the node with the given Nexus URL to
 // the Nexus cluster of which the current node is a member of.
 func (dkvClnt *DKVClient) AddNode(nodeURL string) error {
@@ -231,6 +724,408 @@ func (dkvClnt *DKVClient) GetClusterInfo(dcId string, database string, vBucket s
 	return clusterInfo.GetRegionInfos(), nil
 }
 
+// BackupCluster backs up all the shards of the given database under
+// the given filesystem location through their leaders, using the
+// underlying GRPC BackupCluster method. The resulting shard backups are
+// recorded in a cluster backup manifest placed at the same location.
+func (dkvClnt *DKVClient) BackupCluster(database, path string) (*serverpb.BackupClusterResponse, error) {
+	return dkvClnt.backupCluster(&serverpb.BackupClusterRequest{Database: database, BackupPath: path})
+}
+
+// QuiescedBackupCluster backs up all the shards of the given database as
+// done by BackupCluster, pausing the writes on their leaders for the
+// duration of the backup so that the shard backups form a consistent cut
+// of the database. The leaders resume the writes after the given timeout
+// should the backup not complete by then, which defaults to 10 minutes
+// when zero.
+func (dkvClnt *DKVClient) QuiescedBackupCluster(database, path string, timeout time.Duration) (*serverpb.BackupClusterResponse, error) {
+	return dkvClnt.backupCluster(&serverpb.BackupClusterRequest{Database: database, BackupPath: path,
+		Quiesce: true, QuiesceTimeoutSecs: uint32(timeout.Seconds())})
+}
+
+func (dkvClnt *DKVClient) backupCluster(backupReq *serverpb.BackupClusterRequest) (*serverpb.BackupClusterResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	res, err := dkvClnt.dkvDisCli.BackupCluster(ctx, backupReq)
+	var status *serverpb.Status
+	if res != nil {
+		status = res.Status
+	}
+	if err = errorFromStatus(status, err); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// RestoreCluster restores all the shards of a database, along with
+// their slaves, from the cluster backup whose manifest is at the given
+// filesystem location, using the underlying GRPC RestoreCluster method.
+func (dkvClnt *DKVClient) RestoreCluster(manifestPath string) (*serverpb.RestoreClusterResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), RestoreTimeout)
+	defer cancel()
+	res, err := dkvClnt.dkvDisCli.RestoreCluster(ctx, &serverpb.RestoreClusterRequest{ManifestPath: manifestPath})
+	var status *serverpb.Status
+	if res != nil {
+		status = res.Status
+	}
+	if err = errorFromStatus(status, err); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// PublishSnapshot publishes a read-only snapshot of the given namespace
+// under the given name using the underlying GRPC PublishSnapshot method.
+// This is a convenience wrapper.
+func (dkvClnt *DKVClient) PublishSnapshot(namespace, name string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	pubReq := &serverpb.PublishSnapshotRequest{Namespace: namespace, Name: name}
+	res, err := dkvClnt.dkvSnapCli.PublishSnapshot(ctx, pubReq)
+	return errorFromStatus(res, err)
+}
+
+// UnpublishSnapshot releases the published snapshot with the given
+// `namespace@name` reference using the underlying GRPC UnpublishSnapshot
+// method. This is a convenience wrapper.
+func (dkvClnt *DKVClient) UnpublishSnapshot(snapshot string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	unpubReq := &serverpb.UnpublishSnapshotRequest{Snapshot: snapshot}
+	res, err := dkvClnt.dkvSnapCli.UnpublishSnapshot(ctx, unpubReq)
+	return errorFromStatus(res, err)
+}
+
+// ListSnapshots retrieves the references of all the published snapshots.
+func (dkvClnt *DKVClient) ListSnapshots() ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	res, err := dkvClnt.dkvSnapCli.ListSnapshots(ctx, &empty.Empty{})
+	if res != nil {
+		if err = errorFromStatus(res.Status, err); err != nil {
+			return nil, err
+		}
+		return res.Snapshots, nil
+	}
+	return nil, err
+}
+
+// CompactRange triggers a manual compaction of the keys having the given
+// prefix, or of the entire keyspace when the prefix is empty, using the
+// underlying GRPC CompactRange method. This is a convenience wrapper.
+func (dkvClnt *DKVClient) CompactRange(keyPrefix []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	compReq := &serverpb.CompactRangeRequest{KeyPrefix: keyPrefix}
+	res, err := dkvClnt.dkvAdmCli.CompactRange(ctx, compReq)
+	return errorFromStatus(res, err)
+}
+
+// IngestSST bulk loads the SST files at the given paths on the DKV node,
+// holding the keys without and with TTL respectively, using the underlying
+// GRPC IngestSST method. The files are moved into the store rather than
+// copied if moveFiles is set. This is a convenience wrapper.
+func (dkvClnt *DKVClient) IngestSST(files, ttlFiles []string, moveFiles bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	ingestReq := &serverpb.IngestSSTRequest{Files: files, TtlFiles: ttlFiles, MoveFiles: moveFiles}
+	res, err := dkvClnt.dkvAdmCli.IngestSST(ctx, ingestReq)
+	return errorFromStatus(res, err)
+}
+
+// Export writes a consistent snapshot of the keys lying within the given
+// start key (inclusive) and end key (exclusive) in the given format into
+// the given folder on the DKV node or uploads them to the given http(s)
+// URL, using the underlying GRPC Export method. Empty keys leave the range
+// unbounded on the respective side.
+func (dkvClnt *DKVClient) Export(startKey, endKey []byte, format serverpb.ExportFormat, destination string) (*serverpb.ExportResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	exportReq := &serverpb.ExportRequest{StartKey: startKey, EndKey: endKey, Format: format, Destination: destination}
+	res, err := dkvClnt.dkvAdmCli.Export(ctx, exportReq)
+	var status *serverpb.Status
+	if res != nil {
+		status = res.Status
+	}
+	return res, errorFromStatus(status, err)
+}
+
+// NewExportReader creates a reader of the key value pairs of a file
+// exported in the BINARY format, for processing the file offline.
+func NewExportReader(r io.Reader) (*storage.ExportReader, error) {
+	return storage.NewExportReader(r)
+}
+
+// SetMemtableOptions changes the non zero memtable and level 0 options
+// of the DKV node until it restarts, using the underlying GRPC
+// SetMemtableOptions method. This is a convenience wrapper.
+func (dkvClnt *DKVClient) SetMemtableOptions(mtOpts *serverpb.MemtableOptions) error {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	res, err := dkvClnt.dkvAdmCli.SetMemtableOptions(ctx, mtOpts)
+	return errorFromStatus(res, err)
+}
+
+// EstimateSize estimates the number of keys and the size in bytes of
+// the keys lying within the given start key (inclusive) and end key
+// (exclusive), using the underlying GRPC EstimateSize method. Empty
+// keys leave the range unbounded on the respective side.
+func (dkvClnt *DKVClient) EstimateSize(startKey, endKey []byte) (*serverpb.EstimateSizeResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	estReq := &serverpb.EstimateSizeRequest{StartKey: startKey, EndKey: endKey}
+	res, err := dkvClnt.dkvAdmCli.EstimateSize(ctx, estReq)
+	var status *serverpb.Status
+	if res != nil {
+		status = res.Status
+	}
+	return res, errorFromStatus(status, err)
+}
+
+// ReportReadAmplification samples the reads served by the DKV node over
+// the given window and reports their efficiency along with the tuning
+// suggestions, using the underlying GRPC ReportReadAmplification method.
+// A zero window indicates the default window of the DKV node.
+func (dkvClnt *DKVClient) ReportReadAmplification(window time.Duration) (*serverpb.ReadAmplificationResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), window+Timeout)
+	defer cancel()
+	raReq := &serverpb.ReadAmplificationRequest{SamplingWindowSecs: uint32(window / time.Second)}
+	res, err := dkvClnt.dkvAdmCli.ReportReadAmplification(ctx, raReq)
+	var status *serverpb.Status
+	if res != nil {
+		status = res.Status
+	}
+	return res, errorFromStatus(status, err)
+}
+
+// GetKeyHeatmap retrieves the read and write rates of the DKV node across
+// the key range buckets for every minute over the given number of minutes,
+// using the underlying GRPC GetKeyHeatmap method. Zero minutes retrieves
+// all the minutes retained by the DKV node.
+func (dkvClnt *DKVClient) GetKeyHeatmap(minutes uint32) (*serverpb.GetKeyHeatmapResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	res, err := dkvClnt.dkvAdmCli.GetKeyHeatmap(ctx, &serverpb.GetKeyHeatmapRequest{Minutes: minutes})
+	var status *serverpb.Status
+	if res != nil {
+		status = res.Status
+	}
+	return res, errorFromStatus(status, err)
+}
+
+// PlaceLegalHold places a legal hold over the given key prefix using the
+// underlying GRPC PlaceLegalHold method. The given requester and reason
+// are recorded in the audit log of the DKV node.
+func (dkvClnt *DKVClient) PlaceLegalHold(keyPrefix []byte, requester, reason string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	lhReq := &serverpb.LegalHoldRequest{KeyPrefix: keyPrefix, Requester: requester, Reason: reason}
+	res, err := dkvClnt.dkvAdmCli.PlaceLegalHold(ctx, lhReq)
+	return errorFromStatus(res, err)
+}
+
+// LiftLegalHold lifts the legal hold over the given key prefix using the
+// underlying GRPC LiftLegalHold method. The given requester and reason
+// are recorded in the audit log of the DKV node.
+func (dkvClnt *DKVClient) LiftLegalHold(keyPrefix []byte, requester, reason string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	lhReq := &serverpb.LegalHoldRequest{KeyPrefix: keyPrefix, Requester: requester, Reason: reason}
+	res, err := dkvClnt.dkvAdmCli.LiftLegalHold(ctx, lhReq)
+	return errorFromStatus(res, err)
+}
+
+// ListLegalHolds retrieves all the key prefixes under legal hold.
+func (dkvClnt *DKVClient) ListLegalHolds() ([][]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	res, err := dkvClnt.dkvAdmCli.ListLegalHolds(ctx, &empty.Empty{})
+	if res != nil {
+		if err = errorFromStatus(res.Status, err); err != nil {
+			return nil, err
+		}
+		return res.KeyPrefixes, nil
+	}
+	return nil, err
+}
+
+// BeginPrefixRename begins renaming the given old key prefix to the given
+// new key prefix using the underlying GRPC BeginPrefixRename method. The
+// keys of the old prefix are copied to the new prefix in the background,
+// while the reads of the keys absent under the new prefix fall back to
+// the old prefix.
+func (dkvClnt *DKVClient) BeginPrefixRename(oldPrefix, newPrefix []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	renameReq := &serverpb.PrefixRenameRequest{OldPrefix: oldPrefix, NewPrefix: newPrefix}
+	res, err := dkvClnt.dkvAdmCli.BeginPrefixRename(ctx, renameReq)
+	return errorFromStatus(res, err)
+}
+
+// CompletePrefixRename completes the rename of the given old key prefix,
+// once its keys are copied, using the underlying GRPC CompletePrefixRename
+// method. The keys of the old prefix are deleted in the background.
+func (dkvClnt *DKVClient) CompletePrefixRename(oldPrefix []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	renameReq := &serverpb.PrefixRenameRequest{OldPrefix: oldPrefix}
+	res, err := dkvClnt.dkvAdmCli.CompletePrefixRename(ctx, renameReq)
+	return errorFromStatus(res, err)
+}
+
+// ListPrefixRenames retrieves all the key prefix renames in progress.
+func (dkvClnt *DKVClient) ListPrefixRenames() ([]*serverpb.PrefixRename, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	res, err := dkvClnt.dkvAdmCli.ListPrefixRenames(ctx, &empty.Empty{})
+	if res != nil {
+		if err = errorFromStatus(res.Status, err); err != nil {
+			return nil, err
+		}
+		return res.Renames, nil
+	}
+	return nil, err
+}
+
+// ListQuarantinedRecords retrieves the records set aside in the quarantine
+// namespace of the DKV node, along with the reasons for quarantining them,
+// using the underlying GRPC ListQuarantinedRecords method.
+func (dkvClnt *DKVClient) ListQuarantinedRecords() ([]*serverpb.QuarantinedRecord, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	res, err := dkvClnt.dkvAdmCli.ListQuarantinedRecords(ctx, &empty.Empty{})
+	if res != nil {
+		if err = errorFromStatus(res.Status, err); err != nil {
+			return nil, err
+		}
+		return res.Records, nil
+	}
+	return nil, err
+}
+
+// DeleteSubject irrecoverably erases all the data of the given subject
+// using the underlying GRPC DeleteSubject method. The given requester
+// and reason are recorded in the audit log of the DKV node.
+func (dkvClnt *DKVClient) DeleteSubject(subject []byte, requester, reason string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	delSubjReq := &serverpb.DeleteSubjectRequest{Subject: subject, Requester: requester, Reason: reason}
+	res, err := dkvClnt.dkvAdmCli.DeleteSubject(ctx, delSubjReq)
+	return errorFromStatus(res, err)
+}
+
+// RotateEncryptionKey re-encrypts all the values of the node using the
+// latest master key with the underlying GRPC RotateEncryptionKey method,
+// returning the identifier of that key along with the number of values
+// re-encrypted. The given requester and reason are recorded for auditing.
+func (dkvClnt *DKVClient) RotateEncryptionKey(requester, reason string) (*serverpb.RotateEncryptionKeyResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	rotateReq := &serverpb.RotateEncryptionKeyRequest{Requester: requester, Reason: reason}
+	res, err := dkvClnt.dkvAdmCli.RotateEncryptionKey(ctx, rotateReq)
+	var status *serverpb.Status
+	if res != nil {
+		status = res.Status
+	}
+	if err = errorFromStatus(status, err); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// RotateTenantKey re-encrypts all the values of the given tenant using
+// a new data key with the underlying GRPC RotateTenantKey method,
+// returning the identifier of that key along with the number of values
+// re-encrypted. The given requester and reason are recorded for auditing.
+func (dkvClnt *DKVClient) RotateTenantKey(tenant []byte, requester, reason string) (*serverpb.RotateEncryptionKeyResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	rotateReq := &serverpb.RotateTenantKeyRequest{Tenant: tenant, Requester: requester, Reason: reason}
+	res, err := dkvClnt.dkvAdmCli.RotateTenantKey(ctx, rotateReq)
+	var status *serverpb.Status
+	if res != nil {
+		status = res.Status
+	}
+	if err = errorFromStatus(status, err); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// DeleteTenant irrecoverably erases all the data of the given tenant
+// using the underlying GRPC DeleteTenant method. The given requester
+// and reason are recorded in the audit log of the DKV node.
+func (dkvClnt *DKVClient) DeleteTenant(tenant []byte, requester, reason string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	delTenantReq := &serverpb.DeleteTenantRequest{Tenant: tenant, Requester: requester, Reason: reason}
+	res, err := dkvClnt.dkvAdmCli.DeleteTenant(ctx, delTenantReq)
+	return errorFromStatus(res, err)
+}
+
+// MintAccessToken mints a token granting read-only access to the keys
+// having the given prefix for the given duration, returning the token
+// along with its expiry in epoch seconds. A zero duration results in
+// the longest permitted duration of one hour.
+func (dkvClnt *DKVClient) MintAccessToken(keyPrefix []byte, ttl time.Duration, requester, reason string) (string, uint64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	mintReq := &serverpb.MintAccessTokenRequest{KeyPrefix: keyPrefix, TtlSecs: uint32(ttl.Seconds()), Requester: requester, Reason: reason}
+	res, err := dkvClnt.dkvAdmCli.MintAccessToken(ctx, mintReq)
+	var status *serverpb.Status
+	if res != nil {
+		status = res.Status
+	}
+	if err = errorFromStatus(status, err); err != nil {
+		return "", 0, err
+	}
+	return res.Token, res.ExpireTS, nil
+}
+
+// GetEffectiveConfig retrieves the JSON representation of the effective
+// configuration of the DKV node.
+func (dkvClnt *DKVClient) GetEffectiveConfig() (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	res, err := dkvClnt.dkvAdmCli.GetEffectiveConfig(ctx, &empty.Empty{})
+	var status *serverpb.Status
+	if res != nil {
+		status = res.Status
+	}
+	if err = errorFromStatus(status, err); err != nil {
+		return "", err
+	}
+	return res.Config, nil
+}
+
+// GetEngineStats retrieves the internal statistics of the storage engine
+// of the DKV node, using the underlying GRPC GetEngineStats method.
+func (dkvClnt *DKVClient) GetEngineStats() (*serverpb.GetEngineStatsResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	res, err := dkvClnt.dkvAdmCli.GetEngineStats(ctx, &empty.Empty{})
+	var status *serverpb.Status
+	if res != nil {
+		status = res.Status
+	}
+	if err = errorFromStatus(status, err); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// accessTokenCreds sends the access token as a bearer token with every
+// request. Transport security is not required as the client is insecure.
+type accessTokenCreds string
+
+func (atc accessTokenCreds) GetRequestMetadata(_ context.Context, _ ...string) (map[string]string, error) {
+	return map[string]string{auth.AuthorizationHeader: auth.BearerPrefix + string(atc)}, nil
+}
+
+func (atc accessTokenCreds) RequireTransportSecurity() bool {
+	return false
+}
+
 // KVPair is convenience wrapper that captures a key and its value.
 type KVPair struct {
 	Key, Val []byte
@@ -243,6 +1138,33 @@ type KVPair struct {
 // be used to set the lower bound for the iteration.
 func (dkvClnt *DKVClient) Iterate(keyPrefix, startKey []byte) (<-chan *KVPair, error) {
 	iterReq := &serverpb.IterateRequest{KeyPrefix: keyPrefix, StartKey: startKey}
+	return dkvClnt.iterate(iterReq)
+}
+
+// IterateSnapshot is similar to Iterate except that it iterates through
+// the published snapshot with the given `namespace@name` reference.
+func (dkvClnt *DKVClient) IterateSnapshot(snapshot string, keyPrefix, startKey []byte) (<-chan *KVPair, error) {
+	iterReq := &serverpb.IterateRequest{KeyPrefix: keyPrefix, StartKey: startKey, Snapshot: snapshot}
+	return dkvClnt.iterate(iterReq)
+}
+
+// IterateWithReadOptions is similar to Iterate except that the given
+// options tune how the iteration is served by the storage engine, eg.,
+// skipping the block cache fill for large scans.
+func (dkvClnt *DKVClient) IterateWithReadOptions(readOpts *serverpb.ReadOptions, keyPrefix, startKey []byte) (<-chan *KVPair, error) {
+	iterReq := &serverpb.IterateRequest{KeyPrefix: keyPrefix, StartKey: startKey, ReadOptions: readOpts}
+	return dkvClnt.iterate(iterReq)
+}
+
+// IterateWithPinnedSnapshot is similar to Iterate except that the keys
+// are iterated as of a snapshot taken when the iteration begins, so that
+// a long iteration observes a single consistent view of the keyspace.
+func (dkvClnt *DKVClient) IterateWithPinnedSnapshot(keyPrefix, startKey []byte) (<-chan *KVPair, error) {
+	iterReq := &serverpb.IterateRequest{KeyPrefix: keyPrefix, StartKey: startKey, PinSnapshot: true}
+	return dkvClnt.iterate(iterReq)
+}
+
+func (dkvClnt *DKVClient) iterate(iterReq *serverpb.IterateRequest) (<-chan *KVPair, error) {
 	kvStrm, err := dkvClnt.dkvCli.Iterate(context.Background(), iterReq)
 	if err != nil {
 		return nil, err
@@ -262,6 +1184,75 @@ func (dkvClnt *DKVClient) Iterate(keyPrefix, startKey []byte) (<-chan *KVPair, e
 	return ch, nil
 }
 
+// Aggregate computes the aggregates of the keys having the given prefix
+// within the range from the given start key upto the given end key, using
+// the underlying GRPC Aggregate method. Empty start and end keys leave the
+// range unbounded. Since the aggregation takes time in proportion to the
+// number of keys, its deadline is left to the given context.
+func (dkvClnt *DKVClient) Aggregate(ctx context.Context, keyPrefix, startKey, endKey []byte) (*serverpb.AggregateResponse, error) {
+	aggReq := &serverpb.AggregateRequest{KeyPrefix: keyPrefix, StartKey: startKey, EndKey: endKey}
+	res, err := dkvClnt.dkvCli.Aggregate(ctx, aggReq)
+	var status *serverpb.Status
+	if res != nil {
+		status = res.Status
+	}
+	return res, errorFromStatus(status, err)
+}
+
+// Scan retrieves a page of up to the given number of key value pairs
+// having the given prefix, starting with the given key. The returned
+// continuation token must be passed along with the same prefix for
+// retrieving the next page and is empty once the scan is complete.
+func (dkvClnt *DKVClient) Scan(keyPrefix, startKey []byte, pageSize uint32, continuationToken string) ([]*serverpb.KVPair, string, error) {
+	scanReq := &serverpb.ScanRequest{KeyPrefix: keyPrefix, StartKey: startKey, PageSize: pageSize, ContinuationToken: continuationToken}
+	return dkvClnt.scan(scanReq)
+}
+
+// ScanSnapshot is similar to Scan except that it scans through the
+// published snapshot with the given `namespace@name` reference.
+func (dkvClnt *DKVClient) ScanSnapshot(snapshot string, keyPrefix, startKey []byte, pageSize uint32, continuationToken string) ([]*serverpb.KVPair, string, error) {
+	scanReq := &serverpb.ScanRequest{KeyPrefix: keyPrefix, StartKey: startKey, Snapshot: snapshot, PageSize: pageSize, ContinuationToken: continuationToken}
+	return dkvClnt.scan(scanReq)
+}
+
+// ScanWithReadOptions is similar to Scan except that the given options
+// tune how the scan is served by the storage engine. Only the options
+// passed along with the first page take effect.
+func (dkvClnt *DKVClient) ScanWithReadOptions(readOpts *serverpb.ReadOptions, keyPrefix, startKey []byte, pageSize uint32, continuationToken string) ([]*serverpb.KVPair, string, error) {
+	scanReq := &serverpb.ScanRequest{KeyPrefix: keyPrefix, StartKey: startKey, PageSize: pageSize, ContinuationToken: continuationToken, ReadOptions: readOpts}
+	return dkvClnt.scan(scanReq)
+}
+
+// ScanWithPinnedSnapshot is similar to Scan except that all the pages are
+// served from a snapshot taken for the first page, so that a long scan
+// observes a single consistent view of the keyspace. The snapshot is held
+// until the scan completes or its continuation token expires.
+func (dkvClnt *DKVClient) ScanWithPinnedSnapshot(keyPrefix, startKey []byte, pageSize uint32, continuationToken string) ([]*serverpb.KVPair, string, error) {
+	scanReq := &serverpb.ScanRequest{KeyPrefix: keyPrefix, StartKey: startKey, PageSize: pageSize, ContinuationToken: continuationToken, PinSnapshot: true}
+	return dkvClnt.scan(scanReq)
+}
+
+// CloseScan closes the cursor of the scan continued by the given token,
+// for abandoning the scan before retrieving all of its pages.
+func (dkvClnt *DKVClient) CloseScan(continuationToken string) error {
+	_, _, err := dkvClnt.scan(&serverpb.ScanRequest{ContinuationToken: continuationToken, CloseCursor: true})
+	return err
+}
+
+func (dkvClnt *DKVClient) scan(scanReq *serverpb.ScanRequest) ([]*serverpb.KVPair, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	res, err := dkvClnt.dkvCli.Scan(ctx, scanReq)
+	var status *serverpb.Status
+	if res != nil {
+		status = res.Status
+	}
+	if err = errorFromStatus(status, err); err != nil {
+		return nil, "", err
+	}
+	return res.Items, res.ContinuationToken, nil
+}
+
 // Close closes the underlying GRPC client connection to DKV service
 func (dkvClnt *DKVClient) Close() error {
 	if dkvClnt.cliConn != nil {
@@ -273,7 +1264,7 @@ func (dkvClnt *DKVClient) Close() error {
 func errorFromStatus(res *serverpb.Status, err error) error {
 	switch {
 	case err != nil:
-		return err
+		return replicaReadOnlyError(err)
 	case res != nil && res.Code != 0:
 		return errors.New(res.Message)
 	default:
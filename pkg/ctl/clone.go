@@ -0,0 +1,257 @@
+package ctl
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+)
+
+// CloneOptions captures the settings of a Cloner.
+type CloneOptions struct {
+	// KeyPrefix is the prefix of the keys cloned. All the keys are cloned
+	// when empty.
+	KeyPrefix []byte
+	// PageSize is the number of keys copied at a time. Defaults to 1000.
+	PageSize uint32
+	// BatchSize is the maximum number of changes retrieved at a time while
+	// following the source. Defaults to 1000.
+	BatchSize uint32
+}
+
+const (
+	defaultClonePageSize  = 1000
+	defaultCloneBatchSize = 1000
+)
+
+// Cloner clones the keys matching a prefix from a source DKV cluster onto
+// a target DKV cluster. The keys are first copied from a snapshot of the
+// source, after which the changes committed on the source since the copy
+// began are followed, keeping the target in sync until cutover. Reserved
+// keys internal to DKV are never cloned. A Cloner is not safe for
+// concurrent use.
+type Cloner struct {
+	source, target *DKVClient
+	opts           CloneOptions
+	// Highest fencing epoch of the source observed while following
+	fencingEpoch uint64
+}
+
+// NewCloner creates a Cloner from the given source onto the given target.
+func NewCloner(source, target *DKVClient, opts CloneOptions) *Cloner {
+	if opts.PageSize == 0 {
+		opts.PageSize = defaultClonePageSize
+	}
+	if opts.BatchSize == 0 {
+		opts.BatchSize = defaultCloneBatchSize
+	}
+	return &Cloner{source: source, target: target, opts: opts}
+}
+
+// Copy copies the matching keys from a snapshot of the source onto the
+// target, a page at a time using the MultiPut method, until the given
+// context is done. It returns the number of keys copied along with the
+// change number of the source preceding the snapshot, the changes beyond
+// which must be followed for the target to catch up. Changes committed
+// in between the two are applied again when followed, which converges
+// since the target ends up with the latest value of every changed key.
+func (c *Cloner) Copy(ctx context.Context) (int, uint64, error) {
+	// Asking for the changes beyond any committed change retrieves only
+	// the latest change number of the source
+	res, err := c.source.GetChanges(math.MaxUint64, 1)
+	var status *serverpb.Status
+	if res != nil {
+		status = res.Status
+	}
+	if err = errorFromStatus(status, err); err != nil {
+		return 0, 0, fmt.Errorf("unable to get the latest change number of the source: %v", err)
+	}
+	chngNum := res.MasterChangeNumber
+
+	var numKeys int
+	token := ""
+	for {
+		if err = ctx.Err(); err != nil {
+			return numKeys, chngNum, err
+		}
+		kvs, nextToken, err := c.source.ScanWithPinnedSnapshot(c.opts.KeyPrefix, nil, c.opts.PageSize, token)
+		if err != nil {
+			return numKeys, chngNum, fmt.Errorf("unable to scan the source: %v", err)
+		}
+		puts := kvs[:0]
+		for _, kv := range kvs {
+			if !storage.IsReservedKey(kv.Key) {
+				puts = append(puts, kv)
+			}
+		}
+		if len(puts) > 0 {
+			if err = c.target.MultiPut(puts...); err != nil {
+				return numKeys, chngNum, fmt.Errorf("unable to copy %d keys onto the target: %v", len(puts), err)
+			}
+			numKeys += len(puts)
+		}
+		if nextToken == "" {
+			return numKeys, chngNum, nil
+		}
+		token = nextToken
+	}
+}
+
+// Sync applies the next batch of the changes of the source beyond the
+// given change number onto the target. It returns the change number upto
+// which the changes are applied, along with the latest change number of
+// the source, which are the same once the target has caught up.
+func (c *Cloner) Sync(fromChngNum uint64) (uint64, uint64, error) {
+	res, err := c.source.GetChanges(fromChngNum+1, c.opts.BatchSize)
+	var status *serverpb.Status
+	if res != nil {
+		status = res.Status
+	}
+	if err = errorFromStatus(status, err); err != nil {
+		return fromChngNum, 0, err
+	}
+	if res.FencingEpoch < c.fencingEpoch {
+		return fromChngNum, res.MasterChangeNumber, fmt.Errorf("%w: %d, highest observed epoch: %d", storage.ErrStaleFencingEpoch, res.FencingEpoch, c.fencingEpoch)
+	}
+	c.fencingEpoch = res.FencingEpoch
+	if err = DecodeChangeRecords(res.Changes); err != nil {
+		return fromChngNum, res.MasterChangeNumber, err
+	}
+
+	applied := fromChngNum
+	for _, chng := range res.Changes {
+		if chng.ChangeNumber <= applied {
+			continue
+		}
+		if err = c.apply(chng); err != nil {
+			return applied, res.MasterChangeNumber, fmt.Errorf("unable to apply change number %d onto the target: %v", chng.ChangeNumber, err)
+		}
+		applied = chng.ChangeNumber
+	}
+	return applied, res.MasterChangeNumber, nil
+}
+
+// Follow keeps applying the changes of the source beyond the given change
+// number onto the target until the given context is done, which is the
+// cutover, polling for more changes once every given interval after
+// catching up. Upon cutover, it returns only once caught up with the
+// changes committed on the source so far. The given handler, if any, is invoked after every batch
+// with the change number upto which the changes are applied and the latest
+// change number of the source. It returns the change number upto which
+// the changes are applied, from which following can be resumed later.
+func (c *Cloner) Follow(ctx context.Context, fromChngNum uint64, pollInterval time.Duration, hndlr func(applied, latest uint64)) (uint64, error) {
+	applied := fromChngNum
+	for {
+		next, latest, err := c.Sync(applied)
+		if err != nil {
+			return next, err
+		}
+		caughtUp := next == applied
+		if applied = next; hndlr != nil {
+			hndlr(applied, latest)
+		}
+		if !caughtUp {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return applied, nil
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// apply writes the given change of the source onto the target, for the
+// matching keys. Merges are applied by copying the latest value of the key
+// from the source, since replaying the operands of the changes preceding
+// the snapshot of the copy would merge them twice. The writes of a change
+// are applied atomically using a single MultiPut, or a transaction when
+// the change also deletes keys. Changes deleting keys along with writing
+// keys with an expiry, which transactions cannot express, are applied in
+// their order a batch of puts at a time.
+func (c *Cloner) apply(chng *serverpb.ChangeRecord) error {
+	var writes []*serverpb.TxnWrite
+	var puts []*serverpb.KVPair
+	var hasDeletes, hasExpiry bool
+	for _, trxn := range chng.Trxns {
+		if storage.IsReservedKey(trxn.Key) || !bytes.HasPrefix(trxn.Key, c.opts.KeyPrefix) {
+			continue
+		}
+		kv := &serverpb.KVPair{Key: trxn.Key, Value: trxn.Value, ExpireTS: trxn.ExpireTS}
+		switch trxn.Type {
+		case serverpb.TrxnRecord_Put:
+		case serverpb.TrxnRecord_Delete:
+			kv = nil
+		case serverpb.TrxnRecord_Merge:
+			var err error
+			if kv, err = c.getSource(trxn.Key); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unknown transaction type %s of key %q", trxn.Type, trxn.Key)
+		}
+		if kv == nil {
+			hasDeletes = true
+			writes = append(writes, &serverpb.TxnWrite{Key: trxn.Key, Delete: true})
+			puts = append(puts, nil)
+			continue
+		}
+		hasExpiry = hasExpiry || kv.ExpireTS > 0
+		writes = append(writes, &serverpb.TxnWrite{Key: kv.Key, Value: kv.Value})
+		puts = append(puts, kv)
+	}
+
+	switch {
+	case len(writes) == 0:
+		return nil
+	case !hasDeletes:
+		return c.target.MultiPut(puts...)
+	case !hasExpiry:
+		txn := c.target.NewTxn()
+		for _, write := range writes {
+			txn.write(write)
+		}
+		return txn.Commit()
+	}
+	var batch []*serverpb.KVPair
+	for i, kv := range puts {
+		if kv != nil {
+			batch = append(batch, kv)
+			continue
+		}
+		if len(batch) > 0 {
+			if err := c.target.MultiPut(batch...); err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+		if err := c.target.Delete(writes[i].Key); err != nil {
+			return err
+		}
+	}
+	if len(batch) > 0 {
+		return c.target.MultiPut(batch...)
+	}
+	return nil
+}
+
+// getSource loads the latest value of the given key from the source along
+// with its expiry, returning nil if the key is absent.
+func (c *Cloner) getSource(key []byte) (*serverpb.KVPair, error) {
+	res, err := c.source.Get(serverpb.ReadConsistency_LINEARIZABLE, key)
+	var status *serverpb.Status
+	if res != nil {
+		status = res.Status
+	}
+	if err = errorFromStatus(status, err); err != nil {
+		return nil, err
+	}
+	if res.NotFound {
+		return nil, nil
+	}
+	return &serverpb.KVPair{Key: key, Value: res.Value, ExpireTS: res.ExpireTS}, nil
+}
@@ -0,0 +1,83 @@
+package ctl
+
+import (
+	"context"
+
+	"github.com/flipkart-incubator/dkv/internal/compression"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+const (
+	// GzipCompressor compresses the messages using gzip.
+	GzipCompressor = compression.Gzip
+	// ZstdCompressor compresses the messages using zstd, which typically
+	// compresses better than gzip at a fraction of its cost.
+	ZstdCompressor = compression.Zstd
+)
+
+// WithCompression makes the client compress its requests using the given
+// compressor, which must be one of GzipCompressor or ZstdCompressor, or
+// be empty for no compression. DKV services compress their responses to
+// such a client using the same compressor, including the streamed
+// responses. Calls made with a context returned by WithoutCompression are
+// not compressed, which suits the values that are already compressed.
+func WithCompression(compressor string) ClientOption {
+	return func(opts *clientOptions) error {
+		if err := compression.Validate(compressor); err != nil {
+			return err
+		}
+		if compressor == "" {
+			return nil
+		}
+		return withDialOptions(
+			grpc.WithDefaultCallOptions(grpc.UseCompressor(compressor)),
+			grpc.WithChainUnaryInterceptor(skipCompression),
+			grpc.WithChainStreamInterceptor(skipStreamCompression))(opts)
+	}
+}
+
+type noCompressionKey struct{}
+
+// WithoutCompression returns a context for making a call without any
+// compression, using a client that otherwise compresses its calls.
+func WithoutCompression(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noCompressionKey{}, true)
+}
+
+// PutWithoutCompression is same as Put, except that the request is not
+// compressed regardless of the compressor of this client, which suits the
+// values that are already compressed.
+func (dkvClnt *DKVClient) PutWithoutCompression(key []byte, value []byte) error {
+	ctx, cancel := context.WithTimeout(WithoutCompression(context.Background()), Timeout)
+	defer cancel()
+	putReq := &serverpb.PutRequest{Key: key, Value: value}
+	res, err := dkvClnt.dkvCli.Put(ctx, putReq)
+	var status *serverpb.Status
+	if res != nil {
+		status = res.Status
+	}
+	return errorFromStatus(status, err)
+}
+
+func compressionSkipped(ctx context.Context) bool {
+	skip, _ := ctx.Value(noCompressionKey{}).(bool)
+	return skip
+}
+
+func skipCompression(ctx context.Context, method string, req, reply interface{},
+	cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if compressionSkipped(ctx) {
+		opts = append(opts, grpc.UseCompressor(encoding.Identity))
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+func skipStreamCompression(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn,
+	method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	if compressionSkipped(ctx) {
+		opts = append(opts, grpc.UseCompressor(encoding.Identity))
+	}
+	return streamer(ctx, desc, cc, method, opts...)
+}
@@ -0,0 +1,160 @@
+package ctl
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+)
+
+// KeyChecksum is a convenience wrapper that captures a key along with
+// the checksum of its value and expiry.
+type KeyChecksum struct {
+	Key      []byte
+	Checksum uint64
+	ErrMsg   string
+}
+
+// ChecksumScan invokes the underlying GRPC method for scanning through
+// the keyspace in the ascending order of the keys, retrieving the keys
+// along with the checksums of their values. `keyPrefix` can be used to
+// select only the keys matching the given prefix and `startKey` can be
+// used to set the lower bound for the scan.
+func (dkvClnt *DKVClient) ChecksumScan(keyPrefix, startKey []byte) (<-chan *KeyChecksum, error) {
+	scanReq := &serverpb.ChecksumScanRequest{KeyPrefix: keyPrefix, StartKey: startKey}
+	return dkvClnt.checksumScan(context.Background(), scanReq)
+}
+
+func (dkvClnt *DKVClient) checksumScan(ctx context.Context, scanReq *serverpb.ChecksumScanRequest) (<-chan *KeyChecksum, error) {
+	scanStrm, err := dkvClnt.dkvCli.ChecksumScan(ctx, scanReq)
+	if err != nil {
+		return nil, err
+	}
+	ch := make(chan *KeyChecksum)
+	go func() {
+		defer close(ch)
+		for {
+			scanRes, err := scanStrm.Recv()
+			switch {
+			case err == io.EOF:
+				return
+			case err != nil:
+				ch <- &KeyChecksum{ErrMsg: err.Error()}
+				return
+			case scanRes.Status != nil && scanRes.Status.Code != 0:
+				ch <- &KeyChecksum{ErrMsg: scanRes.Status.Message}
+				return
+			default:
+				ch <- &KeyChecksum{Key: scanRes.Key, Checksum: scanRes.Checksum}
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// DiffKind classifies how a key differs between two DKV nodes.
+type DiffKind int
+
+const (
+	// KeyChanged indicates that the key is present on both the nodes
+	// but with a different value or expiry.
+	KeyChanged DiffKind = iota
+	// KeyMissing indicates that the key is present only on the source.
+	KeyMissing
+	// KeyExtra indicates that the key is present only on the target.
+	KeyExtra
+)
+
+func (dk DiffKind) String() string {
+	switch dk {
+	case KeyChanged:
+		return "CHANGED"
+	case KeyMissing:
+		return "MISSING"
+	case KeyExtra:
+		return "EXTRA"
+	default:
+		return fmt.Sprintf("DiffKind(%d)", int(dk))
+	}
+}
+
+// Diff compares the keys matching the given prefix held by the source
+// and the target DKV nodes, invoking the given handler for every key
+// that differs between them in the ascending order of the keys. Only
+// the keys and the checksums of their values are streamed from either
+// node, each from a snapshot pinned when its scan begins. Hence writes
+// that land on the nodes during the comparison are not reported.
+func Diff(source, target *DKVClient, keyPrefix []byte, hndlr func(key []byte, kind DiffKind)) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Drains a stream upon an early return so that its goroutine
+	// is not blocked forever on the cancelled stream
+	drain := func(ch <-chan *KeyChecksum) {
+		cancel()
+		for range ch {
+		}
+	}
+	scanReq := &serverpb.ChecksumScanRequest{KeyPrefix: keyPrefix, PinSnapshot: true}
+	srcCh, err := source.checksumScan(ctx, scanReq)
+	if err != nil {
+		return err
+	}
+	defer drain(srcCh)
+	tgtCh, err := target.checksumScan(ctx, scanReq)
+	if err != nil {
+		return err
+	}
+	defer drain(tgtCh)
+
+	next := func(ch <-chan *KeyChecksum, node string) (*KeyChecksum, error) {
+		kc, ok := <-ch
+		if !ok {
+			return nil, nil
+		}
+		if kc.ErrMsg != "" {
+			return nil, fmt.Errorf("unable to scan the %s node. Error: %s", node, kc.ErrMsg)
+		}
+		return kc, nil
+	}
+	src, err := next(srcCh, "source")
+	if err != nil {
+		return err
+	}
+	tgt, err := next(tgtCh, "target")
+	if err != nil {
+		return err
+	}
+	for src != nil || tgt != nil {
+		var cmp int
+		switch {
+		case src == nil:
+			cmp = 1
+		case tgt == nil:
+			cmp = -1
+		default:
+			cmp = bytes.Compare(src.Key, tgt.Key)
+		}
+		switch {
+		case cmp < 0:
+			hndlr(src.Key, KeyMissing)
+		case cmp > 0:
+			hndlr(tgt.Key, KeyExtra)
+		case src.Checksum != tgt.Checksum:
+			hndlr(src.Key, KeyChanged)
+		}
+		if cmp <= 0 {
+			if src, err = next(srcCh, "source"); err != nil {
+				return err
+			}
+		}
+		if cmp >= 0 {
+			if tgt, err = next(tgtCh, "target"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
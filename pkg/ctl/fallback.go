@@ -0,0 +1,87 @@
+package ctl
+
+import (
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FallbackReader reads the keys from a slave, retrying the reads on its
+// master when the keys are missing on the slave or the slave is yet to
+// apply the writes observed by the session of the reads. Hence the keys
+// written recently are read correctly, without serving all the reads from
+// the master. Keys deleted on the master are read from the master too,
+// until their deletes are applied by the slave.
+type FallbackReader struct {
+	slave, master *DKVClient
+}
+
+// NewFallbackReader creates a FallbackReader reading from the given slave,
+// falling back to the given master.
+func NewFallbackReader(slave, master *DKVClient) *FallbackReader {
+	return &FallbackReader{slave, master}
+}
+
+// Get loads the value of the given key from the slave, or from the master
+// if it is missing on the slave. The given session, if any, is used for
+// the read from the slave so that the reads awaiting its writes fall back
+// to the master as well.
+func (fr *FallbackReader) Get(sess *Session, rc serverpb.ReadConsistency, key []byte) (*serverpb.GetResponse, error) {
+	var res *serverpb.GetResponse
+	var err error
+	if sess != nil {
+		res, err = sess.Get(fr.slave, rc, key)
+	} else {
+		res, err = fr.slave.Get(rc, key)
+	}
+	switch {
+	case err == nil && len(res.Value) > 0:
+		return res, nil
+	case err != nil && status.Code(err) != codes.FailedPrecondition:
+		return res, err
+	}
+	return fr.master.Get(rc, key)
+}
+
+// MultiGet is similar to Get except that it loads the values of all the
+// given keys, reading only the keys missing on the slave from the master.
+func (fr *FallbackReader) MultiGet(sess *Session, rc serverpb.ReadConsistency, keys ...[]byte) ([]*serverpb.KVPair, error) {
+	var kvs []*serverpb.KVPair
+	var err error
+	if sess != nil {
+		kvs, err = sess.MultiGet(fr.slave, rc, keys...)
+	} else {
+		kvs, err = fr.slave.MultiGet(rc, keys...)
+	}
+	switch {
+	case err != nil && status.Code(err) == codes.FailedPrecondition:
+		return fr.master.MultiGet(rc, keys...)
+	case err != nil:
+		return nil, err
+	}
+
+	present := make(map[string]bool, len(kvs))
+	for _, kv := range kvs {
+		present[string(kv.Key)] = len(kv.Value) > 0
+	}
+	var missingKeys [][]byte
+	for _, key := range keys {
+		if !present[string(key)] {
+			missingKeys = append(missingKeys, key)
+		}
+	}
+	if len(missingKeys) == 0 {
+		return kvs, nil
+	}
+	masterKVs, err := fr.master.MultiGet(rc, missingKeys...)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*serverpb.KVPair, 0, len(kvs)+len(masterKVs))
+	for _, kv := range kvs {
+		if present[string(kv.Key)] {
+			results = append(results, kv)
+		}
+	}
+	return append(results, masterKVs...), nil
+}
@@ -0,0 +1,84 @@
+package ctl
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const defaultHooksRetryBackoff = 100 * time.Millisecond
+
+// ClientHooks are invoked around the unary calls made by a DKVClient, so
+// that the applications can plug in their own metrics, logging and tracing.
+// Every hook is optional and must be safe for concurrent use. Methods are
+// the fully qualified GRPC method names, such as `/dkv.serverpb.DKV/Get`.
+type ClientHooks struct {
+	// OnRequest is invoked before every call with its context, returning
+	// the context with which the call is made, so that the tracers can
+	// attach their spans and propagate them through the GRPC metadata.
+	OnRequest func(ctx context.Context, method string, req interface{}) context.Context
+	// OnResponse is invoked once every call completes, including all its
+	// retries, with the time taken and the error of the call. Failures
+	// reported by the DKV node are carried in the status of the reply.
+	OnResponse func(ctx context.Context, method string, req, reply interface{}, err error, elapsed time.Duration)
+	// OnRetry is invoked before every retry of a call, with the number of
+	// the attempt to follow, starting at 2, and the error that failed the
+	// preceding attempt.
+	OnRetry func(ctx context.Context, method string, attempt int, cause error)
+	// MaxRetries is the number of times the calls failing as unavailable
+	// are retried, within the timeout of the call. Since such calls may
+	// have reached the DKV node, retries must be enabled only when all the
+	// writes made using the client are idempotent. Defaults to 0, which
+	// disables the retries.
+	MaxRetries int
+	// RetryBackoff is the duration awaited before the first retry of a
+	// call, doubled for every subsequent retry. Defaults to 100ms.
+	RetryBackoff time.Duration
+}
+
+// WithHooks makes the unary calls of the client invoke the given hooks.
+// Streaming calls are neither instrumented nor retried.
+func WithHooks(hooks ClientHooks) ClientOption {
+	return func(opts *clientOptions) error {
+		switch {
+		case hooks.MaxRetries < 0:
+			return errors.New("max retries must not be negative")
+		case hooks.RetryBackoff < 0:
+			return errors.New("retry backoff must not be negative")
+		}
+		if hooks.RetryBackoff == 0 {
+			hooks.RetryBackoff = defaultHooksRetryBackoff
+		}
+		return withDialOptions(grpc.WithChainUnaryInterceptor(hooks.intercept))(opts)
+	}
+}
+
+func (h ClientHooks) intercept(ctx context.Context, method string, req, reply interface{},
+	cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if h.OnRequest != nil {
+		ctx = h.OnRequest(ctx, method, req)
+	}
+	start := time.Now()
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	backoff := h.RetryBackoff
+	for attempt := 2; attempt <= h.MaxRetries+1 && status.Code(err) == codes.Unavailable; attempt++ {
+		if h.OnRetry != nil {
+			h.OnRetry(ctx, method, attempt, err)
+		}
+		select {
+		case <-time.After(backoff):
+			err = invoker(ctx, method, req, reply, cc, opts...)
+		case <-ctx.Done():
+			err = status.FromContextError(ctx.Err()).Err()
+		}
+		backoff *= 2
+	}
+	if h.OnResponse != nil {
+		h.OnResponse(ctx, method, req, reply, err, time.Since(start))
+	}
+	return err
+}
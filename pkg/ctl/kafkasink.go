@@ -0,0 +1,157 @@
+package ctl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// KafkaEnvelope is the format in which the changes are published to Kafka.
+type KafkaEnvelope string
+
+const (
+	// KafkaProtoEnvelope publishes every change as a binary ChangeRecord.
+	KafkaProtoEnvelope KafkaEnvelope = "proto"
+	// KafkaJSONEnvelope publishes every change as a ChangeRecord in the
+	// canonical JSON form of protobuf, with the keys and values in base64.
+	KafkaJSONEnvelope KafkaEnvelope = "json"
+)
+
+// KafkaMessage is a message published to a Kafka topic.
+type KafkaMessage struct {
+	// Key is the key of the changed DKV key, by which the messages are
+	// partitioned so that the changes of a key are consumed in order.
+	Key   []byte
+	Value []byte
+}
+
+// A KafkaProducer publishes messages to a Kafka topic, as offered by the
+// Kafka client libraries, which are left to the binaries embedding the
+// sink. SendMessages must return only once all the given messages are
+// acknowledged by the brokers, with the messages of a key published in
+// the given order.
+type KafkaProducer interface {
+	SendMessages(msgs []*KafkaMessage) error
+}
+
+// KafkaSinkOptions captures the settings of a KafkaSink.
+type KafkaSinkOptions struct {
+	// Envelope is the format of the published changes. Defaults to
+	// KafkaProtoEnvelope.
+	Envelope KafkaEnvelope
+	// WatermarkFile is the file tracking the change number upto which the
+	// changes are published, which is created unless it already exists.
+	WatermarkFile string
+	// BatchSize is the maximum number of changes published at a time.
+	// Defaults to 1000.
+	BatchSize uint32
+}
+
+const defaultKafkaSinkBatchSize = 1000
+
+// KafkaSink publishes the change stream of a DKV node to a Kafka topic,
+// for the downstream consumers such as search indexers and caches. Every
+// write of a key in a change is published as a ChangeRecord of its own,
+// holding just that write and keyed by that key. The watermark of the
+// sink is advanced only after a batch of changes is acknowledged by
+// Kafka, hence every change is published at least once, with the changes
+// published again after a failure or a restart of the sink. Consumers
+// can discard such duplicates using the change numbers of the records.
+// Changes served by a master carrying a stale fencing epoch are rejected.
+//
+// Changes carry the values as stored by DKV, hence values encrypted at rest
+// are published encrypted, while merges are published as their operands.
+// Reserved keys internal to DKV are never published.
+type KafkaSink struct {
+	tailer   *changeTailer
+	producer KafkaProducer
+	opts     KafkaSinkOptions
+}
+
+// NewKafkaSink creates a KafkaSink publishing the changes retrieved using
+// the given client through the given producer.
+func NewKafkaSink(client *DKVClient, producer KafkaProducer, opts KafkaSinkOptions) (*KafkaSink, error) {
+	switch opts.Envelope {
+	case "":
+		opts.Envelope = KafkaProtoEnvelope
+	case KafkaProtoEnvelope, KafkaJSONEnvelope:
+	default:
+		return nil, fmt.Errorf("unknown Kafka envelope: %s, must be one of proto|json", opts.Envelope)
+	}
+	if opts.WatermarkFile == "" {
+		return nil, errors.New("watermark file of the Kafka sink must be provided")
+	}
+	if opts.BatchSize == 0 {
+		opts.BatchSize = defaultKafkaSinkBatchSize
+	}
+	tailer := &changeTailer{client: client, sink: "Kafka sink", watermarkFile: opts.WatermarkFile, batchSize: opts.BatchSize}
+	return &KafkaSink{tailer, producer, opts}, nil
+}
+
+// Watermark retrieves the change number upto which the changes have
+// been published by this sink.
+func (ks *KafkaSink) Watermark() (uint64, error) {
+	return ks.tailer.watermark()
+}
+
+// Sync publishes the next batch of changes beyond the watermark, returning
+// the number of changes published. Changes are published together, hence
+// none of them is published when the batch fails to be published.
+func (ks *KafkaSink) Sync(_ context.Context) (int, error) {
+	return ks.tailer.sync(ks.publish)
+}
+
+// Run keeps publishing the changes until the given context is done, polling
+// for more changes once every given interval after catching up.
+func (ks *KafkaSink) Run(ctx context.Context, pollInterval time.Duration) error {
+	return ks.tailer.run(ctx, pollInterval, ks.Sync)
+}
+
+// publish publishes every write of the given changes as a message.
+func (ks *KafkaSink) publish(chngs []*serverpb.ChangeRecord) (int, error) {
+	var msgs []*KafkaMessage
+	for _, chng := range chngs {
+		for _, trxn := range chng.Trxns {
+			if storage.IsReservedKey(trxn.Key) {
+				continue
+			}
+			msg, err := ks.envelope(chng, trxn)
+			if err != nil {
+				return 0, fmt.Errorf("unable to publish change number %d: %v", chng.ChangeNumber, err)
+			}
+			msgs = append(msgs, msg)
+		}
+	}
+	if len(msgs) > 0 {
+		if err := ks.producer.SendMessages(msgs); err != nil {
+			return 0, fmt.Errorf("unable to publish the changes to Kafka: %v", err)
+		}
+	}
+	return len(chngs), nil
+}
+
+// envelope wraps the given write of the given change into a message.
+func (ks *KafkaSink) envelope(chng *serverpb.ChangeRecord, trxn *serverpb.TrxnRecord) (*KafkaMessage, error) {
+	rec := &serverpb.ChangeRecord{ChangeNumber: chng.ChangeNumber, NumberOfTrxns: 1,
+		Trxns: []*serverpb.TrxnRecord{trxn}, FencingEpoch: chng.FencingEpoch}
+	var (
+		value []byte
+		err   error
+	)
+	switch ks.opts.Envelope {
+	case KafkaJSONEnvelope:
+		value, err = protojson.Marshal(rec)
+	default:
+		value, err = proto.Marshal(rec)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &KafkaMessage{Key: trxn.Key, Value: value}, nil
+}
@@ -0,0 +1,260 @@
+package ctl
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/flipkart-incubator/dkv/internal/keypolicy"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"github.com/golang/protobuf/ptypes/empty"
+)
+
+// ErrInvalidKey is matched by the errors of the keys or the values of
+// their segments that do not conform to their key policy.
+var ErrInvalidKey = errors.New("key does not conform to its key policy")
+
+// Strings are terminated within the keys by a zero byte followed by
+// stringTerminator, with their zero bytes followed by stringEscape, so
+// that the keys sort by the strings of their segments.
+const (
+	stringTerminator = 0x01
+	stringEscape     = 0xff
+)
+
+// GetKeyPolicies retrieves the key policies published by the DKV node
+// using the underlying GRPC GetKeyPolicies method.
+func (dkvClnt *DKVClient) GetKeyPolicies() ([]*serverpb.KeyPolicy, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	res, err := dkvClnt.dkvAdmCli.GetKeyPolicies(ctx, &empty.Empty{})
+	var status *serverpb.Status
+	if res != nil {
+		status = res.Status
+	}
+	if err = errorFromStatus(status, err); err != nil {
+		return nil, err
+	}
+	return res.KeyPolicies, nil
+}
+
+// KeyBuilder builds the keys of a namespace as per its key policy and
+// validates them against it. Keys are made of the namespace, the version
+// of the policy and the values of its segments in order, all of which are
+// encoded such that the keys sort by the values of their segments. Hence
+// the keys sharing their leading segments are iterated over using the
+// prefix built from them. Keys are built as per the latest version of the
+// policy, while the keys of every version given are parsed as per their
+// version, so that the keys are migrated to a new version gradually.
+type KeyBuilder struct {
+	policy   *serverpb.KeyPolicy
+	versions map[uint32]*serverpb.KeyPolicy
+}
+
+// NewKeyBuilder creates a KeyBuilder for the keys of the given versions of
+// the key policy of a namespace.
+func NewKeyBuilder(policies ...*serverpb.KeyPolicy) (*KeyBuilder, error) {
+	if err := keypolicy.ValidateVersions(policies); err != nil {
+		return nil, err
+	}
+	kb := &KeyBuilder{versions: make(map[uint32]*serverpb.KeyPolicy, len(policies))}
+	for _, policy := range policies {
+		if kb.policy == nil || policy.Version > kb.policy.Version {
+			kb.policy = policy
+		}
+		kb.versions[policy.Version] = policy
+	}
+	return kb, nil
+}
+
+// NewKeyBuilder creates a KeyBuilder for the keys of the given namespace,
+// as per all the versions of the key policy published for it by the DKV
+// node.
+func (dkvClnt *DKVClient) NewKeyBuilder(namespace string) (*KeyBuilder, error) {
+	policies, err := dkvClnt.GetKeyPolicies()
+	if err != nil {
+		return nil, err
+	}
+	var nsPolicies []*serverpb.KeyPolicy
+	for _, policy := range policies {
+		if policy.Namespace == namespace {
+			nsPolicies = append(nsPolicies, policy)
+		}
+	}
+	if len(nsPolicies) == 0 {
+		return nil, fmt.Errorf("no key policy is published for namespace %s", namespace)
+	}
+	return NewKeyBuilder(nsPolicies...)
+}
+
+// Policy retrieves the latest version of the key policy, as per which the
+// keys are built.
+func (kb *KeyBuilder) Policy() *serverpb.KeyPolicy {
+	return kb.policy
+}
+
+// Build builds the key from the given values of all the segments, in
+// their order. Values of the string segments must be strings, while those
+// of the integer segments must be integers of the same signedness.
+func (kb *KeyBuilder) Build(values ...interface{}) ([]byte, error) {
+	if len(values) != len(kb.policy.Segments) {
+		return nil, fmt.Errorf("%w: namespace %s has %d segments, but got %d values", ErrInvalidKey, kb.policy.Namespace, len(kb.policy.Segments), len(values))
+	}
+	return kb.Prefix(values...)
+}
+
+// Prefix builds the prefix shared by all the keys whose leading segments
+// have the given values. Without any values, the prefix is shared by all
+// the keys of the current version of the policy.
+func (kb *KeyBuilder) Prefix(values ...interface{}) ([]byte, error) {
+	if len(values) > len(kb.policy.Segments) {
+		return nil, fmt.Errorf("%w: namespace %s has %d segments, but got %d values", ErrInvalidKey, kb.policy.Namespace, len(kb.policy.Segments), len(values))
+	}
+	key := append([]byte(kb.policy.Namespace), byte(kb.policy.Version))
+	for i, val := range values {
+		seg := kb.policy.Segments[i]
+		var err error
+		if key, err = appendSegment(key, seg.Type, val); err != nil {
+			return nil, fmt.Errorf("%w: segment %s of namespace %s %v", ErrInvalidKey, seg.Name, kb.policy.Namespace, err)
+		}
+	}
+	return key, nil
+}
+
+// Parse validates the given key against the version of the key policy
+// given by the key and retrieves the values of its segments, in their
+// order within that version. Values of the string segments are strings,
+// while those of the integer segments are uint64 or int64.
+func (kb *KeyBuilder) Parse(key []byte) ([]interface{}, error) {
+	_, values, err := kb.ParseVersion(key)
+	return values, err
+}
+
+// ParseVersion validates the given key like Parse, additionally retrieving
+// the version of the key policy of the key.
+func (kb *KeyBuilder) ParseVersion(key []byte) (*serverpb.KeyPolicy, []interface{}, error) {
+	ns := kb.policy.Namespace
+	if !bytes.HasPrefix(key, []byte(ns)) || len(key) == len(ns) {
+		return nil, nil, fmt.Errorf("%w: key %q is not of namespace %s", ErrInvalidKey, key, ns)
+	}
+	policy, ok := kb.versions[uint32(key[len(ns)])]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: key %q is of version %d of namespace %s, which has no key policy", ErrInvalidKey, key, key[len(ns)], ns)
+	}
+	rest := key[len(ns)+1:]
+	values := make([]interface{}, len(policy.Segments))
+	for i, seg := range policy.Segments {
+		var err error
+		if values[i], rest, err = parseSegment(rest, seg.Type); err != nil {
+			return nil, nil, fmt.Errorf("%w: segment %s of key %q %v", ErrInvalidKey, seg.Name, key, err)
+		}
+	}
+	if len(rest) > 0 {
+		return nil, nil, fmt.Errorf("%w: key %q has %d bytes beyond its segments", ErrInvalidKey, key, len(rest))
+	}
+	return policy, values, nil
+}
+
+// Validate checks if the given key conforms to the version of the key
+// policy given by the key.
+func (kb *KeyBuilder) Validate(key []byte) error {
+	_, err := kb.Parse(key)
+	return err
+}
+
+func appendSegment(key []byte, segType serverpb.KeySegmentType, val interface{}) ([]byte, error) {
+	switch segType {
+	case serverpb.KeySegmentType_STRING_SEGMENT:
+		str, ok := val.(string)
+		if !ok {
+			return nil, fmt.Errorf("must be a string, but got %T", val)
+		}
+		for i := 0; i < len(str); i++ {
+			if key = append(key, str[i]); str[i] == 0 {
+				key = append(key, stringEscape)
+			}
+		}
+		return append(key, 0, stringTerminator), nil
+	case serverpb.KeySegmentType_UINT64_SEGMENT:
+		var u uint64
+		switch v := val.(type) {
+		case uint:
+			u = uint64(v)
+		case uint8:
+			u = uint64(v)
+		case uint16:
+			u = uint64(v)
+		case uint32:
+			u = uint64(v)
+		case uint64:
+			u = v
+		default:
+			return nil, fmt.Errorf("must be an unsigned integer, but got %T", val)
+		}
+		return appendUint64(key, u), nil
+	case serverpb.KeySegmentType_INT64_SEGMENT:
+		var i int64
+		switch v := val.(type) {
+		case int:
+			i = int64(v)
+		case int8:
+			i = int64(v)
+		case int16:
+			i = int64(v)
+		case int32:
+			i = int64(v)
+		case int64:
+			i = v
+		default:
+			return nil, fmt.Errorf("must be a signed integer, but got %T", val)
+		}
+		// Flipping the sign bit orders the negative integers first
+		return appendUint64(key, uint64(i)^(1<<63)), nil
+	default:
+		return nil, fmt.Errorf("is of an unknown type %d", segType)
+	}
+}
+
+func appendUint64(key []byte, u uint64) []byte {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], u)
+	return append(key, buf[:]...)
+}
+
+// parseSegment parses the value of a segment of the given type from the
+// start of the given bytes, along with the bytes that follow it.
+func parseSegment(data []byte, segType serverpb.KeySegmentType) (interface{}, []byte, error) {
+	switch segType {
+	case serverpb.KeySegmentType_STRING_SEGMENT:
+		var str []byte
+		for i := 0; i+1 < len(data); i++ {
+			if data[i] != 0 {
+				str = append(str, data[i])
+				continue
+			}
+			switch data[i+1] {
+			case stringTerminator:
+				return string(str), data[i+2:], nil
+			case stringEscape:
+				str = append(str, 0)
+				i++
+			default:
+				return nil, nil, errors.New("holds an unescaped zero byte")
+			}
+		}
+		return nil, nil, errors.New("is not terminated")
+	case serverpb.KeySegmentType_UINT64_SEGMENT, serverpb.KeySegmentType_INT64_SEGMENT:
+		if len(data) < 8 {
+			return nil, nil, errors.New("is shorter than 8 bytes")
+		}
+		u := binary.BigEndian.Uint64(data)
+		if segType == serverpb.KeySegmentType_INT64_SEGMENT {
+			return int64(u ^ (1 << 63)), data[8:], nil
+		}
+		return u, data[8:], nil
+	default:
+		return nil, nil, fmt.Errorf("is of an unknown type %d", segType)
+	}
+}
@@ -0,0 +1,232 @@
+package ctl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"github.com/golang/protobuf/proto"
+)
+
+// ErrObjectNotFound is returned when loading an object that is absent.
+var ErrObjectNotFound = errors.New("object not found")
+
+// Page size of the scans made for querying the objects.
+const objectQueryPageSize = 100
+
+// A Codec encodes the objects into the values of their keys.
+type Codec interface {
+	Marshal(obj interface{}) ([]byte, error)
+	Unmarshal(data []byte, obj interface{}) error
+}
+
+// JSONCodec encodes the objects as JSON.
+var JSONCodec Codec = jsonCodec{}
+
+// ProtoCodec encodes the objects, which must be protobuf messages, in
+// the protobuf wire format.
+var ProtoCodec Codec = protoCodec{}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(obj interface{}) ([]byte, error) {
+	return json.Marshal(obj)
+}
+
+func (jsonCodec) Unmarshal(data []byte, obj interface{}) error {
+	return json.Unmarshal(data, obj)
+}
+
+type protoCodec struct{}
+
+func (protoCodec) Marshal(obj interface{}) ([]byte, error) {
+	msg, ok := obj.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a protobuf message", obj)
+	}
+	return proto.Marshal(msg)
+}
+
+func (protoCodec) Unmarshal(data []byte, obj interface{}) error {
+	msg, ok := obj.(proto.Message)
+	if !ok {
+		return fmt.Errorf("%T is not a protobuf message", obj)
+	}
+	return proto.Unmarshal(data, msg)
+}
+
+// KeyFunc derives the ID of the given object, which is a pointer to a
+// struct of the registered type. IDs must be unique within the type.
+type KeyFunc func(obj interface{}) (string, error)
+
+// An ObjectMapper saves and loads the objects of the registered struct
+// types using a DKV client. Every object is stored under the key made up
+// of the key prefix of its type followed by its ID, hence the objects of
+// a type can be queried by the prefixes of their IDs. Objects are passed
+// as pointers to their structs. It is safe for concurrent use.
+type ObjectMapper struct {
+	client *DKVClient
+
+	mu    sync.RWMutex
+	types map[reflect.Type]*objectType
+}
+
+type objectType struct {
+	keyPrefix string
+	keyFn     KeyFunc
+	codec     Codec
+}
+
+// NewObjectMapper creates an object mapper without any registered types,
+// which saves and loads the objects using the given client.
+func NewObjectMapper(client *DKVClient) *ObjectMapper {
+	return &ObjectMapper{client: client, types: make(map[reflect.Type]*objectType)}
+}
+
+// Register registers the struct type pointed to by the given object,
+// whose objects are stored under the given key prefix using the given
+// key function and codec. Key prefixes of the registered types must not
+// be prefixes of one another, lest their queries overlap.
+func (om *ObjectMapper) Register(obj interface{}, keyPrefix string, keyFn KeyFunc, codec Codec) error {
+	typ, err := structType(obj)
+	switch {
+	case err != nil:
+		return err
+	case keyPrefix == "":
+		return errors.New("key prefix must not be empty")
+	case keyFn == nil || codec == nil:
+		return errors.New("key function and codec must be given")
+	}
+
+	om.mu.Lock()
+	defer om.mu.Unlock()
+	for regTyp, ot := range om.types {
+		if regTyp != typ && (strings.HasPrefix(ot.keyPrefix, keyPrefix) || strings.HasPrefix(keyPrefix, ot.keyPrefix)) {
+			return fmt.Errorf("key prefix: %s overlaps with key prefix: %s of %s", keyPrefix, ot.keyPrefix, regTyp)
+		}
+	}
+	om.types[typ] = &objectType{keyPrefix: keyPrefix, keyFn: keyFn, codec: codec}
+	return nil
+}
+
+// Save stores the given object under the key derived from it.
+func (om *ObjectMapper) Save(obj interface{}) error {
+	ot, err := om.objectType(obj)
+	if err != nil {
+		return err
+	}
+	id, err := ot.keyFn(obj)
+	if err != nil {
+		return err
+	}
+	value, err := ot.codec.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	return om.client.Put([]byte(ot.keyPrefix+id), value)
+}
+
+// Load loads the object with the given ID into the given object, which
+// determines its type. Returns ErrObjectNotFound if it is absent.
+func (om *ObjectMapper) Load(id string, obj interface{}) error {
+	ot, err := om.objectType(obj)
+	if err != nil {
+		return err
+	}
+	res, err := om.client.Get(serverpb.ReadConsistency_LINEARIZABLE, []byte(ot.keyPrefix+id))
+	var status *serverpb.Status
+	if res != nil {
+		status = res.Status
+	}
+	if err = errorFromStatus(status, err); err != nil {
+		return err
+	}
+	if res.NotFound {
+		return ErrObjectNotFound
+	}
+	return ot.codec.Unmarshal(res.Value, obj)
+}
+
+// Delete deletes the object with the given ID of the type pointed to by
+// the given object.
+func (om *ObjectMapper) Delete(id string, obj interface{}) error {
+	ot, err := om.objectType(obj)
+	if err != nil {
+		return err
+	}
+	return om.client.Delete([]byte(ot.keyPrefix + id))
+}
+
+// Query loads all the objects whose IDs have the given prefix, in the
+// order of their IDs, into the slice pointed to by the given result. Its
+// elements must be the registered structs or the pointers to them.
+func (om *ObjectMapper) Query(idPrefix string, result interface{}) error {
+	slicePtr := reflect.ValueOf(result)
+	if slicePtr.Kind() != reflect.Ptr || slicePtr.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("result must be a pointer to a slice, but got %T", result)
+	}
+	slice := slicePtr.Elem()
+	elemTyp, isPtr := slice.Type().Elem(), false
+	if elemTyp.Kind() == reflect.Ptr {
+		elemTyp, isPtr = elemTyp.Elem(), true
+	}
+	om.mu.RLock()
+	ot, present := om.types[elemTyp]
+	om.mu.RUnlock()
+	if !present {
+		return fmt.Errorf("type %s is not registered", elemTyp)
+	}
+
+	keyPrefix := []byte(ot.keyPrefix + idPrefix)
+	startKey, token := keyPrefix, ""
+	for {
+		kvs, nextToken, err := om.client.Scan(keyPrefix, startKey, objectQueryPageSize, token)
+		if err != nil {
+			return err
+		}
+		for _, kv := range kvs {
+			obj := reflect.New(elemTyp)
+			if err = ot.codec.Unmarshal(kv.Value, obj.Interface()); err != nil {
+				return fmt.Errorf("unable to decode object with key: %s, error: %v", kv.Key, err)
+			}
+			if isPtr {
+				slice = reflect.Append(slice, obj)
+			} else {
+				slice = reflect.Append(slice, obj.Elem())
+			}
+		}
+		if nextToken == "" {
+			break
+		}
+		startKey, token = nil, nextToken
+	}
+	slicePtr.Elem().Set(slice)
+	return nil
+}
+
+func (om *ObjectMapper) objectType(obj interface{}) (*objectType, error) {
+	typ, err := structType(obj)
+	if err != nil {
+		return nil, err
+	}
+	om.mu.RLock()
+	defer om.mu.RUnlock()
+	ot, present := om.types[typ]
+	if !present {
+		return nil, fmt.Errorf("type %s is not registered", typ)
+	}
+	return ot, nil
+}
+
+// structType retrieves the struct type pointed to by the given object.
+func structType(obj interface{}) (reflect.Type, error) {
+	typ := reflect.TypeOf(obj)
+	if typ == nil || typ.Kind() != reflect.Ptr || typ.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("object must be a pointer to a struct, but got %T", obj)
+	}
+	return typ.Elem(), nil
+}
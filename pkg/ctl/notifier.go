@@ -0,0 +1,244 @@
+package ctl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+)
+
+// ChangeNotification notifies the change of a key, published as JSON with
+// the key and the value in base64.
+type ChangeNotification struct {
+	ChangeNumber uint64 `json:"changeNumber"`
+	// Type of the change, one of Put|Delete|Merge
+	Type     string `json:"type"`
+	Key      []byte `json:"key"`
+	Value    []byte `json:"value,omitempty"`
+	ExpireTS uint64 `json:"expireTS,omitempty"`
+}
+
+// A NotificationPublisher delivers the change notifications to their
+// consumers, such as webhooks or message brokers. Publish must return only
+// once the given notification is accepted by the consumer.
+type NotificationPublisher interface {
+	Publish(notif *ChangeNotification) error
+}
+
+// WebhookPublisher delivers the change notifications by POSTing them to a
+// webhook. Notifications are accepted by any 2xx response.
+type WebhookPublisher struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookPublisher creates a WebhookPublisher POSTing the notifications
+// to the given URL, with every request bounded by the given timeout.
+func NewWebhookPublisher(url string, timeout time.Duration) *WebhookPublisher {
+	return &WebhookPublisher{url, &http.Client{Timeout: timeout}}
+}
+
+// Publish POSTs the given notification to the webhook, carrying its change
+// number in the X-DKV-Change-Number header for the deduplication of the
+// retried deliveries.
+func (wp *WebhookPublisher) Publish(notif *ChangeNotification) error {
+	body, err := json.Marshal(notif)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, wp.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-DKV-Change-Number", strconv.FormatUint(notif.ChangeNumber, 10))
+	res, err := wp.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	io.Copy(ioutil.Discard, res.Body)
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("webhook %s responded with status: %s", wp.url, res.Status)
+	}
+	return nil
+}
+
+// NotifierOptions captures the settings of a Notifier.
+type NotifierOptions struct {
+	// KeyPrefixes are the prefixes of the keys whose changes are notified.
+	// Changes of all the keys are notified when empty.
+	KeyPrefixes [][]byte
+	// WatermarkFile is the file tracking the change number upto which the
+	// changes are notified, which is created unless it already exists.
+	WatermarkFile string
+	// DeadLetterFile is the file to which the notifications failing all
+	// their retries are appended, one JSON per line, so that the changes
+	// following them continue to be notified. The Notifier stops at such
+	// notifications instead when empty.
+	DeadLetterFile string
+	// BatchSize is the maximum number of changes retrieved at a time.
+	// Defaults to 1000.
+	BatchSize uint32
+	// MaxRetries is the number of times the delivery of a notification is
+	// retried upon failures. Defaults to 3.
+	MaxRetries uint32
+	// RetryBackoff is the duration awaited before the first retry of a
+	// notification, doubled for every subsequent retry. Defaults to 1s.
+	RetryBackoff time.Duration
+}
+
+const (
+	defaultNotifierBatchSize    = 1000
+	defaultNotifierMaxRetries   = 3
+	defaultNotifierRetryBackoff = time.Second
+)
+
+// deadLetter is a notification that failed all its retries.
+type deadLetter struct {
+	*ChangeNotification
+	Error    string    `json:"error"`
+	FailedAt time.Time `json:"failedAt"`
+}
+
+// Notifier publishes notifications for the changes of the keys matching
+// the configured prefixes, for the consumers not speaking the replication
+// protocol of DKV. Just as the KafkaSink, the watermark of the notifier is
+// advanced only after the notifications are delivered, hence every change
+// is notified at least once. Notifications failing all their retries are
+// set aside into the dead letter file, if any. Reserved keys internal to
+// DKV are never notified.
+type Notifier struct {
+	tailer    *changeTailer
+	publisher NotificationPublisher
+	opts      NotifierOptions
+}
+
+// NewNotifier creates a Notifier for the changes retrieved using the given
+// client, delivered through the given publisher.
+func NewNotifier(client *DKVClient, publisher NotificationPublisher, opts NotifierOptions) (*Notifier, error) {
+	if opts.WatermarkFile == "" {
+		return nil, errors.New("watermark file of the notifier must be provided")
+	}
+	if opts.BatchSize == 0 {
+		opts.BatchSize = defaultNotifierBatchSize
+	}
+	if opts.MaxRetries == 0 {
+		opts.MaxRetries = defaultNotifierMaxRetries
+	}
+	if opts.RetryBackoff == 0 {
+		opts.RetryBackoff = defaultNotifierRetryBackoff
+	}
+	tailer := &changeTailer{client: client, sink: "notifier", watermarkFile: opts.WatermarkFile, batchSize: opts.BatchSize}
+	return &Notifier{tailer, publisher, opts}, nil
+}
+
+// Watermark retrieves the change number upto which the changes have been
+// notified by this notifier.
+func (n *Notifier) Watermark() (uint64, error) {
+	return n.tailer.watermark()
+}
+
+// Sync notifies the next batch of changes beyond the watermark, returning
+// the number of changes processed, including the ones not matching the
+// configured prefixes. The watermark is advanced upto the last change
+// notified even when a subsequent change fails to be notified.
+func (n *Notifier) Sync(ctx context.Context) (int, error) {
+	return n.tailer.sync(func(chngs []*serverpb.ChangeRecord) (int, error) {
+		for i, chng := range chngs {
+			if err := n.notify(ctx, chng); err != nil {
+				return i, err
+			}
+		}
+		return len(chngs), nil
+	})
+}
+
+// Run keeps notifying the changes until the given context is done, polling
+// for more changes once every given interval after catching up.
+func (n *Notifier) Run(ctx context.Context, pollInterval time.Duration) error {
+	return n.tailer.run(ctx, pollInterval, n.Sync)
+}
+
+// notify delivers the notifications of the writes of the given change to
+// the matching keys.
+func (n *Notifier) notify(ctx context.Context, chng *serverpb.ChangeRecord) error {
+	for _, trxn := range chng.Trxns {
+		if storage.IsReservedKey(trxn.Key) || !n.matches(trxn.Key) {
+			continue
+		}
+		notif := &ChangeNotification{ChangeNumber: chng.ChangeNumber, Type: trxn.Type.String(),
+			Key: trxn.Key, Value: trxn.Value, ExpireTS: trxn.ExpireTS}
+		err := n.deliver(ctx, notif)
+		if err == nil {
+			continue
+		}
+		if n.opts.DeadLetterFile == "" || err == ctx.Err() {
+			return fmt.Errorf("unable to notify change number %d: %v", chng.ChangeNumber, err)
+		}
+		if dlErr := n.deadLetter(notif, err); dlErr != nil {
+			return fmt.Errorf("unable to dead letter change number %d: %v", chng.ChangeNumber, dlErr)
+		}
+	}
+	return nil
+}
+
+func (n *Notifier) matches(key []byte) bool {
+	if len(n.opts.KeyPrefixes) == 0 {
+		return true
+	}
+	for _, prefix := range n.opts.KeyPrefixes {
+		if bytes.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// deliver publishes the given notification, retrying upon failures with
+// an exponential backoff until the given context is done.
+func (n *Notifier) deliver(ctx context.Context, notif *ChangeNotification) error {
+	backoff := n.opts.RetryBackoff
+	for attempt := uint32(0); ; attempt++ {
+		err := n.publisher.Publish(notif)
+		if err == nil || attempt == n.opts.MaxRetries {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// deadLetter appends the given notification along with the error of its
+// last delivery to the dead letter file.
+func (n *Notifier) deadLetter(notif *ChangeNotification, deliveryErr error) error {
+	data, err := json.Marshal(&deadLetter{notif, deliveryErr.Error(), time.Now()})
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(n.opts.DeadLetterFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(append(data, '\n')); err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
@@ -0,0 +1,53 @@
+package ctl
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"google.golang.org/grpc/status"
+)
+
+// ErrReplicaReadOnly is matched by the errors of the writes rejected by a
+// slave, which only serves reads, or by a master superseded by a node
+// promoted to master.
+var ErrReplicaReadOnly = errors.New("replica is read-only")
+
+// ReplicaReadOnlyError is returned for the writes rejected by a slave or
+// a superseded master, carrying the address of the current master so that
+// the writes can be redirected to it. It matches ErrReplicaReadOnly
+// through errors.Is.
+type ReplicaReadOnlyError struct {
+	// MasterAddress is the address of the master of the slave, empty if
+	// the slave is yet to discover it.
+	MasterAddress string
+	Message       string
+}
+
+func (e *ReplicaReadOnlyError) Error() string {
+	if e.MasterAddress == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s (master: %s)", e.Message, e.MasterAddress)
+}
+
+// Is reports whether the given target is ErrReplicaReadOnly.
+func (e *ReplicaReadOnlyError) Is(target error) bool {
+	return target == ErrReplicaReadOnly
+}
+
+// replicaReadOnlyError converts the given GRPC error into a
+// ReplicaReadOnlyError when detailed by the REPLICA_READ_ONLY status,
+// returning it as is otherwise.
+func replicaReadOnlyError(err error) error {
+	grpcStatus, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	for _, detail := range grpcStatus.Details() {
+		if st, ok := detail.(*serverpb.Status); ok && st.Code == int32(serverpb.StatusCode_REPLICA_READ_ONLY) {
+			return &ReplicaReadOnlyError{MasterAddress: st.MasterAddress, Message: st.Message}
+		}
+	}
+	return err
+}
@@ -0,0 +1,123 @@
+package ctl
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/flipkart-incubator/dkv/internal/compression"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/encoding"
+)
+
+// EncodeChangeRecords encodes the given change records in place, for
+// sending them to the subscribers that requested the given compression
+// and delta encoding of the keys. Records are compressed individually,
+// unlike the compression of the calls, so that the records which do not
+// shrink, such as those of already compressed values, are left as is.
+// Keys are delta encoded before the compression.
+func EncodeChangeRecords(chngs []*serverpb.ChangeRecord, compressor string, deltaEncodeKeys bool) error {
+	if deltaEncodeKeys {
+		for _, chng := range chngs {
+			deltaEncodeKeysOf(chng)
+		}
+	}
+	if compressor == "" {
+		return nil
+	}
+	comp, err := recordCompressor(compressor)
+	if err != nil {
+		return err
+	}
+	for _, chng := range chngs {
+		if chng.Compression != "" {
+			continue
+		}
+		data, err := proto.Marshal(&serverpb.ChangeRecord{SerialisedForm: chng.SerialisedForm, Trxns: chng.Trxns})
+		if err != nil {
+			return err
+		}
+		var buf bytes.Buffer
+		w, err := comp.Compress(&buf)
+		if err != nil {
+			return err
+		}
+		if _, err = w.Write(data); err == nil {
+			err = w.Close()
+		}
+		if err != nil {
+			return err
+		}
+		if buf.Len() < len(data) {
+			chng.Compression, chng.CompressedForm = compressor, buf.Bytes()
+			chng.SerialisedForm, chng.Trxns = nil, nil
+		}
+	}
+	return nil
+}
+
+// DecodeChangeRecords decodes the given change records in place, as encoded
+// by EncodeChangeRecords. Records that are not encoded are left as is.
+func DecodeChangeRecords(chngs []*serverpb.ChangeRecord) error {
+	for _, chng := range chngs {
+		if chng.Compression != "" {
+			comp, err := recordCompressor(chng.Compression)
+			if err != nil {
+				return err
+			}
+			r, err := comp.Decompress(bytes.NewReader(chng.CompressedForm))
+			if err != nil {
+				return err
+			}
+			data, err := compression.ReadAll(r)
+			if err != nil {
+				return err
+			}
+			var decoded serverpb.ChangeRecord
+			if err = proto.Unmarshal(data, &decoded); err != nil {
+				return fmt.Errorf("unable to decode change number %d: %v", chng.ChangeNumber, err)
+			}
+			chng.SerialisedForm, chng.Trxns = decoded.SerialisedForm, decoded.Trxns
+			chng.Compression, chng.CompressedForm = "", nil
+		}
+		if err := deltaDecodeKeysOf(chng); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func recordCompressor(compressor string) (encoding.Compressor, error) {
+	if err := compression.Validate(compressor); err != nil {
+		return nil, err
+	}
+	return encoding.GetCompressor(compressor), nil
+}
+
+func deltaEncodeKeysOf(chng *serverpb.ChangeRecord) {
+	var prevKey []byte
+	for _, trxn := range chng.Trxns {
+		key := trxn.Key
+		shared := 0
+		for shared < len(prevKey) && shared < len(key) && prevKey[shared] == key[shared] {
+			shared++
+		}
+		trxn.Key, trxn.SharedKeyPrefix = key[shared:], uint32(shared)
+		prevKey = key
+	}
+}
+
+func deltaDecodeKeysOf(chng *serverpb.ChangeRecord) error {
+	var prevKey []byte
+	for _, trxn := range chng.Trxns {
+		if trxn.SharedKeyPrefix > 0 {
+			if int(trxn.SharedKeyPrefix) > len(prevKey) {
+				return fmt.Errorf("unable to decode change number %d: shared key prefix exceeds the preceding key", chng.ChangeNumber)
+			}
+			key := make([]byte, 0, int(trxn.SharedKeyPrefix)+len(trxn.Key))
+			trxn.Key, trxn.SharedKeyPrefix = append(append(key, prevKey[:trxn.SharedKeyPrefix]...), trxn.Key...), 0
+		}
+		prevKey = trxn.Key
+	}
+	return nil
+}
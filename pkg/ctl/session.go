@@ -0,0 +1,97 @@
+package ctl
+
+import (
+	"context"
+	"sync"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+)
+
+// Session tracks the change numbers of the writes made through it, so
+// that the subsequent reads through it observe those writes, even when
+// they are served by slaves. A session is not bound to any client, hence
+// the writes can be made on the master and the reads on its slaves. It
+// is safe for concurrent use.
+type Session struct {
+	mu      sync.Mutex
+	chngNum uint64
+}
+
+// NewSession begins a new session, which has not observed any writes.
+func NewSession() *Session {
+	return &Session{}
+}
+
+// ChangeNumber retrieves the change number of the latest write observed
+// by this session, which can be handed over to another session.
+func (sess *Session) ChangeNumber() uint64 {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	return sess.chngNum
+}
+
+// Observe records the given change number, so that the subsequent reads
+// through this session observe the changes upto it.
+func (sess *Session) Observe(chngNum uint64) {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	if chngNum > sess.chngNum {
+		sess.chngNum = chngNum
+	}
+}
+
+// Put associates the given key and value using the given client and
+// records the change number of the write in this session.
+func (sess *Session) Put(client *DKVClient, key, value []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	res, err := client.dkvCli.Put(ctx, &serverpb.PutRequest{Key: key, Value: value})
+	var status *serverpb.Status
+	if res != nil {
+		status = res.Status
+	}
+	if err = errorFromStatus(status, err); err == nil {
+		sess.Observe(res.ChangeNumber)
+	}
+	return err
+}
+
+// Delete deletes the given key using the given client and records the
+// change number of the delete in this session.
+func (sess *Session) Delete(client *DKVClient, key []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	res, err := client.dkvCli.Delete(ctx, &serverpb.DeleteRequest{Key: key})
+	var status *serverpb.Status
+	if res != nil {
+		status = res.Status
+	}
+	if err = errorFromStatus(status, err); err == nil {
+		sess.Observe(res.ChangeNumber)
+	}
+	return err
+}
+
+// Get loads the value of the given key using the given client, once the
+// serving node has applied all the writes observed by this session. Slaves
+// that fail to do so in time fail the read with the address of their
+// master, from which the key can be read instead.
+func (sess *Session) Get(client *DKVClient, rc serverpb.ReadConsistency, key []byte) (*serverpb.GetResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	getReq := &serverpb.GetRequest{Key: key, ReadConsistency: rc, MinChangeNumber: sess.ChangeNumber()}
+	return client.dkvCli.Get(ctx, getReq)
+}
+
+// MultiGet is similar to Get except that it loads the values of all the
+// given keys.
+func (sess *Session) MultiGet(client *DKVClient, rc serverpb.ReadConsistency, keys ...[]byte) ([]*serverpb.KVPair, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	multiGetReq := &serverpb.MultiGetRequest{Keys: keys, ReadConsistency: rc, MinChangeNumber: sess.ChangeNumber()}
+	res, err := client.dkvCli.MultiGet(ctx, multiGetReq)
+	if err != nil {
+		return nil, err
+	}
+	return res.KeyValues, nil
+}
@@ -0,0 +1,285 @@
+package ctl
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+)
+
+// SQLDialect captures the flavour of SQL spoken by a relational database.
+type SQLDialect string
+
+const (
+	// PostgreSQL is the dialect of PostgreSQL databases.
+	PostgreSQL SQLDialect = "postgres"
+	// MySQL is the dialect of MySQL databases.
+	MySQL SQLDialect = "mysql"
+)
+
+// SQLMergeFunc computes the new value of the given key by merging the
+// given operand into its existing value, which is nil for absent keys.
+// It must mirror the merge operator configured for the key on DKV.
+type SQLMergeFunc func(key, existing, operand []byte) ([]byte, error)
+
+// SQLSinkOptions captures the settings of a SQLSink.
+type SQLSinkOptions struct {
+	// Name identifies the sink, whose watermark is tracked independently
+	// of the other sinks sharing the same database.
+	Name string
+	// Table is the table into which the keys are mirrored. Its columns
+	// are `k` (primary key), `v` and `expire_ts`, holding the key, the
+	// value and the expiry in epoch seconds (0 for no expiry) of a key.
+	Table string
+	// WatermarkTable is the table tracking the watermarks of the sinks.
+	// Defaults to `dkv_sink_watermarks`.
+	WatermarkTable string
+	// BatchSize is the maximum number of changes applied in a single
+	// SQL transaction. Defaults to 1000.
+	BatchSize uint32
+	// Merge computes the values of the keys changed by merges. Merges
+	// fail the sink when not set.
+	Merge SQLMergeFunc
+}
+
+const (
+	defaultSQLSinkWatermarkTable = "dkv_sink_watermarks"
+	defaultSQLSinkBatchSize      = 1000
+)
+
+var (
+	sqlIdentifierRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+	errSQLSinkMergeNotSupported = errors.New("merges cannot be mirrored without a merge function")
+)
+
+// SQLSink mirrors the keyspace of a DKV node into a relational table by
+// applying its change stream. Every batch of changes is applied in a single
+// SQL transaction along with the change number of its last change, which is
+// recorded as the watermark of the sink. Since the watermark is locked and
+// advanced by the same transaction that applies the changes, every change
+// is applied exactly once, even across restarts or when multiple instances
+// of the sink run concurrently. Changes served by a master carrying a stale
+// fencing epoch are rejected.
+//
+// Changes carry the values as stored by DKV, hence values encrypted at rest
+// are mirrored encrypted. Reserved keys internal to DKV are never mirrored.
+// A master restored to an earlier change number must be followed by
+// rebuilding the table and resetting the watermark of the sink.
+type SQLSink struct {
+	client  *DKVClient
+	db      *sql.DB
+	dialect SQLDialect
+	opts    SQLSinkOptions
+}
+
+// NewSQLSink creates a SQLSink applying the changes retrieved using the
+// given client onto the given database. The tables of the sink are
+// created unless they already exist.
+func NewSQLSink(client *DKVClient, db *sql.DB, dialect SQLDialect, opts SQLSinkOptions) (*SQLSink, error) {
+	if dialect != PostgreSQL && dialect != MySQL {
+		return nil, fmt.Errorf("unknown SQL dialect: %s, must be one of postgres|mysql", dialect)
+	}
+	if opts.Name == "" {
+		return nil, errors.New("name of the SQL sink must be provided")
+	}
+	if opts.WatermarkTable == "" {
+		opts.WatermarkTable = defaultSQLSinkWatermarkTable
+	}
+	if opts.BatchSize == 0 {
+		opts.BatchSize = defaultSQLSinkBatchSize
+	}
+	for _, table := range []string{opts.Table, opts.WatermarkTable} {
+		if !sqlIdentifierRegex.MatchString(table) {
+			return nil, fmt.Errorf("invalid table name: %q", table)
+		}
+	}
+	ss := &SQLSink{client, db, dialect, opts}
+	return ss, ss.createTables()
+}
+
+func (ss *SQLSink) createTables() error {
+	var stmts []string
+	switch ss.dialect {
+	case PostgreSQL:
+		stmts = []string{
+			fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (k BYTEA PRIMARY KEY, v BYTEA NOT NULL, expire_ts BIGINT NOT NULL)", ss.opts.Table),
+			fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (sink_name VARCHAR(255) PRIMARY KEY, change_number BIGINT NOT NULL, fencing_epoch BIGINT NOT NULL)", ss.opts.WatermarkTable),
+			fmt.Sprintf("INSERT INTO %s (sink_name, change_number, fencing_epoch) VALUES ($1, 0, 0) ON CONFLICT DO NOTHING", ss.opts.WatermarkTable),
+		}
+	case MySQL:
+		stmts = []string{
+			fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (k VARBINARY(3072) PRIMARY KEY, v LONGBLOB NOT NULL, expire_ts BIGINT UNSIGNED NOT NULL)", ss.opts.Table),
+			fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (sink_name VARCHAR(255) PRIMARY KEY, change_number BIGINT UNSIGNED NOT NULL, fencing_epoch BIGINT UNSIGNED NOT NULL)", ss.opts.WatermarkTable),
+			fmt.Sprintf("INSERT IGNORE INTO %s (sink_name, change_number, fencing_epoch) VALUES (?, 0, 0)", ss.opts.WatermarkTable),
+		}
+	}
+	for i, stmt := range stmts {
+		var args []interface{}
+		if i == len(stmts)-1 {
+			args = append(args, ss.opts.Name)
+		}
+		if _, err := ss.db.Exec(stmt, args...); err != nil {
+			return fmt.Errorf("unable to create the tables of the SQL sink: %v", err)
+		}
+	}
+	return nil
+}
+
+// Watermark retrieves the change number upto which the changes have
+// been applied by this sink.
+func (ss *SQLSink) Watermark() (uint64, error) {
+	var chngNum uint64
+	err := ss.db.QueryRow(ss.query("SELECT change_number FROM %s WHERE sink_name = ?", ss.opts.WatermarkTable), ss.opts.Name).Scan(&chngNum)
+	return chngNum, err
+}
+
+// Sync applies the next batch of changes beyond the watermark, returning
+// the number of changes applied.
+func (ss *SQLSink) Sync(ctx context.Context) (int, error) {
+	tx, err := ss.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var watermark, epoch uint64
+	row := tx.QueryRowContext(ctx, ss.query("SELECT change_number, fencing_epoch FROM %s WHERE sink_name = ? FOR UPDATE", ss.opts.WatermarkTable), ss.opts.Name)
+	if err = row.Scan(&watermark, &epoch); err != nil {
+		return 0, fmt.Errorf("unable to load the watermark of the SQL sink: %v", err)
+	}
+
+	res, err := ss.client.GetChanges(watermark+1, ss.opts.BatchSize)
+	var status *serverpb.Status
+	if res != nil {
+		status = res.Status
+	}
+	if err = errorFromStatus(status, err); err != nil {
+		return 0, err
+	}
+	if res.FencingEpoch < epoch {
+		return 0, fmt.Errorf("%w: %d, highest observed epoch: %d", storage.ErrStaleFencingEpoch, res.FencingEpoch, epoch)
+	}
+	epoch = res.FencingEpoch
+
+	var numApplied int
+	for _, chng := range res.Changes {
+		// Changes upto the watermark are already applied
+		if chng.ChangeNumber <= watermark {
+			continue
+		}
+		if chng.FencingEpoch < epoch {
+			return 0, fmt.Errorf("%w: %d, highest observed epoch: %d", storage.ErrStaleFencingEpoch, chng.FencingEpoch, epoch)
+		}
+		epoch = chng.FencingEpoch
+		for _, trxn := range chng.Trxns {
+			if err = ss.applyTrxn(ctx, tx, trxn); err != nil {
+				return 0, fmt.Errorf("unable to apply change number %d: %v", chng.ChangeNumber, err)
+			}
+		}
+		watermark = chng.ChangeNumber
+		numApplied++
+	}
+
+	stmt := ss.query("UPDATE %s SET change_number = ?, fencing_epoch = ? WHERE sink_name = ?", ss.opts.WatermarkTable)
+	if _, err = tx.ExecContext(ctx, stmt, watermark, epoch, ss.opts.Name); err != nil {
+		return 0, err
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+	return numApplied, nil
+}
+
+// Run keeps applying the changes until the given context is done, polling
+// for more changes once every given interval after catching up.
+func (ss *SQLSink) Run(ctx context.Context, pollInterval time.Duration) error {
+	for {
+		numApplied, err := ss.Sync(ctx)
+		if err != nil {
+			return err
+		}
+		if numApplied > 0 {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+func (ss *SQLSink) applyTrxn(ctx context.Context, tx *sql.Tx, trxn *serverpb.TrxnRecord) error {
+	if storage.IsReservedKey(trxn.Key) {
+		return nil
+	}
+	switch trxn.Type {
+	case serverpb.TrxnRecord_Put:
+		// Empty values are read as absent by DKV
+		if len(trxn.Value) == 0 {
+			return ss.delete(ctx, tx, trxn.Key)
+		}
+		return ss.upsert(ctx, tx, trxn.Key, trxn.Value, trxn.ExpireTS)
+	case serverpb.TrxnRecord_Delete:
+		return ss.delete(ctx, tx, trxn.Key)
+	case serverpb.TrxnRecord_Merge:
+		if ss.opts.Merge == nil {
+			return errSQLSinkMergeNotSupported
+		}
+		var existing []byte
+		var expireTS uint64
+		row := tx.QueryRowContext(ctx, ss.query("SELECT v, expire_ts FROM %s WHERE k = ?", ss.opts.Table), trxn.Key)
+		if err := row.Scan(&existing, &expireTS); err != nil && err != sql.ErrNoRows {
+			return err
+		}
+		value, err := ss.opts.Merge(trxn.Key, existing, trxn.Value)
+		if err != nil {
+			return err
+		}
+		return ss.upsert(ctx, tx, trxn.Key, value, expireTS)
+	default:
+		return fmt.Errorf("unknown transaction type: %s", trxn.Type)
+	}
+}
+
+func (ss *SQLSink) upsert(ctx context.Context, tx *sql.Tx, key, value []byte, expireTS uint64) error {
+	var stmt string
+	switch ss.dialect {
+	case PostgreSQL:
+		stmt = ss.query("INSERT INTO %s (k, v, expire_ts) VALUES (?, ?, ?) ON CONFLICT (k) DO UPDATE SET v = EXCLUDED.v, expire_ts = EXCLUDED.expire_ts", ss.opts.Table)
+	case MySQL:
+		stmt = ss.query("INSERT INTO %s (k, v, expire_ts) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE v = VALUES(v), expire_ts = VALUES(expire_ts)", ss.opts.Table)
+	}
+	_, err := tx.ExecContext(ctx, stmt, key, value, expireTS)
+	return err
+}
+
+func (ss *SQLSink) delete(ctx context.Context, tx *sql.Tx, key []byte) error {
+	_, err := tx.ExecContext(ctx, ss.query("DELETE FROM %s WHERE k = ?", ss.opts.Table), key)
+	return err
+}
+
+// query formats the given statement for the given table, replacing the
+// `?` placeholders with the numbered placeholders of PostgreSQL.
+func (ss *SQLSink) query(format, table string) string {
+	stmt := fmt.Sprintf(format, table)
+	if ss.dialect != PostgreSQL {
+		return stmt
+	}
+	var buf []byte
+	for i, n := 0, 1; i < len(stmt); i++ {
+		if stmt[i] == '?' {
+			buf = append(buf, fmt.Sprintf("$%d", n)...)
+			n++
+		} else {
+			buf = append(buf, stmt[i])
+		}
+	}
+	return string(buf)
+}
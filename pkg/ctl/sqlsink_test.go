@@ -0,0 +1,235 @@
+package ctl
+
+import (
+	"context"
+	"errors"
+	"net"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"google.golang.org/grpc"
+)
+
+const testSQLSinkName = "test-sink"
+
+// changesServer serves the given changes from the requested change number,
+// as done by a master holding the given fencing epoch.
+type changesServer struct {
+	serverpb.UnimplementedDKVReplicationServer
+	epoch uint64
+	chngs []*serverpb.ChangeRecord
+	// replayed serves all the changes regardless of the requested change
+	// number, as when the changes are served again
+	replayed bool
+}
+
+func (cs *changesServer) GetChanges(_ context.Context, getChngsReq *serverpb.GetChangesRequest) (*serverpb.GetChangesResponse, error) {
+	res := &serverpb.GetChangesResponse{Status: &serverpb.Status{}, FencingEpoch: cs.epoch}
+	for _, chng := range cs.chngs {
+		if (cs.replayed || chng.ChangeNumber >= getChngsReq.FromChangeNumber) && len(res.Changes) < int(getChngsReq.MaxNumberOfChanges) {
+			res.Changes = append(res.Changes, chng)
+		}
+		res.MasterChangeNumber = chng.ChangeNumber
+	}
+	res.NumberOfChanges = uint32(len(res.Changes))
+	return res, nil
+}
+
+func newChange(chngNum, epoch uint64, trxns ...*serverpb.TrxnRecord) *serverpb.ChangeRecord {
+	return &serverpb.ChangeRecord{ChangeNumber: chngNum, FencingEpoch: epoch, NumberOfTrxns: uint32(len(trxns)), Trxns: trxns}
+}
+
+func newTrxn(typ serverpb.TrxnRecord_TrxnType, key, value string) *serverpb.TrxnRecord {
+	trxn := &serverpb.TrxnRecord{Type: typ, Key: []byte(key)}
+	if value != "" {
+		trxn.Value = []byte(value)
+	}
+	return trxn
+}
+
+// newTestSQLSink creates a SQL sink over a mocked MySQL database, applying
+// the changes served by the given server.
+func newTestSQLSink(t *testing.T, srv *changesServer, merge SQLMergeFunc) (*SQLSink, sqlmock.Sqlmock) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grpcSrvr := grpc.NewServer()
+	serverpb.RegisterDKVReplicationServer(grpcSrvr, srv)
+	go grpcSrvr.Serve(lis)
+	t.Cleanup(grpcSrvr.Stop)
+
+	client, err := NewInSecureDKVClient(lis.Addr().String(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE IF NOT EXISTS kvs ")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE IF NOT EXISTS dkv_sink_watermarks ")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT IGNORE INTO dkv_sink_watermarks ")).WithArgs(testSQLSinkName).WillReturnResult(sqlmock.NewResult(0, 1))
+	sink, err := NewSQLSink(client, db, MySQL, SQLSinkOptions{Name: testSQLSinkName, Table: "kvs", BatchSize: 10, Merge: merge})
+	if err != nil {
+		t.Fatalf("Unable to create SQL sink. Error: %v", err)
+	}
+	return sink, mock
+}
+
+func expectUpsert(mock sqlmock.Sqlmock, key, value string) *sqlmock.ExpectedExec {
+	return mock.ExpectExec(regexp.QuoteMeta("INSERT INTO kvs (k, v, expire_ts) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE")).
+		WithArgs([]byte(key), []byte(value), 0)
+}
+
+func TestSQLSinkSync(t *testing.T) {
+	errDiskFull := errors.New("disk full")
+	concat := func(_, existing, operand []byte) ([]byte, error) {
+		if existing == nil {
+			return operand, nil
+		}
+		return append(append(existing, ','), operand...), nil
+	}
+
+	tests := []struct {
+		name string
+		srv  *changesServer
+		// merge is the merge function of the sink
+		merge SQLMergeFunc
+		// watermark and epoch are those recorded for the sink beforehand
+		watermark, epoch uint64
+		// expect sets up the statements expected for applying the changes
+		expect func(mock sqlmock.Sqlmock)
+		// newWatermark and newEpoch are those expected to be recorded
+		// along with the changes, unless the sync fails
+		newWatermark, newEpoch uint64
+		numApplied             int
+		failed                 bool
+		cause                  error
+	}{
+		{
+			name: "watermark advances along with the changes",
+			srv: &changesServer{epoch: 1, chngs: []*serverpb.ChangeRecord{
+				newChange(1, 1, newTrxn(serverpb.TrxnRecord_Put, "k1", "v1")),
+				newChange(2, 1, newTrxn(serverpb.TrxnRecord_Delete, "k2", "")),
+				newChange(3, 1, newTrxn(serverpb.TrxnRecord_Put, string(storage.SubjectKeyRecord([]byte("k3"))), "v3")),
+			}},
+			expect: func(mock sqlmock.Sqlmock) {
+				expectUpsert(mock, "k1", "v1").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM kvs WHERE k = ?")).WithArgs([]byte("k2")).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			newWatermark: 3, newEpoch: 1, numApplied: 3,
+		},
+		{
+			name: "changes served again are skipped",
+			srv: &changesServer{epoch: 1, replayed: true, chngs: []*serverpb.ChangeRecord{
+				newChange(1, 1, newTrxn(serverpb.TrxnRecord_Put, "k1", "v1")),
+				newChange(2, 1, newTrxn(serverpb.TrxnRecord_Put, "k2", "v2")),
+				newChange(3, 1, newTrxn(serverpb.TrxnRecord_Put, "k3", "v3")),
+			}},
+			watermark: 2, epoch: 1,
+			expect: func(mock sqlmock.Sqlmock) {
+				expectUpsert(mock, "k3", "v3").WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			newWatermark: 3, newEpoch: 1, numApplied: 1,
+		},
+		{
+			name: "failed apply rolls back without advancing the watermark",
+			srv: &changesServer{epoch: 1, chngs: []*serverpb.ChangeRecord{
+				newChange(1, 1, newTrxn(serverpb.TrxnRecord_Put, "k1", "v1")),
+				newChange(2, 1, newTrxn(serverpb.TrxnRecord_Put, "k2", "v2")),
+			}},
+			expect: func(mock sqlmock.Sqlmock) {
+				expectUpsert(mock, "k1", "v1").WillReturnResult(sqlmock.NewResult(0, 1))
+				expectUpsert(mock, "k2", "v2").WillReturnError(errDiskFull)
+			},
+			failed: true,
+		},
+		{
+			name: "merges are applied onto the existing values",
+			srv: &changesServer{epoch: 1, chngs: []*serverpb.ChangeRecord{
+				newChange(1, 1, newTrxn(serverpb.TrxnRecord_Merge, "k1", "v2"), newTrxn(serverpb.TrxnRecord_Merge, "k2", "v1")),
+			}},
+			merge: concat,
+			expect: func(mock sqlmock.Sqlmock) {
+				selectStmt := regexp.QuoteMeta("SELECT v, expire_ts FROM kvs WHERE k = ?")
+				mock.ExpectQuery(selectStmt).WithArgs([]byte("k1")).
+					WillReturnRows(sqlmock.NewRows([]string{"v", "expire_ts"}).AddRow([]byte("v1"), 0))
+				expectUpsert(mock, "k1", "v1,v2").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(selectStmt).WithArgs([]byte("k2")).WillReturnRows(sqlmock.NewRows([]string{"v", "expire_ts"}))
+				expectUpsert(mock, "k2", "v1").WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			newWatermark: 1, newEpoch: 1, numApplied: 1,
+		},
+		{
+			name: "merges are rejected without a merge function",
+			srv: &changesServer{epoch: 1, chngs: []*serverpb.ChangeRecord{
+				newChange(1, 1, newTrxn(serverpb.TrxnRecord_Merge, "k1", "v1")),
+			}},
+			failed: true, cause: errSQLSinkMergeNotSupported,
+		},
+		{
+			name: "changes served by a superseded master are rejected",
+			srv: &changesServer{epoch: 1, chngs: []*serverpb.ChangeRecord{
+				newChange(1, 1, newTrxn(serverpb.TrxnRecord_Put, "k1", "v1")),
+			}},
+			epoch:  2,
+			failed: true, cause: storage.ErrStaleFencingEpoch,
+		},
+		{
+			name: "changes carrying a stale fencing epoch are rejected",
+			srv: &changesServer{epoch: 2, chngs: []*serverpb.ChangeRecord{
+				newChange(1, 2, newTrxn(serverpb.TrxnRecord_Put, "k1", "v1")),
+				newChange(2, 1, newTrxn(serverpb.TrxnRecord_Put, "k2", "v2")),
+			}},
+			epoch: 1,
+			expect: func(mock sqlmock.Sqlmock) {
+				expectUpsert(mock, "k1", "v1").WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			failed: true, cause: storage.ErrStaleFencingEpoch,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sink, mock := newTestSQLSink(t, tc.srv, tc.merge)
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT change_number, fencing_epoch FROM dkv_sink_watermarks WHERE sink_name = ? FOR UPDATE")).
+				WithArgs(testSQLSinkName).
+				WillReturnRows(sqlmock.NewRows([]string{"change_number", "fencing_epoch"}).AddRow(tc.watermark, tc.epoch))
+			if tc.expect != nil {
+				tc.expect(mock)
+			}
+			// Watermark is advanced within the transaction applying the
+			// changes, which is rolled back upon failure
+			if tc.failed {
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE dkv_sink_watermarks SET change_number = ?, fencing_epoch = ? WHERE sink_name = ?")).
+					WithArgs(tc.newWatermark, tc.newEpoch, testSQLSinkName).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			}
+
+			numApplied, err := sink.Sync(context.Background())
+			switch {
+			case !tc.failed && err != nil:
+				t.Errorf("Unable to sync the changes. Error: %v", err)
+			case tc.failed && err == nil:
+				t.Error("Expected the sync to fail")
+			case tc.cause != nil && !strings.Contains(err.Error(), tc.cause.Error()):
+				t.Errorf("Expected the sync to fail due to %v, but got error: %v", tc.cause, err)
+			case numApplied != tc.numApplied:
+				t.Errorf("Expected %d changes to be applied, but got %d", tc.numApplied, numApplied)
+			}
+			if err = mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
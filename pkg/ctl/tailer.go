@@ -0,0 +1,162 @@
+package ctl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/flipkart-incubator/dkv/internal/storage"
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+)
+
+// sinkWatermark is the content of the watermark file of a sink, such as
+// the KafkaSink and the Notifier, tracking the changes it has processed.
+type sinkWatermark struct {
+	ChangeNumber uint64 `json:"changeNumber"`
+	FencingEpoch uint64 `json:"fencingEpoch"`
+}
+
+// changeTailer tails the change stream of a DKV node on behalf of a sink,
+// tracking the changes processed by the sink in its watermark file. The
+// watermark is advanced only after the changes are processed, hence every
+// change is processed at least once. Changes served by a master carrying
+// a stale fencing epoch are rejected.
+type changeTailer struct {
+	client *DKVClient
+	// Name of the sink, used in the errors.
+	sink          string
+	watermarkFile string
+	batchSize     uint32
+}
+
+// processChanges processes the given changes in order, returning the
+// number of the leading changes that are processed along with the error
+// that stopped the processing of the remaining ones, if any.
+type processChanges func(chngs []*serverpb.ChangeRecord) (int, error)
+
+// watermark retrieves the change number upto which the changes have been
+// processed by the sink.
+func (ct *changeTailer) watermark() (uint64, error) {
+	wm, err := loadWatermark(ct.watermarkFile)
+	if err != nil {
+		return 0, err
+	}
+	return wm.ChangeNumber, nil
+}
+
+// sync processes the next batch of changes beyond the watermark, returning
+// the number of changes processed. The watermark is advanced upto the last
+// change processed even when a subsequent change fails to be processed.
+func (ct *changeTailer) sync(process processChanges) (int, error) {
+	wm, err := loadWatermark(ct.watermarkFile)
+	if err != nil {
+		return 0, fmt.Errorf("unable to load the watermark of the %s: %v", ct.sink, err)
+	}
+
+	res, err := ct.client.GetChanges(wm.ChangeNumber+1, ct.batchSize)
+	var status *serverpb.Status
+	if res != nil {
+		status = res.Status
+	}
+	if err = errorFromStatus(status, err); err != nil {
+		return 0, err
+	}
+	if res.FencingEpoch < wm.FencingEpoch {
+		return 0, fmt.Errorf("%w: %d, highest observed epoch: %d", storage.ErrStaleFencingEpoch, res.FencingEpoch, wm.FencingEpoch)
+	}
+
+	var chngs []*serverpb.ChangeRecord
+	var staleErr error
+	epoch := res.FencingEpoch
+	for _, chng := range res.Changes {
+		// Changes upto the watermark are already processed
+		if chng.ChangeNumber <= wm.ChangeNumber {
+			continue
+		}
+		if chng.FencingEpoch < epoch {
+			staleErr = fmt.Errorf("%w: %d, highest observed epoch: %d", storage.ErrStaleFencingEpoch, chng.FencingEpoch, epoch)
+			break
+		}
+		epoch = chng.FencingEpoch
+		chngs = append(chngs, chng)
+	}
+	if len(chngs) == 0 {
+		return 0, staleErr
+	}
+
+	numProcessed, err := process(chngs)
+	if numProcessed > 0 {
+		last := chngs[numProcessed-1]
+		next := sinkWatermark{ChangeNumber: last.ChangeNumber, FencingEpoch: last.FencingEpoch}
+		if next.FencingEpoch < res.FencingEpoch {
+			next.FencingEpoch = res.FencingEpoch
+		}
+		if saveErr := saveWatermark(ct.watermarkFile, next); saveErr != nil {
+			return 0, fmt.Errorf("unable to save the watermark of the %s: %v", ct.sink, saveErr)
+		}
+	}
+	if err == nil {
+		err = staleErr
+	}
+	return numProcessed, err
+}
+
+// run keeps processing the changes until the given context is done, polling
+// for more changes once every given interval after catching up.
+func (ct *changeTailer) run(ctx context.Context, pollInterval time.Duration, sync func(context.Context) (int, error)) error {
+	for {
+		numProcessed, err := sync(ctx)
+		if err != nil {
+			return err
+		}
+		if numProcessed > 0 {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+func loadWatermark(file string) (sinkWatermark, error) {
+	var wm sinkWatermark
+	data, err := ioutil.ReadFile(file)
+	switch {
+	case os.IsNotExist(err):
+		return wm, nil
+	case err != nil:
+		return wm, err
+	}
+	err = json.Unmarshal(data, &wm)
+	return wm, err
+}
+
+// saveWatermark replaces the watermark file atomically, so that a crash
+// leaves behind either the previous or the given watermark.
+func saveWatermark(file string, wm sinkWatermark) error {
+	data, err := json.Marshal(wm)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(file), filepath.Base(file)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(data); err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), file)
+}
@@ -0,0 +1,206 @@
+package ctl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TaskScheduler schedules tasks in a DKV keyspace, where every task is
+// a key made up of the queue name, the due time and the task ID, so that
+// tasks are ordered by their due time. Workers claim due tasks atomically
+// using CompareAndSet, which leases a task to the claiming worker for the
+// visibility timeout. A task is claimed again by any worker once its lease
+// lapses without the task being completed, hence tasks are processed at
+// least once and must be idempotent.
+type TaskScheduler struct {
+	client            *DKVClient
+	queue             string
+	worker            string
+	visibilityTimeout time.Duration
+}
+
+// Task is a task of a TaskScheduler queue.
+type Task struct {
+	// ID identifies the task among the tasks due at the same time.
+	ID string
+	// Due is the time at which the task is due.
+	Due time.Time
+	// Payload is the opaque data of the task.
+	Payload []byte
+	// Attempts is the number of times the task has been claimed,
+	// including the current claim.
+	Attempts uint32
+
+	key, value []byte
+}
+
+// taskState is stored as the value of every task key.
+type taskState struct {
+	Payload    []byte `json:"p,omitempty"`
+	Worker     string `json:"w,omitempty"`
+	LeaseUntil int64  `json:"l,omitempty"`
+	Attempts   uint32 `json:"a,omitempty"`
+	Done       bool   `json:"d,omitempty"`
+}
+
+const (
+	taskKeyFormat = "%s/%020d/%s"
+	// Number of keys scanned at a time while looking for due tasks
+	taskScanPageSize = 100
+)
+
+var errInvalidTaskKey = errors.New("invalid task key")
+
+// NewTaskScheduler creates a TaskScheduler for the tasks of the given
+// queue, on behalf of the given worker. Claimed tasks remain invisible
+// to other workers for the given visibility timeout.
+func NewTaskScheduler(client *DKVClient, queue, worker string, visibilityTimeout time.Duration) (*TaskScheduler, error) {
+	if queue == "" || strings.Contains(queue, "/") {
+		return nil, fmt.Errorf("invalid queue name: %q, must be non-empty without any '/'", queue)
+	}
+	if visibilityTimeout <= 0 {
+		return nil, fmt.Errorf("visibility timeout: %v must be positive", visibilityTimeout)
+	}
+	return &TaskScheduler{client, queue, worker, visibilityTimeout}, nil
+}
+
+// Schedule schedules the task with the given ID to be due at the given
+// time. Scheduling is idempotent, so that the task is scheduled only if
+// no task with the same ID is already scheduled at the same time, which
+// is indicated by the returned flag.
+func (ts *TaskScheduler) Schedule(id string, due time.Time, payload []byte) (bool, error) {
+	value, err := json.Marshal(&taskState{Payload: payload})
+	if err != nil {
+		return false, err
+	}
+	return ts.client.CompareAndSet(ts.taskKey(id, due), nil, value)
+}
+
+// Claim claims at most the given number of tasks that are due by now,
+// in the order of their due times. Tasks claimed by other workers are
+// skipped until their leases lapse.
+func (ts *TaskScheduler) Claim(maxTasks int) ([]*Task, error) {
+	now := time.Now()
+	prefix := []byte(ts.queue + "/")
+	var tasks []*Task
+	var token string
+	// Scans stopped before their last page release their cursor
+	defer func() {
+		if token != "" {
+			_ = ts.client.CloseScan(token)
+		}
+	}()
+	for len(tasks) < maxTasks {
+		kvs, nextToken, err := ts.client.Scan(prefix, nil, taskScanPageSize, token)
+		if err != nil {
+			// Closing a cursor already released by the failed page
+			// fails harmlessly
+			return tasks, err
+		}
+		token = nextToken
+		for _, kv := range kvs {
+			if len(tasks) == maxTasks {
+				break
+			}
+			task, state, err := ts.parseTask(kv.Key, kv.Value)
+			if err != nil {
+				return tasks, err
+			}
+			if task.Due.After(now) {
+				return tasks, nil
+			}
+			if state.Done {
+				// Completed, but not deleted yet
+				_ = ts.client.Delete(kv.Key)
+				continue
+			}
+			if state.LeaseUntil > now.UnixNano() {
+				continue
+			}
+			if claimed, err := ts.claim(task, state, now); err != nil {
+				return tasks, err
+			} else if claimed {
+				tasks = append(tasks, task)
+			}
+		}
+		if token == "" {
+			break
+		}
+	}
+	return tasks, nil
+}
+
+func (ts *TaskScheduler) claim(task *Task, state *taskState, now time.Time) (bool, error) {
+	state.Worker = ts.worker
+	state.LeaseUntil = now.Add(ts.visibilityTimeout).UnixNano()
+	state.Attempts++
+	value, err := json.Marshal(state)
+	if err != nil {
+		return false, err
+	}
+	if claimed, err := ts.client.CompareAndSet(task.key, task.value, value); err != nil || !claimed {
+		return false, err
+	}
+	task.Attempts, task.value = state.Attempts, value
+	return true, nil
+}
+
+// Extend extends the lease of the given claimed task by the visibility
+// timeout. Returns false if the task is no longer claimed by this worker,
+// which is the case once its lease lapses and another worker claims it.
+func (ts *TaskScheduler) Extend(task *Task) (bool, error) {
+	state := &taskState{}
+	if err := json.Unmarshal(task.value, state); err != nil {
+		return false, err
+	}
+	state.LeaseUntil = time.Now().Add(ts.visibilityTimeout).UnixNano()
+	value, err := json.Marshal(state)
+	if err != nil {
+		return false, err
+	}
+	if extended, err := ts.client.CompareAndSet(task.key, task.value, value); err != nil || !extended {
+		return false, err
+	}
+	task.value = value
+	return true, nil
+}
+
+// Complete removes the given claimed task from the queue. Returns false
+// if the task is no longer claimed by this worker, in which case the
+// task is left to the worker that claimed it.
+func (ts *TaskScheduler) Complete(task *Task) (bool, error) {
+	value, err := json.Marshal(&taskState{Done: true})
+	if err != nil {
+		return false, err
+	}
+	if completed, err := ts.client.CompareAndSet(task.key, task.value, value); err != nil || !completed {
+		return false, err
+	}
+	// Completed tasks left behind are deleted while claiming
+	return true, ts.client.Delete(task.key)
+}
+
+func (ts *TaskScheduler) taskKey(id string, due time.Time) []byte {
+	return []byte(fmt.Sprintf(taskKeyFormat, ts.queue, due.UnixNano(), id))
+}
+
+func (ts *TaskScheduler) parseTask(key, value []byte) (*Task, *taskState, error) {
+	parts := strings.SplitN(string(key), "/", 3)
+	if len(parts) != 3 {
+		return nil, nil, errInvalidTaskKey
+	}
+	dueNanos, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, nil, errInvalidTaskKey
+	}
+	state := &taskState{}
+	if err = json.Unmarshal(value, state); err != nil {
+		return nil, nil, err
+	}
+	task := &Task{ID: parts[2], Due: time.Unix(0, dueNanos), Payload: state.Payload, Attempts: state.Attempts, key: key, value: value}
+	return task, state, nil
+}
@@ -0,0 +1,95 @@
+package ctl
+
+import (
+	"context"
+
+	"github.com/flipkart-incubator/dkv/pkg/serverpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Txn is an optimistic transaction over multiple keys. The values read
+// through the transaction are tracked and the writes are buffered until
+// the transaction is committed, which fails with a conflict if any of the
+// read keys changed in the meantime. A Txn must not be used concurrently.
+type Txn struct {
+	client *DKVClient
+	reads  map[string][]byte
+	writes map[string]*serverpb.TxnWrite
+	order  []string
+}
+
+// NewTxn begins a new optimistic transaction.
+func (dkvClnt *DKVClient) NewTxn() *Txn {
+	return &Txn{client: dkvClnt, reads: make(map[string][]byte), writes: make(map[string]*serverpb.TxnWrite)}
+}
+
+// Get loads the value of the given key, as observed by this transaction.
+// The value of a key written by this transaction is the written value.
+// An empty value indicates that the key is absent.
+func (txn *Txn) Get(key []byte) ([]byte, error) {
+	if write, present := txn.writes[string(key)]; present {
+		if write.Delete {
+			return nil, nil
+		}
+		return write.Value, nil
+	}
+	if value, present := txn.reads[string(key)]; present {
+		return value, nil
+	}
+	res, err := txn.client.Get(serverpb.ReadConsistency_LINEARIZABLE, key)
+	var resStatus *serverpb.Status
+	if res != nil {
+		resStatus = res.Status
+	}
+	if err = errorFromStatus(resStatus, err); err != nil {
+		return nil, err
+	}
+	txn.reads[string(key)] = res.Value
+	return res.Value, nil
+}
+
+// Put buffers the association of the given key and value.
+func (txn *Txn) Put(key, value []byte) {
+	txn.write(&serverpb.TxnWrite{Key: key, Value: value})
+}
+
+// Delete buffers the deletion of the given key.
+func (txn *Txn) Delete(key []byte) {
+	txn.write(&serverpb.TxnWrite{Key: key, Delete: true})
+}
+
+func (txn *Txn) write(write *serverpb.TxnWrite) {
+	if _, present := txn.writes[string(write.Key)]; !present {
+		txn.order = append(txn.order, string(write.Key))
+	}
+	txn.writes[string(write.Key)] = write
+}
+
+// Commit commits the buffered writes, provided none of the keys read by
+// this transaction changed since they were read. Conflicts are reported
+// with an error for which IsTxnConflict holds, upon which the transaction
+// can be retried afresh.
+func (txn *Txn) Commit() error {
+	txnReq := &serverpb.TransactRequest{}
+	for key, value := range txn.reads {
+		txnReq.Reads = append(txnReq.Reads, &serverpb.KVPair{Key: []byte(key), Value: value})
+	}
+	for _, key := range txn.order {
+		txnReq.Writes = append(txnReq.Writes, txn.writes[key])
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	res, err := txn.client.dkvCli.Transact(ctx, txnReq)
+	var resStatus *serverpb.Status
+	if res != nil {
+		resStatus = res.Status
+	}
+	return errorFromStatus(resStatus, err)
+}
+
+// IsTxnConflict checks if the given error indicates that a transaction
+// was not committed due to a conflicting change, which can be retried.
+func IsTxnConflict(err error) bool {
+	return status.Code(err) == codes.Aborted
+}
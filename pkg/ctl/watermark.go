@@ -0,0 +1,51 @@
+package ctl
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/flipkart-incubator/dkv/internal/watermark"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// A ChangeNumberTracker records the highest change number seen in the
+// trailers of the responses of a DKV node, which are populated only when
+// the node is configured with `change-number-trailers`. Comparing it
+// against the change number of a write lets the callers, such as proxies,
+// route reads only to nodes that have caught up with that write. It is
+// safe for concurrent use.
+type ChangeNumberTracker struct {
+	latest uint64
+}
+
+// WithChangeNumberTracker makes the unary calls of the client update the
+// given tracker with the change numbers seen in their trailers.
+func WithChangeNumberTracker(tracker *ChangeNumberTracker) ClientOption {
+	return withDialOptions(grpc.WithChainUnaryInterceptor(tracker.intercept))
+}
+
+// Latest retrieves the highest change number seen so far, which is 0 if
+// none has been seen.
+func (cnt *ChangeNumberTracker) Latest() uint64 {
+	return atomic.LoadUint64(&cnt.latest)
+}
+
+func (cnt *ChangeNumberTracker) intercept(ctx context.Context, method string, req, reply interface{},
+	cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	var trailer metadata.MD
+	err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Trailer(&trailer))...)
+	if chngNum, ok := watermark.FromTrailer(trailer); ok {
+		cnt.observe(chngNum)
+	}
+	return err
+}
+
+func (cnt *ChangeNumberTracker) observe(chngNum uint64) {
+	for {
+		latest := atomic.LoadUint64(&cnt.latest)
+		if chngNum <= latest || atomic.CompareAndSwapUint64(&cnt.latest, latest, chngNum) {
+			return
+		}
+	}
+}
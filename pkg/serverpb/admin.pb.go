@@ -26,6 +26,169 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type ExportFormat int32
+
+const (
+	// SST files that can be ingested into a DKV node using IngestSST, one
+	// holding the keys without TTL and another holding the keys with TTL.
+	ExportFormat_SST ExportFormat = 0
+	// A single file of length prefixed records that can be read without
+	// RocksDB. The file begins with the magic bytes "DKVX" followed by a
+	// version byte, after which every record consists of the big endian
+	// 4 byte length of the key, the key, the big endian 4 byte length of
+	// the value, the value and the big endian 8 byte expiry in epoch
+	// seconds, which is zero for the keys without TTL.
+	ExportFormat_BINARY ExportFormat = 1
+)
+
+// Enum value maps for ExportFormat.
+var (
+	ExportFormat_name = map[int32]string{
+		0: "SST",
+		1: "BINARY",
+	}
+	ExportFormat_value = map[string]int32{
+		"SST":    0,
+		"BINARY": 1,
+	}
+)
+
+func (x ExportFormat) Enum() *ExportFormat {
+	p := new(ExportFormat)
+	*p = x
+	return p
+}
+
+func (x ExportFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExportFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_serverpb_admin_proto_enumTypes[0].Descriptor()
+}
+
+func (ExportFormat) Type() protoreflect.EnumType {
+	return &file_pkg_serverpb_admin_proto_enumTypes[0]
+}
+
+func (x ExportFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExportFormat.Descriptor instead.
+func (ExportFormat) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{0}
+}
+
+// PrefixRenamePhase indicates the progress of a key prefix rename.
+type PrefixRenamePhase int32
+
+const (
+	// Keys of the old prefix are being copied, with the reads falling back
+	// to the old prefix.
+	PrefixRenamePhase_COPYING PrefixRenamePhase = 0
+	// Keys of the old prefix are copied, with the reads falling back to the
+	// old prefix until the rename is completed.
+	PrefixRenamePhase_COPIED PrefixRenamePhase = 1
+	// Keys of the old prefix are being deleted, with the reads no longer
+	// falling back to the old prefix.
+	PrefixRenamePhase_SWEEPING PrefixRenamePhase = 2
+)
+
+// Enum value maps for PrefixRenamePhase.
+var (
+	PrefixRenamePhase_name = map[int32]string{
+		0: "COPYING",
+		1: "COPIED",
+		2: "SWEEPING",
+	}
+	PrefixRenamePhase_value = map[string]int32{
+		"COPYING":  0,
+		"COPIED":   1,
+		"SWEEPING": 2,
+	}
+)
+
+func (x PrefixRenamePhase) Enum() *PrefixRenamePhase {
+	p := new(PrefixRenamePhase)
+	*p = x
+	return p
+}
+
+func (x PrefixRenamePhase) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PrefixRenamePhase) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_serverpb_admin_proto_enumTypes[1].Descriptor()
+}
+
+func (PrefixRenamePhase) Type() protoreflect.EnumType {
+	return &file_pkg_serverpb_admin_proto_enumTypes[1]
+}
+
+func (x PrefixRenamePhase) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PrefixRenamePhase.Descriptor instead.
+func (PrefixRenamePhase) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{1}
+}
+
+type KeySegmentType int32
+
+const (
+	// Strings are terminated within the keys, with their zero bytes escaped.
+	KeySegmentType_STRING_SEGMENT KeySegmentType = 0
+	// Unsigned integers are encoded in 8 bytes, big endian.
+	KeySegmentType_UINT64_SEGMENT KeySegmentType = 1
+	// Signed integers are encoded in 8 bytes, big endian with the sign bit
+	// flipped.
+	KeySegmentType_INT64_SEGMENT KeySegmentType = 2
+)
+
+// Enum value maps for KeySegmentType.
+var (
+	KeySegmentType_name = map[int32]string{
+		0: "STRING_SEGMENT",
+		1: "UINT64_SEGMENT",
+		2: "INT64_SEGMENT",
+	}
+	KeySegmentType_value = map[string]int32{
+		"STRING_SEGMENT": 0,
+		"UINT64_SEGMENT": 1,
+		"INT64_SEGMENT":  2,
+	}
+)
+
+func (x KeySegmentType) Enum() *KeySegmentType {
+	p := new(KeySegmentType)
+	*p = x
+	return p
+}
+
+func (x KeySegmentType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (KeySegmentType) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_serverpb_admin_proto_enumTypes[2].Descriptor()
+}
+
+func (KeySegmentType) Type() protoreflect.EnumType {
+	return &file_pkg_serverpb_admin_proto_enumTypes[2]
+}
+
+func (x KeySegmentType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use KeySegmentType.Descriptor instead.
+func (KeySegmentType) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{2}
+}
+
 type RegionStatus int32
 
 const (
@@ -74,11 +237,11 @@ func (x RegionStatus) String() string {
 }
 
 func (RegionStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_pkg_serverpb_admin_proto_enumTypes[0].Descriptor()
+	return file_pkg_serverpb_admin_proto_enumTypes[3].Descriptor()
 }
 
 func (RegionStatus) Type() protoreflect.EnumType {
-	return &file_pkg_serverpb_admin_proto_enumTypes[0]
+	return &file_pkg_serverpb_admin_proto_enumTypes[3]
 }
 
 func (x RegionStatus) Number() protoreflect.EnumNumber {
@@ -87,7 +250,7 @@ func (x RegionStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use RegionStatus.Descriptor instead.
 func (RegionStatus) EnumDescriptor() ([]byte, []int) {
-	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{0}
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{3}
 }
 
 type TrxnRecord_TrxnType int32
@@ -96,6 +259,7 @@ const (
 	TrxnRecord_Unknown TrxnRecord_TrxnType = 0
 	TrxnRecord_Put     TrxnRecord_TrxnType = 1
 	TrxnRecord_Delete  TrxnRecord_TrxnType = 2
+	TrxnRecord_Merge   TrxnRecord_TrxnType = 3
 )
 
 // Enum value maps for TrxnRecord_TrxnType.
@@ -104,11 +268,13 @@ var (
 		0: "Unknown",
 		1: "Put",
 		2: "Delete",
+		3: "Merge",
 	}
 	TrxnRecord_TrxnType_value = map[string]int32{
 		"Unknown": 0,
 		"Put":     1,
 		"Delete":  2,
+		"Merge":   3,
 	}
 )
 
@@ -123,11 +289,11 @@ func (x TrxnRecord_TrxnType) String() string {
 }
 
 func (TrxnRecord_TrxnType) Descriptor() protoreflect.EnumDescriptor {
-	return file_pkg_serverpb_admin_proto_enumTypes[1].Descriptor()
+	return file_pkg_serverpb_admin_proto_enumTypes[4].Descriptor()
 }
 
 func (TrxnRecord_TrxnType) Type() protoreflect.EnumType {
-	return &file_pkg_serverpb_admin_proto_enumTypes[1]
+	return &file_pkg_serverpb_admin_proto_enumTypes[4]
 }
 
 func (x TrxnRecord_TrxnType) Number() protoreflect.EnumNumber {
@@ -136,20 +302,28 @@ func (x TrxnRecord_TrxnType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use TrxnRecord_TrxnType.Descriptor instead.
 func (TrxnRecord_TrxnType) EnumDescriptor() ([]byte, []int) {
-	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{6, 0}
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{19, 0}
 }
 
-type GetReplicasRequest struct {
+type GetRangeDigestsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Zone identifier to use for looking up replicas of the current master.
-	Zone string `protobuf:"bytes,1,opt,name=zone,proto3" json:"zone,omitempty"`
+	// StartKey is the key from which the first range begins.
+	StartKey []byte `protobuf:"bytes,1,opt,name=startKey,proto3" json:"startKey,omitempty"`
+	// EndKey, if given, ends the last range before it. Otherwise the last
+	// range extends upto the end of the keyspace.
+	EndKey []byte `protobuf:"bytes,2,opt,name=endKey,proto3" json:"endKey,omitempty"`
+	// KeysPerRange is the maximum number of keys of every range. A single
+	// range spans upto the end key when zero.
+	KeysPerRange uint32 `protobuf:"varint,3,opt,name=keysPerRange,proto3" json:"keysPerRange,omitempty"`
+	// MaxRanges is the maximum number of ranges digested. Defaults to 1.
+	MaxRanges uint32 `protobuf:"varint,4,opt,name=maxRanges,proto3" json:"maxRanges,omitempty"`
 }
 
-func (x *GetReplicasRequest) Reset() {
-	*x = GetReplicasRequest{}
+func (x *GetRangeDigestsRequest) Reset() {
+	*x = GetRangeDigestsRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_serverpb_admin_proto_msgTypes[0]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -157,13 +331,13 @@ func (x *GetReplicasRequest) Reset() {
 	}
 }
 
-func (x *GetReplicasRequest) String() string {
+func (x *GetRangeDigestsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetReplicasRequest) ProtoMessage() {}
+func (*GetRangeDigestsRequest) ProtoMessage() {}
 
-func (x *GetReplicasRequest) ProtoReflect() protoreflect.Message {
+func (x *GetRangeDigestsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_serverpb_admin_proto_msgTypes[0]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -175,29 +349,58 @@ func (x *GetReplicasRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetReplicasRequest.ProtoReflect.Descriptor instead.
-func (*GetReplicasRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetRangeDigestsRequest.ProtoReflect.Descriptor instead.
+func (*GetRangeDigestsRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{0}
 }
 
-func (x *GetReplicasRequest) GetZone() string {
+func (x *GetRangeDigestsRequest) GetStartKey() []byte {
 	if x != nil {
-		return x.Zone
+		return x.StartKey
 	}
-	return ""
+	return nil
 }
 
-type GetReplicasResponse struct {
+func (x *GetRangeDigestsRequest) GetEndKey() []byte {
+	if x != nil {
+		return x.EndKey
+	}
+	return nil
+}
+
+func (x *GetRangeDigestsRequest) GetKeysPerRange() uint32 {
+	if x != nil {
+		return x.KeysPerRange
+	}
+	return 0
+}
+
+func (x *GetRangeDigestsRequest) GetMaxRanges() uint32 {
+	if x != nil {
+		return x.MaxRanges
+	}
+	return 0
+}
+
+type RangeDigest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Replicas are all the replicas of the current master.
-	Replicas []*Replica `protobuf:"bytes,1,rep,name=replicas,proto3" json:"replicas,omitempty"`
+	// StartKey is the key from which the range begins, inclusive.
+	StartKey []byte `protobuf:"bytes,1,opt,name=startKey,proto3" json:"startKey,omitempty"`
+	// EndKey is the key before which the range ends. Empty if the range
+	// extends upto the end of the keyspace.
+	EndKey []byte `protobuf:"bytes,2,opt,name=endKey,proto3" json:"endKey,omitempty"`
+	// NumKeys is the number of keys within the range.
+	NumKeys uint64 `protobuf:"varint,3,opt,name=numKeys,proto3" json:"numKeys,omitempty"`
+	// Digest is computed over the keys within the range in their order,
+	// along with the checksums of their values and expiries.
+	Digest uint64 `protobuf:"varint,4,opt,name=digest,proto3" json:"digest,omitempty"`
 }
 
-func (x *GetReplicasResponse) Reset() {
-	*x = GetReplicasResponse{}
+func (x *RangeDigest) Reset() {
+	*x = RangeDigest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_serverpb_admin_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -205,13 +408,13 @@ func (x *GetReplicasResponse) Reset() {
 	}
 }
 
-func (x *GetReplicasResponse) String() string {
+func (x *RangeDigest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetReplicasResponse) ProtoMessage() {}
+func (*RangeDigest) ProtoMessage() {}
 
-func (x *GetReplicasResponse) ProtoReflect() protoreflect.Message {
+func (x *RangeDigest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_serverpb_admin_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -223,33 +426,54 @@ func (x *GetReplicasResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetReplicasResponse.ProtoReflect.Descriptor instead.
-func (*GetReplicasResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use RangeDigest.ProtoReflect.Descriptor instead.
+func (*RangeDigest) Descriptor() ([]byte, []int) {
 	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *GetReplicasResponse) GetReplicas() []*Replica {
+func (x *RangeDigest) GetStartKey() []byte {
 	if x != nil {
-		return x.Replicas
+		return x.StartKey
 	}
 	return nil
 }
 
-type Replica struct {
+func (x *RangeDigest) GetEndKey() []byte {
+	if x != nil {
+		return x.EndKey
+	}
+	return nil
+}
+
+func (x *RangeDigest) GetNumKeys() uint64 {
+	if x != nil {
+		return x.NumKeys
+	}
+	return 0
+}
+
+func (x *RangeDigest) GetDigest() uint64 {
+	if x != nil {
+		return x.Digest
+	}
+	return 0
+}
+
+type GetRangeDigestsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Zone identifier of the replica.
-	Zone string `protobuf:"bytes,1,opt,name=zone,proto3" json:"zone,omitempty"`
-	// Hostname of the replica.
-	Hostname string `protobuf:"bytes,2,opt,name=hostname,proto3" json:"hostname,omitempty"`
-	// GRPC port of the replica.
-	Port uint32 `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`
+	// Status indicates the result of the GetRangeDigests operation.
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// Digests holds the digests of the consecutive ranges, beginning at the
+	// requested start key. The range following the last one is yet to be
+	// digested, unless the last range extends upto the requested end key.
+	Digests []*RangeDigest `protobuf:"bytes,2,rep,name=digests,proto3" json:"digests,omitempty"`
 }
 
-func (x *Replica) Reset() {
-	*x = Replica{}
+func (x *GetRangeDigestsResponse) Reset() {
+	*x = GetRangeDigestsResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_serverpb_admin_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -257,13 +481,13 @@ func (x *Replica) Reset() {
 	}
 }
 
-func (x *Replica) String() string {
+func (x *GetRangeDigestsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Replica) ProtoMessage() {}
+func (*GetRangeDigestsResponse) ProtoMessage() {}
 
-func (x *Replica) ProtoReflect() protoreflect.Message {
+func (x *GetRangeDigestsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_serverpb_admin_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -275,45 +499,46 @@ func (x *Replica) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Replica.ProtoReflect.Descriptor instead.
-func (*Replica) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetRangeDigestsResponse.ProtoReflect.Descriptor instead.
+func (*GetRangeDigestsResponse) Descriptor() ([]byte, []int) {
 	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *Replica) GetZone() string {
-	if x != nil {
-		return x.Zone
-	}
-	return ""
-}
-
-func (x *Replica) GetHostname() string {
+func (x *GetRangeDigestsResponse) GetStatus() *Status {
 	if x != nil {
-		return x.Hostname
+		return x.Status
 	}
-	return ""
+	return nil
 }
 
-func (x *Replica) GetPort() uint32 {
+func (x *GetRangeDigestsResponse) GetDigests() []*RangeDigest {
 	if x != nil {
-		return x.Port
+		return x.Digests
 	}
-	return 0
+	return nil
 }
 
-type GetChangesRequest struct {
+type DivergentRange struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// FromChangeNumber is the starting change number from which to retrieve changes.
-	FromChangeNumber uint64 `protobuf:"varint,1,opt,name=fromChangeNumber,proto3" json:"fromChangeNumber,omitempty"`
-	// MaxNumberOfChanges is the maximum number of changes to return from this invocation.
-	MaxNumberOfChanges uint32 `protobuf:"varint,2,opt,name=maxNumberOfChanges,proto3" json:"maxNumberOfChanges,omitempty"`
-}
-
-func (x *GetChangesRequest) Reset() {
-	*x = GetChangesRequest{}
+	// StartKey is the key from which the range begins, inclusive.
+	StartKey []byte `protobuf:"bytes,1,opt,name=startKey,proto3" json:"startKey,omitempty"`
+	// EndKey is the key before which the range ends. Empty if the range
+	// extends upto the end of the keyspace.
+	EndKey []byte `protobuf:"bytes,2,opt,name=endKey,proto3" json:"endKey,omitempty"`
+	// MasterNumKeys is the number of keys of the master within the range.
+	MasterNumKeys uint64 `protobuf:"varint,3,opt,name=masterNumKeys,proto3" json:"masterNumKeys,omitempty"`
+	// SlaveNumKeys is the number of keys of the slave within the range.
+	SlaveNumKeys uint64 `protobuf:"varint,4,opt,name=slaveNumKeys,proto3" json:"slaveNumKeys,omitempty"`
+	// DetectedTimestamp is the epoch milliseconds at which the divergence
+	// was last detected.
+	DetectedTimestamp int64 `protobuf:"varint,5,opt,name=detectedTimestamp,proto3" json:"detectedTimestamp,omitempty"`
+}
+
+func (x *DivergentRange) Reset() {
+	*x = DivergentRange{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_serverpb_admin_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -321,13 +546,13 @@ func (x *GetChangesRequest) Reset() {
 	}
 }
 
-func (x *GetChangesRequest) String() string {
+func (x *DivergentRange) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetChangesRequest) ProtoMessage() {}
+func (*DivergentRange) ProtoMessage() {}
 
-func (x *GetChangesRequest) ProtoReflect() protoreflect.Message {
+func (x *DivergentRange) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_serverpb_admin_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -339,42 +564,63 @@ func (x *GetChangesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetChangesRequest.ProtoReflect.Descriptor instead.
-func (*GetChangesRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DivergentRange.ProtoReflect.Descriptor instead.
+func (*DivergentRange) Descriptor() ([]byte, []int) {
 	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *GetChangesRequest) GetFromChangeNumber() uint64 {
+func (x *DivergentRange) GetStartKey() []byte {
 	if x != nil {
-		return x.FromChangeNumber
+		return x.StartKey
+	}
+	return nil
+}
+
+func (x *DivergentRange) GetEndKey() []byte {
+	if x != nil {
+		return x.EndKey
+	}
+	return nil
+}
+
+func (x *DivergentRange) GetMasterNumKeys() uint64 {
+	if x != nil {
+		return x.MasterNumKeys
 	}
 	return 0
 }
 
-func (x *GetChangesRequest) GetMaxNumberOfChanges() uint32 {
+func (x *DivergentRange) GetSlaveNumKeys() uint64 {
 	if x != nil {
-		return x.MaxNumberOfChanges
+		return x.SlaveNumKeys
 	}
 	return 0
 }
 
-type GetChangesResponse struct {
+func (x *DivergentRange) GetDetectedTimestamp() int64 {
+	if x != nil {
+		return x.DetectedTimestamp
+	}
+	return 0
+}
+
+type GetDivergentRangesResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Status indicates the result of the GetChanges operation.
+	// Status indicates the result of the GetDivergentRanges operation.
 	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
-	// MasterChangeNumber indicates the latest change number on master node.
-	MasterChangeNumber uint64 `protobuf:"varint,2,opt,name=masterChangeNumber,proto3" json:"masterChangeNumber,omitempty"`
-	// NumberOfChanges indicates the number of change records in the response.
-	NumberOfChanges uint32 `protobuf:"varint,3,opt,name=numberOfChanges,proto3" json:"numberOfChanges,omitempty"`
-	// Changes is the collection of change records.
-	Changes []*ChangeRecord `protobuf:"bytes,4,rep,name=changes,proto3" json:"changes,omitempty"`
+	// Ranges holds the divergent ranges in the order of their start keys.
+	// Ranges are removed once they are verified to be consistent again.
+	Ranges []*DivergentRange `protobuf:"bytes,2,rep,name=ranges,proto3" json:"ranges,omitempty"`
+	// LastPassTimestamp is the epoch milliseconds at which the verifier
+	// last completed verifying the entire keyspace. Zero if it has not yet.
+	LastPassTimestamp int64 `protobuf:"varint,3,opt,name=lastPassTimestamp,proto3" json:"lastPassTimestamp,omitempty"`
 }
 
-func (x *GetChangesResponse) Reset() {
-	*x = GetChangesResponse{}
+func (x *GetDivergentRangesResponse) Reset() {
+	*x = GetDivergentRangesResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_serverpb_admin_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -382,13 +628,13 @@ func (x *GetChangesResponse) Reset() {
 	}
 }
 
-func (x *GetChangesResponse) String() string {
+func (x *GetDivergentRangesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetChangesResponse) ProtoMessage() {}
+func (*GetDivergentRangesResponse) ProtoMessage() {}
 
-func (x *GetChangesResponse) ProtoReflect() protoreflect.Message {
+func (x *GetDivergentRangesResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_serverpb_admin_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -400,56 +646,50 @@ func (x *GetChangesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetChangesResponse.ProtoReflect.Descriptor instead.
-func (*GetChangesResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetDivergentRangesResponse.ProtoReflect.Descriptor instead.
+func (*GetDivergentRangesResponse) Descriptor() ([]byte, []int) {
 	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *GetChangesResponse) GetStatus() *Status {
+func (x *GetDivergentRangesResponse) GetStatus() *Status {
 	if x != nil {
 		return x.Status
 	}
 	return nil
 }
 
-func (x *GetChangesResponse) GetMasterChangeNumber() uint64 {
+func (x *GetDivergentRangesResponse) GetRanges() []*DivergentRange {
 	if x != nil {
-		return x.MasterChangeNumber
+		return x.Ranges
 	}
-	return 0
+	return nil
 }
 
-func (x *GetChangesResponse) GetNumberOfChanges() uint32 {
+func (x *GetDivergentRangesResponse) GetLastPassTimestamp() int64 {
 	if x != nil {
-		return x.NumberOfChanges
+		return x.LastPassTimestamp
 	}
 	return 0
 }
 
-func (x *GetChangesResponse) GetChanges() []*ChangeRecord {
-	if x != nil {
-		return x.Changes
-	}
-	return nil
-}
-
-type ChangeRecord struct {
+type ReplicationLagReport struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// SerialisedForm is the internal byte array representation of this change record.
-	SerialisedForm []byte `protobuf:"bytes,1,opt,name=serialisedForm,proto3" json:"serialisedForm,omitempty"`
-	// ChangeNumber indicates the change number of this change record.
-	ChangeNumber uint64 `protobuf:"varint,2,opt,name=changeNumber,proto3" json:"changeNumber,omitempty"`
-	// NumberOfTrxns indicates the number of transactions associated with this change record.
-	NumberOfTrxns uint32 `protobuf:"varint,3,opt,name=numberOfTrxns,proto3" json:"numberOfTrxns,omitempty"`
-	// Trxns is the collection of transaction records associated with this change record.
-	Trxns []*TrxnRecord `protobuf:"bytes,4,rep,name=trxns,proto3" json:"trxns,omitempty"`
+	// ReplicaID identifies the reporting slave, same as the one with which
+	// it retrieves the changes.
+	ReplicaID string `protobuf:"bytes,1,opt,name=replicaID,proto3" json:"replicaID,omitempty"`
+	// AppliedChangeNumber is the change number upto which the slave applied
+	// the changes of the master.
+	AppliedChangeNumber uint64 `protobuf:"varint,2,opt,name=appliedChangeNumber,proto3" json:"appliedChangeNumber,omitempty"`
+	// AppliedTimestamp is the epoch milliseconds at which the slave last
+	// applied any change.
+	AppliedTimestamp int64 `protobuf:"varint,3,opt,name=appliedTimestamp,proto3" json:"appliedTimestamp,omitempty"`
 }
 
-func (x *ChangeRecord) Reset() {
-	*x = ChangeRecord{}
+func (x *ReplicationLagReport) Reset() {
+	*x = ReplicationLagReport{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_serverpb_admin_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -457,13 +697,13 @@ func (x *ChangeRecord) Reset() {
 	}
 }
 
-func (x *ChangeRecord) String() string {
+func (x *ReplicationLagReport) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChangeRecord) ProtoMessage() {}
+func (*ReplicationLagReport) ProtoMessage() {}
 
-func (x *ChangeRecord) ProtoReflect() protoreflect.Message {
+func (x *ReplicationLagReport) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_serverpb_admin_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -475,56 +715,59 @@ func (x *ChangeRecord) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChangeRecord.ProtoReflect.Descriptor instead.
-func (*ChangeRecord) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReplicationLagReport.ProtoReflect.Descriptor instead.
+func (*ReplicationLagReport) Descriptor() ([]byte, []int) {
 	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *ChangeRecord) GetSerialisedForm() []byte {
+func (x *ReplicationLagReport) GetReplicaID() string {
 	if x != nil {
-		return x.SerialisedForm
+		return x.ReplicaID
 	}
-	return nil
+	return ""
 }
 
-func (x *ChangeRecord) GetChangeNumber() uint64 {
+func (x *ReplicationLagReport) GetAppliedChangeNumber() uint64 {
 	if x != nil {
-		return x.ChangeNumber
+		return x.AppliedChangeNumber
 	}
 	return 0
 }
 
-func (x *ChangeRecord) GetNumberOfTrxns() uint32 {
+func (x *ReplicationLagReport) GetAppliedTimestamp() int64 {
 	if x != nil {
-		return x.NumberOfTrxns
+		return x.AppliedTimestamp
 	}
 	return 0
 }
 
-func (x *ChangeRecord) GetTrxns() []*TrxnRecord {
-	if x != nil {
-		return x.Trxns
-	}
-	return nil
-}
-
-type TrxnRecord struct {
+type ReplicaLag struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Type indicates the type of this transaction - Put, Delete, etc.
-	Type TrxnRecord_TrxnType `protobuf:"varint,1,opt,name=type,proto3,enum=dkv.serverpb.TrxnRecord_TrxnType" json:"type,omitempty"`
-	// Key is the byte array representation of the key associated with this transaction.
-	Key []byte `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
-	// Value is the byte array representation of the value associated with this transaction.
-	Value []byte `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
-	// expireTS is the epoch seconds value, in int64 to associate with the key in the key value store.
-	ExpireTS uint64 `protobuf:"varint,4,opt,name=expireTS,proto3" json:"expireTS,omitempty"`
-}
-
-func (x *TrxnRecord) Reset() {
-	*x = TrxnRecord{}
+	// ReplicaID identifies the slave.
+	ReplicaID string `protobuf:"bytes,1,opt,name=replicaID,proto3" json:"replicaID,omitempty"`
+	// AppliedChangeNumber is the change number upto which the slave applied
+	// the changes of the master.
+	AppliedChangeNumber uint64 `protobuf:"varint,2,opt,name=appliedChangeNumber,proto3" json:"appliedChangeNumber,omitempty"`
+	// AppliedTimestamp is the epoch milliseconds at which the slave last
+	// applied any change, as reported by it. Zero if not reported.
+	AppliedTimestamp int64 `protobuf:"varint,3,opt,name=appliedTimestamp,proto3" json:"appliedTimestamp,omitempty"`
+	// LastSeenTimestamp is the epoch milliseconds at which the slave last
+	// retrieved the changes or reported its progress.
+	LastSeenTimestamp int64 `protobuf:"varint,4,opt,name=lastSeenTimestamp,proto3" json:"lastSeenTimestamp,omitempty"`
+	// LagChanges is the number of changes committed on the master that are
+	// yet to be applied by the slave.
+	LagChanges uint64 `protobuf:"varint,5,opt,name=lagChanges,proto3" json:"lagChanges,omitempty"`
+	// LagMillis is the duration for which the slave has been behind the
+	// master without applying any change. Zero if it is caught up or has
+	// not reported its progress.
+	LagMillis int64 `protobuf:"varint,6,opt,name=lagMillis,proto3" json:"lagMillis,omitempty"`
+}
+
+func (x *ReplicaLag) Reset() {
+	*x = ReplicaLag{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_serverpb_admin_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -532,13 +775,13 @@ func (x *TrxnRecord) Reset() {
 	}
 }
 
-func (x *TrxnRecord) String() string {
+func (x *ReplicaLag) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TrxnRecord) ProtoMessage() {}
+func (*ReplicaLag) ProtoMessage() {}
 
-func (x *TrxnRecord) ProtoReflect() protoreflect.Message {
+func (x *ReplicaLag) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_serverpb_admin_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -550,50 +793,68 @@ func (x *TrxnRecord) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TrxnRecord.ProtoReflect.Descriptor instead.
-func (*TrxnRecord) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReplicaLag.ProtoReflect.Descriptor instead.
+func (*ReplicaLag) Descriptor() ([]byte, []int) {
 	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *TrxnRecord) GetType() TrxnRecord_TrxnType {
+func (x *ReplicaLag) GetReplicaID() string {
 	if x != nil {
-		return x.Type
+		return x.ReplicaID
 	}
-	return TrxnRecord_Unknown
+	return ""
 }
 
-func (x *TrxnRecord) GetKey() []byte {
+func (x *ReplicaLag) GetAppliedChangeNumber() uint64 {
 	if x != nil {
-		return x.Key
+		return x.AppliedChangeNumber
 	}
-	return nil
+	return 0
 }
 
-func (x *TrxnRecord) GetValue() []byte {
+func (x *ReplicaLag) GetAppliedTimestamp() int64 {
 	if x != nil {
-		return x.Value
+		return x.AppliedTimestamp
 	}
-	return nil
+	return 0
 }
 
-func (x *TrxnRecord) GetExpireTS() uint64 {
+func (x *ReplicaLag) GetLastSeenTimestamp() int64 {
 	if x != nil {
-		return x.ExpireTS
+		return x.LastSeenTimestamp
 	}
 	return 0
 }
 
-type BackupRequest struct {
+func (x *ReplicaLag) GetLagChanges() uint64 {
+	if x != nil {
+		return x.LagChanges
+	}
+	return 0
+}
+
+func (x *ReplicaLag) GetLagMillis() int64 {
+	if x != nil {
+		return x.LagMillis
+	}
+	return 0
+}
+
+type GetReplicationLagResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// BackupPath indicates a filesystem folder or file used for backing up the keyspace.
-	BackupPath string `protobuf:"bytes,1,opt,name=backupPath,proto3" json:"backupPath,omitempty"`
+	// Status indicates the result of the GetReplicationLag operation.
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// MasterChangeNumber is the latest change number of the master.
+	MasterChangeNumber uint64 `protobuf:"varint,2,opt,name=masterChangeNumber,proto3" json:"masterChangeNumber,omitempty"`
+	// Replicas holds the lag of every slave, in the order of their IDs.
+	Replicas []*ReplicaLag `protobuf:"bytes,3,rep,name=replicas,proto3" json:"replicas,omitempty"`
 }
 
-func (x *BackupRequest) Reset() {
-	*x = BackupRequest{}
+func (x *GetReplicationLagResponse) Reset() {
+	*x = GetReplicationLagResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_serverpb_admin_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -601,13 +862,13 @@ func (x *BackupRequest) Reset() {
 	}
 }
 
-func (x *BackupRequest) String() string {
+func (x *GetReplicationLagResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BackupRequest) ProtoMessage() {}
+func (*GetReplicationLagResponse) ProtoMessage() {}
 
-func (x *BackupRequest) ProtoReflect() protoreflect.Message {
+func (x *GetReplicationLagResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_serverpb_admin_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -619,29 +880,51 @@ func (x *BackupRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BackupRequest.ProtoReflect.Descriptor instead.
-func (*BackupRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetReplicationLagResponse.ProtoReflect.Descriptor instead.
+func (*GetReplicationLagResponse) Descriptor() ([]byte, []int) {
 	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *BackupRequest) GetBackupPath() string {
+func (x *GetReplicationLagResponse) GetStatus() *Status {
 	if x != nil {
-		return x.BackupPath
+		return x.Status
 	}
-	return ""
+	return nil
 }
 
-type RestoreRequest struct {
+func (x *GetReplicationLagResponse) GetMasterChangeNumber() uint64 {
+	if x != nil {
+		return x.MasterChangeNumber
+	}
+	return 0
+}
+
+func (x *GetReplicationLagResponse) GetReplicas() []*ReplicaLag {
+	if x != nil {
+		return x.Replicas
+	}
+	return nil
+}
+
+type PromoteRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// RestorePath indicates a filesystem folder or file used for restoring the keyspace.
-	RestorePath string `protobuf:"bytes,1,opt,name=restorePath,proto3" json:"restorePath,omitempty"`
+	// SkipFencing promotes the node without issuing a new fencing epoch, so
+	// that the changes of the superseded master are still applied by the
+	// slaves. Meant only for when the superseded master is known to be down.
+	SkipFencing bool `protobuf:"varint,1,opt,name=skipFencing,proto3" json:"skipFencing,omitempty"`
+	// FenceMaster pushes the newly issued fencing epoch onto the master from
+	// which the slave replicates, before the replication is stopped, so that
+	// the superseded master rejects the writes thereafter. The slave is not
+	// promoted if its master can not be fenced. Ignored when fencing is
+	// skipped, as well as on the masters.
+	FenceMaster bool `protobuf:"varint,2,opt,name=fenceMaster,proto3" json:"fenceMaster,omitempty"`
 }
 
-func (x *RestoreRequest) Reset() {
-	*x = RestoreRequest{}
+func (x *PromoteRequest) Reset() {
+	*x = PromoteRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_serverpb_admin_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -649,13 +932,13 @@ func (x *RestoreRequest) Reset() {
 	}
 }
 
-func (x *RestoreRequest) String() string {
+func (x *PromoteRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RestoreRequest) ProtoMessage() {}
+func (*PromoteRequest) ProtoMessage() {}
 
-func (x *RestoreRequest) ProtoReflect() protoreflect.Message {
+func (x *PromoteRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_serverpb_admin_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -667,34 +950,43 @@ func (x *RestoreRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RestoreRequest.ProtoReflect.Descriptor instead.
-func (*RestoreRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use PromoteRequest.ProtoReflect.Descriptor instead.
+func (*PromoteRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *RestoreRequest) GetRestorePath() string {
+func (x *PromoteRequest) GetSkipFencing() bool {
 	if x != nil {
-		return x.RestorePath
+		return x.SkipFencing
 	}
-	return ""
+	return false
 }
 
-type ListNodesResponse struct {
+func (x *PromoteRequest) GetFenceMaster() bool {
+	if x != nil {
+		return x.FenceMaster
+	}
+	return false
+}
+
+type PromoteResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Status indicates the status of the list nodes operation.
+	// Status indicates the result of the Promote operation.
 	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
-	// Leader represents the identifier of the leader node.
-	Leader uint64 `protobuf:"varint,2,opt,name=leader,proto3" json:"leader,omitempty"`
-	// Nodes represents the members of the cluster identified by
-	// their respective identifier and the Nexus URL.
-	Nodes map[uint64]*models.NodeInfo `protobuf:"bytes,3,rep,name=nodes,proto3" json:"nodes,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// FencingEpoch is the newly issued fencing epoch, which is higher than
+	// all the epochs issued or observed by this node so far, or the current
+	// epoch when fencing is skipped.
+	FencingEpoch uint64 `protobuf:"varint,2,opt,name=fencingEpoch,proto3" json:"fencingEpoch,omitempty"`
+	// ChangeNumber is the latest change committed by the node, which for a
+	// promoted slave is the last change applied from its master.
+	ChangeNumber uint64 `protobuf:"varint,3,opt,name=changeNumber,proto3" json:"changeNumber,omitempty"`
 }
 
-func (x *ListNodesResponse) Reset() {
-	*x = ListNodesResponse{}
+func (x *PromoteResponse) Reset() {
+	*x = PromoteResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_serverpb_admin_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -702,13 +994,13 @@ func (x *ListNodesResponse) Reset() {
 	}
 }
 
-func (x *ListNodesResponse) String() string {
+func (x *PromoteResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListNodesResponse) ProtoMessage() {}
+func (*PromoteResponse) ProtoMessage() {}
 
-func (x *ListNodesResponse) ProtoReflect() protoreflect.Message {
+func (x *PromoteResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_serverpb_admin_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -720,45 +1012,46 @@ func (x *ListNodesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListNodesResponse.ProtoReflect.Descriptor instead.
-func (*ListNodesResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use PromoteResponse.ProtoReflect.Descriptor instead.
+func (*PromoteResponse) Descriptor() ([]byte, []int) {
 	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *ListNodesResponse) GetStatus() *Status {
+func (x *PromoteResponse) GetStatus() *Status {
 	if x != nil {
 		return x.Status
 	}
 	return nil
 }
 
-func (x *ListNodesResponse) GetLeader() uint64 {
+func (x *PromoteResponse) GetFencingEpoch() uint64 {
 	if x != nil {
-		return x.Leader
+		return x.FencingEpoch
 	}
 	return 0
 }
 
-func (x *ListNodesResponse) GetNodes() map[uint64]*models.NodeInfo {
+func (x *PromoteResponse) GetChangeNumber() uint64 {
 	if x != nil {
-		return x.Nodes
+		return x.ChangeNumber
 	}
-	return nil
+	return 0
 }
 
-type AddNodeRequest struct {
+type FenceRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// NodeUrl represents the URL for the Nexus service running on
-	// the node that needs to be added to the cluster. Other Nexus
-	// peers communicate with this node on this service.
-	NodeUrl string `protobuf:"bytes,1,opt,name=nodeUrl,proto3" json:"nodeUrl,omitempty"`
+	// FencingEpoch is the epoch issued by the node promoted to master.
+	FencingEpoch uint64 `protobuf:"varint,1,opt,name=fencingEpoch,proto3" json:"fencingEpoch,omitempty"`
+	// MasterAddress is the address of the node promoted to master, to which
+	// the writes rejected by the superseded master are to be redirected.
+	MasterAddress string `protobuf:"bytes,2,opt,name=masterAddress,proto3" json:"masterAddress,omitempty"`
 }
 
-func (x *AddNodeRequest) Reset() {
-	*x = AddNodeRequest{}
+func (x *FenceRequest) Reset() {
+	*x = FenceRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_serverpb_admin_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -766,13 +1059,13 @@ func (x *AddNodeRequest) Reset() {
 	}
 }
 
-func (x *AddNodeRequest) String() string {
+func (x *FenceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddNodeRequest) ProtoMessage() {}
+func (*FenceRequest) ProtoMessage() {}
 
-func (x *AddNodeRequest) ProtoReflect() protoreflect.Message {
+func (x *FenceRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_serverpb_admin_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -784,30 +1077,36 @@ func (x *AddNodeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddNodeRequest.ProtoReflect.Descriptor instead.
-func (*AddNodeRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use FenceRequest.ProtoReflect.Descriptor instead.
+func (*FenceRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *AddNodeRequest) GetNodeUrl() string {
+func (x *FenceRequest) GetFencingEpoch() uint64 {
 	if x != nil {
-		return x.NodeUrl
+		return x.FencingEpoch
+	}
+	return 0
+}
+
+func (x *FenceRequest) GetMasterAddress() string {
+	if x != nil {
+		return x.MasterAddress
 	}
 	return ""
 }
 
-type RemoveNodeRequest struct {
+type GetReplicasRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// NodeUrl represents the URL for the Nexus service running on
-	// the node that needs to be removed from the cluster.
-	NodeUrl string `protobuf:"bytes,1,opt,name=nodeUrl,proto3" json:"nodeUrl,omitempty"`
+	// Zone identifier to use for looking up replicas of the current master.
+	Zone string `protobuf:"bytes,1,opt,name=zone,proto3" json:"zone,omitempty"`
 }
 
-func (x *RemoveNodeRequest) Reset() {
-	*x = RemoveNodeRequest{}
+func (x *GetReplicasRequest) Reset() {
+	*x = GetReplicasRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_serverpb_admin_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -815,13 +1114,13 @@ func (x *RemoveNodeRequest) Reset() {
 	}
 }
 
-func (x *RemoveNodeRequest) String() string {
+func (x *GetReplicasRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveNodeRequest) ProtoMessage() {}
+func (*GetReplicasRequest) ProtoMessage() {}
 
-func (x *RemoveNodeRequest) ProtoReflect() protoreflect.Message {
+func (x *GetReplicasRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_serverpb_admin_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -833,30 +1132,29 @@ func (x *RemoveNodeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveNodeRequest.ProtoReflect.Descriptor instead.
-func (*RemoveNodeRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetReplicasRequest.ProtoReflect.Descriptor instead.
+func (*GetReplicasRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *RemoveNodeRequest) GetNodeUrl() string {
+func (x *GetReplicasRequest) GetZone() string {
 	if x != nil {
-		return x.NodeUrl
+		return x.Zone
 	}
 	return ""
 }
 
-type UpdateStatusRequest struct {
+type GetReplicasResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	RegionInfo *RegionInfo `protobuf:"bytes,1,opt,name=regionInfo,proto3" json:"regionInfo,omitempty"`
-	// Time at which the status was sent from the respective node
-	Timestamp uint64 `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Replicas are all the replicas of the current master.
+	Replicas []*Replica `protobuf:"bytes,1,rep,name=replicas,proto3" json:"replicas,omitempty"`
 }
 
-func (x *UpdateStatusRequest) Reset() {
-	*x = UpdateStatusRequest{}
+func (x *GetReplicasResponse) Reset() {
+	*x = GetReplicasResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_serverpb_admin_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -864,13 +1162,13 @@ func (x *UpdateStatusRequest) Reset() {
 	}
 }
 
-func (x *UpdateStatusRequest) String() string {
+func (x *GetReplicasResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateStatusRequest) ProtoMessage() {}
+func (*GetReplicasResponse) ProtoMessage() {}
 
-func (x *UpdateStatusRequest) ProtoReflect() protoreflect.Message {
+func (x *GetReplicasResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_serverpb_admin_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -882,41 +1180,33 @@ func (x *UpdateStatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateStatusRequest.ProtoReflect.Descriptor instead.
-func (*UpdateStatusRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetReplicasResponse.ProtoReflect.Descriptor instead.
+func (*GetReplicasResponse) Descriptor() ([]byte, []int) {
 	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *UpdateStatusRequest) GetRegionInfo() *RegionInfo {
+func (x *GetReplicasResponse) GetReplicas() []*Replica {
 	if x != nil {
-		return x.RegionInfo
+		return x.Replicas
 	}
 	return nil
 }
 
-func (x *UpdateStatusRequest) GetTimestamp() uint64 {
-	if x != nil {
-		return x.Timestamp
-	}
-	return 0
-}
-
-// Get cluster info filtered on request params
-type GetClusterInfoRequest struct {
+type Replica struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Identifier of datacenter / availability zone
-	DcID *string `protobuf:"bytes,1,opt,name=dcID,proto3,oneof" json:"dcID,omitempty"`
-	// Database identifier
-	Database *string `protobuf:"bytes,2,opt,name=database,proto3,oneof" json:"database,omitempty"`
-	// Shard / vBucket identifier
-	VBucket *string `protobuf:"bytes,3,opt,name=vBucket,proto3,oneof" json:"vBucket,omitempty"`
+	// Zone identifier of the replica.
+	Zone string `protobuf:"bytes,1,opt,name=zone,proto3" json:"zone,omitempty"`
+	// Hostname of the replica.
+	Hostname string `protobuf:"bytes,2,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	// GRPC port of the replica.
+	Port uint32 `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`
 }
 
-func (x *GetClusterInfoRequest) Reset() {
-	*x = GetClusterInfoRequest{}
+func (x *Replica) Reset() {
+	*x = Replica{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_serverpb_admin_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -924,13 +1214,13 @@ func (x *GetClusterInfoRequest) Reset() {
 	}
 }
 
-func (x *GetClusterInfoRequest) String() string {
+func (x *Replica) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetClusterInfoRequest) ProtoMessage() {}
+func (*Replica) ProtoMessage() {}
 
-func (x *GetClusterInfoRequest) ProtoReflect() protoreflect.Message {
+func (x *Replica) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_serverpb_admin_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -942,42 +1232,78 @@ func (x *GetClusterInfoRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetClusterInfoRequest.ProtoReflect.Descriptor instead.
-func (*GetClusterInfoRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Replica.ProtoReflect.Descriptor instead.
+func (*Replica) Descriptor() ([]byte, []int) {
 	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *GetClusterInfoRequest) GetDcID() string {
-	if x != nil && x.DcID != nil {
-		return *x.DcID
+func (x *Replica) GetZone() string {
+	if x != nil {
+		return x.Zone
 	}
 	return ""
 }
 
-func (x *GetClusterInfoRequest) GetDatabase() string {
-	if x != nil && x.Database != nil {
-		return *x.Database
+func (x *Replica) GetHostname() string {
+	if x != nil {
+		return x.Hostname
 	}
 	return ""
 }
 
-func (x *GetClusterInfoRequest) GetVBucket() string {
-	if x != nil && x.VBucket != nil {
-		return *x.VBucket
+func (x *Replica) GetPort() uint32 {
+	if x != nil {
+		return x.Port
 	}
-	return ""
+	return 0
 }
 
-type GetClusterInfoResponse struct {
+type GetChangesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	RegionInfos []*RegionInfo `protobuf:"bytes,1,rep,name=regionInfos,proto3" json:"regionInfos,omitempty"`
+	// FromChangeNumber is the starting change number from which to retrieve changes.
+	FromChangeNumber uint64 `protobuf:"varint,1,opt,name=fromChangeNumber,proto3" json:"fromChangeNumber,omitempty"`
+	// MaxNumberOfChanges is the maximum number of changes to return from this invocation.
+	MaxNumberOfChanges uint32 `protobuf:"varint,2,opt,name=maxNumberOfChanges,proto3" json:"maxNumberOfChanges,omitempty"`
+	// ReplicaID identifies the slave retrieving the changes for replication,
+	// based on which the master tracks the changes applied by every slave.
+	// Left empty by the other consumers of the changes.
+	ReplicaID string `protobuf:"bytes,3,opt,name=replicaID,proto3" json:"replicaID,omitempty"`
+	// MaxWaitMillis is the duration for which the master holds on to this
+	// request when there are no changes to return, responding as soon as
+	// any change is committed. Semi-sync replicas retrieve the changes this
+	// way for confirming their receipt as soon as possible.
+	MaxWaitMillis uint32 `protobuf:"varint,4,opt,name=maxWaitMillis,proto3" json:"maxWaitMillis,omitempty"`
+	// MaxBatchBytes is the size of the serialised changes upon which a
+	// response of StreamChanges is sent, zero leaves it unbounded.
+	MaxBatchBytes uint32 `protobuf:"varint,5,opt,name=maxBatchBytes,proto3" json:"maxBatchBytes,omitempty"`
+	// MaxBatchDelayMillis is the duration for which StreamChanges holds on
+	// to the changes for batching them with the following ones, trading
+	// latency for fewer and larger responses across high latency links.
+	MaxBatchDelayMillis uint32 `protobuf:"varint,6,opt,name=maxBatchDelayMillis,proto3" json:"maxBatchDelayMillis,omitempty"`
+	// HeartbeatMillis is the interval at which StreamChanges sends a
+	// response without any changes when there are none to send, so that
+	// the subscriber can tell an idle master from an unreachable one.
+	HeartbeatMillis uint32 `protobuf:"varint,7,opt,name=heartbeatMillis,proto3" json:"heartbeatMillis,omitempty"`
+	// MaxInFlightBytes is the size of the serialised changes that
+	// StreamChanges sends ahead of the acknowledgements of the subscriber,
+	// pausing once reached until the subscriber acknowledges applying them
+	// by reporting its replication lag. Requires the ReplicaID, zero leaves
+	// it unbounded.
+	MaxInFlightBytes uint32 `protobuf:"varint,8,opt,name=maxInFlightBytes,proto3" json:"maxInFlightBytes,omitempty"`
+	// RecordCompression is the compressor, one of gzip|zstd, with which every
+	// change record is compressed individually, leaving the records that do
+	// not shrink uncompressed. Empty disables the compression of the records.
+	RecordCompression string `protobuf:"bytes,9,opt,name=recordCompression,proto3" json:"recordCompression,omitempty"`
+	// DeltaEncodeKeys omits from the key of every transaction record the
+	// prefix it shares with the key of the preceding one of its change record.
+	DeltaEncodeKeys bool `protobuf:"varint,10,opt,name=deltaEncodeKeys,proto3" json:"deltaEncodeKeys,omitempty"`
 }
 
-func (x *GetClusterInfoResponse) Reset() {
-	*x = GetClusterInfoResponse{}
+func (x *GetChangesRequest) Reset() {
+	*x = GetChangesRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_serverpb_admin_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -985,13 +1311,13 @@ func (x *GetClusterInfoResponse) Reset() {
 	}
 }
 
-func (x *GetClusterInfoResponse) String() string {
+func (x *GetChangesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetClusterInfoResponse) ProtoMessage() {}
+func (*GetChangesRequest) ProtoMessage() {}
 
-func (x *GetClusterInfoResponse) ProtoReflect() protoreflect.Message {
+func (x *GetChangesRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_serverpb_admin_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1003,46 +1329,100 @@ func (x *GetClusterInfoResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetClusterInfoResponse.ProtoReflect.Descriptor instead.
-func (*GetClusterInfoResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetChangesRequest.ProtoReflect.Descriptor instead.
+func (*GetChangesRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *GetClusterInfoResponse) GetRegionInfos() []*RegionInfo {
+func (x *GetChangesRequest) GetFromChangeNumber() uint64 {
 	if x != nil {
-		return x.RegionInfos
+		return x.FromChangeNumber
 	}
-	return nil
+	return 0
 }
 
-type RegionInfo struct {
+func (x *GetChangesRequest) GetMaxNumberOfChanges() uint32 {
+	if x != nil {
+		return x.MaxNumberOfChanges
+	}
+	return 0
+}
+
+func (x *GetChangesRequest) GetReplicaID() string {
+	if x != nil {
+		return x.ReplicaID
+	}
+	return ""
+}
+
+func (x *GetChangesRequest) GetMaxWaitMillis() uint32 {
+	if x != nil {
+		return x.MaxWaitMillis
+	}
+	return 0
+}
+
+func (x *GetChangesRequest) GetMaxBatchBytes() uint32 {
+	if x != nil {
+		return x.MaxBatchBytes
+	}
+	return 0
+}
+
+func (x *GetChangesRequest) GetMaxBatchDelayMillis() uint32 {
+	if x != nil {
+		return x.MaxBatchDelayMillis
+	}
+	return 0
+}
+
+func (x *GetChangesRequest) GetHeartbeatMillis() uint32 {
+	if x != nil {
+		return x.HeartbeatMillis
+	}
+	return 0
+}
+
+func (x *GetChangesRequest) GetMaxInFlightBytes() uint32 {
+	if x != nil {
+		return x.MaxInFlightBytes
+	}
+	return 0
+}
+
+func (x *GetChangesRequest) GetRecordCompression() string {
+	if x != nil {
+		return x.RecordCompression
+	}
+	return ""
+}
+
+func (x *GetChangesRequest) GetDeltaEncodeKeys() bool {
+	if x != nil {
+		return x.DeltaEncodeKeys
+	}
+	return false
+}
+
+type GetChangesResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Identifier of datacenter / availability zone
-	// Slaves will prefer to select master within same dcId ,
-	// Local DC followers will be created for every unique dcId
-	DcID string `protobuf:"bytes,1,opt,name=dcID,proto3" json:"dcID,omitempty"`
-	// Address to communicate with this node
-	// Should be equal to listen-addr used for the node whose status is being updated
-	NodeAddress string `protobuf:"bytes,2,opt,name=nodeAddress,proto3" json:"nodeAddress,omitempty"`
-	// Database identifier
-	Database string `protobuf:"bytes,3,opt,name=database,proto3" json:"database,omitempty"`
-	// Shard / vBucket identifier
-	VBucket string `protobuf:"bytes,4,opt,name=vBucket,proto3" json:"vBucket,omitempty"`
-	// Status of the region
-	Status RegionStatus `protobuf:"varint,5,opt,name=status,proto3,enum=dkv.serverpb.RegionStatus" json:"status,omitempty"`
-	// Master host of the region whose status is being updated
-	// Applicable only for slaves. Used to load balance among various possible masters
-	MasterHost *string `protobuf:"bytes,6,opt,name=masterHost,proto3,oneof" json:"masterHost,omitempty"`
-	// Nexus cluster url of the region
-	// Will be used by new followers to discover the raft cluster
-	NexusClusterUrl *string `protobuf:"bytes,7,opt,name=nexusClusterUrl,proto3,oneof" json:"nexusClusterUrl,omitempty"`
+	// Status indicates the result of the GetChanges operation.
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// MasterChangeNumber indicates the latest change number on master node.
+	MasterChangeNumber uint64 `protobuf:"varint,2,opt,name=masterChangeNumber,proto3" json:"masterChangeNumber,omitempty"`
+	// NumberOfChanges indicates the number of change records in the response.
+	NumberOfChanges uint32 `protobuf:"varint,3,opt,name=numberOfChanges,proto3" json:"numberOfChanges,omitempty"`
+	// Changes is the collection of change records.
+	Changes []*ChangeRecord `protobuf:"bytes,4,rep,name=changes,proto3" json:"changes,omitempty"`
+	// FencingEpoch is the fencing epoch of the serving master.
+	FencingEpoch uint64 `protobuf:"varint,5,opt,name=fencingEpoch,proto3" json:"fencingEpoch,omitempty"`
 }
 
-func (x *RegionInfo) Reset() {
-	*x = RegionInfo{}
+func (x *GetChangesResponse) Reset() {
+	*x = GetChangesResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_serverpb_admin_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1050,13 +1430,13 @@ func (x *RegionInfo) Reset() {
 	}
 }
 
-func (x *RegionInfo) String() string {
+func (x *GetChangesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RegionInfo) ProtoMessage() {}
+func (*GetChangesResponse) ProtoMessage() {}
 
-func (x *RegionInfo) ProtoReflect() protoreflect.Message {
+func (x *GetChangesResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_serverpb_admin_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1068,871 +1448,8419 @@ func (x *RegionInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RegionInfo.ProtoReflect.Descriptor instead.
-func (*RegionInfo) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetChangesResponse.ProtoReflect.Descriptor instead.
+func (*GetChangesResponse) Descriptor() ([]byte, []int) {
 	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *RegionInfo) GetDcID() string {
+func (x *GetChangesResponse) GetStatus() *Status {
 	if x != nil {
-		return x.DcID
+		return x.Status
 	}
-	return ""
+	return nil
 }
 
-func (x *RegionInfo) GetNodeAddress() string {
+func (x *GetChangesResponse) GetMasterChangeNumber() uint64 {
 	if x != nil {
-		return x.NodeAddress
+		return x.MasterChangeNumber
 	}
-	return ""
+	return 0
 }
 
-func (x *RegionInfo) GetDatabase() string {
+func (x *GetChangesResponse) GetNumberOfChanges() uint32 {
 	if x != nil {
-		return x.Database
+		return x.NumberOfChanges
 	}
-	return ""
+	return 0
 }
 
-func (x *RegionInfo) GetVBucket() string {
+func (x *GetChangesResponse) GetChanges() []*ChangeRecord {
 	if x != nil {
-		return x.VBucket
+		return x.Changes
 	}
-	return ""
+	return nil
 }
 
-func (x *RegionInfo) GetStatus() RegionStatus {
+func (x *GetChangesResponse) GetFencingEpoch() uint64 {
 	if x != nil {
-		return x.Status
+		return x.FencingEpoch
 	}
-	return RegionStatus_INACTIVE
+	return 0
 }
 
-func (x *RegionInfo) GetMasterHost() string {
-	if x != nil && x.MasterHost != nil {
-		return *x.MasterHost
+type TransferCheckpointRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// CheckpointID identifies the checkpoint whose transfer is resumed.
+	// A new checkpoint is created when left empty.
+	CheckpointID string `protobuf:"bytes,1,opt,name=checkpointID,proto3" json:"checkpointID,omitempty"`
+	// FileName is the name of the file from which to resume the transfer.
+	// Transfer begins with the first file when left empty.
+	FileName string `protobuf:"bytes,2,opt,name=fileName,proto3" json:"fileName,omitempty"`
+	// Offset is the offset within the given file from which to resume.
+	Offset uint64 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+}
+
+func (x *TransferCheckpointRequest) Reset() {
+	*x = TransferCheckpointRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return ""
 }
 
-func (x *RegionInfo) GetNexusClusterUrl() string {
-	if x != nil && x.NexusClusterUrl != nil {
-		return *x.NexusClusterUrl
+func (x *TransferCheckpointRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferCheckpointRequest) ProtoMessage() {}
+
+func (x *TransferCheckpointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-var File_pkg_serverpb_admin_proto protoreflect.FileDescriptor
+// Deprecated: Use TransferCheckpointRequest.ProtoReflect.Descriptor instead.
+func (*TransferCheckpointRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{16}
+}
 
-var file_pkg_serverpb_admin_proto_rawDesc = []byte{
-	0x0a, 0x18, 0x70, 0x6b, 0x67, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2f, 0x61,
-	0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x64, 0x6b, 0x76, 0x2e,
-	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x16, 0x70, 0x6b, 0x67, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65,
-	0x72, 0x70, 0x62, 0x2f, 0x61, 0x70, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x15, 0x6d,
-	0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x28, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x69,
-	0x63, 0x61, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x7a, 0x6f,
-	0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x7a, 0x6f, 0x6e, 0x65, 0x22, 0x48,
-	0x0a, 0x13, 0x47, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x08, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61,
-	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x52, 0x08,
-	0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x22, 0x4d, 0x0a, 0x07, 0x52, 0x65, 0x70, 0x6c,
-	0x69, 0x63, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x7a, 0x6f, 0x6e, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e,
-	0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x22, 0x6f, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x43, 0x68,
-	0x61, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x10,
-	0x66, 0x72, 0x6f, 0x6d, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x66, 0x72, 0x6f, 0x6d, 0x43, 0x68, 0x61, 0x6e,
-	0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x2e, 0x0a, 0x12, 0x6d, 0x61, 0x78, 0x4e,
-	0x75, 0x6d, 0x62, 0x65, 0x72, 0x4f, 0x66, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x6d, 0x61, 0x78, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x4f,
-	0x66, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x22, 0xd2, 0x01, 0x0a, 0x12, 0x47, 0x65, 0x74,
-	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2e, 0x0a,
-	0x12, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d,
-	0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x12, 0x6d, 0x61, 0x73, 0x74, 0x65,
-	0x72, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x28, 0x0a,
-	0x0f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x4f, 0x66, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x4f, 0x66,
-	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x34, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67,
-	0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73,
-	0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65,
-	0x63, 0x6f, 0x72, 0x64, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x22, 0xb0, 0x01,
-	0x0a, 0x0c, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x26,
-	0x0a, 0x0e, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x73, 0x65, 0x64, 0x46, 0x6f, 0x72, 0x6d,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x73,
-	0x65, 0x64, 0x46, 0x6f, 0x72, 0x6d, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
-	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x63, 0x68,
-	0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x24, 0x0a, 0x0d, 0x6e, 0x75,
-	0x6d, 0x62, 0x65, 0x72, 0x4f, 0x66, 0x54, 0x72, 0x78, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x0d, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x4f, 0x66, 0x54, 0x72, 0x78, 0x6e, 0x73,
-	0x12, 0x2e, 0x0a, 0x05, 0x74, 0x72, 0x78, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x18, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x54,
-	0x72, 0x78, 0x6e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x05, 0x74, 0x72, 0x78, 0x6e, 0x73,
-	0x22, 0xb5, 0x01, 0x0a, 0x0a, 0x54, 0x72, 0x78, 0x6e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12,
-	0x35, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x21, 0x2e,
-	0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x54, 0x72, 0x78,
-	0x6e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x2e, 0x54, 0x72, 0x78, 0x6e, 0x54, 0x79, 0x70, 0x65,
-	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1a,
-	0x0a, 0x08, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x54, 0x53, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x08, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x54, 0x53, 0x22, 0x2c, 0x0a, 0x08, 0x54, 0x72,
-	0x78, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77,
-	0x6e, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x50, 0x75, 0x74, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06,
-	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x10, 0x02, 0x22, 0x2f, 0x0a, 0x0d, 0x42, 0x61, 0x63, 0x6b,
-	0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x62, 0x61, 0x63,
-	0x6b, 0x75, 0x70, 0x50, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x62,
-	0x61, 0x63, 0x6b, 0x75, 0x70, 0x50, 0x61, 0x74, 0x68, 0x22, 0x32, 0x0a, 0x0e, 0x52, 0x65, 0x73,
-	0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x72,
-	0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x50, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0b, 0x72, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x50, 0x61, 0x74, 0x68, 0x22, 0xe7, 0x01,
-	0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
-	0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x06, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x40, 0x0a, 0x05, 0x6e, 0x6f, 0x64,
-	0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73,
-	0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x6f, 0x64, 0x65,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x45,
-	0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x1a, 0x4a, 0x0a, 0x0a, 0x4e,
-	0x6f, 0x64, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x26, 0x0a, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x6f, 0x64,
-	0x65, 0x6c, 0x73, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x2a, 0x0a, 0x0e, 0x41, 0x64, 0x64, 0x4e, 0x6f,
-	0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x6f, 0x64,
-	0x65, 0x55, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x6f, 0x64, 0x65,
-	0x55, 0x72, 0x6c, 0x22, 0x2d, 0x0a, 0x11, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4e, 0x6f, 0x64,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x6f, 0x64, 0x65,
-	0x55, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x6f, 0x64, 0x65, 0x55,
-	0x72, 0x6c, 0x22, 0x6d, 0x0a, 0x13, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x38, 0x0a, 0x0a, 0x72, 0x65, 0x67,
-	0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e,
-	0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x67,
-	0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0a, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x49,
-	0x6e, 0x66, 0x6f, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
-	0x70, 0x22, 0x92, 0x01, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72,
-	0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x04, 0x64,
-	0x63, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x64, 0x63, 0x49,
-	0x44, 0x88, 0x01, 0x01, 0x12, 0x1f, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61,
-	0x73, 0x65, 0x88, 0x01, 0x01, 0x12, 0x1d, 0x0a, 0x07, 0x76, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x02, 0x52, 0x07, 0x76, 0x42, 0x75, 0x63, 0x6b, 0x65,
-	0x74, 0x88, 0x01, 0x01, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x64, 0x63, 0x49, 0x44, 0x42, 0x0b, 0x0a,
-	0x09, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x76,
-	0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x22, 0x54, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x43, 0x6c, 0x75,
-	0x73, 0x74, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x3a, 0x0a, 0x0b, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76,
-	0x65, 0x72, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52,
-	0x0b, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x22, 0xa3, 0x02, 0x0a,
-	0x0a, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x64,
-	0x63, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x63, 0x49, 0x44, 0x12,
-	0x20, 0x0a, 0x0b, 0x6e, 0x6f, 0x64, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6e, 0x6f, 0x64, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
-	0x73, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x18, 0x0a,
-	0x07, 0x76, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
-	0x76, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x32, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x23, 0x0a, 0x0a, 0x6d,
-	0x61, 0x73, 0x74, 0x65, 0x72, 0x48, 0x6f, 0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x48,
-	0x00, 0x52, 0x0a, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x48, 0x6f, 0x73, 0x74, 0x88, 0x01, 0x01,
-	0x12, 0x2d, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x75, 0x73, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72,
-	0x55, 0x72, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x0f, 0x6e, 0x65, 0x78,
-	0x75, 0x73, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x55, 0x72, 0x6c, 0x88, 0x01, 0x01, 0x42,
-	0x0d, 0x0a, 0x0b, 0x5f, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x48, 0x6f, 0x73, 0x74, 0x42, 0x12,
-	0x0a, 0x10, 0x5f, 0x6e, 0x65, 0x78, 0x75, 0x73, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x55,
-	0x72, 0x6c, 0x2a, 0x68, 0x0a, 0x0c, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x12, 0x0c, 0x0a, 0x08, 0x49, 0x4e, 0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x10, 0x00,
-	0x12, 0x0a, 0x0a, 0x06, 0x4c, 0x45, 0x41, 0x44, 0x45, 0x52, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10,
-	0x50, 0x52, 0x49, 0x4d, 0x41, 0x52, 0x59, 0x5f, 0x46, 0x4f, 0x4c, 0x4c, 0x4f, 0x57, 0x45, 0x52,
-	0x10, 0x02, 0x12, 0x16, 0x0a, 0x12, 0x53, 0x45, 0x43, 0x4f, 0x4e, 0x44, 0x41, 0x52, 0x59, 0x5f,
-	0x46, 0x4f, 0x4c, 0x4c, 0x4f, 0x57, 0x45, 0x52, 0x10, 0x03, 0x12, 0x10, 0x0a, 0x0c, 0x41, 0x43,
-	0x54, 0x49, 0x56, 0x45, 0x5f, 0x53, 0x4c, 0x41, 0x56, 0x45, 0x10, 0x04, 0x32, 0xae, 0x02, 0x0a,
-	0x0e, 0x44, 0x4b, 0x56, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
-	0x4f, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x1f, 0x2e,
-	0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74,
-	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20,
-	0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x47, 0x65,
-	0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x39, 0x0a, 0x0a, 0x41, 0x64, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x12, 0x15,
-	0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x52, 0x65,
-	0x70, 0x6c, 0x69, 0x63, 0x61, 0x1a, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76,
-	0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x3c, 0x0a, 0x0d, 0x52,
-	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x12, 0x15, 0x2e, 0x64,
-	0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x70, 0x6c,
-	0x69, 0x63, 0x61, 0x1a, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
-	0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x52, 0x0a, 0x0b, 0x47, 0x65, 0x74,
-	0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x12, 0x20, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73,
-	0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x69,
-	0x63, 0x61, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x64, 0x6b, 0x76,
-	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x70,
-	0x6c, 0x69, 0x63, 0x61, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0x8e, 0x01,
-	0x0a, 0x10, 0x44, 0x4b, 0x56, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x52, 0x65, 0x73, 0x74, 0x6f,
-	0x72, 0x65, 0x12, 0x3b, 0x0a, 0x06, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x12, 0x1b, 0x2e, 0x64,
-	0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x42, 0x61, 0x63, 0x6b,
-	0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e,
-	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
-	0x3d, 0x0a, 0x07, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x1c, 0x2e, 0x64, 0x6b, 0x76,
-	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73,
-	0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x32, 0xd6,
-	0x01, 0x0a, 0x0a, 0x44, 0x4b, 0x56, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x12, 0x3d, 0x0a,
-	0x07, 0x41, 0x64, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x1c, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73,
-	0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x41, 0x64, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72,
-	0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x43, 0x0a, 0x0a,
-	0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x1f, 0x2e, 0x64, 0x6b, 0x76,
-	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65,
-	0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x64, 0x6b,
-	0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x12, 0x44, 0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x16,
-	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1f, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72,
-	0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0xb4, 0x01, 0x0a, 0x0c, 0x44, 0x4b, 0x56, 0x44,
-	0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x12, 0x47, 0x0a, 0x0c, 0x55, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x21, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73,
-	0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x64, 0x6b,
-	0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x12, 0x5b, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x49,
-	0x6e, 0x66, 0x6f, 0x12, 0x23, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
-	0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x49, 0x6e, 0x66,
-	0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73,
-	0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6c, 0x75, 0x73, 0x74,
-	0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0x51,
-	0x0a, 0x10, 0x44, 0x4b, 0x56, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x4e, 0x6f,
-	0x64, 0x65, 0x12, 0x3d, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
-	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x18, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66,
-	0x6f, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x66, 0x6c, 0x69, 0x70, 0x6b, 0x61, 0x72, 0x74, 0x2d, 0x69, 0x6e, 0x63, 0x75, 0x62, 0x61, 0x74,
-	0x6f, 0x72, 0x2f, 0x64, 0x6b, 0x76, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65,
-	0x72, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *TransferCheckpointRequest) GetCheckpointID() string {
+	if x != nil {
+		return x.CheckpointID
+	}
+	return ""
 }
 
-var (
-	file_pkg_serverpb_admin_proto_rawDescOnce sync.Once
-	file_pkg_serverpb_admin_proto_rawDescData = file_pkg_serverpb_admin_proto_rawDesc
-)
+func (x *TransferCheckpointRequest) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
 
-func file_pkg_serverpb_admin_proto_rawDescGZIP() []byte {
-	file_pkg_serverpb_admin_proto_rawDescOnce.Do(func() {
-		file_pkg_serverpb_admin_proto_rawDescData = protoimpl.X.CompressGZIP(file_pkg_serverpb_admin_proto_rawDescData)
-	})
-	return file_pkg_serverpb_admin_proto_rawDescData
+func (x *TransferCheckpointRequest) GetOffset() uint64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
 }
 
-var file_pkg_serverpb_admin_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_pkg_serverpb_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
-var file_pkg_serverpb_admin_proto_goTypes = []interface{}{
-	(RegionStatus)(0),              // 0: dkv.serverpb.RegionStatus
-	(TrxnRecord_TrxnType)(0),       // 1: dkv.serverpb.TrxnRecord.TrxnType
-	(*GetReplicasRequest)(nil),     // 2: dkv.serverpb.GetReplicasRequest
-	(*GetReplicasResponse)(nil),    // 3: dkv.serverpb.GetReplicasResponse
-	(*Replica)(nil),                // 4: dkv.serverpb.Replica
-	(*GetChangesRequest)(nil),      // 5: dkv.serverpb.GetChangesRequest
-	(*GetChangesResponse)(nil),     // 6: dkv.serverpb.GetChangesResponse
-	(*ChangeRecord)(nil),           // 7: dkv.serverpb.ChangeRecord
-	(*TrxnRecord)(nil),             // 8: dkv.serverpb.TrxnRecord
-	(*BackupRequest)(nil),          // 9: dkv.serverpb.BackupRequest
-	(*RestoreRequest)(nil),         // 10: dkv.serverpb.RestoreRequest
-	(*ListNodesResponse)(nil),      // 11: dkv.serverpb.ListNodesResponse
-	(*AddNodeRequest)(nil),         // 12: dkv.serverpb.AddNodeRequest
-	(*RemoveNodeRequest)(nil),      // 13: dkv.serverpb.RemoveNodeRequest
-	(*UpdateStatusRequest)(nil),    // 14: dkv.serverpb.UpdateStatusRequest
-	(*GetClusterInfoRequest)(nil),  // 15: dkv.serverpb.GetClusterInfoRequest
-	(*GetClusterInfoResponse)(nil), // 16: dkv.serverpb.GetClusterInfoResponse
-	(*RegionInfo)(nil),             // 17: dkv.serverpb.RegionInfo
-	nil,                            // 18: dkv.serverpb.ListNodesResponse.NodesEntry
-	(*Status)(nil),                 // 19: dkv.serverpb.Status
-	(*models.NodeInfo)(nil),        // 20: models.NodeInfo
-	(*emptypb.Empty)(nil),          // 21: google.protobuf.Empty
+type CheckpointChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Status indicates the result of the TransferCheckpoint operation.
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// CheckpointID identifies the checkpoint being transferred.
+	CheckpointID string `protobuf:"bytes,2,opt,name=checkpointID,proto3" json:"checkpointID,omitempty"`
+	// ChangeNumber is the change number upto which the checkpoint is complete.
+	ChangeNumber uint64 `protobuf:"varint,3,opt,name=changeNumber,proto3" json:"changeNumber,omitempty"`
+	// FileName is the name of the checkpoint file this chunk belongs to.
+	FileName string `protobuf:"bytes,4,opt,name=fileName,proto3" json:"fileName,omitempty"`
+	// FileSize is the total size of the checkpoint file in bytes.
+	FileSize uint64 `protobuf:"varint,5,opt,name=fileSize,proto3" json:"fileSize,omitempty"`
+	// Offset is the offset of this chunk within the checkpoint file.
+	Offset uint64 `protobuf:"varint,6,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Data is the content of the checkpoint file at the given offset.
+	Data []byte `protobuf:"bytes,7,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *CheckpointChunk) Reset() {
+	*x = CheckpointChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-var file_pkg_serverpb_admin_proto_depIdxs = []int32{
-	4,  // 0: dkv.serverpb.GetReplicasResponse.replicas:type_name -> dkv.serverpb.Replica
-	19, // 1: dkv.serverpb.GetChangesResponse.status:type_name -> dkv.serverpb.Status
-	7,  // 2: dkv.serverpb.GetChangesResponse.changes:type_name -> dkv.serverpb.ChangeRecord
-	8,  // 3: dkv.serverpb.ChangeRecord.trxns:type_name -> dkv.serverpb.TrxnRecord
-	1,  // 4: dkv.serverpb.TrxnRecord.type:type_name -> dkv.serverpb.TrxnRecord.TrxnType
-	19, // 5: dkv.serverpb.ListNodesResponse.status:type_name -> dkv.serverpb.Status
-	18, // 6: dkv.serverpb.ListNodesResponse.nodes:type_name -> dkv.serverpb.ListNodesResponse.NodesEntry
-	17, // 7: dkv.serverpb.UpdateStatusRequest.regionInfo:type_name -> dkv.serverpb.RegionInfo
-	17, // 8: dkv.serverpb.GetClusterInfoResponse.regionInfos:type_name -> dkv.serverpb.RegionInfo
-	0,  // 9: dkv.serverpb.RegionInfo.status:type_name -> dkv.serverpb.RegionStatus
-	20, // 10: dkv.serverpb.ListNodesResponse.NodesEntry.value:type_name -> models.NodeInfo
-	5,  // 11: dkv.serverpb.DKVReplication.GetChanges:input_type -> dkv.serverpb.GetChangesRequest
-	4,  // 12: dkv.serverpb.DKVReplication.AddReplica:input_type -> dkv.serverpb.Replica
-	4,  // 13: dkv.serverpb.DKVReplication.RemoveReplica:input_type -> dkv.serverpb.Replica
-	2,  // 14: dkv.serverpb.DKVReplication.GetReplicas:input_type -> dkv.serverpb.GetReplicasRequest
-	9,  // 15: dkv.serverpb.DKVBackupRestore.Backup:input_type -> dkv.serverpb.BackupRequest
-	10, // 16: dkv.serverpb.DKVBackupRestore.Restore:input_type -> dkv.serverpb.RestoreRequest
-	12, // 17: dkv.serverpb.DKVCluster.AddNode:input_type -> dkv.serverpb.AddNodeRequest
-	13, // 18: dkv.serverpb.DKVCluster.RemoveNode:input_type -> dkv.serverpb.RemoveNodeRequest
-	21, // 19: dkv.serverpb.DKVCluster.ListNodes:input_type -> google.protobuf.Empty
-	14, // 20: dkv.serverpb.DKVDiscovery.UpdateStatus:input_type -> dkv.serverpb.UpdateStatusRequest
-	15, // 21: dkv.serverpb.DKVDiscovery.GetClusterInfo:input_type -> dkv.serverpb.GetClusterInfoRequest
-	21, // 22: dkv.serverpb.DKVDiscoveryNode.GetStatus:input_type -> google.protobuf.Empty
-	6,  // 23: dkv.serverpb.DKVReplication.GetChanges:output_type -> dkv.serverpb.GetChangesResponse
-	19, // 24: dkv.serverpb.DKVReplication.AddReplica:output_type -> dkv.serverpb.Status
-	19, // 25: dkv.serverpb.DKVReplication.RemoveReplica:output_type -> dkv.serverpb.Status
-	3,  // 26: dkv.serverpb.DKVReplication.GetReplicas:output_type -> dkv.serverpb.GetReplicasResponse
-	19, // 27: dkv.serverpb.DKVBackupRestore.Backup:output_type -> dkv.serverpb.Status
-	19, // 28: dkv.serverpb.DKVBackupRestore.Restore:output_type -> dkv.serverpb.Status
-	19, // 29: dkv.serverpb.DKVCluster.AddNode:output_type -> dkv.serverpb.Status
-	19, // 30: dkv.serverpb.DKVCluster.RemoveNode:output_type -> dkv.serverpb.Status
-	11, // 31: dkv.serverpb.DKVCluster.ListNodes:output_type -> dkv.serverpb.ListNodesResponse
-	19, // 32: dkv.serverpb.DKVDiscovery.UpdateStatus:output_type -> dkv.serverpb.Status
-	16, // 33: dkv.serverpb.DKVDiscovery.GetClusterInfo:output_type -> dkv.serverpb.GetClusterInfoResponse
-	17, // 34: dkv.serverpb.DKVDiscoveryNode.GetStatus:output_type -> dkv.serverpb.RegionInfo
-	23, // [23:35] is the sub-list for method output_type
-	11, // [11:23] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+
+func (x *CheckpointChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func init() { file_pkg_serverpb_admin_proto_init() }
-func file_pkg_serverpb_admin_proto_init() {
-	if File_pkg_serverpb_admin_proto != nil {
-		return
+func (*CheckpointChunk) ProtoMessage() {}
+
+func (x *CheckpointChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	file_pkg_serverpb_api_proto_init()
-	if !protoimpl.UnsafeEnabled {
-		file_pkg_serverpb_admin_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetReplicasRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_pkg_serverpb_admin_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetReplicasResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_pkg_serverpb_admin_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Replica); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_pkg_serverpb_admin_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetChangesRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_pkg_serverpb_admin_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetChangesResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_pkg_serverpb_admin_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ChangeRecord); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckpointChunk.ProtoReflect.Descriptor instead.
+func (*CheckpointChunk) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CheckpointChunk) GetStatus() *Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *CheckpointChunk) GetCheckpointID() string {
+	if x != nil {
+		return x.CheckpointID
+	}
+	return ""
+}
+
+func (x *CheckpointChunk) GetChangeNumber() uint64 {
+	if x != nil {
+		return x.ChangeNumber
+	}
+	return 0
+}
+
+func (x *CheckpointChunk) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *CheckpointChunk) GetFileSize() uint64 {
+	if x != nil {
+		return x.FileSize
+	}
+	return 0
+}
+
+func (x *CheckpointChunk) GetOffset() uint64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *CheckpointChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ChangeRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// SerialisedForm is the internal byte array representation of this change record.
+	SerialisedForm []byte `protobuf:"bytes,1,opt,name=serialisedForm,proto3" json:"serialisedForm,omitempty"`
+	// ChangeNumber indicates the change number of this change record.
+	ChangeNumber uint64 `protobuf:"varint,2,opt,name=changeNumber,proto3" json:"changeNumber,omitempty"`
+	// NumberOfTrxns indicates the number of transactions associated with this change record.
+	NumberOfTrxns uint32 `protobuf:"varint,3,opt,name=numberOfTrxns,proto3" json:"numberOfTrxns,omitempty"`
+	// Trxns is the collection of transaction records associated with this change record.
+	Trxns []*TrxnRecord `protobuf:"bytes,4,rep,name=trxns,proto3" json:"trxns,omitempty"`
+	// FencingEpoch is the fencing epoch of the master that served this change
+	// record. Replicas and downstream consumers must reject the records whose
+	// epoch is lower than the highest epoch they have observed, since such
+	// records are served by a master that has since been superseded.
+	FencingEpoch uint64 `protobuf:"varint,5,opt,name=fencingEpoch,proto3" json:"fencingEpoch,omitempty"`
+	// Compression is the compressor with which this record is compressed, as
+	// requested by the RecordCompression of the GetChangesRequest. Empty if
+	// the record is not compressed.
+	Compression string `protobuf:"bytes,6,opt,name=compression,proto3" json:"compression,omitempty"`
+	// CompressedForm holds the SerialisedForm and the Trxns of this record,
+	// compressed using its Compression, both of which are then left empty.
+	CompressedForm []byte `protobuf:"bytes,7,opt,name=compressedForm,proto3" json:"compressedForm,omitempty"`
+	// Checksum is the CRC-32 (IEEE) checksum of the ChangeNumber, the
+	// NumberOfTrxns, the SerialisedForm and the Trxns of this record, which
+	// the replicas verify before applying it so that the records corrupted
+	// in transit are fetched again. Absent for the records served
+	// by the masters of older releases, which are applied unverified.
+	Checksum *uint32 `protobuf:"fixed32,8,opt,name=checksum,proto3,oneof" json:"checksum,omitempty"`
+}
+
+func (x *ChangeRecord) Reset() {
+	*x = ChangeRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChangeRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeRecord) ProtoMessage() {}
+
+func (x *ChangeRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_pkg_serverpb_admin_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TrxnRecord); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeRecord.ProtoReflect.Descriptor instead.
+func (*ChangeRecord) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ChangeRecord) GetSerialisedForm() []byte {
+	if x != nil {
+		return x.SerialisedForm
+	}
+	return nil
+}
+
+func (x *ChangeRecord) GetChangeNumber() uint64 {
+	if x != nil {
+		return x.ChangeNumber
+	}
+	return 0
+}
+
+func (x *ChangeRecord) GetNumberOfTrxns() uint32 {
+	if x != nil {
+		return x.NumberOfTrxns
+	}
+	return 0
+}
+
+func (x *ChangeRecord) GetTrxns() []*TrxnRecord {
+	if x != nil {
+		return x.Trxns
+	}
+	return nil
+}
+
+func (x *ChangeRecord) GetFencingEpoch() uint64 {
+	if x != nil {
+		return x.FencingEpoch
+	}
+	return 0
+}
+
+func (x *ChangeRecord) GetCompression() string {
+	if x != nil {
+		return x.Compression
+	}
+	return ""
+}
+
+func (x *ChangeRecord) GetCompressedForm() []byte {
+	if x != nil {
+		return x.CompressedForm
+	}
+	return nil
+}
+
+func (x *ChangeRecord) GetChecksum() uint32 {
+	if x != nil && x.Checksum != nil {
+		return *x.Checksum
+	}
+	return 0
+}
+
+type TrxnRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Type indicates the type of this transaction - Put, Delete, etc.
+	Type TrxnRecord_TrxnType `protobuf:"varint,1,opt,name=type,proto3,enum=dkv.serverpb.TrxnRecord_TrxnType" json:"type,omitempty"`
+	// Key is the byte array representation of the key associated with this transaction.
+	Key []byte `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// Value is the byte array representation of the value associated with this transaction.
+	Value []byte `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	// expireTS is the epoch seconds value, in int64 to associate with the key in the key value store.
+	ExpireTS uint64 `protobuf:"varint,4,opt,name=expireTS,proto3" json:"expireTS,omitempty"`
+	// SharedKeyPrefix is the length of the prefix that the key shares with
+	// the key of the preceding transaction record, which is omitted from the
+	// Key when the keys are delta encoded as per the GetChangesRequest.
+	SharedKeyPrefix uint32 `protobuf:"varint,5,opt,name=sharedKeyPrefix,proto3" json:"sharedKeyPrefix,omitempty"`
+}
+
+func (x *TrxnRecord) Reset() {
+	*x = TrxnRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TrxnRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrxnRecord) ProtoMessage() {}
+
+func (x *TrxnRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_pkg_serverpb_admin_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BackupRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_pkg_serverpb_admin_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RestoreRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_pkg_serverpb_admin_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListNodesResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrxnRecord.ProtoReflect.Descriptor instead.
+func (*TrxnRecord) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *TrxnRecord) GetType() TrxnRecord_TrxnType {
+	if x != nil {
+		return x.Type
+	}
+	return TrxnRecord_Unknown
+}
+
+func (x *TrxnRecord) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *TrxnRecord) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *TrxnRecord) GetExpireTS() uint64 {
+	if x != nil {
+		return x.ExpireTS
+	}
+	return 0
+}
+
+func (x *TrxnRecord) GetSharedKeyPrefix() uint32 {
+	if x != nil {
+		return x.SharedKeyPrefix
+	}
+	return 0
+}
+
+type PauseWritesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// TimeoutSecs is the duration in seconds after which the writes are
+	// resumed, unless resumed before. Pausing the writes again restarts it.
+	TimeoutSecs uint32 `protobuf:"varint,1,opt,name=timeoutSecs,proto3" json:"timeoutSecs,omitempty"`
+}
+
+func (x *PauseWritesRequest) Reset() {
+	*x = PauseWritesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PauseWritesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseWritesRequest) ProtoMessage() {}
+
+func (x *PauseWritesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_pkg_serverpb_admin_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AddNodeRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseWritesRequest.ProtoReflect.Descriptor instead.
+func (*PauseWritesRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PauseWritesRequest) GetTimeoutSecs() uint32 {
+	if x != nil {
+		return x.TimeoutSecs
+	}
+	return 0
+}
+
+type PauseWritesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Status indicates the result of the PauseWrites operation.
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// ChangeNumber is the latest change committed before the pause.
+	ChangeNumber uint64 `protobuf:"varint,2,opt,name=changeNumber,proto3" json:"changeNumber,omitempty"`
+}
+
+func (x *PauseWritesResponse) Reset() {
+	*x = PauseWritesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PauseWritesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseWritesResponse) ProtoMessage() {}
+
+func (x *PauseWritesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_pkg_serverpb_admin_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RemoveNodeRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseWritesResponse.ProtoReflect.Descriptor instead.
+func (*PauseWritesResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *PauseWritesResponse) GetStatus() *Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *PauseWritesResponse) GetChangeNumber() uint64 {
+	if x != nil {
+		return x.ChangeNumber
+	}
+	return 0
+}
+
+type BackupRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// BackupPath indicates a filesystem folder or file used for backing up the keyspace.
+	BackupPath string `protobuf:"bytes,1,opt,name=backupPath,proto3" json:"backupPath,omitempty"`
+}
+
+func (x *BackupRequest) Reset() {
+	*x = BackupRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BackupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupRequest) ProtoMessage() {}
+
+func (x *BackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_pkg_serverpb_admin_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateStatusRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupRequest.ProtoReflect.Descriptor instead.
+func (*BackupRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *BackupRequest) GetBackupPath() string {
+	if x != nil {
+		return x.BackupPath
+	}
+	return ""
+}
+
+type RestoreRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// RestorePath indicates a filesystem folder or file used for restoring the keyspace.
+	RestorePath string `protobuf:"bytes,1,opt,name=restorePath,proto3" json:"restorePath,omitempty"`
+}
+
+func (x *RestoreRequest) Reset() {
+	*x = RestoreRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RestoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreRequest) ProtoMessage() {}
+
+func (x *RestoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_pkg_serverpb_admin_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetClusterInfoRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreRequest.ProtoReflect.Descriptor instead.
+func (*RestoreRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *RestoreRequest) GetRestorePath() string {
+	if x != nil {
+		return x.RestorePath
+	}
+	return ""
+}
+
+type PointInTimeRestoreRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// RestorePath indicates a filesystem folder or file used for restoring the keyspace.
+	RestorePath string `protobuf:"bytes,1,opt,name=restorePath,proto3" json:"restorePath,omitempty"`
+	// ChangeNumber indicates the change number up to which the keyspace is rebuilt.
+	ChangeNumber uint64 `protobuf:"varint,2,opt,name=changeNumber,proto3" json:"changeNumber,omitempty"`
+}
+
+func (x *PointInTimeRestoreRequest) Reset() {
+	*x = PointInTimeRestoreRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PointInTimeRestoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PointInTimeRestoreRequest) ProtoMessage() {}
+
+func (x *PointInTimeRestoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_pkg_serverpb_admin_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetClusterInfoResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PointInTimeRestoreRequest.ProtoReflect.Descriptor instead.
+func (*PointInTimeRestoreRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *PointInTimeRestoreRequest) GetRestorePath() string {
+	if x != nil {
+		return x.RestorePath
+	}
+	return ""
+}
+
+func (x *PointInTimeRestoreRequest) GetChangeNumber() uint64 {
+	if x != nil {
+		return x.ChangeNumber
+	}
+	return 0
+}
+
+type GetBackupManifestRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// BackupPath indicates a filesystem folder or file holding an existing backup.
+	BackupPath string `protobuf:"bytes,1,opt,name=backupPath,proto3" json:"backupPath,omitempty"`
+}
+
+func (x *GetBackupManifestRequest) Reset() {
+	*x = GetBackupManifestRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBackupManifestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBackupManifestRequest) ProtoMessage() {}
+
+func (x *GetBackupManifestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_pkg_serverpb_admin_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RegionInfo); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBackupManifestRequest.ProtoReflect.Descriptor instead.
+func (*GetBackupManifestRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetBackupManifestRequest) GetBackupPath() string {
+	if x != nil {
+		return x.BackupPath
+	}
+	return ""
+}
+
+type GetBackupManifestResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Status indicates the result of reading the backup manifest.
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// ChangeNumber is the change number upto and including which all changes are present in the backup.
+	ChangeNumber uint64 `protobuf:"varint,2,opt,name=changeNumber,proto3" json:"changeNumber,omitempty"`
+	// CreatedAt is the epoch seconds at which the backup was taken.
+	CreatedAt int64 `protobuf:"varint,3,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+}
+
+func (x *GetBackupManifestResponse) Reset() {
+	*x = GetBackupManifestResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBackupManifestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBackupManifestResponse) ProtoMessage() {}
+
+func (x *GetBackupManifestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBackupManifestResponse.ProtoReflect.Descriptor instead.
+func (*GetBackupManifestResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetBackupManifestResponse) GetStatus() *Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *GetBackupManifestResponse) GetChangeNumber() uint64 {
+	if x != nil {
+		return x.ChangeNumber
+	}
+	return 0
+}
+
+func (x *GetBackupManifestResponse) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type PublishSnapshotRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Namespace is the key prefix whose keys are captured by the snapshot.
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Name is the identifier of the snapshot within the given namespace.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *PublishSnapshotRequest) Reset() {
+	*x = PublishSnapshotRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PublishSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishSnapshotRequest) ProtoMessage() {}
+
+func (x *PublishSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*PublishSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *PublishSnapshotRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *PublishSnapshotRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type UnpublishSnapshotRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Snapshot is the reference of the snapshot in the `namespace@name` format.
+	Snapshot string `protobuf:"bytes,1,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+}
+
+func (x *UnpublishSnapshotRequest) Reset() {
+	*x = UnpublishSnapshotRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnpublishSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnpublishSnapshotRequest) ProtoMessage() {}
+
+func (x *UnpublishSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnpublishSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*UnpublishSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *UnpublishSnapshotRequest) GetSnapshot() string {
+	if x != nil {
+		return x.Snapshot
+	}
+	return ""
+}
+
+type ListSnapshotsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Status indicates the result of the list snapshots operation.
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// Snapshots are the references of all the published snapshots.
+	Snapshots []string `protobuf:"bytes,2,rep,name=snapshots,proto3" json:"snapshots,omitempty"`
+}
+
+func (x *ListSnapshotsResponse) Reset() {
+	*x = ListSnapshotsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSnapshotsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSnapshotsResponse) ProtoMessage() {}
+
+func (x *ListSnapshotsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSnapshotsResponse.ProtoReflect.Descriptor instead.
+func (*ListSnapshotsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListSnapshotsResponse) GetStatus() *Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *ListSnapshotsResponse) GetSnapshots() []string {
+	if x != nil {
+		return x.Snapshots
+	}
+	return nil
+}
+
+type IngestSSTRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Files are the paths of the SST files holding the keys without TTL.
+	Files []string `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	// TtlFiles are the paths of the SST files holding the keys with TTL.
+	TtlFiles []string `protobuf:"bytes,2,rep,name=ttlFiles,proto3" json:"ttlFiles,omitempty"`
+	// MoveFiles moves the files into the store rather than copying them,
+	// which requires them to be on the same filesystem as the store.
+	MoveFiles bool `protobuf:"varint,3,opt,name=moveFiles,proto3" json:"moveFiles,omitempty"`
+}
+
+func (x *IngestSSTRequest) Reset() {
+	*x = IngestSSTRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IngestSSTRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestSSTRequest) ProtoMessage() {}
+
+func (x *IngestSSTRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestSSTRequest.ProtoReflect.Descriptor instead.
+func (*IngestSSTRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *IngestSSTRequest) GetFiles() []string {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *IngestSSTRequest) GetTtlFiles() []string {
+	if x != nil {
+		return x.TtlFiles
+	}
+	return nil
+}
+
+func (x *IngestSSTRequest) GetMoveFiles() bool {
+	if x != nil {
+		return x.MoveFiles
+	}
+	return false
+}
+
+type ExportRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// StartKey is the inclusive lower bound of the key range. The range is
+	// unbounded from below when it is empty.
+	StartKey []byte `protobuf:"bytes,1,opt,name=startKey,proto3" json:"startKey,omitempty"`
+	// EndKey is the exclusive upper bound of the key range. The range is
+	// unbounded from above when it is empty.
+	EndKey []byte `protobuf:"bytes,2,opt,name=endKey,proto3" json:"endKey,omitempty"`
+	// Format is the format of the exported files.
+	Format ExportFormat `protobuf:"varint,3,opt,name=format,proto3,enum=dkv.serverpb.ExportFormat" json:"format,omitempty"`
+	// Destination is the folder on the node into which the files are written.
+	// When it is an http:// or https:// URL, the files are instead uploaded
+	// using PUT requests to the URL followed by the name of every file, such
+	// as to a bucket of an S3 compatible object storage.
+	Destination string `protobuf:"bytes,4,opt,name=destination,proto3" json:"destination,omitempty"`
+}
+
+func (x *ExportRequest) Reset() {
+	*x = ExportRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportRequest) ProtoMessage() {}
+
+func (x *ExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportRequest.ProtoReflect.Descriptor instead.
+func (*ExportRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ExportRequest) GetStartKey() []byte {
+	if x != nil {
+		return x.StartKey
+	}
+	return nil
+}
+
+func (x *ExportRequest) GetEndKey() []byte {
+	if x != nil {
+		return x.EndKey
+	}
+	return nil
+}
+
+func (x *ExportRequest) GetFormat() ExportFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ExportFormat_SST
+}
+
+func (x *ExportRequest) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+type ExportResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Status indicates the result of the export operation.
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// Files are the paths or URLs of the exported files.
+	Files []string `protobuf:"bytes,2,rep,name=files,proto3" json:"files,omitempty"`
+	// NumKeys is the number of keys exported.
+	NumKeys uint64 `protobuf:"varint,3,opt,name=numKeys,proto3" json:"numKeys,omitempty"`
+}
+
+func (x *ExportResponse) Reset() {
+	*x = ExportResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportResponse) ProtoMessage() {}
+
+func (x *ExportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportResponse.ProtoReflect.Descriptor instead.
+func (*ExportResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ExportResponse) GetStatus() *Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *ExportResponse) GetFiles() []string {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *ExportResponse) GetNumKeys() uint64 {
+	if x != nil {
+		return x.NumKeys
+	}
+	return 0
+}
+
+type QuarantinedRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Source identifies the component that quarantined the record, which is
+	// either "scrubber" or "replication".
+	Source string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	// Reason describes the corruption or the validation failure.
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Key is the key of the record quarantined by the scrubber.
+	Key []byte `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	// Value is the raw value of the key quarantined by the scrubber, or the
+	// serialised form of the change quarantined by the replication.
+	Value []byte `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	// ChangeNumber is the change number of the change quarantined by the
+	// replication.
+	ChangeNumber uint64 `protobuf:"varint,5,opt,name=changeNumber,proto3" json:"changeNumber,omitempty"`
+	// QuarantinedAt is the epoch seconds at which the record was quarantined.
+	QuarantinedAt int64 `protobuf:"varint,6,opt,name=quarantinedAt,proto3" json:"quarantinedAt,omitempty"`
+}
+
+func (x *QuarantinedRecord) Reset() {
+	*x = QuarantinedRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QuarantinedRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuarantinedRecord) ProtoMessage() {}
+
+func (x *QuarantinedRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuarantinedRecord.ProtoReflect.Descriptor instead.
+func (*QuarantinedRecord) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *QuarantinedRecord) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *QuarantinedRecord) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *QuarantinedRecord) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *QuarantinedRecord) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *QuarantinedRecord) GetChangeNumber() uint64 {
+	if x != nil {
+		return x.ChangeNumber
+	}
+	return 0
+}
+
+func (x *QuarantinedRecord) GetQuarantinedAt() int64 {
+	if x != nil {
+		return x.QuarantinedAt
+	}
+	return 0
+}
+
+type ListQuarantinedRecordsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Status indicates the result of the list quarantined records operation.
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// Records are all the quarantined records.
+	Records []*QuarantinedRecord `protobuf:"bytes,2,rep,name=records,proto3" json:"records,omitempty"`
+}
+
+func (x *ListQuarantinedRecordsResponse) Reset() {
+	*x = ListQuarantinedRecordsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListQuarantinedRecordsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListQuarantinedRecordsResponse) ProtoMessage() {}
+
+func (x *ListQuarantinedRecordsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListQuarantinedRecordsResponse.ProtoReflect.Descriptor instead.
+func (*ListQuarantinedRecordsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListQuarantinedRecordsResponse) GetStatus() *Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *ListQuarantinedRecordsResponse) GetRecords() []*QuarantinedRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+type PrefixRenameRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// OldPrefix is the key prefix being renamed.
+	OldPrefix []byte `protobuf:"bytes,1,opt,name=oldPrefix,proto3" json:"oldPrefix,omitempty"`
+	// NewPrefix is the key prefix to which the keys are renamed, which must
+	// neither overlap with the old prefix nor with the prefixes of the other
+	// renames in progress. Not needed for completing a rename.
+	NewPrefix []byte `protobuf:"bytes,2,opt,name=newPrefix,proto3" json:"newPrefix,omitempty"`
+}
+
+func (x *PrefixRenameRequest) Reset() {
+	*x = PrefixRenameRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PrefixRenameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrefixRenameRequest) ProtoMessage() {}
+
+func (x *PrefixRenameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrefixRenameRequest.ProtoReflect.Descriptor instead.
+func (*PrefixRenameRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *PrefixRenameRequest) GetOldPrefix() []byte {
+	if x != nil {
+		return x.OldPrefix
+	}
+	return nil
+}
+
+func (x *PrefixRenameRequest) GetNewPrefix() []byte {
+	if x != nil {
+		return x.NewPrefix
+	}
+	return nil
+}
+
+type PrefixRename struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// OldPrefix is the key prefix being renamed.
+	OldPrefix []byte `protobuf:"bytes,1,opt,name=oldPrefix,proto3" json:"oldPrefix,omitempty"`
+	// NewPrefix is the key prefix to which the keys are renamed.
+	NewPrefix []byte `protobuf:"bytes,2,opt,name=newPrefix,proto3" json:"newPrefix,omitempty"`
+	// Phase is the current phase of the rename.
+	Phase PrefixRenamePhase `protobuf:"varint,3,opt,name=phase,proto3,enum=dkv.serverpb.PrefixRenamePhase" json:"phase,omitempty"`
+	// NumKeys is the number of keys copied or deleted in the current phase
+	// since the node started, as tracked by masters.
+	NumKeys uint64 `protobuf:"varint,4,opt,name=numKeys,proto3" json:"numKeys,omitempty"`
+}
+
+func (x *PrefixRename) Reset() {
+	*x = PrefixRename{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PrefixRename) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrefixRename) ProtoMessage() {}
+
+func (x *PrefixRename) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrefixRename.ProtoReflect.Descriptor instead.
+func (*PrefixRename) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *PrefixRename) GetOldPrefix() []byte {
+	if x != nil {
+		return x.OldPrefix
+	}
+	return nil
+}
+
+func (x *PrefixRename) GetNewPrefix() []byte {
+	if x != nil {
+		return x.NewPrefix
+	}
+	return nil
+}
+
+func (x *PrefixRename) GetPhase() PrefixRenamePhase {
+	if x != nil {
+		return x.Phase
+	}
+	return PrefixRenamePhase_COPYING
+}
+
+func (x *PrefixRename) GetNumKeys() uint64 {
+	if x != nil {
+		return x.NumKeys
+	}
+	return 0
+}
+
+type ListPrefixRenamesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Status indicates the result of the list prefix renames operation.
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// Renames are the key prefix renames in progress.
+	Renames []*PrefixRename `protobuf:"bytes,2,rep,name=renames,proto3" json:"renames,omitempty"`
+}
+
+func (x *ListPrefixRenamesResponse) Reset() {
+	*x = ListPrefixRenamesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPrefixRenamesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPrefixRenamesResponse) ProtoMessage() {}
+
+func (x *ListPrefixRenamesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPrefixRenamesResponse.ProtoReflect.Descriptor instead.
+func (*ListPrefixRenamesResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListPrefixRenamesResponse) GetStatus() *Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *ListPrefixRenamesResponse) GetRenames() []*PrefixRename {
+	if x != nil {
+		return x.Renames
+	}
+	return nil
+}
+
+type MemtableOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// WriteBufferSize is the size (in bytes) of a single memtable.
+	WriteBufferSize uint64 `protobuf:"varint,1,opt,name=writeBufferSize,proto3" json:"writeBufferSize,omitempty"`
+	// MaxWriteBufferNumber is the maximum number of memtables, both active
+	// and immutable, held in memory before the writes are stalled.
+	MaxWriteBufferNumber uint32 `protobuf:"varint,2,opt,name=maxWriteBufferNumber,proto3" json:"maxWriteBufferNumber,omitempty"`
+	// Level0FileNumCompactionTrigger is the number of level 0 files that
+	// triggers their compaction into level 1.
+	Level0FileNumCompactionTrigger uint32 `protobuf:"varint,3,opt,name=level0FileNumCompactionTrigger,proto3" json:"level0FileNumCompactionTrigger,omitempty"`
+	// Level0SlowdownWritesTrigger is the number of level 0 files at which
+	// the writes are slowed down.
+	Level0SlowdownWritesTrigger uint32 `protobuf:"varint,4,opt,name=level0SlowdownWritesTrigger,proto3" json:"level0SlowdownWritesTrigger,omitempty"`
+	// Level0StopWritesTrigger is the number of level 0 files at which the
+	// writes are stopped.
+	Level0StopWritesTrigger uint32 `protobuf:"varint,5,opt,name=level0StopWritesTrigger,proto3" json:"level0StopWritesTrigger,omitempty"`
+}
+
+func (x *MemtableOptions) Reset() {
+	*x = MemtableOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MemtableOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemtableOptions) ProtoMessage() {}
+
+func (x *MemtableOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemtableOptions.ProtoReflect.Descriptor instead.
+func (*MemtableOptions) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *MemtableOptions) GetWriteBufferSize() uint64 {
+	if x != nil {
+		return x.WriteBufferSize
+	}
+	return 0
+}
+
+func (x *MemtableOptions) GetMaxWriteBufferNumber() uint32 {
+	if x != nil {
+		return x.MaxWriteBufferNumber
+	}
+	return 0
+}
+
+func (x *MemtableOptions) GetLevel0FileNumCompactionTrigger() uint32 {
+	if x != nil {
+		return x.Level0FileNumCompactionTrigger
+	}
+	return 0
+}
+
+func (x *MemtableOptions) GetLevel0SlowdownWritesTrigger() uint32 {
+	if x != nil {
+		return x.Level0SlowdownWritesTrigger
+	}
+	return 0
+}
+
+func (x *MemtableOptions) GetLevel0StopWritesTrigger() uint32 {
+	if x != nil {
+		return x.Level0StopWritesTrigger
+	}
+	return 0
+}
+
+type GetEngineStatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Status indicates the result of the get engine stats operation.
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// MemtableSizeBytes is the size in bytes of all the in-memory write buffers.
+	MemtableSizeBytes uint64 `protobuf:"varint,2,opt,name=memtableSizeBytes,proto3" json:"memtableSizeBytes,omitempty"`
+	// NumFilesPerLevel is the number of files at every level of the LSM tree.
+	NumFilesPerLevel []uint64 `protobuf:"varint,3,rep,packed,name=numFilesPerLevel,proto3" json:"numFilesPerLevel,omitempty"`
+	// BlockCacheHitRate is the fraction of block lookups served from the block cache.
+	BlockCacheHitRate float64 `protobuf:"fixed64,4,opt,name=blockCacheHitRate,proto3" json:"blockCacheHitRate,omitempty"`
+	// PendingCompactionBytes is the estimated number of bytes to be rewritten by compactions.
+	PendingCompactionBytes uint64 `protobuf:"varint,5,opt,name=pendingCompactionBytes,proto3" json:"pendingCompactionBytes,omitempty"`
+	// OldestChangeNumber is the oldest change number that can be retrieved for replication.
+	OldestChangeNumber uint64 `protobuf:"varint,6,opt,name=oldestChangeNumber,proto3" json:"oldestChangeNumber,omitempty"`
+	// ChangeHistoryBytes is the size in bytes of the retained history of changes.
+	ChangeHistoryBytes uint64 `protobuf:"varint,7,opt,name=changeHistoryBytes,proto3" json:"changeHistoryBytes,omitempty"`
+	// NamespaceCacheStats is the block cache usage of the reads of every namespace accounted for.
+	NamespaceCacheStats []*NamespaceCacheStats `protobuf:"bytes,8,rep,name=namespaceCacheStats,proto3" json:"namespaceCacheStats,omitempty"`
+}
+
+func (x *GetEngineStatsResponse) Reset() {
+	*x = GetEngineStatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetEngineStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEngineStatsResponse) ProtoMessage() {}
+
+func (x *GetEngineStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEngineStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetEngineStatsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetEngineStatsResponse) GetStatus() *Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *GetEngineStatsResponse) GetMemtableSizeBytes() uint64 {
+	if x != nil {
+		return x.MemtableSizeBytes
+	}
+	return 0
+}
+
+func (x *GetEngineStatsResponse) GetNumFilesPerLevel() []uint64 {
+	if x != nil {
+		return x.NumFilesPerLevel
+	}
+	return nil
+}
+
+func (x *GetEngineStatsResponse) GetBlockCacheHitRate() float64 {
+	if x != nil {
+		return x.BlockCacheHitRate
+	}
+	return 0
+}
+
+func (x *GetEngineStatsResponse) GetPendingCompactionBytes() uint64 {
+	if x != nil {
+		return x.PendingCompactionBytes
+	}
+	return 0
+}
+
+func (x *GetEngineStatsResponse) GetOldestChangeNumber() uint64 {
+	if x != nil {
+		return x.OldestChangeNumber
+	}
+	return 0
+}
+
+func (x *GetEngineStatsResponse) GetChangeHistoryBytes() uint64 {
+	if x != nil {
+		return x.ChangeHistoryBytes
+	}
+	return 0
+}
+
+func (x *GetEngineStatsResponse) GetNamespaceCacheStats() []*NamespaceCacheStats {
+	if x != nil {
+		return x.NamespaceCacheStats
+	}
+	return nil
+}
+
+type NamespaceCacheStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Namespace is the key prefix shared by all the keys of the namespace.
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// NumSampledReads is the number of reads of the namespace whose block cache usage was sampled.
+	NumSampledReads uint64 `protobuf:"varint,2,opt,name=numSampledReads,proto3" json:"numSampledReads,omitempty"`
+	// BlockCacheHits is the number of block lookups of the sampled reads served from the block cache.
+	BlockCacheHits uint64 `protobuf:"varint,3,opt,name=blockCacheHits,proto3" json:"blockCacheHits,omitempty"`
+	// BlockReads is the number of block lookups of the sampled reads that missed the block cache.
+	BlockReads uint64 `protobuf:"varint,4,opt,name=blockReads,proto3" json:"blockReads,omitempty"`
+	// BlockCacheHitRate is the fraction of the block lookups of the sampled reads served from the block cache.
+	BlockCacheHitRate float64 `protobuf:"fixed64,5,opt,name=blockCacheHitRate,proto3" json:"blockCacheHitRate,omitempty"`
+}
+
+func (x *NamespaceCacheStats) Reset() {
+	*x = NamespaceCacheStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NamespaceCacheStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NamespaceCacheStats) ProtoMessage() {}
+
+func (x *NamespaceCacheStats) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NamespaceCacheStats.ProtoReflect.Descriptor instead.
+func (*NamespaceCacheStats) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *NamespaceCacheStats) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *NamespaceCacheStats) GetNumSampledReads() uint64 {
+	if x != nil {
+		return x.NumSampledReads
+	}
+	return 0
+}
+
+func (x *NamespaceCacheStats) GetBlockCacheHits() uint64 {
+	if x != nil {
+		return x.BlockCacheHits
+	}
+	return 0
+}
+
+func (x *NamespaceCacheStats) GetBlockReads() uint64 {
+	if x != nil {
+		return x.BlockReads
+	}
+	return 0
+}
+
+func (x *NamespaceCacheStats) GetBlockCacheHitRate() float64 {
+	if x != nil {
+		return x.BlockCacheHitRate
+	}
+	return 0
+}
+
+type RotateEncryptionKeyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Requester identifies the operator rotating the key, for auditing.
+	Requester string `protobuf:"bytes,1,opt,name=requester,proto3" json:"requester,omitempty"`
+	// Reason describes why the key is rotated, for auditing.
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *RotateEncryptionKeyRequest) Reset() {
+	*x = RotateEncryptionKeyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RotateEncryptionKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateEncryptionKeyRequest) ProtoMessage() {}
+
+func (x *RotateEncryptionKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateEncryptionKeyRequest.ProtoReflect.Descriptor instead.
+func (*RotateEncryptionKeyRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *RotateEncryptionKeyRequest) GetRequester() string {
+	if x != nil {
+		return x.Requester
+	}
+	return ""
+}
+
+func (x *RotateEncryptionKeyRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type RotateEncryptionKeyResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Status indicates the result of the key rotation.
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// ActiveKeyID identifies the master key, or the data key of the tenant,
+	// with which the values are encrypted.
+	ActiveKeyID string `protobuf:"bytes,2,opt,name=activeKeyID,proto3" json:"activeKeyID,omitempty"`
+	// NumScanned is the number of values scanned.
+	NumScanned uint64 `protobuf:"varint,3,opt,name=numScanned,proto3" json:"numScanned,omitempty"`
+	// NumReencrypted is the number of values re-encrypted using the active key.
+	NumReencrypted uint64 `protobuf:"varint,4,opt,name=numReencrypted,proto3" json:"numReencrypted,omitempty"`
+}
+
+func (x *RotateEncryptionKeyResponse) Reset() {
+	*x = RotateEncryptionKeyResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RotateEncryptionKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateEncryptionKeyResponse) ProtoMessage() {}
+
+func (x *RotateEncryptionKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateEncryptionKeyResponse.ProtoReflect.Descriptor instead.
+func (*RotateEncryptionKeyResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *RotateEncryptionKeyResponse) GetStatus() *Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *RotateEncryptionKeyResponse) GetActiveKeyID() string {
+	if x != nil {
+		return x.ActiveKeyID
+	}
+	return ""
+}
+
+func (x *RotateEncryptionKeyResponse) GetNumScanned() uint64 {
+	if x != nil {
+		return x.NumScanned
+	}
+	return 0
+}
+
+func (x *RotateEncryptionKeyResponse) GetNumReencrypted() uint64 {
+	if x != nil {
+		return x.NumReencrypted
+	}
+	return 0
+}
+
+type RotateTenantKeyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Tenant identifies the tenant, by its key prefix, whose data key is rotated.
+	Tenant []byte `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	// Requester identifies the operator rotating the key, for auditing.
+	Requester string `protobuf:"bytes,2,opt,name=requester,proto3" json:"requester,omitempty"`
+	// Reason describes why the key is rotated, for auditing.
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *RotateTenantKeyRequest) Reset() {
+	*x = RotateTenantKeyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RotateTenantKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateTenantKeyRequest) ProtoMessage() {}
+
+func (x *RotateTenantKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateTenantKeyRequest.ProtoReflect.Descriptor instead.
+func (*RotateTenantKeyRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *RotateTenantKeyRequest) GetTenant() []byte {
+	if x != nil {
+		return x.Tenant
+	}
+	return nil
+}
+
+func (x *RotateTenantKeyRequest) GetRequester() string {
+	if x != nil {
+		return x.Requester
+	}
+	return ""
+}
+
+func (x *RotateTenantKeyRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type DeleteTenantRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Tenant identifies the tenant, by its key prefix, whose data keys are destroyed.
+	Tenant []byte `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	// Requester identifies the operator erasing the tenant.
+	Requester string `protobuf:"bytes,2,opt,name=requester,proto3" json:"requester,omitempty"`
+	// Reason captures the justification recorded in the audit log.
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *DeleteTenantRequest) Reset() {
+	*x = DeleteTenantRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteTenantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTenantRequest) ProtoMessage() {}
+
+func (x *DeleteTenantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTenantRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTenantRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *DeleteTenantRequest) GetTenant() []byte {
+	if x != nil {
+		return x.Tenant
+	}
+	return nil
+}
+
+func (x *DeleteTenantRequest) GetRequester() string {
+	if x != nil {
+		return x.Requester
+	}
+	return ""
+}
+
+func (x *DeleteTenantRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type CompactRangeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// KeyPrefix restricts the compaction to the keys having this prefix.
+	KeyPrefix []byte `protobuf:"bytes,1,opt,name=keyPrefix,proto3" json:"keyPrefix,omitempty"`
+}
+
+func (x *CompactRangeRequest) Reset() {
+	*x = CompactRangeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompactRangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompactRangeRequest) ProtoMessage() {}
+
+func (x *CompactRangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompactRangeRequest.ProtoReflect.Descriptor instead.
+func (*CompactRangeRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *CompactRangeRequest) GetKeyPrefix() []byte {
+	if x != nil {
+		return x.KeyPrefix
+	}
+	return nil
+}
+
+type EstimateSizeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// StartKey is the inclusive lower bound of the key range. The range is
+	// unbounded from below when it is empty.
+	StartKey []byte `protobuf:"bytes,1,opt,name=startKey,proto3" json:"startKey,omitempty"`
+	// EndKey is the exclusive upper bound of the key range. The range is
+	// unbounded from above when it is empty.
+	EndKey []byte `protobuf:"bytes,2,opt,name=endKey,proto3" json:"endKey,omitempty"`
+}
+
+func (x *EstimateSizeRequest) Reset() {
+	*x = EstimateSizeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EstimateSizeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EstimateSizeRequest) ProtoMessage() {}
+
+func (x *EstimateSizeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EstimateSizeRequest.ProtoReflect.Descriptor instead.
+func (*EstimateSizeRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *EstimateSizeRequest) GetStartKey() []byte {
+	if x != nil {
+		return x.StartKey
+	}
+	return nil
+}
+
+func (x *EstimateSizeRequest) GetEndKey() []byte {
+	if x != nil {
+		return x.EndKey
+	}
+	return nil
+}
+
+type EstimateSizeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Status indicates the result of the estimate size operation.
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// NumKeys is the approximate number of keys in the key range.
+	NumKeys uint64 `protobuf:"varint,2,opt,name=numKeys,proto3" json:"numKeys,omitempty"`
+	// SizeBytes is the approximate size in bytes occupied by the key range.
+	SizeBytes uint64 `protobuf:"varint,3,opt,name=sizeBytes,proto3" json:"sizeBytes,omitempty"`
+}
+
+func (x *EstimateSizeResponse) Reset() {
+	*x = EstimateSizeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EstimateSizeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EstimateSizeResponse) ProtoMessage() {}
+
+func (x *EstimateSizeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EstimateSizeResponse.ProtoReflect.Descriptor instead.
+func (*EstimateSizeResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *EstimateSizeResponse) GetStatus() *Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *EstimateSizeResponse) GetNumKeys() uint64 {
+	if x != nil {
+		return x.NumKeys
+	}
+	return 0
+}
+
+func (x *EstimateSizeResponse) GetSizeBytes() uint64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+type ReadAmplificationRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// SamplingWindowSecs is the duration in seconds over which the reads
+	// are sampled. A default window is used when it is zero.
+	SamplingWindowSecs uint32 `protobuf:"varint,1,opt,name=samplingWindowSecs,proto3" json:"samplingWindowSecs,omitempty"`
+}
+
+func (x *ReadAmplificationRequest) Reset() {
+	*x = ReadAmplificationRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReadAmplificationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadAmplificationRequest) ProtoMessage() {}
+
+func (x *ReadAmplificationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadAmplificationRequest.ProtoReflect.Descriptor instead.
+func (*ReadAmplificationRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ReadAmplificationRequest) GetSamplingWindowSecs() uint32 {
+	if x != nil {
+		return x.SamplingWindowSecs
+	}
+	return 0
+}
+
+type ReadAmplificationResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Status indicates the result of the report read amplification operation.
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// NumSampledReads is the number of reads served during the window.
+	NumSampledReads uint64 `protobuf:"varint,2,opt,name=numSampledReads,proto3" json:"numSampledReads,omitempty"`
+	// NumSampledKeys is the number of keys looked up by the sampled reads.
+	NumSampledKeys uint64 `protobuf:"varint,3,opt,name=numSampledKeys,proto3" json:"numSampledKeys,omitempty"`
+	// BlockReadsPerKey is the average number of blocks read from disk per key.
+	BlockReadsPerKey float64 `protobuf:"fixed64,4,opt,name=blockReadsPerKey,proto3" json:"blockReadsPerKey,omitempty"`
+	// BlockCacheHitRate is the fraction of block lookups served by the block cache.
+	BlockCacheHitRate float64 `protobuf:"fixed64,5,opt,name=blockCacheHitRate,proto3" json:"blockCacheHitRate,omitempty"`
+	// MemtableHitRate is the fraction of reads served entirely by the memtables.
+	MemtableHitRate float64 `protobuf:"fixed64,6,opt,name=memtableHitRate,proto3" json:"memtableHitRate,omitempty"`
+	// BloomUsefulness is the fraction of bloom filter checks that ruled out a file.
+	BloomUsefulness float64 `protobuf:"fixed64,7,opt,name=bloomUsefulness,proto3" json:"bloomUsefulness,omitempty"`
+	// Suggestions are plain-language suggestions for tuning the node.
+	Suggestions []string `protobuf:"bytes,8,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
+}
+
+func (x *ReadAmplificationResponse) Reset() {
+	*x = ReadAmplificationResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReadAmplificationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadAmplificationResponse) ProtoMessage() {}
+
+func (x *ReadAmplificationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadAmplificationResponse.ProtoReflect.Descriptor instead.
+func (*ReadAmplificationResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ReadAmplificationResponse) GetStatus() *Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *ReadAmplificationResponse) GetNumSampledReads() uint64 {
+	if x != nil {
+		return x.NumSampledReads
+	}
+	return 0
+}
+
+func (x *ReadAmplificationResponse) GetNumSampledKeys() uint64 {
+	if x != nil {
+		return x.NumSampledKeys
+	}
+	return 0
+}
+
+func (x *ReadAmplificationResponse) GetBlockReadsPerKey() float64 {
+	if x != nil {
+		return x.BlockReadsPerKey
+	}
+	return 0
+}
+
+func (x *ReadAmplificationResponse) GetBlockCacheHitRate() float64 {
+	if x != nil {
+		return x.BlockCacheHitRate
+	}
+	return 0
+}
+
+func (x *ReadAmplificationResponse) GetMemtableHitRate() float64 {
+	if x != nil {
+		return x.MemtableHitRate
+	}
+	return 0
+}
+
+func (x *ReadAmplificationResponse) GetBloomUsefulness() float64 {
+	if x != nil {
+		return x.BloomUsefulness
+	}
+	return 0
+}
+
+func (x *ReadAmplificationResponse) GetSuggestions() []string {
+	if x != nil {
+		return x.Suggestions
+	}
+	return nil
+}
+
+type GetKeyHeatmapRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Minutes is the number of most recent minutes retrieved. All the
+	// minutes retained by the node are retrieved when it is zero.
+	Minutes uint32 `protobuf:"varint,1,opt,name=minutes,proto3" json:"minutes,omitempty"`
+}
+
+func (x *GetKeyHeatmapRequest) Reset() {
+	*x = GetKeyHeatmapRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetKeyHeatmapRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKeyHeatmapRequest) ProtoMessage() {}
+
+func (x *GetKeyHeatmapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKeyHeatmapRequest.ProtoReflect.Descriptor instead.
+func (*GetKeyHeatmapRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *GetKeyHeatmapRequest) GetMinutes() uint32 {
+	if x != nil {
+		return x.Minutes
+	}
+	return 0
+}
+
+type GetKeyHeatmapResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Status indicates the result of the get key heatmap operation.
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// Buckets are the contiguous key ranges across which the calls of the
+	// last interval are accounted, in the order of their keys.
+	Buckets []*KeyRangeBucket `protobuf:"bytes,2,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	// Intervals are the minutes over which the calls are accounted, oldest
+	// first, the last of which is in progress.
+	Intervals []*KeyHeatmapInterval `protobuf:"bytes,3,rep,name=intervals,proto3" json:"intervals,omitempty"`
+	// SampleEvery is the number of calls of which one is sampled.
+	SampleEvery uint32 `protobuf:"varint,4,opt,name=sampleEvery,proto3" json:"sampleEvery,omitempty"`
+}
+
+func (x *GetKeyHeatmapResponse) Reset() {
+	*x = GetKeyHeatmapResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetKeyHeatmapResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKeyHeatmapResponse) ProtoMessage() {}
+
+func (x *GetKeyHeatmapResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKeyHeatmapResponse.ProtoReflect.Descriptor instead.
+func (*GetKeyHeatmapResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *GetKeyHeatmapResponse) GetStatus() *Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *GetKeyHeatmapResponse) GetBuckets() []*KeyRangeBucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+func (x *GetKeyHeatmapResponse) GetIntervals() []*KeyHeatmapInterval {
+	if x != nil {
+		return x.Intervals
+	}
+	return nil
+}
+
+func (x *GetKeyHeatmapResponse) GetSampleEvery() uint32 {
+	if x != nil {
+		return x.SampleEvery
+	}
+	return 0
+}
+
+type KeyRangeBucket struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// StartKey is the inclusive start of the key range.
+	StartKey []byte `protobuf:"bytes,1,opt,name=startKey,proto3" json:"startKey,omitempty"`
+	// EndKey is the exclusive end of the key range, empty for the last one.
+	EndKey []byte `protobuf:"bytes,2,opt,name=endKey,proto3" json:"endKey,omitempty"`
+}
+
+func (x *KeyRangeBucket) Reset() {
+	*x = KeyRangeBucket{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[52]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeyRangeBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyRangeBucket) ProtoMessage() {}
+
+func (x *KeyRangeBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[52]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyRangeBucket.ProtoReflect.Descriptor instead.
+func (*KeyRangeBucket) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *KeyRangeBucket) GetStartKey() []byte {
+	if x != nil {
+		return x.StartKey
+	}
+	return nil
+}
+
+func (x *KeyRangeBucket) GetEndKey() []byte {
+	if x != nil {
+		return x.EndKey
+	}
+	return nil
+}
+
+type KeyHeatmapInterval struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// StartTime is the start of the interval in seconds since the epoch.
+	StartTime int64 `protobuf:"varint,1,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	// ReadRates are the estimated keys read per second, per bucket.
+	ReadRates []float64 `protobuf:"fixed64,2,rep,packed,name=readRates,proto3" json:"readRates,omitempty"`
+	// WriteRates are the estimated keys written per second, per bucket.
+	WriteRates []float64 `protobuf:"fixed64,3,rep,packed,name=writeRates,proto3" json:"writeRates,omitempty"`
+	// Buckets are the contiguous key ranges across which the calls of the
+	// interval are accounted, which are derived from the keys sampled in
+	// the preceding interval.
+	Buckets []*KeyRangeBucket `protobuf:"bytes,4,rep,name=buckets,proto3" json:"buckets,omitempty"`
+}
+
+func (x *KeyHeatmapInterval) Reset() {
+	*x = KeyHeatmapInterval{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[53]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeyHeatmapInterval) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyHeatmapInterval) ProtoMessage() {}
+
+func (x *KeyHeatmapInterval) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[53]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyHeatmapInterval.ProtoReflect.Descriptor instead.
+func (*KeyHeatmapInterval) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *KeyHeatmapInterval) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *KeyHeatmapInterval) GetReadRates() []float64 {
+	if x != nil {
+		return x.ReadRates
+	}
+	return nil
+}
+
+func (x *KeyHeatmapInterval) GetWriteRates() []float64 {
+	if x != nil {
+		return x.WriteRates
+	}
+	return nil
+}
+
+func (x *KeyHeatmapInterval) GetBuckets() []*KeyRangeBucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+type GetKeyPoliciesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Status indicates the result of the get key policies operation.
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// KeyPolicies are the key policies of the namespaces.
+	KeyPolicies []*KeyPolicy `protobuf:"bytes,2,rep,name=keyPolicies,proto3" json:"keyPolicies,omitempty"`
+}
+
+func (x *GetKeyPoliciesResponse) Reset() {
+	*x = GetKeyPoliciesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[54]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetKeyPoliciesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKeyPoliciesResponse) ProtoMessage() {}
+
+func (x *GetKeyPoliciesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[54]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKeyPoliciesResponse.ProtoReflect.Descriptor instead.
+func (*GetKeyPoliciesResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *GetKeyPoliciesResponse) GetStatus() *Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *GetKeyPoliciesResponse) GetKeyPolicies() []*KeyPolicy {
+	if x != nil {
+		return x.KeyPolicies
+	}
+	return nil
+}
+
+type KeyPolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Namespace is the key prefix shared by all the keys of the policy.
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Version is the version of the layout of the keys, carried by every key
+	// following its namespace. It is in the range 1 to 255.
+	Version uint32 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	// Segments are the components of the keys following their version, in
+	// their order within the keys.
+	Segments []*KeySegment `protobuf:"bytes,3,rep,name=segments,proto3" json:"segments,omitempty"`
+}
+
+func (x *KeyPolicy) Reset() {
+	*x = KeyPolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[55]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeyPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyPolicy) ProtoMessage() {}
+
+func (x *KeyPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[55]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyPolicy.ProtoReflect.Descriptor instead.
+func (*KeyPolicy) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *KeyPolicy) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *KeyPolicy) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *KeyPolicy) GetSegments() []*KeySegment {
+	if x != nil {
+		return x.Segments
+	}
+	return nil
+}
+
+type KeySegment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name identifies the segment, such as tenant, entity or id.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Type is the type of the values of the segment, which determines their
+	// order preserving encoding within the keys.
+	Type KeySegmentType `protobuf:"varint,2,opt,name=type,proto3,enum=dkv.serverpb.KeySegmentType" json:"type,omitempty"`
+}
+
+func (x *KeySegment) Reset() {
+	*x = KeySegment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[56]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeySegment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeySegment) ProtoMessage() {}
+
+func (x *KeySegment) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[56]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeySegment.ProtoReflect.Descriptor instead.
+func (*KeySegment) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *KeySegment) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *KeySegment) GetType() KeySegmentType {
+	if x != nil {
+		return x.Type
+	}
+	return KeySegmentType_STRING_SEGMENT
+}
+
+type LegalHoldRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// KeyPrefix is the namespace or key prefix whose keys are held.
+	KeyPrefix []byte `protobuf:"bytes,1,opt,name=keyPrefix,proto3" json:"keyPrefix,omitempty"`
+	// Requester identifies the operator placing or lifting the hold.
+	Requester string `protobuf:"bytes,2,opt,name=requester,proto3" json:"requester,omitempty"`
+	// Reason captures the justification recorded in the audit log.
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *LegalHoldRequest) Reset() {
+	*x = LegalHoldRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[57]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LegalHoldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LegalHoldRequest) ProtoMessage() {}
+
+func (x *LegalHoldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[57]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LegalHoldRequest.ProtoReflect.Descriptor instead.
+func (*LegalHoldRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *LegalHoldRequest) GetKeyPrefix() []byte {
+	if x != nil {
+		return x.KeyPrefix
+	}
+	return nil
+}
+
+func (x *LegalHoldRequest) GetRequester() string {
+	if x != nil {
+		return x.Requester
+	}
+	return ""
+}
+
+func (x *LegalHoldRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ListLegalHoldsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Status indicates the result of the list legal holds operation.
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// KeyPrefixes are all the namespaces or key prefixes under legal hold.
+	KeyPrefixes [][]byte `protobuf:"bytes,2,rep,name=keyPrefixes,proto3" json:"keyPrefixes,omitempty"`
+}
+
+func (x *ListLegalHoldsResponse) Reset() {
+	*x = ListLegalHoldsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[58]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListLegalHoldsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLegalHoldsResponse) ProtoMessage() {}
+
+func (x *ListLegalHoldsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[58]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLegalHoldsResponse.ProtoReflect.Descriptor instead.
+func (*ListLegalHoldsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ListLegalHoldsResponse) GetStatus() *Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *ListLegalHoldsResponse) GetKeyPrefixes() [][]byte {
+	if x != nil {
+		return x.KeyPrefixes
+	}
+	return nil
+}
+
+type DeleteSubjectRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Subject identifies the data subject whose encryption key is destroyed.
+	Subject []byte `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	// Requester identifies the operator erasing the subject.
+	Requester string `protobuf:"bytes,2,opt,name=requester,proto3" json:"requester,omitempty"`
+	// Reason captures the justification recorded in the audit log.
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *DeleteSubjectRequest) Reset() {
+	*x = DeleteSubjectRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[59]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteSubjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSubjectRequest) ProtoMessage() {}
+
+func (x *DeleteSubjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[59]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSubjectRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSubjectRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *DeleteSubjectRequest) GetSubject() []byte {
+	if x != nil {
+		return x.Subject
+	}
+	return nil
+}
+
+func (x *DeleteSubjectRequest) GetRequester() string {
+	if x != nil {
+		return x.Requester
+	}
+	return ""
+}
+
+func (x *DeleteSubjectRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type MintAccessTokenRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// KeyPrefix restricts the token to reads of the keys having this prefix.
+	KeyPrefix []byte `protobuf:"bytes,1,opt,name=keyPrefix,proto3" json:"keyPrefix,omitempty"`
+	// TtlSecs is the validity of the token in seconds, defaulting to and
+	// capped at one hour.
+	TtlSecs uint32 `protobuf:"varint,2,opt,name=ttlSecs,proto3" json:"ttlSecs,omitempty"`
+	// Requester identifies the engineer being granted access.
+	Requester string `protobuf:"bytes,3,opt,name=requester,proto3" json:"requester,omitempty"`
+	// Reason captures the justification recorded in the audit log.
+	Reason string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *MintAccessTokenRequest) Reset() {
+	*x = MintAccessTokenRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[60]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MintAccessTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MintAccessTokenRequest) ProtoMessage() {}
+
+func (x *MintAccessTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[60]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MintAccessTokenRequest.ProtoReflect.Descriptor instead.
+func (*MintAccessTokenRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *MintAccessTokenRequest) GetKeyPrefix() []byte {
+	if x != nil {
+		return x.KeyPrefix
+	}
+	return nil
+}
+
+func (x *MintAccessTokenRequest) GetTtlSecs() uint32 {
+	if x != nil {
+		return x.TtlSecs
+	}
+	return 0
+}
+
+func (x *MintAccessTokenRequest) GetRequester() string {
+	if x != nil {
+		return x.Requester
+	}
+	return ""
+}
+
+func (x *MintAccessTokenRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type GetEffectiveConfigResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Status indicates the result of the get effective config operation.
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// Config is the JSON representation of the effective configuration.
+	Config string `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+}
+
+func (x *GetEffectiveConfigResponse) Reset() {
+	*x = GetEffectiveConfigResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[61]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetEffectiveConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEffectiveConfigResponse) ProtoMessage() {}
+
+func (x *GetEffectiveConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[61]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEffectiveConfigResponse.ProtoReflect.Descriptor instead.
+func (*GetEffectiveConfigResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *GetEffectiveConfigResponse) GetStatus() *Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *GetEffectiveConfigResponse) GetConfig() string {
+	if x != nil {
+		return x.Config
+	}
+	return ""
+}
+
+type MintAccessTokenResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Status indicates the result of the mint access token operation.
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// Token is the access token to be sent as a bearer token in the
+	// authorization header of subsequent requests.
+	Token string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	// ExpireTS is the epoch seconds at which the token expires.
+	ExpireTS uint64 `protobuf:"varint,3,opt,name=expireTS,proto3" json:"expireTS,omitempty"`
+}
+
+func (x *MintAccessTokenResponse) Reset() {
+	*x = MintAccessTokenResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[62]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MintAccessTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MintAccessTokenResponse) ProtoMessage() {}
+
+func (x *MintAccessTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[62]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MintAccessTokenResponse.ProtoReflect.Descriptor instead.
+func (*MintAccessTokenResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *MintAccessTokenResponse) GetStatus() *Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *MintAccessTokenResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *MintAccessTokenResponse) GetExpireTS() uint64 {
+	if x != nil {
+		return x.ExpireTS
+	}
+	return 0
+}
+
+type ListNodesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Status indicates the status of the list nodes operation.
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// Leader represents the identifier of the leader node.
+	Leader uint64 `protobuf:"varint,2,opt,name=leader,proto3" json:"leader,omitempty"`
+	// Nodes represents the members of the cluster identified by
+	// their respective identifier and the Nexus URL.
+	Nodes map[uint64]*models.NodeInfo `protobuf:"bytes,3,rep,name=nodes,proto3" json:"nodes,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *ListNodesResponse) Reset() {
+	*x = ListNodesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[63]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListNodesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNodesResponse) ProtoMessage() {}
+
+func (x *ListNodesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[63]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNodesResponse.ProtoReflect.Descriptor instead.
+func (*ListNodesResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ListNodesResponse) GetStatus() *Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *ListNodesResponse) GetLeader() uint64 {
+	if x != nil {
+		return x.Leader
+	}
+	return 0
+}
+
+func (x *ListNodesResponse) GetNodes() map[uint64]*models.NodeInfo {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+type AddNodeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// NodeUrl represents the URL for the Nexus service running on
+	// the node that needs to be added to the cluster. Other Nexus
+	// peers communicate with this node on this service.
+	NodeUrl string `protobuf:"bytes,1,opt,name=nodeUrl,proto3" json:"nodeUrl,omitempty"`
+}
+
+func (x *AddNodeRequest) Reset() {
+	*x = AddNodeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[64]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddNodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddNodeRequest) ProtoMessage() {}
+
+func (x *AddNodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[64]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddNodeRequest.ProtoReflect.Descriptor instead.
+func (*AddNodeRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *AddNodeRequest) GetNodeUrl() string {
+	if x != nil {
+		return x.NodeUrl
+	}
+	return ""
+}
+
+type RemoveNodeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// NodeUrl represents the URL for the Nexus service running on
+	// the node that needs to be removed from the cluster.
+	NodeUrl string `protobuf:"bytes,1,opt,name=nodeUrl,proto3" json:"nodeUrl,omitempty"`
+}
+
+func (x *RemoveNodeRequest) Reset() {
+	*x = RemoveNodeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[65]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveNodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveNodeRequest) ProtoMessage() {}
+
+func (x *RemoveNodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[65]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveNodeRequest.ProtoReflect.Descriptor instead.
+func (*RemoveNodeRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *RemoveNodeRequest) GetNodeUrl() string {
+	if x != nil {
+		return x.NodeUrl
+	}
+	return ""
+}
+
+type UpdateStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RegionInfo *RegionInfo `protobuf:"bytes,1,opt,name=regionInfo,proto3" json:"regionInfo,omitempty"`
+	// Time at which the status was sent from the respective node
+	Timestamp uint64 `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (x *UpdateStatusRequest) Reset() {
+	*x = UpdateStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[66]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateStatusRequest) ProtoMessage() {}
+
+func (x *UpdateStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[66]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateStatusRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *UpdateStatusRequest) GetRegionInfo() *RegionInfo {
+	if x != nil {
+		return x.RegionInfo
+	}
+	return nil
+}
+
+func (x *UpdateStatusRequest) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+// Get cluster info filtered on request params
+type GetClusterInfoRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Identifier of datacenter / availability zone
+	DcID *string `protobuf:"bytes,1,opt,name=dcID,proto3,oneof" json:"dcID,omitempty"`
+	// Database identifier
+	Database *string `protobuf:"bytes,2,opt,name=database,proto3,oneof" json:"database,omitempty"`
+	// Shard / vBucket identifier
+	VBucket *string `protobuf:"bytes,3,opt,name=vBucket,proto3,oneof" json:"vBucket,omitempty"`
+}
+
+func (x *GetClusterInfoRequest) Reset() {
+	*x = GetClusterInfoRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[67]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetClusterInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetClusterInfoRequest) ProtoMessage() {}
+
+func (x *GetClusterInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[67]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetClusterInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetClusterInfoRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *GetClusterInfoRequest) GetDcID() string {
+	if x != nil && x.DcID != nil {
+		return *x.DcID
+	}
+	return ""
+}
+
+func (x *GetClusterInfoRequest) GetDatabase() string {
+	if x != nil && x.Database != nil {
+		return *x.Database
+	}
+	return ""
+}
+
+func (x *GetClusterInfoRequest) GetVBucket() string {
+	if x != nil && x.VBucket != nil {
+		return *x.VBucket
+	}
+	return ""
+}
+
+type GetClusterInfoResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RegionInfos []*RegionInfo `protobuf:"bytes,1,rep,name=regionInfos,proto3" json:"regionInfos,omitempty"`
+}
+
+func (x *GetClusterInfoResponse) Reset() {
+	*x = GetClusterInfoResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[68]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetClusterInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetClusterInfoResponse) ProtoMessage() {}
+
+func (x *GetClusterInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[68]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetClusterInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetClusterInfoResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *GetClusterInfoResponse) GetRegionInfos() []*RegionInfo {
+	if x != nil {
+		return x.RegionInfos
+	}
+	return nil
+}
+
+type BackupClusterRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Database identifier whose shards are backed up
+	Database string `protobuf:"bytes,1,opt,name=database,proto3" json:"database,omitempty"`
+	// Filesystem folder under which the shard backups and the cluster backup manifest are placed
+	// Expected to be a location shared by all the nodes of the cluster
+	BackupPath string `protobuf:"bytes,2,opt,name=backupPath,proto3" json:"backupPath,omitempty"`
+	// Pauses the writes on the leaders of all the shards for the duration of the backup, so that
+	// the shard backups together form a consistent cut of the database
+	Quiesce bool `protobuf:"varint,3,opt,name=quiesce,proto3" json:"quiesce,omitempty"`
+	// Duration in seconds after which the leaders resume the writes should the quiesced backup
+	// not complete by then. Defaults to 10 minutes
+	QuiesceTimeoutSecs uint32 `protobuf:"varint,4,opt,name=quiesceTimeoutSecs,proto3" json:"quiesceTimeoutSecs,omitempty"`
+}
+
+func (x *BackupClusterRequest) Reset() {
+	*x = BackupClusterRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[69]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BackupClusterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupClusterRequest) ProtoMessage() {}
+
+func (x *BackupClusterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[69]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupClusterRequest.ProtoReflect.Descriptor instead.
+func (*BackupClusterRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *BackupClusterRequest) GetDatabase() string {
+	if x != nil {
+		return x.Database
+	}
+	return ""
+}
+
+func (x *BackupClusterRequest) GetBackupPath() string {
+	if x != nil {
+		return x.BackupPath
+	}
+	return ""
+}
+
+func (x *BackupClusterRequest) GetQuiesce() bool {
+	if x != nil {
+		return x.Quiesce
+	}
+	return false
+}
+
+func (x *BackupClusterRequest) GetQuiesceTimeoutSecs() uint32 {
+	if x != nil {
+		return x.QuiesceTimeoutSecs
+	}
+	return 0
+}
+
+type BackupClusterResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// Filesystem location of the cluster backup manifest
+	ManifestPath string `protobuf:"bytes,2,opt,name=manifestPath,proto3" json:"manifestPath,omitempty"`
+	// Backups of the individual shards, which together form the cluster backup
+	Shards []*ShardBackup `protobuf:"bytes,3,rep,name=shards,proto3" json:"shards,omitempty"`
+	// Epoch seconds at which the cluster backup began
+	StartedAt int64 `protobuf:"varint,4,opt,name=startedAt,proto3" json:"startedAt,omitempty"`
+	// Epoch seconds at which the backups of all the shards completed
+	CompletedAt int64 `protobuf:"varint,5,opt,name=completedAt,proto3" json:"completedAt,omitempty"`
+	// Database identifier whose shards are backed up
+	Database string `protobuf:"bytes,6,opt,name=database,proto3" json:"database,omitempty"`
+	// Indicates if the writes were paused for the duration of the backup, in which case the
+	// change numbers of the shards form a consistent cut of the database
+	Quiesced bool `protobuf:"varint,7,opt,name=quiesced,proto3" json:"quiesced,omitempty"`
+}
+
+func (x *BackupClusterResponse) Reset() {
+	*x = BackupClusterResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[70]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BackupClusterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupClusterResponse) ProtoMessage() {}
+
+func (x *BackupClusterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[70]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupClusterResponse.ProtoReflect.Descriptor instead.
+func (*BackupClusterResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *BackupClusterResponse) GetStatus() *Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *BackupClusterResponse) GetManifestPath() string {
+	if x != nil {
+		return x.ManifestPath
+	}
+	return ""
+}
+
+func (x *BackupClusterResponse) GetShards() []*ShardBackup {
+	if x != nil {
+		return x.Shards
+	}
+	return nil
+}
+
+func (x *BackupClusterResponse) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *BackupClusterResponse) GetCompletedAt() int64 {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return 0
+}
+
+func (x *BackupClusterResponse) GetDatabase() string {
+	if x != nil {
+		return x.Database
+	}
+	return ""
+}
+
+func (x *BackupClusterResponse) GetQuiesced() bool {
+	if x != nil {
+		return x.Quiesced
+	}
+	return false
+}
+
+type ShardBackup struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Shard / vBucket identifier
+	VBucket string `protobuf:"bytes,1,opt,name=vBucket,proto3" json:"vBucket,omitempty"`
+	// Address of the leader of the shard that took the backup
+	NodeAddress string `protobuf:"bytes,2,opt,name=nodeAddress,proto3" json:"nodeAddress,omitempty"`
+	// Filesystem location of the backup of the shard
+	BackupPath string `protobuf:"bytes,3,opt,name=backupPath,proto3" json:"backupPath,omitempty"`
+	// Change number upto and including which all changes of the shard are present in its backup
+	// Restoring every shard upto its change number rebuilds the cluster as of the backup
+	ChangeNumber uint64 `protobuf:"varint,4,opt,name=changeNumber,proto3" json:"changeNumber,omitempty"`
+	// Epoch seconds at which the backup of the shard was taken
+	CreatedAt int64 `protobuf:"varint,5,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	// Change number of the shard when its writes were paused for a quiesced cluster backup,
+	// which the backup of the shard matches. These form the change number vector of the
+	// consistent cut captured by the cluster backup. Zero unless quiesced
+	QuiescedChangeNumber uint64 `protobuf:"varint,6,opt,name=quiescedChangeNumber,proto3" json:"quiescedChangeNumber,omitempty"`
+}
+
+func (x *ShardBackup) Reset() {
+	*x = ShardBackup{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[71]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShardBackup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShardBackup) ProtoMessage() {}
+
+func (x *ShardBackup) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[71]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShardBackup.ProtoReflect.Descriptor instead.
+func (*ShardBackup) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *ShardBackup) GetVBucket() string {
+	if x != nil {
+		return x.VBucket
+	}
+	return ""
+}
+
+func (x *ShardBackup) GetNodeAddress() string {
+	if x != nil {
+		return x.NodeAddress
+	}
+	return ""
+}
+
+func (x *ShardBackup) GetBackupPath() string {
+	if x != nil {
+		return x.BackupPath
+	}
+	return ""
+}
+
+func (x *ShardBackup) GetChangeNumber() uint64 {
+	if x != nil {
+		return x.ChangeNumber
+	}
+	return 0
+}
+
+func (x *ShardBackup) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *ShardBackup) GetQuiescedChangeNumber() uint64 {
+	if x != nil {
+		return x.QuiescedChangeNumber
+	}
+	return 0
+}
+
+type RestoreClusterRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Filesystem location of the cluster backup manifest, as returned by BackupCluster
+	ManifestPath string `protobuf:"bytes,1,opt,name=manifestPath,proto3" json:"manifestPath,omitempty"`
+}
+
+func (x *RestoreClusterRequest) Reset() {
+	*x = RestoreClusterRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[72]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RestoreClusterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreClusterRequest) ProtoMessage() {}
+
+func (x *RestoreClusterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[72]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreClusterRequest.ProtoReflect.Descriptor instead.
+func (*RestoreClusterRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *RestoreClusterRequest) GetManifestPath() string {
+	if x != nil {
+		return x.ManifestPath
+	}
+	return ""
+}
+
+type RestoreClusterResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// Restorations of the individual shards
+	Shards []*ShardRestore `protobuf:"bytes,2,rep,name=shards,proto3" json:"shards,omitempty"`
+}
+
+func (x *RestoreClusterResponse) Reset() {
+	*x = RestoreClusterResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[73]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RestoreClusterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreClusterResponse) ProtoMessage() {}
+
+func (x *RestoreClusterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[73]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreClusterResponse.ProtoReflect.Descriptor instead.
+func (*RestoreClusterResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *RestoreClusterResponse) GetStatus() *Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *RestoreClusterResponse) GetShards() []*ShardRestore {
+	if x != nil {
+		return x.Shards
+	}
+	return nil
+}
+
+type ShardRestore struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Shard / vBucket identifier
+	VBucket string `protobuf:"bytes,1,opt,name=vBucket,proto3" json:"vBucket,omitempty"`
+	// Address of the leader of the shard that was restored
+	NodeAddress string `protobuf:"bytes,2,opt,name=nodeAddress,proto3" json:"nodeAddress,omitempty"`
+	// Change number upto and including which the shard was restored
+	ChangeNumber uint64 `protobuf:"varint,3,opt,name=changeNumber,proto3" json:"changeNumber,omitempty"`
+	// Addresses of the slaves of the shard that were restored from the same backup
+	SlaveAddresses []string `protobuf:"bytes,4,rep,name=slaveAddresses,proto3" json:"slaveAddresses,omitempty"`
+	// Addresses of the followers of the leader of the shard that were restored
+	// upto the same change number
+	FollowerAddresses []string `protobuf:"bytes,5,rep,name=followerAddresses,proto3" json:"followerAddresses,omitempty"`
+}
+
+func (x *ShardRestore) Reset() {
+	*x = ShardRestore{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[74]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShardRestore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShardRestore) ProtoMessage() {}
+
+func (x *ShardRestore) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[74]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShardRestore.ProtoReflect.Descriptor instead.
+func (*ShardRestore) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *ShardRestore) GetVBucket() string {
+	if x != nil {
+		return x.VBucket
+	}
+	return ""
+}
+
+func (x *ShardRestore) GetNodeAddress() string {
+	if x != nil {
+		return x.NodeAddress
+	}
+	return ""
+}
+
+func (x *ShardRestore) GetChangeNumber() uint64 {
+	if x != nil {
+		return x.ChangeNumber
+	}
+	return 0
+}
+
+func (x *ShardRestore) GetSlaveAddresses() []string {
+	if x != nil {
+		return x.SlaveAddresses
+	}
+	return nil
+}
+
+func (x *ShardRestore) GetFollowerAddresses() []string {
+	if x != nil {
+		return x.FollowerAddresses
+	}
+	return nil
+}
+
+type RegionInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Identifier of datacenter / availability zone
+	// Slaves will prefer to select master within same dcId ,
+	// Local DC followers will be created for every unique dcId
+	DcID string `protobuf:"bytes,1,opt,name=dcID,proto3" json:"dcID,omitempty"`
+	// Address to communicate with this node
+	// Should be equal to listen-addr used for the node whose status is being updated
+	NodeAddress string `protobuf:"bytes,2,opt,name=nodeAddress,proto3" json:"nodeAddress,omitempty"`
+	// Database identifier
+	Database string `protobuf:"bytes,3,opt,name=database,proto3" json:"database,omitempty"`
+	// Shard / vBucket identifier
+	VBucket string `protobuf:"bytes,4,opt,name=vBucket,proto3" json:"vBucket,omitempty"`
+	// Status of the region
+	Status RegionStatus `protobuf:"varint,5,opt,name=status,proto3,enum=dkv.serverpb.RegionStatus" json:"status,omitempty"`
+	// Master host of the region whose status is being updated
+	// Applicable only for slaves. Used to load balance among various possible masters
+	MasterHost *string `protobuf:"bytes,6,opt,name=masterHost,proto3,oneof" json:"masterHost,omitempty"`
+	// Nexus cluster url of the region
+	// Will be used by new followers to discover the raft cluster
+	NexusClusterUrl *string `protobuf:"bytes,7,opt,name=nexusClusterUrl,proto3,oneof" json:"nexusClusterUrl,omitempty"`
+	// Consistency tag recorded by the node once it caught up with its master
+	// Applicable only for slaves. Used to audit where the data of a replica came from
+	ConsistencyTag *ConsistencyTag `protobuf:"bytes,8,opt,name=consistencyTag,proto3,oneof" json:"consistencyTag,omitempty"`
+}
+
+func (x *RegionInfo) Reset() {
+	*x = RegionInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[75]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RegionInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegionInfo) ProtoMessage() {}
+
+func (x *RegionInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[75]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegionInfo.ProtoReflect.Descriptor instead.
+func (*RegionInfo) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *RegionInfo) GetDcID() string {
+	if x != nil {
+		return x.DcID
+	}
+	return ""
+}
+
+func (x *RegionInfo) GetNodeAddress() string {
+	if x != nil {
+		return x.NodeAddress
+	}
+	return ""
+}
+
+func (x *RegionInfo) GetDatabase() string {
+	if x != nil {
+		return x.Database
+	}
+	return ""
+}
+
+func (x *RegionInfo) GetVBucket() string {
+	if x != nil {
+		return x.VBucket
+	}
+	return ""
+}
+
+func (x *RegionInfo) GetStatus() RegionStatus {
+	if x != nil {
+		return x.Status
+	}
+	return RegionStatus_INACTIVE
+}
+
+func (x *RegionInfo) GetMasterHost() string {
+	if x != nil && x.MasterHost != nil {
+		return *x.MasterHost
+	}
+	return ""
+}
+
+func (x *RegionInfo) GetNexusClusterUrl() string {
+	if x != nil && x.NexusClusterUrl != nil {
+		return *x.NexusClusterUrl
+	}
+	return ""
+}
+
+func (x *RegionInfo) GetConsistencyTag() *ConsistencyTag {
+	if x != nil {
+		return x.ConsistencyTag
+	}
+	return nil
+}
+
+type ConsistencyTag struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// SourceNode is the address of the master node from which the replica caught up.
+	SourceNode string `protobuf:"bytes,1,opt,name=sourceNode,proto3" json:"sourceNode,omitempty"`
+	// ChangeNumber is the change number upto which the replica caught up.
+	ChangeNumber uint64 `protobuf:"varint,2,opt,name=changeNumber,proto3" json:"changeNumber,omitempty"`
+	// Checksum is the hex encoded SHA-256 checksum of the sampled key ranges of the replica.
+	Checksum string `protobuf:"bytes,3,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	// Timestamp is the time, in Unix seconds, at which the replica caught up.
+	Timestamp uint64 `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Signature is the signature of all the other fields, signed using the
+	// access token secret of the replica.
+	Signature string `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *ConsistencyTag) Reset() {
+	*x = ConsistencyTag{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_admin_proto_msgTypes[76]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConsistencyTag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsistencyTag) ProtoMessage() {}
+
+func (x *ConsistencyTag) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_admin_proto_msgTypes[76]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsistencyTag.ProtoReflect.Descriptor instead.
+func (*ConsistencyTag) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_admin_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *ConsistencyTag) GetSourceNode() string {
+	if x != nil {
+		return x.SourceNode
+	}
+	return ""
+}
+
+func (x *ConsistencyTag) GetChangeNumber() uint64 {
+	if x != nil {
+		return x.ChangeNumber
+	}
+	return 0
+}
+
+func (x *ConsistencyTag) GetChecksum() string {
+	if x != nil {
+		return x.Checksum
+	}
+	return ""
+}
+
+func (x *ConsistencyTag) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *ConsistencyTag) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+var File_pkg_serverpb_admin_proto protoreflect.FileDescriptor
+
+var file_pkg_serverpb_admin_proto_rawDesc = []byte{
+	0x0a, 0x18, 0x70, 0x6b, 0x67, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2f, 0x61,
+	0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x64, 0x6b, 0x76, 0x2e,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x16, 0x70, 0x6b, 0x67, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x70, 0x62, 0x2f, 0x61, 0x70, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x15, 0x6d,
+	0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x8e, 0x01, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x52, 0x61, 0x6e, 0x67,
+	0x65, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1a, 0x0a, 0x08, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x08, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x65,
+	0x6e, 0x64, 0x4b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x65, 0x6e, 0x64,
+	0x4b, 0x65, 0x79, 0x12, 0x22, 0x0a, 0x0c, 0x6b, 0x65, 0x79, 0x73, 0x50, 0x65, 0x72, 0x52, 0x61,
+	0x6e, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x6b, 0x65, 0x79, 0x73, 0x50,
+	0x65, 0x72, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x52, 0x61,
+	0x6e, 0x67, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x52,
+	0x61, 0x6e, 0x67, 0x65, 0x73, 0x22, 0x73, 0x0a, 0x0b, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4b, 0x65, 0x79,
+	0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x64, 0x4b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x06, 0x65, 0x6e, 0x64, 0x4b, 0x65, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x75, 0x6d, 0x4b,
+	0x65, 0x79, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x6e, 0x75, 0x6d, 0x4b, 0x65,
+	0x79, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x7c, 0x0a, 0x17, 0x47, 0x65,
+	0x74, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x33, 0x0a, 0x07, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x70, 0x62, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x52,
+	0x07, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x22, 0xbc, 0x01, 0x0a, 0x0e, 0x44, 0x69, 0x76,
+	0x65, 0x72, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x4b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x64, 0x4b, 0x65,
+	0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x65, 0x6e, 0x64, 0x4b, 0x65, 0x79, 0x12,
+	0x24, 0x0a, 0x0d, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x4b, 0x65, 0x79, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x75,
+	0x6d, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x6c, 0x61, 0x76, 0x65, 0x4e, 0x75,
+	0x6d, 0x4b, 0x65, 0x79, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x73, 0x6c, 0x61,
+	0x76, 0x65, 0x4e, 0x75, 0x6d, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x2c, 0x0a, 0x11, 0x64, 0x65, 0x74,
+	0x65, 0x63, 0x74, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x65, 0x64, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0xae, 0x01, 0x0a, 0x1a, 0x47, 0x65, 0x74, 0x44,
+	0x69, 0x76, 0x65, 0x72, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x34, 0x0a, 0x06, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x70, 0x62, 0x2e, 0x44, 0x69, 0x76, 0x65, 0x72, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x61, 0x6e,
+	0x67, 0x65, 0x52, 0x06, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x11, 0x6c, 0x61,
+	0x73, 0x74, 0x50, 0x61, 0x73, 0x73, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x6c, 0x61, 0x73, 0x74, 0x50, 0x61, 0x73, 0x73, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x92, 0x01, 0x0a, 0x14, 0x52, 0x65, 0x70,
+	0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x61, 0x67, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x49, 0x44, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x49, 0x44, 0x12,
+	0x30, 0x0a, 0x13, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x13, 0x61, 0x70,
+	0x70, 0x6c, 0x69, 0x65, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x12, 0x2a, 0x0a, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x61, 0x70, 0x70,
+	0x6c, 0x69, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0xf4, 0x01,
+	0x0a, 0x0a, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x4c, 0x61, 0x67, 0x12, 0x1c, 0x0a, 0x09,
+	0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x49, 0x44, 0x12, 0x30, 0x0a, 0x13, 0x61, 0x70,
+	0x70, 0x6c, 0x69, 0x65, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x13, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x2a, 0x0a, 0x10,
+	0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x2c, 0x0a, 0x11, 0x6c, 0x61, 0x73, 0x74,
+	0x53, 0x65, 0x65, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x11, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1e, 0x0a, 0x0a, 0x6c, 0x61, 0x67, 0x43, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x6c, 0x61, 0x67, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x61, 0x67, 0x4d, 0x69, 0x6c,
+	0x6c, 0x69, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6c, 0x61, 0x67, 0x4d, 0x69,
+	0x6c, 0x6c, 0x69, 0x73, 0x22, 0xaf, 0x01, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x61, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70,
+	0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x2e, 0x0a, 0x12, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x12, 0x6d, 0x61,
+	0x73, 0x74, 0x65, 0x72, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x12, 0x34, 0x0a, 0x08, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70,
+	0x62, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x4c, 0x61, 0x67, 0x52, 0x08, 0x72, 0x65,
+	0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x22, 0x54, 0x0a, 0x0e, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x6b, 0x69, 0x70,
+	0x46, 0x65, 0x6e, 0x63, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x73,
+	0x6b, 0x69, 0x70, 0x46, 0x65, 0x6e, 0x63, 0x69, 0x6e, 0x67, 0x12, 0x20, 0x0a, 0x0b, 0x66, 0x65,
+	0x6e, 0x63, 0x65, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0b, 0x66, 0x65, 0x6e, 0x63, 0x65, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x22, 0x87, 0x01, 0x0a,
+	0x0f, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x22,
+	0x0a, 0x0c, 0x66, 0x65, 0x6e, 0x63, 0x69, 0x6e, 0x67, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x66, 0x65, 0x6e, 0x63, 0x69, 0x6e, 0x67, 0x45, 0x70, 0x6f,
+	0x63, 0x68, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x58, 0x0a, 0x0c, 0x46, 0x65, 0x6e, 0x63, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x66, 0x65, 0x6e, 0x63, 0x69, 0x6e,
+	0x67, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x66, 0x65,
+	0x6e, 0x63, 0x69, 0x6e, 0x67, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x24, 0x0a, 0x0d, 0x6d, 0x61,
+	0x73, 0x74, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x22, 0x28, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x7a, 0x6f, 0x6e, 0x65, 0x22, 0x48, 0x0a, 0x13, 0x47, 0x65,
+	0x74, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x31, 0x0a, 0x08, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x70, 0x62, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x73, 0x22, 0x4d, 0x0a, 0x07, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x12,
+	0x12, 0x0a, 0x04, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x7a,
+	0x6f, 0x6e, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x70,
+	0x6f, 0x72, 0x74, 0x22, 0xb9, 0x03, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x10, 0x66, 0x72, 0x6f,
+	0x6d, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x10, 0x66, 0x72, 0x6f, 0x6d, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x2e, 0x0a, 0x12, 0x6d, 0x61, 0x78, 0x4e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x4f, 0x66, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x12, 0x6d, 0x61, 0x78, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x4f, 0x66, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61,
+	0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63,
+	0x61, 0x49, 0x44, 0x12, 0x24, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x57, 0x61, 0x69, 0x74, 0x4d, 0x69,
+	0x6c, 0x6c, 0x69, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x6d, 0x61, 0x78, 0x57,
+	0x61, 0x69, 0x74, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x6d, 0x61, 0x78,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x0d, 0x6d, 0x61, 0x78, 0x42, 0x61, 0x74, 0x63, 0x68, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12,
+	0x30, 0x0a, 0x13, 0x6d, 0x61, 0x78, 0x42, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65, 0x6c, 0x61, 0x79,
+	0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x13, 0x6d, 0x61,
+	0x78, 0x42, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x4d, 0x69, 0x6c, 0x6c, 0x69,
+	0x73, 0x12, 0x28, 0x0a, 0x0f, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x4d, 0x69,
+	0x6c, 0x6c, 0x69, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x68, 0x65, 0x61, 0x72,
+	0x74, 0x62, 0x65, 0x61, 0x74, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x6d,
+	0x61, 0x78, 0x49, 0x6e, 0x46, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x10, 0x6d, 0x61, 0x78, 0x49, 0x6e, 0x46, 0x6c, 0x69, 0x67,
+	0x68, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x11, 0x72, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x11, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x0f, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x45, 0x6e,
+	0x63, 0x6f, 0x64, 0x65, 0x4b, 0x65, 0x79, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f,
+	0x64, 0x65, 0x6c, 0x74, 0x61, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x4b, 0x65, 0x79, 0x73, 0x22,
+	0xf6, 0x01, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x2e, 0x0a, 0x12, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x12, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x12, 0x28, 0x0a, 0x0f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x4f, 0x66,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x6e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x4f, 0x66, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x34,
+	0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x07, 0x63, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x66, 0x65, 0x6e, 0x63, 0x69, 0x6e, 0x67, 0x45,
+	0x70, 0x6f, 0x63, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x66, 0x65, 0x6e, 0x63,
+	0x69, 0x6e, 0x67, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x22, 0x73, 0x0a, 0x19, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x66, 0x65, 0x72, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f,
+	0x69, 0x6e, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x68, 0x65,
+	0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c,
+	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c,
+	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x22, 0xeb, 0x01,
+	0x0a, 0x0f, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x43, 0x68, 0x75, 0x6e,
+	0x6b, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62,
+	0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x22, 0x0a, 0x0c, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x49, 0x44, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x49, 0x44, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e,
+	0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xcc, 0x02, 0x0a, 0x0c,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x26, 0x0a, 0x0e,
+	0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x73, 0x65, 0x64, 0x46, 0x6f, 0x72, 0x6d, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x73, 0x65, 0x64,
+	0x46, 0x6f, 0x72, 0x6d, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x24, 0x0a, 0x0d, 0x6e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x4f, 0x66, 0x54, 0x72, 0x78, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0d, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x4f, 0x66, 0x54, 0x72, 0x78, 0x6e, 0x73, 0x12, 0x2e,
+	0x0a, 0x05, 0x74, 0x72, 0x78, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e,
+	0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x54, 0x72, 0x78,
+	0x6e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x05, 0x74, 0x72, 0x78, 0x6e, 0x73, 0x12, 0x22,
+	0x0a, 0x0c, 0x66, 0x65, 0x6e, 0x63, 0x69, 0x6e, 0x67, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x66, 0x65, 0x6e, 0x63, 0x69, 0x6e, 0x67, 0x45, 0x70, 0x6f,
+	0x63, 0x68, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73,
+	0x65, 0x64, 0x46, 0x6f, 0x72, 0x6d, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x63, 0x6f,
+	0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64, 0x46, 0x6f, 0x72, 0x6d, 0x12, 0x1f, 0x0a, 0x08,
+	0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x08, 0x20, 0x01, 0x28, 0x07, 0x48, 0x00,
+	0x52, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x88, 0x01, 0x01, 0x42, 0x0b, 0x0a,
+	0x09, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x22, 0xea, 0x01, 0x0a, 0x0a, 0x54,
+	0x72, 0x78, 0x6e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x35, 0x0a, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x21, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x54, 0x72, 0x78, 0x6e, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x2e, 0x54, 0x72, 0x78, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x70, 0x69,
+	0x72, 0x65, 0x54, 0x53, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x65, 0x78, 0x70, 0x69,
+	0x72, 0x65, 0x54, 0x53, 0x12, 0x28, 0x0a, 0x0f, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x4b, 0x65,
+	0x79, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x73,
+	0x68, 0x61, 0x72, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x22, 0x37,
+	0x0a, 0x08, 0x54, 0x72, 0x78, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x6e,
+	0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x50, 0x75, 0x74, 0x10, 0x01,
+	0x12, 0x0a, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05,
+	0x4d, 0x65, 0x72, 0x67, 0x65, 0x10, 0x03, 0x22, 0x36, 0x0a, 0x12, 0x50, 0x61, 0x75, 0x73, 0x65,
+	0x57, 0x72, 0x69, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a,
+	0x0b, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x73, 0x22,
+	0x67, 0x0a, 0x13, 0x50, 0x61, 0x75, 0x73, 0x65, 0x57, 0x72, 0x69, 0x74, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x2f, 0x0a, 0x0d, 0x42, 0x61, 0x63, 0x6b,
+	0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x62, 0x61, 0x63,
+	0x6b, 0x75, 0x70, 0x50, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x62,
+	0x61, 0x63, 0x6b, 0x75, 0x70, 0x50, 0x61, 0x74, 0x68, 0x22, 0x32, 0x0a, 0x0e, 0x52, 0x65, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x72,
+	0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x50, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x72, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x50, 0x61, 0x74, 0x68, 0x22, 0x61, 0x0a,
+	0x19, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x49, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x50, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x72, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x50, 0x61, 0x74, 0x68, 0x12, 0x22, 0x0a, 0x0c,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0c, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x22, 0x3a, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x4d, 0x61, 0x6e,
+	0x69, 0x66, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a,
+	0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x50, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x50, 0x61, 0x74, 0x68, 0x22, 0x8b, 0x01, 0x0a,
+	0x19, 0x47, 0x65, 0x74, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65,
+	0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64, 0x6b, 0x76,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x4a, 0x0a, 0x16, 0x50, 0x75,
+	0x62, 0x6c, 0x69, 0x73, 0x68, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x36, 0x0a, 0x18, 0x55, 0x6e, 0x70, 0x75, 0x62, 0x6c,
+	0x69, 0x73, 0x68, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x22, 0x63,
+	0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x73, 0x22, 0x62, 0x0a, 0x10, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x53, 0x53, 0x54,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x1a, 0x0a,
+	0x08, 0x74, 0x74, 0x6c, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x08, 0x74, 0x74, 0x6c, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x6f, 0x76,
+	0x65, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x6d, 0x6f,
+	0x76, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x22, 0x99, 0x01, 0x0a, 0x0d, 0x45, 0x78, 0x70, 0x6f,
+	0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x4b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x64, 0x4b, 0x65, 0x79, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x65, 0x6e, 0x64, 0x4b, 0x65, 0x79, 0x12, 0x32, 0x0a,
+	0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e,
+	0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x45, 0x78, 0x70,
+	0x6f, 0x72, 0x74, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x74, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x22, 0x6e, 0x0a, 0x0e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x75, 0x6d,
+	0x4b, 0x65, 0x79, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x6e, 0x75, 0x6d, 0x4b,
+	0x65, 0x79, 0x73, 0x22, 0xb5, 0x01, 0x0a, 0x11, 0x51, 0x75, 0x61, 0x72, 0x61, 0x6e, 0x74, 0x69,
+	0x6e, 0x65, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x24, 0x0a, 0x0d, 0x71, 0x75, 0x61, 0x72, 0x61, 0x6e, 0x74,
+	0x69, 0x6e, 0x65, 0x64, 0x41, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x71, 0x75,
+	0x61, 0x72, 0x61, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x64, 0x41, 0x74, 0x22, 0x89, 0x01, 0x0a, 0x1e,
+	0x4c, 0x69, 0x73, 0x74, 0x51, 0x75, 0x61, 0x72, 0x61, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x64, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x39, 0x0a, 0x07,
+	0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e,
+	0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x51, 0x75, 0x61,
+	0x72, 0x61, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x07,
+	0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x22, 0x51, 0x0a, 0x13, 0x50, 0x72, 0x65, 0x66, 0x69,
+	0x78, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c,
+	0x0a, 0x09, 0x6f, 0x6c, 0x64, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x09, 0x6f, 0x6c, 0x64, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x1c, 0x0a, 0x09,
+	0x6e, 0x65, 0x77, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x09, 0x6e, 0x65, 0x77, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x22, 0x9b, 0x01, 0x0a, 0x0c, 0x50,
+	0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6f,
+	0x6c, 0x64, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09,
+	0x6f, 0x6c, 0x64, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x65, 0x77,
+	0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x6e, 0x65,
+	0x77, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x35, 0x0a, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x6e, 0x61,
+	0x6d, 0x65, 0x50, 0x68, 0x61, 0x73, 0x65, 0x52, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x6e, 0x75, 0x6d, 0x4b, 0x65, 0x79, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x07, 0x6e, 0x75, 0x6d, 0x4b, 0x65, 0x79, 0x73, 0x22, 0x7f, 0x0a, 0x19, 0x4c, 0x69, 0x73, 0x74,
+	0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x34, 0x0a, 0x07, 0x72, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x70, 0x62, 0x2e, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65,
+	0x52, 0x07, 0x72, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x22, 0xb3, 0x02, 0x0a, 0x0f, 0x4d, 0x65,
+	0x6d, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x28, 0x0a,
+	0x0f, 0x77, 0x72, 0x69, 0x74, 0x65, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x53, 0x69, 0x7a, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x77, 0x72, 0x69, 0x74, 0x65, 0x42, 0x75, 0x66,
+	0x66, 0x65, 0x72, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x32, 0x0a, 0x14, 0x6d, 0x61, 0x78, 0x57, 0x72,
+	0x69, 0x74, 0x65, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x6d, 0x61, 0x78, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42,
+	0x75, 0x66, 0x66, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x46, 0x0a, 0x1e, 0x6c,
+	0x65, 0x76, 0x65, 0x6c, 0x30, 0x46, 0x69, 0x6c, 0x65, 0x4e, 0x75, 0x6d, 0x43, 0x6f, 0x6d, 0x70,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x1e, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x30, 0x46, 0x69, 0x6c, 0x65, 0x4e,
+	0x75, 0x6d, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x72, 0x69, 0x67,
+	0x67, 0x65, 0x72, 0x12, 0x40, 0x0a, 0x1b, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x30, 0x53, 0x6c, 0x6f,
+	0x77, 0x64, 0x6f, 0x77, 0x6e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x73, 0x54, 0x72, 0x69, 0x67, 0x67,
+	0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x1b, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x30,
+	0x53, 0x6c, 0x6f, 0x77, 0x64, 0x6f, 0x77, 0x6e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x73, 0x54, 0x72,
+	0x69, 0x67, 0x67, 0x65, 0x72, 0x12, 0x38, 0x0a, 0x17, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x30, 0x53,
+	0x74, 0x6f, 0x70, 0x57, 0x72, 0x69, 0x74, 0x65, 0x73, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x17, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x30, 0x53, 0x74,
+	0x6f, 0x70, 0x57, 0x72, 0x69, 0x74, 0x65, 0x73, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x22,
+	0xbb, 0x03, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64, 0x6b, 0x76,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2c, 0x0a, 0x11, 0x6d, 0x65, 0x6d, 0x74,
+	0x61, 0x62, 0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x11, 0x6d, 0x65, 0x6d, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x69, 0x7a,
+	0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x6e, 0x75, 0x6d, 0x46, 0x69, 0x6c,
+	0x65, 0x73, 0x50, 0x65, 0x72, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x03, 0x28, 0x04,
+	0x52, 0x10, 0x6e, 0x75, 0x6d, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x50, 0x65, 0x72, 0x4c, 0x65, 0x76,
+	0x65, 0x6c, 0x12, 0x2c, 0x0a, 0x11, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x43, 0x61, 0x63, 0x68, 0x65,
+	0x48, 0x69, 0x74, 0x52, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x43, 0x61, 0x63, 0x68, 0x65, 0x48, 0x69, 0x74, 0x52, 0x61, 0x74, 0x65,
+	0x12, 0x36, 0x0a, 0x16, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6d, 0x70, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x16, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x12, 0x6f, 0x6c, 0x64, 0x65,
+	0x73, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x12, 0x6f, 0x6c, 0x64, 0x65, 0x73, 0x74, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x2e, 0x0a, 0x12, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x12, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x48, 0x69, 0x73, 0x74,
+	0x6f, 0x72, 0x79, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x53, 0x0a, 0x13, 0x6e, 0x61, 0x6d, 0x65,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x43, 0x61, 0x63, 0x68, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x18,
+	0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x70, 0x62, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x43, 0x61,
+	0x63, 0x68, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x13, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x43, 0x61, 0x63, 0x68, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x22, 0xd3, 0x01,
+	0x0a, 0x13, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x43, 0x61, 0x63, 0x68, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x12, 0x28, 0x0a, 0x0f, 0x6e, 0x75, 0x6d, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x64, 0x52, 0x65, 0x61, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x6e, 0x75,
+	0x6d, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x64, 0x52, 0x65, 0x61, 0x64, 0x73, 0x12, 0x26, 0x0a,
+	0x0e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x43, 0x61, 0x63, 0x68, 0x65, 0x48, 0x69, 0x74, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x43, 0x61, 0x63, 0x68,
+	0x65, 0x48, 0x69, 0x74, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65,
+	0x61, 0x64, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x52, 0x65, 0x61, 0x64, 0x73, 0x12, 0x2c, 0x0a, 0x11, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x43, 0x61,
+	0x63, 0x68, 0x65, 0x48, 0x69, 0x74, 0x52, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x11, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x43, 0x61, 0x63, 0x68, 0x65, 0x48, 0x69, 0x74, 0x52,
+	0x61, 0x74, 0x65, 0x22, 0x52, 0x0a, 0x1a, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x45, 0x6e, 0x63,
+	0x72, 0x79, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x72, 0x12,
+	0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0xb5, 0x01, 0x0a, 0x1b, 0x52, 0x6f, 0x74, 0x61,
+	0x74, 0x65, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x65, 0x79, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x4b,
+	0x65, 0x79, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x63, 0x74, 0x69,
+	0x76, 0x65, 0x4b, 0x65, 0x79, 0x49, 0x44, 0x12, 0x1e, 0x0a, 0x0a, 0x6e, 0x75, 0x6d, 0x53, 0x63,
+	0x61, 0x6e, 0x6e, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x6e, 0x75, 0x6d,
+	0x53, 0x63, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x6e, 0x75, 0x6d, 0x52, 0x65,
+	0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0e, 0x6e, 0x75, 0x6d, 0x52, 0x65, 0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x22,
+	0x66, 0x0a, 0x16, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x54, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x4b,
+	0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x65, 0x6e,
+	0x61, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e,
+	0x74, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x72, 0x12,
+	0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x63, 0x0a, 0x13, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x54, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16,
+	0x0a, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06,
+	0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x33, 0x0a, 0x13,
+	0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6b, 0x65, 0x79, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x6b, 0x65, 0x79, 0x50, 0x72, 0x65, 0x66, 0x69,
+	0x78, 0x22, 0x49, 0x0a, 0x13, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x53, 0x69, 0x7a,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x4b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x64, 0x4b, 0x65, 0x79, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x65, 0x6e, 0x64, 0x4b, 0x65, 0x79, 0x22, 0x7c, 0x0a, 0x14,
+	0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x75, 0x6d, 0x4b, 0x65, 0x79, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x07, 0x6e, 0x75, 0x6d, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x1c, 0x0a, 0x09,
+	0x73, 0x69, 0x7a, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x09, 0x73, 0x69, 0x7a, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0x4a, 0x0a, 0x18, 0x52, 0x65,
+	0x61, 0x64, 0x41, 0x6d, 0x70, 0x6c, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2e, 0x0a, 0x12, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x69,
+	0x6e, 0x67, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x53, 0x65, 0x63, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x12, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x57, 0x69, 0x6e, 0x64,
+	0x6f, 0x77, 0x53, 0x65, 0x63, 0x73, 0x22, 0xeb, 0x02, 0x0a, 0x19, 0x52, 0x65, 0x61, 0x64, 0x41,
+	0x6d, 0x70, 0x6c, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x6e, 0x75, 0x6d, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x64,
+	0x52, 0x65, 0x61, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x6e, 0x75, 0x6d,
+	0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x64, 0x52, 0x65, 0x61, 0x64, 0x73, 0x12, 0x26, 0x0a, 0x0e,
+	0x6e, 0x75, 0x6d, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x6e, 0x75, 0x6d, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x64,
+	0x4b, 0x65, 0x79, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x61,
+	0x64, 0x73, 0x50, 0x65, 0x72, 0x4b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x10,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x61, 0x64, 0x73, 0x50, 0x65, 0x72, 0x4b, 0x65, 0x79,
+	0x12, 0x2c, 0x0a, 0x11, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x43, 0x61, 0x63, 0x68, 0x65, 0x48, 0x69,
+	0x74, 0x52, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x43, 0x61, 0x63, 0x68, 0x65, 0x48, 0x69, 0x74, 0x52, 0x61, 0x74, 0x65, 0x12, 0x28,
+	0x0a, 0x0f, 0x6d, 0x65, 0x6d, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x48, 0x69, 0x74, 0x52, 0x61, 0x74,
+	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x6d, 0x65, 0x6d, 0x74, 0x61, 0x62, 0x6c,
+	0x65, 0x48, 0x69, 0x74, 0x52, 0x61, 0x74, 0x65, 0x12, 0x28, 0x0a, 0x0f, 0x62, 0x6c, 0x6f, 0x6f,
+	0x6d, 0x55, 0x73, 0x65, 0x66, 0x75, 0x6c, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0f, 0x62, 0x6c, 0x6f, 0x6f, 0x6d, 0x55, 0x73, 0x65, 0x66, 0x75, 0x6c, 0x6e, 0x65,
+	0x73, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x22, 0x30, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x4b, 0x65, 0x79, 0x48, 0x65,
+	0x61, 0x74, 0x6d, 0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07,
+	0x6d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x6d,
+	0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x22, 0xdf, 0x01, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x4b, 0x65,
+	0x79, 0x48, 0x65, 0x61, 0x74, 0x6d, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x36,
+	0x0a, 0x07, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x4b,
+	0x65, 0x79, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x07, 0x62,
+	0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x3e, 0x0a, 0x09, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76,
+	0x61, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x64, 0x6b, 0x76, 0x2e,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x4b, 0x65, 0x79, 0x48, 0x65, 0x61, 0x74,
+	0x6d, 0x61, 0x70, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x52, 0x09, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x76, 0x61, 0x6c, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x45, 0x76, 0x65, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x73, 0x61, 0x6d,
+	0x70, 0x6c, 0x65, 0x45, 0x76, 0x65, 0x72, 0x79, 0x22, 0x44, 0x0a, 0x0e, 0x4b, 0x65, 0x79, 0x52,
+	0x61, 0x6e, 0x67, 0x65, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x4b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x64, 0x4b, 0x65, 0x79,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x65, 0x6e, 0x64, 0x4b, 0x65, 0x79, 0x22, 0xa8,
+	0x01, 0x0a, 0x12, 0x4b, 0x65, 0x79, 0x48, 0x65, 0x61, 0x74, 0x6d, 0x61, 0x70, 0x49, 0x6e, 0x74,
+	0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54,
+	0x69, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x61, 0x64, 0x52, 0x61, 0x74, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x01, 0x52, 0x09, 0x72, 0x65, 0x61, 0x64, 0x52, 0x61, 0x74, 0x65,
+	0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x77, 0x72, 0x69, 0x74, 0x65, 0x52, 0x61, 0x74, 0x65, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x01, 0x52, 0x0a, 0x77, 0x72, 0x69, 0x74, 0x65, 0x52, 0x61, 0x74, 0x65,
+	0x73, 0x12, 0x36, 0x0a, 0x07, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70,
+	0x62, 0x2e, 0x4b, 0x65, 0x79, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74,
+	0x52, 0x07, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x22, 0x81, 0x01, 0x0a, 0x16, 0x47, 0x65,
+	0x74, 0x4b, 0x65, 0x79, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x39, 0x0a, 0x0b, 0x6b, 0x65, 0x79, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x4b, 0x65, 0x79, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x52, 0x0b, 0x6b, 0x65, 0x79, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x22, 0x79, 0x0a,
+	0x09, 0x4b, 0x65, 0x79, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61,
+	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e,
+	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x34, 0x0a, 0x08, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x70, 0x62, 0x2e, 0x4b, 0x65, 0x79, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x08,
+	0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x52, 0x0a, 0x0a, 0x4b, 0x65, 0x79, 0x53,
+	0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x30, 0x0a, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x4b, 0x65, 0x79, 0x53, 0x65, 0x67, 0x6d, 0x65,
+	0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x66, 0x0a, 0x10,
+	0x4c, 0x65, 0x67, 0x61, 0x6c, 0x48, 0x6f, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1c, 0x0a, 0x09, 0x6b, 0x65, 0x79, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x09, 0x6b, 0x65, 0x79, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x1c,
+	0x0a, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x22, 0x68, 0x0a, 0x16, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x65, 0x67, 0x61,
+	0x6c, 0x48, 0x6f, 0x6c, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x20, 0x0a, 0x0b,
+	0x6b, 0x65, 0x79, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0c, 0x52, 0x0b, 0x6b, 0x65, 0x79, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x65, 0x73, 0x22, 0x66,
+	0x0a, 0x14, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x72, 0x12, 0x16,
+	0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x86, 0x01, 0x0a, 0x16, 0x4d, 0x69, 0x6e, 0x74, 0x41,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6b, 0x65, 0x79, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x6b, 0x65, 0x79, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12,
+	0x18, 0x0a, 0x07, 0x74, 0x74, 0x6c, 0x53, 0x65, 0x63, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x07, 0x74, 0x74, 0x6c, 0x53, 0x65, 0x63, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22,
+	0x62, 0x0a, 0x1a, 0x47, 0x65, 0x74, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x22, 0x79, 0x0a, 0x17, 0x4d, 0x69, 0x6e, 0x74, 0x41, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x54, 0x53, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x54, 0x53, 0x22, 0xe7,
+	0x01, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x06, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x40, 0x0a, 0x05, 0x6e, 0x6f,
+	0x64, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x64, 0x6b, 0x76, 0x2e,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x6f, 0x64,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x1a, 0x4a, 0x0a, 0x0a,
+	0x4e, 0x6f, 0x64, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x26, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x6f,
+	0x64, 0x65, 0x6c, 0x73, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x2a, 0x0a, 0x0e, 0x41, 0x64, 0x64, 0x4e,
+	0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x6f,
+	0x64, 0x65, 0x55, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x6f, 0x64,
+	0x65, 0x55, 0x72, 0x6c, 0x22, 0x2d, 0x0a, 0x11, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4e, 0x6f,
+	0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x6f, 0x64,
+	0x65, 0x55, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x6f, 0x64, 0x65,
+	0x55, 0x72, 0x6c, 0x22, 0x6d, 0x0a, 0x13, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x38, 0x0a, 0x0a, 0x72, 0x65,
+	0x67, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x52, 0x65,
+	0x67, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0a, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e,
+	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x22, 0x92, 0x01, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65,
+	0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x04,
+	0x64, 0x63, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x64, 0x63,
+	0x49, 0x44, 0x88, 0x01, 0x01, 0x12, 0x1f, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62,
+	0x61, 0x73, 0x65, 0x88, 0x01, 0x01, 0x12, 0x1d, 0x0a, 0x07, 0x76, 0x42, 0x75, 0x63, 0x6b, 0x65,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x02, 0x52, 0x07, 0x76, 0x42, 0x75, 0x63, 0x6b,
+	0x65, 0x74, 0x88, 0x01, 0x01, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x64, 0x63, 0x49, 0x44, 0x42, 0x0b,
+	0x0a, 0x09, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x42, 0x0a, 0x0a, 0x08, 0x5f,
+	0x76, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x22, 0x54, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x43, 0x6c,
+	0x75, 0x73, 0x74, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x3a, 0x0a, 0x0b, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f,
+	0x52, 0x0b, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x22, 0x9c, 0x01,
+	0x0a, 0x14, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61,
+	0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61,
+	0x73, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x50, 0x61, 0x74, 0x68,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x50, 0x61,
+	0x74, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x71, 0x75, 0x69, 0x65, 0x73, 0x63, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x07, 0x71, 0x75, 0x69, 0x65, 0x73, 0x63, 0x65, 0x12, 0x2e, 0x0a, 0x12,
+	0x71, 0x75, 0x69, 0x65, 0x73, 0x63, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65,
+	0x63, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x71, 0x75, 0x69, 0x65, 0x73, 0x63,
+	0x65, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x73, 0x22, 0x94, 0x02, 0x0a,
+	0x15, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74,
+	0x50, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x61, 0x6e, 0x69,
+	0x66, 0x65, 0x73, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x31, 0x0a, 0x06, 0x73, 0x68, 0x61, 0x72,
+	0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x64, 0x42, 0x61, 0x63,
+	0x6b, 0x75, 0x70, 0x52, 0x06, 0x73, 0x68, 0x61, 0x72, 0x64, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6d,
+	0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b,
+	0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x64,
+	0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64,
+	0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x71, 0x75, 0x69, 0x65, 0x73,
+	0x63, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x71, 0x75, 0x69, 0x65, 0x73,
+	0x63, 0x65, 0x64, 0x22, 0xdf, 0x01, 0x0a, 0x0b, 0x53, 0x68, 0x61, 0x72, 0x64, 0x42, 0x61, 0x63,
+	0x6b, 0x75, 0x70, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x20, 0x0a,
+	0x0b, 0x6e, 0x6f, 0x64, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x6e, 0x6f, 0x64, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12,
+	0x1e, 0x0a, 0x0a, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x50, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x50, 0x61, 0x74, 0x68, 0x12,
+	0x22, 0x0a, 0x0c, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41,
+	0x74, 0x12, 0x32, 0x0a, 0x14, 0x71, 0x75, 0x69, 0x65, 0x73, 0x63, 0x65, 0x64, 0x43, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x14, 0x71, 0x75, 0x69, 0x65, 0x73, 0x63, 0x65, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x3b, 0x0a, 0x15, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65,
+	0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22,
+	0x0a, 0x0c, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x50, 0x61, 0x74, 0x68, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x50, 0x61,
+	0x74, 0x68, 0x22, 0x7a, 0x0a, 0x16, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x43, 0x6c, 0x75,
+	0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64,
+	0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x32, 0x0a, 0x06, 0x73, 0x68,
+	0x61, 0x72, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x64, 0x6b, 0x76,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x64, 0x52,
+	0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x06, 0x73, 0x68, 0x61, 0x72, 0x64, 0x73, 0x22, 0xc4,
+	0x01, 0x0a, 0x0c, 0x53, 0x68, 0x61, 0x72, 0x64, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x76, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x76, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x6e, 0x6f, 0x64,
+	0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x6e, 0x6f, 0x64, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0c, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12,
+	0x26, 0x0a, 0x0e, 0x73, 0x6c, 0x61, 0x76, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x73, 0x6c, 0x61, 0x76, 0x65, 0x41, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x11, 0x66, 0x6f, 0x6c, 0x6c, 0x6f,
+	0x77, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x11, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x65, 0x73, 0x22, 0x81, 0x03, 0x0a, 0x0a, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e,
+	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x63, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x64, 0x63, 0x49, 0x44, 0x12, 0x20, 0x0a, 0x0b, 0x6e, 0x6f, 0x64, 0x65,
+	0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6e,
+	0x6f, 0x64, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x61,
+	0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x61,
+	0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x42, 0x75, 0x63, 0x6b, 0x65,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74,
+	0x12, 0x32, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x1a, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e,
+	0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x23, 0x0a, 0x0a, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x48, 0x6f,
+	0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0a, 0x6d, 0x61, 0x73, 0x74,
+	0x65, 0x72, 0x48, 0x6f, 0x73, 0x74, 0x88, 0x01, 0x01, 0x12, 0x2d, 0x0a, 0x0f, 0x6e, 0x65, 0x78,
+	0x75, 0x73, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x55, 0x72, 0x6c, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x01, 0x52, 0x0f, 0x6e, 0x65, 0x78, 0x75, 0x73, 0x43, 0x6c, 0x75, 0x73, 0x74,
+	0x65, 0x72, 0x55, 0x72, 0x6c, 0x88, 0x01, 0x01, 0x12, 0x49, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x73,
+	0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x54, 0x61, 0x67, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1c, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e,
+	0x43, 0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x54, 0x61, 0x67, 0x48, 0x02,
+	0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x54, 0x61, 0x67,
+	0x88, 0x01, 0x01, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x48, 0x6f,
+	0x73, 0x74, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x6e, 0x65, 0x78, 0x75, 0x73, 0x43, 0x6c, 0x75, 0x73,
+	0x74, 0x65, 0x72, 0x55, 0x72, 0x6c, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x69,
+	0x73, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x54, 0x61, 0x67, 0x22, 0xac, 0x01, 0x0a, 0x0e, 0x43, 0x6f,
+	0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x54, 0x61, 0x67, 0x12, 0x1e, 0x0a, 0x0a,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x22, 0x0a, 0x0c,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0c, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x12, 0x1c, 0x0a, 0x09,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69,
+	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73,
+	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x2a, 0x23, 0x0a, 0x0c, 0x45, 0x78, 0x70, 0x6f,
+	0x72, 0x74, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x07, 0x0a, 0x03, 0x53, 0x53, 0x54, 0x10,
+	0x00, 0x12, 0x0a, 0x0a, 0x06, 0x42, 0x49, 0x4e, 0x41, 0x52, 0x59, 0x10, 0x01, 0x2a, 0x3a, 0x0a,
+	0x11, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x50, 0x68, 0x61,
+	0x73, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x4f, 0x50, 0x59, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12,
+	0x0a, 0x0a, 0x06, 0x43, 0x4f, 0x50, 0x49, 0x45, 0x44, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x53,
+	0x57, 0x45, 0x45, 0x50, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x2a, 0x4b, 0x0a, 0x0e, 0x4b, 0x65, 0x79,
+	0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x0e, 0x53,
+	0x54, 0x52, 0x49, 0x4e, 0x47, 0x5f, 0x53, 0x45, 0x47, 0x4d, 0x45, 0x4e, 0x54, 0x10, 0x00, 0x12,
+	0x12, 0x0a, 0x0e, 0x55, 0x49, 0x4e, 0x54, 0x36, 0x34, 0x5f, 0x53, 0x45, 0x47, 0x4d, 0x45, 0x4e,
+	0x54, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x49, 0x4e, 0x54, 0x36, 0x34, 0x5f, 0x53, 0x45, 0x47,
+	0x4d, 0x45, 0x4e, 0x54, 0x10, 0x02, 0x2a, 0x68, 0x0a, 0x0c, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0c, 0x0a, 0x08, 0x49, 0x4e, 0x41, 0x43, 0x54, 0x49,
+	0x56, 0x45, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x4c, 0x45, 0x41, 0x44, 0x45, 0x52, 0x10, 0x01,
+	0x12, 0x14, 0x0a, 0x10, 0x50, 0x52, 0x49, 0x4d, 0x41, 0x52, 0x59, 0x5f, 0x46, 0x4f, 0x4c, 0x4c,
+	0x4f, 0x57, 0x45, 0x52, 0x10, 0x02, 0x12, 0x16, 0x0a, 0x12, 0x53, 0x45, 0x43, 0x4f, 0x4e, 0x44,
+	0x41, 0x52, 0x59, 0x5f, 0x46, 0x4f, 0x4c, 0x4c, 0x4f, 0x57, 0x45, 0x52, 0x10, 0x03, 0x12, 0x10,
+	0x0a, 0x0c, 0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x5f, 0x53, 0x4c, 0x41, 0x56, 0x45, 0x10, 0x04,
+	0x32, 0xc7, 0x07, 0x0a, 0x0e, 0x44, 0x4b, 0x56, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x4f, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x73, 0x12, 0x1f, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62,
+	0x2e, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x20, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70,
+	0x62, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x54, 0x0a, 0x0d, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x1f, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x5e, 0x0a, 0x12, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x12, 0x27, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x64, 0x6b, 0x76, 0x2e,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f,
+	0x69, 0x6e, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x30, 0x01, 0x12, 0x39, 0x0a, 0x0a, 0x41, 0x64,
+	0x64, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x12, 0x15, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x1a,
+	0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x3c, 0x0a, 0x0d, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52,
+	0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x12, 0x15, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x1a, 0x14, 0x2e,
+	0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x52, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63,
+	0x61, 0x73, 0x12, 0x20, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70,
+	0x62, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x07, 0x50, 0x72, 0x6f, 0x6d, 0x6f,
+	0x74, 0x65, 0x12, 0x1c, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70,
+	0x62, 0x2e, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1d, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e,
+	0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x39, 0x0a, 0x05, 0x46, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x1a, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x46, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x50, 0x0a, 0x14, 0x52, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c,
+	0x61, 0x67, 0x12, 0x22, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70,
+	0x62, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x61, 0x67,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x1a, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x54, 0x0a, 0x11,
+	0x47, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x61,
+	0x67, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x27, 0x2e, 0x64, 0x6b, 0x76, 0x2e,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x61, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x5e, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x73, 0x12, 0x24, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x69, 0x67,
+	0x65, 0x73, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x64, 0x6b,
+	0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x61,
+	0x6e, 0x67, 0x65, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x56, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x44, 0x69, 0x76, 0x65, 0x72, 0x67, 0x65,
+	0x6e, 0x74, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x1a, 0x28, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e,
+	0x47, 0x65, 0x74, 0x44, 0x69, 0x76, 0x65, 0x72, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x61, 0x6e, 0x67,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0xdb, 0x03, 0x0a, 0x10, 0x44,
+	0x4b, 0x56, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x12,
+	0x3b, 0x0a, 0x06, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x12, 0x1b, 0x2e, 0x64, 0x6b, 0x76, 0x2e,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x3d, 0x0a, 0x07,
+	0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x1c, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x53, 0x0a, 0x12, 0x50,
+	0x6f, 0x69, 0x6e, 0x74, 0x49, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x12, 0x27, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62,
+	0x2e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x49, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x64, 0x6b, 0x76,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x64, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x4d, 0x61, 0x6e,
+	0x69, 0x66, 0x65, 0x73, 0x74, 0x12, 0x26, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x4d, 0x61,
+	0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e,
+	0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74,
+	0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x52, 0x0a, 0x0b, 0x50, 0x61, 0x75, 0x73, 0x65, 0x57,
+	0x72, 0x69, 0x74, 0x65, 0x73, 0x12, 0x20, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x70, 0x62, 0x2e, 0x50, 0x61, 0x75, 0x73, 0x65, 0x57, 0x72, 0x69, 0x74, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x50, 0x61, 0x75, 0x73, 0x65, 0x57, 0x72, 0x69, 0x74,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0c, 0x52, 0x65,
+	0x73, 0x75, 0x6d, 0x65, 0x57, 0x72, 0x69, 0x74, 0x65, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x1a, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70,
+	0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x32, 0xfd, 0x01, 0x0a, 0x0b, 0x44, 0x4b, 0x56,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x4d, 0x0a, 0x0f, 0x50, 0x75, 0x62, 0x6c,
+	0x69, 0x73, 0x68, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x24, 0x2e, 0x64, 0x6b,
+	0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69,
+	0x73, 0x68, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62,
+	0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x51, 0x0a, 0x11, 0x55, 0x6e, 0x70, 0x75, 0x62,
+	0x6c, 0x69, 0x73, 0x68, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x26, 0x2e, 0x64,
+	0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x55, 0x6e, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x73, 0x68, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x4c, 0x0a, 0x0d, 0x4c, 0x69,
+	0x73, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x1a, 0x23, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0xb3, 0x0e, 0x0a, 0x08, 0x44, 0x4b, 0x56,
+	0x41, 0x64, 0x6d, 0x69, 0x6e, 0x12, 0x47, 0x0a, 0x0c, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74,
+	0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x21, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x52, 0x61, 0x6e, 0x67,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x46,
+	0x0a, 0x0e, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x4c, 0x65, 0x67, 0x61, 0x6c, 0x48, 0x6f, 0x6c, 0x64,
+	0x12, 0x1e, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e,
+	0x4c, 0x65, 0x67, 0x61, 0x6c, 0x48, 0x6f, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x45, 0x0a, 0x0d, 0x4c, 0x69, 0x66, 0x74, 0x4c, 0x65,
+	0x67, 0x61, 0x6c, 0x48, 0x6f, 0x6c, 0x64, 0x12, 0x1e, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x4c, 0x65, 0x67, 0x61, 0x6c, 0x48, 0x6f, 0x6c, 0x64,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x4e, 0x0a,
+	0x0e, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x65, 0x67, 0x61, 0x6c, 0x48, 0x6f, 0x6c, 0x64, 0x73, 0x12,
+	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x24, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x65, 0x67, 0x61, 0x6c,
+	0x48, 0x6f, 0x6c, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a,
+	0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x22,
+	0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x53, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70,
+	0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x5e, 0x0a, 0x0f, 0x4d, 0x69, 0x6e, 0x74,
+	0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x24, 0x2e, 0x64, 0x6b,
+	0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x4d, 0x69, 0x6e, 0x74, 0x41,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x25, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62,
+	0x2e, 0x4d, 0x69, 0x6e, 0x74, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x56, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x45,
+	0x66, 0x66, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x16,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x28, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x69,
+	0x76, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x55, 0x0a, 0x0c, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x53, 0x69, 0x7a, 0x65,
+	0x12, 0x21, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e,
+	0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x70, 0x62, 0x2e, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6a, 0x0a, 0x17, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x52, 0x65, 0x61, 0x64, 0x41, 0x6d, 0x70, 0x6c, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x26, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70,
+	0x62, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x41, 0x6d, 0x70, 0x6c, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x64, 0x6b, 0x76,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x41, 0x6d,
+	0x70, 0x6c, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x6a, 0x0a, 0x13, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x45, 0x6e, 0x63,
+	0x72, 0x79, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x65, 0x79, 0x12, 0x28, 0x2e, 0x64, 0x6b, 0x76,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x65,
+	0x45, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x70, 0x62, 0x2e, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x62, 0x0a, 0x0f, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x54, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x4b,
+	0x65, 0x79, 0x12, 0x24, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70,
+	0x62, 0x2e, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x54, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x4b, 0x65,
+	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x45, 0x6e,
+	0x63, 0x72, 0x79, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x0c, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x65, 0x6e,
+	0x61, 0x6e, 0x74, 0x12, 0x21, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x4e, 0x0a, 0x0e,
+	0x47, 0x65, 0x74, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x16,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x24, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x09,
+	0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x53, 0x53, 0x54, 0x12, 0x1e, 0x2e, 0x64, 0x6b, 0x76, 0x2e,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x53,
+	0x53, 0x54, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x43, 0x0a, 0x06, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x1b, 0x2e, 0x64, 0x6b, 0x76, 0x2e,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5e, 0x0a, 0x16, 0x4c, 0x69, 0x73, 0x74, 0x51, 0x75, 0x61, 0x72,
+	0x61, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x16,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x2c, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x51, 0x75, 0x61, 0x72, 0x61, 0x6e,
+	0x74, 0x69, 0x6e, 0x65, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x12, 0x53, 0x65, 0x74, 0x4d, 0x65, 0x6d, 0x74, 0x61,
+	0x62, 0x6c, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1d, 0x2e, 0x64, 0x6b, 0x76,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x4d, 0x65, 0x6d, 0x74, 0x61, 0x62,
+	0x6c, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x4c, 0x0a, 0x11, 0x42, 0x65, 0x67, 0x69, 0x6e, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x65,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x70, 0x62, 0x2e, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x4f, 0x0a,
+	0x14, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52,
+	0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x70, 0x62, 0x2e, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x6e, 0x61, 0x6d,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x54,
+	0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x6e, 0x61,
+	0x6d, 0x65, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x27, 0x2e, 0x64, 0x6b,
+	0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50,
+	0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x58, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x4b, 0x65, 0x79, 0x48, 0x65,
+	0x61, 0x74, 0x6d, 0x61, 0x70, 0x12, 0x22, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x4b, 0x65, 0x79, 0x48, 0x65, 0x61, 0x74, 0x6d,
+	0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x64, 0x6b, 0x76, 0x2e,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x4b, 0x65, 0x79, 0x48,
+	0x65, 0x61, 0x74, 0x6d, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e,
+	0x0a, 0x0e, 0x47, 0x65, 0x74, 0x4b, 0x65, 0x79, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73,
+	0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x24, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x4b, 0x65, 0x79, 0x50, 0x6f,
+	0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0xd6,
+	0x01, 0x0a, 0x0a, 0x44, 0x4b, 0x56, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x12, 0x3d, 0x0a,
+	0x07, 0x41, 0x64, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x1c, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x41, 0x64, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x43, 0x0a, 0x0a,
+	0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x1f, 0x2e, 0x64, 0x6b, 0x76,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65,
+	0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x64, 0x6b,
+	0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x44, 0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x16,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1f, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0xeb, 0x02, 0x0a, 0x0c, 0x44, 0x4b, 0x56, 0x44,
+	0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x12, 0x47, 0x0a, 0x0c, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x21, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x64, 0x6b,
+	0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x5b, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x49,
+	0x6e, 0x66, 0x6f, 0x12, 0x23, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x49, 0x6e, 0x66,
+	0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6c, 0x75, 0x73, 0x74,
+	0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x58,
+	0x0a, 0x0d, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x12,
+	0x22, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x42,
+	0x61, 0x63, 0x6b, 0x75, 0x70, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x70, 0x62, 0x2e, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5b, 0x0a, 0x0e, 0x52, 0x65, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x12, 0x23, 0x2e, 0x64, 0x6b, 0x76,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x24, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x52,
+	0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0x51, 0x0a, 0x10, 0x44, 0x4b, 0x56, 0x44, 0x69, 0x73, 0x63,
+	0x6f, 0x76, 0x65, 0x72, 0x79, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x3d, 0x0a, 0x09, 0x47, 0x65, 0x74,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x18,
+	0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x52, 0x65,
+	0x67, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x66, 0x6c, 0x69, 0x70, 0x6b, 0x61, 0x72, 0x74, 0x2d,
+	0x69, 0x6e, 0x63, 0x75, 0x62, 0x61, 0x74, 0x6f, 0x72, 0x2f, 0x64, 0x6b, 0x76, 0x2f, 0x70, 0x6b,
+	0x67, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_pkg_serverpb_admin_proto_rawDescOnce sync.Once
+	file_pkg_serverpb_admin_proto_rawDescData = file_pkg_serverpb_admin_proto_rawDesc
+)
+
+func file_pkg_serverpb_admin_proto_rawDescGZIP() []byte {
+	file_pkg_serverpb_admin_proto_rawDescOnce.Do(func() {
+		file_pkg_serverpb_admin_proto_rawDescData = protoimpl.X.CompressGZIP(file_pkg_serverpb_admin_proto_rawDescData)
+	})
+	return file_pkg_serverpb_admin_proto_rawDescData
+}
+
+var file_pkg_serverpb_admin_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_pkg_serverpb_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 78)
+var file_pkg_serverpb_admin_proto_goTypes = []interface{}{
+	(ExportFormat)(0),                      // 0: dkv.serverpb.ExportFormat
+	(PrefixRenamePhase)(0),                 // 1: dkv.serverpb.PrefixRenamePhase
+	(KeySegmentType)(0),                    // 2: dkv.serverpb.KeySegmentType
+	(RegionStatus)(0),                      // 3: dkv.serverpb.RegionStatus
+	(TrxnRecord_TrxnType)(0),               // 4: dkv.serverpb.TrxnRecord.TrxnType
+	(*GetRangeDigestsRequest)(nil),         // 5: dkv.serverpb.GetRangeDigestsRequest
+	(*RangeDigest)(nil),                    // 6: dkv.serverpb.RangeDigest
+	(*GetRangeDigestsResponse)(nil),        // 7: dkv.serverpb.GetRangeDigestsResponse
+	(*DivergentRange)(nil),                 // 8: dkv.serverpb.DivergentRange
+	(*GetDivergentRangesResponse)(nil),     // 9: dkv.serverpb.GetDivergentRangesResponse
+	(*ReplicationLagReport)(nil),           // 10: dkv.serverpb.ReplicationLagReport
+	(*ReplicaLag)(nil),                     // 11: dkv.serverpb.ReplicaLag
+	(*GetReplicationLagResponse)(nil),      // 12: dkv.serverpb.GetReplicationLagResponse
+	(*PromoteRequest)(nil),                 // 13: dkv.serverpb.PromoteRequest
+	(*PromoteResponse)(nil),                // 14: dkv.serverpb.PromoteResponse
+	(*FenceRequest)(nil),                   // 15: dkv.serverpb.FenceRequest
+	(*GetReplicasRequest)(nil),             // 16: dkv.serverpb.GetReplicasRequest
+	(*GetReplicasResponse)(nil),            // 17: dkv.serverpb.GetReplicasResponse
+	(*Replica)(nil),                        // 18: dkv.serverpb.Replica
+	(*GetChangesRequest)(nil),              // 19: dkv.serverpb.GetChangesRequest
+	(*GetChangesResponse)(nil),             // 20: dkv.serverpb.GetChangesResponse
+	(*TransferCheckpointRequest)(nil),      // 21: dkv.serverpb.TransferCheckpointRequest
+	(*CheckpointChunk)(nil),                // 22: dkv.serverpb.CheckpointChunk
+	(*ChangeRecord)(nil),                   // 23: dkv.serverpb.ChangeRecord
+	(*TrxnRecord)(nil),                     // 24: dkv.serverpb.TrxnRecord
+	(*PauseWritesRequest)(nil),             // 25: dkv.serverpb.PauseWritesRequest
+	(*PauseWritesResponse)(nil),            // 26: dkv.serverpb.PauseWritesResponse
+	(*BackupRequest)(nil),                  // 27: dkv.serverpb.BackupRequest
+	(*RestoreRequest)(nil),                 // 28: dkv.serverpb.RestoreRequest
+	(*PointInTimeRestoreRequest)(nil),      // 29: dkv.serverpb.PointInTimeRestoreRequest
+	(*GetBackupManifestRequest)(nil),       // 30: dkv.serverpb.GetBackupManifestRequest
+	(*GetBackupManifestResponse)(nil),      // 31: dkv.serverpb.GetBackupManifestResponse
+	(*PublishSnapshotRequest)(nil),         // 32: dkv.serverpb.PublishSnapshotRequest
+	(*UnpublishSnapshotRequest)(nil),       // 33: dkv.serverpb.UnpublishSnapshotRequest
+	(*ListSnapshotsResponse)(nil),          // 34: dkv.serverpb.ListSnapshotsResponse
+	(*IngestSSTRequest)(nil),               // 35: dkv.serverpb.IngestSSTRequest
+	(*ExportRequest)(nil),                  // 36: dkv.serverpb.ExportRequest
+	(*ExportResponse)(nil),                 // 37: dkv.serverpb.ExportResponse
+	(*QuarantinedRecord)(nil),              // 38: dkv.serverpb.QuarantinedRecord
+	(*ListQuarantinedRecordsResponse)(nil), // 39: dkv.serverpb.ListQuarantinedRecordsResponse
+	(*PrefixRenameRequest)(nil),            // 40: dkv.serverpb.PrefixRenameRequest
+	(*PrefixRename)(nil),                   // 41: dkv.serverpb.PrefixRename
+	(*ListPrefixRenamesResponse)(nil),      // 42: dkv.serverpb.ListPrefixRenamesResponse
+	(*MemtableOptions)(nil),                // 43: dkv.serverpb.MemtableOptions
+	(*GetEngineStatsResponse)(nil),         // 44: dkv.serverpb.GetEngineStatsResponse
+	(*NamespaceCacheStats)(nil),            // 45: dkv.serverpb.NamespaceCacheStats
+	(*RotateEncryptionKeyRequest)(nil),     // 46: dkv.serverpb.RotateEncryptionKeyRequest
+	(*RotateEncryptionKeyResponse)(nil),    // 47: dkv.serverpb.RotateEncryptionKeyResponse
+	(*RotateTenantKeyRequest)(nil),         // 48: dkv.serverpb.RotateTenantKeyRequest
+	(*DeleteTenantRequest)(nil),            // 49: dkv.serverpb.DeleteTenantRequest
+	(*CompactRangeRequest)(nil),            // 50: dkv.serverpb.CompactRangeRequest
+	(*EstimateSizeRequest)(nil),            // 51: dkv.serverpb.EstimateSizeRequest
+	(*EstimateSizeResponse)(nil),           // 52: dkv.serverpb.EstimateSizeResponse
+	(*ReadAmplificationRequest)(nil),       // 53: dkv.serverpb.ReadAmplificationRequest
+	(*ReadAmplificationResponse)(nil),      // 54: dkv.serverpb.ReadAmplificationResponse
+	(*GetKeyHeatmapRequest)(nil),           // 55: dkv.serverpb.GetKeyHeatmapRequest
+	(*GetKeyHeatmapResponse)(nil),          // 56: dkv.serverpb.GetKeyHeatmapResponse
+	(*KeyRangeBucket)(nil),                 // 57: dkv.serverpb.KeyRangeBucket
+	(*KeyHeatmapInterval)(nil),             // 58: dkv.serverpb.KeyHeatmapInterval
+	(*GetKeyPoliciesResponse)(nil),         // 59: dkv.serverpb.GetKeyPoliciesResponse
+	(*KeyPolicy)(nil),                      // 60: dkv.serverpb.KeyPolicy
+	(*KeySegment)(nil),                     // 61: dkv.serverpb.KeySegment
+	(*LegalHoldRequest)(nil),               // 62: dkv.serverpb.LegalHoldRequest
+	(*ListLegalHoldsResponse)(nil),         // 63: dkv.serverpb.ListLegalHoldsResponse
+	(*DeleteSubjectRequest)(nil),           // 64: dkv.serverpb.DeleteSubjectRequest
+	(*MintAccessTokenRequest)(nil),         // 65: dkv.serverpb.MintAccessTokenRequest
+	(*GetEffectiveConfigResponse)(nil),     // 66: dkv.serverpb.GetEffectiveConfigResponse
+	(*MintAccessTokenResponse)(nil),        // 67: dkv.serverpb.MintAccessTokenResponse
+	(*ListNodesResponse)(nil),              // 68: dkv.serverpb.ListNodesResponse
+	(*AddNodeRequest)(nil),                 // 69: dkv.serverpb.AddNodeRequest
+	(*RemoveNodeRequest)(nil),              // 70: dkv.serverpb.RemoveNodeRequest
+	(*UpdateStatusRequest)(nil),            // 71: dkv.serverpb.UpdateStatusRequest
+	(*GetClusterInfoRequest)(nil),          // 72: dkv.serverpb.GetClusterInfoRequest
+	(*GetClusterInfoResponse)(nil),         // 73: dkv.serverpb.GetClusterInfoResponse
+	(*BackupClusterRequest)(nil),           // 74: dkv.serverpb.BackupClusterRequest
+	(*BackupClusterResponse)(nil),          // 75: dkv.serverpb.BackupClusterResponse
+	(*ShardBackup)(nil),                    // 76: dkv.serverpb.ShardBackup
+	(*RestoreClusterRequest)(nil),          // 77: dkv.serverpb.RestoreClusterRequest
+	(*RestoreClusterResponse)(nil),         // 78: dkv.serverpb.RestoreClusterResponse
+	(*ShardRestore)(nil),                   // 79: dkv.serverpb.ShardRestore
+	(*RegionInfo)(nil),                     // 80: dkv.serverpb.RegionInfo
+	(*ConsistencyTag)(nil),                 // 81: dkv.serverpb.ConsistencyTag
+	nil,                                    // 82: dkv.serverpb.ListNodesResponse.NodesEntry
+	(*Status)(nil),                         // 83: dkv.serverpb.Status
+	(*models.NodeInfo)(nil),                // 84: models.NodeInfo
+	(*emptypb.Empty)(nil),                  // 85: google.protobuf.Empty
+}
+var file_pkg_serverpb_admin_proto_depIdxs = []int32{
+	83,  // 0: dkv.serverpb.GetRangeDigestsResponse.status:type_name -> dkv.serverpb.Status
+	6,   // 1: dkv.serverpb.GetRangeDigestsResponse.digests:type_name -> dkv.serverpb.RangeDigest
+	83,  // 2: dkv.serverpb.GetDivergentRangesResponse.status:type_name -> dkv.serverpb.Status
+	8,   // 3: dkv.serverpb.GetDivergentRangesResponse.ranges:type_name -> dkv.serverpb.DivergentRange
+	83,  // 4: dkv.serverpb.GetReplicationLagResponse.status:type_name -> dkv.serverpb.Status
+	11,  // 5: dkv.serverpb.GetReplicationLagResponse.replicas:type_name -> dkv.serverpb.ReplicaLag
+	83,  // 6: dkv.serverpb.PromoteResponse.status:type_name -> dkv.serverpb.Status
+	18,  // 7: dkv.serverpb.GetReplicasResponse.replicas:type_name -> dkv.serverpb.Replica
+	83,  // 8: dkv.serverpb.GetChangesResponse.status:type_name -> dkv.serverpb.Status
+	23,  // 9: dkv.serverpb.GetChangesResponse.changes:type_name -> dkv.serverpb.ChangeRecord
+	83,  // 10: dkv.serverpb.CheckpointChunk.status:type_name -> dkv.serverpb.Status
+	24,  // 11: dkv.serverpb.ChangeRecord.trxns:type_name -> dkv.serverpb.TrxnRecord
+	4,   // 12: dkv.serverpb.TrxnRecord.type:type_name -> dkv.serverpb.TrxnRecord.TrxnType
+	83,  // 13: dkv.serverpb.PauseWritesResponse.status:type_name -> dkv.serverpb.Status
+	83,  // 14: dkv.serverpb.GetBackupManifestResponse.status:type_name -> dkv.serverpb.Status
+	83,  // 15: dkv.serverpb.ListSnapshotsResponse.status:type_name -> dkv.serverpb.Status
+	0,   // 16: dkv.serverpb.ExportRequest.format:type_name -> dkv.serverpb.ExportFormat
+	83,  // 17: dkv.serverpb.ExportResponse.status:type_name -> dkv.serverpb.Status
+	83,  // 18: dkv.serverpb.ListQuarantinedRecordsResponse.status:type_name -> dkv.serverpb.Status
+	38,  // 19: dkv.serverpb.ListQuarantinedRecordsResponse.records:type_name -> dkv.serverpb.QuarantinedRecord
+	1,   // 20: dkv.serverpb.PrefixRename.phase:type_name -> dkv.serverpb.PrefixRenamePhase
+	83,  // 21: dkv.serverpb.ListPrefixRenamesResponse.status:type_name -> dkv.serverpb.Status
+	41,  // 22: dkv.serverpb.ListPrefixRenamesResponse.renames:type_name -> dkv.serverpb.PrefixRename
+	83,  // 23: dkv.serverpb.GetEngineStatsResponse.status:type_name -> dkv.serverpb.Status
+	45,  // 24: dkv.serverpb.GetEngineStatsResponse.namespaceCacheStats:type_name -> dkv.serverpb.NamespaceCacheStats
+	83,  // 25: dkv.serverpb.RotateEncryptionKeyResponse.status:type_name -> dkv.serverpb.Status
+	83,  // 26: dkv.serverpb.EstimateSizeResponse.status:type_name -> dkv.serverpb.Status
+	83,  // 27: dkv.serverpb.ReadAmplificationResponse.status:type_name -> dkv.serverpb.Status
+	83,  // 28: dkv.serverpb.GetKeyHeatmapResponse.status:type_name -> dkv.serverpb.Status
+	57,  // 29: dkv.serverpb.GetKeyHeatmapResponse.buckets:type_name -> dkv.serverpb.KeyRangeBucket
+	58,  // 30: dkv.serverpb.GetKeyHeatmapResponse.intervals:type_name -> dkv.serverpb.KeyHeatmapInterval
+	57,  // 31: dkv.serverpb.KeyHeatmapInterval.buckets:type_name -> dkv.serverpb.KeyRangeBucket
+	83,  // 32: dkv.serverpb.GetKeyPoliciesResponse.status:type_name -> dkv.serverpb.Status
+	60,  // 33: dkv.serverpb.GetKeyPoliciesResponse.keyPolicies:type_name -> dkv.serverpb.KeyPolicy
+	61,  // 34: dkv.serverpb.KeyPolicy.segments:type_name -> dkv.serverpb.KeySegment
+	2,   // 35: dkv.serverpb.KeySegment.type:type_name -> dkv.serverpb.KeySegmentType
+	83,  // 36: dkv.serverpb.ListLegalHoldsResponse.status:type_name -> dkv.serverpb.Status
+	83,  // 37: dkv.serverpb.GetEffectiveConfigResponse.status:type_name -> dkv.serverpb.Status
+	83,  // 38: dkv.serverpb.MintAccessTokenResponse.status:type_name -> dkv.serverpb.Status
+	83,  // 39: dkv.serverpb.ListNodesResponse.status:type_name -> dkv.serverpb.Status
+	82,  // 40: dkv.serverpb.ListNodesResponse.nodes:type_name -> dkv.serverpb.ListNodesResponse.NodesEntry
+	80,  // 41: dkv.serverpb.UpdateStatusRequest.regionInfo:type_name -> dkv.serverpb.RegionInfo
+	80,  // 42: dkv.serverpb.GetClusterInfoResponse.regionInfos:type_name -> dkv.serverpb.RegionInfo
+	83,  // 43: dkv.serverpb.BackupClusterResponse.status:type_name -> dkv.serverpb.Status
+	76,  // 44: dkv.serverpb.BackupClusterResponse.shards:type_name -> dkv.serverpb.ShardBackup
+	83,  // 45: dkv.serverpb.RestoreClusterResponse.status:type_name -> dkv.serverpb.Status
+	79,  // 46: dkv.serverpb.RestoreClusterResponse.shards:type_name -> dkv.serverpb.ShardRestore
+	3,   // 47: dkv.serverpb.RegionInfo.status:type_name -> dkv.serverpb.RegionStatus
+	81,  // 48: dkv.serverpb.RegionInfo.consistencyTag:type_name -> dkv.serverpb.ConsistencyTag
+	84,  // 49: dkv.serverpb.ListNodesResponse.NodesEntry.value:type_name -> models.NodeInfo
+	19,  // 50: dkv.serverpb.DKVReplication.GetChanges:input_type -> dkv.serverpb.GetChangesRequest
+	19,  // 51: dkv.serverpb.DKVReplication.StreamChanges:input_type -> dkv.serverpb.GetChangesRequest
+	21,  // 52: dkv.serverpb.DKVReplication.TransferCheckpoint:input_type -> dkv.serverpb.TransferCheckpointRequest
+	18,  // 53: dkv.serverpb.DKVReplication.AddReplica:input_type -> dkv.serverpb.Replica
+	18,  // 54: dkv.serverpb.DKVReplication.RemoveReplica:input_type -> dkv.serverpb.Replica
+	16,  // 55: dkv.serverpb.DKVReplication.GetReplicas:input_type -> dkv.serverpb.GetReplicasRequest
+	13,  // 56: dkv.serverpb.DKVReplication.Promote:input_type -> dkv.serverpb.PromoteRequest
+	15,  // 57: dkv.serverpb.DKVReplication.Fence:input_type -> dkv.serverpb.FenceRequest
+	10,  // 58: dkv.serverpb.DKVReplication.ReportReplicationLag:input_type -> dkv.serverpb.ReplicationLagReport
+	85,  // 59: dkv.serverpb.DKVReplication.GetReplicationLag:input_type -> google.protobuf.Empty
+	5,   // 60: dkv.serverpb.DKVReplication.GetRangeDigests:input_type -> dkv.serverpb.GetRangeDigestsRequest
+	85,  // 61: dkv.serverpb.DKVReplication.GetDivergentRanges:input_type -> google.protobuf.Empty
+	27,  // 62: dkv.serverpb.DKVBackupRestore.Backup:input_type -> dkv.serverpb.BackupRequest
+	28,  // 63: dkv.serverpb.DKVBackupRestore.Restore:input_type -> dkv.serverpb.RestoreRequest
+	29,  // 64: dkv.serverpb.DKVBackupRestore.PointInTimeRestore:input_type -> dkv.serverpb.PointInTimeRestoreRequest
+	30,  // 65: dkv.serverpb.DKVBackupRestore.GetBackupManifest:input_type -> dkv.serverpb.GetBackupManifestRequest
+	25,  // 66: dkv.serverpb.DKVBackupRestore.PauseWrites:input_type -> dkv.serverpb.PauseWritesRequest
+	85,  // 67: dkv.serverpb.DKVBackupRestore.ResumeWrites:input_type -> google.protobuf.Empty
+	32,  // 68: dkv.serverpb.DKVSnapshot.PublishSnapshot:input_type -> dkv.serverpb.PublishSnapshotRequest
+	33,  // 69: dkv.serverpb.DKVSnapshot.UnpublishSnapshot:input_type -> dkv.serverpb.UnpublishSnapshotRequest
+	85,  // 70: dkv.serverpb.DKVSnapshot.ListSnapshots:input_type -> google.protobuf.Empty
+	50,  // 71: dkv.serverpb.DKVAdmin.CompactRange:input_type -> dkv.serverpb.CompactRangeRequest
+	62,  // 72: dkv.serverpb.DKVAdmin.PlaceLegalHold:input_type -> dkv.serverpb.LegalHoldRequest
+	62,  // 73: dkv.serverpb.DKVAdmin.LiftLegalHold:input_type -> dkv.serverpb.LegalHoldRequest
+	85,  // 74: dkv.serverpb.DKVAdmin.ListLegalHolds:input_type -> google.protobuf.Empty
+	64,  // 75: dkv.serverpb.DKVAdmin.DeleteSubject:input_type -> dkv.serverpb.DeleteSubjectRequest
+	65,  // 76: dkv.serverpb.DKVAdmin.MintAccessToken:input_type -> dkv.serverpb.MintAccessTokenRequest
+	85,  // 77: dkv.serverpb.DKVAdmin.GetEffectiveConfig:input_type -> google.protobuf.Empty
+	51,  // 78: dkv.serverpb.DKVAdmin.EstimateSize:input_type -> dkv.serverpb.EstimateSizeRequest
+	53,  // 79: dkv.serverpb.DKVAdmin.ReportReadAmplification:input_type -> dkv.serverpb.ReadAmplificationRequest
+	46,  // 80: dkv.serverpb.DKVAdmin.RotateEncryptionKey:input_type -> dkv.serverpb.RotateEncryptionKeyRequest
+	48,  // 81: dkv.serverpb.DKVAdmin.RotateTenantKey:input_type -> dkv.serverpb.RotateTenantKeyRequest
+	49,  // 82: dkv.serverpb.DKVAdmin.DeleteTenant:input_type -> dkv.serverpb.DeleteTenantRequest
+	85,  // 83: dkv.serverpb.DKVAdmin.GetEngineStats:input_type -> google.protobuf.Empty
+	35,  // 84: dkv.serverpb.DKVAdmin.IngestSST:input_type -> dkv.serverpb.IngestSSTRequest
+	36,  // 85: dkv.serverpb.DKVAdmin.Export:input_type -> dkv.serverpb.ExportRequest
+	85,  // 86: dkv.serverpb.DKVAdmin.ListQuarantinedRecords:input_type -> google.protobuf.Empty
+	43,  // 87: dkv.serverpb.DKVAdmin.SetMemtableOptions:input_type -> dkv.serverpb.MemtableOptions
+	40,  // 88: dkv.serverpb.DKVAdmin.BeginPrefixRename:input_type -> dkv.serverpb.PrefixRenameRequest
+	40,  // 89: dkv.serverpb.DKVAdmin.CompletePrefixRename:input_type -> dkv.serverpb.PrefixRenameRequest
+	85,  // 90: dkv.serverpb.DKVAdmin.ListPrefixRenames:input_type -> google.protobuf.Empty
+	55,  // 91: dkv.serverpb.DKVAdmin.GetKeyHeatmap:input_type -> dkv.serverpb.GetKeyHeatmapRequest
+	85,  // 92: dkv.serverpb.DKVAdmin.GetKeyPolicies:input_type -> google.protobuf.Empty
+	69,  // 93: dkv.serverpb.DKVCluster.AddNode:input_type -> dkv.serverpb.AddNodeRequest
+	70,  // 94: dkv.serverpb.DKVCluster.RemoveNode:input_type -> dkv.serverpb.RemoveNodeRequest
+	85,  // 95: dkv.serverpb.DKVCluster.ListNodes:input_type -> google.protobuf.Empty
+	71,  // 96: dkv.serverpb.DKVDiscovery.UpdateStatus:input_type -> dkv.serverpb.UpdateStatusRequest
+	72,  // 97: dkv.serverpb.DKVDiscovery.GetClusterInfo:input_type -> dkv.serverpb.GetClusterInfoRequest
+	74,  // 98: dkv.serverpb.DKVDiscovery.BackupCluster:input_type -> dkv.serverpb.BackupClusterRequest
+	77,  // 99: dkv.serverpb.DKVDiscovery.RestoreCluster:input_type -> dkv.serverpb.RestoreClusterRequest
+	85,  // 100: dkv.serverpb.DKVDiscoveryNode.GetStatus:input_type -> google.protobuf.Empty
+	20,  // 101: dkv.serverpb.DKVReplication.GetChanges:output_type -> dkv.serverpb.GetChangesResponse
+	20,  // 102: dkv.serverpb.DKVReplication.StreamChanges:output_type -> dkv.serverpb.GetChangesResponse
+	22,  // 103: dkv.serverpb.DKVReplication.TransferCheckpoint:output_type -> dkv.serverpb.CheckpointChunk
+	83,  // 104: dkv.serverpb.DKVReplication.AddReplica:output_type -> dkv.serverpb.Status
+	83,  // 105: dkv.serverpb.DKVReplication.RemoveReplica:output_type -> dkv.serverpb.Status
+	17,  // 106: dkv.serverpb.DKVReplication.GetReplicas:output_type -> dkv.serverpb.GetReplicasResponse
+	14,  // 107: dkv.serverpb.DKVReplication.Promote:output_type -> dkv.serverpb.PromoteResponse
+	83,  // 108: dkv.serverpb.DKVReplication.Fence:output_type -> dkv.serverpb.Status
+	83,  // 109: dkv.serverpb.DKVReplication.ReportReplicationLag:output_type -> dkv.serverpb.Status
+	12,  // 110: dkv.serverpb.DKVReplication.GetReplicationLag:output_type -> dkv.serverpb.GetReplicationLagResponse
+	7,   // 111: dkv.serverpb.DKVReplication.GetRangeDigests:output_type -> dkv.serverpb.GetRangeDigestsResponse
+	9,   // 112: dkv.serverpb.DKVReplication.GetDivergentRanges:output_type -> dkv.serverpb.GetDivergentRangesResponse
+	83,  // 113: dkv.serverpb.DKVBackupRestore.Backup:output_type -> dkv.serverpb.Status
+	83,  // 114: dkv.serverpb.DKVBackupRestore.Restore:output_type -> dkv.serverpb.Status
+	83,  // 115: dkv.serverpb.DKVBackupRestore.PointInTimeRestore:output_type -> dkv.serverpb.Status
+	31,  // 116: dkv.serverpb.DKVBackupRestore.GetBackupManifest:output_type -> dkv.serverpb.GetBackupManifestResponse
+	26,  // 117: dkv.serverpb.DKVBackupRestore.PauseWrites:output_type -> dkv.serverpb.PauseWritesResponse
+	83,  // 118: dkv.serverpb.DKVBackupRestore.ResumeWrites:output_type -> dkv.serverpb.Status
+	83,  // 119: dkv.serverpb.DKVSnapshot.PublishSnapshot:output_type -> dkv.serverpb.Status
+	83,  // 120: dkv.serverpb.DKVSnapshot.UnpublishSnapshot:output_type -> dkv.serverpb.Status
+	34,  // 121: dkv.serverpb.DKVSnapshot.ListSnapshots:output_type -> dkv.serverpb.ListSnapshotsResponse
+	83,  // 122: dkv.serverpb.DKVAdmin.CompactRange:output_type -> dkv.serverpb.Status
+	83,  // 123: dkv.serverpb.DKVAdmin.PlaceLegalHold:output_type -> dkv.serverpb.Status
+	83,  // 124: dkv.serverpb.DKVAdmin.LiftLegalHold:output_type -> dkv.serverpb.Status
+	63,  // 125: dkv.serverpb.DKVAdmin.ListLegalHolds:output_type -> dkv.serverpb.ListLegalHoldsResponse
+	83,  // 126: dkv.serverpb.DKVAdmin.DeleteSubject:output_type -> dkv.serverpb.Status
+	67,  // 127: dkv.serverpb.DKVAdmin.MintAccessToken:output_type -> dkv.serverpb.MintAccessTokenResponse
+	66,  // 128: dkv.serverpb.DKVAdmin.GetEffectiveConfig:output_type -> dkv.serverpb.GetEffectiveConfigResponse
+	52,  // 129: dkv.serverpb.DKVAdmin.EstimateSize:output_type -> dkv.serverpb.EstimateSizeResponse
+	54,  // 130: dkv.serverpb.DKVAdmin.ReportReadAmplification:output_type -> dkv.serverpb.ReadAmplificationResponse
+	47,  // 131: dkv.serverpb.DKVAdmin.RotateEncryptionKey:output_type -> dkv.serverpb.RotateEncryptionKeyResponse
+	47,  // 132: dkv.serverpb.DKVAdmin.RotateTenantKey:output_type -> dkv.serverpb.RotateEncryptionKeyResponse
+	83,  // 133: dkv.serverpb.DKVAdmin.DeleteTenant:output_type -> dkv.serverpb.Status
+	44,  // 134: dkv.serverpb.DKVAdmin.GetEngineStats:output_type -> dkv.serverpb.GetEngineStatsResponse
+	83,  // 135: dkv.serverpb.DKVAdmin.IngestSST:output_type -> dkv.serverpb.Status
+	37,  // 136: dkv.serverpb.DKVAdmin.Export:output_type -> dkv.serverpb.ExportResponse
+	39,  // 137: dkv.serverpb.DKVAdmin.ListQuarantinedRecords:output_type -> dkv.serverpb.ListQuarantinedRecordsResponse
+	83,  // 138: dkv.serverpb.DKVAdmin.SetMemtableOptions:output_type -> dkv.serverpb.Status
+	83,  // 139: dkv.serverpb.DKVAdmin.BeginPrefixRename:output_type -> dkv.serverpb.Status
+	83,  // 140: dkv.serverpb.DKVAdmin.CompletePrefixRename:output_type -> dkv.serverpb.Status
+	42,  // 141: dkv.serverpb.DKVAdmin.ListPrefixRenames:output_type -> dkv.serverpb.ListPrefixRenamesResponse
+	56,  // 142: dkv.serverpb.DKVAdmin.GetKeyHeatmap:output_type -> dkv.serverpb.GetKeyHeatmapResponse
+	59,  // 143: dkv.serverpb.DKVAdmin.GetKeyPolicies:output_type -> dkv.serverpb.GetKeyPoliciesResponse
+	83,  // 144: dkv.serverpb.DKVCluster.AddNode:output_type -> dkv.serverpb.Status
+	83,  // 145: dkv.serverpb.DKVCluster.RemoveNode:output_type -> dkv.serverpb.Status
+	68,  // 146: dkv.serverpb.DKVCluster.ListNodes:output_type -> dkv.serverpb.ListNodesResponse
+	83,  // 147: dkv.serverpb.DKVDiscovery.UpdateStatus:output_type -> dkv.serverpb.Status
+	73,  // 148: dkv.serverpb.DKVDiscovery.GetClusterInfo:output_type -> dkv.serverpb.GetClusterInfoResponse
+	75,  // 149: dkv.serverpb.DKVDiscovery.BackupCluster:output_type -> dkv.serverpb.BackupClusterResponse
+	78,  // 150: dkv.serverpb.DKVDiscovery.RestoreCluster:output_type -> dkv.serverpb.RestoreClusterResponse
+	80,  // 151: dkv.serverpb.DKVDiscoveryNode.GetStatus:output_type -> dkv.serverpb.RegionInfo
+	101, // [101:152] is the sub-list for method output_type
+	50,  // [50:101] is the sub-list for method input_type
+	50,  // [50:50] is the sub-list for extension type_name
+	50,  // [50:50] is the sub-list for extension extendee
+	0,   // [0:50] is the sub-list for field type_name
+}
+
+func init() { file_pkg_serverpb_admin_proto_init() }
+func file_pkg_serverpb_admin_proto_init() {
+	if File_pkg_serverpb_admin_proto != nil {
+		return
+	}
+	file_pkg_serverpb_api_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_pkg_serverpb_admin_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRangeDigestsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RangeDigest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRangeDigestsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DivergentRange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetDivergentRangesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplicationLagReport); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplicaLag); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetReplicationLagResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PromoteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PromoteResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FenceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetReplicasRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetReplicasResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Replica); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetChangesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetChangesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TransferCheckpointRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckpointChunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChangeRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TrxnRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PauseWritesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PauseWritesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BackupRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestoreRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PointInTimeRestoreRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBackupManifestRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBackupManifestResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PublishSnapshotRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnpublishSnapshotRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSnapshotsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IngestSSTRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QuarantinedRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListQuarantinedRecordsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrefixRenameRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrefixRename); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListPrefixRenamesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MemtableOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetEngineStatsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NamespaceCacheStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RotateEncryptionKeyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RotateEncryptionKeyResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RotateTenantKeyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteTenantRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompactRangeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EstimateSizeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EstimateSizeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReadAmplificationRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReadAmplificationResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetKeyHeatmapRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetKeyHeatmapResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeyRangeBucket); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeyHeatmapInterval); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetKeyPoliciesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeyPolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeySegment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LegalHoldRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListLegalHoldsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[59].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteSubjectRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[60].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MintAccessTokenRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[61].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetEffectiveConfigResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[62].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MintAccessTokenResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[63].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListNodesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[64].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddNodeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[65].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveNodeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[66].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[67].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetClusterInfoRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[68].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetClusterInfoResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[69].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BackupClusterRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[70].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BackupClusterResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[71].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShardBackup); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[72].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestoreClusterRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[73].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestoreClusterResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[74].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShardRestore); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[75].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RegionInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_admin_proto_msgTypes[76].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConsistencyTag); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_pkg_serverpb_admin_proto_msgTypes[18].OneofWrappers = []interface{}{}
+	file_pkg_serverpb_admin_proto_msgTypes[67].OneofWrappers = []interface{}{}
+	file_pkg_serverpb_admin_proto_msgTypes[75].OneofWrappers = []interface{}{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_pkg_serverpb_admin_proto_rawDesc,
+			NumEnums:      5,
+			NumMessages:   78,
+			NumExtensions: 0,
+			NumServices:   7,
+		},
+		GoTypes:           file_pkg_serverpb_admin_proto_goTypes,
+		DependencyIndexes: file_pkg_serverpb_admin_proto_depIdxs,
+		EnumInfos:         file_pkg_serverpb_admin_proto_enumTypes,
+		MessageInfos:      file_pkg_serverpb_admin_proto_msgTypes,
+	}.Build()
+	File_pkg_serverpb_admin_proto = out.File
+	file_pkg_serverpb_admin_proto_rawDesc = nil
+	file_pkg_serverpb_admin_proto_goTypes = nil
+	file_pkg_serverpb_admin_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// DKVReplicationClient is the client API for DKVReplication service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DKVReplicationClient interface {
+	// GetChanges retrieves all changes from a given change number.
+	GetChanges(ctx context.Context, in *GetChangesRequest, opts ...grpc.CallOption) (*GetChangesResponse, error)
+	// StreamChanges streams all changes from a given change number, pushing
+	// the changes to the subscriber as they are committed instead of having
+	// it poll for them. Every response holds upto MaxNumberOfChanges changes
+	// in their order. Subscribers resume an interrupted stream from the change
+	// number following the last change they received.
+	StreamChanges(ctx context.Context, in *GetChangesRequest, opts ...grpc.CallOption) (DKVReplication_StreamChangesClient, error)
+	// TransferCheckpoint streams a checkpoint of the entire keyspace for
+	// bootstrapping a slave, which then retrieves the changes following the
+	// change number of that checkpoint. The files of the checkpoint are
+	// streamed in chunks in the order of their names. Interrupted transfers
+	// are resumed from the file and the offset within it upto which they
+	// were received, while the checkpoint remains available on the master.
+	TransferCheckpoint(ctx context.Context, in *TransferCheckpointRequest, opts ...grpc.CallOption) (DKVReplication_TransferCheckpointClient, error)
+	// AddReplica registers a new replica with the current master.
+	AddReplica(ctx context.Context, in *Replica, opts ...grpc.CallOption) (*Status, error)
+	// RemoveReplica deregisters given replica from the current master.
+	RemoveReplica(ctx context.Context, in *Replica, opts ...grpc.CallOption) (*Status, error)
+	// GetReplicas retrieves all the replicas of the current master.
+	GetReplicas(ctx context.Context, in *GetReplicasRequest, opts ...grpc.CallOption) (*GetReplicasResponse, error)
+	// Promote issues a new fencing epoch on a node taking over as master,
+	// which is carried by all the changes subsequently served by it. Slaves
+	// promoted stop replicating from their master and serve the writes and
+	// the changes as a master, until they are restarted.
+	Promote(ctx context.Context, in *PromoteRequest, opts ...grpc.CallOption) (*PromoteResponse, error)
+	// Fence pushes the fencing epoch issued by a node promoted to master onto
+	// the master it supersedes, which thereafter rejects the writes with the
+	// REPLICA_READ_ONLY status carrying the address of the promoted node.
+	// Epochs that are not higher than that of the master are rejected.
+	Fence(ctx context.Context, in *FenceRequest, opts ...grpc.CallOption) (*Status, error)
+	// ReportReplicationLag records the progress of a slave, as reported by
+	// it periodically, for computing its lag from the master.
+	ReportReplicationLag(ctx context.Context, in *ReplicationLagReport, opts ...grpc.CallOption) (*Status, error)
+	// GetReplicationLag retrieves the lag of every slave of the master that
+	// retrieved or reported its changes recently.
+	GetReplicationLag(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetReplicationLagResponse, error)
+	// GetRangeDigests splits the keyspace beginning at the given key into
+	// consecutive ranges of upto the given number of keys, and computes a
+	// digest over the keys, values and expiries of every range. Digests
+	// of the same ranges computed by two nodes can be compared to find the
+	// ranges that diverge between them, without transferring the keys.
+	GetRangeDigests(ctx context.Context, in *GetRangeDigestsRequest, opts ...grpc.CallOption) (*GetRangeDigestsResponse, error)
+	// GetDivergentRanges retrieves the ranges of the keyspace found to
+	// diverge from the master by the consistency verifier of a slave.
+	GetDivergentRanges(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetDivergentRangesResponse, error)
+}
+
+type dKVReplicationClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDKVReplicationClient(cc grpc.ClientConnInterface) DKVReplicationClient {
+	return &dKVReplicationClient{cc}
+}
+
+func (c *dKVReplicationClient) GetChanges(ctx context.Context, in *GetChangesRequest, opts ...grpc.CallOption) (*GetChangesResponse, error) {
+	out := new(GetChangesResponse)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVReplication/GetChanges", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dKVReplicationClient) StreamChanges(ctx context.Context, in *GetChangesRequest, opts ...grpc.CallOption) (DKVReplication_StreamChangesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_DKVReplication_serviceDesc.Streams[0], "/dkv.serverpb.DKVReplication/StreamChanges", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &dKVReplicationStreamChangesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DKVReplication_StreamChangesClient interface {
+	Recv() (*GetChangesResponse, error)
+	grpc.ClientStream
+}
+
+type dKVReplicationStreamChangesClient struct {
+	grpc.ClientStream
+}
+
+func (x *dKVReplicationStreamChangesClient) Recv() (*GetChangesResponse, error) {
+	m := new(GetChangesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *dKVReplicationClient) TransferCheckpoint(ctx context.Context, in *TransferCheckpointRequest, opts ...grpc.CallOption) (DKVReplication_TransferCheckpointClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_DKVReplication_serviceDesc.Streams[1], "/dkv.serverpb.DKVReplication/TransferCheckpoint", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &dKVReplicationTransferCheckpointClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DKVReplication_TransferCheckpointClient interface {
+	Recv() (*CheckpointChunk, error)
+	grpc.ClientStream
+}
+
+type dKVReplicationTransferCheckpointClient struct {
+	grpc.ClientStream
+}
+
+func (x *dKVReplicationTransferCheckpointClient) Recv() (*CheckpointChunk, error) {
+	m := new(CheckpointChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *dKVReplicationClient) AddReplica(ctx context.Context, in *Replica, opts ...grpc.CallOption) (*Status, error) {
+	out := new(Status)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVReplication/AddReplica", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dKVReplicationClient) RemoveReplica(ctx context.Context, in *Replica, opts ...grpc.CallOption) (*Status, error) {
+	out := new(Status)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVReplication/RemoveReplica", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dKVReplicationClient) GetReplicas(ctx context.Context, in *GetReplicasRequest, opts ...grpc.CallOption) (*GetReplicasResponse, error) {
+	out := new(GetReplicasResponse)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVReplication/GetReplicas", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dKVReplicationClient) Promote(ctx context.Context, in *PromoteRequest, opts ...grpc.CallOption) (*PromoteResponse, error) {
+	out := new(PromoteResponse)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVReplication/Promote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dKVReplicationClient) Fence(ctx context.Context, in *FenceRequest, opts ...grpc.CallOption) (*Status, error) {
+	out := new(Status)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVReplication/Fence", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dKVReplicationClient) ReportReplicationLag(ctx context.Context, in *ReplicationLagReport, opts ...grpc.CallOption) (*Status, error) {
+	out := new(Status)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVReplication/ReportReplicationLag", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dKVReplicationClient) GetReplicationLag(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetReplicationLagResponse, error) {
+	out := new(GetReplicationLagResponse)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVReplication/GetReplicationLag", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dKVReplicationClient) GetRangeDigests(ctx context.Context, in *GetRangeDigestsRequest, opts ...grpc.CallOption) (*GetRangeDigestsResponse, error) {
+	out := new(GetRangeDigestsResponse)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVReplication/GetRangeDigests", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dKVReplicationClient) GetDivergentRanges(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetDivergentRangesResponse, error) {
+	out := new(GetDivergentRangesResponse)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVReplication/GetDivergentRanges", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DKVReplicationServer is the server API for DKVReplication service.
+type DKVReplicationServer interface {
+	// GetChanges retrieves all changes from a given change number.
+	GetChanges(context.Context, *GetChangesRequest) (*GetChangesResponse, error)
+	// StreamChanges streams all changes from a given change number, pushing
+	// the changes to the subscriber as they are committed instead of having
+	// it poll for them. Every response holds upto MaxNumberOfChanges changes
+	// in their order. Subscribers resume an interrupted stream from the change
+	// number following the last change they received.
+	StreamChanges(*GetChangesRequest, DKVReplication_StreamChangesServer) error
+	// TransferCheckpoint streams a checkpoint of the entire keyspace for
+	// bootstrapping a slave, which then retrieves the changes following the
+	// change number of that checkpoint. The files of the checkpoint are
+	// streamed in chunks in the order of their names. Interrupted transfers
+	// are resumed from the file and the offset within it upto which they
+	// were received, while the checkpoint remains available on the master.
+	TransferCheckpoint(*TransferCheckpointRequest, DKVReplication_TransferCheckpointServer) error
+	// AddReplica registers a new replica with the current master.
+	AddReplica(context.Context, *Replica) (*Status, error)
+	// RemoveReplica deregisters given replica from the current master.
+	RemoveReplica(context.Context, *Replica) (*Status, error)
+	// GetReplicas retrieves all the replicas of the current master.
+	GetReplicas(context.Context, *GetReplicasRequest) (*GetReplicasResponse, error)
+	// Promote issues a new fencing epoch on a node taking over as master,
+	// which is carried by all the changes subsequently served by it. Slaves
+	// promoted stop replicating from their master and serve the writes and
+	// the changes as a master, until they are restarted.
+	Promote(context.Context, *PromoteRequest) (*PromoteResponse, error)
+	// Fence pushes the fencing epoch issued by a node promoted to master onto
+	// the master it supersedes, which thereafter rejects the writes with the
+	// REPLICA_READ_ONLY status carrying the address of the promoted node.
+	// Epochs that are not higher than that of the master are rejected.
+	Fence(context.Context, *FenceRequest) (*Status, error)
+	// ReportReplicationLag records the progress of a slave, as reported by
+	// it periodically, for computing its lag from the master.
+	ReportReplicationLag(context.Context, *ReplicationLagReport) (*Status, error)
+	// GetReplicationLag retrieves the lag of every slave of the master that
+	// retrieved or reported its changes recently.
+	GetReplicationLag(context.Context, *emptypb.Empty) (*GetReplicationLagResponse, error)
+	// GetRangeDigests splits the keyspace beginning at the given key into
+	// consecutive ranges of upto the given number of keys, and computes a
+	// digest over the keys, values and expiries of every range. Digests
+	// of the same ranges computed by two nodes can be compared to find the
+	// ranges that diverge between them, without transferring the keys.
+	GetRangeDigests(context.Context, *GetRangeDigestsRequest) (*GetRangeDigestsResponse, error)
+	// GetDivergentRanges retrieves the ranges of the keyspace found to
+	// diverge from the master by the consistency verifier of a slave.
+	GetDivergentRanges(context.Context, *emptypb.Empty) (*GetDivergentRangesResponse, error)
+}
+
+// UnimplementedDKVReplicationServer can be embedded to have forward compatible implementations.
+type UnimplementedDKVReplicationServer struct {
+}
+
+func (*UnimplementedDKVReplicationServer) GetChanges(context.Context, *GetChangesRequest) (*GetChangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChanges not implemented")
+}
+func (*UnimplementedDKVReplicationServer) StreamChanges(*GetChangesRequest, DKVReplication_StreamChangesServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamChanges not implemented")
+}
+func (*UnimplementedDKVReplicationServer) TransferCheckpoint(*TransferCheckpointRequest, DKVReplication_TransferCheckpointServer) error {
+	return status.Errorf(codes.Unimplemented, "method TransferCheckpoint not implemented")
+}
+func (*UnimplementedDKVReplicationServer) AddReplica(context.Context, *Replica) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddReplica not implemented")
+}
+func (*UnimplementedDKVReplicationServer) RemoveReplica(context.Context, *Replica) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveReplica not implemented")
+}
+func (*UnimplementedDKVReplicationServer) GetReplicas(context.Context, *GetReplicasRequest) (*GetReplicasResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReplicas not implemented")
+}
+func (*UnimplementedDKVReplicationServer) Promote(context.Context, *PromoteRequest) (*PromoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Promote not implemented")
+}
+func (*UnimplementedDKVReplicationServer) Fence(context.Context, *FenceRequest) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Fence not implemented")
+}
+func (*UnimplementedDKVReplicationServer) ReportReplicationLag(context.Context, *ReplicationLagReport) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportReplicationLag not implemented")
+}
+func (*UnimplementedDKVReplicationServer) GetReplicationLag(context.Context, *emptypb.Empty) (*GetReplicationLagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReplicationLag not implemented")
+}
+func (*UnimplementedDKVReplicationServer) GetRangeDigests(context.Context, *GetRangeDigestsRequest) (*GetRangeDigestsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRangeDigests not implemented")
+}
+func (*UnimplementedDKVReplicationServer) GetDivergentRanges(context.Context, *emptypb.Empty) (*GetDivergentRangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDivergentRanges not implemented")
+}
+
+func RegisterDKVReplicationServer(s *grpc.Server, srv DKVReplicationServer) {
+	s.RegisterService(&_DKVReplication_serviceDesc, srv)
+}
+
+func _DKVReplication_GetChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVReplicationServer).GetChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKVReplication/GetChanges",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVReplicationServer).GetChanges(ctx, req.(*GetChangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DKVReplication_StreamChanges_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetChangesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DKVReplicationServer).StreamChanges(m, &dKVReplicationStreamChangesServer{stream})
+}
+
+type DKVReplication_StreamChangesServer interface {
+	Send(*GetChangesResponse) error
+	grpc.ServerStream
+}
+
+type dKVReplicationStreamChangesServer struct {
+	grpc.ServerStream
+}
+
+func (x *dKVReplicationStreamChangesServer) Send(m *GetChangesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _DKVReplication_TransferCheckpoint_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TransferCheckpointRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DKVReplicationServer).TransferCheckpoint(m, &dKVReplicationTransferCheckpointServer{stream})
+}
+
+type DKVReplication_TransferCheckpointServer interface {
+	Send(*CheckpointChunk) error
+	grpc.ServerStream
+}
+
+type dKVReplicationTransferCheckpointServer struct {
+	grpc.ServerStream
+}
+
+func (x *dKVReplicationTransferCheckpointServer) Send(m *CheckpointChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _DKVReplication_AddReplica_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Replica)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVReplicationServer).AddReplica(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKVReplication/AddReplica",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVReplicationServer).AddReplica(ctx, req.(*Replica))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DKVReplication_RemoveReplica_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Replica)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVReplicationServer).RemoveReplica(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKVReplication/RemoveReplica",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVReplicationServer).RemoveReplica(ctx, req.(*Replica))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DKVReplication_GetReplicas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReplicasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVReplicationServer).GetReplicas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKVReplication/GetReplicas",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVReplicationServer).GetReplicas(ctx, req.(*GetReplicasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DKVReplication_Promote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVReplicationServer).Promote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKVReplication/Promote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVReplicationServer).Promote(ctx, req.(*PromoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DKVReplication_Fence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVReplicationServer).Fence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKVReplication/Fence",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVReplicationServer).Fence(ctx, req.(*FenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DKVReplication_ReportReplicationLag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplicationLagReport)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVReplicationServer).ReportReplicationLag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKVReplication/ReportReplicationLag",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVReplicationServer).ReportReplicationLag(ctx, req.(*ReplicationLagReport))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DKVReplication_GetReplicationLag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVReplicationServer).GetReplicationLag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKVReplication/GetReplicationLag",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVReplicationServer).GetReplicationLag(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DKVReplication_GetRangeDigests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRangeDigestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVReplicationServer).GetRangeDigests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKVReplication/GetRangeDigests",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVReplicationServer).GetRangeDigests(ctx, req.(*GetRangeDigestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DKVReplication_GetDivergentRanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVReplicationServer).GetDivergentRanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKVReplication/GetDivergentRanges",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVReplicationServer).GetDivergentRanges(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _DKVReplication_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "dkv.serverpb.DKVReplication",
+	HandlerType: (*DKVReplicationServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetChanges",
+			Handler:    _DKVReplication_GetChanges_Handler,
+		},
+		{
+			MethodName: "AddReplica",
+			Handler:    _DKVReplication_AddReplica_Handler,
+		},
+		{
+			MethodName: "RemoveReplica",
+			Handler:    _DKVReplication_RemoveReplica_Handler,
+		},
+		{
+			MethodName: "GetReplicas",
+			Handler:    _DKVReplication_GetReplicas_Handler,
+		},
+		{
+			MethodName: "Promote",
+			Handler:    _DKVReplication_Promote_Handler,
+		},
+		{
+			MethodName: "Fence",
+			Handler:    _DKVReplication_Fence_Handler,
+		},
+		{
+			MethodName: "ReportReplicationLag",
+			Handler:    _DKVReplication_ReportReplicationLag_Handler,
+		},
+		{
+			MethodName: "GetReplicationLag",
+			Handler:    _DKVReplication_GetReplicationLag_Handler,
+		},
+		{
+			MethodName: "GetRangeDigests",
+			Handler:    _DKVReplication_GetRangeDigests_Handler,
+		},
+		{
+			MethodName: "GetDivergentRanges",
+			Handler:    _DKVReplication_GetDivergentRanges_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamChanges",
+			Handler:       _DKVReplication_StreamChanges_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "TransferCheckpoint",
+			Handler:       _DKVReplication_TransferCheckpoint_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pkg/serverpb/admin.proto",
+}
+
+// DKVBackupRestoreClient is the client API for DKVBackupRestore service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DKVBackupRestoreClient interface {
+	// Backup backs up the entire keyspace into the given filesystem location.
+	Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*Status, error)
+	// Restore restores the entire keyspace from an existing backup at the
+	// given filesystem location.
+	Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*Status, error)
+	// PointInTimeRestore restores the entire keyspace from an existing backup
+	// at the given filesystem location and then replays the retained changes
+	// on top of it, up to and including the given change number.
+	PointInTimeRestore(ctx context.Context, in *PointInTimeRestoreRequest, opts ...grpc.CallOption) (*Status, error)
+	// GetBackupManifest retrieves the manifest of an existing backup at the
+	// given filesystem location, which captures the change number upto
+	// which the backup is guaranteed to be complete.
+	GetBackupManifest(ctx context.Context, in *GetBackupManifestRequest, opts ...grpc.CallOption) (*GetBackupManifestResponse, error)
+	// PauseWrites completes the writes in progress and rejects the writes
+	// thereafter with the Unavailable code, until they are resumed or the
+	// given timeout elapses. Retrieves the latest change number as of the
+	// pause, such as for quiescing the shards of a cluster backup.
+	PauseWrites(ctx context.Context, in *PauseWritesRequest, opts ...grpc.CallOption) (*PauseWritesResponse, error)
+	// ResumeWrites resumes the writes paused by PauseWrites.
+	ResumeWrites(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Status, error)
+}
+
+type dKVBackupRestoreClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDKVBackupRestoreClient(cc grpc.ClientConnInterface) DKVBackupRestoreClient {
+	return &dKVBackupRestoreClient{cc}
+}
+
+func (c *dKVBackupRestoreClient) Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*Status, error) {
+	out := new(Status)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVBackupRestore/Backup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dKVBackupRestoreClient) Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*Status, error) {
+	out := new(Status)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVBackupRestore/Restore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dKVBackupRestoreClient) PointInTimeRestore(ctx context.Context, in *PointInTimeRestoreRequest, opts ...grpc.CallOption) (*Status, error) {
+	out := new(Status)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVBackupRestore/PointInTimeRestore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dKVBackupRestoreClient) GetBackupManifest(ctx context.Context, in *GetBackupManifestRequest, opts ...grpc.CallOption) (*GetBackupManifestResponse, error) {
+	out := new(GetBackupManifestResponse)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVBackupRestore/GetBackupManifest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dKVBackupRestoreClient) PauseWrites(ctx context.Context, in *PauseWritesRequest, opts ...grpc.CallOption) (*PauseWritesResponse, error) {
+	out := new(PauseWritesResponse)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVBackupRestore/PauseWrites", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dKVBackupRestoreClient) ResumeWrites(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Status, error) {
+	out := new(Status)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVBackupRestore/ResumeWrites", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DKVBackupRestoreServer is the server API for DKVBackupRestore service.
+type DKVBackupRestoreServer interface {
+	// Backup backs up the entire keyspace into the given filesystem location.
+	Backup(context.Context, *BackupRequest) (*Status, error)
+	// Restore restores the entire keyspace from an existing backup at the
+	// given filesystem location.
+	Restore(context.Context, *RestoreRequest) (*Status, error)
+	// PointInTimeRestore restores the entire keyspace from an existing backup
+	// at the given filesystem location and then replays the retained changes
+	// on top of it, up to and including the given change number.
+	PointInTimeRestore(context.Context, *PointInTimeRestoreRequest) (*Status, error)
+	// GetBackupManifest retrieves the manifest of an existing backup at the
+	// given filesystem location, which captures the change number upto
+	// which the backup is guaranteed to be complete.
+	GetBackupManifest(context.Context, *GetBackupManifestRequest) (*GetBackupManifestResponse, error)
+	// PauseWrites completes the writes in progress and rejects the writes
+	// thereafter with the Unavailable code, until they are resumed or the
+	// given timeout elapses. Retrieves the latest change number as of the
+	// pause, such as for quiescing the shards of a cluster backup.
+	PauseWrites(context.Context, *PauseWritesRequest) (*PauseWritesResponse, error)
+	// ResumeWrites resumes the writes paused by PauseWrites.
+	ResumeWrites(context.Context, *emptypb.Empty) (*Status, error)
+}
+
+// UnimplementedDKVBackupRestoreServer can be embedded to have forward compatible implementations.
+type UnimplementedDKVBackupRestoreServer struct {
+}
+
+func (*UnimplementedDKVBackupRestoreServer) Backup(context.Context, *BackupRequest) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Backup not implemented")
+}
+func (*UnimplementedDKVBackupRestoreServer) Restore(context.Context, *RestoreRequest) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
+func (*UnimplementedDKVBackupRestoreServer) PointInTimeRestore(context.Context, *PointInTimeRestoreRequest) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PointInTimeRestore not implemented")
+}
+func (*UnimplementedDKVBackupRestoreServer) GetBackupManifest(context.Context, *GetBackupManifestRequest) (*GetBackupManifestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBackupManifest not implemented")
+}
+func (*UnimplementedDKVBackupRestoreServer) PauseWrites(context.Context, *PauseWritesRequest) (*PauseWritesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseWrites not implemented")
+}
+func (*UnimplementedDKVBackupRestoreServer) ResumeWrites(context.Context, *emptypb.Empty) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeWrites not implemented")
+}
+
+func RegisterDKVBackupRestoreServer(s *grpc.Server, srv DKVBackupRestoreServer) {
+	s.RegisterService(&_DKVBackupRestore_serviceDesc, srv)
+}
+
+func _DKVBackupRestore_Backup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVBackupRestoreServer).Backup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKVBackupRestore/Backup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVBackupRestoreServer).Backup(ctx, req.(*BackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DKVBackupRestore_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVBackupRestoreServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKVBackupRestore/Restore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVBackupRestoreServer).Restore(ctx, req.(*RestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DKVBackupRestore_PointInTimeRestore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PointInTimeRestoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVBackupRestoreServer).PointInTimeRestore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKVBackupRestore/PointInTimeRestore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVBackupRestoreServer).PointInTimeRestore(ctx, req.(*PointInTimeRestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DKVBackupRestore_GetBackupManifest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBackupManifestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVBackupRestoreServer).GetBackupManifest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKVBackupRestore/GetBackupManifest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVBackupRestoreServer).GetBackupManifest(ctx, req.(*GetBackupManifestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DKVBackupRestore_PauseWrites_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseWritesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVBackupRestoreServer).PauseWrites(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKVBackupRestore/PauseWrites",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVBackupRestoreServer).PauseWrites(ctx, req.(*PauseWritesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DKVBackupRestore_ResumeWrites_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVBackupRestoreServer).ResumeWrites(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKVBackupRestore/ResumeWrites",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVBackupRestoreServer).ResumeWrites(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _DKVBackupRestore_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "dkv.serverpb.DKVBackupRestore",
+	HandlerType: (*DKVBackupRestoreServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Backup",
+			Handler:    _DKVBackupRestore_Backup_Handler,
+		},
+		{
+			MethodName: "Restore",
+			Handler:    _DKVBackupRestore_Restore_Handler,
+		},
+		{
+			MethodName: "PointInTimeRestore",
+			Handler:    _DKVBackupRestore_PointInTimeRestore_Handler,
+		},
+		{
+			MethodName: "GetBackupManifest",
+			Handler:    _DKVBackupRestore_GetBackupManifest_Handler,
+		},
+		{
+			MethodName: "PauseWrites",
+			Handler:    _DKVBackupRestore_PauseWrites_Handler,
+		},
+		{
+			MethodName: "ResumeWrites",
+			Handler:    _DKVBackupRestore_ResumeWrites_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pkg/serverpb/admin.proto",
+}
+
+// DKVSnapshotClient is the client API for DKVSnapshot service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DKVSnapshotClient interface {
+	// PublishSnapshot captures the current state of the given namespace and
+	// publishes it as an immutable snapshot under the `namespace@name` reference.
+	PublishSnapshot(ctx context.Context, in *PublishSnapshotRequest, opts ...grpc.CallOption) (*Status, error)
+	// UnpublishSnapshot releases the snapshot published under the given reference.
+	UnpublishSnapshot(ctx context.Context, in *UnpublishSnapshotRequest, opts ...grpc.CallOption) (*Status, error)
+	// ListSnapshots retrieves the references of all the published snapshots.
+	ListSnapshots(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListSnapshotsResponse, error)
+}
+
+type dKVSnapshotClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDKVSnapshotClient(cc grpc.ClientConnInterface) DKVSnapshotClient {
+	return &dKVSnapshotClient{cc}
+}
+
+func (c *dKVSnapshotClient) PublishSnapshot(ctx context.Context, in *PublishSnapshotRequest, opts ...grpc.CallOption) (*Status, error) {
+	out := new(Status)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVSnapshot/PublishSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dKVSnapshotClient) UnpublishSnapshot(ctx context.Context, in *UnpublishSnapshotRequest, opts ...grpc.CallOption) (*Status, error) {
+	out := new(Status)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVSnapshot/UnpublishSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dKVSnapshotClient) ListSnapshots(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListSnapshotsResponse, error) {
+	out := new(ListSnapshotsResponse)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVSnapshot/ListSnapshots", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DKVSnapshotServer is the server API for DKVSnapshot service.
+type DKVSnapshotServer interface {
+	// PublishSnapshot captures the current state of the given namespace and
+	// publishes it as an immutable snapshot under the `namespace@name` reference.
+	PublishSnapshot(context.Context, *PublishSnapshotRequest) (*Status, error)
+	// UnpublishSnapshot releases the snapshot published under the given reference.
+	UnpublishSnapshot(context.Context, *UnpublishSnapshotRequest) (*Status, error)
+	// ListSnapshots retrieves the references of all the published snapshots.
+	ListSnapshots(context.Context, *emptypb.Empty) (*ListSnapshotsResponse, error)
+}
+
+// UnimplementedDKVSnapshotServer can be embedded to have forward compatible implementations.
+type UnimplementedDKVSnapshotServer struct {
+}
+
+func (*UnimplementedDKVSnapshotServer) PublishSnapshot(context.Context, *PublishSnapshotRequest) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublishSnapshot not implemented")
+}
+func (*UnimplementedDKVSnapshotServer) UnpublishSnapshot(context.Context, *UnpublishSnapshotRequest) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnpublishSnapshot not implemented")
+}
+func (*UnimplementedDKVSnapshotServer) ListSnapshots(context.Context, *emptypb.Empty) (*ListSnapshotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSnapshots not implemented")
+}
+
+func RegisterDKVSnapshotServer(s *grpc.Server, srv DKVSnapshotServer) {
+	s.RegisterService(&_DKVSnapshot_serviceDesc, srv)
+}
+
+func _DKVSnapshot_PublishSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVSnapshotServer).PublishSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKVSnapshot/PublishSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVSnapshotServer).PublishSnapshot(ctx, req.(*PublishSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DKVSnapshot_UnpublishSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnpublishSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVSnapshotServer).UnpublishSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKVSnapshot/UnpublishSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVSnapshotServer).UnpublishSnapshot(ctx, req.(*UnpublishSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DKVSnapshot_ListSnapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVSnapshotServer).ListSnapshots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKVSnapshot/ListSnapshots",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVSnapshotServer).ListSnapshots(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _DKVSnapshot_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "dkv.serverpb.DKVSnapshot",
+	HandlerType: (*DKVSnapshotServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PublishSnapshot",
+			Handler:    _DKVSnapshot_PublishSnapshot_Handler,
+		},
+		{
+			MethodName: "UnpublishSnapshot",
+			Handler:    _DKVSnapshot_UnpublishSnapshot_Handler,
+		},
+		{
+			MethodName: "ListSnapshots",
+			Handler:    _DKVSnapshot_ListSnapshots_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pkg/serverpb/admin.proto",
+}
+
+// DKVAdminClient is the client API for DKVAdmin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DKVAdminClient interface {
+	// CompactRange triggers a manual compaction of the keys having the given
+	// prefix or of the entire keyspace when no prefix is given.
+	CompactRange(ctx context.Context, in *CompactRangeRequest, opts ...grpc.CallOption) (*Status, error)
+	// PlaceLegalHold places a legal hold over the given namespace or key prefix,
+	// suspending the expiry of its keys and rejecting their deletes.
+	PlaceLegalHold(ctx context.Context, in *LegalHoldRequest, opts ...grpc.CallOption) (*Status, error)
+	// LiftLegalHold lifts the legal hold over the given namespace or key prefix.
+	LiftLegalHold(ctx context.Context, in *LegalHoldRequest, opts ...grpc.CallOption) (*Status, error)
+	// ListLegalHolds retrieves all the namespaces or key prefixes under legal hold.
+	ListLegalHolds(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListLegalHoldsResponse, error)
+	// DeleteSubject irrecoverably erases all the data of the given subject by
+	// destroying its encryption key.
+	DeleteSubject(ctx context.Context, in *DeleteSubjectRequest, opts ...grpc.CallOption) (*Status, error)
+	// MintAccessToken mints a short-lived token granting read-only access to
+	// the keys having the given prefix, for temporary access to production.
+	MintAccessToken(ctx context.Context, in *MintAccessTokenRequest, opts ...grpc.CallOption) (*MintAccessTokenResponse, error)
+	// GetEffectiveConfig retrieves the fully resolved configuration of the
+	// current node along with the source of every setting, with secrets redacted.
+	GetEffectiveConfig(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetEffectiveConfigResponse, error)
+	// EstimateSize estimates the number of keys and the size of the given
+	// key range, without scanning it. Such estimates are meant for capacity
+	// planning and for deciding the split points of shards.
+	EstimateSize(ctx context.Context, in *EstimateSizeRequest, opts ...grpc.CallOption) (*EstimateSizeResponse, error)
+	// ReportReadAmplification samples the reads served over the given window
+	// and reports their efficiency, along with suggestions for tuning the
+	// block cache, bloom filters and compaction.
+	ReportReadAmplification(ctx context.Context, in *ReadAmplificationRequest, opts ...grpc.CallOption) (*ReadAmplificationResponse, error)
+	// RotateEncryptionKey reloads the master keys used for encryption at rest
+	// and re-encrypts all the values of the local storage using the latest key.
+	// Every node of a cluster holds its own storage, hence must be rotated.
+	RotateEncryptionKey(ctx context.Context, in *RotateEncryptionKeyRequest, opts ...grpc.CallOption) (*RotateEncryptionKeyResponse, error)
+	// RotateTenantKey generates a new data key for the given tenant and
+	// re-encrypts all the values of that tenant using it. The new data key is
+	// replicated along with the re-encrypted values, hence only the master is
+	// to be rotated.
+	RotateTenantKey(ctx context.Context, in *RotateTenantKeyRequest, opts ...grpc.CallOption) (*RotateEncryptionKeyResponse, error)
+	// DeleteTenant irrecoverably erases all the data of the given tenant by
+	// destroying its data keys.
+	DeleteTenant(ctx context.Context, in *DeleteTenantRequest, opts ...grpc.CallOption) (*Status, error)
+	// GetEngineStats retrieves the internal statistics of the storage engine,
+	// such as the memtable size and the number of files per level.
+	GetEngineStats(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetEngineStatsResponse, error)
+	// IngestSST bulk loads the externally built SST files at the given paths
+	// on the node, bypassing the WAL and memtables. Since the ingested keys
+	// are not replicated, it is meant for initial loads before any replica
+	// is added.
+	IngestSST(ctx context.Context, in *IngestSSTRequest, opts ...grpc.CallOption) (*Status, error)
+	// Export writes a consistent snapshot of the given key range into files
+	// at the given location on the node, or uploads them to object storage,
+	// for offline analytics and migrations.
+	Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (*ExportResponse, error)
+	// ListQuarantinedRecords retrieves the records that were found to be
+	// corrupt or were rejected, and hence were set aside in the quarantine
+	// namespace of the node instead of being served or applied.
+	ListQuarantinedRecords(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListQuarantinedRecordsResponse, error)
+	// SetMemtableOptions changes the memtable and level 0 settings of the
+	// node, until it restarts, so that bursts of writes can be absorbed
+	// without a restart. Only the options given are changed.
+	SetMemtableOptions(ctx context.Context, in *MemtableOptions, opts ...grpc.CallOption) (*Status, error)
+	// BeginPrefixRename begins renaming the given key prefix to the new prefix.
+	// Masters copy the keys of the old prefix to the new prefix in the
+	// background, without overwriting the keys already written under the new
+	// prefix, while the reads of the keys absent under the new prefix fall
+	// back to the old prefix on both the masters and the slaves. Beginning an
+	// interrupted rename again resumes it.
+	BeginPrefixRename(ctx context.Context, in *PrefixRenameRequest, opts ...grpc.CallOption) (*Status, error)
+	// CompletePrefixRename ends the fallback of the reads to the old prefix.
+	// Masters then delete the keys of the old prefix in the background, once
+	// they are copied to the new prefix.
+	CompletePrefixRename(ctx context.Context, in *PrefixRenameRequest, opts ...grpc.CallOption) (*Status, error)
+	// ListPrefixRenames retrieves the key prefix renames in progress.
+	ListPrefixRenames(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListPrefixRenamesResponse, error)
+	// GetKeyHeatmap retrieves the read and write rates of the node across the
+	// key range buckets for every minute over the given number of minutes,
+	// estimated from the sampled calls, so that the hot ranges are revealed.
+	GetKeyHeatmap(ctx context.Context, in *GetKeyHeatmapRequest, opts ...grpc.CallOption) (*GetKeyHeatmapResponse, error)
+	// GetKeyPolicies retrieves the key policies published by the node, to
+	// which the keys built by the clients for the namespaces must conform.
+	GetKeyPolicies(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetKeyPoliciesResponse, error)
+}
+
+type dKVAdminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDKVAdminClient(cc grpc.ClientConnInterface) DKVAdminClient {
+	return &dKVAdminClient{cc}
+}
+
+func (c *dKVAdminClient) CompactRange(ctx context.Context, in *CompactRangeRequest, opts ...grpc.CallOption) (*Status, error) {
+	out := new(Status)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVAdmin/CompactRange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dKVAdminClient) PlaceLegalHold(ctx context.Context, in *LegalHoldRequest, opts ...grpc.CallOption) (*Status, error) {
+	out := new(Status)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVAdmin/PlaceLegalHold", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dKVAdminClient) LiftLegalHold(ctx context.Context, in *LegalHoldRequest, opts ...grpc.CallOption) (*Status, error) {
+	out := new(Status)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVAdmin/LiftLegalHold", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dKVAdminClient) ListLegalHolds(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListLegalHoldsResponse, error) {
+	out := new(ListLegalHoldsResponse)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVAdmin/ListLegalHolds", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dKVAdminClient) DeleteSubject(ctx context.Context, in *DeleteSubjectRequest, opts ...grpc.CallOption) (*Status, error) {
+	out := new(Status)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVAdmin/DeleteSubject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dKVAdminClient) MintAccessToken(ctx context.Context, in *MintAccessTokenRequest, opts ...grpc.CallOption) (*MintAccessTokenResponse, error) {
+	out := new(MintAccessTokenResponse)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVAdmin/MintAccessToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dKVAdminClient) GetEffectiveConfig(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetEffectiveConfigResponse, error) {
+	out := new(GetEffectiveConfigResponse)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVAdmin/GetEffectiveConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dKVAdminClient) EstimateSize(ctx context.Context, in *EstimateSizeRequest, opts ...grpc.CallOption) (*EstimateSizeResponse, error) {
+	out := new(EstimateSizeResponse)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVAdmin/EstimateSize", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	file_pkg_serverpb_admin_proto_msgTypes[13].OneofWrappers = []interface{}{}
-	file_pkg_serverpb_admin_proto_msgTypes[15].OneofWrappers = []interface{}{}
-	type x struct{}
-	out := protoimpl.TypeBuilder{
-		File: protoimpl.DescBuilder{
-			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_pkg_serverpb_admin_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   17,
-			NumExtensions: 0,
-			NumServices:   5,
-		},
-		GoTypes:           file_pkg_serverpb_admin_proto_goTypes,
-		DependencyIndexes: file_pkg_serverpb_admin_proto_depIdxs,
-		EnumInfos:         file_pkg_serverpb_admin_proto_enumTypes,
-		MessageInfos:      file_pkg_serverpb_admin_proto_msgTypes,
-	}.Build()
-	File_pkg_serverpb_admin_proto = out.File
-	file_pkg_serverpb_admin_proto_rawDesc = nil
-	file_pkg_serverpb_admin_proto_goTypes = nil
-	file_pkg_serverpb_admin_proto_depIdxs = nil
+	return out, nil
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConnInterface
+func (c *dKVAdminClient) ReportReadAmplification(ctx context.Context, in *ReadAmplificationRequest, opts ...grpc.CallOption) (*ReadAmplificationResponse, error) {
+	out := new(ReadAmplificationResponse)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVAdmin/ReportReadAmplification", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion6
+func (c *dKVAdminClient) RotateEncryptionKey(ctx context.Context, in *RotateEncryptionKeyRequest, opts ...grpc.CallOption) (*RotateEncryptionKeyResponse, error) {
+	out := new(RotateEncryptionKeyResponse)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVAdmin/RotateEncryptionKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
-// DKVReplicationClient is the client API for DKVReplication service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type DKVReplicationClient interface {
-	// GetChanges retrieves all changes from a given change number.
-	GetChanges(ctx context.Context, in *GetChangesRequest, opts ...grpc.CallOption) (*GetChangesResponse, error)
-	// AddReplica registers a new replica with the current master.
-	AddReplica(ctx context.Context, in *Replica, opts ...grpc.CallOption) (*Status, error)
-	// RemoveReplica deregisters given replica from the current master.
-	RemoveReplica(ctx context.Context, in *Replica, opts ...grpc.CallOption) (*Status, error)
-	// GetReplicas retrieves all the replicas of the current master.
-	GetReplicas(ctx context.Context, in *GetReplicasRequest, opts ...grpc.CallOption) (*GetReplicasResponse, error)
+func (c *dKVAdminClient) RotateTenantKey(ctx context.Context, in *RotateTenantKeyRequest, opts ...grpc.CallOption) (*RotateEncryptionKeyResponse, error) {
+	out := new(RotateEncryptionKeyResponse)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVAdmin/RotateTenantKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-type dKVReplicationClient struct {
-	cc grpc.ClientConnInterface
+func (c *dKVAdminClient) DeleteTenant(ctx context.Context, in *DeleteTenantRequest, opts ...grpc.CallOption) (*Status, error) {
+	out := new(Status)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVAdmin/DeleteTenant", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func NewDKVReplicationClient(cc grpc.ClientConnInterface) DKVReplicationClient {
-	return &dKVReplicationClient{cc}
+func (c *dKVAdminClient) GetEngineStats(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetEngineStatsResponse, error) {
+	out := new(GetEngineStatsResponse)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVAdmin/GetEngineStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (c *dKVReplicationClient) GetChanges(ctx context.Context, in *GetChangesRequest, opts ...grpc.CallOption) (*GetChangesResponse, error) {
-	out := new(GetChangesResponse)
-	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVReplication/GetChanges", in, out, opts...)
+func (c *dKVAdminClient) IngestSST(ctx context.Context, in *IngestSSTRequest, opts ...grpc.CallOption) (*Status, error) {
+	out := new(Status)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVAdmin/IngestSST", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *dKVReplicationClient) AddReplica(ctx context.Context, in *Replica, opts ...grpc.CallOption) (*Status, error) {
+func (c *dKVAdminClient) Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (*ExportResponse, error) {
+	out := new(ExportResponse)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVAdmin/Export", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dKVAdminClient) ListQuarantinedRecords(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListQuarantinedRecordsResponse, error) {
+	out := new(ListQuarantinedRecordsResponse)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVAdmin/ListQuarantinedRecords", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dKVAdminClient) SetMemtableOptions(ctx context.Context, in *MemtableOptions, opts ...grpc.CallOption) (*Status, error) {
 	out := new(Status)
-	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVReplication/AddReplica", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVAdmin/SetMemtableOptions", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *dKVReplicationClient) RemoveReplica(ctx context.Context, in *Replica, opts ...grpc.CallOption) (*Status, error) {
+func (c *dKVAdminClient) BeginPrefixRename(ctx context.Context, in *PrefixRenameRequest, opts ...grpc.CallOption) (*Status, error) {
 	out := new(Status)
-	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVReplication/RemoveReplica", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVAdmin/BeginPrefixRename", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *dKVReplicationClient) GetReplicas(ctx context.Context, in *GetReplicasRequest, opts ...grpc.CallOption) (*GetReplicasResponse, error) {
-	out := new(GetReplicasResponse)
-	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVReplication/GetReplicas", in, out, opts...)
+func (c *dKVAdminClient) CompletePrefixRename(ctx context.Context, in *PrefixRenameRequest, opts ...grpc.CallOption) (*Status, error) {
+	out := new(Status)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVAdmin/CompletePrefixRename", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// DKVReplicationServer is the server API for DKVReplication service.
-type DKVReplicationServer interface {
-	// GetChanges retrieves all changes from a given change number.
-	GetChanges(context.Context, *GetChangesRequest) (*GetChangesResponse, error)
-	// AddReplica registers a new replica with the current master.
-	AddReplica(context.Context, *Replica) (*Status, error)
-	// RemoveReplica deregisters given replica from the current master.
-	RemoveReplica(context.Context, *Replica) (*Status, error)
-	// GetReplicas retrieves all the replicas of the current master.
-	GetReplicas(context.Context, *GetReplicasRequest) (*GetReplicasResponse, error)
+func (c *dKVAdminClient) ListPrefixRenames(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListPrefixRenamesResponse, error) {
+	out := new(ListPrefixRenamesResponse)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVAdmin/ListPrefixRenames", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-// UnimplementedDKVReplicationServer can be embedded to have forward compatible implementations.
-type UnimplementedDKVReplicationServer struct {
+func (c *dKVAdminClient) GetKeyHeatmap(ctx context.Context, in *GetKeyHeatmapRequest, opts ...grpc.CallOption) (*GetKeyHeatmapResponse, error) {
+	out := new(GetKeyHeatmapResponse)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVAdmin/GetKeyHeatmap", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (*UnimplementedDKVReplicationServer) GetChanges(context.Context, *GetChangesRequest) (*GetChangesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetChanges not implemented")
+func (c *dKVAdminClient) GetKeyPolicies(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetKeyPoliciesResponse, error) {
+	out := new(GetKeyPoliciesResponse)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVAdmin/GetKeyPolicies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (*UnimplementedDKVReplicationServer) AddReplica(context.Context, *Replica) (*Status, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddReplica not implemented")
+
+// DKVAdminServer is the server API for DKVAdmin service.
+type DKVAdminServer interface {
+	// CompactRange triggers a manual compaction of the keys having the given
+	// prefix or of the entire keyspace when no prefix is given.
+	CompactRange(context.Context, *CompactRangeRequest) (*Status, error)
+	// PlaceLegalHold places a legal hold over the given namespace or key prefix,
+	// suspending the expiry of its keys and rejecting their deletes.
+	PlaceLegalHold(context.Context, *LegalHoldRequest) (*Status, error)
+	// LiftLegalHold lifts the legal hold over the given namespace or key prefix.
+	LiftLegalHold(context.Context, *LegalHoldRequest) (*Status, error)
+	// ListLegalHolds retrieves all the namespaces or key prefixes under legal hold.
+	ListLegalHolds(context.Context, *emptypb.Empty) (*ListLegalHoldsResponse, error)
+	// DeleteSubject irrecoverably erases all the data of the given subject by
+	// destroying its encryption key.
+	DeleteSubject(context.Context, *DeleteSubjectRequest) (*Status, error)
+	// MintAccessToken mints a short-lived token granting read-only access to
+	// the keys having the given prefix, for temporary access to production.
+	MintAccessToken(context.Context, *MintAccessTokenRequest) (*MintAccessTokenResponse, error)
+	// GetEffectiveConfig retrieves the fully resolved configuration of the
+	// current node along with the source of every setting, with secrets redacted.
+	GetEffectiveConfig(context.Context, *emptypb.Empty) (*GetEffectiveConfigResponse, error)
+	// EstimateSize estimates the number of keys and the size of the given
+	// key range, without scanning it. Such estimates are meant for capacity
+	// planning and for deciding the split points of shards.
+	EstimateSize(context.Context, *EstimateSizeRequest) (*EstimateSizeResponse, error)
+	// ReportReadAmplification samples the reads served over the given window
+	// and reports their efficiency, along with suggestions for tuning the
+	// block cache, bloom filters and compaction.
+	ReportReadAmplification(context.Context, *ReadAmplificationRequest) (*ReadAmplificationResponse, error)
+	// RotateEncryptionKey reloads the master keys used for encryption at rest
+	// and re-encrypts all the values of the local storage using the latest key.
+	// Every node of a cluster holds its own storage, hence must be rotated.
+	RotateEncryptionKey(context.Context, *RotateEncryptionKeyRequest) (*RotateEncryptionKeyResponse, error)
+	// RotateTenantKey generates a new data key for the given tenant and
+	// re-encrypts all the values of that tenant using it. The new data key is
+	// replicated along with the re-encrypted values, hence only the master is
+	// to be rotated.
+	RotateTenantKey(context.Context, *RotateTenantKeyRequest) (*RotateEncryptionKeyResponse, error)
+	// DeleteTenant irrecoverably erases all the data of the given tenant by
+	// destroying its data keys.
+	DeleteTenant(context.Context, *DeleteTenantRequest) (*Status, error)
+	// GetEngineStats retrieves the internal statistics of the storage engine,
+	// such as the memtable size and the number of files per level.
+	GetEngineStats(context.Context, *emptypb.Empty) (*GetEngineStatsResponse, error)
+	// IngestSST bulk loads the externally built SST files at the given paths
+	// on the node, bypassing the WAL and memtables. Since the ingested keys
+	// are not replicated, it is meant for initial loads before any replica
+	// is added.
+	IngestSST(context.Context, *IngestSSTRequest) (*Status, error)
+	// Export writes a consistent snapshot of the given key range into files
+	// at the given location on the node, or uploads them to object storage,
+	// for offline analytics and migrations.
+	Export(context.Context, *ExportRequest) (*ExportResponse, error)
+	// ListQuarantinedRecords retrieves the records that were found to be
+	// corrupt or were rejected, and hence were set aside in the quarantine
+	// namespace of the node instead of being served or applied.
+	ListQuarantinedRecords(context.Context, *emptypb.Empty) (*ListQuarantinedRecordsResponse, error)
+	// SetMemtableOptions changes the memtable and level 0 settings of the
+	// node, until it restarts, so that bursts of writes can be absorbed
+	// without a restart. Only the options given are changed.
+	SetMemtableOptions(context.Context, *MemtableOptions) (*Status, error)
+	// BeginPrefixRename begins renaming the given key prefix to the new prefix.
+	// Masters copy the keys of the old prefix to the new prefix in the
+	// background, without overwriting the keys already written under the new
+	// prefix, while the reads of the keys absent under the new prefix fall
+	// back to the old prefix on both the masters and the slaves. Beginning an
+	// interrupted rename again resumes it.
+	BeginPrefixRename(context.Context, *PrefixRenameRequest) (*Status, error)
+	// CompletePrefixRename ends the fallback of the reads to the old prefix.
+	// Masters then delete the keys of the old prefix in the background, once
+	// they are copied to the new prefix.
+	CompletePrefixRename(context.Context, *PrefixRenameRequest) (*Status, error)
+	// ListPrefixRenames retrieves the key prefix renames in progress.
+	ListPrefixRenames(context.Context, *emptypb.Empty) (*ListPrefixRenamesResponse, error)
+	// GetKeyHeatmap retrieves the read and write rates of the node across the
+	// key range buckets for every minute over the given number of minutes,
+	// estimated from the sampled calls, so that the hot ranges are revealed.
+	GetKeyHeatmap(context.Context, *GetKeyHeatmapRequest) (*GetKeyHeatmapResponse, error)
+	// GetKeyPolicies retrieves the key policies published by the node, to
+	// which the keys built by the clients for the namespaces must conform.
+	GetKeyPolicies(context.Context, *emptypb.Empty) (*GetKeyPoliciesResponse, error)
+}
+
+// UnimplementedDKVAdminServer can be embedded to have forward compatible implementations.
+type UnimplementedDKVAdminServer struct {
+}
+
+func (*UnimplementedDKVAdminServer) CompactRange(context.Context, *CompactRangeRequest) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompactRange not implemented")
+}
+func (*UnimplementedDKVAdminServer) PlaceLegalHold(context.Context, *LegalHoldRequest) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlaceLegalHold not implemented")
+}
+func (*UnimplementedDKVAdminServer) LiftLegalHold(context.Context, *LegalHoldRequest) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LiftLegalHold not implemented")
+}
+func (*UnimplementedDKVAdminServer) ListLegalHolds(context.Context, *emptypb.Empty) (*ListLegalHoldsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLegalHolds not implemented")
+}
+func (*UnimplementedDKVAdminServer) DeleteSubject(context.Context, *DeleteSubjectRequest) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSubject not implemented")
+}
+func (*UnimplementedDKVAdminServer) MintAccessToken(context.Context, *MintAccessTokenRequest) (*MintAccessTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MintAccessToken not implemented")
+}
+func (*UnimplementedDKVAdminServer) GetEffectiveConfig(context.Context, *emptypb.Empty) (*GetEffectiveConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEffectiveConfig not implemented")
+}
+func (*UnimplementedDKVAdminServer) EstimateSize(context.Context, *EstimateSizeRequest) (*EstimateSizeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EstimateSize not implemented")
+}
+func (*UnimplementedDKVAdminServer) ReportReadAmplification(context.Context, *ReadAmplificationRequest) (*ReadAmplificationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportReadAmplification not implemented")
+}
+func (*UnimplementedDKVAdminServer) RotateEncryptionKey(context.Context, *RotateEncryptionKeyRequest) (*RotateEncryptionKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateEncryptionKey not implemented")
+}
+func (*UnimplementedDKVAdminServer) RotateTenantKey(context.Context, *RotateTenantKeyRequest) (*RotateEncryptionKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateTenantKey not implemented")
+}
+func (*UnimplementedDKVAdminServer) DeleteTenant(context.Context, *DeleteTenantRequest) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTenant not implemented")
+}
+func (*UnimplementedDKVAdminServer) GetEngineStats(context.Context, *emptypb.Empty) (*GetEngineStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEngineStats not implemented")
+}
+func (*UnimplementedDKVAdminServer) IngestSST(context.Context, *IngestSSTRequest) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IngestSST not implemented")
+}
+func (*UnimplementedDKVAdminServer) Export(context.Context, *ExportRequest) (*ExportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Export not implemented")
+}
+func (*UnimplementedDKVAdminServer) ListQuarantinedRecords(context.Context, *emptypb.Empty) (*ListQuarantinedRecordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListQuarantinedRecords not implemented")
+}
+func (*UnimplementedDKVAdminServer) SetMemtableOptions(context.Context, *MemtableOptions) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMemtableOptions not implemented")
+}
+func (*UnimplementedDKVAdminServer) BeginPrefixRename(context.Context, *PrefixRenameRequest) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BeginPrefixRename not implemented")
+}
+func (*UnimplementedDKVAdminServer) CompletePrefixRename(context.Context, *PrefixRenameRequest) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompletePrefixRename not implemented")
 }
-func (*UnimplementedDKVReplicationServer) RemoveReplica(context.Context, *Replica) (*Status, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RemoveReplica not implemented")
+func (*UnimplementedDKVAdminServer) ListPrefixRenames(context.Context, *emptypb.Empty) (*ListPrefixRenamesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPrefixRenames not implemented")
 }
-func (*UnimplementedDKVReplicationServer) GetReplicas(context.Context, *GetReplicasRequest) (*GetReplicasResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetReplicas not implemented")
+func (*UnimplementedDKVAdminServer) GetKeyHeatmap(context.Context, *GetKeyHeatmapRequest) (*GetKeyHeatmapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetKeyHeatmap not implemented")
+}
+func (*UnimplementedDKVAdminServer) GetKeyPolicies(context.Context, *emptypb.Empty) (*GetKeyPoliciesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetKeyPolicies not implemented")
 }
 
-func RegisterDKVReplicationServer(s *grpc.Server, srv DKVReplicationServer) {
-	s.RegisterService(&_DKVReplication_serviceDesc, srv)
+func RegisterDKVAdminServer(s *grpc.Server, srv DKVAdminServer) {
+	s.RegisterService(&_DKVAdmin_serviceDesc, srv)
 }
 
-func _DKVReplication_GetChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetChangesRequest)
+func _DKVAdmin_CompactRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompactRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVAdminServer).CompactRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKVAdmin/CompactRange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVAdminServer).CompactRange(ctx, req.(*CompactRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DKVAdmin_PlaceLegalHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LegalHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVAdminServer).PlaceLegalHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKVAdmin/PlaceLegalHold",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVAdminServer).PlaceLegalHold(ctx, req.(*LegalHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DKVAdmin_LiftLegalHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LegalHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVAdminServer).LiftLegalHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKVAdmin/LiftLegalHold",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVAdminServer).LiftLegalHold(ctx, req.(*LegalHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DKVAdmin_ListLegalHolds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVAdminServer).ListLegalHolds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKVAdmin/ListLegalHolds",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVAdminServer).ListLegalHolds(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DKVAdmin_DeleteSubject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSubjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVAdminServer).DeleteSubject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKVAdmin/DeleteSubject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVAdminServer).DeleteSubject(ctx, req.(*DeleteSubjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DKVAdmin_MintAccessToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MintAccessTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVAdminServer).MintAccessToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKVAdmin/MintAccessToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVAdminServer).MintAccessToken(ctx, req.(*MintAccessTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DKVAdmin_GetEffectiveConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVAdminServer).GetEffectiveConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKVAdmin/GetEffectiveConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVAdminServer).GetEffectiveConfig(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DKVAdmin_EstimateSize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EstimateSizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVAdminServer).EstimateSize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKVAdmin/EstimateSize",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVAdminServer).EstimateSize(ctx, req.(*EstimateSizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DKVAdmin_ReportReadAmplification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadAmplificationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVAdminServer).ReportReadAmplification(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKVAdmin/ReportReadAmplification",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVAdminServer).ReportReadAmplification(ctx, req.(*ReadAmplificationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DKVAdmin_RotateEncryptionKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateEncryptionKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVAdminServer).RotateEncryptionKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKVAdmin/RotateEncryptionKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVAdminServer).RotateEncryptionKey(ctx, req.(*RotateEncryptionKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DKVAdmin_RotateTenantKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateTenantKeyRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DKVReplicationServer).GetChanges(ctx, in)
+		return srv.(DKVAdminServer).RotateTenantKey(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/dkv.serverpb.DKVReplication/GetChanges",
+		FullMethod: "/dkv.serverpb.DKVAdmin/RotateTenantKey",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DKVReplicationServer).GetChanges(ctx, req.(*GetChangesRequest))
+		return srv.(DKVAdminServer).RotateTenantKey(ctx, req.(*RotateTenantKeyRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DKVReplication_AddReplica_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Replica)
+func _DKVAdmin_DeleteTenant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTenantRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DKVReplicationServer).AddReplica(ctx, in)
+		return srv.(DKVAdminServer).DeleteTenant(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/dkv.serverpb.DKVReplication/AddReplica",
+		FullMethod: "/dkv.serverpb.DKVAdmin/DeleteTenant",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DKVReplicationServer).AddReplica(ctx, req.(*Replica))
+		return srv.(DKVAdminServer).DeleteTenant(ctx, req.(*DeleteTenantRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DKVReplication_RemoveReplica_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Replica)
+func _DKVAdmin_GetEngineStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DKVReplicationServer).RemoveReplica(ctx, in)
+		return srv.(DKVAdminServer).GetEngineStats(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/dkv.serverpb.DKVReplication/RemoveReplica",
+		FullMethod: "/dkv.serverpb.DKVAdmin/GetEngineStats",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DKVReplicationServer).RemoveReplica(ctx, req.(*Replica))
+		return srv.(DKVAdminServer).GetEngineStats(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DKVReplication_GetReplicas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetReplicasRequest)
+func _DKVAdmin_IngestSST_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IngestSSTRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DKVReplicationServer).GetReplicas(ctx, in)
+		return srv.(DKVAdminServer).IngestSST(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/dkv.serverpb.DKVReplication/GetReplicas",
+		FullMethod: "/dkv.serverpb.DKVAdmin/IngestSST",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DKVReplicationServer).GetReplicas(ctx, req.(*GetReplicasRequest))
+		return srv.(DKVAdminServer).IngestSST(ctx, req.(*IngestSSTRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _DKVReplication_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "dkv.serverpb.DKVReplication",
-	HandlerType: (*DKVReplicationServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GetChanges",
-			Handler:    _DKVReplication_GetChanges_Handler,
-		},
-		{
-			MethodName: "AddReplica",
-			Handler:    _DKVReplication_AddReplica_Handler,
-		},
-		{
-			MethodName: "RemoveReplica",
-			Handler:    _DKVReplication_RemoveReplica_Handler,
-		},
-		{
-			MethodName: "GetReplicas",
-			Handler:    _DKVReplication_GetReplicas_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "pkg/serverpb/admin.proto",
-}
-
-// DKVBackupRestoreClient is the client API for DKVBackupRestore service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type DKVBackupRestoreClient interface {
-	// Backup backs up the entire keyspace into the given filesystem location.
-	Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*Status, error)
-	// Restore restores the entire keyspace from an existing backup at the
-	// given filesystem location.
-	Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*Status, error)
-}
-
-type dKVBackupRestoreClient struct {
-	cc grpc.ClientConnInterface
-}
-
-func NewDKVBackupRestoreClient(cc grpc.ClientConnInterface) DKVBackupRestoreClient {
-	return &dKVBackupRestoreClient{cc}
-}
-
-func (c *dKVBackupRestoreClient) Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*Status, error) {
-	out := new(Status)
-	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVBackupRestore/Backup", in, out, opts...)
-	if err != nil {
+func _DKVAdmin_Export_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DKVAdminServer).Export(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKVAdmin/Export",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVAdminServer).Export(ctx, req.(*ExportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *dKVBackupRestoreClient) Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*Status, error) {
-	out := new(Status)
-	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVBackupRestore/Restore", in, out, opts...)
-	if err != nil {
+func _DKVAdmin_ListQuarantinedRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DKVAdminServer).ListQuarantinedRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKVAdmin/ListQuarantinedRecords",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVAdminServer).ListQuarantinedRecords(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// DKVBackupRestoreServer is the server API for DKVBackupRestore service.
-type DKVBackupRestoreServer interface {
-	// Backup backs up the entire keyspace into the given filesystem location.
-	Backup(context.Context, *BackupRequest) (*Status, error)
-	// Restore restores the entire keyspace from an existing backup at the
-	// given filesystem location.
-	Restore(context.Context, *RestoreRequest) (*Status, error)
+func _DKVAdmin_SetMemtableOptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MemtableOptions)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVAdminServer).SetMemtableOptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKVAdmin/SetMemtableOptions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVAdminServer).SetMemtableOptions(ctx, req.(*MemtableOptions))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// UnimplementedDKVBackupRestoreServer can be embedded to have forward compatible implementations.
-type UnimplementedDKVBackupRestoreServer struct {
+func _DKVAdmin_BeginPrefixRename_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PrefixRenameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVAdminServer).BeginPrefixRename(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKVAdmin/BeginPrefixRename",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVAdminServer).BeginPrefixRename(ctx, req.(*PrefixRenameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (*UnimplementedDKVBackupRestoreServer) Backup(context.Context, *BackupRequest) (*Status, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Backup not implemented")
-}
-func (*UnimplementedDKVBackupRestoreServer) Restore(context.Context, *RestoreRequest) (*Status, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
+func _DKVAdmin_CompletePrefixRename_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PrefixRenameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVAdminServer).CompletePrefixRename(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKVAdmin/CompletePrefixRename",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVAdminServer).CompletePrefixRename(ctx, req.(*PrefixRenameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterDKVBackupRestoreServer(s *grpc.Server, srv DKVBackupRestoreServer) {
-	s.RegisterService(&_DKVBackupRestore_serviceDesc, srv)
+func _DKVAdmin_ListPrefixRenames_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVAdminServer).ListPrefixRenames(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKVAdmin/ListPrefixRenames",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVAdminServer).ListPrefixRenames(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _DKVBackupRestore_Backup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(BackupRequest)
+func _DKVAdmin_GetKeyHeatmap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetKeyHeatmapRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DKVBackupRestoreServer).Backup(ctx, in)
+		return srv.(DKVAdminServer).GetKeyHeatmap(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/dkv.serverpb.DKVBackupRestore/Backup",
+		FullMethod: "/dkv.serverpb.DKVAdmin/GetKeyHeatmap",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DKVBackupRestoreServer).Backup(ctx, req.(*BackupRequest))
+		return srv.(DKVAdminServer).GetKeyHeatmap(ctx, req.(*GetKeyHeatmapRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DKVBackupRestore_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RestoreRequest)
+func _DKVAdmin_GetKeyPolicies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DKVBackupRestoreServer).Restore(ctx, in)
+		return srv.(DKVAdminServer).GetKeyPolicies(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/dkv.serverpb.DKVBackupRestore/Restore",
+		FullMethod: "/dkv.serverpb.DKVAdmin/GetKeyPolicies",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DKVBackupRestoreServer).Restore(ctx, req.(*RestoreRequest))
+		return srv.(DKVAdminServer).GetKeyPolicies(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _DKVBackupRestore_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "dkv.serverpb.DKVBackupRestore",
-	HandlerType: (*DKVBackupRestoreServer)(nil),
+var _DKVAdmin_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "dkv.serverpb.DKVAdmin",
+	HandlerType: (*DKVAdminServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "Backup",
-			Handler:    _DKVBackupRestore_Backup_Handler,
+			MethodName: "CompactRange",
+			Handler:    _DKVAdmin_CompactRange_Handler,
 		},
 		{
-			MethodName: "Restore",
-			Handler:    _DKVBackupRestore_Restore_Handler,
+			MethodName: "PlaceLegalHold",
+			Handler:    _DKVAdmin_PlaceLegalHold_Handler,
+		},
+		{
+			MethodName: "LiftLegalHold",
+			Handler:    _DKVAdmin_LiftLegalHold_Handler,
+		},
+		{
+			MethodName: "ListLegalHolds",
+			Handler:    _DKVAdmin_ListLegalHolds_Handler,
+		},
+		{
+			MethodName: "DeleteSubject",
+			Handler:    _DKVAdmin_DeleteSubject_Handler,
+		},
+		{
+			MethodName: "MintAccessToken",
+			Handler:    _DKVAdmin_MintAccessToken_Handler,
+		},
+		{
+			MethodName: "GetEffectiveConfig",
+			Handler:    _DKVAdmin_GetEffectiveConfig_Handler,
+		},
+		{
+			MethodName: "EstimateSize",
+			Handler:    _DKVAdmin_EstimateSize_Handler,
+		},
+		{
+			MethodName: "ReportReadAmplification",
+			Handler:    _DKVAdmin_ReportReadAmplification_Handler,
+		},
+		{
+			MethodName: "RotateEncryptionKey",
+			Handler:    _DKVAdmin_RotateEncryptionKey_Handler,
+		},
+		{
+			MethodName: "RotateTenantKey",
+			Handler:    _DKVAdmin_RotateTenantKey_Handler,
+		},
+		{
+			MethodName: "DeleteTenant",
+			Handler:    _DKVAdmin_DeleteTenant_Handler,
+		},
+		{
+			MethodName: "GetEngineStats",
+			Handler:    _DKVAdmin_GetEngineStats_Handler,
+		},
+		{
+			MethodName: "IngestSST",
+			Handler:    _DKVAdmin_IngestSST_Handler,
+		},
+		{
+			MethodName: "Export",
+			Handler:    _DKVAdmin_Export_Handler,
+		},
+		{
+			MethodName: "ListQuarantinedRecords",
+			Handler:    _DKVAdmin_ListQuarantinedRecords_Handler,
+		},
+		{
+			MethodName: "SetMemtableOptions",
+			Handler:    _DKVAdmin_SetMemtableOptions_Handler,
+		},
+		{
+			MethodName: "BeginPrefixRename",
+			Handler:    _DKVAdmin_BeginPrefixRename_Handler,
+		},
+		{
+			MethodName: "CompletePrefixRename",
+			Handler:    _DKVAdmin_CompletePrefixRename_Handler,
+		},
+		{
+			MethodName: "ListPrefixRenames",
+			Handler:    _DKVAdmin_ListPrefixRenames_Handler,
+		},
+		{
+			MethodName: "GetKeyHeatmap",
+			Handler:    _DKVAdmin_GetKeyHeatmap_Handler,
+		},
+		{
+			MethodName: "GetKeyPolicies",
+			Handler:    _DKVAdmin_GetKeyPolicies_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
@@ -2103,6 +10031,12 @@ type DKVDiscoveryClient interface {
 	UpdateStatus(ctx context.Context, in *UpdateStatusRequest, opts ...grpc.CallOption) (*Status, error)
 	// Retrieve all active nodes in cluster with their status after filtering based on request params
 	GetClusterInfo(ctx context.Context, in *GetClusterInfoRequest, opts ...grpc.CallOption) (*GetClusterInfoResponse, error)
+	// Back up all the shards of the given database through their leaders and record
+	// the change number of every shard backup in a cluster backup manifest
+	BackupCluster(ctx context.Context, in *BackupClusterRequest, opts ...grpc.CallOption) (*BackupClusterResponse, error)
+	// Restore all the shards of a database from a cluster backup, each upto the change number
+	// recorded in the cluster backup manifest, along with the slaves replicating from them
+	RestoreCluster(ctx context.Context, in *RestoreClusterRequest, opts ...grpc.CallOption) (*RestoreClusterResponse, error)
 }
 
 type dKVDiscoveryClient struct {
@@ -2131,12 +10065,36 @@ func (c *dKVDiscoveryClient) GetClusterInfo(ctx context.Context, in *GetClusterI
 	return out, nil
 }
 
+func (c *dKVDiscoveryClient) BackupCluster(ctx context.Context, in *BackupClusterRequest, opts ...grpc.CallOption) (*BackupClusterResponse, error) {
+	out := new(BackupClusterResponse)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVDiscovery/BackupCluster", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dKVDiscoveryClient) RestoreCluster(ctx context.Context, in *RestoreClusterRequest, opts ...grpc.CallOption) (*RestoreClusterResponse, error) {
+	out := new(RestoreClusterResponse)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKVDiscovery/RestoreCluster", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DKVDiscoveryServer is the server API for DKVDiscovery service.
 type DKVDiscoveryServer interface {
 	// Update status of the given database and vBucket
 	UpdateStatus(context.Context, *UpdateStatusRequest) (*Status, error)
 	// Retrieve all active nodes in cluster with their status after filtering based on request params
 	GetClusterInfo(context.Context, *GetClusterInfoRequest) (*GetClusterInfoResponse, error)
+	// Back up all the shards of the given database through their leaders and record
+	// the change number of every shard backup in a cluster backup manifest
+	BackupCluster(context.Context, *BackupClusterRequest) (*BackupClusterResponse, error)
+	// Restore all the shards of a database from a cluster backup, each upto the change number
+	// recorded in the cluster backup manifest, along with the slaves replicating from them
+	RestoreCluster(context.Context, *RestoreClusterRequest) (*RestoreClusterResponse, error)
 }
 
 // UnimplementedDKVDiscoveryServer can be embedded to have forward compatible implementations.
@@ -2149,6 +10107,12 @@ func (*UnimplementedDKVDiscoveryServer) UpdateStatus(context.Context, *UpdateSta
 func (*UnimplementedDKVDiscoveryServer) GetClusterInfo(context.Context, *GetClusterInfoRequest) (*GetClusterInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetClusterInfo not implemented")
 }
+func (*UnimplementedDKVDiscoveryServer) BackupCluster(context.Context, *BackupClusterRequest) (*BackupClusterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BackupCluster not implemented")
+}
+func (*UnimplementedDKVDiscoveryServer) RestoreCluster(context.Context, *RestoreClusterRequest) (*RestoreClusterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreCluster not implemented")
+}
 
 func RegisterDKVDiscoveryServer(s *grpc.Server, srv DKVDiscoveryServer) {
 	s.RegisterService(&_DKVDiscovery_serviceDesc, srv)
@@ -2190,6 +10154,42 @@ func _DKVDiscovery_GetClusterInfo_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DKVDiscovery_BackupCluster_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackupClusterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVDiscoveryServer).BackupCluster(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKVDiscovery/BackupCluster",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVDiscoveryServer).BackupCluster(ctx, req.(*BackupClusterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DKVDiscovery_RestoreCluster_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreClusterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVDiscoveryServer).RestoreCluster(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKVDiscovery/RestoreCluster",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVDiscoveryServer).RestoreCluster(ctx, req.(*RestoreClusterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _DKVDiscovery_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "dkv.serverpb.DKVDiscovery",
 	HandlerType: (*DKVDiscoveryServer)(nil),
@@ -2202,6 +10202,14 @@ var _DKVDiscovery_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetClusterInfo",
 			Handler:    _DKVDiscovery_GetClusterInfo_Handler,
 		},
+		{
+			MethodName: "BackupCluster",
+			Handler:    _DKVDiscovery_BackupCluster_Handler,
+		},
+		{
+			MethodName: "RestoreCluster",
+			Handler:    _DKVDiscovery_RestoreCluster_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/serverpb/admin.proto",
@@ -24,6 +24,175 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// StatusCode enumerates the codes of the failures that the clients can
+// act upon. Other failures carry the code -1.
+type StatusCode int32
+
+const (
+	// The operation succeeded.
+	StatusCode_STATUS_OK StatusCode = 0
+	// The write was made on a slave, which only applies the changes of the
+	// node it replicates from. Such failures are reported as GRPC errors
+	// with the FailedPrecondition code, carrying this status as a detail.
+	StatusCode_REPLICA_READ_ONLY StatusCode = 1
+)
+
+// Enum value maps for StatusCode.
+var (
+	StatusCode_name = map[int32]string{
+		0: "STATUS_OK",
+		1: "REPLICA_READ_ONLY",
+	}
+	StatusCode_value = map[string]int32{
+		"STATUS_OK":         0,
+		"REPLICA_READ_ONLY": 1,
+	}
+)
+
+func (x StatusCode) Enum() *StatusCode {
+	p := new(StatusCode)
+	*p = x
+	return p
+}
+
+func (x StatusCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StatusCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_serverpb_api_proto_enumTypes[0].Descriptor()
+}
+
+func (StatusCode) Type() protoreflect.EnumType {
+	return &file_pkg_serverpb_api_proto_enumTypes[0]
+}
+
+func (x StatusCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StatusCode.Descriptor instead.
+func (StatusCode) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{0}
+}
+
+// WALSync indicates the desired durability of a write request.
+type WALSync int32
+
+const (
+	// Uses the WAL sync policy configured on the server.
+	WALSync_DEFAULT_WAL_SYNC WALSync = 0
+	// Fsyncs the WAL before acknowledging the write.
+	WALSync_SYNC_WAL WALSync = 1
+	// Acknowledges the write without waiting for the WAL to be fsynced.
+	WALSync_ASYNC_WAL WALSync = 2
+)
+
+// Enum value maps for WALSync.
+var (
+	WALSync_name = map[int32]string{
+		0: "DEFAULT_WAL_SYNC",
+		1: "SYNC_WAL",
+		2: "ASYNC_WAL",
+	}
+	WALSync_value = map[string]int32{
+		"DEFAULT_WAL_SYNC": 0,
+		"SYNC_WAL":         1,
+		"ASYNC_WAL":        2,
+	}
+)
+
+func (x WALSync) Enum() *WALSync {
+	p := new(WALSync)
+	*p = x
+	return p
+}
+
+func (x WALSync) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WALSync) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_serverpb_api_proto_enumTypes[1].Descriptor()
+}
+
+func (WALSync) Type() protoreflect.EnumType {
+	return &file_pkg_serverpb_api_proto_enumTypes[1]
+}
+
+func (x WALSync) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WALSync.Descriptor instead.
+func (WALSync) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{1}
+}
+
+// Durability indicates how durable a write is at the time it is acknowledged.
+type Durability int32
+
+const (
+	// Durability of the write is not known, as reported by storage engines
+	// that do not expose whether their WAL is fsynced. Also reported for the
+	// writes that skip the WAL altogether.
+	Durability_UNKNOWN_DURABILITY Durability = 0
+	// Written to the local WAL, without waiting for it to be fsynced.
+	Durability_LOCAL_WAL_WRITTEN Durability = 1
+	// Written to the local WAL and fsynced.
+	Durability_LOCAL_WAL_SYNCED Durability = 2
+	// Committed through consensus by a quorum of the replicas.
+	Durability_CONSENSUS_COMMITTED Durability = 3
+	// Applied by the requested number of slaves, which replicate from the
+	// master asynchronously.
+	Durability_SLAVE_REPLICATED Durability = 4
+)
+
+// Enum value maps for Durability.
+var (
+	Durability_name = map[int32]string{
+		0: "UNKNOWN_DURABILITY",
+		1: "LOCAL_WAL_WRITTEN",
+		2: "LOCAL_WAL_SYNCED",
+		3: "CONSENSUS_COMMITTED",
+		4: "SLAVE_REPLICATED",
+	}
+	Durability_value = map[string]int32{
+		"UNKNOWN_DURABILITY":  0,
+		"LOCAL_WAL_WRITTEN":   1,
+		"LOCAL_WAL_SYNCED":    2,
+		"CONSENSUS_COMMITTED": 3,
+		"SLAVE_REPLICATED":    4,
+	}
+)
+
+func (x Durability) Enum() *Durability {
+	p := new(Durability)
+	*p = x
+	return p
+}
+
+func (x Durability) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Durability) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_serverpb_api_proto_enumTypes[2].Descriptor()
+}
+
+func (Durability) Type() protoreflect.EnumType {
+	return &file_pkg_serverpb_api_proto_enumTypes[2]
+}
+
+func (x Durability) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Durability.Descriptor instead.
+func (Durability) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{2}
+}
+
 // ReadConsistency indicates the desired level of consistency for read requests.
 type ReadConsistency int32
 
@@ -58,11 +227,11 @@ func (x ReadConsistency) String() string {
 }
 
 func (ReadConsistency) Descriptor() protoreflect.EnumDescriptor {
-	return file_pkg_serverpb_api_proto_enumTypes[0].Descriptor()
+	return file_pkg_serverpb_api_proto_enumTypes[3].Descriptor()
 }
 
 func (ReadConsistency) Type() protoreflect.EnumType {
-	return &file_pkg_serverpb_api_proto_enumTypes[0]
+	return &file_pkg_serverpb_api_proto_enumTypes[3]
 }
 
 func (x ReadConsistency) Number() protoreflect.EnumNumber {
@@ -71,7 +240,107 @@ func (x ReadConsistency) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ReadConsistency.Descriptor instead.
 func (ReadConsistency) EnumDescriptor() ([]byte, []int) {
-	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{0}
+	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{3}
+}
+
+// ReadTier indicates the storage tiers from which a read may be served.
+type ReadTier int32
+
+const (
+	// Reads through the memtables, the block cache and the storage files.
+	ReadTier_ALL_TIERS ReadTier = 0
+	// Reads only from the memtables and the block cache, failing the read
+	// of the keys whose blocks are not cached, thereby never touching disk.
+	ReadTier_BLOCK_CACHE_TIER ReadTier = 1
+)
+
+// Enum value maps for ReadTier.
+var (
+	ReadTier_name = map[int32]string{
+		0: "ALL_TIERS",
+		1: "BLOCK_CACHE_TIER",
+	}
+	ReadTier_value = map[string]int32{
+		"ALL_TIERS":        0,
+		"BLOCK_CACHE_TIER": 1,
+	}
+)
+
+func (x ReadTier) Enum() *ReadTier {
+	p := new(ReadTier)
+	*p = x
+	return p
+}
+
+func (x ReadTier) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReadTier) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_serverpb_api_proto_enumTypes[4].Descriptor()
+}
+
+func (ReadTier) Type() protoreflect.EnumType {
+	return &file_pkg_serverpb_api_proto_enumTypes[4]
+}
+
+func (x ReadTier) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReadTier.Descriptor instead.
+func (ReadTier) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{4}
+}
+
+// LockMode indicates how an advisory lock is held.
+type LockMode int32
+
+const (
+	// Held by a single owner, such as a writer of the key.
+	LockMode_EXCLUSIVE LockMode = 0
+	// Held by any number of owners, such as the readers of the key, while
+	// no owner holds the lock exclusively.
+	LockMode_SHARED LockMode = 1
+)
+
+// Enum value maps for LockMode.
+var (
+	LockMode_name = map[int32]string{
+		0: "EXCLUSIVE",
+		1: "SHARED",
+	}
+	LockMode_value = map[string]int32{
+		"EXCLUSIVE": 0,
+		"SHARED":    1,
+	}
+)
+
+func (x LockMode) Enum() *LockMode {
+	p := new(LockMode)
+	*p = x
+	return p
+}
+
+func (x LockMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LockMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_serverpb_api_proto_enumTypes[5].Descriptor()
+}
+
+func (LockMode) Type() protoreflect.EnumType {
+	return &file_pkg_serverpb_api_proto_enumTypes[5]
+}
+
+func (x LockMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LockMode.Descriptor instead.
+func (LockMode) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{5}
 }
 
 type KVPair struct {
@@ -216,6 +485,10 @@ type CompareAndSetResponse struct {
 	// Updated indicates if the given new value was overwritten following
 	// a successful comparison with the given old value.
 	Updated bool `protobuf:"varint,2,opt,name=updated,proto3" json:"updated,omitempty"`
+	// ChangeNumber is the change number of the serving node as of which the
+	// write is committed. Reads carrying it as their MinChangeNumber observe
+	// the write, even when served by slaves.
+	ChangeNumber uint64 `protobuf:"varint,3,opt,name=changeNumber,proto3" json:"changeNumber,omitempty"`
 }
 
 func (x *CompareAndSetResponse) Reset() {
@@ -264,20 +537,26 @@ func (x *CompareAndSetResponse) GetUpdated() bool {
 	return false
 }
 
-type Status struct {
+func (x *CompareAndSetResponse) GetChangeNumber() uint64 {
+	if x != nil {
+		return x.ChangeNumber
+	}
+	return 0
+}
+
+type MergeRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Code captures the error code of the underlying operation.
-	// A non zero error code is considered to be a failure.
-	Code int32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
-	// Message captures if any the error message of the failed operation.
-	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// Key is the key whose value is merged with the given operand.
+	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// Operand is the input to the merge operator configured for the key.
+	Operand []byte `protobuf:"bytes,2,opt,name=operand,proto3" json:"operand,omitempty"`
 }
 
-func (x *Status) Reset() {
-	*x = Status{}
+func (x *MergeRequest) Reset() {
+	*x = MergeRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_serverpb_api_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -285,13 +564,13 @@ func (x *Status) Reset() {
 	}
 }
 
-func (x *Status) String() string {
+func (x *MergeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Status) ProtoMessage() {}
+func (*MergeRequest) ProtoMessage() {}
 
-func (x *Status) ProtoReflect() protoreflect.Message {
+func (x *MergeRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_serverpb_api_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -303,40 +582,40 @@ func (x *Status) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Status.ProtoReflect.Descriptor instead.
-func (*Status) Descriptor() ([]byte, []int) {
+// Deprecated: Use MergeRequest.ProtoReflect.Descriptor instead.
+func (*MergeRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *Status) GetCode() int32 {
+func (x *MergeRequest) GetKey() []byte {
 	if x != nil {
-		return x.Code
+		return x.Key
 	}
-	return 0
+	return nil
 }
 
-func (x *Status) GetMessage() string {
+func (x *MergeRequest) GetOperand() []byte {
 	if x != nil {
-		return x.Message
+		return x.Operand
 	}
-	return ""
+	return nil
 }
 
-type PutRequest struct {
+type MergeResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Key is the key, in bytes, to put into the key value store.
-	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	// Value is the value, in bytes, to associate with the key in the key value store.
-	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
-	// expireTS is the epoch seconds value, in int64 to associate with the key in the key value store.
-	ExpireTS uint64 `protobuf:"varint,3,opt,name=expireTS,proto3" json:"expireTS,omitempty"`
+	// Status indicates the result of the merge operation.
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// ChangeNumber is the change number of the serving node as of which the
+	// write is committed. Reads carrying it as their MinChangeNumber observe
+	// the write, even when served by slaves.
+	ChangeNumber uint64 `protobuf:"varint,2,opt,name=changeNumber,proto3" json:"changeNumber,omitempty"`
 }
 
-func (x *PutRequest) Reset() {
-	*x = PutRequest{}
+func (x *MergeResponse) Reset() {
+	*x = MergeResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_serverpb_api_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -344,13 +623,13 @@ func (x *PutRequest) Reset() {
 	}
 }
 
-func (x *PutRequest) String() string {
+func (x *MergeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PutRequest) ProtoMessage() {}
+func (*MergeResponse) ProtoMessage() {}
 
-func (x *PutRequest) ProtoReflect() protoreflect.Message {
+func (x *MergeResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_serverpb_api_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -362,42 +641,60 @@ func (x *PutRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PutRequest.ProtoReflect.Descriptor instead.
-func (*PutRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use MergeResponse.ProtoReflect.Descriptor instead.
+func (*MergeResponse) Descriptor() ([]byte, []int) {
 	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *PutRequest) GetKey() []byte {
-	if x != nil {
-		return x.Key
-	}
-	return nil
-}
-
-func (x *PutRequest) GetValue() []byte {
+func (x *MergeResponse) GetStatus() *Status {
 	if x != nil {
-		return x.Value
+		return x.Status
 	}
 	return nil
 }
 
-func (x *PutRequest) GetExpireTS() uint64 {
+func (x *MergeResponse) GetChangeNumber() uint64 {
 	if x != nil {
-		return x.ExpireTS
+		return x.ChangeNumber
 	}
 	return 0
 }
 
-type MultiPutRequest struct {
+type ScanRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PutRequest []*PutRequest `protobuf:"bytes,1,rep,name=putRequest,proto3" json:"putRequest,omitempty"`
-}
-
-func (x *MultiPutRequest) Reset() {
-	*x = MultiPutRequest{}
+	// KeyPrefix can be used to scan through keys that have this prefix.
+	KeyPrefix []byte `protobuf:"bytes,1,opt,name=keyPrefix,proto3" json:"keyPrefix,omitempty"`
+	// StartKey can be used to begin the scan from the specified key.
+	StartKey []byte `protobuf:"bytes,2,opt,name=startKey,proto3" json:"startKey,omitempty"`
+	// Snapshot optionally refers to a published snapshot, in the `namespace@name`
+	// format, that is scanned instead of the live keyspace.
+	Snapshot string `protobuf:"bytes,3,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	// PageSize is the maximum number of key value pairs returned in a page.
+	PageSize uint32 `protobuf:"varint,4,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	// ContinuationToken is the token returned with the previous page. When set,
+	// the scan resumes from where that page ended, in which case KeyPrefix and
+	// Snapshot must be the same as those of the first page.
+	ContinuationToken string `protobuf:"bytes,5,opt,name=continuationToken,proto3" json:"continuationToken,omitempty"`
+	// ReadOptions optionally tunes how the storage engine serves this scan.
+	// Pages after the first are served by the cursor opened for the first
+	// page, hence only the options of the first page take effect.
+	ReadOptions *ReadOptions `protobuf:"bytes,6,opt,name=readOptions,proto3" json:"readOptions,omitempty"`
+	// PinSnapshot serves all the pages of this scan from a snapshot of the
+	// keyspace taken for the first page, so that a long scan observes a single
+	// consistent view even while writes proceed. The snapshot is released once
+	// the scan completes or its cursor expires. Only the first page's flag
+	// takes effect.
+	PinSnapshot bool `protobuf:"varint,7,opt,name=pinSnapshot,proto3" json:"pinSnapshot,omitempty"`
+	// CloseCursor closes the cursor of the given continuation token without
+	// returning any page, for abandoning a scan before it completes.
+	CloseCursor bool `protobuf:"varint,8,opt,name=closeCursor,proto3" json:"closeCursor,omitempty"`
+}
+
+func (x *ScanRequest) Reset() {
+	*x = ScanRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_serverpb_api_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -405,13 +702,13 @@ func (x *MultiPutRequest) Reset() {
 	}
 }
 
-func (x *MultiPutRequest) String() string {
+func (x *ScanRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MultiPutRequest) ProtoMessage() {}
+func (*ScanRequest) ProtoMessage() {}
 
-func (x *MultiPutRequest) ProtoReflect() protoreflect.Message {
+func (x *ScanRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_serverpb_api_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -423,29 +720,83 @@ func (x *MultiPutRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MultiPutRequest.ProtoReflect.Descriptor instead.
-func (*MultiPutRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ScanRequest.ProtoReflect.Descriptor instead.
+func (*ScanRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *MultiPutRequest) GetPutRequest() []*PutRequest {
+func (x *ScanRequest) GetKeyPrefix() []byte {
 	if x != nil {
-		return x.PutRequest
+		return x.KeyPrefix
 	}
 	return nil
 }
 
-type PutResponse struct {
+func (x *ScanRequest) GetStartKey() []byte {
+	if x != nil {
+		return x.StartKey
+	}
+	return nil
+}
+
+func (x *ScanRequest) GetSnapshot() string {
+	if x != nil {
+		return x.Snapshot
+	}
+	return ""
+}
+
+func (x *ScanRequest) GetPageSize() uint32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ScanRequest) GetContinuationToken() string {
+	if x != nil {
+		return x.ContinuationToken
+	}
+	return ""
+}
+
+func (x *ScanRequest) GetReadOptions() *ReadOptions {
+	if x != nil {
+		return x.ReadOptions
+	}
+	return nil
+}
+
+func (x *ScanRequest) GetPinSnapshot() bool {
+	if x != nil {
+		return x.PinSnapshot
+	}
+	return false
+}
+
+func (x *ScanRequest) GetCloseCursor() bool {
+	if x != nil {
+		return x.CloseCursor
+	}
+	return false
+}
+
+type ScanResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Status indicates the result of the Put operation.
+	// Status indicates the result of the scan operation.
 	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// Items are the key value pairs of this page.
+	Items []*KVPair `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	// ContinuationToken is used for retrieving the next page. It is empty
+	// once the scan is complete.
+	ContinuationToken string `protobuf:"bytes,3,opt,name=continuationToken,proto3" json:"continuationToken,omitempty"`
 }
 
-func (x *PutResponse) Reset() {
-	*x = PutResponse{}
+func (x *ScanResponse) Reset() {
+	*x = ScanResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_serverpb_api_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -453,13 +804,13 @@ func (x *PutResponse) Reset() {
 	}
 }
 
-func (x *PutResponse) String() string {
+func (x *ScanResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PutResponse) ProtoMessage() {}
+func (*ScanResponse) ProtoMessage() {}
 
-func (x *PutResponse) ProtoReflect() protoreflect.Message {
+func (x *ScanResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_serverpb_api_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -471,29 +822,49 @@ func (x *PutResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PutResponse.ProtoReflect.Descriptor instead.
-func (*PutResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ScanResponse.ProtoReflect.Descriptor instead.
+func (*ScanResponse) Descriptor() ([]byte, []int) {
 	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *PutResponse) GetStatus() *Status {
+func (x *ScanResponse) GetStatus() *Status {
 	if x != nil {
 		return x.Status
 	}
 	return nil
 }
 
-type DeleteRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+func (x *ScanResponse) GetItems() []*KVPair {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ScanResponse) GetContinuationToken() string {
+	if x != nil {
+		return x.ContinuationToken
+	}
+	return ""
+}
+
+type Status struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Key is the key, in bytes, to delete from the key value store.
-	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// Code captures the error code of the underlying operation.
+	// A non zero error code is considered to be a failure.
+	Code int32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	// Message captures if any the error message of the failed operation.
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// MasterAddress is the address of the node to which the writes rejected
+	// with the REPLICA_READ_ONLY code are to be redirected.
+	MasterAddress string `protobuf:"bytes,3,opt,name=masterAddress,proto3" json:"masterAddress,omitempty"`
 }
 
-func (x *DeleteRequest) Reset() {
-	*x = DeleteRequest{}
+func (x *Status) Reset() {
+	*x = Status{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_serverpb_api_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -501,13 +872,13 @@ func (x *DeleteRequest) Reset() {
 	}
 }
 
-func (x *DeleteRequest) String() string {
+func (x *Status) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteRequest) ProtoMessage() {}
+func (*Status) ProtoMessage() {}
 
-func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
+func (x *Status) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_serverpb_api_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -519,29 +890,49 @@ func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
-func (*DeleteRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Status.ProtoReflect.Descriptor instead.
+func (*Status) Descriptor() ([]byte, []int) {
 	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *DeleteRequest) GetKey() []byte {
+func (x *Status) GetCode() int32 {
 	if x != nil {
-		return x.Key
+		return x.Code
 	}
-	return nil
+	return 0
 }
 
-type DeleteResponse struct {
+func (x *Status) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Status) GetMasterAddress() string {
+	if x != nil {
+		return x.MasterAddress
+	}
+	return ""
+}
+
+type PutRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Status indicates the result of the Delete operation.
-	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// Key is the key, in bytes, to put into the key value store.
+	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// Value is the value, in bytes, to associate with the key in the key value store.
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// expireTS is the epoch seconds value, in int64 to associate with the key in the key value store.
+	ExpireTS uint64 `protobuf:"varint,3,opt,name=expireTS,proto3" json:"expireTS,omitempty"`
+	// WalSync optionally overrides the WAL sync policy configured on the server for this PUT request.
+	WalSync WALSync `protobuf:"varint,4,opt,name=walSync,proto3,enum=dkv.serverpb.WALSync" json:"walSync,omitempty"`
 }
 
-func (x *DeleteResponse) Reset() {
-	*x = DeleteResponse{}
+func (x *PutRequest) Reset() {
+	*x = PutRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_serverpb_api_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -549,13 +940,13 @@ func (x *DeleteResponse) Reset() {
 	}
 }
 
-func (x *DeleteResponse) String() string {
+func (x *PutRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteResponse) ProtoMessage() {}
+func (*PutRequest) ProtoMessage() {}
 
-func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
+func (x *PutRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_serverpb_api_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -567,31 +958,49 @@ func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteResponse.ProtoReflect.Descriptor instead.
-func (*DeleteResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use PutRequest.ProtoReflect.Descriptor instead.
+func (*PutRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *DeleteResponse) GetStatus() *Status {
+func (x *PutRequest) GetKey() []byte {
 	if x != nil {
-		return x.Status
+		return x.Key
 	}
 	return nil
 }
 
-type GetRequest struct {
+func (x *PutRequest) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *PutRequest) GetExpireTS() uint64 {
+	if x != nil {
+		return x.ExpireTS
+	}
+	return 0
+}
+
+func (x *PutRequest) GetWalSync() WALSync {
+	if x != nil {
+		return x.WalSync
+	}
+	return WALSync_DEFAULT_WAL_SYNC
+}
+
+type MultiPutRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Key is the key, in bytes, whose associated value is loaded from the key value store.
-	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	// Desired read consistency level for this GET request.
-	ReadConsistency ReadConsistency `protobuf:"varint,2,opt,name=readConsistency,proto3,enum=dkv.serverpb.ReadConsistency" json:"readConsistency,omitempty"`
+	PutRequest []*PutRequest `protobuf:"bytes,1,rep,name=putRequest,proto3" json:"putRequest,omitempty"`
 }
 
-func (x *GetRequest) Reset() {
-	*x = GetRequest{}
+func (x *MultiPutRequest) Reset() {
+	*x = MultiPutRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_serverpb_api_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -599,13 +1008,13 @@ func (x *GetRequest) Reset() {
 	}
 }
 
-func (x *GetRequest) String() string {
+func (x *MultiPutRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetRequest) ProtoMessage() {}
+func (*MultiPutRequest) ProtoMessage() {}
 
-func (x *GetRequest) ProtoReflect() protoreflect.Message {
+func (x *MultiPutRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_serverpb_api_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -617,38 +1026,39 @@ func (x *GetRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetRequest.ProtoReflect.Descriptor instead.
-func (*GetRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use MultiPutRequest.ProtoReflect.Descriptor instead.
+func (*MultiPutRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *GetRequest) GetKey() []byte {
+func (x *MultiPutRequest) GetPutRequest() []*PutRequest {
 	if x != nil {
-		return x.Key
+		return x.PutRequest
 	}
 	return nil
 }
 
-func (x *GetRequest) GetReadConsistency() ReadConsistency {
-	if x != nil {
-		return x.ReadConsistency
-	}
-	return ReadConsistency_SEQUENTIAL
-}
-
-type GetResponse struct {
+type PutResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Status indicates the result of the Get operation.
+	// Status indicates the result of the Put operation.
 	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
-	// Value is the value, in bytes, that is associated with the given key in the key value store.
-	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// Durability indicates how durable the write is at the time it is acknowledged.
+	Durability Durability `protobuf:"varint,2,opt,name=durability,proto3,enum=dkv.serverpb.Durability" json:"durability,omitempty"`
+	// NumReplicas is the number of nodes known to hold the write at the time
+	// it is acknowledged, including the node serving the Put operation. Slaves
+	// replicate asynchronously and hence are never accounted for.
+	NumReplicas uint32 `protobuf:"varint,3,opt,name=numReplicas,proto3" json:"numReplicas,omitempty"`
+	// ChangeNumber is the change number of the serving node as of which the
+	// write is committed. Reads carrying it as their MinChangeNumber observe
+	// the write, even when served by slaves.
+	ChangeNumber uint64 `protobuf:"varint,4,opt,name=changeNumber,proto3" json:"changeNumber,omitempty"`
 }
 
-func (x *GetResponse) Reset() {
-	*x = GetResponse{}
+func (x *PutResponse) Reset() {
+	*x = PutResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_serverpb_api_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -656,13 +1066,13 @@ func (x *GetResponse) Reset() {
 	}
 }
 
-func (x *GetResponse) String() string {
+func (x *PutResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetResponse) ProtoMessage() {}
+func (*PutResponse) ProtoMessage() {}
 
-func (x *GetResponse) ProtoReflect() protoreflect.Message {
+func (x *PutResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_serverpb_api_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -674,38 +1084,61 @@ func (x *GetResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetResponse.ProtoReflect.Descriptor instead.
-func (*GetResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use PutResponse.ProtoReflect.Descriptor instead.
+func (*PutResponse) Descriptor() ([]byte, []int) {
 	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *GetResponse) GetStatus() *Status {
+func (x *PutResponse) GetStatus() *Status {
 	if x != nil {
 		return x.Status
 	}
 	return nil
 }
 
-func (x *GetResponse) GetValue() []byte {
+func (x *PutResponse) GetDurability() Durability {
 	if x != nil {
-		return x.Value
+		return x.Durability
 	}
-	return nil
+	return Durability_UNKNOWN_DURABILITY
 }
 
-type MultiGetRequest struct {
+func (x *PutResponse) GetNumReplicas() uint32 {
+	if x != nil {
+		return x.NumReplicas
+	}
+	return 0
+}
+
+func (x *PutResponse) GetChangeNumber() uint64 {
+	if x != nil {
+		return x.ChangeNumber
+	}
+	return 0
+}
+
+type AwaitDurabilityRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Keys is the collection of keys whose values are returned from the bulk Get operation.
-	Keys [][]byte `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
-	// Desired read consistency level for this MultiGet request.
-	ReadConsistency ReadConsistency `protobuf:"varint,2,opt,name=readConsistency,proto3,enum=dkv.serverpb.ReadConsistency" json:"readConsistency,omitempty"`
-}
-
-func (x *MultiGetRequest) Reset() {
-	*x = MultiGetRequest{}
+	// ChangeNumber is the change number of the writes, as reported by their
+	// PutResponse, whose durability is to be reported.
+	ChangeNumber uint64 `protobuf:"varint,1,opt,name=changeNumber,proto3" json:"changeNumber,omitempty"`
+	// Durability is the desired durability of the writes, which must be one of
+	// LOCAL_WAL_SYNCED or SLAVE_REPLICATED.
+	Durability Durability `protobuf:"varint,2,opt,name=durability,proto3,enum=dkv.serverpb.Durability" json:"durability,omitempty"`
+	// MinReplicas is the number of slaves that must have applied the writes for
+	// them to be SLAVE_REPLICATED. Defaults to 1 when unset.
+	MinReplicas uint32 `protobuf:"varint,3,opt,name=minReplicas,proto3" json:"minReplicas,omitempty"`
+	// TimeoutMillis is the maximum duration to wait for the writes to reach the
+	// desired durability. When unset, the current durability is reported
+	// without waiting. Waiting for LOCAL_WAL_SYNCED fsyncs the WAL right away.
+	TimeoutMillis uint64 `protobuf:"varint,4,opt,name=timeoutMillis,proto3" json:"timeoutMillis,omitempty"`
+}
+
+func (x *AwaitDurabilityRequest) Reset() {
+	*x = AwaitDurabilityRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_serverpb_api_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -713,13 +1146,13 @@ func (x *MultiGetRequest) Reset() {
 	}
 }
 
-func (x *MultiGetRequest) String() string {
+func (x *AwaitDurabilityRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MultiGetRequest) ProtoMessage() {}
+func (*AwaitDurabilityRequest) ProtoMessage() {}
 
-func (x *MultiGetRequest) ProtoReflect() protoreflect.Message {
+func (x *AwaitDurabilityRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_serverpb_api_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -731,38 +1164,58 @@ func (x *MultiGetRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MultiGetRequest.ProtoReflect.Descriptor instead.
-func (*MultiGetRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AwaitDurabilityRequest.ProtoReflect.Descriptor instead.
+func (*AwaitDurabilityRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *MultiGetRequest) GetKeys() [][]byte {
+func (x *AwaitDurabilityRequest) GetChangeNumber() uint64 {
 	if x != nil {
-		return x.Keys
+		return x.ChangeNumber
 	}
-	return nil
+	return 0
 }
 
-func (x *MultiGetRequest) GetReadConsistency() ReadConsistency {
+func (x *AwaitDurabilityRequest) GetDurability() Durability {
 	if x != nil {
-		return x.ReadConsistency
+		return x.Durability
 	}
-	return ReadConsistency_SEQUENTIAL
+	return Durability_UNKNOWN_DURABILITY
 }
 
-type MultiGetResponse struct {
+func (x *AwaitDurabilityRequest) GetMinReplicas() uint32 {
+	if x != nil {
+		return x.MinReplicas
+	}
+	return 0
+}
+
+func (x *AwaitDurabilityRequest) GetTimeoutMillis() uint64 {
+	if x != nil {
+		return x.TimeoutMillis
+	}
+	return 0
+}
+
+type AwaitDurabilityResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Status indicates the result of the bulk Get operation.
+	// Status indicates the result of the AwaitDurability operation.
 	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
-	// Association of keys and values of the bulk Get operation.
-	KeyValues []*KVPair `protobuf:"bytes,2,rep,name=keyValues,proto3" json:"keyValues,omitempty"`
-}
-
-func (x *MultiGetResponse) Reset() {
-	*x = MultiGetResponse{}
+	// Durability is the highest durability reached by the writes, which falls
+	// short of the desired durability if the wait timed out.
+	Durability Durability `protobuf:"varint,2,opt,name=durability,proto3,enum=dkv.serverpb.Durability" json:"durability,omitempty"`
+	// SyncedChangeNumber is the change number upto which the writes are known
+	// to be fsynced to the WAL of the serving node.
+	SyncedChangeNumber uint64 `protobuf:"varint,3,opt,name=syncedChangeNumber,proto3" json:"syncedChangeNumber,omitempty"`
+	// NumReplicas is the number of slaves known to have applied the writes.
+	NumReplicas uint32 `protobuf:"varint,4,opt,name=numReplicas,proto3" json:"numReplicas,omitempty"`
+}
+
+func (x *AwaitDurabilityResponse) Reset() {
+	*x = AwaitDurabilityResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_serverpb_api_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -770,13 +1223,13 @@ func (x *MultiGetResponse) Reset() {
 	}
 }
 
-func (x *MultiGetResponse) String() string {
+func (x *AwaitDurabilityResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MultiGetResponse) ProtoMessage() {}
+func (*AwaitDurabilityResponse) ProtoMessage() {}
 
-func (x *MultiGetResponse) ProtoReflect() protoreflect.Message {
+func (x *AwaitDurabilityResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_serverpb_api_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -788,38 +1241,50 @@ func (x *MultiGetResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MultiGetResponse.ProtoReflect.Descriptor instead.
-func (*MultiGetResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use AwaitDurabilityResponse.ProtoReflect.Descriptor instead.
+func (*AwaitDurabilityResponse) Descriptor() ([]byte, []int) {
 	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *MultiGetResponse) GetStatus() *Status {
+func (x *AwaitDurabilityResponse) GetStatus() *Status {
 	if x != nil {
 		return x.Status
 	}
 	return nil
 }
 
-func (x *MultiGetResponse) GetKeyValues() []*KVPair {
+func (x *AwaitDurabilityResponse) GetDurability() Durability {
 	if x != nil {
-		return x.KeyValues
+		return x.Durability
 	}
-	return nil
+	return Durability_UNKNOWN_DURABILITY
 }
 
-type IterateRequest struct {
+func (x *AwaitDurabilityResponse) GetSyncedChangeNumber() uint64 {
+	if x != nil {
+		return x.SyncedChangeNumber
+	}
+	return 0
+}
+
+func (x *AwaitDurabilityResponse) GetNumReplicas() uint32 {
+	if x != nil {
+		return x.NumReplicas
+	}
+	return 0
+}
+
+type DeleteRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// KeyPrefix can be used to iterate through keys that have this prefix.
-	KeyPrefix []byte `protobuf:"bytes,1,opt,name=keyPrefix,proto3" json:"keyPrefix,omitempty"`
-	// StartKey can be used to begin the iteration from the specified key.
-	StartKey []byte `protobuf:"bytes,2,opt,name=startKey,proto3" json:"startKey,omitempty"`
+	// Key is the key, in bytes, to delete from the key value store.
+	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 }
 
-func (x *IterateRequest) Reset() {
-	*x = IterateRequest{}
+func (x *DeleteRequest) Reset() {
+	*x = DeleteRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_serverpb_api_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -827,13 +1292,13 @@ func (x *IterateRequest) Reset() {
 	}
 }
 
-func (x *IterateRequest) String() string {
+func (x *DeleteRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IterateRequest) ProtoMessage() {}
+func (*DeleteRequest) ProtoMessage() {}
 
-func (x *IterateRequest) ProtoReflect() protoreflect.Message {
+func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_serverpb_api_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -845,26 +1310,1156 @@ func (x *IterateRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IterateRequest.ProtoReflect.Descriptor instead.
-func (*IterateRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *IterateRequest) GetKeyPrefix() []byte {
-	if x != nil {
-		return x.KeyPrefix
-	}
-	return nil
-}
-
-func (x *IterateRequest) GetStartKey() []byte {
+func (x *DeleteRequest) GetKey() []byte {
 	if x != nil {
-		return x.StartKey
+		return x.Key
 	}
 	return nil
 }
 
-type IterateResponse struct {
+type DeleteResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Status indicates the result of the Delete operation.
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// ChangeNumber is the change number of the serving node as of which the
+	// write is committed. Reads carrying it as their MinChangeNumber observe
+	// the write, even when served by slaves.
+	ChangeNumber uint64 `protobuf:"varint,2,opt,name=changeNumber,proto3" json:"changeNumber,omitempty"`
+}
+
+func (x *DeleteResponse) Reset() {
+	*x = DeleteResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_api_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteResponse) ProtoMessage() {}
+
+func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_api_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteResponse.ProtoReflect.Descriptor instead.
+func (*DeleteResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DeleteResponse) GetStatus() *Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *DeleteResponse) GetChangeNumber() uint64 {
+	if x != nil {
+		return x.ChangeNumber
+	}
+	return 0
+}
+
+// ReadOptions tunes how the storage engine serves a read. These options only
+// apply to reads of the live keyspace and are ignored by the storage engines
+// that do not support them.
+type ReadOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// SkipBlockCacheFill avoids caching the blocks loaded by the read, so that
+	// large scans do not evict the hot working set from the block cache.
+	SkipBlockCacheFill bool `protobuf:"varint,1,opt,name=skipBlockCacheFill,proto3" json:"skipBlockCacheFill,omitempty"`
+	// SkipChecksums skips verifying the checksums of the blocks loaded by the
+	// read, which are otherwise verified.
+	SkipChecksums bool `protobuf:"varint,2,opt,name=skipChecksums,proto3" json:"skipChecksums,omitempty"`
+	// ReadTier restricts the storage tiers from which the read may be served.
+	ReadTier ReadTier `protobuf:"varint,3,opt,name=readTier,proto3,enum=dkv.serverpb.ReadTier" json:"readTier,omitempty"`
+}
+
+func (x *ReadOptions) Reset() {
+	*x = ReadOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_api_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReadOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadOptions) ProtoMessage() {}
+
+func (x *ReadOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_api_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadOptions.ProtoReflect.Descriptor instead.
+func (*ReadOptions) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ReadOptions) GetSkipBlockCacheFill() bool {
+	if x != nil {
+		return x.SkipBlockCacheFill
+	}
+	return false
+}
+
+func (x *ReadOptions) GetSkipChecksums() bool {
+	if x != nil {
+		return x.SkipChecksums
+	}
+	return false
+}
+
+func (x *ReadOptions) GetReadTier() ReadTier {
+	if x != nil {
+		return x.ReadTier
+	}
+	return ReadTier_ALL_TIERS
+}
+
+type GetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Key is the key, in bytes, whose associated value is loaded from the key value store.
+	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// Desired read consistency level for this GET request.
+	ReadConsistency ReadConsistency `protobuf:"varint,2,opt,name=readConsistency,proto3,enum=dkv.serverpb.ReadConsistency" json:"readConsistency,omitempty"`
+	// Snapshot optionally refers to a published snapshot, in the `namespace@name`
+	// format, from which the value is loaded instead of the live keyspace.
+	Snapshot string `protobuf:"bytes,3,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	// AsOfChangeNumber optionally refers to a historical change number, as of
+	// which the value is loaded. Such reads are only served within the version
+	// retention configured on the DKV instance.
+	AsOfChangeNumber uint64 `protobuf:"varint,4,opt,name=asOfChangeNumber,proto3" json:"asOfChangeNumber,omitempty"`
+	// MinChangeNumber optionally requires the serving node to have applied
+	// the change with this number before the read, typically the change number
+	// of an earlier write of the same session. Slaves that are behind wait for
+	// the change to be replicated, failing the read if it does not arrive in time.
+	MinChangeNumber uint64 `protobuf:"varint,5,opt,name=minChangeNumber,proto3" json:"minChangeNumber,omitempty"`
+	// ReadOptions optionally tunes how the storage engine serves this read.
+	ReadOptions *ReadOptions `protobuf:"bytes,6,opt,name=readOptions,proto3" json:"readOptions,omitempty"`
+}
+
+func (x *GetRequest) Reset() {
+	*x = GetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_api_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRequest) ProtoMessage() {}
+
+func (x *GetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_api_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRequest.ProtoReflect.Descriptor instead.
+func (*GetRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetRequest) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *GetRequest) GetReadConsistency() ReadConsistency {
+	if x != nil {
+		return x.ReadConsistency
+	}
+	return ReadConsistency_SEQUENTIAL
+}
+
+func (x *GetRequest) GetSnapshot() string {
+	if x != nil {
+		return x.Snapshot
+	}
+	return ""
+}
+
+func (x *GetRequest) GetAsOfChangeNumber() uint64 {
+	if x != nil {
+		return x.AsOfChangeNumber
+	}
+	return 0
+}
+
+func (x *GetRequest) GetMinChangeNumber() uint64 {
+	if x != nil {
+		return x.MinChangeNumber
+	}
+	return 0
+}
+
+func (x *GetRequest) GetReadOptions() *ReadOptions {
+	if x != nil {
+		return x.ReadOptions
+	}
+	return nil
+}
+
+type GetResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Status indicates the result of the Get operation.
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// Value is the value, in bytes, that is associated with the given key in the key value store.
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// ExpireTS is the expiry timestamp of the given key, if any.
+	ExpireTS uint64 `protobuf:"varint,3,opt,name=expireTS,proto3" json:"expireTS,omitempty"`
+	// NotFound indicates that the given key is absent, as opposed to being
+	// associated with an empty value.
+	NotFound bool `protobuf:"varint,4,opt,name=notFound,proto3" json:"notFound,omitempty"`
+}
+
+func (x *GetResponse) Reset() {
+	*x = GetResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_api_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetResponse) ProtoMessage() {}
+
+func (x *GetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_api_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetResponse.ProtoReflect.Descriptor instead.
+func (*GetResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetResponse) GetStatus() *Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *GetResponse) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *GetResponse) GetExpireTS() uint64 {
+	if x != nil {
+		return x.ExpireTS
+	}
+	return 0
+}
+
+func (x *GetResponse) GetNotFound() bool {
+	if x != nil {
+		return x.NotFound
+	}
+	return false
+}
+
+type ExistsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Key is the key whose presence is checked in the key value store.
+	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// Desired read consistency level for this Exists request.
+	ReadConsistency ReadConsistency `protobuf:"varint,2,opt,name=readConsistency,proto3,enum=dkv.serverpb.ReadConsistency" json:"readConsistency,omitempty"`
+	// Snapshot optionally refers to a published snapshot, in the `namespace@name`
+	// format, in which the presence is checked instead of the live keyspace.
+	Snapshot string `protobuf:"bytes,3,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+}
+
+func (x *ExistsRequest) Reset() {
+	*x = ExistsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_api_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExistsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExistsRequest) ProtoMessage() {}
+
+func (x *ExistsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_api_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExistsRequest.ProtoReflect.Descriptor instead.
+func (*ExistsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ExistsRequest) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *ExistsRequest) GetReadConsistency() ReadConsistency {
+	if x != nil {
+		return x.ReadConsistency
+	}
+	return ReadConsistency_SEQUENTIAL
+}
+
+func (x *ExistsRequest) GetSnapshot() string {
+	if x != nil {
+		return x.Snapshot
+	}
+	return ""
+}
+
+type ExistsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Status indicates the result of the Exists operation.
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// Exists indicates if the given key is present in the key value store.
+	Exists bool `protobuf:"varint,2,opt,name=exists,proto3" json:"exists,omitempty"`
+}
+
+func (x *ExistsResponse) Reset() {
+	*x = ExistsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_api_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExistsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExistsResponse) ProtoMessage() {}
+
+func (x *ExistsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_api_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExistsResponse.ProtoReflect.Descriptor instead.
+func (*ExistsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ExistsResponse) GetStatus() *Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *ExistsResponse) GetExists() bool {
+	if x != nil {
+		return x.Exists
+	}
+	return false
+}
+
+type TransactRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Reads is the collection of keys read by the transaction, along with the
+	// values observed by those reads. An empty value indicates that the key
+	// was observed to be absent.
+	Reads []*KVPair `protobuf:"bytes,1,rep,name=reads,proto3" json:"reads,omitempty"`
+	// Writes is the collection of changes committed by the transaction.
+	Writes []*TxnWrite `protobuf:"bytes,2,rep,name=writes,proto3" json:"writes,omitempty"`
+}
+
+func (x *TransactRequest) Reset() {
+	*x = TransactRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_api_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransactRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactRequest) ProtoMessage() {}
+
+func (x *TransactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_api_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactRequest.ProtoReflect.Descriptor instead.
+func (*TransactRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *TransactRequest) GetReads() []*KVPair {
+	if x != nil {
+		return x.Reads
+	}
+	return nil
+}
+
+func (x *TransactRequest) GetWrites() []*TxnWrite {
+	if x != nil {
+		return x.Writes
+	}
+	return nil
+}
+
+type TxnWrite struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Key is the key changed by the transaction.
+	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// Value is the new value of the key, unless the key is deleted.
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// Delete indicates if the key is deleted by the transaction.
+	Delete bool `protobuf:"varint,3,opt,name=delete,proto3" json:"delete,omitempty"`
+}
+
+func (x *TxnWrite) Reset() {
+	*x = TxnWrite{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_api_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TxnWrite) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxnWrite) ProtoMessage() {}
+
+func (x *TxnWrite) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_api_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxnWrite.ProtoReflect.Descriptor instead.
+func (*TxnWrite) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *TxnWrite) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *TxnWrite) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *TxnWrite) GetDelete() bool {
+	if x != nil {
+		return x.Delete
+	}
+	return false
+}
+
+type TransactResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Status indicates the result of the Transact operation.
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// ChangeNumber is the change number of the serving node as of which the
+	// write is committed. Reads carrying it as their MinChangeNumber observe
+	// the write, even when served by slaves.
+	ChangeNumber uint64 `protobuf:"varint,2,opt,name=changeNumber,proto3" json:"changeNumber,omitempty"`
+}
+
+func (x *TransactResponse) Reset() {
+	*x = TransactResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_api_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransactResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactResponse) ProtoMessage() {}
+
+func (x *TransactResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_api_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactResponse.ProtoReflect.Descriptor instead.
+func (*TransactResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *TransactResponse) GetStatus() *Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *TransactResponse) GetChangeNumber() uint64 {
+	if x != nil {
+		return x.ChangeNumber
+	}
+	return 0
+}
+
+type LockRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Key is the key whose advisory lock is acquired.
+	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// Owner identifies the client acquiring the lock.
+	Owner string `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	// LeaseMillis is the duration in milliseconds after which the lock
+	// expires, unless its lease is renewed by the owner.
+	LeaseMillis uint64 `protobuf:"varint,3,opt,name=leaseMillis,proto3" json:"leaseMillis,omitempty"`
+	// Mode is the mode in which the lock is acquired. The sole owner of a
+	// shared lock can upgrade it to an exclusive lock and vice versa.
+	Mode LockMode `protobuf:"varint,4,opt,name=mode,proto3,enum=dkv.serverpb.LockMode" json:"mode,omitempty"`
+}
+
+func (x *LockRequest) Reset() {
+	*x = LockRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_api_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LockRequest) ProtoMessage() {}
+
+func (x *LockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_api_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LockRequest.ProtoReflect.Descriptor instead.
+func (*LockRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *LockRequest) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *LockRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *LockRequest) GetLeaseMillis() uint64 {
+	if x != nil {
+		return x.LeaseMillis
+	}
+	return 0
+}
+
+func (x *LockRequest) GetMode() LockMode {
+	if x != nil {
+		return x.Mode
+	}
+	return LockMode_EXCLUSIVE
+}
+
+type LockResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Status indicates the result of the Lock operation.
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// Acquired indicates if the lock is now held by the given owner.
+	Acquired bool `protobuf:"varint,2,opt,name=acquired,proto3" json:"acquired,omitempty"`
+	// Owner identifies the client holding the lock, when it is not acquired.
+	// For a shared lock, this is one of its owners.
+	Owner string `protobuf:"bytes,3,opt,name=owner,proto3" json:"owner,omitempty"`
+	// ExpiresAt is the epoch in milliseconds at which the lease of the lock
+	// held by the owner expires. For a shared lock held by others, this is
+	// the latest expiry among their leases.
+	ExpiresAt int64 `protobuf:"varint,4,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	// Mode is the mode in which the lock is held.
+	Mode LockMode `protobuf:"varint,5,opt,name=mode,proto3,enum=dkv.serverpb.LockMode" json:"mode,omitempty"`
+}
+
+func (x *LockResponse) Reset() {
+	*x = LockResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_api_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LockResponse) ProtoMessage() {}
+
+func (x *LockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_api_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LockResponse.ProtoReflect.Descriptor instead.
+func (*LockResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *LockResponse) GetStatus() *Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *LockResponse) GetAcquired() bool {
+	if x != nil {
+		return x.Acquired
+	}
+	return false
+}
+
+func (x *LockResponse) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *LockResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *LockResponse) GetMode() LockMode {
+	if x != nil {
+		return x.Mode
+	}
+	return LockMode_EXCLUSIVE
+}
+
+type UnlockRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Key is the key whose advisory lock is released.
+	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// Owner identifies the client releasing the lock.
+	Owner string `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+}
+
+func (x *UnlockRequest) Reset() {
+	*x = UnlockRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_api_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlockRequest) ProtoMessage() {}
+
+func (x *UnlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_api_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlockRequest.ProtoReflect.Descriptor instead.
+func (*UnlockRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *UnlockRequest) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *UnlockRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+type UnlockResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Status indicates the result of the Unlock operation.
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// Released indicates if the lock was held by the given owner, unless
+	// it expired, and is now released.
+	Released bool `protobuf:"varint,2,opt,name=released,proto3" json:"released,omitempty"`
+}
+
+func (x *UnlockResponse) Reset() {
+	*x = UnlockResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_api_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnlockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlockResponse) ProtoMessage() {}
+
+func (x *UnlockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_api_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlockResponse.ProtoReflect.Descriptor instead.
+func (*UnlockResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *UnlockResponse) GetStatus() *Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *UnlockResponse) GetReleased() bool {
+	if x != nil {
+		return x.Released
+	}
+	return false
+}
+
+type MultiGetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Keys is the collection of keys whose values are returned from the bulk Get operation.
+	Keys [][]byte `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	// Desired read consistency level for this MultiGet request.
+	ReadConsistency ReadConsistency `protobuf:"varint,2,opt,name=readConsistency,proto3,enum=dkv.serverpb.ReadConsistency" json:"readConsistency,omitempty"`
+	// Snapshot optionally refers to a published snapshot, in the `namespace@name`
+	// format, from which the values are loaded instead of the live keyspace.
+	Snapshot string `protobuf:"bytes,3,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	// AsOfChangeNumber optionally refers to a historical change number, as of
+	// which the values are loaded. Such reads are only served within the version
+	// retention configured on the DKV instance.
+	AsOfChangeNumber uint64 `protobuf:"varint,4,opt,name=asOfChangeNumber,proto3" json:"asOfChangeNumber,omitempty"`
+	// MinChangeNumber optionally requires the serving node to have applied
+	// the change with this number before the read, typically the change number
+	// of an earlier write of the same session. Slaves that are behind wait for
+	// the change to be replicated, failing the read if it does not arrive in time.
+	MinChangeNumber uint64 `protobuf:"varint,5,opt,name=minChangeNumber,proto3" json:"minChangeNumber,omitempty"`
+	// ReadOptions optionally tunes how the storage engine serves this read.
+	ReadOptions *ReadOptions `protobuf:"bytes,6,opt,name=readOptions,proto3" json:"readOptions,omitempty"`
+}
+
+func (x *MultiGetRequest) Reset() {
+	*x = MultiGetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_api_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MultiGetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MultiGetRequest) ProtoMessage() {}
+
+func (x *MultiGetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_api_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MultiGetRequest.ProtoReflect.Descriptor instead.
+func (*MultiGetRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *MultiGetRequest) GetKeys() [][]byte {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *MultiGetRequest) GetReadConsistency() ReadConsistency {
+	if x != nil {
+		return x.ReadConsistency
+	}
+	return ReadConsistency_SEQUENTIAL
+}
+
+func (x *MultiGetRequest) GetSnapshot() string {
+	if x != nil {
+		return x.Snapshot
+	}
+	return ""
+}
+
+func (x *MultiGetRequest) GetAsOfChangeNumber() uint64 {
+	if x != nil {
+		return x.AsOfChangeNumber
+	}
+	return 0
+}
+
+func (x *MultiGetRequest) GetMinChangeNumber() uint64 {
+	if x != nil {
+		return x.MinChangeNumber
+	}
+	return 0
+}
+
+func (x *MultiGetRequest) GetReadOptions() *ReadOptions {
+	if x != nil {
+		return x.ReadOptions
+	}
+	return nil
+}
+
+type MultiGetResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Status indicates the result of the bulk Get operation.
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// Association of keys and values of the bulk Get operation, where
+	// the i-th pair belongs to the i-th requested key.
+	KeyValues []*KVPair `protobuf:"bytes,2,rep,name=keyValues,proto3" json:"keyValues,omitempty"`
+}
+
+func (x *MultiGetResponse) Reset() {
+	*x = MultiGetResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_api_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MultiGetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MultiGetResponse) ProtoMessage() {}
+
+func (x *MultiGetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_api_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MultiGetResponse.ProtoReflect.Descriptor instead.
+func (*MultiGetResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *MultiGetResponse) GetStatus() *Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *MultiGetResponse) GetKeyValues() []*KVPair {
+	if x != nil {
+		return x.KeyValues
+	}
+	return nil
+}
+
+type IterateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// KeyPrefix can be used to iterate through keys that have this prefix.
+	KeyPrefix []byte `protobuf:"bytes,1,opt,name=keyPrefix,proto3" json:"keyPrefix,omitempty"`
+	// StartKey can be used to begin the iteration from the specified key.
+	StartKey []byte `protobuf:"bytes,2,opt,name=startKey,proto3" json:"startKey,omitempty"`
+	// Snapshot optionally refers to a published snapshot, in the `namespace@name`
+	// format, that is iterated instead of the live keyspace.
+	Snapshot string `protobuf:"bytes,3,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	// ReadOptions optionally tunes how the storage engine serves this read.
+	ReadOptions *ReadOptions `protobuf:"bytes,4,opt,name=readOptions,proto3" json:"readOptions,omitempty"`
+	// PinSnapshot iterates through a snapshot of the keyspace taken when the
+	// iteration begins, so that a long iteration observes a single consistent
+	// view even while writes proceed. The snapshot is released once the stream
+	// ends, is cancelled or exceeds its deadline.
+	PinSnapshot bool `protobuf:"varint,5,opt,name=pinSnapshot,proto3" json:"pinSnapshot,omitempty"`
+}
+
+func (x *IterateRequest) Reset() {
+	*x = IterateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_api_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IterateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IterateRequest) ProtoMessage() {}
+
+func (x *IterateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_api_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IterateRequest.ProtoReflect.Descriptor instead.
+func (*IterateRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *IterateRequest) GetKeyPrefix() []byte {
+	if x != nil {
+		return x.KeyPrefix
+	}
+	return nil
+}
+
+func (x *IterateRequest) GetStartKey() []byte {
+	if x != nil {
+		return x.StartKey
+	}
+	return nil
+}
+
+func (x *IterateRequest) GetSnapshot() string {
+	if x != nil {
+		return x.Snapshot
+	}
+	return ""
+}
+
+func (x *IterateRequest) GetReadOptions() *ReadOptions {
+	if x != nil {
+		return x.ReadOptions
+	}
+	return nil
+}
+
+func (x *IterateRequest) GetPinSnapshot() bool {
+	if x != nil {
+		return x.PinSnapshot
+	}
+	return false
+}
+
+type IterateResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -880,7 +2475,7 @@ type IterateResponse struct {
 func (x *IterateResponse) Reset() {
 	*x = IterateResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_serverpb_api_proto_msgTypes[14]
+		mi := &file_pkg_serverpb_api_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -893,7 +2488,293 @@ func (x *IterateResponse) String() string {
 func (*IterateResponse) ProtoMessage() {}
 
 func (x *IterateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_serverpb_api_proto_msgTypes[14]
+	mi := &file_pkg_serverpb_api_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IterateResponse.ProtoReflect.Descriptor instead.
+func (*IterateResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *IterateResponse) GetStatus() *Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *IterateResponse) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *IterateResponse) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type ChecksumScanRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// KeyPrefix can be used to scan through keys that have this prefix.
+	KeyPrefix []byte `protobuf:"bytes,1,opt,name=keyPrefix,proto3" json:"keyPrefix,omitempty"`
+	// StartKey can be used to begin the scan from the specified key.
+	StartKey []byte `protobuf:"bytes,2,opt,name=startKey,proto3" json:"startKey,omitempty"`
+	// PinSnapshot scans through a snapshot of the keyspace taken when the
+	// scan begins, so that the checksums reflect a single consistent view.
+	PinSnapshot bool `protobuf:"varint,3,opt,name=pinSnapshot,proto3" json:"pinSnapshot,omitempty"`
+}
+
+func (x *ChecksumScanRequest) Reset() {
+	*x = ChecksumScanRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_api_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChecksumScanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChecksumScanRequest) ProtoMessage() {}
+
+func (x *ChecksumScanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_api_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChecksumScanRequest.ProtoReflect.Descriptor instead.
+func (*ChecksumScanRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ChecksumScanRequest) GetKeyPrefix() []byte {
+	if x != nil {
+		return x.KeyPrefix
+	}
+	return nil
+}
+
+func (x *ChecksumScanRequest) GetStartKey() []byte {
+	if x != nil {
+		return x.StartKey
+	}
+	return nil
+}
+
+func (x *ChecksumScanRequest) GetPinSnapshot() bool {
+	if x != nil {
+		return x.PinSnapshot
+	}
+	return false
+}
+
+type ChecksumScanResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Status captures any errors with the current scan.
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// Key of the current scan.
+	Key []byte `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// Checksum of the value and the expiry of the current key.
+	Checksum uint64 `protobuf:"varint,3,opt,name=checksum,proto3" json:"checksum,omitempty"`
+}
+
+func (x *ChecksumScanResponse) Reset() {
+	*x = ChecksumScanResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_api_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChecksumScanResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChecksumScanResponse) ProtoMessage() {}
+
+func (x *ChecksumScanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_api_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChecksumScanResponse.ProtoReflect.Descriptor instead.
+func (*ChecksumScanResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ChecksumScanResponse) GetStatus() *Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *ChecksumScanResponse) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *ChecksumScanResponse) GetChecksum() uint64 {
+	if x != nil {
+		return x.Checksum
+	}
+	return 0
+}
+
+type AggregateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// KeyPrefix can be used to aggregate the keys that have this prefix.
+	KeyPrefix []byte `protobuf:"bytes,1,opt,name=keyPrefix,proto3" json:"keyPrefix,omitempty"`
+	// StartKey can be used to begin the aggregation from the specified key.
+	StartKey []byte `protobuf:"bytes,2,opt,name=startKey,proto3" json:"startKey,omitempty"`
+	// EndKey can be used to end the aggregation before the specified key,
+	// which is excluded from the aggregates.
+	EndKey []byte `protobuf:"bytes,3,opt,name=endKey,proto3" json:"endKey,omitempty"`
+	// PinSnapshot aggregates a snapshot of the keyspace taken when the
+	// aggregation begins, so that the aggregates reflect a single consistent
+	// view.
+	PinSnapshot bool `protobuf:"varint,4,opt,name=pinSnapshot,proto3" json:"pinSnapshot,omitempty"`
+}
+
+func (x *AggregateRequest) Reset() {
+	*x = AggregateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_api_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AggregateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregateRequest) ProtoMessage() {}
+
+func (x *AggregateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_api_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggregateRequest.ProtoReflect.Descriptor instead.
+func (*AggregateRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *AggregateRequest) GetKeyPrefix() []byte {
+	if x != nil {
+		return x.KeyPrefix
+	}
+	return nil
+}
+
+func (x *AggregateRequest) GetStartKey() []byte {
+	if x != nil {
+		return x.StartKey
+	}
+	return nil
+}
+
+func (x *AggregateRequest) GetEndKey() []byte {
+	if x != nil {
+		return x.EndKey
+	}
+	return nil
+}
+
+func (x *AggregateRequest) GetPinSnapshot() bool {
+	if x != nil {
+		return x.PinSnapshot
+	}
+	return false
+}
+
+type AggregateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Status indicates the result of the Aggregate operation.
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// Count is the number of keys aggregated.
+	Count uint64 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// TotalValueBytes is the total size in bytes of the values of the keys.
+	TotalValueBytes uint64 `protobuf:"varint,3,opt,name=totalValueBytes,proto3" json:"totalValueBytes,omitempty"`
+	// MinKey is the smallest of the keys, absent when there are none.
+	MinKey []byte `protobuf:"bytes,4,opt,name=minKey,proto3" json:"minKey,omitempty"`
+	// MaxKey is the largest of the keys, absent when there are none.
+	MaxKey []byte `protobuf:"bytes,5,opt,name=maxKey,proto3" json:"maxKey,omitempty"`
+	// Sum is the sum of the values that are 8 byte big-endian encoded signed
+	// integers, wrapping around on overflow. Other values are left out.
+	Sum int64 `protobuf:"varint,6,opt,name=sum,proto3" json:"sum,omitempty"`
+	// NumSummed is the number of values included in the Sum.
+	NumSummed uint64 `protobuf:"varint,7,opt,name=numSummed,proto3" json:"numSummed,omitempty"`
+}
+
+func (x *AggregateResponse) Reset() {
+	*x = AggregateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_serverpb_api_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AggregateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregateResponse) ProtoMessage() {}
+
+func (x *AggregateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_serverpb_api_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -904,32 +2785,60 @@ func (x *IterateResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IterateResponse.ProtoReflect.Descriptor instead.
-func (*IterateResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use AggregateResponse.ProtoReflect.Descriptor instead.
+func (*AggregateResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_serverpb_api_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *IterateResponse) GetStatus() *Status {
+func (x *AggregateResponse) GetStatus() *Status {
 	if x != nil {
 		return x.Status
 	}
 	return nil
 }
 
-func (x *IterateResponse) GetKey() []byte {
+func (x *AggregateResponse) GetCount() uint64 {
 	if x != nil {
-		return x.Key
+		return x.Count
+	}
+	return 0
+}
+
+func (x *AggregateResponse) GetTotalValueBytes() uint64 {
+	if x != nil {
+		return x.TotalValueBytes
+	}
+	return 0
+}
+
+func (x *AggregateResponse) GetMinKey() []byte {
+	if x != nil {
+		return x.MinKey
 	}
 	return nil
 }
 
-func (x *IterateResponse) GetValue() []byte {
+func (x *AggregateResponse) GetMaxKey() []byte {
 	if x != nil {
-		return x.Value
+		return x.MaxKey
 	}
 	return nil
 }
 
+func (x *AggregateResponse) GetSum() int64 {
+	if x != nil {
+		return x.Sum
+	}
+	return 0
+}
+
+func (x *AggregateResponse) GetNumSummed() uint64 {
+	if x != nil {
+		return x.NumSummed
+	}
+	return 0
+}
+
 var File_pkg_serverpb_api_proto protoreflect.FileDescriptor
 
 var file_pkg_serverpb_api_proto_rawDesc = []byte{
@@ -946,112 +2855,399 @@ var file_pkg_serverpb_api_proto_rawDesc = []byte{
 	0x0a, 0x08, 0x6f, 0x6c, 0x64, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
 	0x52, 0x08, 0x6f, 0x6c, 0x64, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x65,
 	0x77, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x6e, 0x65,
-	0x77, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x5f, 0x0a, 0x15, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72,
-	0x65, 0x41, 0x6e, 0x64, 0x53, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a,
-	0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
-	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x22, 0x36, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
-	0x50, 0x0a, 0x0a, 0x50, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a,
-	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
-	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x54,
-	0x53, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x54,
-	0x53, 0x22, 0x4b, 0x0a, 0x0f, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x50, 0x75, 0x74, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x38, 0x0a, 0x0a, 0x70, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73,
-	0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x50, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x52, 0x0a, 0x70, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x3b,
-	0x0a, 0x0b, 0x50, 0x75, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a,
-	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
-	0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x21, 0x0a, 0x0d, 0x44,
-	0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03,
-	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x3e,
-	0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x77, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x83, 0x01, 0x0a, 0x15, 0x43, 0x6f, 0x6d, 0x70, 0x61,
+	0x72, 0x65, 0x41, 0x6e, 0x64, 0x53, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
 	0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
 	0x32, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x67,
-	0x0a, 0x0a, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03,
-	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x47,
-	0x0a, 0x0f, 0x72, 0x65, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63,
-	0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x73, 0x69,
-	0x73, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x0f, 0x72, 0x65, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x73,
-	0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x22, 0x51, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72,
-	0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x6e, 0x0a, 0x0f, 0x4d, 0x75,
-	0x6c, 0x74, 0x69, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
-	0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x04, 0x6b, 0x65, 0x79,
-	0x73, 0x12, 0x47, 0x0a, 0x0f, 0x72, 0x65, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74,
-	0x65, 0x6e, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x64, 0x6b, 0x76,
-	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x43, 0x6f,
-	0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x0f, 0x72, 0x65, 0x61, 0x64, 0x43,
-	0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x22, 0x74, 0x0a, 0x10, 0x4d, 0x75,
-	0x6c, 0x74, 0x69, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18,
+	0x0a, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x3a, 0x0a, 0x0c,
+	0x4d, 0x65, 0x72, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x18,
+	0x0a, 0x07, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x07, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x6e, 0x64, 0x22, 0x61, 0x0a, 0x0d, 0x4d, 0x65, 0x72, 0x67,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0xae, 0x02, 0x0a, 0x0b,
+	0x53, 0x63, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6b,
+	0x65, 0x79, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09,
+	0x6b, 0x65, 0x79, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x4b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x4b, 0x65, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x2c, 0x0a,
+	0x11, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e,
+	0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x3b, 0x0a, 0x0b, 0x72,
+	0x65, 0x61, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e,
+	0x52, 0x65, 0x61, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x0b, 0x72, 0x65, 0x61,
+	0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x69, 0x6e, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x70,
+	0x69, 0x6e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6c,
+	0x6f, 0x73, 0x65, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0b, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x22, 0x96, 0x01, 0x0a,
+	0x0c, 0x53, 0x63, 0x61, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2a, 0x0a, 0x05, 0x69,
+	0x74, 0x65, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64, 0x6b, 0x76,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x4b, 0x56, 0x50, 0x61, 0x69, 0x72,
+	0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x2c, 0x0a, 0x11, 0x63, 0x6f, 0x6e, 0x74, 0x69,
+	0x6e, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x11, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x5c, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63,
+	0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x24, 0x0a,
+	0x0d, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x22, 0x81, 0x01, 0x0a, 0x0a, 0x50, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78,
+	0x70, 0x69, 0x72, 0x65, 0x54, 0x53, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x65, 0x78,
+	0x70, 0x69, 0x72, 0x65, 0x54, 0x53, 0x12, 0x2f, 0x0a, 0x07, 0x77, 0x61, 0x6c, 0x53, 0x79, 0x6e,
+	0x63, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x57, 0x41, 0x4c, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x07,
+	0x77, 0x61, 0x6c, 0x53, 0x79, 0x6e, 0x63, 0x22, 0x4b, 0x0a, 0x0f, 0x4d, 0x75, 0x6c, 0x74, 0x69,
+	0x50, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x38, 0x0a, 0x0a, 0x70, 0x75,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x50, 0x75,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x0a, 0x70, 0x75, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0xbb, 0x01, 0x0a, 0x0b, 0x50, 0x75, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x38, 0x0a, 0x0a, 0x64, 0x75, 0x72, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79,
+	0x52, 0x0a, 0x64, 0x75, 0x72, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x20, 0x0a, 0x0b,
+	0x6e, 0x75, 0x6d, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0b, 0x6e, 0x75, 0x6d, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x12, 0x22,
+	0x0a, 0x0c, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x22, 0xbe, 0x01, 0x0a, 0x16, 0x41, 0x77, 0x61, 0x69, 0x74, 0x44, 0x75, 0x72, 0x61,
+	0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a,
+	0x0c, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0c, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x12, 0x38, 0x0a, 0x0a, 0x64, 0x75, 0x72, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x70, 0x62, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52,
+	0x0a, 0x64, 0x75, 0x72, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x6d,
+	0x69, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x0b, 0x6d, 0x69, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x12, 0x24, 0x0a,
+	0x0d, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x4d, 0x69, 0x6c,
+	0x6c, 0x69, 0x73, 0x22, 0xd3, 0x01, 0x0a, 0x17, 0x41, 0x77, 0x61, 0x69, 0x74, 0x44, 0x75, 0x72,
+	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x38, 0x0a,
+	0x0a, 0x64, 0x75, 0x72, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x18, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62,
+	0x2e, 0x44, 0x75, 0x72, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x0a, 0x64, 0x75, 0x72,
+	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x2e, 0x0a, 0x12, 0x73, 0x79, 0x6e, 0x63, 0x65,
+	0x64, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x12, 0x73, 0x79, 0x6e, 0x63, 0x65, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x6e, 0x75, 0x6d, 0x52, 0x65,
+	0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6e, 0x75,
+	0x6d, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x22, 0x21, 0x0a, 0x0d, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x62, 0x0a, 0x0e,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c,
 	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
 	0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x32, 0x0a, 0x09,
-	0x6b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x4b,
-	0x56, 0x50, 0x61, 0x69, 0x72, 0x52, 0x09, 0x6b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73,
-	0x22, 0x4a, 0x0a, 0x0e, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6b, 0x65, 0x79, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x6b, 0x65, 0x79, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78,
-	0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x08, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4b, 0x65, 0x79, 0x22, 0x67, 0x0a, 0x0f,
-	0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x22, 0x0a, 0x0c,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0c, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x22, 0x97, 0x01, 0x0a, 0x0b, 0x52, 0x65, 0x61, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x2e, 0x0a, 0x12, 0x73, 0x6b, 0x69, 0x70, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x43, 0x61, 0x63,
+	0x68, 0x65, 0x46, 0x69, 0x6c, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x73, 0x6b,
+	0x69, 0x70, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x43, 0x61, 0x63, 0x68, 0x65, 0x46, 0x69, 0x6c, 0x6c,
+	0x12, 0x24, 0x0a, 0x0d, 0x73, 0x6b, 0x69, 0x70, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x73, 0x6b, 0x69, 0x70, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x73, 0x75, 0x6d, 0x73, 0x12, 0x32, 0x0a, 0x08, 0x72, 0x65, 0x61, 0x64, 0x54, 0x69,
+	0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x54, 0x69, 0x65, 0x72,
+	0x52, 0x08, 0x72, 0x65, 0x61, 0x64, 0x54, 0x69, 0x65, 0x72, 0x22, 0x96, 0x02, 0x0a, 0x0a, 0x47,
+	0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x47, 0x0a, 0x0f, 0x72,
+	0x65, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74, 0x65,
+	0x6e, 0x63, 0x79, 0x52, 0x0f, 0x72, 0x65, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74,
+	0x65, 0x6e, 0x63, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x12, 0x2a, 0x0a, 0x10, 0x61, 0x73, 0x4f, 0x66, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x61, 0x73, 0x4f, 0x66,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x28, 0x0a, 0x0f,
+	0x6d, 0x69, 0x6e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x6d, 0x69, 0x6e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x3b, 0x0a, 0x0b, 0x72, 0x65, 0x61, 0x64, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x64, 0x6b,
+	0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x0b, 0x72, 0x65, 0x61, 0x64, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x22, 0x89, 0x01, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x70, 0x69, 0x72,
+	0x65, 0x54, 0x53, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x65, 0x78, 0x70, 0x69, 0x72,
+	0x65, 0x54, 0x53, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x6f, 0x74, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x6e, 0x6f, 0x74, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x22,
+	0x86, 0x01, 0x0a, 0x0d, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x47, 0x0a, 0x0f, 0x72, 0x65, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x73, 0x69,
+	0x73, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x64,
+	0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x61, 0x64,
+	0x43, 0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x0f, 0x72, 0x65, 0x61,
+	0x64, 0x43, 0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x1a, 0x0a, 0x08,
+	0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x22, 0x56, 0x0a, 0x0e, 0x45, 0x78, 0x69, 0x73,
+	0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64, 0x6b, 0x76,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x69, 0x73,
+	0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73,
+	0x22, 0x6d, 0x0a, 0x0f, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x05, 0x72, 0x65, 0x61, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70,
+	0x62, 0x2e, 0x4b, 0x56, 0x50, 0x61, 0x69, 0x72, 0x52, 0x05, 0x72, 0x65, 0x61, 0x64, 0x73, 0x12,
+	0x2e, 0x0a, 0x06, 0x77, 0x72, 0x69, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x16, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x54,
+	0x78, 0x6e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x52, 0x06, 0x77, 0x72, 0x69, 0x74, 0x65, 0x73, 0x22,
+	0x4a, 0x0a, 0x08, 0x54, 0x78, 0x6e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x22, 0x64, 0x0a, 0x10, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
 	0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
 	0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x10, 0x0a,
-	0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
-	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x2a, 0x33, 0x0a, 0x0f, 0x52, 0x65, 0x61, 0x64, 0x43, 0x6f, 0x6e,
-	0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x0e, 0x0a, 0x0a, 0x53, 0x45, 0x51, 0x55,
-	0x45, 0x4e, 0x54, 0x49, 0x41, 0x4c, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x4c, 0x49, 0x4e, 0x45,
-	0x41, 0x52, 0x49, 0x5a, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x01, 0x32, 0xf7, 0x03, 0x0a, 0x03, 0x44,
-	0x4b, 0x56, 0x12, 0x3a, 0x0a, 0x03, 0x50, 0x75, 0x74, 0x12, 0x18, 0x2e, 0x64, 0x6b, 0x76, 0x2e,
-	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x50, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
-	0x70, 0x62, 0x2e, 0x50, 0x75, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43,
-	0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x1b, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73,
-	0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76,
-	0x65, 0x72, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x03, 0x47, 0x65, 0x74, 0x12, 0x18, 0x2e, 0x64, 0x6b, 0x76,
-	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
-	0x72, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x49, 0x0a, 0x08, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x47, 0x65, 0x74, 0x12, 0x1d, 0x2e, 0x64, 0x6b,
-	0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69,
-	0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x64, 0x6b, 0x76,
-	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x47,
-	0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x08, 0x4d, 0x75,
-	0x6c, 0x74, 0x69, 0x50, 0x75, 0x74, 0x12, 0x1d, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72,
-	0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x50, 0x75, 0x74, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76,
-	0x65, 0x72, 0x70, 0x62, 0x2e, 0x50, 0x75, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x48, 0x0a, 0x07, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x2e, 0x64, 0x6b,
-	0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x61,
-	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x64, 0x6b, 0x76, 0x2e,
-	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x65,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x58, 0x0a, 0x0d, 0x43, 0x6f,
-	0x6d, 0x70, 0x61, 0x72, 0x65, 0x41, 0x6e, 0x64, 0x53, 0x65, 0x74, 0x12, 0x22, 0x2e, 0x64, 0x6b,
-	0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61,
-	0x72, 0x65, 0x41, 0x6e, 0x64, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x23, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x43,
-	0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x41, 0x6e, 0x64, 0x53, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
-	0x6f, 0x6d, 0x2f, 0x66, 0x6c, 0x69, 0x70, 0x6b, 0x61, 0x72, 0x74, 0x2d, 0x69, 0x6e, 0x63, 0x75,
-	0x62, 0x61, 0x74, 0x6f, 0x72, 0x2f, 0x64, 0x6b, 0x76, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x73, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x22, 0x0a,
+	0x0c, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0c, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x22, 0x83, 0x01, 0x0a, 0x0b, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x6c, 0x65, 0x61,
+	0x73, 0x65, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b,
+	0x6c, 0x65, 0x61, 0x73, 0x65, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x12, 0x2a, 0x0a, 0x04, 0x6d,
+	0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x64, 0x6b, 0x76, 0x2e,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x4c, 0x6f, 0x63, 0x6b, 0x4d, 0x6f, 0x64,
+	0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x22, 0xb8, 0x01, 0x0a, 0x0c, 0x4c, 0x6f, 0x63, 0x6b,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x63, 0x71, 0x75, 0x69, 0x72,
+	0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x61, 0x63, 0x71, 0x75, 0x69, 0x72,
+	0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x78, 0x70, 0x69,
+	0x72, 0x65, 0x73, 0x41, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x12, 0x2a, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x70, 0x62, 0x2e, 0x4c, 0x6f, 0x63, 0x6b, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f,
+	0x64, 0x65, 0x22, 0x37, 0x0a, 0x0d, 0x55, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x22, 0x5a, 0x0a, 0x0e, 0x55,
+	0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x72,
+	0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72,
+	0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x64, 0x22, 0x9d, 0x02, 0x0a, 0x0f, 0x4d, 0x75, 0x6c, 0x74,
+	0x69, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6b,
+	0x65, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x12,
+	0x47, 0x0a, 0x0f, 0x72, 0x65, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e,
+	0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x73,
+	0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x0f, 0x72, 0x65, 0x61, 0x64, 0x43, 0x6f, 0x6e,
+	0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x12, 0x2a, 0x0a, 0x10, 0x61, 0x73, 0x4f, 0x66, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10,
+	0x61, 0x73, 0x4f, 0x66, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x12, 0x28, 0x0a, 0x0f, 0x6d, 0x69, 0x6e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x6d, 0x69, 0x6e, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x3b, 0x0a, 0x0b, 0x72, 0x65,
+	0x61, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x52,
+	0x65, 0x61, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x0b, 0x72, 0x65, 0x61, 0x64,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x74, 0x0a, 0x10, 0x4d, 0x75, 0x6c, 0x74, 0x69,
+	0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64, 0x6b,
+	0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x32, 0x0a, 0x09, 0x6b, 0x65, 0x79,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64,
+	0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x4b, 0x56, 0x50, 0x61,
+	0x69, 0x72, 0x52, 0x09, 0x6b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0xc5, 0x01,
+	0x0a, 0x0e, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1c, 0x0a, 0x09, 0x6b, 0x65, 0x79, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x09, 0x6b, 0x65, 0x79, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x1a,
+	0x0a, 0x08, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x08, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4b, 0x65, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x3b, 0x0a, 0x0b, 0x72, 0x65, 0x61, 0x64, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x64, 0x6b,
+	0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x0b, 0x72, 0x65, 0x61, 0x64, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x69, 0x6e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x70, 0x69, 0x6e, 0x53, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x22, 0x67, 0x0a, 0x0f, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x71,
+	0x0a, 0x13, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x53, 0x63, 0x61, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6b, 0x65, 0x79, 0x50, 0x72, 0x65, 0x66,
+	0x69, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x6b, 0x65, 0x79, 0x50, 0x72, 0x65,
+	0x66, 0x69, 0x78, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4b, 0x65, 0x79, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4b, 0x65, 0x79, 0x12,
+	0x20, 0x0a, 0x0b, 0x70, 0x69, 0x6e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x70, 0x69, 0x6e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x22, 0x72, 0x0a, 0x14, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x53, 0x63, 0x61,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x65,
+	0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x63, 0x68, 0x65,
+	0x63, 0x6b, 0x73, 0x75, 0x6d, 0x22, 0x86, 0x01, 0x0a, 0x10, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6b, 0x65,
+	0x79, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x6b,
+	0x65, 0x79, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x4b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x4b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x64, 0x4b, 0x65, 0x79, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x65, 0x6e, 0x64, 0x4b, 0x65, 0x79, 0x12, 0x20, 0x0a, 0x0b,
+	0x70, 0x69, 0x6e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0b, 0x70, 0x69, 0x6e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x22, 0xe1,
+	0x01, 0x0a, 0x11, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x28, 0x0a, 0x0f, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x79, 0x74,
+	0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x69, 0x6e, 0x4b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x06, 0x6d, 0x69, 0x6e, 0x4b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61,
+	0x78, 0x4b, 0x65, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x6d, 0x61, 0x78, 0x4b,
+	0x65, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x75, 0x6d, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x03, 0x73, 0x75, 0x6d, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x75, 0x6d, 0x53, 0x75, 0x6d, 0x6d, 0x65,
+	0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x6e, 0x75, 0x6d, 0x53, 0x75, 0x6d, 0x6d,
+	0x65, 0x64, 0x2a, 0x32, 0x0a, 0x0a, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65,
+	0x12, 0x0d, 0x0a, 0x09, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x4f, 0x4b, 0x10, 0x00, 0x12,
+	0x15, 0x0a, 0x11, 0x52, 0x45, 0x50, 0x4c, 0x49, 0x43, 0x41, 0x5f, 0x52, 0x45, 0x41, 0x44, 0x5f,
+	0x4f, 0x4e, 0x4c, 0x59, 0x10, 0x01, 0x2a, 0x3c, 0x0a, 0x07, 0x57, 0x41, 0x4c, 0x53, 0x79, 0x6e,
+	0x63, 0x12, 0x14, 0x0a, 0x10, 0x44, 0x45, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x5f, 0x57, 0x41, 0x4c,
+	0x5f, 0x53, 0x59, 0x4e, 0x43, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x59, 0x4e, 0x43, 0x5f,
+	0x57, 0x41, 0x4c, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x41, 0x53, 0x59, 0x4e, 0x43, 0x5f, 0x57,
+	0x41, 0x4c, 0x10, 0x02, 0x2a, 0x80, 0x01, 0x0a, 0x0a, 0x44, 0x75, 0x72, 0x61, 0x62, 0x69, 0x6c,
+	0x69, 0x74, 0x79, 0x12, 0x16, 0x0a, 0x12, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x44,
+	0x55, 0x52, 0x41, 0x42, 0x49, 0x4c, 0x49, 0x54, 0x59, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x4c,
+	0x4f, 0x43, 0x41, 0x4c, 0x5f, 0x57, 0x41, 0x4c, 0x5f, 0x57, 0x52, 0x49, 0x54, 0x54, 0x45, 0x4e,
+	0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x4c, 0x4f, 0x43, 0x41, 0x4c, 0x5f, 0x57, 0x41, 0x4c, 0x5f,
+	0x53, 0x59, 0x4e, 0x43, 0x45, 0x44, 0x10, 0x02, 0x12, 0x17, 0x0a, 0x13, 0x43, 0x4f, 0x4e, 0x53,
+	0x45, 0x4e, 0x53, 0x55, 0x53, 0x5f, 0x43, 0x4f, 0x4d, 0x4d, 0x49, 0x54, 0x54, 0x45, 0x44, 0x10,
+	0x03, 0x12, 0x14, 0x0a, 0x10, 0x53, 0x4c, 0x41, 0x56, 0x45, 0x5f, 0x52, 0x45, 0x50, 0x4c, 0x49,
+	0x43, 0x41, 0x54, 0x45, 0x44, 0x10, 0x04, 0x2a, 0x33, 0x0a, 0x0f, 0x52, 0x65, 0x61, 0x64, 0x43,
+	0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x0e, 0x0a, 0x0a, 0x53, 0x45,
+	0x51, 0x55, 0x45, 0x4e, 0x54, 0x49, 0x41, 0x4c, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x4c, 0x49,
+	0x4e, 0x45, 0x41, 0x52, 0x49, 0x5a, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x01, 0x2a, 0x2f, 0x0a, 0x08,
+	0x52, 0x65, 0x61, 0x64, 0x54, 0x69, 0x65, 0x72, 0x12, 0x0d, 0x0a, 0x09, 0x41, 0x4c, 0x4c, 0x5f,
+	0x54, 0x49, 0x45, 0x52, 0x53, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x42, 0x4c, 0x4f, 0x43, 0x4b,
+	0x5f, 0x43, 0x41, 0x43, 0x48, 0x45, 0x5f, 0x54, 0x49, 0x45, 0x52, 0x10, 0x01, 0x2a, 0x25, 0x0a,
+	0x08, 0x4c, 0x6f, 0x63, 0x6b, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x0d, 0x0a, 0x09, 0x45, 0x58, 0x43,
+	0x4c, 0x55, 0x53, 0x49, 0x56, 0x45, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x48, 0x41, 0x52,
+	0x45, 0x44, 0x10, 0x01, 0x32, 0x93, 0x09, 0x0a, 0x03, 0x44, 0x4b, 0x56, 0x12, 0x3a, 0x0a, 0x03,
+	0x50, 0x75, 0x74, 0x12, 0x18, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x70, 0x62, 0x2e, 0x50, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e,
+	0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x50, 0x75, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x12, 0x1b, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70,
+	0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1c, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a,
+	0x03, 0x47, 0x65, 0x74, 0x12, 0x18, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19,
+	0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x47, 0x65,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x08, 0x4d, 0x75, 0x6c,
+	0x74, 0x69, 0x47, 0x65, 0x74, 0x12, 0x1d, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x70, 0x62, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x70, 0x62, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x08, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x50, 0x75, 0x74,
+	0x12, 0x1d, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e,
+	0x4d, 0x75, 0x6c, 0x74, 0x69, 0x50, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x19, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x50,
+	0x75, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5e, 0x0a, 0x0f, 0x41, 0x77,
+	0x61, 0x69, 0x74, 0x44, 0x75, 0x72, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x24, 0x2e,
+	0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x41, 0x77, 0x61,
+	0x69, 0x74, 0x44, 0x75, 0x72, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x70, 0x62, 0x2e, 0x41, 0x77, 0x61, 0x69, 0x74, 0x44, 0x75, 0x72, 0x61, 0x62, 0x69, 0x6c, 0x69,
+	0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x07, 0x49, 0x74,
+	0x65, 0x72, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x70, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x70, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x30, 0x01, 0x12, 0x57, 0x0a, 0x0c, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d,
+	0x53, 0x63, 0x61, 0x6e, 0x12, 0x21, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x53, 0x63, 0x61, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x53,
+	0x63, 0x61, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x4c, 0x0a,
+	0x09, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x12, 0x1e, 0x2e, 0x64, 0x6b, 0x76,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x64, 0x6b, 0x76,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x58, 0x0a, 0x0d, 0x43,
+	0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x41, 0x6e, 0x64, 0x53, 0x65, 0x74, 0x12, 0x22, 0x2e, 0x64,
+	0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6d, 0x70,
+	0x61, 0x72, 0x65, 0x41, 0x6e, 0x64, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x23, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e,
+	0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x41, 0x6e, 0x64, 0x53, 0x65, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a, 0x05, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x12, 0x1a,
+	0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x4d, 0x65,
+	0x72, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x64, 0x6b, 0x76,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x04, 0x53, 0x63, 0x61, 0x6e, 0x12,
+	0x19, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53,
+	0x63, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x64, 0x6b, 0x76,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x63, 0x61, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x06, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73,
+	0x12, 0x1b, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e,
+	0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e,
+	0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x45, 0x78, 0x69,
+	0x73, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x08, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x12, 0x1d, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x04, 0x4c, 0x6f, 0x63, 0x6b, 0x12, 0x19,
+	0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x4c, 0x6f,
+	0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x64, 0x6b, 0x76, 0x2e,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x06, 0x55, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x12,
+	0x1b, 0x2e, 0x64, 0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x55,
+	0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x64,
+	0x6b, 0x76, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x55, 0x6e, 0x6c, 0x6f,
+	0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x66, 0x6c, 0x69, 0x70, 0x6b, 0x61, 0x72,
+	0x74, 0x2d, 0x69, 0x6e, 0x63, 0x75, 0x62, 0x61, 0x74, 0x6f, 0x72, 0x2f, 0x64, 0x6b, 0x76, 0x2f,
+	0x70, 0x6b, 0x67, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1066,56 +3262,123 @@ func file_pkg_serverpb_api_proto_rawDescGZIP() []byte {
 	return file_pkg_serverpb_api_proto_rawDescData
 }
 
-var file_pkg_serverpb_api_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_pkg_serverpb_api_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_pkg_serverpb_api_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_pkg_serverpb_api_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
 var file_pkg_serverpb_api_proto_goTypes = []interface{}{
-	(ReadConsistency)(0),          // 0: dkv.serverpb.ReadConsistency
-	(*KVPair)(nil),                // 1: dkv.serverpb.KVPair
-	(*CompareAndSetRequest)(nil),  // 2: dkv.serverpb.CompareAndSetRequest
-	(*CompareAndSetResponse)(nil), // 3: dkv.serverpb.CompareAndSetResponse
-	(*Status)(nil),                // 4: dkv.serverpb.Status
-	(*PutRequest)(nil),            // 5: dkv.serverpb.PutRequest
-	(*MultiPutRequest)(nil),       // 6: dkv.serverpb.MultiPutRequest
-	(*PutResponse)(nil),           // 7: dkv.serverpb.PutResponse
-	(*DeleteRequest)(nil),         // 8: dkv.serverpb.DeleteRequest
-	(*DeleteResponse)(nil),        // 9: dkv.serverpb.DeleteResponse
-	(*GetRequest)(nil),            // 10: dkv.serverpb.GetRequest
-	(*GetResponse)(nil),           // 11: dkv.serverpb.GetResponse
-	(*MultiGetRequest)(nil),       // 12: dkv.serverpb.MultiGetRequest
-	(*MultiGetResponse)(nil),      // 13: dkv.serverpb.MultiGetResponse
-	(*IterateRequest)(nil),        // 14: dkv.serverpb.IterateRequest
-	(*IterateResponse)(nil),       // 15: dkv.serverpb.IterateResponse
+	(StatusCode)(0),                 // 0: dkv.serverpb.StatusCode
+	(WALSync)(0),                    // 1: dkv.serverpb.WALSync
+	(Durability)(0),                 // 2: dkv.serverpb.Durability
+	(ReadConsistency)(0),            // 3: dkv.serverpb.ReadConsistency
+	(ReadTier)(0),                   // 4: dkv.serverpb.ReadTier
+	(LockMode)(0),                   // 5: dkv.serverpb.LockMode
+	(*KVPair)(nil),                  // 6: dkv.serverpb.KVPair
+	(*CompareAndSetRequest)(nil),    // 7: dkv.serverpb.CompareAndSetRequest
+	(*CompareAndSetResponse)(nil),   // 8: dkv.serverpb.CompareAndSetResponse
+	(*MergeRequest)(nil),            // 9: dkv.serverpb.MergeRequest
+	(*MergeResponse)(nil),           // 10: dkv.serverpb.MergeResponse
+	(*ScanRequest)(nil),             // 11: dkv.serverpb.ScanRequest
+	(*ScanResponse)(nil),            // 12: dkv.serverpb.ScanResponse
+	(*Status)(nil),                  // 13: dkv.serverpb.Status
+	(*PutRequest)(nil),              // 14: dkv.serverpb.PutRequest
+	(*MultiPutRequest)(nil),         // 15: dkv.serverpb.MultiPutRequest
+	(*PutResponse)(nil),             // 16: dkv.serverpb.PutResponse
+	(*AwaitDurabilityRequest)(nil),  // 17: dkv.serverpb.AwaitDurabilityRequest
+	(*AwaitDurabilityResponse)(nil), // 18: dkv.serverpb.AwaitDurabilityResponse
+	(*DeleteRequest)(nil),           // 19: dkv.serverpb.DeleteRequest
+	(*DeleteResponse)(nil),          // 20: dkv.serverpb.DeleteResponse
+	(*ReadOptions)(nil),             // 21: dkv.serverpb.ReadOptions
+	(*GetRequest)(nil),              // 22: dkv.serverpb.GetRequest
+	(*GetResponse)(nil),             // 23: dkv.serverpb.GetResponse
+	(*ExistsRequest)(nil),           // 24: dkv.serverpb.ExistsRequest
+	(*ExistsResponse)(nil),          // 25: dkv.serverpb.ExistsResponse
+	(*TransactRequest)(nil),         // 26: dkv.serverpb.TransactRequest
+	(*TxnWrite)(nil),                // 27: dkv.serverpb.TxnWrite
+	(*TransactResponse)(nil),        // 28: dkv.serverpb.TransactResponse
+	(*LockRequest)(nil),             // 29: dkv.serverpb.LockRequest
+	(*LockResponse)(nil),            // 30: dkv.serverpb.LockResponse
+	(*UnlockRequest)(nil),           // 31: dkv.serverpb.UnlockRequest
+	(*UnlockResponse)(nil),          // 32: dkv.serverpb.UnlockResponse
+	(*MultiGetRequest)(nil),         // 33: dkv.serverpb.MultiGetRequest
+	(*MultiGetResponse)(nil),        // 34: dkv.serverpb.MultiGetResponse
+	(*IterateRequest)(nil),          // 35: dkv.serverpb.IterateRequest
+	(*IterateResponse)(nil),         // 36: dkv.serverpb.IterateResponse
+	(*ChecksumScanRequest)(nil),     // 37: dkv.serverpb.ChecksumScanRequest
+	(*ChecksumScanResponse)(nil),    // 38: dkv.serverpb.ChecksumScanResponse
+	(*AggregateRequest)(nil),        // 39: dkv.serverpb.AggregateRequest
+	(*AggregateResponse)(nil),       // 40: dkv.serverpb.AggregateResponse
 }
 var file_pkg_serverpb_api_proto_depIdxs = []int32{
-	4,  // 0: dkv.serverpb.CompareAndSetResponse.status:type_name -> dkv.serverpb.Status
-	5,  // 1: dkv.serverpb.MultiPutRequest.putRequest:type_name -> dkv.serverpb.PutRequest
-	4,  // 2: dkv.serverpb.PutResponse.status:type_name -> dkv.serverpb.Status
-	4,  // 3: dkv.serverpb.DeleteResponse.status:type_name -> dkv.serverpb.Status
-	0,  // 4: dkv.serverpb.GetRequest.readConsistency:type_name -> dkv.serverpb.ReadConsistency
-	4,  // 5: dkv.serverpb.GetResponse.status:type_name -> dkv.serverpb.Status
-	0,  // 6: dkv.serverpb.MultiGetRequest.readConsistency:type_name -> dkv.serverpb.ReadConsistency
-	4,  // 7: dkv.serverpb.MultiGetResponse.status:type_name -> dkv.serverpb.Status
-	1,  // 8: dkv.serverpb.MultiGetResponse.keyValues:type_name -> dkv.serverpb.KVPair
-	4,  // 9: dkv.serverpb.IterateResponse.status:type_name -> dkv.serverpb.Status
-	5,  // 10: dkv.serverpb.DKV.Put:input_type -> dkv.serverpb.PutRequest
-	8,  // 11: dkv.serverpb.DKV.Delete:input_type -> dkv.serverpb.DeleteRequest
-	10, // 12: dkv.serverpb.DKV.Get:input_type -> dkv.serverpb.GetRequest
-	12, // 13: dkv.serverpb.DKV.MultiGet:input_type -> dkv.serverpb.MultiGetRequest
-	6,  // 14: dkv.serverpb.DKV.MultiPut:input_type -> dkv.serverpb.MultiPutRequest
-	14, // 15: dkv.serverpb.DKV.Iterate:input_type -> dkv.serverpb.IterateRequest
-	2,  // 16: dkv.serverpb.DKV.CompareAndSet:input_type -> dkv.serverpb.CompareAndSetRequest
-	7,  // 17: dkv.serverpb.DKV.Put:output_type -> dkv.serverpb.PutResponse
-	9,  // 18: dkv.serverpb.DKV.Delete:output_type -> dkv.serverpb.DeleteResponse
-	11, // 19: dkv.serverpb.DKV.Get:output_type -> dkv.serverpb.GetResponse
-	13, // 20: dkv.serverpb.DKV.MultiGet:output_type -> dkv.serverpb.MultiGetResponse
-	7,  // 21: dkv.serverpb.DKV.MultiPut:output_type -> dkv.serverpb.PutResponse
-	15, // 22: dkv.serverpb.DKV.Iterate:output_type -> dkv.serverpb.IterateResponse
-	3,  // 23: dkv.serverpb.DKV.CompareAndSet:output_type -> dkv.serverpb.CompareAndSetResponse
-	17, // [17:24] is the sub-list for method output_type
-	10, // [10:17] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	13, // 0: dkv.serverpb.CompareAndSetResponse.status:type_name -> dkv.serverpb.Status
+	13, // 1: dkv.serverpb.MergeResponse.status:type_name -> dkv.serverpb.Status
+	21, // 2: dkv.serverpb.ScanRequest.readOptions:type_name -> dkv.serverpb.ReadOptions
+	13, // 3: dkv.serverpb.ScanResponse.status:type_name -> dkv.serverpb.Status
+	6,  // 4: dkv.serverpb.ScanResponse.items:type_name -> dkv.serverpb.KVPair
+	1,  // 5: dkv.serverpb.PutRequest.walSync:type_name -> dkv.serverpb.WALSync
+	14, // 6: dkv.serverpb.MultiPutRequest.putRequest:type_name -> dkv.serverpb.PutRequest
+	13, // 7: dkv.serverpb.PutResponse.status:type_name -> dkv.serverpb.Status
+	2,  // 8: dkv.serverpb.PutResponse.durability:type_name -> dkv.serverpb.Durability
+	2,  // 9: dkv.serverpb.AwaitDurabilityRequest.durability:type_name -> dkv.serverpb.Durability
+	13, // 10: dkv.serverpb.AwaitDurabilityResponse.status:type_name -> dkv.serverpb.Status
+	2,  // 11: dkv.serverpb.AwaitDurabilityResponse.durability:type_name -> dkv.serverpb.Durability
+	13, // 12: dkv.serverpb.DeleteResponse.status:type_name -> dkv.serverpb.Status
+	4,  // 13: dkv.serverpb.ReadOptions.readTier:type_name -> dkv.serverpb.ReadTier
+	3,  // 14: dkv.serverpb.GetRequest.readConsistency:type_name -> dkv.serverpb.ReadConsistency
+	21, // 15: dkv.serverpb.GetRequest.readOptions:type_name -> dkv.serverpb.ReadOptions
+	13, // 16: dkv.serverpb.GetResponse.status:type_name -> dkv.serverpb.Status
+	3,  // 17: dkv.serverpb.ExistsRequest.readConsistency:type_name -> dkv.serverpb.ReadConsistency
+	13, // 18: dkv.serverpb.ExistsResponse.status:type_name -> dkv.serverpb.Status
+	6,  // 19: dkv.serverpb.TransactRequest.reads:type_name -> dkv.serverpb.KVPair
+	27, // 20: dkv.serverpb.TransactRequest.writes:type_name -> dkv.serverpb.TxnWrite
+	13, // 21: dkv.serverpb.TransactResponse.status:type_name -> dkv.serverpb.Status
+	5,  // 22: dkv.serverpb.LockRequest.mode:type_name -> dkv.serverpb.LockMode
+	13, // 23: dkv.serverpb.LockResponse.status:type_name -> dkv.serverpb.Status
+	5,  // 24: dkv.serverpb.LockResponse.mode:type_name -> dkv.serverpb.LockMode
+	13, // 25: dkv.serverpb.UnlockResponse.status:type_name -> dkv.serverpb.Status
+	3,  // 26: dkv.serverpb.MultiGetRequest.readConsistency:type_name -> dkv.serverpb.ReadConsistency
+	21, // 27: dkv.serverpb.MultiGetRequest.readOptions:type_name -> dkv.serverpb.ReadOptions
+	13, // 28: dkv.serverpb.MultiGetResponse.status:type_name -> dkv.serverpb.Status
+	6,  // 29: dkv.serverpb.MultiGetResponse.keyValues:type_name -> dkv.serverpb.KVPair
+	21, // 30: dkv.serverpb.IterateRequest.readOptions:type_name -> dkv.serverpb.ReadOptions
+	13, // 31: dkv.serverpb.IterateResponse.status:type_name -> dkv.serverpb.Status
+	13, // 32: dkv.serverpb.ChecksumScanResponse.status:type_name -> dkv.serverpb.Status
+	13, // 33: dkv.serverpb.AggregateResponse.status:type_name -> dkv.serverpb.Status
+	14, // 34: dkv.serverpb.DKV.Put:input_type -> dkv.serverpb.PutRequest
+	19, // 35: dkv.serverpb.DKV.Delete:input_type -> dkv.serverpb.DeleteRequest
+	22, // 36: dkv.serverpb.DKV.Get:input_type -> dkv.serverpb.GetRequest
+	33, // 37: dkv.serverpb.DKV.MultiGet:input_type -> dkv.serverpb.MultiGetRequest
+	15, // 38: dkv.serverpb.DKV.MultiPut:input_type -> dkv.serverpb.MultiPutRequest
+	17, // 39: dkv.serverpb.DKV.AwaitDurability:input_type -> dkv.serverpb.AwaitDurabilityRequest
+	35, // 40: dkv.serverpb.DKV.Iterate:input_type -> dkv.serverpb.IterateRequest
+	37, // 41: dkv.serverpb.DKV.ChecksumScan:input_type -> dkv.serverpb.ChecksumScanRequest
+	39, // 42: dkv.serverpb.DKV.Aggregate:input_type -> dkv.serverpb.AggregateRequest
+	7,  // 43: dkv.serverpb.DKV.CompareAndSet:input_type -> dkv.serverpb.CompareAndSetRequest
+	9,  // 44: dkv.serverpb.DKV.Merge:input_type -> dkv.serverpb.MergeRequest
+	11, // 45: dkv.serverpb.DKV.Scan:input_type -> dkv.serverpb.ScanRequest
+	24, // 46: dkv.serverpb.DKV.Exists:input_type -> dkv.serverpb.ExistsRequest
+	26, // 47: dkv.serverpb.DKV.Transact:input_type -> dkv.serverpb.TransactRequest
+	29, // 48: dkv.serverpb.DKV.Lock:input_type -> dkv.serverpb.LockRequest
+	31, // 49: dkv.serverpb.DKV.Unlock:input_type -> dkv.serverpb.UnlockRequest
+	16, // 50: dkv.serverpb.DKV.Put:output_type -> dkv.serverpb.PutResponse
+	20, // 51: dkv.serverpb.DKV.Delete:output_type -> dkv.serverpb.DeleteResponse
+	23, // 52: dkv.serverpb.DKV.Get:output_type -> dkv.serverpb.GetResponse
+	34, // 53: dkv.serverpb.DKV.MultiGet:output_type -> dkv.serverpb.MultiGetResponse
+	16, // 54: dkv.serverpb.DKV.MultiPut:output_type -> dkv.serverpb.PutResponse
+	18, // 55: dkv.serverpb.DKV.AwaitDurability:output_type -> dkv.serverpb.AwaitDurabilityResponse
+	36, // 56: dkv.serverpb.DKV.Iterate:output_type -> dkv.serverpb.IterateResponse
+	38, // 57: dkv.serverpb.DKV.ChecksumScan:output_type -> dkv.serverpb.ChecksumScanResponse
+	40, // 58: dkv.serverpb.DKV.Aggregate:output_type -> dkv.serverpb.AggregateResponse
+	8,  // 59: dkv.serverpb.DKV.CompareAndSet:output_type -> dkv.serverpb.CompareAndSetResponse
+	10, // 60: dkv.serverpb.DKV.Merge:output_type -> dkv.serverpb.MergeResponse
+	12, // 61: dkv.serverpb.DKV.Scan:output_type -> dkv.serverpb.ScanResponse
+	25, // 62: dkv.serverpb.DKV.Exists:output_type -> dkv.serverpb.ExistsResponse
+	28, // 63: dkv.serverpb.DKV.Transact:output_type -> dkv.serverpb.TransactResponse
+	30, // 64: dkv.serverpb.DKV.Lock:output_type -> dkv.serverpb.LockResponse
+	32, // 65: dkv.serverpb.DKV.Unlock:output_type -> dkv.serverpb.UnlockResponse
+	50, // [50:66] is the sub-list for method output_type
+	34, // [34:50] is the sub-list for method input_type
+	34, // [34:34] is the sub-list for extension type_name
+	34, // [34:34] is the sub-list for extension extendee
+	0,  // [0:34] is the sub-list for field type_name
 }
 
 func init() { file_pkg_serverpb_api_proto_init() }
@@ -1136,8 +3399,248 @@ func file_pkg_serverpb_api_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_serverpb_api_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CompareAndSetRequest); i {
+		file_pkg_serverpb_api_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompareAndSetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_api_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompareAndSetResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_api_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MergeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_api_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MergeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_api_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScanRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_api_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScanResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_api_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Status); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_api_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PutRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_api_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MultiPutRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_api_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PutResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_api_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AwaitDurabilityRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_api_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AwaitDurabilityResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_api_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_api_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_api_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReadOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_api_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_api_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_api_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExistsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_api_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExistsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_api_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TransactRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_serverpb_api_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TxnWrite); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1148,8 +3651,8 @@ func file_pkg_serverpb_api_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_serverpb_api_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CompareAndSetResponse); i {
+		file_pkg_serverpb_api_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TransactResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1160,8 +3663,8 @@ func file_pkg_serverpb_api_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_serverpb_api_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Status); i {
+		file_pkg_serverpb_api_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LockRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1172,8 +3675,8 @@ func file_pkg_serverpb_api_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_serverpb_api_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PutRequest); i {
+		file_pkg_serverpb_api_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LockResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1184,8 +3687,8 @@ func file_pkg_serverpb_api_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_serverpb_api_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MultiPutRequest); i {
+		file_pkg_serverpb_api_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnlockRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1196,8 +3699,8 @@ func file_pkg_serverpb_api_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_serverpb_api_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PutResponse); i {
+		file_pkg_serverpb_api_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnlockResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1208,8 +3711,8 @@ func file_pkg_serverpb_api_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_serverpb_api_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteRequest); i {
+		file_pkg_serverpb_api_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MultiGetRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1220,8 +3723,8 @@ func file_pkg_serverpb_api_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_serverpb_api_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteResponse); i {
+		file_pkg_serverpb_api_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MultiGetResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1232,8 +3735,8 @@ func file_pkg_serverpb_api_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_serverpb_api_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetRequest); i {
+		file_pkg_serverpb_api_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IterateRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1244,8 +3747,8 @@ func file_pkg_serverpb_api_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_serverpb_api_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetResponse); i {
+		file_pkg_serverpb_api_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IterateResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1256,8 +3759,8 @@ func file_pkg_serverpb_api_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_serverpb_api_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MultiGetRequest); i {
+		file_pkg_serverpb_api_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChecksumScanRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1268,8 +3771,8 @@ func file_pkg_serverpb_api_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_serverpb_api_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MultiGetResponse); i {
+		file_pkg_serverpb_api_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChecksumScanResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1280,8 +3783,8 @@ func file_pkg_serverpb_api_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_serverpb_api_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IterateRequest); i {
+		file_pkg_serverpb_api_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AggregateRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1292,8 +3795,8 @@ func file_pkg_serverpb_api_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_serverpb_api_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IterateResponse); i {
+		file_pkg_serverpb_api_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AggregateResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1310,8 +3813,8 @@ func file_pkg_serverpb_api_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_pkg_serverpb_api_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   15,
+			NumEnums:      6,
+			NumMessages:   35,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -1345,15 +3848,60 @@ type DKVClient interface {
 	// Get gets the value associated with the given key from the key value store.
 	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
 	// MultiGet gets the values associated with given keys from the key value store.
+	// The response holds exactly one pair per requested key, in the order of the
+	// request. Absent keys are answered with an empty value and duplicate keys
+	// are answered at each of their positions.
 	MultiGet(ctx context.Context, in *MultiGetRequest, opts ...grpc.CallOption) (*MultiGetResponse, error)
 	// MultiPut puts the given keys into the key value store.
 	MultiPut(ctx context.Context, in *MultiPutRequest, opts ...grpc.CallOption) (*PutResponse, error)
+	// AwaitDurability reports how durable the writes upto the given change
+	// number are, optionally waiting for them to reach the desired durability.
+	// Paired with writes using the ASYNC_WAL sync, which are acknowledged once
+	// they are in the memtable, it lets the writers trade durability for
+	// throughput while still learning when their writes are fsynced or
+	// replicated.
+	AwaitDurability(ctx context.Context, in *AwaitDurabilityRequest, opts ...grpc.CallOption) (*AwaitDurabilityResponse, error)
 	// Iterate iterates through the entire keyspace in no particular order and
 	// returns the results as a stream of key value pairs.
 	Iterate(ctx context.Context, in *IterateRequest, opts ...grpc.CallOption) (DKV_IterateClient, error)
+	// ChecksumScan iterates through the keyspace in the ascending order of the
+	// keys and returns a stream of keys along with the checksums of their values
+	// and expiry. Streams from two nodes can be merged to find the keys that
+	// differ between them, without transferring the values.
+	ChecksumScan(ctx context.Context, in *ChecksumScanRequest, opts ...grpc.CallOption) (DKV_ChecksumScanClient, error)
+	// Aggregate computes simple aggregates over the keys within the given
+	// range or with the given prefix on the server, without transferring the
+	// keys or their values.
+	Aggregate(ctx context.Context, in *AggregateRequest, opts ...grpc.CallOption) (*AggregateResponse, error)
 	// CompareAndSet offers the standard CAS style transaction over a given
 	// key. Intended to be used in concurrent workloads with less contention.
 	CompareAndSet(ctx context.Context, in *CompareAndSetRequest, opts ...grpc.CallOption) (*CompareAndSetResponse, error)
+	// Merge merges the given operand into the value of the given key using
+	// the merge operator configured on the server for that key.
+	Merge(ctx context.Context, in *MergeRequest, opts ...grpc.CallOption) (*MergeResponse, error)
+	// Scan returns a page of key value pairs along with a continuation token
+	// for retrieving the next page. All the pages of a scan are served from
+	// the same point in time view of the keyspace.
+	Scan(ctx context.Context, in *ScanRequest, opts ...grpc.CallOption) (*ScanResponse, error)
+	// Exists checks if the given key is present in the key value store,
+	// without transferring its value. Absent keys are mostly ruled out
+	// using bloom filters, without reading the data blocks.
+	Exists(ctx context.Context, in *ExistsRequest, opts ...grpc.CallOption) (*ExistsResponse, error)
+	// Transact offers optimistic transactions over multiple keys. The writes
+	// are committed atomically, provided none of the keys read by the
+	// transaction changed in the meantime. Conflicts are detected at commit
+	// and reported with the ABORTED code, upon which the transaction can be
+	// retried. Intended for read-modify-write workloads with less contention.
+	Transact(ctx context.Context, in *TransactRequest, opts ...grpc.CallOption) (*TransactResponse, error)
+	// Lock acquires an advisory lock over the given key on behalf of the given
+	// owner, for the given lease. Locks are not enforced on the writes to the
+	// key and are only meant for coordinating access across clients. Locks
+	// are held either exclusively by a single owner or shared by many owners.
+	// The owner of a lock renews its lease by locking the key again.
+	Lock(ctx context.Context, in *LockRequest, opts ...grpc.CallOption) (*LockResponse, error)
+	// Unlock releases the advisory lock over the given key, provided it is
+	// held by the given owner.
+	Unlock(ctx context.Context, in *UnlockRequest, opts ...grpc.CallOption) (*UnlockResponse, error)
 }
 
 type dKVClient struct {
@@ -1409,6 +3957,15 @@ func (c *dKVClient) MultiPut(ctx context.Context, in *MultiPutRequest, opts ...g
 	return out, nil
 }
 
+func (c *dKVClient) AwaitDurability(ctx context.Context, in *AwaitDurabilityRequest, opts ...grpc.CallOption) (*AwaitDurabilityResponse, error) {
+	out := new(AwaitDurabilityResponse)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKV/AwaitDurability", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dKVClient) Iterate(ctx context.Context, in *IterateRequest, opts ...grpc.CallOption) (DKV_IterateClient, error) {
 	stream, err := c.cc.NewStream(ctx, &_DKV_serviceDesc.Streams[0], "/dkv.serverpb.DKV/Iterate", opts...)
 	if err != nil {
@@ -1441,6 +3998,47 @@ func (x *dKVIterateClient) Recv() (*IterateResponse, error) {
 	return m, nil
 }
 
+func (c *dKVClient) ChecksumScan(ctx context.Context, in *ChecksumScanRequest, opts ...grpc.CallOption) (DKV_ChecksumScanClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_DKV_serviceDesc.Streams[1], "/dkv.serverpb.DKV/ChecksumScan", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &dKVChecksumScanClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DKV_ChecksumScanClient interface {
+	Recv() (*ChecksumScanResponse, error)
+	grpc.ClientStream
+}
+
+type dKVChecksumScanClient struct {
+	grpc.ClientStream
+}
+
+func (x *dKVChecksumScanClient) Recv() (*ChecksumScanResponse, error) {
+	m := new(ChecksumScanResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *dKVClient) Aggregate(ctx context.Context, in *AggregateRequest, opts ...grpc.CallOption) (*AggregateResponse, error) {
+	out := new(AggregateResponse)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKV/Aggregate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dKVClient) CompareAndSet(ctx context.Context, in *CompareAndSetRequest, opts ...grpc.CallOption) (*CompareAndSetResponse, error) {
 	out := new(CompareAndSetResponse)
 	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKV/CompareAndSet", in, out, opts...)
@@ -1450,6 +4048,60 @@ func (c *dKVClient) CompareAndSet(ctx context.Context, in *CompareAndSetRequest,
 	return out, nil
 }
 
+func (c *dKVClient) Merge(ctx context.Context, in *MergeRequest, opts ...grpc.CallOption) (*MergeResponse, error) {
+	out := new(MergeResponse)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKV/Merge", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dKVClient) Scan(ctx context.Context, in *ScanRequest, opts ...grpc.CallOption) (*ScanResponse, error) {
+	out := new(ScanResponse)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKV/Scan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dKVClient) Exists(ctx context.Context, in *ExistsRequest, opts ...grpc.CallOption) (*ExistsResponse, error) {
+	out := new(ExistsResponse)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKV/Exists", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dKVClient) Transact(ctx context.Context, in *TransactRequest, opts ...grpc.CallOption) (*TransactResponse, error) {
+	out := new(TransactResponse)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKV/Transact", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dKVClient) Lock(ctx context.Context, in *LockRequest, opts ...grpc.CallOption) (*LockResponse, error) {
+	out := new(LockResponse)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKV/Lock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dKVClient) Unlock(ctx context.Context, in *UnlockRequest, opts ...grpc.CallOption) (*UnlockResponse, error) {
+	out := new(UnlockResponse)
+	err := c.cc.Invoke(ctx, "/dkv.serverpb.DKV/Unlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DKVServer is the server API for DKV service.
 type DKVServer interface {
 	// Put puts the given key into the key value store.
@@ -1459,15 +4111,60 @@ type DKVServer interface {
 	// Get gets the value associated with the given key from the key value store.
 	Get(context.Context, *GetRequest) (*GetResponse, error)
 	// MultiGet gets the values associated with given keys from the key value store.
+	// The response holds exactly one pair per requested key, in the order of the
+	// request. Absent keys are answered with an empty value and duplicate keys
+	// are answered at each of their positions.
 	MultiGet(context.Context, *MultiGetRequest) (*MultiGetResponse, error)
 	// MultiPut puts the given keys into the key value store.
 	MultiPut(context.Context, *MultiPutRequest) (*PutResponse, error)
+	// AwaitDurability reports how durable the writes upto the given change
+	// number are, optionally waiting for them to reach the desired durability.
+	// Paired with writes using the ASYNC_WAL sync, which are acknowledged once
+	// they are in the memtable, it lets the writers trade durability for
+	// throughput while still learning when their writes are fsynced or
+	// replicated.
+	AwaitDurability(context.Context, *AwaitDurabilityRequest) (*AwaitDurabilityResponse, error)
 	// Iterate iterates through the entire keyspace in no particular order and
 	// returns the results as a stream of key value pairs.
 	Iterate(*IterateRequest, DKV_IterateServer) error
+	// ChecksumScan iterates through the keyspace in the ascending order of the
+	// keys and returns a stream of keys along with the checksums of their values
+	// and expiry. Streams from two nodes can be merged to find the keys that
+	// differ between them, without transferring the values.
+	ChecksumScan(*ChecksumScanRequest, DKV_ChecksumScanServer) error
+	// Aggregate computes simple aggregates over the keys within the given
+	// range or with the given prefix on the server, without transferring the
+	// keys or their values.
+	Aggregate(context.Context, *AggregateRequest) (*AggregateResponse, error)
 	// CompareAndSet offers the standard CAS style transaction over a given
 	// key. Intended to be used in concurrent workloads with less contention.
 	CompareAndSet(context.Context, *CompareAndSetRequest) (*CompareAndSetResponse, error)
+	// Merge merges the given operand into the value of the given key using
+	// the merge operator configured on the server for that key.
+	Merge(context.Context, *MergeRequest) (*MergeResponse, error)
+	// Scan returns a page of key value pairs along with a continuation token
+	// for retrieving the next page. All the pages of a scan are served from
+	// the same point in time view of the keyspace.
+	Scan(context.Context, *ScanRequest) (*ScanResponse, error)
+	// Exists checks if the given key is present in the key value store,
+	// without transferring its value. Absent keys are mostly ruled out
+	// using bloom filters, without reading the data blocks.
+	Exists(context.Context, *ExistsRequest) (*ExistsResponse, error)
+	// Transact offers optimistic transactions over multiple keys. The writes
+	// are committed atomically, provided none of the keys read by the
+	// transaction changed in the meantime. Conflicts are detected at commit
+	// and reported with the ABORTED code, upon which the transaction can be
+	// retried. Intended for read-modify-write workloads with less contention.
+	Transact(context.Context, *TransactRequest) (*TransactResponse, error)
+	// Lock acquires an advisory lock over the given key on behalf of the given
+	// owner, for the given lease. Locks are not enforced on the writes to the
+	// key and are only meant for coordinating access across clients. Locks
+	// are held either exclusively by a single owner or shared by many owners.
+	// The owner of a lock renews its lease by locking the key again.
+	Lock(context.Context, *LockRequest) (*LockResponse, error)
+	// Unlock releases the advisory lock over the given key, provided it is
+	// held by the given owner.
+	Unlock(context.Context, *UnlockRequest) (*UnlockResponse, error)
 }
 
 // UnimplementedDKVServer can be embedded to have forward compatible implementations.
@@ -1489,12 +4186,39 @@ func (*UnimplementedDKVServer) MultiGet(context.Context, *MultiGetRequest) (*Mul
 func (*UnimplementedDKVServer) MultiPut(context.Context, *MultiPutRequest) (*PutResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method MultiPut not implemented")
 }
+func (*UnimplementedDKVServer) AwaitDurability(context.Context, *AwaitDurabilityRequest) (*AwaitDurabilityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AwaitDurability not implemented")
+}
 func (*UnimplementedDKVServer) Iterate(*IterateRequest, DKV_IterateServer) error {
 	return status.Errorf(codes.Unimplemented, "method Iterate not implemented")
 }
+func (*UnimplementedDKVServer) ChecksumScan(*ChecksumScanRequest, DKV_ChecksumScanServer) error {
+	return status.Errorf(codes.Unimplemented, "method ChecksumScan not implemented")
+}
+func (*UnimplementedDKVServer) Aggregate(context.Context, *AggregateRequest) (*AggregateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Aggregate not implemented")
+}
 func (*UnimplementedDKVServer) CompareAndSet(context.Context, *CompareAndSetRequest) (*CompareAndSetResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CompareAndSet not implemented")
 }
+func (*UnimplementedDKVServer) Merge(context.Context, *MergeRequest) (*MergeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Merge not implemented")
+}
+func (*UnimplementedDKVServer) Scan(context.Context, *ScanRequest) (*ScanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Scan not implemented")
+}
+func (*UnimplementedDKVServer) Exists(context.Context, *ExistsRequest) (*ExistsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Exists not implemented")
+}
+func (*UnimplementedDKVServer) Transact(context.Context, *TransactRequest) (*TransactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Transact not implemented")
+}
+func (*UnimplementedDKVServer) Lock(context.Context, *LockRequest) (*LockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Lock not implemented")
+}
+func (*UnimplementedDKVServer) Unlock(context.Context, *UnlockRequest) (*UnlockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unlock not implemented")
+}
 
 func RegisterDKVServer(s *grpc.Server, srv DKVServer) {
 	s.RegisterService(&_DKV_serviceDesc, srv)
@@ -1590,6 +4314,24 @@ func _DKV_MultiPut_Handler(srv interface{}, ctx context.Context, dec func(interf
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DKV_AwaitDurability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AwaitDurabilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVServer).AwaitDurability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKV/AwaitDurability",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVServer).AwaitDurability(ctx, req.(*AwaitDurabilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DKV_Iterate_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(IterateRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -1611,6 +4353,45 @@ func (x *dKVIterateServer) Send(m *IterateResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _DKV_ChecksumScan_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ChecksumScanRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DKVServer).ChecksumScan(m, &dKVChecksumScanServer{stream})
+}
+
+type DKV_ChecksumScanServer interface {
+	Send(*ChecksumScanResponse) error
+	grpc.ServerStream
+}
+
+type dKVChecksumScanServer struct {
+	grpc.ServerStream
+}
+
+func (x *dKVChecksumScanServer) Send(m *ChecksumScanResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _DKV_Aggregate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AggregateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVServer).Aggregate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKV/Aggregate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVServer).Aggregate(ctx, req.(*AggregateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DKV_CompareAndSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CompareAndSetRequest)
 	if err := dec(in); err != nil {
@@ -1629,6 +4410,114 @@ func _DKV_CompareAndSet_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DKV_Merge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVServer).Merge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKV/Merge",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVServer).Merge(ctx, req.(*MergeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DKV_Scan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVServer).Scan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKV/Scan",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVServer).Scan(ctx, req.(*ScanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DKV_Exists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExistsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVServer).Exists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKV/Exists",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVServer).Exists(ctx, req.(*ExistsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DKV_Transact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVServer).Transact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKV/Transact",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVServer).Transact(ctx, req.(*TransactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DKV_Lock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVServer).Lock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKV/Lock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVServer).Lock(ctx, req.(*LockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DKV_Unlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DKVServer).Unlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dkv.serverpb.DKV/Unlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DKVServer).Unlock(ctx, req.(*UnlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _DKV_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "dkv.serverpb.DKV",
 	HandlerType: (*DKVServer)(nil),
@@ -1653,10 +4542,42 @@ var _DKV_serviceDesc = grpc.ServiceDesc{
 			MethodName: "MultiPut",
 			Handler:    _DKV_MultiPut_Handler,
 		},
+		{
+			MethodName: "AwaitDurability",
+			Handler:    _DKV_AwaitDurability_Handler,
+		},
+		{
+			MethodName: "Aggregate",
+			Handler:    _DKV_Aggregate_Handler,
+		},
 		{
 			MethodName: "CompareAndSet",
 			Handler:    _DKV_CompareAndSet_Handler,
 		},
+		{
+			MethodName: "Merge",
+			Handler:    _DKV_Merge_Handler,
+		},
+		{
+			MethodName: "Scan",
+			Handler:    _DKV_Scan_Handler,
+		},
+		{
+			MethodName: "Exists",
+			Handler:    _DKV_Exists_Handler,
+		},
+		{
+			MethodName: "Transact",
+			Handler:    _DKV_Transact_Handler,
+		},
+		{
+			MethodName: "Lock",
+			Handler:    _DKV_Lock_Handler,
+		},
+		{
+			MethodName: "Unlock",
+			Handler:    _DKV_Unlock_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1664,6 +4585,11 @@ var _DKV_serviceDesc = grpc.ServiceDesc{
 			Handler:       _DKV_Iterate_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ChecksumScan",
+			Handler:       _DKV_ChecksumScan_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "pkg/serverpb/api.proto",
 }